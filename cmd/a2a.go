@@ -0,0 +1,491 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	adk "github.com/inference-gateway/adk/types"
+	config "github.com/inference-gateway/cli/config"
+	services "github.com/inference-gateway/cli/internal/services"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+var a2aCmd = &cobra.Command{
+	Use:   "a2a",
+	Short: "Manage and inspect the A2A agents sessions delegate to",
+	Long: `Manage the Agent-to-Agent (A2A) agents chat sessions delegate to, fetch their
+agent cards and check that they are reachable.
+
+Sessions read their agents from one source: INFER_A2A_AGENTS when it is set,
+otherwise a2a.agents in config.yaml when that list is not empty, otherwise
+agents.yaml. These commands read and write whichever source is active, so an
+agent added here is the one the next session sees. Use 'infer agents' to manage
+agents.yaml entries in detail (models, OCI images, environment).`,
+}
+
+var a2aListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured A2A agents and check they respond",
+	Long: `List the agents from the active source and fetch each agent card to verify
+connectivity. Pass --no-check to list without contacting the agents.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noCheck, _ := cmd.Flags().GetBool("no-check")
+		return runA2AList(context.Background(), os.Stdout, Cfg, !noCheck)
+	},
+}
+
+var a2aAddCmd = &cobra.Command{
+	Use:   "add <url>",
+	Short: "Add an A2A agent after checking its agent card",
+	Long: `Fetch the agent card at <url> to verify the agent is reachable, then add it to
+the active agent source. In agents.yaml the entry is named after the card
+unless --name is given. When INFER_A2A_AGENTS is the active source nothing is
+written; the updated value to export is printed instead.
+
+Examples:
+  infer a2a add http://localhost:8081
+  infer a2a add https://agent.example.com --name reviewer
+  infer a2a add http://localhost:8082 --skip-check`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		skipCheck, _ := cmd.Flags().GetBool("skip-check")
+		return runA2AAdd(context.Background(), os.Stdout, Cfg, args[0], name, !skipCheck)
+	},
+}
+
+var a2aRemoveCmd = &cobra.Command{
+	Use:   "remove <name|url>",
+	Short: "Remove an A2A agent from the active agent source",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runA2ARemove(os.Stdout, Cfg, args[0])
+	},
+	ValidArgsFunction: completeFirstArg(completeA2AAgents),
+}
+
+var a2aCardCmd = &cobra.Command{
+	Use:   "card <name|url>",
+	Short: "Fetch and print an A2A agent card",
+	Long: `Fetch the agent card of a configured agent (by name) or of any agent URL and
+print its description, capabilities and skills. --format json prints the card
+as the agent served it.
+
+Examples:
+  infer a2a card browser-agent
+  infer a2a card http://localhost:8081 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return runA2ACard(context.Background(), os.Stdout, Cfg, args[0], format)
+	},
+	ValidArgsFunction: completeFirstArg(completeA2AAgents),
+}
+
+func init() {
+	a2aCmd.AddCommand(a2aListCmd)
+	a2aCmd.AddCommand(a2aAddCmd)
+	a2aCmd.AddCommand(a2aRemoveCmd)
+	a2aCmd.AddCommand(a2aCardCmd)
+
+	a2aListCmd.Flags().Bool("no-check", false, "Don't fetch agent cards")
+	a2aAddCmd.Flags().String("name", "", "Name of the agents.yaml entry (default: derived from the agent card)")
+	a2aAddCmd.Flags().Bool("skip-check", false, "Add the agent without fetching its agent card")
+	a2aCardCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+
+	rootCmd.AddCommand(a2aCmd)
+}
+
+// a2aCardTimeout bounds fetching a single agent card
+const a2aCardTimeout = 10 * time.Second
+
+// A2A agent sources, in the order sessions consult them
+const (
+	a2aSourceEnv    = "env"
+	a2aSourceConfig = "config"
+	a2aSourceYAML   = "yaml"
+)
+
+// a2aAgent is one agent of the active source
+type a2aAgent struct {
+	Name    string
+	URL     string
+	Enabled bool
+}
+
+// a2aAgentSource is the agent list sessions read, and where it is written
+type a2aAgentSource struct {
+	kind    string
+	path    string
+	project bool
+}
+
+func (s a2aAgentSource) String() string {
+	switch s.kind {
+	case a2aSourceEnv:
+		return "INFER_A2A_AGENTS environment variable"
+	case a2aSourceConfig:
+		return "a2a.agents in " + s.path
+	default:
+		return s.path
+	}
+}
+
+// resolveA2ASource finds the agent source A2AAgentService reads: the a2a.agents
+// list (from INFER_A2A_AGENTS or config.yaml) wins over agents.yaml.
+func resolveA2ASource(cfg *config.Config) (a2aAgentSource, error) {
+	if os.Getenv("INFER_A2A_AGENTS") != "" {
+		return a2aAgentSource{kind: a2aSourceEnv}, nil
+	}
+	if len(cfg.A2A.Agents) == 0 {
+		return a2aAgentSource{kind: a2aSourceYAML, path: config.ResolveAgentsPath()}, nil
+	}
+
+	toProject := false
+	if project, err := scopedConfigMap(true); err == nil {
+		_, lookupErr := lookupConfigKey(project, "a2a.agents")
+		toProject = lookupErr == nil
+	}
+	_, path, err := configWriteTarget(toProject)
+	if err != nil {
+		return a2aAgentSource{}, err
+	}
+	return a2aAgentSource{kind: a2aSourceConfig, path: path, project: toProject}, nil
+}
+
+// loadA2AAgents returns the agents of the active source
+func loadA2AAgents(cfg *config.Config, source a2aAgentSource) ([]a2aAgent, error) {
+	if source.kind != a2aSourceYAML {
+		agents := make([]a2aAgent, 0, len(cfg.A2A.Agents))
+		for _, url := range cfg.A2A.Agents {
+			agents = append(agents, a2aAgent{Name: extractAgentNameFromURL(url), URL: url, Enabled: true})
+		}
+		return agents, nil
+	}
+
+	agentsCfg, err := config.LoadAgents(source.path)
+	if err != nil {
+		return nil, err
+	}
+	agents := make([]a2aAgent, 0, len(agentsCfg.Agents))
+	for _, entry := range agentsCfg.ListEntries() {
+		agents = append(agents, a2aAgent{Name: entry.Name, URL: entry.URL, Enabled: entry.Enabled})
+	}
+	return agents, nil
+}
+
+// findA2AAgent matches target against agent names and URLs
+func findA2AAgent(agents []a2aAgent, target string) (a2aAgent, bool) {
+	for _, agent := range agents {
+		if agent.Name == target || strings.TrimSuffix(agent.URL, "/") == strings.TrimSuffix(target, "/") {
+			return agent, true
+		}
+	}
+	return a2aAgent{}, false
+}
+
+// fetchA2ACard fetches one agent card, bounded by a2aCardTimeout
+func fetchA2ACard(ctx context.Context, cfg *config.Config, url string) (*adk.AgentCard, error) {
+	ctx, cancel := context.WithTimeout(ctx, a2aCardTimeout)
+	defer cancel()
+	return services.NewA2AAgentService(cfg).GetAgentCard(ctx, url)
+}
+
+func runA2AList(ctx context.Context, w io.Writer, cfg *config.Config, check bool) error {
+	source, err := resolveA2ASource(cfg)
+	if err != nil {
+		return err
+	}
+	agents, err := loadA2AAgents(cfg, source)
+	if err != nil {
+		return err
+	}
+
+	if len(agents) == 0 {
+		_, _ = fmt.Fprintln(w, "No A2A agents configured.")
+		_, _ = fmt.Fprintln(w, "Use 'infer a2a add <url>' to add one.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("A2A Agents (%d)", len(agents))))
+	_, _ = fmt.Fprintln(w, listHint("Source: "+source.String()))
+	if !cfg.A2A.Enabled {
+		_, _ = fmt.Fprintln(w, listHint("A2A is disabled; enable it with 'infer config set a2a.enabled true'."))
+	}
+	_, _ = fmt.Fprintln(w)
+
+	headers := []string{"Enabled", "Name", "URL"}
+	if check {
+		headers = append(headers, "Agent Card")
+	}
+	agentsTable := newListTable(headers...)
+	for _, agent := range agents {
+		row := []string{statusIcon(agent.Enabled), agent.Name, agent.URL}
+		if check {
+			row = append(row, a2aCardStatus(ctx, cfg, agent.URL))
+		}
+		agentsTable.Row(row...)
+	}
+	_, _ = fmt.Fprintln(w, agentsTable.Render())
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, statusLegend())
+	return nil
+}
+
+// a2aCardStatus summarizes whether an agent serves its card
+func a2aCardStatus(ctx context.Context, cfg *config.Config, url string) string {
+	card, err := fetchA2ACard(ctx, cfg, url)
+	if err != nil {
+		return icons.CrossMark + " " + err.Error()
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", icons.CheckMark, card.Name, card.Version))
+}
+
+func runA2AAdd(ctx context.Context, w io.Writer, cfg *config.Config, url, name string, check bool) error {
+	source, err := resolveA2ASource(cfg)
+	if err != nil {
+		return err
+	}
+	agents, err := loadA2AAgents(cfg, source)
+	if err != nil {
+		return err
+	}
+	if existing, ok := findA2AAgent(agents, url); ok {
+		return fmt.Errorf("agent at %s is already configured as '%s'", url, existing.Name)
+	}
+
+	if check {
+		card, err := fetchA2ACard(ctx, cfg, url)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the agent card from %s (use --skip-check to add it anyway): %w", url, err)
+		}
+		_, _ = fmt.Fprintf(w, "%s Reached %s %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), card.Name, card.Version)
+		if name == "" {
+			name = a2aAgentSlug(card.Name)
+		}
+	}
+	if name == "" {
+		name = extractAgentNameFromURL(url)
+	}
+
+	switch source.kind {
+	case a2aSourceEnv:
+		urls := append(slices.Clone(cfg.A2A.Agents), url)
+		return fmt.Errorf("INFER_A2A_AGENTS overrides the config files; add the agent there instead:\n  export INFER_A2A_AGENTS=%q", strings.Join(urls, ","))
+	case a2aSourceConfig:
+		if err := writeA2AConfigAgents(source, append(slices.Clone(cfg.A2A.Agents), url)); err != nil {
+			return err
+		}
+	default:
+		agentsCfg, err := config.LoadAgents(source.path)
+		if err != nil {
+			return err
+		}
+		if err := agentsCfg.CreateEntry(config.AgentEntry{Name: name, URL: url, Enabled: true}); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "%s Agent '%s' added to %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), name, source)
+	_, _ = fmt.Fprintln(w, "Note: Restart your chat session for changes to take effect.")
+	return nil
+}
+
+func runA2ARemove(w io.Writer, cfg *config.Config, target string) error {
+	source, err := resolveA2ASource(cfg)
+	if err != nil {
+		return err
+	}
+	agents, err := loadA2AAgents(cfg, source)
+	if err != nil {
+		return err
+	}
+	agent, ok := findA2AAgent(agents, target)
+	if !ok {
+		return fmt.Errorf("no agent named or at '%s' in %s", target, source)
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(cfg.A2A.Agents), func(url string) bool { return url == agent.URL })
+	switch source.kind {
+	case a2aSourceEnv:
+		return fmt.Errorf("INFER_A2A_AGENTS overrides the config files; remove the agent there instead:\n  export INFER_A2A_AGENTS=%q", strings.Join(remaining, ","))
+	case a2aSourceConfig:
+		if err := writeA2AConfigAgents(source, remaining); err != nil {
+			return err
+		}
+	default:
+		agentsCfg, err := config.LoadAgents(source.path)
+		if err != nil {
+			return err
+		}
+		if err := agentsCfg.DeleteEntry(agent.Name); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "%s Agent '%s' removed from %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), agent.Name, source)
+	return nil
+}
+
+// writeA2AConfigAgents replaces a2a.agents in the config.yaml that sets it
+func writeA2AConfigAgents(source a2aAgentSource, urls []string) error {
+	target, _, err := configWriteTarget(source.project)
+	if err != nil {
+		return err
+	}
+	target.Set("a2a.agents", urls)
+	return writeConfigTarget(target, source.project)
+}
+
+// a2aAgentSlug turns an agent card name into an agents.yaml entry name
+func a2aAgentSlug(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	return strings.Join(fields, "-")
+}
+
+func runA2ACard(ctx context.Context, w io.Writer, cfg *config.Config, target, format string) error {
+	url := target
+	if !strings.Contains(target, "://") {
+		source, err := resolveA2ASource(cfg)
+		if err != nil {
+			return err
+		}
+		agents, err := loadA2AAgents(cfg, source)
+		if err != nil {
+			return err
+		}
+		agent, ok := findA2AAgent(agents, target)
+		if !ok {
+			return fmt.Errorf("no agent named '%s' in %s", target, source)
+		}
+		url = agent.URL
+	}
+
+	card, err := fetchA2ACard(ctx, cfg, url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the agent card from %s: %w", url, err)
+	}
+
+	if format == "json" {
+		output, err := json.MarshalIndent(card, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal agent card: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+	return printA2ACard(w, card)
+}
+
+// a2aCardView is the part of an agent card printA2ACard shows, decoded by its
+// A2A JSON names so optional fields read as zero values.
+type a2aCardView struct {
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	Version            string   `json:"version"`
+	URL                string   `json:"url"`
+	ProtocolVersion    string   `json:"protocolVersion"`
+	DefaultInputModes  []string `json:"defaultInputModes"`
+	DefaultOutputModes []string `json:"defaultOutputModes"`
+	Provider           *struct {
+		Organization string `json:"organization"`
+	} `json:"provider"`
+	Capabilities struct {
+		Streaming              *bool `json:"streaming"`
+		PushNotifications      *bool `json:"pushNotifications"`
+		StateTransitionHistory *bool `json:"stateTransitionHistory"`
+	} `json:"capabilities"`
+	Skills []struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+	} `json:"skills"`
+}
+
+// printA2ACard pretty-prints an agent card
+func printA2ACard(w io.Writer, card *adk.AgentCard) error {
+	data, err := json.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent card: %w", err)
+	}
+	var view a2aCardView
+	if err := json.Unmarshal(data, &view); err != nil {
+		return fmt.Errorf("failed to read agent card: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Agent: %s", view.Name)))
+	if view.Description != "" {
+		_, _ = fmt.Fprintln(w, listHint(strings.Join(strings.Fields(view.Description), " ")))
+	}
+	_, _ = fmt.Fprintln(w)
+
+	for _, field := range [][2]string{
+		{"Version", view.Version},
+		{"URL", view.URL},
+		{"Protocol", view.ProtocolVersion},
+		{"Input", strings.Join(view.DefaultInputModes, ", ")},
+		{"Output", strings.Join(view.DefaultOutputModes, ", ")},
+	} {
+		if field[1] != "" {
+			_, _ = fmt.Fprintln(w, listField(field[0], field[1]))
+		}
+	}
+	if view.Provider != nil && view.Provider.Organization != "" {
+		_, _ = fmt.Fprintln(w, listField("Provider", view.Provider.Organization))
+	}
+
+	capability := func(enabled *bool) string { return statusIcon(enabled != nil && *enabled) }
+	_, _ = fmt.Fprintln(w, listField("Capabilities", fmt.Sprintf("%s streaming  %s push notifications  %s state history",
+		capability(view.Capabilities.Streaming),
+		capability(view.Capabilities.PushNotifications),
+		capability(view.Capabilities.StateTransitionHistory))))
+
+	if len(view.Skills) == 0 {
+		return nil
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Skills (%d)", len(view.Skills))))
+	_, _ = fmt.Fprintln(w)
+	skillsTable := newListTable("Skill", "Description", "Tags")
+	for _, skill := range view.Skills {
+		name := skill.Name
+		if name == "" {
+			name = skill.ID
+		}
+		skillsTable.Row(name, strings.Join(strings.Fields(skill.Description), " "), strings.Join(skill.Tags, ", "))
+	}
+	_, _ = fmt.Fprintln(w, skillsTable.Render())
+	return nil
+}
+
+// completeA2AAgents completes the names of the agents in the active source
+func completeA2AAgents(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	source, err := resolveA2ASource(Cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	agents, err := loadA2AAgents(Cfg, source)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		names = append(names, agent.Name+"\t"+agent.URL)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
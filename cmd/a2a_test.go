@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	adk "github.com/inference-gateway/adk/types"
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestResolveA2ASource(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.A2A.Agents = nil
+	t.Setenv("INFER_A2A_AGENTS", "")
+	source, err := resolveA2ASource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source.kind != a2aSourceYAML {
+		t.Errorf("without an a2a.agents list the source must be agents.yaml, got %q", source.kind)
+	}
+
+	cfg.A2A.Agents = []string{"http://localhost:8081"}
+	source, err = resolveA2ASource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source.kind != a2aSourceConfig || source.path != filepath.Join(home, config.ConfigDirName, config.ConfigFileName) {
+		t.Errorf("a non-empty a2a.agents must be written to the userspace config.yaml, got %+v", source)
+	}
+
+	t.Setenv("INFER_A2A_AGENTS", "http://localhost:8081")
+	if source, _ = resolveA2ASource(cfg); source.kind != a2aSourceEnv {
+		t.Errorf("INFER_A2A_AGENTS must win, got %q", source.kind)
+	}
+	if err := runA2AAdd(context.Background(), &bytes.Buffer{}, cfg, "http://localhost:8082", "", false); err == nil ||
+		!strings.Contains(err.Error(), "http://localhost:8081,http://localhost:8082") {
+		t.Errorf("adding under INFER_A2A_AGENTS must print the value to export, got %v", err)
+	}
+}
+
+func TestA2AAddRemoveAgentsYAML(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("INFER_A2A_AGENTS", "")
+	t.Chdir(t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.A2A.Agents = nil
+	var out bytes.Buffer
+	if err := runA2AAdd(context.Background(), &out, cfg, "http://localhost:8081", "reviewer", false); err != nil {
+		t.Fatal(err)
+	}
+
+	agentsCfg, err := config.LoadAgents(config.ResolveAgentsPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := agentsCfg.ReadEntry("reviewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.URL != "http://localhost:8081" || !entry.Enabled {
+		t.Errorf("added entry = %+v", entry)
+	}
+
+	if err := runA2AAdd(context.Background(), &out, cfg, "http://localhost:8081/", "other", false); err == nil {
+		t.Error("adding the same URL twice must fail")
+	}
+
+	if err := runA2ARemove(&out, cfg, "http://localhost:8081"); err != nil {
+		t.Fatal(err)
+	}
+	agentsCfg, _ = config.LoadAgents(config.ResolveAgentsPath())
+	if len(agentsCfg.ListEntries()) != 0 {
+		t.Errorf("remove by URL left %+v", agentsCfg.ListEntries())
+	}
+}
+
+func TestA2AAgentSlug(t *testing.T) {
+	for name, want := range map[string]string{
+		"Browser Agent":       "browser-agent",
+		"google_calendar-bot": "google-calendar-bot",
+		"  n8n  ":             "n8n",
+	} {
+		if got := a2aAgentSlug(name); got != want {
+			t.Errorf("a2aAgentSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestPrintA2ACard(t *testing.T) {
+	var out bytes.Buffer
+	card := &adk.AgentCard{Name: "browser-agent", Description: "Drives a\nheadless browser", Version: "1.2.0"}
+	if err := printA2ACard(&out, card); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"browser-agent", "Drives a headless browser", "1.2.0", "streaming"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("card output missing %q:\n%s", want, out.String())
+		}
+	}
+}
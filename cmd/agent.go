@@ -11,7 +11,6 @@ import (
 	"regexp"
 	"slices"
 	"strings"
-	"sync"
 	"time"
 
 	uuid "github.com/google/uuid"
@@ -26,6 +25,8 @@ import (
 	domain "github.com/inference-gateway/cli/internal/domain"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	services "github.com/inference-gateway/cli/internal/services"
+	structuredoutput "github.com/inference-gateway/cli/internal/services/structuredoutput"
+	toolscheduler "github.com/inference-gateway/cli/internal/services/toolscheduler"
 	streamevent "github.com/inference-gateway/cli/internal/streamevent"
 	telemetry "github.com/inference-gateway/cli/internal/telemetry"
 )
@@ -52,18 +53,116 @@ Examples:
   # Resume existing sessions
   infer agent "continue fixing the authentication bug" --session-id abc-123-def
   infer agent "analyze these new error logs" --session-id abc-123 --files error.log
-  infer agent "try a different approach" --session-id abc-123 --no-save`,
-	Args: cobra.ExactArgs(1),
+  infer agent "try a different approach" --session-id abc-123 --no-save
+
+  # Pipe in context: large input is attached like a paste in chat
+  cat error.log | infer agent "explain this failure"
+  git diff | infer agent "review this change"
+
+  # Run as a persona configured under agents.profiles
+  infer agent --persona reviewer "Review the changes on this branch"
+
+  # Stream typed JSONL events (turns, tool calls, tokens, cost, final message) for CI
+  infer agent "Fix the failing lint job" --output jsonl
+
+  # Run a batch of tasks from a file, two at a time, each in its own conversation
+  infer agent --tasks tasks.yaml --parallel 2
+
+  # Re-run a fixed prompt whenever a matching file is saved
+  infer agent --watch '*.go' "fix the failing test related to this file"
+
+  # Review in CI: findings become GitHub annotations and a JUnit report
+  git diff origin/main | infer agent --ci --junit report.xml "review this change"
+
+  # Print the answer as JSON validated against a schema
+  infer agent --schema findings.schema.json "list the TODOs in this repo"
+
+  # Run in the background and check on it later
+  infer agent --detach "upgrade the dependencies and fix what breaks"
+  infer jobs attach <job>`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyAgentUnattended(cmd, Cfg); err != nil {
+			return err
+		}
+		if err := validateAgentDetach(cmd); err != nil {
+			return err
+		}
 		model, _ := cmd.Flags().GetString("model")
+		persona, _ := cmd.Flags().GetString("persona")
+		if tasksFile, _ := cmd.Flags().GetString("tasks"); tasksFile != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("--tasks cannot be combined with a task description")
+			}
+			if persona != "" {
+				return fmt.Errorf("--persona cannot be combined with --tasks")
+			}
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			return RunAgentTasksCommand(tasksFile, model, parallel)
+		}
 		files, _ := cmd.Flags().GetStringSlice("files")
-		noSave, _ := cmd.Flags().GetBool("no-save")
 		sessionID, _ := cmd.Flags().GetString("session-id")
+		if globs, _ := cmd.Flags().GetStringArray("watch"); len(globs) > 0 {
+			if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+				return fmt.Errorf("--watch requires the prompt to run on every change")
+			}
+			if persona != "" {
+				return fmt.Errorf("--persona cannot be combined with --watch")
+			}
+			return RunAgentWatchCommand(globs, args[0], model, files, sessionID)
+		}
+		if persona != "" {
+			if err := Cfg.ApplyPersona(persona); err != nil {
+				return err
+			}
+		}
+		noSave, _ := cmd.Flags().GetBool("no-save")
 		requireApproval, _ := cmd.Flags().GetBool("require-approval")
 		heartbeat, _ := cmd.Flags().GetBool("heartbeat")
 		remote, _ := cmd.Flags().GetBool("remote")
 		resultFile, _ := cmd.Flags().GetString("result-file")
-		return RunAgentCommand(Cfg, model, args[0], files, noSave, sessionID, requireApproval, heartbeat, remote, resultFile)
+		output, _ := cmd.Flags().GetString("output")
+		if err := validateAgentOutput(output); err != nil {
+			return err
+		}
+
+		task := ""
+		if len(args) == 1 {
+			task = args[0]
+		}
+		// With --require-approval stdin carries approval responses instead.
+		if !requireApproval {
+			stdin, err := readPipedStdin(os.Stdin)
+			if err != nil {
+				return err
+			}
+			task = agentTaskWithStdin(task, stdin, Cfg.Chat.PasteCollapseLines)
+		}
+		if strings.TrimSpace(task) == "" {
+			return fmt.Errorf("requires a task description, as an argument or on stdin")
+		}
+		if detach, _ := cmd.Flags().GetBool("detach"); detach {
+			return runAgentDetached(cmd, Cfg, os.Stdout, task)
+		}
+
+		ci, err := agentCIFromFlags(cmd, requireApproval)
+		if err != nil {
+			return err
+		}
+		if ci != nil {
+			Cfg.Tools.Safety.ApprovalBehaviour = config.ApprovalBehaviourBlock
+			task += agentCIInstructions
+		}
+
+		schema, err := agentSchemaFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if schema != nil {
+			task += agentSchemaInstructions(schema)
+		}
+
+		return RunAgentCommand(Cfg, model, task, files, noSave, sessionID, requireApproval, heartbeat, remote, resultFile, output, ci, schema)
 	},
 }
 
@@ -100,15 +199,35 @@ type AgentSession struct {
 	reminderProvider domain.SystemReminderProvider
 	hookProvider     domain.HookCommandProvider
 	memoryBackend    domain.MemoryBackend
+	memoryDistiller  domain.MemoryDistiller
 	firedReminders   map[string]bool
 	lastToolFailed   bool
 	saveEnabled      bool
 	bgWaiter         *services.BackgroundTasksWaiter
 	requireApproval  bool
 	approvalCh       chan domain.ApprovalResponse
+	// yieldForApproval, when set, is called before waiting for an approval
+	// and the function it returns once the wait is over
+	yieldForApproval func() (resume func())
 	rolloverManager  *services.SessionRolloverManager
 	groupKey         string
 	telemetryCtx     context.Context
+	outputFormat     string
+	eventSink        func(event map[string]any)
+	// audit records every tool call of an unattended run; nil otherwise
+	audit *agentAuditLog
+
+	// budget measures spending against agent.max_cost_per_run and the pricing
+	// budgets, from the session spend when the run and the last turn started
+	budget                domain.BudgetTracker
+	budgetSpentAtRunStart float64
+	budgetSpentAtTurn     float64
+
+	// --schema: the schema the final answer must match, the response format
+	// requesting it, and the validated answer
+	schema           *structuredoutput.Schema
+	responseFormat   *sdk.CreateChatCompletionRequest_ResponseFormat
+	structuredResult any
 }
 
 // baseCtx carries the session root span so LLM-turn and tool spans nest under it.
@@ -130,7 +249,7 @@ func inheritedSubagentMode() domain.AgentMode {
 	return domain.AgentModeStandard
 }
 
-func RunAgentCommand(cfg *config.Config, modelFlag, taskDescription string, files []string, noSave bool, sessionID string, requireApproval, heartbeat, remote bool, resultFile string) (err error) {
+func RunAgentCommand(cfg *config.Config, modelFlag, taskDescription string, files []string, noSave bool, sessionID string, requireApproval, heartbeat, remote bool, resultFile, outputFormat string, ci *agentCIOptions, schema *structuredoutput.Schema) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			outputAgentError(fmt.Sprintf("agent panic: %v", r))
@@ -150,6 +269,12 @@ func RunAgentCommand(cfg *config.Config, modelFlag, taskDescription string, file
 		cfg.Prompts.Agent.SystemPrompt = cfg.Prompts.Agent.SystemPromptRemote
 	}
 
+	audit, err := startAgentUnattended(cfg, os.Stderr)
+	if err != nil {
+		return err
+	}
+	defer audit.close()
+
 	svc := container.NewServiceContainer(cfg)
 	defer func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -188,32 +313,78 @@ For more information, visit: https://github.com/inference-gateway/inference-gate
 		return err
 	}
 
-	agentService := svc.GetAgentService()
-	toolService := svc.GetToolService()
-	fileService := svc.GetFileService()
-	imageService := svc.GetImageService()
-	conversationRepo := svc.GetConversationRepository()
-	stateManager := svc.GetStateManager()
-
 	agentMode := inheritedSubagentMode()
-	stateManager.SetAgentMode(agentMode)
+	if audit != nil {
+		agentMode = domain.AgentModeAutoAccept
+	}
+	session := newAgentSession(cfg, svc, selectedModel, agentMode, !noSave, requireApproval, outputFormat)
+	session.audit = audit
+	if schema != nil {
+		if err := session.useSchema(schema); err != nil {
+			return err
+		}
+	}
+
+	session.rolloverManager = svc.GetSessionRolloverManager()
+	session.groupKey = resolveAndLoadSession(session, session.rolloverManager, sessionID, selectedModel)
+
+	session.maybeRollover()
+	audit.record(agentAuditEntry{
+		Event:     "session_start",
+		SessionID: session.sessionID,
+		Model:     selectedModel,
+		Task:      taskDescription,
+	})
+
+	rec := svc.GetTelemetryRecorder()
+	rec.SetConversationID(session.sessionID)
+	sessionStart := time.Now()
+	endSessionSpan := rec.StartSession(agentMode.AllowedlistKey())
+	session.telemetryCtx = rec.SpanContext(context.Background())
+
+	err = session.execute(taskDescription, files)
+	if err == nil && session.schema != nil {
+		err = session.enforceSchema()
+	}
+	session.emitFinalMessage(err)
+	if err == nil && session.structuredResult != nil && !session.jsonlOutput() {
+		session.outputStructuredResult()
+	}
+
+	endSessionSpan(agentSessionOutcome(err))
+	rec.RecordSession(agentMode.AllowedlistKey(), agentSessionOutcome(err), time.Since(sessionStart))
+	if resultFile != "" {
+		writeSubagentResultFile(resultFile, session, err)
+	}
+	if ci != nil {
+		if ciErr := ci.report(os.Stdout, session.finalAssistantContent()); err == nil {
+			err = ciErr
+		}
+	}
+	return err
+}
+
+// newAgentSession builds a headless session for model on the container's
+// services. It is shared by `infer agent` and `infer serve`.
+func newAgentSession(cfg *config.Config, svc *container.ServiceContainer, model string, agentMode domain.AgentMode, saveEnabled, requireApproval bool, outputFormat string) *AgentSession {
+	conversationRepo := svc.GetConversationRepository()
+	svc.GetStateManager().SetAgentMode(agentMode)
 
-	saveEnabled := !noSave
 	if !saveEnabled {
 		if persistentRepo, ok := conversationRepo.(*services.PersistentConversationRepository); ok {
 			persistentRepo.SetAutoSave(false)
 		}
 	}
 
-	newSessionID := uuid.New().String()
-	session := &AgentSession{
-		agentService:     agentService,
-		toolService:      toolService,
-		fileService:      fileService,
-		imageService:     imageService,
-		model:            selectedModel,
+	sessionID := uuid.New().String()
+	return &AgentSession{
+		agentService:     svc.GetAgentService(),
+		toolService:      svc.GetToolService(),
+		fileService:      svc.GetFileService(),
+		imageService:     svc.GetImageService(),
+		model:            model,
 		agentMode:        agentMode,
-		sessionID:        newSessionID,
+		sessionID:        sessionID,
 		maxTurns:         cfg.Agent.MaxTurns,
 		conversation:     []ConversationMessage{},
 		config:           cfg,
@@ -221,38 +392,21 @@ For more information, visit: https://github.com/inference-gateway/inference-gate
 		reminderProvider: cfg.Reminders,
 		hookProvider:     cfg.Hooks,
 		memoryBackend:    svc.GetMemoryBackend(),
+		memoryDistiller:  svc.GetMemoryDistiller(),
 		firedReminders:   make(map[string]bool),
 		saveEnabled:      saveEnabled,
 		bgWaiter: services.NewBackgroundTasksWaiter(
 			cfg,
-			newSessionID,
+			sessionID,
 			svc.GetBackgroundTaskRegistry(),
 			svc.GetMessageQueue(),
 			conversationRepo,
 		),
 		requireApproval: requireApproval,
 		approvalCh:      make(chan domain.ApprovalResponse, 1),
+		outputFormat:    outputFormat,
+		budget:          svc.GetBudgetService(),
 	}
-
-	session.rolloverManager = svc.GetSessionRolloverManager()
-	session.groupKey = resolveAndLoadSession(session, session.rolloverManager, sessionID, selectedModel)
-
-	session.maybeRollover()
-
-	rec := svc.GetTelemetryRecorder()
-	rec.SetConversationID(session.sessionID)
-	sessionStart := time.Now()
-	endSessionSpan := rec.StartSession(agentMode.AllowedlistKey())
-	session.telemetryCtx = rec.SpanContext(context.Background())
-
-	err = session.execute(taskDescription, files)
-
-	endSessionSpan(agentSessionOutcome(err))
-	rec.RecordSession(agentMode.AllowedlistKey(), agentSessionOutcome(err), time.Since(sessionStart))
-	if resultFile != "" {
-		writeSubagentResultFile(resultFile, session, err)
-	}
-	return err
 }
 
 // agentSessionOutcome maps a run error to the infer.run.outcome enum: a
@@ -473,7 +627,7 @@ func (s *AgentSession) expandFileReferences(content string, additionalFiles []st
 	return result, nil
 }
 
-func (s *AgentSession) execute(taskDescription string, files []string) error {
+func (s *AgentSession) execute(taskDescription string, files []string) (err error) {
 	defer s.emitSessionStats()
 
 	expansion, err := s.expandFileReferences(taskDescription, files)
@@ -489,13 +643,31 @@ func (s *AgentSession) execute(taskDescription string, files []string) error {
 	})
 
 	s.outputMessage(s.conversation[len(s.conversation)-1])
+	s.emitEvent(agentEventSessionStart, map[string]any{"model": s.model})
+
+	startReason := "new"
+	if len(s.conversation) > 1 {
+		startReason = "resume"
+	}
+	s.runLifecycleHooks(domain.HookSessionStart, startReason, nil)
+	defer func() {
+		endReason := "completed"
+		switch {
+		case err != nil:
+			endReason = "error"
+		case s.completedTurns >= s.maxTurns:
+			endReason = "max_turns"
+		}
+		s.distillMemory()
+		s.runLifecycleHooks(domain.HookSessionEnd, endReason, err)
+	}()
 
 	monitorCtx, monitorCancel := context.WithCancel(context.Background())
 	defer monitorCancel()
 	s.bgWaiter.Start(monitorCtx)
 	defer s.bgWaiter.Stop()
 
-	if s.requireApproval {
+	if s.requireApproval && s.eventSink == nil {
 		go s.readApprovalResponses()
 	}
 
@@ -510,8 +682,15 @@ func (s *AgentSession) execute(taskDescription string, files []string) error {
 		}
 		s.dispatchHooks(domain.HookPreStream, turn)
 
+		if err := s.checkBudget(); err != nil {
+			logger.Warn("agent run stopped by a budget", "error", err, "turn", s.completedTurns)
+			s.runLifecycleHooks(domain.HookOnError, "", err)
+			return err
+		}
+
 		if err := s.executeTurn(); err != nil {
 			logger.Error("turn execution failed", "error", err, "turn", s.completedTurns)
+			s.runLifecycleHooks(domain.HookOnError, "", err)
 			return err
 		}
 
@@ -646,13 +825,15 @@ func (s *AgentSession) executeTurn() error {
 	s.lastToolFailed = false
 	ctx := s.baseCtx()
 	requestID := uuid.New().String()
+	s.emitEvent(agentEventTurnStart, map[string]any{"turn": s.completedTurns + 1})
 
 	messages := s.buildSDKMessages()
 
 	req := &domain.AgentRequest{
-		RequestID: requestID,
-		Model:     s.model,
-		Messages:  messages,
+		RequestID:      requestID,
+		Model:          s.model,
+		Messages:       messages,
+		ResponseFormat: s.responseFormat,
 	}
 
 	response, err := s.agentService.Run(ctx, req)
@@ -663,6 +844,33 @@ func (s *AgentSession) executeTurn() error {
 	return s.processSyncResponse(response, requestID)
 }
 
+// checkBudget stops the run before a request that is projected to take
+// spending past a budget - the previous turn's cost stands in for the next
+// one's - or when a budget is already used up. Nobody is there to confirm
+// running past it, so unlike chat the run always stops.
+func (s *AgentSession) checkBudget() error {
+	if s.budget == nil {
+		return nil
+	}
+
+	status := s.budget.BudgetStatus()
+	if s.completedTurns == 0 {
+		s.budgetSpentAtRunStart = status.SessionSpent
+		s.budgetSpentAtTurn = status.SessionSpent
+	}
+	lastTurnCost := status.SessionSpent - s.budgetSpentAtTurn
+	s.budgetSpentAtTurn = status.SessionSpent
+	status.RunSpent = status.SessionSpent - s.budgetSpentAtRunStart
+	status.RunLimit = s.config.Agent.MaxCostPerRun
+
+	budget := status.Exceeds(lastTurnCost)
+	if budget == "" {
+		return nil
+	}
+	spent, limit := status.Spend(budget)
+	return fmt.Errorf("%s budget of $%.2f reached ($%.2f spent), agent run stopped", budget, limit, spent)
+}
+
 func (s *AgentSession) buildSDKMessages() []sdk.Message {
 	var messages []sdk.Message
 
@@ -754,6 +962,7 @@ func (s *AgentSession) processSyncResponse(response *domain.ChatSyncResponse, re
 
 	s.addMessage(assistantMsg)
 	s.outputMessage(assistantMsg)
+	s.emitResponseEvents(assistantMsg)
 
 	if len(response.ToolCalls) == 0 {
 		return nil
@@ -765,6 +974,7 @@ func (s *AgentSession) processSyncResponse(response *domain.ChatSyncResponse, re
 	for _, result := range toolResults {
 		s.addMessage(result)
 		s.outputMessage(result)
+		s.emitToolResultEvent(result)
 	}
 
 	return nil
@@ -793,7 +1003,9 @@ func (s *AgentSession) executeToolCall(toolName, args, callID string, approved b
 		Name:      toolName,
 		Arguments: args,
 	}
-	return s.toolService.ExecuteTool(ctx, toolCall)
+	result, err := s.toolService.ExecuteTool(ctx, toolCall)
+	s.audit.recordToolCall(s.sessionID, toolName, callID, args, result, err)
+	return result, err
 }
 
 func (s *AgentSession) executeToolCallsParallel(toolCalls []sdk.ChatCompletionMessageToolCall) []ConversationMessage {
@@ -801,20 +1013,13 @@ func (s *AgentSession) executeToolCallsParallel(toolCalls []sdk.ChatCompletionMe
 		return []ConversationMessage{}
 	}
 
-	logger.Info("executing tool calls in parallel", "count", len(toolCalls), "max_concurrent", s.config.Agent.MaxConcurrentTools)
+	logger.Info("executing tool calls in parallel", "count", len(toolCalls))
 
 	results := make([]ConversationMessage, len(toolCalls))
-	semaphore := make(chan struct{}, s.config.Agent.MaxConcurrentTools)
-
-	var wg sync.WaitGroup
+	batch := toolscheduler.NewBatch(s.config.Agent.ToolConcurrency)
 	for i, toolCall := range toolCalls {
-		wg.Add(1)
-		go func(index int, tc sdk.ChatCompletionMessageToolCall) {
-			defer wg.Done()
-
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
+		index, tc := i, toolCall
+		batch.Go(tc, func() {
 			result, err := s.executeToolCall(tc.Function.Name, tc.Function.Arguments, tc.ID, false)
 			if err != nil {
 				logger.Error("tool execution failed", "tool", tc.Function.Name, "tool_call_id", tc.ID, "error", err)
@@ -840,10 +1045,10 @@ func (s *AgentSession) executeToolCallsParallel(toolCalls []sdk.ChatCompletionMe
 				ToolExecution: result,
 				Timestamp:     time.Now(),
 			}
-		}(i, toolCall)
+		})
 	}
 
-	wg.Wait()
+	batch.Wait()
 	return results
 }
 
@@ -977,14 +1182,7 @@ func (s *AgentSession) deliverApprovalRequiredTool(tc sdk.ChatCompletionMessageT
 	}
 
 	s.outputApprovalRequest(tc)
-	approved := false
-	select {
-	case resp := <-s.approvalCh:
-		approved = resp.Approved
-	case <-time.After(constants.ApprovalTimeout):
-		logger.Warn("approval timeout for tool", "tool", tc.Function.Name)
-	}
-	if !approved {
+	if !s.awaitApproval(tc) {
 		return s.toolRejectedMessage(tc,
 			fmt.Sprintf("Tool '%s' was rejected by the user.", tc.Function.Name),
 			"tool execution rejected by user")
@@ -994,11 +1192,29 @@ func (s *AgentSession) deliverApprovalRequiredTool(tc sdk.ChatCompletionMessageT
 	return s.toolResultMessage(tc, result, err)
 }
 
+// awaitApproval waits for the answer to an approval request, rejecting the
+// call when none arrives in time
+func (s *AgentSession) awaitApproval(tc sdk.ChatCompletionMessageToolCall) bool {
+	if s.yieldForApproval != nil {
+		defer s.yieldForApproval()()
+	}
+	select {
+	case resp := <-s.approvalCh:
+		return resp.Approved
+	case <-time.After(constants.ApprovalTimeout):
+		logger.Warn("approval timeout for tool", "tool", tc.Function.Name)
+		return false
+	}
+}
+
 // isToolApprovalRequired checks if a tool requires user approval based on config.
 func (s *AgentSession) isToolApprovalRequired(tc sdk.ChatCompletionMessageToolCall) bool {
 	if s.agentMode == domain.AgentModeAutoAccept {
 		return false
 	}
+	if action, ok := s.config.ApprovalRuleAction(tc.Function.Name, tc.Function.Arguments); ok {
+		return action == config.ApprovalRuleAsk
+	}
 	if tc.Function.Name == "Bash" {
 		var args map[string]any
 		if err := json.Unmarshal([]byte(tc.Function.Arguments), &args); err != nil {
@@ -1034,8 +1250,18 @@ func outputAgentError(message string) {
 	}
 }
 
-// outputApprovalRequest writes an approval request JSON line to stdout for the channel manager.
+// outputApprovalRequest writes an approval request JSON line to stdout for the
+// channel manager, or hands it to the event sink when `infer serve` runs the session.
 func (s *AgentSession) outputApprovalRequest(tc sdk.ChatCompletionMessageToolCall) {
+	if s.eventSink != nil {
+		s.emitEvent(agentEventApprovalRequest, map[string]any{
+			"tool_call_id": tc.ID,
+			"name":         tc.Function.Name,
+			"arguments":    toolCallArguments(tc),
+		})
+		return
+	}
+
 	req := domain.ApprovalRequest{
 		Type:       "approval_request",
 		ToolName:   tc.Function.Name,
@@ -1165,6 +1391,39 @@ func (s *AgentSession) dispatchHooks(hook domain.HookPoint, turn int) {
 	agent.RunCommandHooks(context.Background(), s.config, s.hookProvider, s.agentMode.AllowedlistKey(), hook, turn, s.sessionID)
 }
 
+// runLifecycleHooks fires the command hooks of a lifecycle point (session_start,
+// session_end, on_error) with the headless session's metadata. Unlike
+// dispatchHooks it injects no reminders: lifecycle points are command-only.
+func (s *AgentSession) runLifecycleHooks(hook domain.HookPoint, reason string, err error) {
+	payload := domain.HookPayload{
+		Hook:      hook,
+		Turn:      s.completedTurns,
+		SessionID: s.sessionID,
+		Model:     s.model,
+		Messages:  len(s.conversation),
+		Reason:    reason,
+	}
+	if err != nil {
+		payload.Error = err.Error()
+	}
+	agent.RunHooksWithPayload(context.Background(), s.config, s.hookProvider, s.agentMode.AllowedlistKey(), payload)
+}
+
+// distillMemory files the durable facts of the run into memory
+// (memory.distill), leaving out internal messages such as reminders.
+func (s *AgentSession) distillMemory() {
+	var messages []sdk.Message
+	for _, msg := range s.conversation {
+		if !msg.Internal {
+			messages = append(messages, sdk.Message{
+				Role:    sdk.MessageRole(msg.Role),
+				Content: sdk.NewMessageContent(msg.Content),
+			})
+		}
+	}
+	distillSessionMemory(domain.WithSessionID(context.Background(), s.sessionID), s.memoryDistiller, messages, s.model)
+}
+
 // injectDueReminders appends any reminders due at the hook point as internal user
 // messages. Skipped while awaiting tool results (a user message would orphan the
 // pending tool_calls) - that guard is reminder-specific and must not block
@@ -1258,6 +1517,9 @@ func (s *AgentSession) initializeSession(sessionID string) (bool, error) {
 
 	s.conversation = make([]ConversationMessage, 0, len(entries))
 	for _, entry := range entries {
+		if entry.ChangeSummary != nil {
+			continue
+		}
 		msg := s.convertFromConversationEntry(entry)
 		s.conversation = append(s.conversation, msg)
 	}
@@ -1270,7 +1532,7 @@ func (s *AgentSession) initializeSession(sessionID string) (bool, error) {
 }
 
 func (s *AgentSession) outputMessage(msg ConversationMessage) {
-	if msg.Role == "system" || msg.Internal {
+	if msg.Role == "system" || msg.Internal || s.jsonlOutput() || s.schema != nil {
 		return
 	}
 
@@ -1333,8 +1595,13 @@ func formatToolCallSummary(name, argsJSON string) string {
 	return fmt.Sprintf("%s(%s)", name, summary)
 }
 
-// outputStatusMessage outputs a structured JSON status message
+// outputStatusMessage outputs a structured JSON status message. The jsonl
+// format has its own events and leaves these out.
 func (s *AgentSession) outputStatusMessage(messageType, message string, metadata map[string]any) {
+	if s.jsonlOutput() {
+		return
+	}
+
 	statusMsg := map[string]any{
 		"type":      messageType,
 		"message":   message,
@@ -1360,7 +1627,8 @@ func (s *AgentSession) outputStatusMessage(messageType, message string, metadata
 // they are session/conversation-scoped: resumed sessions include restored history and a mid-run
 // rollover resets them with the conversation. Accumulation is in-memory and thus independent of
 // --no-save. Invoked via defer in execute() so it fires on completion, early error, and panic
-// unwind. Suppressed when no usage-bearing request occurred.
+// unwind. Suppressed when no usage-bearing request occurred. With --output jsonl the same totals
+// are printed as the cost event instead.
 func (s *AgentSession) emitSessionStats() {
 	if s.conversationRepo == nil {
 		return
@@ -1377,7 +1645,7 @@ func (s *AgentSession) emitSessionStats() {
 		currency = s.config.Pricing.Currency
 	}
 
-	s.outputStatusMessage("session_stats", "Session complete", map[string]any{
+	stats := map[string]any{
 		"model":             s.model,
 		"prompt_tokens":     tokenStats.TotalInputTokens,
 		"completion_tokens": tokenStats.TotalOutputTokens,
@@ -1389,7 +1657,9 @@ func (s *AgentSession) emitSessionStats() {
 			"total":    costStats.TotalCost,
 			"currency": currency,
 		},
-	})
+	}
+	s.outputStatusMessage("session_stats", "Session complete", stats)
+	s.emitEvent(agentEventCost, stats)
 }
 
 func (s *AgentSession) lastResponseHadNoToolCalls() bool {
@@ -1436,12 +1706,26 @@ func isModelAvailable(models []string, targetModel string) bool {
 
 func init() {
 	agentCmd.Flags().StringP("model", "m", "", "Model to use for the agent (e.g., openai/gpt-4)")
+	agentCmd.Flags().String("persona", "", "Run as a persona from agents.profiles (its system prompt, model, tools and approval policy)")
 	agentCmd.Flags().StringSliceP("files", "f", []string{}, "Files or images to include (e.g., -f image.png -f code.go)")
 	agentCmd.Flags().Bool("no-save", false, "Disable saving conversation to database")
 	agentCmd.Flags().String("session-id", "", "Resume an existing agent session by conversation ID")
 	agentCmd.Flags().Bool("require-approval", false, "Enable IPC-based tool approval via stdin/stdout (used by channel manager)")
+	agentCmd.Flags().Bool("auto-approve", false, "Unattended mode for containers and CI: run every tool without approval and record each call in agent.audit_log (required)")
 	agentCmd.Flags().Bool("heartbeat", false, "Run with the heartbeat system prompt (used by the heartbeat service)")
 	agentCmd.Flags().Bool("remote", false, "Run with the remote-control system prompt (used by the channels-manager daemon)")
 	agentCmd.Flags().String("result-file", "", "Write the final assistant message and outcome as JSON to this path on exit (used by the Agent tool to harvest detached subagents)")
+	agentCmd.Flags().Bool("detach", false, "Run the agent as a background job and return at once; follow it with 'infer jobs'")
+	agentCmd.Flags().String("tasks", "", "Run the tasks of a YAML file, each in its own conversation, and print a summary")
+	agentCmd.Flags().StringArray("watch", nil, "Re-run the prompt whenever a file matching this glob changes (repeatable, e.g. --watch '*.go')")
+	agentCmd.Flags().Int("parallel", 0, "With --tasks, how many tasks run at once (default: the file's parallel, else 1)")
+	agentCmd.Flags().Bool("ci", false, "CI mode: block tools that need approval, print findings as GitHub Actions annotations and fail on --fail-on severities")
+	agentCmd.Flags().String("junit", "", "With --ci, also write the findings as a JUnit XML report to this path")
+	agentCmd.Flags().StringSlice("fail-on", []string{config.ReviewSeverityCritical, config.ReviewSeverityHigh}, "With --ci, finding severities that fail the run (critical, high, medium, low; empty never fails)")
+	agentCmd.Flags().String("schema", "", "Constrain the final answer to the JSON Schema in this file: it is sent to the gateway, validated locally with up to 2 repair turns, and printed as the only stdout line")
+	agentCmd.Flags().String("output", agentOutputMessages, "Output format: messages (one JSON line per conversation message) or jsonl (typed events for CI: turn_start, tool_call, tool_result, tokens, cost, final_message)")
+	_ = agentCmd.RegisterFlagCompletionFunc("model", completeModels)
+	_ = agentCmd.RegisterFlagCompletionFunc("persona", completePersonas)
+	_ = agentCmd.RegisterFlagCompletionFunc("session-id", completeConversationIDs)
 	rootCmd.AddCommand(agentCmd)
 }
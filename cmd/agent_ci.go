@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+// agentCIInstructions is appended to the task with --ci so the final answer
+// carries findings in the format parseReviewFindings reads
+const agentCIInstructions = `
+
+You are running unattended in a CI job: nobody can answer questions or approve tools, and tools that need approval are blocked. Report every finding on its own line in exactly this format:
+[SEVERITY] path/to/file:line - description
+SEVERITY is one of CRITICAL, HIGH, MEDIUM, LOW. Paths are relative to the repository root. If there is nothing to report, say so without any finding lines.`
+
+var ciFindingLocationPattern = regexp.MustCompile(`^(\S+?):(\d+)(?::\d+)?\s+-\s+(.+)$`)
+
+// agentCIOptions configures `infer agent --ci`
+type agentCIOptions struct {
+	JUnitPath string
+	FailOn    []string
+}
+
+// ciFinding is a review finding with its location split out
+type ciFinding struct {
+	Severity string
+	File     string
+	Line     int
+	Message  string
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// agentCIFromFlags returns the --ci options, or nil without --ci
+func agentCIFromFlags(cmd *cobra.Command, requireApproval bool) (*agentCIOptions, error) {
+	if isCI, _ := cmd.Flags().GetBool("ci"); !isCI {
+		return nil, nil
+	}
+	if requireApproval {
+		return nil, fmt.Errorf("--ci cannot be combined with --require-approval")
+	}
+	junitPath, _ := cmd.Flags().GetString("junit")
+	failOn, _ := cmd.Flags().GetStringSlice("fail-on")
+	failOn, err := validateAgentCIFailOn(failOn)
+	if err != nil {
+		return nil, err
+	}
+	return &agentCIOptions{JUnitPath: junitPath, FailOn: failOn}, nil
+}
+
+// validateAgentCIFailOn drops empty entries from --fail-on and rejects
+// unknown severities
+func validateAgentCIFailOn(failOn []string) ([]string, error) {
+	var severities []string
+	for _, severity := range failOn {
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if severity == "" {
+			continue
+		}
+		if !slices.Contains(config.ReviewSeverities, severity) {
+			return nil, fmt.Errorf("invalid --fail-on severity %q: must be one of %q", severity, config.ReviewSeverities)
+		}
+		severities = append(severities, severity)
+	}
+	return severities, nil
+}
+
+// parseCIFindings reads the findings of the agent's final answer
+func parseCIFindings(content string) []ciFinding {
+	var findings []ciFinding
+	for _, f := range parseReviewFindings(content) {
+		finding := ciFinding{Severity: f.Severity, Message: f.Text}
+		if m := ciFindingLocationPattern.FindStringSubmatch(f.Text); m != nil {
+			finding.File, finding.Message = m[1], m[3]
+			finding.Line, _ = strconv.Atoi(m[2])
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// report prints the findings of content as GitHub Actions annotations, writes
+// the JUnit report when asked, and fails when a finding has a --fail-on
+// severity
+func (o *agentCIOptions) report(w io.Writer, content string) error {
+	findings := parseCIFindings(content)
+	for _, f := range findings {
+		fmt.Fprintln(w, githubAnnotation(f))
+	}
+
+	if o.JUnitPath != "" {
+		data, err := junitReport(findings, o.FailOn)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(o.JUnitPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write JUnit report: %w", err)
+		}
+	}
+
+	failed := 0
+	for _, f := range findings {
+		if isBlockingSeverity(f.Severity, o.FailOn) {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("agent reported %d finding(s) at %s severity", failed, strings.Join(o.FailOn, "/"))
+	}
+	return nil
+}
+
+// githubAnnotation formats a finding as a workflow command: critical and high
+// are errors, medium a warning, low a notice
+func githubAnnotation(f ciFinding) string {
+	level := "notice"
+	switch f.Severity {
+	case config.ReviewSeverityCritical, config.ReviewSeverityHigh:
+		level = "error"
+	case config.ReviewSeverityMedium:
+		level = "warning"
+	}
+
+	props := []string{}
+	if f.File != "" {
+		props = append(props, "file="+escapeAnnotationProperty(f.File))
+		if f.Line > 0 {
+			props = append(props, "line="+strconv.Itoa(f.Line))
+		}
+	}
+	props = append(props, "title="+escapeAnnotationProperty("infer ("+f.Severity+")"))
+	return fmt.Sprintf("::%s %s::%s", level, strings.Join(props, ","), escapeAnnotationData(f.Message))
+}
+
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// junitReport renders the findings as a JUnit test suite: findings at a
+// failOn severity fail, the others are skipped, and a clean run is one
+// passing test case
+func junitReport(findings []ciFinding, failOn []string) ([]byte, error) {
+	suite := junitTestSuite{Name: "infer agent"}
+	for _, f := range findings {
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("[%s] %s", strings.ToUpper(f.Severity), f.Message),
+			Classname: cmp.Or(f.File, "infer"),
+			File:      f.File,
+			Line:      f.Line,
+		}
+		msg := &junitMessage{Message: f.Message, Type: f.Severity}
+		if isBlockingSeverity(f.Severity, failOn) {
+			tc.Failure = msg
+			suite.Failures++
+		} else {
+			tc.Skipped = msg
+			suite.Skipped++
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{Name: "no findings", Classname: "infer"})
+	}
+	suite.Tests = len(suite.Cases)
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const agentCITestAnswer = `I reviewed the change.
+
+[HIGH] internal/db/store.go:42 - rows are never closed, leaking connections
+[LOW] README.md - typo in "recieve"
+- [medium] cmd/serve.go:10:5 - error from Close is ignored, 100% of the time`
+
+func TestParseCIFindings(t *testing.T) {
+	findings := parseCIFindings(agentCITestAnswer)
+	if len(findings) != 3 {
+		t.Fatalf("got %d findings, want 3: %+v", len(findings), findings)
+	}
+	if f := findings[0]; f.Severity != "high" || f.File != "internal/db/store.go" || f.Line != 42 || f.Message != "rows are never closed, leaking connections" {
+		t.Errorf("unexpected first finding %+v", f)
+	}
+	if f := findings[1]; f.File != "" || f.Message != `README.md - typo in "recieve"` {
+		t.Errorf("a finding without a line keeps its whole text, got %+v", f)
+	}
+	if f := findings[2]; f.File != "cmd/serve.go" || f.Line != 10 {
+		t.Errorf("unexpected third finding %+v", f)
+	}
+}
+
+func TestGithubAnnotation(t *testing.T) {
+	findings := parseCIFindings(agentCITestAnswer)
+	want := []string{
+		"::error file=internal/db/store.go,line=42,title=infer (high)::rows are never closed, leaking connections",
+		`::notice title=infer (low)::README.md - typo in "recieve"`,
+		"::warning file=cmd/serve.go,line=10,title=infer (medium)::error from Close is ignored, 100%25 of the time",
+	}
+	for i, f := range findings {
+		if got := githubAnnotation(f); got != want[i] {
+			t.Errorf("annotation %d:\n got %s\nwant %s", i, got, want[i])
+		}
+	}
+}
+
+func TestAgentCIReport(t *testing.T) {
+	junit := filepath.Join(t.TempDir(), "report.xml")
+	ci := &agentCIOptions{JUnitPath: junit, FailOn: []string{"critical", "high"}}
+
+	var out bytes.Buffer
+	err := ci.report(&out, agentCITestAnswer)
+	if err == nil || !strings.Contains(err.Error(), "1 finding(s)") {
+		t.Errorf("expected the high finding to fail the run, got %v", err)
+	}
+	if strings.Count(out.String(), "\n") != 3 {
+		t.Errorf("expected one annotation per finding:\n%s", out.String())
+	}
+
+	data, readErr := os.ReadFile(junit)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	for _, want := range []string{`tests="3" failures="1" skipped="2"`, `classname="internal/db/store.go"`, `<failure message="rows are never closed, leaking connections" type="high">`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JUnit report is missing %q:\n%s", want, data)
+		}
+	}
+
+	ci.FailOn = nil
+	if err := ci.report(&out, agentCITestAnswer); err != nil {
+		t.Errorf("an empty --fail-on never fails, got %v", err)
+	}
+	if err := ci.report(&out, "Looks good to me."); err != nil {
+		t.Errorf("a clean answer must pass, got %v", err)
+	}
+	if data, _ := os.ReadFile(junit); !strings.Contains(string(data), `name="no findings"`) {
+		t.Errorf("a clean run needs one passing test case:\n%s", data)
+	}
+}
+
+func TestValidateAgentCIFailOn(t *testing.T) {
+	got, err := validateAgentCIFailOn([]string{" High", "", "critical"})
+	if err != nil || strings.Join(got, ",") != "high,critical" {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := validateAgentCIFailOn([]string{"blocker"}); err == nil {
+		t.Error("expected an unknown severity to be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	agentjobs "github.com/inference-gateway/cli/internal/services/agentjobs"
+)
+
+// agentDetachConflicts are the flags a detached run cannot take: it has no
+// terminal to approve tools from or to print a report to, and --tasks and
+// --watch manage their own agent processes
+var agentDetachConflicts = []string{"require-approval", "result-file", "tasks", "watch", "ci"}
+
+// validateAgentDetach rejects --detach combined with a flag it cannot honour
+func validateAgentDetach(cmd *cobra.Command) error {
+	if detach, _ := cmd.Flags().GetBool("detach"); !detach {
+		return nil
+	}
+	for _, flag := range agentDetachConflicts {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--detach cannot be combined with --%s", flag)
+		}
+	}
+	return nil
+}
+
+// agentJobsStore is where detached runs are recorded
+func agentJobsStore() *agentjobs.Store {
+	return agentjobs.NewStore(config.DefaultJobsPath)
+}
+
+// runAgentDetached starts task as a background `infer agent` process with the
+// run's flags and prints how to follow it. The process keeps running after
+// this one exits; `infer jobs` monitors it.
+func runAgentDetached(cmd *cobra.Command, cfg *config.Config, w io.Writer, task string) error {
+	sessionID, _ := cmd.Flags().GetString("session-id")
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+	model, _ := cmd.Flags().GetString("model")
+	if model == "" {
+		model = cfg.Agent.Model
+	}
+
+	store := agentJobsStore()
+	job := agentjobs.Job{
+		ID:        uuid.New().String(),
+		SessionID: sessionID,
+		Task:      task,
+		Model:     model,
+	}
+	job, err := store.Start(job, os.Args[0], detachedAgentArgs(cmd, sessionID, store.ResultPath(job.ID), task), os.Environ())
+	if err != nil {
+		return err
+	}
+
+	id := shortJobID(job.ID)
+	_, _ = fmt.Fprintf(w, "Started job %s: %s\n", id, formatting.TruncateText(task, 60))
+	_, _ = fmt.Fprintf(w, "  session %s\n\n", sessionID)
+	_, _ = fmt.Fprintf(w, "  infer jobs attach %s   follow it until it finishes\n", id)
+	_, _ = fmt.Fprintf(w, "  infer jobs logs %s     print its output so far\n", id)
+	_, _ = fmt.Fprintf(w, "  infer jobs stop %s     stop it\n", id)
+	return nil
+}
+
+// detachedAgentArgs rebuilds the `infer agent` command line for the job
+// process from the flags of this one. The task is passed as an argument,
+// since the job has no stdin: piped input was already folded into it.
+func detachedAgentArgs(cmd *cobra.Command, sessionID, resultFile, task string) []string {
+	args := []string{"agent", "--session-id", sessionID, "--result-file", resultFile}
+	for _, flag := range []string{"model", "persona", "output", "schema"} {
+		if value, _ := cmd.Flags().GetString(flag); value != "" && cmd.Flags().Changed(flag) {
+			args = append(args, "--"+flag, value)
+		}
+	}
+	files, _ := cmd.Flags().GetStringSlice("files")
+	for _, file := range files {
+		args = append(args, "--files", file)
+	}
+	for _, flag := range []string{"no-save", "auto-approve"} {
+		if set, _ := cmd.Flags().GetBool(flag); set {
+			args = append(args, "--"+flag)
+		}
+	}
+	return append(args, "--", task)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// Output formats accepted by `infer agent --output`. The messages format
+// prints each conversation message as it is added and is what the channel
+// manager and the Agent tool read; jsonl prints the typed events below for
+// CI pipelines and wrappers.
+const (
+	agentOutputMessages = "messages"
+	agentOutputJSONL    = "jsonl"
+)
+
+// Event types printed with --output jsonl. Every line carries "type",
+// "session_id" and "timestamp"; the other fields depend on the type.
+const (
+	agentEventSessionStart     = "session_start"
+	agentEventTurnStart        = "turn_start"
+	agentEventAssistantMessage = "assistant_message"
+	agentEventToolCall         = "tool_call"
+	agentEventToolResult       = "tool_result"
+	agentEventTokens           = "tokens"
+	agentEventCost             = "cost"
+	agentEventFinalMessage     = "final_message"
+	// Sent only to `infer serve` clients, which answer it over the API.
+	agentEventApprovalRequest = "approval_request"
+)
+
+// validateAgentOutput rejects an unknown --output value
+func validateAgentOutput(format string) error {
+	switch format {
+	case agentOutputMessages, agentOutputJSONL:
+		return nil
+	}
+	return fmt.Errorf("invalid --output %q: must be %q or %q", format, agentOutputMessages, agentOutputJSONL)
+}
+
+func (s *AgentSession) jsonlOutput() bool {
+	return s.outputFormat == agentOutputJSONL
+}
+
+// emitEvent prints one --output jsonl event line, or passes the event to the
+// session's sink when `infer serve` runs it. It is a no-op in the messages
+// format, so callers don't need to check.
+func (s *AgentSession) emitEvent(eventType string, fields map[string]any) {
+	if !s.jsonlOutput() && s.eventSink == nil {
+		return
+	}
+
+	event := make(map[string]any, len(fields)+3)
+	for k, v := range fields {
+		event[k] = v
+	}
+	event["type"] = eventType
+	event["session_id"] = s.sessionID
+	event["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+
+	if s.eventSink != nil {
+		s.eventSink(event)
+		return
+	}
+
+	output, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("failed to marshal agent event", "type", eventType, "error", err)
+		return
+	}
+	fmt.Println(string(output))
+}
+
+// emitResponseEvents reports what one model response brought: its text, the
+// tokens it used and the tool calls it made.
+func (s *AgentSession) emitResponseEvents(msg ConversationMessage) {
+	turn := s.completedTurns + 1
+
+	if msg.Content != "" {
+		s.emitEvent(agentEventAssistantMessage, map[string]any{
+			"turn":    turn,
+			"content": msg.Content,
+		})
+	}
+
+	if usage := msg.TokenUsage; usage != nil {
+		s.emitEvent(agentEventTokens, map[string]any{
+			"turn":              turn,
+			"prompt_tokens":     usage.PromptTokens,
+			"completion_tokens": usage.CompletionTokens,
+			"total_tokens":      usage.TotalTokens,
+		})
+	}
+
+	if msg.ToolCalls == nil {
+		return
+	}
+	for _, tc := range *msg.ToolCalls {
+		s.emitEvent(agentEventToolCall, map[string]any{
+			"turn":         turn,
+			"tool_call_id": tc.ID,
+			"name":         tc.Function.Name,
+			"arguments":    toolCallArguments(tc),
+		})
+	}
+}
+
+// emitToolResultEvent reports the outcome of one tool call
+func (s *AgentSession) emitToolResultEvent(msg ConversationMessage) {
+	fields := map[string]any{
+		"turn":         s.completedTurns + 1,
+		"tool_call_id": msg.ToolCallID,
+		"content":      msg.Content,
+	}
+	if result := msg.ToolExecution; result != nil {
+		fields["name"] = result.ToolName
+		fields["success"] = result.Success
+		fields["duration_ms"] = result.Duration.Milliseconds()
+		if result.Rejected {
+			fields["rejected"] = true
+		}
+		if result.Error != "" {
+			fields["error"] = result.Error
+		}
+	}
+	s.emitEvent(agentEventToolResult, fields)
+}
+
+// emitFinalMessage closes a jsonl run with the agent's answer and how the
+// run ended, so a consumer need not reassemble it from earlier events.
+func (s *AgentSession) emitFinalMessage(runErr error) {
+	fields := map[string]any{
+		"content": s.finalAssistantContent(),
+		"turns":   s.completedTurns,
+		"outcome": agentSessionOutcome(runErr),
+	}
+	if runErr != nil {
+		fields["error"] = runErr.Error()
+	}
+	if s.structuredResult != nil {
+		fields["result"] = s.structuredResult
+	}
+	s.emitEvent(agentEventFinalMessage, fields)
+}
+
+// toolCallArguments returns a call's arguments as a JSON object when they
+// parse, and as the raw string the model sent otherwise.
+func toolCallArguments(tc sdk.ChatCompletionMessageToolCall) any {
+	if json.Valid([]byte(tc.Function.Arguments)) {
+		return json.RawMessage(tc.Function.Arguments)
+	}
+	return tc.Function.Arguments
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// decodeEvents splits captured --output jsonl output into one map per line.
+func decodeEvents(t *testing.T, out string) []map[string]any {
+	t.Helper()
+	var events []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var event map[string]any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestValidateAgentOutput(t *testing.T) {
+	for _, format := range []string{agentOutputMessages, agentOutputJSONL} {
+		if err := validateAgentOutput(format); err != nil {
+			t.Errorf("validateAgentOutput(%q) = %v", format, err)
+		}
+	}
+	if err := validateAgentOutput("yaml"); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+}
+
+func TestProcessSyncResponse_JSONLEvents(t *testing.T) {
+	toolService := &domainmocks.FakeToolService{}
+	toolService.ExecuteToolReturns(&domain.ToolExecutionResult{
+		ToolName: "Read",
+		Success:  true,
+		Duration: 1500 * time.Millisecond,
+	}, nil)
+
+	session := &AgentSession{
+		toolService:    toolService,
+		config:         &config.Config{Agent: config.AgentConfig{ToolConcurrency: config.ToolConcurrencyConfig{CPUBound: 1}}},
+		sessionID:      "session-1",
+		completedTurns: 2,
+		outputFormat:   agentOutputJSONL,
+	}
+	response := &domain.ChatSyncResponse{
+		Content: "Reading it now.",
+		ToolCalls: []sdk.ChatCompletionMessageToolCall{{
+			ID:       "call_1",
+			Function: sdk.ChatCompletionMessageToolCallFunction{Name: "Read", Arguments: `{"file_path":"go.mod"}`},
+		}},
+		Usage: &sdk.CompletionUsage{PromptTokens: 100, CompletionTokens: 20, TotalTokens: 120},
+	}
+
+	out := captureStdout(t, func() {
+		if err := session.processSyncResponse(response, "req-1"); err != nil {
+			t.Errorf("processSyncResponse() error = %v", err)
+		}
+	})
+
+	events := decodeEvents(t, out)
+	var types []string
+	for _, event := range events {
+		types = append(types, event["type"].(string))
+		if event["session_id"] != "session-1" || event["timestamp"] == nil {
+			t.Errorf("every event needs the session id and a timestamp, got %v", event)
+		}
+		if turn, ok := event["turn"].(float64); !ok || turn != 3 {
+			t.Errorf("%s: turn = %v, want 3", event["type"], event["turn"])
+		}
+	}
+	want := []string{agentEventAssistantMessage, agentEventTokens, agentEventToolCall, agentEventToolResult}
+	if strings.Join(types, ",") != strings.Join(want, ",") {
+		t.Fatalf("event types = %v, want %v", types, want)
+	}
+
+	if events[1]["total_tokens"] != float64(120) {
+		t.Errorf("tokens event = %v", events[1])
+	}
+	args, _ := events[2]["arguments"].(map[string]any)
+	if events[2]["name"] != "Read" || args["file_path"] != "go.mod" {
+		t.Errorf("tool_call event should carry the parsed arguments, got %v", events[2])
+	}
+	if events[3]["success"] != true || events[3]["duration_ms"] != float64(1500) || events[3]["tool_call_id"] != "call_1" {
+		t.Errorf("tool_result event = %v", events[3])
+	}
+}
+
+func TestEmitFinalMessage(t *testing.T) {
+	session := &AgentSession{
+		sessionID:      "session-1",
+		completedTurns: 4,
+		outputFormat:   agentOutputJSONL,
+		conversation: []ConversationMessage{
+			{Role: "user", Content: "fix it"},
+			{Role: "assistant", Content: "Fixed the lint error."},
+		},
+	}
+
+	event := decodeEvents(t, captureStdout(t, func() { session.emitFinalMessage(errors.New("boom")) }))[0]
+	if event["type"] != agentEventFinalMessage || event["content"] != "Fixed the lint error." {
+		t.Errorf("final_message event = %v", event)
+	}
+	if event["turns"] != float64(4) || event["outcome"] != agentSessionOutcome(errors.New("boom")) || event["error"] != "boom" {
+		t.Errorf("final_message should report how the run ended, got %v", event)
+	}
+
+	session.outputFormat = agentOutputMessages
+	if out := captureStdout(t, func() { session.emitFinalMessage(nil) }); out != "" {
+		t.Errorf("the messages format must not print events, got %q", out)
+	}
+}
+
+func TestEmitSessionStats_JSONLCostEvent(t *testing.T) {
+	session := &AgentSession{
+		model:        "deepseek/deepseek-v4-flash",
+		sessionID:    "session-1",
+		outputFormat: agentOutputJSONL,
+		config:       &config.Config{Pricing: config.PricingConfig{Currency: "USD"}},
+		conversationRepo: seededRepo(t, fakePricing(0.25, 0.125, 0.375),
+			"deepseek/deepseek-v4-flash", 2, 1000, 100, 1100),
+	}
+
+	events := decodeEvents(t, captureStdout(t, session.emitSessionStats))
+	if len(events) != 1 || events[0]["type"] != agentEventCost {
+		t.Fatalf("expected only a cost event, got %v", events)
+	}
+	cost, _ := events[0]["cost"].(map[string]any)
+	if events[0]["total_tokens"] != float64(2200) || cost["total"] != 0.75 {
+		t.Errorf("cost event = %v", events[0])
+	}
+}
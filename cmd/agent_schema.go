@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	logger "github.com/inference-gateway/cli/internal/logger"
+	structuredoutput "github.com/inference-gateway/cli/internal/services/structuredoutput"
+)
+
+// maxSchemaRepairs bounds the extra turns spent asking the model to fix a
+// final answer that doesn't match --schema
+const maxSchemaRepairs = 2
+
+// agentSchemaFromFlags loads the --schema file, or returns nil without it
+func agentSchemaFromFlags(cmd *cobra.Command) (*structuredoutput.Schema, error) {
+	path, _ := cmd.Flags().GetString("schema")
+	if path == "" {
+		return nil, nil
+	}
+	if isCI, _ := cmd.Flags().GetBool("ci"); isCI {
+		return nil, fmt.Errorf("--schema cannot be combined with --ci")
+	}
+	return structuredoutput.Load(path)
+}
+
+// agentSchemaInstructions is appended to the task with --schema, for models
+// and gateways that don't enforce the json_schema response format themselves
+func agentSchemaInstructions(schema *structuredoutput.Schema) string {
+	return "\n\nYour final answer is parsed by a program: reply with only a JSON value matching this JSON Schema, " +
+		"without any other text or markdown.\n" + schema.JSON()
+}
+
+// schemaRepairPrompt asks the model to correct an answer that failed
+// validation against --schema
+func schemaRepairPrompt(problems []string) string {
+	return "<system-reminder>\nYour final answer does not match the required JSON Schema:\n- " +
+		strings.Join(problems, "\n- ") +
+		"\nReply with only the corrected JSON value.\n</system-reminder>"
+}
+
+// useSchema constrains the session's answers to schema
+func (s *AgentSession) useSchema(schema *structuredoutput.Schema) error {
+	responseFormat, err := schema.ResponseFormat()
+	if err != nil {
+		return fmt.Errorf("failed to build response format from schema: %w", err)
+	}
+	s.schema = schema
+	s.responseFormat = responseFormat
+	return nil
+}
+
+// enforceSchema validates the final answer against --schema, asking the
+// model to repair it up to maxSchemaRepairs times, and keeps the parsed value
+// as the session's structured result
+func (s *AgentSession) enforceSchema() error {
+	content := s.finalAssistantContent()
+	for attempt := 0; ; attempt++ {
+		result, problems := s.schema.Check(content)
+		if len(problems) == 0 {
+			s.structuredResult = result
+			return nil
+		}
+		if attempt >= maxSchemaRepairs {
+			return fmt.Errorf("final answer does not match --schema after %d repair attempts: %s",
+				maxSchemaRepairs, strings.Join(problems, "; "))
+		}
+
+		logger.Warn("final answer does not match the schema, asking for a repair",
+			"attempt", attempt+1,
+			"problems", problems)
+		s.addMessage(ConversationMessage{
+			Role:      "user",
+			Content:   schemaRepairPrompt(problems),
+			Timestamp: time.Now(),
+			Internal:  true,
+		})
+		if err := s.executeTurn(); err != nil {
+			return err
+		}
+		s.completedTurns++
+		content = lastAssistantBefore(s.conversation, len(s.conversation))
+	}
+}
+
+// outputStructuredResult prints the validated --schema result as the only
+// stdout line of the messages format
+func (s *AgentSession) outputStructuredResult() {
+	output, err := json.Marshal(s.structuredResult)
+	if err != nil {
+		logger.Error("failed to marshal structured result", "error", err)
+		return
+	}
+	fmt.Println(string(output))
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	structuredoutput "github.com/inference-gateway/cli/internal/services/structuredoutput"
+)
+
+func TestEnforceSchema_ValidAnswer(t *testing.T) {
+	schema, err := structuredoutput.Parse("result", []byte(`{
+		"type": "object",
+		"properties": {"todos": {"type": "integer"}},
+		"required": ["todos"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &AgentSession{}
+	if err := s.useSchema(schema); err != nil {
+		t.Fatalf("useSchema: %v", err)
+	}
+	if s.responseFormat == nil {
+		t.Fatal("expected a response format for the request")
+	}
+	s.conversation = []ConversationMessage{
+		{Role: "user", Content: "count the TODOs" + agentSchemaInstructions(schema)},
+		{Role: "assistant", Content: "```json\n{\"todos\": 3}\n```"},
+	}
+
+	if err := s.enforceSchema(); err != nil {
+		t.Fatalf("enforceSchema: %v", err)
+	}
+	result, ok := s.structuredResult.(map[string]any)
+	if !ok || result["todos"] != 3.0 {
+		t.Errorf("structured result = %#v", s.structuredResult)
+	}
+}
+
+func TestSchemaRepairPrompt(t *testing.T) {
+	prompt := schemaRepairPrompt([]string{`/: missing required property "todos"`, "/extra: unexpected property"})
+	for _, want := range []string{`- /: missing required property "todos"`, "- /extra: unexpected property", "corrected JSON"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("repair prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+)
+
+// maxAgentStdinBytes caps what `infer agent` reads from a pipe, so a stray
+// `yes |` cannot grow the prompt without bound.
+const maxAgentStdinBytes = 10 << 20
+
+// readPipedStdin returns what was piped or redirected into stdin, or "" when
+// stdin is a terminal or a device such as /dev/null, which is what the agent
+// gets when another process spawns it.
+func readPipedStdin(stdin *os.File) (string, error) {
+	info, err := stdin.Stat()
+	if err != nil {
+		return "", nil
+	}
+	if mode := info.Mode(); mode&os.ModeNamedPipe == 0 && !mode.IsRegular() {
+		return "", nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(stdin, maxAgentStdinBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	if len(data) > maxAgentStdinBytes {
+		return "", fmt.Errorf("stdin is larger than %d MiB", maxAgentStdinBytes>>20)
+	}
+	return string(data), nil
+}
+
+// agentTaskWithStdin adds piped input to the task as context: in a code
+// block, or as a pasted-text attachment like the chat input makes when it is
+// longer than chat.paste_collapse_lines. Without a task, the input is the task.
+func agentTaskWithStdin(task, stdin string, collapseLines int) string {
+	stdin = strings.TrimRight(stdin, "\r\n")
+	if strings.TrimSpace(stdin) == "" {
+		return task
+	}
+	if strings.TrimSpace(task) == "" {
+		return stdin
+	}
+
+	if collapseLines > 0 && formatting.CountLines(stdin) > collapseLines {
+		return task + "\n\n" + formatting.WrapPastedText(stdin)
+	}
+	return task + "\n\n```\n" + stdin + "\n```"
+}
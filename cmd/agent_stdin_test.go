@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAgentTaskWithStdin(t *testing.T) {
+	longLog := strings.Repeat("panic: boom\n", 40)
+
+	tests := []struct {
+		name     string
+		task     string
+		stdin    string
+		collapse int
+		want     string
+	}{
+		{name: "no stdin", task: "fix it", want: "fix it"},
+		{name: "blank stdin", task: "fix it", stdin: "\n  \n", want: "fix it"},
+		{name: "stdin is the task", stdin: "summarize the repo\n", want: "summarize the repo"},
+		{
+			name:     "short input in a code block",
+			task:     "explain this failure",
+			stdin:    "exit status 1\n",
+			collapse: 20,
+			want:     "explain this failure\n\n```\nexit status 1\n```",
+		},
+		{
+			name:     "long input as a pasted-text attachment",
+			task:     "explain this failure",
+			stdin:    longLog,
+			collapse: 20,
+			want:     "explain this failure\n\n<pasted lines=\"40\">\n" + strings.TrimSuffix(longLog, "\n") + "\n</pasted>",
+		},
+		{
+			name:  "collapsing disabled",
+			task:  "explain this failure",
+			stdin: longLog,
+			want:  "explain this failure\n\n```\n" + strings.TrimSuffix(longLog, "\n") + "\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := agentTaskWithStdin(tt.task, tt.stdin, tt.collapse); got != tt.want {
+				t.Errorf("agentTaskWithStdin() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPipedStdin(t *testing.T) {
+	t.Run("pipe", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("os.Pipe: %v", err)
+		}
+		defer func() { _ = r.Close() }()
+		go func() {
+			_, _ = w.WriteString("piped log\n")
+			_ = w.Close()
+		}()
+
+		got, err := readPipedStdin(r)
+		if err != nil || got != "piped log\n" {
+			t.Errorf("readPipedStdin() = %q, %v", got, err)
+		}
+	})
+
+	t.Run("redirected file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "input.txt")
+		if err := os.WriteFile(path, []byte("from a file"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+
+		got, err := readPipedStdin(f)
+		if err != nil || got != "from a file" {
+			t.Errorf("readPipedStdin() = %q, %v", got, err)
+		}
+	})
+
+	t.Run("null device is ignored", func(t *testing.T) {
+		f, err := os.Open(os.DevNull)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+
+		got, err := readPipedStdin(f)
+		if err != nil || got != "" {
+			t.Errorf("readPipedStdin() = %q, %v, want nothing read", got, err)
+		}
+	})
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/google/uuid"
+	yaml "gopkg.in/yaml.v3"
+
+	config "github.com/inference-gateway/cli/config"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	agentrunner "github.com/inference-gateway/cli/internal/services/agentrunner"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// agentTasksFile is the file given to `infer agent --tasks`. Model, parallel
+// and tools apply to every task unless the task overrides them.
+type agentTasksFile struct {
+	Model    string          `yaml:"model"`
+	Parallel int             `yaml:"parallel"`
+	Tools    map[string]bool `yaml:"tools"`
+	Tasks    []agentTask     `yaml:"tasks"`
+}
+
+// agentTask is one task of a batch, run in its own conversation
+type agentTask struct {
+	Name   string          `yaml:"name"`
+	Prompt string          `yaml:"prompt"`
+	Model  string          `yaml:"model"`
+	Files  []string        `yaml:"files"`
+	Tools  map[string]bool `yaml:"tools"`
+}
+
+// agentTaskResult is the outcome of one task, from its session_stats line
+type agentTaskResult struct {
+	Name         string
+	SessionID    string
+	Model        string
+	Succeeded    bool
+	Duration     time.Duration
+	Requests     int
+	InputTokens  int
+	OutputTokens int
+	Cost         float64
+	Error        string
+}
+
+// agentTaskRunFunc runs one `infer agent` subprocess; agentrunner.Run in production
+type agentTaskRunFunc func(ctx context.Context, opts agentrunner.Options) (agentrunner.Result, error)
+
+// RunAgentTasksCommand runs the tasks of a batch file, each as its own
+// `infer agent` process and conversation, and prints a summary. It fails when
+// any task failed.
+func RunAgentTasksCommand(path, modelFlag string, parallelFlag int) error {
+	file, err := loadAgentTasksFile(path)
+	if err != nil {
+		return err
+	}
+
+	parallel := file.Parallel
+	if parallelFlag > 0 {
+		parallel = parallelFlag
+	}
+	parallel = max(parallel, 1)
+
+	results := runAgentTasks(context.Background(), file, modelFlag, parallel, agentrunner.Run)
+
+	fmt.Print(renderAgentTaskResults(results))
+	failed := 0
+	for _, result := range results {
+		if !result.Succeeded {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tasks failed", failed, len(results))
+	}
+	return nil
+}
+
+// loadAgentTasksFile reads and validates a tasks file
+func loadAgentTasksFile(path string) (*agentTasksFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tasks file: %w", err)
+	}
+
+	var file agentTasksFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tasks file %s: %w", path, err)
+	}
+	if len(file.Tasks) == 0 {
+		return nil, fmt.Errorf("tasks file %s has no tasks", path)
+	}
+
+	known := toolsWithEnabledSwitch()
+	checkTools := func(where string, tools map[string]bool) error {
+		for name := range tools {
+			if !known[name] {
+				return fmt.Errorf("%s: unknown tool %q in tools (known: %s)", where, name, strings.Join(sortedKeys(known), ", "))
+			}
+		}
+		return nil
+	}
+	if err := checkTools(path, file.Tools); err != nil {
+		return nil, err
+	}
+
+	for i := range file.Tasks {
+		task := &file.Tasks[i]
+		if task.Name == "" {
+			task.Name = fmt.Sprintf("task-%d", i+1)
+		}
+		if strings.TrimSpace(task.Prompt) == "" {
+			return nil, fmt.Errorf("task %q has no prompt", task.Name)
+		}
+		if err := checkTools("task "+strconv.Quote(task.Name), task.Tools); err != nil {
+			return nil, err
+		}
+	}
+	return &file, nil
+}
+
+// toolsWithEnabledSwitch lists the tools.<name> sections that have an
+// enabled flag, the names a task can switch on or off
+func toolsWithEnabledSwitch() map[string]bool {
+	names := map[string]bool{}
+	t := reflect.TypeFor[config.ToolsConfig]()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Struct {
+			continue
+		}
+		if _, ok := field.Type.FieldByName("Enabled"); !ok {
+			continue
+		}
+		if name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ","); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// agentTaskEnv turns tool switches into INFER_TOOLS_<NAME>_ENABLED variables,
+// the task's own switches overriding the file's
+func agentTaskEnv(fileTools, taskTools map[string]bool) []string {
+	merged := map[string]bool{}
+	for name, enabled := range fileTools {
+		merged[name] = enabled
+	}
+	for name, enabled := range taskTools {
+		merged[name] = enabled
+	}
+
+	env := make([]string, 0, len(merged))
+	for _, name := range sortedKeys(merged) {
+		env = append(env, fmt.Sprintf("INFER_TOOLS_%s_ENABLED=%t", strings.ToUpper(name), merged[name]))
+	}
+	return env
+}
+
+// runAgentTasks runs at most parallel tasks at a time and returns their
+// results in file order. The model is the task's, else modelFlag, else the
+// file's, else the agent default.
+func runAgentTasks(ctx context.Context, file *agentTasksFile, modelFlag string, parallel int, run agentTaskRunFunc) []agentTaskResult {
+	results := make([]agentTaskResult, len(file.Tasks))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+
+	for i, task := range file.Tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			model := task.Model
+			if model == "" {
+				model = modelFlag
+			}
+			if model == "" {
+				model = file.Model
+			}
+
+			progressMu.Lock()
+			fmt.Fprintf(os.Stderr, "%s [%d/%d] %s\n", icons.BulletIcon, i+1, len(file.Tasks), task.Name)
+			progressMu.Unlock()
+
+			result := runAgentTask(ctx, task, model, agentTaskEnv(file.Tools, task.Tools), run)
+			results[i] = result
+
+			icon := icons.CheckMark
+			if !result.Succeeded {
+				icon = icons.CrossMark
+			}
+			progressMu.Lock()
+			fmt.Fprintf(os.Stderr, "%s %s (%s)\n", icon, task.Name, result.Duration.Round(time.Second))
+			progressMu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func runAgentTask(ctx context.Context, task agentTask, model string, env []string, run agentTaskRunFunc) agentTaskResult {
+	result := agentTaskResult{Name: task.Name, SessionID: uuid.New().String(), Model: model}
+
+	start := time.Now()
+	res, err := run(ctx, agentrunner.Options{
+		SessionID: result.SessionID,
+		Prompt:    task.Prompt,
+		Model:     model,
+		Files:     task.Files,
+		ExtraEnv:  env,
+		OnLine: func(line []byte) {
+			var stats struct {
+				Type             string `json:"type"`
+				Model            string `json:"model"`
+				PromptTokens     int    `json:"prompt_tokens"`
+				CompletionTokens int    `json:"completion_tokens"`
+				Requests         int    `json:"requests"`
+				Cost             struct {
+					Total float64 `json:"total"`
+				} `json:"cost"`
+			}
+			if json.Unmarshal(line, &stats) != nil || stats.Type != "session_stats" {
+				return
+			}
+			if stats.Model != "" {
+				result.Model = stats.Model
+			}
+			result.Requests, result.Cost = stats.Requests, stats.Cost.Total
+			result.InputTokens, result.OutputTokens = stats.PromptTokens, stats.CompletionTokens
+		},
+	})
+	result.Duration = time.Since(start)
+
+	result.Succeeded = err == nil
+	if err != nil {
+		result.Error = err.Error()
+		if stderr := strings.TrimSpace(res.Stderr); stderr != "" {
+			lines := strings.Split(stderr, "\n")
+			result.Error += ": " + lines[len(lines)-1]
+		}
+	}
+	return result
+}
+
+func renderAgentTaskResults(results []agentTaskResult) string {
+	var sb strings.Builder
+	sb.WriteString("\n" + listTitle(fmt.Sprintf("Tasks (%d)", len(results))) + "\n\n")
+
+	t := newListTable("", "Task", "Model", "Duration", "Requests", "Tokens", "Cost", "Session")
+	var total float64
+	succeeded := 0
+	for _, r := range results {
+		t.Row(
+			statusIcon(r.Succeeded),
+			r.Name,
+			r.Model,
+			r.Duration.Round(time.Second).String(),
+			strconv.Itoa(r.Requests),
+			strconv.Itoa(r.InputTokens+r.OutputTokens),
+			formatting.FormatCost(r.Cost),
+			r.SessionID,
+		)
+		total += r.Cost
+		if r.Succeeded {
+			succeeded++
+		}
+	}
+	sb.WriteString(t.Render() + "\n\n")
+
+	for _, r := range results {
+		if r.Error != "" {
+			sb.WriteString(listField(r.Name, r.Error) + "\n")
+		}
+	}
+	sb.WriteString(listField("Succeeded", fmt.Sprintf("%d/%d", succeeded, len(results))) + "\n")
+	sb.WriteString(listField("Total cost", formatting.FormatCost(total)) + "\n")
+	sb.WriteString(listHint("Inspect a task with: infer conversations show <session>") + "\n")
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	ansi "github.com/charmbracelet/x/ansi"
+
+	agentrunner "github.com/inference-gateway/cli/internal/services/agentrunner"
+)
+
+func writeTasksFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAgentTasksFile(t *testing.T) {
+	file, err := loadAgentTasksFile(writeTasksFile(t, `
+model: openai/gpt-4o
+parallel: 3
+tools:
+  bash: false
+tasks:
+  - prompt: Rename Foo to Bar in pkg/a
+  - name: docs
+    prompt: Update the README
+    tools:
+      write: true
+`))
+	if err != nil {
+		t.Fatalf("loadAgentTasksFile: %v", err)
+	}
+	if file.Parallel != 3 || len(file.Tasks) != 2 || file.Tasks[0].Name != "task-1" || file.Tasks[1].Name != "docs" {
+		t.Errorf("unexpected tasks file %+v", file)
+	}
+
+	for _, bad := range []string{
+		"tasks: []",
+		"tasks:\n  - name: empty\n",
+		"tasks:\n  - prompt: x\n    tools:\n      teleport: true\n",
+	} {
+		if _, err := loadAgentTasksFile(writeTasksFile(t, bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestAgentTaskEnv(t *testing.T) {
+	got := agentTaskEnv(map[string]bool{"bash": false, "write": false}, map[string]bool{"write": true})
+	want := "INFER_TOOLS_BASH_ENABLED=false,INFER_TOOLS_WRITE_ENABLED=true"
+	if strings.Join(got, ",") != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+}
+
+func TestRunAgentTasks(t *testing.T) {
+	file := &agentTasksFile{
+		Model: "file/model",
+		Tools: map[string]bool{"bash": false},
+		Tasks: []agentTask{
+			{Name: "one", Prompt: "first", Model: "task/model"},
+			{Name: "two", Prompt: "second"},
+			{Name: "three", Prompt: "fails"},
+		},
+	}
+
+	var mu sync.Mutex
+	var running, peak atomic.Int32
+	seen := map[string]agentrunner.Options{}
+	run := func(_ context.Context, opts agentrunner.Options) (agentrunner.Result, error) {
+		peak.Store(max(peak.Load(), running.Add(1)))
+		defer running.Add(-1)
+
+		mu.Lock()
+		seen[opts.Prompt] = opts
+		mu.Unlock()
+
+		if opts.Prompt == "fails" {
+			return agentrunner.Result{Stderr: "log line\nno models available"}, errors.New("exit status 1")
+		}
+		opts.OnLine([]byte(`{"role":"assistant","content":"done"}`))
+		opts.OnLine([]byte(`{"type":"session_stats","model":"` + opts.Model + `","prompt_tokens":100,"completion_tokens":20,"requests":2,"cost":{"total":0.5}}`))
+		return agentrunner.Result{}, nil
+	}
+
+	results := runAgentTasks(context.Background(), file, "flag/model", 2, run)
+
+	if seen["first"].Model != "task/model" || seen["second"].Model != "flag/model" {
+		t.Errorf("task model must win over the flag: %q, %q", seen["first"].Model, seen["second"].Model)
+	}
+	if env := seen["first"].ExtraEnv; len(env) != 1 || env[0] != "INFER_TOOLS_BASH_ENABLED=false" {
+		t.Errorf("unexpected env %v", env)
+	}
+	if seen["first"].SessionID == seen["second"].SessionID {
+		t.Error("every task needs its own conversation")
+	}
+	if peak.Load() > 2 {
+		t.Errorf("ran %d tasks at once, want at most 2", peak.Load())
+	}
+
+	if results[0].Name != "one" || !results[0].Succeeded || results[0].Requests != 2 || results[0].InputTokens != 100 || results[0].Cost != 0.5 {
+		t.Errorf("unexpected first result %+v", results[0])
+	}
+	if results[2].Succeeded || results[2].Error != "exit status 1: no models available" {
+		t.Errorf("unexpected failed result %+v", results[2])
+	}
+
+	out := ansi.Strip(renderAgentTaskResults(results))
+	for _, want := range []string{"Tasks (3)", "Succeeded: 2/3", "$1.00", "no models available"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary is missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -365,7 +365,7 @@ func TestExecuteToolCallsParallel(t *testing.T) {
 
 			cfg := &config.Config{
 				Agent: config.AgentConfig{
-					MaxConcurrentTools: tt.maxConcurrentTool,
+					ToolConcurrency: config.ToolConcurrencyConfig{CPUBound: tt.maxConcurrentTool},
 				},
 			}
 
@@ -404,7 +404,7 @@ func TestExecuteToolCalls_BlocksWhenNoApprover(t *testing.T) {
 		t.Run(behaviour, func(t *testing.T) {
 			mockToolService := &domainmocks.FakeToolService{}
 
-			cfg := &config.Config{Agent: config.AgentConfig{MaxConcurrentTools: 5}}
+			cfg := &config.Config{Agent: config.AgentConfig{ToolConcurrency: config.ToolConcurrencyConfig{CPUBound: 5}}}
 			cfg.Tools.Safety.RequireApproval = true
 			cfg.Tools.Safety.ApprovalBehaviour = behaviour
 
@@ -466,7 +466,7 @@ func TestExecuteToolCalls_AutoAcceptBypassesApproval(t *testing.T) {
 	mockToolService := &domainmocks.FakeToolService{}
 	mockToolService.ExecuteToolReturns(&domain.ToolExecutionResult{ToolName: "Write", Success: true, Data: "ok"}, nil)
 
-	cfg := &config.Config{Agent: config.AgentConfig{MaxConcurrentTools: 5}}
+	cfg := &config.Config{Agent: config.AgentConfig{ToolConcurrency: config.ToolConcurrencyConfig{CPUBound: 5}}}
 	cfg.Tools.Safety.RequireApproval = true
 	cfg.Tools.Safety.ApprovalBehaviour = config.ApprovalBehaviourBlock
 
@@ -498,7 +498,7 @@ func TestExecuteToolCalls_IPCApprovalExecutesWhenApproved(t *testing.T) {
 	mockToolService := &domainmocks.FakeToolService{}
 	mockToolService.ExecuteToolReturns(&domain.ToolExecutionResult{ToolName: "Write", Success: true, Data: "ok"}, nil)
 
-	cfg := &config.Config{Agent: config.AgentConfig{MaxConcurrentTools: 5}}
+	cfg := &config.Config{Agent: config.AgentConfig{ToolConcurrency: config.ToolConcurrencyConfig{CPUBound: 5}}}
 	cfg.Tools.Safety.RequireApproval = true
 	cfg.Tools.Safety.ApprovalBehaviour = config.ApprovalBehaviourPrompt
 
@@ -595,7 +595,7 @@ func TestProcessSyncResponseParallel(t *testing.T) {
 
 			cfg := &config.Config{
 				Agent: config.AgentConfig{
-					MaxConcurrentTools: tt.maxConcurrentTools,
+					ToolConcurrency: config.ToolConcurrencyConfig{CPUBound: tt.maxConcurrentTools},
 				},
 			}
 
@@ -1107,7 +1107,7 @@ func TestReasoningContentRoundTripSyncPath(t *testing.T) {
 	t.Run("processSyncResponse persists reasoning_content on assistant message", func(t *testing.T) {
 		session := &AgentSession{
 			toolService:  &domainmocks.FakeToolService{},
-			config:       &config.Config{Agent: config.AgentConfig{MaxConcurrentTools: 1}},
+			config:       &config.Config{Agent: config.AgentConfig{ToolConcurrency: config.ToolConcurrencyConfig{CPUBound: 1}}},
 			conversation: []ConversationMessage{},
 		}
 
@@ -1531,8 +1531,75 @@ func TestAgentSession_DispatchHooks_SkipsOffListCommandHook(t *testing.T) {
 	}
 }
 
+// A lifecycle hook gets the headless session's metadata as JSON on stdin.
+func TestAgentSession_RunLifecycleHooks_PassesSessionMetadata(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "payload.json")
+	s := hookSession("tee "+out, out, []string{"tee .*"})
+	s.config.Hooks.Hooks[0].Hook = domain.HookOnError
+	s.model = "openai/gpt-4o"
+
+	s.runLifecycleHooks(domain.HookOnError, "", errors.New("gateway unreachable"))
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("on_error command hook did not run: %v", err)
+	}
+	var payload domain.HookPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("hook stdin is not a JSON payload: %v (%q)", err, data)
+	}
+	if payload.Hook != domain.HookOnError || payload.SessionID != "sess" || payload.Model != "openai/gpt-4o" || payload.Error != "gateway unreachable" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
 // TestCompletionNotice checks the drained-result header is distilled into a
 // clean one-line channel notification (icon + kind/verb, UUID label dropped).
+// fakeBudgetTracker reports a fixed budget status
+type fakeBudgetTracker struct {
+	status domain.BudgetStatus
+}
+
+func (f *fakeBudgetTracker) BudgetStatus() domain.BudgetStatus { return f.status }
+
+func (f *fakeBudgetTracker) RecordSpend(float64) {}
+
+func TestAgentSession_CheckBudget(t *testing.T) {
+	t.Run("stops before a request that would exceed the run budget", func(t *testing.T) {
+		tracker := &fakeBudgetTracker{status: domain.BudgetStatus{SessionSpent: 3}}
+		s := &AgentSession{
+			config: &config.Config{Agent: config.AgentConfig{MaxCostPerRun: 1}},
+			budget: tracker,
+		}
+
+		if err := s.checkBudget(); err != nil {
+			t.Fatalf("first turn: %v, want the cost of earlier runs left out", err)
+		}
+		s.completedTurns = 1
+		tracker.status.SessionSpent = 3.4
+		if err := s.checkBudget(); err != nil {
+			t.Fatalf("second turn: %v, want $0.40 spent and $0.80 projected to fit", err)
+		}
+		s.completedTurns = 2
+		tracker.status.SessionSpent = 3.8
+		err := s.checkBudget()
+		if err == nil || !strings.Contains(err.Error(), "run budget of $1.00") {
+			t.Fatalf("third turn: %v, want the run budget to stop the run", err)
+		}
+	})
+
+	t.Run("stops the first request once the daily budget is used up", func(t *testing.T) {
+		s := &AgentSession{
+			config: &config.Config{},
+			budget: &fakeBudgetTracker{status: domain.BudgetStatus{DaySpent: 20, DayLimit: 20}},
+		}
+		err := s.checkBudget()
+		if err == nil || !strings.Contains(err.Error(), "daily budget of $20.00 reached ($20.00 spent)") {
+			t.Fatalf("checkBudget() = %v, want the daily budget to stop the run", err)
+		}
+	})
+}
+
 func TestCompletionNotice(t *testing.T) {
 	tests := []struct {
 		name string
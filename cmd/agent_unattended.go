@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// agentUnattendedEnv carries --auto-approve to the `infer agent` processes
+// started by --tasks and --watch
+const agentUnattendedEnv = "INFER_AGENT_UNATTENDED"
+
+// errAgentAuditLogRequired rejects an unattended run without agent.audit_log
+var errAgentAuditLogRequired = errors.New("unattended mode requires an audit log: set agent.audit_log (or INFER_AGENT_AUDIT_LOG) to a file path")
+
+// agentUnattendedWarning is printed to stderr whenever a run is unattended
+const agentUnattendedWarning = `
+################################################################################
+  WARNING: UNATTENDED MODE (--auto-approve / agent.unattended)
+  Every tool call runs WITHOUT approval: files are written and deleted and
+  commands run with no human in the loop. Only use this in a disposable
+  container or CI job. Tool calls are recorded in the audit log:
+  %s
+################################################################################
+
+`
+
+// applyAgentUnattended turns on unattended mode for --auto-approve and checks
+// that an unattended run has an audit log and neither an approver nor --ci,
+// which blocks what needs approval instead. The flag is also
+// exported to the environment so agent subprocesses inherit it.
+func applyAgentUnattended(cmd *cobra.Command, cfg *config.Config) error {
+	if autoApprove, _ := cmd.Flags().GetBool("auto-approve"); autoApprove {
+		cfg.Agent.Unattended = true
+		if err := os.Setenv(agentUnattendedEnv, "true"); err != nil {
+			return fmt.Errorf("failed to export %s: %w", agentUnattendedEnv, err)
+		}
+	}
+	if !cfg.Agent.Unattended {
+		return nil
+	}
+	for _, flag := range []string{"require-approval", "ci"} {
+		if set, _ := cmd.Flags().GetBool(flag); set {
+			return fmt.Errorf("--auto-approve (agent.unattended) cannot be combined with --%s", flag)
+		}
+	}
+	if strings.TrimSpace(cfg.Agent.AuditLog) == "" {
+		return errAgentAuditLogRequired
+	}
+	return nil
+}
+
+// agentAuditEntry is one JSON line of the unattended audit log
+type agentAuditEntry struct {
+	Time      time.Time       `json:"time"`
+	Event     string          `json:"event"`
+	SessionID string          `json:"session_id,omitempty"`
+	Model     string          `json:"model,omitempty"`
+	Task      string          `json:"task,omitempty"`
+	Tool      string          `json:"tool,omitempty"`
+	CallID    string          `json:"tool_call_id,omitempty"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Success   *bool           `json:"success,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Duration  string          `json:"duration,omitempty"`
+}
+
+// agentAuditLog appends agentAuditEntry lines to agent.audit_log. Writes are
+// serialized, and each line is a single append, so parallel tool calls and
+// subagents sharing the file don't interleave.
+type agentAuditLog struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// openAgentAuditLog opens (creating it and its directory) the audit log at path
+func openAgentAuditLog(path string) (*agentAuditLog, error) {
+	path = expandAuditLogPath(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &agentAuditLog{w: f}, nil
+}
+
+func expandAuditLogPath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// record appends entry, stamping its time. Failures are logged, not returned:
+// the audit log must not be the reason a tool result is lost.
+func (a *agentAuditLog) record(entry agentAuditEntry) {
+	if a == nil {
+		return
+	}
+	entry.Time = time.Now().UTC()
+	line, err := json.Marshal(entry)
+	if err != nil {
+		logger.Error("failed to encode audit log entry", "error", err)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		logger.Error("failed to write audit log entry", "error", err)
+	}
+}
+
+// recordToolCall appends the outcome of one tool call
+func (a *agentAuditLog) recordToolCall(sessionID, tool, callID, args string, result *domain.ToolExecutionResult, err error) {
+	if a == nil {
+		return
+	}
+	entry := agentAuditEntry{
+		Event:     "tool_call",
+		SessionID: sessionID,
+		Tool:      tool,
+		CallID:    callID,
+	}
+	if json.Valid([]byte(args)) {
+		entry.Arguments = json.RawMessage(args)
+	}
+	success := err == nil && result != nil && result.Success
+	entry.Success = &success
+	switch {
+	case err != nil:
+		entry.Error = err.Error()
+	case result != nil:
+		entry.Error = result.Error
+		entry.Duration = result.Duration.String()
+	}
+	a.record(entry)
+}
+
+func (a *agentAuditLog) close() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_ = a.w.Close()
+}
+
+// startAgentUnattended opens the audit log and prints the warning for an
+// unattended run, or returns nil when the run is attended
+func startAgentUnattended(cfg *config.Config, stderr io.Writer) (*agentAuditLog, error) {
+	if !cfg.Agent.Unattended {
+		return nil, nil
+	}
+	if strings.TrimSpace(cfg.Agent.AuditLog) == "" {
+		return nil, errAgentAuditLogRequired
+	}
+	audit, err := openAgentAuditLog(cfg.Agent.AuditLog)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintf(stderr, agentUnattendedWarning, expandAuditLogPath(cfg.Agent.AuditLog))
+	logger.Warn("running unattended: tool approval is disabled", "audit_log", cfg.Agent.AuditLog)
+	return audit, nil
+}
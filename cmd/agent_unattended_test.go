@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func newUnattendedTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("auto-approve", false, "")
+	cmd.Flags().Bool("require-approval", false, "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestApplyAgentUnattended(t *testing.T) {
+	t.Setenv(agentUnattendedEnv, "")
+
+	cfg := config.DefaultConfig()
+	if err := applyAgentUnattended(newUnattendedTestCmd(t), cfg); err != nil || cfg.Agent.Unattended {
+		t.Fatalf("without the flag: err = %v, unattended = %v", err, cfg.Agent.Unattended)
+	}
+
+	if err := applyAgentUnattended(newUnattendedTestCmd(t, "--auto-approve"), cfg); !errors.Is(err, errAgentAuditLogRequired) {
+		t.Errorf("--auto-approve without agent.audit_log: err = %v, want errAgentAuditLogRequired", err)
+	}
+	if os.Getenv(agentUnattendedEnv) != "true" {
+		t.Errorf("--auto-approve should export %s for agent subprocesses", agentUnattendedEnv)
+	}
+
+	cfg.Agent.AuditLog = filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := applyAgentUnattended(newUnattendedTestCmd(t, "--auto-approve"), cfg); err != nil {
+		t.Errorf("--auto-approve with an audit log: err = %v", err)
+	}
+	if err := applyAgentUnattended(newUnattendedTestCmd(t, "--require-approval"), cfg); err == nil {
+		t.Error("agent.unattended with --require-approval should be rejected")
+	}
+}
+
+func TestAgentAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	cfg := config.DefaultConfig()
+	cfg.Agent.Unattended = true
+	cfg.Agent.AuditLog = path
+
+	var stderr bytes.Buffer
+	audit, err := startAgentUnattended(cfg, &stderr)
+	if err != nil {
+		t.Fatalf("startAgentUnattended: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "WARNING: UNATTENDED MODE") || !strings.Contains(stderr.String(), path) {
+		t.Errorf("warning banner = %q", stderr.String())
+	}
+
+	audit.record(agentAuditEntry{Event: "session_start", SessionID: "s1", Task: "fix the build"})
+	audit.recordToolCall("s1", "Bash", "call-1", `{"command":"go test ./..."}`,
+		&domain.ToolExecutionResult{Success: true, Duration: time.Second}, nil)
+	audit.recordToolCall("s1", "Write", "call-2", `not json`, nil, errors.New("denied"))
+	audit.close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	var entries []agentAuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry agentAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].Event != "session_start" || entries[0].Task != "fix the build" {
+		t.Errorf("session entry = %+v", entries[0])
+	}
+	if got := entries[1]; got.Tool != "Bash" || got.Success == nil || !*got.Success || string(got.Arguments) != `{"command":"go test ./..."}` {
+		t.Errorf("tool entry = %+v", got)
+	}
+	if got := entries[2]; got.Success == nil || *got.Success || got.Error != "denied" || got.Arguments != nil {
+		t.Errorf("failed tool entry = %+v", got)
+	}
+
+	var nilAudit *agentAuditLog
+	nilAudit.recordToolCall("s1", "Read", "call-3", "{}", nil, nil)
+	nilAudit.close()
+
+	cfg.Agent.Unattended = false
+	if audit, err := startAgentUnattended(cfg, &stderr); audit != nil || err != nil {
+		t.Errorf("attended run: audit = %v, err = %v", audit, err)
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	fsnotify "github.com/fsnotify/fsnotify"
+	uuid "github.com/google/uuid"
+
+	logger "github.com/inference-gateway/cli/internal/logger"
+	agentrunner "github.com/inference-gateway/cli/internal/services/agentrunner"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// agentWatchDebounce is how long the watcher waits for a burst of saves to
+// settle before running the agent
+const agentWatchDebounce = 500 * time.Millisecond
+
+// agentWatchSkipDirs are never watched, on top of hidden directories
+var agentWatchSkipDirs = map[string]bool{"node_modules": true, "vendor": true}
+
+// RunAgentWatchCommand watches the working directory and runs prompt as an
+// `infer agent` process whenever a file matching one of the globs changes.
+// Every run continues the same conversation, so the agent keeps the context
+// of earlier runs. It returns when interrupted.
+func RunAgentWatchCommand(globs []string, prompt, model string, files []string, sessionID string) error {
+	for _, glob := range globs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid --watch glob %q: %w", glob, err)
+		}
+	}
+
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	dirs, err := addAgentWatchDirs(watcher, root)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+	fmt.Fprintf(os.Stderr, "%s Watching %s in %d directories (session %s), press Ctrl+C to stop\n",
+		icons.BulletIcon, strings.Join(globs, ", "), dirs, sessionID)
+
+	run := func(changed []string) {
+		fmt.Fprintf(os.Stderr, "%s Changed: %s\n", icons.BulletIcon, strings.Join(changed, ", "))
+		start := time.Now()
+		_, err := agentrunner.Run(ctx, agentrunner.Options{
+			SessionID: sessionID,
+			Prompt:    agentWatchPrompt(prompt, changed),
+			Model:     model,
+			Files:     files,
+			OnLine:    func(line []byte) { fmt.Println(string(line)) },
+		})
+		switch {
+		case ctx.Err() != nil:
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "%s Agent run failed: %v\n", icons.CrossMark, err)
+		default:
+			fmt.Fprintf(os.Stderr, "%s Agent run finished (%s), watching for changes\n", icons.CheckMark, time.Since(start).Round(time.Second))
+		}
+	}
+
+	addDir := func(dir string) {
+		if _, err := addAgentWatchDirs(watcher, dir); err != nil {
+			logger.Debug("agent watch failed to add directory", "dir", dir, "error", err)
+		}
+	}
+
+	watchAgentTriggers(ctx, watcher.Events, watcher.Errors, root, globs, agentWatchDebounce, addDir, run)
+	return nil
+}
+
+// addAgentWatchDirs watches dir and every directory below it, skipping hidden
+// and dependency directories, and returns how many it added. fsnotify does not
+// watch recursively.
+func addAgentWatchDirs(watcher *fsnotify.Watcher, dir string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != dir && skipAgentWatchDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(p); err != nil {
+			return fmt.Errorf("failed to watch directory %s: %w", p, err)
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+func skipAgentWatchDir(name string) bool {
+	return strings.HasPrefix(name, ".") || agentWatchSkipDirs[name]
+}
+
+// matchAgentWatchGlob reports whether rel, a slash-separated path relative to
+// the watched root, matches one of the globs. A glob without a slash matches
+// the file name in any directory, and a leading "**/" means the same.
+func matchAgentWatchGlob(globs []string, rel string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, glob := range globs {
+		glob = strings.TrimPrefix(glob, "**/")
+		target := rel
+		if !strings.Contains(glob, "/") {
+			target = path.Base(rel)
+		}
+		if ok, _ := path.Match(glob, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// agentWatchPrompt appends the changed files to the fixed prompt so the agent
+// knows what triggered the run
+func agentWatchPrompt(prompt string, changed []string) string {
+	var sb strings.Builder
+	sb.WriteString(prompt)
+	sb.WriteString("\n\nChanged files:\n")
+	for _, file := range changed {
+		sb.WriteString("- " + file + "\n")
+	}
+	return sb.String()
+}
+
+// watchAgentTriggers collects matching file events until they have been
+// quiet for debounce, then calls run with the changed paths. Runs never
+// overlap, and events in the first debounce after a run are dropped so the
+// agent's own edits do not trigger it again.
+func watchAgentTriggers(ctx context.Context, events <-chan fsnotify.Event, errs <-chan error, root string, globs []string, debounce time.Duration, addDir func(string), run func([]string)) {
+	pending := map[string]bool{}
+	var settle <-chan time.Time
+	var ignoreUntil time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			logger.Debug("agent watch file watcher error", "error", err)
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if ev.Op.Has(fsnotify.Create) {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					if !skipAgentWatchDir(info.Name()) {
+						addDir(ev.Name)
+					}
+					continue
+				}
+			}
+			if !ev.Op.Has(fsnotify.Write) && !ev.Op.Has(fsnotify.Create) && !ev.Op.Has(fsnotify.Rename) {
+				continue
+			}
+			if time.Now().Before(ignoreUntil) {
+				continue
+			}
+			rel, err := filepath.Rel(root, ev.Name)
+			if err != nil || !matchAgentWatchGlob(globs, rel) {
+				continue
+			}
+			pending[filepath.ToSlash(rel)] = true
+			settle = time.After(debounce)
+		case <-settle:
+			settle = nil
+			changed := sortedKeys(pending)
+			pending = map[string]bool{}
+			run(changed)
+			ignoreUntil = time.Now().Add(debounce)
+		}
+	}
+}
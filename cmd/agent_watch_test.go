@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	fsnotify "github.com/fsnotify/fsnotify"
+)
+
+func TestMatchAgentWatchGlob(t *testing.T) {
+	tests := []struct {
+		globs []string
+		rel   string
+		want  bool
+	}{
+		{[]string{"*.go"}, "internal/cmd/agent.go", true},
+		{[]string{"*.go"}, "README.md", false},
+		{[]string{"**/*_test.go"}, "cmd/agent_test.go", true},
+		{[]string{"cmd/*.go"}, "cmd/agent.go", true},
+		{[]string{"cmd/*.go"}, "internal/cmd/agent.go", false},
+		{[]string{"*.md", "*.go"}, "main.go", true},
+	}
+	for _, tt := range tests {
+		if got := matchAgentWatchGlob(tt.globs, tt.rel); got != tt.want {
+			t.Errorf("matchAgentWatchGlob(%v, %q) = %t, want %t", tt.globs, tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestAgentWatchPrompt(t *testing.T) {
+	got := agentWatchPrompt("fix the failing test", []string{"a.go", "b.go"})
+	if got != "fix the failing test\n\nChanged files:\n- a.go\n- b.go\n" {
+		t.Errorf("unexpected prompt %q", got)
+	}
+}
+
+func TestWatchAgentTriggers(t *testing.T) {
+	root := t.TempDir()
+	events := make(chan fsnotify.Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan []string, 4)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchAgentTriggers(ctx, events, nil, root, []string{"*.go"}, 50*time.Millisecond, func(string) {}, func(changed []string) {
+			runs <- changed
+		})
+	}()
+
+	send := func(name string, op fsnotify.Op) {
+		events <- fsnotify.Event{Name: filepath.Join(root, name), Op: op}
+	}
+	send("a.go", fsnotify.Write)
+	send("pkg/b.go", fsnotify.Create)
+	send("a.go", fsnotify.Write)
+	send("notes.md", fsnotify.Write)
+	send("c.go", fsnotify.Chmod)
+
+	select {
+	case changed := <-runs:
+		if strings.Join(changed, ",") != "a.go,pkg/b.go" {
+			t.Errorf("got changed files %v, want one run for a.go and pkg/b.go", changed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the agent never ran")
+	}
+
+	send("a.go", fsnotify.Write)
+	select {
+	case changed := <-runs:
+		t.Errorf("an edit right after a run must not trigger another, got %v", changed)
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	cancel()
+	<-done
+}
@@ -648,6 +648,9 @@ func init() {
 	agentsUpdateCmd.Flags().Bool("run", false, "Run this agent locally with Docker")
 	agentsUpdateCmd.Flags().String("model", "", "Model to use for the agent (format: provider/model)")
 	agentsUpdateCmd.Flags().StringSlice("environment", []string{}, "Environment variables (KEY=VALUE)")
+	for _, cmd := range []*cobra.Command{agentsAddCmd, agentsUpdateCmd} {
+		_ = cmd.RegisterFlagCompletionFunc("model", completeModels)
+	}
 
 	agentsListCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
 	agentsShowCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
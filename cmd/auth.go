@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	huh "charm.land/huh/v2"
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+
+	config "github.com/inference-gateway/cli/config"
+	configutils "github.com/inference-gateway/cli/config/utils"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// keyringIndexFileName lists, without their values, the credentials stored
+// in the OS keyring, so startup only queries the keyring for those
+const keyringIndexFileName = "keyring.yaml"
+
+var envVarNamePattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// authCredential is a secret infer auth can keep in the keyring. It is
+// stored under, and resolved into, the environment variable the rest of the
+// CLI and the gateway already read.
+type authCredential struct {
+	name      string
+	envVar    string
+	configKey string
+}
+
+// keyringIndex is the content of keyring.yaml
+type keyringIndex struct {
+	Accounts []string `yaml:"accounts"`
+}
+
+// keyringLoaded records the credentials initConfig read from the keyring,
+// and keyringLoadErrs the ones it could not read
+var (
+	keyringLoaded   = map[string]bool{}
+	keyringLoadErrs = map[string]error{}
+)
+
+// authCredentials lists the known credentials: the gateway API key, the
+// GitHub token, the RunPod key and every provider key of infer env
+func authCredentials() []authCredential {
+	credentials := []authCredential{
+		{name: "gateway", envVar: "INFER_GATEWAY_API_KEY", configKey: "gateway.api_key"},
+		{name: "github", envVar: "GITHUB_TOKEN"},
+		{name: "runpod", envVar: "INFER_PROVISIONER_RUNPOD_API_KEY", configKey: "provisioner.runpod.api_key"},
+	}
+	for _, envVar := range envVars {
+		if prefix, ok := strings.CutSuffix(envVar, "_API_KEY"); ok {
+			name := strings.ReplaceAll(strings.ToLower(prefix), "_", "-")
+			credentials = append(credentials, authCredential{name: name, envVar: envVar})
+		}
+	}
+	return credentials
+}
+
+// resolveAuthCredential maps a credential name, or any environment variable
+// name such as TELEGRAM_BOT_TOKEN, to the credential to store
+func resolveAuthCredential(name string) (authCredential, error) {
+	for _, c := range authCredentials() {
+		if strings.EqualFold(c.name, name) || c.envVar == name {
+			return c, nil
+		}
+	}
+	if envVarNamePattern.MatchString(name) {
+		return authCredential{name: name, envVar: name}, nil
+	}
+	return authCredential{}, fmt.Errorf("unknown credential %q: use one of %s, or an environment variable name", name, strings.Join(authCredentialNames(), ", "))
+}
+
+func authCredentialNames() []string {
+	var names []string
+	for _, c := range authCredentials() {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Store API keys and tokens in the OS keyring",
+	Long: `Store the gateway API key, the GitHub token and provider API keys in the
+OS keyring - the macOS Keychain, the Secret Service (secret-tool) on Linux or
+DPAPI on Windows - instead of in plaintext config files or .env.
+
+Each secret is stored under the environment variable it stands for and read
+at startup, so config files, ${VAR} references and the gateway pick it up
+unchanged. It is not set into the environment, so commands the agent runs
+cannot read it. A variable already set in the environment takes precedence
+over the keyring.
+
+Known credentials: ` + strings.Join(authCredentialNames(), ", ") + `.
+Any other environment variable name, e.g. TELEGRAM_BOT_TOKEN, works too.`,
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login <name>",
+	Short: "Store a credential in the OS keyring",
+	Long: `Store a credential in the OS keyring. The secret is prompted for without
+echo, or read from stdin when it is not a terminal.
+
+Examples:
+  infer auth login gateway
+  infer auth login anthropic
+  gh auth token | infer auth login github`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthLogin(keyring.New(), keyringIndexPath(), args[0])
+	},
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout [name]",
+	Short: "Remove a credential from the OS keyring",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		if all == (len(args) == 1) {
+			return fmt.Errorf("pass a credential name or --all")
+		}
+		return runAuthLogout(keyring.New(), keyringIndexPath(), args, all)
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show where each credential comes from",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthStatus(keyring.New(), keyringIndexPath())
+	},
+}
+
+func runAuthLogin(store keyring.Store, indexPath, name string) error {
+	credential, err := resolveAuthCredential(name)
+	if err != nil {
+		return err
+	}
+	secret, err := readAuthSecret(credential)
+	if err != nil {
+		return err
+	}
+
+	if err := store.Set(credential.envVar, secret); err != nil {
+		return fmt.Errorf("failed to store %s in the %s: %w", credential.name, store.Name(), err)
+	}
+	index, err := loadKeyringIndex(indexPath)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(index.Accounts, credential.envVar) {
+		index.Accounts = append(index.Accounts, credential.envVar)
+		if err := configutils.SaveYAML(indexPath, "keyring", index); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("%s Stored %s (%s) in the %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), credential.name, credential.envVar, store.Name())
+	if os.Getenv(credential.envVar) != "" {
+		fmt.Printf("%s is also set in the environment, which takes precedence - unset it to use the keyring\n", credential.envVar)
+	}
+	if credential.configKey != "" {
+		fmt.Printf("Remove any plaintext %s from your config files: infer config unset %s\n", credential.configKey, credential.configKey)
+	}
+	return nil
+}
+
+// readAuthSecret prompts for the secret on a terminal and reads stdin otherwise
+func readAuthSecret(credential authCredential) (string, error) {
+	var secret string
+	if isInteractiveTerminal() {
+		if err := huh.NewInput().
+			Title(fmt.Sprintf("Secret for %s (%s)", credential.name, credential.envVar)).
+			EchoMode(huh.EchoModePassword).
+			Value(&secret).Run(); err != nil {
+			return "", err
+		}
+	} else {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the secret from stdin: %w", err)
+		}
+		secret = string(data)
+	}
+
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("no secret given for %s", credential.name)
+	}
+	return secret, nil
+}
+
+func runAuthLogout(store keyring.Store, indexPath string, args []string, all bool) error {
+	index, err := loadKeyringIndex(indexPath)
+	if err != nil {
+		return err
+	}
+
+	accounts := index.Accounts
+	if !all {
+		credential, err := resolveAuthCredential(args[0])
+		if err != nil {
+			return err
+		}
+		accounts = []string{credential.envVar}
+	}
+	if len(accounts) == 0 {
+		fmt.Println("No credentials stored in the keyring.")
+		return nil
+	}
+
+	var (
+		errs    []error
+		removed []string
+	)
+	for _, account := range accounts {
+		err := store.Delete(account)
+		switch {
+		case errors.Is(err, keyring.ErrNotFound):
+			if !all {
+				errs = append(errs, fmt.Errorf("%s is not stored in the %s", account, store.Name()))
+			}
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", account, err))
+			continue
+		default:
+			fmt.Printf("%s Removed %s from the %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), account, store.Name())
+		}
+		removed = append(removed, account)
+	}
+
+	index.Accounts = slices.DeleteFunc(slices.Clone(index.Accounts), func(a string) bool { return slices.Contains(removed, a) })
+	if err := configutils.SaveYAML(indexPath, "keyring", index); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func runAuthStatus(store keyring.Store, indexPath string) error {
+	index, err := loadKeyringIndex(indexPath)
+	if err != nil {
+		return err
+	}
+
+	credentials := authCredentials()
+	for _, account := range index.Accounts {
+		if !slices.ContainsFunc(credentials, func(c authCredential) bool { return c.envVar == account }) {
+			credentials = append(credentials, authCredential{name: account, envVar: account})
+		}
+	}
+
+	fmt.Println(listTitle("Credentials"))
+	fmt.Println()
+	fmt.Println(listField("Keyring", store.Name()))
+	fmt.Println(listField("Index", indexPath))
+	fmt.Println()
+
+	credentialsTable := newListTable("Name", "Environment Variable", "Source")
+	rows := 0
+	for _, c := range credentials {
+		source := authCredentialSource(c, slices.Contains(index.Accounts, c.envVar))
+		if source == "" {
+			continue
+		}
+		credentialsTable.Row(c.name, c.envVar, source)
+		rows++
+	}
+	if rows == 0 {
+		fmt.Println("No credentials set.")
+	} else {
+		fmt.Println(credentialsTable.Render())
+	}
+	fmt.Println()
+	fmt.Println(listHint("Store one with: infer auth login <name>"))
+	return nil
+}
+
+// authCredentialSource describes where the value of c comes from, or "" when
+// it is not set anywhere
+func authCredentialSource(c authCredential, stored bool) string {
+	switch {
+	case keyringLoaded[c.envVar]:
+		return "keyring"
+	case stored && os.Getenv(c.envVar) != "":
+		return "environment (overrides keyring)"
+	case stored:
+		return fmt.Sprintf("keyring (unreadable: %v)", keyringLoadErrs[c.envVar])
+	case os.Getenv(c.envVar) != "":
+		return "environment"
+	case c.configKey != "" && V != nil && V.GetString(c.configKey) != "":
+		return "config file (plaintext)"
+	}
+	return ""
+}
+
+// keyringIndexPath returns ~/.infer/keyring.yaml, or the project .infer/
+// when the home directory is unknown
+func keyringIndexPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(config.ConfigDirName, keyringIndexFileName)
+	}
+	return filepath.Join(homeDir, config.ConfigDirName, keyringIndexFileName)
+}
+
+func loadKeyringIndex(path string) (*keyringIndex, error) {
+	return configutils.LoadYAML(path, "keyring", func() *keyringIndex { return &keyringIndex{} })
+}
+
+// loadKeyringCredentials reads every credential stored in the keyring that
+// the environment does not already set. It runs before the config is read so
+// ${VAR} references resolve them. The secrets are handed to
+// keyring.SetSecrets rather than set into the environment, which the Bash
+// tool and every other subprocess would inherit.
+func loadKeyringCredentials(store keyring.Store, indexPath string) map[string]string {
+	loaded := map[string]string{}
+	defer keyring.SetSecrets(loaded)
+
+	index, err := loadKeyringIndex(indexPath)
+	if err != nil || len(index.Accounts) == 0 {
+		return loaded
+	}
+	for _, account := range index.Accounts {
+		if os.Getenv(account) != "" {
+			continue
+		}
+		secret, err := store.Get(account)
+		if err != nil {
+			keyringLoadErrs[account] = err
+			fmt.Fprintf(os.Stderr, "Warning: failed to read %s from the %s: %v\n", account, store.Name(), err)
+			continue
+		}
+		loaded[account] = secret
+		keyringLoaded[account] = true
+	}
+	return loaded
+}
+
+// applyKeyringConfig sets the config keys that loaded INFER_* credentials
+// stand for, as AutomaticEnv does for the same variables in the environment
+func applyKeyringConfig(v *viper.Viper, loaded map[string]string) {
+	if len(loaded) == 0 {
+		return
+	}
+	for _, key := range v.AllKeys() {
+		envVar := "INFER_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		if secret, ok := loaded[envVar]; ok {
+			v.Set(key, secret)
+		}
+	}
+}
+
+// skipKeyring reports whether the command being run has no use for
+// credentials: shell completion and help, which run on every tab press or
+// typo and must not shell out to the keyring or prompt to unlock it
+func skipKeyring() bool {
+	args := os.Args[1:]
+	if slices.Contains(args, "-h") || slices.Contains(args, "--help") {
+		return true
+	}
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "help", "completion":
+		return true
+	}
+	return false
+}
+
+func init() {
+	authLogoutCmd.Flags().Bool("all", false, "Remove every credential infer stored in the keyring")
+
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authStatusCmd)
+	rootCmd.AddCommand(authCmd)
+}
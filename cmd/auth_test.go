@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+
+	configutils "github.com/inference-gateway/cli/config/utils"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
+)
+
+// fakeKeyring is an in-memory keyring.Store
+type fakeKeyring map[string]string
+
+func (f fakeKeyring) Name() string { return "test keyring" }
+
+func (f fakeKeyring) Set(account, secret string) error {
+	f[account] = secret
+	return nil
+}
+
+func (f fakeKeyring) Get(account string) (string, error) {
+	if secret, ok := f[account]; ok {
+		return secret, nil
+	}
+	return "", keyring.ErrNotFound
+}
+
+func (f fakeKeyring) Delete(account string) error {
+	if _, ok := f[account]; !ok {
+		return keyring.ErrNotFound
+	}
+	delete(f, account)
+	return nil
+}
+
+func TestResolveAuthCredential(t *testing.T) {
+	tests := []struct {
+		name, envVar string
+	}{
+		{"gateway", "INFER_GATEWAY_API_KEY"},
+		{"GitHub", "GITHUB_TOKEN"},
+		{"ollama-cloud", "OLLAMA_CLOUD_API_KEY"},
+		{"OPENAI_API_KEY", "OPENAI_API_KEY"},
+		{"TELEGRAM_BOT_TOKEN", "TELEGRAM_BOT_TOKEN"},
+	}
+	for _, tt := range tests {
+		c, err := resolveAuthCredential(tt.name)
+		if err != nil || c.envVar != tt.envVar {
+			t.Errorf("resolveAuthCredential(%q) = %+v, %v; want %s", tt.name, c, err, tt.envVar)
+		}
+	}
+	if _, err := resolveAuthCredential("not-a-credential"); err == nil {
+		t.Error("an unknown lower-case name must be an error")
+	}
+}
+
+func TestLoadKeyringCredentials(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "keyring.yaml")
+	if err := configutils.SaveYAML(indexPath, "keyring", &keyringIndex{Accounts: []string{"INFER_TEST_KEY", "INFER_TEST_SET", "INFER_TEST_MISSING"}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("INFER_TEST_KEY", "")
+	t.Setenv("INFER_TEST_SET", "from-env")
+	t.Setenv("INFER_TEST_MISSING", "")
+	keyringLoaded, keyringLoadErrs = map[string]bool{}, map[string]error{}
+	t.Cleanup(func() { keyringLoaded, keyringLoadErrs = map[string]bool{}, map[string]error{} })
+
+	t.Cleanup(func() { keyring.SetSecrets(nil) })
+
+	loaded := loadKeyringCredentials(fakeKeyring{"INFER_TEST_KEY": "from-keyring", "INFER_TEST_SET": "ignored"}, indexPath)
+
+	if got := keyring.Getenv("INFER_TEST_KEY"); got != "from-keyring" || !keyringLoaded["INFER_TEST_KEY"] || loaded["INFER_TEST_KEY"] != "from-keyring" {
+		t.Errorf("INFER_TEST_KEY = %q, loaded %v", got, keyringLoaded["INFER_TEST_KEY"])
+	}
+	if got := os.Getenv("INFER_TEST_KEY"); got != "" {
+		t.Errorf("a keyring secret must not be set into the environment subprocesses inherit, got %q", got)
+	}
+	if got := keyring.Getenv("INFER_TEST_SET"); got != "from-env" {
+		t.Errorf("the environment must take precedence, got %q", got)
+	}
+	if keyringLoadErrs["INFER_TEST_MISSING"] == nil {
+		t.Error("a missing secret must be recorded as a load error")
+	}
+	if got := authCredentialSource(authCredential{envVar: "INFER_TEST_SET"}, true); got != "environment (overrides keyring)" {
+		t.Errorf("unexpected source %q", got)
+	}
+}
+
+func TestApplyKeyringConfig(t *testing.T) {
+	v := viper.New()
+	v.SetDefault("gateway.api_key", "")
+	v.SetDefault("gateway.url", "http://localhost:8080")
+
+	applyKeyringConfig(v, map[string]string{"INFER_GATEWAY_API_KEY": "from-keyring", "GITHUB_TOKEN": "token"})
+
+	if got := v.GetString("gateway.api_key"); got != "from-keyring" {
+		t.Errorf("gateway.api_key = %q, want the keyring secret", got)
+	}
+	if got := v.GetString("gateway.url"); got != "http://localhost:8080" {
+		t.Errorf("unrelated keys must be left alone, got %q", got)
+	}
+}
+
+func TestSkipKeyring(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"infer", cobra.ShellCompRequestCmd, "chat", ""}, true},
+		{[]string{"infer", "completion", "bash"}, true},
+		{[]string{"infer", "help", "chat"}, true},
+		{[]string{"infer", "chat", "--help"}, true},
+		{[]string{"infer", "version"}, false},
+		{[]string{"infer", "auth", "status"}, false},
+	}
+	args := os.Args
+	t.Cleanup(func() { os.Args = args })
+	for _, tt := range tests {
+		os.Args = tt.args
+		if got := skipKeyring(); got != tt.want {
+			t.Errorf("skipKeyring() for %v = %v, want %v", tt.args[1:], got, tt.want)
+		}
+	}
+}
+
+func TestRunAuthLogout(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "keyring.yaml")
+	store := fakeKeyring{"GITHUB_TOKEN": "a", "OPENAI_API_KEY": "b"}
+	if err := configutils.SaveYAML(indexPath, "keyring", &keyringIndex{Accounts: []string{"GITHUB_TOKEN", "OPENAI_API_KEY", "STALE_TOKEN"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runAuthLogout(store, indexPath, []string{"github"}, false); err != nil {
+		t.Fatalf("logout github: %v", err)
+	}
+	if err := runAuthLogout(store, indexPath, []string{"github"}, false); err == nil {
+		t.Error("logging out a credential that is not stored must fail")
+	}
+	if err := runAuthLogout(store, indexPath, nil, true); err != nil {
+		t.Fatalf("logout --all: %v", err)
+	}
+
+	index, err := loadKeyringIndex(indexPath)
+	if err != nil || len(index.Accounts) != 0 || len(store) != 0 {
+		t.Errorf("expected an empty keyring and index, got %v and %v (%v)", store, index.Accounts, err)
+	}
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	container "github.com/inference-gateway/cli/internal/container"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+)
+
+// benchmarkJudgePrompt asks the judge model for a single 1-10 score
+const benchmarkJudgePrompt = `You grade answers to prompts. Rate the response below from 1 (useless or wrong) to 10 (correct, complete and concise). Reply with the number only.
+
+Prompt:
+%s
+
+Response:
+%s`
+
+var benchmarkScorePattern = regexp.MustCompile(`\b(10|[1-9])\b`)
+
+var benchmarkCmd = &cobra.Command{
+	Use:   "benchmark",
+	Short: "Compare models on a set of prompts",
+	Long: `Run the same prompts against several models through the gateway and
+compare their latency, token usage and cost. With --judge, a judge model also
+scores every response from 1 to 10. Useful when choosing agent.model.
+
+Prompts come from --prompt (repeatable) or --prompts-file, a text file with
+prompts separated by lines containing only "---". Requests run one at a time
+so latencies are comparable; tools and MCP are not used.
+
+Examples:
+  infer benchmark -m openai/gpt-4o,anthropic/claude-sonnet-4 -p "Write a Go function that reverses a string"
+  infer benchmark -m deepseek/deepseek-chat,openai/gpt-4o-mini --prompts-file prompts.txt --judge openai/gpt-4o
+  infer benchmark -m openai/gpt-4o -p "Explain CAP" --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		models, _ := cmd.Flags().GetStringSlice("models")
+		prompts, _ := cmd.Flags().GetStringArray("prompt")
+		promptsFile, _ := cmd.Flags().GetString("prompts-file")
+		judge, _ := cmd.Flags().GetString("judge")
+		maxTokens, _ := cmd.Flags().GetInt("max-tokens")
+		format, _ := cmd.Flags().GetString("format")
+
+		if promptsFile != "" {
+			data, err := os.ReadFile(promptsFile)
+			if err != nil {
+				return fmt.Errorf("failed to read prompts file: %w", err)
+			}
+			prompts = append(prompts, splitBenchmarkPrompts(string(data))...)
+		}
+		return RunBenchmarkCommand(Cfg, models, prompts, judge, maxTokens, format)
+	},
+}
+
+// benchmarkRun is one prompt sent to one model
+type benchmarkRun struct {
+	Model        string   `json:"model"`
+	Prompt       int      `json:"prompt"`
+	LatencyMs    int64    `json:"latency_ms"`
+	InputTokens  int      `json:"input_tokens"`
+	OutputTokens int      `json:"output_tokens"`
+	Cost         float64  `json:"cost"`
+	Score        *float64 `json:"score,omitempty"`
+	Response     string   `json:"response,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// benchmarkSummary totals the runs of one model. Averages only count the
+// runs that succeeded.
+type benchmarkSummary struct {
+	Model        string   `json:"model"`
+	Runs         int      `json:"runs"`
+	Failed       int      `json:"failed"`
+	AvgLatencyMs int64    `json:"avg_latency_ms"`
+	InputTokens  int      `json:"input_tokens"`
+	OutputTokens int      `json:"output_tokens"`
+	Cost         float64  `json:"cost"`
+	AvgScore     *float64 `json:"avg_score,omitempty"`
+}
+
+// RunBenchmarkCommand runs every prompt against every model and prints the
+// comparison
+func RunBenchmarkCommand(cfg *config.Config, models, prompts []string, judge string, maxTokens int, format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q: must be \"text\" or \"json\"", format)
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models to compare: pass --models provider/model,...")
+	}
+	if len(prompts) == 0 {
+		return fmt.Errorf("no prompts: pass --prompt or --prompts-file")
+	}
+	for _, model := range append([]string{judge}, models...) {
+		if model != "" && !strings.Contains(model, "/") {
+			return fmt.Errorf("invalid model %q, expected 'provider/model'", model)
+		}
+	}
+
+	svc := container.NewServiceContainer(cfg)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = svc.Shutdown(ctx)
+	}()
+
+	if err := svc.GetGatewayManager().EnsureStarted(); err != nil {
+		return fmt.Errorf("failed to start inference gateway: %w", err)
+	}
+
+	progress := io.Writer(os.Stderr)
+	if format == "json" {
+		progress = io.Discard
+	}
+	runs := runBenchmark(context.Background(), svc.NewSDKClient(), svc.GetPricingService(), models, prompts, judge, maxTokens, progress)
+	summaries := summarizeBenchmark(runs, models)
+
+	if format == "json" {
+		out, err := json.MarshalIndent(map[string]any{"models": summaries, "runs": runs, "judge": judge}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal benchmark to JSON: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Print(renderBenchmarkTable(summaries, len(prompts), judge))
+	return nil
+}
+
+// splitBenchmarkPrompts splits a prompts file on lines containing only "---"
+func splitBenchmarkPrompts(data string) []string {
+	var prompts, lines []string
+	flush := func() {
+		if prompt := strings.TrimSpace(strings.Join(lines, "\n")); prompt != "" {
+			prompts = append(prompts, prompt)
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "---" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return prompts
+}
+
+// runBenchmark sends each prompt to each model in turn, scoring the responses
+// with judge when it is set. Failures are recorded on the run, not returned.
+func runBenchmark(ctx context.Context, client sdk.Client, pricing domain.PricingService, models, prompts []string, judge string, maxTokens int, progress io.Writer) []benchmarkRun {
+	var runs []benchmarkRun
+	for _, model := range models {
+		for i, prompt := range prompts {
+			_, _ = fmt.Fprintf(progress, "%s: prompt %d/%d\n", model, i+1, len(prompts))
+
+			run := benchmarkRun{Model: model, Prompt: i + 1}
+			start := time.Now()
+			response, err := benchmarkGenerate(ctx, client, model, prompt, maxTokens)
+			run.LatencyMs = time.Since(start).Milliseconds()
+			if err != nil {
+				run.Error = err.Error()
+				runs = append(runs, run)
+				continue
+			}
+
+			run.Response = response.text
+			run.InputTokens, run.OutputTokens = response.inputTokens, response.outputTokens
+			if pricing != nil {
+				_, _, run.Cost = pricing.CalculateCost(model, response.inputTokens, response.outputTokens, response.cachedTokens)
+			}
+
+			if judge != "" {
+				score, err := judgeBenchmarkResponse(ctx, client, judge, prompt, response.text)
+				if err != nil {
+					_, _ = fmt.Fprintf(progress, "%s: judging prompt %d failed: %v\n", model, i+1, err)
+				} else {
+					run.Score = &score
+				}
+			}
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+type benchmarkResponse struct {
+	text         string
+	inputTokens  int
+	outputTokens int
+	cachedTokens int
+}
+
+func benchmarkGenerate(ctx context.Context, client sdk.Client, model, prompt string, maxTokens int) (benchmarkResponse, error) {
+	provider, modelName, _ := strings.Cut(model, "/")
+	messages := []sdk.Message{{Role: sdk.User, Content: sdk.NewMessageContent(prompt)}}
+
+	request := &sdk.CreateChatCompletionRequest{}
+	if maxTokens > 0 {
+		request.MaxTokens = &maxTokens
+	}
+	response, err := client.
+		WithOptions(request).
+		WithMiddlewareOptions(&sdk.MiddlewareOptions{SkipMCP: true}).
+		GenerateContent(ctx, sdk.Provider(provider), modelName, messages)
+	if err != nil {
+		return benchmarkResponse{}, err
+	}
+	if len(response.Choices) == 0 {
+		return benchmarkResponse{}, fmt.Errorf("no response from %s", model)
+	}
+
+	text, _ := response.Choices[0].Message.Content.AsMessageContent0()
+	result := benchmarkResponse{text: strings.TrimSpace(text)}
+	if usage := response.Usage; usage != nil {
+		result.inputTokens, result.outputTokens = int(usage.PromptTokens), int(usage.CompletionTokens)
+		if details := usage.PromptTokensDetails; details != nil && details.CachedTokens != nil {
+			result.cachedTokens = int(*details.CachedTokens)
+		}
+	}
+	return result, nil
+}
+
+func judgeBenchmarkResponse(ctx context.Context, client sdk.Client, judge, prompt, response string) (float64, error) {
+	verdict, err := benchmarkGenerate(ctx, client, judge, fmt.Sprintf(benchmarkJudgePrompt, prompt, response), 10)
+	if err != nil {
+		return 0, err
+	}
+	match := benchmarkScorePattern.FindString(verdict.text)
+	if match == "" {
+		return 0, fmt.Errorf("no score in judge reply %q", formatting.TruncateText(verdict.text, 40))
+	}
+	score, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, err
+	}
+	return score, nil
+}
+
+// summarizeBenchmark totals the runs per model, in the order models were given
+func summarizeBenchmark(runs []benchmarkRun, models []string) []benchmarkSummary {
+	summaries := make([]benchmarkSummary, 0, len(models))
+	for _, model := range models {
+		summary := benchmarkSummary{Model: model}
+		var latency int64
+		var scoreTotal float64
+		scored := 0
+		for _, run := range runs {
+			if run.Model != model {
+				continue
+			}
+			summary.Runs++
+			if run.Error != "" {
+				summary.Failed++
+				continue
+			}
+			latency += run.LatencyMs
+			summary.InputTokens += run.InputTokens
+			summary.OutputTokens += run.OutputTokens
+			summary.Cost += run.Cost
+			if run.Score != nil {
+				scoreTotal += *run.Score
+				scored++
+			}
+		}
+		if succeeded := summary.Runs - summary.Failed; succeeded > 0 {
+			summary.AvgLatencyMs = latency / int64(succeeded)
+		}
+		if scored > 0 {
+			avg := scoreTotal / float64(scored)
+			summary.AvgScore = &avg
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+func renderBenchmarkTable(summaries []benchmarkSummary, prompts int, judge string) string {
+	var sb strings.Builder
+	sb.WriteString(listTitle(fmt.Sprintf("Benchmark (%d models, %d prompts)", len(summaries), prompts)) + "\n\n")
+
+	headers := []string{"Model", "OK", "Avg latency", "Input", "Output", "Cost"}
+	if judge != "" {
+		headers = append(headers, "Score")
+	}
+	t := newListTable(headers...)
+	for _, s := range summaries {
+		latency := "-"
+		if s.Runs > s.Failed {
+			latency = (time.Duration(s.AvgLatencyMs) * time.Millisecond).Round(10 * time.Millisecond).String()
+		}
+		row := []string{
+			s.Model,
+			fmt.Sprintf("%d/%d", s.Runs-s.Failed, s.Runs),
+			latency,
+			strconv.Itoa(s.InputTokens),
+			strconv.Itoa(s.OutputTokens),
+			formatting.FormatCost(s.Cost),
+		}
+		if judge != "" {
+			score := "-"
+			if s.AvgScore != nil {
+				score = fmt.Sprintf("%.1f", *s.AvgScore)
+			}
+			row = append(row, score)
+		}
+		t.Row(row...)
+	}
+	sb.WriteString(t.Render() + "\n\n")
+
+	if judge != "" {
+		sb.WriteString(listField("Judge", judge) + "\n")
+	}
+	sb.WriteString(listHint("Use --format json for every response and error. Set the default with: infer config set agent.model <model>") + "\n")
+	return sb.String()
+}
+
+func init() {
+	benchmarkCmd.Flags().StringSliceP("models", "m", nil, "Models to compare (provider/model, comma separated)")
+	benchmarkCmd.Flags().StringArrayP("prompt", "p", nil, "Prompt to send to every model (repeatable)")
+	benchmarkCmd.Flags().String("prompts-file", "", "File of prompts separated by lines containing only ---")
+	benchmarkCmd.Flags().String("judge", "", "Model that scores each response from 1 to 10")
+	benchmarkCmd.Flags().Int("max-tokens", 1024, "Maximum output tokens per response (0 for the model default)")
+	benchmarkCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+	rootCmd.AddCommand(benchmarkCmd)
+}
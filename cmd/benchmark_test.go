@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	services "github.com/inference-gateway/cli/internal/services"
+	sdkmocks "github.com/inference-gateway/cli/tests/mocks/sdk"
+)
+
+func TestSplitBenchmarkPrompts(t *testing.T) {
+	got := splitBenchmarkPrompts("Reverse a string\nin Go\n---\n\n---\r\nExplain CAP\n")
+	if len(got) != 2 || got[0] != "Reverse a string\nin Go" || got[1] != "Explain CAP" {
+		t.Errorf("unexpected prompts %q", got)
+	}
+}
+
+func TestRunBenchmark(t *testing.T) {
+	client := &sdkmocks.FakeClient{}
+	client.WithOptionsReturns(client)
+	client.WithMiddlewareOptionsReturns(client)
+	client.GenerateContentCalls(func(_ context.Context, provider sdk.Provider, model string, messages []sdk.Message) (*sdk.CreateChatCompletionResponse, error) {
+		switch {
+		case provider == "judge":
+			return &sdk.CreateChatCompletionResponse{Choices: []sdk.ChatCompletionChoice{
+				{Message: sdk.Message{Content: sdk.NewMessageContent("Score: 8")}},
+			}}, nil
+		case model == "broken":
+			return nil, errors.New("model not found")
+		}
+		return &sdk.CreateChatCompletionResponse{
+			Choices: []sdk.ChatCompletionChoice{{Message: sdk.Message{Content: sdk.NewMessageContent("answer")}}},
+			Usage:   &sdk.CompletionUsage{PromptTokens: 1_000_000, CompletionTokens: 100_000},
+		}, nil
+	})
+
+	pricing := services.NewPricingService(&config.PricingConfig{
+		Enabled:      true,
+		CustomPrices: map[string]config.CustomPricing{"openai/gpt-4o": {InputPricePerMToken: 2.5, OutputPricePerMToken: 10}},
+	})
+
+	models := []string{"openai/gpt-4o", "openai/broken"}
+	runs := runBenchmark(context.Background(), client, pricing, models, []string{"a", "b"}, "judge/model", 0, io.Discard)
+	if len(runs) != 4 {
+		t.Fatalf("got %d runs, want one per model and prompt", len(runs))
+	}
+
+	summaries := summarizeBenchmark(runs, models)
+	gpt, broken := summaries[0], summaries[1]
+	if gpt.Runs != 2 || gpt.Failed != 0 || gpt.InputTokens != 2_000_000 || gpt.Cost < 6.99 || gpt.Cost > 7.01 {
+		t.Errorf("unexpected gpt-4o summary %+v", gpt)
+	}
+	if gpt.AvgScore == nil || *gpt.AvgScore != 8 {
+		t.Errorf("expected the judge score to be averaged, got %v", gpt.AvgScore)
+	}
+	if broken.Failed != 2 || broken.AvgScore != nil || runs[2].Error != "model not found" {
+		t.Errorf("unexpected failed summary %+v", broken)
+	}
+
+	out := renderBenchmarkTable(summaries, 2, "judge/model")
+	for _, want := range []string{"Benchmark (2 models, 2 prompts)", "Score", "8.0", "0/2", "$7.00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table is missing %q:\n%s", want, out)
+		}
+	}
+}
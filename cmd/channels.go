@@ -267,12 +267,12 @@ func buildChannelShortcutRegistry(cfg *config.Config) *shortcuts.Registry {
 	reg := shortcuts.NewRegistry()
 
 	reg.Register(shortcuts.NewClearShortcut(nil, nil))
-	reg.Register(shortcuts.NewCompactShortcut(nil))
+	reg.Register(shortcuts.NewCompactShortcut(nil, nil, nil))
 	reg.Register(shortcuts.NewCopyShortcut(nil, nil))
 	reg.Register(shortcuts.NewContextShortcut(nil, nil, nil))
 	reg.Register(shortcuts.NewCostShortcut(nil))
 	reg.Register(shortcuts.NewExitShortcut())
-	reg.Register(shortcuts.NewSwitchShortcut(nil))
+	reg.Register(shortcuts.NewSwitchShortcut(nil, nil))
 	reg.Register(shortcuts.NewThemeShortcut(nil))
 	reg.Register(shortcuts.NewToolsShortcut())
 	reg.Register(shortcuts.NewHelpShortcut(reg))
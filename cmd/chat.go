@@ -13,6 +13,7 @@ import (
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+	colorprofile "github.com/charmbracelet/colorprofile"
 	uuid "github.com/google/uuid"
 	cobra "github.com/spf13/cobra"
 
@@ -24,8 +25,10 @@ import (
 	clipboard "github.com/inference-gateway/cli/internal/clipboard"
 	container "github.com/inference-gateway/cli/internal/container"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	screenshotsvc "github.com/inference-gateway/cli/internal/services"
+	prompttemplates "github.com/inference-gateway/cli/internal/services/prompttemplates"
 	streamevent "github.com/inference-gateway/cli/internal/streamevent"
 	telemetry "github.com/inference-gateway/cli/internal/telemetry"
 	colors "github.com/inference-gateway/cli/internal/ui/styles/colors"
@@ -36,11 +39,29 @@ var chatCmd = &cobra.Command{
 	Use:   "chat",
 	Short: "Start an interactive chat session with model selection",
 	Long: `Start an interactive chat session where you can select a model from a dropdown
-and have a conversational interface with the inference gateway.`,
+and have a conversational interface with the inference gateway.
+
+Use --continue to reopen the most recent conversation, or --resume with a
+conversation ID (see 'infer conversations list') to reopen a specific one.
+
+Use --template to start from a conversation template in prompts.yaml: its
+context is seeded, its model selected and its first message, with its files
+attached as @references, put in the input to review and send. Fill in the
+template's {{variables}} with --var key=value.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := Cfg
 
 		sessionID, _ := cmd.Flags().GetString("session-id")
+		if resumeID, _ := cmd.Flags().GetString("resume"); resumeID != "" {
+			sessionID = resumeID
+		}
+		continueLatest, _ := cmd.Flags().GetBool("continue")
+		resuming := sessionID != "" || continueLatest
+
+		seed, err := chatTemplateSeed(cmd, cfg)
+		if err != nil {
+			return err
+		}
 
 		if os.Getenv("INFER_WEB_MODE") == "true" {
 			cfg.Web.Enabled = true
@@ -81,32 +102,74 @@ and have a conversational interface with the inference gateway.`,
 				}
 			}
 
-			if sessionID != "" {
-				fmt.Println(colors.CreateColoredText("--session-id is not supported in web mode; ignoring.", colors.DimColor))
+			if resuming {
+				fmt.Println(colors.CreateColoredText("Resuming a session is not supported in web mode; ignoring.", colors.DimColor))
 			}
 			return StartWebChatSession(cfg)
 		}
 
 		if !isInteractiveTerminal() {
-			if sessionID != "" {
-				fmt.Println(colors.CreateColoredText("--session-id is not supported in non-interactive mode; ignoring.", colors.DimColor))
+			if resuming {
+				fmt.Println(colors.CreateColoredText("Resuming a session is not supported in non-interactive mode; ignoring.", colors.DimColor))
 			}
 			return runNonInteractiveChat(cfg)
 		}
 
-		return StartChatSession(cfg, sessionID)
+		return StartChatSession(cfg, sessionID, continueLatest, seed)
 	},
 }
 
-// StartChatSession starts a chat session
+// chatTemplateSeed renders the conversation template named by --template with
+// the --var values, or returns nil when no template was asked for
+func chatTemplateSeed(cmd *cobra.Command, cfg *config.Config) (*prompttemplates.ConversationSeed, error) {
+	name, _ := cmd.Flags().GetString("template")
+	if name == "" {
+		return nil, nil
+	}
+
+	templates := cfg.Prompts.ConversationTemplates
+	tmpl, ok := prompttemplates.FindConversation(templates, name)
+	if !ok {
+		if len(templates) == 0 {
+			return nil, fmt.Errorf("conversation template %q not found: none are defined under conversation_templates in prompts.yaml", name)
+		}
+		names := make([]string, 0, len(templates))
+		for _, t := range templates {
+			names = append(names, t.Name)
+		}
+		return nil, fmt.Errorf("conversation template %q not found (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	assignments, _ := cmd.Flags().GetStringArray("var")
+	vars, err := prompttemplates.Vars(cmd.Context(), assignments)
+	if err != nil {
+		return nil, err
+	}
+	seed := prompttemplates.RenderConversation(tmpl, vars)
+	return &seed, nil
+}
+
+// StartChatSession starts a chat session, resuming sessionID when it is set or
+// the most recent conversation when continueLatest is. A new session is seeded
+// from seed when it is set.
 //
 //nolint:funlen // Chat session initialization requires multiple setup steps
-func StartChatSession(cfg *config.Config, sessionID string) error {
+func StartChatSession(cfg *config.Config, sessionID string, continueLatest bool, seed *prompttemplates.ConversationSeed) error {
 	_ = clipboard.Init()
 
 	_ = streamevent.SetWriter(io.Discard)
 
 	telemetry.ExecutionMode = telemetry.ExecInteractive
+
+	// --no-colors, NO_COLOR or a terminal without color support: render the
+	// TUI with the monochrome theme and strip whatever color remains (markdown
+	// and syntax highlighting) at the output.
+	var programOptions []tea.ProgramOption
+	if outputColorsDisabled {
+		cfg.Chat.Theme = domain.MonochromeThemeName
+		programOptions = append(programOptions, tea.WithColorProfile(colorprofile.Ascii))
+	}
+
 	services := container.NewServiceContainer(cfg)
 
 	telemetryRec := services.GetTelemetryRecorder()
@@ -135,8 +198,36 @@ func StartChatSession(cfg *config.Config, sessionID string) error {
 
 	defer doShutdown()
 
+	var sessionEndOnce sync.Once
+	endSession := func(reason string, err error) {
+		sessionEndOnce.Do(func() {
+			payload := domain.HookPayload{
+				Hook:     domain.HookSessionEnd,
+				Model:    services.GetModelService().GetCurrentModel(),
+				Messages: services.GetConversationRepository().GetMessageCount(),
+				Reason:   reason,
+			}
+			if err != nil {
+				payload.Error = err.Error()
+			}
+			repo := services.GetConversationRepository()
+			var messages []sdk.Message
+			for _, entry := range repo.GetMessages() {
+				if !entry.Hidden {
+					messages = append(messages, entry.Message)
+				}
+			}
+			distillSessionMemory(
+				domain.WithSessionID(context.Background(), repo.GetCurrentConversationID()),
+				services.GetMemoryDistiller(), messages, payload.Model,
+			)
+			services.GetAgentService().RunLifecycleHooks(context.Background(), payload)
+		})
+	}
+
 	go func() {
 		<-sigChan
+		endSession("signal", nil)
 		doShutdown()
 		os.Exit(0)
 	}()
@@ -160,6 +251,9 @@ func StartChatSession(cfg *config.Config, sessionID string) error {
 	}
 
 	defaultModel := cfg.Agent.Model
+	if seed != nil && seed.Model != "" {
+		defaultModel = seed.Model
+	}
 	if defaultModel != "" {
 		defaultModel = validateAndSetDefaultModel(services.GetModelService(), models, defaultModel)
 	}
@@ -185,9 +279,18 @@ func StartChatSession(cfg *config.Config, sessionID string) error {
 	conversationOptimizer := services.GetConversationOptimizer()
 	sessionRolloverManager := services.GetSessionRolloverManager()
 
+	if continueLatest {
+		sessionID = latestConversationID(services.GetStorage())
+		if sessionID == "" {
+			fmt.Println(colors.CreateColoredText("No previous conversation to continue; starting a new one.", colors.DimColor))
+		}
+	}
 	if sessionID != "" {
 		resumeChatSession(conversationRepo, sessionRolloverManager, sessionID)
 	}
+	if seed != nil {
+		seedChatSession(conversationRepo, *seed)
+	}
 
 	if mode := inheritedSubagentMode(); mode != domain.AgentModeStandard {
 		stateManager.SetAgentMode(mode)
@@ -222,7 +325,7 @@ func StartChatSession(cfg *config.Config, sessionID string) error {
 		cfg,
 		models,
 		defaultModel,
-		GetVersionInfo(),
+		chatVersionInfo(cfg),
 		agentManager,
 		agentService,
 		backgroundTaskService,
@@ -251,9 +354,12 @@ func StartChatSession(cfg *config.Config, sessionID string) error {
 		services.GetDirectExecutionService(),
 		services.GetToolExecutionCoordinator(),
 		services.GetShellHistoryStorage(),
+		services.GetCheckpoints(),
+		services.GetBudgetService(),
+		services.GetTokenizer(),
 	)
 
-	program := tea.NewProgram(application)
+	program := tea.NewProgram(application, programOptions...)
 	notifier := programNotifier{program: program}
 	services.SetUINotifier(notifier)
 
@@ -264,9 +370,22 @@ func StartChatSession(cfg *config.Config, sessionID string) error {
 		}
 	}
 
+	agentService.RunLifecycleHooks(context.Background(), domain.HookPayload{
+		Hook:     domain.HookSessionStart,
+		Model:    modelService.GetCurrentModel(),
+		Messages: conversationRepo.GetMessageCount(),
+		Reason:   chatStartReason(sessionID),
+	})
+
+	if seed != nil && seed.Message != "" {
+		go program.Send(domain.SetInputEvent{Text: seed.Message})
+	}
+
 	if _, err := program.Run(); err != nil {
+		endSession("error", err)
 		return fmt.Errorf("error running chat interface: %w", err)
 	}
+	endSession("exit", nil)
 
 	application.PrintConversationHistory()
 
@@ -278,6 +397,15 @@ func StartChatSession(cfg *config.Config, sessionID string) error {
 	return nil
 }
 
+// chatStartReason tells the session_start hooks whether the chat resumed a
+// saved conversation or started a fresh one.
+func chatStartReason(sessionID string) string {
+	if sessionID != "" {
+		return "resume"
+	}
+	return "new"
+}
+
 // chatExitMessage builds the message printed when a chat session ends.
 func chatExitMessage(sessionID string) string {
 	if sessionID == "" {
@@ -286,6 +414,18 @@ func chatExitMessage(sessionID string) string {
 	return "Chat session ended. Continue with: infer chat --session-id " + sessionID
 }
 
+// distillSessionMemory files the durable facts of a finished session into
+// memory (memory.distill) before session_end fires. Subagent runs are skipped:
+// the parent session is the one worth remembering. Failures are only logged.
+func distillSessionMemory(ctx context.Context, distiller domain.MemoryDistiller, messages []sdk.Message, model string) {
+	if distiller == nil || tools.IsSubagentProcess() {
+		return
+	}
+	if _, err := distiller.Distill(ctx, messages, model); err != nil {
+		logger.Warn("memory distillation failed", "error", err)
+	}
+}
+
 // resumeChatSession loads the conversation for sessionID into the repository,
 // resolving rollover chains first. When the conversation cannot be loaded it
 // adopts the requested ID for the new session if the repository supports it,
@@ -313,6 +453,48 @@ func resumeChatSession(repo domain.ConversationRepository, rolloverManager *scre
 	logger.Info("resumed chat session", "session_id", sessionID)
 }
 
+// seedChatSession starts the session's conversation under the template's name
+// with its context as a pinned hidden message
+func seedChatSession(repo domain.ConversationRepository, seed prompttemplates.ConversationSeed) {
+	if err := repo.StartNewConversation(seed.Name); err != nil {
+		logger.Warn("failed to start conversation from template", "template", seed.Name, "error", err)
+	}
+	if entry, ok := seed.ContextEntry(); ok {
+		if err := repo.AddMessage(entry); err != nil {
+			logger.Warn("failed to add conversation template context", "template", seed.Name, "error", err)
+		}
+	}
+	fmt.Printf("• Starting from conversation template: %s\n", seed.Name)
+}
+
+// latestConversationID returns the ID of the most recently updated
+// conversation that is not archived, or "" if there is none.
+func latestConversationID(store storage.ConversationStorage) string {
+	if store == nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const pageSize = 20
+	for offset := 0; ; offset += pageSize {
+		conversations, err := store.ListConversations(ctx, pageSize, offset)
+		if err != nil {
+			logger.Warn("failed to list conversations", "error", err)
+			return ""
+		}
+		for _, conversation := range conversations {
+			if !conversation.Archived {
+				return conversation.ID
+			}
+		}
+		if len(conversations) < pageSize {
+			return ""
+		}
+	}
+}
+
 // StartWebChatSession starts a web-based chat session with PTY and WebSocket
 func StartWebChatSession(cfg *config.Config) error {
 	server := web.NewWebTerminalServer(cfg)
@@ -533,4 +715,12 @@ func init() {
 	chatCmd.Flags().Bool("ssh-no-install", false, "Disable auto-installation of infer on remote")
 	chatCmd.Flags().String("ssh-command", "infer", "Path to infer binary on remote")
 	chatCmd.Flags().String("session-id", "", "Resume an existing chat session by conversation ID")
+	chatCmd.Flags().String("resume", "", "Reopen a conversation by ID, skipping the conversation selector")
+	chatCmd.Flags().BoolP("continue", "c", false, "Reopen the most recent conversation")
+	chatCmd.Flags().String("template", "", "Start from a conversation template in prompts.yaml")
+	chatCmd.Flags().StringArray("var", nil, "Template variable as key=value (repeatable)")
+	chatCmd.MarkFlagsMutuallyExclusive("session-id", "resume", "continue", "template")
+	_ = chatCmd.RegisterFlagCompletionFunc("template", completeConversationTemplates)
+	_ = chatCmd.RegisterFlagCompletionFunc("session-id", completeConversationIDs)
+	_ = chatCmd.RegisterFlagCompletionFunc("resume", completeConversationIDs)
 }
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
 	colors "github.com/inference-gateway/cli/internal/ui/styles/colors"
 	mocks "github.com/inference-gateway/cli/tests/mocks/domain"
 )
@@ -79,3 +82,30 @@ func TestResumeChatSession(t *testing.T) {
 		}
 	})
 }
+
+func TestLatestConversationID(t *testing.T) {
+	if id := latestConversationID(nil); id != "" {
+		t.Errorf("no storage should give no conversation, got %q", id)
+	}
+
+	store := storage.NewMemoryStorage()
+	if id := latestConversationID(store); id != "" {
+		t.Errorf("empty storage should give no conversation, got %q", id)
+	}
+
+	save := func(id string, archived bool) {
+		t.Helper()
+		metadata := storage.ConversationMetadata{ID: id, Archived: archived}
+		if err := store.SaveConversation(context.Background(), id, nil, metadata); err != nil {
+			t.Fatalf("SaveConversation(%s): %v", id, err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	save("older", false)
+	save("yesterday", false)
+	save("archived", true)
+
+	if id := latestConversationID(store); id != "yesterday" {
+		t.Errorf("latestConversationID() = %q, want the newest conversation that is not archived", id)
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	container "github.com/inference-gateway/cli/internal/container"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// gitEmptyTreeSHA is the hash of git's empty tree, the parent of a root
+// commit when amending it
+const gitEmptyTreeSHA = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+var commitCmd = &cobra.Command{
+	Use:   "commit",
+	Short: "Generate a commit message for the staged changes and commit them",
+	Long: `Read the staged diff, generate a conventional-commit message with the
+git.commit_message.system_prompt prompt from prompts.yaml, and run git commit
+with it. infer commit never stages anything: stage your changes with git add
+first.
+
+The model is --model, else git.commit_message.model, else agent.model. With
+--print the message is only printed, for use in scripts. --edit opens the
+message in your git editor before committing, and --amend replaces the last
+commit, generating the message from all of its changes.
+
+Examples:
+  infer commit
+  infer commit --edit
+  infer commit --amend
+  git commit -m "$(infer commit --print)"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		model, _ := cmd.Flags().GetString("model")
+		printOnly, _ := cmd.Flags().GetBool("print")
+		amend, _ := cmd.Flags().GetBool("amend")
+		edit, _ := cmd.Flags().GetBool("edit")
+		if printOnly && (amend || edit) {
+			return fmt.Errorf("--print cannot be combined with --amend or --edit")
+		}
+		return RunCommitCommand(Cfg, model, printOnly, amend, edit)
+	},
+}
+
+// RunCommitCommand generates a commit message for the staged changes and
+// prints it or commits with it
+func RunCommitCommand(cfg *config.Config, modelFlag string, printOnly, amend, edit bool) error {
+	model := cmp.Or(modelFlag, cfg.ModelForTask(config.TaskCommitMessage, cfg.Agent.Model))
+	if model == "" {
+		return fmt.Errorf("no commit message model: pass --model or set git.commit_message.model, agent.routing.lightweight or agent.model")
+	}
+
+	ctx := context.Background()
+	diff, err := stagedCommitDiff(ctx, amend)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return fmt.Errorf("nothing staged to commit: stage changes with git add first")
+	}
+
+	svc := container.NewServiceContainer(cfg)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = svc.Shutdown(ctx)
+	}()
+	if err := svc.GetGatewayManager().EnsureStarted(); err != nil {
+		return fmt.Errorf("failed to start inference gateway: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s Generating a commit message with %s...\n", icons.BulletIcon, model)
+	message, err := generateCommitMessage(ctx, svc.NewSDKClient(), model, cfg.Prompts.Git.CommitMessage.SystemPrompt, diff)
+	if err != nil {
+		return err
+	}
+
+	if printOnly {
+		fmt.Println(message)
+		return nil
+	}
+
+	gitArgs := []string{"commit", "-m", message}
+	if amend {
+		gitArgs = append(gitArgs, "--amend")
+	}
+	if edit {
+		gitArgs = append(gitArgs, "--edit")
+	}
+	git := exec.CommandContext(ctx, "git", gitArgs...)
+	git.Stdin, git.Stdout, git.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := git.Run(); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+	return nil
+}
+
+// stagedCommitDiff returns the staged diff, or with amend the diff of the
+// last commit plus what is staged on top of it
+func stagedCommitDiff(ctx context.Context, amend bool) (string, error) {
+	args := []string{"diff", "--cached", "--no-color", "--no-ext-diff"}
+	if amend {
+		base := "HEAD^"
+		if _, err := gitOutput(ctx, "rev-parse", "--verify", "--quiet", "HEAD^"); err != nil {
+			base = gitEmptyTreeSHA
+		}
+		args = append(args, base)
+	}
+	diff, err := gitOutput(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the staged changes: %w", err)
+	}
+	return diff, nil
+}
+
+// generateCommitMessage asks model for a commit message for diff and strips
+// the quotes and code fences models like to add
+func generateCommitMessage(ctx context.Context, client sdk.Client, model, systemPrompt, diff string) (string, error) {
+	reply, err := completeWithDiff(ctx, client, model, systemPrompt, "", diff)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate a commit message: %w", err)
+	}
+
+	message := strings.TrimSpace(reply)
+	if strings.HasPrefix(message, "```") {
+		message = strings.TrimPrefix(message, "```")
+		if i := strings.Index(message, "\n"); i >= 0 && !strings.Contains(message[:i], " ") {
+			message = message[i+1:]
+		}
+		message = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(message), "```"))
+	}
+	for _, quote := range []string{`"`, "'", "`"} {
+		if len(message) > 1 && strings.HasPrefix(message, quote) && strings.HasSuffix(message, quote) {
+			message = strings.TrimSpace(message[1 : len(message)-1])
+		}
+	}
+	if message == "" {
+		return "", fmt.Errorf("%s returned an empty commit message", model)
+	}
+	return message, nil
+}
+
+func init() {
+	commitCmd.Flags().StringP("model", "m", "", "Model for the message (default: git.commit_message.model, else agent.model)")
+	commitCmd.Flags().Bool("print", false, "Print the message instead of committing")
+	commitCmd.Flags().Bool("amend", false, "Replace the last commit, with a message for all of its changes")
+	commitCmd.Flags().BoolP("edit", "e", false, "Edit the message in your git editor before committing")
+	_ = commitCmd.RegisterFlagCompletionFunc("model", completeModels)
+	rootCmd.AddCommand(commitCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	sdkmocks "github.com/inference-gateway/cli/tests/mocks/sdk"
+)
+
+func TestGenerateCommitMessage(t *testing.T) {
+	tests := []struct {
+		reply string
+		want  string
+	}{
+		{"feat: add infer commit", "feat: add infer commit"},
+		{`"fix(cmd): handle empty diff"`, "fix(cmd): handle empty diff"},
+		{"```\nchore: bump deps\n```", "chore: bump deps"},
+		{"```text\ndocs: document --amend\n\nLonger body.\n```", "docs: document --amend\n\nLonger body."},
+		{"`refactor: rename helper`", "refactor: rename helper"},
+	}
+	for _, tt := range tests {
+		client := &sdkmocks.FakeClient{}
+		client.WithOptionsReturns(client)
+		client.WithMiddlewareOptionsReturns(client)
+		client.GenerateContentReturns(&sdk.CreateChatCompletionResponse{Choices: []sdk.ChatCompletionChoice{
+			{Message: sdk.Message{Content: sdk.NewMessageContent(tt.reply)}},
+		}}, nil)
+
+		got, err := generateCommitMessage(context.Background(), client, "openai/gpt-4o", "write a commit message", "+x")
+		if err != nil || got != tt.want {
+			t.Errorf("reply %q: got %q, %v; want %q", tt.reply, got, err, tt.want)
+		}
+	}
+
+	client := &sdkmocks.FakeClient{}
+	client.WithOptionsReturns(client)
+	client.WithMiddlewareOptionsReturns(client)
+	client.GenerateContentReturns(&sdk.CreateChatCompletionResponse{Choices: []sdk.ChatCompletionChoice{
+		{Message: sdk.Message{Content: sdk.NewMessageContent(`""`)}},
+	}}, nil)
+	if _, err := generateCommitMessage(context.Background(), client, "openai/gpt-4o", "", "+x"); err == nil {
+		t.Error("an empty message must be an error")
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	tools "github.com/inference-gateway/cli/internal/agent/tools"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	services "github.com/inference-gateway/cli/internal/services"
+)
+
+// Dynamic shell completion for values that live outside the binary: model
+// names from the gateway, the registered tools and saved conversation IDs.
+// Every lookup is bounded by completionTimeout and fails silently, since a
+// completion error has nowhere to go but a broken prompt.
+
+// completionTimeout bounds the gateway and storage lookups of one TAB press
+const completionTimeout = 3 * time.Second
+
+// completionConversationLimit is how many recent conversations complete
+const completionConversationLimit = 50
+
+// completeFirstArg completes only the first positional argument with fn
+func completeFirstArg(fn cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
+// completeModels completes model names the gateway serves. When the gateway
+// is unreachable it falls back to the models the config already names.
+func completeModels(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	ids, err := services.NewHTTPModelService(completionSDKClient(Cfg)).ListModels(ctx)
+	if err != nil || len(ids) == 0 {
+		ids = configuredModels()
+	}
+	sort.Strings(ids)
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionSDKClient returns a gateway client without retries, so an
+// unreachable gateway fails within completionTimeout
+func completionSDKClient(cfg *config.Config) sdk.Client {
+	baseURL := cfg.Gateway.URL
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	if !strings.HasSuffix(baseURL, "/v1") {
+		baseURL = strings.TrimSuffix(baseURL, "/") + "/v1"
+	}
+	return sdk.NewClient(&sdk.ClientOptions{
+		BaseURL: baseURL,
+		APIKey:  cfg.Gateway.APIKey,
+		Timeout: completionTimeout,
+	})
+}
+
+// configuredModels returns the distinct model values set anywhere in the
+// effective config
+func configuredModels() []string {
+	root, err := effectiveConfigMap()
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for key, value := range flattenConfigKeys(root, "") {
+		if s, ok := value.(string); ok && s != "" && isModelConfigKey(key) && !slices.Contains(ids, s) {
+			ids = append(ids, s)
+		}
+	}
+	return ids
+}
+
+// isModelConfigKey reports whether key holds a model name, e.g. agent.model
+// or compact.summary_model
+func isModelConfigKey(key string) bool {
+	last := key[strings.LastIndex(key, ".")+1:]
+	return last == "model" || strings.HasSuffix(last, "_model")
+}
+
+// flattenConfigKeys maps every leaf of a config map to its dotted key
+func flattenConfigKeys(m map[string]any, prefix string) map[string]any {
+	leaves := map[string]any{}
+	for k, v := range m {
+		key := prefix + k
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			for nk, nv := range flattenConfigKeys(nested, key+".") {
+				leaves[nk] = nv
+			}
+			continue
+		}
+		leaves[key] = v
+	}
+	return leaves
+}
+
+// completeConfigKeys completes the dotted keys of config.yaml
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	root, err := effectiveConfigMap()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	keys := make([]string, 0, len(root))
+	for key := range flattenConfigKeys(root, "") {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigSet completes the key of infer config set, then the value of
+// model keys from the gateway
+func completeConfigSet(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch {
+	case len(args) == 0:
+		return completeConfigKeys(cmd, args, toComplete)
+	case len(args) == 1 && isModelConfigKey(args[0]):
+		return completeModels(cmd, nil, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeToolNames completes the names of the enabled tools
+func completeToolNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil || !Cfg.Tools.Enabled {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := tools.NewRegistry(Cfg, nil, nil, nil, nil, nil, nil, nil).ListAvailableTools()
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePersonas completes the persona names under agents.profiles,
+// described by their descriptions
+func completePersonas(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, name := range Cfg.PersonaNames() {
+		if description := Cfg.Agents.Profiles[name].Description; description != "" {
+			name += "\t" + description
+		}
+		completions = append(completions, name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConversationTemplates completes the conversation template names in
+// prompts.yaml, described by their descriptions
+func completeConversationTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]string, 0, len(Cfg.Prompts.ConversationTemplates))
+	for _, t := range Cfg.Prompts.ConversationTemplates {
+		name := t.Name
+		if t.Description != "" {
+			name += "\t" + t.Description
+		}
+		completions = append(completions, name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConversationIDs completes the IDs of the most recent saved
+// conversations, described by their titles
+func completeConversationIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	stores, err := storage.NewStorage(storage.NewStorageFromConfig(Cfg))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer func() { _ = stores.Conversations.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	conversations, err := stores.Conversations.ListConversations(ctx, completionConversationLimit, 0)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return conversationCompletions(conversations), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// conversationCompletions formats conversations as "id<TAB>title" so shells
+// that show descriptions list the titles next to the IDs
+func conversationCompletions(conversations []storage.ConversationSummary) []string {
+	completions := make([]string, 0, len(conversations))
+	for _, c := range conversations {
+		title := strings.Join(strings.Fields(c.Title), " ")
+		if title == "" {
+			title = fmt.Sprintf("%d messages", c.MessageCount)
+		}
+		completions = append(completions, c.ID+"\t"+title)
+	}
+	return completions
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func withCompletionConfig(t *testing.T, cfg *config.Config) {
+	t.Helper()
+	previous := Cfg
+	Cfg = cfg
+	t.Cleanup(func() { Cfg = previous })
+}
+
+func TestCompleteConfigKeys(t *testing.T) {
+	withCompletionConfig(t, config.DefaultConfig())
+
+	keys, directive := completeFirstArg(completeConfigKeys)(configGetCmd, nil, "")
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v", directive)
+	}
+	for _, want := range []string{"agent.model", "tools.bash.enabled", "logging.dir"} {
+		if !slices.Contains(keys, want) {
+			t.Errorf("keys are missing %q", want)
+		}
+	}
+
+	if keys, _ := completeFirstArg(completeConfigKeys)(configGetCmd, []string{"agent.model"}, ""); len(keys) != 0 {
+		t.Errorf("only the first argument completes, got %v", keys)
+	}
+}
+
+func TestCompleteConfigSetValueFallsBackToConfiguredModels(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Gateway.URL = "http://127.0.0.1:1"
+	cfg.Agent.Model = "openai/gpt-4o"
+	withCompletionConfig(t, cfg)
+
+	models, _ := completeConfigSet(configSetCmd, []string{"agent.model"}, "")
+	if !slices.Contains(models, "openai/gpt-4o") {
+		t.Errorf("expected the configured model while the gateway is down, got %v", models)
+	}
+
+	if values, _ := completeConfigSet(configSetCmd, []string{"tools.bash.enabled"}, ""); len(values) != 0 {
+		t.Errorf("non-model keys must not complete values, got %v", values)
+	}
+}
+
+func TestIsModelConfigKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"agent.model":                true,
+		"git.commit_message.model":   true,
+		"compact.summary_model":      true,
+		"agent.max_turns":            false,
+		"tools.bash.models_disabled": false,
+	} {
+		if got := isModelConfigKey(key); got != want {
+			t.Errorf("isModelConfigKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestConversationCompletions(t *testing.T) {
+	got := conversationCompletions([]domain.ConversationSummary{
+		{ID: "a1", Title: "Fix the\nflaky test"},
+		{ID: "b2", MessageCount: 4},
+	})
+	want := []string{"a1\tFix the flaky test", "b2\t4 messages"}
+	if !slices.Equal(got, want) {
+		t.Errorf("conversationCompletions() = %q, want %q", got, want)
+	}
+}
@@ -291,6 +291,46 @@ func getEffectivePromptsConfigPath() string {
 	return config.DefaultPromptsPath
 }
 
+// resolveOrgPromptFragment sets the org layer of the system prompt: the
+// system_prompt_fragment of ~/.infer/prompts.yaml, which applies even when the
+// project prompts.yaml at promptsPath replaces the rest of the file. When the
+// userspace file is the one loaded, its fragment moves from the project layer.
+func resolveOrgPromptFragment(cfg *config.Config, promptsPath string) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	homePath := filepath.Join(homeDir, config.ConfigDirName, config.PromptsFileName)
+
+	if sameFile(promptsPath, homePath) {
+		cfg.Prompts.Agent.OrgSystemPromptFragment = cfg.Prompts.Agent.SystemPromptFragment
+		cfg.Prompts.Agent.SystemPromptFragment = ""
+		return
+	}
+	if !fileExists(homePath) {
+		return
+	}
+	home, err := config.LoadPrompts(homePath)
+	if err != nil {
+		logger.Warn("failed to load userspace prompts config for its system prompt fragment", "error", err, "path", homePath)
+		return
+	}
+	cfg.Prompts.Agent.OrgSystemPromptFragment = home.Agent.SystemPromptFragment
+}
+
+// sameFile reports whether a and b are the same existing file
+func sameFile(a, b string) bool {
+	aInfo, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bInfo, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(aInfo, bInfo)
+}
+
 // getEffectiveRemindersConfigPath returns the path to the reminders config file
 // Searches in this order: 1) project .infer/reminders.yaml, 2) user home ~/.infer/reminders.yaml
 func getEffectiveRemindersConfigPath() string {
@@ -423,7 +463,8 @@ func getKeybindingsConfigWritePath(toProject bool) (string, error) {
 // loadConfigFromViper assembles the in-memory Config by unmarshalling
 // viper, then layering on the per-file YAML overlays (mcp, keybindings,
 // prompts) and finally honouring INFER_* env overrides. It runs once at
-// startup (initConfig); commands afterwards read the cached cmd.Cfg.
+// startup (initConfig); commands afterwards read the cached cmd.Cfg. A config
+// that fails Validate is returned along with the error for `infer doctor`.
 func loadConfigFromViper() (*config.Config, error) {
 	cfg := &config.Config{}
 	if err := V.Unmarshal(cfg); err != nil {
@@ -432,6 +473,12 @@ func loadConfigFromViper() (*config.Config, error) {
 
 	resolveViperEnvironmentVariables(cfg, "")
 
+	if max := cfg.Agent.MaxConcurrentTools; max > 0 {
+		logger.Warn("agent.max_concurrent_tools is deprecated, use agent.tool_concurrency; capping every tool class at it",
+			"max_concurrent_tools", max)
+		cfg.Agent.ToolConcurrency = cfg.Agent.ToolConcurrency.CappedAt(max)
+	}
+
 	mcpConfigPath := getEffectiveMCPConfigPath()
 	mcpConfig, err := config.LoadMCP(mcpConfigPath)
 	if err != nil {
@@ -463,6 +510,7 @@ func loadConfigFromViper() (*config.Config, error) {
 		prompts = config.DefaultPromptsConfig()
 	}
 	cfg.Prompts = *prompts
+	resolveOrgPromptFragment(cfg, promptsPath)
 	applyPromptsEnvOverrides(cfg)
 	warnDeadPromptEnvVars()
 
@@ -530,7 +578,7 @@ func loadConfigFromViper() (*config.Config, error) {
 	applyPluginsEnvOverrides(cfg)
 
 	if err := cfg.Validate(); err != nil {
-		return nil, err
+		return cfg, err
 	}
 
 	return cfg, nil
@@ -547,6 +595,7 @@ func applyPromptsEnvOverrides(cfg *config.Config) {
 		"INFER_PROMPTS_AGENT_SYSTEM_PROMPT_HEARTBEAT":               &cfg.Prompts.Agent.SystemPromptHeartbeat,
 		"INFER_PROMPTS_AGENT_CUSTOM_INSTRUCTIONS":                   &cfg.Prompts.Agent.CustomInstructions,
 		"INFER_PROMPTS_GIT_COMMIT_MESSAGE_SYSTEM_PROMPT":            &cfg.Prompts.Git.CommitMessage.SystemPrompt,
+		"INFER_PROMPTS_GIT_REVIEW_SYSTEM_PROMPT":                    &cfg.Prompts.Git.Review.SystemPrompt,
 		"INFER_PROMPTS_CONVERSATION_TITLE_GENERATION_SYSTEM_PROMPT": &cfg.Prompts.Conversation.TitleGeneration.SystemPrompt,
 		"INFER_PROMPTS_INIT_PROMPT":                                 &cfg.Prompts.Init.Prompt,
 
@@ -561,6 +610,7 @@ func applyPromptsEnvOverrides(cfg *config.Config) {
 		"INFER_PROMPTS_TOOLS_DELETE_DESCRIPTION":                &cfg.Prompts.Tools.Delete.Description,
 		"INFER_PROMPTS_TOOLS_GREP_DESCRIPTION":                  &cfg.Prompts.Tools.Grep.Description,
 		"INFER_PROMPTS_TOOLS_TREE_DESCRIPTION":                  &cfg.Prompts.Tools.Tree.Description,
+		"INFER_PROMPTS_TOOLS_PROJECT_SEARCH_DESCRIPTION":        &cfg.Prompts.Tools.ProjectSearch.Description,
 		"INFER_PROMPTS_TOOLS_TODO_WRITE_DESCRIPTION":            &cfg.Prompts.Tools.TodoWrite.Description,
 		"INFER_PROMPTS_TOOLS_REQUEST_PLAN_APPROVAL_DESCRIPTION": &cfg.Prompts.Tools.RequestPlanApproval.Description,
 		"INFER_PROMPTS_TOOLS_WEB_FETCH_DESCRIPTION":             &cfg.Prompts.Tools.WebFetch.Description,
@@ -606,11 +656,21 @@ func warnDeadPromptEnvVars() {
 //
 // Supported forms:
 //
+//	INFER_CHAT_KEYBINDINGS_VIM="true|false"
 //	INFER_CHAT_KEYBINDINGS_BINDINGS_<ACTION_ID>_KEYS="key1,key2"
 //	INFER_CHAT_KEYBINDINGS_BINDINGS_<ACTION_ID>_ENABLED="true|false"
 func applyKeybindingEnvOverrides(cfg *config.Config) {
 	const prefix = "INFER_CHAT_KEYBINDINGS_BINDINGS_"
 
+	if val, ok := os.LookupEnv("INFER_CHAT_KEYBINDINGS_VIM"); ok {
+		switch strings.ToLower(strings.TrimSpace(val)) {
+		case "true":
+			cfg.Chat.Keybindings.Vim = true
+		case "false":
+			cfg.Chat.Keybindings.Vim = false
+		}
+	}
+
 	if cfg.Chat.Keybindings.Bindings == nil {
 		cfg.Chat.Keybindings.Bindings = make(map[string]config.KeyBindingEntry)
 	}
@@ -816,6 +876,14 @@ func applyMemoryEnvOverrides(cfg *config.Config) {
 	if v, ok := os.LookupEnv("INFER_MEMORY_BACKEND_GIT_SYNC_ON_FINISH"); ok {
 		cfg.Memory.Backend.Git.Sync.OnFinish = v
 	}
+	if v, ok := os.LookupEnv("INFER_MEMORY_DISTILL_ENABLED"); ok {
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			cfg.Memory.Distill.Enabled = b
+		}
+	}
+	if v, ok := os.LookupEnv("INFER_MEMORY_DISTILL_MODEL"); ok {
+		cfg.Memory.Distill.Model = v
+	}
 }
 
 // pruneMemoryRemindersIfDisabled drops the built-in memory reminders (see
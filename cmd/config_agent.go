@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	container "github.com/inference-gateway/cli/internal/container"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+var configAgentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Inspect the agent configuration",
+}
+
+var configAgentShowPromptCmd = &cobra.Command{
+	Use:   "show-prompt",
+	Short: "Print the assembled system prompt",
+	Long: `Print the system prompt the agent sends, assembled from its ordered layers:
+the base prompt for the agent mode, custom instructions, then the fragments
+ - org: agent.system_prompt_fragment of ~/.infer/prompts.yaml
+ - project: agent.system_prompt_fragment of .infer/prompts.yaml
+ - persona: agents.profiles.<name>.system_prompt_fragment of the active persona
+ - mode: agent.mode_fragments.<mode> of prompts.yaml
+followed by AGENTS.md, plugin instructions and the static context.
+
+With --layers, list the fragments and where each comes from instead.
+  infer config agent show-prompt
+  infer config agent show-prompt --mode plan --persona reviewer
+  infer config agent show-prompt --layers`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if Cfg == nil {
+			return fmt.Errorf("configuration is not loaded")
+		}
+
+		modeKey, _ := cmd.Flags().GetString("mode")
+		mode, ok := domain.ParseAgentMode(modeKey)
+		if !ok {
+			return fmt.Errorf("invalid --mode %q: must be one of standard, plan, auto or readonly", modeKey)
+		}
+		if persona, _ := cmd.Flags().GetString("persona"); persona != "" {
+			if err := Cfg.ApplyPersona(persona); err != nil {
+				return err
+			}
+		}
+
+		if showLayers, _ := cmd.Flags().GetBool("layers"); showLayers {
+			return printPromptLayers(cmd.OutOrStdout(), Cfg.SystemPromptLayers(mode.AllowedlistKey()))
+		}
+
+		services := container.NewServiceContainer(Cfg)
+		services.GetStateManager().SetAgentMode(mode)
+		prompt := services.GetAgentService().BuildSystemPrompt()
+		if prompt == "" {
+			return fmt.Errorf("no system prompt is configured for %s mode", mode.AllowedlistKey())
+		}
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), prompt)
+		return err
+	},
+}
+
+// printPromptLayers lists the system prompt fragments in the order they are
+// appended, each under a heading naming its layer and source
+func printPromptLayers(out io.Writer, layers []config.PromptLayer) error {
+	if len(layers) == 0 {
+		_, err := fmt.Fprintln(out, listHint("No system prompt fragments are configured."))
+		return err
+	}
+	var sb strings.Builder
+	for i, layer := range layers {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s %s\n%s\n", listTitle(layer.Name), listHint("("+layer.Source+")"), layer.Text)
+	}
+	_, err := fmt.Fprint(out, sb.String())
+	return err
+}
+
+func init() {
+	configAgentShowPromptCmd.Flags().String("mode", "standard", "Agent mode to assemble the prompt for (standard, plan, auto, readonly)")
+	configAgentShowPromptCmd.Flags().String("persona", "", "Persona from agents.profiles to apply")
+	configAgentShowPromptCmd.Flags().Bool("layers", false, "List the prompt fragments and their sources instead")
+	_ = configAgentShowPromptCmd.RegisterFlagCompletionFunc("persona", completePersonas)
+
+	configAgentCmd.AddCommand(configAgentShowPromptCmd)
+	configCmd.AddCommand(configAgentCmd)
+}
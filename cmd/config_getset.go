@@ -25,15 +25,18 @@ var configGetCmd = &cobra.Command{
 
 The value reflects what the CLI actually runs with: built-in defaults, the userspace
 ~/.infer/config.yaml baseline merged key-by-key with the project .infer/config.yaml,
-and INFER_* environment overrides.
+and INFER_* environment overrides. Pass --userspace or --project to read what one
+of those files sets instead.
 
 Keys are dotted paths into config.yaml:
   infer config get agent.model
   infer config get tools.sandbox.directories
   infer config get tools.bash
+  infer config get --project agent      # what the project file overrides
   infer config get                      # dump the whole effective config`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: getConfigValue,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFirstArg(completeConfigKeys),
+	RunE:              getConfigValue,
 }
 
 var configSetCmd = &cobra.Command{
@@ -50,15 +53,46 @@ Keys are dotted paths into config.yaml. The value is parsed to the field's type
 
 By default the userspace ~/.infer/config.yaml baseline is updated; pass --project
 to write a sparse override into the project .infer/config.yaml instead.`,
-	Args: cobra.ExactArgs(2),
-	RunE: setConfigValue,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigSet,
+	RunE:              setConfigValue,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value",
+	Long: `Remove a configuration value from config.yaml.
+
+In the userspace ~/.infer/config.yaml baseline this resets the key to its
+built-in default. With --project it drops the project override, so the
+userspace value applies again:
+  infer config unset agent.model
+  infer config unset --project tools.bash.enabled`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeConfigKeys),
+	RunE:              unsetConfigValue,
 }
 
 func init() {
 	configGetCmd.Flags().StringP("format", "f", "yaml", "Output format (yaml, json)")
+	for _, cmd := range []*cobra.Command{configGetCmd, configSetCmd, configUnsetCmd} {
+		cmd.Flags().Bool("userspace", false, "Apply to the userspace baseline (~/.infer/), the default for set and unset")
+	}
 
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+}
+
+// configScope resolves --userspace and --project. scoped is false when
+// neither was given.
+func configScope(cmd *cobra.Command) (toProject, scoped bool, err error) {
+	userspace, _ := cmd.Flags().GetBool("userspace")
+	project := GetProjectFlag(cmd)
+	if userspace && project {
+		return false, false, fmt.Errorf("--userspace and --project cannot be used together")
+	}
+	return project, userspace || project, nil
 }
 
 // getConfigValue prints the effective value of a config key. The effective
@@ -67,17 +101,19 @@ func init() {
 func getConfigValue(cmd *cobra.Command, args []string) error {
 	format, _ := cmd.Flags().GetString("format")
 
-	if Cfg == nil {
-		return fmt.Errorf("configuration is not loaded")
+	toProject, scoped, err := configScope(cmd)
+	if err != nil {
+		return err
 	}
 
-	data, err := yaml.Marshal(Cfg)
-	if err != nil {
-		return fmt.Errorf("failed to serialize config: %w", err)
+	var root map[string]any
+	if scoped {
+		root, err = scopedConfigMap(toProject)
+	} else {
+		root, err = effectiveConfigMap()
 	}
-	root := map[string]any{}
-	if err := yaml.Unmarshal(data, &root); err != nil {
-		return fmt.Errorf("failed to build config map: %w", err)
+	if err != nil {
+		return err
 	}
 
 	var value any = root
@@ -92,6 +128,35 @@ func getConfigValue(cmd *cobra.Command, args []string) error {
 	return printConfigValue(value, format)
 }
 
+func effectiveConfigMap() (map[string]any, error) {
+	if Cfg == nil {
+		return nil, fmt.Errorf("configuration is not loaded")
+	}
+
+	data, err := yaml.Marshal(Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+	root := map[string]any{}
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to build config map: %w", err)
+	}
+	return root, nil
+}
+
+// scopedConfigMap returns only what the userspace or project config.yaml
+// itself sets, without defaults, the other layer or env overrides.
+func scopedConfigMap(toProject bool) (map[string]any, error) {
+	target, path, err := configWriteTarget(toProject)
+	if err != nil {
+		return nil, err
+	}
+	if _, statErr := os.Stat(path); statErr != nil {
+		return nil, fmt.Errorf("no config file at %s", path)
+	}
+	return target.AllSettings(), nil
+}
+
 // lookupConfigKey walks a dotted key into the generic config map.
 func lookupConfigKey(root map[string]any, key string) (any, error) {
 	parts := strings.Split(key, ".")
@@ -138,7 +203,7 @@ func setConfigValue(cmd *cobra.Command, args []string) error {
 
 	kind, ok := resolveConfigKeyKind(key)
 	if !ok {
-		return fmt.Errorf("unknown config key %q (use a dotted path into config.yaml, e.g. agent.model)", key)
+		return unknownConfigKeyError(key)
 	}
 
 	parsed, err := parseConfigValue(rawValue, kind)
@@ -146,13 +211,66 @@ func setConfigValue(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid value for %q: %w", key, err)
 	}
 
-	toProject := GetProjectFlag(cmd)
+	toProject, _, err := configScope(cmd)
+	if err != nil {
+		return err
+	}
 	target, path, err := configWriteTarget(toProject)
 	if err != nil {
 		return err
 	}
 
 	target.Set(key, parsed)
+	if err := writeConfigTarget(target, toProject); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", formatting.FormatSuccess(fmt.Sprintf("Set %s = %v", key, parsed)))
+	fmt.Printf("Configuration saved to: %s\n", path)
+	return nil
+}
+
+// unsetConfigValue removes a key from the config.yaml --userspace/--project
+// selects. Viper cannot unset a key, so the file's settings are rewritten
+// into a fresh viper without it.
+func unsetConfigValue(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	if _, ok := resolveConfigKeyKind(key); !ok {
+		return unknownConfigKeyError(key)
+	}
+
+	toProject, _, err := configScope(cmd)
+	if err != nil {
+		return err
+	}
+	target, path, err := configWriteTarget(toProject)
+	if err != nil {
+		return err
+	}
+
+	settings := target.AllSettings()
+	if !deleteConfigKey(settings, strings.Split(strings.ToLower(key), ".")) {
+		fmt.Printf("%s is not set in %s\n", key, path)
+		return nil
+	}
+
+	rewritten := viper.New()
+	rewritten.SetConfigFile(path)
+	if err := rewritten.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to rebuild config: %w", err)
+	}
+	if err := writeConfigTarget(rewritten, toProject); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", formatting.FormatSuccess(fmt.Sprintf("Unset %s", key)))
+	fmt.Printf("Configuration saved to: %s\n", path)
+	return nil
+}
+
+// writeConfigTarget saves a viper from configWriteTarget: the userspace
+// baseline in full, filled in from the defaults, the project file sparse.
+func writeConfigTarget(target *viper.Viper, toProject bool) error {
 	writeErr := utils.WriteViperConfigWithIndent(target, 2)
 	if toProject {
 		writeErr = utils.WriteViperConfigSparse(target, 2)
@@ -160,12 +278,34 @@ func setConfigValue(cmd *cobra.Command, args []string) error {
 	if writeErr != nil {
 		return fmt.Errorf("failed to save config: %w", writeErr)
 	}
-
-	fmt.Printf("%s\n", formatting.FormatSuccess(fmt.Sprintf("Set %s = %v", key, parsed)))
-	fmt.Printf("Configuration saved to: %s\n", path)
 	return nil
 }
 
+// deleteConfigKey removes the key at path from settings, along with any
+// section the removal leaves empty. Reports whether the key was there.
+func deleteConfigKey(settings map[string]any, path []string) bool {
+	if len(path) == 1 {
+		if _, ok := settings[path[0]]; !ok {
+			return false
+		}
+		delete(settings, path[0])
+		return true
+	}
+
+	section, ok := settings[path[0]].(map[string]any)
+	if !ok || !deleteConfigKey(section, path[1:]) {
+		return false
+	}
+	if len(section) == 0 {
+		delete(settings, path[0])
+	}
+	return true
+}
+
+func unknownConfigKeyError(key string) error {
+	return fmt.Errorf("unknown config key %q (use a dotted path into config.yaml, e.g. agent.model)", key)
+}
+
 // configWriteTarget returns a fresh viper bound to the file `config set` should
 // write, plus that path. Writes target the userspace baseline
 // (~/.infer/config.yaml) by default; --project (toProject) writes a sparse
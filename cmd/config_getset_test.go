@@ -90,3 +90,28 @@ func TestSplitListValue(t *testing.T) {
 		t.Fatalf("expected empty slice, got %v", got)
 	}
 }
+
+func TestDeleteConfigKey(t *testing.T) {
+	settings := map[string]any{
+		"agent": map[string]any{"model": "gpt"},
+		"tools": map[string]any{"bash": map[string]any{"enabled": true}, "grep": map[string]any{"enabled": true}},
+	}
+
+	if !deleteConfigKey(settings, []string{"tools", "bash", "enabled"}) {
+		t.Fatal("tools.bash.enabled should have been deleted")
+	}
+	want := map[string]any{
+		"agent": map[string]any{"model": "gpt"},
+		"tools": map[string]any{"grep": map[string]any{"enabled": true}},
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("emptied sections should be pruned, got %v", settings)
+	}
+
+	if deleteConfigKey(settings, []string{"agent", "max_turns"}) {
+		t.Error("deleting an absent key should report false")
+	}
+	if deleteConfigKey(settings, []string{"agent", "model", "x"}) {
+		t.Error("descending into a scalar should report false")
+	}
+}
@@ -54,6 +54,22 @@ func TestApplyMemoryEnvOverrides_Caps(t *testing.T) {
 	}
 }
 
+func TestApplyMemoryEnvOverrides_Distill(t *testing.T) {
+	t.Setenv("INFER_MEMORY_DISTILL_ENABLED", "false")
+	t.Setenv("INFER_MEMORY_DISTILL_MODEL", "openai/gpt-4o-mini")
+
+	cfg := &config.Config{}
+	cfg.Memory.Distill.Enabled = true
+	applyMemoryEnvOverrides(cfg)
+
+	if cfg.Memory.Distill.Enabled {
+		t.Error("Distill.Enabled = true, want false")
+	}
+	if cfg.Memory.Distill.Model != "openai/gpt-4o-mini" {
+		t.Errorf("Distill.Model = %q", cfg.Memory.Distill.Model)
+	}
+}
+
 func TestPruneMemoryRemindersIfDisabled(t *testing.T) {
 	countMemory := func(rs []config.ReminderConfig) int {
 		n := 0
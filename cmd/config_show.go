@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+)
+
+var configShowCmd = &cobra.Command{
+	Use:   "show [key]",
+	Short: "Show where the configuration comes from",
+	Long: `Show the layers the configuration is built from, lowest precedence first:
+built-in defaults, the userspace ~/.infer/config.yaml, the project
+.infer/config.yaml, INFER_* environment variables and flags.
+
+With --effective, print every setting with its value and the layer it comes
+from, which is what to look at when a value is not the one you expect.
+Secrets such as API keys are redacted. A key limits the output to a section:
+  infer config show
+  infer config show --effective
+  infer config show --effective a2a
+  infer config show --effective agent.model -f json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: showConfig,
+}
+
+func init() {
+	configShowCmd.Flags().Bool("effective", false, "Print every setting with its value and source")
+	configShowCmd.Flags().StringP("format", "f", "text", "Output format for --effective (text, json)")
+
+	configCmd.AddCommand(configShowCmd)
+}
+
+// configLayer is a config.yaml file read on its own, to tell which keys it sets
+type configLayer struct {
+	name     string
+	path     string
+	settings *viper.Viper
+}
+
+// effectiveConfigEntry is one setting of the effective config and its source
+type effectiveConfigEntry struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+func showConfig(cmd *cobra.Command, args []string) error {
+	effective, _ := cmd.Flags().GetBool("effective")
+	format, _ := cmd.Flags().GetString("format")
+
+	if Cfg == nil {
+		return fmt.Errorf("configuration is not loaded")
+	}
+	layers, err := readConfigLayers()
+	if err != nil {
+		return err
+	}
+
+	if !effective {
+		if len(args) == 1 {
+			return fmt.Errorf("a key can only be given with --effective")
+		}
+		fmt.Print(renderConfigLayers(layers))
+		return nil
+	}
+
+	prefix := ""
+	if len(args) == 1 {
+		prefix = strings.ToLower(args[0])
+	}
+	entries := effectiveConfigEntries(reflect.ValueOf(Cfg), prefix, layers, os.Getenv, configFlagChanged)
+	if len(entries) == 0 {
+		return fmt.Errorf("config key %q not found", args[0])
+	}
+
+	switch format {
+	case "json":
+		out, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format config as json: %w", err)
+		}
+		fmt.Println(string(out))
+	case "text":
+		fmt.Print(renderEffectiveConfig(entries))
+	default:
+		return fmt.Errorf("unsupported format %q: must be \"text\" or \"json\"", format)
+	}
+	return nil
+}
+
+// readConfigLayers reads the userspace and project config.yaml on their own,
+// in the order initConfig merges them.
+func readConfigLayers() ([]configLayer, error) {
+	home, project := configLayerFiles()
+
+	var layers []configLayer
+	for _, layer := range []configLayer{{name: "userspace", path: home}, {name: "project", path: project}} {
+		if layer.path == "" {
+			continue
+		}
+		layer.settings = viper.New()
+		layer.settings.SetConfigFile(layer.path)
+		if err := layer.settings.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", layer.path, err)
+		}
+		layers = append(layers, layer)
+	}
+	return layers, nil
+}
+
+// effectiveConfigEntries lists the leaves of cfg under prefix with the source
+// of each value, for `config show --effective`.
+func effectiveConfigEntries(cfg reflect.Value, prefix string, layers []configLayer, getenv func(string) string, flagChanged func(string) bool) []effectiveConfigEntry {
+	mcpPath := getEffectiveMCPConfigPath()
+	if !fileExists(mcpPath) {
+		mcpPath = ""
+	}
+
+	var entries []effectiveConfigEntry
+	walkConfigLeaves(cfg, "", func(key string, val reflect.Value) {
+		if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+".") {
+			return
+		}
+
+		var value any = val.Interface()
+		if isSecretConfigKey(key) && !isLeafZeroValue(val) {
+			value = "<redacted>"
+		}
+
+		entries = append(entries, effectiveConfigEntry{
+			Key:    key,
+			Value:  value,
+			Source: configValueSource(key, val.Kind(), layers, mcpPath, getenv, flagChanged),
+		})
+	})
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// configValueSource names the layer the effective value of key comes from,
+// following the precedence initConfig applies: env over project over
+// userspace over defaults, with flag/env appends added on top. mcp.* is read
+// from mcp.yaml, so config.yaml and env vars do not reach it.
+func configValueSource(key string, kind reflect.Kind, layers []configLayer, mcpPath string, getenv func(string) string, flagChanged func(string) bool) string {
+	if strings.HasPrefix(key, "mcp.") {
+		if mcpPath == "" {
+			return "default"
+		}
+		return "mcp.yaml " + mcpPath
+	}
+
+	source := "default"
+	for _, layer := range layers {
+		if layer.settings.IsSet(key) {
+			source = layer.name + " " + layer.path
+		}
+	}
+
+	// resolveViperEnvironmentVariables applies INFER_* to scalars and string lists only
+	envName := "INFER_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	switch kind {
+	case reflect.Map, reflect.Struct, reflect.Pointer, reflect.Interface:
+	default:
+		if getenv(envName) != "" {
+			source = "env " + envName
+		}
+	}
+
+	for _, a := range configAppendOverrides {
+		if a.key != key {
+			continue
+		}
+		switch {
+		case getenv(a.appendEnv) != "":
+			source += " + env " + a.appendEnv
+		case flagChanged(a.appendFlag):
+			source += " + flag --" + a.appendFlag
+		}
+	}
+	return source
+}
+
+// configFlagChanged reports whether a root persistent flag was given
+func configFlagChanged(name string) bool {
+	flag := rootCmd.PersistentFlags().Lookup(name)
+	return flag != nil && flag.Changed
+}
+
+// isSecretConfigKey reports whether key holds a credential that show must
+// not print
+func isSecretConfigKey(key string) bool {
+	name := key[strings.LastIndex(key, ".")+1:]
+	for _, secret := range []string{"api_key", "token", "password", "secret"} {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func renderConfigLayers(layers []configLayer) string {
+	var sb strings.Builder
+	sb.WriteString(listTitle("Configuration layers") + " " + listHint("(lowest precedence first)") + "\n\n")
+	sb.WriteString(listField("defaults", "built in") + "\n")
+
+	for _, name := range []string{"userspace", "project"} {
+		value := listHint("no config.yaml")
+		for _, layer := range layers {
+			if layer.name == name {
+				value = layer.path
+			}
+		}
+		sb.WriteString(listField(name, value) + "\n")
+	}
+
+	var envNames []string
+	for _, env := range os.Environ() {
+		if name, _, _ := strings.Cut(env, "="); strings.HasPrefix(name, "INFER_") {
+			envNames = append(envNames, name)
+		}
+	}
+	sort.Strings(envNames)
+	envValue := listHint("none set")
+	if len(envNames) > 0 {
+		envValue = strings.Join(envNames, ", ")
+	}
+	sb.WriteString(listField("env", envValue) + "\n")
+
+	var flags []string
+	for _, a := range configAppendOverrides {
+		if configFlagChanged(a.appendFlag) {
+			flags = append(flags, "--"+a.appendFlag)
+		}
+	}
+	flagValue := listHint("none set")
+	if len(flags) > 0 {
+		flagValue = strings.Join(flags, ", ")
+	}
+	sb.WriteString(listField("flags", flagValue) + "\n\n")
+
+	sb.WriteString(listHint("Run `infer config show --effective` to see every value and where it comes from.") + "\n")
+	return sb.String()
+}
+
+// renderEffectiveConfig prints one "key = value  # source" line per setting,
+// the value in JSON so strings, lists and empty values are unambiguous.
+func renderEffectiveConfig(entries []effectiveConfigEntry) string {
+	var sb strings.Builder
+	for _, entry := range entries {
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			value = []byte(fmt.Sprintf("%v", entry.Value))
+		}
+		fmt.Fprintf(&sb, "%s = %s  %s\n", entry.Key, value, listHint("# "+entry.Source))
+	}
+	return sb.String()
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	viper "github.com/spf13/viper"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func newTestConfigLayer(t *testing.T, name, yamlContent string) configLayer {
+	t.Helper()
+	settings := viper.New()
+	settings.SetConfigType("yaml")
+	if err := settings.ReadConfig(strings.NewReader(yamlContent)); err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+	return configLayer{name: name, path: "/" + name + "/config.yaml", settings: settings}
+}
+
+func TestConfigValueSource(t *testing.T) {
+	layers := []configLayer{
+		newTestConfigLayer(t, "userspace", "agent:\n  model: home-model\n  max_turns: 20\n"),
+		newTestConfigLayer(t, "project", "agent:\n  model: project-model\n"),
+	}
+	env := map[string]string{
+		"INFER_A2A_AGENTS":              "http://agent:8080",
+		"INFER_TOOLS_BASH_ALLOW_APPEND": "make",
+	}
+	getenv := func(name string) string { return env[name] }
+	noFlags := func(string) bool { return false }
+
+	tests := []struct {
+		key  string
+		kind reflect.Kind
+		want string
+	}{
+		{"agent.model", reflect.String, "project /project/config.yaml"},
+		{"agent.max_turns", reflect.Int, "userspace /userspace/config.yaml"},
+		{"agent.verbose_tools", reflect.Bool, "default"},
+		{"a2a.agents", reflect.Slice, "env INFER_A2A_AGENTS"},
+		{"tools.bash.mode.all.allow", reflect.Slice, "default + env INFER_TOOLS_BASH_ALLOW_APPEND"},
+		{"mcp.enabled", reflect.Bool, "mcp.yaml .infer/mcp.yaml"},
+	}
+	for _, tt := range tests {
+		if got := configValueSource(tt.key, tt.kind, layers, ".infer/mcp.yaml", getenv, noFlags); got != tt.want {
+			t.Errorf("configValueSource(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	flagged := configValueSource("tools.bash.mode.all.allow", reflect.Slice, nil, "", func(string) string { return "" },
+		func(name string) bool { return name == "tools-bash-allow-append" })
+	if flagged != "default + flag --tools-bash-allow-append" {
+		t.Errorf("flag append source = %q", flagged)
+	}
+}
+
+func TestEffectiveConfigEntries(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Gateway.APIKey = "sk-secret"
+
+	entries := effectiveConfigEntries(reflect.ValueOf(cfg), "gateway", nil, func(string) string { return "" }, func(string) bool { return false })
+	if len(entries) == 0 {
+		t.Fatal("expected the gateway section")
+	}
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Key, "gateway.") {
+			t.Errorf("entry %q is outside the requested section", entry.Key)
+		}
+		if entry.Key == "gateway.api_key" && entry.Value != "<redacted>" {
+			t.Errorf("the API key must be redacted, got %v", entry.Value)
+		}
+	}
+}
@@ -78,8 +78,9 @@ Examples:
 
   # Emit one JSON object per line for piping into jq
   infer conversations show <session-id> --format json | jq .`,
-	Args: cobra.ExactArgs(1),
-	RunE: showConversation,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeConversationIDs),
+	RunE:              showConversation,
 }
 
 func init() {
@@ -0,0 +1,544 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	services "github.com/inference-gateway/cli/internal/services"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// doctorCheckTimeout bounds each network check so an unreachable host cannot
+// stall the whole report.
+const doctorCheckTimeout = 10 * time.Second
+
+// doctorStatus is the outcome of a single doctor check
+type doctorStatus string
+
+const (
+	doctorOK   doctorStatus = "ok"
+	doctorWarn doctorStatus = "warn"
+	doctorFail doctorStatus = "fail"
+	doctorSkip doctorStatus = "skip"
+)
+
+// doctorCheck is one line of the doctor report. Fix says what to do about a
+// warning or failure.
+type doctorCheck struct {
+	Name   string       `json:"name"`
+	Status doctorStatus `json:"status"`
+	Detail string       `json:"detail"`
+	Fix    string       `json:"fix,omitempty"`
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the CLI setup and suggest fixes",
+	Long: `Check the setup the CLI depends on and print a fix for every problem found:
+- config.yaml syntax, unknown keys and invalid values
+- gateway reachability, API key and the default model
+- the storage backend
+- MCP servers and A2A agents
+- ripgrep, used by the Grep tool
+
+Exits with a non-zero status when a check fails, so it can gate scripts.
+
+Examples:
+  infer doctor
+  infer doctor --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return RunDoctorCommand(Cfg, format)
+	},
+}
+
+// RunDoctorCommand runs every doctor check against cfg and prints the report
+func RunDoctorCommand(cfg *config.Config, format string) error {
+	checks := runDoctorChecks(context.Background(), cfg)
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(map[string]any{"checks": checks}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal doctor report: %w", err)
+		}
+		fmt.Println(string(data))
+	case "text", "":
+		fmt.Print(renderDoctorReport(checks))
+	default:
+		return fmt.Errorf("unsupported format %q: must be \"text\" or \"json\"", format)
+	}
+
+	if failed := countDoctorChecks(checks, doctorFail); failed > 0 {
+		return fmt.Errorf("%d doctor check(s) failed", failed)
+	}
+	return nil
+}
+
+func runDoctorChecks(ctx context.Context, cfg *config.Config) []doctorCheck {
+	checks := checkConfigFiles()
+	checks = append(checks, checkGatewayAndModels(ctx, cfg)...)
+	checks = append(checks, checkStorage(ctx, cfg))
+	checks = append(checks, checkMCPServers(ctx, cfg)...)
+	checks = append(checks, checkA2AAgents(ctx, cfg)...)
+	checks = append(checks, checkRipgrep(cfg))
+	return checks
+}
+
+// checkConfigFiles reports the errors the config failed to load with, then
+// looks for keys in each config.yaml layer that the CLI does not read, which
+// it otherwise ignores without a word.
+func checkConfigFiles() []doctorCheck {
+	var checks []doctorCheck
+	for _, err := range configLoadErrs {
+		checks = append(checks, doctorCheck{
+			Name:   "Config",
+			Status: doctorFail,
+			Detail: err.Error(),
+			Fix:    "Correct the value in config.yaml, or reset it with `infer config set <key> <value>`; the other checks ran with the settings that did load.",
+		})
+	}
+
+	home, project := configLayerFiles()
+	for _, path := range []string{home, project} {
+		if path != "" {
+			checks = append(checks, checkConfigFile(path))
+		}
+	}
+	if len(checks) == 0 {
+		checks = append(checks, doctorCheck{
+			Name:   "Config",
+			Status: doctorWarn,
+			Detail: "no config.yaml found, using defaults",
+			Fix:    "Run `infer init` to create .infer/config.yaml.",
+		})
+	}
+	return checks
+}
+
+func checkConfigFile(path string) doctorCheck {
+	check := doctorCheck{Name: "Config " + path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		check.Status, check.Detail = doctorFail, err.Error()
+		check.Fix = "Make the file readable, or remove it to use the defaults."
+		return check
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("invalid YAML: %v", err)
+		check.Fix = "Fix the syntax at the reported line; `infer config init --overwrite` writes a fresh file."
+		return check
+	}
+
+	unknown := unknownConfigKeys(raw, reflect.TypeOf(config.Config{}), "")
+	if len(unknown) > 0 {
+		check.Status = doctorWarn
+		check.Detail = "keys the CLI does not read: " + strings.Join(unknown, ", ")
+		check.Fix = "Check the spelling against docs/configuration-reference.md; channels, prompts, hooks and the like live in their own files under .infer/."
+		return check
+	}
+
+	check.Status, check.Detail = doctorOK, "valid"
+	return check
+}
+
+// unknownConfigKeys returns the dotted paths of the keys in raw that have no
+// matching mapstructure field in t. Maps and lists are not descended into,
+// since their keys are user data.
+func unknownConfigKeys(raw map[string]any, t reflect.Type, prefix string) []string {
+	fields := make(map[string]reflect.Type)
+	collectConfigFields(t, fields)
+
+	var unknown []string
+	for key, value := range raw {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		fieldType, ok := fields[key]
+		if !ok {
+			unknown = append(unknown, path)
+			continue
+		}
+		if nested, isMap := value.(map[string]any); isMap && fieldType.Kind() == reflect.Struct {
+			unknown = append(unknown, unknownConfigKeys(nested, fieldType, path)...)
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown
+}
+
+// collectConfigFields maps the config keys of struct t to their field types,
+// flattening ",squash" and untagged embedded structs the way viper decodes them.
+func collectConfigFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		squash := slices.Contains(strings.Split(opts, ","), "squash") || (field.Anonymous && name == "")
+		switch {
+		case squash && fieldType.Kind() == reflect.Struct:
+			collectConfigFields(fieldType, fields)
+		case name == "-":
+		case name == "":
+			fields[strings.ToLower(field.Name)] = fieldType
+		default:
+			fields[name] = fieldType
+		}
+	}
+}
+
+// doctorGatewayURL is the gateway's base URL, without the /v1 API prefix
+func doctorGatewayURL(cfg *config.Config) string {
+	url := strings.TrimSuffix(cfg.Gateway.URL, "/")
+	if url == "" {
+		url = "http://localhost:8080"
+	}
+	return strings.TrimSuffix(url, "/v1")
+}
+
+// checkGatewayAndModels checks that the gateway answers, accepts the API key
+// and serves the default model. The later checks are skipped once one fails.
+func checkGatewayAndModels(ctx context.Context, cfg *config.Config) []doctorCheck {
+	baseURL := doctorGatewayURL(cfg)
+	gateway := doctorCheck{Name: "Gateway"}
+
+	resp, err := doctorGet(ctx, baseURL+"/health", "")
+	if err != nil {
+		gateway.Status, gateway.Detail = doctorFail, fmt.Sprintf("%s is unreachable: %v", baseURL, err)
+		gateway.Fix = "Start the gateway, or point gateway.url at a running one with `infer config set gateway.url <url>`."
+		if cfg.Gateway.Run {
+			gateway.Status = doctorWarn
+			gateway.Detail = fmt.Sprintf("%s is not running; gateway.run is set, so the CLI starts it when a session begins", baseURL)
+			gateway.Fix = "Nothing to do unless starting a chat fails; then check the container runtime with `docker ps`."
+		}
+		return []doctorCheck{gateway, skippedDoctorCheck("API key"), skippedDoctorCheck("Model")}
+	}
+	_ = resp.Body.Close()
+	gateway.Status, gateway.Detail = doctorOK, "reachable at "+baseURL
+
+	apiKey, models := checkGatewayModels(ctx, cfg, baseURL)
+	if apiKey.Status != doctorOK {
+		return []doctorCheck{gateway, apiKey, skippedDoctorCheck("Model")}
+	}
+	return []doctorCheck{gateway, apiKey, checkDefaultModel(cfg, models)}
+}
+
+// checkGatewayModels lists the gateway's models, which needs a valid API key
+// when the gateway enforces authentication.
+func checkGatewayModels(ctx context.Context, cfg *config.Config, baseURL string) (doctorCheck, []string) {
+	check := doctorCheck{Name: "API key"}
+
+	resp, err := doctorGet(ctx, baseURL+"/v1/models", cfg.Gateway.APIKey)
+	if err != nil {
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("listing models failed: %v", err)
+		check.Fix = "Check the gateway logs; it answered /health but not /v1/models."
+		return check, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("the gateway rejected the API key (%s)", resp.Status)
+		check.Fix = "Set the gateway's key with `infer config set gateway.api_key <key>` or INFER_GATEWAY_API_KEY."
+		return check, nil
+	case resp.StatusCode != http.StatusOK:
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("listing models returned %s", resp.Status)
+		check.Fix = "Check the gateway logs and its provider configuration."
+		return check, nil
+	}
+
+	var body struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&body); err != nil {
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("invalid models response: %v", err)
+		check.Fix = "Make sure gateway.url points at an Inference Gateway."
+		return check, nil
+	}
+
+	models := make([]string, 0, len(body.Data))
+	for _, model := range body.Data {
+		models = append(models, model.ID)
+	}
+
+	check.Status, check.Detail = doctorOK, "accepted"
+	if cfg.Gateway.APIKey == "" {
+		check.Detail = "not set, and the gateway does not require one"
+	}
+	return check, models
+}
+
+func checkDefaultModel(cfg *config.Config, models []string) doctorCheck {
+	check := doctorCheck{Name: "Model"}
+
+	switch {
+	case len(models) == 0:
+		check.Status, check.Detail = doctorFail, "the gateway serves no models"
+		check.Fix = "Set a provider API key in the gateway's environment; `infer env` lists them."
+	case cfg.Agent.Model == "":
+		check.Status, check.Detail = doctorWarn, fmt.Sprintf("no default model set; %d available", len(models))
+		check.Fix = fmt.Sprintf("Pick one with `infer config set agent.model %s`.", models[0])
+	case !slices.Contains(models, cfg.Agent.Model):
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("%s is not served by the gateway", cfg.Agent.Model)
+		check.Fix = fmt.Sprintf("Pick an available model with `infer config set agent.model %s`, or configure its provider on the gateway.", models[0])
+	default:
+		check.Status, check.Detail = doctorOK, fmt.Sprintf("%s is available", cfg.Agent.Model)
+	}
+	return check
+}
+
+// doctorGet fetches url within doctorCheckTimeout. The body is read before the
+// timeout's context is released, so callers can decode it afterwards.
+func doctorGet(ctx context.Context, url, apiKey string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// checkStorage opens the configured storage backend and reads from it. The
+// CLI falls back to in-memory storage when the backend fails, losing history,
+// so a failure here is worth fixing.
+func checkStorage(ctx context.Context, cfg *config.Config) doctorCheck {
+	check := doctorCheck{Name: "Storage"}
+	if !cfg.Storage.Enabled {
+		check.Status, check.Detail = doctorWarn, "disabled; conversations are kept in memory only"
+		check.Fix = "Enable it with `infer config set storage.enabled true` to keep conversation history."
+		return check
+	}
+
+	storageConfig := storage.NewStorageFromConfig(cfg)
+	stores, err := storage.NewStorage(storageConfig)
+	if err != nil {
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("%s backend failed to open: %v", storageConfig.Type, err)
+		check.Fix = fmt.Sprintf("Check the storage.%s settings, or switch with `infer config set storage.type sqlite`.", storageConfig.Type)
+		return check
+	}
+	defer func() { _ = stores.Conversations.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+	defer cancel()
+	if err := stores.Conversations.Health(ctx); err != nil {
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("%s backend is unhealthy: %v", storageConfig.Type, err)
+		check.Fix = fmt.Sprintf("Make sure the %s server is running and the credentials in storage.%s are right.", storageConfig.Type, storageConfig.Type)
+		return check
+	}
+
+	check.Status, check.Detail = doctorOK, fmt.Sprintf("%s backend is healthy", storageConfig.Type)
+	return check
+}
+
+// checkMCPServers pings each enabled MCP server. Servers with run set are
+// started by the CLI, so they are pinged where they listen once started,
+// without starting them here.
+func checkMCPServers(ctx context.Context, cfg *config.Config) []doctorCheck {
+	if !cfg.MCP.Enabled {
+		return []doctorCheck{{Name: "MCP", Status: doctorSkip, Detail: "disabled"}}
+	}
+
+	mcpConfig := cfg.MCP
+	mcpConfig.Servers = make([]config.MCPServerEntry, 0, len(cfg.MCP.Servers))
+	for _, server := range cfg.MCP.Servers {
+		if server.Enabled {
+			server.Run = false
+			mcpConfig.Servers = append(mcpConfig.Servers, server)
+		}
+	}
+	if len(mcpConfig.Servers) == 0 {
+		return []doctorCheck{{Name: "MCP", Status: doctorSkip, Detail: "no servers enabled"}}
+	}
+
+	manager := services.NewMCPManager(domain.SessionID("doctor"), &mcpConfig, nil, nil)
+	defer func() { _ = manager.Close() }()
+
+	checks := make([]doctorCheck, 0, len(mcpConfig.Servers))
+	for _, server := range cfg.MCP.Servers {
+		if !server.Enabled {
+			continue
+		}
+		check := doctorCheck{Name: "MCP " + server.Name}
+
+		ctx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+		err := manager.GetClient(server.Name).PingServer(ctx, server.Name)
+		cancel()
+
+		switch {
+		case err == nil:
+			check.Status, check.Detail = doctorOK, "responding at "+server.GetURL()
+		case server.Run:
+			check.Status, check.Detail = doctorWarn, fmt.Sprintf("not running at %s; the CLI starts its container when a session begins", server.GetURL())
+			check.Fix = "Nothing to do unless its tools are missing in chat; then check the container runtime and the server's image."
+		default:
+			check.Status, check.Detail = doctorFail, fmt.Sprintf("%s: %v", server.GetURL(), err)
+			check.Fix = fmt.Sprintf("Start the server, fix its address in .infer/mcp.yaml, or disable it with `infer mcp disable %s`.", server.Name)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkA2AAgents fetches the agent card of every configured A2A agent
+func checkA2AAgents(ctx context.Context, cfg *config.Config) []doctorCheck {
+	if !cfg.A2A.Enabled {
+		return []doctorCheck{{Name: "A2A", Status: doctorSkip, Detail: "disabled"}}
+	}
+
+	agentService := services.NewA2AAgentService(cfg)
+	urls := agentService.GetConfiguredAgents()
+	if len(urls) == 0 {
+		return []doctorCheck{{
+			Name:   "A2A",
+			Status: doctorWarn,
+			Detail: "enabled, but no agents are configured",
+			Fix:    "Add one with `infer agents add <name> <url>`, or disable A2A with `infer config set a2a.enabled false`.",
+		}}
+	}
+
+	checks := make([]doctorCheck, 0, len(urls))
+	for _, url := range urls {
+		check := doctorCheck{Name: "A2A " + url}
+
+		ctx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+		card, err := agentService.GetAgentCard(ctx, url)
+		cancel()
+
+		if err != nil {
+			check.Status, check.Detail = doctorFail, fmt.Sprintf("agent card unavailable: %v", err)
+			check.Fix = "Start the agent, or correct its URL in .infer/agents.yaml."
+		} else {
+			check.Status, check.Detail = doctorOK, fmt.Sprintf("%s %s", card.Name, card.Version)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkRipgrep looks for rg, which the Grep tool prefers over its slower Go
+// implementation unless tools.grep.backend says otherwise.
+func checkRipgrep(cfg *config.Config) doctorCheck {
+	check := doctorCheck{Name: "ripgrep"}
+	switch {
+	case !cfg.Tools.Grep.Enabled:
+		check.Status, check.Detail = doctorSkip, "the Grep tool is disabled"
+		return check
+	case cfg.Tools.Grep.Backend == "go" || cfg.Tools.Grep.Backend == "native":
+		check.Status, check.Detail = doctorSkip, "tools.grep.backend uses the Go implementation"
+		return check
+	}
+
+	path, err := exec.LookPath("rg")
+	if err != nil {
+		check.Status, check.Detail = doctorWarn, "rg not found; the Grep tool falls back to its slower Go implementation"
+		check.Fix = "Install ripgrep: https://github.com/BurntSushi/ripgrep#installation"
+		return check
+	}
+	check.Status, check.Detail = doctorOK, path
+	return check
+}
+
+func skippedDoctorCheck(name string) doctorCheck {
+	return doctorCheck{Name: name, Status: doctorSkip, Detail: "skipped, see above"}
+}
+
+func countDoctorChecks(checks []doctorCheck, status doctorStatus) int {
+	n := 0
+	for _, check := range checks {
+		if check.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// renderDoctorReport renders the checks one per line, each problem followed
+// by its fix, and a summary line.
+func renderDoctorReport(checks []doctorCheck) string {
+	var sb strings.Builder
+	sb.WriteString(listTitle("Inference Gateway CLI Doctor") + "\n\n")
+
+	for _, check := range checks {
+		detail := check.Detail
+		if check.Status == doctorSkip {
+			detail = listHint(detail)
+		}
+		fmt.Fprintf(&sb, "  %s %s %s\n", doctorStatusIcon(check.Status), listLabelStyle.Render(check.Name+":"), detail)
+		if check.Fix != "" {
+			fmt.Fprintf(&sb, "      %s\n", listHint("Fix: "+check.Fix))
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n%s\n", listHint(fmt.Sprintf("%d ok, %d warnings, %d failed, %d skipped",
+		countDoctorChecks(checks, doctorOK), countDoctorChecks(checks, doctorWarn),
+		countDoctorChecks(checks, doctorFail), countDoctorChecks(checks, doctorSkip))))
+	return sb.String()
+}
+
+func doctorStatusIcon(status doctorStatus) string {
+	switch status {
+	case doctorOK:
+		return icons.CheckMark
+	case doctorFail:
+		return icons.CrossMark
+	case doctorWarn:
+		return "!"
+	default:
+		return "-"
+	}
+}
+
+func init() {
+	doctorCmd.Flags().String("format", "text", "Output format (text, json)")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestUnknownConfigKeys(t *testing.T) {
+	raw := map[string]any{
+		"gateway":         map[string]any{"url": "http://localhost:8080", "api_kye": "x"},
+		"agent":           map[string]any{"model": "openai/gpt-4", "max_turns": 10},
+		"context_windows": map[string]any{"my/model": 8192},
+		"channels":        map[string]any{"enabled": true},
+		"chatt":           map[string]any{},
+	}
+
+	got := unknownConfigKeys(raw, reflect.TypeOf(config.Config{}), "")
+	want := []string{"channels", "chatt", "gateway.api_kye"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unknownConfigKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestUnknownConfigKeysSquash(t *testing.T) {
+	type Base struct {
+		Name string `mapstructure:"name"`
+	}
+	type entry struct {
+		Base  `mapstructure:",squash"`
+		Count *int `mapstructure:"count,omitempty"`
+	}
+
+	got := unknownConfigKeys(map[string]any{"name": "a", "count": 1, "extra": 2}, reflect.TypeOf(entry{}), "entry")
+	if !reflect.DeepEqual(got, []string{"entry.extra"}) {
+		t.Errorf("unknownConfigKeys() = %v, want [entry.extra]", got)
+	}
+}
+
+// newTestGateway serves /health and a /v1/models listing that requires apiKey
+func newTestGateway(t *testing.T, apiKey string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [{"id": "openai/gpt-4"}, {"id": "anthropic/claude"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckGatewayAndModels(t *testing.T) {
+	gateway := newTestGateway(t, "secret")
+
+	tests := []struct {
+		name   string
+		url    string
+		apiKey string
+		model  string
+		want   []doctorStatus
+	}{
+		{name: "healthy", url: gateway.URL + "/v1", apiKey: "secret", model: "openai/gpt-4", want: []doctorStatus{doctorOK, doctorOK, doctorOK}},
+		{name: "wrong api key", url: gateway.URL, apiKey: "nope", model: "openai/gpt-4", want: []doctorStatus{doctorOK, doctorFail, doctorSkip}},
+		{name: "unknown model", url: gateway.URL, apiKey: "secret", model: "openai/gpt-9", want: []doctorStatus{doctorOK, doctorOK, doctorFail}},
+		{name: "no default model", url: gateway.URL, apiKey: "secret", want: []doctorStatus{doctorOK, doctorOK, doctorWarn}},
+		{name: "unreachable", url: "http://127.0.0.1:1", want: []doctorStatus{doctorFail, doctorSkip, doctorSkip}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Gateway: config.GatewayConfig{URL: tt.url, APIKey: tt.apiKey},
+				Agent:   config.AgentConfig{Model: tt.model},
+			}
+
+			checks := checkGatewayAndModels(context.Background(), cfg)
+			got := make([]doctorStatus, 0, len(checks))
+			for _, check := range checks {
+				got = append(got, check.Status)
+				if (check.Status == doctorFail || check.Status == doctorWarn) && check.Fix == "" {
+					t.Errorf("%s: a problem should come with a fix", check.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statuses = %v, want %v (%+v)", got, tt.want, checks)
+			}
+		})
+	}
+}
+
+func TestCheckRipgrepSkipsGoBackend(t *testing.T) {
+	cfg := &config.Config{Tools: config.ToolsConfig{Grep: config.GrepToolConfig{Enabled: true, Backend: "go"}}}
+	if check := checkRipgrep(cfg); check.Status != doctorSkip {
+		t.Errorf("status = %s, want skip with the Go backend", check.Status)
+	}
+}
+
+func TestRenderDoctorReport(t *testing.T) {
+	out := renderDoctorReport([]doctorCheck{
+		{Name: "Gateway", Status: doctorOK, Detail: "reachable"},
+		{Name: "Model", Status: doctorFail, Detail: "missing", Fix: "Pick another."},
+		{Name: "MCP", Status: doctorSkip, Detail: "disabled"},
+	})
+
+	for _, want := range []string{"Gateway:", "Fix: Pick another.", "1 ok, 0 warnings, 1 failed, 1 skipped"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report is missing %q:\n%s", want, out)
+		}
+	}
+}
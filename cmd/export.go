@@ -16,10 +16,11 @@ import (
 )
 
 var exportCmd = &cobra.Command{
-	Use:   "export <session-id>",
-	Short: "Export conversation to markdown",
-	Long:  `Export a conversation session to a markdown file.`,
-	Args:  cobra.RangeArgs(0, 1),
+	Use:               "export <session-id>",
+	Short:             "Export conversation to markdown",
+	Long:              `Export a conversation session to a markdown file.`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeFirstArg(completeConversationIDs),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return fmt.Errorf("session ID required. Provide as argument: infer export <session-id>")
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	services "github.com/inference-gateway/cli/internal/services"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Search past conversations",
+	Long:  `Search the message history of every conversation in the configured storage backend.`,
+}
+
+var historySearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Full-text search the messages of all conversations",
+	Long: `Search the message content of every saved conversation, most recently
+updated first. A message matches when it contains every word of the query,
+ignoring case; hidden entries such as system reminders are not searched.
+Each matching conversation is listed with snippets of its first matches.
+
+In chat, /search <text> runs the same search.
+
+Examples:
+  infer history search "rate limit"
+  infer history search postgres migration --limit 5
+  infer history search flaky test --open
+  infer history search oauth --format json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		open, _ := cmd.Flags().GetBool("open")
+		format, _ := cmd.Flags().GetString("format")
+		return RunHistorySearchCommand(Cfg, strings.Join(args, " "), limit, open, format)
+	},
+}
+
+func init() {
+	historySearchCmd.Flags().IntP("limit", "l", 20, "Maximum number of conversations to list (0 for all)")
+	historySearchCmd.Flags().Bool("open", false, "Resume the most recent matching conversation in chat")
+	historySearchCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+
+	historyCmd.AddCommand(historySearchCmd)
+	rootCmd.AddCommand(historyCmd)
+}
+
+// RunHistorySearchCommand searches the stored conversations for query and
+// optionally resumes the most recent match
+func RunHistorySearchCommand(cfg *config.Config, query string, limit int, open bool, format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q: must be \"text\" or \"json\"", format)
+	}
+
+	stores, err := storage.NewStorage(storage.NewStorageFromConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	hits, err := services.SearchConversations(context.Background(), stores.Conversations, query, limit)
+	_ = stores.Conversations.Close()
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		out, err := json.MarshalIndent(map[string]any{"query": query, "conversations": hits, "count": len(hits)}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal search results to JSON: %w", err)
+		}
+		fmt.Println(string(out))
+	} else {
+		fmt.Print(renderHistorySearch(query, hits, open))
+	}
+
+	if !open || len(hits) == 0 {
+		return nil
+	}
+	if !isInteractiveTerminal() {
+		return fmt.Errorf("--open needs an interactive terminal")
+	}
+	return StartChatSession(cfg, hits[0].ConversationID, false, nil)
+}
+
+func renderHistorySearch(query string, hits []domain.ConversationSearchHit, open bool) string {
+	if len(hits) == 0 {
+		return fmt.Sprintf("No conversations mention %q.\n", query)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(listTitle(fmt.Sprintf("Conversations matching %q (%d)", query, len(hits))) + "\n\n")
+	for _, hit := range hits {
+		fmt.Fprintf(&sb, "%s  %s\n", listLabelStyle.Render(hit.Title), listHint(fmt.Sprintf("%s · %s · %d matching messages",
+			hit.ConversationID, hit.UpdatedAt.Local().Format("2006-01-02 15:04"), hit.Matches)))
+		for _, snippet := range hit.Snippets {
+			fmt.Fprintf(&sb, "  %s %s\n", listHint(snippet.Role+":"), snippet.Snippet)
+		}
+		sb.WriteString("\n")
+	}
+
+	if !open {
+		sb.WriteString(listHint("Resume one with: infer chat --resume <id> (or add --open for the first)") + "\n")
+	}
+	return sb.String()
+}
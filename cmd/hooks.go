@@ -0,0 +1,423 @@
+package cmd
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	constants "github.com/inference-gateway/cli/internal/constants"
+	container "github.com/inference-gateway/cli/internal/container"
+	gitdiff "github.com/inference-gateway/cli/internal/services/gitdiff"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// gitHookMarker identifies the hooks written by `infer hooks install`, so
+// they are the only ones replaced or removed without --force
+const gitHookMarker = "# Installed by infer hooks install"
+
+// gitDiffMaxBytes caps the diff sent to the model
+const gitDiffMaxBytes = 200_000
+
+// gitHookNames are the git hooks infer can install
+var gitHookNames = []string{"pre-commit", "pre-push"}
+
+var reviewFindingPattern = regexp.MustCompile(`(?i)^\s*(?:[-*]\s*)?\[(critical|high|medium|low)\]\s*(.+)$`)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Review changes with a model from git pre-commit and pre-push hooks",
+	Long: `Install git hooks that send the staged diff (pre-commit) or the commits
+being pushed (pre-push) to a model for review before they leave your machine.
+
+The review runs headless with the git.review.system_prompt prompt from
+prompts.yaml and the git.review.model model (default: agent.model). Findings
+whose severity is listed in git.review.block_on (default: critical, high)
+block the commit or push; the others are only printed. A failed review never
+blocks. Bypass the hook once with git's --no-verify.
+
+These are git hooks, unrelated to the agent-loop command hooks of hooks.yaml.`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the review git hooks in the current repository",
+	Long: `Install the review hooks in the current repository's hooks directory
+(honouring core.hooksPath). An existing hook that infer did not write is kept
+unless --force is given, in which case it is moved to <hook>.bak.
+
+Examples:
+  infer hooks install
+  infer hooks install --hook pre-commit,pre-push
+  infer hooks install --hook pre-push --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hooks, _ := cmd.Flags().GetStringSlice("hook")
+		force, _ := cmd.Flags().GetBool("force")
+		return RunHooksInstallCommand(hooks, force)
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the review git hooks from the current repository",
+	Long: `Remove the hooks written by infer hooks install, restoring a hook moved
+aside by --force. Hooks infer did not write are left alone.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hooks, _ := cmd.Flags().GetStringSlice("hook")
+		return RunHooksUninstallCommand(hooks)
+	},
+}
+
+var hooksRunCmd = &cobra.Command{
+	Use:   "run <pre-commit|pre-push> [remote] [url]",
+	Short: "Review the changes of a git hook (called by the installed hooks)",
+	Long: `Review the staged diff (pre-commit) or the commits being pushed (pre-push,
+read from the ref lines git passes on stdin) and fail when the model reports
+a finding with a blocking severity. The installed hooks call this command.`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remote := ""
+		if len(args) > 1 {
+			remote = args[1]
+		}
+		return RunHooksRunCommand(Cfg, args[0], remote, os.Stdin)
+	},
+}
+
+// reviewFinding is one "[SEVERITY] description" line of a review
+type reviewFinding struct {
+	Severity string
+	Text     string
+}
+
+// RunHooksInstallCommand writes the review hooks into the repository's hooks
+// directory
+func RunHooksInstallCommand(hooks []string, force bool) error {
+	if err := validateGitHookNames(hooks); err != nil {
+		return err
+	}
+	dir, err := gitHooksDir()
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		backup, err := installGitHook(dir, hook, force)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s Installed %s hook\n", icons.CheckMark, hook)
+		if backup != "" {
+			fmt.Printf("  The previous hook was moved to %s\n", backup)
+		}
+	}
+	fmt.Println(listHint("Bypass a review once with git commit --no-verify or git push --no-verify"))
+	return nil
+}
+
+// RunHooksUninstallCommand removes the review hooks from the repository
+func RunHooksUninstallCommand(hooks []string) error {
+	if err := validateGitHookNames(hooks); err != nil {
+		return err
+	}
+	dir, err := gitHooksDir()
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		removed, err := uninstallGitHook(dir, hook)
+		if err != nil {
+			return err
+		}
+		if removed {
+			fmt.Printf("%s Removed %s hook\n", icons.CheckMark, hook)
+		}
+	}
+	return nil
+}
+
+// RunHooksRunCommand reviews the changes of hook and returns an error when the
+// review has blocking findings. Anything that prevents the review from
+// running is reported and lets the commit or push through.
+func RunHooksRunCommand(cfg *config.Config, hook, remote string, stdin io.Reader) error {
+	if err := validateGitHookNames([]string{hook}); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	diff, err := gitHookDiff(ctx, hook, remote, stdin)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return nil
+	}
+
+	model := cmp.Or(cfg.Git.Review.Model, cfg.Agent.Model)
+	if model == "" {
+		fmt.Fprintf(os.Stderr, "%s No review model configured (git.review.model or agent.model), skipping the review\n", icons.CrossMark)
+		return nil
+	}
+
+	svc := container.NewServiceContainer(cfg)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = svc.Shutdown(ctx)
+	}()
+	if err := svc.GetGatewayManager().EnsureStarted(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Failed to start inference gateway, skipping the review: %v\n", icons.CrossMark, err)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "%s Reviewing the %s changes with %s...\n", icons.BulletIcon, hook, model)
+	review, err := completeWithDiff(ctx, svc.NewSDKClient(), model, cfg.Prompts.Git.Review.SystemPrompt, "", diff)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s Review failed, not blocking: %v\n", icons.CrossMark, err)
+		return nil
+	}
+
+	findings := parseReviewFindings(review)
+	blocking := blockingReviewFindings(findings, cfg.Git.Review.BlockOn)
+	fmt.Fprint(os.Stderr, renderReviewFindings(findings, cfg.Git.Review.BlockOn))
+	if blocking > 0 {
+		return fmt.Errorf("review found %d blocking issue(s) (%s); fix them or bypass with --no-verify",
+			blocking, strings.Join(cfg.Git.Review.BlockOn, ", "))
+	}
+	return nil
+}
+
+func validateGitHookNames(hooks []string) error {
+	if len(hooks) == 0 {
+		return fmt.Errorf("no hooks given: must be %s", strings.Join(gitHookNames, " or "))
+	}
+	for _, hook := range hooks {
+		if !slices.Contains(gitHookNames, hook) {
+			return fmt.Errorf("unsupported hook %q: must be %s", hook, strings.Join(gitHookNames, " or "))
+		}
+	}
+	return nil
+}
+
+// gitOutput runs git in the current directory, bounded by
+// constants.GitCommandTimeout, and returns its stdout
+func gitOutput(ctx context.Context, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.GitCommandTimeout)
+	defer cancel()
+
+	out, err := gitdiff.RunGit(ctx, "", args...)
+	return string(out), err
+}
+
+// gitHooksDir returns the absolute hooks directory of the current repository
+func gitHooksDir() (string, error) {
+	out, err := gitOutput(context.Background(), "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %w", err)
+	}
+	return filepath.Abs(strings.TrimSpace(out))
+}
+
+// gitHookScript is the hook file for hook. It skips the review when infer
+// is not on PATH rather than blocking every commit.
+func gitHookScript(hook string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s: reviews the changes with a model.
+# Bypass once with --no-verify, remove with: infer hooks uninstall
+if ! command -v infer >/dev/null 2>&1; then
+	echo "infer not found on PATH, skipping the review" >&2
+	exit 0
+fi
+exec infer hooks run %s "$@"
+`, gitHookMarker, hook)
+}
+
+func isInferGitHook(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), gitHookMarker)
+}
+
+// installGitHook writes hook into dir. A foreign hook is an error unless
+// force is set, in which case it is moved aside and its new path returned.
+func installGitHook(dir, hook string, force bool) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	path := filepath.Join(dir, hook)
+	backup := ""
+	if _, err := os.Stat(path); err == nil && !isInferGitHook(path) {
+		if !force {
+			return "", fmt.Errorf("%s already exists and was not written by infer; rerun with --force to replace it", path)
+		}
+		backup = path + ".bak"
+		if err := os.Rename(path, backup); err != nil {
+			return "", fmt.Errorf("failed to move the existing %s hook aside: %w", hook, err)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(gitHookScript(hook)), 0755); err != nil {
+		return "", fmt.Errorf("failed to write %s hook: %w", hook, err)
+	}
+	return backup, nil
+}
+
+// uninstallGitHook removes hook from dir when infer wrote it, restoring a
+// hook moved aside by --force. It reports whether anything was removed.
+func uninstallGitHook(dir, hook string) (bool, error) {
+	path := filepath.Join(dir, hook)
+	if !isInferGitHook(path) {
+		return false, nil
+	}
+	if err := os.Remove(path); err != nil {
+		return false, fmt.Errorf("failed to remove %s hook: %w", hook, err)
+	}
+	if _, err := os.Stat(path + ".bak"); err == nil {
+		if err := os.Rename(path+".bak", path); err != nil {
+			return true, fmt.Errorf("failed to restore the previous %s hook: %w", hook, err)
+		}
+	}
+	return true, nil
+}
+
+// gitHookDiff returns the changes hook reviews: the staged diff for
+// pre-commit, the pushed commits for pre-push
+func gitHookDiff(ctx context.Context, hook, remote string, stdin io.Reader) (string, error) {
+	if hook == "pre-commit" {
+		return gitOutput(ctx, "diff", "--cached", "--no-color", "--no-ext-diff")
+	}
+
+	ranges := prePushDiffRanges(stdin, func(localSHA string) (string, error) {
+		out, err := gitOutput(ctx, "merge-base", localSHA, "refs/remotes/"+remote+"/HEAD")
+		return strings.TrimSpace(out), err
+	})
+	var sb strings.Builder
+	for _, r := range ranges {
+		diff, err := gitOutput(ctx, "diff", "--no-color", "--no-ext-diff", r)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(diff)
+	}
+	return sb.String(), nil
+}
+
+// prePushDiffRanges turns the "<local ref> <local sha> <remote ref> <remote
+// sha>" lines git gives a pre-push hook into diff ranges. Deleted refs are
+// skipped; a new branch is diffed from mergeBase, or skipped when there is
+// none.
+func prePushDiffRanges(r io.Reader, mergeBase func(localSHA string) (string, error)) []string {
+	isZero := func(sha string) bool { return strings.Trim(sha, "0") == "" }
+
+	var ranges []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 || isZero(fields[1]) {
+			continue
+		}
+		localSHA, remoteSHA := fields[1], fields[3]
+		if isZero(remoteSHA) {
+			base, err := mergeBase(localSHA)
+			if err != nil || base == "" {
+				fmt.Fprintf(os.Stderr, "%s No base found for new ref %s, skipping its review\n", icons.BulletIcon, fields[0])
+				continue
+			}
+			remoteSHA = base
+		}
+		ranges = append(ranges, remoteSHA+".."+localSHA)
+	}
+	return ranges
+}
+
+// completeWithDiff sends diff, after the optional intro, to model with
+// systemPrompt and returns the reply
+func completeWithDiff(ctx context.Context, client sdk.Client, model, systemPrompt, intro, diff string) (string, error) {
+	if len(diff) > gitDiffMaxBytes {
+		fmt.Fprintf(os.Stderr, "%s The diff is larger than %d bytes, sending the start only\n", icons.BulletIcon, gitDiffMaxBytes)
+		diff = diff[:gitDiffMaxBytes] + "\n[diff truncated]"
+	}
+
+	provider, modelName, _ := strings.Cut(model, "/")
+	messages := []sdk.Message{
+		{Role: sdk.System, Content: sdk.NewMessageContent(systemPrompt)},
+		{Role: sdk.User, Content: sdk.NewMessageContent(intro + "```diff\n" + diff + "\n```")},
+	}
+	response, err := client.
+		WithOptions(&sdk.CreateChatCompletionRequest{}).
+		WithMiddlewareOptions(&sdk.MiddlewareOptions{SkipMCP: true}).
+		GenerateContent(ctx, sdk.Provider(provider), modelName, messages)
+	if err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("no response from %s", model)
+	}
+	text, _ := response.Choices[0].Message.Content.AsMessageContent0()
+	return strings.TrimSpace(text), nil
+}
+
+// parseReviewFindings extracts the "[SEVERITY] description" lines of a review
+func parseReviewFindings(review string) []reviewFinding {
+	var findings []reviewFinding
+	for _, line := range strings.Split(review, "\n") {
+		if m := reviewFindingPattern.FindStringSubmatch(line); m != nil {
+			findings = append(findings, reviewFinding{Severity: strings.ToLower(m[1]), Text: strings.TrimSpace(m[2])})
+		}
+	}
+	return findings
+}
+
+func isBlockingSeverity(severity string, blockOn []string) bool {
+	return slices.ContainsFunc(blockOn, func(s string) bool {
+		return strings.EqualFold(strings.TrimSpace(s), severity)
+	})
+}
+
+func blockingReviewFindings(findings []reviewFinding, blockOn []string) int {
+	count := 0
+	for _, f := range findings {
+		if isBlockingSeverity(f.Severity, blockOn) {
+			count++
+		}
+	}
+	return count
+}
+
+func renderReviewFindings(findings []reviewFinding, blockOn []string) string {
+	if len(findings) == 0 {
+		return fmt.Sprintf("%s Review passed, no findings\n", icons.CheckMark)
+	}
+	var sb strings.Builder
+	for _, f := range findings {
+		icon := icons.BulletIcon
+		if isBlockingSeverity(f.Severity, blockOn) {
+			icon = icons.CrossMark
+		}
+		fmt.Fprintf(&sb, "%s [%s] %s\n", icon, strings.ToUpper(f.Severity), f.Text)
+	}
+	return sb.String()
+}
+
+func init() {
+	hooksInstallCmd.Flags().StringSlice("hook", []string{"pre-commit"}, "Hooks to install: pre-commit, pre-push")
+	hooksInstallCmd.Flags().Bool("force", false, "Replace an existing hook, moving it to <hook>.bak")
+	hooksUninstallCmd.Flags().StringSlice("hook", gitHookNames, "Hooks to remove: pre-commit, pre-push")
+
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksRunCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	sdkmocks "github.com/inference-gateway/cli/tests/mocks/sdk"
+)
+
+func TestInstallAndUninstallGitHook(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+
+	if _, err := installGitHook(dir, "pre-commit", false); err != nil {
+		t.Fatalf("installGitHook: %v", err)
+	}
+	path := filepath.Join(dir, "pre-commit")
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Fatalf("hook must be an executable file: %v, %v", info, err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "exec infer hooks run pre-commit") {
+		t.Errorf("unexpected hook script:\n%s", data)
+	}
+	if _, err := installGitHook(dir, "pre-commit", false); err != nil {
+		t.Errorf("reinstalling infer's own hook must not need --force: %v", err)
+	}
+
+	foreign := filepath.Join(dir, "pre-push")
+	if err := os.WriteFile(foreign, []byte("#!/bin/sh\nmake lint\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := installGitHook(dir, "pre-push", false); err == nil {
+		t.Error("a foreign hook must not be replaced without --force")
+	}
+	backup, err := installGitHook(dir, "pre-push", true)
+	if err != nil || backup != foreign+".bak" {
+		t.Fatalf("installGitHook --force: %q, %v", backup, err)
+	}
+
+	if removed, err := uninstallGitHook(dir, "pre-push"); err != nil || !removed {
+		t.Fatalf("uninstallGitHook: %t, %v", removed, err)
+	}
+	if data, _ := os.ReadFile(foreign); string(data) != "#!/bin/sh\nmake lint\n" {
+		t.Errorf("the previous hook was not restored, got %q", data)
+	}
+	if removed, _ := uninstallGitHook(dir, "pre-push"); removed {
+		t.Error("a foreign hook must never be removed")
+	}
+}
+
+func TestPrePushDiffRanges(t *testing.T) {
+	zero := strings.Repeat("0", 40)
+	input := strings.Join([]string{
+		"refs/heads/main aaa refs/heads/main bbb",
+		"refs/heads/feature ccc refs/heads/feature " + zero,
+		"(delete) " + zero + " refs/heads/old ddd",
+		"refs/heads/orphan eee refs/heads/orphan " + zero,
+	}, "\n")
+
+	got := prePushDiffRanges(strings.NewReader(input), func(localSHA string) (string, error) {
+		if localSHA == "ccc" {
+			return "base", nil
+		}
+		return "", os.ErrNotExist
+	})
+	if strings.Join(got, ",") != "bbb..aaa,base..ccc" {
+		t.Errorf("got ranges %v", got)
+	}
+}
+
+func TestParseReviewFindings(t *testing.T) {
+	review := `Here is my review:
+[CRITICAL] config.go:12 - API key committed in plain text
+- [medium] main.go:40 - error from Close is ignored
+* [Low] README.md:3 - typo
+This line is not a finding [HIGH]`
+
+	findings := parseReviewFindings(review)
+	if len(findings) != 3 || findings[0].Severity != "critical" || findings[1].Text != "main.go:40 - error from Close is ignored" {
+		t.Fatalf("unexpected findings %+v", findings)
+	}
+	if n := blockingReviewFindings(findings, []string{"critical", "HIGH"}); n != 1 {
+		t.Errorf("got %d blocking findings, want 1", n)
+	}
+	if parseReviewFindings("LGTM") != nil {
+		t.Error("LGTM has no findings")
+	}
+
+	out := renderReviewFindings(findings, []string{"critical"})
+	if !strings.Contains(out, "[CRITICAL] config.go:12") || !strings.Contains(out, "[LOW] README.md:3") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestCompleteWithDiff(t *testing.T) {
+	client := &sdkmocks.FakeClient{}
+	client.WithOptionsReturns(client)
+	client.WithMiddlewareOptionsReturns(client)
+	client.GenerateContentReturns(&sdk.CreateChatCompletionResponse{Choices: []sdk.ChatCompletionChoice{
+		{Message: sdk.Message{Content: sdk.NewMessageContent(" LGTM\n")}},
+	}}, nil)
+
+	review, err := completeWithDiff(context.Background(), client, "openai/gpt-4o", "review this", "", "+fmt.Println(x)")
+	if err != nil || review != "LGTM" {
+		t.Fatalf("completeWithDiff: %q, %v", review, err)
+	}
+
+	_, provider, model, messages := client.GenerateContentArgsForCall(0)
+	if provider != "openai" || model != "gpt-4o" || len(messages) != 2 || messages[0].Role != sdk.System {
+		t.Errorf("unexpected request %s/%s %+v", provider, model, messages)
+	}
+	if text, _ := messages[1].Content.AsMessageContent0(); !strings.Contains(text, "+fmt.Println(x)") {
+		t.Errorf("the diff is missing from the request: %q", text)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	convimport "github.com/inference-gateway/cli/internal/services/convimport"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>...",
+	Short: "Import conversations from other assistants",
+	Long: `Import session exports of other coding assistants into the conversation
+storage, so they show up in 'infer conversations list' and can be resumed.
+
+Supported sources:
+  claude-code  session transcripts (~/.claude/projects/<project>/<session>.jsonl)
+  chatgpt      conversations.json from a ChatGPT data export
+  aider        .aider.chat.history.md
+
+The source is detected from each file unless --from is given. Roles and tool
+calls are mapped on a best-effort basis; attachments are not imported.
+Importing the same export again updates the conversations instead of
+duplicating them.
+
+Examples:
+  infer import ~/.claude/projects/my-project/*.jsonl
+  infer import --from chatgpt conversations.json
+  infer import .aider.chat.history.md --dry-run`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return RunImportCommand(Cfg, args, from, dryRun)
+	},
+}
+
+func init() {
+	importCmd.Flags().String("from", "", "Source of the files (claude-code, chatgpt, aider); detected when empty")
+	importCmd.Flags().Bool("dry-run", false, "List the conversations that would be imported without saving them")
+	rootCmd.AddCommand(importCmd)
+}
+
+// RunImportCommand imports the conversations of the given export files
+func RunImportCommand(cfg *config.Config, paths []string, from string, dryRun bool) error {
+	var source convimport.Source
+	if from != "" {
+		parsed, err := convimport.ParseSource(from)
+		if err != nil {
+			return err
+		}
+		source = parsed
+	}
+
+	var conversations storage.ConversationStorage
+	if !dryRun {
+		stores, err := storage.NewStorage(storage.NewStorageFromConfig(cfg))
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+		conversations = stores.Conversations
+		defer func() { _ = conversations.Close() }()
+	}
+
+	total := 0
+	for _, path := range paths {
+		imported, err := importConversationFile(conversations, path, source)
+		if err != nil {
+			return err
+		}
+		total += imported
+	}
+
+	verb := "Imported"
+	if dryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("• %s %d conversation(s) from %d file(s)\n", verb, total, len(paths))
+	return nil
+}
+
+// importConversationFile parses one export and saves its conversations, or
+// only lists them when store is nil
+func importConversationFile(store storage.ConversationStorage, path string, source convimport.Source) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if source == "" {
+		if source, err = convimport.Detect(path, data); err != nil {
+			return 0, err
+		}
+	}
+
+	parsed, err := convimport.Parse(source, path, data)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+
+	ctx := context.Background()
+	for _, conv := range parsed {
+		id := importedConversationID(source, conv.SourceID)
+		fmt.Printf("  %s  %s (%d messages, %s)\n", id, conv.Title, len(conv.Entries), source)
+		if store == nil {
+			continue
+		}
+		if err := store.SaveConversation(ctx, id, conv.Entries, importedConversationMetadata(id, source, conv)); err != nil {
+			return 0, fmt.Errorf("failed to save %q from %s: %w", conv.Title, path, err)
+		}
+	}
+	return len(parsed), nil
+}
+
+// importedConversationID derives a stable ID from the source's own ID, so a
+// repeated import overwrites the earlier copy
+func importedConversationID(source convimport.Source, sourceID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("infer-import:"+string(source)+":"+sourceID)).String()
+}
+
+func importedConversationMetadata(id string, source convimport.Source, conv convimport.Conversation) storage.ConversationMetadata {
+	createdAt, updatedAt := conv.CreatedAt, conv.UpdatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	if updatedAt.Before(createdAt) {
+		updatedAt = createdAt
+	}
+
+	// Imported titles come from the source or the first prompt; marking them
+	// generated keeps the title generator from spending tokens on old history.
+	return storage.ConversationMetadata{
+		ID:             id,
+		Title:          conv.Title,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		MessageCount:   len(conv.Entries),
+		Model:          conv.Model,
+		Tags:           []string{"imported", string(source)},
+		TitleGenerated: true,
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+func TestImportConversationFileIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".aider.chat.history.md")
+	history := "# aider chat started at 2026-10-01 09:00:00\n\n#### add a hello function\n\nDone.\n"
+	if err := os.WriteFile(path, []byte(history), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := storage.NewMemoryStorage()
+	for range 2 {
+		imported, err := importConversationFile(store, path, "")
+		if err != nil {
+			t.Fatalf("importConversationFile: %v", err)
+		}
+		if imported != 1 {
+			t.Fatalf("imported %d conversations, want 1", imported)
+		}
+	}
+
+	summaries, err := store.ListConversations(context.Background(), 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("got %d stored conversations, a repeated import must not duplicate", len(summaries))
+	}
+	if got := summaries[0]; got.Title != "add a hello function" || got.MessageCount != 2 {
+		t.Errorf("unexpected summary %+v", got)
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	projectindex "github.com/inference-gateway/cli/internal/services/projectindex"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Build or refresh the project index",
+	Long: `Build or refresh the project index in .infer/index: a one-line summary, the
+top-level symbols and a term vector for every text file git tracks or would
+track. Only the files git reports as changed since the last build are read
+again, so restoring .infer/index from a CI cache and running infer index keeps
+it current in seconds.
+
+Examples:
+  infer index
+  infer index --full
+  infer index status
+  infer index search "refresh oauth token"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		full, _ := cmd.Flags().GetBool("full")
+		maxSize, _ := cmd.Flags().GetInt64("max-file-size")
+		format, _ := cmd.Flags().GetString("format")
+		return runIndex(context.Background(), os.Stdout, projectindex.Options{Root: ".", Full: full, MaxFileSize: maxSize}, format)
+	},
+}
+
+var indexStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the index's age and the files changed since it was built",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIndexStatus(context.Background(), os.Stdout, ".")
+	},
+}
+
+var indexSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Find the indexed files that best match a query",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		format, _ := cmd.Flags().GetString("format")
+		return runIndexSearch(os.Stdout, ".", args[0], limit, format)
+	},
+}
+
+func init() {
+	indexCmd.AddCommand(indexStatusCmd)
+	indexCmd.AddCommand(indexSearchCmd)
+
+	indexCmd.Flags().Bool("full", false, "Re-read every file instead of only the changed ones")
+	indexCmd.Flags().Int64("max-file-size", projectindex.DefaultMaxFileSize, "Skip files larger than this many bytes")
+	indexCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+	indexSearchCmd.Flags().IntP("limit", "n", 10, "Maximum number of files to show")
+	indexSearchCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+
+	rootCmd.AddCommand(indexCmd)
+}
+
+func runIndex(ctx context.Context, w io.Writer, opts projectindex.Options, format string) error {
+	path := indexPath(opts.Root)
+	prev, err := projectindex.Load(path)
+	if err != nil {
+		if !opts.Full {
+			return fmt.Errorf("%w (rebuild it with --full)", err)
+		}
+		prev = nil
+	}
+
+	idx, stats, err := projectindex.Build(ctx, opts, prev)
+	if err != nil {
+		return err
+	}
+	if err := projectindex.Save(path, idx); err != nil {
+		return err
+	}
+
+	if format == "json" {
+		output, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal index stats: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	mode := "Built"
+	if stats.Incremental {
+		mode = "Refreshed"
+	}
+	_, _ = fmt.Fprintf(w, "%s the index of %d files in %s\n", mode, stats.Files, stats.Duration.Round(time.Millisecond))
+	_, _ = fmt.Fprintln(w, listHint(fmt.Sprintf("%d indexed, %d unchanged, %d removed, %d skipped", stats.Indexed, stats.Reused, stats.Removed, stats.Skipped)))
+	_, _ = fmt.Fprintln(w, listHint("Saved to "+path))
+	return nil
+}
+
+func runIndexStatus(ctx context.Context, w io.Writer, root string) error {
+	path := indexPath(root)
+	idx, err := projectindex.Load(path)
+	if err != nil {
+		return err
+	}
+	if idx == nil {
+		_, _ = fmt.Fprintln(w, "No project index yet.")
+		_, _ = fmt.Fprintln(w, "Build it with 'infer index'.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle("Project Index"))
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listField("Path", path))
+	_, _ = fmt.Fprintln(w, listField("Files", fmt.Sprintf("%d", len(idx.Files))))
+	_, _ = fmt.Fprintln(w, listField("Updated", idx.UpdatedAt.Local().Format("2006-01-02 15:04:05")))
+	if idx.Commit != "" {
+		_, _ = fmt.Fprintln(w, listField("Commit", idx.Commit[:min(len(idx.Commit), 12)]))
+	}
+	if idx.Version != projectindex.FormatVersion {
+		_, _ = fmt.Fprintln(w, listField("Stale", "built by another version; the next infer index rebuilds it"))
+		return nil
+	}
+
+	stale, err := projectindex.Stale(ctx, root, idx)
+	if err != nil {
+		_, _ = fmt.Fprintln(w, listField("Stale", err.Error()))
+		return nil
+	}
+	_, _ = fmt.Fprintln(w, listField("Stale", fmt.Sprintf("%d file(s) changed since the last build", len(stale))))
+	for _, rel := range stale {
+		_, _ = fmt.Fprintln(w, listHint("  "+rel))
+	}
+	return nil
+}
+
+func runIndexSearch(w io.Writer, root, query string, limit int, format string) error {
+	idx, err := projectindex.Load(indexPath(root))
+	if err != nil {
+		return err
+	}
+	if idx == nil {
+		return fmt.Errorf("no project index yet; build it with 'infer index'")
+	}
+
+	results := projectindex.Search(idx, query, limit)
+	if format == "json" {
+		output, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal search results: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	if len(results) == 0 {
+		_, _ = fmt.Fprintf(w, "No indexed files match %q.\n", query)
+		return nil
+	}
+	resultsTable := newListTable("Score", "File", "Summary")
+	for _, result := range results {
+		resultsTable.Row(fmt.Sprintf("%.2f", result.Score), result.Path, result.Summary)
+	}
+	_, _ = fmt.Fprintln(w, resultsTable.Render())
+	return nil
+}
+
+// indexPath is where the index of the project at root is stored
+func indexPath(root string) string {
+	return filepath.Join(root, projectindex.DefaultPath)
+}
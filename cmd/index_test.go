@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	projectindex "github.com/inference-gateway/cli/internal/services/projectindex"
+)
+
+func TestRunIndexAndSearch(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "retry.go"), []byte("// Package retry backs off failed requests.\npackage retry\n\nfunc Backoff() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runIndex(context.Background(), &out, projectindex.Options{Root: root}, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Built the index of 1 files") {
+		t.Errorf("index output = %q", out.String())
+	}
+
+	out.Reset()
+	if err := runIndex(context.Background(), &out, projectindex.Options{Root: root}, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Refreshed") || !strings.Contains(out.String(), "0 indexed, 1 unchanged") {
+		t.Errorf("second run must reuse the unchanged file, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := runIndexSearch(&out, root, "backoff requests", 5, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "retry.go") {
+		t.Errorf("search output = %q", out.String())
+	}
+
+	if err := runIndexSearch(&out, t.TempDir(), "anything", 5, "text"); err == nil {
+		t.Error("searching without an index must fail")
+	}
+}
@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	agentjobs "github.com/inference-gateway/cli/internal/services/agentjobs"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// jobLogPollInterval is how often a followed job log is checked for output
+const jobLogPollInterval = 500 * time.Millisecond
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Monitor the agent runs started with infer agent --detach",
+	Long: `List, follow and stop the agent runs started with 'infer agent --detach'.
+Each job is its own process, so it keeps running when the terminal or chat that
+started it is closed. Jobs, their output and their results are kept under
+.infer/jobs of the project they were started in.
+
+A job can be referred to by its ID or a unique prefix of it. A finished job's
+conversation can be continued with 'infer chat --session-id <session>'.`,
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List detached agent runs with their status",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return listAgentJobs(os.Stdout, agentJobsStore(), format, time.Now())
+	},
+}
+
+var jobsLogsCmd = &cobra.Command{
+	Use:               "logs <job>",
+	Short:             "Print the output of a detached agent run",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeAgentJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := agentJobsStore()
+		job, err := store.Resolve(args[0])
+		if err != nil {
+			return err
+		}
+		follow, _ := cmd.Flags().GetBool("follow")
+		raw, _ := cmd.Flags().GetBool("raw")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return copyJobLog(ctx, os.Stdout, store, job, follow, raw)
+	},
+}
+
+var jobsAttachCmd = &cobra.Command{
+	Use:   "attach <job>",
+	Short: "Follow a detached agent run until it finishes",
+	Long: `Print a detached agent run's output so far, then keep printing what it
+writes until it finishes, and show its outcome. ctrl+c detaches again and
+leaves the job running.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeAgentJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := agentJobsStore()
+		job, err := store.Resolve(args[0])
+		if err != nil {
+			return err
+		}
+		raw, _ := cmd.Flags().GetBool("raw")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return attachAgentJob(ctx, os.Stdout, store, job, raw)
+	},
+}
+
+var jobsStopCmd = &cobra.Command{
+	Use:               "stop <job>",
+	Short:             "Stop a detached agent run",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeAgentJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := agentJobsStore()
+		job, err := store.Resolve(args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := store.Stop(job); err != nil {
+			return err
+		}
+		fmt.Printf("%s Stopped job %s\n", icons.CheckMark, shortJobID(job.ID))
+		return nil
+	},
+}
+
+// agentJobRow is one job of `infer jobs list --format json`
+type agentJobRow struct {
+	agentjobs.Job
+	Status     agentjobs.State `json:"status"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// listAgentJobs prints the recorded jobs, the most recent first
+func listAgentJobs(w io.Writer, store *agentjobs.Store, format string, now time.Time) error {
+	jobs, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		rows := make([]agentJobRow, 0, len(jobs))
+		for _, job := range jobs {
+			status := store.Status(job)
+			row := agentJobRow{Job: job, Status: status.State, FinishedAt: status.FinishedAt}
+			if status.Result != nil {
+				row.Error = status.Result.Error
+			}
+			rows = append(rows, row)
+		}
+		output, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal jobs: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	if len(jobs) == 0 {
+		_, _ = fmt.Fprintln(w, "No detached agent runs.")
+		_, _ = fmt.Fprintln(w, "Use 'infer agent --detach \"<task>\"' to start one.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Agent Jobs (%d)", len(jobs))))
+	_, _ = fmt.Fprintln(w)
+
+	jobsTable := newListTable("ID", "Status", "Started", "Duration", "Session", "Task")
+	for _, job := range jobs {
+		status := store.Status(job)
+		end := now
+		if status.FinishedAt != nil {
+			end = *status.FinishedAt
+		}
+		jobsTable.Row(
+			shortJobID(job.ID),
+			string(status.State),
+			job.StartedAt.Local().Format("2006-01-02 15:04"),
+			end.Sub(job.StartedAt).Round(time.Second).String(),
+			shortJobID(job.SessionID),
+			formatting.TruncateText(strings.Join(strings.Fields(job.Task), " "), 50),
+		)
+	}
+	_, _ = fmt.Fprintln(w, jobsTable.Render())
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listHint("Use 'infer jobs attach <job>' to follow a job and 'infer jobs logs <job>' for its output."))
+	return nil
+}
+
+// attachAgentJob follows the job's output until it finishes, then prints its
+// outcome. When ctx is done first the job is left running.
+func attachAgentJob(ctx context.Context, w io.Writer, store *agentjobs.Store, job agentjobs.Job, raw bool) error {
+	if err := copyJobLog(ctx, w, store, job, true, raw); err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		_, _ = fmt.Fprintf(w, "\nDetached from job %s; it keeps running.\n", shortJobID(job.ID))
+		return nil
+	}
+
+	status := store.Status(job)
+	_, _ = fmt.Fprintln(w)
+	switch status.State {
+	case agentjobs.StateCompleted:
+		_, _ = fmt.Fprintf(w, "%s Job %s completed\n", icons.CheckMark, shortJobID(job.ID))
+	case agentjobs.StateFailed:
+		_, _ = fmt.Fprintf(w, "%s Job %s failed: %s\n", icons.CrossMark, shortJobID(job.ID), status.Result.Error)
+	default:
+		_, _ = fmt.Fprintf(w, "%s Job %s %s\n", icons.CrossMark, shortJobID(job.ID), status.State)
+	}
+	_, _ = fmt.Fprintln(w, listHint(fmt.Sprintf("Continue the conversation with 'infer chat --session-id %s'.", job.SessionID)))
+	return nil
+}
+
+// copyJobLog writes the job's log to w. With follow it then keeps writing
+// what the job adds until the job stops running or ctx is done.
+func copyJobLog(ctx context.Context, w io.Writer, store *agentjobs.Store, job agentjobs.Job, follow, raw bool) error {
+	f, err := os.Open(store.LogPath(job.ID))
+	if err != nil {
+		return fmt.Errorf("failed to open the job log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := bufio.NewReader(f)
+	var partial string
+	for {
+		line, err := reader.ReadString('\n')
+		if err == nil {
+			writeJobLogLine(w, partial+line, raw)
+			partial = ""
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read the job log: %w", err)
+		}
+		partial += line
+
+		if !follow {
+			if partial != "" {
+				writeJobLogLine(w, partial, raw)
+			}
+			return nil
+		}
+		// Read once more after the job stops, for what it wrote last.
+		follow = store.Status(job).State == agentjobs.StateRunning
+		if follow {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(jobLogPollInterval):
+			}
+		}
+	}
+}
+
+func writeJobLogLine(w io.Writer, line string, raw bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if !raw {
+		var ok bool
+		if line, ok = formatJobLogLine(line); !ok {
+			return
+		}
+	}
+	_, _ = fmt.Fprintln(w, line)
+}
+
+// formatJobLogLine renders one line of a job's output for reading: the
+// conversation messages `infer agent` prints become short role-prefixed
+// lines, and anything else is kept as is. ok is false for lines to skip.
+func formatJobLogLine(line string) (string, bool) {
+	var msg ConversationMessage
+	if err := json.Unmarshal([]byte(line), &msg); err != nil || msg.Role == "" {
+		return line, true
+	}
+
+	switch msg.Role {
+	case "user":
+		return "> " + formatting.TruncateText(strings.TrimSpace(msg.Content), 200), true
+	case "assistant":
+		var parts []string
+		if content := strings.TrimSpace(msg.Content); content != "" {
+			parts = append(parts, content)
+		}
+		if msg.ToolCalls != nil {
+			for _, tc := range *msg.ToolCalls {
+				parts = append(parts, fmt.Sprintf("→ %s %s", tc.Function.Name, formatting.TruncateText(tc.Function.Arguments, 120)))
+			}
+		}
+		return strings.Join(parts, "\n"), len(parts) > 0
+	case "tool":
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(msg.Content), "\n")
+		return "← " + formatting.TruncateText(firstLine, 120), true
+	default:
+		return "", false
+	}
+}
+
+// completeAgentJobIDs completes the IDs of recorded jobs, described by their tasks
+func completeAgentJobIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	jobs, err := agentJobsStore().List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		completions = append(completions, job.ID+"\t"+formatting.TruncateText(strings.Join(strings.Fields(job.Task), " "), 60))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+func init() {
+	jobsListCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+	jobsLogsCmd.Flags().BoolP("follow", "f", false, "Keep printing the job's output until it finishes")
+	jobsLogsCmd.Flags().Bool("raw", false, "Print the output as the agent wrote it (JSON lines)")
+	jobsAttachCmd.Flags().Bool("raw", false, "Print the output as the agent wrote it (JSON lines)")
+
+	jobsCmd.AddCommand(jobsListCmd)
+	jobsCmd.AddCommand(jobsLogsCmd)
+	jobsCmd.AddCommand(jobsAttachCmd)
+	jobsCmd.AddCommand(jobsStopCmd)
+	rootCmd.AddCommand(jobsCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	cobra "github.com/spf13/cobra"
+
+	agentjobs "github.com/inference-gateway/cli/internal/services/agentjobs"
+)
+
+func newDetachTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("detach", false, "")
+	cmd.Flags().String("model", "", "")
+	cmd.Flags().String("persona", "", "")
+	cmd.Flags().String("output", agentOutputMessages, "")
+	cmd.Flags().String("schema", "", "")
+	cmd.Flags().StringSlice("files", nil, "")
+	cmd.Flags().Bool("no-save", false, "")
+	cmd.Flags().Bool("auto-approve", false, "")
+	cmd.Flags().Bool("require-approval", false, "")
+	cmd.Flags().String("result-file", "", "")
+	cmd.Flags().String("tasks", "", "")
+	cmd.Flags().StringArray("watch", nil, "")
+	cmd.Flags().Bool("ci", false, "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestValidateAgentDetach(t *testing.T) {
+	if err := validateAgentDetach(newDetachTestCmd(t, "--ci")); err != nil {
+		t.Errorf("without --detach nothing is rejected, got %v", err)
+	}
+	if err := validateAgentDetach(newDetachTestCmd(t, "--detach", "--model", "openai/gpt-4")); err != nil {
+		t.Errorf("--detach --model: %v", err)
+	}
+	for _, flag := range []string{"--require-approval", "--ci", "--tasks=t.yaml", "--watch=*.go"} {
+		if err := validateAgentDetach(newDetachTestCmd(t, "--detach", flag)); err == nil {
+			t.Errorf("--detach %s should be rejected", flag)
+		}
+	}
+}
+
+func TestDetachedAgentArgs(t *testing.T) {
+	cmd := newDetachTestCmd(t, "--detach", "--model", "openai/gpt-4", "--files", "a.go,b.go", "--auto-approve")
+	args := detachedAgentArgs(cmd, "sess-1", "/tmp/job.result.json", "--fix the bug")
+
+	want := []string{
+		"agent", "--session-id", "sess-1", "--result-file", "/tmp/job.result.json",
+		"--model", "openai/gpt-4", "--files", "a.go", "--files", "b.go", "--auto-approve",
+		"--", "--fix the bug",
+	}
+	if strings.Join(args, " ") != strings.Join(want, " ") {
+		t.Errorf("detachedAgentArgs() = %v\nwant %v", args, want)
+	}
+}
+
+func TestFormatJobLogLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{line: `{"role":"user","content":"fix the bug"}`, want: "> fix the bug", ok: true},
+		{line: `{"role":"assistant","content":"Looking.","tool_calls":[{"id":"1","type":"function","function":{"name":"Read","arguments":"{\"file_path\":\"a.go\"}"}}]}`, want: "Looking.\n→ Read {\"file_path\":\"a.go\"}", ok: true},
+		{line: `{"role":"tool","content":"package main\nfunc main() {}"}`, want: "← package main", ok: true},
+		{line: `{"role":"system","content":"You are..."}`, ok: false},
+		{line: `{"role":"assistant","content":""}`, ok: false},
+		{line: "plain stderr output", want: "plain stderr output", ok: true},
+		{line: `{"type":"session_stats"}`, want: `{"type":"session_stats"}`, ok: true},
+	}
+	for _, tt := range tests {
+		got, ok := formatJobLogLine(tt.line)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("formatJobLogLine(%s) = %q, %v; want %q, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAttachAgentJob_Finished(t *testing.T) {
+	dir := t.TempDir()
+	store := agentjobs.NewStore(dir)
+	job := agentjobs.Job{ID: "0123456789", SessionID: "sess-1"}
+	if err := os.WriteFile(store.LogPath(job.ID), []byte("{\"role\":\"assistant\",\"content\":\"Done.\"}\nno newline"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(store.ResultPath(job.ID), []byte(`{"final_assistant":"Done.","success":true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := attachAgentJob(context.Background(), &out, store, job, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Done.\nno newline\n", "Job 01234567 completed", "infer chat --session-id sess-1"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("attach output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	lipgloss "charm.land/lipgloss/v2"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	logview "github.com/inference-gateway/cli/internal/services/logview"
+	colors "github.com/inference-gateway/cli/internal/ui/styles/colors"
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show and follow the infer logs",
+	Long: `Show the structured logs infer writes under .infer/logs (logging.dir),
+including archived .gz files, filtered by level, component and time range.
+
+A component matches the source file an entry was logged from, e.g. mcp for
+mcp_manager.go or a2a for the A2A client, or the entry's component field.
+--since and --until take a duration ago (15m, 2h), a date (2026-01-02), a time
+of today (14:30) or an RFC 3339 timestamp.
+
+Examples:
+  infer logs --level warn --since 1h
+  infer logs --component mcp --component a2a -f
+  infer logs --grep "connection refused" --json | jq .`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := logsOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if !opts.follow {
+			return runLogs(context.Background(), os.Stdout, logsDir(Cfg), opts)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return runLogs(ctx, os.Stdout, logsDir(Cfg), opts)
+	},
+}
+
+// logsOptions are the parsed flags of infer logs
+type logsOptions struct {
+	filter  logview.Filter
+	lines   int
+	follow  bool
+	rawJSON bool
+}
+
+func logsOptionsFromFlags(cmd *cobra.Command) (logsOptions, error) {
+	level, _ := cmd.Flags().GetString("level")
+	components, _ := cmd.Flags().GetStringSlice("component")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	grep, _ := cmd.Flags().GetString("grep")
+	lines, _ := cmd.Flags().GetInt("lines")
+	follow, _ := cmd.Flags().GetBool("follow")
+	rawJSON, _ := cmd.Flags().GetBool("json")
+
+	opts := logsOptions{
+		filter:  logview.Filter{MinLevel: strings.ToLower(level), Components: components, Grep: grep},
+		lines:   lines,
+		follow:  follow,
+		rawJSON: rawJSON,
+	}
+	if level != "" && !logview.ValidLevel(level) {
+		return opts, fmt.Errorf("invalid level %q: use debug, info, warn or error", level)
+	}
+
+	now := time.Now()
+	var err error
+	if since != "" {
+		if opts.filter.Since, err = logview.ParseTime(since, now); err != nil {
+			return opts, err
+		}
+	}
+	if until != "" {
+		if opts.filter.Until, err = logview.ParseTime(until, now); err != nil {
+			return opts, err
+		}
+		if follow {
+			return opts, fmt.Errorf("--until cannot be combined with --follow")
+		}
+	}
+	return opts, nil
+}
+
+// logsDir returns the directory the logger writes to
+func logsDir(cfg *config.Config) string {
+	if cfg != nil && cfg.Logging.Dir != "" {
+		return cfg.Logging.Dir
+	}
+	return config.DefaultLogsPath
+}
+
+// runLogs prints the last matching entries of dir and, with follow, the
+// matching entries written afterwards until ctx is done
+func runLogs(ctx context.Context, w io.Writer, dir string, opts logsOptions) error {
+	files, err := logview.Files(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 && !opts.follow {
+		_, _ = fmt.Fprintf(w, "No logs found in %s.\n", dir)
+		return nil
+	}
+
+	entries, err := logview.Tail(dir, opts.filter, opts.lines)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		printLogEntry(w, e, opts.rawJSON)
+	}
+	if !opts.follow {
+		return nil
+	}
+	return logview.Follow(ctx, dir, opts.filter, func(e logview.Entry) {
+		printLogEntry(w, e, opts.rawJSON)
+	})
+}
+
+func printLogEntry(w io.Writer, e logview.Entry, rawJSON bool) {
+	if rawJSON {
+		_, _ = fmt.Fprintln(w, e.Raw)
+		return
+	}
+	line := logview.Format(e)
+	if style, ok := logLevelStyle(e.Level); ok {
+		line = style.Render(line)
+	}
+	_, _ = fmt.Fprintln(w, line)
+}
+
+// logLevelStyle colors warnings and errors, unless colors are disabled
+func logLevelStyle(level string) (lipgloss.Style, bool) {
+	if outputColorsDisabled {
+		return lipgloss.Style{}, false
+	}
+	switch level {
+	case "warn":
+		return lipgloss.NewStyle().Foreground(colors.WarningColor.GetLipglossColor()), true
+	case "error", "dpanic", "panic", "fatal":
+		return lipgloss.NewStyle().Foreground(colors.ErrorColor.GetLipglossColor()), true
+	case "debug":
+		return lipgloss.NewStyle().Foreground(colors.DimColor.GetLipglossColor()), true
+	}
+	return lipgloss.Style{}, false
+}
+
+// addLogsFlags defines the flags of infer logs on cmd
+func addLogsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("level", "l", "", "Minimum level to show: debug, info, warn or error")
+	cmd.Flags().StringSliceP("component", "c", nil, "Only show entries of these components, e.g. mcp or a2a (repeatable)")
+	cmd.Flags().String("since", "", "Only show entries after this time, e.g. 1h or 2026-01-02T15:04")
+	cmd.Flags().String("until", "", "Only show entries before this time")
+	cmd.Flags().String("grep", "", "Only show entries containing this text")
+	cmd.Flags().IntP("lines", "n", 100, "Number of entries to show, 0 for all")
+	cmd.Flags().BoolP("follow", "f", false, "Keep printing new entries as they are logged")
+	cmd.Flags().Bool("json", false, "Print the entries as the raw JSON lines")
+}
+
+func init() {
+	addLogsFlags(logsCmd)
+	rootCmd.AddCommand(logsCmd)
+}
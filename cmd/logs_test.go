@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cobra "github.com/spf13/cobra"
+
+	logview "github.com/inference-gateway/cli/internal/services/logview"
+)
+
+func TestRunLogs(t *testing.T) {
+	dir := t.TempDir()
+	lines := strings.Join([]string{
+		`{"level":"info","ts":1760601600,"caller":"services/mcp_manager.go:123","msg":"connected to MCP server"}`,
+		`{"level":"warn","ts":1760605200,"caller":"tools/a2a_query_agent.go:88","msg":"agent unreachable"}`,
+		`{"level":"error","ts":1760608800,"caller":"services/mcp_manager.go:200","msg":"MCP server failed"}`,
+	}, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "app-2025-10-16.log"), []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	opts := logsOptions{filter: logview.Filter{MinLevel: "warn", Components: []string{"mcp"}}, lines: 10}
+	if err := runLogs(context.Background(), &out, dir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.Contains(got, "MCP server failed") || strings.Contains(got, "connected") || strings.Contains(got, "agent unreachable") {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+
+	out.Reset()
+	opts = logsOptions{lines: 1, rawJSON: true}
+	if err := runLogs(context.Background(), &out, dir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out.String()); !strings.HasPrefix(got, `{"level":"error"`) {
+		t.Errorf("--json -n 1 printed %q", got)
+	}
+}
+
+func TestRunLogsWithoutLogs(t *testing.T) {
+	var out bytes.Buffer
+	if err := runLogs(context.Background(), &out, t.TempDir(), logsOptions{lines: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No logs found") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestLogsOptionsFromFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	addLogsFlags(cmd)
+
+	_ = cmd.Flags().Set("level", "verbose")
+	if _, err := logsOptionsFromFlags(cmd); err == nil {
+		t.Error("expected an invalid level to be rejected")
+	}
+
+	_ = cmd.Flags().Set("level", "WARN")
+	_ = cmd.Flags().Set("since", "1h")
+	_ = cmd.Flags().Set("component", "mcp,a2a")
+	opts, err := logsOptionsFromFlags(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.filter.MinLevel != "warn" || opts.filter.Since.IsZero() || len(opts.filter.Components) != 2 {
+		t.Errorf("unexpected options %+v", opts)
+	}
+
+	_ = cmd.Flags().Set("until", "10m")
+	_ = cmd.Flags().Set("follow", "true")
+	if _, err := logsOptionsFromFlags(cmd); err == nil {
+		t.Error("expected --until with --follow to be rejected")
+	}
+}
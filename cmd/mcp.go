@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -103,6 +104,14 @@ func init() {
 	mcpCmd.AddCommand(mcpDisableCmd)
 	mcpCmd.AddCommand(mcpEnableGlobalCmd)
 	mcpCmd.AddCommand(mcpDisableGlobalCmd)
+	mcpCmd.AddCommand(mcpTestCmd)
+
+	mcpListCmd.Flags().Bool("tools", false, "Connect to the enabled servers and list their tools")
+	mcpTestCmd.Flags().BoolP("verbose", "v", false, "Show tool descriptions")
+
+	for _, c := range []*cobra.Command{mcpRemoveCmd, mcpUpdateCmd, mcpEnableCmd, mcpDisableCmd} {
+		c.ValidArgsFunction = completeFirstArg(completeMCPServers)
+	}
 
 	mcpAddCmd.Flags().String("description", "", "Description of the MCP server")
 	mcpAddCmd.Flags().Int("timeout", 0, "Connection timeout in seconds (overrides global)")
@@ -113,6 +122,7 @@ func init() {
 	mcpAddCmd.Flags().String("oci", "", "OCI image to use (required if --run is true)")
 	mcpAddCmd.Flags().Int("port", 0, "Container port to expose")
 	mcpAddCmd.Flags().Int("startup-timeout", 60, "Startup timeout in seconds")
+	mcpAddCmd.Flags().Bool("skip-test", false, "Don't connect to the server after adding it")
 
 	mcpUpdateCmd.Flags().String("url", "", "Update the server URL")
 	mcpUpdateCmd.Flags().String("description", "", "Update the description")
@@ -198,6 +208,10 @@ func listMCPServers(cmd *cobra.Command, args []string) error {
 
 	printMCPToolFilters(cfg.Servers)
 
+	if showTools, _ := cmd.Flags().GetBool("tools"); showTools {
+		printMCPServerTools(cfg)
+	}
+
 	fmt.Println()
 	fmt.Println(statusLegend())
 	return nil
@@ -229,6 +243,20 @@ func printMCPToolFilters(servers []config.MCPServerEntry) {
 	}
 }
 
+// printMCPServerTools connects to the enabled servers and lists their tools
+func printMCPServerTools(cfg *config.MCPConfig) {
+	servers, _ := selectMCPServers(cfg, nil)
+	if len(servers) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println(listTitle("Tools"))
+	fmt.Println()
+	for _, result := range probeMCPServers(context.Background(), cfg, servers) {
+		printMCPProbeResult(os.Stdout, result, false)
+	}
+}
+
 func renderMarkdown(markdown string) (string, error) {
 	// Glamour v2 removed WithAutoStyle; omitting style options defaults to
 	// the dark theme, matching v1's WithAutoStyle behaviour on dark terms.
@@ -324,6 +352,15 @@ func addMCPServer(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Printf("  Status: %s\n", enabledText(enabled))
 	fmt.Printf("\nConfiguration saved to %s\n", configPath)
+
+	if skipTest, _ := cmd.Flags().GetBool("skip-test"); !skipTest && enabled && !run {
+		fmt.Println()
+		result := probeMCPServers(context.Background(), cfg, []config.MCPServerEntry{server})[0]
+		printMCPProbeResult(os.Stdout, result, false)
+		if result.err != nil {
+			fmt.Printf("The server was saved anyway; check it again with: infer mcp test %s\n", name)
+		}
+	}
 	fmt.Printf("\n⚠️  Note: If using chat mode, restart the chat session to connect to the new MCP server.\n")
 	// TODO: Implement hot-reload for MCP configuration changes without requiring chat restart
 
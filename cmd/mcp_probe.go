@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	services "github.com/inference-gateway/cli/internal/services"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+var mcpTestCmd = &cobra.Command{
+	Use:   "test [name...]",
+	Short: "Connect to MCP servers and list their tools",
+	Long: `Connect to MCP servers the way a chat session does and list the tools each
+exposes, so a new or changed server can be checked without starting a session.
+Without names every enabled server is tested; named servers are tested even
+when disabled. Tools hidden by the server's include/exclude filters are marked.
+
+Auto-started (--run) servers are not started here, only probed where they
+listen.
+
+Examples:
+  infer mcp test
+  infer mcp test filesystem --verbose`,
+	ValidArgsFunction: completeMCPServers,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadMCP(getMCPConfigPath(cmd))
+		if err != nil {
+			return fmt.Errorf("failed to load MCP config: %w", err)
+		}
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		return runMCPTest(context.Background(), os.Stdout, cfg, args, verbose)
+	},
+}
+
+// mcpProbeResult is the outcome of connecting to one MCP server
+type mcpProbeResult struct {
+	server  config.MCPServerEntry
+	tools   []domain.MCPDiscoveredTool
+	latency time.Duration
+	err     error
+}
+
+// selectMCPServers returns the named servers, or every enabled one
+func selectMCPServers(cfg *config.MCPConfig, names []string) ([]config.MCPServerEntry, error) {
+	if len(names) == 0 {
+		var servers []config.MCPServerEntry
+		for _, server := range cfg.Servers {
+			if server.Enabled {
+				servers = append(servers, server)
+			}
+		}
+		return servers, nil
+	}
+
+	servers := make([]config.MCPServerEntry, 0, len(names))
+	for _, name := range names {
+		server, err := cfg.ReadEntry(name)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, *server)
+	}
+	return servers, nil
+}
+
+// probeMCPServers initializes each server and discovers its tools. Servers
+// are probed as enabled and without their container, like infer doctor does.
+func probeMCPServers(ctx context.Context, cfg *config.MCPConfig, servers []config.MCPServerEntry) []mcpProbeResult {
+	probeConfig := *cfg
+	probeConfig.Servers = make([]config.MCPServerEntry, 0, len(servers))
+	for _, server := range servers {
+		server.Enabled, server.Run = true, false
+		probeConfig.Servers = append(probeConfig.Servers, server)
+	}
+
+	manager := services.NewMCPManager(domain.SessionID("mcp-test"), &probeConfig, nil, nil)
+	defer func() { _ = manager.Close() }()
+
+	results := make([]mcpProbeResult, 0, len(servers))
+	for _, server := range servers {
+		result := mcpProbeResult{server: server}
+		start := time.Now()
+		discovered, err := manager.GetClient(server.Name).DiscoverTools(ctx)
+		result.latency = time.Since(start)
+		if err != nil {
+			result.err = err
+		} else {
+			result.tools = discovered[server.Name]
+			sort.Slice(result.tools, func(i, j int) bool { return result.tools[i].Name < result.tools[j].Name })
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func runMCPTest(ctx context.Context, w io.Writer, cfg *config.MCPConfig, names []string, verbose bool) error {
+	servers, err := selectMCPServers(cfg, names)
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		_, _ = fmt.Fprintln(w, "No MCP servers enabled.")
+		_, _ = fmt.Fprintln(w, "Test a disabled one with 'infer mcp test <name>'.")
+		return nil
+	}
+	if !cfg.Enabled {
+		_, _ = fmt.Fprintln(w, listHint("MCP is disabled globally; chat sessions will not connect until 'infer mcp enable-global'."))
+		_, _ = fmt.Fprintln(w)
+	}
+
+	results := probeMCPServers(ctx, cfg, servers)
+	failed := 0
+	for _, result := range results {
+		printMCPProbeResult(w, result, verbose)
+		if result.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d MCP server(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// printMCPProbeResult prints one server's connection status and its tools
+func printMCPProbeResult(w io.Writer, result mcpProbeResult, verbose bool) {
+	server := result.server
+	if result.err != nil {
+		_, _ = fmt.Fprintf(w, "%s %s  %s\n", icons.CrossMarkStyle.Render(icons.CrossMark), listTitle(server.Name), server.GetURL())
+		_, _ = fmt.Fprintf(w, "  %v\n", result.err)
+		if server.Run {
+			_, _ = fmt.Fprintln(w, listHint("  This server is auto-started: the CLI starts its container when a session begins."))
+		}
+		_, _ = fmt.Fprintln(w)
+		return
+	}
+
+	hidden := 0
+	for _, tool := range result.tools {
+		if !server.ShouldIncludeTool(tool.Name) {
+			hidden++
+		}
+	}
+	summary := fmt.Sprintf("%d tool(s), %s", len(result.tools), result.latency.Round(time.Millisecond))
+	if hidden > 0 {
+		summary = fmt.Sprintf("%d tool(s), %d filtered out, %s", len(result.tools), hidden, result.latency.Round(time.Millisecond))
+	}
+	_, _ = fmt.Fprintf(w, "%s %s  %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), listTitle(server.Name), server.GetURL())
+	_, _ = fmt.Fprintln(w, listHint("  "+summary))
+
+	for _, tool := range result.tools {
+		name := tool.Name
+		if !server.ShouldIncludeTool(tool.Name) {
+			name += " " + listHint("(filtered)")
+		}
+		line := "  " + icons.BulletIcon + " " + name
+		if verbose && tool.Description != "" {
+			line += " - " + strings.Join(strings.Fields(tool.Description), " ")
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// completeMCPServers completes the names of the servers in mcp.yaml
+func completeMCPServers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.LoadMCP(getMCPConfigPath(cmd))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(cfg.Servers))
+	for _, server := range cfg.Servers {
+		if !slices.Contains(args, server.Name) {
+			names = append(names, server.Name+"\t"+server.GetURL())
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestSelectMCPServers(t *testing.T) {
+	cfg := &config.MCPConfig{Servers: []config.MCPServerEntry{
+		{Name: "fs", Host: "localhost", Port: 3000, Path: "/mcp", Enabled: true},
+		{Name: "search", Host: "localhost", Port: 3001, Path: "/mcp", Enabled: false},
+	}}
+
+	servers, err := selectMCPServers(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 1 || servers[0].Name != "fs" {
+		t.Errorf("default selection = %+v, want only the enabled server", servers)
+	}
+
+	servers, err = selectMCPServers(cfg, []string{"search"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 1 || servers[0].Name != "search" {
+		t.Errorf("named selection = %+v, want the disabled server", servers)
+	}
+
+	if _, err := selectMCPServers(cfg, []string{"missing"}); err == nil {
+		t.Error("expected an error for an unknown server")
+	}
+}
+
+func TestPrintMCPProbeResult(t *testing.T) {
+	server := config.MCPServerEntry{Name: "fs", Host: "localhost", Port: 3000, Path: "/mcp", ExcludeTools: []string{"delete_file"}}
+
+	var out bytes.Buffer
+	printMCPProbeResult(&out, mcpProbeResult{
+		server:  server,
+		latency: 12 * time.Millisecond,
+		tools: []domain.MCPDiscoveredTool{
+			{Name: "delete_file"},
+			{Name: "read_file", Description: "Read a\nfile"},
+		},
+	}, true)
+	for _, want := range []string{"fs", "2 tool(s), 1 filtered out", "(filtered)", "read_file - Read a file"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	printMCPProbeResult(&out, mcpProbeResult{server: server, err: errors.New("connection refused")}, false)
+	if !strings.Contains(out.String(), "connection refused") {
+		t.Errorf("failure output = %q", out.String())
+	}
+}
+
+func TestRunMCPTestNoServers(t *testing.T) {
+	cfg := &config.MCPConfig{Enabled: true}
+	var out bytes.Buffer
+	if err := runMCPTest(context.Background(), &out, cfg, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No MCP servers enabled.") {
+		t.Errorf("output = %q", out.String())
+	}
+}
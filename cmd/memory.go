@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	tools "github.com/inference-gateway/cli/internal/agent/tools"
+	memory "github.com/inference-gateway/cli/internal/infra/memory"
+	project "github.com/inference-gateway/cli/internal/project"
+)
+
+var memoryCmd = &cobra.Command{
+	Use:   "memory",
+	Short: "Manage the agent's persistent memory",
+	Long: `Inspect and prune the facts the agent remembers across sessions.
+
+Facts are Markdown files in the memory directory (~/.infer/memory unless
+memory.dir is set), global ones at its root and project ones under a
+directory per project, catalogued by MEMORY.md. The agent writes them with the
+Memory tool and, when memory.distill.enabled is set, at the end of every
+session; the index is injected at session start.`,
+}
+
+var memoryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List remembered facts",
+	Long: `List remembered facts with their type and description.
+
+Examples:
+  # Every fact
+  infer memory list
+
+  # Facts of the current project only
+  infer memory list --project .
+
+  # Global (user-wide) facts only, as JSON
+  infer memory list --global --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		projectFilter, _ := cmd.Flags().GetString("project")
+		global, _ := cmd.Flags().GetBool("global")
+		if global && projectFilter != "" {
+			return fmt.Errorf("--global and --project are mutually exclusive")
+		}
+		if projectFilter == "." {
+			projectFilter = project.Detect().Slug
+		}
+
+		syncMemoryIn(cmd.Context(), Cfg)
+		dir, err := Cfg.ResolveMemoryDir()
+		if err != nil {
+			return err
+		}
+		return listMemories(os.Stdout, dir, format, project.Slugify(projectFilter), global)
+	},
+}
+
+var memoryForgetCmd = &cobra.Command{
+	Use:   "forget <name>",
+	Short: "Forget a remembered fact",
+	Long: `Delete a fact and its index entry. Use the name shown by 'infer memory
+list': "<slug>" for a global fact or "<project>/<slug>" for a project fact.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeMemoryNames),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return forgetMemory(cmd.Context(), os.Stdout, Cfg, args[0])
+	},
+}
+
+func init() {
+	memoryListCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+	memoryListCmd.Flags().String("project", "", "Only list facts of this project (. for the current one)")
+	memoryListCmd.Flags().Bool("global", false, "Only list global facts")
+	memoryCmd.AddCommand(memoryListCmd)
+	memoryCmd.AddCommand(memoryForgetCmd)
+	rootCmd.AddCommand(memoryCmd)
+}
+
+// syncMemoryIn pulls the memory directory from the configured backend so the
+// commands see facts written on other machines (a no-op for the local backend)
+func syncMemoryIn(ctx context.Context, cfg *config.Config) {
+	if cfg.Memory.Enabled {
+		_ = memory.NewMemoryBackend(cfg).SyncIn(ctx)
+	}
+}
+
+// listMemories prints the facts in dir, keeping only global ones when global
+// is set and only those of projectSlug when it is non-empty.
+func listMemories(w io.Writer, dir, format, projectSlug string, global bool) error {
+	all, err := tools.ListMemories(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list memories: %w", err)
+	}
+
+	entries := make([]tools.MemoryEntry, 0, len(all))
+	for _, entry := range all {
+		entryProject, _, isProject := strings.Cut(entry.Name, "/")
+		switch {
+		case global && isProject:
+			continue
+		case projectSlug != "" && (!isProject || entryProject != projectSlug):
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if format == "json" {
+		output, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal memories: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintln(w, "No memories recorded.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Memories (%d)", len(entries))))
+	_, _ = fmt.Fprintln(w)
+	memoriesTable := newListTable("Name", "Type", "Description", "Updated")
+	for _, entry := range entries {
+		memoriesTable.Row(entry.Name, entry.Type, entry.Description, entry.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	_, _ = fmt.Fprintln(w, memoriesTable.Render())
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listHint("Forget a fact with 'infer memory forget <name>'."))
+	return nil
+}
+
+// forgetMemory deletes a fact through the Memory tool, so the index is updated
+// and the change pushed to the backend like a delete by the agent.
+func forgetMemory(ctx context.Context, w io.Writer, cfg *config.Config, name string) error {
+	if !cfg.Memory.Enabled {
+		return fmt.Errorf("memory is disabled (memory.enabled in memory.yaml)")
+	}
+
+	syncMemoryIn(ctx, cfg)
+	tool := tools.NewMemoryTool(cfg, memory.NewMemoryBackend(cfg), project.Detect())
+	result, err := tool.Execute(ctx, map[string]any{
+		"operation": tools.OperationDelete,
+		"name":      name,
+	})
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return fmt.Errorf("%s", result.Error)
+	}
+	if data, ok := result.Data.(*tools.MemoryToolResult); ok {
+		_, _ = fmt.Fprintln(w, data.Message)
+	}
+	return nil
+}
+
+// completeMemoryNames completes the names of remembered facts.
+func completeMemoryNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	dir, err := Cfg.ResolveMemoryDir()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	entries, err := tools.ListMemories(dir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name, toComplete) {
+			names = append(names, entry.Name+"\t"+entry.Description)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
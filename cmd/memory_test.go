@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+	tools "github.com/inference-gateway/cli/internal/agent/tools"
+	project "github.com/inference-gateway/cli/internal/project"
+)
+
+func newMemoryTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Memory = *config.DefaultMemoryConfig()
+	cfg.Memory.Dir = t.TempDir()
+	cfg.Prompts = *config.DefaultPromptsConfig()
+
+	tool := tools.NewMemoryTool(cfg, nil, project.Identity{Slug: "acme-api", Name: "acme/api"})
+	for _, fact := range []map[string]any{
+		{"name": "prefers-tabs", "type": "user", "description": "Prefers tabs", "content": "Tabs."},
+		{"name": "deploy-freeze", "type": "project", "description": "Deploy freeze on Fridays", "content": "No deploys on Fridays."},
+	} {
+		fact["operation"] = tools.OperationWrite
+		result, err := tool.Execute(context.Background(), fact)
+		if err != nil || !result.Success {
+			t.Fatalf("seeding %v: %v %+v", fact["name"], err, result)
+		}
+	}
+	return cfg
+}
+
+func TestListMemories(t *testing.T) {
+	cfg := newMemoryTestConfig(t)
+
+	names := func(projectSlug string, global bool) []string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := listMemories(&buf, cfg.Memory.Dir, "json", projectSlug, global); err != nil {
+			t.Fatalf("listMemories: %v", err)
+		}
+		var entries []tools.MemoryEntry
+		if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Name)
+		}
+		return out
+	}
+
+	if got := names("", false); strings.Join(got, ",") != "acme-api/deploy-freeze,prefers-tabs" {
+		t.Errorf("all = %v", got)
+	}
+	if got := names("", true); strings.Join(got, ",") != "prefers-tabs" {
+		t.Errorf("global = %v", got)
+	}
+	if got := names("acme-api", false); strings.Join(got, ",") != "acme-api/deploy-freeze" {
+		t.Errorf("project = %v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := listMemories(&buf, cfg.Memory.Dir, "text", "", false); err != nil {
+		t.Fatalf("listMemories: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Deploy freeze on Fridays") || !strings.Contains(buf.String(), "project") {
+		t.Errorf("text output missing fact details:\n%s", buf.String())
+	}
+}
+
+func TestForgetMemory(t *testing.T) {
+	cfg := newMemoryTestConfig(t)
+
+	var buf bytes.Buffer
+	if err := forgetMemory(context.Background(), &buf, cfg, "acme-api/deploy-freeze"); err != nil {
+		t.Fatalf("forgetMemory: %v", err)
+	}
+	if !strings.Contains(buf.String(), `Deleted memory "acme-api/deploy-freeze"`) {
+		t.Errorf("output = %q", buf.String())
+	}
+	if _, err := os.Stat(filepath.Join(cfg.Memory.Dir, "acme-api", "deploy-freeze.md")); !os.IsNotExist(err) {
+		t.Errorf("fact file still present: %v", err)
+	}
+	index, _ := os.ReadFile(filepath.Join(cfg.Memory.Dir, config.MemoryIndexFileName))
+	if strings.Contains(string(index), "deploy-freeze") {
+		t.Errorf("index still lists the fact:\n%s", index)
+	}
+
+	cfg.Memory.Enabled = false
+	if err := forgetMemory(context.Background(), &buf, cfg, "prefers-tabs"); err == nil {
+		t.Error("expected an error when memory is disabled")
+	}
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	container "github.com/inference-gateway/cli/internal/container"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	models "github.com/inference-gateway/cli/internal/models"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+var modelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "List the models available from the gateway",
+	Long: `List the models the inference gateway serves, with each model's context
+window and its per-token pricing. Prices come from pricing.custom_prices when
+set, otherwise from the gateway; the gateway does not report vision or tool
+support per model, so those are not listed.
+
+Examples:
+  infer models
+  infer models --provider anthropic
+  infer models --sort cost
+  infer models --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, _ := cmd.Flags().GetString("provider")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		format, _ := cmd.Flags().GetString("format")
+		return RunModelsCommand(Cfg, provider, sortBy, format)
+	},
+}
+
+// modelInfo is one row of `infer models`. Prices are per million tokens and
+// are omitted when the model has no known price.
+type modelInfo struct {
+	ID            string   `json:"id"`
+	Provider      string   `json:"provider"`
+	ContextWindow int      `json:"context_window,omitempty"`
+	InputPrice    *float64 `json:"input_price_per_mtok,omitempty"`
+	OutputPrice   *float64 `json:"output_price_per_mtok,omitempty"`
+	Subscription  bool     `json:"subscription,omitempty"`
+	Default       bool     `json:"default,omitempty"`
+}
+
+// RunModelsCommand lists the gateway's models
+func RunModelsCommand(cfg *config.Config, provider, sortBy, format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q: must be \"text\" or \"json\"", format)
+	}
+
+	svc := container.NewServiceContainer(cfg)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = svc.Shutdown(ctx)
+	}()
+
+	if err := svc.GetGatewayManager().EnsureStarted(); err != nil {
+		return fmt.Errorf("failed to start inference gateway: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Gateway.Timeout)*time.Second)
+	defer cancel()
+
+	ids, err := svc.GetModelService().ListModels(ctx)
+	if err != nil {
+		return fmt.Errorf("inference gateway is not available: %w", err)
+	}
+
+	rows, err := buildModelInfos(ids, svc.GetPricingService(), cfg.Agent.Model, provider, sortBy)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		out, err := json.MarshalIndent(map[string]any{"models": rows, "count": len(rows)}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal models to JSON: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Print(renderModelsTable(rows, provider))
+	return nil
+}
+
+// buildModelInfos describes the models with a provider prefix matching
+// provider (all when empty), sorted by name, cost or context window. Models
+// without a known price sort after the priced ones.
+func buildModelInfos(ids []string, pricing domain.PricingService, defaultModel, provider, sortBy string) ([]modelInfo, error) {
+	rows := make([]modelInfo, 0, len(ids))
+	for _, id := range ids {
+		modelProvider, _, _ := strings.Cut(id, "/")
+		if provider != "" && !strings.EqualFold(modelProvider, provider) {
+			continue
+		}
+
+		row := modelInfo{ID: id, Provider: modelProvider, Default: id == defaultModel}
+		if window, ok := models.LookupContextWindow(id); ok {
+			row.ContextWindow = window
+		}
+		if pricing != nil {
+			row.Subscription = pricing.RequiresPro(id)
+			if pricing.FormatModelPricing(id) != "" {
+				input, output := pricing.GetInputPrice(id), pricing.GetOutputPrice(id)
+				row.InputPrice, row.OutputPrice = &input, &output
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	var less func(a, b modelInfo) bool
+	switch sortBy {
+	case "name", "":
+		less = func(a, b modelInfo) bool { return a.ID < b.ID }
+	case "cost":
+		less = func(a, b modelInfo) bool {
+			if (a.InputPrice == nil) != (b.InputPrice == nil) {
+				return a.InputPrice != nil
+			}
+			if a.InputPrice != nil {
+				if costA, costB := *a.InputPrice+*a.OutputPrice, *b.InputPrice+*b.OutputPrice; costA != costB {
+					return costA < costB
+				}
+			}
+			return a.ID < b.ID
+		}
+	case "context":
+		less = func(a, b modelInfo) bool {
+			if a.ContextWindow != b.ContextWindow {
+				return a.ContextWindow > b.ContextWindow
+			}
+			return a.ID < b.ID
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sort %q: must be \"name\", \"cost\" or \"context\"", sortBy)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return less(rows[i], rows[j]) })
+
+	return rows, nil
+}
+
+func renderModelsTable(rows []modelInfo, provider string) string {
+	var sb strings.Builder
+	if len(rows) == 0 {
+		if provider != "" {
+			return fmt.Sprintf("No models from provider %q.\n", provider)
+		}
+		return "No models available.\n"
+	}
+
+	sb.WriteString(listTitle(fmt.Sprintf("Models (%d)", len(rows))) + "\n\n")
+
+	t := newListTable("Default", "Model", "Context", "Input $/MTok", "Output $/MTok", "Billing")
+	for _, row := range rows {
+		isDefault := ""
+		if row.Default {
+			isDefault = icons.CheckMark
+		}
+		window, input, output, billing := "?", "-", "-", ""
+		if row.ContextWindow > 0 {
+			window = formatting.FormatContextWindow(row.ContextWindow)
+		}
+		if row.InputPrice != nil {
+			input, output = fmt.Sprintf("%.2f", *row.InputPrice), fmt.Sprintf("%.2f", *row.OutputPrice)
+			if *row.InputPrice == 0 && *row.OutputPrice == 0 {
+				billing = "free"
+			}
+		}
+		if row.Subscription {
+			billing = "subscription"
+		}
+		t.Row(isDefault, row.ID, window, input, output, billing)
+	}
+	sb.WriteString(t.Render() + "\n\n")
+
+	sb.WriteString(listHint("Prices are per million tokens; - means unknown. Set the default with: infer config set agent.model <model>") + "\n")
+	return sb.String()
+}
+
+func init() {
+	modelsCmd.Flags().String("provider", "", "Only list models from this provider (e.g. openai, anthropic)")
+	modelsCmd.Flags().String("sort", "name", "Sort by name, cost (input + output price) or context (largest first)")
+	modelsCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+	rootCmd.AddCommand(modelsCmd)
+}
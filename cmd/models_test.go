@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+	models "github.com/inference-gateway/cli/internal/models"
+	services "github.com/inference-gateway/cli/internal/services"
+)
+
+func TestBuildModelInfos(t *testing.T) {
+	models.SetGatewayContextWindows(map[string]int{"openai/gpt-4o": 128_000, "anthropic/claude": 200_000})
+	defer models.SetGatewayContextWindows(nil)
+
+	pricing := services.NewPricingService(&config.PricingConfig{
+		Enabled: true,
+		CustomPrices: map[string]config.CustomPricing{
+			"openai/gpt-4o":    {InputPricePerMToken: 2.5, OutputPricePerMToken: 10},
+			"anthropic/claude": {InputPricePerMToken: 3, OutputPricePerMToken: 15},
+			"ollama/llama3":    {},
+		},
+	})
+	ids := []string{"openai/gpt-4o", "anthropic/claude", "ollama/llama3", "custom/unpriced"}
+
+	modelIDs := func(rows []modelInfo) string {
+		out := make([]string, 0, len(rows))
+		for _, row := range rows {
+			out = append(out, row.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		sortBy   string
+		provider string
+		want     string
+	}{
+		{sortBy: "name", want: "anthropic/claude,custom/unpriced,ollama/llama3,openai/gpt-4o"},
+		{sortBy: "cost", want: "ollama/llama3,openai/gpt-4o,anthropic/claude,custom/unpriced"},
+		{sortBy: "context", want: "anthropic/claude,openai/gpt-4o,custom/unpriced,ollama/llama3"},
+		{sortBy: "name", provider: "OpenAI", want: "openai/gpt-4o"},
+	}
+	for _, tt := range tests {
+		rows, err := buildModelInfos(ids, pricing, "openai/gpt-4o", tt.provider, tt.sortBy)
+		if err != nil {
+			t.Fatalf("buildModelInfos(%q): %v", tt.sortBy, err)
+		}
+		if got := modelIDs(rows); got != tt.want {
+			t.Errorf("sort %q, provider %q: got %s, want %s", tt.sortBy, tt.provider, got, tt.want)
+		}
+	}
+
+	rows, _ := buildModelInfos(ids, pricing, "openai/gpt-4o", "openai", "name")
+	row := rows[0]
+	if !row.Default || row.ContextWindow != 128_000 || row.InputPrice == nil || *row.OutputPrice != 10 {
+		t.Errorf("unexpected row %+v", row)
+	}
+
+	if _, err := buildModelInfos(ids, pricing, "", "", "speed"); err == nil {
+		t.Error("expected an error for an unknown sort")
+	}
+}
+
+func TestRenderModelsTable(t *testing.T) {
+	input, output := 0.0, 0.0
+	out := renderModelsTable([]modelInfo{
+		{ID: "ollama/llama3", ContextWindow: 8192, InputPrice: &input, OutputPrice: &output},
+		{ID: "custom/unpriced"},
+	}, "")
+
+	for _, want := range []string{"Models (2)", "8K", "free", "custom/unpriced"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table is missing %q:\n%s", want, out)
+		}
+	}
+
+	if out := renderModelsTable(nil, "mistral"); !strings.Contains(out, `No models from provider "mistral"`) {
+		t.Errorf("unexpected empty output %q", out)
+	}
+}
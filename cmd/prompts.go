@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	prompttemplates "github.com/inference-gateway/cli/internal/services/prompttemplates"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+var promptTemplateNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+var promptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "Manage reusable prompt templates",
+	Long: `Manage the reusable prompt templates kept under templates in prompts.yaml,
+so a team can share prompts such as "write a migration plan". Templates may
+reference {{variables}}: {{branch}}, {{date}} and {{dir}} are filled in
+automatically, any other variable is passed as key=value.
+
+In chat, /prompt lists the templates and /prompt <name> key=value... puts the
+rendered template in the input; they are also listed in the command palette.`,
+}
+
+var promptsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the prompt templates",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listPromptTemplates(Cfg.Prompts.Templates, getEffectivePromptsConfigPath())
+	},
+}
+
+var promptsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print a prompt template with its variables filled in",
+	Long: `Print a prompt template rendered with the built-in variables and the --var
+values. Variables without a value are left as {{placeholders}} and reported on
+stderr. --raw prints the template as written.
+
+Examples:
+  infer prompts show migration --var file=db/schema.sql
+  infer agent "$(infer prompts show migration --var file=db/schema.sql)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		assignments, _ := cmd.Flags().GetStringArray("var")
+		raw, _ := cmd.Flags().GetBool("raw")
+		return showPromptTemplate(Cfg.Prompts.Templates, args[0], assignments, raw)
+	},
+}
+
+var promptsAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or update a prompt template",
+	Long: `Add a prompt template to prompts.yaml, from --prompt or from --file ("-"
+reads stdin). An existing template is only replaced with --force.
+
+Examples:
+  infer prompts add migration --description "Plan a schema migration" \
+    --prompt "Write a migration plan for {{file}} on {{branch}}"
+  infer prompts add release-notes --file prompts/release-notes.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prompt, _ := cmd.Flags().GetString("prompt")
+		file, _ := cmd.Flags().GetString("file")
+		description, _ := cmd.Flags().GetString("description")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if file != "" {
+			data, err := readPromptFile(file)
+			if err != nil {
+				return err
+			}
+			prompt = string(data)
+		}
+		tmpl := config.PromptTemplate{Name: args[0], Description: description, Prompt: strings.TrimSpace(prompt)}
+		return addPromptTemplate(getEffectivePromptsConfigPath(), tmpl, force)
+	},
+}
+
+var promptsRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a prompt template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return removePromptTemplate(getEffectivePromptsConfigPath(), args[0])
+	},
+}
+
+func listPromptTemplates(templates []config.PromptTemplate, path string) error {
+	if len(templates) == 0 {
+		fmt.Println("No prompt templates configured.")
+		fmt.Println()
+		fmt.Println("To add one: infer prompts add <name> --prompt \"...\"")
+		return nil
+	}
+
+	fmt.Println(listTitle("Prompt Templates"))
+	fmt.Println()
+	fmt.Println(listField("Config Path", path))
+	fmt.Println()
+	fmt.Println(listHint(fmt.Sprintf("%d template(s) configured", len(templates))))
+	fmt.Println()
+
+	templatesTable := newListTable("Name", "Description", "Variables")
+	for _, t := range templates {
+		variables := strings.Join(prompttemplates.Variables(t.Prompt), ", ")
+		templatesTable.Row(t.Name, cmp.Or(t.Description, "-"), cmp.Or(variables, "-"))
+	}
+	fmt.Println(templatesTable.Render())
+	return nil
+}
+
+func showPromptTemplate(templates []config.PromptTemplate, name string, assignments []string, raw bool) error {
+	tmpl, ok := prompttemplates.Find(templates, name)
+	if !ok {
+		return fmt.Errorf("prompt template %q not found", name)
+	}
+	if raw {
+		fmt.Println(tmpl.Prompt)
+		return nil
+	}
+
+	vars, err := prompttemplates.Vars(context.Background(), assignments)
+	if err != nil {
+		return err
+	}
+	rendered, missing := prompttemplates.Render(tmpl.Prompt, vars)
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "%s No value for %s: pass --var <name>=<value>\n", icons.CrossMark, strings.Join(missing, ", "))
+	}
+	fmt.Println(rendered)
+	return nil
+}
+
+func addPromptTemplate(path string, tmpl config.PromptTemplate, force bool) error {
+	if !promptTemplateNamePattern.MatchString(tmpl.Name) {
+		return fmt.Errorf("invalid template name %q: use letters, digits, '-' and '_'", tmpl.Name)
+	}
+	if tmpl.Prompt == "" {
+		return fmt.Errorf("the template needs a prompt: pass --prompt or --file")
+	}
+
+	templates, err := config.LoadPromptTemplates(path)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(templates, func(t config.PromptTemplate) bool { return strings.EqualFold(t.Name, tmpl.Name) })
+	switch {
+	case i >= 0 && !force:
+		return fmt.Errorf("prompt template %q already exists: pass --force to replace it", tmpl.Name)
+	case i >= 0:
+		templates[i] = tmpl
+	default:
+		templates = append(templates, tmpl)
+	}
+
+	if err := config.SavePromptTemplates(path, templates); err != nil {
+		return err
+	}
+	fmt.Printf("%s Prompt template saved: %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), tmpl.Name)
+	fmt.Printf("Configuration saved to %s\n", path)
+	return nil
+}
+
+func removePromptTemplate(path, name string) error {
+	templates, err := config.LoadPromptTemplates(path)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(templates, func(t config.PromptTemplate) bool { return strings.EqualFold(t.Name, name) })
+	if i < 0 {
+		return fmt.Errorf("prompt template %q not found in %s", name, path)
+	}
+
+	if err := config.SavePromptTemplates(path, slices.Delete(templates, i, i+1)); err != nil {
+		return err
+	}
+	fmt.Printf("%s Prompt template removed: %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), name)
+	fmt.Printf("Configuration saved to %s\n", path)
+	return nil
+}
+
+// readPromptFile reads a template body from path, or from stdin for "-"
+func readPromptFile(path string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the prompt: %w", err)
+	}
+	return data, nil
+}
+
+func init() {
+	promptsShowCmd.Flags().StringArray("var", nil, "Variable value as key=value (repeatable)")
+	promptsShowCmd.Flags().Bool("raw", false, "Print the template without filling in variables")
+
+	promptsAddCmd.Flags().StringP("prompt", "p", "", "Template text")
+	promptsAddCmd.Flags().StringP("file", "f", "", `Read the template text from a file ("-" for stdin)`)
+	promptsAddCmd.Flags().StringP("description", "d", "", "Short description shown in lists")
+	promptsAddCmd.Flags().Bool("force", false, "Replace an existing template with the same name")
+	promptsAddCmd.MarkFlagsMutuallyExclusive("prompt", "file")
+
+	promptsCmd.AddCommand(promptsListCmd)
+	promptsCmd.AddCommand(promptsShowCmd)
+	promptsCmd.AddCommand(promptsAddCmd)
+	promptsCmd.AddCommand(promptsRemoveCmd)
+	rootCmd.AddCommand(promptsCmd)
+}
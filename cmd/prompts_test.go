@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestAddAndRemovePromptTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompts.yaml")
+	migration := config.PromptTemplate{Name: "migration", Prompt: "Write a migration plan for {{file}}"}
+
+	if err := addPromptTemplate(path, migration, false); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if err := addPromptTemplate(path, config.PromptTemplate{Name: "Migration", Prompt: "other"}, false); err == nil {
+		t.Error("adding an existing name without --force must fail")
+	}
+	migration.Description = "Plan a migration"
+	if err := addPromptTemplate(path, migration, true); err != nil {
+		t.Fatalf("add --force failed: %v", err)
+	}
+	for _, invalid := range []config.PromptTemplate{{Name: "has space", Prompt: "x"}, {Name: "empty"}} {
+		if err := addPromptTemplate(path, invalid, false); err == nil {
+			t.Errorf("adding %+v must fail", invalid)
+		}
+	}
+
+	templates, err := config.LoadPromptTemplates(path)
+	if err != nil || len(templates) != 1 || templates[0] != migration {
+		t.Fatalf("unexpected templates %+v, %v", templates, err)
+	}
+
+	if err := removePromptTemplate(path, "MIGRATION"); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if err := removePromptTemplate(path, "migration"); err == nil {
+		t.Error("removing a missing template must fail")
+	}
+	if templates, _ := config.LoadPromptTemplates(path); len(templates) != 0 {
+		t.Errorf("template not removed: %+v", templates)
+	}
+}
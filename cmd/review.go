@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	huh "charm.land/huh/v2"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	container "github.com/inference-gateway/cli/internal/container"
+	prreview "github.com/inference-gateway/cli/internal/services/prreview"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review <pr-number>",
+	Short: "Review a GitHub pull request and post the findings as review comments",
+	Long: `Fetch a pull request of the current repository through the gh CLI, review
+its diff with the git.review.system_prompt prompt from prompts.yaml, and post
+the findings as a review: each finding on a line of the diff becomes an inline
+comment, the others are listed in the review body.
+
+The review is shown and needs your confirmation before it is posted; --yes
+skips the confirmation and --dry-run only shows it. The model is --model, else
+git.review.model, else agent.model. Reviews are posted as comments; they never
+approve or request changes.
+
+Examples:
+  infer review 42
+  infer review 42 --dry-run
+  infer review 42 --model anthropic/claude-sonnet-4 --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		number, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+		if err != nil || number <= 0 {
+			return fmt.Errorf("invalid pull request number %q", args[0])
+		}
+		model, _ := cmd.Flags().GetString("model")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return RunReviewCommand(Cfg, number, model, yes, dryRun)
+	},
+}
+
+// RunReviewCommand reviews pull request number and, once confirmed, posts
+// the findings on it
+func RunReviewCommand(cfg *config.Config, number int, modelFlag string, yes, dryRun bool) error {
+	if !prreview.IsAvailable() {
+		return fmt.Errorf("gh CLI not found: install it from https://cli.github.com and run gh auth login")
+	}
+	model := cmp.Or(modelFlag, cfg.Git.Review.Model, cfg.Agent.Model)
+	if model == "" {
+		return fmt.Errorf("no review model: pass --model or set git.review.model or agent.model")
+	}
+
+	ctx := context.Background()
+	prs := prreview.New()
+	pr, err := prs.GetPullRequest(ctx, number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull request #%d: %w", number, err)
+	}
+	if strings.TrimSpace(pr.Diff) == "" {
+		return fmt.Errorf("pull request #%d has no changes to review", number)
+	}
+
+	svc := container.NewServiceContainer(cfg)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = svc.Shutdown(ctx)
+	}()
+	if err := svc.GetGatewayManager().EnsureStarted(); err != nil {
+		return fmt.Errorf("failed to start inference gateway: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s Reviewing #%d %s with %s...\n", icons.BulletIcon, pr.Number, pr.Title, model)
+	text, err := completeWithDiff(ctx, svc.NewSDKClient(), model, cfg.Prompts.Git.Review.SystemPrompt, pullRequestReviewIntro(pr), pr.Diff)
+	if err != nil {
+		return fmt.Errorf("review failed: %w", err)
+	}
+
+	findings := parseCIFindings(text)
+	if len(findings) == 0 {
+		fmt.Printf("%s No findings on #%d, nothing to post\n", icons.CheckMark, pr.Number)
+		return nil
+	}
+
+	review := buildPullRequestReview(findings, prreview.CommentableLines(pr.Diff), model)
+	fmt.Print(renderPullRequestReview(pr, review))
+	if dryRun {
+		return nil
+	}
+	if err := confirmPostReview(yes, pr.Number); err != nil {
+		return err
+	}
+
+	url, err := prs.PostReview(ctx, pr, review)
+	if err != nil {
+		return fmt.Errorf("failed to post the review: %w", err)
+	}
+	fmt.Printf("%s Posted review: %s\n", icons.CheckMark, url)
+	return nil
+}
+
+// pullRequestReviewIntro gives the model the pull request's title and
+// description ahead of its diff
+func pullRequestReviewIntro(pr *prreview.PullRequest) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Pull request #%d: %s\n", pr.Number, pr.Title)
+	if pr.BaseRef != "" {
+		fmt.Fprintf(&sb, "Merges %s into %s\n", pr.HeadRef, pr.BaseRef)
+	}
+	if body := strings.TrimSpace(pr.Body); body != "" {
+		sb.WriteString("\nDescription:\n" + body + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// buildPullRequestReview turns findings into inline comments when their line
+// is in the diff and into review body entries otherwise
+func buildPullRequestReview(findings []ciFinding, commentable map[string]map[int]bool, model string) prreview.Review {
+	var review prreview.Review
+	var general []string
+	for _, f := range findings {
+		label := fmt.Sprintf("**[%s]**", strings.ToUpper(f.Severity))
+		if commentable[f.File][f.Line] {
+			review.Comments = append(review.Comments, prreview.Comment{Path: f.File, Line: f.Line, Body: label + " " + f.Message})
+			continue
+		}
+		location := ""
+		if f.File != "" {
+			location = fmt.Sprintf(" `%s`", f.File)
+			if f.Line > 0 {
+				location = fmt.Sprintf(" `%s:%d`", f.File, f.Line)
+			}
+		}
+		general = append(general, fmt.Sprintf("- %s%s %s", label, location, f.Message))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Review by `infer review` with %s: %d finding(s).", model, len(findings))
+	if len(general) > 0 {
+		sb.WriteString("\n\n" + strings.Join(general, "\n"))
+	}
+	review.Body = sb.String()
+	return review
+}
+
+func renderPullRequestReview(pr *prreview.PullRequest, review prreview.Review) string {
+	var sb strings.Builder
+	sb.WriteString("\n" + listTitle(fmt.Sprintf("Review of #%d %s", pr.Number, pr.Title)) + "\n\n")
+	sb.WriteString(review.Body + "\n")
+	if len(review.Comments) > 0 {
+		sb.WriteString("\n" + listTitle(fmt.Sprintf("Inline comments (%d)", len(review.Comments))) + "\n\n")
+		for _, c := range review.Comments {
+			sb.WriteString(listField(fmt.Sprintf("%s:%d", c.Path, c.Line), c.Body) + "\n")
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// confirmPostReview prompts y/N on a TTY; non-interactive stdin errors unless --yes
+func confirmPostReview(yes bool, number int) error {
+	if yes {
+		return nil
+	}
+	if !isInteractiveTerminal() {
+		return fmt.Errorf("confirmation required on non-interactive stdin - pass --yes to post or --dry-run to only show the review")
+	}
+	var ok bool
+	if err := huh.NewConfirm().Title(fmt.Sprintf("Post this review to #%d?", number)).Value(&ok).Run(); err != nil || !ok {
+		return fmt.Errorf("review not posted")
+	}
+	return nil
+}
+
+func init() {
+	reviewCmd.Flags().StringP("model", "m", "", "Model for the review (default: git.review.model, else agent.model)")
+	reviewCmd.Flags().BoolP("yes", "y", false, "Post the review without asking for confirmation")
+	reviewCmd.Flags().Bool("dry-run", false, "Show the review without posting it")
+	_ = reviewCmd.RegisterFlagCompletionFunc("model", completeModels)
+	rootCmd.AddCommand(reviewCmd)
+}
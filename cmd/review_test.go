@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	prreview "github.com/inference-gateway/cli/internal/services/prreview"
+)
+
+func TestBuildPullRequestReview(t *testing.T) {
+	findings := parseCIFindings(`[HIGH] main.go:11 - the error of run is dropped
+[MEDIUM] main.go:90 - no test covers the new branch
+[LOW] the description does not mention the behaviour change`)
+	commentable := map[string]map[int]bool{"main.go": {10: true, 11: true}}
+
+	review := buildPullRequestReview(findings, commentable, "openai/gpt-4o")
+
+	if len(review.Comments) != 1 || review.Comments[0] != (prreview.Comment{Path: "main.go", Line: 11, Body: "**[HIGH]** the error of run is dropped"}) {
+		t.Errorf("unexpected inline comments %+v", review.Comments)
+	}
+	for _, want := range []string{
+		"with openai/gpt-4o: 3 finding(s)",
+		"- **[MEDIUM]** `main.go:90` no test covers the new branch",
+		"- **[LOW]** the description does not mention",
+	} {
+		if !strings.Contains(review.Body, want) {
+			t.Errorf("review body is missing %q:\n%s", want, review.Body)
+		}
+	}
+	if strings.Contains(review.Body, "error of run") {
+		t.Error("an inline finding must not be repeated in the body")
+	}
+}
+
+func TestPullRequestReviewIntro(t *testing.T) {
+	intro := pullRequestReviewIntro(&prreview.PullRequest{Number: 42, Title: "Handle run errors", Body: "Fixes #7", BaseRef: "main", HeadRef: "fix-run"})
+	if intro != "Pull request #42: Handle run errors\nMerges fix-run into main\n\nDescription:\nFixes #7\n\n" {
+		t.Errorf("unexpected intro %q", intro)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	viper "github.com/spf13/viper"
 
 	config "github.com/inference-gateway/cli/config"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 	logger "github.com/inference-gateway/cli/internal/logger"
 )
 
@@ -32,7 +33,8 @@ the Inference Gateway. This CLI provides tools for configuration,
 deployment, monitoring, and management of inference services.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		noColors, _ := cmd.Flags().GetBool("no-colors")
-		if noColors || colorprofile.Detect(os.Stdout, os.Environ()) < colorprofile.ANSI {
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		if noColors || noColor || colorprofile.Detect(os.Stdout, os.Environ()) < colorprofile.ANSI {
 			disableOutputColors()
 		}
 	},
@@ -56,6 +58,8 @@ func init() {
 	rootCmd.PersistentFlags().Bool("no-colors", false,
 		"disable ANSI colors in command output (colors are also auto-disabled "+
 			"when stdout is not a terminal or NO_COLOR is set)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "alias for --no-colors")
+	_ = rootCmd.PersistentFlags().MarkHidden("no-color")
 	rootCmd.PersistentFlags().String("tools-bash-allow-append", "",
 		"comma/newline-separated commands added to the bash allow-list in every mode "+
 			"(standard, plan, auto); INFER_TOOLS_BASH_ALLOW_APPEND takes precedence")
@@ -95,6 +99,17 @@ func resolveFlagEnvOverride(flagName, envName string) string {
 	return ""
 }
 
+// configAppendOverrides are the list keys a flag or env var appends to
+// rather than replaces.
+var configAppendOverrides = []struct {
+	key, appendFlag, appendEnv string
+}{
+	{
+		"tools.bash.mode.all.allow",
+		"tools-bash-allow-append", "INFER_TOOLS_BASH_ALLOW_APPEND",
+	},
+}
+
 // applyBashAllowAppends merges flag/env-supplied commands onto the bash
 // allow-list already resolved from defaults and config files. The config-file
 // list (tools.bash.mode.all.allow) is the every-mode baseline that bashAllowFor
@@ -103,16 +118,7 @@ func resolveFlagEnvOverride(flagName, envName string) string {
 // ReadInConfig so the append sees config-file values; v.Set then wins over later
 // layers. The append never replaces the curated defaults - it only adds.
 func applyBashAllowAppends(v *viper.Viper) {
-	appends := []struct {
-		key, appendFlag, appendEnv string
-	}{
-		{
-			"tools.bash.mode.all.allow",
-			"tools-bash-allow-append", "INFER_TOOLS_BASH_ALLOW_APPEND",
-		},
-	}
-
-	for _, a := range appends {
+	for _, a := range configAppendOverrides {
 		if override := resolveFlagEnvOverride(a.appendFlag, a.appendEnv); override != "" {
 			v.Set(a.key, append(v.GetStringSlice(a.key), parseDelimitedList(override)...))
 		}
@@ -126,10 +132,7 @@ func applyBashAllowAppends(v *viper.Viper) {
 // everything else from the home baseline. Net precedence: defaults < home <
 // project < flags < env. A project that omits config.yaml inherits home wholesale.
 func loadLayeredConfig(v *viper.Viper) {
-	homeConfigPath := ""
-	if homeDir, err := os.UserHomeDir(); err == nil {
-		homeConfigPath = filepath.Join(homeDir, config.ConfigDirName, config.ConfigFileName)
-	}
+	homeConfigPath, projectPath := configLayerFiles()
 
 	readLayer := func(path string, merge bool) {
 		v.SetConfigFile(path)
@@ -140,25 +143,45 @@ func loadLayeredConfig(v *viper.Viper) {
 			err = v.ReadInConfig()
 		}
 		if err != nil {
+			if tolerateConfigErrors() {
+				// infer doctor reads the file again and reports why it failed
+				return
+			}
 			fmt.Fprintf(os.Stderr, "Error reading config %s: %v\n", path, err)
 			os.Exit(1)
 		}
 	}
 
 	loaded := false
-	if homeConfigPath != "" && fileExists(homeConfigPath) {
+	if homeConfigPath != "" {
 		readLayer(homeConfigPath, false)
 		loaded = true
 	}
 
-	// The project layer is merged on top of home. When home and project resolve
-	// to the same file (e.g. running from within ~/.infer), skip the second read
-	// so a single file isn't merged onto itself.
-	if projectPath := resolveProjectConfigPath(); projectPath != "" && !sameConfigFile(projectPath, homeConfigPath) {
+	// The project layer is merged on top of home
+	if projectPath != "" {
 		readLayer(projectPath, loaded)
 	}
 }
 
+// configLayerFiles returns the userspace and project config.yaml files
+// initConfig layers, "" for a layer without one. When home and project
+// resolve to the same file (e.g. running from within ~/.infer), only home is
+// returned so a single file isn't merged onto itself.
+func configLayerFiles() (home, project string) {
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		home = filepath.Join(homeDir, config.ConfigDirName, config.ConfigFileName)
+	}
+	project = resolveProjectConfigPath()
+	if project != "" && sameConfigFile(project, home) {
+		project = ""
+	}
+	if home != "" && !fileExists(home) {
+		home = ""
+	}
+	return home, project
+}
+
 // resolveProjectConfigPath returns the first existing project-level config.yaml,
 // matching the legacy search order (cwd ./config.yaml, then ./.infer/config.yaml).
 // Returns "" when neither exists.
@@ -171,6 +194,16 @@ func resolveProjectConfigPath() string {
 	return ""
 }
 
+// configLoadErrs holds the config errors initConfig let through for
+// `infer doctor`, which reports them instead of exiting on them.
+var configLoadErrs []error
+
+// tolerateConfigErrors reports whether the command being run is `infer doctor`
+func tolerateConfigErrors() bool {
+	cmd, _, err := rootCmd.Find(os.Args[1:])
+	return err == nil && cmd == doctorCmd
+}
+
 func initConfig() {
 	V = viper.New()
 	v := V
@@ -182,6 +215,18 @@ func initConfig() {
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	var keyringSecrets map[string]string
+	if !skipKeyring() {
+		keyringSecrets = loadKeyringCredentials(keyring.New(), keyringIndexPath())
+	}
+
+	// Unset by default, so AutomaticEnv alone would not pick these up
+	_ = v.BindEnv("agent.unattended")
+	_ = v.BindEnv("agent.audit_log")
+	_ = v.BindEnv("agent.max_cost_per_run")
+	_ = v.BindEnv("agent.max_concurrent_tools")
+	_ = v.BindEnv("pricing.daily_budget")
+
 	if a2aAgents := os.Getenv("INFER_A2A_AGENTS"); a2aAgents != "" {
 		v.Set("a2a.agents", parseDelimitedList(a2aAgents))
 	}
@@ -192,12 +237,19 @@ func initConfig() {
 
 	loadLayeredConfig(v)
 
+	applyKeyringConfig(v, keyringSecrets)
 	applyBashAllowAppends(v)
 
 	cfg, err := loadConfigFromViper()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
+		if !tolerateConfigErrors() {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		configLoadErrs = append(configLoadErrs, err)
+		if cfg == nil {
+			cfg = config.DefaultConfig()
+		}
 	}
 	Cfg = cfg
 	config.UserContextWindows = cfg.ContextWindows
@@ -0,0 +1,512 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	scheduler "github.com/inference-gateway/cli/internal/services/scheduler"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage scheduled jobs",
+	Long: `Create, inspect, pause and run the scheduled jobs the channels-manager
+daemon fires. Jobs run a fresh agent session on a cron schedule and send the
+result to a channel recipient; they only fire while 'infer channels-manager'
+is running. Jobs live in the configured storage backend, so storage must be
+enabled with a non-memory type.
+
+A job can be referred to by its ID, a unique prefix of it, or its name.`,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled jobs with their next run",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return withScheduleStore(func(store storage.ScheduledJobStorage) error {
+			return listScheduledJobs(cmd.Context(), os.Stdout, store, format, time.Now())
+		})
+	},
+}
+
+var scheduleShowCmd = &cobra.Command{
+	Use:               "show <job>",
+	Short:             "Show a scheduled job and the result of its last run",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeScheduledJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return withScheduleStore(func(store storage.ScheduledJobStorage) error {
+			job, err := resolveScheduledJob(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			return showScheduledJob(os.Stdout, job, format, time.Now())
+		})
+	},
+}
+
+var scheduleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a scheduled job",
+	Long: `Create a job that runs the prompt on a cron schedule and sends the
+agent's answer to a channel recipient.
+
+The cron expression has five fields (minute hour day-of-month month
+day-of-week) or a descriptor such as @daily or @every 1h, in local time.
+
+Examples:
+  infer schedule create --name standup --cron "0 9 * * 1-5" \
+    --prompt "Summarize yesterday's merged PRs" --channel telegram --to 123456789
+  infer schedule create --cron "30 17 * * *" --prompt "Remind me to stretch" \
+    --channel whatsapp --to +15551234567 --once`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		job, err := scheduledJobFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		return withScheduleStore(func(store storage.ScheduledJobStorage) error {
+			return createScheduledJob(cmd.Context(), os.Stdout, store, Cfg, job)
+		})
+	},
+}
+
+var schedulePauseCmd = &cobra.Command{
+	Use:               "pause <job>",
+	Short:             "Pause a scheduled job",
+	Long:              `Pause a job: it stays stored, but the scheduler stops firing it until it is resumed.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeScheduledJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withScheduleStore(func(store storage.ScheduledJobStorage) error {
+			return setScheduledJobPaused(cmd.Context(), os.Stdout, store, args[0], true)
+		})
+	},
+}
+
+var scheduleResumeCmd = &cobra.Command{
+	Use:               "resume <job>",
+	Short:             "Resume a paused scheduled job",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeScheduledJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withScheduleStore(func(store storage.ScheduledJobStorage) error {
+			return setScheduledJobPaused(cmd.Context(), os.Stdout, store, args[0], false)
+		})
+	},
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run <job>",
+	Short: "Run a scheduled job now",
+	Long: `Run a job once right away in this terminal and print the agent's answer.
+The output is not sent to the job's channel, which only the channels-manager
+daemon can reach, but the run is recorded as the job's last result. Paused
+jobs can be run too.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeScheduledJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return withScheduleStore(func(store storage.ScheduledJobStorage) error {
+			return runScheduledJob(ctx, os.Stdout, scheduler.Options{Store: store}, args[0])
+		})
+	},
+}
+
+var scheduleDeleteCmd = &cobra.Command{
+	Use:               "delete <job>",
+	Aliases:           []string{"remove"},
+	Short:             "Delete a scheduled job",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeScheduledJobIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withScheduleStore(func(store storage.ScheduledJobStorage) error {
+			return deleteScheduledJob(cmd.Context(), os.Stdout, store, args[0])
+		})
+	},
+}
+
+// openScheduleStore opens the storage backend that holds the scheduled jobs.
+// The memory backend is refused: jobs kept there would be gone before the
+// channels-manager daemon could see them.
+func openScheduleStore(cfg *config.Config) (*storage.Stores, error) {
+	storageConfig := storage.NewStorageFromConfig(cfg)
+	if storageConfig.Type == config.StorageTypeMemory {
+		return nil, fmt.Errorf("the scheduler requires persistent storage: set storage.enabled: true and a non-memory storage.type (e.g. jsonl)")
+	}
+	stores, err := storage.NewStorage(storageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	return stores, nil
+}
+
+// withScheduleStore runs fn with the scheduled job store and closes it after
+func withScheduleStore(fn func(store storage.ScheduledJobStorage) error) error {
+	stores, err := openScheduleStore(Cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stores.Conversations.Close() }()
+	return fn(stores.ScheduledJobs)
+}
+
+// resolveScheduledJob finds a job by exact ID, then by name or unique ID
+// prefix
+func resolveScheduledJob(ctx context.Context, store storage.ScheduledJobStorage, ref string) (*domain.ScheduledJob, error) {
+	job, err := store.LoadJob(ctx, ref)
+	if err == nil {
+		return job, nil
+	}
+	if !errors.Is(err, storage.ErrJobNotFound) {
+		return nil, err
+	}
+
+	jobs, err := store.ListJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled jobs: %w", err)
+	}
+	var matches []*domain.ScheduledJob
+	for _, j := range jobs {
+		if j.Name == ref || strings.HasPrefix(j.ID, ref) {
+			matches = append(matches, j)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("scheduled job %q not found", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%q matches %d scheduled jobs, use the full ID", ref, len(matches))
+	}
+}
+
+// scheduleNextRun describes when job fires next
+func scheduleNextRun(job *domain.ScheduledJob, now time.Time) string {
+	if job.Paused {
+		return "paused"
+	}
+	next, err := scheduler.NextRun(job.CronExpression, now)
+	if err != nil {
+		return "invalid cron"
+	}
+	return next.Local().Format("2006-01-02 15:04")
+}
+
+// scheduleLastRun describes the last run of job and whether it failed
+func scheduleLastRun(job *domain.ScheduledJob) (string, string) {
+	if job.LastRun == nil {
+		return "never", "-"
+	}
+	status := icons.CheckMark + " ok"
+	if job.LastError != "" {
+		status = icons.CrossMark + " failed"
+	}
+	return job.LastRun.Local().Format("2006-01-02 15:04"), status
+}
+
+func listScheduledJobs(ctx context.Context, w io.Writer, store storage.ScheduledJobStorage, format string, now time.Time) error {
+	jobs, err := store.ListJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list scheduled jobs: %w", err)
+	}
+
+	if format == "json" {
+		output, err := json.MarshalIndent(jobs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal scheduled jobs: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	if len(jobs) == 0 {
+		_, _ = fmt.Fprintln(w, "No scheduled jobs configured.")
+		_, _ = fmt.Fprintln(w, "Use 'infer schedule create' to add one.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Scheduled Jobs (%d)", len(jobs))))
+	_, _ = fmt.Fprintln(w)
+
+	jobsTable := newListTable("ID", "Name", "Cron", "Next Run", "Last Run", "Status", "Channel")
+	for _, job := range jobs {
+		lastRun, status := scheduleLastRun(job)
+		name := job.Name
+		if name == "" {
+			name = "-"
+		}
+		cronExpr := job.CronExpression
+		if job.RunOnce {
+			cronExpr += " (once)"
+		}
+		jobsTable.Row(shortJobID(job.ID), name, cronExpr, scheduleNextRun(job, now), lastRun, status, job.Channel)
+	}
+	_, _ = fmt.Fprintln(w, jobsTable.Render())
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listHint("Jobs fire only while 'infer channels-manager' is running. Use 'infer schedule show <job>' for the last result."))
+	return nil
+}
+
+// shortJobID shortens a UUID for tables; any unique prefix resolves
+func shortJobID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func showScheduledJob(w io.Writer, job *domain.ScheduledJob, format string, now time.Time) error {
+	if format == "json" {
+		output, err := json.MarshalIndent(job, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal scheduled job: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	title := job.ID
+	if job.Name != "" {
+		title = job.Name
+	}
+	lastRun, status := scheduleLastRun(job)
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Scheduled Job: %s", title)))
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listField("ID", job.ID))
+	if job.Description != "" {
+		_, _ = fmt.Fprintln(w, listField("Description", job.Description))
+	}
+	_, _ = fmt.Fprintln(w, listField("Cron", job.CronExpression))
+	if job.RunOnce {
+		_, _ = fmt.Fprintln(w, listField("Run Once", "yes"))
+	}
+	_, _ = fmt.Fprintln(w, listField("Next Run", scheduleNextRun(job, now)))
+	_, _ = fmt.Fprintln(w, listField("Channel", fmt.Sprintf("%s → %s", job.Channel, job.RecipientID)))
+	if job.Model != "" {
+		_, _ = fmt.Fprintln(w, listField("Model", job.Model))
+	}
+	_, _ = fmt.Fprintln(w, listField("Created", job.CreatedAt.Local().Format("2006-01-02 15:04")))
+	_, _ = fmt.Fprintln(w, listField("Last Run", lastRun))
+	if job.LastRun != nil {
+		_, _ = fmt.Fprintln(w, listField("Status", status))
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listField("Prompt", ""))
+	_, _ = fmt.Fprintln(w, job.Prompt)
+
+	if job.LastError != "" {
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, listField("Last Error", ""))
+		_, _ = fmt.Fprintln(w, job.LastError)
+	}
+	if job.LastOutput != "" {
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, listField("Last Output", ""))
+		_, _ = fmt.Fprintln(w, job.LastOutput)
+	}
+	return nil
+}
+
+// scheduledJobFromFlags builds a new job from the flags of schedule create
+func scheduledJobFromFlags(cmd *cobra.Command) (*domain.ScheduledJob, error) {
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	cronExpr, _ := cmd.Flags().GetString("cron")
+	prompt, _ := cmd.Flags().GetString("prompt")
+	channel, _ := cmd.Flags().GetString("channel")
+	recipient, _ := cmd.Flags().GetString("to")
+	model, _ := cmd.Flags().GetString("model")
+	once, _ := cmd.Flags().GetBool("once")
+
+	if strings.TrimSpace(prompt) == "" {
+		return nil, fmt.Errorf("--prompt is required")
+	}
+	if strings.TrimSpace(recipient) == "" {
+		return nil, fmt.Errorf("--to is required: the chat or phone number the %s channel sends to", channel)
+	}
+	if err := scheduler.ParseCron(cronExpr); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &domain.ScheduledJob{
+		ID:             uuid.New().String(),
+		Name:           name,
+		Description:    description,
+		CronExpression: cronExpr,
+		Prompt:         prompt,
+		Channel:        strings.ToLower(channel),
+		RecipientID:    recipient,
+		Model:          model,
+		RunOnce:        once,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func createScheduledJob(ctx context.Context, w io.Writer, store storage.ScheduledJobStorage, cfg *config.Config, job *domain.ScheduledJob) error {
+	if !cfg.Channels.ChannelEnabled(job.Channel) {
+		return fmt.Errorf("channel %q is not enabled: enable telegram or whatsapp in channels.yaml", job.Channel)
+	}
+
+	jobs, err := store.ListJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list scheduled jobs: %w", err)
+	}
+	if max := cfg.Tools.Schedule.MaxJobs; max > 0 && len(jobs) >= max {
+		return fmt.Errorf("max_jobs limit (%d) reached: delete a job or raise tools.schedule.max_jobs", max)
+	}
+	if job.Name != "" {
+		for _, existing := range jobs {
+			if existing.Name == job.Name {
+				return fmt.Errorf("a scheduled job named %q already exists (%s)", job.Name, existing.ID)
+			}
+		}
+	}
+
+	if err := store.SaveJob(ctx, job); err != nil {
+		return fmt.Errorf("failed to save scheduled job: %w", err)
+	}
+	_, _ = fmt.Fprintf(w, "%s Scheduled job %s created\n", icons.CheckMarkStyle.Render(icons.CheckMark), job.ID)
+	_, _ = fmt.Fprintf(w, "  Next run: %s\n", scheduleNextRun(job, time.Now()))
+	if !cfg.Tools.Schedule.Enabled {
+		_, _ = fmt.Fprintln(w, listHint("The scheduler is disabled: set tools.schedule.enabled: true so 'infer channels-manager' fires it."))
+	}
+	return nil
+}
+
+func setScheduledJobPaused(ctx context.Context, w io.Writer, store storage.ScheduledJobStorage, ref string, paused bool) error {
+	job, err := resolveScheduledJob(ctx, store, ref)
+	if err != nil {
+		return err
+	}
+	if job.Paused == paused {
+		state := "active"
+		if paused {
+			state = "paused"
+		}
+		_, _ = fmt.Fprintf(w, "Scheduled job %s is already %s\n", job.ID, state)
+		return nil
+	}
+	state := "paused"
+	if !paused {
+		state = "resumed"
+	}
+
+	job.Paused = paused
+	job.UpdatedAt = time.Now().UTC()
+	if err := store.SaveJob(ctx, job); err != nil {
+		return fmt.Errorf("failed to save scheduled job: %w", err)
+	}
+	_, _ = fmt.Fprintf(w, "%s Scheduled job %s %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), job.ID, state)
+	return nil
+}
+
+func runScheduledJob(ctx context.Context, w io.Writer, opts scheduler.Options, ref string) error {
+	job, err := resolveScheduledJob(ctx, opts.Store, ref)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w, listHint(fmt.Sprintf("Running %s in a new agent session...", job.ID)))
+	_, _ = fmt.Fprintln(w)
+
+	err = scheduler.RunNow(ctx, opts, job, func(msg string) {
+		_, _ = fmt.Fprintln(w, msg)
+		_, _ = fmt.Fprintln(w)
+	})
+	if err != nil {
+		return fmt.Errorf("scheduled job %s failed: %w", job.ID, err)
+	}
+	return nil
+}
+
+func deleteScheduledJob(ctx context.Context, w io.Writer, store storage.ScheduledJobStorage, ref string) error {
+	job, err := resolveScheduledJob(ctx, store, ref)
+	if err != nil {
+		return err
+	}
+	if err := store.DeleteJob(ctx, job.ID); err != nil {
+		return fmt.Errorf("failed to delete scheduled job: %w", err)
+	}
+	_, _ = fmt.Fprintf(w, "%s Scheduled job %s deleted\n", icons.CheckMarkStyle.Render(icons.CheckMark), job.ID)
+	return nil
+}
+
+// completeScheduledJobIDs completes the IDs of the scheduled jobs, described
+// by their names
+func completeScheduledJobIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	stores, err := openScheduleStore(Cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer func() { _ = stores.Conversations.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	jobs, err := stores.ScheduledJobs.ListJobs(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		description := job.Name
+		if description == "" {
+			description = job.CronExpression
+		}
+		completions = append(completions, job.ID+"\t"+description)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+func init() {
+	scheduleListCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+	scheduleShowCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+
+	scheduleCreateCmd.Flags().String("cron", "", "Cron expression, e.g. \"0 9 * * 1-5\" or @daily (required)")
+	scheduleCreateCmd.Flags().StringP("prompt", "p", "", "Prompt the agent runs on each fire (required)")
+	scheduleCreateCmd.Flags().String("channel", "telegram", "Channel the answer is sent to (telegram, whatsapp)")
+	scheduleCreateCmd.Flags().String("to", "", "Recipient on the channel: Telegram chat ID or WhatsApp number (required)")
+	scheduleCreateCmd.Flags().StringP("model", "m", "", "Model to run the job with (default: agent.model)")
+	scheduleCreateCmd.Flags().String("name", "", "Short name to refer to the job by")
+	scheduleCreateCmd.Flags().String("description", "", "What the job is for")
+	scheduleCreateCmd.Flags().Bool("once", false, "Fire once at the next match, then delete the job")
+	_ = scheduleCreateCmd.MarkFlagRequired("cron")
+	_ = scheduleCreateCmd.MarkFlagRequired("prompt")
+	_ = scheduleCreateCmd.MarkFlagRequired("to")
+	_ = scheduleCreateCmd.RegisterFlagCompletionFunc("model", completeModels)
+	_ = scheduleCreateCmd.RegisterFlagCompletionFunc("channel", cobra.FixedCompletions([]string{"telegram", "whatsapp"}, cobra.ShellCompDirectiveNoFileComp))
+
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleShowCmd)
+	scheduleCmd.AddCommand(scheduleCreateCmd)
+	scheduleCmd.AddCommand(schedulePauseCmd)
+	scheduleCmd.AddCommand(scheduleResumeCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+	scheduleCmd.AddCommand(scheduleDeleteCmd)
+	rootCmd.AddCommand(scheduleCmd)
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	scheduler "github.com/inference-gateway/cli/internal/services/scheduler"
+)
+
+func newScheduleTestStore(t *testing.T, jobs ...*domain.ScheduledJob) storage.ScheduledJobStorage {
+	t.Helper()
+	store := storage.NewMemoryStorage()
+	for _, job := range jobs {
+		if err := store.SaveJob(context.Background(), job); err != nil {
+			t.Fatalf("SaveJob: %v", err)
+		}
+	}
+	return store
+}
+
+func testScheduledJob(id, name string) *domain.ScheduledJob {
+	return &domain.ScheduledJob{
+		ID:             id,
+		Name:           name,
+		CronExpression: "0 9 * * *",
+		Prompt:         "summarize the news",
+		Channel:        "telegram",
+		RecipientID:    "42",
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+func TestResolveScheduledJob(t *testing.T) {
+	store := newScheduleTestStore(t,
+		testScheduledJob("aaaa1111-0000", "standup"),
+		testScheduledJob("aaaa2222-0000", "digest"),
+	)
+	ctx := context.Background()
+
+	for ref, want := range map[string]string{
+		"aaaa1111-0000": "aaaa1111-0000",
+		"aaaa22":        "aaaa2222-0000",
+		"standup":       "aaaa1111-0000",
+	} {
+		job, err := resolveScheduledJob(ctx, store, ref)
+		if err != nil || job.ID != want {
+			t.Errorf("resolveScheduledJob(%q) = %v, %v; want %s", ref, job, err, want)
+		}
+	}
+	if _, err := resolveScheduledJob(ctx, store, "aaaa"); err == nil || !strings.Contains(err.Error(), "matches 2") {
+		t.Errorf("expected an ambiguous prefix error, got %v", err)
+	}
+	if _, err := resolveScheduledJob(ctx, store, "missing"); err == nil {
+		t.Error("expected an error for an unknown job")
+	}
+}
+
+func TestListScheduledJobs(t *testing.T) {
+	paused := testScheduledJob("bbbb1111-0000", "paused-job")
+	paused.Paused = true
+	store := newScheduleTestStore(t, testScheduledJob("aaaa1111-0000", "standup"), paused)
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.Local)
+
+	var out bytes.Buffer
+	if err := listScheduledJobs(context.Background(), &out, store, "text", now); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Scheduled Jobs (2)", "aaaa1111", "standup", "2026-10-16 09:00", "paused", "never"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("list output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := listScheduledJobs(context.Background(), &out, store, "json", now); err != nil {
+		t.Fatal(err)
+	}
+	var jobs []domain.ScheduledJob
+	if err := json.Unmarshal(out.Bytes(), &jobs); err != nil || len(jobs) != 2 {
+		t.Errorf("json output = %s, %v", out.String(), err)
+	}
+
+	out.Reset()
+	_ = listScheduledJobs(context.Background(), &out, newScheduleTestStore(t), "text", now)
+	if !strings.Contains(out.String(), "No scheduled jobs configured.") {
+		t.Errorf("empty list output = %q", out.String())
+	}
+}
+
+func TestShowScheduledJobIncludesLastResult(t *testing.T) {
+	job := testScheduledJob("aaaa1111-0000", "standup")
+	lastRun := time.Now().UTC()
+	job.LastRun = &lastRun
+	job.LastError = "agent exited with status 1"
+	job.LastOutput = "partial answer"
+
+	var out bytes.Buffer
+	if err := showScheduledJob(&out, job, "text", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Scheduled Job: standup", "telegram → 42", "summarize the news", "agent exited with status 1", "partial answer"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("show output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCreateScheduledJob(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Channels.Telegram.Enabled = true
+	cfg.Tools.Schedule.MaxJobs = 2
+	store := newScheduleTestStore(t, testScheduledJob("aaaa1111-0000", "standup"))
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	if err := createScheduledJob(ctx, &out, store, cfg, testScheduledJob("new-1", "standup")); err == nil {
+		t.Error("expected a duplicate name to be rejected")
+	}
+	whatsapp := testScheduledJob("new-2", "")
+	whatsapp.Channel = "whatsapp"
+	if err := createScheduledJob(ctx, &out, store, cfg, whatsapp); err == nil || !strings.Contains(err.Error(), "not enabled") {
+		t.Errorf("expected a disabled channel to be rejected, got %v", err)
+	}
+	if err := createScheduledJob(ctx, &out, store, cfg, testScheduledJob("new-3", "digest")); err != nil {
+		t.Fatalf("createScheduledJob: %v", err)
+	}
+	if _, err := store.LoadJob(ctx, "new-3"); err != nil {
+		t.Errorf("job not saved: %v", err)
+	}
+	if err := createScheduledJob(ctx, &out, store, cfg, testScheduledJob("new-4", "")); err == nil || !strings.Contains(err.Error(), "max_jobs") {
+		t.Errorf("expected the max_jobs limit, got %v", err)
+	}
+}
+
+func TestSetScheduledJobPaused(t *testing.T) {
+	store := newScheduleTestStore(t, testScheduledJob("aaaa1111-0000", "standup"))
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	if err := setScheduledJobPaused(ctx, &out, store, "standup", true); err != nil {
+		t.Fatal(err)
+	}
+	job, _ := store.LoadJob(ctx, "aaaa1111-0000")
+	if !job.Paused {
+		t.Error("expected the job to be paused")
+	}
+
+	out.Reset()
+	_ = setScheduledJobPaused(ctx, &out, store, "standup", true)
+	if !strings.Contains(out.String(), "already paused") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	if err := setScheduledJobPaused(ctx, &out, store, "aaaa", false); err != nil {
+		t.Fatal(err)
+	}
+	job, _ = store.LoadJob(ctx, "aaaa1111-0000")
+	if job.Paused {
+		t.Error("expected the job to be resumed")
+	}
+}
+
+func TestRunScheduledJob(t *testing.T) {
+	store := newScheduleTestStore(t, testScheduledJob("aaaa1111-0000", "standup"))
+	opts := scheduler.Options{
+		Store: store,
+		ExecCommand: func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+			return exec.CommandContext(ctx, "echo", `{"role":"assistant","content":"three PRs merged"}`)
+		},
+		BinaryPath: "/usr/bin/true",
+	}
+
+	var out bytes.Buffer
+	if err := runScheduledJob(context.Background(), &out, opts, "standup"); err != nil {
+		t.Fatalf("runScheduledJob: %v", err)
+	}
+	if !strings.Contains(out.String(), "three PRs merged") {
+		t.Errorf("output = %q", out.String())
+	}
+	job, _ := store.LoadJob(context.Background(), "aaaa1111-0000")
+	if job.LastRun == nil || job.LastOutput != "three PRs merged" {
+		t.Errorf("run not recorded: %+v", job)
+	}
+}
+
+func TestDeleteScheduledJob(t *testing.T) {
+	store := newScheduleTestStore(t, testScheduledJob("aaaa1111-0000", "standup"))
+	var out bytes.Buffer
+	if err := deleteScheduledJob(context.Background(), &out, store, "standup"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.LoadJob(context.Background(), "aaaa1111-0000"); err == nil {
+		t.Error("expected the job to be deleted")
+	}
+}
@@ -0,0 +1,764 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	uuid "github.com/google/uuid"
+	websocket "github.com/gorilla/websocket"
+	cobra "github.com/spf13/cobra"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	container "github.com/inference-gateway/cli/internal/container"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the agent over an HTTP and WebSocket API",
+	Long: `Serve the configured agent - the same tools, sandbox and approval rules the TUI
+uses - over HTTP, so editors and web UIs can drive it.
+
+Endpoints:
+  GET    /v1/models                                  Models the agent can use
+  POST   /v1/chat/completions                        OpenAI-compatible, runs the agent to completion
+  POST   /v1/sessions                                Start a session
+  GET    /v1/sessions                                List sessions
+  GET    /v1/sessions/{id}                           Session status, messages and pending approvals
+  DELETE /v1/sessions/{id}                           End a session
+  POST   /v1/sessions/{id}/messages                  Send a message to the agent
+  POST   /v1/sessions/{id}/approvals/{tool_call_id}  Approve or reject a tool call
+  GET    /v1/sessions/{id}/events                    WebSocket stream of the session's events
+
+Sessions live in memory and end after 30 minutes without a run or an events
+subscriber. Runs are taken one at a time, since they share the server's
+conversation repository and services; a run waiting for a tool approval lets
+the others go on meanwhile.
+
+Every request needs the API key; without --api-key or $INFER_SERVE_API_KEY a
+key is generated and printed at startup. Browsers are kept out: requests from
+another origin are refused unless allowed with --allowed-origin, the Host
+header must name the server rather than some other domain, and request bodies
+must be application/json.
+
+Examples:
+  infer serve
+  infer serve --port 9000 --api-key "$INFER_SERVE_API_KEY"
+  infer serve --allowed-origin http://localhost:3000`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetInt("port")
+		apiKey, _ := cmd.Flags().GetString("api-key")
+		if apiKey == "" {
+			apiKey = keyring.Getenv("INFER_SERVE_API_KEY")
+		}
+		allowedOrigins, _ := cmd.Flags().GetStringSlice("allowed-origin")
+		return RunServeCommand(Cfg, host, port, apiKey, allowedOrigins)
+	},
+}
+
+// RunServeCommand starts the agent API server and blocks until it is stopped.
+// Without an API key one is generated, so the server is never left open to
+// whatever else runs on the machine.
+func RunServeCommand(cfg *config.Config, host string, port int, apiKey string, allowedOrigins []string) error {
+	generatedKey := apiKey == ""
+	if generatedKey {
+		key, err := generateServeAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to generate an API key: %w", err)
+		}
+		apiKey = key
+	}
+
+	svc := container.NewServiceContainer(cfg)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = svc.Shutdown(ctx)
+	}()
+
+	if err := svc.GetGatewayManager().EnsureStarted(); err != nil {
+		return fmt.Errorf("failed to start inference gateway: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Gateway.Timeout)*time.Second)
+	defer cancel()
+
+	models, err := svc.GetModelService().ListModels(ctx)
+	if err != nil {
+		return fmt.Errorf("inference gateway is not available: %w", err)
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models available from inference gateway")
+	}
+
+	server := newAgentServer(cfg, models, apiKey, host, allowedOrigins, func(model string, requireApproval bool) *AgentSession {
+		return newAgentSession(cfg, svc, model, domain.AgentModeStandard, false, requireApproval, agentOutputJSONL)
+	})
+
+	reapCtx, stopReaping := context.WithCancel(context.Background())
+	defer stopReaping()
+	go server.reapIdleSessions(reapCtx, time.Minute)
+
+	addr := fmt.Sprintf("%s:%d", host, port)
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           server.routes(),
+		ReadHeaderTimeout: 15 * time.Second,
+	}
+
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		logger.Info("shutting down agent server...")
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("agent server shutdown error", "error", err)
+		}
+	}()
+
+	logger.Info("agent server started", "url", fmt.Sprintf("http://%s", addr))
+	fmt.Printf("\nAgent API available at: http://%s/v1\n", addr)
+	if generatedKey {
+		fmt.Printf("API key: %s\n(generated for this run; set --api-key or INFER_SERVE_API_KEY to choose one)\n", apiKey)
+	}
+	fmt.Println()
+
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server error: %w", err)
+	}
+	return nil
+}
+
+// generateServeAPIKey returns a random key for a server started without one
+func generateServeAPIKey() (string, error) {
+	key := make([]byte, 24)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// serveSessionIdleTimeout is how long a session may go unused before it is
+// ended, so sessions a client never deletes do not pile up
+const serveSessionIdleTimeout = 30 * time.Minute
+
+// agentServer hosts agent sessions behind the serve API. newAgent builds the
+// headless session a serve session runs; requireApproval is set when an API
+// client can answer approval requests.
+type agentServer struct {
+	cfg            *config.Config
+	models         []string
+	apiKey         string
+	host           string
+	allowedOrigins []string
+	newAgent       func(model string, requireApproval bool) *AgentSession
+	upgrader       websocket.Upgrader
+
+	// runMu lets one agent run go at a time. Every session's agent shares
+	// the service container: the conversation repository whose token and
+	// cost stats each run reads and writes, and the agent and tool services
+	// with the state they keep. A run waiting for an approval releases it,
+	// since it touches none of them while it waits.
+	runMu    sync.Mutex
+	mu       sync.Mutex
+	sessions map[string]*serveSession
+}
+
+// newAgentServer creates the server. host is the address it listens on,
+// allowedOrigins the browser origins besides its own that may call it.
+func newAgentServer(cfg *config.Config, models []string, apiKey, host string, allowedOrigins []string, newAgent func(model string, requireApproval bool) *AgentSession) *agentServer {
+	s := &agentServer{
+		cfg:            cfg,
+		models:         models,
+		apiKey:         apiKey,
+		host:           host,
+		allowedOrigins: allowedOrigins,
+		newAgent:       newAgent,
+		sessions:       make(map[string]*serveSession),
+	}
+	s.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     s.originAllowed,
+	}
+	return s
+}
+
+func (s *agentServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/models", s.handleModels)
+	mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	mux.HandleFunc("POST /v1/sessions", s.handleCreateSession)
+	mux.HandleFunc("GET /v1/sessions", s.handleListSessions)
+	mux.HandleFunc("GET /v1/sessions/{id}", s.handleGetSession)
+	mux.HandleFunc("DELETE /v1/sessions/{id}", s.handleDeleteSession)
+	mux.HandleFunc("POST /v1/sessions/{id}/messages", s.handleSessionMessage)
+	mux.HandleFunc("POST /v1/sessions/{id}/approvals/{tool_call_id}", s.handleApproval)
+	mux.HandleFunc("GET /v1/sessions/{id}/events", s.handleSessionEvents)
+	return s.guardBrowsers(s.authorize(mux))
+}
+
+// guardBrowsers refuses what a web page the user happens to visit could send:
+// requests from another origin, requests to a Host that is not this server
+// (DNS rebinding), and bodies other than JSON, which a page can post without
+// a CORS preflight.
+func (s *agentServer) guardBrowsers(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.hostAllowed(r.Host) {
+			writeServeError(w, http.StatusForbidden, fmt.Sprintf("host %q is not served here", r.Host))
+			return
+		}
+		if !s.originAllowed(r) {
+			writeServeError(w, http.StatusForbidden, fmt.Sprintf("origin %q is not allowed", r.Header.Get("Origin")))
+			return
+		}
+		if r.ContentLength != 0 && r.Method != http.MethodGet {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				writeServeError(w, http.StatusUnsupportedMediaType, "request body must be application/json")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hostAllowed accepts IP addresses, localhost and the address the server
+// listens on. A rebound domain name is none of them.
+func (s *agentServer) hostAllowed(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	host = strings.Trim(host, "[]")
+	if net.ParseIP(host) != nil || strings.EqualFold(host, "localhost") {
+		return true
+	}
+	return s.host != "" && strings.EqualFold(host, s.host)
+}
+
+// originAllowed accepts requests without an Origin, as sent by editors and
+// scripts, from the server's own origin, and from the allowed origins
+func (s *agentServer) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range s.allowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// authorize requires the API key as a bearer token; RunServeCommand always
+// sets one. Browsers cannot set headers on a WebSocket, so it is also
+// accepted as ?api_key=.
+func (s *agentServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.apiKey != "" {
+			given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if given == "" {
+				given = r.URL.Query().Get("api_key")
+			}
+			if subtle.ConstantTimeCompare([]byte(given), []byte(s.apiKey)) != 1 {
+				writeServeError(w, http.StatusUnauthorized, "invalid or missing API key")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// openSession starts a session on model, or the configured default model
+// when it is empty.
+func (s *agentServer) openSession(model string, requireApproval bool) (*serveSession, error) {
+	model, err := selectModel(s.models, model, s.cfg.Agent.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	agent := s.newAgent(model, requireApproval)
+	agent.yieldForApproval = func() func() {
+		s.runMu.Unlock()
+		return s.runMu.Lock
+	}
+	ss := newServeSession(agent)
+	s.mu.Lock()
+	s.sessions[ss.id()] = ss
+	s.mu.Unlock()
+	return ss, nil
+}
+
+// reapIdleSessions ends the sessions idle for longer than
+// serveSessionIdleTimeout, checking every interval until ctx is done
+func (s *agentServer) reapIdleSessions(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.removeIdleSessions(now.Add(-serveSessionIdleTimeout))
+		}
+	}
+}
+
+// removeIdleSessions ends the sessions unused since before cutoff
+func (s *agentServer) removeIdleSessions(cutoff time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, ss := range s.sessions {
+		if ss.idleSince(cutoff) {
+			logger.Info("ending idle serve session", "session_id", id)
+			delete(s.sessions, id)
+		}
+	}
+}
+
+func (s *agentServer) session(id string) *serveSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessions[id]
+}
+
+func (s *agentServer) removeSession(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
+
+// run sends content to the session's agent and works until it finishes. The
+// caller must have claimed the session with begin.
+func (s *agentServer) run(ss *serveSession, content string) (string, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+	defer ss.finish()
+
+	agent := ss.agent
+	agent.completedTurns = 0
+	err := agent.execute(content, nil)
+	agent.emitFinalMessage(err)
+	return agent.finalAssistantContent(), err
+}
+
+func (s *agentServer) handleModels(w http.ResponseWriter, r *http.Request) {
+	data := make([]map[string]any, len(s.models))
+	for i, model := range s.models {
+		data[i] = map[string]any{"id": model, "object": "model", "owned_by": "inference-gateway"}
+	}
+	writeServeJSON(w, http.StatusOK, map[string]any{"object": "list", "data": data})
+}
+
+// chatCompletionRequest is the part of an OpenAI chat completion request the
+// agent uses. Tools and sampling options are the agent's own.
+type chatCompletionRequest struct {
+	Model    string        `json:"model"`
+	Messages []sdk.Message `json:"messages"`
+	Stream   bool          `json:"stream"`
+}
+
+// handleChatCompletions runs the agent on the conversation in the request and
+// answers with its final message. No client can answer approval requests
+// here, so tools that need approval are blocked as in an unattended
+// `infer agent` run; use the session API to approve them.
+func (s *agentServer) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if len(req.Messages) == 0 || req.Messages[len(req.Messages)-1].Role != sdk.User {
+		writeServeError(w, http.StatusBadRequest, "the last message must be from the user")
+		return
+	}
+
+	ss, err := s.openSession(req.Model, false)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer s.removeSession(ss.id())
+	ss.begin()
+
+	ss.agent.conversation = append(ss.agent.conversation, chatCompletionHistory(req.Messages[:len(req.Messages)-1])...)
+	prompt := formatting.ExtractTextFromContent(req.Messages[len(req.Messages)-1].Content, nil)
+
+	completionID := "chatcmpl-" + uuid.New().String()
+	w.Header().Set("X-Infer-Session-Id", ss.id())
+	if req.Stream {
+		s.streamChatCompletion(w, ss, completionID, prompt)
+		return
+	}
+
+	start := len(ss.agent.conversation)
+	content, err := s.run(ss, prompt)
+	if err != nil {
+		writeServeError(w, http.StatusBadGateway, fmt.Sprintf("agent run failed: %v", err))
+		return
+	}
+
+	writeServeJSON(w, http.StatusOK, map[string]any{
+		"id":      completionID,
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   ss.agent.model,
+		"choices": []map[string]any{{
+			"index":         0,
+			"message":       map[string]any{"role": "assistant", "content": content},
+			"finish_reason": "stop",
+		}},
+		"usage": conversationUsage(ss.agent.conversation[start:]),
+	})
+}
+
+// chatCompletionHistory keeps the text of the user and assistant messages of
+// a request. System messages would replace the agent's own prompt, and tool
+// calls and results belong to the client's tools, not the agent's, so a
+// history carrying them would be rejected by the gateway.
+func chatCompletionHistory(messages []sdk.Message) []ConversationMessage {
+	var history []ConversationMessage
+	for _, msg := range messages {
+		if msg.Role != sdk.User && msg.Role != sdk.Assistant {
+			continue
+		}
+		content, err := msg.Content.AsMessageContent0()
+		if err != nil {
+			content = formatting.ExtractTextFromContent(msg.Content, nil)
+		}
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		history = append(history, ConversationMessage{
+			Role:      string(msg.Role),
+			Content:   content,
+			Timestamp: time.Now(),
+		})
+	}
+	return history
+}
+
+// streamChatCompletion sends each assistant message of the run as a chunk of
+// server-sent events, ending with a stop chunk and [DONE].
+func (s *agentServer) streamChatCompletion(w http.ResponseWriter, ss *serveSession, completionID, prompt string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeServeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	events, unsubscribe := ss.subscribe()
+	defer unsubscribe()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.run(ss, prompt)
+		done <- err
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	created := time.Now().Unix()
+	chunk := func(delta map[string]any, finishReason any) {
+		writeServeEvent(w, map[string]any{
+			"id":      completionID,
+			"object":  "chat.completion.chunk",
+			"created": created,
+			"model":   ss.agent.model,
+			"choices": []map[string]any{{"index": 0, "delta": delta, "finish_reason": finishReason}},
+		})
+		flusher.Flush()
+	}
+
+	sent := false
+	send := func(event map[string]any) {
+		content, _ := event["content"].(string)
+		if event["type"] != agentEventAssistantMessage || content == "" {
+			return
+		}
+		delta := map[string]any{"content": content}
+		if sent {
+			delta["content"] = "\n\n" + content
+		} else {
+			delta["role"] = "assistant"
+		}
+		sent = true
+		chunk(delta, nil)
+	}
+
+	var runErr error
+	for running := true; running; {
+		select {
+		case event := <-events:
+			send(event)
+		case runErr = <-done:
+			running = false
+		}
+	}
+	for len(events) > 0 {
+		send(<-events)
+	}
+
+	if runErr != nil {
+		writeServeEvent(w, serveErrorBody(http.StatusBadGateway, fmt.Sprintf("agent run failed: %v", runErr)))
+	} else {
+		chunk(map[string]any{}, "stop")
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// conversationUsage sums the token usage of the responses in messages
+func conversationUsage(messages []ConversationMessage) map[string]int64 {
+	usage := map[string]int64{"prompt_tokens": 0, "completion_tokens": 0, "total_tokens": 0}
+	for _, msg := range messages {
+		if u := msg.TokenUsage; u != nil {
+			usage["prompt_tokens"] += int64(u.PromptTokens)
+			usage["completion_tokens"] += int64(u.CompletionTokens)
+			usage["total_tokens"] += int64(u.TotalTokens)
+		}
+	}
+	return usage
+}
+
+func (s *agentServer) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model string `json:"model"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeServeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+	}
+
+	ss, err := s.openSession(req.Model, true)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeServeJSON(w, http.StatusCreated, ss.view(false))
+}
+
+func (s *agentServer) handleListSessions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	views := make([]serveSessionView, 0, len(s.sessions))
+	for _, ss := range s.sessions {
+		views = append(views, ss.view(false))
+	}
+	s.mu.Unlock()
+
+	writeServeJSON(w, http.StatusOK, map[string]any{"sessions": views})
+}
+
+func (s *agentServer) handleGetSession(w http.ResponseWriter, r *http.Request) {
+	ss := s.session(r.PathValue("id"))
+	if ss == nil {
+		writeServeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+	writeServeJSON(w, http.StatusOK, ss.view(true))
+}
+
+func (s *agentServer) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	ss := s.session(r.PathValue("id"))
+	if ss == nil {
+		writeServeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+	if ss.isRunning() {
+		writeServeError(w, http.StatusConflict, "session is running")
+		return
+	}
+	s.removeSession(ss.id())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleSessionMessage sends a message to the session's agent. The run goes
+// on in the background unless the request asks to wait for its final message.
+func (s *agentServer) handleSessionMessage(w http.ResponseWriter, r *http.Request) {
+	ss := s.session(r.PathValue("id"))
+	if ss == nil {
+		writeServeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	var req struct {
+		Content string `json:"content"`
+		Wait    bool   `json:"wait"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		writeServeError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+	if !ss.begin() {
+		writeServeError(w, http.StatusConflict, "session is already running")
+		return
+	}
+
+	if !req.Wait {
+		go func() {
+			if _, err := s.run(ss, req.Content); err != nil {
+				logger.Error("agent run failed", "session_id", ss.id(), "error", err)
+			}
+		}()
+		writeServeJSON(w, http.StatusAccepted, map[string]any{"id": ss.id(), "status": "running"})
+		return
+	}
+
+	content, err := s.run(ss, req.Content)
+	body := map[string]any{
+		"id":      ss.id(),
+		"content": content,
+		"turns":   ss.agent.completedTurns,
+		"outcome": agentSessionOutcome(err),
+	}
+	if err != nil {
+		body["error"] = err.Error()
+	}
+	writeServeJSON(w, http.StatusOK, body)
+}
+
+func (s *agentServer) handleApproval(w http.ResponseWriter, r *http.Request) {
+	ss := s.session(r.PathValue("id"))
+	if ss == nil {
+		writeServeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	var req struct {
+		Approved bool `json:"approved"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if !ss.resolveApproval(r.PathValue("tool_call_id"), req.Approved) {
+		writeServeError(w, http.StatusNotFound, "no approval is pending for this tool call")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleSessionEvents streams the session's events over a WebSocket. The
+// client may answer approval requests on the same socket with
+// {"type":"approval_response","tool_call_id":"...","approved":true}.
+func (s *agentServer) handleSessionEvents(w http.ResponseWriter, r *http.Request) {
+	ss := s.session(r.PathValue("id"))
+	if ss == nil {
+		writeServeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Error("webSocket upgrade failed", "error", err)
+		return
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logger.Warn("failed to close WebSocket connection", "error", err)
+		}
+	}()
+
+	events, unsubscribe := ss.subscribe()
+	defer unsubscribe()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var resp domain.ApprovalResponse
+			if err := conn.ReadJSON(&resp); err != nil {
+				return
+			}
+			if resp.Type == "approval_response" && !ss.resolveApproval(resp.ToolCallID, resp.Approved) {
+				logger.Warn("approval response for a tool call that is not pending", "session_id", ss.id(), "tool_call_id", resp.ToolCallID)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case event := <-events:
+			if err := conn.WriteJSON(event); err != nil {
+				logger.Warn("failed to write session event", "session_id", ss.id(), "error", err)
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("failed to encode serve response", "error", err)
+	}
+}
+
+// writeServeError answers with an OpenAI-style error body
+func writeServeError(w http.ResponseWriter, status int, message string) {
+	writeServeJSON(w, status, serveErrorBody(status, message))
+}
+
+func serveErrorBody(status int, message string) map[string]any {
+	errType := "invalid_request_error"
+	if status >= http.StatusInternalServerError {
+		errType = "server_error"
+	}
+	return map[string]any{"error": map[string]any{"message": message, "type": errType}}
+}
+
+func writeServeEvent(w http.ResponseWriter, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		logger.Error("failed to marshal server-sent event", "error", err)
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
+func init() {
+	serveCmd.Flags().String("host", "localhost", "Address to listen on")
+	serveCmd.Flags().Int("port", 8090, "Port to listen on")
+	serveCmd.Flags().String("api-key", "", "Require this key as a bearer token (default: $INFER_SERVE_API_KEY, or a generated key)")
+	serveCmd.Flags().StringSlice("allowed-origin", nil, "Browser origin allowed to call the server, e.g. http://localhost:3000 (repeatable)")
+	rootCmd.AddCommand(serveCmd)
+}
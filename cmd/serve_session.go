@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// serveSession is one agent conversation hosted by `infer serve`. The agent
+// reports what it does as the same typed events `infer agent --output jsonl`
+// prints; serveSession fans them out to WebSocket subscribers and keeps the
+// approval requests that are waiting for an answer.
+type serveSession struct {
+	agent     *AgentSession
+	createdAt time.Time
+
+	mu          sync.Mutex
+	running     bool
+	lastActive  time.Time
+	messages    []ConversationMessage
+	pending     map[string]map[string]any
+	subscribers map[chan map[string]any]struct{}
+}
+
+// serveSessionView is how the session API describes a session
+type serveSessionView struct {
+	ID               string                `json:"id"`
+	Model            string                `json:"model"`
+	Status           string                `json:"status"`
+	CreatedAt        time.Time             `json:"created_at"`
+	PendingApprovals []map[string]any      `json:"pending_approvals"`
+	Messages         []ConversationMessage `json:"messages,omitempty"`
+}
+
+func newServeSession(agent *AgentSession) *serveSession {
+	ss := &serveSession{
+		agent:       agent,
+		createdAt:   time.Now(),
+		lastActive:  time.Now(),
+		pending:     make(map[string]map[string]any),
+		subscribers: make(map[chan map[string]any]struct{}),
+	}
+	agent.eventSink = ss.publish
+	return ss
+}
+
+func (ss *serveSession) id() string {
+	return ss.agent.sessionID
+}
+
+// publish records approval requests until their tool result arrives and
+// passes the event on to every subscriber. A subscriber that has fallen
+// behind misses the event rather than stalling the agent.
+func (ss *serveSession) publish(event map[string]any) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	toolCallID, _ := event["tool_call_id"].(string)
+	switch event["type"] {
+	case agentEventApprovalRequest:
+		ss.pending[toolCallID] = event
+	case agentEventToolResult:
+		delete(ss.pending, toolCallID)
+	}
+
+	for ch := range ss.subscribers {
+		select {
+		case ch <- event:
+		default:
+			logger.Warn("dropping agent event for slow subscriber", "session_id", ss.id(), "type", event["type"])
+		}
+	}
+}
+
+// subscribe returns a channel of the session's events, starting with the
+// approval requests already waiting so a late client can answer them, and a
+// function that ends the subscription.
+func (ss *serveSession) subscribe() (<-chan map[string]any, func()) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	ch := make(chan map[string]any, 64+len(ss.pending))
+	for _, event := range ss.pending {
+		ch <- event
+	}
+	ss.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		delete(ss.subscribers, ch)
+		ss.lastActive = time.Now()
+	}
+}
+
+// resolveApproval answers the approval request for toolCallID. Reports false
+// when no such request is waiting.
+func (ss *serveSession) resolveApproval(toolCallID string, approved bool) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if _, ok := ss.pending[toolCallID]; !ok {
+		return false
+	}
+	delete(ss.pending, toolCallID)
+
+	resp := domain.ApprovalResponse{Type: "approval_response", ToolCallID: toolCallID, Approved: approved}
+	select {
+	case ss.agent.approvalCh <- resp:
+		return true
+	default:
+		return false
+	}
+}
+
+// begin marks the session as running a message. Reports false if it already is.
+func (ss *serveSession) begin() bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.running {
+		return false
+	}
+	ss.running = true
+	ss.lastActive = time.Now()
+	return true
+}
+
+// finish marks the run over and takes a copy of the conversation for the
+// session API, which must not read the agent's while it runs.
+func (ss *serveSession) finish() {
+	messages := make([]ConversationMessage, 0, len(ss.agent.conversation))
+	for _, msg := range ss.agent.conversation {
+		if msg.Role != "system" && !msg.Internal {
+			messages = append(messages, msg)
+		}
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.running = false
+	ss.lastActive = time.Now()
+	ss.messages = messages
+	clear(ss.pending)
+}
+
+func (ss *serveSession) isRunning() bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.running
+}
+
+// idleSince reports whether nothing has used the session since before cutoff:
+// no run and no event subscriber
+func (ss *serveSession) idleSince(cutoff time.Time) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return !ss.running && len(ss.subscribers) == 0 && ss.lastActive.Before(cutoff)
+}
+
+func (ss *serveSession) view(withMessages bool) serveSessionView {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	v := serveSessionView{
+		ID:               ss.id(),
+		Model:            ss.agent.model,
+		Status:           "idle",
+		CreatedAt:        ss.createdAt,
+		PendingApprovals: make([]map[string]any, 0, len(ss.pending)),
+	}
+	if ss.running {
+		v.Status = "running"
+	}
+	for _, event := range ss.pending {
+		v.PendingApprovals = append(v.PendingApprovals, event)
+	}
+	if withMessages {
+		v.Messages = ss.messages
+	}
+	return v
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	sessiongroups "github.com/inference-gateway/cli/internal/services/sessiongroups"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Manage session groups",
+	Long: `Manage session groups: named keys that point at the conversation they
+resume plus the conversations they rolled over from. The channels-manager keeps
+one group per sender (e.g. channel-telegram-12345); any other name works as
+well, and 'infer chat --session-id <group>' or 'infer agent --session-id <group>'
+resumes the group's current conversation.
+
+In chat, /sessions lists the groups and /sessions <group> switches to one.`,
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List session groups",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return withSessionStores(func(stores *storage.Stores) error {
+			return listSessionGroups(cmd.Context(), os.Stdout, stores.SessionGroups, format)
+		})
+	},
+}
+
+var sessionsShowCmd = &cobra.Command{
+	Use:               "show <group>",
+	Short:             "Show the conversations of a session group",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeSessionGroups),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withSessionStores(func(stores *storage.Stores) error {
+			return showSessionGroup(cmd.Context(), os.Stdout, stores, args[0])
+		})
+	},
+}
+
+var sessionsCreateCmd = &cobra.Command{
+	Use:   "create <group>",
+	Short: "Create a session group",
+	Long: `Create a session group that starts a new conversation, or resumes an
+existing one with --conversation.
+
+Examples:
+  infer sessions create research
+  infer sessions create research --conversation 12345678-1234-1234-1234-123456789abc
+  infer chat --session-id research`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conversationID, _ := cmd.Flags().GetString("conversation")
+		return withSessionStores(func(stores *storage.Stores) error {
+			return createSessionGroup(cmd.Context(), os.Stdout, stores, args[0], conversationID)
+		})
+	},
+}
+
+var sessionsRenameCmd = &cobra.Command{
+	Use:   "rename <group> <new-name>",
+	Short: "Rename a session group",
+	Long: `Rename a session group, keeping its conversations. A channel sender's
+group (channel-<name>-<sender>) is looked up by that name, so renaming it makes
+the sender start a new group with their next message.`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeSessionGroups(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withSessionStores(func(stores *storage.Stores) error {
+			if err := sessiongroups.Rename(cmd.Context(), stores.SessionGroups, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("%s Session group %s renamed to %s\n", icons.CheckMarkStyle.Render(icons.CheckMark), args[0], args[1])
+			return nil
+		})
+	},
+}
+
+var sessionsDeleteCmd = &cobra.Command{
+	Use:               "delete <group>",
+	Aliases:           []string{"remove"},
+	Short:             "Delete a session group",
+	Long:              `Delete a session group. Its conversations are kept and stay listed in 'infer conversations list'.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(completeSessionGroups),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withSessionStores(func(stores *storage.Stores) error {
+			if err := sessiongroups.Delete(cmd.Context(), stores.SessionGroups, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("%s Session group %s deleted\n", icons.CheckMarkStyle.Render(icons.CheckMark), args[0])
+			return nil
+		})
+	},
+}
+
+var sessionsMoveCmd = &cobra.Command{
+	Use:   "move <conversation-id> <group>",
+	Short: "Move a conversation to another session group",
+	Long: `Move a saved conversation out of the groups that hold it into another
+group. It becomes the group's current conversation when the group has none yet,
+otherwise it joins the group's history. A group that loses its current
+conversation resumes the newest one of its history instead.`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return completeConversationIDs(cmd, args, toComplete)
+		case 1:
+			return completeSessionGroups(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withSessionStores(func(stores *storage.Stores) error {
+			return moveConversation(cmd.Context(), os.Stdout, stores, args[0], args[1])
+		})
+	},
+}
+
+// openSessionStores opens the storage backend holding the session groups.
+// The memory backend is refused, as its groups would be gone on exit.
+func openSessionStores(cfg *config.Config) (*storage.Stores, error) {
+	storageConfig := storage.NewStorageFromConfig(cfg)
+	if storageConfig.Type == config.StorageTypeMemory {
+		return nil, fmt.Errorf("session groups require persistent storage: set storage.enabled: true and a non-memory storage.type (e.g. jsonl)")
+	}
+	stores, err := storage.NewStorage(storageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	return stores, nil
+}
+
+// withSessionStores runs fn with the storage backend and closes it after
+func withSessionStores(fn func(stores *storage.Stores) error) error {
+	stores, err := openSessionStores(Cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stores.Conversations.Close() }()
+	return fn(stores)
+}
+
+func listSessionGroups(ctx context.Context, w io.Writer, store storage.SessionGroupStorage, format string) error {
+	groups, err := sessiongroups.List(ctx, store)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		out := make([]map[string]any, 0, len(groups))
+		for _, g := range groups {
+			out = append(out, map[string]any{
+				"group":              g.Key,
+				"current_session_id": g.CurrentSessionID,
+				"history":            g.History,
+				"updated_at":         g.UpdatedAt,
+			})
+		}
+		output, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal session groups: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	if len(groups) == 0 {
+		_, _ = fmt.Fprintln(w, "No session groups configured.")
+		_, _ = fmt.Fprintln(w, "Use 'infer sessions create <group>' to add one.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Session Groups (%d)", len(groups))))
+	_, _ = fmt.Fprintln(w)
+
+	groupsTable := newListTable("Group", "Current Conversation", "Conversations", "Updated")
+	for _, g := range groups {
+		updated := "-"
+		if !g.UpdatedAt.IsZero() {
+			updated = g.UpdatedAt.Local().Format("2006-01-02 15:04")
+		}
+		groupsTable.Row(g.Key, g.CurrentSessionID, fmt.Sprintf("%d", len(g.ConversationIDs())), updated)
+	}
+	_, _ = fmt.Fprintln(w, groupsTable.Render())
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listHint("Resume a group with 'infer chat --session-id <group>'."))
+	return nil
+}
+
+func showSessionGroup(ctx context.Context, w io.Writer, stores *storage.Stores, key string) error {
+	g, err := sessiongroups.Get(ctx, stores.SessionGroups, key)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(w, listTitle(fmt.Sprintf("Session Group: %s", g.Key)))
+	_, _ = fmt.Fprintln(w)
+
+	conversationsTable := newListTable("", "Conversation", "Title", "Messages", "Updated")
+	for _, id := range g.ConversationIDs() {
+		marker := ""
+		if id == g.CurrentSessionID {
+			marker = icons.BulletIcon
+		}
+		title, messages, updated := "(not saved yet)", "-", "-"
+		if _, meta, err := stores.Conversations.LoadConversation(ctx, id); err == nil {
+			title = strings.Join(strings.Fields(meta.Title), " ")
+			if title == "" {
+				title = "Untitled"
+			}
+			messages = fmt.Sprintf("%d", meta.MessageCount)
+			updated = meta.UpdatedAt.Local().Format("2006-01-02 15:04")
+		}
+		conversationsTable.Row(marker, id, title, messages, updated)
+	}
+	_, _ = fmt.Fprintln(w, conversationsTable.Render())
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, listHint(fmt.Sprintf("%s = current conversation", icons.BulletIcon)))
+	return nil
+}
+
+func createSessionGroup(ctx context.Context, w io.Writer, stores *storage.Stores, key, conversationID string) error {
+	if conversationID != "" {
+		if _, _, err := stores.Conversations.LoadConversation(ctx, conversationID); err != nil {
+			return fmt.Errorf("failed to load conversation %s: %w", conversationID, err)
+		}
+	}
+	g, err := sessiongroups.Create(ctx, stores.SessionGroups, key, conversationID)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "%s Session group %s created (conversation %s)\n", icons.CheckMarkStyle.Render(icons.CheckMark), g.Key, g.CurrentSessionID)
+	_, _ = fmt.Fprintf(w, "  Resume it with: infer chat --session-id %s\n", g.Key)
+	return nil
+}
+
+func moveConversation(ctx context.Context, w io.Writer, stores *storage.Stores, conversationID, key string) error {
+	if _, _, err := stores.Conversations.LoadConversation(ctx, conversationID); err != nil {
+		return fmt.Errorf("failed to load conversation %s: %w", conversationID, err)
+	}
+	from, err := sessiongroups.Move(ctx, stores.SessionGroups, conversationID, key)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("%s Conversation %s moved to %s", icons.CheckMarkStyle.Render(icons.CheckMark), conversationID, key)
+	if len(from) > 0 {
+		msg += " (from " + strings.Join(from, ", ") + ")"
+	}
+	_, _ = fmt.Fprintln(w, msg)
+	return nil
+}
+
+// completeSessionGroups completes the session group keys
+func completeSessionGroups(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if Cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	stores, err := openSessionStores(Cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer func() { _ = stores.Conversations.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	groups, err := sessiongroups.List(ctx, stores.SessionGroups)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	keys := make([]string, 0, len(groups))
+	for _, g := range groups {
+		keys = append(keys, g.Key)
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+func init() {
+	sessionsListCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+	sessionsCreateCmd.Flags().String("conversation", "", "Existing conversation ID the group resumes (default: a new conversation)")
+	_ = sessionsCreateCmd.RegisterFlagCompletionFunc("conversation", completeConversationIDs)
+
+	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsShowCmd)
+	sessionsCmd.AddCommand(sessionsCreateCmd)
+	sessionsCmd.AddCommand(sessionsRenameCmd)
+	sessionsCmd.AddCommand(sessionsDeleteCmd)
+	sessionsCmd.AddCommand(sessionsMoveCmd)
+	rootCmd.AddCommand(sessionsCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	sessiongroups "github.com/inference-gateway/cli/internal/services/sessiongroups"
+)
+
+func newSessionsTestStores(t *testing.T) *storage.Stores {
+	t.Helper()
+	mem := storage.NewMemoryStorage()
+	ctx := context.Background()
+	for _, id := range []string{"conv-a", "conv-b"} {
+		if err := mem.SaveConversation(ctx, id, nil, storage.ConversationMetadata{ID: id, Title: "Title " + id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mem.PutSessionGroup(ctx, "channel-telegram-42", storage.SessionGroupEntry{
+		CurrentSessionID: "conv-b",
+		History:          []string{"conv-a"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return &storage.Stores{Conversations: mem, SessionGroups: mem}
+}
+
+func TestListSessionGroups(t *testing.T) {
+	stores := newSessionsTestStores(t)
+	var out bytes.Buffer
+	if err := listSessionGroups(context.Background(), &out, stores.SessionGroups, "text"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Session Groups (1)", "channel-telegram-42", "conv-b"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("list output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	_ = listSessionGroups(context.Background(), &out, storage.NewMemorySessionGroupStorage(), "text")
+	if !strings.Contains(out.String(), "No session groups configured.") {
+		t.Errorf("empty list output = %q", out.String())
+	}
+}
+
+func TestShowSessionGroup(t *testing.T) {
+	stores := newSessionsTestStores(t)
+	var out bytes.Buffer
+	if err := showSessionGroup(context.Background(), &out, stores, "channel-telegram-42"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Title conv-a", "Title conv-b"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("show output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCreateSessionGroupChecksConversation(t *testing.T) {
+	stores := newSessionsTestStores(t)
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	if err := createSessionGroup(ctx, &out, stores, "research", "missing"); err == nil {
+		t.Error("expected an unknown conversation to be rejected")
+	}
+	if err := createSessionGroup(ctx, &out, stores, "research", "conv-a"); err != nil {
+		t.Fatal(err)
+	}
+	g, err := sessiongroups.Get(ctx, stores.SessionGroups, "research")
+	if err != nil || g.CurrentSessionID != "conv-a" {
+		t.Errorf("Get(research) = %+v, %v", g, err)
+	}
+}
+
+func TestMoveConversation(t *testing.T) {
+	stores := newSessionsTestStores(t)
+	ctx := context.Background()
+	if _, err := sessiongroups.Create(ctx, stores.SessionGroups, "research", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := moveConversation(ctx, &out, stores, "conv-a", "research"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "from channel-telegram-42") {
+		t.Errorf("output = %q", out.String())
+	}
+	if err := moveConversation(ctx, &out, stores, "missing", "research"); err == nil {
+		t.Error("expected an unknown conversation to be rejected")
+	}
+}
@@ -50,7 +50,8 @@ Examples:
 
   # No arguments for tools that have defaults
   infer tools execute Tree`,
-	Args: cobra.MinimumNArgs(1),
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeFirstArg(completeToolNames),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, _ := cmd.Flags().GetString("format")
 		return ExecTool(Cfg, args, format)
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+	selfupdate "github.com/inference-gateway/cli/internal/services/selfupdate"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// updateCheckFileName caches the latest release lookup behind the chat
+// update notice, so chat starts without waiting on the network
+const updateCheckFileName = "update-check.json"
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update infer to the latest release",
+	Long: `Download the latest infer release from GitHub for this platform, verify it
+against the release's checksums.txt and replace the running binary with it.
+
+Binaries installed with npm, Nix or Homebrew are left to their package manager.
+--version installs a specific release, including an older one, and --check
+only reports whether an update is available.
+
+Examples:
+  infer update
+  infer update --check
+  infer update --version v0.120.0`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, _ := cmd.Flags().GetString("version")
+		check, _ := cmd.Flags().GetBool("check")
+		return RunUpdateCommand(target, check)
+	},
+}
+
+// RunUpdateCommand replaces the running binary with release target, or with
+// the latest release when target is empty
+func RunUpdateCommand(target string, checkOnly bool) error {
+	ctx := context.Background()
+	updater := selfupdate.New()
+
+	tag := target
+	if tag == "" {
+		latest, err := updater.LatestVersion(ctx)
+		if err != nil {
+			return err
+		}
+		tag = latest
+		if !selfupdate.IsNewer(tag, version) {
+			fmt.Printf("%s infer %s is up to date (latest: %s)\n", icons.CheckMarkStyle.Render(icons.CheckMark), version, tag)
+			return nil
+		}
+	} else if !strings.HasPrefix(tag, "v") {
+		tag = "v" + tag
+	}
+
+	if checkOnly {
+		fmt.Printf("infer %s is available (current: %s) - run infer update to install it\n", tag, version)
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the infer binary: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("failed to locate the infer binary: %w", err)
+	}
+	if err := selfupdate.CheckReplaceable(exe); err != nil {
+		return fmt.Errorf("%s is %w", exe, err)
+	}
+	_ = os.Remove(exe + ".old")
+
+	fmt.Printf("%s Downloading infer %s (%s)...\n", icons.BulletIcon, tag, selfupdate.AssetName())
+	downloaded, err := updater.Download(ctx, tag, filepath.Dir(exe))
+	if errors.Is(err, os.ErrPermission) {
+		return fmt.Errorf("cannot write to %s: rerun with sudo or reinstall infer to a writable directory", filepath.Dir(exe))
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(downloaded) }()
+
+	if err := verifyUpdatedBinary(ctx, downloaded); err != nil {
+		return err
+	}
+	if err := selfupdate.Replace(exe, downloaded); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", exe, err)
+	}
+
+	fmt.Printf("%s Updated infer %s -> %s (%s)\n", icons.CheckMarkStyle.Render(icons.CheckMark), version, tag, exe)
+	return nil
+}
+
+// verifyUpdatedBinary runs the downloaded binary's version command, so an
+// update never installs a binary that cannot start on this machine
+func verifyUpdatedBinary(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, path, "version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("the downloaded binary does not run: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// chatVersionInfo is GetVersionInfo plus the newer release the chat welcome
+// box announces. It only reads the cached check; a stale cache is refreshed
+// in the background, so a new release shows from the next chat on.
+func chatVersionInfo(cfg *config.Config) domain.VersionInfo {
+	info := GetVersionInfo()
+	if !cfg.Chat.UpdateNotice || version == "dev" {
+		return info
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return info
+	}
+	cachePath := filepath.Join(homeDir, config.ConfigDirName, updateCheckFileName)
+
+	latest, stale := selfupdate.CachedLatestVersion(cachePath)
+	if selfupdate.IsNewer(latest, version) {
+		info.Latest = latest
+	}
+	if stale {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := selfupdate.New().RefreshCache(ctx, cachePath); err != nil {
+				logger.Debug("update check failed", "error", err)
+			}
+		}()
+	}
+	return info
+}
+
+func init() {
+	updateCmd.Flags().String("version", "", "Install this release tag instead of the latest, e.g. v0.120.0")
+	updateCmd.Flags().Bool("check", false, "Only report whether an update is available")
+	rootCmd.AddCommand(updateCmd)
+}
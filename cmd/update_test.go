@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestChatVersionInfo(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cache := filepath.Join(home, config.ConfigDirName, updateCheckFileName)
+	if err := os.MkdirAll(filepath.Dir(cache), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	fresh := fmt.Sprintf(`{"checked_at":%q,"latest":"v9.9.9"}`, time.Now().Format(time.RFC3339))
+	if err := os.WriteFile(cache, []byte(fresh), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := version
+	version = "v1.0.0"
+	t.Cleanup(func() { version = previous })
+
+	cfg := config.DefaultConfig()
+	if info := chatVersionInfo(cfg); info.Latest != "v9.9.9" {
+		t.Errorf("expected the cached newer release, got %+v", info)
+	}
+
+	cfg.Chat.UpdateNotice = false
+	if info := chatVersionInfo(cfg); info.Latest != "" {
+		t.Errorf("chat.update_notice: false must hide the notice, got %+v", info)
+	}
+
+	cfg.Chat.UpdateNotice = true
+	version = "v9.9.9"
+	if info := chatVersionInfo(cfg); info.Latest != "" {
+		t.Errorf("an up-to-date build must not show a notice, got %+v", info)
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	config "github.com/inference-gateway/cli/config"
+	container "github.com/inference-gateway/cli/internal/container"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+const usageListPageSize = 100
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Report token usage and cost over time",
+	Long: `Report the token usage and cost of the saved conversations, grouped by
+day, week or month with a breakdown per model. Costs are the ones the pricing
+service recorded when each conversation ran; a conversation is counted in the
+period it was last updated.
+
+Examples:
+  infer usage
+  infer usage --period week --since 2026-09-01
+  infer usage --period month --format csv > usage.csv
+  infer usage --format json | jq '.periods[0]'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		period, _ := cmd.Flags().GetString("period")
+		since, _ := cmd.Flags().GetString("since")
+		format, _ := cmd.Flags().GetString("format")
+		return RunUsageCommand(cmd.OutOrStdout(), Cfg, period, since, format)
+	},
+}
+
+// usageModel is the usage of one model within a period
+type usageModel struct {
+	Model         string  `json:"model"`
+	Conversations int     `json:"conversations"`
+	Requests      int     `json:"requests"`
+	InputTokens   int     `json:"input_tokens"`
+	OutputTokens  int     `json:"output_tokens"`
+	Cost          float64 `json:"cost"`
+}
+
+// usagePeriod is the usage of one day, week or month
+type usagePeriod struct {
+	Period        string       `json:"period"`
+	Start         time.Time    `json:"start"`
+	Conversations int          `json:"conversations"`
+	Requests      int          `json:"requests"`
+	InputTokens   int          `json:"input_tokens"`
+	OutputTokens  int          `json:"output_tokens"`
+	Cost          float64      `json:"cost"`
+	Models        []usageModel `json:"models"`
+}
+
+// RunUsageCommand prints the usage report of the stored conversations
+func RunUsageCommand(w io.Writer, cfg *config.Config, period, since, format string) error {
+	if format != "text" && format != "json" && format != "csv" {
+		return fmt.Errorf("unsupported format %q: must be \"text\", \"json\" or \"csv\"", format)
+	}
+	if _, _, err := usagePeriodStart(time.Now(), period); err != nil {
+		return err
+	}
+	var sinceTime time.Time
+	if since != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, since, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since %q: expected YYYY-MM-DD", since)
+		}
+		sinceTime = parsed
+	}
+
+	store := container.NewServiceContainer(cfg).GetStorage()
+	if store == nil {
+		return fmt.Errorf("storage is not configured")
+	}
+
+	var conversations []storage.ConversationSummary
+	ctx := context.Background()
+	for offset := 0; ; offset += usageListPageSize {
+		page, err := store.ListConversations(ctx, usageListPageSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list conversations: %w", err)
+		}
+		conversations = append(conversations, page...)
+		if len(page) < usageListPageSize {
+			break
+		}
+	}
+
+	periods, err := aggregateUsage(conversations, period, sinceTime)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		out, err := json.MarshalIndent(map[string]any{"period": period, "periods": periods}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal usage to JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case "csv":
+		return writeUsageCSV(w, periods)
+	default:
+		_, err := fmt.Fprint(w, renderUsageTable(periods, period))
+		return err
+	}
+}
+
+// usagePeriodStart returns the start of the day, week (Monday) or month
+// containing t, and the label of that period.
+func usagePeriodStart(t time.Time, period string) (time.Time, string, error) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case "day":
+		return day, day.Format(time.DateOnly), nil
+	case "week":
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		year, week := start.ISOWeek()
+		return start, fmt.Sprintf("%d-W%02d", year, week), nil
+	case "month":
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return start, start.Format("2006-01"), nil
+	default:
+		return time.Time{}, "", fmt.Errorf("unsupported period %q: must be \"day\", \"week\" or \"month\"", period)
+	}
+}
+
+// aggregateUsage groups the conversations updated at or after since by
+// period, most recent first. A conversation without per-model cost stats is
+// attributed to its model as a whole.
+func aggregateUsage(conversations []storage.ConversationSummary, period string, since time.Time) ([]usagePeriod, error) {
+	byPeriod := map[string]*usagePeriod{}
+	byModel := map[string]map[string]*usageModel{}
+
+	for _, conv := range conversations {
+		updated := conv.UpdatedAt.Local()
+		if updated.Before(since) {
+			continue
+		}
+		start, label, err := usagePeriodStart(updated, period)
+		if err != nil {
+			return nil, err
+		}
+
+		p, ok := byPeriod[label]
+		if !ok {
+			p = &usagePeriod{Period: label, Start: start}
+			byPeriod[label] = p
+			byModel[label] = map[string]*usageModel{}
+		}
+		p.Conversations++
+		p.Requests += conv.TokenStats.RequestCount
+		p.InputTokens += conv.TokenStats.TotalInputTokens
+		p.OutputTokens += conv.TokenStats.TotalOutputTokens
+		p.Cost += conv.CostStats.TotalCost
+
+		models := byModel[label]
+		addModel := func(name string, requests, input, output int, cost float64) {
+			if name == "" {
+				name = "unknown"
+			}
+			m, ok := models[name]
+			if !ok {
+				m = &usageModel{Model: name}
+				models[name] = m
+			}
+			m.Conversations++
+			m.Requests += requests
+			m.InputTokens += input
+			m.OutputTokens += output
+			m.Cost += cost
+		}
+
+		if len(conv.CostStats.PerModelStats) == 0 {
+			addModel(conv.Model, conv.TokenStats.RequestCount, conv.TokenStats.TotalInputTokens,
+				conv.TokenStats.TotalOutputTokens, conv.CostStats.TotalCost)
+			continue
+		}
+		for name, stats := range conv.CostStats.PerModelStats {
+			if stats == nil {
+				continue
+			}
+			addModel(name, stats.RequestCount, stats.InputTokens, stats.OutputTokens, stats.TotalCost)
+		}
+	}
+
+	periods := make([]usagePeriod, 0, len(byPeriod))
+	for label, p := range byPeriod {
+		for _, m := range byModel[label] {
+			p.Models = append(p.Models, *m)
+		}
+		sort.Slice(p.Models, func(i, j int) bool {
+			if p.Models[i].Cost != p.Models[j].Cost {
+				return p.Models[i].Cost > p.Models[j].Cost
+			}
+			return p.Models[i].Model < p.Models[j].Model
+		})
+		periods = append(periods, *p)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.After(periods[j].Start) })
+	return periods, nil
+}
+
+// writeUsageCSV writes one row per period and model
+func writeUsageCSV(w io.Writer, periods []usagePeriod) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"period", "model", "conversations", "requests", "input_tokens", "output_tokens", "cost"}); err != nil {
+		return err
+	}
+	for _, p := range periods {
+		for _, m := range p.Models {
+			record := []string{
+				p.Period,
+				m.Model,
+				strconv.Itoa(m.Conversations),
+				strconv.Itoa(m.Requests),
+				strconv.Itoa(m.InputTokens),
+				strconv.Itoa(m.OutputTokens),
+				strconv.FormatFloat(m.Cost, 'f', 6, 64),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func renderUsageTable(periods []usagePeriod, period string) string {
+	if len(periods) == 0 {
+		return "No usage recorded.\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(listTitle(fmt.Sprintf("Usage by %s (%d)", period, len(periods))) + "\n\n")
+
+	t := newListTable("Period", "Model", "Conversations", "Requests", "Input", "Output", "Cost")
+	total := 0.0
+	for _, p := range periods {
+		t.Row(p.Period, listLabelStyle.Render("all models"), strconv.Itoa(p.Conversations), strconv.Itoa(p.Requests),
+			strconv.Itoa(p.InputTokens), strconv.Itoa(p.OutputTokens), formatting.FormatCost(p.Cost))
+		for _, m := range p.Models {
+			t.Row("", m.Model, strconv.Itoa(m.Conversations), strconv.Itoa(m.Requests),
+				strconv.Itoa(m.InputTokens), strconv.Itoa(m.OutputTokens), formatting.FormatCost(m.Cost))
+		}
+		total += p.Cost
+	}
+	sb.WriteString(t.Render() + "\n\n")
+
+	sb.WriteString(listField("Total cost", formatting.FormatCost(total)) + "\n")
+	return sb.String()
+}
+
+func init() {
+	usageCmd.Flags().String("period", "day", "Group usage by day, week or month")
+	usageCmd.Flags().String("since", "", "Only count conversations updated on or after this date (YYYY-MM-DD)")
+	usageCmd.Flags().StringP("format", "f", "text", "Output format (text, json, csv)")
+	rootCmd.AddCommand(usageCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+func TestUsagePeriodStart(t *testing.T) {
+	at := time.Date(2026, 10, 16, 15, 30, 0, 0, time.Local) // a Friday
+
+	tests := []struct {
+		period    string
+		wantStart time.Time
+		wantLabel string
+	}{
+		{"day", time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local), "2026-10-16"},
+		{"week", time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local), "2026-W42"},
+		{"month", time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), "2026-10"},
+	}
+	for _, tt := range tests {
+		start, label, err := usagePeriodStart(at, tt.period)
+		if err != nil {
+			t.Fatalf("usagePeriodStart(%q): %v", tt.period, err)
+		}
+		if !start.Equal(tt.wantStart) || label != tt.wantLabel {
+			t.Errorf("%s: got %v %q, want %v %q", tt.period, start, label, tt.wantStart, tt.wantLabel)
+		}
+	}
+
+	if _, _, err := usagePeriodStart(at, "year"); err == nil {
+		t.Error("expected an error for an unknown period")
+	}
+}
+
+func TestAggregateUsage(t *testing.T) {
+	conversations := []storage.ConversationSummary{
+		{
+			UpdatedAt:  time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local),
+			TokenStats: domain.SessionTokenStats{TotalInputTokens: 300, TotalOutputTokens: 30, RequestCount: 3},
+			CostStats: domain.SessionCostStats{
+				TotalCost: 0.5,
+				PerModelStats: map[string]*domain.ModelCostStats{
+					"openai/gpt-4o":    {InputTokens: 100, OutputTokens: 10, TotalCost: 0.1, RequestCount: 1},
+					"anthropic/claude": {InputTokens: 200, OutputTokens: 20, TotalCost: 0.4, RequestCount: 2},
+				},
+			},
+		},
+		{
+			UpdatedAt:  time.Date(2026, 10, 16, 18, 0, 0, 0, time.Local),
+			Model:      "openai/gpt-4o",
+			TokenStats: domain.SessionTokenStats{TotalInputTokens: 50, TotalOutputTokens: 5, RequestCount: 1},
+			CostStats:  domain.SessionCostStats{TotalCost: 0.05},
+		},
+		{
+			UpdatedAt:  time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local),
+			Model:      "ollama/llama3",
+			TokenStats: domain.SessionTokenStats{TotalInputTokens: 10, TotalOutputTokens: 1, RequestCount: 1},
+		},
+		{
+			UpdatedAt: time.Date(2026, 9, 1, 12, 0, 0, 0, time.Local),
+			CostStats: domain.SessionCostStats{TotalCost: 9},
+		},
+	}
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+
+	periods, err := aggregateUsage(conversations, "day", since)
+	if err != nil {
+		t.Fatalf("aggregateUsage: %v", err)
+	}
+	if len(periods) != 2 || periods[0].Period != "2026-10-16" || periods[1].Period != "2026-10-14" {
+		t.Fatalf("unexpected periods %+v", periods)
+	}
+
+	today := periods[0]
+	if today.Conversations != 2 || today.Requests != 4 || today.InputTokens != 350 || today.Cost < 0.549 || today.Cost > 0.551 {
+		t.Errorf("unexpected day totals %+v", today)
+	}
+	if len(today.Models) != 2 || today.Models[0].Model != "anthropic/claude" {
+		t.Fatalf("models should be sorted by cost, got %+v", today.Models)
+	}
+	gpt := today.Models[1]
+	if gpt.Conversations != 2 || gpt.Requests != 2 || gpt.InputTokens != 150 {
+		t.Errorf("unexpected gpt-4o usage %+v", gpt)
+	}
+
+	monthly, _ := aggregateUsage(conversations, "month", time.Time{})
+	if len(monthly) != 2 || monthly[0].Period != "2026-10" || monthly[0].Conversations != 3 {
+		t.Errorf("unexpected monthly usage %+v", monthly)
+	}
+}
+
+func TestWriteUsageCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeUsageCSV(&buf, []usagePeriod{{
+		Period: "2026-10",
+		Models: []usageModel{{Model: "openai/gpt-4o", Conversations: 1, Requests: 2, InputTokens: 100, OutputTokens: 10, Cost: 0.25}},
+	}})
+	if err != nil {
+		t.Fatalf("writeUsageCSV: %v", err)
+	}
+
+	want := "period,model,conversations,requests,input_tokens,output_tokens,cost\n" +
+		"2026-10,openai/gpt-4o,1,2,100,10,0.250000\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderUsageTable(t *testing.T) {
+	if out := renderUsageTable(nil, "day"); out != "No usage recorded.\n" {
+		t.Errorf("unexpected empty output %q", out)
+	}
+
+	out := renderUsageTable([]usagePeriod{{
+		Period: "2026-W42", Conversations: 1, Cost: 1.5,
+		Models: []usageModel{{Model: "anthropic/claude", Conversations: 1, Cost: 1.5}},
+	}}, "week")
+	for _, want := range []string{"Usage by week (1)", "2026-W42", "anthropic/claude", "$1.50"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table is missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -134,3 +135,58 @@ func TestConfigSetProjectWritesSparseOverride(t *testing.T) {
 
 	require.NoFileExists(t, filepath.Join(homeDir, config.ConfigDirName, config.ConfigFileName))
 }
+
+// TestConfigUnsetProjectDropsOverride confirms `config unset --project` removes
+// the key from the project override, drops the section it leaves empty, and
+// keeps the project's other keys.
+func TestConfigUnsetProjectDropsOverride(t *testing.T) {
+	_, projectDir := splitHomeProjectEnv(t)
+
+	projCfg := filepath.Join(projectDir, config.DefaultConfigPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(projCfg), 0o755))
+	require.NoError(t, os.WriteFile(projCfg, []byte("---\nagent:\n  model: project-model\ngateway:\n  timeout: 5\n"), 0o644))
+
+	require.NoError(t, unsetConfigValue(newProjectFlagCmd(true), []string{"agent.model"}))
+
+	data, err := os.ReadFile(projCfg)
+	require.NoError(t, err)
+	content := string(data)
+	require.NotContains(t, content, "project-model")
+	require.NotContains(t, content, "agent:")
+	require.Contains(t, content, "timeout: 5")
+
+	require.Error(t, unsetConfigValue(newProjectFlagCmd(true), []string{"agent.modle"}), "unknown keys are rejected")
+}
+
+// TestConfigUnsetUserspaceResetsToDefault confirms `config unset` on the
+// userspace baseline writes the key's built-in default back.
+func TestConfigUnsetUserspaceResetsToDefault(t *testing.T) {
+	homeDir, _ := splitHomeProjectEnv(t)
+
+	require.NoError(t, setConfigValue(newProjectFlagCmd(false), []string{"agent.max_turns", "7"}))
+	require.NoError(t, unsetConfigValue(newProjectFlagCmd(false), []string{"agent.max_turns"}))
+
+	data, err := os.ReadFile(filepath.Join(homeDir, config.ConfigDirName, config.ConfigFileName))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "max_turns: "+strconv.Itoa(config.DefaultConfig().Agent.MaxTurns))
+}
+
+// TestConfigScopeFlags confirms --userspace and --project select a single
+// file and cannot be combined.
+func TestConfigScopeFlags(t *testing.T) {
+	cmd := newProjectFlagCmd(true)
+	cmd.Flags().Bool("userspace", false, "")
+
+	toProject, scoped, err := configScope(cmd)
+	require.NoError(t, err)
+	require.True(t, toProject)
+	require.True(t, scoped)
+
+	require.NoError(t, cmd.Flags().Set("userspace", "true"))
+	_, _, err = configScope(cmd)
+	require.Error(t, err)
+
+	_, scoped, err = configScope(newProjectFlagCmd(false))
+	require.NoError(t, err)
+	require.False(t, scoped, "without a flag, get reads the effective config")
+}
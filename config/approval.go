@@ -1,5 +1,15 @@
 package config
 
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
 // ResolveApprovalDelivery decides the effective action for a tool that needs
 // approval, given the configured tools.safety.approval_behaviour, whether an IPC
 // approval broker is attached (headless under the channel manager, i.e.
@@ -35,3 +45,158 @@ func ResolveApprovalDelivery(behaviour string, brokerAttached, isChat bool) stri
 		return ApprovalBehaviourBlock
 	}
 }
+
+// Actions an approval rule can take (see ApprovalRule)
+const (
+	ApprovalRuleAllow = "allow"
+	ApprovalRuleAsk   = "ask"
+)
+
+// ApprovalRule is one entry of tools.safety.rules. A rule matches a tool call
+// when Tool names the tool ("*" for any) and every condition it sets holds:
+//
+//   - Path is a slash-separated glob ("**" spans directories) matched against
+//     the call's file_path/path argument, relative to the working directory
+//     unless the pattern is absolute.
+//   - Command is a regular expression searched for in a Bash call's command.
+//     An allow rule only applies to a single clean command (the same guard as
+//     the bash allow-list), so "^go test" never approves "go test && rm -rf .".
+//
+// Action "allow" runs the call without asking; "ask" always prompts, even for
+// commands on the bash allow-list.
+type ApprovalRule struct {
+	Tool    string `yaml:"tool" mapstructure:"tool"`
+	Path    string `yaml:"path,omitempty" mapstructure:"path"`
+	Command string `yaml:"command,omitempty" mapstructure:"command"`
+	Action  string `yaml:"action" mapstructure:"action"`
+}
+
+// ApprovalRuleAction returns the action of the first tools.safety.rules entry
+// matching the tool call, with arguments as the call's raw JSON. ok is false
+// when no rule matches and the usual approval settings apply.
+func (c *Config) ApprovalRuleAction(toolName, arguments string) (action string, ok bool) {
+	if len(c.Tools.Safety.Rules) == 0 {
+		return "", false
+	}
+
+	var args map[string]any
+	if arguments != "" {
+		_ = json.Unmarshal([]byte(arguments), &args)
+	}
+
+	for _, rule := range c.Tools.Safety.Rules {
+		if rule.matches(toolName, args) {
+			return rule.Action, true
+		}
+	}
+	return "", false
+}
+
+func (r ApprovalRule) matches(toolName string, args map[string]any) bool {
+	if r.Tool != "*" && r.Tool != toolName {
+		return false
+	}
+
+	if r.Path != "" {
+		target := approvalRulePathArg(args)
+		if target == "" || !matchPathGlob(r.Path, relativeToWorkdir(target, r.Path)) {
+			return false
+		}
+	}
+
+	if r.Command != "" {
+		command, _ := args["command"].(string)
+		command = strings.TrimSpace(command)
+		if command == "" {
+			return false
+		}
+		if r.Action == ApprovalRuleAllow {
+			seg, clean := cleanSingleCommand(command)
+			if !clean {
+				return false
+			}
+			command = seg
+		}
+		matched, err := regexp.MatchString(r.Command, command)
+		if err != nil || !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r ApprovalRule) validate(field string) error {
+	if r.Tool == "" {
+		return fmt.Errorf("invalid %s: tool is required", field)
+	}
+	switch r.Action {
+	case ApprovalRuleAllow, ApprovalRuleAsk:
+	default:
+		return fmt.Errorf("invalid %s.action %q: must be %q or %q", field, r.Action, ApprovalRuleAllow, ApprovalRuleAsk)
+	}
+	if r.Path != "" {
+		if _, err := path.Match(strings.ReplaceAll(r.Path, "**", "*"), ""); err != nil {
+			return fmt.Errorf("invalid %s.path %q: %w", field, r.Path, err)
+		}
+	}
+	if r.Command != "" {
+		if _, err := regexp.Compile(r.Command); err != nil {
+			return fmt.Errorf("invalid %s.command %q: %w", field, r.Command, err)
+		}
+	}
+	return nil
+}
+
+// approvalRulePathArg returns the path a file tool call operates on
+func approvalRulePathArg(args map[string]any) string {
+	for _, key := range []string{"file_path", "path"} {
+		if p, ok := args[key].(string); ok && p != "" {
+			return p
+		}
+	}
+	return ""
+}
+
+// relativeToWorkdir makes an absolute p relative to the working directory so
+// relative patterns can match it; paths outside the working directory, and
+// any path checked against an absolute pattern, stay absolute
+func relativeToWorkdir(p, pattern string) string {
+	p = filepath.Clean(p)
+	if filepath.IsAbs(p) && !filepath.IsAbs(pattern) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, p); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				p = rel
+			}
+		}
+	}
+	return filepath.ToSlash(p)
+}
+
+// matchPathGlob reports whether the slash-separated name matches pattern,
+// where "**" as a whole segment matches any number of directories and other
+// segments use path.Match syntax
+func matchPathGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -1,6 +1,11 @@
 package config
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
 
 func TestResolveApprovalDelivery(t *testing.T) {
 	tests := []struct {
@@ -80,3 +85,77 @@ func TestConfigValidate_ApprovalBehaviour(t *testing.T) {
 		t.Error("Validate() with approval_behaviour \"bogus\" should return an error")
 	}
 }
+
+func TestApprovalRuleAction(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{}
+	cfg.Tools.Safety.Rules = []ApprovalRule{
+		{Tool: "Edit", Path: "internal/**/secrets/**", Action: ApprovalRuleAsk},
+		{Tool: "Edit", Path: "internal/**", Action: ApprovalRuleAllow},
+		{Tool: "*", Path: "**/*_test.go", Action: ApprovalRuleAllow},
+		{Tool: "Bash", Command: "^go test", Action: ApprovalRuleAllow},
+		{Tool: "Bash", Command: "^git push", Action: ApprovalRuleAsk},
+	}
+
+	tests := []struct {
+		name       string
+		tool       string
+		args       string
+		wantAction string
+		wantOK     bool
+	}{
+		{"edit under internal", "Edit", `{"file_path":"internal/app/chat.go"}`, ApprovalRuleAllow, true},
+		{"absolute path inside workdir", "Edit", `{"file_path":"` + filepath.ToSlash(filepath.Join(wd, "internal", "a.go")) + `"}`, ApprovalRuleAllow, true},
+		{"earlier ask rule wins", "Edit", `{"file_path":"internal/x/secrets/key.go"}`, ApprovalRuleAsk, true},
+		{"edit elsewhere", "Edit", `{"file_path":"cmd/root.go"}`, "", false},
+		{"escaping the workdir", "Edit", `{"file_path":"internal/../../etc/passwd"}`, "", false},
+		{"wildcard tool with path arg", "Write", `{"file_path":"cmd/root_test.go"}`, ApprovalRuleAllow, true},
+		{"path rule needs a path", "Write", `{"content":"x"}`, "", false},
+		{"go test", "Bash", `{"command":"go test ./..."}`, ApprovalRuleAllow, true},
+		{"chained command is not allowed", "Bash", `{"command":"go test ./... && rm -rf ."}`, "", false},
+		{"ask matches the raw command", "Bash", `{"command":"git push origin main"}`, ApprovalRuleAsk, true},
+		{"unmatched command", "Bash", `{"command":"make"}`, "", false},
+		{"bad json", "Bash", `{`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, ok := cfg.ApprovalRuleAction(tt.tool, tt.args)
+			if action != tt.wantAction || ok != tt.wantOK {
+				t.Errorf("ApprovalRuleAction(%s, %s) = (%q, %v), want (%q, %v)",
+					tt.tool, tt.args, action, ok, tt.wantAction, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestApprovalRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ApprovalRule
+		wantErr string
+	}{
+		{"valid", ApprovalRule{Tool: "Bash", Command: "^go (test|vet)", Action: ApprovalRuleAllow}, ""},
+		{"missing tool", ApprovalRule{Action: ApprovalRuleAllow}, "tool is required"},
+		{"unknown action", ApprovalRule{Tool: "Edit", Action: "deny"}, "action"},
+		{"bad regex", ApprovalRule{Tool: "Bash", Command: "(", Action: ApprovalRuleAsk}, "command"},
+		{"bad glob", ApprovalRule{Tool: "Edit", Path: "[", Action: ApprovalRuleAsk}, "path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.validate("tools.safety.rules[0]")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	utils "github.com/inference-gateway/cli/config/utils"
 )
 
@@ -37,6 +39,19 @@ type WhatsAppChannelConfig struct {
 	AllowedUsers  []string `yaml:"allowed_users" mapstructure:"allowed_users"`
 }
 
+// ChannelEnabled reports whether the named channel (telegram, whatsapp) is
+// enabled. Whether the daemon registered it at runtime is not checked.
+func (c *ChannelsConfig) ChannelEnabled(name string) bool {
+	switch strings.ToLower(name) {
+	case "telegram":
+		return c.Telegram.Enabled
+	case "whatsapp":
+		return c.WhatsApp.Enabled
+	default:
+		return false
+	}
+}
+
 // DefaultChannelsConfig returns the in-code default channels configuration
 // used when no channels.yaml file exists. `infer init` seeds the file from
 // this and the runtime falls back to it when the file is absent.
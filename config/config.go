@@ -1,10 +1,12 @@
 package config
 
 import (
+	"cmp"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 )
@@ -14,12 +16,18 @@ const (
 	AgentsDirName       = ".agents"
 	ConfigFileName      = "config.yaml"
 	GitignoreFileName   = ".gitignore"
+	InferignoreFileName = ".inferignore"
 	LogsDirName         = "logs"
+	JobsDirName         = "jobs"
 	MemoryDirName       = "memory"
+	CacheDirName        = "cache"
+	TokenizersDirName   = "tokenizers"
 	MemoryIndexFileName = "MEMORY.md"
+	ThemesDirName       = "themes"
 
 	DefaultConfigPath           = ConfigDirName + "/" + ConfigFileName
 	DefaultLogsPath             = ConfigDirName + "/" + LogsDirName
+	DefaultJobsPath             = ConfigDirName + "/" + JobsDirName
 	DefaultMemoryMaxChars       = 2000
 	DefaultMemoryMaxEntryChars  = 2000
 	DefaultSkillsMaxChars       = 4000
@@ -48,8 +56,10 @@ type Config struct {
 	Pricing          PricingConfig          `yaml:"pricing" mapstructure:"pricing"`
 	ContextWindows   map[string]int         `yaml:"context_windows" mapstructure:"context_windows"`
 	Compact          CompactConfig          `yaml:"compact" mapstructure:"compact"`
+	Tokenizer        TokenizerConfig        `yaml:"tokenizer" mapstructure:"tokenizer"`
 	Web              WebConfig              `yaml:"web" mapstructure:"web"`
 	Provisioner      ProvisionerConfig      `yaml:"provisioner,omitempty" mapstructure:"provisioner"`
+	Agents           AgentProfilesConfig    `yaml:"agents,omitempty" mapstructure:"agents"`
 	ComputerUse      ComputerUseConfig      `yaml:"-" mapstructure:"-"`
 	Channels         ChannelsConfig         `yaml:"-" mapstructure:"-"`
 	Heartbeat        HeartbeatConfig        `yaml:"-" mapstructure:"-"`
@@ -59,6 +69,7 @@ type Config struct {
 	Hooks            HooksConfig            `yaml:"-" mapstructure:"-"`
 	Plugins          PluginsConfig          `yaml:"-" mapstructure:"-"`
 	configDir        string
+	persona          *activePersona
 }
 
 // ContainerRuntimeConfig contains container runtime settings
@@ -132,6 +143,10 @@ type RetryConfig struct {
 	MaxBackoffSec        int   `yaml:"max_backoff_sec" mapstructure:"max_backoff_sec"`
 	BackoffMultiplier    int   `yaml:"backoff_multiplier" mapstructure:"backoff_multiplier"`
 	RetryableStatusCodes []int `yaml:"retryable_status_codes" mapstructure:"retryable_status_codes"`
+	// RateLimitMaxWaitSec caps how long the agent waits when a provider
+	// rate-limits a request and says when to retry; a longer wait fails the
+	// request (or moves on to agent.model_fallbacks) instead. 0 never gives up.
+	RateLimitMaxWaitSec int `yaml:"rate_limit_max_wait_sec" mapstructure:"rate_limit_max_wait_sec"`
 }
 
 // LoggingConfig contains logging settings
@@ -177,6 +192,7 @@ type ToolsConfig struct {
 	Delete          DeleteToolConfig          `yaml:"delete" mapstructure:"delete"`
 	Grep            GrepToolConfig            `yaml:"grep" mapstructure:"grep"`
 	Tree            TreeToolConfig            `yaml:"tree" mapstructure:"tree"`
+	ProjectSearch   ProjectSearchToolConfig   `yaml:"project_search" mapstructure:"project_search"`
 	WebFetch        WebFetchToolConfig        `yaml:"web_fetch" mapstructure:"web_fetch"`
 	WebSearch       WebSearchToolConfig       `yaml:"web_search" mapstructure:"web_search"`
 	TodoWrite       TodoWriteToolConfig       `yaml:"todo_write" mapstructure:"todo_write"`
@@ -250,6 +266,13 @@ type TreeToolConfig struct {
 	RequireApproval *bool `yaml:"require_approval,omitempty" mapstructure:"require_approval,omitempty"`
 }
 
+// ProjectSearchToolConfig contains ProjectSearch-specific tool settings.
+// The tool is offered only when the project has an index (infer index).
+type ProjectSearchToolConfig struct {
+	Enabled         bool  `yaml:"enabled" mapstructure:"enabled"`
+	RequireApproval *bool `yaml:"require_approval,omitempty" mapstructure:"require_approval,omitempty"`
+}
+
 // WebFetchToolConfig contains fetch-specific tool settings
 type WebFetchToolConfig struct {
 	Enabled         bool              `yaml:"enabled" mapstructure:"enabled"`
@@ -261,12 +284,23 @@ type WebFetchToolConfig struct {
 
 // WebSearchToolConfig contains web search-specific tool settings
 type WebSearchToolConfig struct {
-	Enabled         bool     `yaml:"enabled" mapstructure:"enabled"`
-	DefaultEngine   string   `yaml:"default_engine" mapstructure:"default_engine"`
-	MaxResults      int      `yaml:"max_results" mapstructure:"max_results"`
-	Engines         []string `yaml:"engines" mapstructure:"engines"`
-	Timeout         int      `yaml:"timeout" mapstructure:"timeout"`
-	RequireApproval *bool    `yaml:"require_approval,omitempty" mapstructure:"require_approval,omitempty"`
+	Enabled         bool                 `yaml:"enabled" mapstructure:"enabled"`
+	DefaultEngine   string               `yaml:"default_engine" mapstructure:"default_engine"`
+	MaxResults      int                  `yaml:"max_results" mapstructure:"max_results"`
+	Engines         []string             `yaml:"engines" mapstructure:"engines"`
+	Timeout         int                  `yaml:"timeout" mapstructure:"timeout"`
+	Fetch           WebSearchFetchConfig `yaml:"fetch" mapstructure:"fetch"`
+	RequireApproval *bool                `yaml:"require_approval,omitempty" mapstructure:"require_approval,omitempty"`
+}
+
+// WebSearchFetchConfig controls the optional result-fetching pipeline: when
+// enabled, WebSearch fetches the top N results itself (through the WebFetch
+// domain allow-list) and attaches a short extractive summary of each page, so
+// the model doesn't need a search followed by N separate WebFetch calls.
+type WebSearchFetchConfig struct {
+	Enabled         bool `yaml:"enabled" mapstructure:"enabled"`
+	TopN            int  `yaml:"top_n" mapstructure:"top_n"`
+	MaxSummaryChars int  `yaml:"max_summary_chars" mapstructure:"max_summary_chars"`
 }
 
 // TodoWriteToolConfig contains TodoWrite-specific tool settings
@@ -313,6 +347,7 @@ type AgentToolConfig struct {
 	Wait               bool                   `yaml:"wait" mapstructure:"wait"`                 // false => async (fire-and-forget + notify)
 	MaxParallel        int                    `yaml:"max_parallel" mapstructure:"max_parallel"` // cap on concurrent subagents per call
 	MaxDepth           int                    `yaml:"max_depth" mapstructure:"max_depth"`       // recursion guard (a subagent is itself an `infer agent`)
+	MaxTurns           int                    `yaml:"max_turns" mapstructure:"max_turns"`       // subagent turn budget and cap on per-task max_turns; 0 => agent.max_turns
 	Model              string                 `yaml:"model,omitempty" mapstructure:"model,omitempty"`
 	InheritMock        bool                   `yaml:"inherit_mock" mapstructure:"inherit_mock"` // propagate gateway.mock to spawned subagents
 	Interactive        AgentInteractiveConfig `yaml:"interactive" mapstructure:"interactive"`
@@ -395,6 +430,10 @@ type SafetyConfig struct {
 	// RequireApproval / the per-tool require_approval override / the per-mode bash
 	// allow-list. Resolve via ApprovalBehaviourFor; validated by Config.Validate.
 	ApprovalBehaviour string `yaml:"approval_behaviour" mapstructure:"approval_behaviour"`
+	// Rules are evaluated in order before the settings above; the first match
+	// decides whether the call runs without asking ("allow") or always prompts
+	// ("ask"). See ApprovalRule.
+	Rules []ApprovalRule `yaml:"rules,omitempty" mapstructure:"rules"`
 }
 
 // ExportConfig contains settings for export command
@@ -405,11 +444,67 @@ type ExportConfig struct {
 
 // CompactConfig contains conversation compaction settings
 type CompactConfig struct {
-	Enabled               bool `yaml:"enabled" mapstructure:"enabled"`
-	AutoAt                int  `yaml:"auto_at" mapstructure:"auto_at"`
-	KeepFirstMessages     int  `yaml:"keep_first_messages" mapstructure:"keep_first_messages"`
-	RolloverOnIdleMinutes int  `yaml:"rollover_on_idle_minutes" mapstructure:"rollover_on_idle_minutes"`
-	SummaryMaxTokens      int  `yaml:"summary_max_tokens" mapstructure:"summary_max_tokens"`
+	Enabled               bool   `yaml:"enabled" mapstructure:"enabled"`
+	AutoAt                int    `yaml:"auto_at" mapstructure:"auto_at"`
+	Strategy              string `yaml:"strategy" mapstructure:"strategy"`
+	KeepFirstMessages     int    `yaml:"keep_first_messages" mapstructure:"keep_first_messages"`
+	KeepLastMessages      int    `yaml:"keep_last_messages" mapstructure:"keep_last_messages"`
+	RolloverOnIdleMinutes int    `yaml:"rollover_on_idle_minutes" mapstructure:"rollover_on_idle_minutes"`
+	SummaryMaxTokens      int    `yaml:"summary_max_tokens" mapstructure:"summary_max_tokens"`
+	SummaryModel          string `yaml:"summary_model,omitempty" mapstructure:"summary_model"`
+	SummaryPrompt         string `yaml:"summary_prompt,omitempty" mapstructure:"summary_prompt"`
+}
+
+// TokenizerConfig selects how tokens are counted for the context-usage
+// indicator and auto-compaction
+type TokenizerConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Models maps model name patterns to encodings, on top of the built-in
+	// mapping; the longest pattern contained in the model name wins
+	Models map[string]string `yaml:"models,omitempty" mapstructure:"models"`
+	// CacheDir holds the encodings (default: ~/.infer/cache/tokenizers)
+	CacheDir string `yaml:"cache_dir,omitempty" mapstructure:"cache_dir"`
+	// DownloadEncodings fetches encodings missing from CacheDir from
+	// OpenAI's public tiktoken storage. Off by default: without the files,
+	// OpenAI-style models are estimated.
+	DownloadEncodings bool `yaml:"download_encodings" mapstructure:"download_encodings"`
+	// AnthropicAPIKey counts Claude tokens with Anthropic's API directly
+	// instead of through the gateway
+	AnthropicAPIKey string `yaml:"anthropic_api_key,omitempty" mapstructure:"anthropic_api_key"`
+}
+
+// TokenizerEncodings lists the encodings tokenizer.models can map to
+var TokenizerEncodings = []string{"o200k_base", "cl100k_base", "anthropic", "heuristic"}
+
+// Compaction strategies selectable with compact.strategy
+const (
+	// CompactStrategyPinAware keeps the first messages and the pinned ones
+	// and summarizes everything else
+	CompactStrategyPinAware = "pin-aware"
+	// CompactStrategySummarizeOldest also keeps the last keep_last_messages
+	// verbatim and summarizes only the older middle of the conversation
+	CompactStrategySummarizeOldest = "summarize-oldest"
+	// CompactStrategyDropToolResults first removes the output of older tool
+	// calls and summarizes only when that is not enough to get under auto_at
+	CompactStrategyDropToolResults = "drop-tool-results-first"
+	// CompactStrategySlidingWindow drops the older middle without a summary
+	CompactStrategySlidingWindow = "sliding-window"
+)
+
+// CompactStrategies lists the valid compact.strategy values
+var CompactStrategies = []string{
+	CompactStrategyPinAware,
+	CompactStrategySummarizeOldest,
+	CompactStrategyDropToolResults,
+	CompactStrategySlidingWindow,
+}
+
+// EffectiveStrategy returns the configured strategy, pin-aware when unset
+func (c CompactConfig) EffectiveStrategy() string {
+	if c.Strategy == "" {
+		return CompactStrategyPinAware
+	}
+	return c.Strategy
 }
 
 // ProvisionerConfig contains on-demand GPU provisioning settings (issue #939).
@@ -487,8 +582,9 @@ type AgentSkillsConfig struct {
 	MaxChars       int      `yaml:"max_chars" mapstructure:"max_chars"`
 }
 
-// AgentsMDConfig controls native injection of the project-root AGENTS.md
-// into the system prompt.
+// AgentsMDConfig controls native injection of AGENTS.md into the system
+// prompt: ~/.infer/AGENTS.md, the repository root's and those of the
+// directories down to the working directory. The caps apply per file.
 type AgentsMDConfig struct {
 	Enabled  bool `yaml:"enabled" mapstructure:"enabled"`
 	MaxChars int  `yaml:"max_chars" mapstructure:"max_chars"`
@@ -499,21 +595,126 @@ type AgentsMDConfig struct {
 // All system prompts, custom instructions, and system reminder settings
 // live in prompts.yaml and are read from cfg.Prompts.Agent.* at runtime.
 type AgentConfig struct {
-	Model                    string             `yaml:"model" mapstructure:"model"`
-	SystemPromptWithDefaults bool               `yaml:"system_prompt_with_defaults" mapstructure:"system_prompt_with_defaults"`
-	Context                  AgentContextConfig `yaml:"context" mapstructure:"context"`
-	Skills                   AgentSkillsConfig  `yaml:"skills" mapstructure:"skills"`
-	AgentsMD                 AgentsMDConfig     `yaml:"agents_md" mapstructure:"agents_md"`
-	VerboseTools             bool               `yaml:"verbose_tools" mapstructure:"verbose_tools"`
-	MaxTurns                 int                `yaml:"max_turns" mapstructure:"max_turns"`
-	MaxTokens                int                `yaml:"max_tokens" mapstructure:"max_tokens"`
-	ReasoningEffort          string             `yaml:"reasoning_effort,omitempty" mapstructure:"reasoning_effort"`
-	MaxConcurrentTools       int                `yaml:"max_concurrent_tools" mapstructure:"max_concurrent_tools"`
+	Model                    string                `yaml:"model" mapstructure:"model"`
+	SystemPromptWithDefaults bool                  `yaml:"system_prompt_with_defaults" mapstructure:"system_prompt_with_defaults"`
+	Context                  AgentContextConfig    `yaml:"context" mapstructure:"context"`
+	Skills                   AgentSkillsConfig     `yaml:"skills" mapstructure:"skills"`
+	AgentsMD                 AgentsMDConfig        `yaml:"agents_md" mapstructure:"agents_md"`
+	VerboseTools             bool                  `yaml:"verbose_tools" mapstructure:"verbose_tools"`
+	MaxTurns                 int                   `yaml:"max_turns" mapstructure:"max_turns"`
+	MaxTokens                int                   `yaml:"max_tokens" mapstructure:"max_tokens"`
+	ReasoningEffort          string                `yaml:"reasoning_effort,omitempty" mapstructure:"reasoning_effort"`
+	ReasoningBudget          int                   `yaml:"reasoning_budget,omitempty" mapstructure:"reasoning_budget"`
+	ToolConcurrency          ToolConcurrencyConfig `yaml:"tool_concurrency" mapstructure:"tool_concurrency"`
+	ModelFallbacks           []string              `yaml:"model_fallbacks,omitempty" mapstructure:"model_fallbacks"`
+	Routing                  ModelRoutingConfig    `yaml:"routing" mapstructure:"routing"`
+	PromptCaching            bool                  `yaml:"prompt_caching" mapstructure:"prompt_caching"`
+	Sampling                 SamplingConfig        `yaml:"sampling,omitempty" mapstructure:"sampling"`
+	StopSequences            []string              `yaml:"stop_sequences,omitempty" mapstructure:"stop_sequences"`
+	// Unattended makes `infer agent` run every tool without approval, for
+	// containers and CI where nobody can answer a prompt. It requires
+	// AuditLog, the JSONL file every tool call is appended to.
+	Unattended bool   `yaml:"unattended" mapstructure:"unattended"`
+	AuditLog   string `yaml:"audit_log,omitempty" mapstructure:"audit_log"`
+	// MaxCostPerRun caps the estimated cost of one agent run, from the
+	// user's message to the final answer. 0 means no cap.
+	MaxCostPerRun float64 `yaml:"max_cost_per_run" mapstructure:"max_cost_per_run"`
+	// MaxConcurrentTools is the single limit ToolConcurrency replaced.
+	// Deprecated: still honoured as a cap on every tool class.
+	MaxConcurrentTools int `yaml:"max_concurrent_tools,omitempty" mapstructure:"max_concurrent_tools"`
+}
+
+// SamplingConfig holds the sampling parameters sent with every chat request.
+// Unset parameters are left out, so the provider's defaults apply.
+type SamplingConfig struct {
+	Temperature      *float32 `yaml:"temperature,omitempty" mapstructure:"temperature"`
+	TopP             *float32 `yaml:"top_p,omitempty" mapstructure:"top_p"`
+	FrequencyPenalty *float32 `yaml:"frequency_penalty,omitempty" mapstructure:"frequency_penalty"`
+	PresencePenalty  *float32 `yaml:"presence_penalty,omitempty" mapstructure:"presence_penalty"`
+	Seed             *int     `yaml:"seed,omitempty" mapstructure:"seed"`
+}
+
+// ModelRoutingConfig picks a model per task type, so lightweight calls and
+// routine agent turns can run on a cheaper or faster model. Empty entries
+// leave the task on the model it would otherwise use.
+type ModelRoutingConfig struct {
+	// Lightweight is used for title generation, commit messages and
+	// summarization when their own model setting is empty
+	Lightweight string `yaml:"lightweight,omitempty" mapstructure:"lightweight"`
+	// ToolSelection is used for agent turns that continue after tool calls
+	// which only read, searched or fetched
+	ToolSelection string `yaml:"tool_selection,omitempty" mapstructure:"tool_selection"`
+	// CodeGeneration is used for agent turns answering a user message or
+	// continuing after files were changed or commands run
+	CodeGeneration string `yaml:"code_generation,omitempty" mapstructure:"code_generation"`
+}
+
+// maxStopSequences is the most stop sequences the chat completions API takes
+const maxStopSequences = 4
+
+// Task types routed by agent.routing
+const (
+	TaskTitleGeneration = "title_generation"
+	TaskCommitMessage   = "commit_message"
+	TaskSummarization   = "summarization"
+	TaskToolSelection   = "tool_selection"
+	TaskCodeGeneration  = "code_generation"
+)
+
+// ToolConcurrencyConfig bounds how many tool calls of each class run at once
+// within one turn. Local reads and searches are CPU-bound; web, A2A and MCP
+// calls are network-bound; tools that write files or run commands are
+// mutating and additionally wait for earlier calls touching the same file.
+type ToolConcurrencyConfig struct {
+	CPUBound     int `yaml:"cpu_bound" mapstructure:"cpu_bound"`
+	NetworkBound int `yaml:"network_bound" mapstructure:"network_bound"`
+	Mutating     int `yaml:"mutating" mapstructure:"mutating"`
+}
+
+const (
+	DefaultToolConcurrencyCPUBound     = 4
+	DefaultToolConcurrencyNetworkBound = 8
+	DefaultToolConcurrencyMutating     = 4
+)
+
+// EffectiveCPUBound returns the CPU-bound limit, defaulting when unset
+func (c ToolConcurrencyConfig) EffectiveCPUBound() int {
+	if c.CPUBound > 0 {
+		return c.CPUBound
+	}
+	return DefaultToolConcurrencyCPUBound
+}
+
+// EffectiveNetworkBound returns the network-bound limit, defaulting when unset
+func (c ToolConcurrencyConfig) EffectiveNetworkBound() int {
+	if c.NetworkBound > 0 {
+		return c.NetworkBound
+	}
+	return DefaultToolConcurrencyNetworkBound
+}
+
+// EffectiveMutating returns the mutating limit, defaulting when unset
+func (c ToolConcurrencyConfig) EffectiveMutating() int {
+	if c.Mutating > 0 {
+		return c.Mutating
+	}
+	return DefaultToolConcurrencyMutating
+}
+
+// CappedAt returns the limits with none above max, for the deprecated
+// agent.max_concurrent_tools
+func (c ToolConcurrencyConfig) CappedAt(max int) ToolConcurrencyConfig {
+	return ToolConcurrencyConfig{
+		CPUBound:     min(c.EffectiveCPUBound(), max),
+		NetworkBound: min(c.EffectiveNetworkBound(), max),
+		Mutating:     min(c.EffectiveMutating(), max),
+	}
 }
 
 // GitConfig contains git shortcut-specific settings
 type GitConfig struct {
 	CommitMessage GitCommitMessageConfig `yaml:"commit_message" mapstructure:"commit_message"`
+	Review        GitReviewConfig        `yaml:"review" mapstructure:"review"`
 }
 
 // A2AConfig contains A2A agent configuration
@@ -546,6 +747,26 @@ type GitCommitMessageConfig struct {
 	Model string `yaml:"model" mapstructure:"model"`
 }
 
+// Review severities a model can report, most severe first
+const (
+	ReviewSeverityCritical = "critical"
+	ReviewSeverityHigh     = "high"
+	ReviewSeverityMedium   = "medium"
+	ReviewSeverityLow      = "low"
+)
+
+// ReviewSeverities lists the review severities, most severe first
+var ReviewSeverities = []string{ReviewSeverityCritical, ReviewSeverityHigh, ReviewSeverityMedium, ReviewSeverityLow}
+
+// GitReviewConfig contains settings for the review run by the git hooks of
+// `infer hooks install`. Findings with a severity in BlockOn block the
+// commit or push. The system prompt lives in prompts.yaml under
+// git.review.system_prompt.
+type GitReviewConfig struct {
+	Model   string   `yaml:"model" mapstructure:"model"`
+	BlockOn []string `yaml:"block_on" mapstructure:"block_on"`
+}
+
 // ConversationTitleConfig contains settings for AI-generated conversation
 // titles. The system prompt lives in prompts.yaml under
 // conversation.title_generation.system_prompt.
@@ -558,10 +779,35 @@ type ConversationTitleConfig struct {
 
 // ChatConfig contains chat interface settings
 type ChatConfig struct {
-	Theme         string            `yaml:"theme" mapstructure:"theme"`
-	Keybindings   KeybindingsConfig `yaml:"-" mapstructure:"-"`
-	StatusBar     StatusBarConfig   `yaml:"status_bar" mapstructure:"status_bar"`
-	InputMaxLines int               `yaml:"input_max_lines" mapstructure:"input_max_lines"`
+	Theme              string              `yaml:"theme" mapstructure:"theme"`
+	Keybindings        KeybindingsConfig   `yaml:"-" mapstructure:"-"`
+	StatusBar          StatusBarConfig     `yaml:"status_bar" mapstructure:"status_bar"`
+	InputMaxLines      int                 `yaml:"input_max_lines" mapstructure:"input_max_lines"`
+	SyntaxHighlighting bool                `yaml:"syntax_highlighting" mapstructure:"syntax_highlighting"`
+	InlineImages       bool                `yaml:"inline_images" mapstructure:"inline_images"`
+	PasteCollapseLines int                 `yaml:"paste_collapse_lines" mapstructure:"paste_collapse_lines"`
+	Notifications      NotificationsConfig `yaml:"notifications" mapstructure:"notifications"`
+	ApprovalAlert      ApprovalAlertConfig `yaml:"approval_alert" mapstructure:"approval_alert"`
+	UndoSendSeconds    int                 `yaml:"undo_send_seconds" mapstructure:"undo_send_seconds"`
+	UpdateNotice       bool                `yaml:"update_notice" mapstructure:"update_notice"`
+	HideThinking       bool                `yaml:"hide_thinking" mapstructure:"hide_thinking"`
+	Checkpoints        bool                `yaml:"checkpoints" mapstructure:"checkpoints"`
+}
+
+// ApprovalAlertConfig rings the terminal bell and flashes the status bar when
+// a tool or plan approval prompt stays unanswered for DelaySeconds.
+type ApprovalAlertConfig struct {
+	Enabled      bool `yaml:"enabled" mapstructure:"enabled"`
+	DelaySeconds int  `yaml:"delay_seconds" mapstructure:"delay_seconds"`
+}
+
+// NotificationsConfig contains settings for desktop notifications sent when
+// a long agent turn finishes or an approval prompt appears while the terminal
+// is unfocused. Method is auto, osc777, terminal-notifier, or notify-send.
+type NotificationsConfig struct {
+	Enabled        bool   `yaml:"enabled" mapstructure:"enabled"`
+	Method         string `yaml:"method" mapstructure:"method"`
+	MinTurnSeconds int    `yaml:"min_turn_seconds" mapstructure:"min_turn_seconds"`
 }
 
 // StatusBarConfig contains settings for the chat status bar
@@ -569,6 +815,20 @@ type ChatConfig struct {
 type StatusBarConfig struct {
 	Enabled    bool                `yaml:"enabled" mapstructure:"enabled"`
 	Indicators StatusBarIndicators `yaml:"indicators" mapstructure:"indicators"`
+	// Order lists indicator and segment names in display order; anything
+	// not listed keeps its default position after the listed ones
+	Order    []string           `yaml:"order,omitempty" mapstructure:"order"`
+	Segments []StatusBarSegment `yaml:"segments,omitempty" mapstructure:"segments"`
+}
+
+// StatusBarSegment is a user-defined status bar indicator showing the first
+// line of a shell command's output, re-run every IntervalSeconds. A command
+// that fails or prints nothing hides the segment until its next run.
+type StatusBarSegment struct {
+	Name            string `yaml:"name" mapstructure:"name"`
+	Command         string `yaml:"command" mapstructure:"command"`
+	IntervalSeconds int    `yaml:"interval_seconds,omitempty" mapstructure:"interval_seconds"`
+	Color           string `yaml:"color,omitempty" mapstructure:"color"`
 }
 
 // StatusBarIndicators contains individual enable/disable toggles for each indicator
@@ -803,6 +1063,7 @@ func DefaultConfig() *Config { //nolint:funlen
 				MaxBackoffSec:        60,
 				BackoffMultiplier:    2,
 				RetryableStatusCodes: []int{408, 429, 500, 502, 503, 504},
+				RateLimitMaxWaitSec:  300,
 			},
 		},
 		Logging: LoggingConfig{
@@ -879,6 +1140,10 @@ func DefaultConfig() *Config { //nolint:funlen
 				Enabled:         true,
 				RequireApproval: &[]bool{false}[0],
 			},
+			ProjectSearch: ProjectSearchToolConfig{
+				Enabled:         true,
+				RequireApproval: &[]bool{false}[0],
+			},
 			WebFetch: WebFetchToolConfig{
 				Enabled:        true,
 				AllowedDomains: []string{"golang.org", "localhost"},
@@ -899,6 +1164,11 @@ func DefaultConfig() *Config { //nolint:funlen
 				MaxResults:    10,
 				Engines:       []string{"duckduckgo", "google"},
 				Timeout:       10,
+				Fetch: WebSearchFetchConfig{
+					Enabled:         false,
+					TopN:            3,
+					MaxSummaryChars: 1500,
+				},
 			},
 			TodoWrite: TodoWriteToolConfig{
 				Enabled:         true,
@@ -974,12 +1244,21 @@ func DefaultConfig() *Config { //nolint:funlen
 			VerboseTools:             false,
 			MaxTurns:                 50,
 			MaxTokens:                8192,
-			MaxConcurrentTools:       5,
+			ToolConcurrency: ToolConcurrencyConfig{
+				CPUBound:     DefaultToolConcurrencyCPUBound,
+				NetworkBound: DefaultToolConcurrencyNetworkBound,
+				Mutating:     DefaultToolConcurrencyMutating,
+			},
+			PromptCaching: true,
 		},
 		Git: GitConfig{
 			CommitMessage: GitCommitMessageConfig{
 				Model: "",
 			},
+			Review: GitReviewConfig{
+				Model:   "",
+				BlockOn: []string{ReviewSeverityCritical, ReviewSeverityHigh},
+			},
 		},
 		Storage: StorageConfig{
 			Enabled: true,
@@ -1034,8 +1313,23 @@ func DefaultConfig() *Config { //nolint:funlen
 				Enabled:  true,
 				Bindings: GetDefaultKeybindings(),
 			},
-			StatusBar:     GetDefaultStatusBarConfig(),
-			InputMaxLines: 20,
+			StatusBar:          GetDefaultStatusBarConfig(),
+			InputMaxLines:      20,
+			SyntaxHighlighting: true,
+			InlineImages:       true,
+			PasteCollapseLines: 20,
+			Notifications: NotificationsConfig{
+				Enabled:        false,
+				Method:         "auto",
+				MinTurnSeconds: 30,
+			},
+			ApprovalAlert: ApprovalAlertConfig{
+				Enabled:      false,
+				DelaySeconds: 10,
+			},
+			UndoSendSeconds: 10,
+			UpdateNotice:    true,
+			Checkpoints:     true,
 		},
 		A2A: A2AConfig{
 			Enabled:               true,
@@ -1075,10 +1369,15 @@ func DefaultConfig() *Config { //nolint:funlen
 		Compact: CompactConfig{
 			Enabled:               true,
 			AutoAt:                80,
+			Strategy:              CompactStrategyPinAware,
 			KeepFirstMessages:     2,
+			KeepLastMessages:      6,
 			RolloverOnIdleMinutes: 30,
 			SummaryMaxTokens:      1024,
 		},
+		Tokenizer: TokenizerConfig{
+			Enabled: true,
+		},
 		Web: WebConfig{
 			Enabled:               false,
 			Port:                  3000,
@@ -1132,6 +1431,10 @@ func (c *Config) IsApprovalRequired(toolName string) bool { // nolint:gocyclo,cy
 		if c.Tools.Tree.RequireApproval != nil {
 			return *c.Tools.Tree.RequireApproval
 		}
+	case "ProjectSearch":
+		if c.Tools.ProjectSearch.RequireApproval != nil {
+			return *c.Tools.ProjectSearch.RequireApproval
+		}
 	case "WebFetch":
 		if c.Tools.WebFetch.RequireApproval != nil {
 			return *c.Tools.WebFetch.RequireApproval
@@ -1157,7 +1460,7 @@ func (c *Config) IsApprovalRequired(toolName string) bool { // nolint:gocyclo,cy
 		return false
 	case "ApproveSubagent":
 		return true
-	case "RequestPlanApproval":
+	case "RequestPlanApproval", "UpdatePlanStep":
 		return false
 	case "AskUserQuestion":
 		return false
@@ -1214,6 +1517,12 @@ func (c *Config) Validate() error {
 		)
 	}
 
+	for i, rule := range c.Tools.Safety.Rules {
+		if err := rule.validate(fmt.Sprintf("tools.safety.rules[%d]", i)); err != nil {
+			return err
+		}
+	}
+
 	switch c.Agent.ReasoningEffort {
 	case "", "minimal", "low", "medium", "high":
 	default:
@@ -1223,6 +1532,86 @@ func (c *Config) Validate() error {
 		)
 	}
 
+	if err := c.Agent.Sampling.Validate("agent.sampling"); err != nil {
+		return err
+	}
+	for _, name := range c.PersonaNames() {
+		if err := c.Agents.Profiles[name].Sampling.Validate("agents.profiles." + name + ".sampling"); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Agent.StopSequences) > maxStopSequences {
+		return fmt.Errorf("invalid agent.stop_sequences: at most %d are allowed, got %d", maxStopSequences, len(c.Agent.StopSequences))
+	}
+	if slices.Contains(c.Agent.StopSequences, "") {
+		return fmt.Errorf("invalid agent.stop_sequences: entries must not be empty")
+	}
+
+	if c.Agent.ReasoningBudget < 0 {
+		return fmt.Errorf("invalid agent.reasoning_budget %d: must not be negative", c.Agent.ReasoningBudget)
+	}
+
+	if c.Agent.MaxCostPerRun < 0 {
+		return fmt.Errorf("invalid agent.max_cost_per_run %v: must not be negative", c.Agent.MaxCostPerRun)
+	}
+
+	if c.Pricing.DailyBudget < 0 {
+		return fmt.Errorf("invalid pricing.daily_budget %v: must not be negative", c.Pricing.DailyBudget)
+	}
+
+	for _, model := range c.Agent.ModelFallbacks {
+		if provider, name, ok := strings.Cut(model, "/"); !ok || provider == "" || name == "" {
+			return fmt.Errorf("invalid agent.model_fallbacks entry %q: expected 'provider/model'", model)
+		}
+	}
+	for _, rule := range []struct {
+		key   string
+		model string
+	}{
+		{"lightweight", c.Agent.Routing.Lightweight},
+		{"tool_selection", c.Agent.Routing.ToolSelection},
+		{"code_generation", c.Agent.Routing.CodeGeneration},
+	} {
+		if provider, name, ok := strings.Cut(rule.model, "/"); rule.model != "" && (!ok || provider == "" || name == "") {
+			return fmt.Errorf("invalid agent.routing.%s %q: expected 'provider/model'", rule.key, rule.model)
+		}
+	}
+
+	if c.Compact.Strategy != "" && !slices.Contains(CompactStrategies, c.Compact.Strategy) {
+		return fmt.Errorf(
+			"invalid compact.strategy %q: must be one of %q",
+			c.Compact.Strategy, CompactStrategies,
+		)
+	}
+	if c.Compact.KeepLastMessages < 0 {
+		return fmt.Errorf(
+			"invalid compact.keep_last_messages %d: must be >= 0",
+			c.Compact.KeepLastMessages,
+		)
+	}
+	for pattern, encoding := range c.Tokenizer.Models {
+		if !slices.Contains(TokenizerEncodings, encoding) {
+			return fmt.Errorf(
+				"invalid tokenizer.models[%q] %q: must be one of %q",
+				pattern, encoding, TokenizerEncodings,
+			)
+		}
+	}
+
+	for _, limit := range []struct {
+		key   string
+		value int
+	}{
+		{"cpu_bound", c.Agent.ToolConcurrency.CPUBound},
+		{"network_bound", c.Agent.ToolConcurrency.NetworkBound},
+		{"mutating", c.Agent.ToolConcurrency.Mutating},
+	} {
+		if limit.value < 0 {
+			return fmt.Errorf("invalid agent.tool_concurrency.%s %d: must be >= 0", limit.key, limit.value)
+		}
+	}
+
 	if c.SpeechToText.RetainRecordings < 0 {
 		return fmt.Errorf(
 			"invalid speech_to_text.retain_recordings %d: must be >= 0",
@@ -1234,6 +1623,27 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	for _, severity := range c.Git.Review.BlockOn {
+		if !slices.Contains(ReviewSeverities, strings.ToLower(strings.TrimSpace(severity))) {
+			return fmt.Errorf(
+				"invalid git.review.block_on severity %q: must be one of %q",
+				severity, ReviewSeverities,
+			)
+		}
+	}
+
+	for _, name := range c.PersonaNames() {
+		switch behaviour := c.Agents.Profiles[name].ApprovalBehaviour; behaviour {
+		case "", ApprovalBehaviourPrompt, ApprovalBehaviourIPC, ApprovalBehaviourBlock:
+		default:
+			return fmt.Errorf(
+				"invalid agents.profiles.%s.approval_behaviour %q: must be one of %q, %q, or %q",
+				name, behaviour,
+				ApprovalBehaviourPrompt, ApprovalBehaviourIPC, ApprovalBehaviourBlock,
+			)
+		}
+	}
+
 	if err := c.Reminders.Validate(); err != nil {
 		return fmt.Errorf("invalid reminders: %w", err)
 	}
@@ -1285,6 +1695,26 @@ func (c *Config) GetDefaultModel() string {
 	return c.Agent.Model
 }
 
+// ModelForTask returns the model for a task type: the task's own setting
+// (conversation.title_generation.model, git.commit_message.model,
+// compact.summary_model), then the agent.routing rule for its type, then
+// fallback
+func (c *Config) ModelForTask(task, fallback string) string {
+	switch task {
+	case TaskTitleGeneration:
+		return cmp.Or(c.Conversation.TitleGeneration.Model, c.Agent.Routing.Lightweight, fallback)
+	case TaskCommitMessage:
+		return cmp.Or(c.Git.CommitMessage.Model, c.Agent.Routing.Lightweight, fallback)
+	case TaskSummarization:
+		return cmp.Or(c.Compact.SummaryModel, c.Agent.Routing.Lightweight, fallback)
+	case TaskToolSelection:
+		return cmp.Or(c.Agent.Routing.ToolSelection, fallback)
+	case TaskCodeGeneration:
+		return cmp.Or(c.Agent.Routing.CodeGeneration, fallback)
+	}
+	return fallback
+}
+
 func (c *Config) GetSandboxDirectories() []string {
 	return c.Tools.Sandbox.Directories
 }
@@ -1305,6 +1735,20 @@ func (c *Config) ResolveMemoryDir() (string, error) {
 	return filepath.Join(home, ConfigDirName, MemoryDirName), nil
 }
 
+// ResolveTokenizerCacheDir resolves the directory downloaded tokenizer
+// encodings are kept in: tokenizer.cache_dir, or ~/.infer/cache/tokenizers so
+// every project shares them
+func (c *Config) ResolveTokenizerCacheDir() (string, error) {
+	if strings.TrimSpace(c.Tokenizer.CacheDir) != "" {
+		return c.Tokenizer.CacheDir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home dir: %w", err)
+	}
+	return filepath.Join(home, ConfigDirName, CacheDirName, TokenizersDirName), nil
+}
+
 func (c *Config) GetProtectedPaths() []string {
 	return c.Tools.Sandbox.ProtectedPaths
 }
@@ -1362,6 +1806,17 @@ func TelemetryDir() string {
 	return filepath.Join(home, ConfigDirName, "telemetry")
 }
 
+// ThemeDirs returns the directories user-defined theme files are loaded from:
+// userspace (~/.infer/themes) first, then the project (.infer/themes), so a
+// project theme replaces a userspace one of the same name.
+func ThemeDirs() []string {
+	dirs := make([]string, 0, 2)
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ConfigDirName, ThemesDirName))
+	}
+	return append(dirs, filepath.Join(ConfigDirName, ThemesDirName))
+}
+
 // IsBashCommandAllowed (and the per-mode allow-list resolution) lives in
 // bash_allowedlist.go, alongside the shell-aware clean-command guard (redirection
 // stripping, compound-command splitting, command-substitution rejection) it
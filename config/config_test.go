@@ -1107,3 +1107,140 @@ func TestValidatePathInSandbox_ConfigDirUserspace(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCompactStrategy(t *testing.T) {
+	for _, strategy := range append([]string{""}, CompactStrategies...) {
+		cfg := &Config{}
+		cfg.Compact.Strategy = strategy
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("strategy %q: unexpected error: %v", strategy, err)
+		}
+	}
+
+	cfg := &Config{}
+	cfg.Compact.Strategy = "newest-first"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for unknown compact.strategy")
+	}
+
+	cfg = &Config{}
+	cfg.Compact.KeepLastMessages = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for negative keep_last_messages")
+	}
+}
+
+func TestValidateTokenizerModels(t *testing.T) {
+	cfg := &Config{}
+	cfg.Tokenizer.Models = map[string]string{"qwen": "heuristic", "gpt-4o-mini": "o200k_base"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("known encodings should validate: %v", err)
+	}
+
+	cfg.Tokenizer.Models["llama"] = "llama3"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for an unknown tokenizer encoding")
+	}
+}
+
+func TestToolConcurrencyConfig(t *testing.T) {
+	var unset ToolConcurrencyConfig
+	if unset.EffectiveCPUBound() != DefaultToolConcurrencyCPUBound ||
+		unset.EffectiveNetworkBound() != DefaultToolConcurrencyNetworkBound ||
+		unset.EffectiveMutating() != DefaultToolConcurrencyMutating {
+		t.Errorf("unset limits should fall back to the defaults, got %+v", unset)
+	}
+
+	set := ToolConcurrencyConfig{CPUBound: 2, NetworkBound: 16, Mutating: 1}
+	if set.EffectiveCPUBound() != 2 || set.EffectiveNetworkBound() != 16 || set.EffectiveMutating() != 1 {
+		t.Errorf("configured limits should be kept, got %+v", set)
+	}
+
+	capped := set.CappedAt(1)
+	if capped.EffectiveCPUBound() != 1 || capped.EffectiveNetworkBound() != 1 || capped.EffectiveMutating() != 1 {
+		t.Errorf("max_concurrent_tools: 1 should cap every class at 1, got %+v", capped)
+	}
+	if capped := unset.CappedAt(6); capped.CPUBound != 4 || capped.NetworkBound != 6 || capped.Mutating != 4 {
+		t.Errorf("the cap should only lower limits above it, got %+v", capped)
+	}
+
+	cfg := &Config{}
+	cfg.Agent.ToolConcurrency.Mutating = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for negative agent.tool_concurrency.mutating")
+	}
+}
+
+func TestValidateModelFallbacks(t *testing.T) {
+	cfg := &Config{}
+	cfg.Agent.ModelFallbacks = []string{"anthropic/claude-sonnet-4", "openai/gpt-4o"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("valid fallbacks rejected: %v", err)
+	}
+
+	for _, entry := range []string{"gpt-4o", "openai/", "/gpt-4o"} {
+		cfg.Agent.ModelFallbacks = []string{entry}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected error for agent.model_fallbacks entry %q", entry)
+		}
+	}
+}
+
+func TestValidateReasoningBudget(t *testing.T) {
+	cfg := &Config{}
+	cfg.Agent.ReasoningBudget = 4096
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("valid reasoning budget rejected: %v", err)
+	}
+
+	cfg.Agent.ReasoningBudget = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for a negative agent.reasoning_budget")
+	}
+}
+
+func TestValidateStopSequences(t *testing.T) {
+	cfg := &Config{}
+	cfg.Agent.StopSequences = []string{"</answer>", "\n\nUser:"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("valid stop sequences rejected: %v", err)
+	}
+
+	for _, stop := range [][]string{{"a", "b", "c", "d", "e"}, {"END", ""}} {
+		cfg.Agent.StopSequences = stop
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected error for agent.stop_sequences %q", stop)
+		}
+	}
+}
+
+func TestModelForTask(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.ModelForTask(TaskTitleGeneration, "openai/gpt-4o"); got != "openai/gpt-4o" {
+		t.Errorf("unrouted task should use the fallback, got %q", got)
+	}
+
+	cfg.Agent.Routing = ModelRoutingConfig{
+		Lightweight:   "openai/gpt-4o-mini",
+		ToolSelection: "groq/llama-3.3-70b",
+	}
+	cfg.Git.CommitMessage.Model = "anthropic/claude-haiku-4"
+
+	tests := map[string]string{
+		TaskTitleGeneration: "openai/gpt-4o-mini",
+		TaskSummarization:   "openai/gpt-4o-mini",
+		TaskCommitMessage:   "anthropic/claude-haiku-4",
+		TaskToolSelection:   "groq/llama-3.3-70b",
+		TaskCodeGeneration:  "openai/gpt-4o",
+	}
+	for task, want := range tests {
+		if got := cfg.ModelForTask(task, "openai/gpt-4o"); got != want {
+			t.Errorf("ModelForTask(%q) = %q, want %q", task, got, want)
+		}
+	}
+
+	cfg.Agent.Routing.CodeGeneration = "claude-sonnet-4"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for agent.routing.code_generation without a provider")
+	}
+}
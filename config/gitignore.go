@@ -20,6 +20,8 @@ bin/
 backups/
 tmp/
 plans/
+index/
+jobs/
 `
 
 // EnsureProjectGitignore writes ./.infer/.gitignore if it is absent, creating
@@ -35,7 +35,10 @@ const DefaultHooksYAML = `---
 enabled: false
 
 # Uncomment and adapt. hook is one of: pre_session, pre_stream, post_stream,
-# pre_tool, post_tool, pre_queue_drain, post_queue_drain, post_session.
+# pre_tool, post_tool, pre_queue_drain, post_queue_drain, post_session, or a
+# lifecycle point: session_start, session_end, pre_compact, on_error. Every
+# command receives the session metadata (hook, turn, session_id, model, mode,
+# cwd, and reason/error where known) as JSON on stdin.
 # hooks:
 #   - name: gofmt
 #     hook: post_session
@@ -143,6 +143,7 @@ func TestHooksConfig_Validate(t *testing.T) {
 		{"empty command", hooksCfg(true, hookCmd("gofmt", domain.HookPostSession, "", 30)), true},
 		{"empty hook", hooksCfg(true, hookCmd("gofmt", "", "gofmt -w .", 30)), true},
 		{"unknown hook", hooksCfg(true, hookCmd("gofmt", domain.HookPoint("nope"), "gofmt -w .", 30)), true},
+		{"lifecycle hook", hooksCfg(true, hookCmd("metrics", domain.HookSessionEnd, "./post-metrics.sh", 30)), false},
 		{"negative timeout", hooksCfg(true, hookCmd("gofmt", domain.HookPostSession, "gofmt -w .", -1)), true},
 	}
 	for _, tc := range tests {
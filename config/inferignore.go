@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// Inferignore holds the patterns of a project's .inferignore. The file uses
+// .gitignore syntax and hides paths from every context source (Tree, Grep,
+// @ file autocomplete, the project index) without making them protected paths:
+// the agent can still read or edit them when asked to explicitly.
+type Inferignore struct {
+	root    string
+	matcher *ignore.GitIgnore
+}
+
+// LoadInferignore compiles root/.inferignore. Returns nil when the file is
+// absent or unreadable; a nil *Inferignore excludes nothing.
+func LoadInferignore(root string) *Inferignore {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil
+	}
+	path := filepath.Join(absRoot, InferignoreFileName)
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	matcher, err := ignore.CompileIgnoreFile(path)
+	if err != nil {
+		return nil
+	}
+	return &Inferignore{root: absRoot, matcher: matcher}
+}
+
+// Path returns the location of the loaded .inferignore file
+func (i *Inferignore) Path() string {
+	if i == nil {
+		return ""
+	}
+	return filepath.Join(i.root, InferignoreFileName)
+}
+
+// Excludes reports whether path matches a .inferignore pattern. Relative paths
+// are resolved against the working directory; paths outside the project root
+// never match. A directory pattern such as "vendor/" also matches the
+// directory itself so walkers can prune it.
+func (i *Inferignore) Excludes(path string) bool {
+	if i == nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(i.root, absPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	return i.matcher.MatchesPath(rel) || i.matcher.MatchesPath(rel+"/")
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	require "github.com/stretchr/testify/require"
+)
+
+func TestLoadInferignoreMissingFile(t *testing.T) {
+	ig := LoadInferignore(t.TempDir())
+	require.Nil(t, ig)
+	require.False(t, ig.Excludes("vendor/lib.go"))
+}
+
+func TestInferignoreExcludes(t *testing.T) {
+	dir := chdirTemp(t)
+	require.NoError(t, os.WriteFile(InferignoreFileName, []byte("vendor/\n*.gen.go\n# comment\n"), 0o644))
+
+	ig := LoadInferignore(".")
+	require.NotNil(t, ig)
+
+	require.True(t, ig.Excludes("vendor"))
+	require.True(t, ig.Excludes("vendor/lib/lib.go"))
+	require.True(t, ig.Excludes(filepath.Join(dir, "vendor", "lib.go")))
+	require.True(t, ig.Excludes("api/types.gen.go"))
+	require.False(t, ig.Excludes("main.go"))
+	require.False(t, ig.Excludes("."))
+	require.False(t, ig.Excludes(filepath.Join(filepath.Dir(dir), "vendor")))
+}
@@ -9,9 +9,11 @@ const (
 	DefaultKeybindingsPath = ConfigDirName + "/" + KeybindingsFileName
 )
 
-// KeybindingsConfig contains settings for customizing keybindings
+// KeybindingsConfig contains settings for customizing keybindings. Vim turns
+// on modal (normal/insert) editing in the chat input.
 type KeybindingsConfig struct {
 	Enabled  bool                       `yaml:"enabled" mapstructure:"enabled"`
+	Vim      bool                       `yaml:"vim" mapstructure:"vim"`
 	Bindings map[string]KeyBindingEntry `yaml:"bindings,omitempty" mapstructure:"bindings,omitempty"`
 }
 
@@ -72,9 +74,9 @@ func GetDefaultKeybindings() map[string]KeyBindingEntry {
 
 // DefaultKeybindingActionIDs returns the set of every action ID present in the
 // default keybindings, including the namespace-path actions (chat_focus_attachments,
-// diff_viewer_*, explorer_*) that components resolve directly via ResolveNamespaceBindings
-// and that the runtime key registry never registers. Callers use it to tell a legitimate
-// (if unregistered) action from a typo.
+// chat_focus_todos, chat_focus_queue, diff_viewer_*, explorer_*) that components resolve
+// directly via ResolveNamespaceBindings and that the runtime key registry never registers.
+// Callers use it to tell a legitimate (if unregistered) action from a typo.
 func DefaultKeybindingActionIDs() map[string]struct{} {
 	defaults := GetDefaultKeybindings()
 	ids := make(map[string]struct{}, len(defaults))
@@ -126,12 +128,36 @@ func addChatBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "chat",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceChat, "focus_todos")] = KeyBindingEntry{
+		Keys:        []string{"alt+x"},
+		Description: "edit the todo list by hand (↑/↓ move · space done · shift+↑/↓ reorder · d delete · esc done)",
+		Category:    "chat",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceChat, "focus_queue")] = KeyBindingEntry{
+		Keys:        []string{"alt+q"},
+		Description: "manage queued messages (↑/↓ select · shift+↑/↓ reorder · e edit · p priority · d delete · esc done)",
+		Category:    "chat",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceChat, "undo_send")] = KeyBindingEntry{
+		Keys:        []string{"alt+z"},
+		Description: "undo send: pull the last message back before the model replies",
+		Category:    "chat",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceChat, "retry_with_alternate")] = KeyBindingEntry{
+		Keys:        []string{"alt+y"},
+		Description: "retry a failed turn with the alternate model offered in the error",
+		Category:    "chat",
+		Enabled:     &enabled,
+	}
 }
 
 func addDisplayBindings(bindings map[string]KeyBindingEntry) {
 	enabled := true
 	bindings[ActionID(NamespaceDisplay, "toggle_raw_format")] = KeyBindingEntry{
-		Keys:        []string{"ctrl+r"},
+		Keys:        []string{"alt+r"},
 		Description: "toggle raw/rendered markdown",
 		Category:    "display",
 		Enabled:     &enabled,
@@ -142,12 +168,60 @@ func addDisplayBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "display",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceDisplay, "toggle_pinned_box")] = KeyBindingEntry{
+		Keys:        []string{"alt+p"},
+		Description: "toggle pinned messages",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceDisplay, "toggle_side_panel")] = KeyBindingEntry{
+		Keys:        []string{"alt+s"},
+		Description: "cycle side panel: todos, diff, file, hidden",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceDisplay, "grow_side_panel")] = KeyBindingEntry{
+		Keys:        []string{"alt+="},
+		Description: "widen side panel",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceDisplay, "shrink_side_panel")] = KeyBindingEntry{
+		Keys:        []string{"alt+-"},
+		Description: "narrow side panel",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceDisplay, "toggle_minimap")] = KeyBindingEntry{
+		Keys:        []string{"alt+m"},
+		Description: "toggle conversation minimap",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
 	bindings[ActionID(NamespaceDisplay, "toggle_thinking")] = KeyBindingEntry{
-		Keys:        []string{"ctrl+k"},
+		Keys:        []string{"alt+t"},
 		Description: "expand/collapse thinking blocks",
 		Category:    "display",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceDisplay, "hide_thinking")] = KeyBindingEntry{
+		Keys:        []string{"alt+h"},
+		Description: "show/hide thinking blocks",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceDisplay, "search_conversation")] = KeyBindingEntry{
+		Keys:        []string{"ctrl+f"},
+		Description: "search the conversation",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
+	bindings[ActionID(NamespaceDisplay, "conversation_outline")] = KeyBindingEntry{
+		Keys:        []string{"alt+o"},
+		Description: "jump to a message via the outline",
+		Category:    "display",
+		Enabled:     &enabled,
+	}
 }
 
 func addNavigationBindings(bindings map[string]KeyBindingEntry) {
@@ -270,6 +344,12 @@ func addTextEditingBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "text_editing",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceTextEditing, "open_in_editor")] = KeyBindingEntry{
+		Keys:        []string{"alt+e"},
+		Description: "compose the message in $EDITOR",
+		Category:    "text_editing",
+		Enabled:     &enabled,
+	}
 	bindings[ActionID(NamespaceTextEditing, "history_up")] = KeyBindingEntry{
 		Keys:        []string{"up"},
 		Description: "navigate to previous message in history",
@@ -282,6 +362,12 @@ func addTextEditingBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "text_editing",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceTextEditing, "history_search")] = KeyBindingEntry{
+		Keys:        []string{"ctrl+r"},
+		Description: "reverse search the input history",
+		Category:    "text_editing",
+		Enabled:     &enabled,
+	}
 }
 
 func addClipboardBindings(bindings map[string]KeyBindingEntry) {
@@ -298,6 +384,12 @@ func addClipboardBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "clipboard",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceClipboard, "copy_code_block")] = KeyBindingEntry{
+		Keys:        []string{"alt+c"},
+		Description: "copy a code block from the latest response",
+		Category:    "clipboard",
+		Enabled:     &enabled,
+	}
 }
 
 func addPlanApprovalBindings(bindings map[string]KeyBindingEntry) {
@@ -332,6 +424,12 @@ func addPlanApprovalBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "plan_approval",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespacePlanApproval, "plan_approval_edit")] = KeyBindingEntry{
+		Keys:        []string{"e"},
+		Description: "edit plan in $EDITOR before approving",
+		Category:    "plan_approval",
+		Enabled:     &enabled,
+	}
 }
 
 func addModeBindings(bindings map[string]KeyBindingEntry) {
@@ -368,6 +466,12 @@ func addSelectionBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "selection",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceSelection, "visual_mode")] = KeyBindingEntry{
+		Keys:        []string{"alt+v"},
+		Description: "select conversation lines to copy",
+		Category:    "selection",
+		Enabled:     &enabled,
+	}
 }
 
 func addHelpBindings(bindings map[string]KeyBindingEntry) {
@@ -378,6 +482,12 @@ func addHelpBindings(bindings map[string]KeyBindingEntry) {
 		Category:    "help",
 		Enabled:     &enabled,
 	}
+	bindings[ActionID(NamespaceHelp, "command_palette")] = KeyBindingEntry{
+		Keys:        []string{"ctrl+k"},
+		Description: "open the command palette",
+		Category:    "help",
+		Enabled:     &enabled,
+	}
 }
 
 // addDiffViewerBindings registers the configurable keys for the `/diff` changes
@@ -35,6 +35,12 @@ const (
 	DefaultMemoryGitTimeout       = 60
 )
 
+// Defaults for end-of-session distillation (memory.distill).
+const (
+	DefaultMemoryDistillMinMessages = 6
+	DefaultMemoryDistillTimeout     = 30
+)
+
 // MemoryConfig configures the agent's persistent, cross-session memory. When
 // enabled, durable facts are stored as individual Markdown fact-files under a
 // global directory (~/.infer/memory by default), catalogued by an index file
@@ -51,6 +57,19 @@ type MemoryConfig struct {
 	MaxChars      int                 `yaml:"max_chars" mapstructure:"max_chars"`             // cap on the injected MEMORY.md index
 	MaxEntryChars int                 `yaml:"max_entry_chars" mapstructure:"max_entry_chars"` // cap on a single fact's content at write time; 0 => default
 	Backend       MemoryBackendConfig `yaml:"backend" mapstructure:"backend"`
+	Distill       MemoryDistillConfig `yaml:"distill" mapstructure:"distill"`
+}
+
+// MemoryDistillConfig configures the end-of-session pass that asks a model to
+// distill durable facts and preferences from the conversation and files them
+// through the Memory tool, so memory grows without the agent having to decide
+// to write it mid-task. Its prompt lives at
+// PromptsConfig.Conversation.MemoryDistill in prompts.yaml.
+type MemoryDistillConfig struct {
+	Enabled     bool   `yaml:"enabled" mapstructure:"enabled"`
+	Model       string `yaml:"model,omitempty" mapstructure:"model"`     // "" => the session's model
+	MinMessages int    `yaml:"min_messages" mapstructure:"min_messages"` // shorter sessions are skipped; 0 => default
+	Timeout     int    `yaml:"timeout" mapstructure:"timeout"`           // seconds; 0 => default
 }
 
 // MemoryBackendConfig selects how the memory directory is synced. type: local
@@ -104,6 +123,11 @@ func DefaultMemoryConfig() *MemoryConfig {
 				},
 			},
 		},
+		Distill: MemoryDistillConfig{
+			Enabled:     true,
+			MinMessages: DefaultMemoryDistillMinMessages,
+			Timeout:     DefaultMemoryDistillTimeout,
+		},
 	}
 }
 
@@ -129,6 +153,12 @@ func (m MemoryConfig) Validate() error {
 	if m.MaxEntryChars < 0 {
 		return fmt.Errorf("invalid memory.max_entry_chars %d: must be >= 0 (0 means default %d)", m.MaxEntryChars, DefaultMemoryMaxEntryChars)
 	}
+	if m.Distill.MinMessages < 0 {
+		return fmt.Errorf("invalid memory.distill.min_messages %d: must be >= 0", m.Distill.MinMessages)
+	}
+	if m.Distill.Timeout < 0 {
+		return fmt.Errorf("invalid memory.distill.timeout %d: must be >= 0", m.Distill.Timeout)
+	}
 	return m.Backend.validate(m.Enabled)
 }
 
@@ -142,6 +172,25 @@ func (m MemoryConfig) EffectiveMaxEntryChars() int {
 	return DefaultMemoryMaxEntryChars
 }
 
+// EffectiveMinMessages returns the user/assistant message count below which a
+// session is not distilled, defaulting when unset.
+func (d MemoryDistillConfig) EffectiveMinMessages() int {
+	if d.MinMessages > 0 {
+		return d.MinMessages
+	}
+	return DefaultMemoryDistillMinMessages
+}
+
+// EffectiveTimeout returns the bound on the distillation call, defaulting
+// when unset.
+func (d MemoryDistillConfig) EffectiveTimeout() time.Duration {
+	t := d.Timeout
+	if t <= 0 {
+		t = DefaultMemoryDistillTimeout
+	}
+	return time.Duration(t) * time.Second
+}
+
 func (b MemoryBackendConfig) validate(memoryEnabled bool) error {
 	switch b.Type {
 	case "", MemoryBackendLocal, MemoryBackendGit:
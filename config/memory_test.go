@@ -33,6 +33,8 @@ func TestMemoryConfig_Validate(t *testing.T) {
 		{"git without repo but disabled is valid", config.MemoryConfig{Enabled: false, Backend: config.MemoryBackendConfig{Type: config.MemoryBackendGit}}, false},
 		{"zero max_entry_chars is valid (means default)", config.MemoryConfig{Enabled: true, MaxChars: 100, MaxEntryChars: 0}, false},
 		{"negative max_entry_chars is invalid", config.MemoryConfig{Enabled: true, MaxChars: 100, MaxEntryChars: -1}, true},
+		{"negative distill.min_messages is invalid", config.MemoryConfig{Enabled: true, MaxChars: 100, Distill: config.MemoryDistillConfig{MinMessages: -1}}, true},
+		{"negative distill.timeout is invalid", config.MemoryConfig{Enabled: true, MaxChars: 100, Distill: config.MemoryDistillConfig{Timeout: -1}}, true},
 	}
 
 	for _, tt := range tests {
@@ -60,6 +62,20 @@ func TestMemoryConfig_EffectiveMaxEntryChars(t *testing.T) {
 	}
 }
 
+func TestMemoryDistillConfig_Effective(t *testing.T) {
+	empty := config.MemoryDistillConfig{}
+	if got := empty.EffectiveMinMessages(); got != config.DefaultMemoryDistillMinMessages {
+		t.Errorf("EffectiveMinMessages() = %d, want %d", got, config.DefaultMemoryDistillMinMessages)
+	}
+	if got := empty.EffectiveTimeout(); got != config.DefaultMemoryDistillTimeout*time.Second {
+		t.Errorf("EffectiveTimeout() = %v, want %ds", got, config.DefaultMemoryDistillTimeout)
+	}
+	set := config.MemoryDistillConfig{MinMessages: 2, Timeout: 5}
+	if set.EffectiveMinMessages() != 2 || set.EffectiveTimeout() != 5*time.Second {
+		t.Errorf("explicit values not honored: %d, %v", set.EffectiveMinMessages(), set.EffectiveTimeout())
+	}
+}
+
 func TestMemoryGitConfig_Effective(t *testing.T) {
 	empty := config.MemoryGitConfig{}
 	if got := empty.EffectiveBranch(); got != config.DefaultMemoryGitBranch {
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AgentProfilesConfig holds the named agent personas under agents.profiles
+type AgentProfilesConfig struct {
+	Profiles map[string]AgentProfile `yaml:"profiles,omitempty" mapstructure:"profiles"`
+}
+
+// AgentProfile is a named persona bundling a system prompt, model, sampling
+// parameters, tool allowlist and approval policy, selected with
+// `infer agent --persona` or /persona in chat. Unset fields keep the regular
+// configuration.
+type AgentProfile struct {
+	Description          string         `yaml:"description,omitempty" mapstructure:"description"`
+	SystemPrompt         string         `yaml:"system_prompt,omitempty" mapstructure:"system_prompt"`
+	SystemPromptFragment string         `yaml:"system_prompt_fragment,omitempty" mapstructure:"system_prompt_fragment"` // appended to the system prompt
+	Model                string         `yaml:"model,omitempty" mapstructure:"model"`
+	Sampling             SamplingConfig `yaml:"sampling,omitempty" mapstructure:"sampling"`                     // per-parameter overrides of agent.sampling
+	Tools                []string       `yaml:"tools,omitempty" mapstructure:"tools"`                           // allowlist; empty keeps every enabled tool
+	RequireApproval      *bool          `yaml:"require_approval,omitempty" mapstructure:"require_approval"`     // overrides tools.safety.require_approval
+	ApprovalBehaviour    string         `yaml:"approval_behaviour,omitempty" mapstructure:"approval_behaviour"` // overrides tools.safety.approval_behaviour
+}
+
+// activePersona is the persona in effect and the settings it replaced, so
+// switching personas never compounds and clearing one restores the config
+type activePersona struct {
+	name              string
+	tools             map[string]bool
+	systemPrompt      string
+	model             string
+	sampling          SamplingConfig
+	requireApproval   bool
+	approvalBehaviour string
+}
+
+// PersonaNames returns the configured persona names, sorted
+func (c *Config) PersonaNames() []string {
+	names := make([]string, 0, len(c.Agents.Profiles))
+	for name := range c.Agents.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActivePersona returns the name of the persona in effect, "" for none
+func (c *Config) ActivePersona() string {
+	if c.persona == nil {
+		return ""
+	}
+	return c.persona.name
+}
+
+// ApplyPersona switches to the named persona: its system prompt, model,
+// sampling parameters and approval policy replace the configured ones and its tool allowlist narrows
+// the tools offered to the model. An empty name clears the active persona and
+// restores the configured settings.
+func (c *Config) ApplyPersona(name string) error {
+	profile, ok := c.Agents.Profiles[name]
+	if name != "" && !ok {
+		return fmt.Errorf("unknown persona %q (configured: %v)", name, c.PersonaNames())
+	}
+
+	if c.persona != nil {
+		c.Prompts.Agent.SystemPrompt = c.persona.systemPrompt
+		c.Agent.Model = c.persona.model
+		c.Agent.Sampling = c.persona.sampling
+		c.Tools.Safety.RequireApproval = c.persona.requireApproval
+		c.Tools.Safety.ApprovalBehaviour = c.persona.approvalBehaviour
+		c.persona = nil
+	}
+	if name == "" {
+		return nil
+	}
+
+	c.persona = &activePersona{
+		name:              name,
+		systemPrompt:      c.Prompts.Agent.SystemPrompt,
+		model:             c.Agent.Model,
+		sampling:          c.Agent.Sampling,
+		requireApproval:   c.Tools.Safety.RequireApproval,
+		approvalBehaviour: c.Tools.Safety.ApprovalBehaviour,
+	}
+	if len(profile.Tools) > 0 {
+		c.persona.tools = make(map[string]bool, len(profile.Tools))
+		for _, tool := range profile.Tools {
+			c.persona.tools[tool] = true
+		}
+	}
+	if profile.SystemPrompt != "" {
+		c.Prompts.Agent.SystemPrompt = profile.SystemPrompt
+	}
+	if profile.Model != "" {
+		c.Agent.Model = profile.Model
+	}
+	c.Agent.Sampling = c.Agent.Sampling.Overlay(profile.Sampling)
+	if profile.RequireApproval != nil {
+		c.Tools.Safety.RequireApproval = *profile.RequireApproval
+	}
+	if profile.ApprovalBehaviour != "" {
+		c.Tools.Safety.ApprovalBehaviour = profile.ApprovalBehaviour
+	}
+	return nil
+}
+
+// PersonaAllowsTool reports whether the active persona's tool allowlist
+// permits toolName; always true without a persona or an allowlist
+func (c *Config) PersonaAllowsTool(toolName string) bool {
+	if c.persona == nil || c.persona.tools == nil {
+		return true
+	}
+	return c.persona.tools[toolName]
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyPersona(t *testing.T) {
+	off := false
+	cfg := DefaultConfig()
+	cfg.Agent.Model = "openai/gpt-4o"
+	cfg.Prompts.Agent.SystemPrompt = "base prompt"
+	cfg.Tools.Safety.RequireApproval = true
+	cfg.Agents.Profiles = map[string]AgentProfile{
+		"reviewer":  {SystemPrompt: "You review code.", Model: "anthropic/claude-sonnet", Tools: []string{"Read", "Grep"}, RequireApproval: &off},
+		"architect": {SystemPrompt: "You design systems."},
+	}
+
+	if err := cfg.ApplyPersona("reviewer"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Prompts.Agent.SystemPrompt != "You review code." || cfg.Agent.Model != "anthropic/claude-sonnet" || cfg.Tools.Safety.RequireApproval {
+		t.Errorf("reviewer not applied: prompt=%q model=%q approval=%t", cfg.Prompts.Agent.SystemPrompt, cfg.Agent.Model, cfg.Tools.Safety.RequireApproval)
+	}
+	if !cfg.PersonaAllowsTool("Grep") || cfg.PersonaAllowsTool("Bash") {
+		t.Error("the reviewer's tool allowlist must admit Grep and reject Bash")
+	}
+
+	if err := cfg.ApplyPersona("architect"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Agent.Model != "openai/gpt-4o" || !cfg.Tools.Safety.RequireApproval || !cfg.PersonaAllowsTool("Bash") {
+		t.Error("switching personas must not carry over the previous persona's settings")
+	}
+
+	if err := cfg.ApplyPersona(""); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ActivePersona() != "" || cfg.Prompts.Agent.SystemPrompt != "base prompt" {
+		t.Errorf("clearing must restore the configured prompt, got %q", cfg.Prompts.Agent.SystemPrompt)
+	}
+
+	if err := cfg.ApplyPersona("missing"); err == nil || !strings.Contains(err.Error(), "architect") {
+		t.Errorf("unknown persona error = %v, want it to list the configured ones", err)
+	}
+}
+
+func TestValidatePersonaApprovalBehaviour(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Agents.Profiles = map[string]AgentProfile{"ci": {ApprovalBehaviour: "sometimes"}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "agents.profiles.ci.approval_behaviour") {
+		t.Errorf("Validate() = %v, want an approval_behaviour error", err)
+	}
+}
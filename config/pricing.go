@@ -5,6 +5,35 @@ type PricingConfig struct {
 	Enabled      bool                     `yaml:"enabled" mapstructure:"enabled"`
 	Currency     string                   `yaml:"currency" mapstructure:"currency"`
 	CustomPrices map[string]CustomPricing `yaml:"custom_prices" mapstructure:"custom_prices"`
+	Budget       BudgetConfig             `yaml:"budget" mapstructure:"budget"`
+	// DailyBudget is a hard cap on what all runs spend in a day, totalled in
+	// the storage backend. It joins Budget.PerDay; the lower of the two
+	// applies. 0 means no cap.
+	DailyBudget float64 `yaml:"daily_budget" mapstructure:"daily_budget"`
+}
+
+// DayLimit returns the daily budget in force: the lower of pricing.daily_budget
+// and pricing.budget.per_day that is set, or 0 when neither is
+func (p *PricingConfig) DayLimit() float64 {
+	switch {
+	case p.DailyBudget <= 0:
+		return p.Budget.PerDay
+	case p.Budget.PerDay <= 0:
+		return p.DailyBudget
+	default:
+		return min(p.DailyBudget, p.Budget.PerDay)
+	}
+}
+
+// BudgetConfig caps spending. A limit of 0 means no budget.
+type BudgetConfig struct {
+	// PerSession caps the cost of the current conversation.
+	PerSession float64 `yaml:"per_session" mapstructure:"per_session"`
+	// PerDay caps the cost of all conversations active today.
+	PerDay float64 `yaml:"per_day" mapstructure:"per_day"`
+	// WarnPercent is the share of a budget, in percent, at which the cost
+	// indicator turns yellow. It turns red once a budget is used up.
+	WarnPercent int `yaml:"warn_percent" mapstructure:"warn_percent"`
 }
 
 // CustomPricing allows users to override default pricing for specific models.
@@ -24,5 +53,8 @@ func GetDefaultPricingConfig() PricingConfig {
 		Enabled:      true,
 		Currency:     "USD",
 		CustomPrices: make(map[string]CustomPricing),
+		Budget: BudgetConfig{
+			WarnPercent: 80,
+		},
 	}
 }
@@ -1,6 +1,14 @@
 package config
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
 	utils "github.com/inference-gateway/cli/config/utils"
 )
 
@@ -47,9 +55,15 @@ func mergePromptDefaults(loaded, defaults *PromptsConfig) {
 	if loaded.Git.CommitMessage.SystemPrompt == "" {
 		loaded.Git.CommitMessage.SystemPrompt = defaults.Git.CommitMessage.SystemPrompt
 	}
+	if loaded.Git.Review.SystemPrompt == "" {
+		loaded.Git.Review.SystemPrompt = defaults.Git.Review.SystemPrompt
+	}
 	if loaded.Conversation.TitleGeneration.SystemPrompt == "" {
 		loaded.Conversation.TitleGeneration.SystemPrompt = defaults.Conversation.TitleGeneration.SystemPrompt
 	}
+	if loaded.Conversation.MemoryDistill.SystemPrompt == "" {
+		loaded.Conversation.MemoryDistill.SystemPrompt = defaults.Conversation.MemoryDistill.SystemPrompt
+	}
 	if loaded.Init.Prompt == "" {
 		loaded.Init.Prompt = defaults.Init.Prompt
 	}
@@ -71,8 +85,10 @@ func mergeToolDefaults(loaded, defaults *PromptsToolsConfig) {
 	mergeToolDescription(&loaded.Delete, &defaults.Delete)
 	mergeToolDescription(&loaded.Grep, &defaults.Grep)
 	mergeToolDescription(&loaded.Tree, &defaults.Tree)
+	mergeToolDescription(&loaded.ProjectSearch, &defaults.ProjectSearch)
 	mergeToolDescription(&loaded.TodoWrite, &defaults.TodoWrite)
 	mergeToolDescription(&loaded.RequestPlanApproval, &defaults.RequestPlanApproval)
+	mergeToolDescription(&loaded.UpdatePlanStep, &defaults.UpdatePlanStep)
 	mergeToolDescription(&loaded.AskUserQuestion, &defaults.AskUserQuestion)
 	mergeToolDescription(&loaded.WebFetch, &defaults.WebFetch)
 	mergeToolDescription(&loaded.WebSearch, &defaults.WebSearch)
@@ -110,6 +126,82 @@ func SavePrompts(path string, cfg *PromptsConfig) error {
 	return utils.SaveYAML(path, "prompts", cfg)
 }
 
+// LoadPromptTemplates reads the templates list of the prompts.yaml at path
+// as written, without the env expansion LoadPrompts applies, so it can be
+// edited and passed back to SavePromptTemplates. A missing file has none.
+func LoadPromptTemplates(path string) ([]PromptTemplate, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompts config: %w", err)
+	}
+
+	var file struct {
+		Templates []PromptTemplate `yaml:"templates"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse prompts config: %w", err)
+	}
+	return file.Templates, nil
+}
+
+// SavePromptTemplates replaces the templates list of the prompts.yaml at
+// path and leaves every other key as written. SavePrompts is not used
+// because it would bake the env-expanded, default-backfilled prompts into
+// the file.
+func SavePromptTemplates(path string, templates []PromptTemplate) error {
+	doc := yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read prompts config: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse prompts config: %w", err)
+		}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse prompts config: %s is not a mapping", path)
+	}
+
+	var value yaml.Node
+	if err := value.Encode(templates); err != nil {
+		return fmt.Errorf("failed to marshal prompt templates: %w", err)
+	}
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "templates" {
+			root.Content[i+1] = &value
+			replaced = true
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "templates"}, &value)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to marshal prompts config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to close prompts encoder: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create prompts config directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write prompts config: %w", err)
+	}
+	return nil
+}
+
 // PromptsConfig holds every customisable LLM prompt the CLI ships with.
 // It mirrors the nested key structure those prompts had when they lived
 // under .infer/config.yaml so users can move existing values verbatim.
@@ -119,6 +211,32 @@ type PromptsConfig struct {
 	Conversation PromptsConversationConfig `yaml:"conversation" mapstructure:"conversation"`
 	Init         PromptsInitConfig         `yaml:"init" mapstructure:"init"`
 	Tools        PromptsToolsConfig        `yaml:"tools" mapstructure:"tools"`
+	Templates    []PromptTemplate          `yaml:"templates,omitempty" mapstructure:"templates"`
+	// ConversationTemplates seed new chat sessions; see ConversationTemplate
+	ConversationTemplates []ConversationTemplate `yaml:"conversation_templates,omitempty" mapstructure:"conversation_templates"`
+}
+
+// PromptTemplate is a reusable prompt managed with `infer prompts` and
+// inserted in chat with /prompt. Prompt may reference {{variables}} that
+// are filled in when the template is used.
+type PromptTemplate struct {
+	Name        string `yaml:"name" mapstructure:"name"`
+	Description string `yaml:"description,omitempty" mapstructure:"description"`
+	Prompt      string `yaml:"prompt" mapstructure:"prompt"`
+}
+
+// ConversationTemplate seeds a new chat session, started with
+// `infer chat --template <name>` or /template: Context is kept in the
+// conversation as system context for the model, Message is put in the input
+// with Files attached as @references, and Model is selected. Context and
+// Message may reference {{variables}} like a PromptTemplate.
+type ConversationTemplate struct {
+	Name        string   `yaml:"name" mapstructure:"name"`
+	Description string   `yaml:"description,omitempty" mapstructure:"description"`
+	Context     string   `yaml:"context,omitempty" mapstructure:"context"`
+	Message     string   `yaml:"message,omitempty" mapstructure:"message"`
+	Files       []string `yaml:"files,omitempty" mapstructure:"files"`
+	Model       string   `yaml:"model,omitempty" mapstructure:"model"`
 }
 
 type PromptsAgentConfig struct {
@@ -128,24 +246,86 @@ type PromptsAgentConfig struct {
 	SystemPromptRemote    string `yaml:"system_prompt_remote" mapstructure:"system_prompt_remote"`
 	SystemPromptHeartbeat string `yaml:"system_prompt_heartbeat" mapstructure:"system_prompt_heartbeat"`
 	CustomInstructions    string `yaml:"custom_instructions" mapstructure:"custom_instructions"`
+
+	// SystemPromptFragment is appended to the system prompt. In
+	// ~/.infer/prompts.yaml it is the org layer, which still applies when the
+	// project has its own prompts.yaml; see Config.SystemPromptLayers.
+	SystemPromptFragment string `yaml:"system_prompt_fragment,omitempty" mapstructure:"system_prompt_fragment"`
+	// ModeFragments are appended in the agent mode they are keyed by:
+	// standard, plan, auto or readonly
+	ModeFragments map[string]string `yaml:"mode_fragments,omitempty" mapstructure:"mode_fragments"`
+	// OrgSystemPromptFragment is the system_prompt_fragment of
+	// ~/.infer/prompts.yaml, resolved when the prompts are loaded
+	OrgSystemPromptFragment string `yaml:"-" mapstructure:"-"`
+}
+
+// PromptLayer is one fragment of the layered system prompt
+type PromptLayer struct {
+	Name   string // org, project, persona or mode
+	Source string // where the fragment is configured
+	Text   string
+}
+
+// SystemPromptLayers returns the fragments appended to the base system prompt
+// in mode (a mode_fragments key), in order: org (~/.infer/prompts.yaml),
+// project (.infer/prompts.yaml), the active persona, then the agent mode.
+// Empty fragments are left out.
+func (c *Config) SystemPromptLayers(mode string) []PromptLayer {
+	agent := c.Prompts.Agent
+	candidates := []PromptLayer{
+		{Name: "org", Source: "~/" + DefaultPromptsPath + " agent.system_prompt_fragment", Text: agent.OrgSystemPromptFragment},
+		{Name: "project", Source: DefaultPromptsPath + " agent.system_prompt_fragment", Text: agent.SystemPromptFragment},
+	}
+	if persona := c.ActivePersona(); persona != "" {
+		candidates = append(candidates, PromptLayer{
+			Name:   "persona",
+			Source: "agents.profiles." + persona + ".system_prompt_fragment",
+			Text:   c.Agents.Profiles[persona].SystemPromptFragment,
+		})
+	}
+	candidates = append(candidates, PromptLayer{
+		Name:   "mode",
+		Source: PromptsFileName + " agent.mode_fragments." + mode,
+		Text:   agent.ModeFragments[mode],
+	})
+
+	layers := make([]PromptLayer, 0, len(candidates))
+	for _, layer := range candidates {
+		if layer.Text = strings.TrimSpace(layer.Text); layer.Text != "" {
+			layers = append(layers, layer)
+		}
+	}
+	return layers
 }
 
 type PromptsGitConfig struct {
 	CommitMessage PromptsGitCommitMessageConfig `yaml:"commit_message" mapstructure:"commit_message"`
+	Review        PromptsGitReviewConfig        `yaml:"review" mapstructure:"review"`
 }
 
 type PromptsGitCommitMessageConfig struct {
 	SystemPrompt string `yaml:"system_prompt" mapstructure:"system_prompt"`
 }
 
+type PromptsGitReviewConfig struct {
+	SystemPrompt string `yaml:"system_prompt" mapstructure:"system_prompt"`
+}
+
 type PromptsConversationConfig struct {
-	TitleGeneration PromptsConversationTitleConfig `yaml:"title_generation" mapstructure:"title_generation"`
+	TitleGeneration PromptsConversationTitleConfig   `yaml:"title_generation" mapstructure:"title_generation"`
+	MemoryDistill   PromptsConversationDistillConfig `yaml:"memory_distill" mapstructure:"memory_distill"`
 }
 
 type PromptsConversationTitleConfig struct {
 	SystemPrompt string `yaml:"system_prompt" mapstructure:"system_prompt"`
 }
 
+// PromptsConversationDistillConfig holds the prompt that turns a finished
+// session into memory facts (memory.distill in memory.yaml).
+type PromptsConversationDistillConfig struct {
+	SystemPrompt string `yaml:"system_prompt" mapstructure:"system_prompt"`
+}
+
 type PromptsInitConfig struct {
 	Prompt string `yaml:"prompt" mapstructure:"prompt"`
 }
@@ -177,8 +357,10 @@ type PromptsToolsConfig struct {
 	Delete              PromptsToolDescription `yaml:"Delete" mapstructure:"Delete"`
 	Grep                PromptsToolDescription `yaml:"Grep" mapstructure:"Grep"`
 	Tree                PromptsToolDescription `yaml:"Tree" mapstructure:"Tree"`
+	ProjectSearch       PromptsToolDescription `yaml:"ProjectSearch" mapstructure:"ProjectSearch"`
 	TodoWrite           PromptsToolDescription `yaml:"TodoWrite" mapstructure:"TodoWrite"`
 	RequestPlanApproval PromptsToolDescription `yaml:"RequestPlanApproval" mapstructure:"RequestPlanApproval"`
+	UpdatePlanStep      PromptsToolDescription `yaml:"UpdatePlanStep" mapstructure:"UpdatePlanStep"`
 	AskUserQuestion     PromptsToolDescription `yaml:"AskUserQuestion" mapstructure:"AskUserQuestion"`
 	WebFetch            PromptsToolDescription `yaml:"WebFetch" mapstructure:"WebFetch"`
 	WebSearch           PromptsToolDescription `yaml:"WebSearch" mapstructure:"WebSearch"`
@@ -337,6 +519,22 @@ EXAMPLES:
 
 Respond with ONLY the commit message, no quotes or explanation.`,
 			},
+			Review: PromptsGitReviewConfig{
+				SystemPrompt: `You review a git diff before it is committed or pushed. Look for bugs, security issues,
+leaked secrets, data loss, broken error handling and leftover debug code. Ignore style and
+formatting nits.
+
+Report every finding on its own line in exactly this format:
+[SEVERITY] path/to/file:line - description
+
+SEVERITY is one of:
+- CRITICAL: security holes, secrets or credentials, data loss, code that cannot build
+- HIGH: bugs that break existing behaviour
+- MEDIUM: likely bugs, missing error handling, risky edge cases
+- LOW: minor issues worth a follow-up
+
+If there is nothing to report, respond with exactly: LGTM`,
+			},
 		},
 		Conversation: PromptsConversationConfig{
 			TitleGeneration: PromptsConversationTitleConfig{
@@ -357,6 +555,23 @@ EXAMPLES:
 
 Respond with ONLY the title, no quotes or explanation.`,
 			},
+			MemoryDistill: PromptsConversationDistillConfig{
+				SystemPrompt: `You maintain a persistent memory of durable facts for a coding agent. Read the session transcript and extract only what will still be useful in future sessions:
+- user: the user's role, expertise and preferences
+- feedback: corrections or confirmed ways of working the user asked for, with the reason
+- project: goals, constraints or decisions not recorded in the code or its history
+- reference: pointers to external resources (URLs, dashboards, tickets)
+
+RULES:
+- Skip anything that only matters to this session, can be read from the code or git history, or is already in the memory index below.
+- To update an existing fact, reuse its exact name from the index.
+- Each fact is one short Markdown paragraph; convert relative dates to absolute ones.
+- Return at most 5 facts. Most sessions warrant none.
+
+Respond with ONLY a JSON array, no code fences or explanation:
+[{"name": "kebab-case-slug", "type": "user|feedback|project|reference", "description": "one-line summary", "content": "the fact"}]
+Respond with [] when nothing is worth remembering.`,
+			},
 		},
 		Init: PromptsInitConfig{
 			Prompt: `Generate an AGENTS.md at the project root following the open standard at https://agents.md.
@@ -481,6 +696,9 @@ If you want to create a new file, use:
 		Tree: PromptsToolDescription{
 			Description: `Display directory structure in a tree format, similar to the Unix tree command. Use format "compact" for a token-efficient one-directory-per-line listing (root-first, git-tracked non-ignored files only) when you just need to see where files live.`,
 		},
+		ProjectSearch: PromptsToolDescription{
+			Description: `Find the project files most related to a topic, ranked from the project index (built by "infer index"). Matches words and identifiers in file paths, symbol names and content, not meaning, so phrase the query with the terms the code likely uses (e.g. "refresh oauth token"). Use it to find where to start when you don't know the file or symbol names; use Grep for exact text.`,
+		},
 		TodoWrite: PromptsToolDescription{
 			Description: `Use this tool to create and manage a structured task list for your current coding session. This helps you track progress, organize complex tasks, and demonstrate thoroughness to the user.
 It also helps the user understand the progress of the task and overall progress of their requests.
@@ -551,7 +769,19 @@ Required parameters:
 - title: A short human-readable phrase (≤ 60 chars, no slashes). Becomes the H1 heading and the filename slug.
 - plan: The full plan as Markdown using H2 sections in this order - ## Context, ## Files to Modify, ## Current Code, ## Changes, ## Performance Impact, ## Critical Files, ## Edge Cases, ## Verification. Omit any section that is not applicable.
 
+Write the concrete steps under ## Changes (and ## Verification) as a numbered list (1., 2., ...): once the plan is approved those items become a checklist the user watches you work through.
+
 Only call this tool when the plan is final. If you need clarification, ask the user in a normal assistant turn first.`,
+		},
+		UpdatePlanStep: PromptsToolDescription{
+			Description: `Mark progress on the numbered steps of the approved plan.
+
+After a plan is approved its top-level numbered list items are tracked as a checklist shown to the user. Call this tool with the step number and:
+- in_progress when you start working on a step
+- completed as soon as the step is done
+- pending to reopen a step you need to revisit
+
+Steps are numbered 1..N in the order they appear in the plan. Keep the checklist current so the user sees real progress; it fails when no approved plan is being tracked.`,
 		},
 		AskUserQuestion: PromptsToolDescription{
 			Description: `Ask the user 1-4 multiple-choice clarifying questions as an interactive form (plan mode only).
@@ -574,7 +804,7 @@ Notes:
 			Description: `Fetch content from allowed URLs. Set download=true to save the file to disk automatically. Useful for downloading A2A task artifacts or other files.`,
 		},
 		WebSearch: PromptsToolDescription{
-			Description: `Search the web using Google or DuckDuckGo search engines`,
+			Description: `Search the web using Google or DuckDuckGo search engines. When fetch_top is available, prefer it over separate WebFetch calls: it fetches the top results (allowed domains only) and returns a short summary of each page in one call.`,
 		},
 		Schedule: PromptsToolDescription{
 			Description: `Schedule a task that fires on a cron schedule and delivers its output through the same messaging channel that triggered the current session (e.g. Telegram).
@@ -606,7 +836,7 @@ The scheduler runs inside the 'infer channels-manager' daemon. Jobs only fire wh
 		Agent: PromptsToolDescription{
 			Description: `Spawn local subagents - each an autonomous "infer agent" subprocess with its own isolated session - to run work in parallel and fold their results back into this conversation. Use this to fan out independent tasks (research, edits across separate areas, parallel investigations) without standing up an A2A agent server.
 
-Provide either 'tasks' (an array of {description, label?, model?, system_prompt?, type?} objects) to run several subagents at once, or 'description' for a single subagent. Give a subagent a specialized role/persona by setting its system_prompt (each subagent can have its own).
+Provide either 'tasks' (an array of {description, label?, model?, system_prompt?, type?, tools?, max_turns?} objects) to run several subagents at once, or 'description' for a single subagent. Give a subagent a specialized role/persona by setting its system_prompt (each subagent can have its own). Narrow a subagent to just the tools its subtask needs with 'tools' (e.g. ["Read", "Grep"]) and bound its effort with 'max_turns'; only its final report comes back to you.
 
 Choose each subagent's capability with 'type': ReadOnly (DEFAULT) is Explore-like - read/search tools only, never needs approval - use it for investigation, research, and reading code. ReadWrite can modify files and run commands; its mutations require approval. Prefer ReadOnly unless the task must change something.
 
@@ -22,7 +22,9 @@ func TestDefaultPromptsConfig_AllPromptsPopulated(t *testing.T) {
 		"agent.system_prompt_remote":                  cfg.Agent.SystemPromptRemote,
 		"agent.system_prompt_heartbeat":               cfg.Agent.SystemPromptHeartbeat,
 		"git.commit_message.system_prompt":            cfg.Git.CommitMessage.SystemPrompt,
+		"git.review.system_prompt":                    cfg.Git.Review.SystemPrompt,
 		"conversation.title_generation.system_prompt": cfg.Conversation.TitleGeneration.SystemPrompt,
+		"conversation.memory_distill.system_prompt":   cfg.Conversation.MemoryDistill.SystemPrompt,
 		"init.prompt":                                 cfg.Init.Prompt,
 		"tools.Bash.description":                      cfg.Tools.Bash.Description,
 		"tools.BashOutput.description":                cfg.Tools.BashOutput.Description,
@@ -316,3 +318,77 @@ func TestSavePrompts(t *testing.T) {
 		})
 	}
 }
+
+func TestSavePromptTemplates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".infer", "prompts.yaml")
+	templates := []config.PromptTemplate{{Name: "migration", Description: "Plan a migration", Prompt: "Write a migration plan for {{file}}"}}
+
+	if err := config.SavePromptTemplates(path, templates); err != nil {
+		t.Fatalf("SavePromptTemplates() on a missing file failed: %v", err)
+	}
+	loaded, err := config.LoadPrompts(path)
+	if err != nil {
+		t.Fatalf("LoadPrompts() failed: %v", err)
+	}
+	if len(loaded.Templates) != 1 || loaded.Templates[0] != templates[0] {
+		t.Errorf("templates not round-tripped, got %+v", loaded.Templates)
+	}
+
+	original := "agent:\n  system_prompt: \"uses $${HOME} literally\"\ntemplates:\n  - name: old\n    prompt: old\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SavePromptTemplates(path, templates); err != nil {
+		t.Fatalf("SavePromptTemplates() on an existing file failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+	if !strings.Contains(saved, "$${HOME}") {
+		t.Errorf("other keys must be kept as written, got:\n%s", saved)
+	}
+	if strings.Contains(saved, "name: old") || !strings.Contains(saved, "name: migration") {
+		t.Errorf("templates not replaced, got:\n%s", saved)
+	}
+	if strings.Contains(saved, "commit_message") {
+		t.Errorf("defaults must not be written back, got:\n%s", saved)
+	}
+
+	raw, err := config.LoadPromptTemplates(path)
+	if err != nil || len(raw) != 1 || raw[0] != templates[0] {
+		t.Errorf("LoadPromptTemplates() = %+v, %v", raw, err)
+	}
+	if none, err := config.LoadPromptTemplates(filepath.Join(t.TempDir(), "missing.yaml")); err != nil || none != nil {
+		t.Errorf("a missing file must have no templates, got %+v, %v", none, err)
+	}
+}
+
+func TestSystemPromptLayers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Prompts.Agent.OrgSystemPromptFragment = "org rules"
+	cfg.Prompts.Agent.SystemPromptFragment = "  project rules\n"
+	cfg.Prompts.Agent.ModeFragments = map[string]string{"plan": "plan rules"}
+	cfg.Agents.Profiles = map[string]config.AgentProfile{"reviewer": {SystemPromptFragment: "reviewer rules"}}
+
+	names := func(layers []config.PromptLayer) []string {
+		var out []string
+		for _, layer := range layers {
+			out = append(out, layer.Name+"="+layer.Text)
+		}
+		return out
+	}
+
+	if got := strings.Join(names(cfg.SystemPromptLayers("standard")), ","); got != "org=org rules,project=project rules" {
+		t.Errorf("standard layers = %s", got)
+	}
+
+	if err := cfg.ApplyPersona("reviewer"); err != nil {
+		t.Fatal(err)
+	}
+	want := "org=org rules,project=project rules,persona=reviewer rules,mode=plan rules"
+	if got := strings.Join(names(cfg.SystemPromptLayers("plan")), ","); got != want {
+		t.Errorf("plan layers with a persona = %s, want %s", got, want)
+	}
+}
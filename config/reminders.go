@@ -4,7 +4,6 @@ import (
 	"cmp"
 	"fmt"
 	"maps"
-	"os"
 	"slices"
 	"strings"
 
@@ -12,6 +11,7 @@ import (
 
 	utils "github.com/inference-gateway/cli/config/utils"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 )
 
 const (
@@ -176,7 +176,7 @@ func LoadReminders(path string) (*RemindersConfig, error) {
 // expanded, mirroring the file loader (LoadYAML); the result is validated by the
 // caller through Config.Validate.
 func ParseReminders(data []byte) (*RemindersConfig, error) {
-	expanded := os.ExpandEnv(string(data))
+	expanded := keyring.ExpandEnv(string(data))
 	cfg := new(RemindersConfig)
 	if err := yaml.Unmarshal([]byte(expanded), cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse reminders config: %w", err)
@@ -322,6 +322,8 @@ func (r RemindersConfig) Validate() error {
 			return fmt.Errorf("reminders[%d] (%s): text is required", i, rc.Name)
 		case rc.Hook != "" && !rc.Hook.Valid():
 			return fmt.Errorf("reminders[%d] (%s): unknown hook %q (valid: %v)", i, rc.Name, rc.Hook, domain.HookPoints)
+		case rc.Hook.Lifecycle():
+			return fmt.Errorf("reminders[%d] (%s): hook %s takes command hooks only (hooks.yaml)", i, rc.Name, rc.Hook)
 		case rc.Trigger != "" && !rc.Trigger.Valid():
 			return fmt.Errorf("reminders[%d] (%s): unknown trigger %q (valid: %v)", i, rc.Name, rc.Trigger, ReminderTriggers)
 		case rc.Trigger == ReminderTriggerOnFailure && rc.Hook != domain.HookPostTool:
@@ -404,6 +404,7 @@ func TestReminders_Validate(t *testing.T) {
 		{"missing name", config.ReminderConfig{Text: "t", Hook: domain.HookPreStream}, true},
 		{"missing text", config.ReminderConfig{Name: "a", Hook: domain.HookPreStream}, true},
 		{"unknown hook", config.ReminderConfig{Name: "a", Text: "t", Hook: domain.HookPoint("not_a_hook")}, true},
+		{"lifecycle hook is command-only", config.ReminderConfig{Name: "a", Text: "t", Hook: domain.HookSessionStart}, true},
 		{"unknown trigger", config.ReminderConfig{Name: "a", Text: "t", Trigger: config.ReminderTrigger("nope")}, true},
 		{"turns_before_max needs threshold", config.ReminderConfig{Name: "a", Text: "t", Trigger: config.ReminderTriggerTurnsBeforeMax}, true},
 		{"negative interval", config.ReminderConfig{Name: "a", Text: "t", Trigger: config.ReminderTriggerInterval, Interval: -1}, true},
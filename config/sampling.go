@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SamplingParams lists the parameter names /model params accepts, in the
+// order they are displayed
+var SamplingParams = []string{"temperature", "top_p", "frequency_penalty", "presence_penalty", "seed"}
+
+// Validate checks each set parameter against the range the chat completions
+// API accepts; field prefixes the error, e.g. "agent.sampling"
+func (s SamplingConfig) Validate(field string) error {
+	for _, rule := range []struct {
+		name   string
+		value  *float32
+		lo, hi float32
+	}{
+		{"temperature", s.Temperature, 0, 2},
+		{"top_p", s.TopP, 0, 1},
+		{"frequency_penalty", s.FrequencyPenalty, -2, 2},
+		{"presence_penalty", s.PresencePenalty, -2, 2},
+	} {
+		if rule.value != nil && (*rule.value < rule.lo || *rule.value > rule.hi) {
+			return fmt.Errorf("invalid %s.%s %v: must be between %v and %v", field, rule.name, *rule.value, rule.lo, rule.hi)
+		}
+	}
+	return nil
+}
+
+// Overlay returns s with the parameters set in override replacing its own
+func (s SamplingConfig) Overlay(override SamplingConfig) SamplingConfig {
+	if override.Temperature != nil {
+		s.Temperature = override.Temperature
+	}
+	if override.TopP != nil {
+		s.TopP = override.TopP
+	}
+	if override.FrequencyPenalty != nil {
+		s.FrequencyPenalty = override.FrequencyPenalty
+	}
+	if override.PresencePenalty != nil {
+		s.PresencePenalty = override.PresencePenalty
+	}
+	if override.Seed != nil {
+		s.Seed = override.Seed
+	}
+	return s
+}
+
+// Set parses value for the named parameter; "default" unsets it so the
+// provider's default applies again. The result is validated.
+func (s *SamplingConfig) Set(name, value string) error {
+	if !slices.Contains(SamplingParams, name) {
+		return fmt.Errorf("unknown sampling parameter %q (known: %s)", name, strings.Join(SamplingParams, ", "))
+	}
+
+	next := *s
+	if name == "seed" {
+		next.Seed = nil
+		if value != "default" {
+			seed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid seed %q: must be an integer", value)
+			}
+			next.Seed = &seed
+		}
+		*s = next
+		return nil
+	}
+
+	var v *float32
+	if value != "default" {
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: must be a number", name, value)
+		}
+		f32 := float32(f)
+		v = &f32
+	}
+	switch name {
+	case "temperature":
+		next.Temperature = v
+	case "top_p":
+		next.TopP = v
+	case "frequency_penalty":
+		next.FrequencyPenalty = v
+	case "presence_penalty":
+		next.PresencePenalty = v
+	}
+	if err := next.Validate("sampling"); err != nil {
+		return err
+	}
+	*s = next
+	return nil
+}
+
+// Get formats the named parameter's value, "" when unset
+func (s SamplingConfig) Get(name string) string {
+	formatFloat := func(v *float32) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(float64(*v), 'g', -1, 32)
+	}
+	switch name {
+	case "temperature":
+		return formatFloat(s.Temperature)
+	case "top_p":
+		return formatFloat(s.TopP)
+	case "frequency_penalty":
+		return formatFloat(s.FrequencyPenalty)
+	case "presence_penalty":
+		return formatFloat(s.PresencePenalty)
+	case "seed":
+		if s.Seed == nil {
+			return ""
+		}
+		return strconv.Itoa(*s.Seed)
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSamplingConfigSet(t *testing.T) {
+	var s SamplingConfig
+	for _, pair := range [][2]string{{"temperature", "0.2"}, {"top_p", "0.95"}, {"presence_penalty", "-0.5"}, {"seed", "42"}} {
+		if err := s.Set(pair[0], pair[1]); err != nil {
+			t.Fatalf("Set(%s, %s): %v", pair[0], pair[1], err)
+		}
+	}
+	for name, want := range map[string]string{"temperature": "0.2", "top_p": "0.95", "presence_penalty": "-0.5", "seed": "42", "frequency_penalty": ""} {
+		if got := s.Get(name); got != want {
+			t.Errorf("Get(%s) = %q, want %q", name, got, want)
+		}
+	}
+
+	for _, pair := range [][2]string{{"temperature", "2.5"}, {"top_p", "high"}, {"seed", "1.5"}, {"top_k", "40"}} {
+		before := s
+		if err := s.Set(pair[0], pair[1]); err == nil {
+			t.Errorf("Set(%s, %s) should fail", pair[0], pair[1])
+		}
+		if s != before {
+			t.Errorf("a failed Set(%s, %s) changed the config", pair[0], pair[1])
+		}
+	}
+
+	if err := s.Set("seed", "default"); err != nil || s.Seed != nil {
+		t.Errorf("seed=default should unset it, got %v (err %v)", s.Seed, err)
+	}
+}
+
+func TestApplyPersonaSampling(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := cfg.Agent.Sampling.Set("temperature", "0.7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Agent.Sampling.Set("seed", "1"); err != nil {
+		t.Fatal(err)
+	}
+	var coder SamplingConfig
+	if err := coder.Set("temperature", "0"); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Agents.Profiles = map[string]AgentProfile{"coder": {Sampling: coder}}
+
+	if err := cfg.ApplyPersona("coder"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Agent.Sampling.Get("temperature") != "0" || cfg.Agent.Sampling.Get("seed") != "1" {
+		t.Errorf("persona sampling must override only what it sets, got temperature=%s seed=%s",
+			cfg.Agent.Sampling.Get("temperature"), cfg.Agent.Sampling.Get("seed"))
+	}
+
+	if err := cfg.ApplyPersona(""); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Agent.Sampling.Get("temperature") != "0.7" {
+		t.Errorf("clearing the persona must restore agent.sampling, got temperature=%s", cfg.Agent.Sampling.Get("temperature"))
+	}
+}
+
+func TestValidateSampling(t *testing.T) {
+	cfg := DefaultConfig()
+	top := float32(1.5)
+	cfg.Agents.Profiles = map[string]AgentProfile{"wild": {Sampling: SamplingConfig{TopP: &top}}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "agents.profiles.wild.sampling.top_p") {
+		t.Errorf("Validate() = %v, want a top_p range error", err)
+	}
+}
@@ -10,13 +10,15 @@ import (
 	"path/filepath"
 
 	yaml "gopkg.in/yaml.v3"
+
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 )
 
 // LoadYAML reads path. If the file does not exist, defaults() is returned so
 // callers can treat absence as "use defaults" without special-casing. The
-// file body is run through os.ExpandEnv so ${VAR} references resolve from
-// the environment before unmarshalling - any future content that needs a
-// literal `${…}` token must escape it as `$$…`.
+// file body is run through keyring.ExpandEnv so ${VAR} references resolve
+// from the environment, or the keyring, before unmarshalling - any future
+// content that needs a literal `${…}` token must escape it as `$$…`.
 //
 // label scopes error messages, e.g. "channels" produces
 // "failed to read channels config: …".
@@ -30,7 +32,7 @@ func LoadYAML[T any](path, label string, defaults func() *T) (*T, error) {
 		return nil, fmt.Errorf("failed to read %s config: %w", label, err)
 	}
 
-	expanded := os.ExpandEnv(string(data))
+	expanded := keyring.ExpandEnv(string(data))
 
 	cfg := new(T)
 	if err := yaml.Unmarshal([]byte(expanded), cfg); err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,7 +17,7 @@ import (
 	formatting "github.com/inference-gateway/cli/internal/formatting"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	services "github.com/inference-gateway/cli/internal/services"
-	plugins "github.com/inference-gateway/cli/internal/services/plugins"
+	toolscheduler "github.com/inference-gateway/cli/internal/services/toolscheduler"
 	telemetry "github.com/inference-gateway/cli/internal/telemetry"
 )
 
@@ -33,6 +34,7 @@ type AgentServiceImpl struct {
 	timeoutSeconds   int
 	maxTokens        int
 	reasoningEffort  *sdk.CreateChatCompletionRequestReasoningEffort
+	reasoningBudget  int
 	optimizer        domain.ConversationOptimizer
 	tokenizer        *services.TokenizerService
 	approvalPolicy   domain.ApprovalPolicy
@@ -41,6 +43,7 @@ type AgentServiceImpl struct {
 	hookProvider     domain.HookCommandProvider
 	memoryBackend    domain.MemoryBackend
 	recorder         *telemetry.Recorder
+	budgetTracker    domain.BudgetTracker
 
 	// Reminder cadence is session-scoped, not per-request. sessionTurns counts
 	// cumulative model turns across the whole chat session so an `interval`
@@ -68,6 +71,11 @@ type AgentServiceImpl struct {
 	toolCallsMap map[string]*sdk.ChatCompletionMessageToolCall
 	toolCallsMux sync.RWMutex
 
+	// Per-hunk reviews received with an approval, keyed by tool call ID and
+	// consumed by executeToolInternal when the approved call runs.
+	hunkReviews   map[string]domain.HunkReview
+	hunkReviewMux sync.Mutex
+
 	// Context caching
 	gitContextCache    string
 	gitContextTurn     int
@@ -198,6 +206,19 @@ func (p *eventPublisher) publishChatChunk(content, reasoningContent string, tool
 	}
 }
 
+// publishToolCallDraft publishes a ToolCallUpdateEvent carrying the arguments
+// generated so far for a tool call that is still streaming
+func (p *eventPublisher) publishToolCallDraft(callID, toolName, arguments string) {
+	p.chatEvents <- domain.ToolCallUpdateEvent{
+		RequestID:  p.requestID,
+		Timestamp:  time.Now(),
+		ToolCallID: callID,
+		ToolName:   toolName,
+		Arguments:  arguments,
+		Status:     domain.ToolCallStreamStatusStreaming,
+	}
+}
+
 // publishOptimizationStatus publishes an OptimizationStatusEvent
 func (p *eventPublisher) publishOptimizationStatus(message string, isActive bool, originalCount, optimizedCount int) {
 	p.chatEvents <- domain.OptimizationStatusEvent{
@@ -264,6 +285,48 @@ func (p *eventPublisher) publishBashOutputChunk(callID string, output string, is
 	}
 }
 
+// publishToolProgress publishes a progress report from a running tool as a
+// ToolExecutionProgressEvent. Like bash output, reports are dropped rather
+// than blocking the tool when the channel is full.
+func (p *eventPublisher) publishToolProgress(callID string, toolName string, progress domain.ToolProgress) {
+	event := domain.ToolExecutionProgressEvent{
+		BaseChatEvent: domain.BaseChatEvent{
+			RequestID: p.requestID,
+			Timestamp: time.Now(),
+		},
+		ToolCallID: callID,
+		ToolName:   toolName,
+		Status:     "running",
+		Message:    progress.Step,
+		Progress:   &progress,
+	}
+
+	select {
+	case p.chatEvents <- event:
+	default:
+		logger.Warn("tool progress dropped - channel full")
+	}
+}
+
+// throttledToolProgress wraps a progress callback so a tool reporting on
+// every read or test result reaches the UI at most once per
+// ToolProgressThrottle. The final 100% report is always delivered.
+func throttledToolProgress(callback domain.ToolProgressCallback) domain.ToolProgressCallback {
+	var mu sync.Mutex
+	var last time.Time
+	return func(progress domain.ToolProgress) {
+		mu.Lock()
+		now := time.Now()
+		if progress.Percent < 100 && now.Sub(last) < constants.ToolProgressThrottle {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+		callback(progress)
+	}
+}
+
 // publishTodoUpdate publishes a TodoUpdateChatEvent when TodoWrite tool executes
 func (p *eventPublisher) publishTodoUpdate(todos []domain.TodoItem) {
 	event := domain.TodoUpdateChatEvent{
@@ -359,11 +422,6 @@ func NewAgent(
 
 	approvalPolicy := services.NewStandardApprovalPolicy(cfg, stateManager)
 
-	hookProvider := domain.HookCommandProvider(cfg.Hooks)
-	if pluginProvider := plugins.NewPluginHookCommandProvider(cfg); pluginProvider != nil {
-		hookProvider = pluginProvider
-	}
-
 	return &AgentServiceImpl{
 		client:           client,
 		toolService:      toolService,
@@ -376,12 +434,13 @@ func NewAgent(
 		timeoutSeconds:   timeoutSeconds,
 		maxTokens:        cfg.GetAgentConfig().MaxTokens,
 		reasoningEffort:  reasoningEffortOption(cfg.GetAgentConfig().ReasoningEffort),
+		reasoningBudget:  cfg.GetAgentConfig().ReasoningBudget,
 		optimizer:        optimizer,
 		tokenizer:        tokenizer,
 		approvalPolicy:   approvalPolicy,
 		bgRegistry:       bgRegistry,
 		reminderProvider: cfg.Reminders,
-		hookProvider:     hookProvider,
+		hookProvider:     HookProvider(cfg),
 		firedReminders:   make(map[string]bool),
 		activeSessions:   make(map[string]*sessionCancel),
 		metrics:          make(map[string]*domain.ChatMetrics),
@@ -399,12 +458,67 @@ func reasoningEffortOption(effort string) *sdk.CreateChatCompletionRequestReason
 	return &e
 }
 
+// requestOptions returns the options sent with each chat request. Sampling
+// parameters are read per request, so /model params and /persona apply from
+// the next one.
+func (s *AgentServiceImpl) requestOptions(responseFormat *sdk.CreateChatCompletionRequest_ResponseFormat) *sdk.CreateChatCompletionRequest {
+	opts := &sdk.CreateChatCompletionRequest{
+		MaxTokens:       &s.maxTokens,
+		ReasoningEffort: s.reasoningEffort,
+		ResponseFormat:  responseFormat,
+	}
+	if s.config != nil {
+		sampling := s.config.Agent.Sampling
+		opts.Temperature = sampling.Temperature
+		opts.TopP = sampling.TopP
+		opts.FrequencyPenalty = sampling.FrequencyPenalty
+		opts.PresencePenalty = sampling.PresencePenalty
+		opts.Seed = sampling.Seed
+		opts.Stop = stopOption(s.config.Agent.StopSequences)
+	}
+	return opts
+}
+
+// stopOption maps agent.stop_sequences (validated in Config.Validate) to the
+// SDK's optional request field.
+func stopOption(sequences []string) *sdk.CreateChatCompletionRequest_Stop {
+	if len(sequences) == 0 {
+		return nil
+	}
+	var stop sdk.CreateChatCompletionRequest_Stop
+	if err := stop.FromCreateChatCompletionRequestStop1(sequences); err != nil {
+		return nil
+	}
+	return &stop
+}
+
+// reasoningBudgetHeader carries agent.reasoning_budget, which the chat
+// completion request has no field for, for the gateway to map onto the
+// provider's thinking token budget
+const reasoningBudgetHeader = "X-Reasoning-Budget-Tokens"
+
+// withReasoningBudget forwards agent.reasoning_budget on client's requests
+// when one is set.
+func (s *AgentServiceImpl) withReasoningBudget(client sdk.Client) sdk.Client {
+	if s.reasoningBudget <= 0 {
+		return client
+	}
+	return client.WithHeader(reasoningBudgetHeader, strconv.Itoa(s.reasoningBudget))
+}
+
 // SetTelemetryRecorder wires the telemetry recorder so per-request token usage
 // is tapped in storeIterationMetrics. A nil recorder disables recording.
 func (s *AgentServiceImpl) SetTelemetryRecorder(rec *telemetry.Recorder) {
 	s.recorder = rec
 }
 
+// SetBudgetTracker wires the pricing budgets checked before each request of
+// an agent run, and the daily total each request's cost is recorded in. A nil
+// tracker disables both.
+func (s *AgentServiceImpl) SetBudgetTracker(tracker domain.BudgetTracker) {
+	s.budgetTracker = tracker
+}
+
 // SetMemoryBackend wires the memory sync backend so the chat agent pulls memory
 // once at session start (SyncIn on HookPreSession). SyncOut is driven by the
 // Memory tool on write/delete, not here - chat fires HookPostSession after every
@@ -447,25 +561,22 @@ func (s *AgentServiceImpl) Run(ctx context.Context, req *domain.AgentRequest) (*
 
 		providerType := sdk.Provider(provider)
 
-		client := s.client.WithOptions(&sdk.CreateChatCompletionRequest{
-			MaxTokens:       &s.maxTokens,
-			ReasoningEffort: s.reasoningEffort,
-		}).
+		client := s.withReasoningBudget(s.client.WithOptions(s.requestOptions(req.ResponseFormat)).
 			WithMiddlewareOptions(&sdk.MiddlewareOptions{
 				SkipMCP: true,
-			})
+			}))
 		if s.toolService != nil {
 			mode := domain.AgentModeStandard
 			if s.stateManager != nil {
 				mode = s.stateManager.GetAgentMode()
 			}
-			availableTools = s.toolService.ListToolsForMode(mode)
+			availableTools = onlyNamedTools(s.toolService.ListToolsForMode(mode), req.Tools)
 			if len(availableTools) > 0 {
 				client = s.client.WithTools(&availableTools)
 			}
 		}
 
-		response, err := client.GenerateContent(timeoutCtx, providerType, modelName, messages)
+		response, err := s.generateContentWaitingOutRateLimits(timeoutCtx, client, providerType, modelName, s.withPromptCache(provider, messages))
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate content: %w", err)
 		}
@@ -500,6 +611,32 @@ func (s *AgentServiceImpl) Run(ctx context.Context, req *domain.AgentRequest) (*
 	return syncResponse, nil
 }
 
+// generateContentWaitingOutRateLimits is GenerateContent retried after rate
+// limits, waiting as long as the provider asks (see waitOutRateLimit)
+func (s *AgentServiceImpl) generateContentWaitingOutRateLimits(ctx context.Context, client sdk.Client, provider sdk.Provider, model string, messages []sdk.Message) (*sdk.CreateChatCompletionResponse, error) {
+	if s.config == nil {
+		return client.GenerateContent(ctx, provider, model, messages)
+	}
+	retryCfg := s.config.Client.Retry
+	for retries := 0; ; retries++ {
+		response, err := client.GenerateContent(ctx, provider, model, messages)
+		if err == nil || !retryCfg.Enabled || !isRateLimitError(err) || retries >= retryCfg.MaxAttempts {
+			return response, err
+		}
+		delay, ok := rateLimitDelay(err, retryCfg, retryBackoff(retryCfg, retries))
+		if !ok {
+			return nil, err
+		}
+		logger.Warn("rate limited, retrying", "model", model, "attempt", retries+1, "delay", delay.String(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+	}
+}
+
 // extractFirstChoice pulls content, reasoning, and tool calls from the first
 // choice of a non-streaming response. Reasoning preference matches the
 // streaming path in agent_streaming.go.
@@ -807,6 +944,7 @@ func (s *AgentServiceImpl) storeIterationMetrics(
 		s.conversationRepo.AddCachedTokens(cached)
 	}
 
+	spentBefore := s.conversationRepo.GetSessionCostStats().TotalCost
 	if err := s.conversationRepo.AddTokenUsage(
 		model,
 		int(effectiveUsage.PromptTokens),
@@ -816,6 +954,9 @@ func (s *AgentServiceImpl) storeIterationMetrics(
 	); err != nil {
 		logger.Error("failed to add token usage to session", "error", err)
 	}
+	if s.budgetTracker != nil {
+		s.budgetTracker.RecordSpend(s.conversationRepo.GetSessionCostStats().TotalCost - spentBefore)
+	}
 
 	if s.recorder != nil {
 		s.recorder.RecordUsage(model, int(effectiveUsage.PromptTokens), int(effectiveUsage.CompletionTokens), cached)
@@ -842,11 +983,6 @@ func (s *AgentServiceImpl) optimizeConversation(_ context.Context, req *domain.A
 	return conversation
 }
 
-type IndexedToolResult struct {
-	Index  int
-	Result domain.ConversationEntry
-}
-
 func (s *AgentServiceImpl) executeToolCallsParallel( // nolint:funlen
 	ctx context.Context,
 	toolCalls []*sdk.ChatCompletionMessageToolCall,
@@ -896,6 +1032,11 @@ func (s *AgentServiceImpl) executeToolCallsParallel( // nolint:funlen
 
 	for _, at := range approvalTools {
 
+		if s.hasInterrupt() {
+			results[at.index] = s.skipToolCall(*at.tool, eventPublisher)
+			continue
+		}
+
 		time.Sleep(constants.AgentToolExecutionDelay)
 
 		result := s.executeTool(ctx, *at.tool, eventPublisher, isChatMode)
@@ -903,21 +1044,14 @@ func (s *AgentServiceImpl) executeToolCallsParallel( // nolint:funlen
 	}
 
 	if len(parallelTools) > 0 {
-		resultsChan := make(chan IndexedToolResult, len(parallelTools))
-		semaphore := make(chan struct{}, s.config.GetAgentConfig().MaxConcurrentTools)
-
-		var wg sync.WaitGroup
+		batch := toolscheduler.NewBatch(s.config.GetAgentConfig().ToolConcurrency)
 		for _, pt := range parallelTools {
-			wg.Add(1)
-			go func(index int, toolCall *sdk.ChatCompletionMessageToolCall) {
-				defer func() {
-					wg.Done()
-				}()
-
-				semaphore <- struct{}{}
-				defer func() {
-					<-semaphore
-				}()
+			index, toolCall := pt.index, pt.tool
+			batch.Go(*toolCall, func() {
+				if s.hasInterrupt() {
+					results[index] = s.skipToolCall(*toolCall, eventPublisher)
+					return
+				}
 
 				eventPublisher.publishToolStatusChange(
 					toolCall.ID,
@@ -928,23 +1062,10 @@ func (s *AgentServiceImpl) executeToolCallsParallel( // nolint:funlen
 
 				time.Sleep(constants.AgentToolExecutionDelay)
 
-				result := s.executeTool(ctx, *toolCall, eventPublisher, isChatMode)
-
-				resultsChan <- IndexedToolResult{
-					Index:  index,
-					Result: result,
-				}
-			}(pt.index, pt.tool)
-		}
-
-		go func() {
-			wg.Wait()
-			close(resultsChan)
-		}()
-
-		for res := range resultsChan {
-			results[res.Index] = res.Result
+				results[index] = s.executeTool(ctx, *toolCall, eventPublisher, isChatMode)
+			})
 		}
+		batch.Wait()
 	}
 
 	if err := s.batchSaveToolResults(results); err != nil {
@@ -1013,6 +1134,15 @@ func (s *AgentServiceImpl) executeToolInternal(
 
 	time.Sleep(constants.AgentToolExecutionDelay)
 
+	review, reviewed := s.takeHunkReview(tc.ID)
+	if reviewed {
+		if review.Arguments == "" {
+			s.conversationRepo.RemovePendingToolCallByID(tc.ID)
+			return s.createHunkRejectionEntry(tc, review, startTime)
+		}
+		tc.Function.Arguments = review.Arguments
+	}
+
 	if !isCompleteJSON(tc.Function.Arguments) {
 		incompleteErr := fmt.Errorf(
 			"TOOL FAILED: %s - content was truncated due to output token limits (received %d chars of incomplete JSON). %s",
@@ -1071,6 +1201,12 @@ func (s *AgentServiceImpl) executeToolInternal(
 		execCtx = domain.WithBashDetachChannel(execCtx, detachChan)
 	}
 
+	var lastProgress atomic.Pointer[domain.ToolProgress]
+	execCtx = domain.WithToolProgressCallback(execCtx, throttledToolProgress(func(progress domain.ToolProgress) {
+		lastProgress.Store(&progress)
+		eventPublisher.publishToolProgress(tc.ID, tc.Function.Name, progress)
+	}))
+
 	if tc.Function.Name == "AskUserQuestion" && domain.GetChatHandler(ctx) != nil {
 		execCtx = domain.WithUserQuestionBroker(execCtx, &chatQuestionBroker{publisher: eventPublisher})
 	}
@@ -1103,7 +1239,11 @@ func (s *AgentServiceImpl) executeToolInternal(
 			ticker.Stop()
 			resultReceived = true
 		case <-ticker.C:
-			eventPublisher.publishToolStatusChange(tc.ID, tc.Function.Name, "running", "Processing...", nil)
+			if progress := lastProgress.Load(); progress != nil {
+				eventPublisher.publishToolProgress(tc.ID, tc.Function.Name, *progress)
+			} else {
+				eventPublisher.publishToolStatusChange(tc.ID, tc.Function.Name, "running", "Processing...", nil)
+			}
 		case <-ctx.Done():
 			logger.Error("tool execution cancelled", "tool", tc.Function.Name)
 			return s.createErrorEntry(tc, ctx.Err(), startTime)
@@ -1144,6 +1284,9 @@ func (s *AgentServiceImpl) executeToolInternal(
 	}
 
 	formattedContent := s.conversationRepo.FormatToolResultForLLM(toolExecutionResult)
+	if reviewed {
+		formattedContent += "\n\n" + review.Feedback()
+	}
 
 	entry := domain.ConversationEntry{
 		Message: domain.Message{
@@ -1358,12 +1501,14 @@ func (s *AgentServiceImpl) requestToolApproval(
 	eventPublisher *eventPublisher,
 ) (bool, error) {
 	responseChan := make(chan domain.ApprovalAction, 1)
+	hunkReviewChan := make(chan domain.HunkReview, 1)
 
 	eventPublisher.chatEvents <- domain.ToolApprovalRequestedEvent{
-		RequestID:    eventPublisher.requestID,
-		Timestamp:    time.Now(),
-		ToolCall:     tc,
-		ResponseChan: responseChan,
+		RequestID:      eventPublisher.requestID,
+		Timestamp:      time.Now(),
+		ToolCall:       tc,
+		ResponseChan:   responseChan,
+		HunkReviewChan: hunkReviewChan,
 	}
 
 	var approved bool
@@ -1376,6 +1521,9 @@ func (s *AgentServiceImpl) requestToolApproval(
 			s.stateManager.SetAgentMode(domain.AgentModeAutoAccept)
 		}
 		approved = response == domain.ApprovalApprove || response == domain.ApprovalAutoAccept
+		if approved {
+			s.storeHunkReview(tc.ID, hunkReviewChan)
+		}
 	case <-ctx.Done():
 		err = fmt.Errorf("approval request cancelled: %w", ctx.Err())
 	case <-time.After(constants.ApprovalTimeout):
@@ -1389,6 +1537,46 @@ func (s *AgentServiceImpl) requestToolApproval(
 	return approved, err
 }
 
+// storeHunkReview keeps the per-hunk review sent with an approval, if any, for
+// executeToolInternal. The coordinator sends the review before the decision,
+// so it is already buffered by the time the approval is received.
+func (s *AgentServiceImpl) storeHunkReview(toolCallID string, hunkReviewChan chan domain.HunkReview) {
+	select {
+	case review := <-hunkReviewChan:
+		s.hunkReviewMux.Lock()
+		defer s.hunkReviewMux.Unlock()
+		if s.hunkReviews == nil {
+			s.hunkReviews = make(map[string]domain.HunkReview)
+		}
+		s.hunkReviews[toolCallID] = review
+	default:
+	}
+}
+
+// takeHunkReview returns and forgets the per-hunk review for a tool call.
+func (s *AgentServiceImpl) takeHunkReview(toolCallID string) (domain.HunkReview, bool) {
+	s.hunkReviewMux.Lock()
+	defer s.hunkReviewMux.Unlock()
+
+	review, ok := s.hunkReviews[toolCallID]
+	if ok {
+		delete(s.hunkReviews, toolCallID)
+	}
+	return review, ok
+}
+
+// createHunkRejectionEntry is the rejection entry for a call whose hunks were
+// all rejected during review. It carries the review so the model learns why
+// each hunk was declined.
+func (s *AgentServiceImpl) createHunkRejectionEntry(tc sdk.ChatCompletionMessageToolCall, review domain.HunkReview, startTime time.Time) domain.ConversationEntry {
+	entry := s.createRejectionEntry(tc, startTime)
+	entry.Message.Content = sdk.NewMessageContent(fmt.Sprintf(
+		"Tool call rejected by user: %s\n\n%s\n\nYou can provide alternative instructions or ask me to proceed differently.",
+		tc.Function.Name, review.Feedback(),
+	))
+	return entry
+}
+
 func (s *AgentServiceImpl) createErrorEntry(tc sdk.ChatCompletionMessageToolCall, err error, startTime time.Time) domain.ConversationEntry {
 	return domain.ConversationEntry{
 		Message: domain.Message{
@@ -1436,6 +1624,39 @@ func (s *AgentServiceImpl) createRejectionEntry(tc sdk.ChatCompletionMessageTool
 	}
 }
 
+// hasInterrupt reports whether the user queued a message to handle after the
+// current tool call, in which case tool calls that haven't started are skipped
+func (s *AgentServiceImpl) hasInterrupt() bool {
+	return s.messageQueue != nil && s.messageQueue.HasInterrupt()
+}
+
+// skipToolCall returns the result of a tool call skipped for an interrupt
+// message. Unlike a rejection it doesn't end the run: the model gets the
+// queued message next and decides whether to call the tool again.
+func (s *AgentServiceImpl) skipToolCall(tc sdk.ChatCompletionMessageToolCall, eventPublisher *eventPublisher) domain.ConversationEntry {
+	eventPublisher.publishToolStatusChange(tc.ID, tc.Function.Name, "failed", "skipped", nil)
+
+	var args map[string]any
+	if err := json.Unmarshal([]byte(tc.Function.Arguments), &args); err != nil {
+		args = make(map[string]any)
+	}
+
+	return domain.ConversationEntry{
+		Message: domain.Message{
+			Role:       sdk.Tool,
+			Content:    sdk.NewMessageContent(fmt.Sprintf("Tool call skipped: %s was not run because the user sent a message to handle first.", tc.Function.Name)),
+			ToolCallID: &tc.ID,
+		},
+		Time: time.Now(),
+		ToolExecution: &domain.ToolExecutionResult{
+			ToolName:  tc.Function.Name,
+			Arguments: args,
+			Success:   false,
+			Error:     "skipped for a queued message",
+		},
+	}
+}
+
 func (s *AgentServiceImpl) batchSaveToolResults(entries []domain.ConversationEntry) error {
 	savedCount := 0
 	for _, entry := range entries {
@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"fmt"
+	"slices"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+const budgetContinueLabel = "Continue"
+
+// checkBudget runs before each request of an agent run. When the next request
+// is projected to take spending past a budget - the previous turn's cost stands
+// in for the next one's - or a budget is already used up, chat mode pauses the
+// run and asks the user whether to keep going. Declining, or a headless run
+// with nobody to ask, stops the run with an error. Budgets are the run's
+// agent.max_cost_per_run and the pricing session and daily budgets. It returns
+// false when the run was stopped.
+func (a *EventDrivenAgent) checkBudget() bool {
+	tracker := a.service.budgetTracker
+	if tracker == nil {
+		return true
+	}
+
+	status := tracker.BudgetStatus()
+	if a.agentCtx.Turns <= 1 {
+		a.budgetSpentAtRunStart = status.SessionSpent
+		a.budgetSpentAtTurn = status.SessionSpent
+	}
+	lastTurnCost := status.SessionSpent - a.budgetSpentAtTurn
+	a.budgetSpentAtTurn = status.SessionSpent
+
+	status.RunSpent = status.SessionSpent - a.budgetSpentAtRunStart
+	if a.cfg != nil {
+		status.RunLimit = a.cfg.MaxCostPerRun
+	}
+	for _, budget := range a.budgetsOverridden {
+		status = withoutBudget(status, budget)
+	}
+	if !status.HasBudget() {
+		return true
+	}
+
+	budget := status.Exceeds(lastTurnCost)
+	if budget == "" {
+		return true
+	}
+	spent, limit := status.Spend(budget)
+
+	logger.Info("agent run reached a budget",
+		"budget", budget,
+		"spent", spent,
+		"limit", limit,
+		"projected_turn_cost", lastTurnCost)
+
+	if a.req.IsChatMode && a.confirmOverBudget(budget, spent, limit) {
+		a.budgetsOverridden = append(a.budgetsOverridden, budget)
+		return true
+	}
+	if a.agentCtx.Ctx.Err() != nil {
+		return false
+	}
+
+	a.failStream(fmt.Errorf("%s budget of $%.2f reached ($%.2f spent), agent run stopped", budget, limit, spent))
+	return false
+}
+
+// withoutBudget drops the limit of budget from status, once the user chose to
+// run past it
+func withoutBudget(status domain.BudgetStatus, budget string) domain.BudgetStatus {
+	switch budget {
+	case "run":
+		status.RunLimit = 0
+	case "session":
+		status.SessionLimit = 0
+	case "daily":
+		status.DayLimit = 0
+	}
+	return status
+}
+
+// confirmOverBudget asks the user, through the question form, whether to run
+// past the budget. Dismissing the form counts as no.
+func (a *EventDrivenAgent) confirmOverBudget(budget string, spent, limit float64) bool {
+	broker := &chatQuestionBroker{publisher: a.eventPublisher}
+	answers, ok, err := broker.AskUserQuestions(a.agentCtx.Ctx, []domain.UserQuestion{{
+		Header:   "Budget",
+		Question: fmt.Sprintf("The next request may exceed the %s budget of $%.2f ($%.2f spent so far). Keep the agent running?", budget, limit, spent),
+		Options: []domain.UserQuestionOption{
+			{Label: budgetContinueLabel, Description: fmt.Sprintf("Run past the %s budget until this run ends", budget)},
+			{Label: "Stop", Description: "End the agent run now"},
+		},
+	}})
+	if err != nil || !ok || len(answers) == 0 {
+		return false
+	}
+	return slices.Contains(answers[0].SelectedLabels, budgetContinueLabel)
+}
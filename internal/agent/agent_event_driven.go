@@ -12,6 +12,7 @@ import (
 	constants "github.com/inference-gateway/cli/internal/constants"
 	domain "github.com/inference-gateway/cli/internal/domain"
 	logger "github.com/inference-gateway/cli/internal/logger"
+	toolscheduler "github.com/inference-gateway/cli/internal/services/toolscheduler"
 )
 
 // EventDrivenAgent manages agent execution using event-driven state machine
@@ -57,6 +58,23 @@ type EventDrivenAgent struct {
 	// to every outbound request payload but never to *agentCtx.Conversation,
 	// so it is neither persisted nor rendered (see volatileTailMessage).
 	volatileTail []sdk.Message
+
+	// Budget check: the session spend when the run and the previous turn
+	// started, so the run's cost is known and the cost of one turn can be
+	// projected onto the next, and the budgets the user chose to run past for
+	// the rest of this run.
+	budgetSpentAtRunStart float64
+	budgetSpentAtTurn     float64
+	budgetsOverridden     []string
+
+	// failure is the error that moved the run to StateError, handed to the
+	// on_error hooks when the event loop exits
+	failure error
+
+	// Model selection: the model agent.routing picked for the current turn,
+	// and how many agent.model_fallbacks entries the turn has failed over past
+	turnModel     string
+	fallbackIndex int
 }
 
 // NewEventDrivenAgent creates a new event-driven agent
@@ -130,9 +148,11 @@ func (a *EventDrivenAgent) registerStateHandlers() {
 		BackgroundTaskRegistry: a.registry,
 		Provider:               a.provider,
 		Model:                  a.model,
-		MaxConcurrentTools:     a.cfg.MaxConcurrentTools,
-		ToolExecutor:           &a.toolExecutor,
-		StartStreaming:         a.startStreaming,
+		NewToolBatch: func() domain.ToolBatch {
+			return toolscheduler.NewBatch(a.cfg.ToolConcurrency)
+		},
+		ToolExecutor:   &a.toolExecutor,
+		StartStreaming: a.startStreaming,
 
 		GetMetrics: a.service.GetMetrics,
 		ShouldRequireApproval: func(toolCall *sdk.ChatCompletionMessageToolCall, isChatMode bool) bool {
@@ -165,6 +185,9 @@ func (a *EventDrivenAgent) registerStateHandlers() {
 			return a.service.stateManager.GetAgentMode()
 		},
 		PublishChatEvent: func(event domain.ChatEvent) {
+			if errEvent, ok := event.(domain.ChatErrorEvent); ok {
+				a.failure = errEvent.Error
+			}
 			a.eventPublisher.chatEvents <- event
 		},
 		PublishChatComplete: func(reasoning string, toolCalls []sdk.ChatCompletionMessageToolCall, metrics *domain.ChatMetrics) {
@@ -250,9 +273,12 @@ func (a *EventDrivenAgent) processEvents() {
 			a.handleEvent(event)
 
 			currentState := a.stateMachine.GetCurrentState()
+			if currentState == domain.StateError {
+				a.runErrorHooks()
+				return
+			}
 			if currentState == domain.StateStopped ||
-				currentState == domain.StateCancelled ||
-				currentState == domain.StateError {
+				currentState == domain.StateCancelled {
 				return
 			}
 
@@ -265,6 +291,24 @@ func (a *EventDrivenAgent) processEvents() {
 	}
 }
 
+// runErrorHooks fires the on_error lifecycle hooks once the run has stopped
+// on an unrecoverable error.
+func (a *EventDrivenAgent) runErrorHooks() {
+	payload := domain.HookPayload{
+		Hook:     domain.HookOnError,
+		Turn:     a.agentCtx.Turns,
+		Model:    a.req.Model,
+		Messages: len(*a.agentCtx.Conversation),
+	}
+	if a.agentCtx.Ctx != nil {
+		payload.SessionID = domain.GetSessionID(a.agentCtx.Ctx)
+	}
+	if a.failure != nil {
+		payload.Error = a.failure.Error()
+	}
+	a.service.RunLifecycleHooks(context.Background(), payload)
+}
+
 // handleEvent processes a single event based on current state using the state handler registry
 func (a *EventDrivenAgent) handleEvent(event domain.AgentEvent) {
 	a.mu.Lock()
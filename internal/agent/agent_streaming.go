@@ -10,6 +10,7 @@ import (
 
 	sdk "github.com/inference-gateway/sdk"
 
+	config "github.com/inference-gateway/cli/config"
 	constants "github.com/inference-gateway/cli/internal/constants"
 	domain "github.com/inference-gateway/cli/internal/domain"
 	logger "github.com/inference-gateway/cli/internal/logger"
@@ -38,6 +39,12 @@ func (a *EventDrivenAgent) startStreaming() {
 		time.Sleep(constants.AgentIterationDelay)
 	}
 
+	a.selectTurnModel()
+
+	if !a.checkBudget() {
+		return
+	}
+
 	a.eventPublisher.publishChatStart()
 
 	if a.agentCtx.Turns == 1 {
@@ -49,20 +56,19 @@ func (a *EventDrivenAgent) startStreaming() {
 	if a.service.stateManager != nil {
 		mode = a.service.stateManager.GetAgentMode()
 	}
-	a.availableTools = a.service.toolService.ListToolsForMode(mode)
+	a.availableTools = onlyNamedTools(a.service.toolService.ListToolsForMode(mode), a.req.Tools)
 
+	options := a.service.requestOptions(a.req.ResponseFormat)
+	options.StreamOptions = &sdk.ChatCompletionStreamOptions{
+		IncludeUsage: true,
+	}
 	client := a.service.client.
-		WithOptions(&sdk.CreateChatCompletionRequest{
-			MaxTokens:       &a.service.maxTokens,
-			ReasoningEffort: a.service.reasoningEffort,
-			StreamOptions: &sdk.ChatCompletionStreamOptions{
-				IncludeUsage: true,
-			},
-		}).
+		WithOptions(options).
 		WithMiddlewareOptions(&sdk.MiddlewareOptions{
 			SkipMCP: true,
 		})
 
+	client = a.service.withReasoningBudget(client)
 	if len(a.availableTools) > 0 {
 		client = client.WithTools(&a.availableTools)
 	}
@@ -74,14 +80,28 @@ func (a *EventDrivenAgent) startStreaming() {
 		maxReconnects = retryCfg.MaxAttempts
 	}
 
+	rateLimitRetries := 0
 	for attempt := 0; ; attempt++ {
-		if !a.streamOnce(client, iterationStartTime) {
+		broken, err := a.streamOnce(client, iterationStartTime)
+		if !broken && err == nil {
 			return
 		}
 
-		if attempt >= maxReconnects {
-			a.failStream(fmt.Errorf("connection lost: stream stalled after %d reconnect attempts", maxReconnects))
-			return
+		if err == nil && attempt >= maxReconnects {
+			err = fmt.Errorf("connection lost: stream stalled after %d reconnect attempts", maxReconnects)
+		}
+		if err != nil {
+			if a.waitOutRateLimit(err, &rateLimitRetries, maxReconnects) {
+				attempt = -1
+				continue
+			}
+			if !a.failoverModel(err) {
+				a.failStream(err)
+				return
+			}
+			attempt, rateLimitRetries = -1, 0
+			a.eventPublisher.publishChatStart()
+			continue
 		}
 
 		if a.service.stateManager != nil {
@@ -97,15 +117,16 @@ func (a *EventDrivenAgent) startStreaming() {
 	}
 }
 
-// streamOnce runs a single streaming request end to end. It returns true when
-// the stream broke mid-flight (stalled or transport error) and the caller
-// should reconnect, false when the turn finished - successfully, cancelled, or
-// with a non-recoverable error that has already been published.
-func (a *EventDrivenAgent) streamOnce(client sdk.Client, iterationStartTime time.Time) bool {
+// streamOnce runs a single streaming request end to end. It returns broken
+// when the stream broke mid-flight (stalled or transport error) and the caller
+// should reconnect, and a non-nil error when the request failed or timed out
+// before finishing - not yet published, so the caller can fail over to the
+// next model first. Both are zero when the turn finished or was cancelled.
+func (a *EventDrivenAgent) streamOnce(client sdk.Client, iterationStartTime time.Time) (bool, error) {
 	requestCtx, requestCancel := context.WithTimeout(a.agentCtx.Ctx, time.Duration(a.service.timeoutSeconds)*time.Second)
 	defer requestCancel()
 
-	requestCtx, turnSpan := a.service.recorder.StartLLMTurnSpan(requestCtx, a.req.Model)
+	requestCtx, turnSpan := a.service.recorder.StartLLMTurnSpan(requestCtx, a.activeModel())
 	defer turnSpan.End()
 
 	events, err := a.openStream(requestCtx, requestCancel, client)
@@ -114,7 +135,7 @@ func (a *EventDrivenAgent) streamOnce(client sdk.Client, iterationStartTime time
 			logger.Warn("stream connect stalled, reconnecting",
 				"request_id", a.req.RequestID,
 				"turn", a.agentCtx.Turns)
-			return true
+			return true, nil
 		}
 		logger.Error("failed to create stream",
 			"error", err,
@@ -122,12 +143,10 @@ func (a *EventDrivenAgent) streamOnce(client sdk.Client, iterationStartTime time
 			"conversationLength", len(*a.agentCtx.Conversation),
 			"provider", a.provider)
 		telemetry.SetSpanError(requestCtx, err)
-		a.failStream(err)
-		return false
+		return false, err
 	}
 
-	broken := a.processStreamEvents(requestCtx, events, iterationStartTime)
-	return broken
+	return a.processStreamEvents(requestCtx, events, iterationStartTime)
 }
 
 // openStream issues the streaming request bounded by the stall threshold: a
@@ -135,7 +154,7 @@ func (a *EventDrivenAgent) streamOnce(client sdk.Client, iterationStartTime time
 // a dead network for the ~75s OS timeout) is cancelled and reported as
 // errConnectStalled so the reconnect loop counts it like any other stall.
 func (a *EventDrivenAgent) openStream(requestCtx context.Context, cancel context.CancelFunc, client sdk.Client) (<-chan sdk.SSEvent, error) {
-	conversation := a.outboundConversation()
+	conversation := a.service.withPromptCache(a.provider, a.outboundConversation())
 
 	stallAfter := time.Duration(a.service.config.Client.StallThresholdSec) * time.Second
 	if stallAfter <= 0 {
@@ -181,6 +200,7 @@ func (a *EventDrivenAgent) outboundConversation() []sdk.Message {
 // failStream publishes a terminal stream error and moves the state machine to
 // StateError.
 func (a *EventDrivenAgent) failStream(err error) {
+	a.failure = err
 	a.eventPublisher.chatEvents <- domain.ChatErrorEvent{
 		RequestID: a.req.RequestID,
 		Timestamp: time.Now(),
@@ -192,10 +212,57 @@ func (a *EventDrivenAgent) failStream(err error) {
 	a.events <- domain.MessageReceivedEvent{}
 }
 
+// waitOutRateLimit waits before retrying a request the active model refused
+// with a rate limit, showing a countdown in the status bar. It reports whether
+// to retry: false when err isn't a rate limit, the retries are used up or the
+// provider asked for longer than rate_limit_max_wait_sec, leaving the caller
+// to fail over or surface err. A cancelled wait also retries, so the next
+// request sees the cancellation and ends the turn quietly.
+func (a *EventDrivenAgent) waitOutRateLimit(err error, retries *int, maxRetries int) bool {
+	if !isRateLimitError(err) || *retries >= maxRetries {
+		return false
+	}
+	delay, ok := rateLimitDelay(err, a.service.config.Client.Retry, a.reconnectBackoff(*retries))
+	if !ok {
+		logger.Warn("rate limited for longer than client.retry.rate_limit_max_wait_sec, not waiting",
+			"request_id", a.req.RequestID,
+			"model", a.activeModel(),
+			"retry_after", delay.String())
+		return false
+	}
+
+	*retries++
+	logger.Warn("rate limited, retrying",
+		"request_id", a.req.RequestID,
+		"model", a.activeModel(),
+		"attempt", *retries,
+		"delay", delay.String(),
+		"error", err)
+	if a.service.stateManager != nil {
+		a.service.stateManager.SetRetryStatus(&domain.RetryStatus{
+			Attempt:     *retries,
+			MaxAttempts: maxRetries,
+			RetryAt:     time.Now().Add(delay),
+		})
+	}
+	a.eventPublisher.publishChatStart()
+
+	select {
+	case <-a.agentCtx.Ctx.Done():
+	case <-time.After(delay):
+	}
+	return true
+}
+
 // reconnectBackoff returns the exponential backoff delay before reconnect
 // attempt number attempt+1, derived from the client retry config.
 func (a *EventDrivenAgent) reconnectBackoff(attempt int) time.Duration {
-	retryCfg := a.service.config.Client.Retry
+	return retryBackoff(a.service.config.Client.Retry, attempt)
+}
+
+// retryBackoff returns the exponential backoff delay before retry number
+// attempt+1 under retryCfg
+func retryBackoff(retryCfg config.RetryConfig, attempt int) time.Duration {
 	delay := time.Duration(retryCfg.InitialBackoffSec) * time.Second
 	if delay <= 0 {
 		delay = time.Second
@@ -211,13 +278,15 @@ func (a *EventDrivenAgent) reconnectBackoff(attempt int) time.Duration {
 
 // processStreamEvents processes streaming events from the LLM. It returns true
 // when the stream broke mid-flight - no events for the configured stall
-// threshold, or a transport read error - so the caller can reconnect.
+// threshold, or a transport read error - so the caller can reconnect, and the
+// timeout error when the request deadline passed.
 func (a *EventDrivenAgent) processStreamEvents(
 	requestCtx context.Context,
 	events <-chan sdk.SSEvent,
 	iterationStartTime time.Time,
-) bool {
+) (bool, error) {
 	var allToolCallDeltas []sdk.ChatCompletionMessageToolCallChunk
+	drafts := toolCallDrafts{}
 	var message sdk.Message
 	var streamUsage *sdk.CompletionUsage
 
@@ -233,28 +302,27 @@ func (a *EventDrivenAgent) processStreamEvents(
 	for {
 		select {
 		case <-requestCtx.Done():
-			a.handleStreamInterrupted(requestCtx, message)
-			return false
+			return false, a.handleStreamInterrupted(requestCtx, message)
 
 		case <-stallC:
 			logger.Warn("stream stalled, reconnecting",
 				"request_id", a.req.RequestID,
 				"stalled_for", stallAfter.String())
-			return true
+			return true, nil
 
 		case event, ok := <-events:
 			if !ok {
 				a.finalizeStream(requestCtx, message, allToolCallDeltas, streamUsage, iterationStartTime)
-				return false
+				return false, nil
 			}
 
 			if stallTimer != nil {
 				stallTimer.Reset(stallAfter)
 			}
 
-			usage, broken := a.processStreamEvent(event, &message, &allToolCallDeltas)
+			usage, broken := a.processStreamEvent(event, &message, &allToolCallDeltas, drafts)
 			if broken {
-				return true
+				return true, nil
 			}
 			if usage != nil {
 				streamUsage = usage
@@ -265,29 +333,21 @@ func (a *EventDrivenAgent) processStreamEvents(
 
 // handleStreamInterrupted handles a stream that ended via ctx cancellation -
 // either a real timeout (DeadlineExceeded) or user cancellation (Canceled).
-// Timeout: publish an error and transition to StateError.
+// Timeout: return the error for the caller to fail over or publish.
 // Cancellation: persist any partial assistant content so the user doesn't
 // lose mid-flight output (e.g. a half-written poem when Esc is pressed),
-// then return silently - the main event loop owns the StateCancelled
-// transition via cancelChan.
-func (a *EventDrivenAgent) handleStreamInterrupted(requestCtx context.Context, partial sdk.Message) {
+// then return nil - the main event loop owns the StateCancelled transition
+// via cancelChan.
+func (a *EventDrivenAgent) handleStreamInterrupted(requestCtx context.Context, partial sdk.Message) error {
 	if requestCtx.Err() == context.DeadlineExceeded {
 		logger.Error("stream timeout", "error", requestCtx.Err())
 		telemetry.SetSpanError(requestCtx, requestCtx.Err())
-		a.eventPublisher.chatEvents <- domain.ChatErrorEvent{
-			RequestID: a.req.RequestID,
-			Timestamp: time.Now(),
-			Error:     fmt.Errorf("stream timed out after %d seconds", a.service.timeoutSeconds),
-		}
-		if err := a.stateMachine.Transition(a.agentCtx, domain.StateError); err != nil {
-			logger.Error("failed to transition to Error state after stream failure", "error", err)
-		}
-		a.events <- domain.MessageReceivedEvent{}
-		return
+		return fmt.Errorf("stream timed out after %d seconds", a.service.timeoutSeconds)
 	}
 
 	logger.Debug("stream cancelled", "request_id", a.req.RequestID, "err", requestCtx.Err())
 	a.persistPartialAssistantMessage(partial)
+	return nil
 }
 
 // persistPartialAssistantMessage saves the partial assistant text + reasoning
@@ -320,7 +380,7 @@ func (a *EventDrivenAgent) persistPartialAssistantMessage(partial sdk.Message) {
 	entry := domain.ConversationEntry{
 		Message:          assistantMessage,
 		ReasoningContent: reasoning,
-		Model:            a.req.Model,
+		Model:            a.activeModel(),
 		Time:             time.Now(),
 	}
 	if err := a.service.conversationRepo.AddMessage(entry); err != nil {
@@ -336,6 +396,7 @@ func (a *EventDrivenAgent) processStreamEvent(
 	event sdk.SSEvent,
 	message *sdk.Message,
 	allToolCallDeltas *[]sdk.ChatCompletionMessageToolCallChunk,
+	drafts toolCallDrafts,
 ) (*sdk.CompletionUsage, bool) {
 	if event.Event == nil {
 		if event.Data != nil {
@@ -368,17 +429,20 @@ func (a *EventDrivenAgent) processStreamEvent(
 	}
 
 	for _, choice := range streamResponse.Choices {
-		a.processChoiceDelta(choice, message, allToolCallDeltas)
+		a.processChoiceDelta(choice, message, allToolCallDeltas, drafts)
 	}
 
 	return streamUsage, false
 }
 
-// processChoiceDelta processes a single choice delta from the stream response
+// processChoiceDelta processes a single choice delta from the stream response.
+// Tool call deltas are also folded into drafts so the partially generated
+// arguments can be previewed while the call is still streaming.
 func (a *EventDrivenAgent) processChoiceDelta(
 	choice sdk.ChatCompletionStreamChoice,
 	message *sdk.Message,
 	allToolCallDeltas *[]sdk.ChatCompletionMessageToolCallChunk,
+	drafts toolCallDrafts,
 ) {
 	a.accumulateReasoning(choice.Delta, message)
 
@@ -397,6 +461,10 @@ func (a *EventDrivenAgent) processChoiceDelta(
 	if deltaContent != "" || reasoning != "" || len(toolCalls) > 0 {
 		a.eventPublisher.publishChatChunk(deltaContent, reasoning, toolCalls)
 	}
+
+	for _, draft := range drafts.apply(toolCalls, time.Now()) {
+		a.eventPublisher.publishToolCallDraft(draft.id, draft.name, draft.args.String())
+	}
 }
 
 // accumulateReasoning accumulates reasoning content from the delta into the message
@@ -509,7 +577,7 @@ func (a *EventDrivenAgent) finalizeStream(
 	assistantEntry := domain.ConversationEntry{
 		Message:          assistantMessage,
 		ReasoningContent: reasoning,
-		Model:            a.req.Model,
+		Model:            a.activeModel(),
 		Time:             time.Now(),
 	}
 
@@ -533,7 +601,7 @@ func (a *EventDrivenAgent) finalizeStream(
 		availableTools:  a.availableTools,
 	}
 
-	a.service.storeIterationMetrics(ctx, a.req.RequestID, a.req.Model, iterationStartTime, streamUsage, polyfillInput)
+	a.service.storeIterationMetrics(ctx, a.req.RequestID, a.activeModel(), iterationStartTime, streamUsage, polyfillInput)
 
 	toolCallsSlice := make([]*sdk.ChatCompletionMessageToolCall, 0, len(completeToolCalls))
 	for i := range completeToolCalls {
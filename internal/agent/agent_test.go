@@ -1031,6 +1031,41 @@ func TestExecuteToolInternal_PublishesTerminalStatus(t *testing.T) {
 	}
 }
 
+func TestAgentServiceImpl_SkipToolCall_ForInterrupt(t *testing.T) {
+	fakeQueue := &domainmocks.FakeMessageQueue{}
+	s := &AgentServiceImpl{messageQueue: fakeQueue}
+	assert.False(t, s.hasInterrupt())
+
+	fakeQueue.HasInterruptReturns(true)
+	assert.True(t, s.hasInterrupt())
+
+	chatEvents := make(chan domain.ChatEvent, 8)
+	publisher := newEventPublisher("request-123", chatEvents)
+	tc := sdk.ChatCompletionMessageToolCall{
+		ID:       "call-1",
+		Function: sdk.ChatCompletionMessageToolCallFunction{Name: "Bash", Arguments: `{"command": "make"}`},
+	}
+
+	entry := s.skipToolCall(tc, publisher)
+	assert.Equal(t, sdk.Tool, entry.Message.Role)
+	require.NotNil(t, entry.Message.ToolCallID)
+	assert.Equal(t, "call-1", *entry.Message.ToolCallID)
+
+	content, err := entry.Message.Content.AsMessageContent0()
+	require.NoError(t, err)
+	assert.Contains(t, content, "Tool call skipped")
+
+	require.NotNil(t, entry.ToolExecution)
+	assert.False(t, entry.ToolExecution.Success)
+	assert.False(t, entry.ToolExecution.Rejected, "a skipped call must not end the run like a rejection")
+	assert.Equal(t, "make", entry.ToolExecution.Arguments["command"])
+
+	event := <-chatEvents
+	progress, ok := event.(domain.ToolExecutionProgressEvent)
+	require.True(t, ok)
+	assert.Equal(t, "failed", progress.Status)
+}
+
 func TestAgentServiceImpl_CancelRequest_WithCancelChannel(t *testing.T) {
 	agentService := &AgentServiceImpl{
 		activeSessions: make(map[string]*sessionCancel),
@@ -1589,3 +1624,31 @@ func TestAgentServiceImpl_BatchDrainQueue_IdempotentOnRepairedConversation(t *te
 	}
 	assert.Equal(t, 0, cancelled, "validator must be a no-op on already-repaired conversation")
 }
+
+func TestThrottledToolProgress(t *testing.T) {
+	var delivered []domain.ToolProgress
+	report := throttledToolProgress(func(progress domain.ToolProgress) {
+		delivered = append(delivered, progress)
+	})
+
+	report(domain.ToolProgress{Percent: 10})
+	report(domain.ToolProgress{Percent: 20})
+	report(domain.ToolProgress{Percent: 100})
+
+	assert.Len(t, delivered, 2)
+	assert.Equal(t, float64(10), delivered[0].Percent)
+	assert.Equal(t, float64(100), delivered[1].Percent)
+}
+
+func TestEventPublisher_PublishToolProgress(t *testing.T) {
+	chatEvents := make(chan domain.ChatEvent, 1)
+	publisher := newEventPublisher("request-123", chatEvents)
+
+	publisher.publishToolProgress("call-1", "WebFetch", domain.ToolProgress{Percent: 40, Step: "4 KB of 10 KB"})
+	publisher.publishToolProgress("call-1", "WebFetch", domain.ToolProgress{Percent: 50})
+
+	event := (<-chatEvents).(domain.ToolExecutionProgressEvent)
+	assert.Equal(t, "running", event.Status)
+	assert.Equal(t, "4 KB of 10 KB", event.Message)
+	assert.Equal(t, float64(40), event.Progress.Percent)
+}
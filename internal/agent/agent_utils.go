@@ -138,7 +138,8 @@ func (s *AgentServiceImpl) clearToolCallsMap() {
 }
 
 // BuildSystemPrompt assembles the static system prompt sent as message[0]
-// (base prompt + custom instructions + AGENTS.md + plugins + static context).
+// (base prompt + custom instructions + the org, project, persona and mode
+// fragments + AGENTS.md + plugins + static context).
 // It is deliberately byte-identical across turns within a session so local LLM
 // servers get KV-cache prefix hits; volatile context (git, tree, memory,
 // active skill, date) rides in volatileTailMessage instead. Returns "" when no
@@ -156,6 +157,10 @@ func (s *AgentServiceImpl) BuildSystemPrompt() string {
 		parts = append(parts, s.config.Prompts.Agent.CustomInstructions)
 	}
 
+	for _, layer := range s.config.SystemPromptLayers(s.agentModeKey()) {
+		parts = append(parts, layer.Text)
+	}
+
 	if info := s.buildAgentsMDInfo(); info != "" {
 		parts = append(parts, info)
 	}
@@ -291,9 +296,14 @@ func (s *AgentServiceImpl) SystemPromptSections() []PromptSection {
 	sections := []PromptSection{
 		{Name: "base_prompt", Text: s.getSystemPromptForMode()},
 		{Name: "custom_instructions", Text: s.config.Prompts.Agent.CustomInstructions},
-		{Name: "agents_md", Text: s.buildAgentsMDInfo()},
-		{Name: "plugins", Text: plugins.InstructionsBlock(s.config)},
 	}
+	for _, layer := range s.config.SystemPromptLayers(s.agentModeKey()) {
+		sections = append(sections, PromptSection{Name: layer.Name + "_fragment", Text: layer.Text})
+	}
+	sections = append(sections,
+		PromptSection{Name: "agents_md", Text: s.buildAgentsMDInfo()},
+		PromptSection{Name: "plugins", Text: plugins.InstructionsBlock(s.config)},
+	)
 	if agentConfig.SystemPromptWithDefaults {
 		sections = append(sections, s.contextSections()...)
 		sections = append(sections, s.volatileContextSections(0, nil)...)
@@ -438,6 +448,20 @@ func (s *AgentServiceImpl) buildToolsInfo() string {
 	return b.String()
 }
 
+// onlyNamedTools keeps the definitions named in names; empty names keeps all
+func onlyNamedTools(defs []sdk.ChatCompletionTool, names []string) []sdk.ChatCompletionTool {
+	if len(names) == 0 {
+		return defs
+	}
+	kept := make([]sdk.ChatCompletionTool, 0, len(names))
+	for _, def := range defs {
+		if slices.Contains(names, def.Function.Name) {
+			kept = append(kept, def)
+		}
+	}
+	return kept
+}
+
 // getSystemPromptForMode returns the appropriate system prompt based on current agent mode
 func (s *AgentServiceImpl) getSystemPromptForMode() string {
 	prompts := s.config.Prompts.Agent
@@ -471,6 +495,14 @@ func (s *AgentServiceImpl) getSystemPromptForMode() string {
 	}
 }
 
+// agentModeKey returns the mode_fragments key of the current agent mode
+func (s *AgentServiceImpl) agentModeKey() string {
+	if s.stateManager == nil {
+		return domain.AgentModeStandard.AllowedlistKey()
+	}
+	return s.stateManager.GetAgentMode().AllowedlistKey()
+}
+
 // buildSkillsInfo lists discovered Agent Skills with their absolute SKILL.md
 // paths so the model can read each one on demand via the Read tool.
 // Empty when skills are disabled or none were discovered.
@@ -572,35 +604,6 @@ func (s *AgentServiceImpl) buildActiveSkillInfo(messages []sdk.Message) string {
 	return b.String()
 }
 
-// buildAgentsMDInfo injects the project-root AGENTS.md into the system
-// prompt, appended after custom instructions. Returns "" when the file is
-// missing/unreadable or agent.agents_md.enabled is false.
-func (s *AgentServiceImpl) buildAgentsMDInfo() string {
-	if s.config == nil || !s.config.Agent.AgentsMD.Enabled {
-		return ""
-	}
-
-	data, err := os.ReadFile("AGENTS.md")
-	if err != nil {
-		if !os.IsNotExist(err) {
-			logger.Debug("failed to read project AGENTS.md", "error", err)
-		}
-		return ""
-	}
-
-	content := strings.TrimSpace(string(data))
-	if content == "" {
-		return ""
-	}
-
-	content, marker := plugins.CapInstructions(content, s.config.Agent.AgentsMD.MaxLines, s.config.Agent.AgentsMD.MaxChars)
-	if marker != "" {
-		content += "\n" + marker
-	}
-
-	return "PROJECT INSTRUCTIONS (AGENTS.md):\n" + content
-}
-
 // buildMemoryInfo loads the MEMORY.md index once per session and injects it as a
 // context block so the agent knows which durable facts exist; individual facts
 // are loaded on demand via the Memory tool. Cached like gitContextCache; the
@@ -1079,6 +1082,20 @@ func (s *AgentServiceImpl) dispatchHooks(agentCtx *domain.AgentContext, hook dom
 	RunCommandHooks(agentCtx.Ctx, s.config, s.hookProvider, modeKey, hook, agentCtx.Turns, sessionID)
 }
 
+// RunLifecycleHooks implements domain.AgentService. Lifecycle points fire from
+// outside the loop (the chat program, /compact, the rollover manager), so the
+// session id falls back to the current conversation when the caller has none.
+func (s *AgentServiceImpl) RunLifecycleHooks(ctx context.Context, payload domain.HookPayload) {
+	modeKey := domain.AgentModeStandard.AllowedlistKey()
+	if s.stateManager != nil {
+		modeKey = s.stateManager.GetAgentMode().AllowedlistKey()
+	}
+	if payload.SessionID == "" && s.conversationRepo != nil {
+		payload.SessionID = s.conversationRepo.GetCurrentConversationID()
+	}
+	RunHooksWithPayload(ctx, s.config, s.hookProvider, modeKey, payload)
+}
+
 // conversationAwaitsToolResults reports whether the last message is an assistant
 // turn carrying tool_calls that have not yet been answered. Injecting a user
 // reminder in that state would orphan the tool_calls, so reminder injection is
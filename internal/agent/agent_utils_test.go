@@ -628,6 +628,7 @@ func TestBuildMemoryInfo_TruncatesAtLineBoundary(t *testing.T) {
 }
 
 func TestBuildAgentsMDInfo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
 	newSvc := func(enabled bool, maxChars int) *AgentServiceImpl {
 		cfg := &config.Config{}
 		cfg.Agent.AgentsMD = config.AgentsMDConfig{Enabled: enabled, MaxChars: maxChars}
@@ -708,7 +709,7 @@ func TestBuildSystemPrompt_AgentsMDAfterCustomInstructions(t *testing.T) {
 	got := s.BuildSystemPrompt()
 	base := strings.Index(got, "base prompt")
 	custom := strings.Index(got, "custom instructions here")
-	agentsMD := strings.Index(got, "PROJECT INSTRUCTIONS (AGENTS.md):\nproject rules here")
+	agentsMD := strings.Index(got, "PROJECT INSTRUCTIONS (AGENTS.md):\n--- AGENTS.md ---\nproject rules here\n--- end of AGENTS.md ---")
 	require.GreaterOrEqual(t, base, 0)
 	require.Greater(t, custom, base)
 	require.Greater(t, agentsMD, custom)
@@ -731,7 +732,7 @@ func TestBuildSystemPrompt_PluginInstructionsAfterAgentsMD(t *testing.T) {
 	s := &AgentServiceImpl{config: cfg}
 
 	got := s.BuildSystemPrompt()
-	project := strings.Index(got, "PROJECT INSTRUCTIONS (AGENTS.md):\nproject rules")
+	project := strings.Index(got, "PROJECT INSTRUCTIONS (AGENTS.md):\n--- AGENTS.md ---\nproject rules\n--- end of AGENTS.md ---")
 	plugin := strings.Index(got, "PLUGIN INSTRUCTIONS (ponytail):\nbe lazy")
 	require.GreaterOrEqual(t, project, 0)
 	require.Greater(t, plugin, project)
@@ -820,3 +821,28 @@ func TestVolatileTailMessage(t *testing.T) {
 		require.True(t, ok)
 	})
 }
+
+func TestBuildSystemPrompt_LayeredFragments(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cfg := &config.Config{}
+	cfg.Prompts.Agent.SystemPrompt = "base prompt"
+	cfg.Prompts.Agent.SystemPromptPlan = "plan prompt"
+	cfg.Prompts.Agent.CustomInstructions = "custom instructions"
+	cfg.Prompts.Agent.OrgSystemPromptFragment = "org fragment"
+	cfg.Prompts.Agent.SystemPromptFragment = "project fragment"
+	cfg.Prompts.Agent.ModeFragments = map[string]string{"plan": "plan fragment"}
+	cfg.Agents.Profiles = map[string]config.AgentProfile{"reviewer": {SystemPromptFragment: "persona fragment"}}
+	require.NoError(t, cfg.ApplyPersona("reviewer"))
+
+	stateManager := services.NewStateManager(false)
+	stateManager.SetAgentMode(domain.AgentModePlan)
+	s := &AgentServiceImpl{config: cfg, stateManager: stateManager}
+
+	require.Equal(t,
+		"plan prompt\n\ncustom instructions\n\norg fragment\n\nproject fragment\n\npersona fragment\n\nplan fragment",
+		s.BuildSystemPrompt())
+
+	stateManager.SetAgentMode(domain.AgentModeStandard)
+	require.NotContains(t, s.BuildSystemPrompt(), "plan fragment")
+}
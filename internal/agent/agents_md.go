@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	config "github.com/inference-gateway/cli/config"
+	logger "github.com/inference-gateway/cli/internal/logger"
+	plugins "github.com/inference-gateway/cli/internal/services/plugins"
+)
+
+// agentsMDFileName is the instruction file looked up in every directory
+const agentsMDFileName = "AGENTS.md"
+
+// agentsMDFile is an AGENTS.md found by discoverAgentsMDFiles. label is how
+// the file is named in the system prompt delimiters.
+type agentsMDFile struct {
+	path  string
+	label string
+}
+
+// discoverAgentsMDFiles returns the AGENTS.md files that apply in dir, most
+// general first: ~/.infer/AGENTS.md, then one per directory from the
+// repository root (the nearest ancestor holding .git, else dir itself) down
+// to dir, so a monorepo subproject's instructions come after the root ones.
+// Only files that exist are returned.
+func discoverAgentsMDFiles(dir, home string) []agentsMDFile {
+	var files []agentsMDFile
+	if home != "" {
+		path := filepath.Join(home, config.ConfigDirName, agentsMDFileName)
+		if isRegularFile(path) {
+			files = append(files, agentsMDFile{
+				path:  path,
+				label: filepath.Join("~", config.ConfigDirName, agentsMDFileName),
+			})
+		}
+	}
+
+	root := findRepoRoot(dir)
+	var chain []string
+	for current := dir; ; current = filepath.Dir(current) {
+		chain = append(chain, current)
+		if current == root || filepath.Dir(current) == current {
+			break
+		}
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		path := filepath.Join(chain[i], agentsMDFileName)
+		if !isRegularFile(path) {
+			continue
+		}
+		label, err := filepath.Rel(root, path)
+		if err != nil {
+			label = path
+		}
+		files = append(files, agentsMDFile{path: path, label: label})
+	}
+	return files
+}
+
+// findRepoRoot returns the nearest ancestor of dir (dir included) that holds a
+// .git entry, or dir when there is none
+func findRepoRoot(dir string) string {
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+		if filepath.Dir(current) == current {
+			return dir
+		}
+	}
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// buildAgentsMDInfo injects the applicable AGENTS.md files into the system
+// prompt, appended after custom instructions. Each file is capped on its own
+// and wrapped in delimiters naming it. Returns "" when no file has content or
+// agent.agents_md.enabled is false.
+func (s *AgentServiceImpl) buildAgentsMDInfo() string {
+	if s.config == nil || !s.config.Agent.AgentsMD.Enabled {
+		return ""
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		logger.Debug("failed to resolve working directory for AGENTS.md", "error", err)
+		return ""
+	}
+	home, _ := os.UserHomeDir()
+
+	var sections []string
+	for _, file := range discoverAgentsMDFiles(dir, home) {
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			logger.Debug("failed to read AGENTS.md", "path", file.path, "error", err)
+			continue
+		}
+
+		content := strings.TrimSpace(string(data))
+		if content == "" {
+			continue
+		}
+
+		content, marker := plugins.CapInstructions(content, s.config.Agent.AgentsMD.MaxLines, s.config.Agent.AgentsMD.MaxChars)
+		if marker != "" {
+			content += "\n" + marker
+		}
+		sections = append(sections, fmt.Sprintf("--- %s ---\n%s\n--- end of %s ---", file.label, content, file.label))
+	}
+
+	if len(sections) == 0 {
+		return ""
+	}
+
+	header := "PROJECT INSTRUCTIONS (AGENTS.md):\n"
+	if len(sections) > 1 {
+		header += "Files are listed from most general to most specific; where they conflict, follow the later one.\n\n"
+	}
+	return header + strings.Join(sections, "\n\n")
+}
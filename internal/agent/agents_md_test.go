@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func writeAgentsMD(t *testing.T, dir, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, agentsMDFileName), []byte(content), 0o644))
+}
+
+func TestDiscoverAgentsMDFiles(t *testing.T) {
+	home := t.TempDir()
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "services", "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "services"), 0o755))
+
+	writeAgentsMD(t, filepath.Join(home, config.ConfigDirName), "user")
+	writeAgentsMD(t, repo, "root")
+	writeAgentsMD(t, sub, "api")
+
+	t.Run("user file, root and subdirectory chain in order", func(t *testing.T) {
+		files := discoverAgentsMDFiles(sub, home)
+		var labels []string
+		for _, f := range files {
+			labels = append(labels, f.label)
+		}
+		require.Equal(t, []string{
+			filepath.Join("~", ".infer", "AGENTS.md"),
+			"AGENTS.md",
+			filepath.Join("services", "api", "AGENTS.md"),
+		}, labels)
+	})
+
+	t.Run("does not climb above the repository root", func(t *testing.T) {
+		writeAgentsMD(t, filepath.Dir(repo), "outside")
+		t.Cleanup(func() { _ = os.Remove(filepath.Join(filepath.Dir(repo), agentsMDFileName)) })
+
+		files := discoverAgentsMDFiles(repo, "")
+		require.Len(t, files, 1)
+		require.Equal(t, filepath.Join(repo, agentsMDFileName), files[0].path)
+	})
+
+	t.Run("without a repository only the working directory is used", func(t *testing.T) {
+		dir := t.TempDir()
+		require.Empty(t, discoverAgentsMDFiles(dir, ""))
+		writeAgentsMD(t, dir, "here")
+		require.Len(t, discoverAgentsMDFiles(dir, ""), 1)
+	})
+}
+
+func TestBuildAgentsMDInfo_MergesHierarchy(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "web")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0o755))
+
+	writeAgentsMD(t, filepath.Join(home, config.ConfigDirName), "Prefer short answers.")
+	writeAgentsMD(t, repo, "Use Go 1.26.")
+	writeAgentsMD(t, sub, "Use pnpm.")
+	t.Chdir(sub)
+
+	cfg := &config.Config{}
+	cfg.Agent.AgentsMD = config.AgentsMDConfig{Enabled: true}
+	got := (&AgentServiceImpl{config: cfg}).buildAgentsMDInfo()
+
+	require.True(t, strings.HasPrefix(got, "PROJECT INSTRUCTIONS (AGENTS.md):\n"))
+	require.Contains(t, got, "most general to most specific")
+	user := strings.Index(got, "--- ~/.infer/AGENTS.md ---\nPrefer short answers.\n--- end of ~/.infer/AGENTS.md ---")
+	root := strings.Index(got, "--- AGENTS.md ---\nUse Go 1.26.")
+	web := strings.Index(got, "--- web/AGENTS.md ---\nUse pnpm.")
+	require.True(t, user >= 0 && root > user && web > root, "expected user, root and subdirectory order, got:\n%s", got)
+}
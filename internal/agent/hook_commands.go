@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
 	logger "github.com/inference-gateway/cli/internal/logger"
+	plugins "github.com/inference-gateway/cli/internal/services/plugins"
 	streamevent "github.com/inference-gateway/cli/internal/streamevent"
 )
 
@@ -34,16 +36,35 @@ const hookCommandOutputLimit = 4096
 // emitted as a hook_command stream event and logged, never fed back into the
 // conversation or used to alter the loop (that feedback is a later iteration).
 func RunCommandHooks(ctx context.Context, cfg *config.Config, provider domain.HookCommandProvider, modeKey string, hook domain.HookPoint, turn int, sessionID string) {
+	RunHooksWithPayload(ctx, cfg, provider, modeKey, domain.HookPayload{Hook: hook, Turn: turn, SessionID: sessionID})
+}
+
+// RunHooksWithPayload is RunCommandHooks for callers that know more about the
+// session than the loop points do - the lifecycle points (session_start,
+// session_end, pre_compact, on_error) pass the model, the reason or the error.
+// The mode and working directory are filled in when the payload leaves them
+// empty.
+func RunHooksWithPayload(ctx context.Context, cfg *config.Config, provider domain.HookCommandProvider, modeKey string, payload domain.HookPayload) {
 	if provider == nil {
 		if cfg == nil {
 			return
 		}
 		provider = cfg.Hooks
 	}
+	hook := payload.Hook
 	due := provider.CommandsDue(hook)
 	if len(due) == 0 {
 		return
 	}
+	if payload.Mode == "" {
+		payload.Mode = modeKey
+	}
+	if payload.Cwd == "" {
+		payload.Cwd, _ = os.Getwd()
+	}
+	if payload.Timestamp.IsZero() {
+		payload.Timestamp = time.Now()
+	}
 	for _, hc := range due {
 		if cfg == nil || !cfg.IsBashCommandAllowed(hc.Command, modeKey) {
 			hint := config.BashCommandRejectionHint(hc.Command)
@@ -55,16 +76,26 @@ func RunCommandHooks(ctx context.Context, cfg *config.Config, provider domain.Ho
 			})
 			continue
 		}
-		runHookCommand(ctx, hook, turn, sessionID, hc)
+		runHookCommand(ctx, payload, hc)
 	}
 }
 
+// HookProvider returns the command hooks in effect for cfg: the user's
+// hooks.yaml merged with the hooks of enabled plugins.
+func HookProvider(cfg *config.Config) domain.HookCommandProvider {
+	if pluginProvider := plugins.NewPluginHookCommandProvider(cfg); pluginProvider != nil {
+		return pluginProvider
+	}
+	return cfg.Hooks
+}
+
 // runHookCommand executes one allow-listed command hook fire-and-observe: it runs
-// `bash -c <command>` under the command's timeout, feeds a small JSON context on
-// stdin (hook, turn, session id - Claude-Code style), captures combined output,
-// and emits a hook_command stream event carrying the exit code, duration and
-// (truncated) output.
-func runHookCommand(ctx context.Context, hook domain.HookPoint, turn int, sessionID string, hc domain.HookCommand) {
+// `bash -c <command>` under the command's timeout, feeds the payload as JSON on
+// stdin (hook, turn, session id and whatever session metadata the firing point
+// knows - Claude-Code style), captures combined output, and emits a
+// hook_command stream event carrying the exit code, duration and (truncated)
+// output.
+func runHookCommand(ctx context.Context, payload domain.HookPayload, hc domain.HookCommand) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -74,9 +105,10 @@ func runHookCommand(ctx context.Context, hook domain.HookPoint, turn int, sessio
 		defer cancel()
 	}
 
+	hook := payload.Hook
 	start := time.Now()
 	cmd := exec.CommandContext(ctx, "bash", "-c", hc.Command)
-	cmd.Stdin = strings.NewReader(hookCommandStdin(hook, turn, sessionID))
+	cmd.Stdin = strings.NewReader(hookCommandStdin(payload))
 	out, err := cmd.CombinedOutput()
 	durMs := time.Since(start).Milliseconds()
 
@@ -85,23 +117,19 @@ func runHookCommand(ctx context.Context, hook domain.HookPoint, turn int, sessio
 		"name", hc.Name, "hook", string(hook), "command", hc.Command,
 		"exit_code", exitCode, "duration_ms", durMs, "error", errStr)
 	streamevent.EmitDebugEvent("hook_command", map[string]any{
-		"name": hc.Name, "hook": string(hook), "command": hc.Command, "turn": turn,
+		"name": hc.Name, "hook": string(hook), "command": hc.Command, "turn": payload.Turn,
 		"exit_code": exitCode, "duration_ms": durMs,
 		"output": truncateHookOutput(string(out)), "error": errStr,
 	})
 }
 
 // hookCommandStdin builds the JSON context fed to a hook command on stdin.
-func hookCommandStdin(hook domain.HookPoint, turn int, sessionID string) string {
-	payload, err := json.Marshal(map[string]any{
-		"hook":       string(hook),
-		"turn":       turn,
-		"session_id": sessionID,
-	})
+func hookCommandStdin(payload domain.HookPayload) string {
+	data, err := json.Marshal(payload)
 	if err != nil {
 		return "{}"
 	}
-	return string(payload)
+	return string(data)
 }
 
 // classifyCommandError maps an exec error to an (exit code, message): 0/"" on
@@ -148,12 +148,38 @@ func TestRunCommandHooks_NilSafe(t *testing.T) {
 	assert.Empty(t, parseEvents(t, buf))
 }
 
+// A lifecycle hook receives the session metadata its firing point supplied,
+// with the mode and working directory filled in, as JSON on stdin.
+func TestRunHooksWithPayload_FeedsSessionMetadata(t *testing.T) {
+	buf := withDebugStreamWriter(t)
+	provider := hooksProvider(true, config.HookCommandConfig{
+		Name: "meta", Hook: domain.HookOnError, Command: "cat", Timeout: 5,
+	})
+
+	RunHooksWithPayload(context.Background(), allowCfg("cat"), provider, "plan", domain.HookPayload{
+		Hook: domain.HookOnError, Turn: 3, SessionID: "sess-9", Model: "openai/gpt-4o", Error: "stream timed out",
+	})
+
+	events := parseEvents(t, buf)
+	require.Len(t, events, 1)
+	out, _ := events[0]["output"].(string)
+	var payload domain.HookPayload
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	assert.Equal(t, domain.HookOnError, payload.Hook)
+	assert.Equal(t, "sess-9", payload.SessionID)
+	assert.Equal(t, "openai/gpt-4o", payload.Model)
+	assert.Equal(t, "stream timed out", payload.Error)
+	assert.Equal(t, "plan", payload.Mode)
+	assert.NotEmpty(t, payload.Cwd)
+	assert.False(t, payload.Timestamp.IsZero())
+}
+
 // runHookCommand kills a command that overruns its timeout and still reports it
 // (a non-zero/-1 exit), proving the per-command timeout is honored.
 func TestRunHookCommand_HonorsTimeout(t *testing.T) {
 	buf := withDebugStreamWriter(t)
 	start := time.Now()
-	runHookCommand(context.Background(), domain.HookPostSession, 1, "s",
+	runHookCommand(context.Background(), domain.HookPayload{Hook: domain.HookPostSession, Turn: 1, SessionID: "s"},
 		domain.HookCommand{Name: "slow", Command: "sleep 5", Timeout: 100 * time.Millisecond})
 
 	if elapsed := time.Since(start); elapsed > 3*time.Second {
@@ -0,0 +1,50 @@
+package agent
+
+import (
+	"cmp"
+
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// activeModel returns the provider/model answering the current turn: the
+// model selected for it, or the agent.model_fallbacks entry the turn failed
+// over to
+func (a *EventDrivenAgent) activeModel() string {
+	if a.fallbackIndex == 0 {
+		return cmp.Or(a.turnModel, a.req.Model)
+	}
+	return a.cfg.ModelFallbacks[a.fallbackIndex-1]
+}
+
+// failoverModel switches the turn to the next usable agent.model_fallbacks
+// entry after the active model failed with err - a request error (including
+// rate limiting), a timeout, or a stream that stayed broken through every
+// reconnect. It returns false when the chain is exhausted or the run was
+// cancelled, leaving the caller to publish err.
+func (a *EventDrivenAgent) failoverModel(err error) bool {
+	if a.cfg == nil || a.agentCtx.Ctx.Err() != nil {
+		return false
+	}
+
+	failed := a.activeModel()
+	for a.fallbackIndex < len(a.cfg.ModelFallbacks) {
+		next := a.cfg.ModelFallbacks[a.fallbackIndex]
+		a.fallbackIndex++
+
+		provider, model, perr := a.service.parseProvider(next)
+		if perr != nil {
+			logger.Warn("skipping invalid model fallback", "model", next, "error", perr)
+			continue
+		}
+
+		logger.Warn("model failed, retrying turn with fallback",
+			"request_id", a.req.RequestID,
+			"turn", a.agentCtx.Turns,
+			"failed_model", failed,
+			"fallback", next,
+			"error", err)
+		a.provider, a.model = provider, model
+		return true
+	}
+	return false
+}
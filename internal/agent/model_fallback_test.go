@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func newFallbackTestAgent(ctx context.Context, fallbacks ...string) *EventDrivenAgent {
+	return &EventDrivenAgent{
+		service:  &AgentServiceImpl{},
+		cfg:      &config.AgentConfig{ModelFallbacks: fallbacks},
+		agentCtx: &domain.AgentContext{Ctx: ctx},
+		req:      &domain.AgentRequest{RequestID: "r1", Model: "anthropic/claude-sonnet-4"},
+		provider: "anthropic",
+		model:    "claude-sonnet-4",
+	}
+}
+
+func TestFailoverModel_WalksTheChain(t *testing.T) {
+	a := newFallbackTestAgent(context.Background(), "openai/gpt-4o", "not-a-model", "groq/llama-3.3-70b")
+	errRateLimited := errors.New("429 too many requests")
+
+	assert.Equal(t, "anthropic/claude-sonnet-4", a.activeModel())
+
+	assert.True(t, a.failoverModel(errRateLimited))
+	assert.Equal(t, "openai", a.provider)
+	assert.Equal(t, "gpt-4o", a.model)
+	assert.Equal(t, "openai/gpt-4o", a.activeModel())
+
+	assert.True(t, a.failoverModel(errRateLimited), "invalid entries are skipped")
+	assert.Equal(t, "groq", a.provider)
+	assert.Equal(t, "llama-3.3-70b", a.model)
+	assert.Equal(t, "groq/llama-3.3-70b", a.activeModel())
+
+	assert.False(t, a.failoverModel(errRateLimited), "chain exhausted")
+}
+
+func TestFailoverModel_ResetsForNextTurn(t *testing.T) {
+	a := newFallbackTestAgent(context.Background(), "openai/gpt-4o")
+
+	assert.True(t, a.failoverModel(errors.New("timeout")))
+	a.selectTurnModel()
+
+	assert.Equal(t, "anthropic", a.provider)
+	assert.Equal(t, "claude-sonnet-4", a.model)
+	assert.Equal(t, "anthropic/claude-sonnet-4", a.activeModel())
+}
+
+func TestFailoverModel_NotAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a := newFallbackTestAgent(ctx, "openai/gpt-4o")
+
+	assert.False(t, a.failoverModel(context.Canceled))
+	assert.Equal(t, "anthropic", a.provider)
+}
+
+func TestFailoverModel_NoFallbacksConfigured(t *testing.T) {
+	a := newFallbackTestAgent(context.Background())
+
+	assert.False(t, a.failoverModel(errors.New("boom")))
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	logger "github.com/inference-gateway/cli/internal/logger"
+	toolscheduler "github.com/inference-gateway/cli/internal/services/toolscheduler"
+)
+
+// turnTask classifies the turn about to stream for agent.routing. A turn that
+// continues after tool calls which only read, searched or fetched is tool
+// selection; the first turn after a user message, and one that continues
+// after files were changed or commands run, is code generation.
+func turnTask(conversation []sdk.Message) string {
+	if len(conversation) == 0 || conversation[len(conversation)-1].Role != sdk.Tool {
+		return config.TaskCodeGeneration
+	}
+
+	for i := len(conversation) - 1; i >= 0; i-- {
+		msg := conversation[i]
+		if msg.Role != sdk.Assistant {
+			continue
+		}
+		if msg.ToolCalls == nil {
+			break
+		}
+		for _, tc := range *msg.ToolCalls {
+			if toolscheduler.Classify(tc.Function.Name, tc.Function.Arguments).Class == toolscheduler.ClassMutating {
+				return config.TaskCodeGeneration
+			}
+		}
+		return config.TaskToolSelection
+	}
+	return config.TaskCodeGeneration
+}
+
+// selectTurnModel points a new turn at its model - the agent.routing rule for
+// the turn's task type, else the requested model - undoing any failover of the
+// previous turn, so a transient failure only diverts the turn it happened in
+func (a *EventDrivenAgent) selectTurnModel() {
+	a.fallbackIndex = 0
+
+	model := a.req.Model
+	if a.service.config != nil && a.agentCtx.Conversation != nil {
+		task := turnTask(*a.agentCtx.Conversation)
+		model = a.service.config.ModelForTask(task, a.req.Model)
+		if model != a.req.Model {
+			logger.Debug("routing turn", "task", task, "model", model, "turn", a.agentCtx.Turns)
+		}
+	}
+
+	provider, name, err := a.service.parseProvider(model)
+	if err != nil {
+		logger.Warn("invalid routed model, using the requested model", "model", model, "error", err)
+		model = a.req.Model
+		if provider, name, err = a.service.parseProvider(model); err != nil {
+			return
+		}
+	}
+	a.turnModel = model
+	a.provider, a.model = provider, name
+}
@@ -0,0 +1,91 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func assistantCalling(calls ...sdk.ChatCompletionMessageToolCall) sdk.Message {
+	return sdk.Message{Role: sdk.Assistant, ToolCalls: &calls}
+}
+
+func namedToolCall(name, args string) sdk.ChatCompletionMessageToolCall {
+	return sdk.ChatCompletionMessageToolCall{
+		Function: sdk.ChatCompletionMessageToolCallFunction{Name: name, Arguments: args},
+	}
+}
+
+func TestTurnTask(t *testing.T) {
+	user := sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("fix the bug")}
+	result := sdk.Message{Role: sdk.Tool, Content: sdk.NewMessageContent("ok")}
+
+	tests := []struct {
+		name         string
+		conversation []sdk.Message
+		want         string
+	}{
+		{"empty", nil, config.TaskCodeGeneration},
+		{"user message", []sdk.Message{user}, config.TaskCodeGeneration},
+		{
+			"after reads",
+			[]sdk.Message{user, assistantCalling(namedToolCall("Read", `{"file_path":"a.go"}`), namedToolCall("Grep", `{"pattern":"x"}`)), result, result},
+			config.TaskToolSelection,
+		},
+		{
+			"after an edit",
+			[]sdk.Message{user, assistantCalling(namedToolCall("Read", `{"file_path":"a.go"}`), namedToolCall("Edit", `{"file_path":"a.go"}`)), result, result},
+			config.TaskCodeGeneration,
+		},
+		{
+			"after bash",
+			[]sdk.Message{user, assistantCalling(namedToolCall("Bash", `{"command":"go test ./..."}`)), result},
+			config.TaskCodeGeneration,
+		},
+		{
+			"user message queued after tool results",
+			[]sdk.Message{user, assistantCalling(namedToolCall("Read", `{"file_path":"a.go"}`)), result, user},
+			config.TaskCodeGeneration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, turnTask(tt.conversation))
+		})
+	}
+}
+
+func TestSelectTurnModel_RoutesByTask(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Agent.Routing.ToolSelection = "groq/llama-3.3-70b"
+
+	conversation := []sdk.Message{
+		{Role: sdk.User, Content: sdk.NewMessageContent("where is main?")},
+		assistantCalling(namedToolCall("Grep", `{"pattern":"func main"}`)),
+		{Role: sdk.Tool, Content: sdk.NewMessageContent("main.go:3")},
+	}
+	a := &EventDrivenAgent{
+		service:  &AgentServiceImpl{config: cfg},
+		cfg:      &cfg.Agent,
+		agentCtx: &domain.AgentContext{Ctx: context.Background(), Conversation: &conversation},
+		req:      &domain.AgentRequest{Model: "anthropic/claude-sonnet-4"},
+	}
+
+	a.selectTurnModel()
+	assert.Equal(t, "groq", a.provider)
+	assert.Equal(t, "llama-3.3-70b", a.model)
+	assert.Equal(t, "groq/llama-3.3-70b", a.activeModel())
+
+	conversation = append(conversation, sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("now fix it")})
+	a.selectTurnModel()
+	assert.Equal(t, "anthropic", a.provider)
+	assert.Equal(t, "claude-sonnet-4", a.model)
+	assert.Equal(t, "anthropic/claude-sonnet-4", a.activeModel())
+}
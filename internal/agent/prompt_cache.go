@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"encoding/json"
+	"slices"
+
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// cacheControlProviders take explicit cache_control breakpoints. Others
+// (OpenAI, DeepSeek, ...) cache a repeated prompt prefix automatically, which
+// only needs the system prompt at message[0] to stay byte-stable.
+var cacheControlProviders = map[sdk.Provider]bool{
+	sdk.Anthropic: true,
+}
+
+// cacheableTextPart is a text content part carrying a cache_control
+// breakpoint, which sdk.TextContentPart has no field for
+type cacheableTextPart struct {
+	Type         string            `json:"type"`
+	Text         string            `json:"text"`
+	CacheControl *sdk.CacheControl `json:"cache_control,omitempty"`
+}
+
+// withPromptCache returns the request messages with an ephemeral cache_control
+// breakpoint on the system message when agent.prompt_caching is on and the
+// provider takes breakpoints, so the system prompt and AGENTS.md prefix is
+// cached across turns. messages is never modified.
+func (s *AgentServiceImpl) withPromptCache(provider string, messages []sdk.Message) []sdk.Message {
+	if s.config == nil || !s.config.Agent.PromptCaching || !cacheControlProviders[sdk.Provider(provider)] {
+		return messages
+	}
+	if len(messages) == 0 || messages[0].Role != sdk.System {
+		return messages
+	}
+
+	text, err := messages[0].Content.AsMessageContent0()
+	if err != nil || text == "" {
+		return messages
+	}
+
+	raw, err := json.Marshal([]cacheableTextPart{{
+		Type:         string(sdk.TextContentPartTypeText),
+		Text:         text,
+		CacheControl: &sdk.CacheControl{Type: sdk.Ephemeral},
+	}})
+	if err != nil {
+		return messages
+	}
+	var content sdk.MessageContent
+	if err := content.UnmarshalJSON(raw); err != nil {
+		return messages
+	}
+
+	out := slices.Clone(messages)
+	out[0].Content = content
+	return out
+}
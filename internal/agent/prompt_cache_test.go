@@ -0,0 +1,41 @@
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestWithPromptCache(t *testing.T) {
+	messages := []sdk.Message{
+		{Role: sdk.System, Content: sdk.NewMessageContent("You are a coding agent.\n\n# AGENTS.md\nUse tabs.")},
+		{Role: sdk.User, Content: sdk.NewMessageContent("hi")},
+	}
+	cfg := &config.Config{Agent: config.AgentConfig{PromptCaching: true}}
+	s := &AgentServiceImpl{config: cfg}
+
+	out := s.withPromptCache("anthropic", messages)
+
+	raw, err := json.Marshal(out[0].Content)
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`[{"type":"text","text":"You are a coding agent.\n\n# AGENTS.md\nUse tabs.","cache_control":{"type":"ephemeral"}}]`,
+		string(raw))
+	assert.Equal(t, messages[1], out[1])
+
+	original, err := messages[0].Content.AsMessageContent0()
+	require.NoError(t, err, "the shared conversation must keep its plain system prompt")
+	assert.Contains(t, original, "AGENTS.md")
+
+	assert.Equal(t, messages, s.withPromptCache("openai", messages),
+		"providers that cache automatically get no breakpoint")
+
+	cfg.Agent.PromptCaching = false
+	assert.Equal(t, messages, s.withPromptCache("anthropic", messages))
+}
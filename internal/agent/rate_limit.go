@@ -0,0 +1,94 @@
+package agent
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+// The agent's SDK client leaves 429 responses to the agent (see
+// config.RetryConfig.RateLimitMaxWaitSec) because the SDK only reports "HTTP
+// 429" once its own retries run out, dropping the response body and headers.
+// The gateway relays the provider's error, which is where the provider says
+// how long to wait.
+var (
+	rateLimitStatusPattern = regexp.MustCompile(`(?i)status(?: code)?: 429\b|\bHTTP 429\b|\brate[_ ]limit`)
+
+	// "Please try again in 6.5s", "retry after 1m30s", "Retry-After: 20"
+	rateLimitTextHintPattern = regexp.MustCompile(
+		`(?i)(?:try again|retry)[ -](?:in|after):?\s*(\d+(?:\.\d+)?)\s*(ms|milliseconds?|s|secs?|seconds?|m|mins?|minutes?|h|hours?)?\b`)
+	rateLimitDurationHintPattern = regexp.MustCompile(`(?i)(?:try again|retry) (?:in|after) ((?:\d+(?:\.\d+)?(?:ms|h|m|s))+)\b`)
+
+	// {"retry_after": 12}, {"retry_after_ms": 1500}, {"retryDelay": "27s"}
+	rateLimitJSONHintPattern   = regexp.MustCompile(`(?i)"retry[_-]?after(_ms)?"\s*:\s*"?(\d+(?:\.\d+)?)`)
+	rateLimitRetryDelayPattern = regexp.MustCompile(`"retryDelay"\s*:\s*"(\d+(?:\.\d+)?s)"`)
+)
+
+// isRateLimitError reports whether err is a 429 / rate limit response
+func isRateLimitError(err error) bool {
+	return err != nil && rateLimitStatusPattern.MatchString(err.Error())
+}
+
+// rateLimitHint returns how long the provider asked to wait in a rate limit
+// error, or false when it didn't say
+func rateLimitHint(err error) (time.Duration, bool) {
+	msg := err.Error()
+
+	if m := rateLimitDurationHintPattern.FindStringSubmatch(msg); m != nil {
+		if d, perr := time.ParseDuration(m[1]); perr == nil {
+			return d, true
+		}
+	}
+	if m := rateLimitTextHintPattern.FindStringSubmatch(msg); m != nil {
+		return hintDuration(m[1], m[2])
+	}
+	if m := rateLimitRetryDelayPattern.FindStringSubmatch(msg); m != nil {
+		if d, perr := time.ParseDuration(m[1]); perr == nil {
+			return d, true
+		}
+	}
+	if m := rateLimitJSONHintPattern.FindStringSubmatch(msg); m != nil {
+		if m[1] != "" {
+			return hintDuration(m[2], "ms")
+		}
+		return hintDuration(m[2], "s")
+	}
+	return 0, false
+}
+
+// hintDuration converts a number and a unit word ("s" when empty) to a duration
+func hintDuration(value, unit string) (time.Duration, bool) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	scale := time.Second
+	switch u := strings.ToLower(unit); {
+	case u == "ms" || strings.HasPrefix(u, "milli"):
+		scale = time.Millisecond
+	case u == "m" || strings.HasPrefix(u, "min"):
+		scale = time.Minute
+	case u == "h" || strings.HasPrefix(u, "hour"):
+		scale = time.Hour
+	}
+	return time.Duration(n * float64(scale)), true
+}
+
+// rateLimitDelay returns how long to wait before retrying a rate-limited
+// request: the provider's hint when it gave one, else fallback. ok is false
+// when the provider asked for longer than client.retry.rate_limit_max_wait_sec,
+// in which case waiting is pointless and the error should surface.
+func rateLimitDelay(err error, retry config.RetryConfig, fallback time.Duration) (time.Duration, bool) {
+	delay, hinted := rateLimitHint(err)
+	if !hinted {
+		return fallback, true
+	}
+	if maxWait := time.Duration(retry.RateLimitMaxWaitSec) * time.Second; maxWait > 0 && delay > maxWait {
+		return delay, false
+	}
+	return delay, true
+}
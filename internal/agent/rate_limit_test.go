@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestRateLimitHint(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       string
+		rateLimit bool
+		want      time.Duration
+		wantHint  bool
+	}{
+		{"openai message", `API stream error: Rate limit reached for gpt-4o on tokens per min. Please try again in 6.5s. (status code: 429)`, true, 6500 * time.Millisecond, true},
+		{"go duration", `API stream error: slow down, retry after 1m30s (status code: 429)`, true, 90 * time.Second, true},
+		{"worded unit", `stream request failed with status: 429, response body: please try again in 20 seconds`, true, 20 * time.Second, true},
+		{"retry-after text", `API stream error: too many requests, Retry-After: 12 (status code: 429)`, true, 12 * time.Second, true},
+		{"json seconds", `stream request failed with status: 429, response body: {"error":"busy","retry_after":3}`, true, 3 * time.Second, true},
+		{"json millis", `stream request failed with status: 429, response body: {"retry_after_ms": 1500}`, true, 1500 * time.Millisecond, true},
+		{"google retryDelay", `API stream error: {"details":[{"@type":"RetryInfo","retryDelay":"27s"}]} (status code: 429)`, true, 27 * time.Second, true},
+		{"no hint", `API stream error: rate_limit_error: too many requests (status code: 429)`, true, 0, false},
+		{"not a rate limit", `API stream error: upstream unavailable (status code: 503)`, false, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.New(tt.err)
+			if got := isRateLimitError(err); got != tt.rateLimit {
+				t.Errorf("isRateLimitError() = %v, want %v", got, tt.rateLimit)
+			}
+			got, ok := rateLimitHint(err)
+			if got != tt.want || ok != tt.wantHint {
+				t.Errorf("rateLimitHint() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	retry := config.RetryConfig{RateLimitMaxWaitSec: 60}
+	fallback := 5 * time.Second
+
+	if d, ok := rateLimitDelay(errors.New("HTTP 429"), retry, fallback); d != fallback || !ok {
+		t.Errorf("without a hint: (%v, %v), want the fallback", d, ok)
+	}
+	if d, ok := rateLimitDelay(errors.New("429: try again in 30s (status code: 429)"), retry, fallback); d != 30*time.Second || !ok {
+		t.Errorf("with a hint: (%v, %v), want 30s", d, ok)
+	}
+	if _, ok := rateLimitDelay(errors.New("quota exceeded, retry after 2h (status code: 429)"), retry, fallback); ok {
+		t.Error("a hint over rate_limit_max_wait_sec should not be waited out")
+	}
+	retry.RateLimitMaxWaitSec = 0
+	if d, ok := rateLimitDelay(errors.New("retry after 2h (status code: 429)"), retry, fallback); d != 2*time.Hour || !ok {
+		t.Errorf("with no cap: (%v, %v), want 2h", d, ok)
+	}
+}
@@ -0,0 +1,33 @@
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestRequestOptions(t *testing.T) {
+	cfg := &config.Config{}
+	require.NoError(t, cfg.Agent.Sampling.Set("temperature", "0.2"))
+	require.NoError(t, cfg.Agent.Sampling.Set("seed", "7"))
+	cfg.Agent.StopSequences = []string{"</answer>"}
+	s := &AgentServiceImpl{config: cfg, maxTokens: 1024}
+
+	data, err := json.Marshal(s.requestOptions(nil))
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.InDelta(t, 0.2, got["temperature"], 1e-6)
+	assert.Equal(t, 7.0, got["seed"])
+	assert.Equal(t, []any{"</answer>"}, got["stop"])
+	assert.NotContains(t, got, "top_p", "unset parameters keep the provider default")
+
+	data, err = json.Marshal((&AgentServiceImpl{maxTokens: 1024}).requestOptions(nil))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "stop")
+}
@@ -3,13 +3,14 @@ package states
 import (
 	"encoding/json"
 	"fmt"
-	"sync"
 	"time"
 
 	sdk "github.com/inference-gateway/sdk"
 
+	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
 	logger "github.com/inference-gateway/cli/internal/logger"
+	toolscheduler "github.com/inference-gateway/cli/internal/services/toolscheduler"
 )
 
 // toolRound holds the per-round state for approving and executing a batch of
@@ -23,8 +24,7 @@ type toolRound struct {
 	results []domain.ConversationEntry // indexed by tool position
 	ready   []bool                     // ready[i] set once results[i] is filled
 	flushed int                        // next slot index to flush, in order
-	wg      sync.WaitGroup             // tracks spawned executions
-	sem     chan struct{}              // bounds concurrent executions
+	batch   domain.ToolBatch           // schedules spawned executions
 }
 
 // ApprovingToolsState handles events in the ApprovingTools state.
@@ -63,7 +63,7 @@ func (s *ApprovingToolsState) Handle(event domain.AgentEvent) error {
 		round := &toolRound{
 			results: make([]domain.ConversationEntry, len(*s.ctx.ToolsNeedingApproval)),
 			ready:   make([]bool, len(*s.ctx.ToolsNeedingApproval)),
-			sem:     make(chan struct{}, s.maxConcurrent()),
+			batch:   s.newBatch(),
 		}
 
 		logger.Debug("starting tool approval", "total_tools", len(*s.ctx.ToolsNeedingApproval))
@@ -159,17 +159,12 @@ func (s *ApprovingToolsState) getNextToolForProcessing() (*sdk.ChatCompletionMes
 }
 
 // spawnExecution runs an approved tool in the background, recording its result
-// in the round's slot. Concurrency is bounded by the round semaphore.
+// in the round's slot. Concurrency and ordering are left to the round's batch.
 func (s *ApprovingToolsState) spawnExecution(round *toolRound, idx int, tc sdk.ChatCompletionMessageToolCall) {
-	round.wg.Add(1)
-	go func() {
-		defer round.wg.Done()
-		round.sem <- struct{}{}
-		defer func() { <-round.sem }()
-
+	round.batch.Go(tc, func() {
 		logger.Debug("executing approved tool", "tool", tc.Function.Name)
 		s.completeSlot(round, idx, s.ctx.ExecuteToolInternal(tc, true))
-	}()
+	})
 }
 
 // spawnAllRemaining executes the just-approved tool and every remaining tool in
@@ -246,7 +241,7 @@ func (s *ApprovingToolsState) flushLocked(round *toolRound) {
 // another LLM turn, returning control to the user (same semantics as the
 // non-approval route's "tool was rejected - stopping agent loop", issue #786).
 func (s *ApprovingToolsState) finishApprovals(round *toolRound) {
-	round.wg.Wait()
+	round.batch.Wait()
 	s.flushReady(round)
 
 	s.ctx.AgentCtx.LastToolFailed = domain.AnyToolFailed(*s.ctx.ToolResults)
@@ -302,13 +297,13 @@ func (s *ApprovingToolsState) shouldAutoApproveRemaining() bool {
 	return s.ctx.GetAgentMode() == domain.AgentModeAutoAccept
 }
 
-// maxConcurrent returns the bound on concurrently executing tools, clamped to
-// at least 1 so a missing/zero config value cannot deadlock the semaphore.
-func (s *ApprovingToolsState) maxConcurrent() int {
-	if s.ctx.MaxConcurrentTools < 1 {
-		return 1
+// newBatch creates the round's tool scheduler, falling back to the default
+// limits when the context doesn't provide one.
+func (s *ApprovingToolsState) newBatch() domain.ToolBatch {
+	if s.ctx.NewToolBatch == nil {
+		return toolscheduler.NewBatch(config.ToolConcurrencyConfig{})
 	}
-	return s.ctx.MaxConcurrentTools
+	return s.ctx.NewToolBatch()
 }
 
 // handleApprovalFailure handles when approval fails (timeout, error, etc.)
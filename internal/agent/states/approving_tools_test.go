@@ -65,7 +65,6 @@ func newApprovingCtx(
 		ToolsNeedingApproval: &tna,
 		CurrentToolIndex:     &idx,
 		ToolResults:          &tr,
-		MaxConcurrentTools:   5,
 		Request:              &domain.AgentRequest{RequestID: "req-1"},
 		AgentCtx: &domain.AgentContext{
 			Ctx:          context.Background(),
@@ -0,0 +1,53 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	constants "github.com/inference-gateway/cli/internal/constants"
+)
+
+// toolCallDraft is a tool call the model is still generating. Its arguments
+// are the raw (usually incomplete) JSON received so far, kept so the UI can
+// preview the file path or command before the call finishes streaming.
+type toolCallDraft struct {
+	id            string
+	name          string
+	args          strings.Builder
+	lastPublished time.Time
+}
+
+// toolCallDrafts tracks the drafts of one stream, keyed by tool call index
+type toolCallDrafts map[int]*toolCallDraft
+
+// apply folds deltas into the drafts and returns the drafts that are due for
+// a preview update: those that changed and were not published within the
+// last ToolCallUpdateThrottle, so long Write contents don't flood the UI.
+func (d toolCallDrafts) apply(deltas []sdk.ChatCompletionMessageToolCallChunk, now time.Time) []*toolCallDraft {
+	var due []*toolCallDraft
+	for _, delta := range deltas {
+		draft := d[delta.Index]
+		if draft == nil {
+			draft = &toolCallDraft{id: fmt.Sprintf("draft-%d", delta.Index)}
+			d[delta.Index] = draft
+		}
+		if delta.ID != nil && *delta.ID != "" {
+			draft.id = *delta.ID
+		}
+		if delta.Function != nil {
+			if draft.name == "" {
+				draft.name = delta.Function.Name
+			}
+			draft.args.WriteString(delta.Function.Arguments)
+		}
+		if draft.name == "" || now.Sub(draft.lastPublished) < constants.ToolCallUpdateThrottle {
+			continue
+		}
+		draft.lastPublished = now
+		due = append(due, draft)
+	}
+	return due
+}
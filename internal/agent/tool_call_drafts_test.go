@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	require "github.com/stretchr/testify/require"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	constants "github.com/inference-gateway/cli/internal/constants"
+)
+
+func TestToolCallDraftsApply(t *testing.T) {
+	drafts := toolCallDrafts{}
+	now := time.Now()
+
+	due := drafts.apply([]sdk.ChatCompletionMessageToolCallChunk{
+		makeToolCallChunk(0, "call-1", "Write", `{"file_path": "/tmp/a`),
+	}, now)
+	require.Len(t, due, 1)
+	require.Equal(t, "call-1", due[0].id)
+	require.Equal(t, "Write", due[0].name)
+	require.Equal(t, `{"file_path": "/tmp/a`, due[0].args.String())
+
+	due = drafts.apply([]sdk.ChatCompletionMessageToolCallChunk{
+		makeToolCallChunk(0, "", "", `.go", "content": "pack`),
+	}, now.Add(time.Millisecond))
+	require.Empty(t, due, "updates within the throttle window are held back")
+
+	due = drafts.apply([]sdk.ChatCompletionMessageToolCallChunk{
+		makeToolCallChunk(0, "", "", `age main`),
+	}, now.Add(constants.ToolCallUpdateThrottle))
+	require.Len(t, due, 1)
+	require.Equal(t, `{"file_path": "/tmp/a.go", "content": "package main`, due[0].args.String())
+}
+
+func TestToolCallDraftsApplyWaitsForName(t *testing.T) {
+	drafts := toolCallDrafts{}
+	now := time.Now()
+
+	due := drafts.apply([]sdk.ChatCompletionMessageToolCallChunk{makeToolCallChunk(1, "", "", "")}, now)
+	require.Empty(t, due)
+
+	due = drafts.apply([]sdk.ChatCompletionMessageToolCallChunk{makeToolCallChunk(1, "", "Bash", `{"command": "rm`)}, now)
+	require.Len(t, due, 1)
+	require.Equal(t, "draft-1", due[0].id, "a placeholder id is used until the provider sends one")
+}
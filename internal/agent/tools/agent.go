@@ -27,6 +27,10 @@ import (
 // the depth reaches the configured max (a subagent is itself an `infer agent`).
 const subagentDepthEnv = "INFER_SUBAGENT_DEPTH"
 
+// subagentMaxTurnsEnv sets a subagent's turn budget through the regular
+// agent.max_turns environment override.
+const subagentMaxTurnsEnv = "INFER_AGENT_MAX_TURNS"
+
 // subagentSystemPromptEnv carries a per-subagent system prompt to the spawned
 // subagent (read in initConfig), so each subagent can run with its own role.
 const subagentSystemPromptEnv = "INFER_SUBAGENT_SYSTEM_PROMPT"
@@ -42,6 +46,12 @@ type AgentTaskSpec struct {
 	// ReadOnly -> AgentModeReadOnly (Explore-like, no approval), ReadWrite ->
 	// AgentModeStandard (can mutate, approval applies).
 	Mode domain.AgentMode
+	// Tools narrows the subagent's toolset further to these tool names; empty
+	// keeps every tool its Mode allows.
+	Tools []string
+	// MaxTurns is the subagent's turn budget; 0 uses tools.agent.max_turns,
+	// else the subagent's agent.max_turns.
+	MaxTurns int
 }
 
 // AgentSubResult is the per-subagent outcome reported back to the LLM.
@@ -119,6 +129,8 @@ func (t *AgentTool) Definition() sdk.ChatCompletionTool {
 								"model":         map[string]any{"type": "string", "description": "Optional model override for this subagent"},
 								"system_prompt": map[string]any{"type": "string", "description": "Optional system prompt giving THIS subagent a specialized role/persona for its task"},
 								"type":          map[string]any{"type": "string", "enum": []string{"ReadOnly", "ReadWrite"}, "description": "Capability. ReadOnly (default) is Explore-like: read/search tools only, never needs approval - use for investigation/research. ReadWrite can modify files and run commands; its mutations require approval."},
+								"tools":         map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Optional allowlist of tool names (e.g. [\"Read\", \"Grep\", \"Bash\"]) narrowing what this subagent may use within its type"},
+								"max_turns":     map[string]any{"type": "integer", "description": "Optional turn budget: the subagent stops and reports after this many model turns"},
 							},
 							"required": []string{"description"},
 						},
@@ -136,6 +148,15 @@ func (t *AgentTool) Definition() sdk.ChatCompletionTool {
 						"enum":        []string{"ReadOnly", "ReadWrite"},
 						"description": "Capability for the single-task form. ReadOnly (default) is Explore-like: read/search only, never needs approval. ReadWrite can modify files and run commands; mutations require approval.",
 					},
+					"tools": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Optional tool allowlist for the single-task form, narrowing what the subagent may use within its type",
+					},
+					"max_turns": map[string]any{
+						"type":        "integer",
+						"description": "Optional turn budget for the single-task form",
+					},
 				},
 			},
 		},
@@ -186,6 +207,7 @@ func (t *AgentTool) Execute(ctx context.Context, args map[string]any) (*domain.T
 	logger.Debug("agent tool invoked", "mode", mode, "wait", wait, "tasks", len(specs), "parent_session", parentSession)
 	for i := range specs {
 		specs[i].Model = t.resolveModel(specs[i].Model, parentModel)
+		specs[i].MaxTurns = t.resolveMaxTurns(specs[i].MaxTurns)
 	}
 
 	if mode == domain.SubagentModeInteractive {
@@ -441,6 +463,12 @@ func (t *AgentTool) buildChatPaneCommand(spec AgentTaskSpec, sessionID string) s
 	if spec.Mode != domain.AgentModeStandard {
 		parts = append(parts, domain.EnvSubagentAgentMode+"="+shellQuote(spec.Mode.AllowedlistKey()))
 	}
+	if len(spec.Tools) > 0 {
+		parts = append(parts, domain.EnvSubagentTools+"="+shellQuote(strings.Join(spec.Tools, ",")))
+	}
+	if spec.MaxTurns > 0 {
+		parts = append(parts, fmt.Sprintf("%s=%d", subagentMaxTurnsEnv, spec.MaxTurns))
+	}
 	if spec.Model != "" {
 		parts = append(parts, "INFER_AGENT_MODEL="+shellQuote(spec.Model))
 	}
@@ -461,7 +489,8 @@ func (t *AgentTool) buildChatPaneCommand(spec AgentTaskSpec, sessionID string) s
 }
 
 // subagentExtraEnv builds the environment passed to a headless subagent: the
-// depth guard plus an optional per-subagent system prompt and trace context.
+// depth guard plus an optional per-subagent system prompt, toolset, turn
+// budget and trace context.
 func (t *AgentTool) subagentExtraEnv(ctx context.Context, spec AgentTaskSpec) []string {
 	env := []string{fmt.Sprintf("%s=%d", subagentDepthEnv, currentSubagentDepth()+1)}
 	env = append(env, domain.GetTraceEnv(ctx)...)
@@ -471,6 +500,12 @@ func (t *AgentTool) subagentExtraEnv(ctx context.Context, spec AgentTaskSpec) []
 	if spec.Mode != domain.AgentModeStandard {
 		env = append(env, domain.EnvSubagentAgentMode+"="+spec.Mode.AllowedlistKey())
 	}
+	if len(spec.Tools) > 0 {
+		env = append(env, domain.EnvSubagentTools+"="+strings.Join(spec.Tools, ","))
+	}
+	if spec.MaxTurns > 0 {
+		env = append(env, fmt.Sprintf("%s=%d", subagentMaxTurnsEnv, spec.MaxTurns))
+	}
 	if t.config.Gateway.Mock && t.config.Tools.Agent.InheritMock {
 		env = append(env, "INFER_GATEWAY_MOCK=true")
 	}
@@ -647,6 +682,18 @@ func (t *AgentTool) resolveModel(taskModel, parentModel string) string {
 	return parentModel
 }
 
+// resolveMaxTurns picks the subagent turn budget: the per-task max_turns,
+// else tools.agent.max_turns, capped by tools.agent.max_turns when both are
+// set so the model cannot grant a subagent more turns than the operator
+// allows. 0 leaves the subagent on its own agent.max_turns.
+func (t *AgentTool) resolveMaxTurns(taskMaxTurns int) int {
+	limit := t.config.Tools.Agent.MaxTurns
+	if taskMaxTurns <= 0 || (limit > 0 && taskMaxTurns > limit) {
+		return limit
+	}
+	return taskMaxTurns
+}
+
 func (t *AgentTool) errorResult(args map[string]any, start time.Time, msg string) *domain.ToolExecutionResult {
 	return &domain.ToolExecutionResult{
 		ToolName:  "Agent",
@@ -792,6 +839,8 @@ func parseAgentTasks(args map[string]any) ([]AgentTaskSpec, error) {
 				Files:        optionalStringSlice(m, "files"),
 				SystemPrompt: optionalString(m, "system_prompt"),
 				Mode:         resolveSubagentType(optionalString(m, "type")),
+				Tools:        optionalStringSlice(m, "tools"),
+				MaxTurns:     optionalInt(m, "max_turns"),
 			})
 		}
 		return specs, nil
@@ -805,6 +854,8 @@ func parseAgentTasks(args map[string]any) ([]AgentTaskSpec, error) {
 			Files:        optionalStringSlice(args, "files"),
 			SystemPrompt: optionalString(args, "system_prompt"),
 			Mode:         resolveSubagentType(optionalString(args, "type")),
+			Tools:        optionalStringSlice(args, "tools"),
+			MaxTurns:     optionalInt(args, "max_turns"),
 		}}, nil
 	}
 
@@ -836,6 +887,17 @@ func optionalStringSlice(m map[string]any, key string) []string {
 	return out
 }
 
+// optionalInt reads a JSON number argument; absent or non-positive values are 0
+func optionalInt(m map[string]any, key string) int {
+	switch v := m[key].(type) {
+	case float64:
+		return max(int(v), 0)
+	case int:
+		return max(v, 0)
+	}
+	return 0
+}
+
 func toSubResult(spec AgentTaskSpec, sessionID, answer string, err error) AgentSubResult {
 	sub := AgentSubResult{
 		Label:     spec.Label,
@@ -867,6 +929,11 @@ func shortSession(id string) string {
 	return id
 }
 
+// IsSubagentProcess reports whether this process was spawned by the Agent tool.
+func IsSubagentProcess() bool {
+	return currentSubagentDepth() > 0
+}
+
 // currentSubagentDepth reads the recursion depth from the environment (0 at the
 // top level).
 func currentSubagentDepth() int {
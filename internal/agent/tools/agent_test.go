@@ -206,6 +206,44 @@ func TestSubagentExtraEnv_EmitsSubagentMode(t *testing.T) {
 	}
 }
 
+// A subagent's tool allowlist and turn budget travel as env vars, in both the
+// headless env and the tmux pane command; tools.agent.max_turns is the default
+// and caps what the model may ask for.
+func TestSubagentToolsAndMaxTurns(t *testing.T) {
+	tool := newTestAgentTool(t)
+
+	specs, err := parseAgentTasks(map[string]any{"description": "scan", "tools": []any{"Read", "Grep"}, "max_turns": float64(5)})
+	if err != nil || len(specs) != 1 {
+		t.Fatalf("parse failed: specs=%+v err=%v", specs, err)
+	}
+	spec := specs[0]
+	if strings.Join(spec.Tools, ",") != "Read,Grep" || spec.MaxTurns != 5 {
+		t.Fatalf("tools/max_turns not parsed: %+v", spec)
+	}
+
+	env := strings.Join(tool.subagentExtraEnv(context.Background(), spec), " ")
+	if !strings.Contains(env, domain.EnvSubagentTools+"=Read,Grep") || !strings.Contains(env, "INFER_AGENT_MAX_TURNS=5") {
+		t.Fatalf("headless env missing toolset or turn budget; got %q", env)
+	}
+	if cmd := tool.buildChatPaneCommand(spec, "sess"); !strings.Contains(cmd, domain.EnvSubagentTools+"='Read,Grep'") || !strings.Contains(cmd, "INFER_AGENT_MAX_TURNS=5") {
+		t.Fatalf("pane command missing toolset or turn budget; got %q", cmd)
+	}
+	if env := strings.Join(tool.subagentExtraEnv(context.Background(), AgentTaskSpec{}), " "); strings.Contains(env, domain.EnvSubagentTools) || strings.Contains(env, "INFER_AGENT_MAX_TURNS") {
+		t.Fatalf("unrestricted subagent must not set the toolset or turn budget; got %q", env)
+	}
+
+	if got := tool.resolveMaxTurns(5); got != 5 {
+		t.Fatalf("resolveMaxTurns(5) with no limit = %d, want 5", got)
+	}
+	tool.config.Tools.Agent.MaxTurns = 3
+	if got := tool.resolveMaxTurns(0); got != 3 {
+		t.Fatalf("resolveMaxTurns(0) = %d, want the configured default 3", got)
+	}
+	if got := tool.resolveMaxTurns(10); got != 3 {
+		t.Fatalf("resolveMaxTurns(10) = %d, want it capped at 3", got)
+	}
+}
+
 // A mock-mode parent must propagate mock mode to its subagents explicitly:
 // headless subprocesses only inherit env vars (not config/flag state) and tmux
 // panes run under the tmux server's environment, so without this a mock parent
@@ -26,6 +26,7 @@ type GrepTool struct {
 	enabled        bool
 	gitignore      *ignore.GitIgnore
 	gitignoreCache map[string]*ignore.GitIgnore
+	inferignore    *config.Inferignore
 	cacheMutex     sync.RWMutex
 	ripgrepPath    string
 	useRipgrep     bool
@@ -41,6 +42,7 @@ func NewGrepTool(cfg *config.Config) *GrepTool {
 		gitignoreCache: make(map[string]*ignore.GitIgnore),
 	}
 	tool.loadGitignore()
+	tool.inferignore = config.LoadInferignore(".")
 	tool.detectRipgrep()
 	return tool
 }
@@ -369,6 +371,9 @@ func (t *GrepTool) buildRipgrepArgs(outputMode string, args map[string]any) []st
 
 	rgArgs = t.addOutputModeArgs(rgArgs, outputMode, args)
 	rgArgs = t.addSearchOptions(rgArgs, args)
+	if t.inferignore != nil {
+		rgArgs = append(rgArgs, "--ignore-file", t.inferignore.Path())
+	}
 
 	return rgArgs
 }
@@ -687,10 +692,13 @@ func (t *GrepTool) searchFiles(ctx context.Context, regex *regexp.Regexp, search
 		}
 
 		if d.IsDir() {
+			if path != searchPath && t.inferignore.Excludes(path) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
-		if t.isPathExcluded(path) {
+		if t.isPathExcluded(path) || t.inferignore.Excludes(path) {
 			return nil
 		}
 
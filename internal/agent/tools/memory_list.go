@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+// MemoryEntry is one stored fact as listed by `infer memory list`.
+type MemoryEntry struct {
+	Name        string    `json:"name"` // index key: "slug" or "project/slug"
+	Type        string    `json:"type,omitempty"`
+	Project     string    `json:"project,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Session     string    `json:"session,omitempty"`
+	Path        string    `json:"path"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListMemories reads every fact-file in the memory dir: the global facts at
+// its root and the project facts one level down. Hidden directories (the git
+// backend's .git) and the MEMORY.md index are skipped; a missing dir yields no
+// entries. Entries are sorted by name.
+func ListMemories(dir string) ([]MemoryEntry, error) {
+	top, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []MemoryEntry
+	for _, item := range top {
+		if strings.HasPrefix(item.Name(), ".") {
+			continue
+		}
+		if !item.IsDir() {
+			if entry, ok := readMemoryEntry(filepath.Join(dir, item.Name()), ""); ok {
+				entries = append(entries, entry)
+			}
+			continue
+		}
+
+		projectFiles, err := os.ReadDir(filepath.Join(dir, item.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range projectFiles {
+			if !file.IsDir() {
+				if entry, ok := readMemoryEntry(filepath.Join(dir, item.Name(), file.Name()), item.Name()); ok {
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// readMemoryEntry parses the frontmatter of the fact-file at path. ok is false
+// for the index and for files that are not fact-files.
+func readMemoryEntry(path, projectSlug string) (MemoryEntry, bool) {
+	base := filepath.Base(path)
+	if base == config.MemoryIndexFileName || filepath.Ext(base) != ".md" {
+		return MemoryEntry{}, false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return MemoryEntry{}, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return MemoryEntry{}, false
+	}
+
+	entry := MemoryEntry{
+		Name:      joinKey(projectSlug, strings.TrimSuffix(base, ".md")),
+		Path:      path,
+		UpdatedAt: info.ModTime(),
+	}
+	rest, found := bytes.CutPrefix(content, []byte("---\n"))
+	if !found {
+		return entry, true
+	}
+	frontmatter, _, found := bytes.Cut(rest, []byte("\n---"))
+	if !found {
+		return entry, true
+	}
+	var fm memoryFrontmatter
+	if err := yaml.Unmarshal(frontmatter, &fm); err == nil {
+		entry.Type = fm.Metadata.Type
+		entry.Project = fm.Metadata.Project
+		entry.Description = fm.Description
+		entry.Session = fm.Metadata.Session
+	}
+	return entry, true
+}
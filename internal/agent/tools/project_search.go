@@ -0,0 +1,258 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	projectindex "github.com/inference-gateway/cli/internal/services/projectindex"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// projectSearchDefaultLimit and projectSearchMaxLimit bound how many files a
+// search returns
+const (
+	projectSearchDefaultLimit = 10
+	projectSearchMaxLimit     = 50
+)
+
+// ProjectSearchTool ranks the project's files against a query using the index
+// infer index builds, so the model can find where a topic lives without
+// knowing the names to grep for. The index matches terms, not meaning: its
+// vectors are hashed counts of the words and identifiers in each file.
+type ProjectSearchTool struct {
+	config    *config.Config
+	enabled   bool
+	indexPath string
+	formatter domain.BaseFormatter
+}
+
+// NewProjectSearchTool creates a new project search tool. It is enabled only
+// when the project has an index, so a project without one doesn't offer a
+// tool that can only fail.
+func NewProjectSearchTool(cfg *config.Config) *ProjectSearchTool {
+	indexPath := filepath.FromSlash(projectindex.DefaultPath)
+	_, err := os.Stat(indexPath)
+	return &ProjectSearchTool{
+		config:    cfg,
+		enabled:   cfg.Tools.Enabled && cfg.Tools.ProjectSearch.Enabled && err == nil,
+		indexPath: indexPath,
+		formatter: domain.NewBaseFormatter("ProjectSearch"),
+	}
+}
+
+// Definition returns the tool definition for the LLM
+func (t *ProjectSearchTool) Definition() sdk.ChatCompletionTool {
+	description := t.config.Prompts.Tools.ProjectSearch.Description
+	return sdk.ChatCompletionTool{
+		Type: sdk.Function,
+		Function: sdk.FunctionObject{
+			Name:        "ProjectSearch",
+			Description: &description,
+			Parameters: &sdk.FunctionParameters{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Words and identifiers describing what to find, e.g. \"refresh oauth token\"",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of files to return (optional, defaults to 10)",
+						"minimum":     1,
+						"maximum":     projectSearchMaxLimit,
+						"default":     projectSearchDefaultLimit,
+					},
+				},
+				"required": []string{"query"},
+			},
+		},
+	}
+}
+
+// Execute searches the project index
+func (t *ProjectSearchTool) Execute(ctx context.Context, args map[string]any) (*domain.ToolExecutionResult, error) {
+	start := time.Now()
+	if err := t.Validate(args); err != nil {
+		return nil, err
+	}
+
+	query, _ := args["query"].(string)
+	limit := projectSearchDefaultLimit
+	if limitFloat, ok := args["limit"].(float64); ok {
+		limit = int(limitFloat)
+	}
+
+	idx, err := projectindex.Load(t.indexPath)
+	if err == nil && idx == nil {
+		err = fmt.Errorf("no project index yet; build it with 'infer index'")
+	}
+	if err != nil {
+		return &domain.ToolExecutionResult{
+			ToolName:  "ProjectSearch",
+			Arguments: args,
+			Success:   false,
+			Duration:  time.Since(start),
+			Error:     err.Error(),
+		}, nil
+	}
+
+	data := &domain.ProjectSearchToolResult{Query: query, IndexedAt: idx.UpdatedAt}
+	for _, match := range projectindex.Search(idx, query, limit) {
+		data.Matches = append(data.Matches, domain.ProjectSearchMatch{
+			Path:    match.Path,
+			Score:   match.Score,
+			Summary: match.Summary,
+		})
+	}
+
+	return &domain.ToolExecutionResult{
+		ToolName:  "ProjectSearch",
+		Arguments: args,
+		Success:   true,
+		Duration:  time.Since(start),
+		Data:      data,
+	}, nil
+}
+
+// Validate checks if the project search arguments are valid
+func (t *ProjectSearchTool) Validate(args map[string]any) error {
+	if !t.config.Tools.Enabled {
+		return fmt.Errorf("project search tool is not enabled")
+	}
+
+	query, ok := args["query"].(string)
+	if !ok || strings.TrimSpace(query) == "" {
+		return fmt.Errorf("query is required and must be a non-empty string")
+	}
+
+	if limit, ok := args["limit"]; ok {
+		limitFloat, isFloat := limit.(float64)
+		if !isFloat {
+			return fmt.Errorf("limit must be a number")
+		}
+		if limitFloat < 1 || limitFloat > projectSearchMaxLimit {
+			return fmt.Errorf("limit must be between 1 and %d", projectSearchMaxLimit)
+		}
+	}
+
+	return nil
+}
+
+// IsEnabled returns whether the project search tool is enabled
+func (t *ProjectSearchTool) IsEnabled() bool {
+	return t.enabled
+}
+
+// FormatResult formats tool execution results for different contexts
+func (t *ProjectSearchTool) FormatResult(result *domain.ToolExecutionResult, formatType domain.FormatterType) string {
+	switch formatType {
+	case domain.FormatterUI:
+		return t.FormatForUI(result)
+	case domain.FormatterLLM:
+		return t.FormatForLLM(result)
+	case domain.FormatterShort:
+		return t.FormatPreview(result)
+	default:
+		return t.FormatForUI(result)
+	}
+}
+
+// FormatPreview returns a short preview of the result for UI display
+func (t *ProjectSearchTool) FormatPreview(result *domain.ToolExecutionResult) string {
+	if result == nil {
+		return "Tool execution result unavailable"
+	}
+
+	data, ok := result.Data.(*domain.ProjectSearchToolResult)
+	if !ok {
+		if result.Success {
+			return "Project search completed"
+		}
+		return "Project search failed"
+	}
+
+	switch len(data.Matches) {
+	case 0:
+		return "No matching files"
+	case 1:
+		return "1 matching file"
+	default:
+		return fmt.Sprintf("%d matching files", len(data.Matches))
+	}
+}
+
+// FormatResultBody returns the matched paths for the collapsed preview
+func (t *ProjectSearchTool) FormatResultBody(result *domain.ToolExecutionResult) string {
+	if result == nil {
+		return ""
+	}
+
+	data, ok := result.Data.(*domain.ProjectSearchToolResult)
+	if !ok {
+		return ""
+	}
+	paths := make([]string, 0, len(data.Matches))
+	for _, match := range data.Matches {
+		paths = append(paths, match.Path)
+	}
+	return strings.Join(paths, "\n")
+}
+
+// FormatForUI formats the result for UI display
+func (t *ProjectSearchTool) FormatForUI(result *domain.ToolExecutionResult) string {
+	if result == nil {
+		return "Tool execution result unavailable"
+	}
+
+	toolCall := t.formatter.FormatToolCall(result.Arguments, false)
+	statusIcon := t.formatter.FormatStatusIcon(result.Success)
+	preview := t.FormatPreview(result)
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "%s\n", toolCall)
+	fmt.Fprintf(&output, "└─ %s %s", statusIcon, preview)
+
+	return output.String()
+}
+
+// FormatForLLM formats the result for LLM consumption: the ranked files with
+// their summaries, and the index's age so the model knows how fresh it is
+func (t *ProjectSearchTool) FormatForLLM(result *domain.ToolExecutionResult) string {
+	if result == nil {
+		return "Tool execution result unavailable"
+	}
+
+	var dataContent string
+	if data, ok := result.Data.(*domain.ProjectSearchToolResult); ok {
+		var output strings.Builder
+		fmt.Fprintf(&output, "Query: %s\n", data.Query)
+		fmt.Fprintf(&output, "Index built: %s\n", data.IndexedAt.Format(time.RFC3339))
+		if len(data.Matches) == 0 {
+			output.WriteString("\nNo indexed file matches the query.\n")
+		}
+		for _, match := range data.Matches {
+			fmt.Fprintf(&output, "\n%s (%.2f)", match.Path, match.Score)
+			if match.Summary != "" {
+				fmt.Fprintf(&output, " - %s", match.Summary)
+			}
+		}
+		dataContent = output.String()
+	}
+	return t.formatter.FormatExpanded(result, dataContent)
+}
+
+// ShouldCollapseArg determines if an argument should be collapsed in display
+func (t *ProjectSearchTool) ShouldCollapseArg(key string) bool {
+	return false
+}
+
+// ShouldAlwaysExpand determines if tool results should always be expanded in UI
+func (t *ProjectSearchTool) ShouldAlwaysExpand() bool {
+	return false
+}
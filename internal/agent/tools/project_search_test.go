@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/inference-gateway/cli/config"
+	"github.com/inference-gateway/cli/internal/domain"
+	projectindex "github.com/inference-gateway/cli/internal/services/projectindex"
+)
+
+func projectSearchConfig() *config.Config {
+	return &config.Config{
+		Tools: config.ToolsConfig{
+			Enabled:       true,
+			ProjectSearch: config.ProjectSearchToolConfig{Enabled: true},
+		},
+		Prompts: *config.DefaultPromptsConfig(),
+	}
+}
+
+func TestProjectSearchTool_DisabledWithoutIndex(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if NewProjectSearchTool(projectSearchConfig()).IsEnabled() {
+		t.Error("ProjectSearch should not be offered before the project is indexed")
+	}
+}
+
+func TestProjectSearchTool_Execute(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"auth/token.go": "package auth\n\n// RefreshToken renews an expired oauth token.\nfunc RefreshToken() {}\n",
+		"ui/theme.go":   "package ui\n\n// Theme holds the palette.\ntype Theme struct{}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	idx, _, err := projectindex.Build(context.Background(), projectindex.Options{Root: dir, Full: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := projectindex.Save(filepath.Join(dir, projectindex.DefaultPath), idx); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	tool := NewProjectSearchTool(projectSearchConfig())
+	if !tool.IsEnabled() {
+		t.Fatal("ProjectSearch should be enabled once the project is indexed")
+	}
+
+	result, err := tool.Execute(context.Background(), map[string]any{"query": "refresh oauth token", "limit": float64(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, ok := result.Data.(*domain.ProjectSearchToolResult)
+	if !result.Success || !ok || len(data.Matches) != 1 || data.Matches[0].Path != "auth/token.go" {
+		t.Fatalf("Execute() = %+v, want auth/token.go as the only match", result)
+	}
+
+	if err := tool.Validate(map[string]any{"query": " "}); err == nil {
+		t.Error("Validate() should reject an empty query")
+	}
+	if err := tool.Validate(map[string]any{"query": "x", "limit": float64(100)}); err == nil {
+		t.Error("Validate() should reject a limit above the maximum")
+	}
+}
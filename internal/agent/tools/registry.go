@@ -146,6 +146,7 @@ func (r *Registry) registerTools() {
 	r.tools["Delete"] = NewDeleteTool(cfg)
 	r.tools["Grep"] = NewGrepTool(cfg)
 	r.tools["Tree"] = NewTreeTool(cfg)
+	r.tools["ProjectSearch"] = NewProjectSearchTool(cfg)
 	r.tools["TodoWrite"] = NewTodoWriteTool(cfg)
 
 	var planStore storage.PlanStorage
@@ -155,6 +156,9 @@ func (r *Registry) registerTools() {
 		jobStore = r.stores.ScheduledJobs
 	}
 	r.tools["RequestPlanApproval"] = NewRequestPlanApprovalTool(cfg, planStore)
+	if planSteps, ok := r.stateManager.(domain.PlanStepManager); ok {
+		r.tools["UpdatePlanStep"] = NewUpdatePlanStepTool(cfg, planSteps)
+	}
 
 	if cfg.Tools.AskUserQuestion.Enabled {
 		r.tools["AskUserQuestion"] = NewAskUserQuestionTool(cfg)
@@ -215,14 +215,7 @@ func optionalBool(args map[string]any, key string) bool {
 // We only check the channel's *enabled* flag - runtime registration is the
 // daemon's job.
 func (t *ScheduleTool) channelConfigured(name string) bool {
-	switch strings.ToLower(name) {
-	case "telegram":
-		return t.config.Channels.Telegram.Enabled
-	case "whatsapp":
-		return t.config.Channels.WhatsApp.Enabled
-	default:
-		return false
-	}
+	return t.config.Channels.ChannelEnabled(name)
 }
 
 func (t *ScheduleTool) execCreate(ctx context.Context, args map[string]any, store storage.ScheduledJobStorage, start time.Time) (*domain.ToolExecutionResult, error) {
@@ -24,6 +24,7 @@ type TreeTool struct {
 	enabled        bool
 	gitignore      *ignore.GitIgnore
 	gitignoreCache map[string]*ignore.GitIgnore
+	inferignore    *config.Inferignore
 	cacheMutex     sync.RWMutex
 	formatter      domain.BaseFormatter
 }
@@ -37,6 +38,7 @@ func NewTreeTool(cfg *config.Config) *TreeTool {
 		gitignoreCache: make(map[string]*ignore.GitIgnore),
 	}
 	tool.loadGitignore()
+	tool.inferignore = config.LoadInferignore(".")
 	return tool
 }
 
@@ -424,8 +426,12 @@ func (t *TreeTool) processDirectory(dirPath, entryName string, maxDepth int, sho
 	return subFiles, subDirs, subTruncated
 }
 
-// shouldExclude checks if a filename should be excluded based on gitignore
+// shouldExclude checks if a filename should be excluded based on .inferignore
+// and, when respected, gitignore
 func (t *TreeTool) shouldExclude(fullPath string, _ /* name */ string, respectGitignore bool) bool {
+	if t.inferignore.Excludes(fullPath) {
+		return true
+	}
 	if respectGitignore && t.isPathExcludedByGitignore(fullPath) {
 		return true
 	}
@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	colors "github.com/inference-gateway/cli/internal/ui/styles/colors"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// UpdatePlanStepTool lets the agent check off the numbered steps of the
+// approved plan. The checklist lives in the state manager; this tool only
+// moves one step's status at a time.
+type UpdatePlanStepTool struct {
+	config    *config.Config
+	enabled   bool
+	formatter domain.BaseFormatter
+	steps     domain.PlanStepManager
+}
+
+// NewUpdatePlanStepTool creates a new UpdatePlanStep tool
+func NewUpdatePlanStepTool(cfg *config.Config, steps domain.PlanStepManager) *UpdatePlanStepTool {
+	return &UpdatePlanStepTool{
+		config:    cfg,
+		enabled:   true,
+		formatter: domain.NewBaseFormatter("UpdatePlanStep"),
+		steps:     steps,
+	}
+}
+
+// Definition returns the tool definition for the LLM
+func (t *UpdatePlanStepTool) Definition() sdk.ChatCompletionTool {
+	description := t.config.Prompts.Tools.UpdatePlanStep.Description
+	return sdk.ChatCompletionTool{
+		Type: sdk.Function,
+		Function: sdk.FunctionObject{
+			Name:        "UpdatePlanStep",
+			Description: &description,
+			Parameters: &sdk.FunctionParameters{
+				"$schema":              "http://json-schema.org/draft-07/schema#",
+				"additionalProperties": false,
+				"type":                 "object",
+				"required":             []string{"step", "status"},
+				"properties": map[string]any{
+					"step": map[string]any{
+						"type":        "integer",
+						"minimum":     1,
+						"description": "The 1-based number of the plan step",
+					},
+					"status": map[string]any{
+						"type": "string",
+						"enum": []string{"pending", "in_progress", "completed"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Execute sets the status of one plan step
+func (t *UpdatePlanStepTool) Execute(ctx context.Context, args map[string]any) (*domain.ToolExecutionResult, error) {
+	start := time.Now()
+
+	number, status, err := extractPlanStepArgs(args)
+	if err != nil {
+		return t.failure(args, start, err), nil
+	}
+
+	step, err := t.steps.UpdatePlanStep(number, status)
+	if err != nil {
+		return t.failure(args, start, err), nil
+	}
+
+	return &domain.ToolExecutionResult{
+		ToolName:  "UpdatePlanStep",
+		Arguments: args,
+		Success:   true,
+		Duration:  time.Since(start),
+		Data: &domain.PlanStepsToolResult{
+			Step:  step,
+			Steps: t.steps.GetPlanSteps(),
+		},
+	}, nil
+}
+
+func (t *UpdatePlanStepTool) failure(args map[string]any, start time.Time, err error) *domain.ToolExecutionResult {
+	return &domain.ToolExecutionResult{
+		ToolName:  "UpdatePlanStep",
+		Arguments: args,
+		Success:   false,
+		Duration:  time.Since(start),
+		Error:     err.Error(),
+	}
+}
+
+// Validate checks if the UpdatePlanStep tool arguments are valid
+func (t *UpdatePlanStepTool) Validate(args map[string]any) error {
+	_, _, err := extractPlanStepArgs(args)
+	return err
+}
+
+// IsEnabled returns whether the UpdatePlanStep tool is enabled
+func (t *UpdatePlanStepTool) IsEnabled() bool {
+	return t.enabled
+}
+
+// extractPlanStepArgs pulls `step` and `status` from the raw argument map.
+// JSON numbers arrive as float64.
+func extractPlanStepArgs(args map[string]any) (int, string, error) {
+	rawStep, ok := args["step"].(float64)
+	if !ok || rawStep != float64(int(rawStep)) || rawStep < 1 {
+		return 0, "", fmt.Errorf("step parameter is required and must be a positive integer")
+	}
+
+	status, _ := args["status"].(string)
+	switch status {
+	case "pending", "in_progress", "completed":
+	default:
+		return 0, "", fmt.Errorf("status must be one of: pending, in_progress, completed")
+	}
+	return int(rawStep), status, nil
+}
+
+// FormatResult formats tool execution results for different contexts
+func (t *UpdatePlanStepTool) FormatResult(result *domain.ToolExecutionResult, formatType domain.FormatterType) string {
+	switch formatType {
+	case domain.FormatterUI:
+		return t.FormatForUI(result)
+	case domain.FormatterLLM:
+		return t.FormatForLLM(result)
+	case domain.FormatterShort:
+		return t.FormatPreview(result)
+	default:
+		return t.FormatForUI(result)
+	}
+}
+
+// FormatPreview returns a short preview of the result for UI display
+func (t *UpdatePlanStepTool) FormatPreview(result *domain.ToolExecutionResult) string {
+	if result == nil {
+		return "Tool execution result unavailable"
+	}
+
+	stepsResult, ok := result.Data.(*domain.PlanStepsToolResult)
+	if !ok {
+		if result.Success {
+			return "Plan step updated"
+		}
+		return "Plan step update failed"
+	}
+
+	completed := countCompletedPlanSteps(stepsResult.Steps)
+	return fmt.Sprintf("Step %d %s (%d/%d steps done)", stepsResult.Step.Number,
+		strings.ReplaceAll(stepsResult.Step.Status, "_", " "), completed, len(stepsResult.Steps))
+}
+
+// FormatForUI formats the result for UI display. The checklist itself is
+// shown next to the todo list, so this stays to one line.
+func (t *UpdatePlanStepTool) FormatForUI(result *domain.ToolExecutionResult) string {
+	if result == nil {
+		return "Tool execution result unavailable"
+	}
+
+	statusIcon := t.formatter.FormatStatusIcon(result.Success)
+	return fmt.Sprintf("UpdatePlanStep(...)\n└─ %s %s", statusIcon, t.FormatPreview(result))
+}
+
+// FormatForLLM formats the result for LLM consumption with the whole checklist
+func (t *UpdatePlanStepTool) FormatForLLM(result *domain.ToolExecutionResult) string {
+	if result == nil {
+		return "Tool execution result unavailable"
+	}
+
+	var dataContent string
+	if stepsResult, ok := result.Data.(*domain.PlanStepsToolResult); ok {
+		dataContent = t.formatSteps(stepsResult.Steps)
+	}
+	return t.formatter.FormatExpanded(result, dataContent)
+}
+
+func (t *UpdatePlanStepTool) formatSteps(steps []domain.PlanStep) string {
+	var output strings.Builder
+
+	header := colors.CreateColoredText("Plan Steps", colors.AccentColor)
+	completionText := colors.CreateColoredText(fmt.Sprintf("(%d/%d completed)", countCompletedPlanSteps(steps), len(steps)), colors.DimColor)
+	fmt.Fprintf(&output, "%s %s\n\n", header, completionText)
+
+	for _, step := range steps {
+		fmt.Fprintf(&output, "%d. [%s] %s\n", step.Number, step.Status, step.Content)
+	}
+	return output.String()
+}
+
+func countCompletedPlanSteps(steps []domain.PlanStep) int {
+	completed := 0
+	for _, step := range steps {
+		if step.Status == "completed" {
+			completed++
+		}
+	}
+	return completed
+}
+
+// ShouldCollapseArg determines if an argument should be collapsed in display
+func (t *UpdatePlanStepTool) ShouldCollapseArg(key string) bool {
+	return false
+}
+
+// ShouldAlwaysExpand determines if tool results should always be expanded in UI
+func (t *UpdatePlanStepTool) ShouldAlwaysExpand() bool {
+	return false
+}
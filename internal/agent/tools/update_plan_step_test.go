@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inference-gateway/cli/config"
+	"github.com/inference-gateway/cli/internal/domain"
+)
+
+// planStepsState is an in-memory domain.PlanStepManager for the tool tests
+type planStepsState struct {
+	state *domain.ApplicationState
+}
+
+func (p *planStepsState) SetPlanSteps(steps []domain.PlanStep) { p.state.SetPlanSteps(steps) }
+func (p *planStepsState) GetPlanSteps() []domain.PlanStep      { return p.state.GetPlanSteps() }
+func (p *planStepsState) UpdatePlanStep(number int, status string) (domain.PlanStep, error) {
+	return p.state.UpdatePlanStep(number, status)
+}
+
+func TestUpdatePlanStepTool_Execute(t *testing.T) {
+	cfg := &config.Config{Prompts: *config.DefaultPromptsConfig()}
+	steps := &planStepsState{state: domain.NewApplicationState()}
+	tool := NewUpdatePlanStepTool(cfg, steps)
+
+	if def := tool.Definition(); def.Function.Name != "UpdatePlanStep" || *def.Function.Description == "" {
+		t.Fatalf("unexpected definition %+v", def.Function)
+	}
+
+	args := map[string]any{"step": float64(1), "status": "completed"}
+	result, err := tool.Execute(context.Background(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success {
+		t.Fatal("updating a step without an approved plan should fail")
+	}
+
+	steps.SetPlanSteps(domain.ParsePlanSteps("1. Add middleware\n2. Add tests"))
+	result, err = tool.Execute(context.Background(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success {
+		t.Fatalf("Execute failed: %s", result.Error)
+	}
+	data, ok := result.Data.(*domain.PlanStepsToolResult)
+	if !ok || data.Step.Number != 1 || data.Steps[0].Status != "completed" || data.Steps[1].Status != "pending" {
+		t.Errorf("unexpected result data %+v", result.Data)
+	}
+	if got := tool.FormatPreview(result); got != "Step 1 completed (1/2 steps done)" {
+		t.Errorf("preview = %q", got)
+	}
+
+	for _, bad := range []map[string]any{
+		{"step": float64(0), "status": "completed"},
+		{"step": 1.5, "status": "completed"},
+		{"step": float64(1), "status": "done"},
+		{"status": "completed"},
+	} {
+		if err := tool.Validate(bad); err == nil {
+			t.Errorf("Validate(%v) should fail", bad)
+		}
+	}
+}
@@ -204,7 +204,18 @@ func (t *WebFetchTool) fetchHTTPContent(ctx context.Context, url string) (*domai
 		sizeWarning = true
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, t.config.Tools.WebFetch.Safety.MaxSize))
+	limit := t.config.Tools.WebFetch.Safety.MaxSize
+	progress := &fetchProgressReader{
+		reader: io.LimitReader(resp.Body, limit),
+		ctx:    ctx,
+		total:  resp.ContentLength,
+		format: t.formatSize,
+	}
+	if progress.total > limit {
+		progress.total = limit
+	}
+
+	body, err := io.ReadAll(progress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
@@ -233,6 +244,31 @@ func (t *WebFetchTool) fetchHTTPContent(ctx context.Context, url string) (*domai
 	return result, nil
 }
 
+// fetchProgressReader reports how much of a response body has been read to
+// the tool progress callback in ctx. Without a Content-Length the total is
+// unknown and only the byte count is reported.
+type fetchProgressReader struct {
+	reader io.Reader
+	ctx    context.Context
+	total  int64
+	read   int64
+	format func(int64) string
+}
+
+func (r *fetchProgressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if n > 0 {
+		if r.total > 0 {
+			domain.ReportToolProgress(r.ctx, float64(r.read)*100/float64(r.total),
+				fmt.Sprintf("%s of %s", r.format(r.read), r.format(r.total)))
+		} else {
+			domain.ReportToolProgress(r.ctx, -1, r.format(r.read)+" received")
+		}
+	}
+	return n, err
+}
+
 // isBinaryContent reports whether a fetched body is non-text and therefore must
 // not be inlined into the LLM context (raw bytes tokenize into garbage and can
 // blow the context window). Content-Type is the primary signal; when it is
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -386,3 +387,32 @@ func TestFetchTool_Execute_ChannelImageDisplayHint(t *testing.T) {
 		t.Error("raw binary bytes leaked into Content")
 	}
 }
+
+// TestFetchTool_Execute_ReportsProgress checks that the body download is
+// reported to the progress callback, ending at 100% of the Content-Length.
+func TestFetchTool_Execute_ReportsProgress(t *testing.T) {
+	page := strings.Repeat("a", 64*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Length", strconv.Itoa(len(page)))
+		_, _ = w.Write([]byte(page))
+	}))
+	defer srv.Close()
+
+	var reports []domain.ToolProgress
+	ctx := domain.WithToolProgressCallback(context.Background(), func(progress domain.ToolProgress) {
+		reports = append(reports, progress)
+	})
+
+	tool := newHTTPTestFetchTool(t)
+	if _, err := tool.Execute(ctx, map[string]any{"url": srv.URL}); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(reports) == 0 {
+		t.Fatal("expected progress reports while reading the body")
+	}
+	last := reports[len(reports)-1]
+	if last.Percent != 100 || !strings.Contains(last.Step, "64.0 KB of 64.0 KB") {
+		t.Errorf("unexpected final report %+v", last)
+	}
+}
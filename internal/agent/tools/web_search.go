@@ -14,6 +14,7 @@ import (
 
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 	sdk "github.com/inference-gateway/sdk"
 )
 
@@ -21,6 +22,7 @@ import (
 type WebSearchTool struct {
 	config    *config.Config
 	client    *http.Client
+	fetcher   *WebFetchTool
 	enabled   bool
 	formatter domain.BaseFormatter
 }
@@ -32,6 +34,7 @@ func NewWebSearchTool(cfg *config.Config) *WebSearchTool {
 		client: &http.Client{
 			Timeout: time.Duration(cfg.Tools.WebSearch.Timeout) * time.Second,
 		},
+		fetcher:   NewWebFetchTool(cfg),
 		enabled:   cfg.Tools.Enabled && cfg.Tools.WebSearch.Enabled,
 		formatter: domain.NewBaseFormatter("WebSearch"),
 	}
@@ -48,39 +51,51 @@ func (t *WebSearchTool) engines() []string {
 // Definition returns the tool definition for the LLM
 func (t *WebSearchTool) Definition() sdk.ChatCompletionTool {
 	description := t.config.Prompts.Tools.WebSearch.Description
+	properties := map[string]any{
+		"query": map[string]any{
+			"type":        "string",
+			"description": "The search query to execute",
+		},
+		"engine": map[string]any{
+			"type":        "string",
+			"description": fmt.Sprintf("The search engine to use (%s). %s is recommended for reliable results.", strings.Join(t.engines(), " or "), t.config.Tools.WebSearch.DefaultEngine),
+			"enum":        t.engines(),
+			"default":     t.config.Tools.WebSearch.DefaultEngine,
+		},
+		"limit": map[string]any{
+			"type":        "integer",
+			"description": "Maximum number of search results to return",
+			"minimum":     1,
+			"maximum":     50,
+			"default":     t.config.Tools.WebSearch.MaxResults,
+		},
+		"format": map[string]any{
+			"type":        "string",
+			"description": "Output format (text or json)",
+			"enum":        []string{"text", "json"},
+			"default":     "text",
+		},
+	}
+
+	if t.fetchEnabled() {
+		properties["fetch_top"] = map[string]any{
+			"type":        "integer",
+			"description": "Fetch the top N results and attach a short summary of each page (only domains allowed by WebFetch are fetched). Set to 0 to return search results only.",
+			"minimum":     0,
+			"maximum":     maxSearchFetchTop,
+			"default":     t.config.Tools.WebSearch.Fetch.TopN,
+		}
+	}
+
 	return sdk.ChatCompletionTool{
 		Type: sdk.Function,
 		Function: sdk.FunctionObject{
 			Name:        "WebSearch",
 			Description: &description,
 			Parameters: &sdk.FunctionParameters{
-				"type": "object",
-				"properties": map[string]any{
-					"query": map[string]any{
-						"type":        "string",
-						"description": "The search query to execute",
-					},
-					"engine": map[string]any{
-						"type":        "string",
-						"description": fmt.Sprintf("The search engine to use (%s). %s is recommended for reliable results.", strings.Join(t.engines(), " or "), t.config.Tools.WebSearch.DefaultEngine),
-						"enum":        t.engines(),
-						"default":     t.config.Tools.WebSearch.DefaultEngine,
-					},
-					"limit": map[string]any{
-						"type":        "integer",
-						"description": "Maximum number of search results to return",
-						"minimum":     1,
-						"maximum":     50,
-						"default":     t.config.Tools.WebSearch.MaxResults,
-					},
-					"format": map[string]any{
-						"type":        "string",
-						"description": "Output format (text or json)",
-						"enum":        []string{"text", "json"},
-						"default":     "text",
-					},
-				},
-				"required": []string{"query"},
+				"type":       "object",
+				"properties": properties,
+				"required":   []string{"query"},
 			},
 		},
 	}
@@ -145,9 +160,14 @@ func (t *WebSearchTool) Execute(ctx context.Context, args map[string]any) (*doma
 
 	if err != nil {
 		result.Error = err.Error()
-	} else {
-		result.Data = searchResult
+		return result, nil
+	}
+
+	if fetchTop := t.resolveFetchTop(args); fetchTop > 0 {
+		t.fetchTopResults(ctx, searchResult.Results, fetchTop)
+		result.Duration = time.Since(start)
 	}
+	result.Data = searchResult
 
 	return result, nil
 }
@@ -187,6 +207,12 @@ func (t *WebSearchTool) Validate(args map[string]any) error {
 		}
 	}
 
+	if args["fetch_top"] != nil {
+		if err := t.validateFetchTop(args["fetch_top"]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -261,7 +287,7 @@ func (t *WebSearchTool) searchDuckDuckGo(ctx context.Context, query string, limi
 
 // performGoogleSearch performs the actual Google search
 func (t *WebSearchTool) performGoogleSearch(ctx context.Context, query string, limit int) ([]domain.WebSearchResult, error) {
-	apiKey := os.Getenv("GOOGLE_SEARCH_API_KEY")
+	apiKey := keyring.Getenv("GOOGLE_SEARCH_API_KEY")
 	searchEngineID := os.Getenv("GOOGLE_SEARCH_ENGINE_ID")
 
 	if apiKey != "" && searchEngineID != "" {
@@ -311,7 +337,7 @@ func (t *WebSearchTool) performGoogleScraping(_ /* ctx */ context.Context, query
 
 // performDuckDuckGoSearch performs the actual DuckDuckGo search
 func (t *WebSearchTool) performDuckDuckGoSearch(ctx context.Context, query string, limit int) ([]domain.WebSearchResult, error) {
-	apiKey := os.Getenv("DUCKDUCKGO_SEARCH_API_KEY")
+	apiKey := keyring.Getenv("DUCKDUCKGO_SEARCH_API_KEY")
 
 	if apiKey != "" {
 		return t.performDuckDuckGoAPI(ctx, query, limit, apiKey)
@@ -621,8 +647,20 @@ func (t *WebSearchTool) FormatPreview(result *domain.ToolExecutionResult) string
 		return fmt.Sprintf("No results found for '%s'", searchResponse.Query)
 	}
 
-	return fmt.Sprintf("Found %d results for '%s' via %s (%v)",
+	preview := fmt.Sprintf("Found %d results for '%s' via %s (%v)",
 		searchResponse.Total, searchResponse.Query, searchResponse.Engine, searchResponse.Time)
+
+	fetched := 0
+	for _, r := range searchResponse.Results {
+		if r.Summary != "" {
+			fetched++
+		}
+	}
+	if fetched > 0 {
+		preview += fmt.Sprintf(", summarized %d", fetched)
+	}
+
+	return preview
 }
 
 // FormatForUI formats the result for UI display
@@ -683,6 +721,12 @@ func (t *WebSearchTool) formatSearchData(data any) string {
 				snippetPreview := t.formatter.TruncateText(result.Snippet, 150)
 				fmt.Fprintf(&output, "     %s\n", snippetPreview)
 			}
+			if result.Summary != "" {
+				fmt.Fprintf(&output, "     Page summary: %s\n", result.Summary)
+			}
+			if result.FetchError != "" {
+				fmt.Fprintf(&output, "     (not fetched: %s)\n", result.FetchError)
+			}
 			output.WriteString("\n")
 		}
 	}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// maxSearchFetchTop caps how many results a single WebSearch call may fetch,
+// whatever the model asks for, so one search can't fan out into dozens of
+// page downloads.
+const maxSearchFetchTop = 10
+
+var (
+	searchFetchDropBlocks = regexp.MustCompile(`(?is)<(script|style|noscript|svg|head)\b[^>]*>.*?</(script|style|noscript|svg|head)>`)
+	searchFetchTags       = regexp.MustCompile(`(?s)<[^>]*>`)
+	searchFetchSpaces     = regexp.MustCompile(`\s+`)
+)
+
+// fetchEnabled reports whether the search-and-fetch pipeline is available. It
+// needs both the WebSearch fetch option and the WebFetch tool, because every
+// fetched page goes through WebFetch's domain allow-list.
+func (t *WebSearchTool) fetchEnabled() bool {
+	return t.config.Tools.WebSearch.Fetch.Enabled &&
+		t.config.Tools.WebFetch.Enabled &&
+		t.config.Tools.WebSearch.Fetch.TopN >= 0
+}
+
+// resolveFetchTop returns how many results to fetch for this call: the
+// fetch_top argument when given, otherwise the configured default.
+func (t *WebSearchTool) resolveFetchTop(args map[string]any) int {
+	if !t.fetchEnabled() {
+		return 0
+	}
+
+	n := t.config.Tools.WebSearch.Fetch.TopN
+	switch v := args["fetch_top"].(type) {
+	case float64:
+		n = int(v)
+	case int:
+		n = v
+	}
+
+	return max(0, min(n, maxSearchFetchTop))
+}
+
+// validateFetchTop validates the fetch_top parameter
+func (t *WebSearchTool) validateFetchTop(fetchTop any) error {
+	var n int
+	switch v := fetchTop.(type) {
+	case float64:
+		n = int(v)
+	case int:
+		n = v
+	default:
+		return fmt.Errorf("fetch_top parameter must be a number")
+	}
+
+	if n < 0 || n > maxSearchFetchTop {
+		return fmt.Errorf("fetch_top must be between 0 and %d", maxSearchFetchTop)
+	}
+	return nil
+}
+
+// fetchTopResults fetches the first n results concurrently and stores a
+// summary (or the reason it was skipped) on each. Results whose domain is not
+// in the WebFetch allow-list are never requested.
+func (t *WebSearchTool) fetchTopResults(ctx context.Context, results []domain.WebSearchResult, n int) {
+	n = min(n, len(results))
+
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func(r *domain.WebSearchResult) {
+			defer wg.Done()
+			r.Summary, r.FetchError = t.fetchSummary(ctx, r.URL)
+		}(&results[i])
+	}
+	wg.Wait()
+}
+
+// fetchSummary fetches a single result page and reduces it to a short
+// extractive summary.
+func (t *WebSearchTool) fetchSummary(ctx context.Context, url string) (string, string) {
+	if err := t.fetcher.validateURL(url); err != nil {
+		return "", err.Error()
+	}
+
+	fetchResult, err := t.fetcher.fetchContent(ctx, url)
+	if err != nil {
+		return "", err.Error()
+	}
+
+	if fetchResult.Status < 200 || fetchResult.Status >= 300 {
+		return "", fmt.Sprintf("HTTP %d", fetchResult.Status)
+	}
+
+	if isBinaryContent(fetchResult.ContentType, fetchResult.Content) {
+		return "", fmt.Sprintf("binary content (%s)", fetchResult.ContentType)
+	}
+
+	summary := summarizePageText(extractPageText(fetchResult.Content), t.config.Tools.WebSearch.Fetch.MaxSummaryChars)
+	if summary == "" {
+		return "", "page has no readable text"
+	}
+
+	return summary, ""
+}
+
+// extractPageText strips markup, scripts and styles from an HTML page and
+// collapses whitespace. Plain-text bodies pass through mostly unchanged.
+func extractPageText(body string) string {
+	text := searchFetchDropBlocks.ReplaceAllString(body, " ")
+	text = searchFetchTags.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(searchFetchSpaces.ReplaceAllString(text, " "))
+}
+
+// summarizePageText keeps the leading maxChars characters of a page, cut back
+// to the last sentence boundary when one is close enough to the limit that the
+// summary doesn't end mid-sentence. A non-positive maxChars disables the cap.
+func summarizePageText(text string, maxChars int) string {
+	runes := []rune(text)
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return text
+	}
+
+	cut := string(runes[:maxChars])
+	if idx := strings.LastIndexAny(cut, ".!?"); idx >= len(cut)/2 {
+		return cut[:idx+1]
+	}
+
+	return strings.TrimSpace(cut) + "..."
+}
@@ -2,9 +2,13 @@ package tools
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/inference-gateway/cli/config"
+	"github.com/inference-gateway/cli/internal/domain"
 )
 
 func TestWebSearchTool_Definition(t *testing.T) {
@@ -212,3 +216,101 @@ func TestWebSearchTool_Execute_Disabled(t *testing.T) {
 		t.Error("Expected nil result when tool is disabled")
 	}
 }
+
+// newFetchingSearchTool builds a WebSearch tool with the fetch pipeline on and
+// the WebFetch allow-list limited to loopback.
+func newFetchingSearchTool(maxSummaryChars int) *WebSearchTool {
+	cfg := &config.Config{
+		Tools: config.ToolsConfig{
+			Enabled: true,
+			WebSearch: config.WebSearchToolConfig{
+				Enabled: true,
+				Fetch: config.WebSearchFetchConfig{
+					Enabled:         true,
+					TopN:            2,
+					MaxSummaryChars: maxSummaryChars,
+				},
+			},
+			WebFetch: config.WebFetchToolConfig{
+				Enabled:        true,
+				AllowedDomains: []string{"127.0.0.1"},
+				Safety:         config.FetchSafetyConfig{MaxSize: 1024 * 1024, Timeout: 5},
+			},
+		},
+		Prompts: *config.DefaultPromptsConfig(),
+	}
+	return NewWebSearchTool(cfg)
+}
+
+func TestWebSearchTool_FetchTopResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>x</title><script>var secret = 1;</script></head>` +
+			`<body><h1>Go &amp; testing</h1><p>Table-driven tests are idiomatic.</p></body></html>`))
+	}))
+	defer srv.Close()
+
+	tool := newFetchingSearchTool(1500)
+	results := []domain.WebSearchResult{
+		{Title: "allowed", URL: srv.URL + "/a"},
+		{Title: "blocked", URL: "https://not-allowed.example.com/b"},
+		{Title: "beyond top n", URL: srv.URL + "/c"},
+	}
+
+	tool.fetchTopResults(context.Background(), results, tool.resolveFetchTop(map[string]any{}))
+
+	if !strings.Contains(results[0].Summary, "Go & testing Table-driven tests are idiomatic.") {
+		t.Errorf("expected readable summary, got %q", results[0].Summary)
+	}
+	if strings.Contains(results[0].Summary, "secret") {
+		t.Errorf("script content leaked into summary: %q", results[0].Summary)
+	}
+	if results[1].Summary != "" || results[1].FetchError != "domain not allowed" {
+		t.Errorf("expected disallowed domain to be skipped, got summary=%q err=%q", results[1].Summary, results[1].FetchError)
+	}
+	if results[2].Summary != "" || results[2].FetchError != "" {
+		t.Errorf("result beyond fetch_top should be untouched, got %+v", results[2])
+	}
+}
+
+func TestWebSearchTool_ResolveFetchTop(t *testing.T) {
+	tool := newFetchingSearchTool(1500)
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want int
+	}{
+		{name: "config default", args: map[string]any{}, want: 2},
+		{name: "explicit value", args: map[string]any{"fetch_top": float64(4)}, want: 4},
+		{name: "explicit zero disables", args: map[string]any{"fetch_top": float64(0)}, want: 0},
+		{name: "clamped to maximum", args: map[string]any{"fetch_top": float64(99)}, want: maxSearchFetchTop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tool.resolveFetchTop(tt.args); got != tt.want {
+				t.Errorf("resolveFetchTop() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	tool.config.Tools.WebFetch.Enabled = false
+	if got := tool.resolveFetchTop(map[string]any{"fetch_top": float64(3)}); got != 0 {
+		t.Errorf("expected pipeline off without WebFetch, got %d", got)
+	}
+}
+
+func TestSummarizePageText(t *testing.T) {
+	text := "First sentence here. Second sentence is a bit longer than the first one."
+
+	if got := summarizePageText(text, 0); got != text {
+		t.Errorf("expected no cap for maxChars 0, got %q", got)
+	}
+	if got := summarizePageText(text, 36); got != "First sentence here." {
+		t.Errorf("expected cut at sentence boundary, got %q", got)
+	}
+	if got := summarizePageText("no punctuation at all in this text", 10); got != "no punctua..." {
+		t.Errorf("expected hard cut with ellipsis, got %q", got)
+	}
+}
@@ -1,10 +1,13 @@
 package app
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,13 +25,16 @@ import (
 	storage "github.com/inference-gateway/cli/internal/infra/storage"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	services "github.com/inference-gateway/cli/internal/services"
+	checkpoint "github.com/inference-gateway/cli/internal/services/checkpoint"
 	gitdiff "github.com/inference-gateway/cli/internal/services/gitdiff"
 	shortcuts "github.com/inference-gateway/cli/internal/shortcuts"
 	ui "github.com/inference-gateway/cli/internal/ui"
 	autocomplete "github.com/inference-gateway/cli/internal/ui/autocomplete"
 	components "github.com/inference-gateway/cli/internal/ui/components"
 	factory "github.com/inference-gateway/cli/internal/ui/components/factory"
+	graphics "github.com/inference-gateway/cli/internal/ui/graphics"
 	keybinding "github.com/inference-gateway/cli/internal/ui/keybinding"
+	keys "github.com/inference-gateway/cli/internal/ui/keys"
 	styles "github.com/inference-gateway/cli/internal/ui/styles"
 )
 
@@ -36,6 +42,14 @@ import (
 // the snippet attachments tree below the input.
 var actChatFocusAttachments = config.ActionID(config.NamespaceChat, "focus_attachments")
 
+// actChatFocusTodos is the chat-namespace action that moves key focus to the
+// todo list for editing it by hand.
+var actChatFocusTodos = config.ActionID(config.NamespaceChat, "focus_todos")
+
+// actChatFocusQueue is the chat-namespace action that moves key focus to the
+// queued messages for reordering, editing and deleting them.
+var actChatFocusQueue = config.ActionID(config.NamespaceChat, "focus_queue")
+
 // ChatApplication represents the main application model using state management
 type ChatApplication struct {
 	// Dependencies
@@ -72,6 +86,18 @@ type ChatApplication struct {
 	messageQueue domain.MessageQueue
 	mouseEnabled bool
 
+	// Desktop notifications for long turns and pending prompts; nil when
+	// disabled in config.
+	notifications *turnNotifier
+
+	// Sequence of the latest approval prompt, so a bell/flash alert scheduled
+	// for an answered prompt is dropped.
+	approvalAlertSeq int
+
+	// The last regular message sent, kept for the undo send window; nil once
+	// undone or replaced by the next send.
+	lastSent *sentMessage
+
 	// UI components
 	conversationView     ui.ConversationRenderer
 	inputView            ui.InputComponent
@@ -82,6 +108,8 @@ type ChatApplication struct {
 	helpBar              ui.HelpBarComponent
 	queueBoxView         *components.QueueBoxView
 	todoBoxView          *components.TodoBoxView
+	pinnedBoxView        *components.PinnedBoxView
+	sidePanelView        *components.SidePanelView
 	approvalBoxView      *components.ApprovalBoxView
 	questionFormView     *components.QuestionFormView
 	modelSelector        *components.ModelSelectorImpl
@@ -94,6 +122,7 @@ type ChatApplication struct {
 	diffViewer           *components.DiffViewerImpl
 	fileExplorer         *components.FileExplorerImpl
 	helpView             *components.HelpViewImpl
+	commandPalette       *components.CommandPaletteImpl
 	toolsView            *components.ToolsViewImpl
 	a2aAgentsView        *components.A2AAgentsViewImpl
 
@@ -126,6 +155,18 @@ type ChatApplication struct {
 	// tree; the fixed guard bindings live in the package-level guardKeys.
 	focusAttachments key.Binding
 
+	// Config-backed binding that moves key focus to the todo list
+	focusTodos key.Binding
+
+	// Config-backed binding that moves key focus to the message queue, and
+	// the queued message being edited in the input, if any
+	focusQueue key.Binding
+	queueEdit  *queueEditState
+
+	// Hand edits to the todo list waiting to be reported to the agent, held
+	// back while it is busy so the note never lands inside a tool round.
+	pendingTodoSync []domain.TodoItem
+
 	// Track last key handled by keybinding action to prevent double-handling
 	lastHandledKey string
 	lastView       domain.ViewState
@@ -171,6 +212,9 @@ func NewChatApplication(
 	directExecutionService domain.DirectExecutionService,
 	toolExecutionCoordinator domain.ToolExecutionCoordinator,
 	shellHistoryStore storage.ShellHistoryStorage,
+	checkpoints *checkpoint.Store,
+	budgetTracker domain.BudgetTracker,
+	tokenEstimator domain.TokenEstimator,
 ) *ChatApplication {
 	initialView := domain.ViewStateModelSelection
 	if defaultModel != "" {
@@ -207,6 +251,7 @@ func NewChatApplication(
 		stateManager:             stateManager,
 		messageQueue:             messageQueue,
 		mouseEnabled:             true,
+		notifications:            newTurnNotifier(cfg.Chat.Notifications),
 	}
 
 	if err := app.stateManager.TransitionToView(initialView); err != nil {
@@ -232,6 +277,11 @@ func NewChatApplication(
 		cv.SetStateManager(app.stateManager)
 		cv.SetAgentNameResolver(buildAgentNameResolver())
 		cv.SetAgentModelResolver(buildAgentModelResolver())
+		cv.SetSyntaxHighlighting(cfg.Chat.SyntaxHighlighting)
+		cv.SetThinkingHidden(cfg.Chat.HideThinking)
+		if cfg.Chat.InlineImages {
+			cv.SetInlineImages(graphics.DetectProtocol(os.Getenv))
+		}
 	}
 
 	historyName := os.Getenv(domain.EnvSubagentHistoryName)
@@ -261,8 +311,12 @@ func NewChatApplication(
 		isb.SetStateManager(app.stateManager)
 		isb.SetConfig(app.config)
 		isb.SetConversationRepo(app.conversationRepo)
+		isb.SetBudgetTracker(budgetTracker)
 		isb.SetToolService(app.toolService)
-		isb.SetTokenEstimator(services.NewTokenizerService(services.DefaultTokenizerConfig()))
+		if tokenEstimator == nil {
+			tokenEstimator = services.NewTokenizerService(services.DefaultTokenizerConfig())
+		}
+		isb.SetTokenEstimator(tokenEstimator)
 		isb.SetBackgroundShellService(app.toolRegistry.GetBackgroundShellService())
 		isb.SetBackgroundTaskService(app.backgroundTaskService)
 		if app.backgroundTaskRegistry != nil {
@@ -275,11 +329,16 @@ func NewChatApplication(
 	app.modeIndicator.SetStateManager(app.stateManager)
 	app.helpBar = factory.CreateHelpBar(app.themeService)
 	app.helpView = components.NewHelpView(app.themeService, styleProvider)
+	app.commandPalette = components.NewCommandPalette(styleProvider)
 	app.queueBoxView = components.NewQueueBoxView(styleProvider)
 	app.queueBoxView.SetToolFormatter(toolFormatterService)
 	app.todoBoxView = components.NewTodoBoxView(styleProvider)
+	app.pinnedBoxView = components.NewPinnedBoxView(styleProvider)
+	app.sidePanelView = components.NewSidePanelView(styleProvider)
 	app.snippetAttachmentsView = components.NewSnippetAttachmentsView(styleProvider)
 	app.focusAttachments = focusAttachmentsBinding(app.config.Chat.Keybindings)
+	app.focusTodos = focusTodosBinding(app.config.Chat.Keybindings)
+	app.focusQueue = focusQueueBinding(app.config.Chat.Keybindings)
 	app.approvalBoxView = components.NewApprovalBoxView(styleProvider, app.stateManager, toolFormatterService)
 	app.questionFormView = components.NewQuestionFormView(styleProvider, app.stateManager)
 
@@ -363,6 +422,7 @@ func NewChatApplication(
 		app.chatCompletionRunner,
 		app.directExecutionService,
 		app.toolExecutionCoordinator,
+		checkpoints,
 	)
 
 	app.messageHistoryHandler = handlers.NewMessageHistoryHandler(
@@ -380,13 +440,7 @@ func (app *ChatApplication) updateHelpBarShortcuts() {
 // collectKeyBindings gathers the input-prefix hints and the active keybinding
 // shortcuts into a single list, shared by the help bar and the /help overlay.
 func (app *ChatApplication) collectKeyBindings() []key.Binding {
-	bindings := []key.Binding{
-		key.NewBinding(key.WithKeys("!"), key.WithHelp("!", "for bash mode")),
-		key.NewBinding(key.WithKeys("!!"), key.WithHelp("!!", "for tools mode")),
-		key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "for shortcuts")),
-		key.NewBinding(key.WithKeys("@"), key.WithHelp("@", "for file paths")),
-		key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "for github issues")),
-	}
+	bindings := inputModeBindings()
 
 	if app.keyBindingManager != nil {
 		for _, kbShortcut := range app.keyBindingManager.GetHelpShortcuts() {
@@ -400,6 +454,18 @@ func (app *ChatApplication) collectKeyBindings() []key.Binding {
 	return bindings
 }
 
+// inputModeBindings are the input prefixes that switch what the input line
+// does, listed alongside the keybindings in the help bar and overlay.
+func inputModeBindings() []key.Binding {
+	return []key.Binding{
+		key.NewBinding(key.WithKeys("!"), key.WithHelp("!", "for bash mode")),
+		key.NewBinding(key.WithKeys("!!"), key.WithHelp("!!", "for tools mode")),
+		key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "for shortcuts")),
+		key.NewBinding(key.WithKeys("@"), key.WithHelp("@", "for file paths")),
+		key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "for github issues")),
+	}
+}
+
 // Init initializes the application
 func (app *ChatApplication) Init() tea.Cmd {
 	var cmds []tea.Cmd
@@ -433,6 +499,10 @@ func (app *ChatApplication) Init() tea.Cmd {
 		app.mcpManager.StartMonitoring(context.Background())
 	}
 
+	if cmd := app.startStatusSegments(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
 	if msgs := app.conversationRepo.GetMessages(); len(msgs) > 0 {
 		cmds = append(cmds, func() tea.Msg {
 			return domain.UpdateHistoryEvent{History: msgs}
@@ -462,6 +532,12 @@ func (app *ChatApplication) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmds []tea.Cmd
 
+	if app.notifications != nil {
+		if cmd := app.notifications.handle(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
 	if cmd := app.handleAppEvents(msg); cmd != nil {
 		cmds = append(cmds, cmd)
 	}
@@ -490,6 +566,12 @@ func (app *ChatApplication) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, func() tea.Msg { return domain.DrainQueueEvent{} })
 	}
 
+	if cv, ok := app.conversationView.(*components.ConversationView); ok {
+		if seq := cv.TakeImageTransmissions(); seq != "" {
+			cmds = append(cmds, tea.Raw(seq))
+		}
+	}
+
 	app.lastView = viewBefore
 
 	return app, tea.Batch(cmds...)
@@ -546,7 +628,8 @@ func isDomainEvent(msg tea.Msg) bool {
 		domain.ChatCompleteEvent,
 		domain.ChatErrorEvent,
 		domain.OptimizationStatusEvent,
-		domain.RolloverCompletedEvent:
+		domain.RolloverCompletedEvent,
+		domain.CheckpointCreatedEvent:
 		return true
 
 	// Tool execution
@@ -562,6 +645,7 @@ func isDomainEvent(msg tea.Msg) bool {
 		domain.ToolApprovalResponseEvent,
 		domain.PlanApprovalRequestedEvent,
 		domain.PlanApprovalResponseEvent,
+		domain.PlanEditedEvent,
 		domain.UserQuestionRequestedEvent:
 		return true
 
@@ -591,6 +675,9 @@ func isDomainEvent(msg tea.Msg) bool {
 		domain.AgentStatusUpdateEvent,
 		domain.DrainQueueEvent,
 		domain.DrainQueueRetryEvent,
+		domain.RetryTurnEvent,
+		domain.MacroPlaybackEvent,
+		domain.MacroStepEvent,
 		domain.NavigateBackInTimeEvent,
 		domain.MessageHistoryRestoreEvent,
 		domain.ComputerUsePausedEvent,
@@ -613,12 +700,36 @@ func (app *ChatApplication) handleAppEvents(msg tea.Msg) tea.Cmd {
 	case domain.TriggerHelpViewEvent:
 		return tea.Batch(app.handleHelpViewTrigger()...)
 
+	case domain.TriggerCommandPaletteEvent:
+		return tea.Batch(app.handleCommandPaletteTrigger()...)
+
+	case domain.UndoSendEvent:
+		return app.handleUndoSend()
+
+	case domain.ConversationEntryActionEvent:
+		return app.handleConversationEntryAction(m)
+
 	case domain.MessageHistoryRestoreEvent:
 		return app.messageHistoryHandler.HandleRestore(m)
 
 	case tea.BackgroundColorMsg:
 		app.handleBackgroundColorDetected(m)
 
+	case domain.ToolApprovalRequestedEvent, domain.PlanApprovalRequestedEvent:
+		return app.scheduleApprovalAlert()
+
+	case approvalAlertMsg:
+		return app.handleApprovalAlert(m)
+
+	case approvalFlashEndMsg:
+		app.endApprovalFlash()
+
+	case statusSegmentOutputMsg:
+		return app.handleStatusSegmentOutput(m)
+
+	case statusSegmentTickMsg:
+		return app.handleStatusSegmentTick(m)
+
 	}
 
 	return nil
@@ -703,6 +814,8 @@ func (app *ChatApplication) dispatchViewMessage(currentView domain.ViewState, ms
 		return app.handleToolsListView(msg)
 	case domain.ViewStateA2AAgents:
 		return app.handleA2AAgentsView(msg)
+	case domain.ViewStateCommandPalette:
+		return app.handleCommandPaletteView(msg)
 	default:
 		return nil
 	}
@@ -712,7 +825,7 @@ func (app *ChatApplication) dispatchViewMessage(currentView domain.ViewState, ms
 func (app *ChatApplication) isInputBlocked(currentView domain.ViewState) bool {
 	inHistoryMode := false
 	if cv, ok := app.conversationView.(*components.ConversationView); ok {
-		inHistoryMode = cv.IsInMessageHistoryMode()
+		inHistoryMode = cv.IsInMessageHistoryMode() || cv.IsInOutlineMode() || cv.IsInCodeBlockPicker() || cv.IsSearching() || cv.IsInLineSelection() || cv.IsContextMenuOpen()
 	}
 
 	return currentView != domain.ViewStateChat ||
@@ -771,7 +884,11 @@ func (app *ChatApplication) handleChatView(msg tea.Msg) []tea.Cmd {
 			cv.EnterMessageHistoryMode(readyEvent.Messages)
 
 			if iv, ok := app.inputView.(*components.InputView); ok {
-				iv.SetCustomHint("Input paused - use ↑/↓ to navigate, enter to restore, esc to cancel")
+				hint := "Input paused - use ↑/↓ to navigate, enter to restore, f to fork, p to pin, esc to cancel"
+				if app.messageHistoryHandler.HasDiscardedBranch() {
+					hint += ", r to recover the discarded branch"
+				}
+				iv.SetCustomHint(hint)
 			}
 		}
 		return cmds
@@ -820,6 +937,13 @@ func (app *ChatApplication) handleChatViewKeyPress(keyMsg tea.KeyPressMsg) []tea
 		return nil
 	}
 
+	if app.approvalBoxView != nil && app.approvalBoxView.IsReviewingHunks() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		if cmd := app.approvalBoxView.Forward(keyMsg); cmd != nil {
+			return []tea.Cmd{cmd}
+		}
+		return nil
+	}
+
 	if app.stateManager.GetApprovalUIState() != nil {
 		switch keyMsg.Code {
 		case tea.KeyLeft, tea.KeyRight, tea.KeyEnter:
@@ -834,10 +958,63 @@ func (app *ChatApplication) handleChatViewKeyPress(keyMsg tea.KeyPressMsg) []tea
 		}
 	}
 
+	if cv, ok := app.conversationView.(*components.ConversationView); ok && cv.IsContextMenuOpen() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		app.lastHandledKey = keyMsg.String()
+		return app.handleContextMenuKeys(cv, keyMsg)
+	}
+
 	if cv, ok := app.conversationView.(*components.ConversationView); ok && cv.IsInMessageHistoryMode() {
 		return app.handleMessageHistoryKeys(keyMsg)
 	}
 
+	if cv, ok := app.conversationView.(*components.ConversationView); ok && cv.IsInOutlineMode() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		app.handleOutlineKeys(cv, keyMsg)
+		return nil
+	}
+
+	if cv, ok := app.conversationView.(*components.ConversationView); ok && cv.IsInCodeBlockPicker() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		return app.handleCodeBlockPickerKeys(cv, keyMsg)
+	}
+
+	if cv, ok := app.conversationView.(*components.ConversationView); ok && cv.IsInLineSelection() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		app.lastHandledKey = keyMsg.String()
+		return app.handleLineSelectionKeys(cv, keyMsg)
+	}
+
+	if cv, ok := app.conversationView.(*components.ConversationView); ok && cv.IsSearching() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		app.lastHandledKey = keyMsg.String()
+		app.handleConversationSearchKeys(cv, keyMsg)
+		return nil
+	}
+
+	if iv, ok := app.inputView.(*components.InputView); ok && iv.IsSearchingHistory() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		app.lastHandledKey = keyMsg.String()
+		app.handleHistorySearchKeys(iv, keyMsg)
+		return nil
+	}
+
+	if app.queueBoxView != nil && app.queueBoxView.IsFocused() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		app.lastHandledKey = keyMsg.String()
+		return app.handleQueueBoxKeys(keyMsg)
+	}
+	if app.queueBoxView != nil && key.Matches(keyMsg, app.focusQueue) && app.queueBoxView.Focus(app.messageQueue.Size()) {
+		app.lastHandledKey = keyMsg.String()
+		return nil
+	}
+	if app.queueEdit != nil && key.Matches(keyMsg, guardKeys.cancel) {
+		app.lastHandledKey = keyMsg.String()
+		app.cancelQueueEdit()
+		return nil
+	}
+	if app.todoBoxView != nil && app.todoBoxView.IsFocused() && !key.Matches(keyMsg, guardKeys.interrupt) {
+		app.lastHandledKey = keyMsg.String()
+		return app.handleTodoBoxKeys(keyMsg)
+	}
+	if app.todoBoxView != nil && key.Matches(keyMsg, app.focusTodos) && app.todoBoxView.Focus() {
+		app.lastHandledKey = keyMsg.String()
+		return nil
+	}
+
 	if app.attachmentsFocused && !key.Matches(keyMsg, guardKeys.interrupt) {
 		app.lastHandledKey = keyMsg.String()
 		return app.handleAttachmentsKeys(keyMsg)
@@ -853,6 +1030,13 @@ func (app *ChatApplication) handleChatViewKeyPress(keyMsg tea.KeyPressMsg) []tea
 		return nil
 	}
 
+	if iv, ok := app.inputView.(*components.InputView); ok && !key.Matches(keyMsg, guardKeys.interrupt) {
+		if cmd, handled := iv.HandleVimKey(keyMsg); handled {
+			app.lastHandledKey = keyMsg.String()
+			return []tea.Cmd{cmd}
+		}
+	}
+
 	isHandledByAction := app.keyBindingManager.IsKeyHandledByAction(keyMsg)
 
 	if cmd := app.keyBindingManager.ProcessKey(keyMsg); cmd != nil {
@@ -1026,11 +1210,13 @@ func (app *ChatApplication) handleFileSelectionView(msg tea.Msg) []tea.Cmd {
 // string-composition logic and View wraps it. MouseMode is read from
 // the app's mouse-enabled state on every render so the ctrl+s toggle
 // actually takes effect - without this, no mouse/wheel events arrive.
+// Focus reporting is only requested when desktop notifications need it.
 func (app *ChatApplication) View() tea.View {
 	v := tea.NewView(app.viewContent())
 	if app.mouseEnabled {
 		v.MouseMode = tea.MouseModeCellMotion
 	}
+	v.ReportFocus = app.notifications != nil
 	v.AltScreen = true
 	return v
 }
@@ -1063,6 +1249,8 @@ func (app *ChatApplication) viewContent() string {
 		return app.renderToolsList()
 	case domain.ViewStateA2AAgents:
 		return app.renderA2AAgents()
+	case domain.ViewStateCommandPalette:
+		return app.renderCommandPalette()
 	default:
 		return fmt.Sprintf("Unknown view state: %v", currentView)
 	}
@@ -1564,6 +1752,7 @@ func (app *ChatApplication) handleThemeSelectionView(msg tea.Msg) []tea.Cmd {
 		}
 	}
 
+	previewed := app.themeService.GetCurrentThemeName()
 	model, cmd := app.themeSelector.Update(msg)
 	app.themeSelector = model.(*components.ThemeSelectorImpl)
 
@@ -1571,6 +1760,10 @@ func (app *ChatApplication) handleThemeSelectionView(msg tea.Msg) []tea.Cmd {
 		cmds = append(cmds, cmd)
 	}
 
+	if app.themeService.GetCurrentThemeName() != previewed {
+		app.updateAllComponentsWithNewTheme()
+	}
+
 	return app.handleThemeSelection(cmds)
 }
 
@@ -1648,10 +1841,13 @@ func (app *ChatApplication) updateAllComponentsWithNewTheme() {
 	app.modelSelector = components.NewModelSelector(app.availableModels, app.modelService, app.pricingService, app.config, styleProvider)
 }
 
+// renderThemeSelection draws the selector over the chat, which is rendered
+// in whichever theme is highlighted so it can be judged before committing.
 func (app *ChatApplication) renderThemeSelection() string {
 	width, height := app.stateManager.GetDimensions()
 	app.themeSelector.SetWidth(width)
 	app.themeSelector.SetHeight(height)
+	app.themeSelector.SetBackdrop(app.renderChatInterface())
 	return app.themeSelector.View().Content
 }
 
@@ -1772,13 +1968,7 @@ func (app *ChatApplication) handleHelpViewTrigger() []tea.Cmd {
 	app.helpView.SetWidth(width)
 	app.helpView.SetHeight(height)
 
-	bindings := app.collectKeyBindings()
-	shortcuts := make([]ui.KeyShortcut, len(bindings))
-	for i, b := range bindings {
-		h := b.Help()
-		shortcuts[i] = ui.KeyShortcut{Key: h.Key, Description: h.Desc}
-	}
-	app.helpView.SetContent(app.buildHelpCommands(), shortcuts)
+	app.helpView.SetContent(app.buildHelpCommands(), app.buildHelpKeybindings())
 
 	if err := app.stateManager.TransitionToView(domain.ViewStateHelp); err != nil {
 		cmds = append(cmds, func() tea.Msg {
@@ -1819,6 +2009,47 @@ func (app *ChatApplication) buildHelpCommands() []components.HelpCommand {
 	return commands
 }
 
+// buildHelpKeybindings lists the input modes followed by every enabled
+// keybinding, user overrides applied, grouped by namespace. Unlike the help
+// bar it is not limited to the bindings active in the current context, and it
+// includes the namespaces components resolve themselves (diff viewer,
+// explorer).
+func (app *ChatApplication) buildHelpKeybindings() []ui.KeyShortcut {
+	var helpKeys []ui.KeyShortcut
+	for _, b := range inputModeBindings() {
+		h := b.Help()
+		helpKeys = append(helpKeys, ui.KeyShortcut{Key: h.Key, Description: h.Desc, Category: "input_modes"})
+	}
+
+	var kbCfg config.KeybindingsConfig
+	if app.config != nil {
+		kbCfg = app.config.Chat.Keybindings
+	}
+	resolved, _ := config.ResolveKeybindings(kbCfg)
+
+	ids := slices.Collect(maps.Keys(resolved))
+	slices.SortFunc(ids, func(a, b string) int {
+		if c := cmp.Compare(resolved[a].Category, resolved[b].Category); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	for _, id := range ids {
+		entry := resolved[id]
+		if (entry.Enabled != nil && !*entry.Enabled) || len(entry.Keys) == 0 {
+			continue
+		}
+		sorted := slices.Sorted(slices.Values(entry.Keys))
+		helpKeys = append(helpKeys, ui.KeyShortcut{
+			Key:         strings.Join(sorted, ", "),
+			Description: entry.Description,
+			Category:    entry.Category,
+		})
+	}
+	return helpKeys
+}
+
 func (app *ChatApplication) handleHelpView(msg tea.Msg) []tea.Cmd {
 	var cmds []tea.Cmd
 
@@ -2065,9 +2296,11 @@ func (app *ChatApplication) renderChatInterface() string {
 		app.helpBar,
 		app.queueBoxView,
 		app.todoBoxView,
+		app.pinnedBoxView,
 		app.approvalBoxView,
 		app.questionFormView,
 		app.snippetAttachmentsView,
+		app.sidePanelView,
 	)
 
 	return chatInterface
@@ -2215,6 +2448,10 @@ func (app *ChatApplication) updateUIComponents(msg tea.Msg, activeView domain.Vi
 
 	app.handleTodoEvents(msg, &cmds)
 
+	app.handlePinnedEvents(msg)
+
+	app.handleSidePanelEvents(msg, &cmds)
+
 	app.handleAutocompleteEvents(msg, &cmds)
 
 	return cmds
@@ -2370,6 +2607,11 @@ func (app *ChatApplication) handleTodoEvents(msg tea.Msg, cmds *[]tea.Cmd) {
 			app.stateManager.SetTodos(todoMsg.Todos)
 			*cmds = append(*cmds, components.ScheduleAutoCollapse())
 		}
+	case domain.PlanStepsUpdateEvent:
+		if app.todoBoxView != nil {
+			app.todoBoxView.SetPlanSteps(todoMsg.Steps)
+			*cmds = append(*cmds, components.ScheduleAutoCollapse())
+		}
 	case domain.ToggleTodoBoxEvent:
 		if app.todoBoxView != nil {
 			app.todoBoxView.Toggle()
@@ -2381,6 +2623,57 @@ func (app *ChatApplication) handleTodoEvents(msg tea.Msg, cmds *[]tea.Cmd) {
 	}
 }
 
+// handlePinnedEvents keeps the pinned messages panel in sync with the history
+func (app *ChatApplication) handlePinnedEvents(msg tea.Msg) {
+	if app.pinnedBoxView == nil {
+		return
+	}
+
+	switch pinnedMsg := msg.(type) {
+	case domain.UpdateHistoryEvent:
+		app.pinnedBoxView.SetEntries(pinnedMsg.History)
+	case domain.TogglePinnedBoxEvent:
+		app.pinnedBoxView.Toggle()
+	}
+}
+
+// handleSidePanelEvents keeps the side panel in sync with the todos and the
+// history, and cycles or resizes it on request
+func (app *ChatApplication) handleSidePanelEvents(msg tea.Msg, cmds *[]tea.Cmd) {
+	if app.sidePanelView == nil {
+		return
+	}
+
+	var status string
+	switch panelMsg := msg.(type) {
+	case domain.TodoUpdateEvent:
+		app.sidePanelView.SetTodos(panelMsg.Todos)
+	case domain.UpdateHistoryEvent:
+		app.sidePanelView.SetEntries(panelMsg.History)
+	case domain.ToggleSidePanelEvent:
+		status = fmt.Sprintf("Side panel: %s", app.sidePanelView.Cycle())
+		width, _ := app.stateManager.GetDimensions()
+		if app.sidePanelView.Content() != components.SidePanelHidden && app.sidePanelView.PanelWidth(width) == 0 {
+			status += " (terminal too narrow to split)"
+		}
+	case domain.ResizeSidePanelEvent:
+		if app.sidePanelView.Content() == components.SidePanelHidden {
+			return
+		}
+		status = fmt.Sprintf("Side panel width: %d%%", app.sidePanelView.Resize(panelMsg.Delta))
+	}
+
+	if status != "" {
+		*cmds = append(*cmds, func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    status,
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		})
+	}
+}
+
 // handleAutocompleteEvents handles autocomplete-related events
 func (app *ChatApplication) handleAutocompleteEvents(msg tea.Msg, cmds *[]tea.Cmd) {
 	if app.autocomplete == nil {
@@ -2519,6 +2812,12 @@ func (app *ChatApplication) SendMessage() tea.Cmd {
 		}
 	}
 
+	var draft components.Draft
+	if iv, ok := app.inputView.(*components.InputView); ok {
+		draft = iv.Draft()
+		input = iv.ExpandPastedText(input)
+	}
+
 	app.inputView.ClearInput()
 
 	app.conversationView.ResetUserScroll()
@@ -2531,6 +2830,10 @@ func (app *ChatApplication) SendMessage() tea.Cmd {
 		}
 	}
 
+	if app.queueEdit != nil {
+		return app.saveQueueEdit(input)
+	}
+
 	if editing {
 		editState := app.stateManager.GetMessageEditState()
 
@@ -2550,6 +2853,10 @@ func (app *ChatApplication) SendMessage() tea.Cmd {
 		}
 	}
 
+	app.flushTodoSync()
+	app.rememberSent(input, draft)
+	app.recordMacroStep(input)
+
 	content := input
 	if augmented, appended := app.augmentWithSnippets(input); appended {
 		content = augmented
@@ -2672,15 +2979,7 @@ func (app *ChatApplication) handleEditReady(event domain.MessageHistoryEditReady
 
 	entries := app.conversationRepo.GetMessages()
 	deleteIndex := app.adjustRestoreIndexForEdit(entries, event.MessageIndex)
-
-	var err error
-	if deleteIndex == 0 {
-		err = app.conversationRepo.Clear()
-	} else {
-		err = app.conversationRepo.DeleteMessagesAfterIndex(deleteIndex - 1)
-	}
-
-	if err != nil {
+	if err := app.messageHistoryHandler.DiscardBranch(entries, deleteIndex); err != nil {
 		logger.Error("failed to delete messages during edit", "error", err)
 		cmds = append(cmds, func() tea.Msg {
 			return domain.ShowErrorEvent{
@@ -2702,7 +3001,7 @@ func (app *ChatApplication) handleEditReady(event domain.MessageHistoryEditReady
 		iv.SetCursor(len(event.Content))
 
 		timestamp := event.Snapshot.Timestamp.Format("15:04:05")
-		hint := fmt.Sprintf("Editing message from %s - Press enter to submit", timestamp)
+		hint := fmt.Sprintf("Editing message from %s - Press enter to submit (the previous branch is kept - press r in message history to recover it)", timestamp)
 		iv.SetCustomHint(hint)
 	}
 
@@ -2806,11 +3105,191 @@ func (app *ChatApplication) handleMessageHistoryKeys(keyMsg tea.KeyPressMsg) []t
 	case key.Matches(keyMsg, gk.cancel):
 		cv.ExitMessageHistoryMode()
 		iv.ClearCustomHint()
+	case key.Matches(keyMsg, gk.historyRecover) && app.messageHistoryHandler.HasDiscardedBranch():
+		cv.ExitMessageHistoryMode()
+		iv.ClearCustomHint()
+		cmds = append(cmds, app.messageHistoryHandler.HandleRecoverBranch())
+	case key.Matches(keyMsg, gk.historyFork):
+		if selectedIndex := cv.GetSelectedMessageIndex(); selectedIndex >= 0 {
+			cv.ExitMessageHistoryMode()
+			iv.ClearCustomHint()
+			cmds = append(cmds, app.messageHistoryHandler.HandleFork(selectedIndex))
+		}
+	case key.Matches(keyMsg, gk.historyPin):
+		if selectedIndex := cv.GetSelectedMessageIndex(); selectedIndex >= 0 {
+			cv.ExitMessageHistoryMode()
+			iv.ClearCustomHint()
+			cmds = append(cmds, app.messageHistoryHandler.HandleTogglePin(selectedIndex))
+		}
+	}
+
+	return cmds
+}
+
+// handleOutlineKeys moves through the conversation outline and jumps to the
+// selected entry on enter.
+func (app *ChatApplication) handleOutlineKeys(cv *components.ConversationView, keyMsg tea.KeyPressMsg) {
+	gk := guardKeys
+	switch {
+	case key.Matches(keyMsg, gk.navUp):
+		cv.NavigateOutline(-1)
+		return
+	case key.Matches(keyMsg, gk.navDown):
+		cv.NavigateOutline(1)
+		return
+	case key.Matches(keyMsg, gk.confirm):
+		cv.JumpToOutlineSelection()
+	case key.Matches(keyMsg, gk.cancel):
+		cv.ExitOutlineMode()
+	default:
+		return
+	}
+
+	if iv, ok := app.inputView.(*components.InputView); ok {
+		iv.ClearCustomHint()
+	}
+}
+
+// handleCodeBlockPickerKeys copies the code block picked by number or by the
+// highlighted row on enter.
+func (app *ChatApplication) handleCodeBlockPickerKeys(cv *components.ConversationView, keyMsg tea.KeyPressMsg) []tea.Cmd {
+	gk := guardKeys
+	var cmds []tea.Cmd
+	switch {
+	case key.Matches(keyMsg, gk.navUp):
+		cv.NavigateCodeBlockPicker(-1)
+		return nil
+	case key.Matches(keyMsg, gk.navDown):
+		cv.NavigateCodeBlockPicker(1)
+		return nil
+	case key.Matches(keyMsg, gk.confirm):
+		if block, ok := cv.SelectCodeBlock(0); ok {
+			cmds = append(cmds, keybinding.CopyCodeBlock(block))
+		}
+	case key.Matches(keyMsg, gk.codeBlockNumber):
+		block, ok := cv.SelectCodeBlock(int(keyMsg.String()[0] - '0'))
+		if !ok {
+			return nil
+		}
+		cmds = append(cmds, keybinding.CopyCodeBlock(block))
+	case key.Matches(keyMsg, gk.cancel):
+		cv.ExitCodeBlockPicker()
+	default:
+		return nil
 	}
 
+	if iv, ok := app.inputView.(*components.InputView); ok {
+		iv.ClearCustomHint()
+	}
 	return cmds
 }
 
+// handleConversationSearchKeys edits the search query and steps between
+// matches while the conversation search is open. All keys are consumed.
+func (app *ChatApplication) handleConversationSearchKeys(cv *components.ConversationView, keyMsg tea.KeyPressMsg) {
+	iv, _ := app.inputView.(*components.InputView)
+
+	gk := guardKeys
+	switch {
+	case key.Matches(keyMsg, gk.cancel):
+		cv.EndSearch()
+		if iv != nil {
+			iv.ClearCustomHint()
+		}
+		return
+	case key.Matches(keyMsg, gk.searchNext):
+		cv.NextSearchMatch()
+	case key.Matches(keyMsg, gk.searchPrev):
+		cv.PrevSearchMatch()
+	case key.Matches(keyMsg, gk.searchBackspace):
+		query := []rune(cv.SearchQuery())
+		if len(query) > 0 {
+			cv.SetSearchQuery(string(query[:len(query)-1]))
+		}
+	default:
+		if text := keys.PrintableText(keyMsg); text != "" {
+			cv.SetSearchQuery(cv.SearchQuery() + text)
+		}
+	}
+
+	if iv != nil {
+		iv.SetCustomHint(cv.SearchHint())
+	}
+}
+
+// handleHistorySearchKeys edits the reverse history search query and cycles
+// through its matches. All keys are consumed while the search is open.
+func (app *ChatApplication) handleHistorySearchKeys(iv *components.InputView, keyMsg tea.KeyPressMsg) {
+	gk := guardKeys
+	switch {
+	case key.Matches(keyMsg, gk.cancel):
+		iv.CancelHistorySearch()
+		iv.ClearCustomHint()
+		return
+	case key.Matches(keyMsg, gk.confirm):
+		iv.AcceptHistorySearch()
+		iv.ClearCustomHint()
+		return
+	case key.Matches(keyMsg, gk.historySearchOlder):
+		iv.HistorySearchOlder()
+	case key.Matches(keyMsg, gk.historySearchNewer):
+		iv.HistorySearchNewer()
+	case key.Matches(keyMsg, gk.searchBackspace):
+		query := []rune(iv.HistorySearchQuery())
+		if len(query) > 0 {
+			iv.SetHistorySearchQuery(string(query[:len(query)-1]))
+		}
+	default:
+		if text := keys.PrintableText(keyMsg); text != "" {
+			iv.SetHistorySearchQuery(iv.HistorySearchQuery() + text)
+		}
+	}
+
+	iv.SetCustomHint(iv.HistorySearchHint())
+}
+
+// handleLineSelectionKeys moves the visual selection cursor, marks a range
+// and copies it. All keys are consumed while selecting.
+func (app *ChatApplication) handleLineSelectionKeys(cv *components.ConversationView, keyMsg tea.KeyPressMsg) []tea.Cmd {
+	iv, _ := app.inputView.(*components.InputView)
+	page := max(cv.Viewport.Height()/2, 1)
+
+	gk := guardKeys
+	switch {
+	case key.Matches(keyMsg, gk.cancel):
+		cv.EndLineSelection()
+		if iv != nil {
+			iv.ClearCustomHint()
+		}
+		return nil
+	case key.Matches(keyMsg, gk.selectionCopy):
+		text := cv.SelectedText()
+		cv.EndLineSelection()
+		if iv != nil {
+			iv.ClearCustomHint()
+		}
+		if strings.TrimSpace(text) == "" {
+			return nil
+		}
+		return []tea.Cmd{keybinding.CopySelectedText(text)}
+	case key.Matches(keyMsg, gk.navUp):
+		cv.MoveLineSelection(-1)
+	case key.Matches(keyMsg, gk.navDown):
+		cv.MoveLineSelection(1)
+	case key.Matches(keyMsg, gk.selectionPgUp):
+		cv.MoveLineSelection(-page)
+	case key.Matches(keyMsg, gk.selectionPgDn):
+		cv.MoveLineSelection(page)
+	case key.Matches(keyMsg, gk.selectionMark):
+		cv.ToggleSelectionMark()
+	}
+
+	if iv != nil {
+		iv.SetCustomHint(cv.SelectionHint())
+	}
+	return nil
+}
+
 // buildAgentNameResolver loads ~/.infer/agents.yaml (or the project-level
 // equivalent) once and returns a closure that maps an agent URL to its
 // configured friendly name. Used by the background-agent indicator to show
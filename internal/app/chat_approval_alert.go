@@ -0,0 +1,65 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	components "github.com/inference-gateway/cli/internal/ui/components"
+)
+
+// approvalFlashDuration is how long the status bar stays highlighted
+const approvalFlashDuration = 800 * time.Millisecond
+
+// terminalBell is the BEL control character
+const terminalBell = "\a"
+
+// approvalAlertMsg fires once an approval prompt has been up for the
+// configured delay; seq ties it to the prompt that scheduled it.
+type approvalAlertMsg struct {
+	seq int
+}
+
+// approvalFlashEndMsg ends the status bar flash
+type approvalFlashEndMsg struct{}
+
+// scheduleApprovalAlert starts the unanswered-approval timer for a new tool or
+// plan approval prompt. Any earlier timer is invalidated by the new sequence.
+func (app *ChatApplication) scheduleApprovalAlert() tea.Cmd {
+	alert := app.config.Chat.ApprovalAlert
+	if !alert.Enabled {
+		return nil
+	}
+	app.approvalAlertSeq++
+	seq := app.approvalAlertSeq
+	delay := time.Duration(max(alert.DelaySeconds, 0)) * time.Second
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return approvalAlertMsg{seq: seq}
+	})
+}
+
+// handleApprovalAlert rings the bell and flashes the status bar when the
+// prompt that scheduled msg is still waiting on the user.
+func (app *ChatApplication) handleApprovalAlert(msg approvalAlertMsg) tea.Cmd {
+	if msg.seq != app.approvalAlertSeq {
+		return nil
+	}
+	if app.stateManager.GetApprovalUIState() == nil && app.stateManager.GetPlanApprovalUIState() == nil {
+		return nil
+	}
+
+	cmds := []tea.Cmd{tea.Raw(terminalBell)}
+	if sv, ok := app.statusView.(*components.StatusView); ok {
+		sv.SetFlash(true)
+		cmds = append(cmds, tea.Tick(approvalFlashDuration, func(time.Time) tea.Msg {
+			return approvalFlashEndMsg{}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (app *ChatApplication) endApprovalFlash() {
+	if sv, ok := app.statusView.(*components.StatusView); ok {
+		sv.SetFlash(false)
+	}
+}
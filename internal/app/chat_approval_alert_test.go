@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	services "github.com/inference-gateway/cli/internal/services"
+)
+
+func TestApprovalAlert(t *testing.T) {
+	cfg := &config.Config{}
+	stateManager := services.NewStateManager(false)
+	app := &ChatApplication{config: cfg, stateManager: stateManager}
+
+	if cmd := app.scheduleApprovalAlert(); cmd != nil {
+		t.Fatal("no alert should be scheduled while the option is disabled")
+	}
+
+	cfg.Chat.ApprovalAlert.Enabled = true
+	if cmd := app.scheduleApprovalAlert(); cmd == nil {
+		t.Fatal("expected an alert timer")
+	}
+	stale := approvalAlertMsg{seq: app.approvalAlertSeq}
+
+	if cmd := app.handleApprovalAlert(stale); cmd != nil {
+		t.Error("an answered prompt must not ring the bell")
+	}
+
+	stateManager.SetupApprovalUIState(&sdk.ChatCompletionMessageToolCall{}, make(chan domain.ApprovalAction, 1))
+	if cmd := app.handleApprovalAlert(stale); cmd == nil {
+		t.Error("expected the bell for a prompt still waiting on the user")
+	}
+
+	app.scheduleApprovalAlert()
+	if cmd := app.handleApprovalAlert(stale); cmd != nil {
+		t.Error("a timer from an earlier prompt must be ignored")
+	}
+}
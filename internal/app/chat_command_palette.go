@@ -0,0 +1,314 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	components "github.com/inference-gateway/cli/internal/ui/components"
+)
+
+type paletteView struct {
+	shortcut string
+	title    string
+}
+
+// paletteViews are the view transitions offered in the palette, keyed by the
+// shortcut that performs them. They replace the plain shortcut row so each
+// view is listed once, under a descriptive title.
+var paletteViews = []paletteView{
+	{"model", "Select a model"},
+	{"theme", "Select a theme"},
+	{"conversations", "Browse saved conversations"},
+	{"tools", "Browse available tools"},
+	{"a2a", "Browse A2A agents"},
+	{"diff", "Open the changes panel"},
+	{"explorer", "Open the file explorer"},
+	{"help", "Show all commands and keybindings"},
+}
+
+// paletteHiddenActions are keybinding actions that make no sense without the
+// key that triggers them (enter/tab dispatch, cancel) or that would reopen the
+// palette itself. Text editing actions are left out wholesale, except for
+// composing in $EDITOR.
+var paletteHiddenActions = map[string]bool{
+	config.ActionID(config.NamespaceGlobal, "cancel"):          true,
+	config.ActionID(config.NamespaceChat, "tab_key_handler"):   true,
+	config.ActionID(config.NamespaceChat, "enter_key_handler"): true,
+	config.ActionID(config.NamespaceHelp, "command_palette"):   true,
+}
+
+// paletteToggle is a boolean config setting the palette can flip for the
+// current session. apply, when set, pushes the new value to components that
+// copied it at startup.
+type paletteToggle struct {
+	title string
+	value *bool
+	apply func(app *ChatApplication, on bool) tea.Cmd
+}
+
+// paletteToggles lists the settings that take effect immediately when flipped.
+func (app *ChatApplication) paletteToggles() []paletteToggle {
+	chat := &app.config.Chat
+	indicators := &chat.StatusBar.Indicators
+	return []paletteToggle{
+		{title: "Status bar", value: &chat.StatusBar.Enabled},
+		{title: "Syntax highlighting", value: &chat.SyntaxHighlighting, apply: applySyntaxHighlighting},
+		{title: "Approval alert", value: &chat.ApprovalAlert.Enabled},
+		{title: "Status bar: model", value: &indicators.Model},
+		{title: "Status bar: theme", value: &indicators.Theme},
+		{title: "Status bar: max output", value: &indicators.MaxOutput},
+		{title: "Status bar: A2A agents", value: &indicators.A2AAgents},
+		{title: "Status bar: tools", value: &indicators.Tools},
+		{title: "Status bar: background shells", value: &indicators.BackgroundShells},
+		{title: "Status bar: A2A tasks", value: &indicators.A2ATasks},
+		{title: "Status bar: MCP", value: &indicators.MCP},
+		{title: "Status bar: context usage", value: &indicators.ContextUsage},
+		{title: "Status bar: session tokens", value: &indicators.SessionTokens},
+		{title: "Status bar: cost", value: &indicators.Cost},
+		{title: "Git branch", value: &indicators.GitBranch},
+		{title: "Git pull request", value: &indicators.GitPR},
+	}
+}
+
+func applySyntaxHighlighting(app *ChatApplication, on bool) tea.Cmd {
+	cv, ok := app.conversationView.(*components.ConversationView)
+	if !ok {
+		return nil
+	}
+	cv.SetSyntaxHighlighting(on)
+	return func() tea.Msg {
+		return domain.UpdateHistoryEvent{History: app.conversationRepo.GetMessages()}
+	}
+}
+
+// handleCommandPaletteTrigger collects the commands while the chat view is
+// still active, so keybinding actions are filtered by the context they run
+// in, then opens the palette.
+func (app *ChatApplication) handleCommandPaletteTrigger() []tea.Cmd {
+	width, height := app.stateManager.GetDimensions()
+	app.commandPalette.SetWidth(width)
+	app.commandPalette.SetHeight(height)
+	focusCmd := app.commandPalette.SetCommands(app.buildPaletteCommands())
+
+	if err := app.stateManager.TransitionToView(domain.ViewStateCommandPalette); err != nil {
+		return []tea.Cmd{func() tea.Msg {
+			return domain.ShowErrorEvent{
+				Error:  fmt.Sprintf("Failed to open the command palette: %v", err),
+				Sticky: false,
+			}
+		}}
+	}
+
+	return []tea.Cmd{focusCmd}
+}
+
+// buildPaletteCommands lists views first, then slash commands, prompt
+// templates, keybinding actions and config toggles.
+func (app *ChatApplication) buildPaletteCommands() []components.PaletteCommand {
+	var commands []components.PaletteCommand
+	commands = append(commands, app.paletteViewCommands()...)
+	commands = append(commands, app.paletteShortcutCommands()...)
+	commands = append(commands, app.paletteTemplateCommands()...)
+	commands = append(commands, app.paletteActionCommands()...)
+	commands = append(commands, app.paletteToggleCommands()...)
+	return commands
+}
+
+func (app *ChatApplication) paletteViewCommands() []components.PaletteCommand {
+	if app.shortcutRegistry == nil {
+		return nil
+	}
+
+	var commands []components.PaletteCommand
+	for _, view := range paletteViews {
+		if _, ok := app.shortcutRegistry.Get(view.shortcut); !ok {
+			continue
+		}
+		commands = append(commands, components.PaletteCommand{
+			Category: "view",
+			Title:    view.title,
+			Hint:     "/" + view.shortcut,
+			Run:      runShortcut(view.shortcut),
+		})
+	}
+	return commands
+}
+
+// paletteShortcutCommands lists every slash command. Commands that need
+// arguments are typed into the input instead of being run.
+func (app *ChatApplication) paletteShortcutCommands() []components.PaletteCommand {
+	if app.shortcutRegistry == nil {
+		return nil
+	}
+
+	var commands []components.PaletteCommand
+	for _, s := range app.shortcutRegistry.GetAll() {
+		name := s.GetName()
+		if slices.ContainsFunc(paletteViews, func(v paletteView) bool { return v.shortcut == name }) {
+			continue
+		}
+
+		run := runShortcut(name)
+		if !s.CanExecute(nil) {
+			text := "/" + name + " "
+			run = func() tea.Cmd {
+				return func() tea.Msg { return domain.SetInputEvent{Text: text} }
+			}
+		}
+		commands = append(commands, components.PaletteCommand{
+			Category: "command",
+			Title:    capitalize(s.GetDescription()),
+			Hint:     "/" + name,
+			Run:      run,
+		})
+	}
+	return commands
+}
+
+// paletteTemplateCommands lists the prompt templates of prompts.yaml. They
+// run through /prompt so the input gets the same rendering as typing it.
+func (app *ChatApplication) paletteTemplateCommands() []components.PaletteCommand {
+	if app.config == nil || app.shortcutRegistry == nil {
+		return nil
+	}
+	if _, ok := app.shortcutRegistry.Get("prompt"); !ok {
+		return nil
+	}
+
+	var commands []components.PaletteCommand
+	for _, t := range app.config.Prompts.Templates {
+		title := t.Name
+		if t.Description != "" {
+			title += " - " + t.Description
+		}
+		commands = append(commands, components.PaletteCommand{
+			Category: "prompt",
+			Title:    title,
+			Hint:     "/prompt " + t.Name,
+			Run:      runShortcut("prompt " + t.Name),
+		})
+	}
+	return commands
+}
+
+// paletteActionCommands lists the keybinding actions active in the chat view,
+// run exactly as if their key had been pressed.
+func (app *ChatApplication) paletteActionCommands() []components.PaletteCommand {
+	if app.keyBindingManager == nil {
+		return nil
+	}
+
+	var commands []components.PaletteCommand
+	for _, action := range app.keyBindingManager.GetRegistry().GetActiveActions(app) {
+		if paletteHiddenActions[action.ID] {
+			continue
+		}
+		if strings.HasPrefix(action.ID, string(config.NamespaceTextEditing)+"_") &&
+			action.ID != config.ActionID(config.NamespaceTextEditing, "open_in_editor") {
+			continue
+		}
+
+		help := action.Binding.Help()
+		if help.Desc == "" {
+			continue
+		}
+		handler := action.Handler
+		commands = append(commands, components.PaletteCommand{
+			Category: action.Category,
+			Title:    capitalize(help.Desc),
+			Hint:     help.Key,
+			Run:      func() tea.Cmd { return handler(app, tea.KeyPressMsg{}) },
+		})
+	}
+	return commands
+}
+
+// paletteToggleCommands lists the config toggles with their current value.
+// Flipping one changes the running session only; config.yaml is untouched.
+func (app *ChatApplication) paletteToggleCommands() []components.PaletteCommand {
+	if app.config == nil {
+		return nil
+	}
+
+	var commands []components.PaletteCommand
+	for _, toggle := range app.paletteToggles() {
+		commands = append(commands, components.PaletteCommand{
+			Category: "toggle",
+			Title:    toggle.title,
+			Hint:     onOff(*toggle.value),
+			Run: func() tea.Cmd {
+				*toggle.value = !*toggle.value
+				status := fmt.Sprintf("%s %s for this session", toggle.title, onOff(*toggle.value))
+				cmds := []tea.Cmd{func() tea.Msg {
+					return domain.SetStatusEvent{Message: status, Spinner: false, StatusType: domain.StatusDefault}
+				}}
+				if toggle.apply != nil {
+					cmds = append(cmds, toggle.apply(app, *toggle.value))
+				}
+				return tea.Batch(cmds...)
+			},
+		})
+	}
+	return commands
+}
+
+func (app *ChatApplication) handleCommandPaletteView(msg tea.Msg) []tea.Cmd {
+	var cmds []tea.Cmd
+
+	model, cmd := app.commandPalette.Update(msg)
+	app.commandPalette = model.(*components.CommandPaletteImpl)
+	if cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
+	selected, ok := app.commandPalette.Selected()
+	if !ok && !app.commandPalette.IsCancelled() {
+		return cmds
+	}
+
+	if err := app.stateManager.TransitionToView(domain.ViewStateChat); err != nil {
+		return []tea.Cmd{tea.Quit}
+	}
+	app.focusedComponent = app.inputView
+
+	if ok && selected.Run != nil {
+		cmds = append(cmds, selected.Run())
+	}
+	return cmds
+}
+
+func (app *ChatApplication) renderCommandPalette() string {
+	width, height := app.stateManager.GetDimensions()
+	app.commandPalette.SetWidth(width)
+	app.commandPalette.SetHeight(height)
+	return app.commandPalette.View().Content
+}
+
+// runShortcut submits a slash command as if it had been typed and sent.
+func runShortcut(name string) func() tea.Cmd {
+	return func() tea.Cmd {
+		return func() tea.Msg { return domain.UserInputEvent{Content: "/" + name} }
+	}
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	key "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	components "github.com/inference-gateway/cli/internal/ui/components"
+	keybinding "github.com/inference-gateway/cli/internal/ui/keybinding"
+)
+
+// handleContextMenuKeys moves through the open entry context menu, picking
+// an action on enter. All keys are consumed while it is open.
+func (app *ChatApplication) handleContextMenuKeys(cv *components.ConversationView, keyMsg tea.KeyPressMsg) []tea.Cmd {
+	gk := guardKeys
+	switch {
+	case key.Matches(keyMsg, gk.navUp):
+		cv.MoveContextMenu(-1)
+	case key.Matches(keyMsg, gk.navDown):
+		cv.MoveContextMenu(1)
+	case key.Matches(keyMsg, gk.confirm):
+		if cmd := cv.ConfirmContextMenu(); cmd != nil {
+			return []tea.Cmd{cmd}
+		}
+	case key.Matches(keyMsg, gk.cancel):
+		cv.CloseContextMenu()
+	}
+	return nil
+}
+
+// handleConversationEntryAction applies an action picked from the context
+// menu of the entry at event.EntryIndex.
+func (app *ChatApplication) handleConversationEntryAction(event domain.ConversationEntryActionEvent) tea.Cmd {
+	entries := app.conversationRepo.GetMessages()
+	if event.EntryIndex < 0 || event.EntryIndex >= len(entries) {
+		return nil
+	}
+	entry := entries[event.EntryIndex]
+
+	switch event.Action {
+	case domain.EntryActionCopy:
+		return keybinding.CopySelectedText(components.ConversationEntryText(entry))
+	case domain.EntryActionToggleExpand:
+		app.conversationView.ToggleToolResultExpansion(event.EntryIndex)
+	case domain.EntryActionPin:
+		return app.messageHistoryHandler.HandleTogglePin(event.EntryIndex)
+	case domain.EntryActionQuoteReply:
+		app.quoteReply(components.ConversationEntryText(entry))
+	case domain.EntryActionRerunTool:
+		return app.rerunTool(entry.ToolExecution)
+	}
+	return nil
+}
+
+// quoteReply puts text into the input as a markdown quote, above whatever
+// the user had already typed.
+func (app *ChatApplication) quoteReply(text string) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+
+	input := strings.Join(lines, "\n") + "\n\n" + app.inputView.GetInput()
+	app.inputView.SetText(input)
+	app.inputView.SetCursor(len(input))
+}
+
+// rerunTool runs a tool again with the arguments it was called with, through
+// the same path as typing !!Tool({...}). It waits for the agent to be idle so
+// the result does not land in the middle of a turn.
+func (app *ChatApplication) rerunTool(execution *domain.ToolExecutionResult) tea.Cmd {
+	if execution == nil {
+		return nil
+	}
+	if app.stateManager.IsAgentBusy() {
+		return func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    "Wait for the agent to finish before re-running a tool",
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		}
+	}
+
+	args := ""
+	if len(execution.Arguments) > 0 {
+		encoded, err := json.Marshal(execution.Arguments)
+		if err != nil {
+			return func() tea.Msg {
+				return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to re-run %s: %v", execution.ToolName, err)}
+			}
+		}
+		args = string(encoded)
+	}
+
+	command := fmt.Sprintf("!!%s(%s)", execution.ToolName, args)
+	return func() tea.Msg { return domain.UserInputEvent{Content: command} }
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestQuoteReply_KeepsTheDraftBelowTheQuote(t *testing.T) {
+	app, inputView := newInputRoutingTestApp(t, domain.ViewStateChat, "my draft")
+
+	app.quoteReply("first line\n\nsecond line\n")
+
+	want := "> first line\n>\n> second line\n\nmy draft"
+	if got := inputView.GetInput(); got != want {
+		t.Errorf("input = %q, want %q", got, want)
+	}
+}
+
+func TestRerunTool(t *testing.T) {
+	app, _ := newInputRoutingTestApp(t, domain.ViewStateChat, "")
+
+	cmd := app.rerunTool(&domain.ToolExecutionResult{
+		ToolName:  "Read",
+		Arguments: map[string]any{"file_path": "main.go"},
+	})
+	event, ok := cmd().(domain.UserInputEvent)
+	if !ok || event.Content != `!!Read({"file_path":"main.go"})` {
+		t.Fatalf("rerunTool should submit the call as direct tool input, got %#v", cmd())
+	}
+
+	cmd = app.rerunTool(&domain.ToolExecutionResult{ToolName: "Tree"})
+	if event, _ := cmd().(domain.UserInputEvent); event.Content != "!!Tree()" {
+		t.Errorf("a call without arguments should re-run as !!Tree(), got %q", event.Content)
+	}
+
+	_ = app.stateManager.StartChatSession("req-1", "test-model", nil)
+	if _, ok := app.rerunTool(&domain.ToolExecutionResult{ToolName: "Tree"})().(domain.SetStatusEvent); !ok {
+		t.Error("re-running while the agent is busy should only report why it waited")
+	}
+}
@@ -7,10 +7,10 @@ import (
 )
 
 // guardKeys holds the fixed key.Bindings for the chat view's precedence
-// guards — the focus modes (attachments tree, status bar, question form,
-// message history) that capture keys before the keybinding registry runs.
+// guards — the focus modes (attachments tree, todo list, message queue, status
+// bar, question form, message history) that capture keys before the keybinding registry runs.
 // These are navigation keys local to their overlay and are not user-remappable;
-// the config-backed focus-attachments binding lives on ChatApplication.
+// the config-backed focus bindings live on ChatApplication.
 var guardKeys = struct {
 	// interrupt always falls through the guards so the user can cancel the turn.
 	interrupt key.Binding
@@ -20,6 +20,12 @@ var guardKeys = struct {
 	confirm key.Binding
 	cancel  key.Binding
 
+	historyRecover key.Binding
+	historyFork    key.Binding
+	historyPin     key.Binding
+
+	codeBlockNumber key.Binding
+
 	attachRemove key.Binding
 	attachClear  key.Binding
 	attachExit   key.Binding
@@ -31,6 +37,28 @@ var guardKeys = struct {
 
 	questionToggle    key.Binding
 	questionBackspace key.Binding
+
+	// search keys leave letters free for the query, so no k/j here.
+	searchNext      key.Binding
+	searchPrev      key.Binding
+	searchBackspace key.Binding
+
+	historySearchOlder key.Binding
+	historySearchNewer key.Binding
+
+	// list keys are shared by the editable todo list and message queue.
+	listMoveUp   key.Binding
+	listMoveDown key.Binding
+	listDelete   key.Binding
+	listExit     key.Binding
+	todoToggle   key.Binding
+	queueEdit    key.Binding
+	queueBump    key.Binding
+
+	selectionMark key.Binding
+	selectionCopy key.Binding
+	selectionPgUp key.Binding
+	selectionPgDn key.Binding
 }{
 	interrupt: key.NewBinding(key.WithKeys("ctrl+c")),
 
@@ -39,6 +67,12 @@ var guardKeys = struct {
 	confirm: key.NewBinding(key.WithKeys("enter")),
 	cancel:  key.NewBinding(key.WithKeys("esc")),
 
+	historyRecover: key.NewBinding(key.WithKeys("r")),
+	historyFork:    key.NewBinding(key.WithKeys("f")),
+	historyPin:     key.NewBinding(key.WithKeys("p")),
+
+	codeBlockNumber: key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9")),
+
 	attachRemove: key.NewBinding(key.WithKeys("d", "x", "backspace", "delete")),
 	attachClear:  key.NewBinding(key.WithKeys("c")),
 	attachExit:   key.NewBinding(key.WithKeys("esc", "q")),
@@ -50,6 +84,26 @@ var guardKeys = struct {
 
 	questionToggle:    key.NewBinding(key.WithKeys(" ", "space")),
 	questionBackspace: key.NewBinding(key.WithKeys("backspace")),
+
+	searchNext:      key.NewBinding(key.WithKeys("enter", "down", "ctrl+n")),
+	searchPrev:      key.NewBinding(key.WithKeys("up", "ctrl+p")),
+	searchBackspace: key.NewBinding(key.WithKeys("backspace")),
+
+	historySearchOlder: key.NewBinding(key.WithKeys("ctrl+r", "up", "ctrl+p")),
+	historySearchNewer: key.NewBinding(key.WithKeys("down", "ctrl+n", "ctrl+s")),
+
+	listMoveUp:   key.NewBinding(key.WithKeys("shift+up", "K")),
+	listMoveDown: key.NewBinding(key.WithKeys("shift+down", "J")),
+	listDelete:   key.NewBinding(key.WithKeys("d", "delete", "backspace")),
+	listExit:     key.NewBinding(key.WithKeys("esc", "q")),
+	todoToggle:   key.NewBinding(key.WithKeys("space", " ", "x", "enter")),
+	queueEdit:    key.NewBinding(key.WithKeys("e", "enter")),
+	queueBump:    key.NewBinding(key.WithKeys("p")),
+
+	selectionMark: key.NewBinding(key.WithKeys("v", "space", " ")),
+	selectionCopy: key.NewBinding(key.WithKeys("y", "enter")),
+	selectionPgUp: key.NewBinding(key.WithKeys("pgup", "ctrl+u")),
+	selectionPgDn: key.NewBinding(key.WithKeys("pgdown", "ctrl+d")),
 }
 
 // focusAttachmentsBinding resolves the user-remappable focus-attachments keys
@@ -59,3 +113,17 @@ func focusAttachmentsBinding(kb config.KeybindingsConfig) key.Binding {
 	focusKeys := config.ResolveNamespaceBindings(kb, config.NamespaceChat)[actChatFocusAttachments]
 	return key.NewBinding(key.WithKeys(focusKeys...))
 }
+
+// focusTodosBinding resolves the user-remappable keys that move focus to the
+// todo list, like focusAttachmentsBinding.
+func focusTodosBinding(kb config.KeybindingsConfig) key.Binding {
+	focusKeys := config.ResolveNamespaceBindings(kb, config.NamespaceChat)[actChatFocusTodos]
+	return key.NewBinding(key.WithKeys(focusKeys...))
+}
+
+// focusQueueBinding resolves the user-remappable keys that move focus to the
+// message queue, like focusAttachmentsBinding.
+func focusQueueBinding(kb config.KeybindingsConfig) key.Binding {
+	focusKeys := config.ResolveNamespaceBindings(kb, config.NamespaceChat)[actChatFocusQueue]
+	return key.NewBinding(key.WithKeys(focusKeys...))
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	shortcuts "github.com/inference-gateway/cli/internal/shortcuts"
+)
+
+// recordMacroStep adds a submitted input to the macro being recorded, if any.
+// Only what the user sends is recorded, not the steps of a macro replaying.
+func (app *ChatApplication) recordMacroStep(input string) {
+	if app.shortcutRegistry == nil {
+		return
+	}
+	if s, ok := app.shortcutRegistry.Get("macro"); ok {
+		if macro, ok := s.(*shortcuts.MacroShortcut); ok {
+			macro.Record(input)
+		}
+	}
+}
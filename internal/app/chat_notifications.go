@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+	notifier "github.com/inference-gateway/cli/internal/services/notifier"
+)
+
+// notificationTitle is the title of every desktop notification
+const notificationTitle = "infer"
+
+// desktopNotifier is the part of notifier.Notifier the chat uses
+type desktopNotifier interface {
+	Notify(title, body string) tea.Cmd
+}
+
+// turnNotifier decides when a desktop notification is worth sending: a turn
+// that ran at least minTurn, or a prompt waiting on the user, and only while
+// the terminal does not have focus.
+type turnNotifier struct {
+	notifier  desktopNotifier
+	minTurn   time.Duration
+	focused   bool
+	turnStart time.Time
+	now       func() time.Time
+}
+
+// newTurnNotifier returns nil when notifications are disabled or the method
+// is invalid, so callers only need a nil check.
+func newTurnNotifier(cfg config.NotificationsConfig) *turnNotifier {
+	if !cfg.Enabled {
+		return nil
+	}
+	n, err := notifier.New(cfg.Method)
+	if err != nil {
+		logger.Warn("desktop notifications disabled", "error", err)
+		return nil
+	}
+	logger.Debug("desktop notifications enabled", "method", n.Method())
+	return &turnNotifier{
+		notifier: n,
+		minTurn:  time.Duration(cfg.MinTurnSeconds) * time.Second,
+		focused:  true,
+		now:      time.Now,
+	}
+}
+
+// handle tracks terminal focus and the current turn, returning a command that
+// sends a notification when msg needs one.
+func (tn *turnNotifier) handle(msg tea.Msg) tea.Cmd {
+	switch m := msg.(type) {
+	case tea.FocusMsg:
+		tn.focused = true
+	case tea.BlurMsg:
+		tn.focused = false
+	case domain.ChatStartEvent:
+		if tn.turnStart.IsZero() {
+			tn.turnStart = tn.now()
+		}
+	case domain.ChatCompleteEvent:
+		if m.Cancelled || len(m.ToolCalls) == 0 {
+			return tn.endTurn("Agent finished")
+		}
+	case domain.ChatErrorEvent:
+		return tn.endTurn("Agent stopped with an error")
+	case domain.ToolApprovalRequestedEvent:
+		return tn.notify("Approval needed for " + m.ToolCall.Function.Name)
+	case domain.PlanApprovalRequestedEvent:
+		return tn.notify("Plan ready for review")
+	case domain.UserQuestionRequestedEvent:
+		return tn.notify("The agent has a question for you")
+	}
+	return nil
+}
+
+// endTurn closes the current turn and notifies if it ran long enough
+func (tn *turnNotifier) endTurn(summary string) tea.Cmd {
+	if tn.turnStart.IsZero() {
+		return nil
+	}
+	elapsed := tn.now().Sub(tn.turnStart)
+	tn.turnStart = time.Time{}
+	if elapsed < tn.minTurn {
+		return nil
+	}
+	return tn.notify(fmt.Sprintf("%s after %s", summary, elapsed.Round(time.Second)))
+}
+
+func (tn *turnNotifier) notify(body string) tea.Cmd {
+	if tn.focused {
+		return nil
+	}
+	return tn.notifier.Notify(notificationTitle, body)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+type recordingNotifier struct {
+	bodies []string
+}
+
+func (r *recordingNotifier) Notify(_, body string) tea.Cmd {
+	r.bodies = append(r.bodies, body)
+	return func() tea.Msg { return nil }
+}
+
+func newTestTurnNotifier() (*turnNotifier, *recordingNotifier, *time.Time) {
+	rec := &recordingNotifier{}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tn := &turnNotifier{
+		notifier: rec,
+		minTurn:  30 * time.Second,
+		focused:  true,
+		now:      func() time.Time { return now },
+	}
+	return tn, rec, &now
+}
+
+func TestTurnNotifier_LongTurnWhileUnfocused(t *testing.T) {
+	tn, rec, now := newTestTurnNotifier()
+
+	tn.handle(tea.BlurMsg{})
+	tn.handle(domain.ChatStartEvent{})
+	*now = now.Add(20 * time.Second)
+	toolCall := sdk.ChatCompletionMessageToolCall{ID: "call_1"}
+	if cmd := tn.handle(domain.ChatCompleteEvent{ToolCalls: []sdk.ChatCompletionMessageToolCall{toolCall}}); cmd != nil {
+		t.Fatal("a completion with tool calls does not end the turn")
+	}
+	tn.handle(domain.ChatStartEvent{})
+	*now = now.Add(25 * time.Second)
+
+	if cmd := tn.handle(domain.ChatCompleteEvent{}); cmd == nil {
+		t.Fatal("expected a notification for a 45s turn")
+	}
+	if len(rec.bodies) != 1 || rec.bodies[0] != "Agent finished after 45s" {
+		t.Errorf("notifications = %q", rec.bodies)
+	}
+}
+
+func TestTurnNotifier_SkipsShortOrFocusedTurns(t *testing.T) {
+	tn, rec, now := newTestTurnNotifier()
+
+	tn.handle(domain.ChatStartEvent{})
+	*now = now.Add(time.Minute)
+	tn.handle(domain.ChatCompleteEvent{})
+
+	tn.handle(tea.BlurMsg{})
+	tn.handle(domain.ChatStartEvent{})
+	*now = now.Add(5 * time.Second)
+	tn.handle(domain.ChatErrorEvent{})
+
+	if len(rec.bodies) != 0 {
+		t.Errorf("expected no notifications, got %q", rec.bodies)
+	}
+}
+
+func TestTurnNotifier_PromptsWhileUnfocused(t *testing.T) {
+	tn, rec, _ := newTestTurnNotifier()
+
+	approval := domain.ToolApprovalRequestedEvent{}
+	approval.ToolCall.Function.Name = "Bash"
+	if cmd := tn.handle(approval); cmd != nil {
+		t.Fatal("no notification while the terminal has focus")
+	}
+
+	tn.handle(tea.BlurMsg{})
+	tn.handle(approval)
+	tn.handle(domain.PlanApprovalRequestedEvent{})
+	tn.handle(domain.UserQuestionRequestedEvent{})
+	tn.handle(tea.FocusMsg{})
+	tn.handle(domain.UserQuestionRequestedEvent{})
+
+	want := []string{"Approval needed for Bash", "Plan ready for review", "The agent has a question for you"}
+	if len(rec.bodies) != len(want) {
+		t.Fatalf("notifications = %q, want %q", rec.bodies, want)
+	}
+	for i := range want {
+		if rec.bodies[i] != want[i] {
+			t.Errorf("notification %d = %q, want %q", i, rec.bodies[i], want[i])
+		}
+	}
+}
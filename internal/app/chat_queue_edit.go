@@ -0,0 +1,158 @@
+package app
+
+import (
+	key "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	components "github.com/inference-gateway/cli/internal/ui/components"
+)
+
+// queueEditState is a queued message being edited in the input. The request
+// ID confirms the message is still at index when the edit is saved, since
+// the agent may have taken the queue in the meantime.
+type queueEditState struct {
+	index     int
+	requestID string
+	draft     string
+}
+
+// handleQueueBoxKeys interprets keys while the message queue holds focus:
+// select, reorder, edit, delete, change priority, or leave. All keys are consumed. The queue
+// can drain under the cursor, so focus is dropped once it is empty.
+func (app *ChatApplication) handleQueueBoxKeys(keyMsg tea.KeyPressMsg) []tea.Cmd {
+	qv := app.queueBoxView
+	size := app.messageQueue.Size()
+	cursor := min(qv.Cursor(), size-1)
+
+	gk := guardKeys
+	switch {
+	case size == 0, key.Matches(keyMsg, app.focusQueue), key.Matches(keyMsg, gk.listExit):
+		qv.Blur()
+	case key.Matches(keyMsg, gk.listMoveUp):
+		if app.messageQueue.Move(cursor, cursor-1) {
+			cursor--
+		}
+	case key.Matches(keyMsg, gk.listMoveDown):
+		if app.messageQueue.Move(cursor, cursor+1) {
+			cursor++
+		}
+	case key.Matches(keyMsg, gk.navUp):
+		cursor--
+	case key.Matches(keyMsg, gk.navDown):
+		cursor++
+	case key.Matches(keyMsg, gk.listDelete):
+		app.messageQueue.Remove(cursor)
+		if app.messageQueue.IsEmpty() {
+			qv.Blur()
+		}
+	case key.Matches(keyMsg, gk.queueEdit):
+		return app.startQueueEdit(cursor)
+	case key.Matches(keyMsg, gk.queueBump):
+		var cmd tea.Cmd
+		cursor, cmd = app.cycleQueuePriority(cursor)
+		qv.SetCursor(cursor, app.messageQueue.Size())
+		return []tea.Cmd{cmd}
+	}
+
+	qv.SetCursor(cursor, app.messageQueue.Size())
+	return nil
+}
+
+// cycleQueuePriority moves the message at index to the next priority and
+// returns its new index, so the cursor follows it to its new place
+func (app *ChatApplication) cycleQueuePriority(index int) (int, tea.Cmd) {
+	queued := app.messageQueue.GetAll()
+	if index < 0 || index >= len(queued) {
+		return index, nil
+	}
+
+	priority := queued[index].Priority.Next()
+	if !app.messageQueue.SetPriority(index, priority) {
+		return index, nil
+	}
+	for i, msg := range app.messageQueue.GetAll() {
+		if msg.RequestID == queued[index].RequestID && msg.QueuedAt.Equal(queued[index].QueuedAt) {
+			index = i
+			break
+		}
+	}
+
+	switch priority {
+	case domain.QueuePriorityInterrupt:
+		return index, queueStatus("Queued message will be sent after the current tool call")
+	case domain.QueuePriorityLow:
+		return index, queueStatus("Queued message will be sent after the others")
+	default:
+		return index, queueStatus("Queued message will be sent in order")
+	}
+}
+
+// startQueueEdit loads the queued message at index into the input. Enter
+// saves it back in place and esc restores the draft the input held.
+func (app *ChatApplication) startQueueEdit(index int) []tea.Cmd {
+	queued := app.messageQueue.GetAll()
+	if index < 0 || index >= len(queued) {
+		return nil
+	}
+
+	content, err := queued[index].Message.Content.AsMessageContent0()
+	if err != nil || queued[index].Message.ToolCalls != nil {
+		return []tea.Cmd{queueStatus("Only text messages can be edited")}
+	}
+
+	app.queueBoxView.Blur()
+	app.queueEdit = &queueEditState{
+		index:     index,
+		requestID: queued[index].RequestID,
+		draft:     app.inputView.GetInput(),
+	}
+	app.inputView.SetText(content)
+	app.inputView.SetCursor(len(content))
+	if iv, ok := app.inputView.(*components.InputView); ok {
+		iv.SetCustomHint("Editing a queued message - enter to save, esc to cancel")
+	}
+	return nil
+}
+
+// saveQueueEdit replaces the queued message being edited with content. If it
+// was sent while the user was typing, the edited text stays in the input so
+// it can be sent as a new message.
+func (app *ChatApplication) saveQueueEdit(content string) tea.Cmd {
+	edit := app.queueEdit
+
+	queued := app.messageQueue.GetAll()
+	if edit.index >= len(queued) || queued[edit.index].RequestID != edit.requestID {
+		app.endQueueEdit(content)
+		return queueStatus("The queued message was already sent - press enter to send your edit as a new message")
+	}
+
+	app.endQueueEdit(edit.draft)
+
+	app.messageQueue.Replace(edit.index, sdk.Message{
+		Role:    sdk.User,
+		Content: sdk.NewMessageContent(content),
+	})
+	return queueStatus("Queued message updated")
+}
+
+// cancelQueueEdit leaves the queued message as it was
+func (app *ChatApplication) cancelQueueEdit() {
+	app.endQueueEdit(app.queueEdit.draft)
+}
+
+func (app *ChatApplication) endQueueEdit(draft string) {
+	app.queueEdit = nil
+	app.inputView.SetText(draft)
+	app.inputView.SetCursor(len(draft))
+	if iv, ok := app.inputView.(*components.InputView); ok {
+		iv.ClearCustomHint()
+	}
+}
+
+func queueStatus(message string) tea.Cmd {
+	return func() tea.Msg {
+		return domain.SetStatusEvent{Message: message, Spinner: false, StatusType: domain.StatusDefault}
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"testing"
+
+	key "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	services "github.com/inference-gateway/cli/internal/services"
+	components "github.com/inference-gateway/cli/internal/ui/components"
+)
+
+func newQueueEditTestApp(t *testing.T, contents ...string) (*ChatApplication, *components.InputView, *services.MessageQueueService) {
+	t.Helper()
+	app, inputView := newInputRoutingTestApp(t, domain.ViewStateChat, "my draft")
+	queue := services.NewMessageQueueService()
+	for _, content := range contents {
+		queue.Enqueue(sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(content)}, "req-"+content)
+	}
+	app.messageQueue = queue
+	app.queueBoxView = components.NewQueueBoxView(nil)
+	app.focusQueue = key.NewBinding(key.WithKeys("alt+q"))
+	if !app.queueBoxView.Focus(queue.Size()) {
+		t.Fatal("expected the queue to take focus")
+	}
+	return app, inputView, queue
+}
+
+func TestHandleQueueBoxKeys_ReorderDeleteAndEdit(t *testing.T) {
+	app, inputView, queue := newQueueEditTestApp(t, "first", "second", "third")
+
+	app.handleQueueBoxKeys(printableKey("j"))
+	app.handleQueueBoxKeys(tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModShift})
+	app.handleQueueBoxKeys(printableKey("j"))
+	app.handleQueueBoxKeys(printableKey("j"))
+	app.handleQueueBoxKeys(printableKey("d"))
+
+	got := queuedContentsOf(t, queue)
+	if len(got) != 2 || got[0] != "second" || got[1] != "first" {
+		t.Fatalf("queue = %v, want [second first]", got)
+	}
+	if app.queueBoxView.Cursor() != 1 {
+		t.Errorf("cursor = %d, want it clamped to the last message", app.queueBoxView.Cursor())
+	}
+
+	app.handleQueueBoxKeys(printableKey("e"))
+	if app.queueBoxView.IsFocused() || inputView.GetInput() != "first" {
+		t.Fatalf("edit should load the message into the input, got %q", inputView.GetInput())
+	}
+
+	inputView.SetText("first, reworded")
+	app.SendMessage()
+	if got := queuedContentsOf(t, queue); got[1] != "first, reworded" {
+		t.Errorf("queue = %v, want the edit saved in place", got)
+	}
+	if inputView.GetInput() != "my draft" || app.queueEdit != nil {
+		t.Errorf("saving should restore the draft, got %q", inputView.GetInput())
+	}
+}
+
+func TestQueueEdit_KeepsTextWhenAlreadySent(t *testing.T) {
+	app, inputView, queue := newQueueEditTestApp(t, "only")
+
+	app.handleQueueBoxKeys(printableKey("e"))
+	queue.Dequeue()
+	inputView.SetText("only, reworded")
+	app.SendMessage()
+
+	if queue.Size() != 0 || inputView.GetInput() != "only, reworded" {
+		t.Errorf("the edit should stay in the input to send anew, got %q", inputView.GetInput())
+	}
+}
+
+func queuedContentsOf(t *testing.T, queue *services.MessageQueueService) []string {
+	t.Helper()
+	var contents []string
+	for _, queued := range queue.GetAll() {
+		content, _ := queued.Message.Content.AsMessageContent0()
+		contents = append(contents, content)
+	}
+	return contents
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	config "github.com/inference-gateway/cli/config"
+	components "github.com/inference-gateway/cli/internal/ui/components"
+)
+
+const (
+	// defaultStatusSegmentInterval applies to segments without an interval.
+	defaultStatusSegmentInterval = 30 * time.Second
+
+	// statusSegmentTimeout bounds one run of a segment command so a hung
+	// script cannot pile up runs.
+	statusSegmentTimeout = 5 * time.Second
+
+	// maxStatusSegmentWidth caps a segment's text so a chatty command cannot
+	// push the built-in indicators off the status bar.
+	maxStatusSegmentWidth = 40
+)
+
+// statusSegmentOutputMsg carries the output of one run of the segment at
+// index in chat.status_bar.segments.
+type statusSegmentOutputMsg struct {
+	index  int
+	output string
+}
+
+// statusSegmentTickMsg fires when the segment at index is due to run again.
+type statusSegmentTickMsg struct {
+	index int
+}
+
+// startStatusSegments runs every configured script segment once; each run
+// schedules the next when its output arrives.
+func (app *ChatApplication) startStatusSegments() tea.Cmd {
+	if app.config == nil || !app.config.Chat.StatusBar.Enabled {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for i, segment := range app.config.Chat.StatusBar.Segments {
+		if segment.Name != "" && segment.Command != "" {
+			cmds = append(cmds, runStatusSegmentCmd(i, segment))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// runStatusSegmentCmd runs a segment command off the UI goroutine. Failures
+// are reported as empty output, which hides the segment until the next run.
+func runStatusSegmentCmd(index int, segment config.StatusBarSegment) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), statusSegmentTimeout)
+		defer cancel()
+
+		output, err := exec.CommandContext(ctx, "bash", "-c", segment.Command).Output()
+		if err != nil {
+			return statusSegmentOutputMsg{index: index}
+		}
+		return statusSegmentOutputMsg{index: index, output: statusSegmentText(string(output))}
+	}
+}
+
+// statusSegmentText reduces command output to its first non-blank line,
+// truncated to fit the status bar.
+func statusSegmentText(output string) string {
+	for line := range strings.SplitSeq(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxStatusSegmentWidth {
+			line = string(runes[:maxStatusSegmentWidth-1]) + "…"
+		}
+		return line
+	}
+	return ""
+}
+
+// handleStatusSegmentOutput shows a segment's latest output and schedules
+// its next run.
+func (app *ChatApplication) handleStatusSegmentOutput(msg statusSegmentOutputMsg) tea.Cmd {
+	segments := app.config.Chat.StatusBar.Segments
+	if msg.index >= len(segments) {
+		return nil
+	}
+	segment := segments[msg.index]
+
+	if isb, ok := app.inputStatusBar.(*components.InputStatusBar); ok {
+		isb.SetSegmentOutput(segment.Name, msg.output)
+	}
+
+	interval := defaultStatusSegmentInterval
+	if segment.IntervalSeconds > 0 {
+		interval = time.Duration(segment.IntervalSeconds) * time.Second
+	}
+	index := msg.index
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return statusSegmentTickMsg{index: index}
+	})
+}
+
+func (app *ChatApplication) handleStatusSegmentTick(msg statusSegmentTickMsg) tea.Cmd {
+	segments := app.config.Chat.StatusBar.Segments
+	if msg.index >= len(segments) {
+		return nil
+	}
+	return runStatusSegmentCmd(msg.index, segments[msg.index])
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatusSegmentText(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "trims the trailing newline", output: "prod-eu\n", want: "prod-eu"},
+		{name: "keeps the first non-blank line", output: "\n  VPN up  \nconnected since 9:00\n", want: "VPN up"},
+		{name: "blank output hides the segment", output: " \n\n", want: ""},
+		{name: "long lines are truncated", output: strings.Repeat("x", 60), want: strings.Repeat("x", maxStatusSegmentWidth-1) + "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusSegmentText(tt.output); got != tt.want {
+				t.Errorf("statusSegmentText(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
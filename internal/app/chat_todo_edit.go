@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	key "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// handleTodoBoxKeys interprets keys while the todo list holds focus: move,
+// check off, reorder, delete, or leave. All keys are consumed. Each edit is
+// mirrored to the state manager and side panel right away; the agent hears
+// about them once the user leaves the list.
+func (app *ChatApplication) handleTodoBoxKeys(keyMsg tea.KeyPressMsg) []tea.Cmd {
+	tv := app.todoBoxView
+	gk := guardKeys
+	switch {
+	case key.Matches(keyMsg, app.focusTodos), key.Matches(keyMsg, gk.listExit):
+		tv.Blur()
+	case key.Matches(keyMsg, gk.listMoveUp):
+		tv.MoveSelected(-1)
+	case key.Matches(keyMsg, gk.listMoveDown):
+		tv.MoveSelected(1)
+	case key.Matches(keyMsg, gk.navUp):
+		tv.MoveCursor(-1)
+	case key.Matches(keyMsg, gk.navDown):
+		tv.MoveCursor(1)
+	case key.Matches(keyMsg, gk.todoToggle):
+		tv.ToggleSelectedDone()
+	case key.Matches(keyMsg, gk.listDelete):
+		tv.DeleteSelected()
+	}
+
+	todos := tv.GetTodos()
+	app.stateManager.SetTodos(todos)
+	if app.sidePanelView != nil {
+		app.sidePanelView.SetTodos(todos)
+	}
+
+	if tv.IsFocused() {
+		return nil
+	}
+	return app.finishTodoEdit()
+}
+
+// finishTodoEdit queues the user's edits for the agent when focus leaves the
+// list, reporting them right away if the agent is idle.
+func (app *ChatApplication) finishTodoEdit() []tea.Cmd {
+	todos, edited := app.todoBoxView.TakeEdits()
+	if !edited {
+		return nil
+	}
+	app.pendingTodoSync = todos
+	if app.pendingTodoSync == nil {
+		app.pendingTodoSync = []domain.TodoItem{}
+	}
+
+	status := "Todo list updated - the agent will see it with your next message"
+	if app.flushTodoSync() {
+		status = "Todo list updated"
+	}
+	return []tea.Cmd{func() tea.Msg {
+		return domain.SetStatusEvent{Message: status, Spinner: false, StatusType: domain.StatusDefault}
+	}}
+}
+
+// flushTodoSync adds the pending todo edits to the conversation as a hidden
+// TodoWrite-style reminder so the model's view of the list matches the
+// user's. It waits while the agent is busy, since a message added mid-turn
+// would split a tool call from its result. Reports whether it was added.
+func (app *ChatApplication) flushTodoSync() bool {
+	if app.pendingTodoSync == nil || app.conversationRepo == nil || app.stateManager.IsAgentBusy() {
+		return false
+	}
+
+	entry := domain.ConversationEntry{
+		Message: sdk.Message{
+			Role:    sdk.User,
+			Content: sdk.NewMessageContent(todoEditReminder(app.pendingTodoSync)),
+		},
+		Time:   time.Now(),
+		Hidden: true,
+	}
+	if err := app.conversationRepo.AddMessage(entry); err != nil {
+		logger.Error("failed to add todo edit reminder", "error", err)
+		return false
+	}
+	app.pendingTodoSync = nil
+	return true
+}
+
+// todoEditReminder renders the edited list in the shape TodoWrite takes, so
+// the model can carry on from it with its next TodoWrite call.
+func todoEditReminder(todos []domain.TodoItem) string {
+	var b strings.Builder
+	b.WriteString("<system-reminder>\nThe user edited the todo list by hand. ")
+	if len(todos) == 0 {
+		b.WriteString("They removed every item, so the list is now empty.")
+	} else {
+		b.WriteString("This is the current list; treat it as the latest TodoWrite state and keep it in order:\n")
+		for _, todo := range todos {
+			fmt.Fprintf(&b, "\n- [%s] %s", todo.Status, todo.Content)
+		}
+	}
+	b.WriteString("\n</system-reminder>")
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	key "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	services "github.com/inference-gateway/cli/internal/services"
+	components "github.com/inference-gateway/cli/internal/ui/components"
+)
+
+func TestHandleTodoBoxKeys_EditsAndSyncsToAgent(t *testing.T) {
+	app, _ := newInputRoutingTestApp(t, domain.ViewStateChat, "")
+	repo := services.NewInMemoryConversationRepository(nil, nil)
+	app.conversationRepo = repo
+	app.focusTodos = key.NewBinding(key.WithKeys("alt+x"))
+	app.todoBoxView = components.NewTodoBoxView(nil)
+	app.todoBoxView.SetTodos([]domain.TodoItem{
+		{ID: "1", Content: "write the parser", Status: "in_progress"},
+		{ID: "2", Content: "add tests", Status: "pending"},
+		{ID: "3", Content: "update docs", Status: "pending"},
+	})
+	if !app.todoBoxView.Focus() {
+		t.Fatal("expected the todo list to take focus")
+	}
+
+	app.handleTodoBoxKeys(printableKey(" "))
+	app.handleTodoBoxKeys(printableKey("j"))
+	app.handleTodoBoxKeys(printableKey("j"))
+	app.handleTodoBoxKeys(tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModShift})
+	app.handleTodoBoxKeys(printableKey("j"))
+	app.handleTodoBoxKeys(printableKey("d"))
+
+	want := []string{"write the parser:completed", "update docs:pending"}
+	var got []string
+	for _, todo := range app.stateManager.GetTodos() {
+		got = append(got, todo.Content+":"+todo.Status)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("state manager todos = %v, want %v", got, want)
+	}
+	if repo.GetMessageCount() != 0 {
+		t.Fatal("the agent should only hear about edits once the list is left")
+	}
+
+	app.handleTodoBoxKeys(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if app.todoBoxView.IsFocused() {
+		t.Fatal("esc should return focus to the input")
+	}
+	messages := repo.GetMessages()
+	if len(messages) != 1 || !messages[0].Hidden {
+		t.Fatalf("expected one hidden reminder, got %d entries", len(messages))
+	}
+	content, _ := messages[0].Message.Content.AsMessageContent0()
+	if !strings.Contains(content, "- [completed] write the parser\n- [pending] update docs") {
+		t.Errorf("unexpected reminder %q", content)
+	}
+}
+
+func TestFlushTodoSync_WaitsWhileAgentBusy(t *testing.T) {
+	app, _ := newInputRoutingTestApp(t, domain.ViewStateChat, "")
+	repo := services.NewInMemoryConversationRepository(nil, nil)
+	app.conversationRepo = repo
+	app.pendingTodoSync = []domain.TodoItem{}
+	_ = app.stateManager.StartChatSession("req-1", "test-model", nil)
+
+	if app.flushTodoSync() || repo.GetMessageCount() != 0 {
+		t.Fatal("edits must not be added while the agent is mid-turn")
+	}
+
+	app.stateManager.EndChatSession()
+	if !app.flushTodoSync() || repo.GetMessageCount() != 1 || app.pendingTodoSync != nil {
+		t.Fatal("edits should be added once the agent is idle")
+	}
+	content, _ := repo.GetMessages()[0].Message.Content.AsMessageContent0()
+	if !strings.Contains(content, "the list is now empty") {
+		t.Errorf("unexpected reminder %q", content)
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	components "github.com/inference-gateway/cli/internal/ui/components"
+)
+
+// sentMessage is a message sent from the input, remembered so a premature
+// enter can be taken back before the model answers.
+type sentMessage struct {
+	draft    components.Draft
+	snippets []components.SnippetSelection
+	// entries is the conversation length before the message was added.
+	entries int
+	sentAt  time.Time
+}
+
+// rememberSent records the message being sent for undo send. Slash and bash
+// commands run immediately and are not undoable.
+func (app *ChatApplication) rememberSent(input string, draft components.Draft) {
+	app.lastSent = nil
+	if app.undoSendWindow() <= 0 || app.conversationRepo == nil || isCommandInput(input) {
+		return
+	}
+
+	// The temp files behind pasted images are removed once sent; the image
+	// data itself is kept in the attachment.
+	for i := range draft.Images {
+		draft.Images[i].SourcePath = ""
+	}
+
+	app.lastSent = &sentMessage{
+		draft:    draft,
+		snippets: app.pendingSnippets,
+		entries:  app.conversationRepo.GetMessageCount(),
+		sentAt:   time.Now(),
+	}
+}
+
+func (app *ChatApplication) undoSendWindow() time.Duration {
+	if app.config == nil {
+		return 0
+	}
+	return time.Duration(app.config.Chat.UndoSendSeconds) * time.Second
+}
+
+// handleUndoSend cancels the in-flight request and restores the last sent
+// message - text, images and snippets - to the input.
+func (app *ChatApplication) handleUndoSend() tea.Cmd {
+	sent := app.lastSent
+	if sent == nil || time.Since(sent.sentAt) > app.undoSendWindow() || !app.awaitingReply(sent) {
+		return func() tea.Msg {
+			return domain.SetStatusEvent{Message: "Nothing to undo", Spinner: false}
+		}
+	}
+	app.lastSent = nil
+
+	if chatSession := app.stateManager.GetChatSession(); chatSession != nil && app.agentService != nil {
+		_ = app.agentService.CancelRequest(chatSession.RequestID)
+	}
+	app.stateManager.EndChatSession()
+	app.stateManager.EndToolExecution()
+
+	var err error
+	if sent.entries == 0 {
+		err = app.conversationRepo.Clear()
+	} else {
+		err = app.conversationRepo.DeleteMessagesAfterIndex(sent.entries - 1)
+	}
+	if err != nil {
+		return func() tea.Msg {
+			return domain.ShowErrorEvent{
+				Error:  fmt.Sprintf("Failed to undo send: %v", err),
+				Sticky: false,
+			}
+		}
+	}
+
+	if iv, ok := app.inputView.(*components.InputView); ok {
+		iv.RestoreDraft(sent.draft)
+	}
+	app.pendingSnippets = append(sent.snippets, app.pendingSnippets...)
+	app.focusedComponent = app.inputView
+
+	return tea.Batch(
+		func() tea.Msg {
+			return domain.UpdateHistoryEvent{History: app.conversationRepo.GetMessages()}
+		},
+		func() tea.Msg {
+			return domain.SetStatusEvent{Message: "Message moved back to the input", Spinner: false}
+		},
+	)
+}
+
+// awaitingReply reports whether the model has yet to answer the sent
+// message: nothing has streamed in and the conversation holds no assistant
+// or tool entries past it.
+func (app *ChatApplication) awaitingReply(sent *sentMessage) bool {
+	if chatSession := app.stateManager.GetChatSession(); chatSession != nil && !chatSession.IsFirstChunk {
+		return false
+	}
+
+	entries := app.conversationRepo.GetMessages()
+	if len(entries) <= sent.entries {
+		return false
+	}
+	for _, entry := range entries[sent.entries:] {
+		if entry.Message.Role == sdk.Assistant || entry.Message.Role == sdk.Tool {
+			return false
+		}
+	}
+	return true
+}
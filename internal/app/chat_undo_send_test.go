@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	services "github.com/inference-gateway/cli/internal/services"
+	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
+)
+
+func TestUndoSend(t *testing.T) {
+	app, inputView := newInputRoutingTestApp(t, domain.ViewStateChat, "fix the flaky test ")
+	inputView.SetCursor(len(inputView.GetInput()))
+	inputView.AddImageAttachment(domain.ImageAttachment{Data: "aGk=", MimeType: "image/png", SourcePath: "/tmp/no-such-paste.png"})
+	draft := inputView.GetInput()
+
+	repo := services.NewInMemoryConversationRepository(nil, nil)
+	_ = repo.AddMessage(domain.ConversationEntry{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("hello")}})
+	agentService := &domainmocks.FakeAgentService{}
+	app.config = &config.Config{Chat: config.ChatConfig{UndoSendSeconds: 10}}
+	app.conversationRepo = repo
+	app.agentService = agentService
+
+	send := func() {
+		t.Helper()
+		if cmd := app.SendMessage(); cmd == nil {
+			t.Fatal("expected the message to be sent")
+		}
+		_ = repo.AddMessage(domain.ConversationEntry{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(draft)}})
+		_ = app.stateManager.StartChatSession("req-1", "test-model", nil)
+	}
+
+	send()
+	app.handleUndoSend()
+
+	if agentService.CancelRequestCallCount() != 1 || agentService.CancelRequestArgsForCall(0) != "req-1" {
+		t.Error("undo should cancel the in-flight request")
+	}
+	if app.stateManager.GetChatSession() != nil {
+		t.Error("undo should end the chat session")
+	}
+	if repo.GetMessageCount() != 1 {
+		t.Errorf("the sent message should be removed from the conversation, %d entries left", repo.GetMessageCount())
+	}
+	if inputView.GetInput() != draft || len(inputView.GetImageAttachments()) != 1 {
+		t.Errorf("expected the draft and its image back in the input, got %q with %d images",
+			inputView.GetInput(), len(inputView.GetImageAttachments()))
+	}
+
+	app.handleUndoSend()
+	if agentService.CancelRequestCallCount() != 1 {
+		t.Error("a message can only be undone once")
+	}
+
+	send()
+	_ = repo.AddMessage(domain.ConversationEntry{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("done")}})
+	app.handleUndoSend()
+	if inputView.GetInput() != "" || repo.GetMessageCount() != 3 {
+		t.Error("a message the model already answered must not be undone")
+	}
+}
@@ -114,6 +114,9 @@ func TestChatApplication_QuestionFormRendersOnEvent(t *testing.T) {
 		c.GetDirectExecutionService(),
 		c.GetToolExecutionCoordinator(),
 		c.GetShellHistoryStorage(),
+		c.GetCheckpoints(),
+		c.GetBudgetService(),
+		c.GetTokenizer(),
 	)
 
 	c.GetStateManager().SetDimensions(120, 40)
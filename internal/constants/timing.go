@@ -17,6 +17,7 @@ const (
 	ParallelToolsTickInterval = 500 * time.Millisecond // Parallel tools UI refresh interval
 	TimerUpdateThrottle       = 100 * time.Millisecond // Minimum time between timer updates (e.g., bash command duration)
 	RenderThrottleInterval    = 33 * time.Millisecond  // Throttle interval for streaming content rendering (~30 FPS)
+	ToolProgressThrottle      = 100 * time.Millisecond // Minimum time between progress reports forwarded from a running tool
 
 	// Test timing delays
 	TestSleepDelay = 100 * time.Millisecond // Standard delay in tests for timing-sensitive operations
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -27,12 +30,14 @@ import (
 	a2acoord "github.com/inference-gateway/cli/internal/services/a2acoord"
 	approvalcoord "github.com/inference-gateway/cli/internal/services/approvalcoord"
 	chatcompletion "github.com/inference-gateway/cli/internal/services/chatcompletion"
+	checkpoint "github.com/inference-gateway/cli/internal/services/checkpoint"
 	directexec "github.com/inference-gateway/cli/internal/services/directexec"
 	eventlistener "github.com/inference-gateway/cli/internal/services/eventlistener"
 	githubissues "github.com/inference-gateway/cli/internal/services/githubissues"
 	githubsetup "github.com/inference-gateway/cli/internal/services/githubsetup"
 	jobs "github.com/inference-gateway/cli/internal/services/jobs"
 	skills "github.com/inference-gateway/cli/internal/services/skills"
+	tokenizers "github.com/inference-gateway/cli/internal/services/tokenizers"
 	toolcoordinator "github.com/inference-gateway/cli/internal/services/toolcoordinator"
 	shortcuts "github.com/inference-gateway/cli/internal/shortcuts"
 	stt "github.com/inference-gateway/cli/internal/stt"
@@ -89,8 +94,11 @@ type ServiceContainer struct {
 	backgroundJobManager   *services.BackgroundJobManager
 	backgroundShellService *services.BackgroundShellService
 	memoryBackend          domain.MemoryBackend
+	memoryDistiller        domain.MemoryDistiller
 	storage                storage.ConversationStorage
 	stores                 *storage.Stores
+	checkpoints            *checkpoint.Store
+	budgetService          *services.BudgetService
 
 	// Token polyfill - used by /context, conversation optimizer, and the
 	// session rollover manager. Created unconditionally so any surface can
@@ -321,6 +329,19 @@ func (c *ServiceContainer) initializeDomainServices() {
 	c.ensureBackgroundTaskRegistry()
 	c.memoryBackend = memory.NewMemoryBackend(c.config)
 
+	c.telemetryRecorder = telemetry.New(telemetry.Options{
+		Enabled:           c.config.Telemetry.Enabled,
+		Dir:               config.TelemetryDir(),
+		SessionID:         string(c.sessionID),
+		OTLPEndpoint:      c.config.Telemetry.OTLP.Endpoint,
+		OTLPHeaders:       c.config.Telemetry.OTLP.Headers,
+		OTLPInterval:      time.Duration(c.config.Telemetry.OTLP.Interval) * time.Second,
+		ReceiverAddress:   c.config.Telemetry.ReceiverAddress,
+		Cost:              c.GetPricingService().CalculateCost,
+		AttrSessionIDKey:  c.config.Telemetry.AttrSessionIDKey,
+		AttrToolCallIDKey: c.config.Telemetry.AttrToolCallIDKey,
+	})
+
 	storageConfig := storage.NewStorageFromConfig(c.config)
 	stores, err := storage.NewStorage(storageConfig)
 	c.stores = stores
@@ -341,19 +362,6 @@ func (c *ServiceContainer) initializeDomainServices() {
 	modelClient := c.createRawSDKClient()
 	c.modelService = services.NewHTTPModelService(modelClient)
 
-	c.telemetryRecorder = telemetry.New(telemetry.Options{
-		Enabled:           c.config.Telemetry.Enabled,
-		Dir:               config.TelemetryDir(),
-		SessionID:         string(c.sessionID),
-		OTLPEndpoint:      c.config.Telemetry.OTLP.Endpoint,
-		OTLPHeaders:       c.config.Telemetry.OTLP.Headers,
-		OTLPInterval:      time.Duration(c.config.Telemetry.OTLP.Interval) * time.Second,
-		ReceiverAddress:   c.config.Telemetry.ReceiverAddress,
-		Cost:              c.GetPricingService().CalculateCost,
-		AttrSessionIDKey:  c.config.Telemetry.AttrSessionIDKey,
-		AttrToolCallIDKey: c.config.Telemetry.AttrToolCallIDKey,
-	})
-
 	if c.config.Tools.Enabled || c.config.IsA2AToolsEnabled() {
 		c.toolService = services.NewLLMToolServiceWithRegistry(c.config, c.toolRegistry)
 	} else {
@@ -364,15 +372,24 @@ func (c *ServiceContainer) initializeDomainServices() {
 	}
 
 	if c.tokenizer == nil {
-		c.tokenizer = services.NewTokenizerService(services.DefaultTokenizerConfig())
+		tokenizerConfig := services.DefaultTokenizerConfig()
+		if c.config.Tokenizer.Enabled {
+			tokenizerConfig.Counters = c.createTokenCounters()
+			tokenizerConfig.Counters.Preload(c.config.Agent.Model)
+			tokenizerConfig.Model = c.modelService.GetCurrentModel
+		}
+		c.tokenizer = services.NewTokenizerService(tokenizerConfig)
 	}
 
 	summaryClient := c.createRawSDKClient()
+	memoryTool, _ := c.toolRegistry.GetTool(tools.ToolNameMemory)
+	c.memoryDistiller = services.NewMemoryDistiller(c.config, summaryClient, memoryTool)
 	c.conversationOptimizer = services.NewConversationOptimizer(services.OptimizerConfig{
 		Enabled:           c.config.Compact.Enabled,
 		AutoAt:            c.config.Compact.AutoAt,
 		BufferSize:        2,
 		KeepFirstMessages: c.config.Compact.KeepFirstMessages,
+		KeepLastMessages:  c.config.Compact.KeepLastMessages,
 		Client:            summaryClient,
 		Config:            c.config,
 		Tokenizer:         c.tokenizer,
@@ -388,6 +405,11 @@ func (c *ServiceContainer) initializeDomainServices() {
 				c.tokenizer,
 				groupStore,
 			)
+			c.sessionRolloverManager.SetPreCompactHook(func(ctx context.Context, payload domain.HookPayload) {
+				if c.agent != nil {
+					c.agent.RunLifecycleHooks(ctx, payload)
+				}
+			})
 		}
 	}
 
@@ -401,7 +423,7 @@ func (c *ServiceContainer) initializeDomainServices() {
 
 	c.githubIssueService = githubissues.New()
 
-	agentClient := c.createRawSDKClient()
+	agentClient := c.createAgentSDKClient()
 	agentImpl := agent.NewAgent(
 		agentClient,
 		c.toolService,
@@ -417,6 +439,7 @@ func (c *ServiceContainer) initializeDomainServices() {
 	)
 	agentImpl.SetMemoryBackend(c.memoryBackend)
 	agentImpl.SetTelemetryRecorder(c.telemetryRecorder)
+	agentImpl.SetBudgetTracker(c.GetBudgetService())
 	c.agent = agentImpl
 }
 
@@ -437,12 +460,16 @@ func (c *ServiceContainer) initializeStorageBackend(
 	}
 
 	c.storage = stores.Conversations
-	persistentRepo := services.NewPersistentConversationRepository(toolFormatterService, c.PricingService(), stores.Conversations)
+	conversations := stores.Conversations
+	if c.telemetryRecorder != nil {
+		conversations = telemetry.NewConversationStorage(conversations, c.telemetryRecorder, string(storageConfig.Type))
+	}
+	persistentRepo := services.NewPersistentConversationRepository(toolFormatterService, c.PricingService(), conversations)
 	c.conversationRepo = persistentRepo
 	logger.Info("initialized conversation storage", "type", storageConfig.Type)
 
 	titleClient := c.createRawSDKClient()
-	c.titleGenerator = services.NewConversationTitleGenerator(titleClient, stores.Conversations, c.config)
+	c.titleGenerator = services.NewConversationTitleGenerator(titleClient, conversations, c.config)
 	c.backgroundJobManager = services.NewBackgroundJobManager(c.titleGenerator, c.config)
 
 	persistentRepo.SetTitleGenerator(c.titleGenerator)
@@ -523,10 +550,15 @@ func (c *ServiceContainer) initializeChatOrchestrationServices() {
 		Listener:             c.chatEventListener,
 	})
 
+	var plans storage.PlanStorage
+	if c.stores != nil {
+		plans = c.stores.Plans
+	}
 	c.approvalCoordinator = approvalcoord.NewService(approvalcoord.Options{
 		AgentService:     c.agent,
 		ConversationRepo: c.conversationRepo,
 		StateManager:     c.stateManager,
+		Plans:            plans,
 	})
 
 	c.chatCompletionRunner = chatcompletion.NewRunner(chatcompletion.Options{
@@ -535,6 +567,7 @@ func (c *ServiceContainer) initializeChatOrchestrationServices() {
 		ModelService:     c.modelService,
 		StateManager:     c.stateManager,
 		Listener:         c.chatEventListener,
+		ModelFallbacks:   c.config.Agent.ModelFallbacks,
 	})
 
 	c.directExecutionService = directexec.NewService(directexec.Options{
@@ -556,6 +589,9 @@ func (c *ServiceContainer) initializeChatOrchestrationServices() {
 // initializeUIComponents creates UI components and theme
 func (c *ServiceContainer) initializeUIComponents() {
 	themeProvider := domain.NewThemeProvider()
+	for _, err := range themeProvider.LoadCustomThemes(config.ThemeDirs()...) {
+		logger.Warn("skipping custom theme", "error", err)
+	}
 
 	if configuredTheme := c.config.GetTheme(); configuredTheme != "" {
 		if err := themeProvider.SetTheme(configuredTheme); err != nil {
@@ -569,18 +605,24 @@ func (c *ServiceContainer) initializeUIComponents() {
 // initializeExtensibility sets up extensible systems
 func (c *ServiceContainer) initializeExtensibility() {
 	c.shortcutRegistry = shortcuts.NewRegistry()
+	if c.config.Chat.Checkpoints {
+		c.checkpoints = checkpoint.New("")
+	}
 	c.registerDefaultCommands()
 }
 
 // registerDefaultCommands registers the built-in commands
 func (c *ServiceContainer) registerDefaultCommands() {
+	compactionPlanner, _ := c.conversationOptimizer.(domain.CompactionPlanner)
 	c.shortcutRegistry.Register(shortcuts.NewClearShortcut(c.conversationRepo, c.backgroundTaskRegistry))
-	c.shortcutRegistry.Register(shortcuts.NewCompactShortcut(c.conversationRepo))
+	c.shortcutRegistry.Register(shortcuts.NewCompactShortcut(c.conversationRepo, compactionPlanner, c.modelService))
 	c.shortcutRegistry.Register(shortcuts.NewCopyShortcut(c.conversationRepo, clipboardtext.NewWriter()))
 	c.shortcutRegistry.Register(shortcuts.NewContextShortcut(c.conversationRepo, c.modelService, c.tokenizer))
 	c.shortcutRegistry.Register(shortcuts.NewCostShortcut(c.conversationRepo))
 	c.shortcutRegistry.Register(shortcuts.NewExitShortcut())
-	c.shortcutRegistry.Register(shortcuts.NewSwitchShortcut(c.modelService))
+	c.shortcutRegistry.Register(shortcuts.NewSwitchShortcut(c.modelService, c.config))
+	c.shortcutRegistry.Register(shortcuts.NewPersonaShortcut(c.config, c.modelService))
+	c.shortcutRegistry.Register(shortcuts.NewRewindShortcut(c.conversationRepo, c.checkpoints))
 	c.shortcutRegistry.Register(shortcuts.NewThemeShortcut(c.themeService))
 	c.shortcutRegistry.Register(shortcuts.NewToolsShortcut())
 	c.shortcutRegistry.Register(shortcuts.NewHelpShortcut(c.shortcutRegistry))
@@ -593,10 +635,17 @@ func (c *ServiceContainer) registerDefaultCommands() {
 	if persistentRepo, ok := c.conversationRepo.(*services.PersistentConversationRepository); ok {
 		c.shortcutRegistry.Register(shortcuts.NewConversationSelectShortcut(persistentRepo))
 		c.shortcutRegistry.Register(shortcuts.NewNewShortcut(persistentRepo, c.backgroundTaskRegistry))
+		c.shortcutRegistry.Register(shortcuts.NewSearchShortcut(persistentRepo))
+		if c.stores != nil {
+			c.shortcutRegistry.Register(shortcuts.NewSessionsShortcut(c.stores.SessionGroups, c.stores.Conversations))
+		}
 	}
 
 	c.shortcutRegistry.Register(shortcuts.NewInitGithubActionShortcut())
 	c.shortcutRegistry.Register(shortcuts.NewInitShortcut(c.config))
+	c.shortcutRegistry.Register(shortcuts.NewPromptShortcut(c.config))
+	c.shortcutRegistry.Register(shortcuts.NewTemplateShortcut(c.config))
+	c.shortcutRegistry.Register(shortcuts.NewLogsShortcut(c.config))
 
 	if c.config.IsA2AToolsEnabled() {
 		c.shortcutRegistry.Register(shortcuts.NewA2ATaskManagementShortcut(c.config))
@@ -612,10 +661,21 @@ func (c *ServiceContainer) registerDefaultCommands() {
 	}
 
 	configDir := c.config.GetConfigDir()
+	c.shortcutRegistry.Register(shortcuts.NewMacroShortcut(c.shortcutRegistry, configDir))
+
 	customShortcutClient := c.createRawSDKClient()
 	if err := c.shortcutRegistry.LoadCustomShortcuts(configDir, customShortcutClient, c.modelService, c.imageService, c.toolService); err != nil {
 		logger.Error("failed to load custom shortcuts", "error", err, "config_dir", configDir)
 	}
+
+	// Userspace commands first so the project's .infer/commands win on name clashes
+	commandDirs := []string{configDir, config.ConfigDirName}
+	if home, err := os.UserHomeDir(); err == nil {
+		commandDirs = append([]string{filepath.Join(home, config.ConfigDirName)}, commandDirs...)
+	}
+	if err := c.shortcutRegistry.LoadMarkdownCommands(commandDirs...); err != nil {
+		logger.Error("failed to load markdown commands", "error", err, "config_dir", configDir)
+	}
 }
 
 // Logger returns the logger instance for this container
@@ -635,6 +695,12 @@ func (c *ServiceContainer) GetSessionRolloverManager() *services.SessionRollover
 	return c.sessionRolloverManager
 }
 
+// GetTokenizer returns the token estimator shared by the context indicator
+// and auto-compaction
+func (c *ServiceContainer) GetTokenizer() *services.TokenizerService {
+	return c.tokenizer
+}
+
 func (c *ServiceContainer) GetModelService() domain.ModelService {
 	return c.modelService
 }
@@ -660,6 +726,12 @@ func (c *ServiceContainer) GetMemoryBackend() domain.MemoryBackend {
 	return c.memoryBackend
 }
 
+// GetMemoryDistiller returns the end-of-session memory distiller, a no-op when
+// memory or memory.distill is disabled.
+func (c *ServiceContainer) GetMemoryDistiller() domain.MemoryDistiller {
+	return c.memoryDistiller
+}
+
 func (c *ServiceContainer) GetFileService() domain.FileService {
 	return c.fileService
 }
@@ -804,10 +876,47 @@ func (c *ServiceContainer) createRetryConfig() *sdk.RetryConfig {
 // createSDKClient creates a configured SDK client with retry and timeout settings
 // createRawSDKClient creates the raw SDK client for services that need it
 func (c *ServiceContainer) createRawSDKClient() sdk.Client {
+	return c.newSDKClient(c.createRetryConfig())
+}
+
+// createAgentSDKClient creates the agent's SDK client, which leaves 429s to
+// the agent: the SDK would retry them on a fixed backoff and then report a
+// bare "HTTP 429", dropping the provider's error and its retry hint
+func (c *ServiceContainer) createAgentSDKClient() sdk.Client {
+	retryConfig := c.createRetryConfig()
+	codes := retryConfig.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = []int{408, 429, 500, 502, 503, 504}
+	}
+	retryConfig.RetryableStatusCodes = slices.DeleteFunc(slices.Clone(codes), func(code int) bool {
+		return code == http.StatusTooManyRequests
+	})
+	return c.newSDKClient(retryConfig)
+}
+
+func (c *ServiceContainer) newSDKClient(retryConfig *sdk.RetryConfig) sdk.Client {
 	if c.config == nil {
 		panic("ServiceContainer: config is nil when creating SDK client")
 	}
 
+	baseURL := c.gatewayAPIURL()
+
+	timeout := c.config.Client.Timeout
+	if timeout == 0 {
+		timeout = 200
+	}
+
+	return sdk.NewClient(&sdk.ClientOptions{
+		BaseURL:     baseURL,
+		APIKey:      c.config.Gateway.APIKey,
+		Timeout:     time.Duration(timeout) * time.Second,
+		RetryConfig: retryConfig,
+	})
+}
+
+// gatewayAPIURL returns the gateway's /v1 API URL, preferring the address of
+// a gateway this process runs
+func (c *ServiceContainer) gatewayAPIURL() string {
 	baseURL := c.config.Gateway.URL
 	if c.gatewayManager != nil && c.config.Gateway.Run {
 		actualURL := c.gatewayManager.GetGatewayURL()
@@ -822,18 +931,38 @@ func (c *ServiceContainer) createRawSDKClient() sdk.Client {
 	if !strings.HasSuffix(baseURL, "/v1") {
 		baseURL = strings.TrimSuffix(baseURL, "/") + "/v1"
 	}
+	return baseURL
+}
+
+// createTokenCounters creates the real tokenizers behind the token
+// estimates. Claude models are counted with Anthropic's counting endpoint,
+// directly with tokenizer.anthropic_api_key or else through the gateway's
+// provider proxy.
+func (c *ServiceContainer) createTokenCounters() *tokenizers.Registry {
+	opts := tokenizers.Options{
+		Models:            c.config.Tokenizer.Models,
+		DownloadEncodings: c.config.Tokenizer.DownloadEncodings,
+	}
+	if dir, err := c.config.ResolveTokenizerCacheDir(); err == nil {
+		opts.CacheDir = dir
+	}
 
-	timeout := c.config.Client.Timeout
-	if timeout == 0 {
-		timeout = 200
+	if key := c.config.Tokenizer.AnthropicAPIKey; key != "" {
+		opts.AnthropicURL = "https://api.anthropic.com/v1/messages/count_tokens"
+		opts.AnthropicHeaders = map[string]string{"x-api-key": key}
+	} else {
+		opts.AnthropicURL = strings.TrimSuffix(c.gatewayAPIURL(), "/v1") + "/proxy/anthropic/messages/count_tokens"
+		if c.config.Gateway.APIKey != "" {
+			opts.AnthropicHeaders = map[string]string{"Authorization": "Bearer " + c.config.Gateway.APIKey}
+		}
 	}
+	return tokenizers.NewRegistry(opts)
+}
 
-	return sdk.NewClient(&sdk.ClientOptions{
-		BaseURL:     baseURL,
-		APIKey:      c.config.Gateway.APIKey,
-		Timeout:     time.Duration(timeout) * time.Second,
-		RetryConfig: c.createRetryConfig(),
-	})
+// NewSDKClient returns a new SDK client for the gateway, for commands that
+// call models directly
+func (c *ServiceContainer) NewSDKClient() sdk.Client {
+	return c.createRawSDKClient()
 }
 
 // GetBackgroundJobManager returns the background job manager
@@ -855,6 +984,25 @@ func (c *ServiceContainer) GetShellHistoryStorage() storage.ShellHistoryStorage
 	return c.stores.ShellHistory
 }
 
+// GetBudgetService returns the tracker of spending against the pricing
+// budgets, which keeps the daily total in the storage backend when it is up
+func (c *ServiceContainer) GetBudgetService() *services.BudgetService {
+	if c.budgetService == nil {
+		var spend storage.SpendStorage
+		if c.stores != nil {
+			spend = c.stores.Spend
+		}
+		c.budgetService = services.NewBudgetService(&c.config.Pricing, c.conversationRepo, spend)
+	}
+	return c.budgetService
+}
+
+// GetCheckpoints returns the per-turn workspace checkpoint store, or nil when
+// chat.checkpoints is off or the workspace is not a git work tree
+func (c *ServiceContainer) GetCheckpoints() *checkpoint.Store {
+	return c.checkpoints
+}
+
 // GetGatewayManager returns the gateway manager
 func (c *ServiceContainer) GetGatewayManager() domain.GatewayManager {
 	return c.gatewayManager
@@ -82,6 +82,12 @@ type AgentRequest struct {
 	Model      string        `json:"model"`
 	Messages   []sdk.Message `json:"messages"`
 	IsChatMode bool          `json:"is_chat_mode"`
+	// Tools limits the tools offered to the model for this request to the
+	// named ones; empty offers every tool the agent mode allows
+	Tools []string `json:"tools,omitempty"`
+	// ResponseFormat, when set, asks the model for output in this format,
+	// e.g. a json_schema for `infer agent --schema`
+	ResponseFormat *sdk.CreateChatCompletionRequest_ResponseFormat `json:"-"`
 }
 
 // AgentService handles agent operations with both sync and streaming modes
@@ -102,6 +108,11 @@ type AgentService interface {
 	// byte-stable across turns; volatile context travels separately as a hidden
 	// per-request message (see `infer debug agent system_prompt`).
 	BuildSystemPrompt() string
+
+	// RunLifecycleHooks runs the command hooks attached to a lifecycle point
+	// (session_start, session_end, pre_compact, on_error) with the given
+	// session metadata
+	RunLifecycleHooks(ctx context.Context, payload HookPayload)
 }
 
 // CachedAgentCard represents a cached agent card with metadata
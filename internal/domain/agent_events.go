@@ -69,6 +69,16 @@ type StateHandler interface {
 	Name() AgentExecutionState
 }
 
+// ToolBatch runs the tool calls of one turn concurrently, ordering calls that
+// touch the same files. Calls must be submitted in tool-call order.
+type ToolBatch interface {
+	// Go runs run in the background once tc may start
+	Go(tc sdk.ChatCompletionMessageToolCall, run func())
+
+	// Wait blocks until every submitted call has finished
+	Wait()
+}
+
 // StateContext provides access to agent dependencies for state handlers
 type StateContext struct {
 	// Core dependencies
@@ -100,9 +110,9 @@ type StateContext struct {
 	Provider               string
 	Model                  string
 
-	// MaxConcurrentTools bounds how many approved tools may execute concurrently
+	// NewToolBatch creates the scheduler that runs approved tools concurrently
 	// while later tools are still being approved.
-	MaxConcurrentTools int
+	NewToolBatch func() ToolBatch
 
 	// Function callbacks
 	ToolExecutor   *func()
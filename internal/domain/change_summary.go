@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeSummary is what one agent turn changed: the files its tools wrote,
+// edited or deleted, with line counts, and the commands it ran. It rides on a
+// conversation entry of its own that is shown in the conversation but never
+// sent to the model.
+type ChangeSummary struct {
+	Files    []FileChange `json:"files"`
+	Commands []string     `json:"commands,omitempty"`
+}
+
+// FileChange is one file touched in a turn. Added and Removed count lines;
+// a file written over whole counts its new lines as added.
+type FileChange struct {
+	Path    string `json:"path"`
+	Added   int    `json:"added,omitempty"`
+	Removed int    `json:"removed,omitempty"`
+	Created bool   `json:"created,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// Totals returns the lines added and removed across all files
+func (s ChangeSummary) Totals() (added, removed int) {
+	for _, f := range s.Files {
+		added += f.Added
+		removed += f.Removed
+	}
+	return added, removed
+}
+
+// Headline describes the summary in one line, e.g.
+// "3 files changed (+40 -12), 2 commands run"
+func (s ChangeSummary) Headline() string {
+	added, removed := s.Totals()
+	headline := fmt.Sprintf("%d %s changed (+%d -%d)", len(s.Files), plural(len(s.Files), "file", "files"), added, removed)
+	if n := len(s.Commands); n > 0 {
+		headline += fmt.Sprintf(", %d %s run", n, plural(n, "command", "commands"))
+	}
+	return headline
+}
+
+// String is the plain text form stored as the entry's content, which exports
+// and search see
+func (s ChangeSummary) String() string {
+	var b strings.Builder
+	b.WriteString("Changes: " + s.Headline())
+	for _, f := range s.Files {
+		fmt.Fprintf(&b, "\n%s %s %s", f.Marker(), f.Path, f.LineCounts())
+	}
+	for _, command := range s.Commands {
+		b.WriteString("\n$ " + command)
+	}
+	return b.String()
+}
+
+// Marker is the one-letter status of the change, as in git status: A for a
+// created file, D for a deleted one and M otherwise
+func (f FileChange) Marker() string {
+	switch {
+	case f.Deleted:
+		return "D"
+	case f.Created:
+		return "A"
+	default:
+		return "M"
+	}
+}
+
+// LineCounts returns "+added -removed", leaving out zero counts
+func (f FileChange) LineCounts() string {
+	var parts []string
+	if f.Added > 0 {
+		parts = append(parts, fmt.Sprintf("+%d", f.Added))
+	}
+	if f.Removed > 0 {
+		parts = append(parts, fmt.Sprintf("-%d", f.Removed))
+	}
+	return strings.Join(parts, " ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
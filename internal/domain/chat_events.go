@@ -24,6 +24,8 @@ type ToolExecutionProgressEvent struct {
 	Status     string
 	Message    string
 	Images     []ImageAttachment
+	// Progress is set when the tool itself reported how far along it is.
+	Progress *ToolProgress
 }
 
 // BashOutputChunkEvent indicates a new chunk of bash output is available
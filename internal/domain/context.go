@@ -20,6 +20,24 @@ const BashOutputCallbackKey ContextKey = "bash_output_callback"
 // separately by the tool and is unaffected.
 type BashOutputCallback func(output string)
 
+// ToolProgressCallbackKey is the context key for the live progress callback.
+// Long-running tools report how far along they are through it, and the chat
+// shows the latest report as a progress line under the running tool
+const ToolProgressCallbackKey ContextKey = "tool_progress_callback"
+
+// ToolProgress is a single progress report from a running tool. Percent runs
+// from 0 to 100, or is negative when the total is unknown and only Step (e.g.
+// "1.2 MB received" or "12 tests run") describes how far the tool has got.
+type ToolProgress struct {
+	Percent float64
+	Step    string
+}
+
+// ToolProgressCallback receives progress reports from a running tool. Reports
+// may be dropped or coalesced on the way to the UI, so tools can report as
+// often as is convenient.
+type ToolProgressCallback func(progress ToolProgress)
+
 // BashDetachChannelKey is the context key for the bash detach signal channel
 // When this key is set in the context, the bash tool can signal when a command
 // should be detached to the background (e.g., via keyboard shortcut)
@@ -87,6 +87,30 @@ func HasBashOutputCallback(ctx context.Context) bool {
 	return GetBashOutputCallback(ctx) != nil
 }
 
+// ========================================
+// Tool Progress Callback
+// ========================================
+
+// WithToolProgressCallback returns a new context with a tool progress callback
+func WithToolProgressCallback(ctx context.Context, callback ToolProgressCallback) context.Context {
+	return context.WithValue(ctx, ToolProgressCallbackKey, callback)
+}
+
+// GetToolProgressCallback retrieves the tool progress callback from context
+// Returns nil if the key is not set or if the value is not a ToolProgressCallback
+func GetToolProgressCallback(ctx context.Context) ToolProgressCallback {
+	callback, _ := ctx.Value(ToolProgressCallbackKey).(ToolProgressCallback)
+	return callback
+}
+
+// ReportToolProgress sends a progress report to the callback in ctx, if any.
+// Tools call it unconditionally; without a callback it does nothing.
+func ReportToolProgress(ctx context.Context, percent float64, step string) {
+	if callback := GetToolProgressCallback(ctx); callback != nil {
+		callback(ToolProgress{Percent: percent, Step: step})
+	}
+}
+
 // ========================================
 // Bash Detach Channel
 // ========================================
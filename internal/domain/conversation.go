@@ -3,6 +3,8 @@ package domain
 import (
 	"strings"
 	"time"
+
+	sdk "github.com/inference-gateway/sdk"
 )
 
 // CreateTitleFromMessage creates a short title from message content (fallback title)
@@ -44,6 +46,8 @@ type ConversationMetadata struct {
 	TitleInvalidated    bool              `json:"title_invalidated,omitempty"`
 	TitleGenerationTime *time.Time        `json:"title_generation_time,omitempty"`
 	ContextID           string            `json:"context_id,omitempty"`
+	ParentID            string            `json:"parent_id,omitempty"`
+	Archived            bool              `json:"archived,omitempty"`
 }
 
 // ConversationSummary contains summary information about a conversation
@@ -61,4 +65,48 @@ type ConversationSummary struct {
 	TitleGenerated      bool              `json:"title_generated,omitempty"`
 	TitleInvalidated    bool              `json:"title_invalidated,omitempty"`
 	TitleGenerationTime *time.Time        `json:"title_generation_time,omitempty"`
+	ParentID            string            `json:"parent_id,omitempty"`
+	Archived            bool              `json:"archived,omitempty"`
+}
+
+// ConversationSearchHit is a stored conversation whose messages match a
+// history search, with snippets of the first matching messages
+type ConversationSearchHit struct {
+	ConversationID string                      `json:"conversation_id"`
+	Title          string                      `json:"title"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
+	Matches        int                         `json:"matches"`
+	Snippets       []ConversationSearchSnippet `json:"snippets"`
+}
+
+// ConversationSearchSnippet is the text around a match in one message
+type ConversationSearchSnippet struct {
+	Role    string    `json:"role"`
+	Time    time.Time `json:"time"`
+	Snippet string    `json:"snippet"`
+}
+
+// PinKey identifies a message by role and text. Compaction rebuilds the
+// conversation from bare messages, so pins are matched back up by this key.
+// Messages without plain text content have no key and cannot be pinned.
+func PinKey(msg sdk.Message) string {
+	content, err := msg.Content.AsMessageContent0()
+	if err != nil || strings.TrimSpace(content) == "" {
+		return ""
+	}
+	return string(msg.Role) + "\x00" + content
+}
+
+// PinnedKeys returns the PinKey of every pinned entry.
+func PinnedKeys(entries []ConversationEntry) map[string]bool {
+	keys := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.Pinned {
+			continue
+		}
+		if key := PinKey(entry.Message); key != "" {
+			keys[key] = true
+		}
+	}
+	return keys
 }
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+)
+
+func TestPinnedKeys(t *testing.T) {
+	entries := []ConversationEntry{
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("keep me")}, Pinned: true},
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("not pinned")}},
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("  ")}, Pinned: true},
+	}
+
+	keys := PinnedKeys(entries)
+	if len(keys) != 1 {
+		t.Fatalf("got %d keys, want 1: %v", len(keys), keys)
+	}
+	if !keys[PinKey(sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("keep me")})] {
+		t.Error("a re-created message with the same role and text should match its pin")
+	}
+	if keys[PinKey(sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("keep me")})] {
+		t.Error("the role is part of the key")
+	}
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// ThemeRoles lists the semantic color roles a theme file may set, in the
+// order they are documented and previewed.
+var ThemeRoles = []string{
+	"user", "assistant", "error", "success", "status",
+	"accent", "dim", "border", "diff_add", "diff_remove",
+}
+
+// hexColorPattern matches #rgb and #rrggbb colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// themeFile is the on-disk format of a user-defined theme
+type themeFile struct {
+	Name   string            `yaml:"name"`
+	Base   string            `yaml:"base"`
+	Colors map[string]string `yaml:"colors"`
+}
+
+// CustomTheme is a theme loaded from a YAML file. Roles the file does not set
+// fall back to its base theme.
+type CustomTheme struct {
+	base   Theme
+	colors map[string]string
+}
+
+func (t *CustomTheme) color(role string, fallback func() string) string {
+	if c, ok := t.colors[role]; ok {
+		return c
+	}
+	return fallback()
+}
+
+func (t *CustomTheme) GetUserColor() string { return t.color("user", t.base.GetUserColor) }
+func (t *CustomTheme) GetAssistantColor() string {
+	return t.color("assistant", t.base.GetAssistantColor)
+}
+func (t *CustomTheme) GetErrorColor() string   { return t.color("error", t.base.GetErrorColor) }
+func (t *CustomTheme) GetSuccessColor() string { return t.color("success", t.base.GetSuccessColor) }
+func (t *CustomTheme) GetStatusColor() string  { return t.color("status", t.base.GetStatusColor) }
+func (t *CustomTheme) GetAccentColor() string  { return t.color("accent", t.base.GetAccentColor) }
+func (t *CustomTheme) GetDimColor() string     { return t.color("dim", t.base.GetDimColor) }
+func (t *CustomTheme) GetBorderColor() string  { return t.color("border", t.base.GetBorderColor) }
+func (t *CustomTheme) GetDiffAddColor() string { return t.color("diff_add", t.base.GetDiffAddColor) }
+func (t *CustomTheme) GetDiffRemoveColor() string {
+	return t.color("diff_remove", t.base.GetDiffRemoveColor)
+}
+
+// LoadCustomThemes registers the *.yaml and *.yml theme files found in dirs.
+// Later directories win over earlier ones, so pass the userspace directory
+// before the project one. Missing directories are skipped; each file that
+// fails to parse or validate is skipped and reported in the returned errors.
+func (tp *ThemeProvider) LoadCustomThemes(dirs ...string) []error {
+	var errs []error
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("failed to read themes directory %s: %w", dir, err))
+			}
+			continue
+		}
+
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			name, theme, err := tp.parseThemeFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid theme %s: %w", path, err))
+				continue
+			}
+			tp.themes[name] = theme
+			tp.custom[name] = true
+		}
+	}
+	return errs
+}
+
+// parseThemeFile reads and validates a theme file. The theme is named after
+// the file unless it sets a name of its own.
+func (tp *ThemeProvider) parseThemeFile(path string) (string, Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var file themeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return "", nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	name := strings.TrimSpace(file.Name)
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if _, exists := tp.themes[name]; exists && !tp.custom[name] {
+		return "", nil, fmt.Errorf("theme name '%s' is reserved by a built-in theme", name)
+	}
+
+	baseName := file.Base
+	if baseName == "" {
+		baseName = "tokyo-night"
+	}
+	base, exists := tp.themes[baseName]
+	if !exists || tp.custom[baseName] {
+		return "", nil, fmt.Errorf("base theme '%s' is not a built-in theme", baseName)
+	}
+
+	if len(file.Colors) == 0 {
+		return "", nil, fmt.Errorf("no colors defined")
+	}
+	colors := make(map[string]string, len(file.Colors))
+	for role, value := range file.Colors {
+		if !isThemeRole(role) {
+			return "", nil, fmt.Errorf("unknown color role '%s' (valid roles: %s)", role, strings.Join(ThemeRoles, ", "))
+		}
+		value = strings.TrimSpace(value)
+		if !isValidThemeColor(value) {
+			return "", nil, fmt.Errorf("invalid color '%s' for role '%s' (use #rgb, #rrggbb, or an ANSI code 0-255)", value, role)
+		}
+		colors[role] = value
+	}
+
+	return name, &CustomTheme{base: base, colors: colors}, nil
+}
+
+// IsCustomTheme reports whether the named theme was loaded from a theme file
+func (tp *ThemeProvider) IsCustomTheme(name string) bool {
+	return tp.custom[name]
+}
+
+func isThemeRole(role string) bool {
+	for _, r := range ThemeRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidThemeColor accepts hex colors and ANSI 256-color codes, the two
+// forms lipgloss.Color understands.
+func isValidThemeColor(value string) bool {
+	if hexColorPattern.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// sortThemeNames orders built-in themes first, then custom themes, each
+// alphabetically.
+func (tp *ThemeProvider) sortThemeNames(names []string) {
+	sort.Slice(names, func(i, j int) bool {
+		if tp.custom[names[i]] != tp.custom[names[j]] {
+			return !tp.custom[names[i]]
+		}
+		return names[i] < names[j]
+	})
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeThemeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadCustomThemes(t *testing.T) {
+	userDir, projectDir := t.TempDir(), t.TempDir()
+
+	writeThemeFile(t, userDir, "ocean.yaml", "colors:\n  user: \"#111111\"\n")
+	writeThemeFile(t, projectDir, "ocean.yml", "base: dracula\ncolors:\n  user: \"#222\"\n  accent: \"39\"\n")
+	writeThemeFile(t, projectDir, "named.yaml", "name: sunset\ncolors:\n  error: \"#ff0000\"\n")
+	writeThemeFile(t, projectDir, "notes.txt", "not a theme")
+	writeThemeFile(t, projectDir, "dracula.yaml", "colors:\n  user: \"#000000\"\n")
+	writeThemeFile(t, projectDir, "badrole.yaml", "colors:\n  background: \"#000000\"\n")
+	writeThemeFile(t, projectDir, "badcolor.yaml", "colors:\n  user: blue\n")
+	writeThemeFile(t, projectDir, "badbase.yaml", "base: ocean\ncolors:\n  user: \"#000000\"\n")
+
+	tp := NewThemeProvider()
+	errs := tp.LoadCustomThemes(userDir, projectDir, filepath.Join(userDir, "missing"))
+
+	if len(errs) != 4 {
+		t.Fatalf("got %d errors, want 4 (reserved name, bad role, bad color, custom base): %v", len(errs), errs)
+	}
+	for _, want := range []string{"reserved by a built-in", "unknown color role", "invalid color", "not a built-in theme"} {
+		found := false
+		for _, err := range errs {
+			found = found || strings.Contains(err.Error(), want)
+		}
+		if !found {
+			t.Errorf("expected an error containing %q in %v", want, errs)
+		}
+	}
+
+	ocean, err := tp.GetTheme("ocean")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ocean.GetUserColor(); got != "#222" {
+		t.Errorf("user color = %q, want the project theme to replace the userspace one", got)
+	}
+	if got := ocean.GetAccentColor(); got != "39" {
+		t.Errorf("accent color = %q, want ANSI code 39", got)
+	}
+	if got, want := ocean.GetErrorColor(), NewDraculaTheme().GetErrorColor(); got != want {
+		t.Errorf("error color = %q, want base theme fallback %q", got, want)
+	}
+
+	if _, err := tp.GetTheme("sunset"); err != nil {
+		t.Errorf("expected the theme to use its name field: %v", err)
+	}
+	if !tp.IsCustomTheme("ocean") || tp.IsCustomTheme("dracula") {
+		t.Error("IsCustomTheme should only report themes loaded from files")
+	}
+
+	themes := tp.ListThemes()
+	want := []string{"charm", "dracula", "github-light", "monochrome", "tokyo-night", "ocean", "sunset"}
+	if strings.Join(themes, ",") != strings.Join(want, ",") {
+		t.Errorf("ListThemes() = %v, want %v", themes, want)
+	}
+
+	if err := tp.SetTheme("sunset"); err != nil || tp.GetCurrentTheme().GetErrorColor() != "#ff0000" {
+		t.Errorf("expected custom theme to be selectable, err=%v", err)
+	}
+}
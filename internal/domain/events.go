@@ -249,12 +249,15 @@ func (e MessageQueuedEvent) GetRequestID() string    { return e.RequestID }
 func (e MessageQueuedEvent) GetTimestamp() time.Time { return e.Timestamp }
 
 // ToolApprovalRequestedEvent is used for standard tool approval workflow.
-// Computer-use tools use a separate pause/resume mechanism.
+// Computer-use tools use a separate pause/resume mechanism. HunkReviewChan,
+// when set, receives the per-hunk review of an Edit/MultiEdit call before the
+// approval itself is sent on ResponseChan.
 type ToolApprovalRequestedEvent struct {
-	RequestID    string
-	Timestamp    time.Time
-	ToolCall     sdk.ChatCompletionMessageToolCall
-	ResponseChan chan ApprovalAction `json:"-"`
+	RequestID      string
+	Timestamp      time.Time
+	ToolCall       sdk.ChatCompletionMessageToolCall
+	ResponseChan   chan ApprovalAction `json:"-"`
+	HunkReviewChan chan HunkReview     `json:"-"`
 }
 
 func (e ToolApprovalRequestedEvent) GetRequestID() string    { return e.RequestID }
@@ -24,8 +24,19 @@ const (
 	HookPostQueueDrain HookPoint = "post_queue_drain" // after draining queued user messages
 )
 
+// Lifecycle hook points fire outside the agent loop, once per event rather
+// than once per run, so they take command hooks only: a reminder attached to
+// one would have no conversation turn to land in.
+const (
+	HookSessionStart HookPoint = "session_start" // chat or `infer agent` session opened
+	HookSessionEnd   HookPoint = "session_end"   // session closed, cleanly or not
+	HookPreCompact   HookPoint = "pre_compact"   // before the conversation is summarized
+	HookOnError      HookPoint = "on_error"      // the run stopped on an unrecoverable error
+)
+
 // HookPoints is the canonical catalog, used for config validation. Order is
-// the loop order (a run flows top to bottom, looping the middle phases).
+// the loop order (a run flows top to bottom, looping the middle phases),
+// followed by the lifecycle points.
 var HookPoints = []HookPoint{
 	HookPreSession,
 	HookPreStream,
@@ -35,11 +46,26 @@ var HookPoints = []HookPoint{
 	HookPreQueueDrain,
 	HookPostQueueDrain,
 	HookPostSession,
+	HookSessionStart,
+	HookSessionEnd,
+	HookPreCompact,
+	HookOnError,
+}
+
+// LifecycleHookPoints are the command-only points of HookPoints.
+var LifecycleHookPoints = []HookPoint{
+	HookSessionStart,
+	HookSessionEnd,
+	HookPreCompact,
+	HookOnError,
 }
 
 // Valid reports whether h is one of the pre-defined hook points.
 func (h HookPoint) Valid() bool { return slices.Contains(HookPoints, h) }
 
+// Lifecycle reports whether h fires outside the agent loop (command hooks only).
+func (h HookPoint) Lifecycle() bool { return slices.Contains(LifecycleHookPoints, h) }
+
 // SystemReminder is a resolved reminder ready to inject into the conversation.
 type SystemReminder struct {
 	Name string
@@ -91,3 +117,20 @@ type HookCommand struct {
 	Command string
 	Timeout time.Duration
 }
+
+// HookPayload is the session metadata a command hook receives as JSON on
+// stdin. Hook, Turn and SessionID are always set; the rest are filled where
+// the firing point knows them: Reason says why a session ended or a compaction
+// started, Error carries the failure at on_error.
+type HookPayload struct {
+	Hook      HookPoint `json:"hook"`
+	Turn      int       `json:"turn"`
+	SessionID string    `json:"session_id"`
+	Model     string    `json:"model,omitempty"`
+	Mode      string    `json:"mode,omitempty"`
+	Cwd       string    `json:"cwd,omitempty"`
+	Messages  int       `json:"messages,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// HunkReview is the outcome of reviewing an Edit/MultiEdit approval hunk by
+// hunk. Arguments holds the rewritten tool arguments containing only the
+// accepted hunks; it is empty when every hunk was rejected, in which case the
+// call is treated as rejected. The remaining fields are reported back to the
+// model alongside the tool result.
+type HunkReview struct {
+	Total     int            `json:"total_hunks"`
+	Accepted  []int          `json:"accepted_hunks"`
+	Rejected  []RejectedHunk `json:"rejected_hunks"`
+	Arguments string         `json:"-"`
+}
+
+// RejectedHunk records a hunk the user declined, with the optional reason
+// they gave for it. Hunk is 1-based to match the approval box numbering.
+type RejectedHunk struct {
+	Hunk    int    `json:"hunk"`
+	Summary string `json:"summary"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Feedback renders the review as structured text for the model: a one-line
+// header followed by the review as JSON.
+func (r HunkReview) Feedback() string {
+	header := fmt.Sprintf("Partial approval: user accepted %d of %d hunks; rejected hunks were not applied.",
+		len(r.Accepted), r.Total)
+	if len(r.Accepted) == 0 {
+		header = fmt.Sprintf("User rejected all %d hunks; no changes were applied.", r.Total)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return header
+	}
+	return header + "\n" + string(data)
+}
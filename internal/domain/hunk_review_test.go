@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHunkReview_Feedback(t *testing.T) {
+	t.Run("partial approval lists rejected hunks with reasons", func(t *testing.T) {
+		review := HunkReview{
+			Total:     3,
+			Accepted:  []int{1, 3},
+			Rejected:  []RejectedHunk{{Hunk: 2, Summary: "line 10: -1 +2", Reason: "keep the old name"}},
+			Arguments: `{"file_path":"a.go"}`,
+		}
+
+		got := review.Feedback()
+		if !strings.HasPrefix(got, "Partial approval: user accepted 2 of 3 hunks") {
+			t.Errorf("unexpected header: %q", got)
+		}
+		if !strings.Contains(got, `"reason":"keep the old name"`) {
+			t.Errorf("expected the rejection reason in the feedback, got %q", got)
+		}
+		if strings.Contains(got, "file_path") {
+			t.Errorf("rewritten arguments must not leak into the feedback, got %q", got)
+		}
+	})
+
+	t.Run("all rejected", func(t *testing.T) {
+		review := HunkReview{Total: 2, Rejected: []RejectedHunk{{Hunk: 1}, {Hunk: 2}}}
+		if got := review.Feedback(); !strings.HasPrefix(got, "User rejected all 2 hunks") {
+			t.Errorf("unexpected header: %q", got)
+		}
+	})
+}
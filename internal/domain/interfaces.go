@@ -113,6 +113,7 @@ type ConversationEntry struct {
 	Model            string            `json:"model,omitempty"`
 	Time             time.Time         `json:"time"`
 	Hidden           bool              `json:"hidden,omitempty"`
+	Pinned           bool              `json:"pinned,omitempty"`
 	Images           []ImageAttachment `json:"images,omitempty"`
 	ReasoningContent string            `json:"reasoning_content,omitempty"`
 
@@ -125,6 +126,10 @@ type ConversationEntry struct {
 	Rejected           bool               `json:"rejected,omitempty"`
 	IsPlan             bool               `json:"is_plan,omitempty"`
 	PlanApprovalStatus PlanApprovalStatus `json:"plan_approval_status,omitempty"`
+
+	// ChangeSummary marks a summary of the files a turn changed, shown in
+	// the conversation and never sent to the model
+	ChangeSummary *ChangeSummary `json:"change_summary,omitempty"`
 }
 
 // PlanApprovalStatus represents the approval status of a plan
@@ -209,6 +214,12 @@ type ConversationExporter interface {
 	Export(format ExportFormat) ([]byte, error)
 }
 
+// MessagePinner marks conversation entries as pinned, so compaction keeps them
+// verbatim instead of summarizing them away
+type MessagePinner interface {
+	SetMessagePinned(index int, pinned bool) error
+}
+
 // ConversationRepository is the composed interface for all conversation storage
 // and retrieval operations. New code should depend on the narrower sub-interfaces above.
 type ConversationRepository interface {
@@ -225,6 +236,37 @@ type ConversationOptimizer interface {
 	OptimizeMessages(messages []sdk.Message, model string, force bool) []sdk.Message
 }
 
+// CompactionPlanner is implemented by optimizers that can show what a forced
+// compaction would do without running it (`/compact --dry-run`)
+type CompactionPlanner interface {
+	PreviewCompaction(messages []sdk.Message, model string) CompactionPreview
+}
+
+// CompactionPreview describes the outcome of a forced compaction: how many
+// messages stay verbatim, which ones are summarized, dropped or lose their tool
+// output, and the estimated tokens of what stays (before the summary is added).
+type CompactionPreview struct {
+	Strategy          string
+	SummaryModel      string
+	Messages          int
+	Kept              int
+	Pinned            int
+	Summarized        int
+	Dropped           int
+	ToolResultsElided int
+	TokensBefore      int
+	TokensKept        int
+	Removed           []CompactionRemoval
+}
+
+// CompactionRemoval is one message a compaction would take out of the
+// conversation. Action is "summarize", "drop" or "elide" (tool output only).
+type CompactionRemoval struct {
+	Role    string
+	Action  string
+	Excerpt string
+}
+
 // ModelService handles model selection and information
 type ModelService interface {
 	ListModels(ctx context.Context) ([]string, error)
@@ -280,9 +322,13 @@ type ChatService interface {
 
 // MessageQueue handles centralized message queuing for all components
 type MessageQueue interface {
-	// Enqueue adds a message to the queue
+	// Enqueue adds a message to the queue with normal priority
 	Enqueue(message Message, requestID string)
 
+	// EnqueueWithPriority adds a message behind the queued ones of the same
+	// or a higher priority
+	EnqueueWithPriority(message Message, requestID string, priority QueuePriority)
+
 	// Dequeue removes and returns the next message from the queue
 	// Returns nil if the queue is empty
 	Dequeue() *QueuedMessage
@@ -302,6 +348,26 @@ type MessageQueue interface {
 
 	// GetAll returns all messages in the queue without removing them
 	GetAll() []QueuedMessage
+
+	// Remove deletes the message at index, as returned by GetAll
+	// Returns false if the index is out of range
+	Remove(index int) bool
+
+	// Move moves the message at from to position to, shifting the ones between
+	// Returns false if either index is out of range
+	Move(from, to int) bool
+
+	// Replace swaps the message at index for message, keeping its place and request ID
+	// Returns false if the index is out of range
+	Replace(index int, message Message) bool
+
+	// SetPriority changes the priority of the message at index, moving it
+	// behind the other messages of its new priority
+	// Returns false if the index is out of range
+	SetPriority(index int, priority QueuePriority) bool
+
+	// HasInterrupt reports whether a message of QueuePriorityInterrupt waits
+	HasInterrupt() bool
 }
 
 // ViewManager handles view state transitions
@@ -374,6 +440,7 @@ type PlanApprovalUIManager interface {
 	SetupPlanApprovalUIState(planContent, planID string, responseChan chan PlanApprovalAction)
 	GetPlanApprovalUIState() *PlanApprovalUIState
 	SetPlanApprovalSelectedIndex(index int)
+	SetPlanApprovalContent(planContent string)
 	ClearPlanApprovalUIState()
 }
 
@@ -390,6 +457,13 @@ type TodoManager interface {
 	GetTodos() []TodoItem
 }
 
+// PlanStepManager handles the checklist of an approved plan's numbered steps
+type PlanStepManager interface {
+	SetPlanSteps(steps []PlanStep)
+	GetPlanSteps() []PlanStep
+	UpdatePlanStep(number int, status string) (PlanStep, error)
+}
+
 // AgentReadinessManager handles A2A agent readiness tracking
 type AgentReadinessManager interface {
 	InitializeAgentReadiness(totalAgents int)
@@ -686,11 +760,14 @@ type WebFetchService interface {
 	GetCacheStats() map[string]any
 }
 
-// WebSearchResult represents a single search result
+// WebSearchResult represents a single search result. Summary and FetchError
+// are only populated when the search also fetched the result page.
 type WebSearchResult struct {
-	Title   string `json:"title"`
-	URL     string `json:"url"`
-	Snippet string `json:"snippet"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Snippet    string `json:"snippet"`
+	Summary    string `json:"summary,omitempty"`
+	FetchError string `json:"fetch_error,omitempty"`
 }
 
 // WebSearchResponse represents the complete search response
@@ -1037,6 +1114,20 @@ type TreeToolResult struct {
 	Truncated       bool   `json:"truncated"`
 }
 
+// ProjectSearchToolResult represents the result of a project index search
+type ProjectSearchToolResult struct {
+	Query     string               `json:"query"`
+	Matches   []ProjectSearchMatch `json:"matches"`
+	IndexedAt time.Time            `json:"indexed_at"`
+}
+
+// ProjectSearchMatch is one file ranked by a project index search
+type ProjectSearchMatch struct {
+	Path    string  `json:"path"`
+	Score   float64 `json:"score"`
+	Summary string  `json:"summary,omitempty"`
+}
+
 // DeleteToolResult represents the result of a delete operation
 type DeleteToolResult struct {
 	Path              string   `json:"path"`
@@ -1202,6 +1293,7 @@ type A2ATaskCoordinator interface {
 type ApprovalCoordinator interface {
 	HandlePlanApprovalRequested(msg PlanApprovalRequestedEvent) tea.Cmd
 	HandlePlanApprovalResponse(msg PlanApprovalResponseEvent) (cmd tea.Cmd, restart bool)
+	HandlePlanEdited(msg PlanEditedEvent) tea.Cmd
 	HandleUserQuestionRequested(msg UserQuestionRequestedEvent) tea.Cmd
 	HandleComputerUsePaused(msg ComputerUsePausedEvent) tea.Cmd
 	HandleComputerUseResumed(msg ComputerUseResumedEvent) (cmd tea.Cmd, restart bool)
@@ -1220,7 +1312,8 @@ type ActiveToolTracker interface {
 // ChatCompletionRunner owns the LLM streaming lifecycle - initiating
 // streaming, translating chat-start / chat-chunk / chat-complete / chat-error
 // events into UI state transitions, and handling the model-restoration side
-// effect after a temporary /model switch.
+// effect after a temporary /model switch, and the alternate model offered
+// when a turn fails.
 //
 // Start takes a BashDetachChannelHolder because the agent core needs that
 // narrow interface attached to its context when launching tools that may
@@ -1234,6 +1327,10 @@ type ChatCompletionRunner interface {
 	HandleChatError(msg ChatErrorEvent) tea.Cmd
 	HandleOptimizationStatus(msg OptimizationStatusEvent) tea.Cmd
 	SetPendingRestoration(originalModel string)
+	SetNextRequestTools(tools []string)
+	// TakeRetryModel returns the alternate model offered for the last failed
+	// turn, once; "" when there is none
+	TakeRetryModel() string
 }
 
 // ToolExecutionCoordinator owns the tool round-trip: streaming-status updates
@@ -1307,3 +1404,10 @@ type MemoryBackend interface {
 	SyncIn(ctx context.Context) error
 	SyncOut(ctx context.Context) error
 }
+
+// MemoryDistiller files the durable facts of a finished session into the
+// persistent memory (memory.distill). Distill returns how many facts were
+// written; like MemoryBackend it is best-effort and callers log and continue.
+type MemoryDistiller interface {
+	Distill(ctx context.Context, messages []sdk.Message, model string) (int, error)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PlanStep is one numbered step of an approved plan, tracked as a checklist
+// while the agent executes the plan. Status uses the TodoItem values:
+// "pending", "in_progress" or "completed".
+type PlanStep struct {
+	Number  int    `json:"number"`
+	Content string `json:"content"`
+	Status  string `json:"status"`
+}
+
+// PlanStepsToolResult is the result of an UpdatePlanStep call: the step that
+// changed and the whole checklist after the change
+type PlanStepsToolResult struct {
+	Step  PlanStep   `json:"step"`
+	Steps []PlanStep `json:"steps"`
+}
+
+// planStepPattern matches a top-level ordered list item ("1. ..." or "1) ...")
+var planStepPattern = regexp.MustCompile(`^ {0,3}\d+[.)]\s+(.+)$`)
+
+// ParsePlanSteps extracts the top-level numbered list items of a plan as a
+// pending checklist, numbered in order of appearance. Items indented four or
+// more spaces (nested lists) and fenced code are skipped; lists under
+// different headings join one sequence, so "## Changes" and "## Verification"
+// steps both count.
+func ParsePlanSteps(plan string) []PlanStep {
+	var steps []PlanStep
+	inFence := false
+	for _, line := range strings.Split(plan, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		m := planStepPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		steps = append(steps, PlanStep{
+			Number:  len(steps) + 1,
+			Content: strings.TrimSpace(m[1]),
+			Status:  "pending",
+		})
+	}
+	return steps
+}
+
+// PlanStepsReminder is the instruction added to the plan execution prompt
+// when the approved plan has numbered steps, or "" when it has none
+func PlanStepsReminder(steps []PlanStep) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("The plan's %d numbered steps are tracked as a checklist the user can see: "+
+		"call UpdatePlanStep to mark each step in_progress when you start it and completed when it is done.", len(steps))
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "testing"
+
+func TestParsePlanSteps(t *testing.T) {
+	plan := "# Add auth\n\n## Context\nWe need 2 things.\n\n## Changes\n1. Add the middleware\n2) Wire it in `main.go`\n    1. nested detail\n\n```sh\n1. not a step\n```\n\n## Verification\n1. Run `go test ./...`\n"
+
+	steps := ParsePlanSteps(plan)
+	want := []string{"Add the middleware", "Wire it in `main.go`", "Run `go test ./...`"}
+	if len(steps) != len(want) {
+		t.Fatalf("got %d steps %+v, want %d", len(steps), steps, len(want))
+	}
+	for i, step := range steps {
+		if step.Number != i+1 || step.Content != want[i] || step.Status != "pending" {
+			t.Errorf("step %d = %+v, want #%d %q pending", i, step, i+1, want[i])
+		}
+	}
+
+	if got := ParsePlanSteps("- a bullet\n- another"); got != nil {
+		t.Errorf("a plan without numbered items should have no steps, got %+v", got)
+	}
+	if PlanStepsReminder(nil) != "" {
+		t.Error("no steps should mean no reminder")
+	}
+}
+
+func TestApplicationState_UpdatePlanStep(t *testing.T) {
+	s := NewApplicationState()
+	if _, err := s.UpdatePlanStep(1, "completed"); err == nil {
+		t.Fatal("updating without tracked steps should fail")
+	}
+
+	s.SetPlanSteps(ParsePlanSteps("1. first\n2. second"))
+	before := s.GetPlanSteps()
+
+	step, err := s.UpdatePlanStep(2, "completed")
+	if err != nil {
+		t.Fatalf("UpdatePlanStep: %v", err)
+	}
+	if step.Number != 2 || step.Status != "completed" {
+		t.Errorf("updated step = %+v", step)
+	}
+	if before[1].Status != "pending" {
+		t.Error("slices handed out before the update must not change")
+	}
+	if _, err := s.UpdatePlanStep(3, "completed"); err == nil {
+		t.Error("an out-of-range step should fail")
+	}
+}
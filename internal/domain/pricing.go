@@ -22,6 +22,101 @@ type SessionCostStats struct {
 	TotalOutputCost float64
 	PerModelStats   map[string]*ModelCostStats
 	Currency        string
+	// TotalCacheSavings is what the cached prompt tokens would have cost
+	// more at the full input rate
+	TotalCacheSavings float64
+}
+
+// BudgetLevel is how far spending has gone into a budget
+type BudgetLevel int
+
+const (
+	BudgetOK BudgetLevel = iota
+	BudgetWarning
+	BudgetExceeded
+)
+
+// BudgetStatus is the spending measured against the pricing budgets. A limit
+// of 0 means there is no such budget. The run budget (agent.max_cost_per_run)
+// is only known to the agent run that fills it in.
+type BudgetStatus struct {
+	RunSpent     float64
+	RunLimit     float64
+	SessionSpent float64
+	SessionLimit float64
+	DaySpent     float64
+	DayLimit     float64
+	WarnPercent  int
+}
+
+// HasBudget reports whether any budget is set
+func (s BudgetStatus) HasBudget() bool {
+	return s.RunLimit > 0 || s.SessionLimit > 0 || s.DayLimit > 0
+}
+
+// Spend returns the spending and limit of budget ("run", "session" or "daily")
+func (s BudgetStatus) Spend(budget string) (spent, limit float64) {
+	switch budget {
+	case "run":
+		return s.RunSpent, s.RunLimit
+	case "daily":
+		return s.DaySpent, s.DayLimit
+	default:
+		return s.SessionSpent, s.SessionLimit
+	}
+}
+
+// Usage returns the budget closest to being used up - "session" or "daily" -
+// with its limit and the share of it spent, in percent. It returns "" when no
+// budget is set.
+func (s BudgetStatus) Usage() (budget string, limit, percent float64) {
+	if s.SessionLimit > 0 {
+		budget, limit, percent = "session", s.SessionLimit, s.SessionSpent*100/s.SessionLimit
+	}
+	if s.DayLimit > 0 {
+		if dayPercent := s.DaySpent * 100 / s.DayLimit; budget == "" || dayPercent > percent {
+			budget, limit, percent = "daily", s.DayLimit, dayPercent
+		}
+	}
+	return budget, limit, percent
+}
+
+// Level returns how far spending has gone into the closest budget
+func (s BudgetStatus) Level() BudgetLevel {
+	budget, _, percent := s.Usage()
+	switch {
+	case budget == "":
+		return BudgetOK
+	case percent >= 100:
+		return BudgetExceeded
+	case s.WarnPercent > 0 && percent >= float64(s.WarnPercent):
+		return BudgetWarning
+	default:
+		return BudgetOK
+	}
+}
+
+// Exceeds names the budget ("run", "session" or "daily") that spending next
+// more would go past, or one already used up. It returns "" when all have room.
+func (s BudgetStatus) Exceeds(next float64) string {
+	switch {
+	case s.RunLimit > 0 && (s.RunSpent >= s.RunLimit || s.RunSpent+next > s.RunLimit):
+		return "run"
+	case s.SessionLimit > 0 && (s.SessionSpent >= s.SessionLimit || s.SessionSpent+next > s.SessionLimit):
+		return "session"
+	case s.DayLimit > 0 && (s.DaySpent >= s.DayLimit || s.DaySpent+next > s.DayLimit):
+		return "daily"
+	default:
+		return ""
+	}
+}
+
+// BudgetTracker measures spending against the configured pricing budgets
+type BudgetTracker interface {
+	BudgetStatus() BudgetStatus
+
+	// RecordSpend adds the cost of a request to the day's stored total
+	RecordSpend(amount float64)
 }
 
 // PricingService provides pricing information and cost calculation for different models.
@@ -11,6 +11,8 @@ import (
 //
 // Each fire spawns a fresh `infer agent` subprocess with a brand-new session
 // ID - no context is carried between fires, matching the issue's requirement.
+// Paused jobs stay stored but never fire; LastOutput keeps the (truncated)
+// assistant messages of the most recent run.
 type ScheduledJob struct {
 	ID             string     `yaml:"id" json:"id"`
 	Name           string     `yaml:"name,omitempty" json:"name,omitempty"`
@@ -21,10 +23,12 @@ type ScheduledJob struct {
 	RecipientID    string     `yaml:"recipient_id" json:"recipient_id"`
 	Model          string     `yaml:"model,omitempty" json:"model,omitempty"`
 	RunOnce        bool       `yaml:"run_once,omitempty" json:"run_once,omitempty"`
+	Paused         bool       `yaml:"paused,omitempty" json:"paused,omitempty"`
 	CreatedAt      time.Time  `yaml:"created_at" json:"created_at"`
 	UpdatedAt      time.Time  `yaml:"updated_at" json:"updated_at"`
 	LastRun        *time.Time `yaml:"last_run,omitempty" json:"last_run,omitempty"`
 	LastError      string     `yaml:"last_error,omitempty" json:"last_error,omitempty"`
+	LastOutput     string     `yaml:"last_output,omitempty" json:"last_output,omitempty"`
 }
 
 // SchedulerService manages the lifecycle of scheduled jobs. It is started by
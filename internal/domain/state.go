@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -39,6 +40,9 @@ type ApplicationState struct {
 	// Todo State
 	todos []TodoItem
 
+	// Plan Step State
+	planSteps []PlanStep
+
 	// Agent Readiness State
 	agentReadiness *AgentReadinessState
 
@@ -77,6 +81,7 @@ const (
 	ViewStateHelp
 	ViewStateToolsList
 	ViewStateA2AAgents
+	ViewStateCommandPalette
 )
 
 // AgentMode represents the operational mode of the agent
@@ -159,6 +164,8 @@ func (v ViewState) String() string {
 		return "ToolsList"
 	case ViewStateA2AAgents:
 		return "A2AAgents"
+	case ViewStateCommandPalette:
+		return "CommandPalette"
 	default:
 		return "Unknown"
 	}
@@ -200,6 +207,45 @@ type QueuedMessage struct {
 	Message   sdk.Message
 	QueuedAt  time.Time
 	RequestID string
+	Priority  QueuePriority
+}
+
+// QueuePriority orders the message queue: higher priorities are taken first,
+// and messages of the same priority in the order they were queued.
+type QueuePriority int
+
+const (
+	// QueuePriorityLow waits behind every other queued message
+	QueuePriorityLow QueuePriority = -1
+	// QueuePriorityNormal reaches the agent at its next turn
+	QueuePriorityNormal QueuePriority = 0
+	// QueuePriorityInterrupt is sent as soon as the running tool finishes:
+	// the tool calls of the batch that have not started yet are skipped.
+	QueuePriorityInterrupt QueuePriority = 1
+)
+
+// Next returns the priority after p when cycling normal → interrupt → low
+func (p QueuePriority) Next() QueuePriority {
+	switch p {
+	case QueuePriorityNormal:
+		return QueuePriorityInterrupt
+	case QueuePriorityInterrupt:
+		return QueuePriorityLow
+	default:
+		return QueuePriorityNormal
+	}
+}
+
+// Label returns the tag shown next to a queued message, "" for normal
+func (p QueuePriority) Label() string {
+	switch p {
+	case QueuePriorityInterrupt:
+		return "after current tool"
+	case QueuePriorityLow:
+		return "low"
+	default:
+		return ""
+	}
 }
 
 // RetryStatus tracks the current retry state for reconnection attempts.
@@ -207,6 +253,8 @@ type QueuedMessage struct {
 type RetryStatus struct {
 	Attempt     int
 	MaxAttempts int
+	// RetryAt is when a rate-limited request is retried; zero for reconnects
+	RetryAt time.Time
 }
 
 // ChatSession represents an active chat session state
@@ -434,6 +482,7 @@ type MessageSnapshot struct {
 	Content      string          `json:"content"`
 	Timestamp    time.Time       `json:"timestamp"`
 	TruncatedMsg string          `json:"truncated_msg"`
+	Pinned       bool            `json:"pinned,omitempty"`
 }
 
 // NewApplicationState creates a new application state
@@ -520,6 +569,7 @@ func (s *ApplicationState) isValidTransition(from, to ViewState) bool {
 			ViewStateHelp,
 			ViewStateToolsList,
 			ViewStateA2AAgents,
+			ViewStateCommandPalette,
 		},
 		ViewStateFileSelection:         {ViewStateChat},
 		ViewStateConversationSelection: {ViewStateChat},
@@ -532,6 +582,7 @@ func (s *ApplicationState) isValidTransition(from, to ViewState) bool {
 		ViewStateHelp:                  {ViewStateChat},
 		ViewStateToolsList:             {ViewStateChat},
 		ViewStateA2AAgents:             {ViewStateChat},
+		ViewStateCommandPalette:        {ViewStateChat},
 	}
 
 	allowed, exists := validTransitions[from]
@@ -900,6 +951,13 @@ func (s *ApplicationState) SetPlanApprovalSelectedIndex(index int) {
 	}
 }
 
+// SetPlanApprovalContent replaces the pending plan's content
+func (s *ApplicationState) SetPlanApprovalContent(planContent string) {
+	if s.planApprovalUIState != nil {
+		s.planApprovalUIState.PlanContent = planContent
+	}
+}
+
 // ClearPlanApprovalUIState clears the plan approval UI state
 func (s *ApplicationState) ClearPlanApprovalUIState() {
 	if s.planApprovalUIState != nil && s.planApprovalUIState.ResponseChan != nil {
@@ -946,6 +1004,33 @@ func (s *ApplicationState) GetTodos() []TodoItem {
 	return s.todos
 }
 
+// Plan Step State Management
+
+// SetPlanSteps replaces the approved plan's checklist
+func (s *ApplicationState) SetPlanSteps(steps []PlanStep) {
+	s.planSteps = steps
+}
+
+// GetPlanSteps returns the approved plan's checklist
+func (s *ApplicationState) GetPlanSteps() []PlanStep {
+	return s.planSteps
+}
+
+// UpdatePlanStep sets the status of the numbered step and returns it. The
+// checklist is copied so slices handed out earlier are not changed.
+func (s *ApplicationState) UpdatePlanStep(number int, status string) (PlanStep, error) {
+	if len(s.planSteps) == 0 {
+		return PlanStep{}, fmt.Errorf("no approved plan steps are being tracked")
+	}
+	if number < 1 || number > len(s.planSteps) {
+		return PlanStep{}, fmt.Errorf("step %d does not exist, the plan has %d steps", number, len(s.planSteps))
+	}
+	steps := slices.Clone(s.planSteps)
+	steps[number-1].Status = status
+	s.planSteps = steps
+	return steps[number-1], nil
+}
+
 // Message Edit State Management
 
 // SetMessageEditState sets the message edit state
@@ -27,6 +27,13 @@ const (
 // runs, which therefore stay Standard-by-default.
 const EnvSubagentAgentMode = "INFER_SUBAGENT_AGENT_MODE"
 
+// EnvSubagentTools names the environment variable the Agent tool sets to a
+// comma-separated tool allowlist when a task restricts its subagent's toolset.
+// The subagent's tool service then offers and executes only those tools, on
+// top of the filtering its agent mode already applies. Unset means no extra
+// restriction.
+const EnvSubagentTools = "INFER_SUBAGENT_TOOLS"
+
 // EnvSubagentResultFile names the environment variable the Agent tool sets on an
 // interactive subagent's `infer chat` so it writes its last assistant message
 // (as a SubagentResultFile JSON) to that path on each completed turn. The parent
@@ -10,6 +10,7 @@ import (
 // ThemeProvider implements ThemeService and manages available themes
 type ThemeProvider struct {
 	themes      map[string]Theme
+	custom      map[string]bool
 	currentName string
 }
 
@@ -17,6 +18,7 @@ type ThemeProvider struct {
 func NewThemeProvider() *ThemeProvider {
 	provider := &ThemeProvider{
 		themes:      make(map[string]Theme),
+		custom:      make(map[string]bool),
 		currentName: "tokyo-night",
 	}
 
@@ -30,6 +32,7 @@ func (tp *ThemeProvider) registerDefaultThemes() {
 	tp.themes["github-light"] = NewGithubLightTheme()
 	tp.themes["dracula"] = NewDraculaTheme()
 	tp.themes["charm"] = NewCharmTheme()
+	tp.themes[MonochromeThemeName] = NewMonochromeTheme()
 }
 
 // GetTheme returns the theme by name, or the current theme if name is empty
@@ -66,12 +69,13 @@ func (tp *ThemeProvider) GetCurrentThemeName() string {
 	return tp.currentName
 }
 
-// ListThemes returns all available theme names (implements ThemeService interface)
+// ListThemes returns all available theme names, built-in themes first (implements ThemeService interface)
 func (tp *ThemeProvider) ListThemes() []string {
 	names := make([]string, 0, len(tp.themes))
 	for name := range tp.themes {
 		names = append(names, name)
 	}
+	tp.sortThemeNames(names)
 	return names
 }
 
@@ -154,3 +158,27 @@ func (t *CharmTheme) GetDimColor() string        { return charmtone.Smoke.Hex()
 func (t *CharmTheme) GetBorderColor() string     { return charmtone.Iron.Hex() }
 func (t *CharmTheme) GetDiffAddColor() string    { return charmtone.Julep.Hex() }
 func (t *CharmTheme) GetDiffRemoveColor() string { return charmtone.Cherry.Hex() }
+
+// MonochromeThemeName is the built-in theme used for --no-color and NO_COLOR.
+const MonochromeThemeName = "monochrome"
+
+// MonochromeTheme sets no colors at all, leaving text in the terminal's own
+// foreground. The UI falls back on structural markers (symbols, bold and
+// reverse video) for what color would otherwise convey, which keeps it usable
+// for colorblind users and in captured logs.
+type MonochromeTheme struct{}
+
+func NewMonochromeTheme() *MonochromeTheme {
+	return &MonochromeTheme{}
+}
+
+func (t *MonochromeTheme) GetUserColor() string       { return "" }
+func (t *MonochromeTheme) GetAssistantColor() string  { return "" }
+func (t *MonochromeTheme) GetErrorColor() string      { return "" }
+func (t *MonochromeTheme) GetSuccessColor() string    { return "" }
+func (t *MonochromeTheme) GetStatusColor() string     { return "" }
+func (t *MonochromeTheme) GetAccentColor() string     { return "" }
+func (t *MonochromeTheme) GetDimColor() string        { return "" }
+func (t *MonochromeTheme) GetBorderColor() string     { return "" }
+func (t *MonochromeTheme) GetDiffAddColor() string    { return "" }
+func (t *MonochromeTheme) GetDiffRemoveColor() string { return "" }
@@ -44,8 +44,9 @@ type UpdateStatusEvent struct {
 
 // ShowErrorEvent displays an error message
 type ShowErrorEvent struct {
-	Error  string
-	Sticky bool // Whether error persists until dismissed
+	Error      string
+	Sticky     bool   // Whether error persists until dismissed
+	RetryModel string // Model the failed turn can be retried with, "" for none
 }
 
 // ClearErrorEvent clears any displayed error
@@ -100,6 +101,14 @@ type RolloverCompletedEvent struct {
 	Images  []ImageAttachment
 }
 
+// CheckpointCreatedEvent is dispatched when the workspace snapshot taken
+// before a chat turn finishes. Like RolloverCompletedEvent it carries the
+// pending user message, so the turn starts only once its checkpoint exists.
+type CheckpointCreatedEvent struct {
+	Message sdk.Message
+	Images  []ImageAttachment
+}
+
 // ModelSelectedEvent indicates model selection
 type ModelSelectedEvent struct {
 	Model string
@@ -187,10 +196,12 @@ func (e ToolExecutionCompletedEvent) GetTimestamp() time.Time { return e.Timesta
 
 // Approval Events
 
-// ToolApprovalResponseEvent captures the user's approval decision
+// ToolApprovalResponseEvent captures the user's approval decision. HunkReview
+// is set when the user reviewed an Edit/MultiEdit call hunk by hunk.
 type ToolApprovalResponseEvent struct {
-	Action   ApprovalAction
-	ToolCall sdk.ChatCompletionMessageToolCall
+	Action     ApprovalAction
+	ToolCall   sdk.ChatCompletionMessageToolCall
+	HunkReview *HunkReview
 }
 
 // Plan Approval Events
@@ -207,9 +218,26 @@ type TodoUpdateEvent struct {
 	Todos []TodoItem
 }
 
+// PlanStepsUpdateEvent indicates the approved plan's checklist has changed
+type PlanStepsUpdateEvent struct {
+	Steps []PlanStep
+}
+
 // ToggleTodoBoxEvent toggles the todo box expanded/collapsed state
 type ToggleTodoBoxEvent struct{}
 
+// TogglePinnedBoxEvent toggles the pinned messages panel expanded/collapsed state
+type TogglePinnedBoxEvent struct{}
+
+// ToggleSidePanelEvent cycles the side panel through todos, the latest diff,
+// a file preview and hidden
+type ToggleSidePanelEvent struct{}
+
+// ResizeSidePanelEvent grows (Delta > 0) or shrinks the side panel by Delta steps
+type ResizeSidePanelEvent struct {
+	Delta int
+}
+
 // GitPRResolvedEvent carries the PR number for the current branch, resolved
 // asynchronously by the input view's fetch command. An empty PR means no PR
 // exists (or gh is unavailable). Defined here rather than as a component-local
@@ -247,6 +275,21 @@ type DrainQueueEvent struct{}
 // re-arms only while work is still stranded and stops the moment the queue drains.
 type DrainQueueRetryEvent struct{}
 
+// RetryTurnEvent re-dispatches a failed turn on the alternate model offered
+// with its error (ShowErrorEvent.RetryModel), switching the session to it.
+type RetryTurnEvent struct{}
+
+// MacroPlaybackEvent starts replaying a recorded macro: each step is submitted
+// as if typed, once the agent is idle on the chat view.
+type MacroPlaybackEvent struct {
+	Name  string
+	Steps []string
+}
+
+// MacroStepEvent asks the orchestrator to submit the next step of the macro
+// being replayed. It re-arms itself on a short timer while the agent is busy.
+type MacroStepEvent struct{}
+
 // BackgroundTasksChangedEvent signals that a background job's status changed
 // (submitted, signalled, completed, or failed). The supervisor pushes it so the
 // /tasks view and the inline conversation rows refresh on real change instead of
@@ -285,9 +328,42 @@ type TriggerGithubActionSetupEvent struct{}
 // lists every slash command and keybinding in two tables.
 type TriggerHelpViewEvent struct{}
 
+// TriggerCommandPaletteEvent opens the searchable command palette listing
+// every shortcut, keybinding action, view and config toggle.
+type TriggerCommandPaletteEvent struct{}
+
+// UndoSendEvent cancels the request for the message just sent and moves it
+// back into the input, if the model has not started replying.
+type UndoSendEvent struct{}
+
 // PlanApprovalSelectionChangedEvent signals that the plan-approval button
 // selection has moved and the conversation viewport needs to re-render so
 // the highlighted button reflects the new index.
 type PlanApprovalSelectionChangedEvent struct {
 	NewIndex int
 }
+
+// PlanEditedEvent carries the plan markdown saved from the editor while the
+// plan awaits approval. It replaces the pending plan before the user decides.
+type PlanEditedEvent struct {
+	PlanContent string
+}
+
+// ConversationEntryAction is an action offered by the right-click context
+// menu on a conversation entry
+type ConversationEntryAction int
+
+const (
+	EntryActionCopy ConversationEntryAction = iota
+	EntryActionToggleExpand
+	EntryActionPin
+	EntryActionQuoteReply
+	EntryActionRerunTool
+)
+
+// ConversationEntryActionEvent applies an action picked from the context menu
+// to the conversation entry at EntryIndex
+type ConversationEntryActionEvent struct {
+	Action     ConversationEntryAction
+	EntryIndex int
+}
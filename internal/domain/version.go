@@ -3,4 +3,7 @@ package domain
 // VersionInfo contains build-time version information
 type VersionInfo struct {
 	Version string
+	// Latest is a newer release than Version, when one is known; the chat
+	// welcome box shows it as an update notice
+	Latest string
 }
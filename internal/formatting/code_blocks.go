@@ -0,0 +1,99 @@
+package formatting
+
+import "strings"
+
+// CodeBlock is a fenced code block extracted from markdown content
+type CodeBlock struct {
+	Language string
+	Code     string
+}
+
+// ExtractCodeBlocks returns the fenced (``` or ~~~) code blocks of a markdown
+// document in order, with the fence lines stripped and indentation kept
+// verbatim. A fence that is never closed runs to the end of the content, so a
+// still-streaming response yields its partial block.
+func ExtractCodeBlocks(content string) []CodeBlock {
+	var (
+		blocks  []CodeBlock
+		current *CodeBlock
+		lines   []string
+		fence   string
+		indent  int
+	)
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if current == nil {
+			marker, info, width, ok := openingFence(line)
+			if !ok {
+				continue
+			}
+			current = &CodeBlock{Language: info}
+			fence, indent, lines = marker, width, nil
+			continue
+		}
+
+		if isClosingFence(line, fence) {
+			current.Code = strings.Join(lines, "\n")
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		lines = append(lines, trimFenceIndent(line, indent))
+	}
+
+	if current != nil {
+		current.Code = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+		blocks = append(blocks, *current)
+	}
+	return blocks
+}
+
+// openingFence reports whether line opens a code block, returning the fence
+// marker, the first word of the info string, and the fence's indentation.
+func openingFence(line string) (string, string, int, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := len(line) - len(trimmed)
+	if indent > 3 || len(trimmed) < 3 {
+		return "", "", 0, false
+	}
+
+	char := trimmed[0]
+	if char != '`' && char != '~' {
+		return "", "", 0, false
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == char {
+		n++
+	}
+	if n < 3 {
+		return "", "", 0, false
+	}
+
+	info := strings.TrimSpace(trimmed[n:])
+	if char == '`' && strings.Contains(info, "`") {
+		return "", "", 0, false
+	}
+	if fields := strings.Fields(info); len(fields) > 0 {
+		info = fields[0]
+	}
+	return trimmed[:n], info, indent, true
+}
+
+// isClosingFence reports whether line closes a block opened with fence: the
+// same character, at least as long, and nothing after it.
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	if len(line)-len(strings.TrimLeft(line, " ")) > 3 || len(trimmed) < len(fence) {
+		return false
+	}
+	return strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// trimFenceIndent removes up to indent leading spaces, matching how markdown
+// de-indents the content of an indented fence.
+func trimFenceIndent(line string, indent int) string {
+	for i := 0; i < indent && strings.HasPrefix(line, " "); i++ {
+		line = line[1:]
+	}
+	return line
+}
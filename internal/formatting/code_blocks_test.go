@@ -0,0 +1,59 @@
+package formatting
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractCodeBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []CodeBlock
+	}{
+		{"no blocks", "just prose with `inline` code", nil},
+		{
+			"keeps indentation",
+			"Try this:\n\n```go\nfunc main() {\n\tif ok {\n\t\treturn\n\t}\n}\n```\n",
+			[]CodeBlock{{Language: "go", Code: "func main() {\n\tif ok {\n\t\treturn\n\t}\n}"}},
+		},
+		{
+			"multiple blocks and tilde fences",
+			"```bash\nmake build\n```\ntext\n~~~\n  two spaces\n~~~",
+			[]CodeBlock{{Language: "bash", Code: "make build"}, {Code: "  two spaces"}},
+		},
+		{
+			"longer fence contains a shorter one",
+			"````markdown\n```go\nx := 1\n```\n````",
+			[]CodeBlock{{Language: "markdown", Code: "```go\nx := 1\n```"}},
+		},
+		{
+			"info string keeps only the language",
+			"```python title=\"x.py\"\nprint(1)\n```",
+			[]CodeBlock{{Language: "python", Code: "print(1)"}},
+		},
+		{
+			"indented fence is de-indented",
+			"  ```\n  a\n    b\n  ```",
+			[]CodeBlock{{Code: "a\n  b"}},
+		},
+		{
+			"unclosed fence runs to the end",
+			"```js\nconsole.log(1)\n",
+			[]CodeBlock{{Language: "js", Code: "console.log(1)"}},
+		},
+		{
+			"crlf line endings",
+			"```\r\nline\r\n```\r\n",
+			[]CodeBlock{{Code: "line"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractCodeBlocks(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ExtractCodeBlocks() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -202,6 +202,28 @@ func joinArgs(args []string) string {
 	return result
 }
 
+// ============================================================================
+// Token Formatting
+// ============================================================================
+
+// FormatContextWindow renders a token count as "1M" / "128K" / raw, picking
+// the most readable form. Boundaries are exact multiples to avoid awkward
+// numbers like "1.0M" when a matcher returns 1_000_000.
+func FormatContextWindow(tokens int) string {
+	switch {
+	case tokens >= 1_000_000 && tokens%1_000_000 == 0:
+		return fmt.Sprintf("%dM", tokens/1_000_000)
+	case tokens >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(tokens)/1_000_000)
+	case tokens >= 1024 && tokens%1024 == 0:
+		return fmt.Sprintf("%dK", tokens/1024)
+	case tokens >= 1000:
+		return fmt.Sprintf("%dK", tokens/1000)
+	default:
+		return fmt.Sprintf("%d", tokens)
+	}
+}
+
 // ============================================================================
 // Cost Formatting
 // ============================================================================
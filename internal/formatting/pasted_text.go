@@ -0,0 +1,45 @@
+package formatting
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pastedTextBlock matches a pasted-text attachment as it is sent to the model
+var pastedTextBlock = regexp.MustCompile(`(?s)<pasted lines="(\d+)">\n.*?\n</pasted>`)
+
+// CountLines returns the number of lines in text, ignoring a trailing newline
+func CountLines(text string) int {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return 0
+	}
+	return strings.Count(text, "\n") + 1
+}
+
+// PastedTextLabel is the placeholder shown in place of a pasted-text
+// attachment of the given size, e.g. "[pasted 412 lines]".
+func PastedTextLabel(lines int) string {
+	return fmt.Sprintf("[pasted %d lines]", lines)
+}
+
+// WrapPastedText encloses a pasted-text attachment in the markers the model
+// receives, so the conversation view can collapse it again when rendering.
+func WrapPastedText(text string) string {
+	text = strings.TrimSuffix(text, "\n")
+	return fmt.Sprintf("<pasted lines=\"%d\">\n%s\n</pasted>", CountLines(text), text)
+}
+
+// CollapsePastedText replaces every pasted-text attachment in content with
+// its placeholder label.
+func CollapsePastedText(content string) string {
+	if !strings.Contains(content, "<pasted lines=") {
+		return content
+	}
+	return pastedTextBlock.ReplaceAllStringFunc(content, func(block string) string {
+		lines, _ := strconv.Atoi(pastedTextBlock.FindStringSubmatch(block)[1])
+		return PastedTextLabel(lines)
+	})
+}
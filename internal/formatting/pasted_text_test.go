@@ -0,0 +1,35 @@
+package formatting
+
+import "testing"
+
+func TestCountLines(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"one", 1},
+		{"one\n", 1},
+		{"one\ntwo\n\nfour", 4},
+	}
+	for _, tt := range tests {
+		if got := CountLines(tt.text); got != tt.want {
+			t.Errorf("CountLines(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCollapsePastedText(t *testing.T) {
+	trace := "panic: boom\n\ngoroutine 1 [running]:\nmain.main()\n"
+	content := "Why does this crash?\n" + WrapPastedText(trace) + "\nand this:\n" + WrapPastedText("a\nb")
+
+	want := "Why does this crash?\n[pasted 4 lines]\nand this:\n[pasted 2 lines]"
+	if got := CollapsePastedText(content); got != want {
+		t.Errorf("CollapsePastedText() = %q, want %q", got, want)
+	}
+
+	plain := "no attachments <pasted> here"
+	if got := CollapsePastedText(plain); got != plain {
+		t.Errorf("plain text changed: %q", got)
+	}
+}
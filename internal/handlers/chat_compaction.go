@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -30,7 +31,17 @@ func (h *ChatHandler) nonHiddenMessages() []sdk.Message {
 
 // optimizeWithTimeout force-compacts messages under a hard timeout. It returns
 // (optimized, true) on success and (nil, false) if the summarization timed out.
+// The pre_compact hooks run first, while the conversation is still whole.
 func (h *ChatHandler) optimizeWithTimeout(messages []sdk.Message, model string) ([]sdk.Message, bool) {
+	if h.agentService != nil {
+		h.agentService.RunLifecycleHooks(context.Background(), domain.HookPayload{
+			Hook:     domain.HookPreCompact,
+			Model:    model,
+			Messages: len(messages),
+			Reason:   "manual",
+		})
+	}
+
 	optimizedChan := make(chan []sdk.Message, 1)
 	go func() {
 		optimizedChan <- h.conversationOptimizer.OptimizeMessages(messages, model, true)
@@ -47,9 +58,10 @@ func (h *ChatHandler) optimizeWithTimeout(messages []sdk.Message, model string)
 
 // reseedConversationWithMessages saves the current conversation and starts a
 // fresh one titled "Continued from <old title>", seeded with the given messages.
-// The old conversation is preserved in storage; only the in-memory working set
-// is replaced.
+// Messages that were pinned stay pinned. The old conversation is preserved in
+// storage; only the in-memory working set is replaced.
 func (h *ChatHandler) reseedConversationWithMessages(messages []sdk.Message, model string) error {
+	pinned := domain.PinnedKeys(h.conversationRepo.GetMessages())
 	newTitle := fmt.Sprintf("Continued from %s", h.conversationRepo.GetCurrentConversationTitle())
 	if err := h.conversationRepo.StartNewConversation(newTitle); err != nil {
 		return err
@@ -59,6 +71,7 @@ func (h *ChatHandler) reseedConversationWithMessages(messages []sdk.Message, mod
 			Message: msg,
 			Model:   model,
 			Time:    time.Now(),
+			Pinned:  pinned[domain.PinKey(msg)],
 		}
 		if err := h.conversationRepo.AddMessage(entry); err != nil {
 			logger.Error("failed to add optimized message", "error", err)
@@ -107,7 +120,13 @@ func (h *ChatHandler) newSessionAfterPlanApproval(planID string) {
 		return
 	}
 
-	if err := h.addHiddenUserMessage(planExecutionContinuePrompt(planID)); err != nil {
+	prompt := planExecutionContinuePrompt(planID)
+	if h.stateManager != nil {
+		if reminder := domain.PlanStepsReminder(h.stateManager.GetPlanSteps()); reminder != "" {
+			prompt += " " + reminder
+		}
+	}
+	if err := h.addHiddenUserMessage(prompt); err != nil {
 		logger.Error("failed to add plan execution continue message", "error", err)
 	}
 
@@ -5,6 +5,8 @@ import (
 
 	assert "github.com/stretchr/testify/assert"
 
+	domain "github.com/inference-gateway/cli/internal/domain"
+	services "github.com/inference-gateway/cli/internal/services"
 	mocks "github.com/inference-gateway/cli/tests/mocks/domain"
 )
 
@@ -58,4 +60,23 @@ func TestNewSessionAfterPlanApproval(t *testing.T) {
 			t.Errorf("expected one hidden continue message, got %d adds", repo.AddMessageCallCount())
 		}
 	})
+
+	t.Run("reminds the agent to track the plan's steps", func(t *testing.T) {
+		repo := &mocks.FakeConversationRepository{}
+		stateManager := services.NewStateManager(false)
+		stateManager.SetPlanSteps([]domain.PlanStep{{Number: 1, Content: "Write the test", Status: "pending"}})
+
+		h := &ChatHandler{
+			conversationRepo: repo,
+			stateManager:     stateManager,
+		}
+
+		h.newSessionAfterPlanApproval("2026-06-28-090000-plan")
+
+		if repo.AddMessageCallCount() != 1 {
+			t.Fatalf("expected one hidden continue message, got %d adds", repo.AddMessageCallCount())
+		}
+		content, _ := repo.AddMessageArgsForCall(0).Message.Content.AsMessageContent0()
+		assert.Contains(t, content, "UpdatePlanStep")
+	})
 }
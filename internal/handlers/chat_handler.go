@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -10,17 +11,20 @@ import (
 	domain "github.com/inference-gateway/cli/internal/domain"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	services "github.com/inference-gateway/cli/internal/services"
+	checkpoint "github.com/inference-gateway/cli/internal/services/checkpoint"
 	shortcuts "github.com/inference-gateway/cli/internal/shortcuts"
 )
 
 // stateManager is the narrow slice of the app state manager the chat handler
 // and its sub-handlers need: chat-session lifecycle, view transitions, the
-// plan-approval overlay, and the todo list. *services.StateManager satisfies it.
+// plan-approval overlay, the todo list and the approved plan's checklist.
+// *services.StateManager satisfies it.
 type stateManager interface {
 	domain.ChatSessionManager
 	domain.ViewManager
 	domain.PlanApprovalUIManager
 	domain.TodoManager
+	domain.PlanStepManager
 }
 
 type ChatHandler struct {
@@ -49,7 +53,9 @@ type ChatHandler struct {
 	shortcutHandler        *ChatShortcutHandler
 	skillsService          domain.SkillsService
 	githubIssueService     domain.GitHubIssueService
+	checkpoints            *checkpoint.Store
 	drainRetryArmed        bool
+	macro                  macroPlayback
 }
 
 func NewChatHandler(
@@ -76,6 +82,7 @@ func NewChatHandler(
 	completionRunner domain.ChatCompletionRunner,
 	directExec domain.DirectExecutionService,
 	toolCoordinator domain.ToolExecutionCoordinator,
+	checkpoints *checkpoint.Store,
 ) *ChatHandler {
 	handler := &ChatHandler{
 		agentService:           agentService,
@@ -101,6 +108,7 @@ func NewChatHandler(
 		completionRunner:       completionRunner,
 		directExec:             directExec,
 		toolCoordinator:        toolCoordinator,
+		checkpoints:            checkpoints,
 	}
 
 	handler.messageProcessor = NewChatMessageProcessor(handler)
@@ -117,6 +125,8 @@ func (h *ChatHandler) Handle(msg tea.Msg) tea.Cmd { // nolint:cyclop,gocyclo,fun
 		return h.HandleUserInputEvent(m)
 	case domain.RolloverCompletedEvent:
 		return h.HandleRolloverCompletedEvent(m)
+	case domain.CheckpointCreatedEvent:
+		return h.HandleCheckpointCreatedEvent(m)
 	case domain.FileSelectionRequestEvent:
 		return h.HandleFileSelectionRequestEvent(m)
 	case domain.ConversationSelectedEvent:
@@ -177,6 +187,8 @@ func (h *ChatHandler) Handle(msg tea.Msg) tea.Cmd { // nolint:cyclop,gocyclo,fun
 		return h.HandlePlanApprovalRequestedEvent(m)
 	case domain.PlanApprovalResponseEvent:
 		return h.HandlePlanApprovalResponseEvent(m)
+	case domain.PlanEditedEvent:
+		return h.approvalCoordinator.HandlePlanEdited(m)
 	case domain.UserQuestionRequestedEvent:
 		return h.HandleUserQuestionRequestedEvent(m)
 	case domain.TodoUpdateChatEvent:
@@ -187,6 +199,12 @@ func (h *ChatHandler) Handle(msg tea.Msg) tea.Cmd { // nolint:cyclop,gocyclo,fun
 		return h.HandleDrainQueueEvent(m)
 	case domain.DrainQueueRetryEvent:
 		return h.HandleDrainQueueRetryEvent(m)
+	case domain.RetryTurnEvent:
+		return h.HandleRetryTurnEvent(m)
+	case domain.MacroPlaybackEvent:
+		return h.HandleMacroPlaybackEvent(m)
+	case domain.MacroStepEvent:
+		return h.HandleMacroStepEvent(m)
 	case domain.NavigateBackInTimeEvent:
 		return nil
 	case domain.MessageHistoryRestoreEvent:
@@ -259,6 +277,7 @@ func (h *ChatHandler) HandleChatCompleteEvent(
 	cmd := h.completionRunner.HandleChatComplete(msg)
 	if msg.Cancelled {
 		h.toolCoordinator.SetActiveToolCallID("")
+		h.stopMacro()
 	}
 	if h.shouldDrainAfterComplete(msg) {
 		return tea.Batch(cmd, drainQueueCmd())
@@ -289,6 +308,48 @@ func (h *ChatHandler) HandleChatErrorEvent(
 	return h.completionRunner.HandleChatError(msg)
 }
 
+// HandleRetryTurnEvent switches to the model offered with the last turn's
+// error and sends the same conversation again, which still ends with the
+// request that failed.
+func (h *ChatHandler) HandleRetryTurnEvent(_ domain.RetryTurnEvent) tea.Cmd {
+	if h.stateManager.IsAgentBusy() {
+		return nil
+	}
+
+	model := h.completionRunner.TakeRetryModel()
+	if model == "" {
+		return func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    "No failed turn to retry",
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		}
+	}
+
+	if err := h.modelService.SelectModel(model); err != nil {
+		logger.Error("failed to switch model for retry", "model", model, "error", err)
+		return func() tea.Msg {
+			return domain.ShowErrorEvent{
+				Error:  fmt.Sprintf("Failed to switch to model '%s': %v", model, err),
+				Sticky: true,
+			}
+		}
+	}
+
+	h.stateManager.SetChatPending()
+	return tea.Batch(
+		func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    fmt.Sprintf("Retrying with %s", model),
+				Spinner:    true,
+				StatusType: domain.StatusPreparing,
+			}
+		},
+		h.startChatCompletion(),
+	)
+}
+
 func (h *ChatHandler) HandleOptimizationStatusEvent(
 	msg domain.OptimizationStatusEvent,
 ) tea.Cmd {
@@ -306,6 +367,14 @@ func (h *ChatHandler) HandleRolloverCompletedEvent(
 	return h.messageProcessor.appendUserMessageAndStartCompletion(msg.Message, msg.Images)
 }
 
+// HandleCheckpointCreatedEvent starts the turn deferred while the workspace
+// was snapshotted (kicked off by ChatMessageProcessor.checkpointThenContinue).
+func (h *ChatHandler) HandleCheckpointCreatedEvent(
+	msg domain.CheckpointCreatedEvent,
+) tea.Cmd {
+	return h.messageProcessor.addUserMessageAndStartCompletion(msg.Message, msg.Images)
+}
+
 func (h *ChatHandler) HandleToolCallUpdateEvent(
 	msg domain.ToolCallUpdateEvent,
 ) tea.Cmd {
@@ -396,6 +396,7 @@ func TestFormatMetricsWithoutSessionTokens(t *testing.T) {
 		nil, // completionRunner
 		nil, // directExec
 		nil, // toolCoordinator
+		nil, // checkpoints
 	)
 
 	err := conversationRepo.AddTokenUsage("test-model", 100, 50, 150, 0)
@@ -752,6 +753,7 @@ func setupTestChatHandler(_ *testing.T, setupMocks func(*mocks.FakeAgentService,
 		fakeRunner,
 		fakeDirect,
 		fakeToolCoord,
+		nil, // checkpoints
 	)
 }
 
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	constants "github.com/inference-gateway/cli/internal/constants"
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// maxMacroSteps bounds one playback, so a macro that invokes itself cannot
+// loop forever.
+const maxMacroSteps = 200
+
+// macroPlayback is the macro being replayed: the steps still to submit and how
+// many have been submitted so far. The zero value means no macro is running.
+type macroPlayback struct {
+	name  string
+	steps []string
+	ran   int
+}
+
+// HandleMacroPlaybackEvent queues a macro's steps. A macro started by a step
+// of another macro runs in place of that step, ahead of the remaining ones.
+func (h *ChatHandler) HandleMacroPlaybackEvent(msg domain.MacroPlaybackEvent) tea.Cmd {
+	running := h.macro.name != ""
+	if !running {
+		h.macro = macroPlayback{name: msg.Name}
+	}
+	h.macro.steps = append(slices.Clone(msg.Steps), h.macro.steps...)
+
+	if running {
+		return nil
+	}
+	return func() tea.Msg { return domain.MacroStepEvent{} }
+}
+
+// HandleMacroStepEvent submits the next macro step once the agent is idle on
+// the chat view, so each step sees the result of the previous one. Like the
+// queue drain it waits on a short timer rather than a completion signal,
+// because steps finish in different ways: agent turns, bash runs, shortcuts.
+func (h *ChatHandler) HandleMacroStepEvent(_ domain.MacroStepEvent) tea.Cmd {
+	if h.macro.name == "" {
+		return nil
+	}
+
+	if h.stateManager.IsAgentBusy() || h.stateManager.GetCurrentView() != domain.ViewStateChat {
+		return macroStepTick()
+	}
+
+	name := h.macro.name
+	if len(h.macro.steps) == 0 {
+		h.stopMacro()
+		return func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    fmt.Sprintf("Macro /%s finished", name),
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		}
+	}
+
+	if h.macro.ran >= maxMacroSteps {
+		h.stopMacro()
+		return func() tea.Msg {
+			return domain.ShowErrorEvent{
+				Error:  fmt.Sprintf("Macro /%s stopped after %d steps", name, maxMacroSteps),
+				Sticky: false,
+			}
+		}
+	}
+
+	step := h.macro.steps[0]
+	h.macro.steps = h.macro.steps[1:]
+	h.macro.ran++
+
+	return tea.Batch(
+		h.messageProcessor.handleUserInput(domain.UserInputEvent{Content: step}),
+		macroStepTick(),
+	)
+}
+
+// stopMacro abandons the macro being replayed, if any.
+func (h *ChatHandler) stopMacro() {
+	h.macro = macroPlayback{}
+}
+
+func macroStepTick() tea.Cmd {
+	return tea.Tick(constants.DrainQueueRetryInterval, func(time.Time) tea.Msg {
+		return domain.MacroStepEvent{}
+	})
+}
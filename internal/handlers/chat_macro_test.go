@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	services "github.com/inference-gateway/cli/internal/services"
+	mocks "github.com/inference-gateway/cli/tests/mocks/domain"
+)
+
+func newMacroTestHandler() (*ChatHandler, *services.StateManager, *mocks.FakeDirectExecutionService) {
+	sm := services.NewStateManager(false)
+	_ = sm.TransitionToView(domain.ViewStateChat)
+	directExec := &mocks.FakeDirectExecutionService{}
+
+	h := &ChatHandler{
+		stateManager: sm,
+		messageQueue: &mocks.FakeMessageQueue{},
+		directExec:   directExec,
+	}
+	h.messageProcessor = NewChatMessageProcessor(h)
+	return h, sm, directExec
+}
+
+func TestHandleMacroStepEvent_SubmitsStepsWhenIdle(t *testing.T) {
+	h, sm, directExec := newMacroTestHandler()
+
+	if cmd := h.HandleMacroPlaybackEvent(domain.MacroPlaybackEvent{
+		Name:  "check",
+		Steps: []string{"!go test ./...", "!go vet ./..."},
+	}); cmd == nil {
+		t.Fatal("expected playback to schedule its first step")
+	}
+
+	if cmd := h.HandleMacroStepEvent(domain.MacroStepEvent{}); cmd == nil {
+		t.Fatal("expected a Cmd for the first step")
+	}
+	if got := directExec.HandleBashCommandCallCount(); got != 1 {
+		t.Fatalf("expected first step to run, got %d bash commands", got)
+	}
+	if got := directExec.HandleBashCommandArgsForCall(0); got != "!go test ./..." {
+		t.Errorf("expected first step to be submitted as typed, got %q", got)
+	}
+
+	_ = sm.StartToolExecution([]sdk.ChatCompletionMessageToolCall{{ID: "busy"}})
+	if cmd := h.HandleMacroStepEvent(domain.MacroStepEvent{}); cmd == nil {
+		t.Fatal("expected a busy agent to re-arm the step timer")
+	}
+	if got := directExec.HandleBashCommandCallCount(); got != 1 {
+		t.Fatalf("expected no step while the agent is busy, got %d bash commands", got)
+	}
+
+	sm.EndToolExecution()
+	_ = h.HandleMacroStepEvent(domain.MacroStepEvent{})
+	if got := directExec.HandleBashCommandCallCount(); got != 2 {
+		t.Fatalf("expected second step once idle, got %d bash commands", got)
+	}
+
+	msg := h.HandleMacroStepEvent(domain.MacroStepEvent{})()
+	status, ok := msg.(domain.SetStatusEvent)
+	if !ok || status.Message != "Macro /check finished" {
+		t.Errorf("expected a finished status, got %#v", msg)
+	}
+	if h.HandleMacroStepEvent(domain.MacroStepEvent{}) != nil {
+		t.Error("expected no more steps after the macro finished")
+	}
+}
+
+func TestHandleMacroPlaybackEvent_NestedMacroRunsInPlace(t *testing.T) {
+	h, _, directExec := newMacroTestHandler()
+
+	_ = h.HandleMacroPlaybackEvent(domain.MacroPlaybackEvent{Name: "outer", Steps: []string{"!one", "!three"}})
+	_ = h.HandleMacroStepEvent(domain.MacroStepEvent{})
+
+	if cmd := h.HandleMacroPlaybackEvent(domain.MacroPlaybackEvent{Name: "inner", Steps: []string{"!two"}}); cmd != nil {
+		t.Error("expected a nested macro to reuse the running step timer")
+	}
+	_ = h.HandleMacroStepEvent(domain.MacroStepEvent{})
+	_ = h.HandleMacroStepEvent(domain.MacroStepEvent{})
+
+	var got []string
+	for i := range directExec.HandleBashCommandCallCount() {
+		got = append(got, directExec.HandleBashCommandArgsForCall(i))
+	}
+	want := []string{"!one", "!two", "!three"}
+	if len(got) != len(want) {
+		t.Fatalf("expected steps %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected steps %v, got %v", want, got)
+		}
+	}
+}
+
+func TestHandleMacroStepEvent_StopsRunawayMacro(t *testing.T) {
+	h, _, directExec := newMacroTestHandler()
+
+	_ = h.HandleMacroPlaybackEvent(domain.MacroPlaybackEvent{Name: "loop", Steps: []string{"!true"}})
+	for range maxMacroSteps {
+		_ = h.HandleMacroStepEvent(domain.MacroStepEvent{})
+		_ = h.HandleMacroPlaybackEvent(domain.MacroPlaybackEvent{Name: "loop", Steps: []string{"!true"}})
+	}
+
+	msg := h.HandleMacroStepEvent(domain.MacroStepEvent{})()
+	if _, ok := msg.(domain.ShowErrorEvent); !ok {
+		t.Fatalf("expected an error once the step limit is hit, got %#v", msg)
+	}
+	if got := directExec.HandleBashCommandCallCount(); got != maxMacroSteps {
+		t.Errorf("expected %d steps to run, got %d", maxMacroSteps, got)
+	}
+}
@@ -330,6 +330,31 @@ func (p *ChatMessageProcessor) processChatMessage(
 	return p.appendUserMessageAndStartCompletion(message, images)
 }
 
+// checkpointThenContinue snapshots the workspace before the turn starts, so
+// /rewind can return the files and conversation to this point. Staging a
+// large repository takes a while, so the snapshot runs as a tea.Cmd and a
+// CheckpointCreatedEvent starts the turn once it is done. A failed snapshot
+// only costs this turn's checkpoint.
+func (p *ChatMessageProcessor) checkpointThenContinue(message sdk.Message, images []domain.ImageAttachment) tea.Cmd {
+	p.handler.stateManager.SetChatPending()
+
+	store := p.handler.checkpoints
+	prompt, _ := message.Content.AsMessageContent0()
+	repo := p.handler.conversationRepo
+	conversationID := repo.GetCurrentConversationID()
+	messageCount := repo.GetMessageCount()
+
+	return func() tea.Msg {
+		if _, err := store.Create(context.Background(), conversationID, messageCount, prompt); err != nil {
+			logger.Warn("chat: failed to checkpoint the workspace", "error", err)
+		}
+		return domain.CheckpointCreatedEvent{
+			Message: message,
+			Images:  images,
+		}
+	}
+}
+
 // shouldRolloverNow is a cheap pre-check on the synchronous Update path so
 // that the vast majority of user messages (where no rollover is due) skip
 // the async dispatch entirely. The real ShouldRollover/PerformRollover run
@@ -384,12 +409,21 @@ func (p *ChatMessageProcessor) compactThenContinue(message sdk.Message, images [
 	return tea.Batch(statusCmd, rolloverCmd)
 }
 
-// appendUserMessageAndStartCompletion is the synchronous tail of
-// processChatMessage. It persists the user message, fires the history
-// refresh and optional optimization status, and kicks off the chat
-// completion. Called directly when no rollover is due, and via
-// HandleRolloverCompletedEvent after an async rollover finishes.
+// appendUserMessageAndStartCompletion is the tail of processChatMessage.
+// Called directly when no rollover is due, and via
+// HandleRolloverCompletedEvent after an async rollover finishes. With
+// checkpoints enabled the turn waits for the workspace snapshot first.
 func (p *ChatMessageProcessor) appendUserMessageAndStartCompletion(message sdk.Message, images []domain.ImageAttachment) tea.Cmd {
+	if p.handler.checkpoints != nil {
+		return p.checkpointThenContinue(message, images)
+	}
+	return p.addUserMessageAndStartCompletion(message, images)
+}
+
+// addUserMessageAndStartCompletion persists the user message, fires the
+// history refresh and optional optimization status, and kicks off the chat
+// completion.
+func (p *ChatMessageProcessor) addUserMessageAndStartCompletion(message sdk.Message, images []domain.ImageAttachment) tea.Cmd {
 	userEntry := domain.ConversationEntry{
 		Message: message,
 		Time:    time.Now(),
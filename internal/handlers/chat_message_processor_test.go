@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"os/exec"
 	"strings"
 	"testing"
 	"time"
@@ -21,6 +22,7 @@ import (
 	storage "github.com/inference-gateway/cli/internal/infra/storage"
 	models "github.com/inference-gateway/cli/internal/models"
 	services "github.com/inference-gateway/cli/internal/services"
+	checkpoint "github.com/inference-gateway/cli/internal/services/checkpoint"
 	shortcuts "github.com/inference-gateway/cli/internal/shortcuts"
 )
 
@@ -137,6 +139,7 @@ func TestChatMessageProcessor_handleUserInput(t *testing.T) {
 				fakeRunner,
 				fakeDirect,
 				nil, // toolCoordinator
+				nil, // checkpoints
 			)
 
 			processor := NewChatMessageProcessor(handler)
@@ -621,3 +624,39 @@ func TestChatMessageProcessor_isSkillInvocation_NilService(t *testing.T) {
 	p := NewChatMessageProcessor(&ChatHandler{})
 	require.False(t, p.isSkillInvocation("/maintainer"))
 }
+
+func TestChatMessageProcessor_CheckpointsBeforeTurn(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed; skipping git-backed test")
+	}
+	dir := t.TempDir()
+	out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput()
+	require.NoError(t, err, string(out))
+	store := checkpoint.New(dir)
+	require.NotNil(t, store)
+
+	conversationRepo := services.NewInMemoryConversationRepository(nil, nil)
+	fakeRunner := &mocks.FakeChatCompletionRunner{}
+	fakeRunner.StartReturns(func() tea.Msg { return nil })
+	handler := &ChatHandler{
+		conversationRepo: conversationRepo,
+		stateManager:     services.NewStateManager(false),
+		messageQueue:     services.NewMessageQueueService(),
+		completionRunner: fakeRunner,
+		checkpoints:      store,
+	}
+	handler.messageProcessor = NewChatMessageProcessor(handler)
+
+	message := sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("hello")}
+	cmd := handler.messageProcessor.appendUserMessageAndStartCompletion(message, nil)
+	require.NotNil(t, cmd)
+	assert.Equal(t, 0, conversationRepo.GetMessageCount(), "the turn should wait for its checkpoint")
+
+	event, ok := cmd().(domain.CheckpointCreatedEvent)
+	require.True(t, ok)
+	assert.Len(t, store.List(conversationRepo.GetCurrentConversationID()), 1)
+
+	require.NotNil(t, handler.Handle(event))
+	assert.Equal(t, 1, conversationRepo.GetMessageCount())
+	assert.Equal(t, 1, fakeRunner.StartCallCount())
+}
@@ -10,7 +10,9 @@ import (
 	domain "github.com/inference-gateway/cli/internal/domain"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	services "github.com/inference-gateway/cli/internal/services"
+	checkpoint "github.com/inference-gateway/cli/internal/services/checkpoint"
 	gitdiff "github.com/inference-gateway/cli/internal/services/gitdiff"
+	prompttemplates "github.com/inference-gateway/cli/internal/services/prompttemplates"
 	shortcuts "github.com/inference-gateway/cli/internal/shortcuts"
 	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
 	sdk "github.com/inference-gateway/sdk"
@@ -53,6 +55,7 @@ func (s *ChatShortcutHandler) executeShortcut(
 			}
 
 			s.handler.stateManager.SetTodos([]domain.TodoItem{})
+			s.handler.stateManager.SetPlanSteps(nil)
 
 			return tea.Batch(
 				func() tea.Msg {
@@ -65,6 +68,9 @@ func (s *ChatShortcutHandler) executeShortcut(
 						Todos: []domain.TodoItem{},
 					}
 				},
+				func() tea.Msg {
+					return domain.PlanStepsUpdateEvent{}
+				},
 				func() tea.Msg {
 					return domain.SetStatusEvent{
 						Message:    "Conversation cleared",
@@ -227,6 +233,16 @@ func (s *ChatShortcutHandler) handleShortcutSideEffect(sideEffect shortcuts.Side
 		return s.handleEmbedImagesSideEffect(data)
 	case shortcuts.SideEffectSendMessageWithModel:
 		return s.handleSendMessageWithModelSideEffect(data)
+	case shortcuts.SideEffectRunMacro:
+		return s.handleRunMacroSideEffect(data)
+	case shortcuts.SideEffectLoadConversation:
+		return s.handleLoadConversationSideEffect(data)
+	case shortcuts.SideEffectSendPrompt:
+		return s.handleSendPromptSideEffect(data)
+	case shortcuts.SideEffectRewindConversation:
+		return s.handleRewindConversationSideEffect(data)
+	case shortcuts.SideEffectStartFromTemplate:
+		return s.handleStartFromTemplateSideEffect(data)
 	default:
 		return domain.SetStatusEvent{
 			Message:    "Shortcut completed",
@@ -382,6 +398,65 @@ func (s *ChatShortcutHandler) handleStartNewConversationSideEffect(data any) tea
 	)()
 }
 
+// handleStartFromTemplateSideEffect starts a new conversation seeded from a
+// conversation template: the context is added as a pinned hidden message, the
+// template's model selected and its first message put in the input
+func (s *ChatShortcutHandler) handleStartFromTemplateSideEffect(data any) tea.Msg {
+	seed, ok := data.(prompttemplates.ConversationSeed)
+	if !ok {
+		return domain.SetStatusEvent{
+			Message:    "Invalid conversation template data",
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+
+	if err := s.handler.conversationRepo.StartNewConversation(seed.Name); err != nil {
+		return domain.SetStatusEvent{
+			Message:    fmt.Sprintf("Failed to start new conversation: %v", err),
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+
+	if entry, ok := seed.ContextEntry(); ok {
+		if err := s.handler.conversationRepo.AddMessage(entry); err != nil {
+			logger.Error("failed to add conversation template context", "template", seed.Name, "error", err)
+		}
+	}
+
+	status := fmt.Sprintf("• Started new conversation from template: %s", seed.Name)
+	if seed.Model != "" {
+		if err := s.handler.modelService.SelectModel(seed.Model); err != nil {
+			logger.Error("failed to select conversation template model", "model", seed.Model, "error", err)
+			status = fmt.Sprintf("%s (model '%s' unavailable: %v)", status, seed.Model, err)
+		}
+	}
+
+	return tea.Batch(
+		func() tea.Msg {
+			return domain.UpdateHistoryEvent{
+				History: s.handler.conversationRepo.GetMessages(),
+			}
+		},
+		func() tea.Msg {
+			return domain.TodoUpdateEvent{
+				Todos: nil,
+			}
+		},
+		func() tea.Msg {
+			return domain.SetInputEvent{Text: seed.Message}
+		},
+		func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    status,
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		},
+	)()
+}
+
 func (s *ChatShortcutHandler) handleShowA2ATaskManagementSideEffect() tea.Msg {
 	if err := s.handler.stateManager.TransitionToView(domain.ViewStateA2ATaskManagement); err != nil {
 		logger.Error("failed to transition to task management view", "error", err)
@@ -479,6 +554,66 @@ func (s *ChatShortcutHandler) handleSetInputSideEffect(data any) tea.Msg {
 	)()
 }
 
+// handleRunMacroSideEffect hands a macro's steps to the orchestrator, which
+// replays them on the Update loop as the agent becomes idle.
+func (s *ChatShortcutHandler) handleRunMacroSideEffect(data any) tea.Msg {
+	macro, ok := data.(shortcuts.MacroData)
+	if !ok || len(macro.Steps) == 0 {
+		return domain.SetStatusEvent{
+			Message:    "Invalid macro data",
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+
+	return domain.MacroPlaybackEvent{Name: macro.Name, Steps: macro.Steps}
+}
+
+// handleLoadConversationSideEffect loads the saved conversation whose ID is
+// data, as if it had been picked in the conversation selector
+// handleRewindConversationSideEffect refreshes the history after /rewind and
+// puts the rewound turn's prompt back in the input to edit or resend
+func (s *ChatShortcutHandler) handleRewindConversationSideEffect(data any) tea.Msg {
+	cp, ok := data.(checkpoint.Checkpoint)
+	if !ok {
+		return domain.SetStatusEvent{
+			Message:    "Invalid checkpoint data",
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+
+	return tea.Batch(
+		func() tea.Msg {
+			return domain.UpdateHistoryEvent{
+				History: s.handler.conversationRepo.GetMessages(),
+			}
+		},
+		func() tea.Msg {
+			return domain.SetInputEvent{Text: cp.Prompt}
+		},
+		func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    fmt.Sprintf("Rewound files and conversation to before turn %d", cp.Turn),
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		},
+	)()
+}
+
+func (s *ChatShortcutHandler) handleLoadConversationSideEffect(data any) tea.Msg {
+	conversationID, ok := data.(string)
+	if !ok || conversationID == "" {
+		return domain.SetStatusEvent{
+			Message:    "Invalid conversation data",
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+	return domain.ConversationSelectedEvent{ConversationID: conversationID}
+}
+
 func (s *ChatShortcutHandler) handleGenerateSnippetSideEffect(data any) tea.Msg {
 	return tea.Batch(
 		func() tea.Msg {
@@ -799,3 +934,71 @@ func (s *ChatShortcutHandler) handleSendMessageWithModelSideEffect(data any) tea
 		s.handler.startChatCompletion(),
 	)()
 }
+
+// handleSendPromptSideEffect sends a markdown command's rendered prompt as the
+// user's message, on the command's model and tools for this one completion
+func (s *ChatShortcutHandler) handleSendPromptSideEffect(data any) tea.Msg {
+	prompt, ok := data.(shortcuts.PromptData)
+	if !ok || prompt.Prompt == "" {
+		return domain.SetStatusEvent{
+			Message:    "Invalid prompt data",
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+
+	originalModel := s.handler.modelService.GetCurrentModel()
+	if prompt.Model != "" && prompt.Model != originalModel {
+		if err := s.handler.modelService.SelectModel(prompt.Model); err != nil {
+			return domain.SetStatusEvent{
+				Message:    fmt.Sprintf("Failed to switch to model '%s': %v", prompt.Model, err),
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		}
+		if s.handler.completionRunner != nil && originalModel != "" {
+			s.handler.completionRunner.SetPendingRestoration(originalModel)
+		}
+	}
+
+	userEntry := domain.ConversationEntry{
+		Message: sdk.Message{
+			Role:    sdk.User,
+			Content: sdk.NewMessageContent(prompt.Prompt),
+		},
+		Time: time.Now(),
+	}
+	if err := s.handler.conversationRepo.AddMessage(userEntry); err != nil {
+		logger.Error("failed to add message to conversation", "error", err)
+		if s.handler.modelService.GetCurrentModel() != originalModel && originalModel != "" {
+			if restoreErr := s.handler.modelService.SelectModel(originalModel); restoreErr != nil {
+				logger.Error("failed to restore original model", "model", originalModel, "error", restoreErr)
+			}
+		}
+		return domain.SetStatusEvent{
+			Message:    fmt.Sprintf("Failed to add message: %v", err),
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+
+	if s.handler.completionRunner != nil && len(prompt.Tools) > 0 {
+		s.handler.completionRunner.SetNextRequestTools(prompt.Tools)
+	}
+
+	return tea.Batch(
+		func() tea.Msg {
+			return domain.UpdateHistoryEvent{
+				History: s.handler.conversationRepo.GetMessages(),
+			}
+		},
+		func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    "Starting response...",
+				Spinner:    true,
+				StatusType: domain.StatusPreparing,
+			}
+		},
+		s.handler.startChatCompletion(),
+	)()
+}
@@ -1,20 +1,43 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+	uuid "github.com/google/uuid"
 	domain "github.com/inference-gateway/cli/internal/domain"
 	formatting "github.com/inference-gateway/cli/internal/formatting"
 	logger "github.com/inference-gateway/cli/internal/logger"
+	services "github.com/inference-gateway/cli/internal/services"
 	sdk "github.com/inference-gateway/sdk"
 )
 
 // MessageHistoryHandler handles message history navigation and restoration
 type MessageHistoryHandler struct {
 	conversationRepo domain.ConversationRepository
+
+	// discarded is the branch most recently cut off by an edit, kept so the
+	// user can swap back to it. Guarded by discardedMux because recovery runs
+	// in a tea.Cmd goroutine.
+	discarded    *discardedBranch
+	discardedMux sync.Mutex
+}
+
+// discardedBranch is the tail of a conversation, from fromIndex on, that an
+// edit truncated.
+type discardedBranch struct {
+	conversationID string
+	fromIndex      int
+	entries        []domain.ConversationEntry
+}
+
+// belongsTo reports whether the branch can be swapped into the conversation
+func (b *discardedBranch) belongsTo(conversationID string) bool {
+	return b != nil && b.conversationID == conversationID
 }
 
 // NewMessageHistoryHandler creates a new message history handler
@@ -105,6 +128,162 @@ func (h *MessageHistoryHandler) HandleEditSubmit(event domain.MessageEditSubmitE
 	}
 }
 
+// DiscardBranch deletes entries[fromIndex:] for an edit, keeping them so
+// HandleRecoverBranch can swap them back in. Only the most recent branch is
+// kept; an empty tail leaves the stash alone.
+//
+// Editing the first message clears the conversation, which would only get
+// its new ID once the edited message is added. The ID is assigned up front
+// instead, so the branch is bound to the edited conversation and not to one
+// loaded before the edit is sent.
+func (h *MessageHistoryHandler) DiscardBranch(entries []domain.ConversationEntry, fromIndex int) error {
+	if err := h.truncateFrom(fromIndex); err != nil {
+		return err
+	}
+	if fromIndex < 0 || fromIndex >= len(entries) {
+		return nil
+	}
+
+	if persistentRepo, ok := h.conversationRepo.(*services.PersistentConversationRepository); ok && persistentRepo.GetCurrentConversationID() == "" {
+		persistentRepo.SetConversationID(uuid.New().String())
+	}
+
+	h.discardedMux.Lock()
+	defer h.discardedMux.Unlock()
+	h.discarded = &discardedBranch{
+		conversationID: h.conversationRepo.GetCurrentConversationID(),
+		fromIndex:      fromIndex,
+		entries:        append([]domain.ConversationEntry(nil), entries[fromIndex:]...),
+	}
+	return nil
+}
+
+// HasDiscardedBranch reports whether there is a branch to recover in the
+// current conversation.
+func (h *MessageHistoryHandler) HasDiscardedBranch() bool {
+	h.discardedMux.Lock()
+	defer h.discardedMux.Unlock()
+	return h.discarded.belongsTo(h.conversationRepo.GetCurrentConversationID())
+}
+
+// HandleRecoverBranch swaps the discarded branch back in. The branch it
+// replaces is stashed in turn, so recovering twice returns to the edit.
+func (h *MessageHistoryHandler) HandleRecoverBranch() tea.Cmd {
+	return func() tea.Msg {
+		h.discardedMux.Lock()
+		defer h.discardedMux.Unlock()
+
+		branch := h.discarded
+		entries := h.conversationRepo.GetMessages()
+		if !branch.belongsTo(h.conversationRepo.GetCurrentConversationID()) || branch.fromIndex > len(entries) {
+			return domain.ShowErrorEvent{Error: "No discarded branch to recover in this conversation"}
+		}
+
+		current := append([]domain.ConversationEntry(nil), entries[branch.fromIndex:]...)
+		if err := h.truncateFrom(branch.fromIndex); err != nil {
+			logger.Error("failed to truncate conversation for branch recovery", "error", err, "index", branch.fromIndex)
+			return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to recover branch: %v", err)}
+		}
+		for _, entry := range branch.entries {
+			if err := h.conversationRepo.AddMessage(entry); err != nil {
+				logger.Error("failed to restore discarded message", "error", err)
+				return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to recover branch: %v", err)}
+			}
+		}
+
+		h.discarded = nil
+		if len(current) > 0 {
+			h.discarded = &discardedBranch{
+				conversationID: h.conversationRepo.GetCurrentConversationID(),
+				fromIndex:      branch.fromIndex,
+				entries:        current,
+			}
+		}
+
+		return domain.UpdateHistoryEvent{
+			History: h.conversationRepo.GetMessages(),
+		}
+	}
+}
+
+// HandleFork copies the conversation up to and including the entry at
+// messageIndex (plus the tool responses of a tool-calling turn) into a new
+// conversation and switches to it. The original conversation is kept as is.
+func (h *MessageHistoryHandler) HandleFork(messageIndex int) tea.Cmd {
+	persistentRepo, ok := h.conversationRepo.(*services.PersistentConversationRepository)
+	if !ok {
+		return func() tea.Msg {
+			return domain.ShowErrorEvent{Error: "Forking a conversation requires persistent storage"}
+		}
+	}
+
+	entries := h.conversationRepo.GetMessages()
+	forkIndex := h.adjustRestoreIndex(entries, messageIndex)
+
+	if _, err := persistentRepo.ForkConversation(context.Background(), forkIndex); err != nil {
+		logger.Error("failed to fork conversation", "error", err, "index", forkIndex)
+		return func() tea.Msg {
+			return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to fork conversation: %v", err)}
+		}
+	}
+
+	return tea.Batch(
+		func() tea.Msg {
+			return domain.UpdateHistoryEvent{History: h.conversationRepo.GetMessages()}
+		},
+		func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    fmt.Sprintf("Forked into new conversation: %s", persistentRepo.GetCurrentConversationTitle()),
+				StatusType: domain.StatusDefault,
+			}
+		},
+	)
+}
+
+// HandleTogglePin pins the entry at messageIndex, or unpins it if it is
+// already pinned.
+func (h *MessageHistoryHandler) HandleTogglePin(messageIndex int) tea.Cmd {
+	return func() tea.Msg {
+		pinner, ok := h.conversationRepo.(domain.MessagePinner)
+		if !ok {
+			return domain.ShowErrorEvent{Error: "Pinning messages is not supported by this conversation store"}
+		}
+
+		entries := h.conversationRepo.GetMessages()
+		if messageIndex < 0 || messageIndex >= len(entries) {
+			return domain.ShowErrorEvent{Error: fmt.Sprintf("Invalid message index: %d", messageIndex)}
+		}
+
+		pinned := !entries[messageIndex].Pinned
+		if err := pinner.SetMessagePinned(messageIndex, pinned); err != nil {
+			logger.Error("failed to pin message", "error", err, "index", messageIndex)
+			return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to pin message: %v", err)}
+		}
+
+		status := "Message pinned - it will be kept verbatim when the conversation is compacted"
+		if !pinned {
+			status = "Message unpinned"
+		}
+
+		return tea.Batch(
+			func() tea.Msg {
+				return domain.UpdateHistoryEvent{History: h.conversationRepo.GetMessages()}
+			},
+			func() tea.Msg {
+				return domain.SetStatusEvent{Message: status, StatusType: domain.StatusDefault}
+			},
+		)()
+	}
+}
+
+// truncateFrom deletes every entry at or after index.
+func (h *MessageHistoryHandler) truncateFrom(index int) error {
+	if index == 0 {
+		return h.conversationRepo.Clear()
+	}
+	return h.conversationRepo.DeleteMessagesAfterIndex(index - 1)
+}
+
 // adjustRestoreIndex adjusts the restore index based on message role and tool calls
 func (h *MessageHistoryHandler) adjustRestoreIndex(entries []domain.ConversationEntry, restoreIndex int) int {
 	if restoreIndex >= len(entries) {
@@ -167,6 +346,7 @@ func (h *MessageHistoryHandler) extractMessages(entries []domain.ConversationEnt
 			Content:      content,
 			Timestamp:    entry.Time,
 			TruncatedMsg: truncated,
+			Pinned:       entry.Pinned,
 		}
 		messages = append(messages, message)
 	}
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
 	services "github.com/inference-gateway/cli/internal/services"
 	sdk "github.com/inference-gateway/sdk"
 )
@@ -117,3 +119,135 @@ func TestMessageHistoryHandler_HandleEditSubmit_MiddleMessage(t *testing.T) {
 		t.Errorf("Expected %d messages (deletion happens in app layer), got %d", expectedCount, len(remainingMessages))
 	}
 }
+
+func TestMessageHistoryHandler_RecoverDiscardedBranch(t *testing.T) {
+	repo := services.NewInMemoryConversationRepository(nil, nil)
+	handler := NewMessageHistoryHandler(repo)
+
+	for _, m := range []struct {
+		role    sdk.MessageRole
+		content string
+	}{
+		{sdk.User, "first"},
+		{sdk.Assistant, "first reply"},
+		{sdk.User, "original question"},
+		{sdk.Assistant, "original answer"},
+	} {
+		if err := repo.AddMessage(domain.ConversationEntry{
+			Time:    time.Now(),
+			Message: sdk.Message{Role: m.role, Content: sdk.NewMessageContent(m.content)},
+		}); err != nil {
+			t.Fatalf("Failed to add message: %v", err)
+		}
+	}
+
+	if handler.HasDiscardedBranch() {
+		t.Fatal("expected no discarded branch before an edit")
+	}
+
+	if err := handler.DiscardBranch(repo.GetMessages(), 2); err != nil {
+		t.Fatalf("Failed to discard branch: %v", err)
+	}
+	for _, msg := range []sdk.Message{
+		{Role: sdk.User, Content: sdk.NewMessageContent("edited question")},
+		{Role: sdk.Assistant, Content: sdk.NewMessageContent("edited answer")},
+	} {
+		if err := repo.AddMessage(domain.ConversationEntry{Message: msg}); err != nil {
+			t.Fatalf("Failed to add message: %v", err)
+		}
+	}
+
+	if !handler.HasDiscardedBranch() {
+		t.Fatal("expected the truncated branch to be kept")
+	}
+
+	contents := func() []string {
+		var out []string
+		for _, e := range repo.GetMessages() {
+			c, _ := e.Message.Content.AsMessageContent0()
+			out = append(out, c)
+		}
+		return out
+	}
+
+	if _, ok := handler.HandleRecoverBranch()().(domain.UpdateHistoryEvent); !ok {
+		t.Fatal("expected an UpdateHistoryEvent after recovery")
+	}
+	got := contents()
+	if len(got) != 4 || got[2] != "original question" || got[3] != "original answer" {
+		t.Fatalf("expected the original branch back, got %v", got)
+	}
+
+	handler.HandleRecoverBranch()()
+	got = contents()
+	if len(got) != 4 || got[2] != "edited question" || got[3] != "edited answer" {
+		t.Fatalf("recovering again should swap back to the edit, got %v", got)
+	}
+}
+
+func TestMessageHistoryHandler_RecoverBranchOnlyInItsConversation(t *testing.T) {
+	storageBackend, err := storage.NewSQLiteStorage(storage.SQLiteConfig{Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	repo := services.NewPersistentConversationRepository(&services.ToolFormatterService{}, nil, storageBackend)
+	handler := NewMessageHistoryHandler(repo)
+
+	addUser := func(content string) {
+		t.Helper()
+		if err := repo.AddMessage(domain.ConversationEntry{
+			Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(content)},
+		}); err != nil {
+			t.Fatalf("Failed to add message: %v", err)
+		}
+	}
+
+	addUser("other conversation")
+	otherID := repo.GetCurrentConversationID()
+	if err := repo.StartNewConversation("edited"); err != nil {
+		t.Fatalf("Failed to start conversation: %v", err)
+	}
+	addUser("original first message")
+
+	if err := handler.DiscardBranch(repo.GetMessages(), 0); err != nil {
+		t.Fatalf("Failed to discard branch: %v", err)
+	}
+	editedID := repo.GetCurrentConversationID()
+	if editedID == "" {
+		t.Fatal("expected the edited conversation to keep an ID")
+	}
+	if !handler.HasDiscardedBranch() {
+		t.Fatal("expected the branch to be recoverable in the edited conversation")
+	}
+
+	if err := repo.LoadConversation(context.Background(), otherID); err != nil {
+		t.Fatalf("Failed to load conversation: %v", err)
+	}
+	if handler.HasDiscardedBranch() {
+		t.Fatal("a branch cut at the first message must not be offered in another conversation")
+	}
+	if _, ok := handler.HandleRecoverBranch()().(domain.ShowErrorEvent); !ok {
+		t.Fatal("expected recovery to be refused in another conversation")
+	}
+	if got := repo.GetMessages(); len(got) != 1 {
+		t.Fatalf("recovery changed the loaded conversation: %d entries", len(got))
+	}
+}
+
+func TestMessageHistoryHandler_ForkRequiresPersistentStorage(t *testing.T) {
+	repo := services.NewInMemoryConversationRepository(nil, nil)
+	handler := NewMessageHistoryHandler(repo)
+
+	if err := repo.AddMessage(domain.ConversationEntry{
+		Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("hello")},
+	}); err != nil {
+		t.Fatalf("Failed to add message: %v", err)
+	}
+
+	if _, ok := handler.HandleFork(0)().(domain.ShowErrorEvent); !ok {
+		t.Fatal("expected an error event without persistent storage")
+	}
+	if repo.GetMessageCount() != 1 {
+		t.Errorf("the conversation must be left untouched, got %d messages", repo.GetMessageCount())
+	}
+}
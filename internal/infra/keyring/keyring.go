@@ -0,0 +1,256 @@
+// Package keyring stores secrets in the operating system's credential store:
+// the macOS Keychain (security CLI), the Secret Service on Linux (secret-tool
+// from libsecret) and DPAPI-encrypted files on Windows (PowerShell). Like the
+// git and gh integrations it shells out to the platform tools rather than
+// linking a native library, so the binary stays cgo-free.
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Service is the service name every secret is stored under
+const Service = "infer"
+
+// commandTimeout bounds a single keyring call. Unlocking a Secret Service
+// collection can show a prompt, so it is generous.
+const commandTimeout = 60 * time.Second
+
+var (
+	// ErrNotFound is returned by Get and Delete when no secret is stored
+	ErrNotFound = errors.New("not found in the keyring")
+
+	// ErrUnsupported is returned on platforms without a supported keyring
+	ErrUnsupported = fmt.Errorf("no supported keyring on %s", runtime.GOOS)
+
+	accountPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// Store reads and writes secrets by account name
+type Store interface {
+	Set(account, secret string) error
+	Get(account string) (string, error)
+	Delete(account string) error
+	// Name describes the backend, e.g. "macOS Keychain"
+	Name() string
+}
+
+type runnerFunc func(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error)
+
+// New returns the keyring of the current platform
+func New() Store {
+	switch runtime.GOOS {
+	case "darwin":
+		return &keychain{run: runCommand}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return &secretService{run: runCommand}
+	case "windows":
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return unsupported{}
+		}
+		return &dpapi{run: runCommand, dir: filepath.Join(dir, "infer", "credentials")}
+	default:
+		return unsupported{}
+	}
+}
+
+func runCommand(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return out, fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return out, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
+
+func validateAccount(account string) error {
+	if !accountPattern.MatchString(account) {
+		return fmt.Errorf("invalid keyring account %q", account)
+	}
+	return nil
+}
+
+// exitCode returns the exit status of a failed command, or -1
+func exitCode(err error) int {
+	if exitErr, ok := errors.AsType[*exec.ExitError](err); ok {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// keychain stores secrets as generic passwords in the login keychain. The
+// add command goes through `security -i` on stdin, with the secret
+// hex-encoded, so it never appears in the process list.
+type keychain struct {
+	run runnerFunc
+}
+
+// keychainNotFound is the exit status of security when no item matches
+const keychainNotFound = 44
+
+func (k *keychain) Name() string { return "macOS Keychain" }
+
+func (k *keychain) Set(account, secret string) error {
+	if err := validateAccount(account); err != nil {
+		return err
+	}
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", Service, account, hex.EncodeToString([]byte(secret)))
+	_, err := k.run(context.Background(), []byte(command), "security", "-i")
+	return err
+}
+
+func (k *keychain) Get(account string) (string, error) {
+	if err := validateAccount(account); err != nil {
+		return "", err
+	}
+	out, err := k.run(context.Background(), nil, "security", "find-generic-password", "-s", Service, "-a", account, "-w")
+	if exitCode(err) == keychainNotFound {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func (k *keychain) Delete(account string) error {
+	if err := validateAccount(account); err != nil {
+		return err
+	}
+	_, err := k.run(context.Background(), nil, "security", "delete-generic-password", "-s", Service, "-a", account)
+	if exitCode(err) == keychainNotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+// secretService stores secrets through secret-tool, which reads the secret
+// from stdin. Lookups of a missing secret exit 1 with no output.
+type secretService struct {
+	run runnerFunc
+}
+
+func (s *secretService) Name() string { return "Secret Service" }
+
+func (s *secretService) Set(account, secret string) error {
+	if err := validateAccount(account); err != nil {
+		return err
+	}
+	_, err := s.run(context.Background(), []byte(secret), "secret-tool", "store",
+		"--label", Service+" "+account, "service", Service, "account", account)
+	return err
+}
+
+func (s *secretService) Get(account string) (string, error) {
+	if err := validateAccount(account); err != nil {
+		return "", err
+	}
+	out, err := s.run(context.Background(), nil, "secret-tool", "lookup", "service", Service, "account", account)
+	if err != nil && len(out) == 0 && exitCode(err) == 1 {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (s *secretService) Delete(account string) error {
+	if _, err := s.Get(account); err != nil {
+		return err
+	}
+	_, err := s.run(context.Background(), nil, "secret-tool", "clear", "service", Service, "account", account)
+	return err
+}
+
+// dpapi encrypts each secret for the current Windows user with DPAPI
+// (ConvertFrom-SecureString without a key) and keeps the blob in dir
+type dpapi struct {
+	run runnerFunc
+	dir string
+}
+
+const (
+	dpapiEncryptScript = `$s = [Console]::In.ReadToEnd(); ConvertTo-SecureString $s -AsPlainText -Force | ConvertFrom-SecureString`
+	dpapiDecryptScript = `$s = ConvertTo-SecureString ([Console]::In.ReadToEnd().Trim()); ` +
+		`[Runtime.InteropServices.Marshal]::PtrToStringBSTR([Runtime.InteropServices.Marshal]::SecureStringToBSTR($s))`
+)
+
+func (d *dpapi) Name() string { return "Windows DPAPI" }
+
+func (d *dpapi) path(account string) string { return filepath.Join(d.dir, account) }
+
+func (d *dpapi) powershell(stdin []byte, script string) ([]byte, error) {
+	return d.run(context.Background(), stdin, "powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+}
+
+func (d *dpapi) Set(account, secret string) error {
+	if err := validateAccount(account); err != nil {
+		return err
+	}
+	blob, err := d.powershell([]byte(secret), dpapiEncryptScript)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create the credentials directory: %w", err)
+	}
+	return os.WriteFile(d.path(account), bytes.TrimSpace(blob), 0600)
+}
+
+func (d *dpapi) Get(account string) (string, error) {
+	if err := validateAccount(account); err != nil {
+		return "", err
+	}
+	blob, err := os.ReadFile(d.path(account))
+	if os.IsNotExist(err) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	out, err := d.powershell(blob, dpapiDecryptScript)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+func (d *dpapi) Delete(account string) error {
+	if err := validateAccount(account); err != nil {
+		return err
+	}
+	err := os.Remove(d.path(account))
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+type unsupported struct{}
+
+func (unsupported) Name() string               { return "none" }
+func (unsupported) Set(string, string) error   { return ErrUnsupported }
+func (unsupported) Get(string) (string, error) { return "", ErrUnsupported }
+func (unsupported) Delete(string) error        { return ErrUnsupported }
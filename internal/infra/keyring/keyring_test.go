@@ -0,0 +1,92 @@
+package keyring
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	require "github.com/stretchr/testify/require"
+)
+
+// fakeRunner records each call and answers with a canned output
+type fakeRunner struct {
+	calls  [][]string
+	stdins []string
+	out    func(name string, args []string, stdin []byte) ([]byte, error)
+}
+
+func (f *fakeRunner) run(_ context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, append([]string{name}, args...))
+	f.stdins = append(f.stdins, string(stdin))
+	if f.out == nil {
+		return nil, nil
+	}
+	return f.out(name, args, stdin)
+}
+
+func TestKeychain(t *testing.T) {
+	f := &fakeRunner{out: func(_ string, args []string, _ []byte) ([]byte, error) {
+		if args[0] == "find-generic-password" {
+			return []byte("s3cret\n"), nil
+		}
+		return nil, nil
+	}}
+	k := &keychain{run: f.run}
+
+	require.NoError(t, k.Set("OPENAI_API_KEY", "s3cret"))
+	require.Equal(t, []string{"security", "-i"}, f.calls[0])
+	require.NotContains(t, f.stdins[0], "s3cret", "the secret must not be passed in clear")
+	require.Contains(t, f.stdins[0], "-a OPENAI_API_KEY -X 733363726574")
+
+	secret, err := k.Get("OPENAI_API_KEY")
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", secret)
+
+	require.Error(t, k.Set("bad account; rm -rf", "x"))
+}
+
+func TestSecretService(t *testing.T) {
+	f := &fakeRunner{out: func(_ string, args []string, _ []byte) ([]byte, error) {
+		if args[0] == "lookup" {
+			return []byte("s3cret"), nil
+		}
+		return nil, nil
+	}}
+	s := &secretService{run: f.run}
+
+	require.NoError(t, s.Set("GITHUB_TOKEN", "s3cret"))
+	require.Equal(t, "s3cret", f.stdins[0])
+	require.NotContains(t, strings.Join(f.calls[0], " "), "s3cret")
+
+	secret, err := s.Get("GITHUB_TOKEN")
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", secret)
+
+	require.NoError(t, s.Delete("GITHUB_TOKEN"))
+	require.Equal(t, []string{"secret-tool", "clear", "service", Service, "account", "GITHUB_TOKEN"}, f.calls[len(f.calls)-1])
+}
+
+func TestDPAPI(t *testing.T) {
+	f := &fakeRunner{out: func(_ string, args []string, stdin []byte) ([]byte, error) {
+		switch args[len(args)-1] {
+		case dpapiEncryptScript:
+			return []byte("enc:" + string(stdin) + "\r\n"), nil
+		case dpapiDecryptScript:
+			return []byte(strings.TrimPrefix(string(stdin), "enc:") + "\r\n"), nil
+		}
+		return nil, errors.New("unexpected script")
+	}}
+	d := &dpapi{run: f.run, dir: t.TempDir()}
+
+	_, err := d.Get("INFER_GATEWAY_API_KEY")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, d.Set("INFER_GATEWAY_API_KEY", "s3cret"))
+	secret, err := d.Get("INFER_GATEWAY_API_KEY")
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", secret)
+
+	require.NoError(t, d.Delete("INFER_GATEWAY_API_KEY"))
+	require.ErrorIs(t, d.Delete("INFER_GATEWAY_API_KEY"), ErrNotFound)
+}
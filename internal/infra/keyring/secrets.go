@@ -0,0 +1,65 @@
+package keyring
+
+import (
+	"os"
+	"sort"
+	"sync"
+)
+
+// The credentials read from the keyring at startup, by the environment
+// variable they stand for. They are kept out of the process environment,
+// which the Bash tool and every other subprocess inherit; code that reads a
+// credential looks it up here instead.
+var (
+	secretsMu sync.RWMutex
+	secrets   = map[string]string{}
+)
+
+// SetSecrets replaces the loaded credentials
+func SetSecrets(loaded map[string]string) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets = make(map[string]string, len(loaded))
+	for name, value := range loaded {
+		secrets[name] = value
+	}
+}
+
+// LookupEnv is os.LookupEnv falling back to the loaded credentials, so a
+// variable set in the environment takes precedence over the keyring
+func LookupEnv(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		return value, true
+	}
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	value, ok := secrets[name]
+	return value, ok
+}
+
+// Getenv is os.Getenv falling back to the loaded credentials
+func Getenv(name string) string {
+	value, _ := LookupEnv(name)
+	return value
+}
+
+// ExpandEnv is os.ExpandEnv resolving ${VAR} references with Getenv
+func ExpandEnv(s string) string {
+	return os.Expand(s, Getenv)
+}
+
+// Environ returns the loaded credentials not set in the environment as
+// NAME=value pairs, for a trusted subprocess such as the gateway that needs
+// them. Never pass them to processes the model controls.
+func Environ() []string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	var env []string
+	for name, value := range secrets {
+		if os.Getenv(name) == "" {
+			env = append(env, name+"="+value)
+		}
+	}
+	sort.Strings(env)
+	return env
+}
@@ -0,0 +1,30 @@
+package keyring
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSecrets(t *testing.T) {
+	t.Setenv("INFER_TEST_FROM_ENV", "env")
+	t.Setenv("INFER_TEST_SECRET", "")
+	SetSecrets(map[string]string{"INFER_TEST_SECRET": "s3cret", "INFER_TEST_FROM_ENV": "shadowed"})
+	t.Cleanup(func() { SetSecrets(nil) })
+
+	if got := Getenv("INFER_TEST_SECRET"); got != "s3cret" {
+		t.Errorf("Getenv = %q, want the loaded secret", got)
+	}
+	if got := Getenv("INFER_TEST_FROM_ENV"); got != "env" {
+		t.Errorf("the environment must take precedence, got %q", got)
+	}
+	if got := os.Getenv("INFER_TEST_SECRET"); got != "" {
+		t.Errorf("a secret must stay out of the environment, got %q", got)
+	}
+	if got := ExpandEnv("key=${INFER_TEST_SECRET} env=$INFER_TEST_FROM_ENV"); got != "key=s3cret env=env" {
+		t.Errorf("ExpandEnv = %q", got)
+	}
+	env := Environ()
+	if len(env) != 1 || env[0] != "INFER_TEST_SECRET=s3cret" {
+		t.Errorf("Environ = %v, want only the secret the environment does not set", env)
+	}
+}
@@ -171,6 +171,74 @@ func conformanceConversationManagement(t *testing.T, storage ConversationStorage
 
 		assert.Equal(t, "New Title", loadedMetadata.Title)
 		assert.Equal(t, []string{"updated", "test"}, loadedMetadata.Tags)
+
+		summaries, err := storage.ListConversations(ctx, 100, 0)
+		require.NoError(t, err)
+		for _, summary := range summaries {
+			if summary.ID == conversationID {
+				assert.Equal(t, []string{"updated", "test"}, summary.Tags, "the selector filters on listed tags")
+			}
+		}
+	})
+
+	t.Run("Fork Lineage", func(t *testing.T) {
+		parentID := "test-conversation-parent"
+		require.NoError(t, storage.SaveConversation(ctx, parentID, createTestEntries(), createTestMetadata(parentID)))
+
+		forkID := "test-conversation-fork"
+		metadata := createTestMetadata(forkID)
+		metadata.ParentID = parentID
+		require.NoError(t, storage.SaveConversation(ctx, forkID, createTestEntries()[:1], metadata))
+		require.NoError(t, storage.SaveConversation(ctx, forkID, createTestEntries(), metadata))
+
+		_, loadedMetadata, err := storage.LoadConversation(ctx, forkID)
+		require.NoError(t, err)
+		assert.Equal(t, parentID, loadedMetadata.ParentID)
+
+		summaries, err := storage.ListConversations(ctx, 100, 0)
+		require.NoError(t, err)
+		parents := make(map[string]string)
+		for _, s := range summaries {
+			parents[s.ID] = s.ParentID
+		}
+		assert.Equal(t, parentID, parents[forkID])
+		assert.Empty(t, parents[parentID])
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		conversationID := "test-conversation-archive"
+		metadata := createTestMetadata(conversationID)
+		require.NoError(t, storage.SaveConversation(ctx, conversationID, createTestEntries(), metadata))
+
+		archived := func() (bool, bool) {
+			_, loaded, err := storage.LoadConversation(ctx, conversationID)
+			require.NoError(t, err)
+			summaries, err := storage.ListConversations(ctx, 100, 0)
+			require.NoError(t, err)
+			for _, s := range summaries {
+				if s.ID == conversationID {
+					return loaded.Archived, s.Archived
+				}
+			}
+			t.Fatal("conversation missing from the list")
+			return false, false
+		}
+
+		metadata.Archived = true
+		require.NoError(t, storage.UpdateConversationMetadata(ctx, conversationID, metadata))
+		loaded, listed := archived()
+		assert.True(t, loaded)
+		assert.True(t, listed)
+
+		require.NoError(t, storage.SaveConversation(ctx, conversationID, createTestEntries(), metadata))
+		loaded, _ = archived()
+		assert.True(t, loaded, "saving an archived conversation keeps it archived")
+
+		metadata.Archived = false
+		require.NoError(t, storage.UpdateConversationMetadata(ctx, conversationID, metadata))
+		loaded, listed = archived()
+		assert.False(t, loaded)
+		assert.False(t, listed)
 	})
 }
 
@@ -264,6 +332,12 @@ func conformanceSessionGroups(t *testing.T, s SessionGroupStorage) {
 	assert.Equal(t, "uuid-2", all["channel-telegram-42"].CurrentSessionID)
 	assert.Equal(t, "uuid-3", all["second"].CurrentSessionID)
 	assert.True(t, all["second"].LastRollover.IsZero())
+
+	require.NoError(t, s.DeleteSessionGroup(ctx, "second"))
+	_, ok, err = s.GetSessionGroup(ctx, "second")
+	require.NoError(t, err)
+	assert.False(t, ok, "deleted key must report not-found")
+	require.NoError(t, s.DeleteSessionGroup(ctx, "second"), "deleting a missing key must not fail")
 }
 
 func createTestEntries() []domain.ConversationEntry {
@@ -452,3 +526,33 @@ func conformanceShellHistoryCRUD(t *testing.T, store ShellHistoryStorage) {
 	assert.Equal(t, "ls -la", history[0])
 	assert.Equal(t, "git status", history[1])
 }
+
+// runSpendStorageConformance runs the same behavioural suite against any
+// SpendStorage implementation.
+func runSpendStorageConformance(t *testing.T, newStorage func(t *testing.T) SpendStorage) {
+	t.Helper()
+
+	t.Run("DailySpend", func(t *testing.T) {
+		conformanceDailySpend(t, newStorage(t))
+	})
+}
+
+func conformanceDailySpend(t *testing.T, store SpendStorage) {
+	ctx := context.Background()
+
+	total, err := store.GetDailySpend(ctx, "2026-03-10")
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	require.NoError(t, store.AddDailySpend(ctx, "2026-03-10", 1.25))
+	require.NoError(t, store.AddDailySpend(ctx, "2026-03-10", 0.5))
+	require.NoError(t, store.AddDailySpend(ctx, "2026-03-11", 4))
+
+	total, err = store.GetDailySpend(ctx, "2026-03-10")
+	require.NoError(t, err)
+	assert.InDelta(t, 1.75, total, 1e-9)
+
+	total, err = store.GetDailySpend(ctx, "2026-03-11")
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0, total, 1e-9)
+}
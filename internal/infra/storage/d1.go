@@ -103,11 +103,15 @@ func NewD1Storage(config D1Config) (*D1Storage, error) {
 // runMigrations applies the SQLite schema over HTTP. The migration SQL is
 // reused verbatim from the SQLite migrations to guarantee schema parity; each
 // statement is sent individually so it works whether or not D1 accepts
-// multi-statement queries.
+// multi-statement queries. Every migration runs on each start, so an ADD
+// COLUMN that already ran fails with "duplicate column name" and is skipped.
 func (s *D1Storage) runMigrations(ctx context.Context) error {
 	for _, m := range migrations.GetSQLiteMigrations() {
 		for _, stmt := range splitSQLStatements(m.UpSQL) {
 			if _, err := s.exec(ctx, stmt); err != nil {
+				if strings.Contains(err.Error(), "duplicate column name") {
+					continue
+				}
 				return fmt.Errorf("migration %s (%s) failed: %w", m.Version, m.Description, err)
 			}
 		}
@@ -337,6 +341,38 @@ func (s *D1Storage) SaveConversation(ctx context.Context, conversationID string,
 		return fmt.Errorf("failed to save conversation: %w", err)
 	}
 
+	if metadata.ParentID != "" {
+		_, err = s.exec(ctx, `
+			INSERT INTO conversation_forks (conversation_id, parent_id) VALUES (?, ?)
+			ON CONFLICT(conversation_id) DO NOTHING
+		`, conversationID, metadata.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to save conversation lineage: %w", err)
+		}
+	}
+
+	if metadata.Archived {
+		return s.saveArchived(ctx, conversationID, true)
+	}
+
+	return nil
+}
+
+// saveArchived sets or clears the archived flag, kept in its own table like
+// fork lineage so the conversations row is untouched.
+func (s *D1Storage) saveArchived(ctx context.Context, conversationID string, archived bool) error {
+	var err error
+	if archived {
+		_, err = s.exec(ctx, `
+			INSERT INTO conversation_archive (conversation_id, archived_at) VALUES (?, ?)
+			ON CONFLICT(conversation_id) DO NOTHING
+		`, conversationID, time.Now())
+	} else {
+		_, err = s.exec(ctx, "DELETE FROM conversation_archive WHERE conversation_id = ?", conversationID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save archived flag: %w", err)
+	}
 	return nil
 }
 
@@ -362,8 +398,12 @@ func (s *D1Storage) loadConversationMetadata(ctx context.Context, conversationID
 	rows, err := s.queryRows(ctx, `
 		SELECT id, title, count, messages, total_input_tokens, total_output_tokens,
 		       request_count, cost_stats, models, tags, title_generated, title_invalidated, title_generation_time,
-		       created_at, updated_at
-		FROM conversations WHERE id = ?
+		       created_at, updated_at, COALESCE(f.parent_id, '') AS parent_id,
+		       a.conversation_id IS NOT NULL AS archived
+		FROM conversations
+		LEFT JOIN conversation_forks f ON f.conversation_id = conversations.id
+		LEFT JOIN conversation_archive a ON a.conversation_id = conversations.id
+		WHERE id = ?
 	`, conversationID)
 	if err != nil {
 		return metadata, "", fmt.Errorf("failed to load conversation: %w", err)
@@ -376,6 +416,8 @@ func (s *D1Storage) loadConversationMetadata(ctx context.Context, conversationID
 	metadata.ID = asString(r["id"])
 	metadata.Title = asString(r["title"])
 	metadata.MessageCount = asInt(r["count"])
+	metadata.ParentID = asString(r["parent_id"])
+	metadata.Archived = asBool(r["archived"])
 	metadata.TitleGenerated = asBool(r["title_generated"])
 	metadata.TitleInvalidated = asBool(r["title_invalidated"])
 	metadata.TitleGenerationTime = asTimePtr(r["title_generation_time"])
@@ -414,11 +456,15 @@ func (s *D1Storage) loadConversationMetadata(ctx context.Context, conversationID
 	return metadata, asString(r["messages"]), nil
 }
 
-// ListConversations returns a list of conversation summaries (lean: no models/tags/title fields).
+// ListConversations returns a list of conversation summaries (lean: no messages or title-generation fields).
 func (s *D1Storage) ListConversations(ctx context.Context, limit, offset int) ([]ConversationSummary, error) {
 	rows, err := s.queryRows(ctx, `
-		SELECT id, title, created_at, updated_at, count, total_input_tokens, total_output_tokens, request_count, cost_stats
+		SELECT id, title, created_at, updated_at, count, total_input_tokens, total_output_tokens, request_count, cost_stats,
+		       models, tags, COALESCE(f.parent_id, '') AS parent_id,
+		       a.conversation_id IS NOT NULL AS archived
 		FROM conversations
+		LEFT JOIN conversation_forks f ON f.conversation_id = conversations.id
+		LEFT JOIN conversation_archive a ON a.conversation_id = conversations.id
 		ORDER BY updated_at DESC
 		LIMIT ? OFFSET ?
 	`, limit, offset)
@@ -434,6 +480,18 @@ func (s *D1Storage) ListConversations(ctx context.Context, limit, offset int) ([
 		summary.CreatedAt = asTime(r["created_at"])
 		summary.UpdatedAt = asTime(r["updated_at"])
 		summary.MessageCount = asInt(r["count"])
+		summary.ParentID = asString(r["parent_id"])
+		summary.Archived = asBool(r["archived"])
+
+		var models []string
+		if modelsJSON := asString(r["models"]); modelsJSON != "" && modelsJSON != "[]" {
+			if err := json.Unmarshal([]byte(modelsJSON), &models); err == nil && len(models) > 0 {
+				summary.Model = models[0]
+			}
+		}
+		if tagsJSON := asString(r["tags"]); tagsJSON != "" && tagsJSON != "[]" {
+			_ = json.Unmarshal([]byte(tagsJSON), &summary.Tags)
+		}
 
 		totalInputTokens := asInt(r["total_input_tokens"])
 		totalOutputTokens := asInt(r["total_output_tokens"])
@@ -530,6 +588,8 @@ func (s *D1Storage) DeleteConversation(ctx context.Context, conversationID strin
 	if changes == 0 {
 		return fmt.Errorf("conversation not found: %s", conversationID)
 	}
+	_, _ = s.exec(ctx, "DELETE FROM conversation_forks WHERE conversation_id = ?", conversationID)
+	_, _ = s.exec(ctx, "DELETE FROM conversation_archive WHERE conversation_id = ?", conversationID)
 	return nil
 }
 
@@ -568,7 +628,7 @@ func (s *D1Storage) UpdateConversationMetadata(ctx context.Context, conversation
 	if changes == 0 {
 		return fmt.Errorf("conversation not found: %s", conversationID)
 	}
-	return nil
+	return s.saveArchived(ctx, conversationID, metadata.Archived)
 }
 
 // Close releases resources. D1 holds no persistent connection, so this is a no-op.
@@ -675,6 +735,14 @@ func (s *D1Storage) ListSessionGroups(ctx context.Context) (map[string]SessionGr
 	return out, nil
 }
 
+// DeleteSessionGroup removes the entry for groupKey.
+func (s *D1Storage) DeleteSessionGroup(ctx context.Context, groupKey string) error {
+	if _, err := s.exec(ctx, `DELETE FROM session_groups WHERE group_key = ?`, groupKey); err != nil {
+		return fmt.Errorf("delete session group %s: %w", groupKey, err)
+	}
+	return nil
+}
+
 // decodeHistory unmarshals the session-group history JSON array.
 func decodeHistory(historyJSON, groupKey string) ([]string, error) {
 	if historyJSON == "" {
@@ -694,8 +762,8 @@ func decodeHistory(historyJSON, groupKey string) ([]string, error) {
 // SaveJob creates or updates a scheduled job via UPSERT.
 func (s *D1Storage) SaveJob(ctx context.Context, job *domain.ScheduledJob) error {
 	_, err := s.exec(ctx, `
-	INSERT INTO scheduled_jobs(id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, created_at, updated_at, last_run, last_error)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO scheduled_jobs(id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, paused, created_at, updated_at, last_run, last_error, last_output)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	ON CONFLICT(id) DO UPDATE SET
 		name = excluded.name,
 		description = excluded.description,
@@ -705,12 +773,14 @@ func (s *D1Storage) SaveJob(ctx context.Context, job *domain.ScheduledJob) error
 		recipient_id = excluded.recipient_id,
 		model = excluded.model,
 		run_once = excluded.run_once,
+		paused = excluded.paused,
 		updated_at = excluded.updated_at,
 		last_run = excluded.last_run,
-		last_error = excluded.last_error
+		last_error = excluded.last_error,
+		last_output = excluded.last_output
 `, job.ID, job.Name, job.Description, job.CronExpression, job.Prompt,
-		job.Channel, job.RecipientID, job.Model, job.RunOnce,
-		job.CreatedAt, job.UpdatedAt, job.LastRun, job.LastError)
+		job.Channel, job.RecipientID, job.Model, job.RunOnce, job.Paused,
+		job.CreatedAt, job.UpdatedAt, job.LastRun, job.LastError, job.LastOutput)
 	if err != nil {
 		return fmt.Errorf("save scheduled job %s: %w", job.ID, err)
 	}
@@ -720,7 +790,7 @@ func (s *D1Storage) SaveJob(ctx context.Context, job *domain.ScheduledJob) error
 // LoadJob returns a job by ID.
 func (s *D1Storage) LoadJob(ctx context.Context, id string) (*domain.ScheduledJob, error) {
 	rows, err := s.queryRows(ctx, `
-	SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, created_at, updated_at, last_run, last_error
+	SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, paused, created_at, updated_at, last_run, last_error, last_output
 	FROM scheduled_jobs WHERE id = ?
 `, id)
 	if err != nil {
@@ -740,9 +810,11 @@ func (s *D1Storage) LoadJob(ctx context.Context, id string) (*domain.ScheduledJo
 		RecipientID:    asString(r["recipient_id"]),
 		Model:          asString(r["model"]),
 		RunOnce:        asBool(r["run_once"]),
+		Paused:         asBool(r["paused"]),
 		CreatedAt:      asTime(r["created_at"]),
 		UpdatedAt:      asTime(r["updated_at"]),
 		LastError:      asString(r["last_error"]),
+		LastOutput:     asString(r["last_output"]),
 	}
 	if lr := asTimePtr(r["last_run"]); lr != nil {
 		job.LastRun = lr
@@ -753,7 +825,7 @@ func (s *D1Storage) LoadJob(ctx context.Context, id string) (*domain.ScheduledJo
 // ListJobs returns all jobs sorted by CreatedAt ascending.
 func (s *D1Storage) ListJobs(ctx context.Context) ([]*domain.ScheduledJob, error) {
 	rows, err := s.queryRows(ctx, `
-	SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, created_at, updated_at, last_run, last_error
+	SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, paused, created_at, updated_at, last_run, last_error, last_output
 	FROM scheduled_jobs ORDER BY created_at ASC
 `)
 	if err != nil {
@@ -771,9 +843,11 @@ func (s *D1Storage) ListJobs(ctx context.Context) ([]*domain.ScheduledJob, error
 			RecipientID:    asString(r["recipient_id"]),
 			Model:          asString(r["model"]),
 			RunOnce:        asBool(r["run_once"]),
+			Paused:         asBool(r["paused"]),
 			CreatedAt:      asTime(r["created_at"]),
 			UpdatedAt:      asTime(r["updated_at"]),
 			LastError:      asString(r["last_error"]),
+			LastOutput:     asString(r["last_output"]),
 		}
 		if lr := asTimePtr(r["last_run"]); lr != nil {
 			job.LastRun = lr
@@ -896,3 +970,33 @@ func (s *D1Storage) LoadHistory(ctx context.Context, limit int) ([]string, error
 	slices.Reverse(commands)
 	return commands, nil
 }
+
+// ---------------------------------------------------------------------------
+// SpendStorage (D1Storage)
+// ---------------------------------------------------------------------------
+
+// AddDailySpend adds amount to the total of day via UPSERT.
+func (s *D1Storage) AddDailySpend(ctx context.Context, day string, amount float64) error {
+	_, err := s.exec(ctx, `
+	INSERT INTO daily_spend(day, total)
+	VALUES (?, ?)
+	ON CONFLICT(day) DO UPDATE SET total = daily_spend.total + excluded.total
+`, day, amount)
+	if err != nil {
+		return fmt.Errorf("add daily spend for %s: %w", day, err)
+	}
+	return nil
+}
+
+// GetDailySpend returns the total of day.
+func (s *D1Storage) GetDailySpend(ctx context.Context, day string) (float64, error) {
+	rows, err := s.queryRows(ctx, "SELECT total FROM daily_spend WHERE day = ?", day)
+	if err != nil {
+		return 0, fmt.Errorf("get daily spend for %s: %w", day, err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	total, _ := rows[0]["total"].(float64)
+	return total, nil
+}
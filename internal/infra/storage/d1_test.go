@@ -174,6 +174,9 @@ func TestD1Storage_Conformance(t *testing.T) {
 	runShellHistoryStorageConformance(t, func(t *testing.T) ShellHistoryStorage {
 		return setupTestD1Storage(t)
 	})
+	runSpendStorageConformance(t, func(t *testing.T) SpendStorage {
+		return setupTestD1Storage(t)
+	})
 }
 
 // TestD1Storage_RequestShape asserts the driver hits the documented D1 endpoint
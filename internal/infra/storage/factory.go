@@ -98,6 +98,7 @@ type fullBackend interface {
 	ScheduledJobStorage
 	PlanStorage
 	ShellHistoryStorage
+	SpendStorage
 }
 
 // NewStorage creates a new storage instance based on the provided configuration
@@ -112,6 +113,7 @@ func NewStorage(config StorageConfig) (*Stores, error) {
 		ScheduledJobs: backend,
 		Plans:         backend,
 		ShellHistory:  backend,
+		Spend:         backend,
 	}, nil
 }
 
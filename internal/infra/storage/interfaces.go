@@ -33,6 +33,10 @@ type SessionGroupStorage interface {
 	// ListSessionGroups returns all entries keyed by their group key. Used by
 	// administrative tooling and tests.
 	ListSessionGroups(ctx context.Context) (map[string]SessionGroupEntry, error)
+
+	// DeleteSessionGroup removes the entry for groupKey. The conversations it
+	// points at are kept. Deleting a missing key is not an error.
+	DeleteSessionGroup(ctx context.Context, groupKey string) error
 }
 
 // ConversationStorage defines the interface for persistent conversation storage
@@ -123,6 +127,17 @@ type ShellHistoryStorage interface {
 	LoadHistory(ctx context.Context, limit int) ([]string, error)
 }
 
+// SpendStorage defines the interface for persisting the running total of
+// what was spent on each day, which the daily budget is measured against.
+// Days are local calendar dates formatted "2006-01-02".
+type SpendStorage interface {
+	// AddDailySpend adds amount to the total of day.
+	AddDailySpend(ctx context.Context, day string, amount float64) error
+
+	// GetDailySpend returns the total of day, 0 when nothing was spent.
+	GetDailySpend(ctx context.Context, day string) (float64, error)
+}
+
 // Stores is the aggregate returned by NewStorage, holding all storage backends.
 type Stores struct {
 	Conversations ConversationStorage
@@ -130,6 +145,7 @@ type Stores struct {
 	ScheduledJobs ScheduledJobStorage
 	Plans         PlanStorage
 	ShellHistory  ShellHistoryStorage
+	Spend         SpendStorage
 }
 
 // StorageConfig contains configuration for storage backends
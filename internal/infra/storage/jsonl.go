@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -316,6 +317,8 @@ func (s *JsonlStorage) ListConversations(ctx context.Context, limit, offset int)
 			TitleGenerated:      metadata.TitleGenerated,
 			TitleInvalidated:    metadata.TitleInvalidated,
 			TitleGenerationTime: metadata.TitleGenerationTime,
+			ParentID:            metadata.ParentID,
+			Archived:            metadata.Archived,
 		})
 	}
 
@@ -899,6 +902,22 @@ func (s *JsonlStorage) ListSessionGroups(_ context.Context) (map[string]SessionG
 	return s.loadSessionGroupsLocked()
 }
 
+// DeleteSessionGroup removes the entry for groupKey from the on-disk index.
+func (s *JsonlStorage) DeleteSessionGroup(_ context.Context, groupKey string) error {
+	s.groupIndexMu.Lock()
+	defer s.groupIndexMu.Unlock()
+
+	groups, err := s.loadSessionGroupsLocked()
+	if err != nil {
+		return err
+	}
+	if _, ok := groups[groupKey]; !ok {
+		return nil
+	}
+	delete(groups, groupKey)
+	return s.saveSessionGroupsLocked(groups)
+}
+
 // ---------------------------------------------------------------------------
 // ScheduledJobStorage (JsonlStorage) - file-based, keeps historical paths
 // ---------------------------------------------------------------------------
@@ -1181,3 +1200,56 @@ func (s *JsonlStorage) LoadHistory(_ context.Context, limit int) ([]string, erro
 	}
 	return allLines, nil
 }
+
+// ---------------------------------------------------------------------------
+// SpendStorage (JsonlStorage) - one append-only file per day
+// ---------------------------------------------------------------------------
+
+// dailySpendFilePath returns the path to the spend log of day. Each line is
+// one amount; appends keep concurrent agent processes from losing updates.
+func (s *JsonlStorage) dailySpendFilePath(day string) string {
+	return filepath.Join(filepath.Dir(s.basePath), "spend", day+".jsonl")
+}
+
+// AddDailySpend adds amount to the total of day.
+func (s *JsonlStorage) AddDailySpend(_ context.Context, day string, amount float64) error {
+	path := s.dailySpendFilePath(day)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create spend directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open spend file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := file.WriteString(strconv.FormatFloat(amount, 'g', -1, 64) + "\n"); err != nil {
+		return fmt.Errorf("failed to write to spend file: %w", err)
+	}
+	return nil
+}
+
+// GetDailySpend returns the total of day.
+func (s *JsonlStorage) GetDailySpend(_ context.Context, day string) (float64, error) {
+	file, err := os.Open(s.dailySpendFilePath(day))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to open spend file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	total := 0.0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		amount, err := strconv.ParseFloat(strings.TrimSpace(scanner.Text()), 64)
+		if err != nil {
+			continue
+		}
+		total += amount
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("error reading spend file: %w", err)
+	}
+	return total, nil
+}
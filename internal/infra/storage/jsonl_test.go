@@ -877,4 +877,7 @@ func TestJsonlStorage_Conformance(t *testing.T) {
 	runShellHistoryStorageConformance(t, func(t *testing.T) ShellHistoryStorage {
 		return newConformanceJsonlStorage(t)
 	})
+	runSpendStorageConformance(t, func(t *testing.T) SpendStorage {
+		return newConformanceJsonlStorage(t)
+	})
 }
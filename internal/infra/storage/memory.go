@@ -18,6 +18,7 @@ type MemoryStorage struct {
 	scheduledJobs map[string]*domain.ScheduledJob
 	plans         map[string]*PlanRecord
 	shellHistory  []string
+	dailySpend    map[string]float64
 	mutex         sync.RWMutex
 }
 
@@ -96,6 +97,8 @@ func (m *MemoryStorage) ListConversations(ctx context.Context, limit, offset int
 			TitleGenerated:      data.metadata.TitleGenerated,
 			TitleInvalidated:    data.metadata.TitleInvalidated,
 			TitleGenerationTime: data.metadata.TitleGenerationTime,
+			ParentID:            data.metadata.ParentID,
+			Archived:            data.metadata.Archived,
 		}
 		summaries = append(summaries, summary)
 	}
@@ -195,6 +198,15 @@ func (m *MemoryStorage) ListSessionGroups(_ context.Context) (map[string]Session
 	return out, nil
 }
 
+// DeleteSessionGroup removes the entry for groupKey.
+func (m *MemoryStorage) DeleteSessionGroup(_ context.Context, groupKey string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.sessionGroups, groupKey)
+	return nil
+}
+
 func cloneSessionGroupEntry(entry SessionGroupEntry) SessionGroupEntry {
 	if len(entry.History) == 0 {
 		return entry
@@ -346,3 +358,25 @@ func (m *MemoryStorage) LoadHistory(ctx context.Context, limit int) ([]string, e
 	copy(result, m.shellHistory[len(m.shellHistory)-limit:])
 	return result, nil
 }
+
+// ---------------------------------------------------------------------------
+// SpendStorage (MemoryStorage)
+// ---------------------------------------------------------------------------
+
+// AddDailySpend adds amount to the total of day.
+func (m *MemoryStorage) AddDailySpend(_ context.Context, day string, amount float64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.dailySpend == nil {
+		m.dailySpend = make(map[string]float64)
+	}
+	m.dailySpend[day] += amount
+	return nil
+}
+
+// GetDailySpend returns the total of day.
+func (m *MemoryStorage) GetDailySpend(_ context.Context, day string) (float64, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.dailySpend[day], nil
+}
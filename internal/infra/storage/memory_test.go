@@ -336,4 +336,7 @@ func TestMemoryStorage_Conformance(t *testing.T) {
 	runShellHistoryStorageConformance(t, func(t *testing.T) ShellHistoryStorage {
 		return NewMemoryStorage()
 	})
+	runSpendStorageConformance(t, func(t *testing.T) SpendStorage {
+		return NewMemoryStorage()
+	})
 }
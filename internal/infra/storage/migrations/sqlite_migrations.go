@@ -101,5 +101,59 @@ func GetSQLiteMigrations() []Migration {
 				DROP TABLE IF EXISTS shell_history;
 			`,
 		},
+		{
+			Version:     "006",
+			Description: "Conversation fork lineage",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS conversation_forks (
+					conversation_id TEXT PRIMARY KEY,
+					parent_id       TEXT NOT NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_conversation_forks_parent_id ON conversation_forks(parent_id);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_conversation_forks_parent_id;
+				DROP TABLE IF EXISTS conversation_forks;
+			`,
+		},
+		{
+			Version:     "007",
+			Description: "Archived conversations",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS conversation_archive (
+					conversation_id TEXT PRIMARY KEY,
+					archived_at     DATETIME NOT NULL
+				);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS conversation_archive;
+			`,
+		},
+		{
+			Version:     "008",
+			Description: "Scheduled job pause flag and last output",
+			UpSQL: `
+				ALTER TABLE scheduled_jobs ADD COLUMN paused BOOLEAN NOT NULL DEFAULT FALSE;
+				ALTER TABLE scheduled_jobs ADD COLUMN last_output TEXT NOT NULL DEFAULT '';
+			`,
+			DownSQL: `
+				ALTER TABLE scheduled_jobs DROP COLUMN last_output;
+				ALTER TABLE scheduled_jobs DROP COLUMN paused;
+			`,
+		},
+		{
+			Version:     "009",
+			Description: "Daily spend totals for the daily budget",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS daily_spend (
+					day   TEXT PRIMARY KEY,
+					total REAL NOT NULL DEFAULT 0
+				);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS daily_spend;
+			`,
+		},
 	}
 }
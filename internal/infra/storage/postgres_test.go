@@ -33,7 +33,7 @@ func TestPostgresStorage_Conformance(t *testing.T) {
 		t.Cleanup(func() { _ = storage.Close() })
 
 		_, err = storage.DB().ExecContext(context.Background(),
-			"TRUNCATE conversations, session_groups, scheduled_jobs, plans, shell_history")
+			"TRUNCATE conversations, session_groups, scheduled_jobs, plans, shell_history, daily_spend")
 		require.NoError(t, err)
 
 		return storage
@@ -43,6 +43,7 @@ func TestPostgresStorage_Conformance(t *testing.T) {
 	runScheduledJobStorageConformance(t, func(t *testing.T) ScheduledJobStorage { return newStorage(t) })
 	runPlanStorageConformance(t, func(t *testing.T) PlanStorage { return newStorage(t) })
 	runShellHistoryStorageConformance(t, func(t *testing.T) ShellHistoryStorage { return newStorage(t) })
+	runSpendStorageConformance(t, func(t *testing.T) SpendStorage { return newStorage(t) })
 }
 
 // parsePostgresDSN parses a space-separated "key=value" libpq DSN into a
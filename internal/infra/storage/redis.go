@@ -234,6 +234,8 @@ func (s *RedisStorage) ListConversations(ctx context.Context, limit, offset int)
 			TitleGenerated:      metadata.TitleGenerated,
 			TitleInvalidated:    metadata.TitleInvalidated,
 			TitleGenerationTime: metadata.TitleGenerationTime,
+			ParentID:            metadata.ParentID,
+			Archived:            metadata.Archived,
 		}
 
 		summaries = append(summaries, summary)
@@ -479,6 +481,14 @@ func (s *RedisStorage) ListSessionGroups(ctx context.Context) (map[string]Sessio
 	return out, nil
 }
 
+// DeleteSessionGroup removes the entry for groupKey from the session-groups hash.
+func (s *RedisStorage) DeleteSessionGroup(ctx context.Context, groupKey string) error {
+	if err := s.client.HDel(ctx, sessionGroupsKey, groupKey).Err(); err != nil {
+		return fmt.Errorf("redis HDEL %s/%s: %w", sessionGroupsKey, groupKey, err)
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // ScheduledJobStorage (RedisStorage)
 // ---------------------------------------------------------------------------
@@ -487,6 +497,7 @@ const (
 	redisScheduledJobsKey = "scheduled_jobs"
 	redisPlansKey         = "plans"
 	redisShellHistoryKey  = "shell_history"
+	redisDailySpendKey    = "daily_spend"
 )
 
 // scheduledJobKey returns the Redis key for a scheduled job.
@@ -684,3 +695,33 @@ func (s *RedisStorage) LoadHistory(ctx context.Context, limit int) ([]string, er
 	}
 	return commands, nil
 }
+
+// ---------------------------------------------------------------------------
+// SpendStorage (RedisStorage)
+// ---------------------------------------------------------------------------
+
+// dailySpendKey returns the Redis key for a day's spend total.
+func (s *RedisStorage) dailySpendKey(day string) string {
+	return fmt.Sprintf("%s:%s", redisDailySpendKey, day)
+}
+
+// AddDailySpend adds amount to the total of day. INCRBYFLOAT keeps concurrent
+// agents from losing each other's updates.
+func (s *RedisStorage) AddDailySpend(ctx context.Context, day string, amount float64) error {
+	if err := s.client.IncrByFloat(ctx, s.dailySpendKey(day), amount).Err(); err != nil {
+		return fmt.Errorf("add daily spend for %s: %w", day, err)
+	}
+	return nil
+}
+
+// GetDailySpend returns the total of day.
+func (s *RedisStorage) GetDailySpend(ctx context.Context, day string) (float64, error) {
+	total, err := s.client.Get(ctx, s.dailySpendKey(day)).Float64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get daily spend for %s: %w", day, err)
+	}
+	return total, nil
+}
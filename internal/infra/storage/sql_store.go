@@ -115,6 +115,38 @@ func (s *sqlStore) SaveConversation(ctx context.Context, conversationID string,
 		return fmt.Errorf("failed to save conversation: %w", err)
 	}
 
+	if metadata.ParentID != "" {
+		_, err = s.db.ExecContext(ctx, s.rebind(`
+			INSERT INTO conversation_forks (conversation_id, parent_id) VALUES (?, ?)
+			ON CONFLICT(conversation_id) DO NOTHING
+		`), conversationID, metadata.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to save conversation lineage: %w", err)
+		}
+	}
+
+	if metadata.Archived {
+		return s.saveArchived(ctx, conversationID, true)
+	}
+
+	return nil
+}
+
+// saveArchived sets or clears the archived flag, kept in its own table like
+// fork lineage so the conversations row is untouched.
+func (s *sqlStore) saveArchived(ctx context.Context, conversationID string, archived bool) error {
+	var err error
+	if archived {
+		_, err = s.db.ExecContext(ctx, s.rebind(`
+			INSERT INTO conversation_archive (conversation_id, archived_at) VALUES (?, ?)
+			ON CONFLICT(conversation_id) DO NOTHING
+		`), conversationID, time.Now().Format(time.RFC3339))
+	} else {
+		_, err = s.db.ExecContext(ctx, s.rebind("DELETE FROM conversation_archive WHERE conversation_id = ?"), conversationID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save archived flag: %w", err)
+	}
 	return nil
 }
 
@@ -143,14 +175,17 @@ func (s *sqlStore) loadConversationMetadata(ctx context.Context, conversationID
 	err := s.db.QueryRowContext(ctx, s.rebind(`
 		SELECT id, title, count, messages, total_input_tokens, total_output_tokens,
 		       request_count, cost_stats, models, tags, title_generated, title_invalidated, title_generation_time,
-		       created_at, updated_at
-		FROM conversations WHERE id = ?
+		       created_at, updated_at, COALESCE(f.parent_id, ''), a.conversation_id IS NOT NULL
+		FROM conversations
+		LEFT JOIN conversation_forks f ON f.conversation_id = conversations.id
+		LEFT JOIN conversation_archive a ON a.conversation_id = conversations.id
+		WHERE id = ?
 	`), conversationID).Scan(
 		&metadata.ID, &metadata.Title, &metadata.MessageCount,
 		&messagesJSON, &totalInputTokens, &totalOutputTokens,
 		&requestCount, &costStatsJSON, &modelsJSON, &tagsJSON,
 		&metadata.TitleGenerated, &metadata.TitleInvalidated, &titleGenerationTime,
-		&metadata.CreatedAt, &metadata.UpdatedAt,
+		&metadata.CreatedAt, &metadata.UpdatedAt, &metadata.ParentID, &metadata.Archived,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -193,8 +228,11 @@ func (s *sqlStore) loadConversationMetadata(ctx context.Context, conversationID
 // ListConversations returns a list of conversation summaries.
 func (s *sqlStore) ListConversations(ctx context.Context, limit, offset int) ([]ConversationSummary, error) {
 	rows, err := s.db.QueryContext(ctx, s.rebind(`
-		SELECT id, title, created_at, updated_at, count, total_input_tokens, total_output_tokens, request_count, cost_stats
+		SELECT id, title, created_at, updated_at, count, total_input_tokens, total_output_tokens, request_count, cost_stats,
+		       models, tags, COALESCE(f.parent_id, ''), a.conversation_id IS NOT NULL
 		FROM conversations
+		LEFT JOIN conversation_forks f ON f.conversation_id = conversations.id
+		LEFT JOIN conversation_archive a ON a.conversation_id = conversations.id
 		ORDER BY updated_at DESC
 		LIMIT ? OFFSET ?
 	`), limit, offset)
@@ -207,11 +245,12 @@ func (s *sqlStore) ListConversations(ctx context.Context, limit, offset int) ([]
 	for rows.Next() {
 		var summary ConversationSummary
 		var totalInputTokens, totalOutputTokens, requestCount int
-		var costStatsJSON string
+		var costStatsJSON, modelsJSON, tagsJSON string
 
 		err := rows.Scan(
 			&summary.ID, &summary.Title, &summary.CreatedAt, &summary.UpdatedAt,
 			&summary.MessageCount, &totalInputTokens, &totalOutputTokens, &requestCount, &costStatsJSON,
+			&modelsJSON, &tagsJSON, &summary.ParentID, &summary.Archived,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan conversation: %w", err)
@@ -230,6 +269,17 @@ func (s *sqlStore) ListConversations(ctx context.Context, limit, offset int) ([]
 			}
 		}
 
+		var models []string
+		if modelsJSON != "" && modelsJSON != "[]" {
+			if err := json.Unmarshal([]byte(modelsJSON), &models); err == nil && len(models) > 0 {
+				summary.Model = models[0]
+			}
+		}
+
+		if tagsJSON != "" && tagsJSON != "[]" {
+			_ = json.Unmarshal([]byte(tagsJSON), &summary.Tags)
+		}
+
 		summaries = append(summaries, summary)
 	}
 
@@ -313,6 +363,9 @@ func (s *sqlStore) DeleteConversation(ctx context.Context, conversationID string
 		return fmt.Errorf("conversation not found: %s", conversationID)
 	}
 
+	_, _ = s.db.ExecContext(ctx, s.rebind("DELETE FROM conversation_forks WHERE conversation_id = ?"), conversationID)
+	_, _ = s.db.ExecContext(ctx, s.rebind("DELETE FROM conversation_archive WHERE conversation_id = ?"), conversationID)
+
 	return nil
 }
 
@@ -357,7 +410,7 @@ func (s *sqlStore) UpdateConversationMetadata(ctx context.Context, conversationI
 		return fmt.Errorf("conversation not found: %s", conversationID)
 	}
 
-	return nil
+	return s.saveArchived(ctx, conversationID, metadata.Archived)
 }
 
 // Close closes the database connection.
@@ -498,6 +551,14 @@ func (s *sqlStore) ListSessionGroups(ctx context.Context) (map[string]SessionGro
 	return out, nil
 }
 
+// DeleteSessionGroup removes the entry for groupKey.
+func (s *sqlStore) DeleteSessionGroup(ctx context.Context, groupKey string) error {
+	if _, err := s.db.ExecContext(ctx, s.rebind(`DELETE FROM session_groups WHERE group_key = ?`), groupKey); err != nil {
+		return fmt.Errorf("delete session group %s: %w", groupKey, err)
+	}
+	return nil
+}
+
 // decodeSessionHistory unmarshals a stored history blob (TEXT in both dialects,
 // scanned as []byte) into a slice of session IDs.
 func decodeSessionHistory(historyJSON []byte, groupKey string) ([]string, error) {
@@ -518,8 +579,8 @@ func decodeSessionHistory(historyJSON []byte, groupKey string) ([]string, error)
 // SaveJob creates or updates a scheduled job via UPSERT.
 func (s *sqlStore) SaveJob(ctx context.Context, job *domain.ScheduledJob) error {
 	_, err := s.db.ExecContext(ctx, s.rebind(`
-		INSERT INTO scheduled_jobs(id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, created_at, updated_at, last_run, last_error)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO scheduled_jobs(id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, paused, created_at, updated_at, last_run, last_error, last_output)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			name = excluded.name,
 			description = excluded.description,
@@ -529,12 +590,14 @@ func (s *sqlStore) SaveJob(ctx context.Context, job *domain.ScheduledJob) error
 			recipient_id = excluded.recipient_id,
 			model = excluded.model,
 			run_once = excluded.run_once,
+			paused = excluded.paused,
 			updated_at = excluded.updated_at,
 			last_run = excluded.last_run,
-			last_error = excluded.last_error
+			last_error = excluded.last_error,
+			last_output = excluded.last_output
 	`), job.ID, job.Name, job.Description, job.CronExpression, job.Prompt,
-		job.Channel, job.RecipientID, job.Model, job.RunOnce,
-		job.CreatedAt, job.UpdatedAt, job.LastRun, job.LastError)
+		job.Channel, job.RecipientID, job.Model, job.RunOnce, job.Paused,
+		job.CreatedAt, job.UpdatedAt, job.LastRun, job.LastError, job.LastOutput)
 	if err != nil {
 		return fmt.Errorf("save scheduled job %s: %w", job.ID, err)
 	}
@@ -547,12 +610,12 @@ func (s *sqlStore) LoadJob(ctx context.Context, id string) (*domain.ScheduledJob
 	var lastRun sql.NullTime
 
 	err := s.db.QueryRowContext(ctx, s.rebind(`
-		SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, created_at, updated_at, last_run, last_error
+		SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, paused, created_at, updated_at, last_run, last_error, last_output
 		FROM scheduled_jobs WHERE id = ?
 	`), id).Scan(
 		&job.ID, &job.Name, &job.Description, &job.CronExpression, &job.Prompt,
-		&job.Channel, &job.RecipientID, &job.Model, &job.RunOnce,
-		&job.CreatedAt, &job.UpdatedAt, &lastRun, &job.LastError,
+		&job.Channel, &job.RecipientID, &job.Model, &job.RunOnce, &job.Paused,
+		&job.CreatedAt, &job.UpdatedAt, &lastRun, &job.LastError, &job.LastOutput,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -569,7 +632,7 @@ func (s *sqlStore) LoadJob(ctx context.Context, id string) (*domain.ScheduledJob
 // ListJobs returns all jobs sorted by CreatedAt ascending.
 func (s *sqlStore) ListJobs(ctx context.Context) ([]*domain.ScheduledJob, error) {
 	rows, err := s.db.QueryContext(ctx, s.rebind(`
-		SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, created_at, updated_at, last_run, last_error
+		SELECT id, name, description, cron_expression, prompt, channel, recipient_id, model, run_once, paused, created_at, updated_at, last_run, last_error, last_output
 		FROM scheduled_jobs ORDER BY created_at ASC
 	`))
 	if err != nil {
@@ -583,8 +646,8 @@ func (s *sqlStore) ListJobs(ctx context.Context) ([]*domain.ScheduledJob, error)
 		var lastRun sql.NullTime
 		if err := rows.Scan(
 			&job.ID, &job.Name, &job.Description, &job.CronExpression, &job.Prompt,
-			&job.Channel, &job.RecipientID, &job.Model, &job.RunOnce,
-			&job.CreatedAt, &job.UpdatedAt, &lastRun, &job.LastError,
+			&job.Channel, &job.RecipientID, &job.Model, &job.RunOnce, &job.Paused,
+			&job.CreatedAt, &job.UpdatedAt, &lastRun, &job.LastError, &job.LastOutput,
 		); err != nil {
 			return nil, fmt.Errorf("scan scheduled job: %w", err)
 		}
@@ -723,3 +786,33 @@ func (s *sqlStore) LoadHistory(ctx context.Context, limit int) ([]string, error)
 	slices.Reverse(commands)
 	return commands, rows.Err()
 }
+
+// ---------------------------------------------------------------------------
+// SpendStorage (sqlStore)
+// ---------------------------------------------------------------------------
+
+// AddDailySpend adds amount to the total of day via UPSERT.
+func (s *sqlStore) AddDailySpend(ctx context.Context, day string, amount float64) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(`
+		INSERT INTO daily_spend(day, total)
+		VALUES (?, ?)
+		ON CONFLICT(day) DO UPDATE SET total = daily_spend.total + excluded.total
+	`), day, amount)
+	if err != nil {
+		return fmt.Errorf("add daily spend for %s: %w", day, err)
+	}
+	return nil
+}
+
+// GetDailySpend returns the total of day.
+func (s *sqlStore) GetDailySpend(ctx context.Context, day string) (float64, error) {
+	var total float64
+	err := s.db.QueryRowContext(ctx, s.rebind("SELECT total FROM daily_spend WHERE day = ?"), day).Scan(&total)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get daily spend for %s: %w", day, err)
+	}
+	return total, nil
+}
@@ -48,4 +48,9 @@ func TestSQLiteStorage_Conformance(t *testing.T) {
 		t.Cleanup(cleanup)
 		return storage
 	})
+	runSpendStorageConformance(t, func(t *testing.T) SpendStorage {
+		storage, cleanup := setupTestStorage(t)
+		t.Cleanup(cleanup)
+		return storage
+	})
 }
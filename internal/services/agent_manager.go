@@ -15,6 +15,7 @@ import (
 
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	telemetry "github.com/inference-gateway/cli/internal/telemetry"
 	utils "github.com/inference-gateway/cli/internal/utils"
@@ -437,6 +438,25 @@ func (am *AgentManager) pullImage(ctx context.Context, agent config.AgentEntry)
 }
 
 // startContainer starts the agent container
+// resolveAgentEnv overrides the agent's configured environment with the .env
+// file and then the environment, where credentials stored with `infer auth
+// login` count as set.
+func resolveAgentEnv(env, dotEnvVars map[string]string) map[string]string {
+	resolvedEnv := make(map[string]string, len(env))
+	for key := range env {
+		if value, exists := dotEnvVars[key]; exists {
+			resolvedEnv[key] = value
+			logger.Warn("using .env value for variable", "key", key)
+		} else if value, exists := keyring.LookupEnv(key); exists {
+			resolvedEnv[key] = value
+			logger.Warn("using system environment value for variable", "key", key)
+		} else {
+			resolvedEnv[key] = env[key]
+		}
+	}
+	return resolvedEnv
+}
+
 func (am *AgentManager) startContainer(ctx context.Context, agent config.AgentEntry) error {
 	assignedPort := am.assignPort(agent)
 	containerPort := "8080"
@@ -482,20 +502,7 @@ func (am *AgentManager) startContainer(ctx context.Context, agent config.AgentEn
 		env["A2A_ARTIFACTS_STORAGE_BASE_URL"] = agent.ArtifactsURL
 	}
 
-	resolvedEnv := make(map[string]string)
-	for key := range env {
-		if value, exists := dotEnvVars[key]; exists {
-			resolvedEnv[key] = value
-			logger.Warn("using .env value for variable", "key", key)
-		} else if value, exists := os.LookupEnv(key); exists {
-			resolvedEnv[key] = value
-			logger.Warn("using system environment value for variable", "key", key)
-		} else {
-			resolvedEnv[key] = env[key]
-		}
-	}
-
-	for key, value := range resolvedEnv {
+	for key, value := range resolveAgentEnv(env, dotEnvVars) {
 		args = append(args, "-e", fmt.Sprintf("%s=%s", key, value))
 	}
 
@@ -7,9 +7,35 @@ import (
 
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 	require "github.com/stretchr/testify/require"
 )
 
+func TestResolveAgentEnv(t *testing.T) {
+	t.Setenv("AGENT_ENV_FROM_SHELL", "shell")
+	keyring.SetSecrets(map[string]string{
+		"AGENT_ENV_FROM_KEYRING": "stored",
+		"AGENT_ENV_FROM_SHELL":   "stored",
+		"AGENT_ENV_FROM_DOTENV":  "stored",
+	})
+	defer keyring.SetSecrets(nil)
+
+	env := map[string]string{
+		"AGENT_ENV_FROM_KEYRING": "configured",
+		"AGENT_ENV_FROM_SHELL":   "configured",
+		"AGENT_ENV_FROM_DOTENV":  "configured",
+		"AGENT_ENV_DEFAULT":      "configured",
+	}
+	resolved := resolveAgentEnv(env, map[string]string{"AGENT_ENV_FROM_DOTENV": "dotenv"})
+
+	require.Equal(t, map[string]string{
+		"AGENT_ENV_FROM_KEYRING": "stored",
+		"AGENT_ENV_FROM_SHELL":   "shell",
+		"AGENT_ENV_FROM_DOTENV":  "dotenv",
+		"AGENT_ENV_DEFAULT":      "configured",
+	}, resolved)
+}
+
 func TestAgentManager_loadDotEnvFile(t *testing.T) {
 	tmpDir := t.TempDir()
 
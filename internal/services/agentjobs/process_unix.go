@@ -0,0 +1,57 @@
+//go:build !windows
+
+package agentjobs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// detachedProcAttr starts the job in its own session, so it keeps running when
+// the terminal that started it closes
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// lockJob takes an exclusive lock on the job's lock file and hands it to cmd,
+// so the job process holds it for as long as it runs. The caller closes the
+// returned file once cmd has started; the job's copy keeps the lock.
+func lockJob(cmd *exec.Cmd, path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	cmd.ExtraFiles = append(cmd.ExtraFiles, f)
+	return f, nil
+}
+
+// jobAlive reports whether the job still holds its lock. A stored PID alone
+// proves nothing: once the job is gone the PID can belong to any process.
+// The lock is inherited by the commands the job starts, so the job counts as
+// running until they are gone too, which keeps its process group alive.
+func jobAlive(job Job, lockPath string) bool {
+	f, err := os.Open(lockPath)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_SH|syscall.LOCK_NB)
+	if err == nil {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		return false
+	}
+	return errors.Is(err, syscall.EWOULDBLOCK)
+}
+
+// terminateProcess sends SIGTERM to the job's process group, which includes
+// the tool commands it started
+func terminateProcess(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGTERM)
+}
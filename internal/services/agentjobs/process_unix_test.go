@@ -0,0 +1,45 @@
+//go:build !windows
+
+package agentjobs
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStore_StatusFollowsTheJobLock(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	job, err := store.Start(Job{ID: "sleeper"}, "sleep", []string{"30"}, os.Environ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := store.Status(job).State; got != StateRunning {
+		t.Fatalf("Status(started job) = %s, want running", got)
+	}
+
+	// A live PID without the job's lock is some other process
+	reused := Job{ID: "reused", PID: os.Getpid()}
+	if got := store.Status(reused).State; got != StateExited {
+		t.Errorf("Status(reused PID) = %s, want exited", got)
+	}
+	if _, err := store.Stop(reused); err == nil {
+		t.Error("Stop() should not signal a process that is not the job")
+	}
+
+	job, err = store.Stop(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for store.Status(job).State == StateRunning {
+		if time.Now().After(deadline) {
+			t.Fatal("job still running after Stop()")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := store.Status(job).State; got != StateStopped {
+		t.Errorf("Status(stopped job) = %s, want stopped", got)
+	}
+}
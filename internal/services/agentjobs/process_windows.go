@@ -0,0 +1,63 @@
+//go:build windows
+
+package agentjobs
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+const (
+	createNewProcessGroup = 0x00000200
+	detachedProcess       = 0x00000008
+	processQueryLimited   = 0x1000
+	stillActive           = 259
+)
+
+// startTimeSlack is how far a process's creation time may be from the job's
+// recorded start for the process to be taken as the job
+const startTimeSlack = 5 * time.Second
+
+// detachedProcAttr starts the job without a console, so it keeps running when
+// the terminal that started it closes
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: createNewProcessGroup | detachedProcess}
+}
+
+// lockJob is a no-op: child processes cannot inherit extra files on Windows,
+// so jobAlive checks the process creation time instead
+func lockJob(*exec.Cmd, string) (*os.File, error) {
+	return nil, nil
+}
+
+// jobAlive reports whether the job's PID is still the process started for
+// it, by its creation time, since the PID can be reused once the job is gone
+func jobAlive(job Job, _ string) bool {
+	handle, err := syscall.OpenProcess(processQueryLimited, false, uint32(job.PID))
+	if err != nil {
+		return false
+	}
+	defer func() { _ = syscall.CloseHandle(handle) }()
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(handle, &code); err != nil || code != stillActive {
+		return false
+	}
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return false
+	}
+	created := time.Unix(0, creation.Nanoseconds())
+	return created.Sub(job.StartedAt).Abs() <= startTimeSlack
+}
+
+func terminateProcess(pid int) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return proc.Kill()
+}
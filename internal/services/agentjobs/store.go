@@ -0,0 +1,248 @@
+// Package agentjobs keeps track of detached agent runs: `infer agent --detach`
+// starts the agent as a separate process, writing its output to a log file, and
+// records the job on disk so `infer jobs` can list, follow and stop it from any
+// later session. The job process writes its outcome with --result-file when it
+// exits, so nothing has to stay running to learn how a job ended.
+//
+// Like agentrunner it is a leaf package (imports only domain + stdlib).
+package agentjobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// State is where a job is in its life
+type State string
+
+const (
+	StateRunning   State = "running"
+	StateCompleted State = "completed"
+	StateFailed    State = "failed"
+	StateStopped   State = "stopped"
+	// StateExited is a job whose process is gone without writing a result,
+	// e.g. because it was killed or the machine restarted
+	StateExited State = "exited"
+)
+
+// ErrJobNotFound is returned when no job matches a reference
+var ErrJobNotFound = errors.New("job not found")
+
+// Job is the record of one detached agent run
+type Job struct {
+	ID        string     `json:"id"`
+	SessionID string     `json:"session_id"`
+	Task      string     `json:"task"`
+	Model     string     `json:"model,omitempty"`
+	PID       int        `json:"pid"`
+	StartedAt time.Time  `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+}
+
+// Status is a job's state, with its outcome once it finished
+type Status struct {
+	State      State
+	FinishedAt *time.Time
+	Result     *domain.SubagentResultFile
+}
+
+// Store keeps job records, logs and results in one directory
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store over dir, which is created on the first job
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// LogPath is the file a job's output is written to
+func (s *Store) LogPath(id string) string {
+	return filepath.Join(s.dir, id+".log")
+}
+
+// ResultPath is the file a job writes its outcome to when it exits
+func (s *Store) ResultPath(id string) string {
+	return filepath.Join(s.dir, id+".result.json")
+}
+
+// lockPath is the file a running job holds locked
+func (s *Store) lockPath(id string) string {
+	return filepath.Join(s.dir, id+".lock")
+}
+
+func (s *Store) recordPath(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// Start runs bin with args as a detached process, its stdout and stderr going
+// to the job's log, and records it. The process outlives the caller.
+func (s *Store) Start(job Job, bin string, args []string, env []string) (Job, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return job, fmt.Errorf("failed to create jobs directory: %w", err)
+	}
+	logFile, err := os.OpenFile(s.LogPath(job.ID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return job, fmt.Errorf("failed to create job log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	cmd := exec.Command(bin, args...)
+	cmd.Env = env
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = detachedProcAttr()
+	lock, err := lockJob(cmd, s.lockPath(job.ID))
+	if err != nil {
+		return job, fmt.Errorf("failed to lock job: %w", err)
+	}
+	if lock != nil {
+		defer func() { _ = lock.Close() }()
+	}
+	if err := cmd.Start(); err != nil {
+		return job, fmt.Errorf("failed to start job: %w", err)
+	}
+
+	job.PID = cmd.Process.Pid
+	job.StartedAt = time.Now()
+	if err := s.save(job); err != nil {
+		_ = cmd.Process.Kill()
+		return job, err
+	}
+	_ = cmd.Process.Release()
+	return job, nil
+}
+
+func (s *Store) save(job Job) error {
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+	tmp := s.recordPath(job.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	if err := os.Rename(tmp, s.recordPath(job.ID)); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	return nil
+}
+
+// List returns every recorded job, the most recently started first
+func (s *Store) List() ([]Job, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jobs directory: %w", err)
+	}
+
+	var jobs []Job
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".result.json") {
+			continue
+		}
+		job, err := s.load(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	slices.SortFunc(jobs, func(a, b Job) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return jobs, nil
+}
+
+func (s *Store) load(id string) (Job, error) {
+	data, err := os.ReadFile(s.recordPath(id))
+	if err != nil {
+		return Job{}, err
+	}
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return Job{}, fmt.Errorf("invalid job record %s: %w", id, err)
+	}
+	return job, nil
+}
+
+// Resolve finds the job whose ID is ref or starts with it
+func (s *Store) Resolve(ref string) (Job, error) {
+	if job, err := s.load(ref); err == nil {
+		return job, nil
+	}
+
+	jobs, err := s.List()
+	if err != nil {
+		return Job{}, err
+	}
+	var matches []Job
+	for _, job := range jobs {
+		if ref != "" && strings.HasPrefix(job.ID, ref) {
+			matches = append(matches, job)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Job{}, fmt.Errorf("%w: %q", ErrJobNotFound, ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return Job{}, fmt.Errorf("%q matches %d jobs, use the full ID", ref, len(matches))
+	}
+}
+
+// Status reports the job's state: the outcome it wrote when it exited, or
+// whether it is still running. A job that died without writing a result is
+// exited, even if its PID has since been reused.
+func (s *Store) Status(job Job) Status {
+	if data, err := os.ReadFile(s.ResultPath(job.ID)); err == nil {
+		var result domain.SubagentResultFile
+		if json.Unmarshal(data, &result) == nil {
+			status := Status{State: StateCompleted, Result: &result}
+			if info, err := os.Stat(s.ResultPath(job.ID)); err == nil {
+				finished := info.ModTime()
+				status.FinishedAt = &finished
+			}
+			if !result.Success {
+				status.State = StateFailed
+			}
+			if job.StoppedAt != nil {
+				status.State = StateStopped
+			}
+			return status
+		}
+	}
+
+	if job.PID > 0 && jobAlive(job, s.lockPath(job.ID)) {
+		return Status{State: StateRunning}
+	}
+	if job.StoppedAt != nil {
+		return Status{State: StateStopped, FinishedAt: job.StoppedAt}
+	}
+	return Status{State: StateExited}
+}
+
+// Stop asks a running job to end and records that it was stopped
+func (s *Store) Stop(job Job) (Job, error) {
+	if s.Status(job).State != StateRunning {
+		return job, fmt.Errorf("job %s is not running", job.ID)
+	}
+	if err := terminateProcess(job.PID); err != nil {
+		return job, fmt.Errorf("failed to stop job %s: %w", job.ID, err)
+	}
+	now := time.Now()
+	job.StoppedAt = &now
+	return job, s.save(job)
+}
@@ -0,0 +1,89 @@
+package agentjobs
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStore_ListAndResolve(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	jobs, err := store.List()
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("List() on a missing directory = %v, %v; want no jobs", jobs, err)
+	}
+
+	if err := os.MkdirAll(store.dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, job := range []Job{
+		{ID: "aaaa1111", SessionID: "s1", Task: "first", StartedAt: now.Add(-time.Hour)},
+		{ID: "aaaa2222", SessionID: "s2", Task: "second", StartedAt: now},
+		{ID: "bbbb3333", SessionID: "s3", Task: "third", StartedAt: now.Add(-time.Minute)},
+	} {
+		if err := store.save(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(store.ResultPath("aaaa1111"), []byte(`{"success": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err = store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 3 || jobs[0].ID != "aaaa2222" || jobs[2].ID != "aaaa1111" {
+		t.Fatalf("List() = %+v, want the three jobs newest first", jobs)
+	}
+
+	if job, err := store.Resolve("bbbb"); err != nil || job.Task != "third" {
+		t.Errorf("Resolve(prefix) = %+v, %v", job, err)
+	}
+	if _, err := store.Resolve("aaaa"); err == nil {
+		t.Error("Resolve() should reject an ambiguous prefix")
+	}
+	if _, err := store.Resolve("cccc"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Resolve(unknown) error = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestStore_Status(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := os.MkdirAll(store.dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	exited := Job{ID: "exited"}
+	if got := store.Status(exited).State; got != StateExited {
+		t.Errorf("Status(no process, no result) = %s, want exited", got)
+	}
+
+	stoppedAt := time.Now()
+	stopped := Job{ID: "stopped", StoppedAt: &stoppedAt}
+	if got := store.Status(stopped).State; got != StateStopped {
+		t.Errorf("Status(stopped) = %s, want stopped", got)
+	}
+
+	if err := os.WriteFile(store.ResultPath("done"), []byte(`{"final_assistant": "all fixed", "success": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	done := store.Status(Job{ID: "done", PID: os.Getpid()})
+	if done.State != StateCompleted || done.Result == nil || done.Result.FinalAssistant != "all fixed" || done.FinishedAt == nil {
+		t.Errorf("Status(with result) = %+v, want completed with the answer", done)
+	}
+
+	if err := os.WriteFile(store.ResultPath("broke"), []byte(`{"success": false, "error": "boom"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.Status(Job{ID: "broke"}).State; got != StateFailed {
+		t.Errorf("Status(failed result) = %s, want failed", got)
+	}
+
+	if _, err := store.Stop(exited); err == nil {
+		t.Error("Stop() should refuse a job that is not running")
+	}
+}
@@ -18,10 +18,12 @@ import (
 //     read-only by construction so nothing it can call mutates
 //  3. Non-chat (headless agent) mode bypasses approval; there the Bash tool's own
 //     per-mode gate (executeBash) decides what runs
-//  4. Bash commands are governed by the per-mode allow-list (config.IsBashCommandAllowed):
+//  4. The first matching tools.safety.rules entry decides: "allow" bypasses
+//     approval, "ask" always prompts
+//  5. Bash commands are governed by the per-mode allow-list (config.IsBashCommandAllowed):
 //     reached only in chat, non-auto mode, so allowed commands bypass approval and
 //     anything off-list prompts the user
-//  5. Other tools check configuration (per-tool or global require_approval setting)
+//  6. Other tools check configuration (per-tool or global require_approval setting)
 type StandardApprovalPolicy struct {
 	config       *config.Config
 	stateManager domain.AgentModeManager
@@ -58,6 +60,10 @@ func (p *StandardApprovalPolicy) ShouldRequireApproval(
 		return false
 	}
 
+	if action, ok := p.config.ApprovalRuleAction(toolCall.Function.Name, toolCall.Function.Arguments); ok {
+		return action == config.ApprovalRuleAsk
+	}
+
 	if toolCall.Function.Name == "Bash" {
 		return !p.isBashCommandAllowed(toolCall)
 	}
@@ -168,6 +168,33 @@ func TestStandardApprovalPolicy_ConfigBasedApproval(t *testing.T) {
 	}
 }
 
+func TestStandardApprovalPolicy_Rules(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Tools.Safety.Rules = []config.ApprovalRule{
+		{Tool: "Edit", Path: "internal/**", Action: config.ApprovalRuleAllow},
+		{Tool: "Bash", Command: "^echo", Action: config.ApprovalRuleAsk},
+	}
+	stateManager := NewStateManager(false)
+	stateManager.SetAgentMode(domain.AgentModeStandard)
+	policy := NewStandardApprovalPolicy(cfg, stateManager)
+	ctx := context.Background()
+
+	if policy.ShouldRequireApproval(ctx, createToolCall("Edit", `{"file_path": "internal/app/chat.go"}`), true) {
+		t.Error("an allow rule should bypass approval")
+	}
+	if !policy.ShouldRequireApproval(ctx, createToolCall("Edit", `{"file_path": "cmd/root.go"}`), true) {
+		t.Error("an unmatched call should fall back to require_approval")
+	}
+	if !policy.ShouldRequireApproval(ctx, createToolCall("Bash", `{"command": "echo hi"}`), true) {
+		t.Error("an ask rule should prompt even for an allow-listed command")
+	}
+
+	stateManager.SetAgentMode(domain.AgentModeAutoAccept)
+	if policy.ShouldRequireApproval(ctx, createToolCall("Bash", `{"command": "echo hi"}`), true) {
+		t.Error("auto-accept mode should still bypass ask rules")
+	}
+}
+
 func TestStandardApprovalPolicy_WithNilStateManager(t *testing.T) {
 	policy := NewStandardApprovalPolicy(createTestConfig(), nil)
 	ctx := context.Background()
@@ -1,13 +1,16 @@
 package approvalcoord
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	sdk "github.com/inference-gateway/sdk"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
 	logger "github.com/inference-gateway/cli/internal/logger"
 )
 
@@ -17,13 +20,16 @@ import (
 // latter via embedding).
 type planRepoUpdater interface {
 	UpdatePlanStatus(action domain.PlanApprovalAction)
+	UpdatePendingPlanContent(content string)
 }
 
 // stateManager is the narrow slice of the app state manager this coordinator
-// needs: the plan-approval and user-question overlays, computer-use pause,
-// chat-session end, and mode switching. *services.StateManager satisfies it.
+// needs: the plan-approval and user-question overlays, the approved plan's
+// checklist, computer-use pause, chat-session end, and mode switching.
+// *services.StateManager satisfies it.
 type stateManager interface {
 	domain.PlanApprovalUIManager
+	domain.PlanStepManager
 	domain.UserQuestionUIManager
 	domain.ComputerUsePauseManager
 	domain.ChatSessionManager
@@ -36,13 +42,16 @@ type Service struct {
 	agentService     domain.AgentService
 	conversationRepo domain.ConversationRepository
 	stateManager     stateManager
+	plans            storage.PlanStorage
 }
 
-// Options bundles the dependencies needed to construct a Service.
+// Options bundles the dependencies needed to construct a Service. Plans is
+// optional; without it edited plans are not written back to plan storage.
 type Options struct {
 	AgentService     domain.AgentService
 	ConversationRepo domain.ConversationRepository
 	StateManager     stateManager
+	Plans            storage.PlanStorage
 }
 
 // NewService creates a new approval coordinator.
@@ -51,6 +60,7 @@ func NewService(opts Options) *Service {
 		agentService:     opts.AgentService,
 		conversationRepo: opts.ConversationRepo,
 		stateManager:     opts.StateManager,
+		plans:            opts.Plans,
 	}
 }
 
@@ -74,7 +84,7 @@ func (s *Service) planApprovalRequestedCmds(_ string) []tea.Cmd {
 		},
 		func() tea.Msg {
 			return domain.SetStatusEvent{
-				Message:    "Plan ready - use arrow keys to select and Enter to confirm",
+				Message:    "Plan ready - use arrow keys to select, Enter to confirm, e to edit",
 				Spinner:    false,
 				StatusType: domain.StatusDefault,
 			}
@@ -111,10 +121,15 @@ func (s *Service) HandlePlanApprovalResponse(msg domain.PlanApprovalResponseEven
 		return nil, false
 	}
 
+	planContent := planApprovalState.PlanContent
+
 	logger.Info("clearing plan approval UI state to prevent re-entry")
 	s.stateManager.ClearPlanApprovalUIState()
 
 	s.updatePlanStatus(msg.Action)
+	if msg.Action != domain.PlanApprovalReject {
+		s.stateManager.SetPlanSteps(domain.ParsePlanSteps(planContent))
+	}
 
 	statusMessage, restart := s.applyPlanDecision(msg.Action)
 
@@ -134,9 +149,94 @@ func (s *Service) HandlePlanApprovalResponse(msg domain.PlanApprovalResponseEven
 		},
 	}
 
+	if msg.Action != domain.PlanApprovalReject {
+		steps := s.stateManager.GetPlanSteps()
+		cmds = append(cmds, func() tea.Msg {
+			return domain.PlanStepsUpdateEvent{Steps: steps}
+		})
+	}
+
 	return tea.Batch(cmds...), restart
 }
 
+// HandlePlanEdited replaces the pending plan with the version saved from the
+// editor: in the approval UI, in the conversation (which is what the agent
+// executes once approved) and in plan storage under the same plan ID. An empty
+// edit keeps the original plan.
+func (s *Service) HandlePlanEdited(msg domain.PlanEditedEvent) tea.Cmd {
+	planApprovalState := s.stateManager.GetPlanApprovalUIState()
+	if planApprovalState == nil {
+		logger.Warn("approvalCoordinator.HandlePlanEdited: planApprovalState is nil, ignoring")
+		return nil
+	}
+
+	content := strings.TrimSpace(msg.PlanContent)
+	if content == "" {
+		return func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    "Edited plan is empty - keeping the original plan",
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		}
+	}
+	if content == strings.TrimSpace(planApprovalState.PlanContent) {
+		return nil
+	}
+
+	s.stateManager.SetPlanApprovalContent(content)
+	if updater, ok := s.conversationRepo.(planRepoUpdater); ok {
+		updater.UpdatePendingPlanContent(content)
+	}
+
+	statusMessage := "Plan updated - use arrow keys to select and Enter to confirm"
+	if err := s.savePlan(planApprovalState.PlanID, content); err != nil {
+		logger.Error("failed to save edited plan", "plan_id", planApprovalState.PlanID, "error", err)
+		statusMessage = fmt.Sprintf("Plan updated but not saved to plan storage: %v", err)
+	}
+
+	return tea.Batch(
+		func() tea.Msg {
+			return domain.UpdateHistoryEvent{
+				History: s.conversationRepo.GetMessages(),
+			}
+		},
+		func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message:    statusMessage,
+				Spinner:    false,
+				StatusType: domain.StatusDefault,
+			}
+		},
+	)
+}
+
+// savePlan writes the edited plan over the stored record. The plan markdown
+// starts with the title H1 the RequestPlanApproval tool added; it becomes the
+// record's title again and the rest its body.
+func (s *Service) savePlan(planID, content string) error {
+	if s.plans == nil || planID == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	record, err := s.plans.LoadPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+
+	body := content
+	if heading, rest, _ := strings.Cut(content, "\n"); strings.HasPrefix(heading, "# ") {
+		if title := strings.TrimSpace(strings.TrimPrefix(heading, "# ")); title != "" {
+			record.Title = title
+		}
+		body = strings.TrimSpace(rest)
+	}
+	record.Body = body
+
+	return s.plans.SavePlan(ctx, record)
+}
+
 // applyPlanDecision performs the agent-mode + session-state side effects for
 // each plan-approval action and returns the status string + restart flag.
 func (s *Service) applyPlanDecision(action domain.PlanApprovalAction) (string, bool) {
@@ -219,7 +319,10 @@ func (s *Service) HandleComputerUseResumed(_ domain.ComputerUseResumedEvent) (te
 // addHiddenContinueMessage injects the plan-approval continuation prompt.
 func (s *Service) addHiddenContinueMessage() {
 	logger.Info("addHiddenContinueMessage called")
-	const planContinuePrompt = "The plan has been approved. Please proceed with executing it step by step. Start by taking the first action required to implement the plan."
+	planContinuePrompt := "The plan has been approved. Please proceed with executing it step by step. Start by taking the first action required to implement the plan."
+	if reminder := domain.PlanStepsReminder(s.stateManager.GetPlanSteps()); reminder != "" {
+		planContinuePrompt += " " + reminder
+	}
 
 	if err := s.addHiddenContinue(planContinuePrompt); err != nil {
 		logger.Error("failed to add continue message to conversation", "error", err)
@@ -1,10 +1,16 @@
 package approvalcoord
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
 	services "github.com/inference-gateway/cli/internal/services"
 	mocksdomain "github.com/inference-gateway/cli/tests/mocks/domain"
 )
@@ -129,6 +135,25 @@ func TestService_HandlePlanApprovalResponse(t *testing.T) {
 		}
 	})
 
+	t.Run("Accept tracks the plan's numbered steps and mentions them in the continue message", func(t *testing.T) {
+		svc, repo, state, _ := newCoordinator()
+		state.SetupPlanApprovalUIState("# Plan\n\n1. Add middleware\n2. Add tests", "", nil)
+
+		svc.HandlePlanApprovalResponse(domain.PlanApprovalResponseEvent{
+			Action: domain.PlanApprovalAccept,
+		})
+
+		steps := state.GetPlanSteps()
+		if len(steps) != 2 || steps[1].Content != "Add tests" {
+			t.Fatalf("expected 2 tracked plan steps, got %+v", steps)
+		}
+		msgs := repo.GetMessages()
+		content, _ := msgs[len(msgs)-1].Message.Content.AsMessageContent0()
+		if !strings.Contains(content, "UpdatePlanStep") {
+			t.Errorf("continue message should point the agent at UpdatePlanStep, got %q", content)
+		}
+	})
+
 	t.Run("Reject ends chat session, does not switch mode, does not request restart", func(t *testing.T) {
 		svc, repo, state, _ := newCoordinator()
 		state.SetAgentMode(domain.AgentModePlan)
@@ -154,6 +179,66 @@ func TestService_HandlePlanApprovalResponse(t *testing.T) {
 	})
 }
 
+func TestService_HandlePlanEdited(t *testing.T) {
+	const planID = "2026-06-28-090000-add-auth"
+
+	t.Run("edited plan replaces the UI state, plan entry and stored plan", func(t *testing.T) {
+		svc, repo, state, _ := newCoordinator()
+		plans := storage.NewMemoryStorage()
+		svc.plans = plans
+		ctx := context.Background()
+		if err := plans.SavePlan(ctx, &storage.PlanRecord{ID: planID, Title: "Add auth", Body: "1. Add middleware", CreatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+		original := "# Add auth\n\n1. Add middleware"
+		_ = repo.AddMessage(domain.ConversationEntry{
+			Message:            sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent(original)},
+			IsPlan:             true,
+			PlanApprovalStatus: domain.PlanApprovalPending,
+		})
+		state.SetupPlanApprovalUIState(original, planID, nil)
+
+		edited := "# Add token auth\n\n1. Add middleware\n2. Add tests\n"
+		if cmd := svc.HandlePlanEdited(domain.PlanEditedEvent{PlanContent: edited}); cmd == nil {
+			t.Fatal("expected a cmd refreshing the history")
+		}
+
+		want := "# Add token auth\n\n1. Add middleware\n2. Add tests"
+		if got := state.GetPlanApprovalUIState().PlanContent; got != want {
+			t.Errorf("UI plan = %q, want %q", got, want)
+		}
+		msgs := repo.GetMessages()
+		if got, _ := msgs[len(msgs)-1].Message.Content.AsMessageContent0(); got != want {
+			t.Errorf("plan entry = %q, want %q", got, want)
+		}
+		record, err := plans.LoadPlan(ctx, planID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.Title != "Add token auth" || record.Body != "1. Add middleware\n2. Add tests" {
+			t.Errorf("stored plan = %+v", record)
+		}
+	})
+
+	t.Run("empty edit keeps the original plan", func(t *testing.T) {
+		svc, _, state, _ := newCoordinator()
+		state.SetupPlanApprovalUIState("# Plan\n- step 1", planID, nil)
+
+		svc.HandlePlanEdited(domain.PlanEditedEvent{PlanContent: "  \n"})
+
+		if got := state.GetPlanApprovalUIState().PlanContent; got != "# Plan\n- step 1" {
+			t.Errorf("plan = %q, want the original", got)
+		}
+	})
+
+	t.Run("no pending plan is a no-op", func(t *testing.T) {
+		svc, _, _, _ := newCoordinator()
+		if cmd := svc.HandlePlanEdited(domain.PlanEditedEvent{PlanContent: "# Plan"}); cmd != nil {
+			t.Error("expected nil cmd without a pending plan")
+		}
+	})
+}
+
 func TestService_HandleComputerUsePaused(t *testing.T) {
 	t.Run("cancels request, marks paused, returns non-nil cmd", func(t *testing.T) {
 		svc, _, state, agent := newCoordinator()
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+const (
+	// budgetDayRefreshInterval is how long the stored daily total is reused
+	// before storage is read again.
+	budgetDayRefreshInterval = time.Minute
+
+	budgetDayLayout = "2006-01-02"
+)
+
+// BudgetService measures spending against pricing.budget and
+// pricing.daily_budget. The session spend is the current conversation's cost;
+// the daily spend is the day's total kept in the storage backend, which every
+// agent process adds its requests to, so parallel runs and restarts share it.
+type BudgetService struct {
+	config *config.PricingConfig
+	repo   domain.ConversationRepository
+	spend  storage.SpendStorage
+	now    func() time.Time
+
+	// The stored total as last read, and the session spend at that moment:
+	// the day's spend is the stored total plus what the session spent since.
+	mutex              sync.Mutex
+	dayStored          float64
+	daySessionBaseline float64
+	dayReadFor         string
+	dayReadConv        string
+	dayReadAt          time.Time
+}
+
+// NewBudgetService creates a budget service reading costs from repo and the
+// daily total from spend. Without spend storage the daily spend is the
+// session's alone.
+func NewBudgetService(cfg *config.PricingConfig, repo domain.ConversationRepository, spend storage.SpendStorage) *BudgetService {
+	return &BudgetService{
+		config: cfg,
+		repo:   repo,
+		spend:  spend,
+		now:    time.Now,
+	}
+}
+
+// BudgetStatus returns the spending against the configured budgets. It has no
+// budgets when pricing is disabled.
+func (b *BudgetService) BudgetStatus() domain.BudgetStatus {
+	if b.config == nil || !b.config.Enabled || b.repo == nil {
+		return domain.BudgetStatus{}
+	}
+
+	sessionSpent := b.repo.GetSessionCostStats().TotalCost
+	status := domain.BudgetStatus{
+		SessionSpent: sessionSpent,
+		SessionLimit: b.config.Budget.PerSession,
+		DaySpent:     sessionSpent,
+		DayLimit:     b.config.DayLimit(),
+		WarnPercent:  b.config.Budget.WarnPercent,
+	}
+	if status.DayLimit > 0 && b.spend != nil {
+		status.DaySpent = b.daySpent(sessionSpent)
+	}
+	return status
+}
+
+// RecordSpend adds amount to today's stored total. Failures are logged: a
+// lost update only makes the daily budget more lenient.
+func (b *BudgetService) RecordSpend(amount float64) {
+	if b.spend == nil || amount <= 0 {
+		return
+	}
+	day := b.now().Format(budgetDayLayout)
+	if err := b.spend.AddDailySpend(context.Background(), day, amount); err != nil {
+		logger.Warn("failed to record daily spend", "day", day, "error", err)
+	}
+}
+
+// daySpent returns today's stored total, read at most once a minute, plus
+// what the session spent since it was read. The total is read again at
+// midnight and when the conversation changes, whose spend starts over.
+func (b *BudgetService) daySpent(sessionSpent float64) float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.now()
+	day := now.Format(budgetDayLayout)
+	current := b.repo.GetCurrentConversationID()
+	if day != b.dayReadFor || current != b.dayReadConv || now.Sub(b.dayReadAt) >= budgetDayRefreshInterval {
+		stored, err := b.spend.GetDailySpend(context.Background(), day)
+		if err != nil {
+			logger.Warn("failed to read the daily spend", "day", day, "error", err)
+			if day == b.dayReadFor {
+				b.dayReadAt = now
+				return b.dayStored + max(sessionSpent-b.daySessionBaseline, 0)
+			}
+		}
+		b.dayStored = stored
+		b.daySessionBaseline = sessionSpent
+		b.dayReadFor = day
+		b.dayReadConv = current
+		b.dayReadAt = now
+	}
+	return b.dayStored + max(sessionSpent-b.daySessionBaseline, 0)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+// countingSpend counts the reads of the stored daily totals
+type countingSpend struct {
+	*storage.MemoryStorage
+	reads int
+}
+
+func (s *countingSpend) GetDailySpend(ctx context.Context, day string) (float64, error) {
+	s.reads++
+	return s.MemoryStorage.GetDailySpend(ctx, day)
+}
+
+func budgetTestPricing(budget config.BudgetConfig) *config.PricingConfig {
+	return &config.PricingConfig{
+		Enabled:  true,
+		Currency: "USD",
+		CustomPrices: map[string]config.CustomPricing{
+			"test/model": {InputPricePerMToken: 1.0, OutputPricePerMToken: 1.0},
+		},
+		Budget: budget,
+	}
+}
+
+func TestBudgetService_SessionBudget(t *testing.T) {
+	cfg := budgetTestPricing(config.BudgetConfig{PerSession: 5, WarnPercent: 80})
+	repo := NewInMemoryConversationRepository(nil, NewPricingService(cfg))
+	budget := NewBudgetService(cfg, repo, nil)
+
+	if level := budget.BudgetStatus().Level(); level != domain.BudgetOK {
+		t.Errorf("level with nothing spent = %d, want BudgetOK", level)
+	}
+
+	if err := repo.AddTokenUsage("test/model", 4_000_000, 100_000, 4_100_000, 0); err != nil {
+		t.Fatal(err)
+	}
+	status := budget.BudgetStatus()
+	if status.Level() != domain.BudgetWarning {
+		t.Errorf("level at $%.2f of $5 = %d, want BudgetWarning", status.SessionSpent, status.Level())
+	}
+	if name, limit, _ := status.Usage(); name != "session" || limit != 5 {
+		t.Errorf("Usage() = %q, %v, want session, 5", name, limit)
+	}
+	if got := status.Exceeds(0.5); got != "" {
+		t.Errorf("Exceeds(0.5) = %q, want no budget exceeded", got)
+	}
+	if got := status.Exceeds(1); got != "session" {
+		t.Errorf("Exceeds(1) = %q, want session", got)
+	}
+
+	if err := repo.AddTokenUsage("test/model", 1_000_000, 0, 1_000_000, 0); err != nil {
+		t.Fatal(err)
+	}
+	if level := budget.BudgetStatus().Level(); level != domain.BudgetExceeded {
+		t.Errorf("level past the budget = %d, want BudgetExceeded", level)
+	}
+
+	cfg.Enabled = false
+	if budget.BudgetStatus().HasBudget() {
+		t.Error("budgets must be off while pricing is disabled")
+	}
+}
+
+func TestBudgetService_DailyBudget(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	cfg := budgetTestPricing(config.BudgetConfig{PerDay: 10, WarnPercent: 80})
+	repo := NewInMemoryConversationRepository(nil, NewPricingService(cfg))
+	spend := &countingSpend{MemoryStorage: storage.NewMemoryStorage()}
+	budget := NewBudgetService(cfg, repo, spend)
+	budget.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if err := spend.AddDailySpend(ctx, "2026-03-10", 5.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := spend.AddDailySpend(ctx, "2026-03-09", 40); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.AddTokenUsage("test/model", 1_000_000, 0, 1_000_000, 0); err != nil {
+		t.Fatal(err)
+	}
+	status := budget.BudgetStatus()
+	if status.DaySpent != 5.5 {
+		t.Errorf("DaySpent = %v, want 5.5 (the stored total for today)", status.DaySpent)
+	}
+
+	if err := repo.AddTokenUsage("test/model", 1_000_000, 0, 1_000_000, 0); err != nil {
+		t.Fatal(err)
+	}
+	budget.RecordSpend(1)
+	status = budget.BudgetStatus()
+	if status.DaySpent != 6.5 {
+		t.Errorf("DaySpent = %v, want 6.5 (stored total plus this session's spend since)", status.DaySpent)
+	}
+	if name, _, percent := status.Usage(); name != "daily" || percent != 65 {
+		t.Errorf("Usage() = %q, %v%%, want daily, 65%%", name, percent)
+	}
+	if spend.reads != 1 {
+		t.Errorf("storage read %d times, want the day's total reused within a minute", spend.reads)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if status := budget.BudgetStatus(); status.DaySpent != 6.5 {
+		t.Errorf("DaySpent after a refresh = %v, want 6.5 without counting the recorded spend twice", status.DaySpent)
+	}
+	if spend.reads != 2 {
+		t.Errorf("storage read %d times, want a refresh after a minute", spend.reads)
+	}
+
+	now = now.Add(10 * time.Hour)
+	if status := budget.BudgetStatus(); status.DaySpent != 0 {
+		t.Errorf("DaySpent on a new day = %v, want 0", status.DaySpent)
+	}
+}
+
+func TestBudgetService_DailyBudgetLimit(t *testing.T) {
+	cfg := budgetTestPricing(config.BudgetConfig{PerDay: 20})
+	cfg.DailyBudget = 8
+	budget := NewBudgetService(cfg, NewInMemoryConversationRepository(nil, NewPricingService(cfg)), nil)
+
+	if limit := budget.BudgetStatus().DayLimit; limit != 8 {
+		t.Errorf("DayLimit = %v, want the lower of daily_budget and budget.per_day", limit)
+	}
+
+	cfg.Budget.PerDay = 0
+	if limit := budget.BudgetStatus().DayLimit; limit != 8 {
+		t.Errorf("DayLimit with only daily_budget = %v, want 8", limit)
+	}
+}
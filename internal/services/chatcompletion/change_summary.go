@@ -0,0 +1,145 @@
+package chatcompletion
+
+import (
+	"strings"
+	"time"
+
+	udiff "github.com/aymanbagabas/go-udiff"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// BuildChangeSummary collects the files the successful Write, Edit, MultiEdit
+// and Delete calls among entries changed, and the Bash commands they ran.
+// ok is false when no file changed.
+func BuildChangeSummary(entries []domain.ConversationEntry) (summary domain.ChangeSummary, ok bool) {
+	byPath := make(map[string]int)
+	add := func(change domain.FileChange) {
+		if change.Path == "" {
+			return
+		}
+		i, seen := byPath[change.Path]
+		if !seen {
+			byPath[change.Path] = len(summary.Files)
+			summary.Files = append(summary.Files, change)
+			return
+		}
+		f := &summary.Files[i]
+		f.Added += change.Added
+		f.Removed += change.Removed
+		f.Created = f.Created || change.Created
+		f.Deleted = change.Deleted
+	}
+
+	for _, entry := range entries {
+		te := entry.ToolExecution
+		if te == nil || !te.Success || te.Rejected || isUserInitiatedBashEntry(entry) {
+			continue
+		}
+		switch te.ToolName {
+		case "Edit":
+			add(editChange(te))
+		case "MultiEdit":
+			add(multiEditChange(te))
+		case "Write":
+			add(writeChange(te))
+		case "Delete":
+			for _, change := range deleteChanges(te) {
+				add(change)
+			}
+		case "Bash":
+			if command, _ := te.Arguments["command"].(string); strings.TrimSpace(command) != "" {
+				summary.Commands = append(summary.Commands, strings.TrimSpace(command))
+			}
+		}
+	}
+	return summary, len(summary.Files) > 0
+}
+
+// changeSummaryEntry is the conversation entry that shows summary
+func changeSummaryEntry(summary domain.ChangeSummary) domain.ConversationEntry {
+	return domain.ConversationEntry{
+		Message: sdk.Message{
+			Role:    sdk.Assistant,
+			Content: sdk.NewMessageContent(summary.String()),
+		},
+		Time:          time.Now(),
+		ChangeSummary: &summary,
+	}
+}
+
+func editChange(te *domain.ToolExecutionResult) domain.FileChange {
+	path, _ := te.Arguments["file_path"].(string)
+	oldString, _ := te.Arguments["old_string"].(string)
+	newString, _ := te.Arguments["new_string"].(string)
+
+	added, removed := lineDelta(oldString, newString)
+	if result, ok := te.Data.(*domain.EditToolResult); ok && result.ReplacedCount > 1 {
+		added *= result.ReplacedCount
+		removed *= result.ReplacedCount
+	}
+	return domain.FileChange{Path: path, Added: added, Removed: removed}
+}
+
+func multiEditChange(te *domain.ToolExecutionResult) domain.FileChange {
+	path, _ := te.Arguments["file_path"].(string)
+	change := domain.FileChange{Path: path}
+
+	edits, _ := te.Arguments["edits"].([]any)
+	for _, raw := range edits {
+		edit, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		oldString, _ := edit["old_string"].(string)
+		newString, _ := edit["new_string"].(string)
+		added, removed := lineDelta(oldString, newString)
+		change.Added += added
+		change.Removed += removed
+	}
+	return change
+}
+
+func writeChange(te *domain.ToolExecutionResult) domain.FileChange {
+	path, _ := te.Arguments["file_path"].(string)
+	content, _ := te.Arguments["content"].(string)
+	change := domain.FileChange{Path: path, Added: countLines(content)}
+	if result, ok := te.Data.(*domain.FileWriteToolResult); ok {
+		change.Created = result.Created
+	}
+	return change
+}
+
+func deleteChanges(te *domain.ToolExecutionResult) []domain.FileChange {
+	if result, ok := te.Data.(*domain.DeleteToolResult); ok && len(result.DeletedFiles) > 0 {
+		changes := make([]domain.FileChange, 0, len(result.DeletedFiles))
+		for _, path := range result.DeletedFiles {
+			changes = append(changes, domain.FileChange{Path: path, Deleted: true})
+		}
+		return changes
+	}
+	path, _ := te.Arguments["path"].(string)
+	return []domain.FileChange{{Path: path, Deleted: true}}
+}
+
+// lineDelta counts the lines a line-level diff from before to after adds
+// and removes
+func lineDelta(before, after string) (added, removed int) {
+	for _, edit := range udiff.Lines(before, after) {
+		removed += countLines(before[edit.Start:edit.End])
+		added += countLines(edit.New)
+	}
+	return added, removed
+}
+
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	n := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+package chatcompletion
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func toolEntry(name string, args map[string]any, data any) domain.ConversationEntry {
+	return domain.ConversationEntry{
+		Message: sdk.Message{Role: sdk.Tool, Content: sdk.NewMessageContent("ok")},
+		ToolExecution: &domain.ToolExecutionResult{
+			ToolName:  name,
+			Arguments: args,
+			Success:   true,
+			Data:      data,
+		},
+	}
+}
+
+func TestBuildChangeSummary(t *testing.T) {
+	failed := toolEntry("Write", map[string]any{"file_path": "failed.go", "content": "x\n"}, nil)
+	failed.ToolExecution.Success = false
+
+	entries := []domain.ConversationEntry{
+		toolEntry("Read", map[string]any{"file_path": "main.go"}, nil),
+		toolEntry("Edit", map[string]any{
+			"file_path":  "main.go",
+			"old_string": "a\nb\n",
+			"new_string": "a\nc\nd\n",
+		}, &domain.EditToolResult{ReplacedCount: 1}),
+		toolEntry("Write", map[string]any{"file_path": "new.go", "content": "package x\n\nfunc X() {}\n"}, &domain.FileWriteToolResult{Created: true}),
+		toolEntry("MultiEdit", map[string]any{
+			"file_path": "main.go",
+			"edits": []any{
+				map[string]any{"old_string": "x", "new_string": "y"},
+			},
+		}, nil),
+		toolEntry("Delete", map[string]any{"path": "old"}, &domain.DeleteToolResult{DeletedFiles: []string{"old/a.go", "old/b.go"}}),
+		toolEntry("Bash", map[string]any{"command": "go test ./..."}, nil),
+		failed,
+	}
+
+	summary, ok := BuildChangeSummary(entries)
+	if !ok {
+		t.Fatal("expected a summary")
+	}
+	want := []domain.FileChange{
+		{Path: "main.go", Added: 3, Removed: 2},
+		{Path: "new.go", Added: 3, Created: true},
+		{Path: "old/a.go", Deleted: true},
+		{Path: "old/b.go", Deleted: true},
+	}
+	if len(summary.Files) != len(want) {
+		t.Fatalf("Files = %+v, want %+v", summary.Files, want)
+	}
+	for i := range want {
+		if summary.Files[i] != want[i] {
+			t.Errorf("Files[%d] = %+v, want %+v", i, summary.Files[i], want[i])
+		}
+	}
+	if len(summary.Commands) != 1 || summary.Commands[0] != "go test ./..." {
+		t.Errorf("Commands = %v", summary.Commands)
+	}
+	if got := summary.Headline(); got != "4 files changed (+6 -2), 1 command run" {
+		t.Errorf("Headline() = %q", got)
+	}
+
+	if _, ok := BuildChangeSummary(entries[5:]); ok {
+		t.Error("a turn that only ran commands should have no summary")
+	}
+}
+
+func TestRunner_HandleChatComplete_AddsChangeSummary(t *testing.T) {
+	runner, repo, state, _, _ := newRunnerForTest()
+	_ = state.StartChatSession("req-1", "model", make(chan domain.ChatEvent))
+
+	_ = repo.AddMessage(toolEntry("Write", map[string]any{"file_path": "before.go", "content": "x\n"}, nil))
+	_ = repo.AddMessage(domain.ConversationEntry{
+		Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("add a file")},
+	})
+	_ = repo.AddMessage(toolEntry("Write", map[string]any{"file_path": "a.go", "content": "x\n"}, nil))
+
+	_ = runner.HandleChatComplete(domain.ChatCompleteEvent{RequestID: "req-1", Timestamp: time.Now()})
+
+	entries := repo.GetMessages()
+	last := entries[len(entries)-1]
+	if last.ChangeSummary == nil {
+		t.Fatalf("expected a change summary entry, got %+v", last)
+	}
+	if len(last.ChangeSummary.Files) != 1 || last.ChangeSummary.Files[0].Path != "a.go" {
+		t.Errorf("summary should cover only the last turn, got %+v", last.ChangeSummary.Files)
+	}
+	content, _ := last.Message.Content.AsMessageContent0()
+	if !strings.HasPrefix(content, "Changes: 1 file changed") {
+		t.Errorf("content = %q", content)
+	}
+	if messages := BuildAgentMessagesFromEntries(entries); len(messages) != len(entries)-1 {
+		t.Errorf("the summary entry must not be sent to the model")
+	}
+
+	_ = runner.HandleChatComplete(domain.ChatCompleteEvent{RequestID: "req-1", Timestamp: time.Now()})
+	if got := len(repo.GetMessages()); got != len(entries) {
+		t.Errorf("a turn with no new changes should add no summary, got %d entries want %d", got, len(entries))
+	}
+}
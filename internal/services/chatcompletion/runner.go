@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -40,6 +41,17 @@ type Runner struct {
 
 	pendingRestoration   string
 	pendingRestorationMu sync.RWMutex
+
+	nextTools   []string
+	nextToolsMu sync.Mutex
+
+	// Retry offer: the models to retry a failed turn with are the
+	// configured fallbacks, then the other models used this session, most
+	// recent first. retryModel is the one offered for the last failure.
+	modelFallbacks []string
+	usedModels     []string
+	retryModel     string
+	retryMu        sync.Mutex
 }
 
 // Options bundles the dependencies needed to construct a Runner.
@@ -49,6 +61,7 @@ type Options struct {
 	ModelService     domain.ModelService
 	StateManager     stateManager
 	Listener         domain.ChatEventListener
+	ModelFallbacks   []string
 }
 
 // NewRunner creates a new ChatCompletionRunner.
@@ -59,6 +72,7 @@ func NewRunner(opts Options) *Runner {
 		modelService:     opts.ModelService,
 		stateManager:     opts.StateManager,
 		listener:         opts.Listener,
+		modelFallbacks:   opts.ModelFallbacks,
 	}
 }
 
@@ -80,6 +94,8 @@ func (r *Runner) Start(holder domain.BashDetachChannelHolder) tea.Cmd {
 			}
 		}
 
+		r.noteModel(currentModel)
+
 		entries := r.conversationRepo.GetMessages()
 		messages := BuildAgentMessagesFromEntries(entries)
 
@@ -90,6 +106,7 @@ func (r *Runner) Start(holder domain.BashDetachChannelHolder) tea.Cmd {
 			Model:      currentModel,
 			Messages:   messages,
 			IsChatMode: true,
+			Tools:      r.takeNextTools(),
 		}
 
 		ctx = domain.WithChatHandler(ctx, holder)
@@ -121,6 +138,22 @@ func (r *Runner) SetPendingRestoration(originalModel string) {
 	r.pendingRestoration = originalModel
 }
 
+// SetNextRequestTools limits the tools offered to the model to tools for the
+// next completion only, e.g. for a markdown command declaring its tools.
+func (r *Runner) SetNextRequestTools(tools []string) {
+	r.nextToolsMu.Lock()
+	defer r.nextToolsMu.Unlock()
+	r.nextTools = tools
+}
+
+func (r *Runner) takeNextTools() []string {
+	r.nextToolsMu.Lock()
+	defer r.nextToolsMu.Unlock()
+	tools := r.nextTools
+	r.nextTools = nil
+	return tools
+}
+
 // HandleChatStart transitions chat status to Starting and emits the initial
 // "Starting response..." status. Clearing the orchestrator's active-tool
 // indicator is the orchestrator's responsibility (see ChatHandler wrapper).
@@ -196,6 +229,10 @@ func (r *Runner) HandleChatComplete(msg domain.ChatCompleteEvent) tea.Cmd {
 		_ = r.stateManager.UpdateChatStatus(domain.ChatStatusWaitingTools)
 	}
 
+	if msg.Cancelled || len(msg.ToolCalls) == 0 {
+		r.addChangeSummary()
+	}
+
 	cmds := []tea.Cmd{
 		func() tea.Msg {
 			history := r.conversationRepo.GetMessages()
@@ -237,6 +274,29 @@ func (r *Runner) HandleChatComplete(msg domain.ChatCompleteEvent) tea.Cmd {
 	return tea.Sequence(cmds...)
 }
 
+// addChangeSummary appends a summary of what the turn that just ended
+// changed, when it changed any file. The turn is everything since the last
+// user message or earlier summary.
+func (r *Runner) addChangeSummary() {
+	entries := r.conversationRepo.GetMessages()
+	start := len(entries)
+	for start > 0 {
+		prev := entries[start-1]
+		if prev.ChangeSummary != nil || (prev.Message.Role == sdk.User && !isUserInitiatedBashEntry(prev)) {
+			break
+		}
+		start--
+	}
+
+	summary, ok := BuildChangeSummary(entries[start:])
+	if !ok {
+		return
+	}
+	if err := r.conversationRepo.AddMessage(changeSummaryEntry(summary)); err != nil {
+		logger.Error("failed to add change summary", "error", err)
+	}
+}
+
 // writeSubagentResultFile lets an interactive subagent's `infer chat` hand its
 // last assistant message back to the parent Agent tool. When launched as an
 // interactive subagent the parent sets INFER_SUBAGENT_RESULT_FILE; on each fully
@@ -315,7 +375,8 @@ func lastAssistantText(entries []domain.ConversationEntry) string {
 }
 
 // HandleChatError tears down session state and emits a sticky error event
-// (with a friendlier message for "timed out" errors).
+// (with a friendlier message for "timed out" errors) offering to retry the
+// turn on an alternate model.
 func (r *Runner) HandleChatError(msg domain.ChatErrorEvent) tea.Cmd {
 	r.writeSubagentResultFileError(msg.Error)
 	_ = r.stateManager.UpdateChatStatus(domain.ChatStatusError)
@@ -329,12 +390,54 @@ func (r *Runner) HandleChatError(msg domain.ChatErrorEvent) tea.Cmd {
 		errorMsg = fmt.Sprintf("⏰ %v\n\nSuggestions:\n• Try breaking your request into smaller parts\n• Check if the server is overloaded\n• Verify your network connection", msg.Error)
 	}
 
+	retryModel := r.offerRetry(r.modelService.GetCurrentModel())
 	return func() tea.Msg {
 		return domain.ShowErrorEvent{
-			Error:  errorMsg,
-			Sticky: true,
+			Error:      errorMsg,
+			Sticky:     true,
+			RetryModel: retryModel,
+		}
+	}
+}
+
+// noteModel records model as used this session and withdraws any retry
+// offer, which only stands until the next turn starts.
+func (r *Runner) noteModel(model string) {
+	r.retryMu.Lock()
+	defer r.retryMu.Unlock()
+	r.retryModel = ""
+	r.usedModels = append(slices.DeleteFunc(r.usedModels, func(m string) bool { return m == model }), model)
+}
+
+// offerRetry picks the model to offer for retrying a turn that failed on
+// failedModel and remembers it for TakeRetryModel.
+func (r *Runner) offerRetry(failedModel string) string {
+	r.retryMu.Lock()
+	defer r.retryMu.Unlock()
+	r.retryModel = ""
+	for _, model := range r.modelFallbacks {
+		if model != failedModel {
+			r.retryModel = model
+			return model
+		}
+	}
+	for i := len(r.usedModels) - 1; i >= 0; i-- {
+		if model := r.usedModels[i]; model != failedModel {
+			r.retryModel = model
+			return model
 		}
 	}
+	return ""
+}
+
+// TakeRetryModel returns the model offered for retrying the last failed
+// turn and withdraws the offer, so one keystroke retries once.
+func (r *Runner) TakeRetryModel() string {
+	r.retryMu.Lock()
+	defer r.retryMu.Unlock()
+	model := r.retryModel
+	r.retryModel = ""
+	return model
 }
 
 // HandleOptimizationStatus surfaces the "Optimizing conversation..." status
@@ -521,7 +624,7 @@ func BuildAgentMessagesFromEntries(entries []domain.ConversationEntry) []sdk.Mes
 		if isUserInitiatedBashEntry(entry) {
 			continue
 		}
-		if entry.PendingToolCall != nil {
+		if entry.PendingToolCall != nil || entry.ChangeSummary != nil {
 			continue
 		}
 		msg := entry.Message
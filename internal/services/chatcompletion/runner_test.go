@@ -1,6 +1,7 @@
 package chatcompletion
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -408,3 +409,61 @@ func TestRunner_SetPendingRestoration_RestoresOnComplete(t *testing.T) {
 		}
 	})
 }
+
+func TestRunner_SetNextRequestTools_AppliesToOneRequest(t *testing.T) {
+	runner, _, _, agent, model := newRunnerForTest()
+	model.GetCurrentModelReturns("openai/gpt-4o")
+	agent.RunWithStreamReturns(make(chan domain.ChatEvent), nil)
+
+	runner.SetNextRequestTools([]string{"Read", "Grep"})
+	_ = runner.Start(nil)()
+	_ = runner.Start(nil)()
+
+	if agent.RunWithStreamCallCount() != 2 {
+		t.Fatalf("expected 2 requests, got %d", agent.RunWithStreamCallCount())
+	}
+	if _, req := agent.RunWithStreamArgsForCall(0); strings.Join(req.Tools, ",") != "Read,Grep" {
+		t.Errorf("first request tools = %v, want [Read Grep]", req.Tools)
+	}
+	if _, req := agent.RunWithStreamArgsForCall(1); req.Tools != nil {
+		t.Errorf("second request must offer every tool again, got %v", req.Tools)
+	}
+}
+
+func TestRunner_HandleChatError_OffersRetryModel(t *testing.T) {
+	runner, _, _, agent, model := newRunnerForTest()
+	agent.RunWithStreamReturns(make(chan domain.ChatEvent), nil)
+
+	model.GetCurrentModelReturns("openai/gpt-4o")
+	_ = runner.Start(nil)()
+	model.GetCurrentModelReturns("anthropic/claude-sonnet-4")
+	_ = runner.Start(nil)()
+
+	msg := runner.HandleChatError(domain.ChatErrorEvent{RequestID: "r", Error: errors.New("context length exceeded")})()
+	errEvt, ok := msg.(domain.ShowErrorEvent)
+	if !ok {
+		t.Fatalf("expected ShowErrorEvent, got %T", msg)
+	}
+	if errEvt.RetryModel != "openai/gpt-4o" {
+		t.Errorf("RetryModel = %q, want the other model used this session", errEvt.RetryModel)
+	}
+
+	if got := runner.TakeRetryModel(); got != "openai/gpt-4o" {
+		t.Errorf("TakeRetryModel() = %q, want openai/gpt-4o", got)
+	}
+	if got := runner.TakeRetryModel(); got != "" {
+		t.Errorf("the retry offer must be taken only once, got %q", got)
+	}
+
+	runner.modelFallbacks = []string{"anthropic/claude-sonnet-4", "groq/llama-3.3-70b"}
+	_ = runner.HandleChatError(domain.ChatErrorEvent{RequestID: "r", Error: errors.New("overloaded")})
+	if got := runner.TakeRetryModel(); got != "groq/llama-3.3-70b" {
+		t.Errorf("configured fallbacks come first, skipping the failed model; got %q", got)
+	}
+
+	_ = runner.HandleChatError(domain.ChatErrorEvent{RequestID: "r", Error: errors.New("overloaded")})
+	_ = runner.Start(nil)()
+	if got := runner.TakeRetryModel(); got != "" {
+		t.Errorf("starting a new turn must withdraw the offer, got %q", got)
+	}
+}
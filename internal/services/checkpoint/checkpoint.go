@@ -0,0 +1,287 @@
+// Package checkpoint snapshots the workspace before each chat turn so /rewind
+// can put the files and the conversation back to any earlier turn.
+//
+// A checkpoint is a git tree object written through a scratch index seeded
+// from the real one (so unchanged files are not rehashed). It covers tracked
+// and untracked files but not ignored ones, and never touches HEAD, the
+// user's index, stashes or refs. The trees are unreferenced, which keeps them
+// out of `git log` and `git stash list`; git's default two-week prune grace
+// period keeps them for far longer than a session. Outside a git work tree
+// checkpoints are disabled.
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gitTimeout bounds every git command a checkpoint or restore runs
+const gitTimeout = 30 * time.Second
+
+// ErrNotGitRepo is returned when the workspace is not inside a git work tree
+var ErrNotGitRepo = errors.New("checkpoints need a git work tree")
+
+// Checkpoint is the workspace and conversation state before one turn
+type Checkpoint struct {
+	// Turn numbers checkpoints from 1 within the conversation
+	Turn int
+	// Entries is the conversation length before the turn's user message
+	Entries int
+	// Prompt is the user message that started the turn
+	Prompt    string
+	Tree      string
+	CreatedAt time.Time
+}
+
+// Store keeps the current conversation's checkpoints for one workspace;
+// switching conversations starts over. A nil *Store is a valid, disabled
+// store.
+type Store struct {
+	workdir string
+
+	mu             sync.Mutex
+	root           string
+	conversationID string
+	checkpoints    []Checkpoint
+}
+
+// New returns a store snapshotting the git work tree containing workdir
+// (process cwd when empty), or nil outside a work tree
+func New(workdir string) *Store {
+	s := &Store{workdir: workdir}
+	if _, err := s.workTree(context.Background()); err != nil {
+		return nil
+	}
+	return s
+}
+
+// Create snapshots the workspace as the checkpoint for the conversation's
+// next turn
+func (s *Store) Create(ctx context.Context, conversationID string, entries int, prompt string) (Checkpoint, error) {
+	if s == nil {
+		return Checkpoint{}, ErrNotGitRepo
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tree, err := s.snapshot(ctx)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	if conversationID != s.conversationID {
+		s.conversationID = conversationID
+		s.checkpoints = nil
+	}
+	cp := Checkpoint{
+		Turn:      len(s.checkpoints) + 1,
+		Entries:   entries,
+		Prompt:    prompt,
+		Tree:      tree,
+		CreatedAt: time.Now(),
+	}
+	s.checkpoints = append(s.checkpoints, cp)
+	return cp, nil
+}
+
+// List returns the conversation's checkpoints, oldest first
+func (s *Store) List(conversationID string) []Checkpoint {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if conversationID != s.conversationID {
+		return nil
+	}
+	return append([]Checkpoint(nil), s.checkpoints...)
+}
+
+// Restore puts the workspace back to how it was before turn and drops that
+// checkpoint and every later one; the caller rewinds the conversation to the
+// returned checkpoint's Entries. Files created since are removed, changed and
+// deleted ones are written back; ignored files and the index are left alone.
+func (s *Store) Restore(ctx context.Context, conversationID string, turn int) (Checkpoint, error) {
+	if s == nil {
+		return Checkpoint{}, ErrNotGitRepo
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := len(s.checkpoints)
+	if conversationID != s.conversationID {
+		count = 0
+	}
+	if turn < 1 || turn > count {
+		return Checkpoint{}, fmt.Errorf("no checkpoint for turn %d (have %d)", turn, count)
+	}
+	cp := s.checkpoints[turn-1]
+
+	current, err := s.snapshot(ctx)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	if current != cp.Tree {
+		if err := s.checkout(ctx, cp.Tree, current); err != nil {
+			return Checkpoint{}, err
+		}
+	}
+
+	s.checkpoints = s.checkpoints[:turn-1]
+	return cp, nil
+}
+
+// snapshot writes the work tree as a tree object and returns its hash
+func (s *Store) snapshot(ctx context.Context) (string, error) {
+	root, err := s.workTree(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	index, cleanup, err := s.scratchIndex(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	if _, err := s.git(ctx, index, "add", "-A", "--", root); err != nil {
+		return "", err
+	}
+	out, err := s.git(ctx, index, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// checkout makes the work tree match target, given current is its snapshot
+func (s *Store) checkout(ctx context.Context, target, current string) error {
+	out, err := s.git(ctx, "", "diff-tree", "-r", "-z", "--no-renames", "--name-status", target, current)
+	if err != nil {
+		return err
+	}
+
+	var restore []string
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		status, path := fields[i], fields[i+1]
+		if status == "A" {
+			if err := os.Remove(filepath.Join(s.root, path)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			removeEmptyParents(s.root, filepath.Dir(filepath.Join(s.root, path)))
+			continue
+		}
+		restore = append(restore, path)
+	}
+	if len(restore) == 0 {
+		return nil
+	}
+
+	index, cleanup, err := s.tempIndex()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if _, err := s.git(ctx, index, "read-tree", target); err != nil {
+		return err
+	}
+	_, err = s.gitStdin(ctx, index, strings.Join(restore, "\x00")+"\x00", "checkout-index", "-f", "-z", "--stdin")
+	return err
+}
+
+// workTree resolves and caches the top of the work tree
+func (s *Store) workTree(ctx context.Context) (string, error) {
+	if s.root != "" {
+		return s.root, nil
+	}
+	out, err := s.git(ctx, "", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", ErrNotGitRepo
+	}
+	s.root = strings.TrimSpace(string(out))
+	return s.root, nil
+}
+
+// scratchIndex copies the repository's index to a temp file so `git add`
+// reuses its stat cache without modifying it
+func (s *Store) scratchIndex(ctx context.Context) (string, func(), error) {
+	index, cleanup, err := s.tempIndex()
+	if err != nil {
+		return "", nil, err
+	}
+
+	out, err := s.git(ctx, "", "rev-parse", "--path-format=absolute", "--git-path", "index")
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	data, err := os.ReadFile(strings.TrimSpace(string(out)))
+	if err != nil && !os.IsNotExist(err) {
+		cleanup()
+		return "", nil, err
+	}
+	if err == nil {
+		if err := os.WriteFile(index, data, 0o600); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+	}
+	return index, cleanup, nil
+}
+
+func (s *Store) tempIndex() (string, func(), error) {
+	dir, err := os.MkdirTemp("", "infer-checkpoint-")
+	if err != nil {
+		return "", nil, err
+	}
+	return filepath.Join(dir, "index"), func() { _ = os.RemoveAll(dir) }, nil
+}
+
+func (s *Store) git(ctx context.Context, index string, args ...string) ([]byte, error) {
+	return s.gitStdin(ctx, index, "", args...)
+}
+
+// gitStdin runs git in the workspace, against index when set (GIT_INDEX_FILE)
+func (s *Store) gitStdin(ctx context.Context, index, stdin string, args ...string) ([]byte, error) {
+	cctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cctx, "git", args...)
+	cmd.Dir = s.workdir
+	if s.root != "" {
+		cmd.Dir = s.root
+	}
+	if index != "" {
+		cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+index)
+	}
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// removeEmptyParents removes dir and its parents up to root while they are
+// empty, so a rewind also drops directories the turn created
+func removeEmptyParents(root, dir string) {
+	for dir != root && strings.HasPrefix(dir, root) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
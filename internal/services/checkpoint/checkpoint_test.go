@@ -0,0 +1,149 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepo creates a git repo in a temp dir with one committed file
+// ("tracked.txt" containing "v1\n") and a staged-but-uncommitted one
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test")
+	writeFile(t, dir, "tracked.txt", "v1\n")
+	writeFile(t, dir, ".gitignore", "ignored.log\n")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed; skipping git-backed test")
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestStore_CreateAndRestore(t *testing.T) {
+	repo := newTestRepo(t)
+	writeFile(t, repo, "notes.txt", "untracked before\n")
+	statusBefore := runGit(t, repo, "status", "--porcelain")
+
+	ctx := context.Background()
+	store := New(repo)
+	first, err := store.Create(ctx, "conv-1", 0, "add a feature")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	writeFile(t, repo, "tracked.txt", "v2\n")
+	writeFile(t, repo, "pkg/new.go", "package pkg\n")
+	writeFile(t, repo, "ignored.log", "keep me\n")
+	if err := os.Remove(filepath.Join(repo, "notes.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create(ctx, "conv-1", 2, "now refactor"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	writeFile(t, repo, "tracked.txt", "v3\n")
+
+	cp, err := store.Restore(ctx, "conv-1", 1)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if cp.Turn != first.Turn || cp.Prompt != "add a feature" || cp.Entries != 0 {
+		t.Errorf("restored checkpoint = %+v", cp)
+	}
+
+	if got := readFile(t, repo, "tracked.txt"); got != "v1\n" {
+		t.Errorf("tracked.txt = %q, want v1", got)
+	}
+	if got := readFile(t, repo, "notes.txt"); got != "untracked before\n" {
+		t.Errorf("notes.txt = %q, want it restored", got)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "pkg")); !os.IsNotExist(err) {
+		t.Errorf("pkg/ created after the checkpoint should be removed, stat err = %v", err)
+	}
+	if got := readFile(t, repo, "ignored.log"); got != "keep me\n" {
+		t.Errorf("ignored files must be left alone, got %q", got)
+	}
+	if got := runGit(t, repo, "status", "--porcelain"); got != statusBefore {
+		t.Errorf("status after restore = %q, want %q", got, statusBefore)
+	}
+	if n := len(store.List("conv-1")); n != 0 {
+		t.Errorf("checkpoints after rewinding to turn 1 = %d, want 0", n)
+	}
+	if out := runGit(t, repo, "stash", "list"); out != "" {
+		t.Errorf("checkpoints must not create stashes, got %q", out)
+	}
+}
+
+func TestStore_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	outside := New(t.TempDir())
+	if outside != nil {
+		t.Fatal("New outside a git work tree should return a nil store")
+	}
+	if _, err := outside.Create(ctx, "conv-1", 0, "x"); !errors.Is(err, ErrNotGitRepo) {
+		t.Errorf("Create on a nil store: err = %v, want ErrNotGitRepo", err)
+	}
+
+	store := New(newTestRepo(t))
+	if _, err := store.Restore(ctx, "conv-1", 1); err == nil {
+		t.Error("Restore without checkpoints should fail")
+	}
+	if _, err := store.Create(ctx, "conv-1", 0, "x"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got := store.List("conv-2"); got != nil {
+		t.Errorf("another conversation's checkpoints must not be listed, got %v", got)
+	}
+	if _, err := store.Restore(ctx, "conv-2", 1); err == nil {
+		t.Error("Restore from another conversation should fail")
+	}
+	if _, err := store.Create(ctx, "conv-2", 0, "y"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got := store.List("conv-2"); len(got) != 1 || got[0].Turn != 1 {
+		t.Errorf("a new conversation starts its own turns, got %+v", got)
+	}
+
+	if outside.List("conv-1") != nil {
+		t.Error("nil store should list nothing")
+	}
+}
@@ -88,6 +88,25 @@ func (r *InMemoryConversationRepository) MarkMessageAsPlanByIndex(index int) {
 	}
 }
 
+// SetMessagePinned pins or unpins the entry at index. Only user and assistant
+// messages with text content can be pinned.
+func (r *InMemoryConversationRepository) SetMessagePinned(index int, pinned bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if index < 0 || index >= len(r.messages) {
+		return fmt.Errorf("index %d out of range (total entries: %d)", index, len(r.messages))
+	}
+
+	entry := &r.messages[index]
+	if pinned && (entry.Message.Role != sdk.User && entry.Message.Role != sdk.Assistant || domain.PinKey(entry.Message) == "") {
+		return fmt.Errorf("cannot pin %s message without text content", entry.Message.Role)
+	}
+
+	entry.Pinned = pinned
+	return nil
+}
+
 // UpdatePlanStatus updates the status of the most recent pending plan
 func (r *InMemoryConversationRepository) UpdatePlanStatus(action domain.PlanApprovalAction) {
 	r.mutex.Lock()
@@ -108,6 +127,20 @@ func (r *InMemoryConversationRepository) UpdatePlanStatus(action domain.PlanAppr
 	}
 }
 
+// UpdatePendingPlanContent replaces the content of the most recent pending
+// plan, so an edited plan is what the agent sees once it is approved
+func (r *InMemoryConversationRepository) UpdatePendingPlanContent(content string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i := len(r.messages) - 1; i >= 0; i-- {
+		if r.messages[i].IsPlan && r.messages[i].PlanApprovalStatus == domain.PlanApprovalPending {
+			r.messages[i].Message.Content = sdk.NewMessageContent(content)
+			break
+		}
+	}
+}
+
 // AddPendingToolCall adds a pending tool call entry that requires approval
 func (r *InMemoryConversationRepository) AddPendingToolCall(toolCall sdk.ChatCompletionMessageToolCall, responseChan chan domain.ApprovalAction) error {
 	r.mutex.Lock()
@@ -449,6 +482,11 @@ func (r *InMemoryConversationRepository) AddTokenUsage(model string, inputTokens
 	r.costStats.TotalOutputCost += outputCost
 	r.costStats.TotalCost += totalCost
 
+	if cachedTokens > 0 {
+		uncachedInputCost := float64(inputTokens) * r.pricingService.GetInputPrice(model) / 1_000_000.0
+		r.costStats.TotalCacheSavings += max(uncachedInputCost-inputCost, 0)
+	}
+
 	return nil
 }
 
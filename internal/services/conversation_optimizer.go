@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,13 +23,17 @@ type ConversationOptimizer struct {
 	autoAt            int
 	bufferSize        int
 	keepFirstMessages int
+	keepLastMessages  int
 	client            sdk.Client
 	config            *config.Config
 	tokenizer         *TokenizerService
 	repo              domain.ConversationRepository
 }
 
-var _ domain.ConversationOptimizer = (*ConversationOptimizer)(nil)
+var (
+	_ domain.ConversationOptimizer = (*ConversationOptimizer)(nil)
+	_ domain.CompactionPlanner     = (*ConversationOptimizer)(nil)
+)
 
 // OptimizerConfig represents configuration for the conversation optimizer
 type OptimizerConfig struct {
@@ -36,6 +41,7 @@ type OptimizerConfig struct {
 	AutoAt            int
 	BufferSize        int
 	KeepFirstMessages int
+	KeepLastMessages  int
 	Client            sdk.Client
 	Config            *config.Config
 	Tokenizer         *TokenizerService
@@ -70,6 +76,7 @@ func NewConversationOptimizer(config OptimizerConfig) domain.ConversationOptimiz
 		autoAt:            config.AutoAt,
 		bufferSize:        config.BufferSize,
 		keepFirstMessages: config.KeepFirstMessages,
+		keepLastMessages:  config.KeepLastMessages,
 		client:            config.Client,
 		config:            config.Config,
 		tokenizer:         tokenizer,
@@ -157,72 +164,266 @@ func (co *ConversationOptimizer) OptimizeMessages(messages []sdk.Message, model
 	return result
 }
 
-// smartOptimize implements the smart optimization strategy
-// It keeps the first N messages (default 2) and summarizes the rest
-func (co *ConversationOptimizer) smartOptimize(messages []sdk.Message, model string) ([]sdk.Message, error) {
-	minMessages := co.keepFirstMessages + 1
-	if len(messages) < minMessages {
-		return messages, nil
-	}
+// compactionPlan is how a strategy splits the conversation: head, pinned,
+// kept and tail stay verbatim (in that order, with the summary of summarize
+// between kept and tail); dropped messages are removed without a trace.
+// elided counts the kept tool results whose output was replaced.
+type compactionPlan struct {
+	strategy  string
+	head      []sdk.Message
+	pinned    []sdk.Message
+	kept      []sdk.Message
+	summarize []sdk.Message
+	dropped   []sdk.Message
+	tail      []sdk.Message
+	elided    []sdk.Message
+}
 
-	result := make([]sdk.Message, co.keepFirstMessages)
-	copy(result, messages[:co.keepFirstMessages])
+// changes reports whether applying the plan alters the conversation
+func (p compactionPlan) changes() bool {
+	return len(p.summarize) > 0 || len(p.dropped) > 0 || len(p.elided) > 0
+}
 
-	summaryStartIndex := co.keepFirstMessages
+// elidedToolOutput replaces the content of an older tool result under the
+// drop-tool-results-first strategy
+const elidedToolOutput = "[tool output removed during compaction]"
 
-	summaryStartIndex = co.adjustBoundaryForToolCallsAtStart(messages, summaryStartIndex)
+// strategy returns the configured compaction strategy
+func (co *ConversationOptimizer) strategy() string {
+	if co.config == nil {
+		return config.CompactStrategyPinAware
+	}
+	return co.config.Compact.EffectiveStrategy()
+}
 
-	if summaryStartIndex < co.keepFirstMessages {
-		logger.Info("adjusting kept messages due to boundary moved back",
-			"original_keep", co.keepFirstMessages,
-			"adjusted_keep", summaryStartIndex)
-		result = make([]sdk.Message, summaryStartIndex)
-		copy(result, messages[:summaryStartIndex])
-	} else if summaryStartIndex > co.keepFirstMessages {
-		logger.Info("adjusting kept messages due to boundary moved forward to include tool responses",
+// summarizerModel returns compact.summary_model or agent.routing.lightweight
+// when set, else the model the conversation runs on
+func (co *ConversationOptimizer) summarizerModel(model string) string {
+	if co.config == nil {
+		return model
+	}
+	return co.config.ModelForTask(config.TaskSummarization, model)
+}
+
+// planCompaction splits the (system-less) conversation according to the
+// configured strategy. The first keep_first_messages are always kept, with the
+// boundary moved so no tool call is separated from its results, and so are
+// pinned messages. summarize-oldest, drop-tool-results-first and
+// sliding-window also keep the last keep_last_messages; the tail never starts
+// with a tool result, whose call would otherwise be compacted away.
+func (co *ConversationOptimizer) planCompaction(messages []sdk.Message, model string) compactionPlan {
+	plan := compactionPlan{strategy: co.strategy()}
+	if len(messages) < co.keepFirstMessages+1 {
+		plan.head = messages
+		return plan
+	}
+
+	headEnd := co.adjustBoundaryForToolCallsAtStart(messages, co.keepFirstMessages)
+	if headEnd != co.keepFirstMessages {
+		logger.Info("adjusting kept messages due to tool call boundary",
 			"original_keep", co.keepFirstMessages,
-			"adjusted_keep", summaryStartIndex)
-		result = make([]sdk.Message, summaryStartIndex)
-		copy(result, messages[:summaryStartIndex])
+			"adjusted_keep", headEnd)
+	}
+	if headEnd >= len(messages) {
+		plan.head = messages
+		return plan
 	}
+	plan.head = messages[:headEnd]
 
-	if summaryStartIndex >= len(messages) {
-		return messages, nil
+	tailStart := len(messages)
+	if plan.strategy != config.CompactStrategyPinAware && co.keepLastMessages > 0 {
+		tailStart = max(headEnd, len(messages)-co.keepLastMessages)
+		for tailStart < len(messages) && messages[tailStart].Role == sdk.Tool {
+			tailStart++
+		}
 	}
+	plan.tail = messages[tailStart:]
+	middle := messages[headEnd:tailStart]
 
-	messagesToSummarize := messages[summaryStartIndex:]
+	if plan.strategy == config.CompactStrategyDropToolResults {
+		kept := make([]sdk.Message, len(middle))
+		for i, msg := range middle {
+			if msg.Role == sdk.Tool && len(messageText(msg)) > len(elidedToolOutput) {
+				plan.elided = append(plan.elided, msg)
+				msg.Content = sdk.NewMessageContent(elidedToolOutput)
+			}
+			kept[i] = msg
+		}
+		if !co.overThreshold(plan.head, kept, plan.tail, model) {
+			plan.kept = kept
+			return plan
+		}
+		plan.elided = nil
+		middle = kept
+	}
 
-	if len(messagesToSummarize) == 0 {
+	pinned, rest := co.splitPinned(middle)
+	plan.pinned = pinned
+	if plan.strategy == config.CompactStrategySlidingWindow {
+		plan.dropped = rest
+	} else {
+		plan.summarize = rest
+	}
+	return plan
+}
+
+// overThreshold reports whether the given parts still exceed auto_at percent
+// of the model's context window. An unknown window never does, so eliding tool
+// output is as far as drop-tool-results-first goes for such models.
+func (co *ConversationOptimizer) overThreshold(head, kept, tail []sdk.Message, model string) bool {
+	contextWindow, known := models.LookupContextWindow(model)
+	if !known {
+		return false
+	}
+	parts := make([]sdk.Message, 0, len(head)+len(kept)+len(tail))
+	parts = append(append(append(parts, head...), kept...), tail...)
+	return co.tokenizer.EstimateMessagesTokens(parts) >= (contextWindow*co.autoAt)/100
+}
+
+// smartOptimize applies the configured strategy: it keeps what the plan keeps,
+// replaces the messages to summarize with one LLM summary and removes the
+// dropped ones. The conversation is returned unchanged when the plan has
+// nothing to compact.
+func (co *ConversationOptimizer) smartOptimize(messages []sdk.Message, model string) ([]sdk.Message, error) {
+	plan := co.planCompaction(messages, model)
+	if !plan.changes() {
 		return messages, nil
 	}
 
+	result := make([]sdk.Message, 0, len(plan.head)+len(plan.pinned)+len(plan.kept)+len(plan.tail)+1)
+	result = append(result, plan.head...)
+	result = append(result, plan.pinned...)
+	result = append(result, plan.kept...)
+
+	if len(plan.summarize) > 0 {
+		summary, err := co.summarize(plan.summarize, model)
+		if err != nil {
+			return nil, err
+		}
+		if summary != "" {
+			wrappedSummary := formatting.WrapText(summary, 80)
+			formattedSummary := fmt.Sprintf("--- Context Summary ---\n\n%s\n\n--- End Summary ---", wrappedSummary)
+			result = append(result, sdk.Message{
+				Role:    "assistant",
+				Content: sdk.NewMessageContent(formattedSummary),
+			})
+		}
+	}
+
+	if len(plan.dropped) > 0 {
+		logger.Info("dropped messages outside the sliding window", "count", len(plan.dropped))
+	}
+	if len(plan.elided) > 0 {
+		logger.Info("removed older tool output", "count", len(plan.elided))
+	}
+
+	return append(result, plan.tail...), nil
+}
+
+// summarize asks the summarizer model for a summary of messages
+func (co *ConversationOptimizer) summarize(messages []sdk.Message, model string) (string, error) {
 	if co.client == nil {
-		return nil, fmt.Errorf("LLM client is required for conversation compaction")
+		return "", fmt.Errorf("LLM client is required for conversation compaction")
 	}
+	model = co.summarizerModel(model)
 	if model == "" {
-		return nil, fmt.Errorf("model is required for conversation compaction")
+		return "", fmt.Errorf("model is required for conversation compaction")
 	}
 
-	logger.Info("generating LLM summary for compaction", "model", model, "messages_to_summarize", len(messagesToSummarize))
-	summary, err := co.GenerateLLMSummary(messagesToSummarize, model)
+	logger.Info("generating LLM summary for compaction", "model", model, "messages_to_summarize", len(messages))
+	summary, err := co.GenerateLLMSummary(messages, model)
 	if err != nil {
 		logger.Error("failed to generate LLM summary", "error", err)
-		return nil, fmt.Errorf("failed to generate summary: %w", err)
+		return "", fmt.Errorf("failed to generate summary: %w", err)
 	}
-
 	logger.Info("lLM summary generated successfully", "summary_length", len(summary))
+	return summary, nil
+}
+
+// PreviewCompaction implements domain.CompactionPlanner: it plans a forced
+// compaction of messages without calling the summarizer.
+func (co *ConversationOptimizer) PreviewCompaction(messages []sdk.Message, model string) domain.CompactionPreview {
+	var system, conversation []sdk.Message
+	for _, msg := range messages {
+		if msg.Role == sdk.System {
+			system = append(system, msg)
+		} else {
+			conversation = append(conversation, msg)
+		}
+	}
 
-	if summary != "" {
-		wrappedSummary := formatting.WrapText(summary, 80)
-		formattedSummary := fmt.Sprintf("--- Context Summary ---\n\n%s\n\n--- End Summary ---", wrappedSummary)
-		summaryMsg := sdk.Message{
-			Role:    "assistant",
-			Content: sdk.NewMessageContent(formattedSummary),
+	plan := co.planCompaction(conversation, model)
+	kept := slices.Concat(system, plan.head, plan.pinned, plan.kept, plan.tail)
+	preview := domain.CompactionPreview{
+		Strategy:          plan.strategy,
+		Messages:          len(messages),
+		Kept:              len(kept),
+		Pinned:            len(plan.pinned),
+		Summarized:        len(plan.summarize),
+		Dropped:           len(plan.dropped),
+		ToolResultsElided: len(plan.elided),
+		TokensBefore:      co.tokenizer.EstimateMessagesTokens(messages),
+		TokensKept:        co.tokenizer.EstimateMessagesTokens(kept),
+	}
+	if len(plan.summarize) > 0 {
+		preview.SummaryModel = co.summarizerModel(model)
+	}
+
+	for _, removal := range []struct {
+		action   string
+		messages []sdk.Message
+	}{{"summarize", plan.summarize}, {"drop", plan.dropped}, {"elide", plan.elided}} {
+		for _, msg := range removal.messages {
+			preview.Removed = append(preview.Removed, domain.CompactionRemoval{
+				Role:    string(msg.Role),
+				Action:  removal.action,
+				Excerpt: excerpt(messageText(msg), 80),
+			})
 		}
-		result = append(result, summaryMsg)
 	}
+	return preview
+}
+
+// messageText returns the text content of msg, empty for multimodal content
+func messageText(msg sdk.Message) string {
+	text, err := msg.Content.AsMessageContent0()
+	if err != nil {
+		return ""
+	}
+	return text
+}
 
-	return result, nil
+// excerpt collapses whitespace in text and cuts it to limit runes
+func excerpt(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > limit {
+		return string(runes[:limit-1]) + "…"
+	}
+	return text
+}
+
+// splitPinned separates the messages the user pinned, which are kept verbatim,
+// from the ones to summarize. Pins are looked up on the repository, so without
+// one nothing is pinned. A pinned assistant message is kept without its tool
+// calls, since their results are summarized away.
+func (co *ConversationOptimizer) splitPinned(messages []sdk.Message) (pinned, rest []sdk.Message) {
+	if co.repo == nil {
+		return nil, messages
+	}
+	keys := domain.PinnedKeys(co.repo.GetMessages())
+	if len(keys) == 0 {
+		return nil, messages
+	}
+
+	for _, msg := range messages {
+		if msg.Role == sdk.Tool || !keys[domain.PinKey(msg)] {
+			rest = append(rest, msg)
+			continue
+		}
+		msg.ToolCalls = nil
+		pinned = append(pinned, msg)
+	}
+	return pinned, rest
 }
 
 // adjustBoundaryForToolCallsAtStart ensures tool call/response pairs aren't split
@@ -284,6 +485,18 @@ func (co *ConversationOptimizer) adjustBoundaryForToolCallsAtStart(messages []sd
 	return adjustedBoundary
 }
 
+// defaultSummaryPrompt is the summarizer's system prompt unless
+// compact.summary_prompt replaces it
+const defaultSummaryPrompt = `You are a conversation summarizer. Create a concise summary that preserves the essential context and progress made in the conversation.
+
+Focus on:
+- Key tasks completed or in progress
+- Important decisions or findings
+- Critical context needed to continue the conversation
+- Any unresolved issues or next steps
+
+Keep the summary brief but informative (2-3 sentences max).`
+
 // GenerateLLMSummary creates a concise summary of conversation messages using an LLM.
 // It uses the SDK client to generate an intelligent summary focused on key tasks,
 // decisions, critical context, and next steps. The summary is limited to 2-3 sentences.
@@ -293,17 +506,13 @@ func (co *ConversationOptimizer) GenerateLLMSummary(messages []sdk.Message, mode
 
 	summaryMessages := make([]sdk.Message, 0, len(messages)+2)
 
+	prompt := defaultSummaryPrompt
+	if co.config != nil && co.config.Compact.SummaryPrompt != "" {
+		prompt = co.config.Compact.SummaryPrompt
+	}
 	summaryMessages = append(summaryMessages, sdk.Message{
-		Role: sdk.System,
-		Content: sdk.NewMessageContent(`You are a conversation summarizer. Create a concise summary that preserves the essential context and progress made in the conversation.
-
-Focus on:
-- Key tasks completed or in progress
-- Important decisions or findings
-- Critical context needed to continue the conversation
-- Any unresolved issues or next steps
-
-Keep the summary brief but informative (2-3 sentences max).`),
+		Role:    sdk.System,
+		Content: sdk.NewMessageContent(prompt),
 	})
 
 	for _, msg := range messages {
@@ -1,9 +1,11 @@
 package services_test
 
 import (
+	"strings"
 	"testing"
 
 	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
 	models "github.com/inference-gateway/cli/internal/models"
 	services "github.com/inference-gateway/cli/internal/services"
 	mocks "github.com/inference-gateway/cli/tests/mocks/sdk"
@@ -530,6 +532,186 @@ func TestOptimizeMessages_ForcedCompactWorksForUnknownModel(t *testing.T) {
 		"forced compaction should reduce the message count")
 }
 
+func TestOptimizeMessages_KeepsPinnedMessages(t *testing.T) {
+	repo := services.NewInMemoryConversationRepository(nil, nil)
+	toolCalls := []sdk.ChatCompletionMessageToolCall{{ID: "call-1"}}
+	messages := []sdk.Message{
+		{Role: "user", Content: sdk.NewMessageContent("hi")},
+		{Role: "assistant", Content: sdk.NewMessageContent("hello")},
+		{Role: "user", Content: sdk.NewMessageContent("never touch the migrations folder")},
+		{Role: "assistant", Content: sdk.NewMessageContent("noted, checking"), ToolCalls: &toolCalls},
+		{Role: "tool", Content: sdk.NewMessageContent("ok"), ToolCallID: stringPtr("call-1")},
+		{Role: "user", Content: sdk.NewMessageContent("more")},
+	}
+	for i, msg := range messages {
+		require.NoError(t, repo.AddMessage(domain.ConversationEntry{Message: msg}))
+		if i == 2 || i == 3 {
+			require.NoError(t, repo.SetMessagePinned(i, true))
+		}
+	}
+
+	mockClient := createMockSDKClient(t, "Summary text")
+	optimizer := services.NewConversationOptimizer(services.OptimizerConfig{
+		Enabled:           true,
+		AutoAt:            80,
+		KeepFirstMessages: 2,
+		Client:            mockClient,
+		Config:            &config.Config{},
+		Repo:              repo,
+	})
+
+	result := optimizer.OptimizeMessages(messages, "ollama_cloud/some-unlisted-model", true)
+
+	require.Len(t, result, 5, "first two kept, two pinned kept, one summary")
+	pinnedUser, _ := result[2].Content.AsMessageContent0()
+	assert.Equal(t, "never touch the migrations folder", pinnedUser)
+	pinnedAssistant, _ := result[3].Content.AsMessageContent0()
+	assert.Equal(t, "noted, checking", pinnedAssistant)
+	assert.Nil(t, result[3].ToolCalls, "tool calls of a pinned message are summarized away")
+	summary, _ := result[4].Content.AsMessageContent0()
+	assert.Contains(t, summary, "Summary text")
+	validateNoOrphanedToolCalls(t, result)
+}
+
+// strategyConversation is a user/assistant/tool exchange long enough for every
+// compaction strategy to keep a head and a tail and compact the middle
+func strategyConversation() []sdk.Message {
+	toolCalls := []sdk.ChatCompletionMessageToolCall{
+		{ID: "call-1", Function: sdk.ChatCompletionMessageToolCallFunction{Name: "Read"}},
+	}
+	return []sdk.Message{
+		{Role: "user", Content: sdk.NewMessageContent("first")},
+		{Role: "assistant", Content: sdk.NewMessageContent("second")},
+		{Role: "user", Content: sdk.NewMessageContent("read the file")},
+		{Role: "assistant", Content: sdk.NewMessageContent("reading"), ToolCalls: &toolCalls},
+		{Role: "tool", Content: sdk.NewMessageContent("a long file body that is worth removing from context"), ToolCallID: stringPtr("call-1")},
+		{Role: "assistant", Content: sdk.NewMessageContent("done reading")},
+		{Role: "user", Content: sdk.NewMessageContent("latest question")},
+		{Role: "assistant", Content: sdk.NewMessageContent("latest answer")},
+	}
+}
+
+func TestOptimizeMessages_Strategies(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      string
+		wantContents  []string
+		wantSummaries int
+	}{
+		{
+			name:          "pin-aware summarizes everything after the head",
+			strategy:      config.CompactStrategyPinAware,
+			wantContents:  []string{"first", "second", "summary"},
+			wantSummaries: 1,
+		},
+		{
+			name:          "summarize-oldest keeps the tail",
+			strategy:      config.CompactStrategySummarizeOldest,
+			wantContents:  []string{"first", "second", "summary", "latest question", "latest answer"},
+			wantSummaries: 1,
+		},
+		{
+			name:          "sliding-window drops the middle without a summary",
+			strategy:      config.CompactStrategySlidingWindow,
+			wantContents:  []string{"first", "second", "latest question", "latest answer"},
+			wantSummaries: 0,
+		},
+		{
+			name:     "drop-tool-results-first elides tool output for an unknown window",
+			strategy: config.CompactStrategyDropToolResults,
+			wantContents: []string{"first", "second", "read the file", "reading",
+				"[tool output removed during compaction]", "done reading", "latest question", "latest answer"},
+			wantSummaries: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Compact.Strategy = tt.strategy
+			cfg.Compact.KeepLastMessages = 2
+
+			mockClient := createMockSDKClient(t, "summary")
+			optimizer := services.NewConversationOptimizer(services.OptimizerConfig{
+				Enabled:           true,
+				AutoAt:            80,
+				KeepFirstMessages: 2,
+				KeepLastMessages:  2,
+				Client:            mockClient,
+				Config:            cfg,
+			})
+
+			result := optimizer.OptimizeMessages(strategyConversation(), "ollama_cloud/some-unlisted-model", true)
+
+			contents := make([]string, len(result))
+			for i, msg := range result {
+				contents[i], _ = msg.Content.AsMessageContent0()
+				if strings.HasPrefix(contents[i], "--- Context Summary ---") {
+					contents[i] = "summary"
+				}
+			}
+			assert.Equal(t, tt.wantContents, contents)
+			assert.Equal(t, tt.wantSummaries, mockClient.GenerateContentCallCount())
+			validateNoOrphanedToolCalls(t, result)
+		})
+	}
+}
+
+func TestOptimizeMessages_SummaryModelAndPrompt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Compact.SummaryModel = "openai/gpt-4o-mini"
+	cfg.Compact.SummaryPrompt = "Summarize in one line."
+
+	mockClient := createMockSDKClient(t, "summary")
+	optimizer := services.NewConversationOptimizer(services.OptimizerConfig{
+		Enabled: true,
+		AutoAt:  80,
+		Client:  mockClient,
+		Config:  cfg,
+	})
+
+	optimizer.OptimizeMessages(strategyConversation(), "anthropic/claude-sonnet", true)
+
+	require.Equal(t, 1, mockClient.GenerateContentCallCount())
+	_, provider, model, messages := mockClient.GenerateContentArgsForCall(0)
+	assert.Equal(t, sdk.Provider("openai"), provider)
+	assert.Equal(t, "gpt-4o-mini", model)
+	prompt, _ := messages[0].Content.AsMessageContent0()
+	assert.Equal(t, "Summarize in one line.", prompt)
+}
+
+func TestPreviewCompaction(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Compact.Strategy = config.CompactStrategySummarizeOldest
+	cfg.Compact.SummaryModel = "openai/gpt-4o-mini"
+
+	mockClient := createMockSDKClient(t, "summary")
+	optimizer := services.NewConversationOptimizer(services.OptimizerConfig{
+		Enabled:           true,
+		AutoAt:            80,
+		KeepFirstMessages: 2,
+		KeepLastMessages:  2,
+		Client:            mockClient,
+		Config:            cfg,
+	})
+	planner, ok := optimizer.(domain.CompactionPlanner)
+	require.True(t, ok)
+
+	messages := append([]sdk.Message{{Role: "system", Content: sdk.NewMessageContent("system prompt")}},
+		strategyConversation()...)
+	preview := planner.PreviewCompaction(messages, "anthropic/claude-sonnet")
+
+	assert.Equal(t, 0, mockClient.GenerateContentCallCount(), "a preview never summarizes")
+	assert.Equal(t, config.CompactStrategySummarizeOldest, preview.Strategy)
+	assert.Equal(t, "openai/gpt-4o-mini", preview.SummaryModel)
+	assert.Equal(t, 9, preview.Messages)
+	assert.Equal(t, 5, preview.Kept)
+	assert.Equal(t, 4, preview.Summarized)
+	assert.Less(t, preview.TokensKept, preview.TokensBefore)
+	require.Len(t, preview.Removed, 4)
+	assert.Equal(t, domain.CompactionRemoval{Role: "user", Action: "summarize", Excerpt: "read the file"}, preview.Removed[0])
+}
+
 // Helper functions
 
 func stringPtr(s string) *string {
@@ -134,3 +134,24 @@ func TestInMemoryConversationRepository_RemovePendingToolCallByID(t *testing.T)
 		})
 	}
 }
+
+func TestInMemoryConversationRepository_SetMessagePinned(t *testing.T) {
+	repo := NewInMemoryConversationRepository(nil, nil)
+	for _, msg := range []sdk.Message{
+		{Role: sdk.User, Content: sdk.NewMessageContent("always use tabs")},
+		{Role: sdk.Tool, Content: sdk.NewMessageContent("tool output")},
+		{Role: sdk.Assistant, Content: sdk.NewMessageContent("")},
+	} {
+		assert.NoError(t, repo.AddMessage(domain.ConversationEntry{Message: msg}))
+	}
+
+	assert.NoError(t, repo.SetMessagePinned(0, true))
+	assert.True(t, repo.GetMessages()[0].Pinned)
+
+	assert.Error(t, repo.SetMessagePinned(1, true), "tool results cannot be pinned")
+	assert.Error(t, repo.SetMessagePinned(2, true), "messages without text cannot be pinned")
+	assert.Error(t, repo.SetMessagePinned(3, true), "out of range")
+
+	assert.NoError(t, repo.SetMessagePinned(0, false))
+	assert.False(t, repo.GetMessages()[0].Pinned)
+}
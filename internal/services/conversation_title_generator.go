@@ -131,10 +131,7 @@ func (g *ConversationTitleGenerator) generateTitle(ctx context.Context, entries
 		return "", fmt.Errorf("AI client not available")
 	}
 
-	model := g.config.Conversation.TitleGeneration.Model
-	if model == "" {
-		model = g.config.Agent.Model
-	}
+	model := g.config.ModelForTask(config.TaskTitleGeneration, g.config.Agent.Model)
 	if model == "" {
 		return "", fmt.Errorf("no model configured for conversation titles")
 	}
@@ -0,0 +1,101 @@
+package convimport
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+)
+
+const (
+	aiderSessionPrefix = "# aider chat started at "
+	aiderUserPrefix    = "#### "
+	aiderToolPrefix    = "> "
+)
+
+// parseAider reads a .aider.chat.history.md file, which appends one section
+// per session. User input lines start with "####", aider's own output (edits
+// applied, commands run) with ">", and everything else is the model's reply.
+// Aider's output is kept as hidden assistant text.
+func parseAider(name string, data []byte) ([]Conversation, error) {
+	var (
+		conversations []Conversation
+		conv          *Conversation
+		role          sdk.MessageRole
+		lines         []string
+	)
+
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(lines, "\n"))
+		lines = nil
+		if conv == nil || text == "" {
+			return
+		}
+		switch role {
+		case sdk.User:
+			conv.Entries = append(conv.Entries, textEntry(sdk.User, text, "", conv.CreatedAt))
+		case sdk.Tool:
+			entry := textEntry(sdk.Assistant, "[aider output]\n"+text, "", conv.CreatedAt)
+			entry.Hidden = true
+			conv.Entries = append(conv.Entries, entry)
+		default:
+			conv.Entries = append(conv.Entries, textEntry(sdk.Assistant, text, conv.Model, conv.CreatedAt))
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if started, ok := strings.CutPrefix(line, aiderSessionPrefix); ok {
+			flush()
+			at, _ := time.ParseInLocation(time.DateTime, strings.TrimSpace(started), time.Local)
+			conversations = append(conversations, Conversation{
+				SourceID:  fmt.Sprintf("%s@%s", filepath.Base(name), strings.TrimSpace(started)),
+				CreatedAt: at,
+				UpdatedAt: at,
+			})
+			conv = &conversations[len(conversations)-1]
+			role = ""
+			continue
+		}
+		if conv == nil {
+			continue
+		}
+
+		var lineRole sdk.MessageRole = sdk.Assistant
+		text := line
+		switch {
+		case strings.HasPrefix(line, aiderUserPrefix):
+			lineRole, text = sdk.User, strings.TrimPrefix(line, aiderUserPrefix)
+		case strings.HasPrefix(line, aiderToolPrefix) || line == ">":
+			lineRole, text = sdk.Tool, strings.TrimPrefix(strings.TrimPrefix(line, ">"), " ")
+			if model, ok := aiderModel(text); ok && conv.Model == "" {
+				conv.Model = model
+			}
+		case strings.TrimSpace(line) == "":
+			lines = append(lines, line)
+			continue
+		}
+
+		if lineRole != role {
+			flush()
+			role = lineRole
+		}
+		lines = append(lines, text)
+	}
+	flush()
+
+	return conversations, nil
+}
+
+// aiderModel reads the model from aider's startup banner ("Model: ..." or
+// "Main model: ...")
+func aiderModel(line string) (string, bool) {
+	for _, prefix := range []string{"Main model: ", "Model: "} {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			model, _, _ := strings.Cut(rest, " ")
+			return model, model != ""
+		}
+	}
+	return "", false
+}
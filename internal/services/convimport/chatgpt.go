@@ -0,0 +1,138 @@
+package convimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// chatGPTConversation is one conversation of a ChatGPT data export
+// (conversations.json). Messages form a tree through mapping; the branch the
+// user last saw ends at current_node.
+type chatGPTConversation struct {
+	ID          string                 `json:"id"`
+	Title       string                 `json:"title"`
+	CreateTime  float64                `json:"create_time"`
+	UpdateTime  float64                `json:"update_time"`
+	CurrentNode string                 `json:"current_node"`
+	Mapping     map[string]chatGPTNode `json:"mapping"`
+}
+
+type chatGPTNode struct {
+	Parent  string `json:"parent"`
+	Message *struct {
+		Author struct {
+			Role string `json:"role"`
+			Name string `json:"name"`
+		} `json:"author"`
+		CreateTime float64 `json:"create_time"`
+		Content    struct {
+			ContentType string            `json:"content_type"`
+			Parts       []json.RawMessage `json:"parts"`
+			Text        string            `json:"text"`
+		} `json:"content"`
+		Metadata struct {
+			ModelSlug        string `json:"model_slug"`
+			IsVisuallyHidden bool   `json:"is_visually_hidden_from_conversation"`
+		} `json:"metadata"`
+	} `json:"message"`
+}
+
+// parseChatGPT reads the current branch of each conversation. ChatGPT tool
+// messages carry no call IDs, so their output is kept as hidden assistant
+// text rather than as tool results.
+func parseChatGPT(data []byte) ([]Conversation, error) {
+	var exported []chatGPTConversation
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return nil, err
+	}
+
+	conversations := make([]Conversation, 0, len(exported))
+	for _, source := range exported {
+		conv := Conversation{
+			SourceID:  source.ID,
+			Title:     source.Title,
+			CreatedAt: unixSeconds(source.CreateTime),
+			UpdatedAt: unixSeconds(source.UpdateTime),
+		}
+
+		branch, err := chatGPTBranch(source)
+		if err != nil {
+			return nil, fmt.Errorf("conversation %q: %w", source.Title, err)
+		}
+		for _, node := range branch {
+			msg := node.Message
+			if msg == nil || msg.Metadata.IsVisuallyHidden {
+				continue
+			}
+			text := chatGPTText(msg.Content.Parts, msg.Content.Text)
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+
+			at := unixSeconds(msg.CreateTime)
+			switch msg.Author.Role {
+			case "user":
+				conv.Entries = append(conv.Entries, textEntry(sdk.User, text, "", at))
+			case "assistant":
+				if msg.Metadata.ModelSlug != "" {
+					conv.Model = msg.Metadata.ModelSlug
+				}
+				conv.Entries = append(conv.Entries, textEntry(sdk.Assistant, text, msg.Metadata.ModelSlug, at))
+			case "tool":
+				entry := textEntry(sdk.Assistant, fmt.Sprintf("[%s output]\n%s", msg.Author.Name, text), "", at)
+				entry.Hidden = true
+				conv.Entries = append(conv.Entries, entry)
+			}
+		}
+		conversations = append(conversations, conv)
+	}
+	return conversations, nil
+}
+
+// chatGPTBranch walks from current_node up to the root and returns the nodes
+// in conversation order
+func chatGPTBranch(source chatGPTConversation) ([]chatGPTNode, error) {
+	var branch []chatGPTNode
+	seen := map[string]bool{}
+	for id := source.CurrentNode; id != ""; {
+		if seen[id] {
+			return nil, fmt.Errorf("message %s is its own ancestor", id)
+		}
+		seen[id] = true
+		node, ok := source.Mapping[id]
+		if !ok {
+			break
+		}
+		branch = append(branch, node)
+		id = node.Parent
+	}
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+	return branch, nil
+}
+
+// chatGPTText joins the text parts of a message; other parts (images, files)
+// are objects and are skipped
+func chatGPTText(parts []json.RawMessage, text string) string {
+	for _, part := range parts {
+		var s string
+		if err := json.Unmarshal(part, &s); err == nil {
+			text = joinText(text, s)
+		}
+	}
+	return text
+}
+
+func unixSeconds(seconds float64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*1e9)).UTC()
+}
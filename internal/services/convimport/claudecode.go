@@ -0,0 +1,178 @@
+package convimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// claudeCodeLine is one line of a Claude Code session transcript
+// (~/.claude/projects/<project>/<session>.jsonl)
+type claudeCodeLine struct {
+	Type      string    `json:"type"`
+	SessionID string    `json:"sessionId"`
+	Timestamp time.Time `json:"timestamp"`
+	Summary   string    `json:"summary"`
+	IsMeta    bool      `json:"isMeta"`
+	Message   *struct {
+		ID      string          `json:"id"`
+		Role    string          `json:"role"`
+		Model   string          `json:"model"`
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// claudeCodeBlock is a content block of a Claude Code message
+type claudeCodeBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	Thinking  string          `json:"thinking"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+}
+
+// parseClaudeCode reads a session transcript. Assistant turns are written as
+// one line per content block sharing a message ID, so those are merged back
+// into one message; tool results become tool messages.
+func parseClaudeCode(name string, data []byte) ([]Conversation, error) {
+	conv := Conversation{SourceID: strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))}
+	lastAssistantID := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record claudeCodeLine
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, err
+		}
+
+		if record.Type == "summary" && conv.Title == "" {
+			conv.Title = record.Summary
+		}
+		if record.Message == nil || record.IsMeta || (record.Type != "user" && record.Type != "assistant") {
+			continue
+		}
+		if record.SessionID != "" {
+			conv.SourceID = record.SessionID
+		}
+		if conv.CreatedAt.IsZero() {
+			conv.CreatedAt = record.Timestamp
+		}
+		conv.UpdatedAt = record.Timestamp
+
+		blocks := claudeCodeBlocks(record.Message.Content)
+		if record.Type == "assistant" {
+			if record.Message.Model != "" {
+				conv.Model = record.Message.Model
+			}
+			if record.Message.ID == "" || record.Message.ID != lastAssistantID || len(conv.Entries) == 0 {
+				conv.Entries = append(conv.Entries, textEntry(sdk.Assistant, "", record.Message.Model, record.Timestamp))
+			}
+			lastAssistantID = record.Message.ID
+			mergeClaudeCodeAssistant(&conv.Entries[len(conv.Entries)-1], blocks)
+			continue
+		}
+
+		lastAssistantID = ""
+		conv.Entries = append(conv.Entries, claudeCodeUserEntries(blocks, record.Timestamp)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return []Conversation{conv}, nil
+}
+
+// claudeCodeBlocks decodes message content, which is either a plain string
+// or a list of blocks
+func claudeCodeBlocks(raw json.RawMessage) []claudeCodeBlock {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []claudeCodeBlock{{Type: "text", Text: text}}
+	}
+	var blocks []claudeCodeBlock
+	_ = json.Unmarshal(raw, &blocks)
+	return blocks
+}
+
+func mergeClaudeCodeAssistant(entry *domain.ConversationEntry, blocks []claudeCodeBlock) {
+	text, _ := entry.Message.Content.AsMessageContent0()
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			text = joinText(text, block.Text)
+		case "thinking":
+			entry.ReasoningContent = joinText(entry.ReasoningContent, block.Thinking)
+		case "tool_use":
+			var calls []sdk.ChatCompletionMessageToolCall
+			if entry.Message.ToolCalls != nil {
+				calls = *entry.Message.ToolCalls
+			}
+			arguments := string(block.Input)
+			if arguments == "" {
+				arguments = "{}"
+			}
+			calls = append(calls, sdk.ChatCompletionMessageToolCall{
+				ID:       block.ID,
+				Type:     sdk.Function,
+				Function: sdk.ChatCompletionMessageToolCallFunction{Name: block.Name, Arguments: arguments},
+			})
+			entry.Message.ToolCalls = &calls
+		}
+	}
+	entry.Message.Content = sdk.NewMessageContent(text)
+}
+
+func claudeCodeUserEntries(blocks []claudeCodeBlock, at time.Time) []domain.ConversationEntry {
+	var entries []domain.ConversationEntry
+	text := ""
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			text = joinText(text, block.Text)
+		case "tool_result":
+			toolCallID := block.ToolUseID
+			result := textEntry(sdk.Tool, claudeCodeResultText(block.Content), "", at)
+			result.Message.ToolCallID = &toolCallID
+			entries = append(entries, result)
+		}
+	}
+	if strings.TrimSpace(text) != "" {
+		entries = append(entries, textEntry(sdk.User, text, "", at))
+	}
+	return entries
+}
+
+// claudeCodeResultText flattens tool result content, a string or a list of
+// text blocks
+func claudeCodeResultText(raw json.RawMessage) string {
+	text := ""
+	for _, block := range claudeCodeBlocks(raw) {
+		if block.Type == "text" {
+			text = joinText(text, block.Text)
+		}
+	}
+	return text
+}
+
+func joinText(existing, text string) string {
+	if existing == "" {
+		return text
+	}
+	if text == "" {
+		return existing
+	}
+	return existing + "\n\n" + text
+}
@@ -0,0 +1,130 @@
+// Package convimport converts the session exports of other coding assistants
+// into conversation entries, so `infer import` can store them alongside the
+// CLI's own history. Roles and tool calls are mapped on a best-effort basis:
+// anything a source records that has no counterpart here (attachments,
+// images, UI metadata) is dropped.
+package convimport
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// Source is an assistant whose exports can be imported
+type Source string
+
+const (
+	SourceClaudeCode Source = "claude-code"
+	SourceChatGPT    Source = "chatgpt"
+	SourceAider      Source = "aider"
+)
+
+// Sources lists the supported sources, for help text and validation
+var Sources = []Source{SourceClaudeCode, SourceChatGPT, SourceAider}
+
+// Conversation is one conversation read from an export
+type Conversation struct {
+	// SourceID identifies the conversation within its source, so importing
+	// the same export twice can update rather than duplicate it.
+	SourceID  string
+	Title     string
+	Model     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Entries   []domain.ConversationEntry
+}
+
+// ParseSource validates a source name given by the user
+func ParseSource(name string) (Source, error) {
+	for _, source := range Sources {
+		if strings.EqualFold(name, string(source)) {
+			return source, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported source %q: must be one of %s", name, joinSources())
+}
+
+// Detect guesses the source of an export from its file name and content
+func Detect(path string, data []byte) (Source, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case strings.HasSuffix(filepath.Base(path), ".aider.chat.history.md"),
+		bytes.HasPrefix(trimmed, []byte(aiderSessionPrefix)):
+		return SourceAider, nil
+	case bytes.HasPrefix(trimmed, []byte("[")) && bytes.Contains(trimmed, []byte(`"mapping"`)):
+		return SourceChatGPT, nil
+	case filepath.Ext(path) == ".jsonl" && bytes.HasPrefix(trimmed, []byte("{")):
+		return SourceClaudeCode, nil
+	}
+	return "", fmt.Errorf("cannot tell the source of %s: pass --from %s", path, joinSources())
+}
+
+// Parse reads the conversations of an export. name is used for the
+// conversation IDs and titles of sources that do not record them.
+func Parse(source Source, name string, data []byte) ([]Conversation, error) {
+	var (
+		conversations []Conversation
+		err           error
+	)
+	switch source {
+	case SourceClaudeCode:
+		conversations, err = parseClaudeCode(name, data)
+	case SourceChatGPT:
+		conversations, err = parseChatGPT(data)
+	case SourceAider:
+		conversations, err = parseAider(name, data)
+	default:
+		return nil, fmt.Errorf("unsupported source %q", source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s export: %w", source, err)
+	}
+
+	kept := conversations[:0]
+	for _, conv := range conversations {
+		if len(conv.Entries) == 0 {
+			continue
+		}
+		if conv.Title == "" {
+			conv.Title = titleFromEntries(conv.Entries)
+		}
+		kept = append(kept, conv)
+	}
+	return kept, nil
+}
+
+func joinSources() string {
+	names := make([]string, 0, len(Sources))
+	for _, source := range Sources {
+		names = append(names, string(source))
+	}
+	return strings.Join(names, ", ")
+}
+
+// titleFromEntries titles a conversation after its first user message, the
+// way a new conversation is titled before one is generated
+func titleFromEntries(entries []domain.ConversationEntry) string {
+	for _, entry := range entries {
+		if entry.Message.Role != sdk.User {
+			continue
+		}
+		if text, err := entry.Message.Content.AsMessageContent0(); err == nil && strings.TrimSpace(text) != "" {
+			return domain.CreateTitleFromMessage(text)
+		}
+	}
+	return "Imported conversation"
+}
+
+func textEntry(role sdk.MessageRole, text, model string, at time.Time) domain.ConversationEntry {
+	return domain.ConversationEntry{
+		Message: sdk.Message{Role: role, Content: sdk.NewMessageContent(text)},
+		Model:   model,
+		Time:    at,
+	}
+}
@@ -0,0 +1,158 @@
+package convimport
+
+import (
+	"testing"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+func entryText(t *testing.T, entry domain.ConversationEntry) string {
+	t.Helper()
+	text, err := entry.Message.Content.AsMessageContent0()
+	if err != nil {
+		t.Fatalf("content is not text: %v", err)
+	}
+	return text
+}
+
+func TestParseClaudeCode(t *testing.T) {
+	data := []byte(`{"type":"summary","summary":"Fix the flaky test"}
+{"type":"user","sessionId":"abc","timestamp":"2026-10-01T10:00:00Z","message":{"role":"user","content":"why does this test fail?"}}
+{"type":"assistant","sessionId":"abc","timestamp":"2026-10-01T10:00:05Z","message":{"id":"msg_1","role":"assistant","model":"claude-sonnet","content":[{"type":"text","text":"Let me look."}]}}
+{"type":"assistant","sessionId":"abc","timestamp":"2026-10-01T10:00:06Z","message":{"id":"msg_1","role":"assistant","model":"claude-sonnet","content":[{"type":"tool_use","id":"toolu_1","name":"Read","input":{"file_path":"a_test.go"}}]}}
+{"type":"user","sessionId":"abc","timestamp":"2026-10-01T10:00:07Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":[{"type":"text","text":"package a"}]}]}}
+{"type":"user","sessionId":"abc","isMeta":true,"timestamp":"2026-10-01T10:00:08Z","message":{"role":"user","content":"<caveat>"}}
+`)
+
+	conversations, err := Parse(SourceClaudeCode, "abc.jsonl", data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(conversations) != 1 {
+		t.Fatalf("got %d conversations, want 1", len(conversations))
+	}
+	conv := conversations[0]
+	if conv.SourceID != "abc" || conv.Title != "Fix the flaky test" || conv.Model != "claude-sonnet" {
+		t.Errorf("unexpected conversation %+v", conv)
+	}
+	if len(conv.Entries) != 3 {
+		t.Fatalf("got %d entries, want user, assistant and tool", len(conv.Entries))
+	}
+
+	assistant := conv.Entries[1]
+	if entryText(t, assistant) != "Let me look." || assistant.Message.ToolCalls == nil || len(*assistant.Message.ToolCalls) != 1 {
+		t.Fatalf("assistant blocks were not merged: %+v", assistant.Message)
+	}
+	call := (*assistant.Message.ToolCalls)[0]
+	if call.ID != "toolu_1" || call.Function.Name != "Read" || call.Function.Arguments != `{"file_path":"a_test.go"}` {
+		t.Errorf("unexpected tool call %+v", call)
+	}
+
+	result := conv.Entries[2]
+	if result.Message.Role != sdk.Tool || result.Message.ToolCallID == nil || *result.Message.ToolCallID != "toolu_1" || entryText(t, result) != "package a" {
+		t.Errorf("unexpected tool result %+v", result.Message)
+	}
+}
+
+func TestParseChatGPT(t *testing.T) {
+	data := []byte(`[{
+  "id": "conv-1", "title": "Regex help", "create_time": 1759312800.5, "update_time": 1759313000,
+  "current_node": "c",
+  "mapping": {
+    "root": {"parent": null, "message": null},
+    "a": {"parent": "root", "message": {"author": {"role": "user"}, "content": {"content_type": "text", "parts": ["match digits"]}}},
+    "stale": {"parent": "a", "message": {"author": {"role": "assistant"}, "content": {"content_type": "text", "parts": ["old answer"]}}},
+    "b": {"parent": "a", "message": {"author": {"role": "tool", "name": "python"}, "content": {"content_type": "text", "parts": ["ok"]}}},
+    "c": {"parent": "b", "message": {"author": {"role": "assistant"}, "content": {"content_type": "text", "parts": ["Use \\d+"]}, "metadata": {"model_slug": "gpt-4o"}}}
+  }
+}]`)
+
+	conversations, err := Parse(SourceChatGPT, "conversations.json", data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	conv := conversations[0]
+	if conv.SourceID != "conv-1" || conv.Title != "Regex help" || conv.Model != "gpt-4o" || conv.CreatedAt.Unix() != 1759312800 {
+		t.Errorf("unexpected conversation %+v", conv)
+	}
+	if len(conv.Entries) != 3 {
+		t.Fatalf("got %d entries, want the current branch only", len(conv.Entries))
+	}
+	if !conv.Entries[1].Hidden || entryText(t, conv.Entries[2]) != `Use \d+` {
+		t.Errorf("unexpected entries %+v", conv.Entries)
+	}
+}
+
+func TestParseAider(t *testing.T) {
+	data := []byte(`
+# aider chat started at 2026-10-01 09:00:00
+
+> Aider v0.80.0
+> Main model: gpt-4o with diff edit format
+
+#### add a hello function
+#### in main.go
+
+Here is the change.
+
+> Applied edit to main.go
+
+# aider chat started at 2026-10-02 09:00:00
+
+> Aider v0.80.0
+`)
+
+	conversations, err := Parse(SourceAider, ".aider.chat.history.md", data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(conversations) != 2 {
+		t.Fatalf("got %d conversations, want 2", len(conversations))
+	}
+
+	conv := conversations[0]
+	if conv.Model != "gpt-4o" || conv.Title != "add a hello function in main.go" {
+		t.Errorf("unexpected conversation %+v", conv)
+	}
+	roles := ""
+	for _, entry := range conv.Entries {
+		role := string(entry.Message.Role)
+		if entry.Hidden {
+			role = "hidden"
+		}
+		roles += role + ","
+	}
+	if roles != "hidden,user,assistant,hidden," {
+		t.Errorf("got roles %s", roles)
+	}
+	if entryText(t, conv.Entries[1]) != "add a hello function\nin main.go" {
+		t.Errorf("user lines were not joined: %q", entryText(t, conv.Entries[1]))
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		want Source
+	}{
+		{"/repo/.aider.chat.history.md", "anything", SourceAider},
+		{"history.md", "# aider chat started at 2026-10-01 09:00:00", SourceAider},
+		{"conversations.json", `[{"title":"x","mapping":{}}]`, SourceChatGPT},
+		{"session.jsonl", `{"type":"user"}`, SourceClaudeCode},
+	}
+	for _, tt := range tests {
+		got, err := Detect(tt.path, []byte(tt.data))
+		if err != nil || got != tt.want {
+			t.Errorf("Detect(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+		}
+	}
+
+	if _, err := Detect("notes.txt", []byte("hello")); err == nil {
+		t.Error("expected an error for an unknown export")
+	}
+	if _, err := ParseSource("Claude-Code"); err != nil {
+		t.Errorf("ParseSource: %v", err)
+	}
+}
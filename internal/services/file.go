@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/inference-gateway/cli/config"
 	"github.com/inference-gateway/cli/internal/domain"
 )
 
@@ -24,6 +25,8 @@ func (s *FileServiceImpl) ListProjectFiles() ([]string, error) {
 		return nil, fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	inferignore := config.LoadInferignore(cwd)
+
 	var files []string
 	err = filepath.WalkDir(cwd, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -31,10 +34,13 @@ func (s *FileServiceImpl) ListProjectFiles() ([]string, error) {
 		}
 
 		if d.IsDir() {
+			if path != cwd && inferignore.Excludes(path) {
+				return filepath.SkipDir
+			}
 			return s.handleDirectory(d, path, cwd)
 		}
 
-		if strings.HasPrefix(d.Name(), ".") {
+		if strings.HasPrefix(d.Name(), ".") || inferignore.Excludes(path) {
 			return nil
 		}
 
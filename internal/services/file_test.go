@@ -28,6 +28,9 @@ func TestFileServiceImpl_ListProjectFiles(t *testing.T) {
 		{"node_modules/package/index.js", "module.exports = {}", false},
 		{"large_file.txt", string(make([]byte, 200*1024)), false}, // 200KB file
 		{".hidden_file.txt", "hidden", false},
+		{config.InferignoreFileName, "generated/\n*.pb.go\n", false},
+		{"generated/client.go", "package generated", false},
+		{"src/api.pb.go", "package src", false},
 	}
 
 	for _, tf := range testFiles {
@@ -94,6 +97,8 @@ func TestFileServiceImpl_ListProjectFiles(t *testing.T) {
 		"node_modules/package/index.js", // node_modules is excluded
 		"large_file.txt",                // Files over 100KB are excluded
 		".hidden_file.txt",              // Hidden files are excluded
+		"generated/client.go",           // .inferignore directory pattern
+		"src/api.pb.go",                 // .inferignore file pattern
 	}
 
 	for _, excluded := range excludedFiles {
@@ -18,6 +18,7 @@ import (
 
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 	logger "github.com/inference-gateway/cli/internal/logger"
 )
 
@@ -299,7 +300,7 @@ func (gm *GatewayManager) runContainer(ctx context.Context) error {
 	}
 
 	for _, envVar := range apiKeyEnvVars {
-		if value := os.Getenv(envVar); value != "" {
+		if value := keyring.Getenv(envVar); value != "" {
 			args = append(args, "-e", fmt.Sprintf("%s=%s", envVar, value))
 		}
 	}
@@ -485,7 +486,7 @@ func (gm *GatewayManager) downloadBinary(ctx context.Context) (string, error) {
 // githubToken returns the GitHub token from the environment, preferring
 // GITHUB_TOKEN and falling back to GH_TOKEN (matching the gh CLI)
 func githubToken() string {
-	if t := strings.TrimSpace(os.Getenv("GITHUB_TOKEN")); t != "" {
+	if t := strings.TrimSpace(keyring.Getenv("GITHUB_TOKEN")); t != "" {
 		return t
 	}
 	return strings.TrimSpace(os.Getenv("GH_TOKEN"))
@@ -747,11 +748,11 @@ func (gm *GatewayManager) configureGatewayOutput(cmd *exec.Cmd) error {
 
 // loadEnvironment loads environment variables from .env file or system environment
 func (gm *GatewayManager) loadEnvironment() []string {
+	envVars := append(os.Environ(), keyring.Environ()...)
 	if _, err := os.Stat(".env"); err != nil {
-		return os.Environ()
+		return envVars
 	}
 
-	envVars := os.Environ()
 	envFile, err := os.ReadFile(".env")
 	if err != nil {
 		return envVars
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+const (
+	historySearchPageSize = 100
+
+	// historySearchSnippets is how many matching messages are quoted per
+	// conversation; the rest are only counted.
+	historySearchSnippets = 3
+
+	// historySearchContext is how many characters are kept on each side of
+	// the first matching term in a snippet.
+	historySearchContext = 40
+)
+
+// SearchConversations full-text searches the message content of every stored
+// conversation, most recently updated first, and returns at most limit
+// conversations (all when limit is 0). A message matches when it contains
+// every whitespace-separated term of query, ignoring case. Hidden entries such
+// as system reminders are not searched.
+func SearchConversations(ctx context.Context, store storage.ConversationStorage, query string, limit int) ([]domain.ConversationSearchHit, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query is empty")
+	}
+
+	var hits []domain.ConversationSearchHit
+	for offset := 0; ; offset += historySearchPageSize {
+		page, err := store.ListConversations(ctx, historySearchPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list conversations: %w", err)
+		}
+
+		for _, summary := range page {
+			entries, _, err := store.LoadConversation(ctx, summary.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load conversation %s: %w", summary.ID, err)
+			}
+			hit := searchConversationEntries(entries, terms)
+			if hit.Matches == 0 {
+				continue
+			}
+			hit.ConversationID, hit.Title, hit.UpdatedAt = summary.ID, summary.Title, summary.UpdatedAt
+			hits = append(hits, hit)
+			if limit > 0 && len(hits) >= limit {
+				return hits, nil
+			}
+		}
+
+		if len(page) < historySearchPageSize {
+			return hits, nil
+		}
+	}
+}
+
+func searchConversationEntries(entries []domain.ConversationEntry, terms []string) domain.ConversationSearchHit {
+	var hit domain.ConversationSearchHit
+	for _, entry := range entries {
+		if entry.Hidden {
+			continue
+		}
+		text, err := entry.Message.Content.AsMessageContent0()
+		if err != nil {
+			continue
+		}
+		snippet, ok := matchSnippet(text, terms)
+		if !ok {
+			continue
+		}
+		hit.Matches++
+		if len(hit.Snippets) < historySearchSnippets {
+			hit.Snippets = append(hit.Snippets, domain.ConversationSearchSnippet{
+				Role:    string(entry.Message.Role),
+				Time:    entry.Time,
+				Snippet: snippet,
+			})
+		}
+	}
+	return hit
+}
+
+// matchSnippet reports whether text contains every term and returns the text
+// around the first term on one line. Terms must be lower case.
+func matchSnippet(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	lowerText := string(lower)
+
+	for _, term := range terms {
+		if !strings.Contains(lowerText, term) {
+			return "", false
+		}
+	}
+
+	// Index in runes, since lowercasing keeps the rune count but not the byte count
+	at := len([]rune(lowerText[:strings.Index(lowerText, terms[0])]))
+	start := max(at-historySearchContext, 0)
+	end := min(at+len([]rune(terms[0]))+historySearchContext, len(runes))
+
+	snippet := strings.Join(strings.Fields(string(runes[start:end])), " ")
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(runes) {
+		snippet += "..."
+	}
+	return snippet, true
+}
+
+// SearchSavedConversations searches the stored conversations without
+// switching the current one
+func (r *PersistentConversationRepository) SearchSavedConversations(ctx context.Context, query string, limit int) ([]domain.ConversationSearchHit, error) {
+	return SearchConversations(ctx, r.storage, query, limit)
+}
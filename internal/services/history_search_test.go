@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+func TestSearchConversations(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage()
+
+	message := func(role sdk.MessageRole, text string) domain.ConversationEntry {
+		return domain.ConversationEntry{Message: sdk.Message{Role: role, Content: sdk.NewMessageContent(text)}}
+	}
+	save := func(id, title string, updated time.Time, entries ...domain.ConversationEntry) {
+		t.Helper()
+		metadata := storage.ConversationMetadata{ID: id, Title: title, CreatedAt: updated, UpdatedAt: updated, MessageCount: len(entries)}
+		if err := store.SaveConversation(ctx, id, entries, metadata); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	reminder := message(sdk.User, "postgres migration reminder")
+	reminder.Hidden = true
+	save("old", "Database work", now.Add(-time.Hour),
+		message(sdk.User, "How do I write a Postgres migration?"),
+		message(sdk.Assistant, "Create the migration file first. Then run the Postgres migration tool."),
+	)
+	save("new", "Unrelated", now, message(sdk.User, "Fix the flaky test"), reminder)
+
+	hits, err := SearchConversations(ctx, store, "postgres MIGRATION", 0)
+	if err != nil {
+		t.Fatalf("SearchConversations: %v", err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, hidden entries must not match: %+v", len(hits), hits)
+	}
+	hit := hits[0]
+	if hit.ConversationID != "old" || hit.Title != "Database work" || hit.Matches != 2 || len(hit.Snippets) != 2 {
+		t.Errorf("unexpected hit %+v", hit)
+	}
+	if hit.Snippets[0].Role != "user" || !strings.Contains(hit.Snippets[0].Snippet, "Postgres migration") {
+		t.Errorf("unexpected snippet %+v", hit.Snippets[0])
+	}
+
+	if _, err := SearchConversations(ctx, store, "  ", 0); err == nil {
+		t.Error("expected an error for an empty query")
+	}
+}
+
+func TestMatchSnippet(t *testing.T) {
+	text := strings.Repeat("a", 60) + "\n  The NEEDLE\tis here " + strings.Repeat("b", 60)
+
+	snippet, ok := matchSnippet(text, []string{"needle", "here"})
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if !strings.HasPrefix(snippet, "...") || !strings.HasSuffix(snippet, "...") || !strings.Contains(snippet, "The NEEDLE is here") {
+		t.Errorf("unexpected snippet %q", snippet)
+	}
+
+	if _, ok := matchSnippet(text, []string{"needle", "missing"}); ok {
+		t.Error("every term must match")
+	}
+
+	if snippet, _ := matchSnippet("ÄÖÜ straße", []string{"straße"}); snippet != "ÄÖÜ straße" {
+		t.Errorf("unexpected unicode snippet %q", snippet)
+	}
+}
@@ -0,0 +1,409 @@
+// Package logview reads the structured logs infer writes under .infer/logs:
+// one zap JSON object per line in app-YYYY-MM-DD.log, with archived files
+// gzip-compressed to app-YYYY-MM-DD.log.<unix>.gz. It filters entries by
+// level, component and time range and follows the current file for new ones.
+package logview
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PollInterval is how often Follow checks the log file for new lines
+const PollInterval = 500 * time.Millisecond
+
+// maxLineSize bounds a single log line; zap entries with large payloads can
+// exceed bufio.Scanner's 64KB default
+const maxLineSize = 4 * 1024 * 1024
+
+var logFilePattern = regexp.MustCompile(`^app-(\d{4}-\d{2}-\d{2})\.log(?:\.(\d+)\.gz)?$`)
+
+// levels orders the zap level names from least to most severe
+var levels = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+
+// Entry is one parsed log line
+type Entry struct {
+	Time    time.Time
+	Level   string
+	Caller  string
+	Message string
+	// Fields holds the structured key/values, without level, ts, caller and msg
+	Fields map[string]any
+	// Raw is the line as written
+	Raw string
+}
+
+// Component returns the component of the entry: its "component" field when
+// set, otherwise the source file of the caller without directory and .go,
+// e.g. "mcp_manager" for services/mcp_manager.go:123
+func (e Entry) Component() string {
+	if c, ok := e.Fields["component"].(string); ok && c != "" {
+		return c
+	}
+	file, _, _ := strings.Cut(e.Caller, ":")
+	return strings.TrimSuffix(filepath.Base(file), ".go")
+}
+
+// ParseLine parses a zap JSON log line; ok is false for anything else
+func ParseLine(line string) (Entry, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return Entry{}, false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return Entry{}, false
+	}
+
+	e := Entry{Fields: fields, Raw: line}
+	e.Level, _ = fields["level"].(string)
+	e.Caller, _ = fields["caller"].(string)
+	e.Message, _ = fields["msg"].(string)
+	if ts, ok := fields["ts"].(float64); ok {
+		sec, frac := math.Modf(ts)
+		e.Time = time.Unix(int64(sec), int64(frac*1e9))
+	}
+	for _, key := range []string{"level", "ts", "caller", "msg"} {
+		delete(fields, key)
+	}
+	return e, true
+}
+
+// ValidLevel reports whether level is a zap level name
+func ValidLevel(level string) bool {
+	return slices.Contains(levels, strings.ToLower(level))
+}
+
+func severity(level string) int {
+	return slices.Index(levels, strings.ToLower(level))
+}
+
+// Filter selects log entries. The zero Filter matches everything.
+type Filter struct {
+	// MinLevel drops entries less severe than it, e.g. "warn"
+	MinLevel string
+	// Components keeps entries whose component or caller contains any of
+	// them, case-insensitively
+	Components []string
+	// Since and Until bound the entry time when set
+	Since time.Time
+	Until time.Time
+	// Grep keeps entries whose raw line contains it, case-insensitively
+	Grep string
+}
+
+// Match reports whether e passes the filter
+func (f Filter) Match(e Entry) bool {
+	if f.MinLevel != "" && severity(e.Level) < severity(f.MinLevel) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Time.After(f.Until) {
+		return false
+	}
+	if f.Grep != "" && !strings.Contains(strings.ToLower(e.Raw), strings.ToLower(f.Grep)) {
+		return false
+	}
+	if len(f.Components) == 0 {
+		return true
+	}
+	haystack := strings.ToLower(e.Component() + " " + e.Caller)
+	for _, c := range f.Components {
+		if strings.Contains(haystack, strings.ToLower(c)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTime parses a --since/--until value relative to now: a duration ago
+// ("15m", "2h"), a date ("2006-01-02"), a time of today ("15:04" or
+// "15:04:05") or an RFC 3339 timestamp
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d.Abs()), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use a duration such as 1h, a date, a time such as 14:30 or an RFC 3339 timestamp", value)
+}
+
+// logFile is a log file in dir with the day it was written
+type logFile struct {
+	path     string
+	day      string
+	archived int64
+}
+
+// Files returns the log files of dir, oldest first: for each day its
+// archives in the order they were rotated, then the live file
+func Files(dir string) ([]string, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.path)
+	}
+	return paths, nil
+}
+
+func listFiles(dir string) ([]logFile, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the log directory: %w", err)
+	}
+
+	var files []logFile
+	for _, entry := range entries {
+		m := logFilePattern.FindStringSubmatch(entry.Name())
+		if m == nil || entry.IsDir() {
+			continue
+		}
+		f := logFile{path: filepath.Join(dir, entry.Name()), day: m[1], archived: math.MaxInt64}
+		if m[2] != "" {
+			_, _ = fmt.Sscan(m[2], &f.archived)
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].day != files[j].day {
+			return files[i].day < files[j].day
+		}
+		return files[i].archived < files[j].archived
+	})
+	return files, nil
+}
+
+// Read calls fn for every entry of the logs in dir that matches filter, in
+// the order they were written. Files from days before filter.Since are
+// skipped without being opened.
+func Read(dir string, filter Filter, fn func(Entry)) error {
+	files, err := listFiles(dir)
+	if err != nil {
+		return err
+	}
+	var sinceDay string
+	if !filter.Since.IsZero() {
+		sinceDay = filter.Since.Format("2006-01-02")
+	}
+	for _, f := range files {
+		if f.day < sinceDay {
+			continue
+		}
+		if err := readFile(f.path, filter, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tail returns the last n entries of the logs in dir that match filter, or
+// all of them when n is not positive
+func Tail(dir string, filter Filter, n int) ([]Entry, error) {
+	var entries []Entry
+	err := Read(dir, filter, func(e Entry) {
+		entries = append(entries, e)
+		if n > 0 && len(entries) > 2*n {
+			entries = slices.Clone(entries[len(entries)-n:])
+		}
+	})
+	if n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries, err
+}
+
+func readFile(path string, filter Filter, fn func(Entry)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		if e, ok := ParseLine(scanner.Text()); ok && filter.Match(e) {
+			fn(e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// Follow calls fn for each matching entry written to the current log file
+// of dir after it is called, until ctx is done. It moves on to the next
+// day's file when the logger starts one and rereads a file the archiver
+// truncated from its start.
+func Follow(ctx context.Context, dir string, filter Filter, fn func(Entry)) error {
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	var (
+		current string
+		offset  int64
+		partial string
+		started bool
+	)
+	for {
+		files, err := listFiles(dir)
+		if err != nil {
+			return err
+		}
+		if latest := latestLive(files); latest != current || !started {
+			offset = 0
+			if !started {
+				if info, err := os.Stat(latest); err == nil {
+					offset = info.Size()
+				}
+			}
+			current, partial, started = latest, "", true
+		}
+
+		if current != "" {
+			if offset, partial, err = readNew(current, offset, partial, filter, fn); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestLive returns the path of the newest non-archived log file
+func latestLive(files []logFile) string {
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i].archived == math.MaxInt64 {
+			return files[i].path
+		}
+	}
+	return ""
+}
+
+// readNew reads path from offset and calls fn for every complete matching
+// line. A trailing line without a newline is returned as partial and
+// completed on the next call.
+func readNew(path string, offset int64, partial string, filter Filter, fn func(Entry)) (int64, string, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return offset, partial, err
+	}
+	if info.Size() < offset {
+		offset, partial = 0, ""
+	}
+	if info.Size() == offset {
+		return offset, partial, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return offset, partial, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data := make([]byte, info.Size()-offset)
+	n, err := file.ReadAt(data, offset)
+	if err != nil && err != io.EOF {
+		return offset, partial, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	offset += int64(n)
+
+	lines := strings.Split(partial+string(data[:n]), "\n")
+	for _, line := range lines[:len(lines)-1] {
+		if e, ok := ParseLine(line); ok && filter.Match(e) {
+			fn(e)
+		}
+	}
+	return offset, lines[len(lines)-1], nil
+}
+
+// Format renders e as a single human-readable line:
+// time, level, component, message and the remaining fields as key=value
+func Format(e Entry) string {
+	var sb strings.Builder
+	sb.WriteString(e.Time.Format("2006-01-02 15:04:05.000"))
+	fmt.Fprintf(&sb, " %-5s", strings.ToUpper(e.Level))
+	if component := e.Component(); component != "" {
+		fmt.Fprintf(&sb, " [%s]", component)
+	}
+	sb.WriteString(" " + e.Message)
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		if k != "component" && k != "stacktrace" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&sb, " %s=%s", k, formatValue(e.Fields[k]))
+	}
+	return sb.String()
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		if strings.ContainsAny(v, " \t\n\"=") || v == "" {
+			data, _ := json.Marshal(v)
+			return string(data)
+		}
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
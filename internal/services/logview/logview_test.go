@@ -0,0 +1,214 @@
+package logview
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	infoLine  = `{"level":"info","ts":1760601600.5,"caller":"services/mcp_manager.go:123","msg":"connected to MCP server","server":"fs"}`
+	warnLine  = `{"level":"warn","ts":1760605200,"caller":"tools/a2a_query_agent.go:88","msg":"agent unreachable","url":"http://localhost:8081"}`
+	errorLine = `{"level":"error","ts":1760608800,"caller":"services/mcp_manager.go:200","msg":"MCP server failed","error":"connection refused"}`
+)
+
+func writeLog(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeGzipLog(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	_, _ = gz.Write([]byte(strings.Join(lines, "\n") + "\n"))
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+}
+
+func messages(entries []Entry) []string {
+	var msgs []string
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
+
+func TestParseLine(t *testing.T) {
+	e, ok := ParseLine(infoLine)
+	if !ok {
+		t.Fatal("expected the line to parse")
+	}
+	if e.Level != "info" || e.Message != "connected to MCP server" || e.Caller != "services/mcp_manager.go:123" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e.Time.UnixMilli() != 1760601600500 {
+		t.Errorf("Time = %v", e.Time)
+	}
+	if e.Component() != "mcp_manager" {
+		t.Errorf("Component() = %q", e.Component())
+	}
+	if _, ok := e.Fields["msg"]; ok || e.Fields["server"] != "fs" {
+		t.Errorf("Fields = %v", e.Fields)
+	}
+
+	if _, ok := ParseLine("not json"); ok {
+		t.Error("expected a non-JSON line to be skipped")
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	info, _ := ParseLine(infoLine)
+	warn, _ := ParseLine(warnLine)
+	errEntry, _ := ParseLine(errorLine)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []bool
+	}{
+		{"zero filter", Filter{}, []bool{true, true, true}},
+		{"min level", Filter{MinLevel: "warn"}, []bool{false, true, true}},
+		{"component", Filter{Components: []string{"MCP"}}, []bool{true, false, true}},
+		{"component from caller dir", Filter{Components: []string{"tools/"}}, []bool{false, true, false}},
+		{"since", Filter{Since: time.Unix(1760605200, 0)}, []bool{false, true, true}},
+		{"until", Filter{Until: time.Unix(1760605200, 0)}, []bool{true, true, false}},
+		{"grep", Filter{Grep: "REFUSED"}, []bool{false, false, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, e := range []Entry{info, warn, errEntry} {
+				if got := tt.filter.Match(e); got != tt.want[i] {
+					t.Errorf("Match(%s) = %v, want %v", e.Message, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"90m":                  now.Add(-90 * time.Minute),
+		"2026-10-15":           time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		"2026-10-15T08:30":     time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC),
+		"09:15":                time.Date(2026, 10, 16, 9, 15, 0, 0, time.UTC),
+		"2026-10-15T08:30:00Z": time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC),
+	}
+	for value, want := range tests {
+		got, err := ParseTime(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseTime("yesterday", now); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestReadOrdersArchivesBeforeLiveFile(t *testing.T) {
+	dir := t.TempDir()
+	writeLog(t, filepath.Join(dir, "app-2025-10-16.log"), errorLine)
+	writeGzipLog(t, filepath.Join(dir, "app-2025-10-16.log.200.gz"), warnLine)
+	writeGzipLog(t, filepath.Join(dir, "app-2025-10-16.log.100.gz"), infoLine)
+	writeLog(t, filepath.Join(dir, "app-2025-10-15.log"), `{"level":"info","ts":1760500000,"msg":"yesterday"}`)
+	writeLog(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	entries, err := Tail(dir, Filter{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(messages(entries), "|")
+	want := "yesterday|connected to MCP server|agent unreachable|MCP server failed"
+	if got != want {
+		t.Errorf("order = %q, want %q", got, want)
+	}
+
+	entries, _ = Tail(dir, Filter{}, 2)
+	if got := strings.Join(messages(entries), "|"); got != "agent unreachable|MCP server failed" {
+		t.Errorf("Tail(2) = %q", got)
+	}
+}
+
+func TestReadMissingDir(t *testing.T) {
+	entries, err := Tail(filepath.Join(t.TempDir(), "missing"), Filter{}, 10)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Tail() = %v, %v; want no entries and no error", entries, err)
+	}
+}
+
+func TestFollowPrintsNewEntries(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app-2025-10-16.log")
+	writeLog(t, path, infoLine)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+	done := make(chan error, 1)
+	go func() {
+		done <- Follow(ctx, dir, Filter{MinLevel: "warn"}, func(e Entry) {
+			mu.Lock()
+			got = append(got, e.Message)
+			mu.Unlock()
+		})
+	}()
+
+	time.Sleep(2 * PollInterval)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(warnLine + "\n" + infoLine + "\n" + errorLine[:20])
+	_ = f.Sync()
+	time.Sleep(2 * PollInterval)
+	_, _ = f.WriteString(errorLine[20:] + "\n")
+	_ = f.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(got)
+		mu.Unlock()
+		if n >= 2 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(got, "|") != "agent unreachable|MCP server failed" {
+		t.Errorf("followed %q", got)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	e, _ := ParseLine(errorLine)
+	line := Format(e)
+	for _, want := range []string{"ERROR", "[mcp_manager]", "MCP server failed", `error="connection refused"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Format() = %q, missing %q", line, want)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"os/exec"
 	"strings"
 	"sync"
@@ -14,6 +13,7 @@ import (
 
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	utils "github.com/inference-gateway/cli/internal/utils"
 )
@@ -815,7 +815,7 @@ func (m *MCPManager) startContainer(ctx context.Context, server config.MCPServer
 	)
 
 	for key, value := range server.Env {
-		expandedValue := os.ExpandEnv(value)
+		expandedValue := keyring.ExpandEnv(value)
 		args = append(args, "-e", fmt.Sprintf("%s=%s", key, expandedValue))
 	}
 
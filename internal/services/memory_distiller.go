@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+	tools "github.com/inference-gateway/cli/internal/agent/tools"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// maxDistilledFacts bounds how many facts one session can add to memory, so a
+// model that ignores the prompt's limit cannot flood the index.
+const maxDistilledFacts = 5
+
+// MemoryDistiller asks a model, at session end, which durable facts the
+// conversation revealed and files them through the Memory tool, so they land
+// in the same fact-files and MEMORY.md index (and remote sync) as facts the
+// agent writes itself.
+type MemoryDistiller struct {
+	config *config.Config
+	client sdk.Client
+	memory domain.Tool
+}
+
+var _ domain.MemoryDistiller = (*MemoryDistiller)(nil)
+
+// NewMemoryDistiller creates a distiller. memory is the registered Memory tool;
+// when it is nil (memory disabled) Distill is a no-op.
+func NewMemoryDistiller(cfg *config.Config, client sdk.Client, memory domain.Tool) *MemoryDistiller {
+	return &MemoryDistiller{
+		config: cfg,
+		client: client,
+		memory: memory,
+	}
+}
+
+// distilledFact is one entry of the JSON array the distillation prompt asks for
+type distilledFact struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+}
+
+// Distill summarizes messages into memory facts using memory.distill.model (or
+// model when unset). Sessions with fewer than memory.distill.min_messages user
+// and assistant messages are skipped. Facts the Memory tool rejects are logged
+// and skipped; the count of facts written is returned.
+func (d *MemoryDistiller) Distill(ctx context.Context, messages []sdk.Message, model string) (int, error) {
+	if d == nil || d.memory == nil || d.client == nil || !d.config.Memory.Enabled || !d.config.Memory.Distill.Enabled {
+		return 0, nil
+	}
+
+	transcript, turns := formatTranscriptForDistill(messages)
+	if turns < d.config.Memory.Distill.EffectiveMinMessages() {
+		return 0, nil
+	}
+
+	if d.config.Memory.Distill.Model != "" {
+		model = d.config.Memory.Distill.Model
+	}
+	provider, modelName, ok := strings.Cut(model, "/")
+	if !ok {
+		return 0, fmt.Errorf("invalid model format %q, expected 'provider/model'", model)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.config.Memory.Distill.EffectiveTimeout())
+	defer cancel()
+
+	request := []sdk.Message{
+		{Role: sdk.System, Content: sdk.NewMessageContent(d.config.Prompts.Conversation.MemoryDistill.SystemPrompt)},
+		{Role: sdk.User, Content: sdk.NewMessageContent(fmt.Sprintf(
+			"Current memory index:\n\n%s\n\nSession transcript:\n\n%s", d.currentIndex(), transcript))},
+	}
+	response, err := d.client.
+		WithMiddlewareOptions(&sdk.MiddlewareOptions{SkipMCP: true}).
+		GenerateContent(ctx, sdk.Provider(provider), modelName, request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to distill memory: %w", err)
+	}
+	if len(response.Choices) == 0 {
+		return 0, fmt.Errorf("no memory distillation returned")
+	}
+	content, err := response.Choices[0].Message.Content.AsMessageContent0()
+	if err != nil {
+		return 0, fmt.Errorf("failed to extract memory distillation: %w", err)
+	}
+
+	facts, err := parseDistilledFacts(content)
+	if err != nil {
+		return 0, err
+	}
+
+	written := 0
+	for _, fact := range facts {
+		args := map[string]any{
+			"operation":   tools.OperationWrite,
+			"name":        fact.Name,
+			"type":        fact.Type,
+			"description": fact.Description,
+			"content":     fact.Content,
+		}
+		if err := d.memory.Validate(args); err != nil {
+			logger.Debug("skipping distilled memory", "name", fact.Name, "error", err)
+			continue
+		}
+		result, err := d.memory.Execute(ctx, args)
+		if err != nil || result == nil || !result.Success {
+			logger.Debug("failed to write distilled memory", "name", fact.Name, "error", err)
+			continue
+		}
+		written++
+	}
+
+	logger.Info("distilled session into memory", "model", model, "facts", written)
+	return written, nil
+}
+
+// currentIndex returns the MEMORY.md index the model should deduplicate
+// against, capped like the injected index
+func (d *MemoryDistiller) currentIndex() string {
+	dir, err := d.config.ResolveMemoryDir()
+	if err != nil {
+		return "(empty)"
+	}
+	data, err := os.ReadFile(filepath.Join(dir, config.MemoryIndexFileName))
+	index := strings.TrimSpace(string(data))
+	if err != nil || index == "" {
+		return "(empty)"
+	}
+	if maxChars := d.config.Memory.MaxChars; maxChars > 0 && len(index) > maxChars {
+		index = index[:maxChars] + "\n... [truncated]"
+	}
+	return index
+}
+
+// formatTranscriptForDistill renders the user and assistant text of messages
+// (tool traffic is left out) and counts those messages
+func formatTranscriptForDistill(messages []sdk.Message) (string, int) {
+	var b strings.Builder
+	turns := 0
+	for _, msg := range messages {
+		var role string
+		switch msg.Role {
+		case sdk.User:
+			role = "User"
+		case sdk.Assistant:
+			role = "Assistant"
+		default:
+			continue
+		}
+
+		text, err := msg.Content.AsMessageContent0()
+		text = strings.TrimSpace(text)
+		if err != nil || text == "" {
+			continue
+		}
+		if len(text) > 2000 {
+			text = text[:2000] + "... [truncated]"
+		}
+		turns++
+		fmt.Fprintf(&b, "%s: %s\n\n", role, text)
+	}
+	return b.String(), turns
+}
+
+// parseDistilledFacts decodes the model's JSON array, tolerating a Markdown
+// code fence around it, and keeps at most maxDistilledFacts entries
+func parseDistilledFacts(content string) ([]distilledFact, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	content = strings.TrimSpace(content)
+
+	var facts []distilledFact
+	if err := json.Unmarshal([]byte(content), &facts); err != nil {
+		return nil, fmt.Errorf("failed to parse memory distillation: %w", err)
+	}
+	if len(facts) > maxDistilledFacts {
+		facts = facts[:maxDistilledFacts]
+	}
+	return facts, nil
+}
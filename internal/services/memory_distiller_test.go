@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+	tools "github.com/inference-gateway/cli/internal/agent/tools"
+	project "github.com/inference-gateway/cli/internal/project"
+	services "github.com/inference-gateway/cli/internal/services"
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+)
+
+func newTestDistiller(t *testing.T, response string) (*services.MemoryDistiller, *config.Config) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Memory = *config.DefaultMemoryConfig()
+	cfg.Memory.Dir = t.TempDir()
+	cfg.Memory.Distill.MinMessages = 2
+	cfg.Prompts = *config.DefaultPromptsConfig()
+
+	client := createMockSDKClient(t, response)
+	memoryTool := tools.NewMemoryTool(cfg, nil, project.Identity{})
+	return services.NewMemoryDistiller(cfg, client, memoryTool), cfg
+}
+
+func distillConversation() []sdk.Message {
+	return []sdk.Message{
+		{Role: sdk.User, Content: sdk.NewMessageContent("I'm a Go maintainer; always run task lint before committing")},
+		{Role: sdk.Assistant, Content: sdk.NewMessageContent("Understood, I'll run task lint first.")},
+	}
+}
+
+func TestMemoryDistiller_WritesFacts(t *testing.T) {
+	distiller, cfg := newTestDistiller(t, "```json\n"+`[
+		{"name": "lint-before-commit", "type": "feedback", "description": "Run task lint before committing", "content": "Always run task lint before committing."},
+		{"name": "Bad Type", "type": "gossip", "description": "x", "content": "x"}
+	]`+"\n```")
+
+	written, err := distiller.Distill(context.Background(), distillConversation(), "openai/gpt-4o")
+	require.NoError(t, err)
+	assert.Equal(t, 1, written, "facts the Memory tool rejects are skipped")
+
+	index, err := os.ReadFile(filepath.Join(cfg.Memory.Dir, config.MemoryIndexFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "lint-before-commit")
+}
+
+func TestMemoryDistiller_Skips(t *testing.T) {
+	t.Run("short sessions", func(t *testing.T) {
+		distiller, cfg := newTestDistiller(t, "[]")
+		cfg.Memory.Distill.MinMessages = 5
+
+		written, err := distiller.Distill(context.Background(), distillConversation(), "openai/gpt-4o")
+		require.NoError(t, err)
+		assert.Zero(t, written)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		distiller, cfg := newTestDistiller(t, `[{"name": "x", "type": "user", "description": "x", "content": "x"}]`)
+		cfg.Memory.Distill.Enabled = false
+
+		written, err := distiller.Distill(context.Background(), distillConversation(), "openai/gpt-4o")
+		require.NoError(t, err)
+		assert.Zero(t, written)
+	})
+
+	t.Run("nothing worth remembering", func(t *testing.T) {
+		distiller, cfg := newTestDistiller(t, "[]")
+
+		written, err := distiller.Distill(context.Background(), distillConversation(), "openai/gpt-4o")
+		require.NoError(t, err)
+		assert.Zero(t, written)
+		_, err = os.Stat(filepath.Join(cfg.Memory.Dir, config.MemoryIndexFileName))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestMemoryDistiller_UsesDistillModel(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Memory = *config.DefaultMemoryConfig()
+	cfg.Memory.Dir = t.TempDir()
+	cfg.Memory.Distill.MinMessages = 1
+	cfg.Memory.Distill.Model = "anthropic/claude-haiku"
+	cfg.Prompts = *config.DefaultPromptsConfig()
+
+	client := createMockSDKClient(t, "[]")
+	distiller := services.NewMemoryDistiller(cfg, client, tools.NewMemoryTool(cfg, nil, project.Identity{}))
+
+	_, err := distiller.Distill(context.Background(), distillConversation(), "openai/gpt-4o")
+	require.NoError(t, err)
+
+	require.Equal(t, 1, client.GenerateContentCallCount())
+	_, provider, model, messages := client.GenerateContentArgsForCall(0)
+	assert.Equal(t, sdk.Provider("anthropic"), provider)
+	assert.Equal(t, "claude-haiku", model)
+	prompt, _ := messages[1].Content.AsMessageContent0()
+	assert.True(t, strings.Contains(prompt, "always run task lint"), "transcript is sent to the model")
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"slices"
 	"sync"
+	"time"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
 	sdk "github.com/inference-gateway/sdk"
@@ -20,17 +22,37 @@ func NewMessageQueueService() *MessageQueueService {
 	}
 }
 
-// Enqueue adds a message to the queue
+// Enqueue adds a message to the queue with normal priority
 func (mq *MessageQueueService) Enqueue(message sdk.Message, requestID string) {
+	mq.EnqueueWithPriority(message, requestID, domain.QueuePriorityNormal)
+}
+
+// EnqueueWithPriority adds a message behind the queued ones of the same or a
+// higher priority
+func (mq *MessageQueueService) EnqueueWithPriority(message sdk.Message, requestID string, priority domain.QueuePriority) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
-	mq.messages = append(mq.messages, domain.QueuedMessage{
+	mq.insert(domain.QueuedMessage{
 		Message:   message,
+		QueuedAt:  time.Now(),
 		RequestID: requestID,
+		Priority:  priority,
 	})
 }
 
+// insert places msg before the first message of a lower priority
+func (mq *MessageQueueService) insert(msg domain.QueuedMessage) {
+	at := len(mq.messages)
+	for i, queued := range mq.messages {
+		if queued.Priority < msg.Priority {
+			at = i
+			break
+		}
+	}
+	mq.messages = slices.Insert(mq.messages, at, msg)
+}
+
 // Dequeue removes and returns the next message from the queue
 // Returns nil if the queue is empty
 func (mq *MessageQueueService) Dequeue() *domain.QueuedMessage {
@@ -93,3 +115,79 @@ func (mq *MessageQueueService) GetAll() []domain.QueuedMessage {
 	copy(result, mq.messages)
 	return result
 }
+
+// Remove deletes the message at index, as returned by GetAll
+// Returns false if the index is out of range, e.g. because the queue was
+// drained after the caller read it
+func (mq *MessageQueueService) Remove(index int) bool {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	if index < 0 || index >= len(mq.messages) {
+		return false
+	}
+
+	mq.messages = slices.Delete(mq.messages, index, index+1)
+	return true
+}
+
+// Move moves the message at from to position to, shifting the ones between
+// Returns false if either index is out of range or the message at to has a
+// different priority: messages only move among those of their own priority
+func (mq *MessageQueueService) Move(from, to int) bool {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	if from < 0 || from >= len(mq.messages) || to < 0 || to >= len(mq.messages) {
+		return false
+	}
+	if mq.messages[from].Priority != mq.messages[to].Priority {
+		return false
+	}
+
+	msg := mq.messages[from]
+	mq.messages = slices.Insert(slices.Delete(mq.messages, from, from+1), to, msg)
+	return true
+}
+
+// Replace swaps the message at index for message, keeping its place and request ID
+// Returns false if the index is out of range
+func (mq *MessageQueueService) Replace(index int, message sdk.Message) bool {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	if index < 0 || index >= len(mq.messages) {
+		return false
+	}
+
+	mq.messages[index].Message = message
+	return true
+}
+
+// SetPriority changes the priority of the message at index, moving it behind
+// the other messages of its new priority
+// Returns false if the index is out of range
+func (mq *MessageQueueService) SetPriority(index int, priority domain.QueuePriority) bool {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	if index < 0 || index >= len(mq.messages) {
+		return false
+	}
+
+	msg := mq.messages[index]
+	msg.Priority = priority
+	mq.messages = slices.Delete(mq.messages, index, index+1)
+	mq.insert(msg)
+	return true
+}
+
+// HasInterrupt reports whether a message of QueuePriorityInterrupt waits
+func (mq *MessageQueueService) HasInterrupt() bool {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+
+	return slices.ContainsFunc(mq.messages, func(msg domain.QueuedMessage) bool {
+		return msg.Priority == domain.QueuePriorityInterrupt
+	})
+}
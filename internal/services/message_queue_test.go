@@ -0,0 +1,94 @@
+package services
+
+import (
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func queuedContents(t *testing.T, mq *MessageQueueService) []string {
+	t.Helper()
+	var contents []string
+	for _, queued := range mq.GetAll() {
+		content, err := queued.Message.Content.AsMessageContent0()
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents = append(contents, content)
+	}
+	return contents
+}
+
+func TestMessageQueueService_RemoveMoveReplace(t *testing.T) {
+	mq := NewMessageQueueService()
+	for _, content := range []string{"a", "b", "c", "d"} {
+		mq.Enqueue(sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(content)}, "req-"+content)
+	}
+
+	if !mq.Move(3, 0) {
+		t.Fatal("expected Move to succeed")
+	}
+	if !mq.Move(1, 2) {
+		t.Fatal("expected Move to succeed")
+	}
+	if !mq.Remove(3) {
+		t.Fatal("expected Remove to succeed")
+	}
+	if !mq.Replace(1, sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("b2")}) {
+		t.Fatal("expected Replace to succeed")
+	}
+
+	got := queuedContents(t, mq)
+	if len(got) != 3 || got[0] != "d" || got[1] != "b2" || got[2] != "a" {
+		t.Fatalf("queue = %v, want [d b2 a]", got)
+	}
+	if mq.GetAll()[1].RequestID != "req-b" {
+		t.Error("Replace should keep the request ID")
+	}
+
+	if mq.Remove(3) || mq.Move(0, 3) || mq.Move(-1, 0) || mq.Replace(5, sdk.Message{}) {
+		t.Error("out of range indexes should be rejected")
+	}
+}
+
+func TestMessageQueueService_Priorities(t *testing.T) {
+	mq := NewMessageQueueService()
+	enqueue := func(content string, priority domain.QueuePriority) {
+		mq.EnqueueWithPriority(sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(content)}, "req-"+content, priority)
+	}
+	enqueue("low", domain.QueuePriorityLow)
+	enqueue("a", domain.QueuePriorityNormal)
+	enqueue("urgent", domain.QueuePriorityInterrupt)
+	enqueue("b", domain.QueuePriorityNormal)
+
+	got := queuedContents(t, mq)
+	if len(got) != 4 || got[0] != "urgent" || got[1] != "a" || got[2] != "b" || got[3] != "low" {
+		t.Fatalf("queue = %v, want [urgent a b low]", got)
+	}
+	if !mq.HasInterrupt() {
+		t.Error("HasInterrupt() = false with an interrupt queued")
+	}
+
+	if !mq.SetPriority(0, domain.QueuePriorityNormal) || !mq.SetPriority(3, domain.QueuePriorityInterrupt) {
+		t.Fatal("expected SetPriority to succeed")
+	}
+	got = queuedContents(t, mq)
+	if len(got) != 4 || got[0] != "low" || got[1] != "a" || got[2] != "b" || got[3] != "urgent" {
+		t.Fatalf("queue = %v, want [low a b urgent]", got)
+	}
+	if mq.SetPriority(4, domain.QueuePriorityLow) {
+		t.Error("out of range indexes should be rejected")
+	}
+	if mq.Move(0, 1) {
+		t.Error("Move() should not take a message past those of another priority")
+	}
+
+	if next := mq.Dequeue(); next == nil || next.RequestID != "req-low" || next.QueuedAt.IsZero() {
+		t.Errorf("Dequeue() = %+v, want the raised message with its queue time", next)
+	}
+	if mq.HasInterrupt() {
+		t.Error("HasInterrupt() = true after the interrupt was taken")
+	}
+}
@@ -0,0 +1,120 @@
+// Package notifier sends desktop notifications, either through the terminal
+// (OSC 777) or through a native notification utility.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// Notification methods accepted in chat.notifications.method
+const (
+	MethodAuto             = "auto"
+	MethodOSC777           = "osc777"
+	MethodTerminalNotifier = "terminal-notifier"
+	MethodNotifySend       = "notify-send"
+)
+
+// notifyTimeout bounds how long a notification utility may run
+const notifyTimeout = 5 * time.Second
+
+// Notifier delivers desktop notifications with a single resolved method
+type Notifier struct {
+	method string
+	run    func(ctx context.Context, name string, args ...string) error
+}
+
+// New creates a notifier for method. MethodAuto picks terminal-notifier on
+// macOS or notify-send on Linux when installed, and falls back to OSC 777,
+// which the terminal turns into a notification itself.
+func New(method string) (*Notifier, error) {
+	resolved, err := resolveMethod(method, runtime.GOOS, exec.LookPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Notifier{method: resolved, run: runCommand}, nil
+}
+
+// resolveMethod validates method and resolves MethodAuto for the platform
+func resolveMethod(method, goos string, lookPath func(string) (string, error)) (string, error) {
+	switch method {
+	case MethodOSC777, MethodTerminalNotifier, MethodNotifySend:
+		return method, nil
+	case "", MethodAuto:
+	default:
+		return "", fmt.Errorf("unknown notification method %q (use auto, osc777, terminal-notifier, or notify-send)", method)
+	}
+
+	candidate := ""
+	switch goos {
+	case "darwin":
+		candidate = MethodTerminalNotifier
+	case "linux", "freebsd", "openbsd", "netbsd":
+		candidate = MethodNotifySend
+	}
+	if candidate != "" {
+		if _, err := lookPath(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return MethodOSC777, nil
+}
+
+// Method returns the resolved notification method
+func (n *Notifier) Method() string {
+	return n.method
+}
+
+// Notify returns a command that shows a notification. With OSC 777 the escape
+// sequence is written straight to the terminal; otherwise the utility runs in
+// the background and failures are only logged.
+func (n *Notifier) Notify(title, body string) tea.Cmd {
+	switch n.method {
+	case MethodOSC777:
+		return tea.Raw(osc777(title, body))
+	case MethodTerminalNotifier:
+		return n.runCmd(MethodTerminalNotifier, "-title", title, "-message", body, "-group", "infer")
+	default:
+		return n.runCmd(MethodNotifySend, "--app-name=infer", title, body)
+	}
+}
+
+func (n *Notifier) runCmd(name string, args ...string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+		if err := n.run(ctx, name, args...); err != nil {
+			logger.Warn("failed to send desktop notification", "method", name, "error", err)
+		}
+		return nil
+	}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) error {
+	return exec.CommandContext(ctx, name, args...).Run()
+}
+
+// osc777 builds the OSC 777 notify sequence. Semicolons separate its fields
+// and control characters would end it early, so both are replaced.
+func osc777(title, body string) string {
+	clean := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			switch {
+			case r == ';':
+				return ','
+			case r < 0x20 || r == 0x7f:
+				return ' '
+			}
+			return r
+		}, s)
+	}
+	return "\x1b]777;notify;" + clean(title) + ";" + clean(body) + "\x1b\\"
+}
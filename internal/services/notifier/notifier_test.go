@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestResolveMethod(t *testing.T) {
+	installed := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, n := range names {
+				if n == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		goos     string
+		lookPath func(string) (string, error)
+		want     string
+		wantErr  bool
+	}{
+		{"explicit method is kept", MethodNotifySend, "darwin", installed(), MethodNotifySend, false},
+		{"auto on linux with notify-send", MethodAuto, "linux", installed(MethodNotifySend), MethodNotifySend, false},
+		{"auto on macOS with terminal-notifier", "", "darwin", installed(MethodTerminalNotifier), MethodTerminalNotifier, false},
+		{"auto falls back to OSC 777", MethodAuto, "linux", installed(), MethodOSC777, false},
+		{"auto on windows", MethodAuto, "windows", installed(MethodNotifySend), MethodOSC777, false},
+		{"unknown method", "growl", "linux", installed(), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveMethod(tt.method, tt.goos, tt.lookPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveMethod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveMethod() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotify_OSC777(t *testing.T) {
+	n := &Notifier{method: MethodOSC777}
+	msg := n.Notify("infer; done", "Turn finished\nin 2m")
+	if msg == nil {
+		t.Fatal("expected a command")
+	}
+	raw, ok := msg().(tea.RawMsg)
+	if !ok {
+		t.Fatalf("expected a raw terminal write, got %T", msg())
+	}
+	if want := "\x1b]777;notify;infer, done;Turn finished in 2m\x1b\\"; raw.Msg != want {
+		t.Errorf("sequence = %q, want %q", raw.Msg, want)
+	}
+}
+
+func TestNotify_Command(t *testing.T) {
+	var gotName string
+	var gotArgs []string
+	n := &Notifier{
+		method: MethodNotifySend,
+		run: func(_ context.Context, name string, args ...string) error {
+			gotName, gotArgs = name, args
+			return errors.New("no notification daemon")
+		},
+	}
+
+	if msg := n.Notify("infer", "Approval needed")(); msg != nil {
+		t.Errorf("expected no message, got %#v", msg)
+	}
+	if gotName != MethodNotifySend || !reflect.DeepEqual(gotArgs, []string{"--app-name=infer", "infer", "Approval needed"}) {
+		t.Errorf("ran %s %v", gotName, gotArgs)
+	}
+}
@@ -166,6 +166,61 @@ func (r *PersistentConversationRepository) SaveConversation(ctx context.Context)
 	return r.storage.SaveConversation(ctx, conversationID, entries, metadata)
 }
 
+// ForkConversation saves the current conversation, then copies its entries up
+// to and including throughIndex into a new conversation that records the
+// current one as its parent. The fork becomes the active conversation and its
+// ID is returned; the original is left untouched in storage.
+func (r *PersistentConversationRepository) ForkConversation(ctx context.Context, throughIndex int) (string, error) {
+	parentID := r.GetCurrentConversationID()
+	if parentID == "" {
+		return "", fmt.Errorf("no active conversation to fork")
+	}
+
+	allEntries := r.GetMessages()
+	if throughIndex < 0 || throughIndex >= len(allEntries) {
+		return "", fmt.Errorf("invalid fork index %d: conversation has %d entries", throughIndex, len(allEntries))
+	}
+
+	if err := r.SaveConversation(ctx); err != nil {
+		return "", fmt.Errorf("failed to save conversation before forking: %w", err)
+	}
+
+	entries := make([]domain.ConversationEntry, 0, throughIndex+1)
+	for _, entry := range allEntries[:throughIndex+1] {
+		if entry.PendingToolCall == nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	r.metadataMutex.RLock()
+	parent := r.metadata
+	r.metadataMutex.RUnlock()
+
+	forkID := uuid.New().String()
+	now := time.Now()
+	metadata := storage.ConversationMetadata{
+		ID:             forkID,
+		Title:          parent.Title + " (fork)",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		MessageCount:   len(entries),
+		Model:          parent.Model,
+		Tags:           append([]string{}, parent.Tags...),
+		TitleGenerated: parent.TitleGenerated,
+		ParentID:       parentID,
+	}
+
+	if err := r.storage.SaveConversation(ctx, forkID, entries, metadata); err != nil {
+		return "", fmt.Errorf("failed to save fork: %w", err)
+	}
+
+	if err := r.LoadConversation(ctx, forkID); err != nil {
+		return "", err
+	}
+
+	return forkID, nil
+}
+
 // ListSavedConversations returns a list of saved conversations
 func (r *PersistentConversationRepository) ListSavedConversations(ctx context.Context, limit, offset int) ([]storage.ConversationSummary, error) {
 	return r.storage.ListConversations(ctx, limit, offset)
@@ -176,6 +231,44 @@ func (r *PersistentConversationRepository) DeleteSavedConversation(ctx context.C
 	return r.storage.DeleteConversation(ctx, conversationID)
 }
 
+// SetSavedConversationTags replaces the tags of a saved conversation.
+func (r *PersistentConversationRepository) SetSavedConversationTags(ctx context.Context, conversationID string, tags []string) error {
+	return r.updateSavedMetadata(ctx, conversationID, func(metadata *storage.ConversationMetadata) {
+		metadata.Tags = tags
+	})
+}
+
+// SetSavedConversationArchived archives or restores a saved conversation.
+// Archived conversations are hidden from the conversation selector unless
+// asked for.
+func (r *PersistentConversationRepository) SetSavedConversationArchived(ctx context.Context, conversationID string, archived bool) error {
+	return r.updateSavedMetadata(ctx, conversationID, func(metadata *storage.ConversationMetadata) {
+		metadata.Archived = archived
+	})
+}
+
+// updateSavedMetadata applies update to a saved conversation's metadata. When
+// it is the current conversation the in-memory metadata is updated as well,
+// so the next auto-save keeps the change.
+func (r *PersistentConversationRepository) updateSavedMetadata(ctx context.Context, conversationID string, update func(*storage.ConversationMetadata)) error {
+	_, metadata, err := r.storage.LoadConversation(ctx, conversationID)
+	if err != nil {
+		return fmt.Errorf("failed to load conversation: %w", err)
+	}
+
+	update(&metadata)
+	if err := r.storage.UpdateConversationMetadata(ctx, conversationID, metadata); err != nil {
+		return fmt.Errorf("failed to update conversation: %w", err)
+	}
+
+	r.metadataMutex.Lock()
+	defer r.metadataMutex.Unlock()
+	if r.conversationID == conversationID {
+		update(&r.metadata)
+	}
+	return nil
+}
+
 // SetConversationTitle sets the title for the current conversation
 func (r *PersistentConversationRepository) SetConversationTitle(title string) {
 	r.metadataMutex.Lock()
@@ -371,6 +464,32 @@ func (r *PersistentConversationRepository) DeleteMessagesAfterIndex(index int) e
 	return nil
 }
 
+// SetMessagePinned wraps the in-memory implementation with auto-save
+func (r *PersistentConversationRepository) SetMessagePinned(index int, pinned bool) error {
+	if err := r.InMemoryConversationRepository.SetMessagePinned(index, pinned); err != nil {
+		return err
+	}
+
+	r.metadataMutex.RLock()
+	shouldAutoSave := r.autoSave && r.conversationID != ""
+	r.metadataMutex.RUnlock()
+
+	if shouldAutoSave {
+		r.autoSaveMutex.Lock()
+		defer r.autoSaveMutex.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := r.SaveConversation(ctx); err != nil {
+			logger.Warn("failed to auto-save conversation after pinning a message", "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AddTokenUsage wraps the in-memory implementation with persistence and auto-save
 func (r *PersistentConversationRepository) AddTokenUsage(model string, inputTokens, outputTokens, totalTokens, cachedTokens int) error {
 	r.metadataMutex.RLock()
@@ -154,6 +154,44 @@ func TestPersistentConversationRepository_BasicOperations(t *testing.T) {
 	})
 }
 
+func TestPersistentConversationRepository_ForkConversation(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo.SetAutoSave(false)
+
+	_, err := repo.ForkConversation(ctx, 0)
+	assert.Error(t, err, "forking without an active conversation must fail")
+
+	require.NoError(t, repo.StartNewConversation("Original"))
+	parentID := repo.GetCurrentConversationID()
+
+	for _, text := range []string{"first", "second", "third"} {
+		require.NoError(t, repo.AddMessage(domain.ConversationEntry{
+			Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(text)},
+			Time:    time.Now(),
+		}))
+	}
+
+	_, err = repo.ForkConversation(ctx, 3)
+	assert.Error(t, err, "index past the end must fail")
+
+	forkID, err := repo.ForkConversation(ctx, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, parentID, forkID)
+	assert.Equal(t, forkID, repo.GetCurrentConversationID())
+	assert.Equal(t, 2, repo.GetMessageCount())
+
+	metadata := repo.GetCurrentConversationMetadata()
+	assert.Equal(t, parentID, metadata.ParentID)
+	assert.Equal(t, "Original (fork)", metadata.Title)
+
+	require.NoError(t, repo.LoadConversation(ctx, parentID))
+	assert.Equal(t, 3, repo.GetMessageCount(), "the parent must keep its full history")
+	assert.Empty(t, repo.GetCurrentConversationMetadata().ParentID)
+}
+
 func TestPersistentConversationRepository_AutoSaveTitle(t *testing.T) {
 	repo, cleanup := setupTestRepository(t)
 	defer cleanup()
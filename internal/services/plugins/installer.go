@@ -13,6 +13,7 @@ import (
 
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 	skills "github.com/inference-gateway/cli/internal/services/skills"
 )
 
@@ -74,7 +75,7 @@ func NewInstaller() *Installer {
 }
 
 func githubToken() string {
-	if t := strings.TrimSpace(os.Getenv("GITHUB_TOKEN")); t != "" {
+	if t := strings.TrimSpace(keyring.Getenv("GITHUB_TOKEN")); t != "" {
 		return t
 	}
 	return strings.TrimSpace(os.Getenv("GH_TOKEN"))
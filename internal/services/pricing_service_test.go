@@ -460,3 +460,25 @@ func TestPricingService_CalculateCost(t *testing.T) {
 	assert.InDelta(t, expectedOutputCost, outputCost, 0.01)
 	assert.InDelta(t, expectedTotalCost, totalCost, 0.01)
 }
+
+// TestAddTokenUsage_CacheSavings covers the session's cache savings: the
+// difference between billing the cached tokens at the full input rate and at
+// the cache-read rate, zero when the gateway reports no cache-read rate.
+func TestAddTokenUsage_CacheSavings(t *testing.T) {
+	cacheRead := 0.25
+	setGatewayPricing(map[string]gatewayPrice{
+		"g/discounted": {inputPerMTok: 2.5, outputPerMTok: 10.0, cacheReadPerMTok: &cacheRead},
+		"g/no-rate":    {inputPerMTok: 2.5, outputPerMTok: 10.0},
+	})
+	defer setGatewayPricing(nil)
+
+	repo := NewInMemoryConversationRepository(nil, NewPricingService(&config.PricingConfig{Enabled: true}))
+
+	assert.NoError(t, repo.AddTokenUsage("g/discounted", 1_000_000, 0, 1_000_000, 800_000))
+	assert.InDelta(t, 1.8, repo.GetSessionCostStats().TotalCacheSavings, 1e-9,
+		"800k cached tokens at $2.50 - $0.25 per MTok")
+
+	assert.NoError(t, repo.AddTokenUsage("g/no-rate", 1_000_000, 0, 1_000_000, 800_000))
+	assert.InDelta(t, 1.8, repo.GetSessionCostStats().TotalCacheSavings, 1e-9,
+		"no cache-read rate means no discount")
+}
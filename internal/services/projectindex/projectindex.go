@@ -0,0 +1,391 @@
+// Package projectindex builds the project index `infer index` maintains under
+// .infer/index: a one-line summary, the top-level symbols and a term vector for
+// every text file git tracks (or would track). Refreshes are incremental: only
+// the files git reports as changed since the previous build are read again,
+// so CI can restore a cached index and bring it up to date in seconds.
+package projectindex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+// FormatVersion is bumped whenever the on-disk layout or the way entries are
+// derived changes; an index with another version is rebuilt from scratch.
+const FormatVersion = 1
+
+// DefaultPath is where the index lives, relative to the project root
+const DefaultPath = config.ConfigDirName + "/index/project.json"
+
+// DefaultMaxFileSize skips files larger than this; they are almost always
+// generated or data files nobody wants summarized.
+const DefaultMaxFileSize = 512 * 1024
+
+// skippedDirs are never walked when the project is not a git repository
+var skippedDirs = map[string]bool{
+	".git": true, ".infer": true, "node_modules": true, "vendor": true,
+	"dist": true, "build": true, "target": true, "__pycache__": true,
+}
+
+// Index is the persisted project index
+type Index struct {
+	Version   int                  `json:"version"`
+	Commit    string               `json:"commit,omitempty"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	Dirty     []string             `json:"dirty,omitempty"`
+	Files     map[string]FileEntry `json:"files"`
+}
+
+// FileEntry is what the index knows about one file
+type FileEntry struct {
+	Hash     string    `json:"hash"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"mod_time"`
+	Language string    `json:"language,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Symbols  []Symbol  `json:"symbols,omitempty"`
+	Vector   []float32 `json:"vector,omitempty"`
+}
+
+// Options control a build
+type Options struct {
+	// Root is the project directory; paths in the index are relative to it
+	Root string
+	// MaxFileSize skips larger files; zero means DefaultMaxFileSize
+	MaxFileSize int64
+	// Full ignores the previous index and reads every file again
+	Full bool
+}
+
+// Stats summarize what a build did
+type Stats struct {
+	Files       int           `json:"files"`
+	Indexed     int           `json:"indexed"`
+	Reused      int           `json:"reused"`
+	Removed     int           `json:"removed"`
+	Skipped     int           `json:"skipped"`
+	Incremental bool          `json:"incremental"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// Load reads an index from path. A missing file yields (nil, nil).
+func Load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse index %s: %w", path, err)
+	}
+	return &idx, nil
+}
+
+// Save writes the index to path, creating its directory
+func Save(path string, idx *Index) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Build indexes the project, reusing entries from prev for the files git
+// does not report as changed since prev was built.
+func Build(ctx context.Context, opts Options, prev *Index) (*Index, Stats, error) {
+	start := time.Now()
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+
+	files, inGit, err := listFiles(ctx, opts.Root)
+	if err != nil {
+		return nil, Stats{}, err
+	}
+
+	idx := &Index{Version: FormatVersion, UpdatedAt: time.Now().UTC(), Files: make(map[string]FileEntry, len(files))}
+	var changed map[string]bool
+	if inGit {
+		idx.Commit = gitOutput(ctx, opts.Root, "rev-parse", "HEAD")
+		dirty, err := gitDirty(ctx, opts.Root)
+		if err != nil {
+			return nil, Stats{}, err
+		}
+		idx.Dirty = dirty
+		if prev != nil && !opts.Full && prev.Version == FormatVersion {
+			changed = changedSince(ctx, opts.Root, prev, idx.Commit, dirty)
+		}
+	}
+
+	stats := Stats{Incremental: prev != nil && !opts.Full && prev.Version == FormatVersion}
+	if !stats.Incremental {
+		prev = nil
+	}
+
+	for _, rel := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, Stats{}, err
+		}
+		info, err := os.Stat(filepath.Join(opts.Root, rel))
+		if err != nil || !info.Mode().IsRegular() || info.Size() > opts.MaxFileSize {
+			stats.Skipped++
+			continue
+		}
+
+		if old, ok := reusable(prev, changed, rel, info); ok {
+			idx.Files[rel] = old
+			stats.Reused++
+			continue
+		}
+
+		entry, ok, err := indexFile(opts.Root, rel, info)
+		if err != nil {
+			return nil, Stats{}, err
+		}
+		if !ok {
+			stats.Skipped++
+			continue
+		}
+		if old, found := lookup(prev, rel); found && old.Hash == entry.Hash {
+			stats.Reused++
+		} else {
+			stats.Indexed++
+		}
+		idx.Files[rel] = entry
+	}
+
+	if prev != nil {
+		for rel := range prev.Files {
+			if _, ok := idx.Files[rel]; !ok {
+				stats.Removed++
+			}
+		}
+	}
+	stats.Files = len(idx.Files)
+	stats.Duration = time.Since(start)
+	return idx, stats, nil
+}
+
+// Stale lists the indexed or unindexed files git reports as changed since idx
+// was built, without reading any of them.
+func Stale(ctx context.Context, root string, idx *Index) ([]string, error) {
+	if idx == nil {
+		return nil, fmt.Errorf("no index")
+	}
+	if gitOutput(ctx, root, "rev-parse", "--is-inside-work-tree") != "true" {
+		return nil, fmt.Errorf("not a git repository; run infer index to refresh")
+	}
+	dirty, err := gitDirty(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	changed := changedSince(ctx, root, idx, gitOutput(ctx, root, "rev-parse", "HEAD"), dirty)
+	if changed == nil {
+		return nil, fmt.Errorf("commit %s is no longer in history; run infer index --full", idx.Commit)
+	}
+
+	stale := make([]string, 0, len(changed))
+	for rel := range changed {
+		stale = append(stale, rel)
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+func lookup(prev *Index, rel string) (FileEntry, bool) {
+	if prev == nil {
+		return FileEntry{}, false
+	}
+	entry, ok := prev.Files[rel]
+	return entry, ok
+}
+
+// reusable reports whether the previous entry for rel still holds. With git
+// the changed set decides; without it the size and modification time do.
+func reusable(prev *Index, changed map[string]bool, rel string, info fs.FileInfo) (FileEntry, bool) {
+	old, ok := lookup(prev, rel)
+	if !ok {
+		return FileEntry{}, false
+	}
+	if changed != nil {
+		return old, !changed[rel]
+	}
+	return old, old.Size == info.Size() && old.ModTime.Equal(info.ModTime().UTC())
+}
+
+// changedSince returns the paths changed between prev and the working tree:
+// the commits since prev.Commit, the files dirty now and the ones dirty when
+// prev was built (they may have been reverted since). Returns nil when the
+// previous commit cannot be diffed, which forces the mtime comparison.
+func changedSince(ctx context.Context, root string, prev *Index, head string, dirty []string) map[string]bool {
+	changed := map[string]bool{}
+	if prev.Commit != head {
+		if prev.Commit == "" || head == "" {
+			return nil
+		}
+		cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", "-z", prev.Commit, head)
+		cmd.Dir = root
+		out, err := cmd.Output()
+		if err != nil {
+			return nil
+		}
+		for _, rel := range splitNUL(out) {
+			changed[rel] = true
+		}
+	}
+	for _, rel := range dirty {
+		changed[rel] = true
+	}
+	for _, rel := range prev.Dirty {
+		changed[rel] = true
+	}
+	return changed
+}
+
+// listFiles returns the project's files relative to root. In a git repository
+// that is every tracked or untracked-but-not-ignored file. Paths matched by
+// the project's .inferignore are left out either way.
+func listFiles(ctx context.Context, root string) ([]string, bool, error) {
+	inferignore := config.LoadInferignore(root)
+	cmd := exec.CommandContext(ctx, "git", "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	cmd.Dir = root
+	if out, err := cmd.Output(); err == nil {
+		files := slices.DeleteFunc(splitNUL(out), func(rel string) bool {
+			return isConfigPath(rel) || inferignore.Excludes(filepath.Join(root, rel))
+		})
+		sort.Strings(files)
+		return files, true, nil
+	}
+
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (skippedDirs[d.Name()] || strings.HasPrefix(d.Name(), ".") || inferignore.Excludes(path)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if inferignore.Excludes(path) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list project files: %w", err)
+	}
+	return files, false, nil
+}
+
+// gitDirty returns the paths `git status` reports as modified, added,
+// deleted, renamed or untracked
+func gitDirty(ctx context.Context, root string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain", "-z", "--untracked-files=all")
+	cmd.Dir = root
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+
+	var dirty []string
+	fields := splitNUL(out)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if len(field) < 4 {
+			continue
+		}
+		dirty = append(dirty, field[3:])
+		if field[0] == 'R' || field[0] == 'C' {
+			i++
+			if i < len(fields) {
+				dirty = append(dirty, fields[i])
+			}
+		}
+	}
+	dirty = slices.DeleteFunc(dirty, isConfigPath)
+	sort.Strings(dirty)
+	return dirty, nil
+}
+
+func gitOutput(ctx context.Context, root string, args ...string) string {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = root
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// isConfigPath reports whether rel is inside the project's .infer directory,
+// which holds the index itself and is never indexed
+func isConfigPath(rel string) bool {
+	return strings.HasPrefix(rel, config.ConfigDirName+"/")
+}
+
+func splitNUL(out []byte) []string {
+	var parts []string
+	for _, part := range bytes.Split(out, []byte{0}) {
+		if len(part) > 0 {
+			parts = append(parts, string(part))
+		}
+	}
+	return parts
+}
+
+// indexFile reads one file and derives its entry. ok is false for binary
+// files.
+func indexFile(root, rel string, info fs.FileInfo) (FileEntry, bool, error) {
+	data, err := os.ReadFile(filepath.Join(root, rel))
+	if err != nil {
+		return FileEntry{}, false, fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return FileEntry{}, false, nil
+	}
+
+	sum := sha256.Sum256(data)
+	content := string(data)
+	language := languageOf(rel)
+	symbols := extractSymbols(language, content)
+	return FileEntry{
+		Hash:     hex.EncodeToString(sum[:]),
+		Size:     info.Size(),
+		ModTime:  info.ModTime().UTC(),
+		Language: language,
+		Summary:  summarize(language, content),
+		Symbols:  symbols,
+		Vector:   fileVector(rel, symbols, content),
+	}, true, nil
+}
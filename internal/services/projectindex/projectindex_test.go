@@ -0,0 +1,164 @@
+package projectindex
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed; skipping git-backed test")
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test")
+	writeFile(t, dir, "auth/token.go", "// Package auth refreshes OAuth tokens.\npackage auth\n\nfunc RefreshToken() error { return nil }\n")
+	writeFile(t, dir, "README.md", "# Demo\n\nA demo project for the index.\n")
+	writeFile(t, dir, ".gitignore", "ignored.txt\n")
+	writeFile(t, dir, "ignored.txt", "not indexed\n")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+	return dir
+}
+
+func TestBuildIncremental(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+
+	idx, stats, err := Build(ctx, Options{Root: repo}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Incremental || stats.Indexed != 3 {
+		t.Errorf("first build stats = %+v, want 3 indexed files", stats)
+	}
+	if _, ok := idx.Files["ignored.txt"]; ok {
+		t.Error("git-ignored files must not be indexed")
+	}
+	entry := idx.Files["auth/token.go"]
+	if entry.Summary != "Package auth refreshes OAuth tokens." {
+		t.Errorf("summary = %q", entry.Summary)
+	}
+	if len(entry.Symbols) != 1 || entry.Symbols[0].Name != "RefreshToken" || entry.Symbols[0].Line != 4 {
+		t.Errorf("symbols = %+v", entry.Symbols)
+	}
+
+	path := filepath.Join(repo, DefaultPath)
+	if err := Save(path, idx); err != nil {
+		t.Fatal(err)
+	}
+	prev, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, repo, "auth/token.go", "package auth\n\nfunc RefreshToken() error { return nil }\n\nfunc RevokeToken() {}\n")
+	writeFile(t, repo, "new.py", "def handler():\n    pass\n")
+	if err := os.Remove(filepath.Join(repo, "README.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := Stale(ctx, repo, prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"README.md", "auth/token.go", "new.py"} {
+		if !slices.Contains(stale, want) {
+			t.Errorf("stale = %v, missing %s", stale, want)
+		}
+	}
+
+	idx, stats, err = Build(ctx, Options{Root: repo}, prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stats.Incremental || stats.Indexed != 2 || stats.Reused != 1 || stats.Removed != 1 {
+		t.Errorf("incremental stats = %+v, want 2 indexed, 1 reused, 1 removed", stats)
+	}
+	if got := len(idx.Files["auth/token.go"].Symbols); got != 2 {
+		t.Errorf("changed file was not re-indexed: %d symbols", got)
+	}
+}
+
+func TestBuildHonorsInferignore(t *testing.T) {
+	repo := newTestRepo(t)
+	writeFile(t, repo, ".inferignore", "vendor/\n")
+	writeFile(t, repo, "vendor/lib/lib.go", "package lib\n")
+
+	idx, _, err := Build(context.Background(), Options{Root: repo}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := idx.Files["vendor/lib/lib.go"]; ok {
+		t.Error(".inferignore'd files must not be indexed")
+	}
+	if _, ok := idx.Files["auth/token.go"]; !ok {
+		t.Error("files outside .inferignore patterns must still be indexed")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	repo := newTestRepo(t)
+	idx, _, err := Build(context.Background(), Options{Root: repo}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := Search(idx, "refresh oauth token", 2)
+	if len(results) == 0 || results[0].Path != "auth/token.go" {
+		t.Errorf("results = %+v, want auth/token.go first", results)
+	}
+	if results := Search(idx, "RefreshToken", 1); len(results) != 1 || results[0].Score < 0.5 {
+		t.Errorf("a symbol name match must rank with the symbol bonus, got %+v", results)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("parseHTTPRequest snake_case_name x")
+	want := []string{"parse", "http", "request", "snake", "case", "name"}
+	if !slices.Equal(got, want) {
+		t.Errorf("tokenize = %v, want %v", got, want)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		language, content, want string
+	}{
+		{"go", "//go:build linux\n\n// Package x does y.\n// And z.\npackage x\n", "Package x does y. And z."},
+		{"python", "\"\"\"Module docstring.\"\"\"\nimport os\n", "Module docstring."},
+		{"shell", "#!/bin/sh\n# Installs the CLI.\nset -e\n", "Installs the CLI."},
+		{"markdown", "# Title\n\nFirst paragraph\ncontinues.\n\nMore.\n", "Title First paragraph continues."},
+		{"go", "package x\n", ""},
+	}
+	for _, tt := range tests {
+		if got := summarize(tt.language, tt.content); got != tt.want {
+			t.Errorf("summarize(%s, %q) = %q, want %q", tt.language, tt.content, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package projectindex
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxSummaryLen caps a file summary
+const maxSummaryLen = 200
+
+// maxSymbols caps the symbols kept per file
+const maxSymbols = 200
+
+// Symbol is a top-level declaration found in a file
+type Symbol struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	Line int    `json:"line"`
+}
+
+// languages maps file extensions to the language names the symbol patterns use
+var languages = map[string]string{
+	".go": "go", ".py": "python", ".js": "javascript", ".jsx": "javascript", ".mjs": "javascript",
+	".ts": "typescript", ".tsx": "typescript", ".rs": "rust", ".java": "java", ".kt": "kotlin",
+	".rb": "ruby", ".c": "c", ".h": "c", ".cc": "cpp", ".cpp": "cpp", ".hpp": "cpp", ".cs": "csharp",
+	".php": "php", ".swift": "swift", ".sh": "shell", ".bash": "shell", ".md": "markdown",
+	".yaml": "yaml", ".yml": "yaml", ".json": "json", ".toml": "toml", ".sql": "sql",
+}
+
+// symbolPattern captures a declaration's name in group 1
+type symbolPattern struct {
+	kind string
+	re   *regexp.Regexp
+}
+
+var symbolPatterns = map[string][]symbolPattern{
+	"go": {
+		{"method", regexp.MustCompile(`^func \([^)]*\) (\w+)`)},
+		{"func", regexp.MustCompile(`^func (\w+)`)},
+		{"type", regexp.MustCompile(`^type (\w+)`)},
+		{"type", regexp.MustCompile(`^\t(\w+) +(?:struct|interface)\b`)},
+	},
+	"python": {
+		{"class", regexp.MustCompile(`^class (\w+)`)},
+		{"func", regexp.MustCompile(`^(?:async )?def (\w+)`)},
+	},
+	"javascript": {
+		{"class", regexp.MustCompile(`^(?:export )?(?:default )?class (\w+)`)},
+		{"func", regexp.MustCompile(`^(?:export )?(?:default )?(?:async )?function\*? (\w+)`)},
+		{"const", regexp.MustCompile(`^(?:export )?const (\w+) *=`)},
+	},
+	"typescript": {
+		{"class", regexp.MustCompile(`^(?:export )?(?:default )?(?:abstract )?class (\w+)`)},
+		{"func", regexp.MustCompile(`^(?:export )?(?:default )?(?:async )?function\*? (\w+)`)},
+		{"type", regexp.MustCompile(`^(?:export )?(?:interface|type|enum) (\w+)`)},
+		{"const", regexp.MustCompile(`^(?:export )?const (\w+) *[:=]`)},
+	},
+	"rust": {
+		{"func", regexp.MustCompile(`^(?:pub(?:\([^)]*\))? )?(?:async )?fn (\w+)`)},
+		{"type", regexp.MustCompile(`^(?:pub(?:\([^)]*\))? )?(?:struct|enum|trait|type) (\w+)`)},
+	},
+	"java": {
+		{"type", regexp.MustCompile(`^(?:public |protected |private )?(?:abstract |final )?(?:class|interface|enum|record) (\w+)`)},
+	},
+	"kotlin": {
+		{"type", regexp.MustCompile(`^(?:data |sealed |abstract |open )?(?:class|interface|object) (\w+)`)},
+		{"func", regexp.MustCompile(`^fun (?:<[^>]*> )?(\w+)`)},
+	},
+	"ruby": {
+		{"type", regexp.MustCompile(`^(?:class|module) (\w+)`)},
+		{"func", regexp.MustCompile(`^ *def (?:self\.)?(\w+)`)},
+	},
+	"shell": {
+		{"func", regexp.MustCompile(`^(?:function )?(\w+) *\(\) *\{`)},
+	},
+	"markdown": {
+		{"heading", regexp.MustCompile(`^#{1,3} +(.+?) *#*$`)},
+	},
+}
+
+// languageOf names the language of a path from its extension
+func languageOf(rel string) string {
+	base := filepath.Base(rel)
+	switch base {
+	case "Dockerfile", "Makefile", "Taskfile.yml", "go.mod":
+		return strings.ToLower(base)
+	}
+	return languages[strings.ToLower(filepath.Ext(rel))]
+}
+
+// extractSymbols finds the top-level declarations of a file
+func extractSymbols(language, content string) []Symbol {
+	patterns := symbolPatterns[language]
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var symbols []Symbol
+	for i, line := range strings.Split(content, "\n") {
+		for _, pattern := range patterns {
+			if match := pattern.re.FindStringSubmatch(line); match != nil {
+				symbols = append(symbols, Symbol{Name: match[1], Kind: pattern.kind, Line: i + 1})
+				break
+			}
+		}
+		if len(symbols) == maxSymbols {
+			break
+		}
+	}
+	return symbols
+}
+
+// summarize returns the file's leading comment (a Go package comment, a
+// Python docstring, a script header) or its first Markdown heading and
+// paragraph, flattened to one line.
+func summarize(language, content string) string {
+	var parts []string
+	inBlock := false
+	for _, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(line, "#!"), strings.HasPrefix(line, "//go:build"), strings.HasPrefix(line, "// +build"):
+			continue
+		case language == "markdown":
+			if line == "" {
+				if len(parts) > 1 {
+					return clip(strings.Join(parts, " "))
+				}
+				continue
+			}
+			if strings.HasPrefix(line, "#") && len(parts) > 0 {
+				return clip(strings.Join(parts, " "))
+			}
+			parts = append(parts, strings.TrimSpace(strings.TrimLeft(line, "#")))
+			continue
+		case inBlock:
+			end := strings.Contains(line, "*/") || strings.Contains(line, `"""`)
+			line = strings.TrimSpace(strings.NewReplacer("*/", "", `"""`, "").Replace(line))
+			parts = append(parts, strings.TrimSpace(strings.TrimPrefix(line, "*")))
+			if end {
+				return clip(strings.Join(parts, " "))
+			}
+			continue
+		case strings.HasPrefix(line, "/*"), strings.HasPrefix(line, `"""`):
+			inBlock = true
+			rest := strings.TrimPrefix(strings.TrimPrefix(line, "/*"), `"""`)
+			if strings.Contains(rest, "*/") || strings.Contains(rest, `"""`) {
+				return clip(strings.NewReplacer("*/", "", `"""`, "").Replace(rest))
+			}
+			parts = append(parts, strings.TrimSpace(strings.TrimPrefix(rest, "*")))
+			continue
+		case strings.HasPrefix(line, "//"):
+			parts = append(parts, strings.TrimSpace(strings.TrimLeft(line, "/")))
+			continue
+		case strings.HasPrefix(line, "#") && language != "c" && language != "cpp":
+			parts = append(parts, strings.TrimSpace(strings.TrimLeft(line, "#")))
+			continue
+		case line == "" && len(parts) == 0:
+			continue
+		}
+		break
+	}
+	return clip(strings.Join(parts, " "))
+}
+
+// clip collapses whitespace and caps s at maxSummaryLen
+func clip(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) <= maxSummaryLen {
+		return s
+	}
+	cut := strings.LastIndex(s[:maxSummaryLen], " ")
+	if cut <= 0 {
+		cut = maxSummaryLen
+	}
+	return s[:cut] + "…"
+}
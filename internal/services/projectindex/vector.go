@@ -0,0 +1,167 @@
+package projectindex
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// VectorDims is the length of a file's term vector. Terms are hashed into
+// the dimensions, so vectors need no vocabulary and no embedding model; the
+// collisions cost some precision but keep the index small and offline.
+const VectorDims = 128
+
+// pathWeight and symbolWeight boost the terms of a file's path and symbol
+// names over terms that merely occur in its content
+const (
+	pathWeight   = 3
+	symbolWeight = 2
+)
+
+// Result is one file matched by Search
+type Result struct {
+	Path    string  `json:"path"`
+	Score   float64 `json:"score"`
+	Summary string  `json:"summary,omitempty"`
+}
+
+// Search ranks the indexed files by how well their term vectors match query,
+// with a bonus for files declaring a symbol named like a query term
+func Search(idx *Index, query string, limit int) []Result {
+	terms := tokenize(query)
+	if idx == nil || len(terms) == 0 {
+		return nil
+	}
+	counts := map[string]float64{}
+	for _, term := range terms {
+		counts[term]++
+	}
+	queryVector := hashTerms(counts)
+	words := map[string]bool{}
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		words[word] = true
+	}
+
+	var results []Result
+	for rel, entry := range idx.Files {
+		score := cosine(queryVector, entry.Vector)
+		for _, symbol := range entry.Symbols {
+			if words[strings.ToLower(symbol.Name)] {
+				score += 0.5
+				break
+			}
+		}
+		if score > 0 {
+			results = append(results, Result{Path: rel, Score: score, Summary: entry.Summary})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Path < results[j].Path
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// fileVector builds the term vector of a file from its path, symbols and content
+func fileVector(rel string, symbols []Symbol, content string) []float32 {
+	counts := map[string]float64{}
+	for _, term := range tokenize(rel) {
+		counts[term] += pathWeight
+	}
+	for _, symbol := range symbols {
+		for _, term := range tokenize(symbol.Name) {
+			counts[term] += symbolWeight
+		}
+	}
+	for _, term := range tokenize(content) {
+		counts[term]++
+	}
+	return hashTerms(counts)
+}
+
+// hashTerms folds term counts into a normalized VectorDims vector, damping
+// frequent terms logarithmically
+func hashTerms(counts map[string]float64) []float32 {
+	vector := make([]float64, VectorDims)
+	for term, count := range counts {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(term))
+		vector[h.Sum32()%VectorDims] += 1 + math.Log(count)
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	out := make([]float32, VectorDims)
+	if norm == 0 {
+		return out
+	}
+	norm = math.Sqrt(norm)
+	for i, v := range vector {
+		out[i] = float32(v / norm)
+	}
+	return out
+}
+
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+// tokenize splits text into lowercase terms, breaking identifiers at
+// camelCase and snake_case boundaries; terms shorter than two characters and
+// common keywords are dropped
+func tokenize(text string) []string {
+	var terms []string
+	var word []rune
+	flush := func() {
+		if len(word) >= 2 {
+			term := strings.ToLower(string(word))
+			if !stopWords[term] {
+				terms = append(terms, term)
+			}
+		}
+		word = word[:0]
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if unicode.IsUpper(r) && len(word) > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					flush()
+				}
+			}
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return terms
+}
+
+var stopWords = map[string]bool{
+	"the": true, "and": true, "or": true, "of": true, "to": true, "in": true, "is": true, "it": true,
+	"if": true, "for": true, "func": true, "return": true, "nil": true, "err": true, "var": true,
+	"const": true, "type": true, "import": true, "package": true, "def": true, "self": true,
+	"this": true, "let": true, "function": true, "class": true, "new": true, "null": true,
+	"true": true, "false": true, "string": true, "int": true, "else": true, "with": true,
+}
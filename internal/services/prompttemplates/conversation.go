@@ -0,0 +1,71 @@
+package prompttemplates
+
+import (
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// ConversationSeed is a conversation template rendered for a new session
+type ConversationSeed struct {
+	Name    string
+	Model   string
+	Context string
+	Message string
+}
+
+// FindConversation returns the conversation template called name, ignoring case
+func FindConversation(templates []config.ConversationTemplate, name string) (config.ConversationTemplate, bool) {
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return config.ConversationTemplate{}, false
+}
+
+// RenderConversation fills in the variables of a conversation template and
+// appends its files to the first message as @references, which the chat
+// expands into their content when the message is sent
+func RenderConversation(tmpl config.ConversationTemplate, vars map[string]string) ConversationSeed {
+	systemContext, _ := Render(strings.TrimSpace(tmpl.Context), vars)
+	message, _ := Render(strings.TrimSpace(tmpl.Message), vars)
+
+	refs := make([]string, 0, len(tmpl.Files))
+	for _, file := range tmpl.Files {
+		if file = strings.TrimSpace(file); file != "" {
+			refs = append(refs, "@"+file)
+		}
+	}
+	if len(refs) > 0 {
+		message = strings.TrimSpace(message + "\n\n" + strings.Join(refs, " "))
+	}
+
+	return ConversationSeed{
+		Name:    tmpl.Name,
+		Model:   tmpl.Model,
+		Context: systemContext,
+		Message: message,
+	}
+}
+
+// ContextEntry returns the message that carries the seed's context to the
+// model: hidden from the history and pinned so compaction keeps it. ok is
+// false when the template has no context.
+func (s ConversationSeed) ContextEntry() (domain.ConversationEntry, bool) {
+	if s.Context == "" {
+		return domain.ConversationEntry{}, false
+	}
+	return domain.ConversationEntry{
+		Message: sdk.Message{
+			Role:    sdk.User,
+			Content: sdk.NewMessageContent("<system-reminder>\n" + s.Context + "\n</system-reminder>"),
+		},
+		Time:   time.Now(),
+		Hidden: true,
+		Pinned: true,
+	}, true
+}
@@ -0,0 +1,46 @@
+package prompttemplates
+
+import (
+	"testing"
+
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestFindConversation(t *testing.T) {
+	templates := []config.ConversationTemplate{{Name: "bugfix", Message: "a"}, {Name: "Review", Message: "b"}}
+
+	tmpl, ok := FindConversation(templates, "review")
+	require.True(t, ok)
+	require.Equal(t, "b", tmpl.Message)
+
+	_, ok = FindConversation(templates, "missing")
+	require.False(t, ok)
+}
+
+func TestRenderConversation(t *testing.T) {
+	seed := RenderConversation(config.ConversationTemplate{
+		Name:    "bugfix",
+		Context: "  We are fixing bugs on {{branch}}. Write a failing test first.  ",
+		Message: "Fix {{issue}}",
+		Files:   []string{"CONTRIBUTING.md", " ", "internal/app/chat.go"},
+		Model:   "anthropic/claude-sonnet-4",
+	}, map[string]string{"branch": "main"})
+
+	require.Equal(t, "bugfix", seed.Name)
+	require.Equal(t, "anthropic/claude-sonnet-4", seed.Model)
+	require.Equal(t, "We are fixing bugs on main. Write a failing test first.", seed.Context)
+	require.Equal(t, "Fix {{issue}}\n\n@CONTRIBUTING.md @internal/app/chat.go", seed.Message)
+
+	entry, ok := seed.ContextEntry()
+	require.True(t, ok)
+	require.True(t, entry.Hidden)
+	require.True(t, entry.Pinned)
+	text, err := entry.Message.Content.AsMessageContent0()
+	require.NoError(t, err)
+	require.Contains(t, text, "We are fixing bugs on main.")
+
+	_, ok = RenderConversation(config.ConversationTemplate{Name: "empty"}, nil).ContextEntry()
+	require.False(t, ok)
+}
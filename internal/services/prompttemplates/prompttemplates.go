@@ -0,0 +1,106 @@
+// Package prompttemplates fills in the {{variable}} placeholders of the
+// reusable prompt templates kept under `templates` in prompts.yaml. It is
+// shared by `infer prompts` and the in-chat /prompt picker so both render a
+// template the same way.
+package prompttemplates
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/cli/config"
+	constants "github.com/inference-gateway/cli/internal/constants"
+	gitdiff "github.com/inference-gateway/cli/internal/services/gitdiff"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// Find returns the template called name, ignoring case
+func Find(templates []config.PromptTemplate, name string) (config.PromptTemplate, bool) {
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return config.PromptTemplate{}, false
+}
+
+// Variables lists the variables text references, in order of first use
+func Variables(text string) []string {
+	var names []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(names, m[1]) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// Render replaces every placeholder of text that has a value in vars and
+// returns the names of those that have none. Unfilled placeholders are left
+// in the text so the user can still complete them by hand.
+func Render(text string, vars map[string]string) (string, []string) {
+	var missing []string
+	rendered := placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+		return placeholder
+	})
+	return rendered, missing
+}
+
+// BuiltinVars returns the variables every template can use without passing
+// them: branch (the current git branch, when in a repository), date
+// (YYYY-MM-DD) and dir (the working directory)
+func BuiltinVars(ctx context.Context) map[string]string {
+	vars := map[string]string{"date": time.Now().Format(time.DateOnly)}
+	if dir, err := os.Getwd(); err == nil {
+		vars["dir"] = dir
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.GitCommandTimeout)
+	defer cancel()
+	if out, err := gitdiff.RunGit(ctx, "", "branch", "--show-current"); err == nil {
+		if branch := strings.TrimSpace(string(out)); branch != "" {
+			vars["branch"] = branch
+		}
+	}
+	return vars
+}
+
+// Vars parses key=value assignments into variables and adds the built-in
+// ones the assignments do not override
+func Vars(ctx context.Context, assignments []string) (map[string]string, error) {
+	vars, err := parseAssignments(assignments)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range BuiltinVars(ctx) {
+		if _, set := vars[name]; !set {
+			vars[name] = value
+		}
+	}
+	return vars, nil
+}
+
+func parseAssignments(args []string) (map[string]string, error) {
+	vars := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid variable %q, expected key=value", arg)
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars, nil
+}
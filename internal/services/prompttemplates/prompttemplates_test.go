@@ -0,0 +1,53 @@
+package prompttemplates
+
+import (
+	"context"
+	"testing"
+
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestVariables(t *testing.T) {
+	require.Equal(t, []string{"file", "branch"}, Variables("Plan {{file}} on {{ branch }}, then {{file}} again"))
+	require.Empty(t, Variables("no placeholders, not even {{ }} or {{1x}}"))
+}
+
+func TestRender(t *testing.T) {
+	rendered, missing := Render("Write a migration plan for {{file}} on {{branch}} ({{ ticket }})", map[string]string{
+		"file":   "db/schema.sql",
+		"branch": "main",
+	})
+	require.Equal(t, "Write a migration plan for db/schema.sql on main ({{ ticket }})", rendered)
+	require.Equal(t, []string{"ticket"}, missing)
+}
+
+func TestFind(t *testing.T) {
+	templates := []config.PromptTemplate{{Name: "migration", Prompt: "a"}, {Name: "Release-Notes", Prompt: "b"}}
+
+	tmpl, ok := Find(templates, "release-notes")
+	require.True(t, ok)
+	require.Equal(t, "b", tmpl.Prompt)
+
+	_, ok = Find(templates, "missing")
+	require.False(t, ok)
+}
+
+func TestVars(t *testing.T) {
+	vars, err := Vars(context.Background(), []string{"file=a.go", "query=x=1", "date=yesterday"})
+	require.NoError(t, err)
+	require.Equal(t, "a.go", vars["file"])
+	require.Equal(t, "x=1", vars["query"])
+	require.Equal(t, "yesterday", vars["date"])
+	require.NotEmpty(t, vars["dir"])
+
+	_, err = Vars(context.Background(), []string{"file"})
+	require.Error(t, err)
+}
+
+func TestBuiltinVars(t *testing.T) {
+	vars := BuiltinVars(context.Background())
+	require.NotEmpty(t, vars["date"])
+	require.NotEmpty(t, vars["dir"])
+}
@@ -0,0 +1,206 @@
+// Package prreview fetches GitHub pull requests and posts reviews on them for
+// `infer review`. Like githubissues it shells out to the user's gh CLI, so
+// authentication and the current repository are inherited from gh.
+package prreview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const cmdTimeout = 60 * time.Second
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// runnerFunc shells out to gh with the given args and stdin. Stubbed in tests.
+type runnerFunc func(ctx context.Context, stdin []byte, args ...string) ([]byte, error)
+
+// Service reads pull requests and posts reviews through gh
+type Service struct {
+	runner runnerFunc
+}
+
+// PullRequest is the part of a pull request a review needs
+type PullRequest struct {
+	Number  int
+	Title   string
+	Body    string
+	URL     string
+	Author  string
+	BaseRef string
+	HeadRef string
+	HeadSHA string
+	Diff    string
+}
+
+// Comment is an inline review comment on a line of the new side of the diff
+type Comment struct {
+	Path string
+	Line int
+	Body string
+}
+
+// Review is what PostReview posts: a summary body and inline comments
+type Review struct {
+	Body     string
+	Comments []Comment
+}
+
+// New constructs a Service that shells out to the real gh CLI
+func New() *Service {
+	return &Service{runner: defaultRunner}
+}
+
+func defaultRunner(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("gh %s: %s", strings.Join(args[:min(2, len(args))], " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh %s: %w", strings.Join(args[:min(2, len(args))], " "), err)
+	}
+	return out, nil
+}
+
+// IsAvailable reports whether the gh CLI is on PATH
+func IsAvailable() bool {
+	_, err := exec.LookPath("gh")
+	return err == nil
+}
+
+// GetPullRequest fetches pull request number of the current repository with
+// its diff
+func (s *Service) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	if number <= 0 {
+		return nil, errors.New("invalid pull request number")
+	}
+	ctx, cancel := context.WithTimeout(ctx, cmdTimeout)
+	defer cancel()
+
+	out, err := s.runner(ctx, nil,
+		"pr", "view", strconv.Itoa(number),
+		"--json", "number,title,body,url,author,baseRefName,headRefName,headRefOid",
+	)
+	if err != nil {
+		return nil, err
+	}
+	var raw struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Body   string `json:"body"`
+		URL    string `json:"url"`
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		BaseRefName string `json:"baseRefName"`
+		HeadRefName string `json:"headRefName"`
+		HeadRefOid  string `json:"headRefOid"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request #%d: %w", number, err)
+	}
+
+	diff, err := s.runner(ctx, nil, "pr", "diff", strconv.Itoa(number), "--color", "never")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PullRequest{
+		Number:  raw.Number,
+		Title:   raw.Title,
+		Body:    raw.Body,
+		URL:     raw.URL,
+		Author:  raw.Author.Login,
+		BaseRef: raw.BaseRefName,
+		HeadRef: raw.HeadRefName,
+		HeadSHA: raw.HeadRefOid,
+		Diff:    string(diff),
+	}, nil
+}
+
+// PostReview posts review on pr as a comment-only review pinned to the
+// reviewed head commit, and returns its URL. Every comment must be on a line
+// from CommentableLines, or GitHub rejects the whole review.
+func (s *Service) PostReview(ctx context.Context, pr *PullRequest, review Review) (string, error) {
+	type apiComment struct {
+		Path string `json:"path"`
+		Line int    `json:"line"`
+		Side string `json:"side"`
+		Body string `json:"body"`
+	}
+	payload := struct {
+		CommitID string       `json:"commit_id,omitempty"`
+		Body     string       `json:"body"`
+		Event    string       `json:"event"`
+		Comments []apiComment `json:"comments"`
+	}{CommitID: pr.HeadSHA, Body: review.Body, Event: "COMMENT", Comments: []apiComment{}}
+	for _, c := range review.Comments {
+		payload.Comments = append(payload.Comments, apiComment{Path: c.Path, Line: c.Line, Side: "RIGHT", Body: c.Body})
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal review: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmdTimeout)
+	defer cancel()
+	out, err := s.runner(ctx, data,
+		"api", "--method", "POST",
+		fmt.Sprintf("repos/{owner}/{repo}/pulls/%d/reviews", pr.Number),
+		"--input", "-",
+	)
+	if err != nil {
+		return "", err
+	}
+
+	var posted struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(out, &posted); err != nil {
+		return "", fmt.Errorf("failed to parse the posted review: %w", err)
+	}
+	return posted.HTMLURL, nil
+}
+
+// CommentableLines returns, per file, the new-side line numbers of a unified
+// diff that an inline review comment can attach to: added and context lines
+func CommentableLines(diff string) map[string]map[int]bool {
+	lines := map[string]map[int]bool{}
+	var file string
+	next := 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			file, next = "", 0
+		case strings.HasPrefix(line, "+++ "):
+			file = ""
+			if path, ok := strings.CutPrefix(line, "+++ b/"); ok {
+				file = strings.TrimSpace(path)
+				lines[file] = map[int]bool{}
+			}
+		case strings.HasPrefix(line, "@@"):
+			if m := hunkHeaderPattern.FindStringSubmatch(line); m != nil {
+				next, _ = strconv.Atoi(m[1])
+			}
+		case file == "" || next == 0:
+		case strings.HasPrefix(line, "+"), strings.HasPrefix(line, " "):
+			lines[file][next] = true
+			next++
+		}
+	}
+	return lines
+}
@@ -0,0 +1,100 @@
+package prreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	require "github.com/stretchr/testify/require"
+)
+
+const testDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -10,4 +10,5 @@ func main() {
+ 	cfg := load()
+-	run(cfg)
++	if err := run(cfg); err != nil {
++		log.Fatal(err)
++	}
+ }
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package main
+-func old() {}
+`
+
+// fakeRunner answers each gh subcommand from a canned map and records the
+// calls and stdin it got
+type fakeRunner struct {
+	responses map[string][]byte
+	calls     [][]string
+	stdin     []byte
+}
+
+func (f *fakeRunner) run(_ context.Context, stdin []byte, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	f.stdin = stdin
+	if out, ok := f.responses[strings.Join(args[:2], " ")]; ok {
+		return out, nil
+	}
+	return nil, errors.New("unexpected gh call")
+}
+
+func TestGetPullRequest(t *testing.T) {
+	f := &fakeRunner{responses: map[string][]byte{
+		"pr view": []byte(`{"number":42,"title":"Handle run errors","body":"Fixes #7","url":"https://github.com/o/r/pull/42",
+			"author":{"login":"alice"},"baseRefName":"main","headRefName":"fix-run","headRefOid":"abc123"}`),
+		"pr diff": []byte(testDiff),
+	}}
+	s := &Service{runner: f.run}
+
+	pr, err := s.GetPullRequest(context.Background(), 42)
+	require.NoError(t, err)
+	require.Equal(t, "Handle run errors", pr.Title)
+	require.Equal(t, "alice", pr.Author)
+	require.Equal(t, "abc123", pr.HeadSHA)
+	require.Equal(t, testDiff, pr.Diff)
+
+	_, err = s.GetPullRequest(context.Background(), 0)
+	require.Error(t, err)
+}
+
+func TestPostReview(t *testing.T) {
+	f := &fakeRunner{responses: map[string][]byte{
+		"api --method": []byte(`{"id":1,"html_url":"https://github.com/o/r/pull/42#pullrequestreview-1"}`),
+	}}
+	s := &Service{runner: f.run}
+
+	url, err := s.PostReview(context.Background(), &PullRequest{Number: 42, HeadSHA: "abc123"}, Review{
+		Body:     "1 finding",
+		Comments: []Comment{{Path: "main.go", Line: 11, Body: "**[HIGH]** check the error"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://github.com/o/r/pull/42#pullrequestreview-1", url)
+	require.Contains(t, f.calls[0], "repos/{owner}/{repo}/pulls/42/reviews")
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(f.stdin, &payload))
+	require.Equal(t, "COMMENT", payload["event"])
+	require.Equal(t, "abc123", payload["commit_id"])
+	comment := payload["comments"].([]any)[0].(map[string]any)
+	require.Equal(t, "RIGHT", comment["side"])
+	require.EqualValues(t, 11, comment["line"])
+}
+
+func TestCommentableLines(t *testing.T) {
+	lines := CommentableLines(testDiff)
+
+	for _, line := range []int{10, 11, 12, 13, 14} {
+		require.True(t, lines["main.go"][line], "line %d of main.go", line)
+	}
+	require.False(t, lines["main.go"][15])
+	require.NotContains(t, lines, "old.go")
+}
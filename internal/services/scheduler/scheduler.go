@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	uuid "github.com/google/uuid"
 	cron "github.com/robfig/cron/v3"
@@ -51,6 +52,10 @@ type Service struct {
 // the jobs in storage.
 const pollInterval = 2 * time.Second
 
+// maxLastOutput bounds the assistant output kept on a job after a run; the
+// end of the output is kept, as that is where an agent puts its answer.
+const maxLastOutput = 4000
+
 // Options bundles dependencies and configuration for NewService.
 type Options struct {
 	Store         storage.ScheduledJobStorage
@@ -99,6 +104,19 @@ func ParseCron(expr string) error {
 	return err
 }
 
+// NextRun returns the first time after after that expr fires, in after's
+// location - the daemon's cron runs in local time.
+func NextRun(expr string, after time.Time) (time.Time, error) {
+	parser := cron.NewParser(
+		cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+	)
+	schedule, err := parser.Parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after), nil
+}
+
 // Start initialises the cron scheduler, loads all jobs from storage, and begins
 // polling storage for changes.
 func (s *Service) Start(ctx context.Context) error {
@@ -208,11 +226,15 @@ func (s *Service) fire(job domain.ScheduledJob) {
 		return
 	}
 
-	var firstSendErr error
+	var (
+		firstSendErr error
+		output       strings.Builder
+	)
 	sendFn := func(content string) {
 		if content == "" {
 			return
 		}
+		appendOutput(&output, content)
 		out := domain.OutboundMessage{
 			ChannelName: job.Channel,
 			RecipientID: job.RecipientID,
@@ -237,6 +259,7 @@ func (s *Service) fire(job domain.ScheduledJob) {
 	default:
 		job.LastError = ""
 	}
+	job.LastOutput = truncateOutput(output.String())
 
 	if job.RunOnce {
 		if err := s.store.DeleteJob(context.Background(), job.ID); err != nil {
@@ -259,6 +282,7 @@ func (s *Service) persistRun(job *domain.ScheduledJob) {
 	}
 	current.LastRun = job.LastRun
 	current.LastError = job.LastError
+	current.LastOutput = job.LastOutput
 	if err := s.store.SaveJob(context.Background(), current); err != nil {
 		logger.Warn("failed to persist scheduled job run state", "id", job.ID, "error", err)
 	}
@@ -288,6 +312,59 @@ func (s *Service) runAgent(ctx context.Context, job domain.ScheduledJob, sendFn
 	return nil
 }
 
+// RunNow executes job once in the calling process, for `infer schedule run`.
+// The assistant messages go to onMessage instead of the job's channel, which
+// only the channels-manager daemon can reach, and the run is recorded on the
+// job like a scheduled fire. A run-once job is kept, since it has not fired.
+// opts.ChannelLookup is not used.
+func RunNow(ctx context.Context, opts Options, job *domain.ScheduledJob, onMessage func(string)) error {
+	if opts.Store == nil {
+		return errors.New("scheduler: Store is required")
+	}
+	s := &Service{store: opts.Store, execCmd: opts.ExecCommand, binaryPath: opts.BinaryPath}
+
+	var output strings.Builder
+	err := s.runAgent(ctx, *job, func(content string) {
+		if content == "" {
+			return
+		}
+		appendOutput(&output, content)
+		if onMessage != nil {
+			onMessage(content)
+		}
+	})
+
+	now := time.Now().UTC()
+	job.LastRun = &now
+	job.LastError = ""
+	if err != nil {
+		job.LastError = err.Error()
+	}
+	job.LastOutput = truncateOutput(output.String())
+	s.persistRun(job)
+	return err
+}
+
+func appendOutput(output *strings.Builder, content string) {
+	if output.Len() > 0 {
+		output.WriteString("\n\n")
+	}
+	output.WriteString(content)
+}
+
+// truncateOutput keeps the last maxLastOutput bytes of output, on a rune
+// boundary
+func truncateOutput(output string) string {
+	if len(output) <= maxLastOutput {
+		return output
+	}
+	cut := len(output) - maxLastOutput
+	for cut < len(output) && !utf8.RuneStart(output[cut]) {
+		cut++
+	}
+	return "…" + output[cut:]
+}
+
 // formatAgentLine is a near-duplicate of services.formatAgentMessage. It's
 // kept here to avoid an import cycle (services -> services/scheduler ->
 // services). Behaviour must stay in sync with the original.
@@ -361,7 +438,7 @@ func (s *Service) startPoller(ctx context.Context) {
 }
 
 // reconcile diffs the jobs in storage against the known fingerprints:
-// new/changed jobs are (re-)registered, vanished jobs are removed. The
+// new/changed jobs are (re-)registered, vanished and paused jobs are removed. The
 // fingerprint is the job's marshaled bytes, so hand-edited YAML files on the
 // jsonl backend are picked up too; unchanged jobs are never re-registered, so
 // the poll does not reset @every schedules.
@@ -374,6 +451,9 @@ func (s *Service) reconcile(ctx context.Context, known map[string]string) {
 
 	seen := make(map[string]bool, len(jobs))
 	for _, job := range jobs {
+		if job.Paused {
+			continue
+		}
 		data, err := yaml.Marshal(job)
 		if err != nil {
 			logger.Warn("failed to fingerprint scheduled job", "id", job.ID, "error", err)
@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
 	storage "github.com/inference-gateway/cli/internal/infra/storage"
@@ -427,3 +428,113 @@ func TestService_Fire_ChannelMissing_RecordsError(t *testing.T) {
 		t.Fatal("expected LastError to be set when channel not found")
 	}
 }
+
+func TestService_PausedJobIsNotRegistered(t *testing.T) {
+	ch := &fakeChannel{name: "telegram"}
+	fired := &atomic.Int32{}
+	svc, store := newTestService(t, ch, fired)
+
+	job := &domain.ScheduledJob{
+		ID:             "paused",
+		CronExpression: "@every 1s",
+		Prompt:         "x",
+		Channel:        "telegram",
+		RecipientID:    "user1",
+		Paused:         true,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := store.SaveJob(context.Background(), job); err != nil {
+		t.Fatalf("SaveJob: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := svc.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = svc.Stop(ctx) }()
+
+	if ids := svc.JobIDs(); len(ids) != 0 {
+		t.Fatalf("expected a paused job not to be registered, got %v", ids)
+	}
+
+	resumed := *job
+	resumed.Paused = false
+	if err := store.SaveJob(context.Background(), &resumed); err != nil {
+		t.Fatalf("SaveJob: %v", err)
+	}
+	deadline := time.Now().Add(4 * time.Second)
+	for time.Now().Before(deadline) && len(svc.JobIDs()) == 0 {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if ids := svc.JobIDs(); len(ids) != 1 {
+		t.Fatalf("expected the resumed job to be registered, got %v", ids)
+	}
+}
+
+func TestRunNow_RecordsOutput(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	job := &domain.ScheduledJob{
+		ID:             "manual",
+		CronExpression: "0 8 * * *",
+		Prompt:         "x",
+		Channel:        "telegram",
+		RecipientID:    "user1",
+		RunOnce:        true,
+		LastError:      "previous failure",
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := store.SaveJob(context.Background(), job); err != nil {
+		t.Fatalf("SaveJob: %v", err)
+	}
+
+	var messages []string
+	err := RunNow(context.Background(), Options{
+		Store: store,
+		ExecCommand: func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+			return exec.CommandContext(ctx, "echo", `{"role":"assistant","content":"done manually"}`)
+		},
+		BinaryPath: "/usr/bin/true",
+	}, job, func(msg string) { messages = append(messages, msg) })
+	if err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0] != "done manually" {
+		t.Fatalf("unexpected messages %v", messages)
+	}
+	loaded, err := store.LoadJob(context.Background(), "manual")
+	if err != nil {
+		t.Fatalf("expected a run-once job to survive a manual run: %v", err)
+	}
+	if loaded.LastRun == nil || loaded.LastError != "" || loaded.LastOutput != "done manually" {
+		t.Fatalf("run not recorded: %+v", loaded)
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2026, 10, 16, 7, 30, 0, 0, time.UTC)
+	next, err := NextRun("0 8 * * *", after)
+	if err != nil {
+		t.Fatalf("NextRun: %v", err)
+	}
+	if want := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("NextRun = %v, want %v", next, want)
+	}
+	if _, err := NextRun("bogus", after); err == nil {
+		t.Fatal("expected an error for a bogus expression")
+	}
+}
+
+func TestTruncateOutput(t *testing.T) {
+	long := strings.Repeat("é", maxLastOutput)
+	got := truncateOutput(long)
+	if !strings.HasPrefix(got, "…") || len(got) > maxLastOutput+len("…") {
+		t.Fatalf("unexpected truncation to %d bytes", len(got))
+	}
+	if !utf8.ValidString(got) {
+		t.Fatal("truncation split a rune")
+	}
+	if truncateOutput("short") != "short" {
+		t.Fatal("short output must be kept as is")
+	}
+}
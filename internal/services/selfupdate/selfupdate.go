@@ -0,0 +1,295 @@
+// Package selfupdate replaces the running infer binary with a GitHub release
+// and tells whether a newer release exists. Releases publish raw executables
+// named infer-<GOOS>-<GOARCH> next to a checksums.txt of sha256 sums, the
+// same assets install.sh and the npm wrapper download.
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
+)
+
+const (
+	releasesAPIURL      = "https://api.github.com/repos/inference-gateway/cli/releases"
+	releasesDownloadURL = "https://github.com/inference-gateway/cli/releases/download"
+
+	// CheckInterval is how long a cached latest-release lookup stays fresh
+	CheckInterval = 24 * time.Hour
+)
+
+// Updater looks up and downloads infer releases
+type Updater struct {
+	// apiURL, downloadURL and client are overridable in tests
+	apiURL      string
+	downloadURL string
+	client      *http.Client
+}
+
+// New creates an Updater for the inference-gateway/cli releases
+func New() *Updater {
+	return &Updater{
+		apiURL:      releasesAPIURL,
+		downloadURL: releasesDownloadURL,
+		client:      &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// AssetName returns the release asset of this platform
+func AssetName() string {
+	return fmt.Sprintf("infer-%s-%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// LatestVersion returns the tag of the latest release, e.g. "v0.130.0"
+func (u *Updater) LatestVersion(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.apiURL+"/latest", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create release request: %w", err)
+	}
+	req.Header.Set("User-Agent", "inference-gateway-cli")
+	if t := githubToken(); t != "" {
+		req.Header.Set("Authorization", "Bearer "+t)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query the latest release: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		return "", fmt.Errorf("GitHub API rate limit exceeded (60 req/hour for unauthenticated requests) - set GITHUB_TOKEN (or GH_TOKEN) to raise the limit to 5,000/hour, or try again later")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query the latest release: HTTP %d", resp.StatusCode)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to decode the release response: %w", err)
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("the latest release has no tag name")
+	}
+	return release.TagName, nil
+}
+
+// Download fetches this platform's binary of release tag into dir,
+// verifying it against the release's checksums.txt, and returns its path.
+// The file is created in dir so Replace can rename it over the current
+// binary without crossing filesystems.
+func (u *Updater) Download(ctx context.Context, tag, dir string) (string, error) {
+	asset := AssetName()
+	wantSum, err := u.checksum(ctx, tag, asset)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := u.get(ctx, fmt.Sprintf("%s/%s/%s", u.downloadURL, tag, asset))
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", asset, err)
+	}
+	defer func() { _ = body.Close() }()
+
+	tmp, err := os.CreateTemp(dir, ".infer-update-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create the download file: %w", err)
+	}
+	tmpName := tmp.Name()
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("failed to download %s: %w", asset, err)
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, wantSum) {
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("checksum mismatch for %s: got %s, want %s", asset, got, wantSum)
+	}
+	if err := os.Chmod(tmpName, 0o755); err != nil {
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("failed to mark %s executable: %w", asset, err)
+	}
+	return tmpName, nil
+}
+
+// checksum returns the sha256 of asset from the checksums.txt of tag
+func (u *Updater) checksum(ctx context.Context, tag, asset string) (string, error) {
+	body, err := u.get(ctx, fmt.Sprintf("%s/%s/checksums.txt", u.downloadURL, tag))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the release checksums: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the release checksums: %w", err)
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("release %s has no %s binary", tag, asset)
+}
+
+func (u *Updater) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	req.Header.Set("User-Agent", "inference-gateway-cli")
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("status %d from %s", resp.StatusCode, url)
+	}
+	return resp.Body, nil
+}
+
+// Replace swaps the binary at exe for newPath. A running executable can be
+// renamed but not overwritten on Windows, so the old binary is moved aside
+// to exe.old first; that file is removed by the next update.
+func Replace(exe, newPath string) error {
+	if runtime.GOOS != "windows" {
+		return os.Rename(newPath, exe)
+	}
+
+	old := exe + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return err
+	}
+	if err := os.Rename(newPath, exe); err != nil {
+		_ = os.Rename(old, exe)
+		return err
+	}
+	return nil
+}
+
+// IsNewer reports whether release version latest is newer than current.
+// Versions are compared as vMAJOR.MINOR.PATCH; a pre-release is older than
+// its release. Unparsable versions, such as "dev" builds, are never older.
+func IsNewer(latest, current string) bool {
+	l, lPre, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, cPre, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return cPre != "" && (lPre == "" || lPre > cPre)
+}
+
+func parseVersion(v string) ([3]int, string, bool) {
+	var parts [3]int
+	v, pre, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(v), "v"), "-")
+	fields := strings.Split(v, ".")
+	if len(fields) != 3 {
+		return parts, "", false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return parts, "", false
+		}
+		parts[i] = n
+	}
+	return parts, pre, true
+}
+
+// cachedCheck is the content of the update-check cache file
+type cachedCheck struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+// CachedLatestVersion returns the latest release recorded in cachePath and
+// whether that record is older than CheckInterval. It never touches the
+// network, so it is safe on the chat startup path.
+func CachedLatestVersion(cachePath string) (latest string, stale bool) {
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return "", true
+	}
+	var check cachedCheck
+	if err := json.Unmarshal(data, &check); err != nil {
+		return "", true
+	}
+	return check.Latest, time.Since(check.CheckedAt) > CheckInterval
+}
+
+// RefreshCache looks up the latest release and records it in cachePath
+func (u *Updater) RefreshCache(ctx context.Context, cachePath string) error {
+	latest, err := u.LatestVersion(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cachedCheck{CheckedAt: time.Now(), Latest: latest})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(cachePath, data, 0o644)
+}
+
+// ErrManagedInstall is returned by CheckReplaceable for binaries installed
+// by a package manager, which should update them instead
+var ErrManagedInstall = errors.New("installed by a package manager")
+
+// CheckReplaceable rejects binaries a package manager owns: npm (under
+// node_modules), Nix (under /nix/store) and Homebrew (under a Cellar)
+func CheckReplaceable(exe string) error {
+	path := filepath.ToSlash(exe)
+	switch {
+	case strings.Contains(path, "/node_modules/"):
+		return fmt.Errorf("%w (npm): run npm install -g @inference-gateway/cli@latest", ErrManagedInstall)
+	case strings.HasPrefix(path, "/nix/store/"):
+		return fmt.Errorf("%w (Nix): update the flake input instead", ErrManagedInstall)
+	case strings.Contains(path, "/Cellar/"):
+		return fmt.Errorf("%w (Homebrew): run brew upgrade instead", ErrManagedInstall)
+	}
+	return nil
+}
+
+// githubToken returns GITHUB_TOKEN, falling back to GH_TOKEN (matching the
+// gh CLI), to raise the API rate limit when available
+func githubToken() string {
+	if t := strings.TrimSpace(keyring.Getenv("GITHUB_TOKEN")); t != "" {
+		return t
+	}
+	return strings.TrimSpace(os.Getenv("GH_TOKEN"))
+}
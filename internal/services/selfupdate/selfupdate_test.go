@@ -0,0 +1,128 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	require "github.com/stretchr/testify/require"
+)
+
+func releaseServer(t *testing.T, content, sum string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.3"}`))
+		case "/download/v1.2.3/checksums.txt":
+			_, _ = fmt.Fprintf(w, "%s  %s\n", sum, AssetName())
+		case "/download/v1.2.3/" + AssetName():
+			_, _ = w.Write([]byte(content))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testUpdater(srv *httptest.Server) *Updater {
+	return &Updater{apiURL: srv.URL + "/api", downloadURL: srv.URL + "/download", client: srv.Client()}
+}
+
+func sha256hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func TestLatestVersion(t *testing.T) {
+	u := testUpdater(releaseServer(t, "", ""))
+
+	tag, err := u.LatestVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3", tag)
+}
+
+func TestDownloadVerifiesChecksum(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := testUpdater(releaseServer(t, "#!new-infer", sha256hex("#!new-infer"))).Download(context.Background(), "v1.2.3", dir)
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "#!new-infer", string(data))
+
+	_, err = testUpdater(releaseServer(t, "#!tampered", sha256hex("#!new-infer"))).Download(context.Background(), "v1.2.3", dir)
+	require.ErrorContains(t, err, "checksum mismatch")
+	entries, _ := os.ReadDir(dir)
+	require.Len(t, entries, 1, "a rejected download must be removed")
+
+	_, err = testUpdater(releaseServer(t, "", "")).Download(context.Background(), "v9.9.9", dir)
+	require.Error(t, err)
+}
+
+func TestReplace(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "infer")
+	next := filepath.Join(dir, ".infer-update-1")
+	require.NoError(t, os.WriteFile(exe, []byte("old"), 0o755))
+	require.NoError(t, os.WriteFile(next, []byte("new"), 0o755))
+
+	require.NoError(t, Replace(exe, next))
+	data, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(data))
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.2.3", "v1.2.2", true},
+		{"v1.10.0", "v1.9.9", true},
+		{"v2.0.0", "1.99.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.2.2", "v1.2.3", false},
+		{"v1.2.3", "v1.2.3-rc.1", true},
+		{"v1.2.3-rc.2", "v1.2.3-rc.1", true},
+		{"v1.2.3-rc.1", "v1.2.3", false},
+		{"v1.2.3", "dev", false},
+		{"latest", "v1.0.0", false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, IsNewer(tt.latest, tt.current), "IsNewer(%q, %q)", tt.latest, tt.current)
+	}
+}
+
+func TestCachedLatestVersion(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "update-check.json")
+
+	latest, stale := CachedLatestVersion(cachePath)
+	require.Empty(t, latest)
+	require.True(t, stale)
+
+	require.NoError(t, testUpdater(releaseServer(t, "", "")).RefreshCache(context.Background(), cachePath))
+	latest, stale = CachedLatestVersion(cachePath)
+	require.Equal(t, "v1.2.3", latest)
+	require.False(t, stale)
+}
+
+func TestCheckReplaceable(t *testing.T) {
+	require.NoError(t, CheckReplaceable("/usr/local/bin/infer"))
+	for _, exe := range []string{
+		"/usr/lib/node_modules/@inference-gateway/cli/bin/infer-linux-amd64",
+		"/nix/store/abc-infer/bin/infer",
+		"/opt/homebrew/Cellar/infer/1.0.0/bin/infer",
+	} {
+		err := CheckReplaceable(exe)
+		require.True(t, errors.Is(err, ErrManagedInstall), "CheckReplaceable(%q) = %v", exe, err)
+	}
+}
@@ -31,6 +31,7 @@ type SessionRolloverManager struct {
 	tokenizer  *TokenizerService
 	groupStore storage.SessionGroupStorage
 	indexMutex sync.Mutex
+	preCompact func(ctx context.Context, payload domain.HookPayload)
 }
 
 // NewSessionRolloverManager constructs a manager. The optimizer is required for
@@ -59,6 +60,12 @@ func NewSessionRolloverManager(
 	}
 }
 
+// SetPreCompactHook registers fn to run before a rollover summarizes the
+// conversation, so the pre_compact lifecycle hooks see it while it is whole.
+func (m *SessionRolloverManager) SetPreCompactHook(fn func(ctx context.Context, payload domain.HookPayload)) {
+	m.preCompact = fn
+}
+
 // ResolveSessionID maps a raw --session-id value to the conversation ID that
 // should actually be loaded.
 //
@@ -253,6 +260,16 @@ func (m *SessionRolloverManager) PerformRollover(ctx context.Context, model, gro
 		"message_count", len(messages),
 		"model", model)
 
+	if m.preCompact != nil {
+		m.preCompact(ctx, domain.HookPayload{
+			Hook:      domain.HookPreCompact,
+			SessionID: originalID,
+			Model:     model,
+			Messages:  len(messages),
+			Reason:    "auto",
+		})
+	}
+
 	optimized := m.optimizer.OptimizeMessages(messages, model, true)
 	if len(optimized) >= len(messages) {
 		// Optimizer decided no compaction was useful (e.g. very short
@@ -269,11 +286,13 @@ func (m *SessionRolloverManager) PerformRollover(ctx context.Context, model, gro
 
 	newID := m.repo.GetCurrentConversationID()
 
+	pinned := domain.PinnedKeys(entries)
 	for _, msg := range optimized {
 		entry := domain.ConversationEntry{
 			Message: msg,
 			Model:   model,
 			Time:    time.Now(),
+			Pinned:  pinned[domain.PinKey(msg)],
 		}
 		if err := m.repo.AddMessage(entry); err != nil {
 			logger.Error("failed to add summarized message to new session", "error", err)
@@ -0,0 +1,220 @@
+// Package sessiongroups manages session groups: stable keys such as
+// "channel-telegram-12345" or "standup" that point at the conversation a
+// group resumes (its current session) plus the conversations it rolled over
+// from (its history). Passing a group key as --session-id resumes the
+// group's current conversation.
+package sessiongroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/google/uuid"
+
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+// ErrNotFound is returned when a group key does not exist
+var ErrNotFound = errors.New("session group not found")
+
+// Group is a session group with its key
+type Group struct {
+	Key string
+	storage.SessionGroupEntry
+}
+
+// ConversationIDs returns the conversations of the group, current first, then
+// history newest first
+func (g Group) ConversationIDs() []string {
+	ids := make([]string, 0, len(g.History)+1)
+	if g.CurrentSessionID != "" {
+		ids = append(ids, g.CurrentSessionID)
+	}
+	for i := len(g.History) - 1; i >= 0; i-- {
+		if id := g.History[i]; id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ValidateKey checks that key can name a group. A UUID is refused, since
+// --session-id treats a UUID as a conversation ID rather than a group key.
+func ValidateKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return errors.New("session group name cannot be empty")
+	}
+	if strings.ContainsAny(key, " \t\n/\\") {
+		return fmt.Errorf("invalid session group name %q: use letters, digits, '-' or '_'", key)
+	}
+	if _, err := uuid.Parse(key); err == nil {
+		return fmt.Errorf("invalid session group name %q: a UUID is read as a conversation ID", key)
+	}
+	return nil
+}
+
+// List returns all groups, most recently updated first
+func List(ctx context.Context, store storage.SessionGroupStorage) ([]Group, error) {
+	entries, err := store.ListSessionGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session groups: %w", err)
+	}
+	groups := make([]Group, 0, len(entries))
+	for key, entry := range entries {
+		groups = append(groups, Group{Key: key, SessionGroupEntry: entry})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if !groups[i].UpdatedAt.Equal(groups[j].UpdatedAt) {
+			return groups[i].UpdatedAt.After(groups[j].UpdatedAt)
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups, nil
+}
+
+// Get returns the group for key, or ErrNotFound
+func Get(ctx context.Context, store storage.SessionGroupStorage, key string) (Group, error) {
+	entry, ok, err := store.GetSessionGroup(ctx, key)
+	if err != nil {
+		return Group{}, fmt.Errorf("failed to load session group %s: %w", key, err)
+	}
+	if !ok {
+		return Group{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return Group{Key: key, SessionGroupEntry: entry}, nil
+}
+
+// Create adds a group whose current session is sessionID, or a new session
+// ID when it is empty
+func Create(ctx context.Context, store storage.SessionGroupStorage, key, sessionID string) (Group, error) {
+	if err := ValidateKey(key); err != nil {
+		return Group{}, err
+	}
+	if err := ensureAbsent(ctx, store, key); err != nil {
+		return Group{}, err
+	}
+	if sessionID == "" {
+		sessionID = uuid.NewString()
+	}
+	g := Group{Key: key, SessionGroupEntry: storage.SessionGroupEntry{
+		CurrentSessionID: sessionID,
+		UpdatedAt:        time.Now(),
+	}}
+	if err := store.PutSessionGroup(ctx, key, g.SessionGroupEntry); err != nil {
+		return Group{}, fmt.Errorf("failed to save session group %s: %w", key, err)
+	}
+	return g, nil
+}
+
+// Rename moves the group at oldKey to newKey, keeping its conversations
+func Rename(ctx context.Context, store storage.SessionGroupStorage, oldKey, newKey string) error {
+	if err := ValidateKey(newKey); err != nil {
+		return err
+	}
+	g, err := Get(ctx, store, oldKey)
+	if err != nil {
+		return err
+	}
+	if err := ensureAbsent(ctx, store, newKey); err != nil {
+		return err
+	}
+	g.UpdatedAt = time.Now()
+	if err := store.PutSessionGroup(ctx, newKey, g.SessionGroupEntry); err != nil {
+		return fmt.Errorf("failed to save session group %s: %w", newKey, err)
+	}
+	if err := store.DeleteSessionGroup(ctx, oldKey); err != nil {
+		return fmt.Errorf("failed to delete session group %s: %w", oldKey, err)
+	}
+	return nil
+}
+
+// Delete removes the group at key. Its conversations are kept.
+func Delete(ctx context.Context, store storage.SessionGroupStorage, key string) error {
+	if _, err := Get(ctx, store, key); err != nil {
+		return err
+	}
+	if err := store.DeleteSessionGroup(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete session group %s: %w", key, err)
+	}
+	return nil
+}
+
+// Move takes conversationID out of every group that holds it and adds it to
+// the group at targetKey: as its current session when the group has none,
+// otherwise to its history. A group that loses its current session falls
+// back to the newest conversation of its history, or a new session. Move
+// returns the keys of the groups the conversation was taken from.
+func Move(ctx context.Context, store storage.SessionGroupStorage, conversationID, targetKey string) ([]string, error) {
+	target, err := Get(ctx, store, targetKey)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(target.ConversationIDs(), conversationID) {
+		return nil, fmt.Errorf("conversation %s is already in session group %s", conversationID, targetKey)
+	}
+
+	groups, err := List(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var from []string
+	for _, g := range groups {
+		if !slices.Contains(g.ConversationIDs(), conversationID) {
+			continue
+		}
+		g.SessionGroupEntry = without(g.SessionGroupEntry, conversationID)
+		g.UpdatedAt = now
+		if err := store.PutSessionGroup(ctx, g.Key, g.SessionGroupEntry); err != nil {
+			return from, fmt.Errorf("failed to save session group %s: %w", g.Key, err)
+		}
+		from = append(from, g.Key)
+	}
+
+	if target.CurrentSessionID == "" {
+		target.CurrentSessionID = conversationID
+	} else {
+		target.History = append(target.History, conversationID)
+	}
+	target.UpdatedAt = now
+	if err := store.PutSessionGroup(ctx, targetKey, target.SessionGroupEntry); err != nil {
+		return from, fmt.Errorf("failed to save session group %s: %w", targetKey, err)
+	}
+	return from, nil
+}
+
+// without returns entry with id removed from its current session and history
+func without(entry storage.SessionGroupEntry, id string) storage.SessionGroupEntry {
+	history := make([]string, 0, len(entry.History))
+	for _, h := range entry.History {
+		if h != id {
+			history = append(history, h)
+		}
+	}
+	entry.History = history
+	if entry.CurrentSessionID == id {
+		if n := len(history); n > 0 {
+			entry.CurrentSessionID = history[n-1]
+			entry.History = history[:n-1]
+		} else {
+			entry.CurrentSessionID = uuid.NewString()
+		}
+	}
+	return entry
+}
+
+func ensureAbsent(ctx context.Context, store storage.SessionGroupStorage, key string) error {
+	_, ok, err := store.GetSessionGroup(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to load session group %s: %w", key, err)
+	}
+	if ok {
+		return fmt.Errorf("session group %s already exists", key)
+	}
+	return nil
+}
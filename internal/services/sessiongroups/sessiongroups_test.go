@@ -0,0 +1,134 @@
+package sessiongroups
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+func newTestStore(t *testing.T, groups map[string]storage.SessionGroupEntry) storage.SessionGroupStorage {
+	t.Helper()
+	store := storage.NewMemorySessionGroupStorage()
+	for key, entry := range groups {
+		if err := store.PutSessionGroup(context.Background(), key, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func TestConversationIDs(t *testing.T) {
+	g := Group{SessionGroupEntry: storage.SessionGroupEntry{
+		CurrentSessionID: "c",
+		History:          []string{"a", "b", "c"},
+	}}
+	if got := g.ConversationIDs(); !slices.Equal(got, []string{"c", "b", "a"}) {
+		t.Errorf("ConversationIDs() = %v", got)
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"", "two words", "a/b", "0b7c1e4e-3f0a-4d7e-9a59-2b1f5c8d9e10"} {
+		if ValidateKey(key) == nil {
+			t.Errorf("ValidateKey(%q) = nil, want an error", key)
+		}
+	}
+	if err := ValidateKey("channel-telegram-42"); err != nil {
+		t.Errorf("ValidateKey() = %v", err)
+	}
+}
+
+func TestListSortsByUpdatedAt(t *testing.T) {
+	now := time.Now()
+	store := newTestStore(t, map[string]storage.SessionGroupEntry{
+		"old":   {CurrentSessionID: "1", UpdatedAt: now.Add(-time.Hour)},
+		"new":   {CurrentSessionID: "2", UpdatedAt: now},
+		"older": {CurrentSessionID: "3", UpdatedAt: now.Add(-2 * time.Hour)},
+	})
+	groups, err := List(context.Background(), store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, g := range groups {
+		keys = append(keys, g.Key)
+	}
+	if !slices.Equal(keys, []string{"new", "old", "older"}) {
+		t.Errorf("List() order = %v", keys)
+	}
+}
+
+func TestCreateRenameDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, nil)
+
+	g, err := Create(ctx, store, "research", "")
+	if err != nil || g.CurrentSessionID == "" {
+		t.Fatalf("Create() = %+v, %v", g, err)
+	}
+	if _, err := Create(ctx, store, "research", ""); err == nil {
+		t.Error("expected creating an existing group to fail")
+	}
+
+	if err := Rename(ctx, store, "research", "papers"); err != nil {
+		t.Fatalf("Rename() = %v", err)
+	}
+	if _, err := Get(ctx, store, "research"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("old key still present: %v", err)
+	}
+	renamed, err := Get(ctx, store, "papers")
+	if err != nil || renamed.CurrentSessionID != g.CurrentSessionID {
+		t.Errorf("Get(papers) = %+v, %v", renamed, err)
+	}
+
+	if err := Delete(ctx, store, "papers"); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if err := Delete(ctx, store, "papers"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() of a missing group = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, map[string]storage.SessionGroupEntry{
+		"source": {CurrentSessionID: "conv-2", History: []string{"conv-1"}},
+		"target": {CurrentSessionID: "conv-9"},
+		"empty":  {},
+	})
+
+	from, err := Move(ctx, store, "conv-2", "target")
+	if err != nil || !slices.Equal(from, []string{"source"}) {
+		t.Fatalf("Move() = %v, %v", from, err)
+	}
+	source, _ := Get(ctx, store, "source")
+	if source.CurrentSessionID != "conv-1" || len(source.History) != 0 {
+		t.Errorf("source after move = %+v, want conv-1 promoted to current", source)
+	}
+	target, _ := Get(ctx, store, "target")
+	if target.CurrentSessionID != "conv-9" || !slices.Equal(target.History, []string{"conv-2"}) {
+		t.Errorf("target after move = %+v", target)
+	}
+
+	if _, err := Move(ctx, store, "conv-2", "target"); err == nil {
+		t.Error("expected moving into the same group to fail")
+	}
+	if _, err := Move(ctx, store, "conv-1", "empty"); err != nil {
+		t.Fatal(err)
+	}
+	empty, _ := Get(ctx, store, "empty")
+	if empty.CurrentSessionID != "conv-1" {
+		t.Errorf("a group without a current session should adopt the conversation, got %+v", empty)
+	}
+	source, _ = Get(ctx, store, "source")
+	if source.CurrentSessionID == "" || source.CurrentSessionID == "conv-1" {
+		t.Errorf("an emptied group should get a new session, got %+v", source)
+	}
+	if _, err := Move(ctx, store, "conv-1", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Move() to a missing group = %v, want ErrNotFound", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
+	keyring "github.com/inference-gateway/cli/internal/infra/keyring"
 )
 
 const (
@@ -144,7 +145,7 @@ func NewInstaller() *Installer {
 // GITHUB_TOKEN and falling back to GH_TOKEN (matching the gh CLI). It returns
 // "" when neither is set.
 func githubToken() string {
-	if t := strings.TrimSpace(os.Getenv("GITHUB_TOKEN")); t != "" {
+	if t := strings.TrimSpace(keyring.Getenv("GITHUB_TOKEN")); t != "" {
 		return t
 	}
 	return strings.TrimSpace(os.Getenv("GH_TOKEN"))
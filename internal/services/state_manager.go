@@ -457,6 +457,14 @@ func (sm *StateManager) SetPlanApprovalSelectedIndex(index int) {
 	sm.state.SetPlanApprovalSelectedIndex(index)
 }
 
+// SetPlanApprovalContent replaces the pending plan's content
+func (sm *StateManager) SetPlanApprovalContent(planContent string) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.state.SetPlanApprovalContent(planContent)
+}
+
 // ClearPlanApprovalUIState clears the plan approval UI state
 func (sm *StateManager) ClearPlanApprovalUIState() {
 	sm.mutex.Lock()
@@ -507,6 +515,30 @@ func (sm *StateManager) GetTodos() []domain.TodoItem {
 	return sm.state.GetTodos()
 }
 
+// SetPlanSteps replaces the approved plan's checklist
+func (sm *StateManager) SetPlanSteps(steps []domain.PlanStep) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.state.SetPlanSteps(steps)
+}
+
+// GetPlanSteps returns the approved plan's checklist
+func (sm *StateManager) GetPlanSteps() []domain.PlanStep {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	return sm.state.GetPlanSteps()
+}
+
+// UpdatePlanStep sets the status of one step of the approved plan
+func (sm *StateManager) UpdatePlanStep(number int, status string) (domain.PlanStep, error) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	return sm.state.UpdatePlanStep(number, status)
+}
+
 // AddQueuedMessage adds a message to the input queue
 func (sm *StateManager) AddQueuedMessage(message sdk.Message, requestID string) {
 	sm.mutex.Lock()
@@ -0,0 +1,427 @@
+// Package structuredoutput constrains an agent's final answer to a JSON
+// Schema: the schema is sent to the gateway as a json_schema response format,
+// and the answer is validated locally so a model that ignores the constraint
+// can be asked to repair it.
+//
+// Validation covers the JSON Schema keywords structured-output schemas use in
+// practice: type, enum, const, properties, required, additionalProperties,
+// items, min/max length, items and properties counts, minimum/maximum,
+// pattern, allOf/anyOf/oneOf/not and local $ref into $defs or definitions.
+// Unknown keywords are ignored.
+package structuredoutput
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// Schema is a parsed JSON Schema document
+type Schema struct {
+	name string
+	root map[string]any
+}
+
+// Load reads a JSON Schema from path. The schema name sent to the gateway is
+// derived from the file name.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return Parse(name, data)
+}
+
+// Parse parses a JSON Schema document named name
+func Parse(name string, data []byte) (*Schema, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return &Schema{name: schemaName(name), root: root}, nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// schemaName makes name valid as a response format name: a-z, A-Z, 0-9,
+// underscores and dashes, at most 64 characters
+func schemaName(name string) string {
+	name = invalidNameChars.ReplaceAllString(name, "_")
+	if name == "" {
+		name = "result"
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// JSON returns the schema document, for embedding in a prompt
+func (s *Schema) JSON() string {
+	data, _ := json.MarshalIndent(s.root, "", "  ")
+	return string(data)
+}
+
+// ResponseFormat returns the json_schema response format requesting output
+// that matches the schema
+func (s *Schema) ResponseFormat() (*sdk.CreateChatCompletionRequest_ResponseFormat, error) {
+	format := sdk.ResponseFormatJSONSchema{Type: sdk.JSONSchema}
+	format.JSONSchema.Name = s.name
+	schema := sdk.ResponseFormatJSONSchemaSchema(s.root)
+	format.JSONSchema.Schema = &schema
+
+	var rf sdk.CreateChatCompletionRequest_ResponseFormat
+	if err := rf.FromResponseFormatJSONSchema(format); err != nil {
+		return nil, err
+	}
+	return &rf, nil
+}
+
+// Extract parses the JSON value in an answer, tolerating a surrounding
+// markdown code fence
+func Extract(content string) (any, error) {
+	text := strings.TrimSpace(content)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```")
+		if nl := strings.IndexByte(text, '\n'); nl >= 0 {
+			text = text[nl+1:]
+		}
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "```"))
+	}
+	if text == "" {
+		return nil, fmt.Errorf("the answer is empty")
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil, fmt.Errorf("the answer is not valid JSON: %w", err)
+	}
+	return value, nil
+}
+
+// Check extracts the JSON value of an answer and validates it, returning the
+// value and every problem found
+func (s *Schema) Check(content string) (any, []string) {
+	value, err := Extract(content)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	return value, s.Validate(value)
+}
+
+// Validate returns the places where value does not match the schema, each as
+// "<JSON pointer>: <problem>"; none means it matches
+func (s *Schema) Validate(value any) []string {
+	v := &validator{root: s.root}
+	v.validate(s.root, value, "")
+	return v.problems
+}
+
+type validator struct {
+	root     map[string]any
+	problems []string
+	depth    int
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	if path == "" {
+		path = "/"
+	}
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// matches reports whether value matches schema without recording problems
+func (v *validator) matches(schema any, value any, path string) bool {
+	sub := &validator{root: v.root, depth: v.depth}
+	sub.validate(schema, value, path)
+	return len(sub.problems) == 0
+}
+
+func (v *validator) validate(schemaValue any, value any, path string) {
+	switch schema := schemaValue.(type) {
+	case bool:
+		if !schema {
+			v.fail(path, "no value is allowed here")
+		}
+		return
+	case map[string]any:
+		v.validateObjectSchema(schema, value, path)
+	}
+}
+
+func (v *validator) validateObjectSchema(schema map[string]any, value any, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		v.validateRef(ref, value, path)
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "expected %s, got %s", describeType(t), typeOf(value))
+		return
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return equal(e, value) }) {
+		v.fail(path, "must be one of %s", compactJSON(enum))
+	}
+	if c, ok := schema["const"]; ok && !equal(c, value) {
+		v.fail(path, "must be %s", compactJSON(c))
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		v.validateObject(schema, val, path)
+	case []any:
+		v.validateArray(schema, val, path)
+	case string:
+		v.validateString(schema, val, path)
+	case float64:
+		v.validateNumber(schema, val, path)
+	}
+
+	v.validateCombinators(schema, value, path)
+}
+
+func (v *validator) validateRef(ref string, value any, path string) {
+	if v.depth > 32 {
+		v.fail(path, "$ref %q nests too deeply", ref)
+		return
+	}
+	target, ok := resolveRef(v.root, ref)
+	if !ok {
+		v.fail(path, "unresolvable $ref %q", ref)
+		return
+	}
+	v.depth++
+	v.validate(target, value, path)
+	v.depth--
+}
+
+// resolveRef resolves a local reference like "#/$defs/item"
+func resolveRef(root map[string]any, ref string) (any, bool) {
+	if ref == "#" {
+		return root, true
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+	var node any = root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func (v *validator) validateObject(schema map[string]any, obj map[string]any, path string) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := obj[name]; !present {
+					v.fail(path, "missing required property %q", name)
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		childPath := path + "/" + k
+		if propSchema, ok := properties[k]; ok {
+			v.validate(propSchema, obj[k], childPath)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(path, "unexpected property %q", k)
+			}
+		case map[string]any:
+			v.validate(additional, obj[k], childPath)
+		}
+	}
+
+	if n, ok := number(schema["minProperties"]); ok && float64(len(obj)) < n {
+		v.fail(path, "must have at least %v properties", n)
+	}
+	if n, ok := number(schema["maxProperties"]); ok && float64(len(obj)) > n {
+		v.fail(path, "must have at most %v properties", n)
+	}
+}
+
+func (v *validator) validateArray(schema map[string]any, arr []any, path string) {
+	if items, ok := schema["items"]; ok {
+		for i, item := range arr {
+			v.validate(items, item, fmt.Sprintf("%s/%d", path, i))
+		}
+	}
+	if n, ok := number(schema["minItems"]); ok && float64(len(arr)) < n {
+		v.fail(path, "must have at least %v items", n)
+	}
+	if n, ok := number(schema["maxItems"]); ok && float64(len(arr)) > n {
+		v.fail(path, "must have at most %v items", n)
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if equal(arr[i], arr[j]) {
+					v.fail(path, "items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+}
+
+func (v *validator) validateString(schema map[string]any, s string, path string) {
+	length := float64(len([]rune(s)))
+	if n, ok := number(schema["minLength"]); ok && length < n {
+		v.fail(path, "must be at least %v characters", n)
+	}
+	if n, ok := number(schema["maxLength"]); ok && length > n {
+		v.fail(path, "must be at most %v characters", n)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(s) {
+			v.fail(path, "must match pattern %q", pattern)
+		}
+	}
+}
+
+func (v *validator) validateNumber(schema map[string]any, n float64, path string) {
+	if lo, ok := number(schema["minimum"]); ok && n < lo {
+		v.fail(path, "must be >= %v", lo)
+	}
+	if hi, ok := number(schema["maximum"]); ok && n > hi {
+		v.fail(path, "must be <= %v", hi)
+	}
+	if lo, ok := number(schema["exclusiveMinimum"]); ok && n <= lo {
+		v.fail(path, "must be > %v", lo)
+	}
+	if hi, ok := number(schema["exclusiveMaximum"]); ok && n >= hi {
+		v.fail(path, "must be < %v", hi)
+	}
+}
+
+func (v *validator) validateCombinators(schema map[string]any, value any, path string) {
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path)
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && !slices.ContainsFunc(anyOf, func(sub any) bool { return v.matches(sub, value, path) }) {
+		v.fail(path, "does not match any of the anyOf schemas")
+	}
+	if one, ok := schema["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range one {
+			if v.matches(sub, value, path) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.fail(path, "must match exactly one of the oneOf schemas, matched %d", matched)
+		}
+	}
+	if not, ok := schema["not"]; ok && v.matches(not, value, path) {
+		v.fail(path, "must not match the not schema")
+	}
+}
+
+func matchesType(t any, value any) bool {
+	switch t := t.(type) {
+	case string:
+		return isType(t, value)
+	case []any:
+		return slices.ContainsFunc(t, func(name any) bool {
+			s, ok := name.(string)
+			return ok && isType(s, value)
+		})
+	}
+	return true
+}
+
+func isType(name string, value any) bool {
+	switch name {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+func typeOf(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func describeType(t any) string {
+	if names, ok := t.([]any); ok {
+		parts := make([]string, 0, len(names))
+		for _, n := range names {
+			parts = append(parts, fmt.Sprint(n))
+		}
+		return strings.Join(parts, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func number(v any) (float64, bool) {
+	n, ok := v.(float64)
+	return n, ok
+}
+
+func equal(a, b any) bool {
+	return compactJSON(a) == compactJSON(b)
+}
+
+func compactJSON(v any) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,171 @@
+package structuredoutput
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const findingsSchema = `{
+  "type": "object",
+  "properties": {
+    "summary": {"type": "string", "minLength": 1},
+    "findings": {
+      "type": "array",
+      "items": {"$ref": "#/$defs/finding"}
+    }
+  },
+  "required": ["summary", "findings"],
+  "additionalProperties": false,
+  "$defs": {
+    "finding": {
+      "type": "object",
+      "properties": {
+        "severity": {"enum": ["critical", "high", "medium", "low"]},
+        "line": {"type": "integer", "minimum": 1}
+      },
+      "required": ["severity"]
+    }
+  }
+}`
+
+func mustParse(t *testing.T, schema string) *Schema {
+	t.Helper()
+	s, err := Parse("findings", []byte(schema))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return s
+}
+
+func TestCheck(t *testing.T) {
+	s := mustParse(t, findingsSchema)
+
+	tests := []struct {
+		name    string
+		answer  string
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			answer: `{"summary": "two issues", "findings": [{"severity": "high", "line": 3}, {"severity": "low"}]}`,
+		},
+		{
+			name:   "fenced",
+			answer: "```json\n{\"summary\": \"ok\", \"findings\": []}\n```",
+		},
+		{
+			name:    "not JSON",
+			answer:  "Here are the findings: none",
+			wantErr: []string{"not valid JSON"},
+		},
+		{
+			name:    "empty",
+			answer:  "  ",
+			wantErr: []string{"empty"},
+		},
+		{
+			name:    "missing and extra properties",
+			answer:  `{"findings": [], "notes": "x"}`,
+			wantErr: []string{`/: missing required property "summary"`, `/: unexpected property "notes"`},
+		},
+		{
+			name:   "nested problems through $ref",
+			answer: `{"summary": "", "findings": [{"severity": "urgent", "line": 2.5}]}`,
+			wantErr: []string{
+				"/findings/0/line: expected integer, got number",
+				`/findings/0/severity: must be one of ["critical","high","medium","low"]`,
+				"/summary: must be at least 1 characters",
+			},
+		},
+		{
+			name:    "wrong root type",
+			answer:  `["a"]`,
+			wantErr: []string{"/: expected object, got array"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, problems := s.Check(tt.answer)
+			if len(tt.wantErr) == 0 {
+				if len(problems) > 0 {
+					t.Fatalf("unexpected problems: %v", problems)
+				}
+				return
+			}
+			if len(problems) != len(tt.wantErr) {
+				t.Fatalf("got problems %v, want %v", problems, tt.wantErr)
+			}
+			for i, want := range tt.wantErr {
+				if !strings.Contains(problems[i], want) {
+					t.Errorf("problem %d = %q, want it to contain %q", i, problems[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCombinators(t *testing.T) {
+	s := mustParse(t, `{
+		"oneOf": [
+			{"type": "string", "pattern": "^v[0-9]+$"},
+			{"type": "integer", "exclusiveMinimum": 0}
+		]
+	}`)
+
+	for _, ok := range []any{"v2", 7.0} {
+		if problems := s.Validate(ok); len(problems) > 0 {
+			t.Errorf("Validate(%v) = %v, want no problems", ok, problems)
+		}
+	}
+	for _, bad := range []any{"2", 0.0, true} {
+		if problems := s.Validate(bad); len(problems) == 0 {
+			t.Errorf("Validate(%v) found no problems", bad)
+		}
+	}
+}
+
+func TestLoadAndResponseFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review result.json")
+	if err := os.WriteFile(path, []byte(findingsSchema), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	rf, err := s.ResponseFormat()
+	if err != nil {
+		t.Fatalf("ResponseFormat: %v", err)
+	}
+	data, err := json.Marshal(rf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Type       string `json:"type"`
+		JSONSchema struct {
+			Name   string         `json:"name"`
+			Schema map[string]any `json:"schema"`
+		} `json:"json_schema"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "json_schema" || got.JSONSchema.Name != "review_result" {
+		t.Errorf("response format = %s", data)
+	}
+	if got.JSONSchema.Schema["type"] != "object" {
+		t.Errorf("schema not embedded: %s", data)
+	}
+
+	if _, err := Parse("bad", []byte("{")); err == nil {
+		t.Error("expected error for an invalid schema document")
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"unicode/utf8"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
+	tokenizers "github.com/inference-gateway/cli/internal/services/tokenizers"
 	sdk "github.com/inference-gateway/sdk"
 )
 
 // TokenizerService provides token counting functionality for LLM messages.
 // This is a polyfill for providers (like Ollama Cloud) that don't return
-// token usage metrics in their API responses.
+// token usage metrics in their API responses. With counters it counts text
+// with the current model's real tokenizer, falling back to the heuristic
+// while that tokenizer is unavailable.
 type TokenizerService struct {
 	// charsPerToken is the average characters per token estimate
 	// OpenAI suggests ~4 characters per token for English text
@@ -23,6 +26,9 @@ type TokenizerService struct {
 
 	// toolCallOverhead is the estimated additional tokens for tool call formatting
 	toolCallOverhead int
+
+	counters *tokenizers.Registry
+	model    func() string
 }
 
 // TokenizerConfig holds configuration for the tokenizer service
@@ -35,6 +41,12 @@ type TokenizerConfig struct {
 
 	// ToolCallOverhead is extra tokens per tool call (default: 10)
 	ToolCallOverhead int
+
+	// Counters count text with real tokenizers; nil keeps the heuristic
+	Counters *tokenizers.Registry
+
+	// Model returns the model whose tokenizer counts (usually the current one)
+	Model func() string
 }
 
 // DefaultTokenizerConfig returns the default tokenizer configuration
@@ -62,17 +74,26 @@ func NewTokenizerService(config TokenizerConfig) *TokenizerService {
 		charsPerToken:    config.CharsPerToken,
 		messageOverhead:  config.MessageOverhead,
 		toolCallOverhead: config.ToolCallOverhead,
+		counters:         config.Counters,
+		model:            config.Model,
 	}
 }
 
 // EstimateTokenCount estimates the number of tokens in a text string.
-// This uses a character-based heuristic that provides a reasonable
+// It counts with the model's tokenizer when one is configured and loaded,
+// and otherwise uses a character-based heuristic that provides a reasonable
 // approximation for most English text.
 func (t *TokenizerService) EstimateTokenCount(text string) int {
 	if text == "" {
 		return 0
 	}
 
+	if counter := t.counter(); counter != nil {
+		if tokens, ok := counter.Count(text); ok {
+			return tokens
+		}
+	}
+
 	charCount := utf8.RuneCountInString(text)
 
 	tokens := float64(charCount) / t.charsPerToken
@@ -80,6 +101,18 @@ func (t *TokenizerService) EstimateTokenCount(text string) int {
 	return int(tokens + 0.5)
 }
 
+// counter returns the current model's tokenizer, or nil for the heuristic
+func (t *TokenizerService) counter() tokenizers.Counter {
+	if t.counters == nil || t.model == nil {
+		return nil
+	}
+	model := t.model()
+	if model == "" {
+		return nil
+	}
+	return t.counters.CounterFor(model)
+}
+
 // EstimateMessageTokens estimates the total tokens for a single message
 func (t *TokenizerService) EstimateMessageTokens(msg sdk.Message) int {
 	tokens := t.messageOverhead
@@ -243,8 +276,15 @@ func (t *TokenizerService) IsLikelyCodeContent(text string) bool {
 	return codeScore >= 3
 }
 
-// AdjustedEstimate provides a more accurate estimate for code vs prose
+// AdjustedEstimate provides a more accurate estimate for code vs prose. A
+// real tokenizer's count needs no adjustment.
 func (t *TokenizerService) AdjustedEstimate(text string) int {
+	if counter := t.counter(); counter != nil {
+		if tokens, ok := counter.Count(text); ok {
+			return tokens
+		}
+	}
+
 	baseEstimate := t.EstimateTokenCount(text)
 
 	if t.IsLikelyCodeContent(text) {
@@ -1,12 +1,18 @@
 package services
 
 import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	sdk "github.com/inference-gateway/sdk"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
+	tokenizers "github.com/inference-gateway/cli/internal/services/tokenizers"
 	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
 )
 
@@ -608,3 +614,42 @@ func TestEffectiveContextTokens(t *testing.T) {
 		t.Errorf("larger lastInput should win: got %d, want %d", got, est+5000)
 	}
 }
+
+func TestEstimateTokenCount_WithCounters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := range 256 {
+			_, _ = fmt.Fprintf(w, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), i)
+		}
+		_, _ = fmt.Fprintf(w, "%s 256\n", base64.StdEncoding.EncodeToString([]byte("hello")))
+		_, _ = fmt.Fprintf(w, "%s 257\n", base64.StdEncoding.EncodeToString([]byte(" hello")))
+	}))
+	defer server.Close()
+
+	model := "deepseek/deepseek-chat"
+	config := DefaultTokenizerConfig()
+	config.Counters = tokenizers.NewRegistry(tokenizers.Options{
+		EncodingURL: server.URL,
+		Models:      map[string]string{"llama": tokenizers.EncodingHeuristic},
+	})
+	config.Model = func() string { return model }
+	tokenizer := NewTokenizerService(config)
+
+	text := "hello hello hello"
+	heuristic := NewTokenizerService(DefaultTokenizerConfig()).EstimateTokenCount(text)
+	if got := tokenizer.EstimateTokenCount(text); got != heuristic {
+		t.Errorf("before the encoding loads the heuristic should count: got %d, want %d", got, heuristic)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for tokenizer.EstimateTokenCount(text) != 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("EstimateTokenCount(%q) = %d, want 3 from the encoding", text, tokenizer.EstimateTokenCount(text))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	model = "ollama/llama3"
+	if got := tokenizer.EstimateTokenCount(text); got != heuristic {
+		t.Errorf("a model mapped to the heuristic: got %d, want %d", got, heuristic)
+	}
+}
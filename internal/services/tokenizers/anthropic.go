@@ -0,0 +1,208 @@
+package tokenizers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// anthropicVersion is the API version sent with counting requests
+const anthropicVersion = "2023-06-01"
+
+// anthropicWorkers is how many counting requests run at once, so a long
+// conversation loaded at once does not hit the endpoint's rate limit
+const anthropicWorkers = 4
+
+// anthropicQueueSize bounds the texts waiting to be counted. A text that
+// finds the queue full is not queued and is offered again when next counted.
+const anthropicQueueSize = 256
+
+// errRateLimited is a refused counting request worth asking again later
+var errRateLimited = errors.New("token counting rate limited")
+
+// anthropicCounter counts with Anthropic's token counting endpoint. Each
+// text is counted once, in the background by a fixed pool of workers; until
+// its count is back the fallback's count stands in for it.
+type anthropicCounter struct {
+	model    string
+	opts     Options
+	cache    *countCache
+	fallback Counter
+	queue    chan countJob
+	workers  sync.Once
+
+	mu       sync.Mutex
+	inFlight map[cacheKey]bool
+	overhead int
+	// failed stops counting requests after the endpoint refused one, e.g.
+	// because the gateway does not proxy it
+	failed bool
+}
+
+func newAnthropicCounter(model string, opts Options, cache *countCache, fallback Counter) *anthropicCounter {
+	return &anthropicCounter{
+		model:    model,
+		opts:     opts,
+		cache:    cache,
+		fallback: fallback,
+		queue:    make(chan countJob, anthropicQueueSize),
+		inFlight: make(map[cacheKey]bool),
+		overhead: -1,
+	}
+}
+
+// Count returns the endpoint's count for text when it is known, and
+// otherwise the fallback's while the endpoint is asked
+func (c *anthropicCounter) Count(text string) (int, bool) {
+	if text == "" {
+		return 0, true
+	}
+	key := newCacheKey(EncodingAnthropic+":"+c.model, text)
+	if tokens, ok := c.cache.get(key); ok {
+		return tokens, true
+	}
+
+	c.mu.Lock()
+	if !c.failed && !c.inFlight[key] {
+		c.workers.Do(c.startWorkers)
+		select {
+		case c.queue <- countJob{key: key, text: text}:
+			c.inFlight[key] = true
+		default:
+		}
+	}
+	c.mu.Unlock()
+
+	return c.fallback.Count(text)
+}
+
+// countJob is a text waiting for its count
+type countJob struct {
+	key  cacheKey
+	text string
+}
+
+// startWorkers starts the workers that take texts off the queue
+func (c *anthropicCounter) startWorkers() {
+	for range anthropicWorkers {
+		go func() {
+			for job := range c.queue {
+				c.fetch(job.key, job.text)
+			}
+		}()
+	}
+}
+
+func (c *anthropicCounter) fetch(key cacheKey, text string) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.inFlight, key)
+		c.mu.Unlock()
+	}()
+
+	c.mu.Lock()
+	failed := c.failed
+	c.mu.Unlock()
+	if failed {
+		return
+	}
+
+	overhead, err := c.requestOverhead()
+	if err == nil {
+		var tokens int
+		if tokens, err = c.countRequest(text); err == nil {
+			c.cache.put(key, max(tokens-overhead, 0))
+			return
+		}
+	}
+
+	if errors.Is(err, errRateLimited) {
+		return
+	}
+	c.mu.Lock()
+	if !c.failed {
+		c.failed = true
+		logger.Warn("anthropic token counting unavailable, estimating tokens instead", "model", c.model, "error", err)
+	}
+	c.mu.Unlock()
+}
+
+// requestOverhead is what the endpoint counts for a request around its
+// message text, measured once with a one-token message
+func (c *anthropicCounter) requestOverhead() (int, error) {
+	c.mu.Lock()
+	overhead := c.overhead
+	c.mu.Unlock()
+	if overhead >= 0 {
+		return overhead, nil
+	}
+
+	tokens, err := c.countRequest("a")
+	if err != nil {
+		return 0, err
+	}
+	overhead = max(tokens-1, 0)
+	c.mu.Lock()
+	c.overhead = overhead
+	c.mu.Unlock()
+	return overhead, nil
+}
+
+type anthropicCountRequest struct {
+	Model    string                  `json:"model"`
+	Messages []anthropicCountMessage `json:"messages"`
+}
+
+type anthropicCountMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicCountResponse struct {
+	InputTokens int `json:"input_tokens"`
+}
+
+// countRequest counts text sent as a single user message
+func (c *anthropicCounter) countRequest(text string) (int, error) {
+	body, err := json.Marshal(anthropicCountRequest{
+		Model:    c.model,
+		Messages: []anthropicCountMessage{{Role: "user", Content: text}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, c.opts.AnthropicURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("anthropic-version", anthropicVersion)
+	for name, value := range c.opts.AnthropicHeaders {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return 0, errRateLimited
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("token counting request failed: %s", resp.Status)
+	}
+
+	var result anthropicCountResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode the token count: %w", err)
+	}
+	return result.InputTokens, nil
+}
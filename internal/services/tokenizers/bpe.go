@@ -0,0 +1,241 @@
+package tokenizers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// maxPieceLen bounds the pieces merged whole. Merging is quadratic in the
+// piece length, so longer runs such as minified code or base64 are counted
+// in chunks, which can only split a token at each chunk boundary.
+const maxPieceLen = 256
+
+// maxRankFileSize bounds a downloaded rank file; o200k_base is about 3.6MB
+const maxRankFileSize = 16 << 20
+
+// BPE is a tiktoken byte pair encoding: the rank of every mergeable byte
+// sequence, lower ranks merging first
+type BPE struct {
+	ranks map[string]int
+}
+
+// LoadBPE reads a .tiktoken rank file, one base64 token and its rank per line
+func LoadBPE(r io.Reader) (*BPE, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		encoded, rankText, ok := bytes.Cut(line, []byte(" "))
+		if !ok {
+			return nil, fmt.Errorf("malformed rank line %q", line)
+		}
+		token, err := base64.StdEncoding.DecodeString(string(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("malformed token %q: %w", encoded, err)
+		}
+		rank, err := strconv.Atoi(string(rankText))
+		if err != nil {
+			return nil, fmt.Errorf("malformed rank %q: %w", rankText, err)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(ranks) == 0 {
+		return nil, errors.New("empty rank file")
+	}
+	return &BPE{ranks: ranks}, nil
+}
+
+// Count returns the number of tokens text encodes to
+func (b *BPE) Count(text string) int {
+	tokens := 0
+	for _, piece := range splitPieces(text) {
+		for len(piece) > maxPieceLen {
+			tokens += b.countPiece(piece[:maxPieceLen])
+			piece = piece[maxPieceLen:]
+		}
+		tokens += b.countPiece(piece)
+	}
+	return tokens
+}
+
+// countPiece merges the bytes of piece pair by pair, always the pair of the
+// lowest rank first, until no pair is mergeable, and returns the parts left
+func (b *BPE) countPiece(piece string) int {
+	if _, ok := b.ranks[piece]; ok {
+		return 1
+	}
+
+	// bounds[i] is where the i-th part starts; the last entry is the end
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+	for len(bounds) > 2 {
+		best, bestRank := -1, 0
+		for i := 0; i+2 < len(bounds); i++ {
+			rank, ok := b.ranks[piece[bounds[i]:bounds[i+2]]]
+			if ok && (best < 0 || rank < bestRank) {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		bounds = append(bounds[:best+1], bounds[best+2:]...)
+	}
+	return len(bounds) - 1
+}
+
+// errEncodingNotCached is a rank file missing from the cache directory while
+// downloads are off
+var errEncodingNotCached = errors.New("encoding not in the cache directory and downloads are off")
+
+// bpeCounter counts with a tiktoken encoding, loading its rank file from the
+// cache directory, or downloading it there when allowed, on first use
+type bpeCounter struct {
+	encoding string
+	opts     Options
+	cache    *countCache
+
+	load sync.Once
+	bpe  atomic.Pointer[BPE]
+}
+
+func newBPECounter(encoding string, opts Options, cache *countCache) *bpeCounter {
+	return &bpeCounter{encoding: encoding, opts: opts, cache: cache}
+}
+
+// Count counts text once the encoding is loaded; until then ok is false
+func (c *bpeCounter) Count(text string) (int, bool) {
+	c.startLoading()
+
+	bpe := c.bpe.Load()
+	if bpe == nil {
+		return 0, false
+	}
+	key := newCacheKey(c.encoding, text)
+	if tokens, ok := c.cache.get(key); ok {
+		return tokens, true
+	}
+	tokens := bpe.Count(text)
+	c.cache.put(key, tokens)
+	return tokens, true
+}
+
+// startLoading loads the encoding in the background, once
+func (c *bpeCounter) startLoading() {
+	c.load.Do(func() { go c.loadEncoding() })
+}
+
+func (c *bpeCounter) loadEncoding() {
+	bpe, err := c.readEncoding()
+	if errors.Is(err, errEncodingNotCached) {
+		logger.Debug("tokenizer encoding not cached, estimating tokens instead", "encoding", c.encoding)
+		return
+	}
+	if err != nil {
+		logger.Warn("tokenizer encoding unavailable, estimating tokens instead", "encoding", c.encoding, "error", err)
+		return
+	}
+	c.bpe.Store(bpe)
+}
+
+func (c *bpeCounter) readEncoding() (*BPE, error) {
+	path := filepath.Join(c.opts.CacheDir, c.encoding+".tiktoken")
+	if c.opts.CacheDir != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			if bpe, err := c.parse(data); err == nil {
+				return bpe, nil
+			}
+			logger.Warn("ignoring corrupt tokenizer encoding", "path", path)
+		}
+	}
+	if !c.opts.DownloadEncodings {
+		return nil, errEncodingNotCached
+	}
+
+	data, err := c.download()
+	if err != nil {
+		return nil, err
+	}
+	bpe, err := c.parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.opts.CacheDir != "" {
+		if err := writeFileAtomic(path, data); err != nil {
+			logger.Warn("failed to cache tokenizer encoding", "path", path, "error", err)
+		}
+	}
+	return bpe, nil
+}
+
+// parse checks a rank file against its pinned digest, if any, and loads it
+func (c *bpeCounter) parse(data []byte) (*BPE, error) {
+	if want, ok := c.opts.EncodingHashes[c.encoding]; ok {
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != want {
+			return nil, fmt.Errorf("%s.tiktoken has SHA-256 %s, want %s", c.encoding, got, want)
+		}
+	}
+	return LoadBPE(bytes.NewReader(data))
+}
+
+func (c *bpeCounter) download() ([]byte, error) {
+	url := c.opts.EncodingURL + "/" + c.encoding + ".tiktoken"
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRankFileSize))
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,50 @@
+package tokenizers
+
+import (
+	"sync"
+
+	xxh3 "github.com/zeebo/xxh3"
+)
+
+// defaultCacheEntries bounds the count cache. A conversation's messages are
+// counted again on every estimate, so the cache mostly holds one entry per
+// message of the conversations in use.
+const defaultCacheEntries = 8192
+
+type cacheKey struct {
+	encoding string
+	hash     xxh3.Uint128
+}
+
+// countCache remembers the token counts of texts per encoding. When it is
+// full it starts over, which is cheaper than tracking recency and costs only
+// a recount of the current conversation.
+type countCache struct {
+	mu     sync.Mutex
+	max    int
+	counts map[cacheKey]int
+}
+
+func newCountCache(max int) *countCache {
+	return &countCache{max: max, counts: make(map[cacheKey]int)}
+}
+
+func newCacheKey(encoding, text string) cacheKey {
+	return cacheKey{encoding: encoding, hash: xxh3.HashString128(text)}
+}
+
+func (c *countCache) get(key cacheKey) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens, ok := c.counts[key]
+	return tokens, ok
+}
+
+func (c *countCache) put(key cacheKey, tokens int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.counts) >= c.max {
+		clear(c.counts)
+	}
+	c.counts[key] = tokens
+}
@@ -0,0 +1,129 @@
+package tokenizers
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// splitPieces splits text into the pieces BPE merges within, following the
+// cl100k_base pre-tokenization pattern:
+//
+//	'(?i:[sdmt]|ll|ve|re)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]|\s+(?!\S)|\s+
+//
+// Go's regexp has no lookahead, hence the hand-written scanner. o200k_base
+// splits a little differently around letter case and contractions; using
+// the same pieces for it changes counts by well under a percent.
+func splitPieces(text string) []string {
+	var pieces []string
+	for i := 0; i < len(text); {
+		n := pieceLen(text[i:])
+		pieces = append(pieces, text[i:i+n])
+		i += n
+	}
+	return pieces
+}
+
+// pieceLen returns the byte length of the piece s starts with
+func pieceLen(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+
+	if r == '\'' {
+		if n := contractionLen(s[size:]); n > 0 {
+			return size + n
+		}
+	}
+
+	if isLetter(r) {
+		return size + runLen(s[size:], isLetter)
+	}
+	if r != '\r' && r != '\n' && !isNumber(r) {
+		if next, nextSize := utf8.DecodeRuneInString(s[size:]); isLetter(next) {
+			return size + nextSize + runLen(s[size+nextSize:], isLetter)
+		}
+	}
+
+	if isNumber(r) {
+		n := size
+		for range 2 {
+			next, nextSize := utf8.DecodeRuneInString(s[n:])
+			if !isNumber(next) {
+				break
+			}
+			n += nextSize
+		}
+		return n
+	}
+
+	start := 0
+	if r == ' ' {
+		start = size
+	}
+	if next, _ := utf8.DecodeRuneInString(s[start:]); start < len(s) && isPunct(next) {
+		n := start + runLen(s[start:], isPunct)
+		return n + runLen(s[n:], isNewline)
+	}
+
+	// r is whitespace
+	n := runLen(s, unicode.IsSpace)
+	lastNewline := -1
+	for i, c := range s[:n] {
+		if isNewline(c) {
+			lastNewline = i + 1
+		}
+	}
+	if lastNewline > 0 {
+		return lastNewline
+	}
+	if n == len(s) {
+		return n
+	}
+	// Leave the last space to prefix the word that follows.
+	if _, lastSize := utf8.DecodeLastRuneInString(s[:n]); n > lastSize {
+		return n - lastSize
+	}
+	return n
+}
+
+// contractionLen returns the length of the contraction suffix s starts with,
+// after an apostrophe, or 0
+func contractionLen(s string) int {
+	if len(s) >= 2 {
+		switch lower(s[0]) + lower(s[1]) {
+		case "ll", "ve", "re":
+			return 2
+		}
+	}
+	if len(s) >= 1 {
+		switch lower(s[0]) {
+		case "s", "d", "m", "t":
+			return 1
+		}
+	}
+	return 0
+}
+
+func lower(b byte) string {
+	if 'A' <= b && b <= 'Z' {
+		b += 'a' - 'A'
+	}
+	return string(b)
+}
+
+// runLen returns the byte length of the run of runes in s matching f
+func runLen(s string, f func(rune) bool) int {
+	for i, r := range s {
+		if !f(r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isLetter(r rune) bool  { return unicode.IsLetter(r) }
+func isNumber(r rune) bool  { return unicode.IsNumber(r) }
+func isNewline(r rune) bool { return r == '\r' || r == '\n' }
+
+// isPunct reports whether r is neither whitespace, a letter nor a number
+func isPunct(r rune) bool {
+	return !unicode.IsSpace(r) && !isLetter(r) && !isNumber(r)
+}
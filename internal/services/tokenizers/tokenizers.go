@@ -0,0 +1,213 @@
+// Package tokenizers counts tokens the way the model providers do, so the
+// context-usage indicator and auto-compaction see real counts rather than a
+// characters-per-token estimate. OpenAI-style models are counted with the
+// tiktoken BPE encodings, Claude models with Anthropic's token counting
+// endpoint, and each model is mapped to one of them by name.
+//
+// Counters never block on the network: an encoding's rank file is loaded and
+// Anthropic counts are fetched in the background, and until they are there a
+// counter reports that it cannot count so the caller estimates instead. Rank
+// files are downloaded only when Options.DownloadEncodings is set.
+package tokenizers
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Encodings a model can be mapped to
+const (
+	// EncodingO200k is the tiktoken encoding of GPT-4o and later OpenAI models
+	EncodingO200k = "o200k_base"
+	// EncodingCl100k is the tiktoken encoding of GPT-4 and GPT-3.5. It is
+	// also the closest public match for most open models.
+	EncodingCl100k = "cl100k_base"
+	// EncodingAnthropic counts with Anthropic's token counting endpoint
+	EncodingAnthropic = "anthropic"
+	// EncodingHeuristic leaves counting to the caller's estimate
+	EncodingHeuristic = "heuristic"
+)
+
+// DefaultEncoding is used for models no mapping matches
+const DefaultEncoding = EncodingCl100k
+
+// DefaultModelEncodings maps model name patterns to encodings. A pattern
+// matches when the model name, without its provider prefix, contains it; the
+// longest matching pattern wins.
+var DefaultModelEncodings = map[string]string{
+	"gpt-4o":         EncodingO200k,
+	"gpt-4.1":        EncodingO200k,
+	"gpt-4.5":        EncodingO200k,
+	"gpt-5":          EncodingO200k,
+	"o1":             EncodingO200k,
+	"o3":             EncodingO200k,
+	"o4":             EncodingO200k,
+	"gpt-oss":        EncodingO200k,
+	"gpt-4":          EncodingCl100k,
+	"gpt-3.5":        EncodingCl100k,
+	"claude":         EncodingAnthropic,
+	"text-embedding": EncodingCl100k,
+}
+
+// Counter counts the tokens of a text. ok is false when the counter cannot
+// count yet, or at all, and the caller should estimate instead.
+type Counter interface {
+	Count(text string) (tokens int, ok bool)
+}
+
+// Options configures a Registry
+type Options struct {
+	// Models maps model name patterns to encodings, on top of
+	// DefaultModelEncodings
+	Models map[string]string
+	// CacheDir is where encoding rank files are kept
+	CacheDir string
+	// DownloadEncodings fetches rank files missing from CacheDir from
+	// EncodingURL. Without it only the files already there are used.
+	DownloadEncodings bool
+	// EncodingURL is the base URL rank files are downloaded from
+	EncodingURL string
+	// EncodingHashes are the hex SHA-256 digests rank files must match, by
+	// encoding. They default to DefaultEncodingHashes when rank files come
+	// from DefaultEncodingURL; files from another URL are not checked unless
+	// their digests are given.
+	EncodingHashes map[string]string
+	// AnthropicURL is the token counting endpoint for Claude models
+	AnthropicURL string
+	// AnthropicHeaders are sent with every counting request, for the
+	// gateway's or Anthropic's authentication
+	AnthropicHeaders map[string]string
+	// HTTPClient is used for downloads and counting requests
+	HTTPClient *http.Client
+}
+
+// DefaultEncodingURL serves the tiktoken rank files
+const DefaultEncodingURL = "https://openaipublic.blob.core.windows.net/encodings"
+
+// DefaultEncodingHashes are the SHA-256 digests of the rank files served by
+// DefaultEncodingURL, as pinned by tiktoken itself
+var DefaultEncodingHashes = map[string]string{
+	EncodingCl100k: "223921b76ee99bde995b7ff738513eef100fb51d18c93597a113bcffe865b2a7",
+	EncodingO200k:  "446a9538cb6c348e3516120d7c08b09f57c36495e2acfffe59a5bf8b0cfb1a2d",
+}
+
+// Registry hands out the counter of each model's encoding, sharing the
+// loaded encodings and one count cache between them
+type Registry struct {
+	opts   Options
+	models map[string]string
+	cache  *countCache
+
+	mu        sync.Mutex
+	bpe       map[string]*bpeCounter
+	anthropic map[string]*anthropicCounter
+}
+
+// NewRegistry creates a Registry. Nothing is loaded until a counter is used.
+func NewRegistry(opts Options) *Registry {
+	if opts.EncodingURL == "" {
+		opts.EncodingURL = DefaultEncodingURL
+	}
+	if opts.EncodingHashes == nil && opts.EncodingURL == DefaultEncodingURL {
+		opts.EncodingHashes = DefaultEncodingHashes
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	models := make(map[string]string, len(DefaultModelEncodings)+len(opts.Models))
+	for pattern, encoding := range DefaultModelEncodings {
+		models[pattern] = encoding
+	}
+	for pattern, encoding := range opts.Models {
+		models[strings.ToLower(pattern)] = encoding
+	}
+
+	return &Registry{
+		opts:      opts,
+		models:    models,
+		cache:     newCountCache(defaultCacheEntries),
+		bpe:       make(map[string]*bpeCounter),
+		anthropic: make(map[string]*anthropicCounter),
+	}
+}
+
+// EncodingFor returns the encoding model is counted with. Models of the
+// anthropic provider are counted with its endpoint unless mapped otherwise.
+func (r *Registry) EncodingFor(model string) string {
+	model = strings.ToLower(model)
+	provider, name, found := strings.Cut(model, "/")
+	if !found {
+		provider, name = "", model
+	}
+
+	bestLen := -1
+	encoding := DefaultEncoding
+	if provider == "anthropic" {
+		encoding = EncodingAnthropic
+	}
+	for pattern, enc := range r.models {
+		if strings.Contains(name, pattern) && len(pattern) > bestLen {
+			bestLen = len(pattern)
+			encoding = enc
+		}
+	}
+	return encoding
+}
+
+// CounterFor returns the counter for model, or nil when the model is mapped
+// to the heuristic or to an encoding this registry does not know
+func (r *Registry) CounterFor(model string) Counter {
+	switch encoding := r.EncodingFor(model); encoding {
+	case EncodingO200k, EncodingCl100k:
+		return r.bpeCounter(encoding)
+	case EncodingAnthropic:
+		if r.opts.AnthropicURL == "" {
+			return r.bpeCounter(DefaultEncoding)
+		}
+		return r.anthropicCounter(model)
+	default:
+		return nil
+	}
+}
+
+// Preload starts loading the encoding model is counted with, so that counts
+// are real from the first estimate
+func (r *Registry) Preload(model string) {
+	switch encoding := r.EncodingFor(model); encoding {
+	case EncodingO200k, EncodingCl100k:
+		r.bpeCounter(encoding).startLoading()
+	case EncodingAnthropic:
+		r.bpeCounter(DefaultEncoding).startLoading()
+	}
+}
+
+func (r *Registry) bpeCounter(encoding string) *bpeCounter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counter, ok := r.bpe[encoding]
+	if !ok {
+		counter = newBPECounter(encoding, r.opts, r.cache)
+		r.bpe[encoding] = counter
+	}
+	return counter
+}
+
+func (r *Registry) anthropicCounter(model string) *anthropicCounter {
+	_, name, found := strings.Cut(model, "/")
+	if !found {
+		name = model
+	}
+	fallback := r.bpeCounter(DefaultEncoding)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counter, ok := r.anthropic[name]
+	if !ok {
+		counter = newAnthropicCounter(name, r.opts, r.cache, fallback)
+		r.anthropic[name] = counter
+	}
+	return counter
+}
@@ -0,0 +1,345 @@
+package tokenizers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rankFile builds a .tiktoken rank file: every single byte, then tokens in
+// merge order
+func rankFile(tokens ...string) string {
+	var b strings.Builder
+	rank := 0
+	for i := range 256 {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), rank)
+		rank++
+	}
+	for _, token := range tokens {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+		rank++
+	}
+	return b.String()
+}
+
+// waitCount polls counter until it counts text or the deadline passes
+func waitCount(t *testing.T, counter Counter, text string, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, ok := counter.Count(text)
+		if ok && got == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Count(%q) = %d, %v; want %d", text, got, ok, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSplitPieces(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "Hello world", want: []string{"Hello", " world"}},
+		{text: "I'll don't", want: []string{"I", "'ll", " don", "'t"}},
+		{text: "12345 apples", want: []string{"123", "45", " apples"}},
+		{text: "foo();\n", want: []string{"foo", "();\n"}},
+		{text: "a  b", want: []string{"a", " ", " b"}},
+		{text: "x\n\n  y", want: []string{"x", "\n\n", " ", " y"}},
+		{text: "end   ", want: []string{"end", "   "}},
+		{text: "(value", want: []string{"(value"}},
+		{text: "\xff\xfe", want: []string{"\xff\xfe"}},
+	}
+	for _, tt := range tests {
+		got := splitPieces(tt.text)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("splitPieces(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBPE_Count(t *testing.T) {
+	bpe, err := LoadBPE(strings.NewReader(rankFile("he", "ll", "hell", "hello", " w", "or", " wor")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "", want: 0},
+		{text: "hello", want: 1},
+		{text: "hello world", want: 4}, // "hello" " wor" "l" "d"
+		{text: "help", want: 3},        // "he" "l" "p"
+	}
+	for _, tt := range tests {
+		if got := bpe.Count(tt.text); got != tt.want {
+			t.Errorf("Count(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+
+	if _, err := LoadBPE(strings.NewReader("not a rank file\n")); err == nil {
+		t.Error("expected an error for a malformed rank file")
+	}
+}
+
+func TestRegistry_EncodingFor(t *testing.T) {
+	registry := NewRegistry(Options{Models: map[string]string{"qwen": EncodingHeuristic, "GPT-4o-Mini": EncodingCl100k}})
+
+	tests := map[string]string{
+		"openai/gpt-4o":             EncodingO200k,
+		"openai/gpt-4o-mini":        EncodingCl100k,
+		"openai/gpt-4-turbo":        EncodingCl100k,
+		"openai/o3-mini":            EncodingO200k,
+		"anthropic/claude-sonnet-4": EncodingAnthropic,
+		"anthropic/some-new-model":  EncodingAnthropic,
+		"ollama/qwen3-coder":        EncodingHeuristic,
+		"deepseek/deepseek-chat":    DefaultEncoding,
+		"openrouter/claude-3-haiku": EncodingAnthropic,
+	}
+	for model, want := range tests {
+		if got := registry.EncodingFor(model); got != want {
+			t.Errorf("EncodingFor(%q) = %q, want %q", model, got, want)
+		}
+	}
+	if registry.CounterFor("ollama/qwen3-coder") != nil {
+		t.Error("a model mapped to the heuristic should have no counter")
+	}
+}
+
+func TestRegistry_DownloadsAndCachesEncoding(t *testing.T) {
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+EncodingCl100k+".tiktoken" {
+			http.NotFound(w, r)
+			return
+		}
+		downloads.Add(1)
+		_, _ = w.Write([]byte(rankFile("hello")))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	registry := NewRegistry(Options{CacheDir: cacheDir, EncodingURL: server.URL, DownloadEncodings: true})
+	waitCount(t, registry.CounterFor("deepseek/deepseek-chat"), "hello", 1)
+
+	if _, err := os.Stat(filepath.Join(cacheDir, EncodingCl100k+".tiktoken")); err != nil {
+		t.Fatalf("encoding not cached: %v", err)
+	}
+
+	// A new registry loads the cached file instead of downloading again.
+	registry = NewRegistry(Options{CacheDir: cacheDir, EncodingURL: server.URL, DownloadEncodings: true})
+	waitCount(t, registry.CounterFor("deepseek/deepseek-chat"), "hello", 1)
+	if n := downloads.Load(); n != 1 {
+		t.Errorf("downloads = %d, want 1", n)
+	}
+}
+
+func TestRegistry_DownloadsOnlyWhenAllowed(t *testing.T) {
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write([]byte(rankFile("hello")))
+	}))
+	defer server.Close()
+
+	counter := newBPECounter(EncodingCl100k, Options{
+		CacheDir:    t.TempDir(),
+		EncodingURL: server.URL,
+		HTTPClient:  server.Client(),
+	}, newCountCache(defaultCacheEntries))
+	if _, err := counter.readEncoding(); !errors.Is(err, errEncodingNotCached) {
+		t.Errorf("readEncoding() error = %v, want errEncodingNotCached", err)
+	}
+	if n := downloads.Load(); n != 0 {
+		t.Errorf("downloads = %d, want none without DownloadEncodings", n)
+	}
+}
+
+func TestRegistry_VerifiesEncodingHash(t *testing.T) {
+	served := rankFile("hello")
+	sum := sha256.Sum256([]byte(served))
+	hash := hex.EncodeToString(sum[:])
+
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write([]byte(served))
+	}))
+	defer server.Close()
+
+	// A tampered cached file is downloaded again instead of used.
+	cacheDir := t.TempDir()
+	path := filepath.Join(cacheDir, EncodingCl100k+".tiktoken")
+	if err := os.WriteFile(path, []byte(rankFile("hel", "lo")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry(Options{
+		CacheDir:          cacheDir,
+		EncodingURL:       server.URL,
+		EncodingHashes:    map[string]string{EncodingCl100k: hash},
+		DownloadEncodings: true,
+	})
+	waitCount(t, registry.CounterFor("deepseek/deepseek-chat"), "hello", 1)
+	if n := downloads.Load(); n != 1 {
+		t.Errorf("downloads = %d, want 1", n)
+	}
+
+	// A download that does not match its digest is neither used nor cached.
+	cacheDir = t.TempDir()
+	counter := newBPECounter(EncodingCl100k, Options{
+		CacheDir:          cacheDir,
+		EncodingURL:       server.URL,
+		EncodingHashes:    map[string]string{EncodingCl100k: strings.Repeat("0", 64)},
+		HTTPClient:        server.Client(),
+		DownloadEncodings: true,
+	}, newCountCache(defaultCacheEntries))
+	if _, err := counter.readEncoding(); err == nil || !strings.Contains(err.Error(), "SHA-256") {
+		t.Errorf("readEncoding() error = %v, want a digest mismatch", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, EncodingCl100k+".tiktoken")); !os.IsNotExist(err) {
+		t.Errorf("unverified encoding was cached: %v", err)
+	}
+
+	if got := NewRegistry(Options{}).opts.EncodingHashes[EncodingO200k]; got != DefaultEncodingHashes[EncodingO200k] {
+		t.Errorf("default EncodingHashes[o200k_base] = %q, want the pinned digest", got)
+	}
+}
+
+func TestAnthropicCounter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		requests.Add(1)
+		if r.Header.Get("x-api-key") != "secret" || r.Header.Get("anthropic-version") == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req anthropicCountRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "claude-sonnet-4" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		// 7 tokens of overhead plus one per word
+		words := len(strings.Fields(req.Messages[0].Content))
+		_ = json.NewEncoder(w).Encode(anthropicCountResponse{InputTokens: 7 + words})
+	}))
+	defer server.Close()
+
+	registry := NewRegistry(Options{
+		EncodingURL:      server.URL + "/missing",
+		AnthropicURL:     server.URL,
+		AnthropicHeaders: map[string]string{"x-api-key": "secret"},
+	})
+	counter := registry.CounterFor("anthropic/claude-sonnet-4")
+
+	waitCount(t, counter, "one two three", 3)
+	before := requests.Load()
+	if got, ok := counter.Count("one two three"); !ok || got != 3 {
+		t.Errorf("cached Count = %d, %v; want 3", got, ok)
+	}
+	if requests.Load() != before {
+		t.Error("a counted text should not be requested again")
+	}
+}
+
+func TestAnthropicCounter_StopsAfterFailure(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			requests.Add(1)
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	registry := NewRegistry(Options{EncodingURL: server.URL, AnthropicURL: server.URL + "/count"})
+	counter := registry.CounterFor("anthropic/claude-sonnet-4").(*anthropicCounter)
+
+	_, _ = counter.Count("first")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		counter.mu.Lock()
+		failed := counter.failed
+		counter.mu.Unlock()
+		if failed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("counter did not give up on a failing endpoint")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	before := requests.Load()
+	if _, ok := counter.Count("second"); ok {
+		t.Error("without an encoding or the endpoint the counter cannot count")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if requests.Load() != before {
+		t.Error("no counting requests expected after the endpoint failed")
+	}
+}
+
+func TestAnthropicCounter_QueuesForAFixedPool(t *testing.T) {
+	var active, peak atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		_ = json.NewEncoder(w).Encode(anthropicCountResponse{InputTokens: 1})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	registry := NewRegistry(Options{EncodingURL: server.URL + "/missing", AnthropicURL: server.URL})
+	counter := registry.CounterFor("anthropic/claude-sonnet-4").(*anthropicCounter)
+
+	texts := anthropicQueueSize + 2*anthropicWorkers
+	for i := range texts {
+		_, _ = counter.Count(fmt.Sprintf("text %d", i))
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for active.Load() < anthropicWorkers {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d requests running, want %d", active.Load(), anthropicWorkers)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if p := peak.Load(); p > anthropicWorkers {
+		t.Errorf("%d requests ran at once, want at most %d", p, anthropicWorkers)
+	}
+	counter.mu.Lock()
+	waiting := len(counter.inFlight)
+	counter.mu.Unlock()
+	if waiting >= texts {
+		t.Errorf("%d texts waiting, want the overflow left for a later Count", waiting)
+	}
+}
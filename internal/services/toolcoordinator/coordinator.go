@@ -44,6 +44,11 @@ type Coordinator struct {
 
 	activeToolCallID   string
 	activeToolCallIDMu sync.RWMutex
+
+	// hunkReviewChan is the pending approval's per-hunk review channel, kept
+	// here rather than on the approval UI state because only the coordinator
+	// forwards it.
+	hunkReviewChan chan domain.HunkReview
 }
 
 // Options bundles the dependencies needed to construct a Coordinator.
@@ -138,6 +143,7 @@ func (c *Coordinator) HandleToolCallReady(_ domain.ToolCallReadyEvent) tea.Cmd {
 func (c *Coordinator) HandleToolApprovalRequested(msg domain.ToolApprovalRequestedEvent) tea.Cmd {
 	c.addPendingToolCall(msg.ToolCall, msg.ResponseChan)
 	c.stateManager.SetupApprovalUIState(&msg.ToolCall, msg.ResponseChan)
+	c.hunkReviewChan = msg.HunkReviewChan
 	writeSubagentApprovalSidecar(msg.ToolCall)
 
 	c.stateManager.BroadcastEvent(domain.ToolApprovalNotificationEvent{
@@ -173,6 +179,11 @@ func (c *Coordinator) HandleToolApprovalResponse(msg domain.ToolApprovalResponse
 		return c.applyAutoAccept(msg)
 	}
 
+	if msg.HunkReview != nil {
+		c.sendHunkReview(*msg.HunkReview)
+	}
+	c.hunkReviewChan = nil
+
 	c.sendApprovalDecision(msg.Action)
 	c.stateManager.ClearApprovalUIState()
 
@@ -242,7 +253,30 @@ func (c *Coordinator) sendApprovalDecision(action domain.ApprovalAction) {
 	}
 }
 
+// sendHunkReview forwards a per-hunk review to the agent ahead of the
+// approval decision, so the agent sees it as soon as the approval arrives.
+// Non-blocking; the review is dropped if the agent did not ask for one.
+func (c *Coordinator) sendHunkReview(review domain.HunkReview) {
+	if c.hunkReviewChan == nil {
+		logger.Warn("dropping hunk review - no review channel for this approval")
+		return
+	}
+	select {
+	case c.hunkReviewChan <- review:
+		logger.Info("sent hunk review to agent", "accepted", len(review.Accepted), "total", review.Total)
+	default:
+		logger.Warn("failed to send hunk review - channel full")
+	}
+}
+
 func (c *Coordinator) formatApprovalStatus(msg domain.ToolApprovalResponseEvent) (string, bool) {
+	if review := msg.HunkReview; review != nil && msg.Action == domain.ApprovalApprove {
+		if len(review.Accepted) == 0 {
+			return fmt.Sprintf("All hunks rejected: %s", msg.ToolCall.Function.Name), false
+		}
+		return fmt.Sprintf("Applying %d of %d hunks - executing %s...",
+			len(review.Accepted), review.Total, msg.ToolCall.Function.Name), true
+	}
 	switch msg.Action {
 	case domain.ApprovalApprove:
 		return fmt.Sprintf("Tool approved - executing %s...", msg.ToolCall.Function.Name), true
@@ -364,8 +398,8 @@ func (c *Coordinator) runningProgressCmds(msg domain.ToolExecutionProgressEvent)
 }
 
 // HandleToolExecutionCompleted finalizes the tool round-trip: clears the
-// active-tool indicator, refreshes history, optionally fires a todo-update
-// command, and emits a "Tools completed" status.
+// active-tool indicator, refreshes history, optionally fires todo and plan
+// step update commands, and emits a "Tools completed" status.
 func (c *Coordinator) HandleToolExecutionCompleted(msg domain.ToolExecutionCompletedEvent) tea.Cmd {
 	c.SetActiveToolCallID("")
 
@@ -389,6 +423,9 @@ func (c *Coordinator) HandleToolExecutionCompleted(msg domain.ToolExecutionCompl
 	if todoUpdateCmd := extractTodoUpdateCmd(msg.Results); todoUpdateCmd != nil {
 		cmds = append(cmds, todoUpdateCmd)
 	}
+	if planStepsCmd := extractPlanStepsUpdateCmd(msg.Results); planStepsCmd != nil {
+		cmds = append(cmds, planStepsCmd)
+	}
 
 	cmds = c.appendChatListener(cmds)
 	return tea.Sequence(cmds...)
@@ -476,3 +513,24 @@ func extractTodoUpdateCmd(results []*domain.ToolExecutionResult) tea.Cmd {
 	}
 	return nil
 }
+
+// extractPlanStepsUpdateCmd returns a command refreshing the plan checklist
+// from the last successful UpdatePlanStep result, which carries every step.
+func extractPlanStepsUpdateCmd(results []*domain.ToolExecutionResult) tea.Cmd {
+	var latest *domain.PlanStepsToolResult
+	for _, result := range results {
+		if result == nil || result.ToolName != "UpdatePlanStep" || !result.Success {
+			continue
+		}
+		if stepsResult, ok := result.Data.(*domain.PlanStepsToolResult); ok && stepsResult != nil {
+			latest = stepsResult
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	steps := latest.Steps
+	return func() tea.Msg {
+		return domain.PlanStepsUpdateEvent{Steps: steps}
+	}
+}
@@ -130,6 +130,40 @@ func TestCoordinator_HandleToolApprovalResponse(t *testing.T) {
 			t.Errorf("expected reject decision to be sent")
 		}
 	})
+
+	t.Run("HunkReview is forwarded on the review channel before the approval", func(t *testing.T) {
+		c, _, _, _ := newCoordinatorForTest()
+		responseChan := make(chan domain.ApprovalAction, 1)
+		reviewChan := make(chan domain.HunkReview, 1)
+		toolCall := sdk.ChatCompletionMessageToolCall{
+			ID:       "tc-1",
+			Function: sdk.ChatCompletionMessageToolCallFunction{Name: "Edit"},
+		}
+		_ = c.HandleToolApprovalRequested(domain.ToolApprovalRequestedEvent{
+			ToolCall:       toolCall,
+			ResponseChan:   responseChan,
+			HunkReviewChan: reviewChan,
+		})
+
+		review := &domain.HunkReview{Total: 2, Accepted: []int{1}, Arguments: `{"file_path":"a.go"}`}
+		_ = c.HandleToolApprovalResponse(domain.ToolApprovalResponseEvent{
+			Action:     domain.ApprovalApprove,
+			ToolCall:   toolCall,
+			HunkReview: review,
+		})
+
+		select {
+		case got := <-reviewChan:
+			if got.Total != 2 || len(got.Accepted) != 1 {
+				t.Errorf("unexpected hunk review forwarded: %+v", got)
+			}
+		default:
+			t.Fatalf("expected hunk review to be sent down the review channel")
+		}
+		if action := <-responseChan; action != domain.ApprovalApprove {
+			t.Errorf("expected ApprovalApprove after hunk review, got %v", action)
+		}
+	})
 }
 
 func TestCoordinator_HandleToolExecutionProgress(t *testing.T) {
@@ -19,6 +19,9 @@ type LLMToolService struct {
 	registry *tools.Registry
 	enabled  bool
 	config   *config.Config
+	// allowed is the subagent tool allowlist from INFER_SUBAGENT_TOOLS; nil
+	// when the process is not a restricted subagent
+	allowed map[string]bool
 }
 
 // NewLLMToolServiceWithRegistry creates a new LLM tool service with an existing registry
@@ -27,11 +30,32 @@ func NewLLMToolServiceWithRegistry(cfg *config.Config, registry *tools.Registry)
 		registry: registry,
 		enabled:  cfg.Tools.Enabled,
 		config:   cfg,
+		allowed:  subagentToolAllowlist(os.Getenv(domain.EnvSubagentTools)),
 	}
 }
 
+// subagentToolAllowlist parses the comma-separated INFER_SUBAGENT_TOOLS value
+func subagentToolAllowlist(raw string) map[string]bool {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	allowed := map[string]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			allowed[name] = true
+		}
+	}
+	return allowed
+}
+
 // isToolEnabled checks if a tool should be included based on its type and configuration
 func (s *LLMToolService) isToolEnabled(toolName string) bool {
+	if s.allowed != nil && !s.allowed[toolName] {
+		return false
+	}
+	if !s.config.PersonaAllowsTool(toolName) {
+		return false
+	}
 	if s.isA2ATool(toolName) {
 		return s.config.IsA2AToolsEnabled() && s.registry.IsToolEnabled(toolName)
 	}
@@ -59,6 +83,7 @@ func (s *LLMToolService) ListToolsForMode(mode domain.AgentMode) []sdk.ChatCompl
 			"Read":                true,
 			"Grep":                true,
 			"Tree":                true,
+			"ProjectSearch":       true,
 			"A2A_QueryAgent":      true,
 			"TodoWrite":           true,
 			"RequestPlanApproval": true,
@@ -81,6 +106,7 @@ func (s *LLMToolService) ListToolsForMode(mode domain.AgentMode) []sdk.ChatCompl
 			"Read":               true,
 			"Grep":               true,
 			"Tree":               true,
+			"ProjectSearch":      true,
 			"WebFetch":           true,
 			"WebSearch":          true,
 			"ListSubagents":      true,
@@ -135,6 +161,12 @@ func (s *LLMToolService) isA2ATool(toolName string) bool {
 // ExecuteTool executes a tool with the given arguments
 func (s *LLMToolService) ExecuteTool(ctx context.Context, toolCall sdk.ChatCompletionMessageToolCallFunction) (*domain.ToolExecutionResult, error) {
 	if !s.isToolEnabled(toolCall.Name) {
+		if s.allowed != nil && !s.allowed[toolCall.Name] {
+			return nil, fmt.Errorf("tool %s is not in this subagent's toolset", toolCall.Name)
+		}
+		if !s.config.PersonaAllowsTool(toolCall.Name) {
+			return nil, fmt.Errorf("tool %s is not in the %s persona's toolset", toolCall.Name, s.config.ActivePersona())
+		}
 		if s.isA2ATool(toolCall.Name) {
 			return nil, fmt.Errorf("A2A tools are not enabled")
 		}
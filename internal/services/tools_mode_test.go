@@ -51,3 +51,19 @@ func TestListToolsForMode_AskUserQuestionPlanOnly(t *testing.T) {
 		t.Error("expected AskUserQuestion to be excluded from auto-accept mode")
 	}
 }
+
+func TestListToolsForMode_SubagentToolAllowlist(t *testing.T) {
+	t.Setenv(domain.EnvSubagentTools, "Read, Bash")
+	cfg := config.DefaultConfig()
+	registry := tools.NewRegistry(cfg, nil, nil, nil, nil, nil, nil, nil)
+	svc := NewLLMToolServiceWithRegistry(cfg, registry)
+
+	names := toolNamesForMode(svc, domain.AgentModeStandard)
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"Bash", "Read"}) {
+		t.Errorf("standard mode with an allowlist = %v, want only Read and Bash", names)
+	}
+	if names := toolNamesForMode(svc, domain.AgentModeReadOnly); !slices.Equal(names, []string{"Read"}) {
+		t.Errorf("the allowlist must not widen ReadOnly mode, got %v", names)
+	}
+}
@@ -0,0 +1,266 @@
+// Package toolscheduler runs the tool calls of one agent turn with adaptive
+// concurrency. Each call is classified as CPU-bound (local reads and
+// searches), network-bound (web, A2A, MCP, subagents) or mutating (file
+// writes, commands, desktop input), and runs under that class's limit from
+// agent.tool_concurrency.
+//
+// Ordering is preserved where it matters: a call waits for every earlier call
+// in the batch it conflicts with - a mutation of the same file (or of a
+// directory containing it), a read of a file an earlier call writes, or any
+// call whose effects can't be scoped, like Bash. Reads of unrelated files and
+// mutations of different files still run in parallel.
+package toolscheduler
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+// Class is the resource a tool call is bound by
+type Class int
+
+const (
+	ClassCPU Class = iota
+	ClassNetwork
+	ClassMutating
+)
+
+// String returns the class name used in logs
+func (c Class) String() string {
+	switch c {
+	case ClassNetwork:
+		return "network"
+	case ClassMutating:
+		return "mutating"
+	default:
+		return "cpu"
+	}
+}
+
+// Access describes what a tool call touches. Reads and Writes hold absolute,
+// cleaned file paths or named resources (e.g. "todos"); Exclusive marks a call
+// whose effects can't be scoped, which conflicts with every other call.
+type Access struct {
+	Class     Class
+	Reads     []string
+	Writes    []string
+	Exclusive bool
+}
+
+// Named resources shared by tools that don't operate on files
+const (
+	resourceTodos     = "todos"
+	resourcePlanSteps = "plan_steps"
+	resourceMemory    = "memory"
+	resourceDesktop   = "desktop"
+	resourceShells    = "shells"
+)
+
+var fileWriteArg = map[string]string{
+	"Write":     "file_path",
+	"Edit":      "file_path",
+	"MultiEdit": "file_path",
+	"Delete":    "path",
+}
+
+var fileReadArg = map[string]string{
+	"Read": "file_path",
+	"Grep": "path",
+	"Tree": "path",
+}
+
+var networkTools = map[string]bool{
+	"WebFetch":          true,
+	"WebSearch":         true,
+	"Agent":             true,
+	"SendSubagentInput": true,
+	"ApproveSubagent":   true,
+	"CloseSubagent":     true,
+	"Schedule":          true,
+}
+
+var cpuTools = map[string]bool{
+	"ProjectSearch":       true,
+	"ListSubagents":       true,
+	"GetSubagentResult":   true,
+	"ReadSubagentScreen":  true,
+	"Wait":                true,
+	"AskUserQuestion":     true,
+	"RequestPlanApproval": true,
+}
+
+var namedResourceTools = map[string]struct {
+	resource string
+	write    bool
+}{
+	"TodoWrite":           {resourceTodos, true},
+	"UpdatePlanStep":      {resourcePlanSteps, true},
+	"Memory":              {resourceMemory, true},
+	"BashOutput":          {resourceShells, false},
+	"ListShells":          {resourceShells, false},
+	"KillShell":           {resourceShells, true},
+	"GetFocusedApp":       {resourceDesktop, false},
+	"GetLatestScreenshot": {resourceDesktop, false},
+	"ActivateApp":         {resourceDesktop, true},
+	"KeyboardType":        {resourceDesktop, true},
+	"MouseClick":          {resourceDesktop, true},
+	"MouseMove":           {resourceDesktop, true},
+	"MouseScroll":         {resourceDesktop, true},
+}
+
+// Classify returns the access of a call to the named tool with the given JSON
+// arguments. Unknown tools, and file tools whose path can't be read from the
+// arguments, are treated as exclusive mutations.
+func Classify(name, argsJSON string) Access {
+	var args map[string]any
+	_ = json.Unmarshal([]byte(argsJSON), &args)
+
+	if key, ok := fileWriteArg[name]; ok {
+		if path := argPath(args, key, ""); path != "" {
+			return Access{Class: ClassMutating, Writes: []string{path}}
+		}
+		return Access{Class: ClassMutating, Exclusive: true}
+	}
+	if key, ok := fileReadArg[name]; ok {
+		if path := argPath(args, key, "."); path != "" {
+			return Access{Class: ClassCPU, Reads: []string{path}}
+		}
+		return Access{Class: ClassCPU}
+	}
+	if res, ok := namedResourceTools[name]; ok {
+		if res.write {
+			return Access{Class: ClassMutating, Writes: []string{res.resource}}
+		}
+		return Access{Class: ClassCPU, Reads: []string{res.resource}}
+	}
+	switch {
+	case networkTools[name], strings.HasPrefix(name, "A2A_"), strings.HasPrefix(name, "MCP_"):
+		return Access{Class: ClassNetwork}
+	case cpuTools[name]:
+		return Access{Class: ClassCPU}
+	}
+	return Access{Class: ClassMutating, Exclusive: true}
+}
+
+// argPath resolves the path argument key to an absolute, cleaned path, using
+// fallback when the argument is absent
+func argPath(args map[string]any, key, fallback string) string {
+	path, _ := args[key].(string)
+	if path == "" {
+		path = fallback
+	}
+	if path == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	return abs
+}
+
+// Conflicts reports whether a and b must not run concurrently
+func (a Access) Conflicts(b Access) bool {
+	if a.Exclusive || b.Exclusive {
+		return true
+	}
+	return overlaps(a.Writes, b.Writes) || overlaps(a.Writes, b.Reads) || overlaps(a.Reads, b.Writes)
+}
+
+func overlaps(xs, ys []string) bool {
+	for _, x := range xs {
+		for _, y := range ys {
+			if resourcesOverlap(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourcesOverlap reports whether two resources are the same, or one is a
+// file path inside the other
+func resourcesOverlap(x, y string) bool {
+	if x == y {
+		return true
+	}
+	if !filepath.IsAbs(x) || !filepath.IsAbs(y) {
+		return false
+	}
+	return isWithin(x, y) || isWithin(y, x)
+}
+
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Batch schedules the tool calls of one turn. Calls are submitted in
+// tool-call order with Go; each starts once the calls it conflicts with have
+// finished and a slot of its class is free.
+type Batch struct {
+	mu    sync.Mutex
+	slots map[Class]chan struct{}
+	calls []*scheduledCall
+	wg    sync.WaitGroup
+}
+
+type scheduledCall struct {
+	access Access
+	done   chan struct{}
+}
+
+// NewBatch creates a batch bounded by cfg's per-class limits
+func NewBatch(cfg config.ToolConcurrencyConfig) *Batch {
+	return &Batch{
+		slots: map[Class]chan struct{}{
+			ClassCPU:      make(chan struct{}, cfg.EffectiveCPUBound()),
+			ClassNetwork:  make(chan struct{}, cfg.EffectiveNetworkBound()),
+			ClassMutating: make(chan struct{}, cfg.EffectiveMutating()),
+		},
+	}
+}
+
+// Go runs run in the background for tc once tc may start. A call only ever
+// waits on calls submitted before it, so the batch cannot deadlock.
+func (b *Batch) Go(tc sdk.ChatCompletionMessageToolCall, run func()) {
+	access := Classify(tc.Function.Name, tc.Function.Arguments)
+	call := &scheduledCall{access: access, done: make(chan struct{})}
+
+	b.mu.Lock()
+	var deps []chan struct{}
+	for _, prior := range b.calls {
+		if access.Conflicts(prior.access) {
+			deps = append(deps, prior.done)
+		}
+	}
+	b.calls = append(b.calls, call)
+	b.mu.Unlock()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		defer close(call.done)
+
+		for _, dep := range deps {
+			<-dep
+		}
+
+		slot := b.slots[access.Class]
+		slot <- struct{}{}
+		defer func() { <-slot }()
+
+		run()
+	}()
+}
+
+// Wait blocks until every submitted call has finished
+func (b *Batch) Wait() {
+	b.wg.Wait()
+}
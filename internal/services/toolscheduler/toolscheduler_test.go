@@ -0,0 +1,140 @@
+package toolscheduler
+
+import (
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func call(name, args string) sdk.ChatCompletionMessageToolCall {
+	return sdk.ChatCompletionMessageToolCall{
+		ID:       name,
+		Function: sdk.ChatCompletionMessageToolCallFunction{Name: name, Arguments: args},
+	}
+}
+
+func TestClassify(t *testing.T) {
+	abs, err := filepath.Abs("main.go")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		tool string
+		args string
+		want Access
+	}{
+		{"read", "Read", `{"file_path": "main.go"}`, Access{Class: ClassCPU, Reads: []string{abs}}},
+		{"edit", "Edit", `{"file_path": "main.go"}`, Access{Class: ClassMutating, Writes: []string{abs}}},
+		{"write without path", "Write", `{}`, Access{Class: ClassMutating, Exclusive: true}},
+		{"web", "WebFetch", `{"url": "https://example.com"}`, Access{Class: ClassNetwork}},
+		{"mcp", "MCP_github_list_issues", `{}`, Access{Class: ClassNetwork}},
+		{"a2a", "A2A_SubmitTask", `{}`, Access{Class: ClassNetwork}},
+		{"todos", "TodoWrite", `{}`, Access{Class: ClassMutating, Writes: []string{resourceTodos}}},
+		{"bash", "Bash", `{"command": "go test ./..."}`, Access{Class: ClassMutating, Exclusive: true}},
+		{"unknown", "SomePluginTool", `{}`, Access{Class: ClassMutating, Exclusive: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Classify(tt.tool, tt.args))
+		})
+	}
+}
+
+func TestAccessConflicts(t *testing.T) {
+	readA := Access{Class: ClassCPU, Reads: []string{"/repo/a.go"}}
+	readB := Access{Class: ClassCPU, Reads: []string{"/repo/b.go"}}
+	writeA := Access{Class: ClassMutating, Writes: []string{"/repo/a.go"}}
+	writeB := Access{Class: ClassMutating, Writes: []string{"/repo/b.go"}}
+	grepRepo := Access{Class: ClassCPU, Reads: []string{"/repo"}}
+	bash := Access{Class: ClassMutating, Exclusive: true}
+	web := Access{Class: ClassNetwork}
+
+	assert.False(t, readA.Conflicts(readB))
+	assert.False(t, readA.Conflicts(readA), "reads never conflict with reads")
+	assert.False(t, writeA.Conflicts(writeB), "mutations of different files run in parallel")
+	assert.True(t, writeA.Conflicts(writeA))
+	assert.True(t, writeA.Conflicts(readA))
+	assert.True(t, grepRepo.Conflicts(writeB), "a search of a directory waits for writes inside it")
+	assert.True(t, bash.Conflicts(readA))
+	assert.True(t, web.Conflicts(bash))
+	assert.False(t, web.Conflicts(writeA))
+}
+
+func TestBatchSerializesMutationsOfTheSameFile(t *testing.T) {
+	batch := NewBatch(config.ToolConcurrencyConfig{})
+
+	var mu sync.Mutex
+	var order []string
+	record := func(id string, delay time.Duration) func() {
+		return func() {
+			time.Sleep(delay)
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+		}
+	}
+
+	batch.Go(call("Edit", `{"file_path": "/repo/a.go"}`), record("edit-a", 40*time.Millisecond))
+	batch.Go(call("Read", `{"file_path": "/repo/a.go"}`), record("read-a", 0))
+	batch.Go(call("Edit", `{"file_path": "/repo/a.go"}`), record("edit-a-again", 0))
+	batch.Wait()
+
+	assert.Equal(t, []string{"edit-a", "read-a", "edit-a-again"}, order)
+}
+
+func TestBatchRunsIndependentCallsInParallel(t *testing.T) {
+	batch := NewBatch(config.ToolConcurrencyConfig{CPUBound: 2, Mutating: 2})
+
+	var running, peak atomic.Int32
+	run := func() {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		running.Add(-1)
+	}
+
+	batch.Go(call("Read", `{"file_path": "/repo/a.go"}`), run)
+	batch.Go(call("Read", `{"file_path": "/repo/b.go"}`), run)
+	batch.Go(call("Read", `{"file_path": "/repo/c.go"}`), run)
+	batch.Go(call("Write", `{"file_path": "/repo/d.go"}`), run)
+	batch.Wait()
+
+	assert.Equal(t, int32(3), peak.Load(), "two reads (the CPU-bound limit) and one write should overlap")
+}
+
+func TestBatchBashIsABarrier(t *testing.T) {
+	batch := NewBatch(config.ToolConcurrencyConfig{})
+
+	var mu sync.Mutex
+	var order []string
+	record := func(id string, delay time.Duration) func() {
+		return func() {
+			time.Sleep(delay)
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+		}
+	}
+
+	batch.Go(call("Write", `{"file_path": "/repo/a.go"}`), record("write", 30*time.Millisecond))
+	batch.Go(call("Bash", `{"command": "go build ./..."}`), record("bash", 30*time.Millisecond))
+	batch.Go(call("Read", `{"file_path": "/repo/b.go"}`), record("read", 0))
+	batch.Wait()
+
+	assert.Equal(t, []string{"write", "bash", "read"}, order)
+}
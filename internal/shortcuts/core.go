@@ -7,8 +7,10 @@ import (
 	"slices"
 	"strings"
 
+	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
 	models "github.com/inference-gateway/cli/internal/models"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
 	sdk "github.com/inference-gateway/sdk"
 )
 
@@ -50,21 +52,29 @@ func (c *ClearShortcut) Execute(ctx context.Context, args []string) (ShortcutRes
 
 // CompactShortcut runs conversation optimization to reduce token usage
 type CompactShortcut struct {
-	repo domain.ConversationRepository
+	repo         domain.ConversationRepository
+	planner      domain.CompactionPlanner
+	modelService domain.ModelService
 }
 
-func NewCompactShortcut(repo domain.ConversationRepository) *CompactShortcut {
+// NewCompactShortcut creates the /compact shortcut. planner and modelService
+// back `/compact --dry-run`; without them only a real compaction is offered.
+func NewCompactShortcut(repo domain.ConversationRepository, planner domain.CompactionPlanner, modelService domain.ModelService) *CompactShortcut {
 	return &CompactShortcut{
-		repo: repo,
+		repo:         repo,
+		planner:      planner,
+		modelService: modelService,
 	}
 }
 
 func (c *CompactShortcut) GetName() string { return "compact" }
 func (c *CompactShortcut) GetDescription() string {
-	return "Save current conversation and start new session with summary"
+	return "Save current conversation and start new session with summary (--dry-run to preview)"
+}
+func (c *CompactShortcut) GetUsage() string { return "/compact [--dry-run]" }
+func (c *CompactShortcut) CanExecute(args []string) bool {
+	return len(args) == 0 || (len(args) == 1 && args[0] == "--dry-run")
 }
-func (c *CompactShortcut) GetUsage() string              { return "/compact" }
-func (c *CompactShortcut) CanExecute(args []string) bool { return len(args) == 0 }
 
 func (c *CompactShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
 	if c.repo.GetMessageCount() == 0 {
@@ -74,6 +84,10 @@ func (c *CompactShortcut) Execute(ctx context.Context, args []string) (ShortcutR
 		}, nil
 	}
 
+	if len(args) == 1 {
+		return c.dryRun(), nil
+	}
+
 	return ShortcutResult{
 		Output:     "Compacting conversation history...",
 		Success:    true,
@@ -82,6 +96,58 @@ func (c *CompactShortcut) Execute(ctx context.Context, args []string) (ShortcutR
 	}, nil
 }
 
+// dryRun previews what a forced compaction of the visible conversation would
+// keep and remove, without summarizing anything
+func (c *CompactShortcut) dryRun() ShortcutResult {
+	if c.planner == nil || c.modelService == nil {
+		return ShortcutResult{
+			Output:  "Compaction preview is not available",
+			Success: false,
+		}
+	}
+
+	var messages []sdk.Message
+	for _, entry := range c.repo.GetMessages() {
+		if !entry.Hidden {
+			messages = append(messages, entry.Message)
+		}
+	}
+	preview := c.planner.PreviewCompaction(messages, c.modelService.GetCurrentModel())
+
+	var output strings.Builder
+	output.WriteString("## Compaction Preview\n\n")
+	fmt.Fprintf(&output, "**Strategy:** %s\n", preview.Strategy)
+	if preview.SummaryModel != "" {
+		fmt.Fprintf(&output, "**Summary Model:** %s\n", preview.SummaryModel)
+	}
+	fmt.Fprintf(&output, "**Messages:** %d → %d kept verbatim", preview.Messages, preview.Kept)
+	if preview.Pinned > 0 {
+		fmt.Fprintf(&output, " (%d pinned)", preview.Pinned)
+	}
+	output.WriteString("\n")
+	if preview.Summarized > 0 {
+		fmt.Fprintf(&output, "**Summarized:** %d\n", preview.Summarized)
+	}
+	if preview.Dropped > 0 {
+		fmt.Fprintf(&output, "**Dropped:** %d\n", preview.Dropped)
+	}
+	if preview.ToolResultsElided > 0 {
+		fmt.Fprintf(&output, "**Tool Outputs Removed:** %d\n", preview.ToolResultsElided)
+	}
+	fmt.Fprintf(&output, "**Tokens:** ~%d → ~%d (before summary)\n", preview.TokensBefore, preview.TokensKept)
+
+	if len(preview.Removed) == 0 {
+		output.WriteString("\nNothing would be removed - the conversation is already compact.")
+		return ShortcutResult{Output: output.String(), Success: true}
+	}
+
+	output.WriteString("\n### Would Be Removed\n\n")
+	for _, removal := range preview.Removed {
+		fmt.Fprintf(&output, "- [%s] %s: %s\n", removal.Action, removal.Role, cmp.Or(removal.Excerpt, "(no text)"))
+	}
+	return ShortcutResult{Output: output.String(), Success: true}
+}
+
 // ContextShortcut shows context window usage information
 type ContextShortcut struct {
 	repo         domain.ConversationRepository
@@ -229,6 +295,11 @@ func (c *CostShortcut) Execute(ctx context.Context, args []string) (ShortcutResu
 		costStats.TotalOutputCost,
 		formatTokenCount(tokenStats.TotalOutputTokens))
 	fmt.Fprintf(&output, "| **API Requests** | %d |\n", tokenStats.RequestCount)
+	if costStats.TotalCacheSavings > 0 {
+		fmt.Fprintf(&output, "| **Cache Savings** | $%.4f (%s cached tokens) |\n",
+			costStats.TotalCacheSavings,
+			formatTokenCount(tokenStats.TotalCachedTokens))
+	}
 	fmt.Fprintf(&output, "| **Total Cost** | $%.4f %s |\n\n",
 		costStats.TotalCost, costStats.Currency)
 
@@ -394,23 +465,35 @@ type ModelSwitchData struct {
 	Prompt        string
 }
 
-// SwitchShortcut switches the active model
+// modelParamsSubcommand is the /model argument that shows or tweaks the
+// sampling parameters instead of naming a model
+const modelParamsSubcommand = "params"
+
+// SwitchShortcut switches the active model and tweaks its sampling
+// parameters for the session
 type SwitchShortcut struct {
 	modelService domain.ModelService
+	config       *config.Config
 }
 
-func NewSwitchShortcut(modelService domain.ModelService) *SwitchShortcut {
-	return &SwitchShortcut{modelService: modelService}
+func NewSwitchShortcut(modelService domain.ModelService, cfg *config.Config) *SwitchShortcut {
+	return &SwitchShortcut{modelService: modelService, config: cfg}
 }
 
 func (c *SwitchShortcut) GetName() string { return "model" }
 func (c *SwitchShortcut) GetDescription() string {
-	return "Switch model or execute a prompt with a specific model"
+	return "Switch model, execute a prompt with a specific model, or tweak sampling parameters"
+}
+func (c *SwitchShortcut) GetUsage() string {
+	return "/model [model-name] [prompt] | /model params [name=value|default ...]"
 }
-func (c *SwitchShortcut) GetUsage() string              { return "/model [model-name] [prompt]" }
 func (c *SwitchShortcut) CanExecute(args []string) bool { return true }
 
 func (c *SwitchShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	if len(args) > 0 && args[0] == modelParamsSubcommand && c.config != nil {
+		return c.executeParams(args[1:]), nil
+	}
+
 	if len(args) == 0 {
 		return ShortcutResult{
 			Output:     "Select a model from the dropdown",
@@ -467,6 +550,41 @@ func (c *SwitchShortcut) Execute(ctx context.Context, args []string) (ShortcutRe
 	}, nil
 }
 
+// executeParams sets each name=value pair on agent.sampling for the rest of
+// the session, then lists the parameters in effect. Nothing is applied when
+// a pair is invalid.
+func (c *SwitchShortcut) executeParams(pairs []string) ShortcutResult {
+	sampling := c.config.Agent.Sampling
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || value == "" {
+			return ShortcutResult{
+				Output:  fmt.Sprintf("%s Expected name=value, got %q. Usage: %s", icons.StyledCrossMark(), pair, c.GetUsage()),
+				Success: false,
+			}
+		}
+		if err := sampling.Set(name, value); err != nil {
+			return ShortcutResult{
+				Output:  fmt.Sprintf("%s %v", icons.StyledCrossMark(), err),
+				Success: false,
+			}
+		}
+	}
+	c.config.Agent.Sampling = sampling
+
+	var sb strings.Builder
+	sb.WriteString("## Sampling parameters\n\n")
+	for _, name := range config.SamplingParams {
+		value := sampling.Get(name)
+		if value == "" {
+			value = "provider default"
+		}
+		fmt.Fprintf(&sb, "- **%s**: %s\n", name, value)
+	}
+	sb.WriteString("\nChanges last for this session. Set with `/model params temperature=0.2 seed=7`, reset with `/model params temperature=default`.")
+	return ShortcutResult{Output: sb.String(), Success: true}
+}
+
 // ThemeShortcut switches the active theme
 type ThemeShortcut struct {
 	themeService domain.ThemeService
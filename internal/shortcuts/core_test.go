@@ -120,6 +120,64 @@ func TestContextShortcut_Execute_NoUsageNoEstimateOmitsPercent(t *testing.T) {
 	}
 }
 
+type stubCompactionPlanner struct {
+	preview  domain.CompactionPreview
+	messages []sdk.Message
+	model    string
+}
+
+func (s *stubCompactionPlanner) PreviewCompaction(messages []sdk.Message, model string) domain.CompactionPreview {
+	s.messages, s.model = messages, model
+	return s.preview
+}
+
+func TestCompactShortcut_CanExecute(t *testing.T) {
+	sc := NewCompactShortcut(nil, nil, nil)
+	if !sc.CanExecute(nil) || !sc.CanExecute([]string{"--dry-run"}) {
+		t.Error("expected /compact and /compact --dry-run to be accepted")
+	}
+	if sc.CanExecute([]string{"--force"}) {
+		t.Error("expected unknown flags to be rejected")
+	}
+}
+
+func TestCompactShortcut_Execute_DryRunPreviewsWithoutCompacting(t *testing.T) {
+	repo := &domainmocks.FakeConversationRepository{}
+	repo.GetMessageCountReturns(3)
+	repo.GetMessagesReturns([]domain.ConversationEntry{
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("hi")}},
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("reminder")}, Hidden: true},
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("hello")}},
+	})
+	planner := &stubCompactionPlanner{preview: domain.CompactionPreview{
+		Strategy:     "sliding-window",
+		Messages:     2,
+		Kept:         1,
+		Dropped:      1,
+		TokensBefore: 40,
+		TokensKept:   20,
+		Removed:      []domain.CompactionRemoval{{Role: "assistant", Action: "drop", Excerpt: "hello"}},
+	}}
+	model := &mockModelService{currentModel: "openai/gpt-4o"}
+
+	res, err := NewCompactShortcut(repo, planner, model).Execute(context.Background(), []string{"--dry-run"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if res.SideEffect != SideEffectNone {
+		t.Errorf("expected no side effect for a dry run, got %v", res.SideEffect)
+	}
+	if len(planner.messages) != 2 || planner.model != "openai/gpt-4o" {
+		t.Errorf("expected the 2 visible messages and current model, got %d messages and %q", len(planner.messages), planner.model)
+	}
+	for _, want := range []string{"**Strategy:** sliding-window", "**Dropped:** 1", "- [drop] assistant: hello"} {
+		if !strings.Contains(res.Output, want) {
+			t.Errorf("expected %q in output, got: %s", want, res.Output)
+		}
+	}
+}
+
 func TestHelpShortcut_Execute_OpensOverlay(t *testing.T) {
 	registry := NewRegistry()
 	registry.Register(NewExitShortcut())
@@ -45,6 +45,7 @@ type CustomShortcutConfig struct {
 	Snippet       *SnippetConfig     `yaml:"snippet,omitempty"`
 	PassSessionID bool               `yaml:"pass_session_id,omitempty"`
 	Subcommands   []SubcommandConfig `yaml:"subcommands,omitempty"`
+	Steps         []string           `yaml:"steps,omitempty"`
 }
 
 // CustomShortcutsConfig represents the structure of a custom shortcuts YAML file
@@ -196,6 +197,14 @@ func fillTemplate(template string, data map[string]string) string {
 }
 
 func (c *CustomShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	if len(c.config.Steps) > 0 {
+		return ShortcutResult{
+			Success:    true,
+			SideEffect: SideEffectRunMacro,
+			Data:       MacroData{Name: c.config.Name, Steps: c.config.Steps},
+		}, nil
+	}
+
 	if c.config.Tool != "" {
 		return c.executeWithTool(ctx, args)
 	}
@@ -573,9 +582,9 @@ func loadShortcutsFromFile(filename string, client sdk.Client, modelService doma
 			fmt.Printf("Warning: shortcut without name found in %s, skipping\n", filename)
 			continue
 		}
-		// Must have either a command or a tool
-		if shortcutConfig.Command == "" && shortcutConfig.Tool == "" {
-			fmt.Printf("Warning: shortcut '%s' must have either 'command' or 'tool' specified in %s, skipping\n", shortcutConfig.Name, filename)
+		// Must have a command, a tool or macro steps
+		if shortcutConfig.Command == "" && shortcutConfig.Tool == "" && len(shortcutConfig.Steps) == 0 {
+			fmt.Printf("Warning: shortcut '%s' must have either 'command', 'tool' or 'steps' specified in %s, skipping\n", shortcutConfig.Name, filename)
 			continue
 		}
 
@@ -48,6 +48,11 @@ const (
 	SideEffectShowExplorer
 	SideEffectShowToolsList
 	SideEffectShowA2AAgents
+	SideEffectRunMacro
+	SideEffectLoadConversation
+	SideEffectSendPrompt
+	SideEffectRewindConversation
+	SideEffectStartFromTemplate
 )
 
 // PersistentConversationRepository interface for conversation persistence
@@ -60,4 +65,6 @@ type PersistentConversationRepository interface {
 	GetCurrentConversationID() string
 	SetConversationTitle(title string)
 	DeleteSavedConversation(ctx context.Context, conversationID string) error
+	SetSavedConversationTags(ctx context.Context, conversationID string, tags []string) error
+	SetSavedConversationArchived(ctx context.Context, conversationID string, archived bool) error
 }
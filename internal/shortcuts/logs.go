@@ -0,0 +1,74 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	config "github.com/inference-gateway/cli/config"
+	logview "github.com/inference-gateway/cli/internal/services/logview"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// logsShortcutLines is how many entries /logs shows
+const logsShortcutLines = 30
+
+// LogsShortcut shows the latest entries of the infer logs, so MCP and A2A
+// connection problems can be looked into without leaving the chat
+type LogsShortcut struct {
+	config *config.Config
+}
+
+// NewLogsShortcut creates the /logs shortcut
+func NewLogsShortcut(cfg *config.Config) *LogsShortcut {
+	return &LogsShortcut{config: cfg}
+}
+
+func (c *LogsShortcut) GetName() string { return "logs" }
+func (c *LogsShortcut) GetDescription() string {
+	return "Show recent log entries, filtered by level and component"
+}
+func (c *LogsShortcut) GetUsage() string              { return "/logs [debug|info|warn|error] [component...]" }
+func (c *LogsShortcut) CanExecute(args []string) bool { return true }
+
+// GetSubcommands offers the levels for autocomplete
+func (c *LogsShortcut) GetSubcommands() []Subcommand {
+	return []Subcommand{
+		{Name: "error", Description: "Errors only"},
+		{Name: "warn", Description: "Warnings and errors"},
+		{Name: "info", Description: "Info and above"},
+		{Name: "debug", Description: "Everything, when logging.debug is on"},
+	}
+}
+
+func (c *LogsShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	var filter logview.Filter
+	if len(args) > 0 && logview.ValidLevel(args[0]) {
+		filter.MinLevel = strings.ToLower(args[0])
+		args = args[1:]
+	}
+	filter.Components = args
+
+	dir := c.config.Logging.Dir
+	if dir == "" {
+		dir = config.DefaultLogsPath
+	}
+	entries, err := logview.Tail(dir, filter, logsShortcutLines)
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Failed to read the logs: %v", icons.StyledCrossMark(), err),
+			Success: false,
+		}, nil
+	}
+	if len(entries) == 0 {
+		return ShortcutResult{Output: fmt.Sprintf("No matching log entries in `%s`.", dir), Success: true}, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Logs (last %d from `%s`)\n\n```\n", len(entries), dir)
+	for _, e := range entries {
+		sb.WriteString(logview.Format(e) + "\n")
+	}
+	sb.WriteString("```\n\nFollow them with `infer logs -f`, see `infer logs --help` for time ranges.")
+	return ShortcutResult{Output: sb.String(), Success: true}, nil
+}
@@ -0,0 +1,44 @@
+package shortcuts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestLogsShortcut_FiltersByLevelAndComponent(t *testing.T) {
+	dir := t.TempDir()
+	lines := strings.Join([]string{
+		`{"level":"info","ts":1760601600,"caller":"services/mcp_manager.go:123","msg":"connected to MCP server"}`,
+		`{"level":"error","ts":1760605200,"caller":"tools/a2a_query_agent.go:88","msg":"agent unreachable"}`,
+		`{"level":"error","ts":1760608800,"caller":"services/mcp_manager.go:200","msg":"MCP server failed"}`,
+	}, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "app-2025-10-16.log"), []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{}
+	cfg.Logging.Dir = dir
+	s := NewLogsShortcut(cfg)
+
+	result, err := s.Execute(context.Background(), []string{"error", "mcp"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success || !strings.Contains(result.Output, "MCP server failed") {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	for _, unwanted := range []string{"connected to MCP server", "agent unreachable"} {
+		if strings.Contains(result.Output, unwanted) {
+			t.Errorf("output should not contain %q:\n%s", unwanted, result.Output)
+		}
+	}
+
+	result, _ = s.Execute(context.Background(), []string{"debug", "nothing-matches"})
+	if !result.Success || !strings.Contains(result.Output, "No matching log entries") {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
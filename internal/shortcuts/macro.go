@@ -0,0 +1,251 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// macrosFileName is where recorded macros are saved, inside the shortcuts
+// directory so they load like any other custom shortcut.
+const macrosFileName = "custom-macros.yaml"
+
+var macroNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// MacroData contains the inputs a macro replays, in order
+type MacroData struct {
+	Name  string
+	Steps []string
+}
+
+// MacroShortcut records the inputs submitted in the chat - messages, !bash and
+// !!tool commands, other shortcuts - and saves them as a custom shortcut with
+// steps, which replays them one at a time once the agent is idle.
+type MacroShortcut struct {
+	registry *Registry
+	baseDir  string
+
+	mutex       sync.Mutex
+	recording   string
+	description string
+	steps       []string
+}
+
+// NewMacroShortcut creates the /macro shortcut. Macros are saved under the
+// shortcuts/ directory of baseDir and registered in registry as they are saved.
+func NewMacroShortcut(registry *Registry, baseDir string) *MacroShortcut {
+	return &MacroShortcut{registry: registry, baseDir: baseDir}
+}
+
+func (c *MacroShortcut) GetName() string { return "macro" }
+func (c *MacroShortcut) GetDescription() string {
+	return "Record inputs as a replayable macro"
+}
+func (c *MacroShortcut) GetUsage() string {
+	return "/macro record <name> [description] | /macro stop | /macro cancel"
+}
+func (c *MacroShortcut) CanExecute(args []string) bool { return len(args) >= 1 }
+
+// GetSubcommands returns the list of subcommands for autocomplete
+func (c *MacroShortcut) GetSubcommands() []Subcommand {
+	return []Subcommand{
+		{Name: "record", Description: "Start recording a macro"},
+		{Name: "stop", Description: "Save the macro being recorded"},
+		{Name: "cancel", Description: "Discard the macro being recorded"},
+	}
+}
+
+func (c *MacroShortcut) Execute(_ context.Context, args []string) (ShortcutResult, error) {
+	switch args[0] {
+	case "record":
+		return c.startRecording(args[1:]), nil
+	case "stop":
+		return c.stopRecording(), nil
+	case "cancel":
+		return c.cancelRecording(), nil
+	default:
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Unknown subcommand '%s'. Usage: %s", icons.StyledCrossMark(), args[0], c.GetUsage()),
+			Success: false,
+		}, nil
+	}
+}
+
+// Record appends a submitted input to the macro being recorded. It does
+// nothing when not recording, and never records /macro itself.
+func (c *MacroShortcut) Record(input string) {
+	input = strings.TrimSpace(input)
+	if input == "" || input == "/macro" || strings.HasPrefix(input, "/macro ") {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.recording != "" {
+		c.steps = append(c.steps, input)
+	}
+}
+
+// Recording returns the name of the macro being recorded, or "" when idle.
+func (c *MacroShortcut) Recording() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.recording
+}
+
+func (c *MacroShortcut) startRecording(args []string) ShortcutResult {
+	if len(args) == 0 {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Usage: /macro record <name> [description]", icons.StyledCrossMark()),
+			Success: false,
+		}
+	}
+
+	name := args[0]
+	if !macroNameRe.MatchString(name) {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Invalid macro name '%s': use letters, digits, '-' and '_'", icons.StyledCrossMark(), name),
+			Success: false,
+		}
+	}
+	if existing, ok := c.registry.Get(name); ok && !isMacro(existing) {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s /%s is already a shortcut, pick another name", icons.StyledCrossMark(), name),
+			Success: false,
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.recording != "" {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Already recording macro '%s'. Use /macro stop or /macro cancel first", icons.StyledCrossMark(), c.recording),
+			Success: false,
+		}
+	}
+
+	c.recording = name
+	c.steps = nil
+	c.description = strings.Join(args[1:], " ")
+
+	return ShortcutResult{
+		Output:  fmt.Sprintf("%s Recording macro '%s'. Everything you send is recorded until /macro stop", icons.StyledCheckMark(), name),
+		Success: true,
+	}
+}
+
+func (c *MacroShortcut) stopRecording() ShortcutResult {
+	c.mutex.Lock()
+	name, steps, description := c.recording, c.steps, c.description
+	c.recording, c.steps, c.description = "", nil, ""
+	c.mutex.Unlock()
+
+	if name == "" {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Not recording a macro", icons.StyledCrossMark()),
+			Success: false,
+		}
+	}
+	if len(steps) == 0 {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Nothing was recorded, macro '%s' discarded", icons.StyledCrossMark(), name),
+			Success: false,
+		}
+	}
+
+	if description == "" {
+		description = fmt.Sprintf("Macro: %d recorded steps", len(steps))
+	}
+	macro := CustomShortcutConfig{Name: name, Description: description, Steps: steps}
+
+	path, err := c.saveMacro(macro)
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Failed to save macro '%s': %v", icons.StyledCrossMark(), name, err),
+			Success: false,
+		}
+	}
+	c.registry.Register(NewCustomShortcut(macro, nil, nil, nil, nil))
+
+	return ShortcutResult{
+		Output:  fmt.Sprintf("%s Saved macro /%s (%d steps) to %s", icons.StyledCheckMark(), name, len(steps), path),
+		Success: true,
+	}
+}
+
+func (c *MacroShortcut) cancelRecording() ShortcutResult {
+	c.mutex.Lock()
+	name := c.recording
+	c.recording, c.steps, c.description = "", nil, ""
+	c.mutex.Unlock()
+
+	if name == "" {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Not recording a macro", icons.StyledCrossMark()),
+			Success: false,
+		}
+	}
+	return ShortcutResult{
+		Output:  fmt.Sprintf("%s Discarded macro '%s'", icons.StyledCheckMark(), name),
+		Success: true,
+	}
+}
+
+// saveMacro writes macro into the macros file, replacing any earlier macro
+// with the same name, and returns the file's path.
+func (c *MacroShortcut) saveMacro(macro CustomShortcutConfig) (string, error) {
+	dir := filepath.Join(c.baseDir, "shortcuts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create shortcuts directory: %w", err)
+	}
+	path := filepath.Join(dir, macrosFileName)
+
+	var file CustomShortcutsConfig
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	case !os.IsNotExist(err):
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	replaced := false
+	for i := range file.Shortcuts {
+		if file.Shortcuts[i].Name == macro.Name {
+			file.Shortcuts[i] = macro
+			replaced = true
+		}
+	}
+	if !replaced {
+		file.Shortcuts = append(file.Shortcuts, macro)
+	}
+
+	out, err := yaml.Marshal(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode macros: %w", err)
+	}
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// isMacro reports whether shortcut is a custom shortcut made of steps, which a
+// new recording may overwrite.
+func isMacro(shortcut Shortcut) bool {
+	custom, ok := shortcut.(*CustomShortcut)
+	return ok && len(custom.config.Steps) > 0
+}
@@ -0,0 +1,124 @@
+package shortcuts
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMacroShortcut_RecordAndReplay(t *testing.T) {
+	baseDir := t.TempDir()
+	registry := NewRegistry()
+	macro := NewMacroShortcut(registry, baseDir)
+	registry.Register(macro)
+
+	result, _ := macro.Execute(context.Background(), []string{"record", "fix-tests", "Run", "tests", "and", "fix"})
+	if !result.Success {
+		t.Fatalf("expected recording to start, got %q", result.Output)
+	}
+	if got := macro.Recording(); got != "fix-tests" {
+		t.Fatalf("expected to be recording fix-tests, got %q", got)
+	}
+
+	steps := []string{"!go test ./...", "/copy", "Fix the failing tests"}
+	for _, step := range steps {
+		macro.Record(step)
+	}
+	macro.Record("   ")
+	macro.Record("/macro stop")
+
+	result, _ = macro.Execute(context.Background(), []string{"stop"})
+	if !result.Success {
+		t.Fatalf("expected macro to be saved, got %q", result.Output)
+	}
+	if macro.Recording() != "" {
+		t.Error("expected recording to end on stop")
+	}
+
+	saved, ok := registry.Get("fix-tests")
+	if !ok {
+		t.Fatal("expected saved macro to be registered")
+	}
+	if got := saved.GetDescription(); got != "Run tests and fix" {
+		t.Errorf("expected description from the record arguments, got %q", got)
+	}
+
+	replay, _ := saved.Execute(context.Background(), nil)
+	data, ok := replay.Data.(MacroData)
+	if replay.SideEffect != SideEffectRunMacro || !ok {
+		t.Fatalf("expected a macro side effect, got %#v", replay)
+	}
+	if !slices.Equal(data.Steps, steps) {
+		t.Errorf("expected steps %v, got %v", steps, data.Steps)
+	}
+
+	loaded, err := loadShortcutsFromFile(filepath.Join(baseDir, "shortcuts", macrosFileName), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("expected macros file to load: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].GetName() != "fix-tests" {
+		t.Fatalf("expected the saved macro in the macros file, got %v", loaded)
+	}
+}
+
+func TestMacroShortcut_RerecordReplacesMacro(t *testing.T) {
+	baseDir := t.TempDir()
+	registry := NewRegistry()
+	macro := NewMacroShortcut(registry, baseDir)
+
+	for _, step := range []string{"first", "second"} {
+		_, _ = macro.Execute(context.Background(), []string{"record", "flow"})
+		macro.Record(step)
+		_, _ = macro.Execute(context.Background(), []string{"stop"})
+	}
+
+	loaded, err := loadShortcutsFromFile(filepath.Join(baseDir, "shortcuts", macrosFileName), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("expected macros file to load: %v", err)
+	}
+	if len(loaded) != 1 {
+		t.Fatalf("expected re-recording to replace the macro, got %d macros", len(loaded))
+	}
+	replay, _ := loaded[0].Execute(context.Background(), nil)
+	if data := replay.Data.(MacroData); !slices.Equal(data.Steps, []string{"second"}) {
+		t.Errorf("expected the latest recording, got %v", data.Steps)
+	}
+}
+
+func TestMacroShortcut_RejectsInvalidRecordings(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(NewExitShortcut())
+	macro := NewMacroShortcut(registry, t.TempDir())
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing name", []string{"record"}},
+		{"invalid name", []string{"record", "../etc"}},
+		{"built-in shortcut name", []string{"record", "exit"}},
+		{"stop while idle", []string{"stop"}},
+		{"cancel while idle", []string{"cancel"}},
+		{"unknown subcommand", []string{"play"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := macro.Execute(context.Background(), tt.args)
+			if result.Success {
+				t.Errorf("expected failure, got %q", result.Output)
+			}
+			if macro.Recording() != "" {
+				t.Error("expected no recording to start")
+			}
+		})
+	}
+
+	_, _ = macro.Execute(context.Background(), []string{"record", "empty"})
+	if result, _ := macro.Execute(context.Background(), []string{"stop"}); result.Success {
+		t.Error("expected stopping an empty recording to fail")
+	}
+	if _, ok := registry.Get("empty"); ok {
+		t.Error("expected an empty recording not to be registered")
+	}
+}
@@ -0,0 +1,157 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CommandsDirName is the directory, inside .infer, holding the markdown
+// commands
+const CommandsDirName = "commands"
+
+// MarkdownCommandConfig is the frontmatter of a markdown command
+type MarkdownCommandConfig struct {
+	Description  string   `yaml:"description"`
+	ArgumentHint string   `yaml:"argument-hint"`
+	Model        string   `yaml:"model"`
+	Tools        []string `yaml:"tools"`
+}
+
+// PromptData is the prompt a markdown command sends, with the model and
+// tools it runs with; an empty Model keeps the current model and empty Tools
+// offers every tool
+type PromptData struct {
+	Prompt string
+	Model  string
+	Tools  []string
+}
+
+// MarkdownCommand is a user-defined slash command loaded from
+// .infer/commands/<name>.md: the file's body is a prompt template sent as the
+// user's message, with $ARGUMENTS replaced by everything typed after the
+// command and $1..$9 by the individual arguments
+type MarkdownCommand struct {
+	name   string
+	path   string
+	config MarkdownCommandConfig
+	body   string
+}
+
+var markdownCommandPositional = regexp.MustCompile(`\$([1-9])`)
+
+func (c *MarkdownCommand) GetName() string { return c.name }
+
+func (c *MarkdownCommand) GetDescription() string {
+	if c.config.Description != "" {
+		return c.config.Description
+	}
+	return "Run the prompt in " + filepath.Base(c.path)
+}
+
+func (c *MarkdownCommand) GetUsage() string {
+	if c.config.ArgumentHint != "" {
+		return fmt.Sprintf("/%s %s", c.name, c.config.ArgumentHint)
+	}
+	return "/" + c.name
+}
+
+func (c *MarkdownCommand) CanExecute(args []string) bool { return true }
+
+func (c *MarkdownCommand) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	prompt := c.Render(args)
+	if prompt == "" {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("Command /%s has an empty prompt in %s", c.name, c.path),
+			Success: false,
+		}, nil
+	}
+	return ShortcutResult{
+		Success:    true,
+		SideEffect: SideEffectSendPrompt,
+		Data:       PromptData{Prompt: prompt, Model: c.config.Model, Tools: c.config.Tools},
+	}, nil
+}
+
+// Render fills the prompt template with args. A template without $ARGUMENTS
+// or positional placeholders gets the arguments appended on their own line.
+func (c *MarkdownCommand) Render(args []string) string {
+	joined := strings.Join(args, " ")
+	hasPlaceholder := strings.Contains(c.body, "$ARGUMENTS") || markdownCommandPositional.MatchString(c.body)
+
+	prompt := strings.ReplaceAll(c.body, "$ARGUMENTS", joined)
+	prompt = markdownCommandPositional.ReplaceAllStringFunc(prompt, func(match string) string {
+		i, _ := strconv.Atoi(match[1:])
+		if i <= len(args) {
+			return args[i-1]
+		}
+		return ""
+	})
+	prompt = strings.TrimSpace(prompt)
+	if !hasPlaceholder && joined != "" {
+		prompt += "\n\n" + joined
+	}
+	return prompt
+}
+
+// LoadMarkdownCommands loads the *.md files of each dir's commands directory,
+// a later dir's command replacing an earlier one of the same name
+func LoadMarkdownCommands(dirs ...string) ([]*MarkdownCommand, error) {
+	byName := map[string]*MarkdownCommand{}
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, CommandsDirName, "*.md"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to glob markdown commands: %w", err)
+		}
+		for _, file := range files {
+			command, err := loadMarkdownCommand(file)
+			if err != nil {
+				fmt.Printf("Warning: failed to load command from %s: %v\n", file, err)
+				continue
+			}
+			byName[command.name] = command
+		}
+	}
+
+	commands := make([]*MarkdownCommand, 0, len(byName))
+	for _, command := range byName {
+		commands = append(commands, command)
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].name < commands[j].name })
+	return commands, nil
+}
+
+// loadMarkdownCommand reads one command file; its name is the file name
+// without the .md extension
+func loadMarkdownCommand(path string) (*MarkdownCommand, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return nil, fmt.Errorf("invalid command name %q", name)
+	}
+
+	command := &MarkdownCommand{name: name, path: path, body: string(data)}
+	content := strings.TrimLeft(string(data), "\ufeff")
+	if rest, ok := strings.CutPrefix(content, "---"); ok {
+		frontmatter, body, found := strings.Cut(rest, "\n---")
+		if !found {
+			return nil, fmt.Errorf("malformed frontmatter (expected closing `---` delimiter)")
+		}
+		if err := yaml.Unmarshal([]byte(frontmatter), &command.config); err != nil {
+			return nil, fmt.Errorf("invalid YAML in frontmatter: %w", err)
+		}
+		command.body = body
+	}
+	return command, nil
+}
@@ -0,0 +1,97 @@
+package shortcuts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCommand(t *testing.T, dir, name, content string) {
+	t.Helper()
+	commandsDir := filepath.Join(dir, CommandsDirName)
+	if err := os.MkdirAll(commandsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(commandsDir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadMarkdownCommands(t *testing.T) {
+	user, project := t.TempDir(), t.TempDir()
+	writeCommand(t, user, "review.md", "Review the diff.")
+	writeCommand(t, user, "explain.md", "Explain $ARGUMENTS")
+	writeCommand(t, project, "review.md", `---
+description: Review a pull request
+argument-hint: <pr> [focus]
+model: anthropic/claude-sonnet-4
+tools: [Read, Grep, Bash]
+---
+Review pull request #$1, focusing on $2.
+`)
+	writeCommand(t, project, "broken.md", "---\ndescription: no closing delimiter\n")
+	writeCommand(t, project, "notes.txt", "not a command")
+
+	commands, err := LoadMarkdownCommands(user, project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commands) != 2 || commands[0].GetName() != "explain" || commands[1].GetName() != "review" {
+		t.Fatalf("commands = %+v, want explain and the project's review", commands)
+	}
+
+	review := commands[1]
+	if review.GetDescription() != "Review a pull request" || review.GetUsage() != "/review <pr> [focus]" {
+		t.Errorf("description = %q, usage = %q", review.GetDescription(), review.GetUsage())
+	}
+	result, err := review.Execute(context.Background(), []string{"42", "security"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prompt, ok := result.Data.(PromptData)
+	if !ok || result.SideEffect != SideEffectSendPrompt {
+		t.Fatalf("result = %+v, want a send-prompt side effect", result)
+	}
+	if prompt.Prompt != "Review pull request #42, focusing on security." || prompt.Model != "anthropic/claude-sonnet-4" || strings.Join(prompt.Tools, ",") != "Read,Grep,Bash" {
+		t.Errorf("prompt data = %+v", prompt)
+	}
+}
+
+func TestMarkdownCommand_Render(t *testing.T) {
+	tests := []struct {
+		body string
+		args []string
+		want string
+	}{
+		{"Explain $ARGUMENTS in detail", []string{"the", "cache"}, "Explain the cache in detail"},
+		{"Compare $1 with $2", []string{"a.go"}, "Compare a.go with"},
+		{"Summarize the changes.", []string{"since", "v1"}, "Summarize the changes.\n\nsince v1"},
+		{"Summarize the changes.", nil, "Summarize the changes."},
+	}
+	for _, tt := range tests {
+		c := &MarkdownCommand{name: "x", body: tt.body}
+		if got := c.Render(tt.args); got != tt.want {
+			t.Errorf("Render(%q, %v) = %q, want %q", tt.body, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRegistry_MarkdownCommandsKeepBuiltins(t *testing.T) {
+	dir := t.TempDir()
+	writeCommand(t, dir, "exit.md", "Never leave.")
+	writeCommand(t, dir, "deploy.md", "Deploy $ARGUMENTS")
+
+	r := NewRegistry()
+	r.Register(NewExitShortcut())
+	if err := r.LoadMarkdownCommands(dir); err != nil {
+		t.Fatal(err)
+	}
+	if shortcut, _ := r.Get("exit"); shortcut.GetDescription() != "Exit the chat session" {
+		t.Error("a markdown command must not replace a built-in shortcut")
+	}
+	if _, ok := r.Get("deploy"); !ok {
+		t.Error("deploy command was not registered")
+	}
+}
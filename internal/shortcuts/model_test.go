@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	config "github.com/inference-gateway/cli/config"
 )
 
 // MockModelService for testing
@@ -48,7 +50,7 @@ func (m *mockModelService) ValidateModel(modelID string) error {
 
 func TestSwitchShortcut_GetName(t *testing.T) {
 	modelService := &mockModelService{}
-	shortcut := NewSwitchShortcut(modelService)
+	shortcut := NewSwitchShortcut(modelService, nil)
 
 	if shortcut.GetName() != "model" {
 		t.Errorf("Expected name 'model', got '%s'", shortcut.GetName())
@@ -57,9 +59,9 @@ func TestSwitchShortcut_GetName(t *testing.T) {
 
 func TestSwitchShortcut_GetUsage(t *testing.T) {
 	modelService := &mockModelService{}
-	shortcut := NewSwitchShortcut(modelService)
+	shortcut := NewSwitchShortcut(modelService, nil)
 
-	expected := "/model [model-name] [prompt]"
+	expected := "/model [model-name] [prompt] | /model params [name=value|default ...]"
 	if shortcut.GetUsage() != expected {
 		t.Errorf("Expected usage '%s', got '%s'", expected, shortcut.GetUsage())
 	}
@@ -67,7 +69,7 @@ func TestSwitchShortcut_GetUsage(t *testing.T) {
 
 func TestSwitchShortcut_CanExecute(t *testing.T) {
 	modelService := &mockModelService{}
-	shortcut := NewSwitchShortcut(modelService)
+	shortcut := NewSwitchShortcut(modelService, nil)
 
 	tests := []struct {
 		name string
@@ -94,7 +96,7 @@ func TestSwitchShortcut_Execute_NoArgs(t *testing.T) {
 		currentModel:    "claude-sonnet-4",
 		availableModels: []string{"claude-sonnet-4"},
 	}
-	shortcut := NewSwitchShortcut(modelService)
+	shortcut := NewSwitchShortcut(modelService, nil)
 
 	ctx := context.Background()
 	result, err := shortcut.Execute(ctx, []string{})
@@ -121,7 +123,7 @@ func TestSwitchShortcut_Execute_PermanentSwitch(t *testing.T) {
 		currentModel:    "claude-sonnet-4",
 		availableModels: []string{"claude-sonnet-4", "claude-opus-4"},
 	}
-	shortcut := NewSwitchShortcut(modelService)
+	shortcut := NewSwitchShortcut(modelService, nil)
 
 	ctx := context.Background()
 	result, err := shortcut.Execute(ctx, []string{"claude-opus-4"})
@@ -199,7 +201,7 @@ func TestSwitchShortcut_Execute_WithPrompt(t *testing.T) {
 				availableModels: []string{"claude-opus-4", "claude-sonnet-4"},
 				validateErr:     tt.validateErr,
 			}
-			shortcut := NewSwitchShortcut(modelService)
+			shortcut := NewSwitchShortcut(modelService, nil)
 
 			ctx := context.Background()
 			result, err := shortcut.Execute(ctx, tt.args)
@@ -263,3 +265,44 @@ func buildExpectedPrompt(args []string) string {
 	}
 	return expectedPrompt
 }
+
+func TestSwitchShortcut_Execute_Params(t *testing.T) {
+	cfg := &config.Config{}
+	shortcut := NewSwitchShortcut(&mockModelService{}, cfg)
+
+	result, err := shortcut.Execute(context.Background(), []string{"params", "temperature=0.2", "seed=7"})
+	if err != nil {
+		t.Fatalf("Execute() returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Execute() failed: %s", result.Output)
+	}
+	if result.SideEffect != SideEffectNone {
+		t.Errorf("/model params must not switch the model, got side effect %v", result.SideEffect)
+	}
+	if got := cfg.Agent.Sampling.Get("temperature"); got != "0.2" {
+		t.Errorf("temperature = %q, want 0.2", got)
+	}
+	if !strings.Contains(result.Output, "**seed**: 7") || !strings.Contains(result.Output, "**top_p**: provider default") {
+		t.Errorf("unexpected listing:\n%s", result.Output)
+	}
+
+	for _, args := range [][]string{
+		{"params", "temperature=3"},
+		{"params", "top_p=0.9", "tempreature=0.5"},
+		{"params", "seed"},
+	} {
+		result, _ := shortcut.Execute(context.Background(), args)
+		if result.Success {
+			t.Errorf("Execute(%v) should fail", args)
+		}
+	}
+	if cfg.Agent.Sampling.TopP != nil {
+		t.Error("a failed /model params must not apply any of its pairs")
+	}
+
+	result, _ = shortcut.Execute(context.Background(), []string{"params", "temperature=default"})
+	if !result.Success || cfg.Agent.Sampling.Temperature != nil {
+		t.Errorf("temperature=default should unset it, got %v", cfg.Agent.Sampling.Temperature)
+	}
+}
@@ -0,0 +1,112 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	config "github.com/inference-gateway/cli/config"
+	domain "github.com/inference-gateway/cli/internal/domain"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// personaDefault clears the active persona
+const personaDefault = "default"
+
+// PersonaShortcut lists the agent personas from agents.profiles and switches
+// the chat to one: its system prompt, model, tool allowlist and approval
+// policy apply from the next message on
+type PersonaShortcut struct {
+	config       *config.Config
+	modelService domain.ModelService
+}
+
+// NewPersonaShortcut creates the /persona shortcut
+func NewPersonaShortcut(cfg *config.Config, modelService domain.ModelService) *PersonaShortcut {
+	return &PersonaShortcut{config: cfg, modelService: modelService}
+}
+
+func (c *PersonaShortcut) GetName() string { return "persona" }
+func (c *PersonaShortcut) GetDescription() string {
+	return "List agent personas or switch to one"
+}
+func (c *PersonaShortcut) GetUsage() string              { return "/persona [name|default]" }
+func (c *PersonaShortcut) CanExecute(args []string) bool { return len(args) <= 1 }
+
+// GetSubcommands offers the persona names for autocomplete
+func (c *PersonaShortcut) GetSubcommands() []Subcommand {
+	names := c.config.PersonaNames()
+	subcommands := make([]Subcommand, 0, len(names)+1)
+	for _, name := range names {
+		subcommands = append(subcommands, Subcommand{Name: name, Description: c.config.Agents.Profiles[name].Description})
+	}
+	return append(subcommands, Subcommand{Name: personaDefault, Description: "Back to the configured prompt, model, tools and approvals"})
+}
+
+func (c *PersonaShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	if len(args) == 0 {
+		return ShortcutResult{Output: c.listPersonas(), Success: true}, nil
+	}
+
+	name := args[0]
+	if name == personaDefault {
+		name = ""
+	}
+	if err := c.config.ApplyPersona(name); err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s %v. Run /persona to list them", icons.StyledCrossMark(), err),
+			Success: false,
+		}, nil
+	}
+
+	if model := c.config.Agent.Model; model != "" && c.modelService != nil && model != c.modelService.GetCurrentModel() {
+		if err := c.modelService.SelectModel(model); err != nil {
+			return ShortcutResult{
+				Output:  fmt.Sprintf("%s Switched persona but could not select model %s: %v", icons.StyledCrossMark(), model, err),
+				Success: false,
+			}, nil
+		}
+	}
+
+	if name == "" {
+		return ShortcutResult{Output: "Cleared the persona; back to the configured agent settings", Success: true}, nil
+	}
+	return ShortcutResult{Output: fmt.Sprintf("%s Switched to the **%s** persona", icons.StyledCheckMark(), name), Success: true}, nil
+}
+
+func (c *PersonaShortcut) listPersonas() string {
+	names := c.config.PersonaNames()
+	if len(names) == 0 {
+		return "No personas configured. Add one under `agents.profiles.<name>` in config.yaml"
+	}
+
+	active := c.config.ActivePersona()
+	var sb strings.Builder
+	sb.WriteString("## Personas\n\n")
+	for _, name := range names {
+		profile := c.config.Agents.Profiles[name]
+		fmt.Fprintf(&sb, "- **%s**", name)
+		if name == active {
+			sb.WriteString(" (active)")
+		}
+		if profile.Description != "" {
+			sb.WriteString(" - " + profile.Description)
+		}
+		var details []string
+		if profile.Model != "" {
+			details = append(details, "model "+profile.Model)
+		}
+		if len(profile.Tools) > 0 {
+			details = append(details, "tools "+strings.Join(profile.Tools, ", "))
+		}
+		if profile.RequireApproval != nil {
+			details = append(details, fmt.Sprintf("approval %t", *profile.RequireApproval))
+		}
+		if len(details) > 0 {
+			sb.WriteString(" (" + strings.Join(details, "; ") + ")")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nSwitch with `/persona <name>`, clear with `/persona default`.")
+	return sb.String()
+}
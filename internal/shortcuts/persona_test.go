@@ -0,0 +1,47 @@
+package shortcuts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func TestPersonaShortcut_SwitchesAndClears(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Agent.Model = "openai/gpt-4o"
+	cfg.Agents.Profiles = map[string]config.AgentProfile{
+		"reviewer": {Description: "Reviews diffs", Model: "anthropic/claude-sonnet", Tools: []string{"Read", "Grep"}},
+	}
+	models := &mockModelService{currentModel: "openai/gpt-4o"}
+	s := NewPersonaShortcut(cfg, models)
+
+	result, err := s.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"**reviewer**", "Reviews diffs", "tools Read, Grep"} {
+		if !strings.Contains(result.Output, want) {
+			t.Errorf("list output missing %q:\n%s", want, result.Output)
+		}
+	}
+
+	if result, _ := s.Execute(context.Background(), []string{"reviewer"}); !result.Success {
+		t.Fatalf("switching failed: %+v", result)
+	}
+	if cfg.ActivePersona() != "reviewer" || models.currentModel != "anthropic/claude-sonnet" {
+		t.Errorf("persona = %q, model = %q", cfg.ActivePersona(), models.currentModel)
+	}
+
+	if result, _ := s.Execute(context.Background(), []string{"default"}); !result.Success {
+		t.Fatalf("clearing failed: %+v", result)
+	}
+	if cfg.ActivePersona() != "" || models.currentModel != "openai/gpt-4o" {
+		t.Errorf("clearing must restore the model; persona = %q, model = %q", cfg.ActivePersona(), models.currentModel)
+	}
+
+	if result, _ := s.Execute(context.Background(), []string{"missing"}); result.Success {
+		t.Error("an unknown persona must fail")
+	}
+}
@@ -0,0 +1,89 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	config "github.com/inference-gateway/cli/config"
+	prompttemplates "github.com/inference-gateway/cli/internal/services/prompttemplates"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// PromptShortcut fills the input with a prompt template from prompts.yaml,
+// its {{variables}} rendered from the built-in values and key=value args
+type PromptShortcut struct {
+	config *config.Config
+}
+
+// NewPromptShortcut creates the /prompt shortcut
+func NewPromptShortcut(cfg *config.Config) *PromptShortcut {
+	return &PromptShortcut{config: cfg}
+}
+
+func (c *PromptShortcut) GetName() string { return "prompt" }
+func (c *PromptShortcut) GetDescription() string {
+	return "Insert a prompt template from prompts.yaml"
+}
+func (c *PromptShortcut) GetUsage() string              { return "/prompt [name] [key=value...]" }
+func (c *PromptShortcut) CanExecute(args []string) bool { return true }
+
+// GetSubcommands offers the template names for autocomplete
+func (c *PromptShortcut) GetSubcommands() []Subcommand {
+	subcommands := make([]Subcommand, 0, len(c.config.Prompts.Templates))
+	for _, t := range c.config.Prompts.Templates {
+		subcommands = append(subcommands, Subcommand{Name: t.Name, Description: t.Description})
+	}
+	return subcommands
+}
+
+func (c *PromptShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	if len(args) == 0 {
+		return ShortcutResult{Output: c.listTemplates(), Success: true}, nil
+	}
+
+	tmpl, ok := prompttemplates.Find(c.config.Prompts.Templates, args[0])
+	if !ok {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Unknown prompt template '%s'. Run /prompt to list them", icons.StyledCrossMark(), args[0]),
+			Success: false,
+		}, nil
+	}
+
+	vars, err := prompttemplates.Vars(ctx, args[1:])
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s %v. Usage: %s", icons.StyledCrossMark(), err, c.GetUsage()),
+			Success: false,
+		}, nil
+	}
+
+	rendered, _ := prompttemplates.Render(tmpl.Prompt, vars)
+	return ShortcutResult{
+		Success:    true,
+		SideEffect: SideEffectSetInput,
+		Data:       rendered,
+	}, nil
+}
+
+func (c *PromptShortcut) listTemplates() string {
+	templates := c.config.Prompts.Templates
+	if len(templates) == 0 {
+		return "No prompt templates yet. Add one with `infer prompts add <name> --prompt \"...\"`"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Prompt Templates\n\n")
+	for _, t := range templates {
+		fmt.Fprintf(&sb, "- **%s**", t.Name)
+		if t.Description != "" {
+			sb.WriteString(" - " + t.Description)
+		}
+		if vars := prompttemplates.Variables(t.Prompt); len(vars) > 0 {
+			fmt.Fprintf(&sb, " (`%s`)", strings.Join(vars, "`, `"))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nUse `/prompt <name> key=value...`; unset variables stay as {{placeholders}} to fill in.")
+	return sb.String()
+}
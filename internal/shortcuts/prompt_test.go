@@ -0,0 +1,64 @@
+package shortcuts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+)
+
+func newTestPromptShortcut() *PromptShortcut {
+	cfg := &config.Config{}
+	cfg.Prompts.Templates = []config.PromptTemplate{
+		{Name: "migration", Description: "Plan a migration", Prompt: "Write a migration plan for {{file}} ({{ticket}})"},
+	}
+	return NewPromptShortcut(cfg)
+}
+
+func TestPromptShortcut_SetsRenderedInput(t *testing.T) {
+	s := newTestPromptShortcut()
+
+	result, err := s.Execute(context.Background(), []string{"migration", "file=db/schema.sql"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success || result.SideEffect != SideEffectSetInput {
+		t.Fatalf("expected a successful set-input result, got %+v", result)
+	}
+	if result.Data != "Write a migration plan for db/schema.sql ({{ticket}})" {
+		t.Errorf("Data = %q", result.Data)
+	}
+}
+
+func TestPromptShortcut_ListsTemplates(t *testing.T) {
+	s := newTestPromptShortcut()
+
+	result, err := s.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"**migration**", "Plan a migration", "`file`, `ticket`"} {
+		if !strings.Contains(result.Output, want) {
+			t.Errorf("Output missing %q:\n%s", want, result.Output)
+		}
+	}
+
+	if subs := s.GetSubcommands(); len(subs) != 1 || subs[0].Name != "migration" {
+		t.Errorf("GetSubcommands() = %+v", subs)
+	}
+}
+
+func TestPromptShortcut_Errors(t *testing.T) {
+	s := newTestPromptShortcut()
+
+	for _, args := range [][]string{{"missing"}, {"migration", "file"}} {
+		result, err := s.Execute(context.Background(), args)
+		if err != nil {
+			t.Fatalf("Execute(%v) error = %v", args, err)
+		}
+		if result.Success {
+			t.Errorf("Execute(%v) should fail, got %+v", args, result)
+		}
+	}
+}
@@ -42,6 +42,26 @@ func (r *Registry) LoadCustomShortcuts(baseDir string, client sdk.Client, modelS
 	return nil
 }
 
+// LoadMarkdownCommands registers the markdown commands of the given config
+// directories. A command never replaces a shortcut registered before it.
+func (r *Registry) LoadMarkdownCommands(dirs ...string) error {
+	commands, err := LoadMarkdownCommands(dirs...)
+	if err != nil {
+		return fmt.Errorf("failed to load markdown commands: %w", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, command := range commands {
+		if _, exists := r.shortcuts[command.GetName()]; exists {
+			fmt.Printf("Warning: command %s is shadowed by the /%s shortcut, skipping\n", command.path, command.GetName())
+			continue
+		}
+		r.shortcuts[command.GetName()] = command
+	}
+	return nil
+}
+
 // Register adds a shortcut to the registry
 func (r *Registry) Register(shortcut Shortcut) {
 	r.mutex.Lock()
@@ -0,0 +1,102 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	checkpoint "github.com/inference-gateway/cli/internal/services/checkpoint"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// maxRewindPromptLen truncates the prompts shown in the checkpoint list
+const maxRewindPromptLen = 80
+
+// RewindShortcut lists the workspace checkpoints taken before each turn and
+// restores the files and the conversation to one of them
+type RewindShortcut struct {
+	repo        domain.ConversationRepository
+	checkpoints *checkpoint.Store
+}
+
+// NewRewindShortcut creates the /rewind shortcut. A nil store means
+// checkpoints are off.
+func NewRewindShortcut(repo domain.ConversationRepository, checkpoints *checkpoint.Store) *RewindShortcut {
+	return &RewindShortcut{repo: repo, checkpoints: checkpoints}
+}
+
+func (r *RewindShortcut) GetName() string { return "rewind" }
+func (r *RewindShortcut) GetDescription() string {
+	return "List turn checkpoints or restore files and conversation to one"
+}
+func (r *RewindShortcut) GetUsage() string              { return "/rewind [turn]" }
+func (r *RewindShortcut) CanExecute(args []string) bool { return len(args) <= 1 }
+
+func (r *RewindShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	if r.checkpoints == nil {
+		return ShortcutResult{
+			Output:  "Checkpoints are off. Enable `chat.checkpoints` and run infer inside a git repository",
+			Success: false,
+		}, nil
+	}
+
+	conversationID := r.repo.GetCurrentConversationID()
+	if len(args) == 0 {
+		return ShortcutResult{Output: r.listCheckpoints(conversationID), Success: true}, nil
+	}
+
+	turn, err := strconv.Atoi(args[0])
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Invalid turn %q. Run /rewind to list the checkpoints", icons.StyledCrossMark(), args[0]),
+			Success: false,
+		}, nil
+	}
+
+	cp, err := r.checkpoints.Restore(ctx, conversationID, turn)
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Failed to rewind: %v", icons.StyledCrossMark(), err),
+			Success: false,
+		}, nil
+	}
+
+	if cp.Entries == 0 {
+		err = r.repo.Clear()
+	} else {
+		err = r.repo.DeleteMessagesAfterIndex(cp.Entries - 1)
+	}
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Restored the files but failed to rewind the conversation: %v", icons.StyledCrossMark(), err),
+			Success: false,
+		}, nil
+	}
+
+	return ShortcutResult{
+		Success:    true,
+		SideEffect: SideEffectRewindConversation,
+		Data:       cp,
+	}, nil
+}
+
+func (r *RewindShortcut) listCheckpoints(conversationID string) string {
+	checkpoints := r.checkpoints.List(conversationID)
+	if len(checkpoints) == 0 {
+		return "No checkpoints yet. One is taken before each message you send"
+	}
+
+	var b strings.Builder
+	b.WriteString("## Checkpoints\n\n")
+	for _, cp := range checkpoints {
+		prompt, _, _ := strings.Cut(strings.TrimSpace(cp.Prompt), "\n")
+		if runes := []rune(prompt); len(runes) > maxRewindPromptLen {
+			prompt = string(runes[:maxRewindPromptLen-3]) + "..."
+		}
+		fmt.Fprintf(&b, "- **%d** %s - %s\n", cp.Turn, cp.CreatedAt.Format("15:04:05"), prompt)
+	}
+	b.WriteString("\nRun `/rewind <turn>` to restore the files and conversation to just before that turn.")
+	return b.String()
+}
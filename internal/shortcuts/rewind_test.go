@@ -0,0 +1,89 @@
+package shortcuts
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	checkpoint "github.com/inference-gateway/cli/internal/services/checkpoint"
+	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
+)
+
+func TestRewindShortcut_Disabled(t *testing.T) {
+	sc := NewRewindShortcut(&domainmocks.FakeConversationRepository{}, nil)
+
+	res, err := sc.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if res.Success || !strings.Contains(res.Output, "chat.checkpoints") {
+		t.Errorf("disabled rewind = %+v", res)
+	}
+}
+
+func TestRewindShortcut_RestoresFilesAndConversation(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed; skipping git-backed test")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	file := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(file, []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := checkpoint.New(dir)
+	ctx := context.Background()
+	if _, err := store.Create(ctx, "conv-1", 0, "first"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := store.Create(ctx, "conv-1", 4, "second\nwith details"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := os.WriteFile(file, []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &domainmocks.FakeConversationRepository{}
+	repo.GetCurrentConversationIDReturns("conv-1")
+	sc := NewRewindShortcut(repo, store)
+
+	res, err := sc.Execute(ctx, nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"**1**", "first", "**2**", "second"} {
+		if !strings.Contains(res.Output, want) {
+			t.Errorf("list output missing %q:\n%s", want, res.Output)
+		}
+	}
+	if strings.Contains(res.Output, "with details") {
+		t.Errorf("list should show only the prompt's first line:\n%s", res.Output)
+	}
+
+	res, err = sc.Execute(ctx, []string{"2"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !res.Success || res.SideEffect != SideEffectRewindConversation {
+		t.Fatalf("rewind result = %+v", res)
+	}
+	if cp, ok := res.Data.(checkpoint.Checkpoint); !ok || cp.Turn != 2 {
+		t.Errorf("rewind data = %#v", res.Data)
+	}
+	if repo.DeleteMessagesAfterIndexCallCount() != 1 || repo.DeleteMessagesAfterIndexArgsForCall(0) != 3 {
+		t.Errorf("conversation should be cut after entry 3, calls = %d", repo.DeleteMessagesAfterIndexCallCount())
+	}
+	if got, _ := os.ReadFile(file); string(got) != "package main\n" {
+		t.Errorf("main.go = %q, want the checkpointed content", got)
+	}
+
+	if res, _ := sc.Execute(ctx, []string{"2"}); res.Success {
+		t.Error("a rewound checkpoint should be gone")
+	}
+}
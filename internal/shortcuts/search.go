@@ -0,0 +1,70 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// searchShortcutLimit caps how many conversations /search lists
+const searchShortcutLimit = 10
+
+// ConversationSearcher searches the stored conversations
+type ConversationSearcher interface {
+	SearchSavedConversations(ctx context.Context, query string, limit int) ([]domain.ConversationSearchHit, error)
+}
+
+// SearchShortcut full-text searches the message content of every stored
+// conversation, matching `infer history search`
+type SearchShortcut struct {
+	searcher ConversationSearcher
+}
+
+// NewSearchShortcut creates a new search shortcut
+func NewSearchShortcut(searcher ConversationSearcher) *SearchShortcut {
+	return &SearchShortcut{searcher: searcher}
+}
+
+func (s *SearchShortcut) GetName() string { return "search" }
+func (s *SearchShortcut) GetDescription() string {
+	return "Search the messages of all saved conversations"
+}
+func (s *SearchShortcut) GetUsage() string              { return "/search <text>" }
+func (s *SearchShortcut) CanExecute(args []string) bool { return len(args) > 0 }
+
+func (s *SearchShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	query := strings.Join(args, " ")
+	hits, err := s.searcher.SearchSavedConversations(ctx, query, searchShortcutLimit)
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("Failed to search conversations: %v", err),
+			Success: false,
+		}, nil
+	}
+
+	if len(hits) == 0 {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("No saved conversations mention %q.", query),
+			Success: true,
+		}, nil
+	}
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "## Conversations matching %q\n\n", query)
+	for _, hit := range hits {
+		fmt.Fprintf(&output, "**%s** (`%s`, %s, %d matching messages)\n",
+			hit.Title, hit.ConversationID, hit.UpdatedAt.Format("2006-01-02 15:04"), hit.Matches)
+		for _, snippet := range hit.Snippets {
+			fmt.Fprintf(&output, "- %s: %s\n", snippet.Role, snippet.Snippet)
+		}
+		output.WriteString("\n")
+	}
+	output.WriteString("Open one with /conversations, or `infer chat --resume <id>`.")
+
+	return ShortcutResult{
+		Output:  output.String(),
+		Success: true,
+	}, nil
+}
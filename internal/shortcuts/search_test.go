@@ -0,0 +1,60 @@
+package shortcuts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+type fakeConversationSearcher struct {
+	query string
+	hits  []domain.ConversationSearchHit
+	err   error
+}
+
+func (f *fakeConversationSearcher) SearchSavedConversations(_ context.Context, query string, _ int) ([]domain.ConversationSearchHit, error) {
+	f.query = query
+	return f.hits, f.err
+}
+
+func TestSearchShortcut(t *testing.T) {
+	searcher := &fakeConversationSearcher{hits: []domain.ConversationSearchHit{{
+		ConversationID: "abc-123",
+		Title:          "Database work",
+		UpdatedAt:      time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+		Matches:        1,
+		Snippets:       []domain.ConversationSearchSnippet{{Role: "user", Snippet: "write a Postgres migration"}},
+	}}}
+	s := NewSearchShortcut(searcher)
+
+	if s.CanExecute(nil) {
+		t.Error("/search needs a query")
+	}
+
+	res, err := s.Execute(context.Background(), []string{"postgres", "migration"})
+	if err != nil || !res.Success {
+		t.Fatalf("Execute: %+v, %v", res, err)
+	}
+	if searcher.query != "postgres migration" {
+		t.Errorf("searched for %q", searcher.query)
+	}
+	for _, want := range []string{"Database work", "abc-123", "user: write a Postgres migration", "infer chat --resume"} {
+		if !strings.Contains(res.Output, want) {
+			t.Errorf("output is missing %q:\n%s", want, res.Output)
+		}
+	}
+
+	searcher.hits = nil
+	if res, _ := s.Execute(context.Background(), []string{"nothing"}); !strings.Contains(res.Output, "No saved conversations") {
+		t.Errorf("unexpected output %q", res.Output)
+	}
+
+	searcher.err = errors.New("storage down")
+	if res, _ := s.Execute(context.Background(), []string{"x"}); res.Success {
+		t.Error("a failed search must not succeed")
+	}
+}
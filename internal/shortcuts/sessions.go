@@ -0,0 +1,106 @@
+package shortcuts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+	sessiongroups "github.com/inference-gateway/cli/internal/services/sessiongroups"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// SessionsShortcut lists the session groups and switches the chat to the
+// conversation a group resumes
+type SessionsShortcut struct {
+	groups        storage.SessionGroupStorage
+	conversations storage.ConversationStorage
+}
+
+// NewSessionsShortcut creates the /sessions shortcut
+func NewSessionsShortcut(groups storage.SessionGroupStorage, conversations storage.ConversationStorage) *SessionsShortcut {
+	return &SessionsShortcut{groups: groups, conversations: conversations}
+}
+
+func (c *SessionsShortcut) GetName() string { return "sessions" }
+func (c *SessionsShortcut) GetDescription() string {
+	return "List session groups or switch to one"
+}
+func (c *SessionsShortcut) GetUsage() string              { return "/sessions [group]" }
+func (c *SessionsShortcut) CanExecute(args []string) bool { return len(args) <= 1 }
+
+// GetSubcommands offers the group keys for autocomplete
+func (c *SessionsShortcut) GetSubcommands() []Subcommand {
+	groups, err := sessiongroups.List(context.Background(), c.groups)
+	if err != nil {
+		return nil
+	}
+	subcommands := make([]Subcommand, 0, len(groups))
+	for _, g := range groups {
+		subcommands = append(subcommands, Subcommand{
+			Name:        g.Key,
+			Description: conversationCount(g),
+		})
+	}
+	return subcommands
+}
+
+func (c *SessionsShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	if len(args) == 0 {
+		return c.listGroups(ctx), nil
+	}
+
+	g, err := sessiongroups.Get(ctx, c.groups, args[0])
+	if errors.Is(err, sessiongroups.ErrNotFound) {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Unknown session group '%s'. Run /sessions to list them", icons.StyledCrossMark(), args[0]),
+			Success: false,
+		}, nil
+	}
+	if err != nil {
+		return ShortcutResult{Output: fmt.Sprintf("%s %v", icons.StyledCrossMark(), err), Success: false}, nil
+	}
+
+	for _, id := range g.ConversationIDs() {
+		if _, _, err := c.conversations.LoadConversation(ctx, id); err == nil {
+			return ShortcutResult{Success: true, SideEffect: SideEffectLoadConversation, Data: id}, nil
+		}
+	}
+	return ShortcutResult{
+		Output:  fmt.Sprintf("Session group `%s` has no saved conversation yet. Start it with `infer chat --session-id %s`.", g.Key, g.Key),
+		Success: true,
+	}, nil
+}
+
+func (c *SessionsShortcut) listGroups(ctx context.Context) ShortcutResult {
+	groups, err := sessiongroups.List(ctx, c.groups)
+	if err != nil {
+		return ShortcutResult{Output: fmt.Sprintf("%s %v", icons.StyledCrossMark(), err), Success: false}
+	}
+	if len(groups) == 0 {
+		return ShortcutResult{
+			Output:  "No session groups yet. Create one with `infer sessions create <group>`",
+			Success: true,
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Session Groups\n\n")
+	for _, g := range groups {
+		fmt.Fprintf(&sb, "- **%s** - %s", g.Key, conversationCount(g))
+		if !g.UpdatedAt.IsZero() {
+			fmt.Fprintf(&sb, ", updated %s", g.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nSwitch with `/sessions <group>`, manage them with `infer sessions`.")
+	return ShortcutResult{Output: sb.String(), Success: true}
+}
+
+func conversationCount(g sessiongroups.Group) string {
+	if n := len(g.ConversationIDs()); n != 1 {
+		return fmt.Sprintf("%d conversations", n)
+	}
+	return "1 conversation"
+}
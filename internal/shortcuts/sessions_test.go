@@ -0,0 +1,64 @@
+package shortcuts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+func newTestSessionsShortcut(t *testing.T) *SessionsShortcut {
+	t.Helper()
+	mem := storage.NewMemoryStorage()
+	ctx := context.Background()
+	if err := mem.SaveConversation(ctx, "conv-a", nil, storage.ConversationMetadata{ID: "conv-a"}); err != nil {
+		t.Fatal(err)
+	}
+	groups := map[string]storage.SessionGroupEntry{
+		"research": {CurrentSessionID: "unsaved", History: []string{"conv-a"}},
+		"fresh":    {CurrentSessionID: "unsaved-too"},
+	}
+	for key, entry := range groups {
+		if err := mem.PutSessionGroup(ctx, key, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewSessionsShortcut(mem, mem)
+}
+
+func TestSessionsShortcut_ListsGroups(t *testing.T) {
+	s := newTestSessionsShortcut(t)
+
+	result, err := s.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"**research** - 2 conversations", "**fresh** - 1 conversation"} {
+		if !strings.Contains(result.Output, want) {
+			t.Errorf("Output missing %q:\n%s", want, result.Output)
+		}
+	}
+	if subs := s.GetSubcommands(); len(subs) != 2 {
+		t.Errorf("GetSubcommands() = %+v", subs)
+	}
+}
+
+func TestSessionsShortcut_SwitchesToNewestSavedConversation(t *testing.T) {
+	s := newTestSessionsShortcut(t)
+
+	result, _ := s.Execute(context.Background(), []string{"research"})
+	if !result.Success || result.SideEffect != SideEffectLoadConversation || result.Data != "conv-a" {
+		t.Fatalf("expected to load conv-a, got %+v", result)
+	}
+
+	result, _ = s.Execute(context.Background(), []string{"fresh"})
+	if result.SideEffect != SideEffectNone || !strings.Contains(result.Output, "no saved conversation") {
+		t.Errorf("unexpected result for a group without saved conversations: %+v", result)
+	}
+
+	result, _ = s.Execute(context.Background(), []string{"missing"})
+	if result.Success {
+		t.Errorf("expected an unknown group to fail, got %+v", result)
+	}
+}
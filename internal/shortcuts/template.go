@@ -0,0 +1,93 @@
+package shortcuts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	config "github.com/inference-gateway/cli/config"
+	prompttemplates "github.com/inference-gateway/cli/internal/services/prompttemplates"
+	icons "github.com/inference-gateway/cli/internal/ui/styles/icons"
+)
+
+// TemplateShortcut starts a new conversation from a conversation template in
+// prompts.yaml: its context is seeded, its model selected and its first
+// message, with the template's files attached, put in the input to review
+type TemplateShortcut struct {
+	config *config.Config
+}
+
+// NewTemplateShortcut creates the /template shortcut
+func NewTemplateShortcut(cfg *config.Config) *TemplateShortcut {
+	return &TemplateShortcut{config: cfg}
+}
+
+func (c *TemplateShortcut) GetName() string { return "template" }
+func (c *TemplateShortcut) GetDescription() string {
+	return "Start a new conversation from a template"
+}
+func (c *TemplateShortcut) GetUsage() string              { return "/template [name] [key=value...]" }
+func (c *TemplateShortcut) CanExecute(args []string) bool { return true }
+
+// GetSubcommands offers the template names for autocomplete
+func (c *TemplateShortcut) GetSubcommands() []Subcommand {
+	templates := c.config.Prompts.ConversationTemplates
+	subcommands := make([]Subcommand, 0, len(templates))
+	for _, t := range templates {
+		subcommands = append(subcommands, Subcommand{Name: t.Name, Description: t.Description})
+	}
+	return subcommands
+}
+
+func (c *TemplateShortcut) Execute(ctx context.Context, args []string) (ShortcutResult, error) {
+	if len(args) == 0 {
+		return ShortcutResult{Output: c.listTemplates(), Success: true}, nil
+	}
+
+	tmpl, ok := prompttemplates.FindConversation(c.config.Prompts.ConversationTemplates, args[0])
+	if !ok {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s Unknown conversation template '%s'. Run /template to list them", icons.StyledCrossMark(), args[0]),
+			Success: false,
+		}, nil
+	}
+
+	vars, err := prompttemplates.Vars(ctx, args[1:])
+	if err != nil {
+		return ShortcutResult{
+			Output:  fmt.Sprintf("%s %v. Usage: %s", icons.StyledCrossMark(), err, c.GetUsage()),
+			Success: false,
+		}, nil
+	}
+
+	return ShortcutResult{
+		Success:    true,
+		SideEffect: SideEffectStartFromTemplate,
+		Data:       prompttemplates.RenderConversation(tmpl, vars),
+	}, nil
+}
+
+func (c *TemplateShortcut) listTemplates() string {
+	templates := c.config.Prompts.ConversationTemplates
+	if len(templates) == 0 {
+		return "No conversation templates yet. Add them under `conversation_templates` in .infer/prompts.yaml"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Conversation Templates\n\n")
+	for _, t := range templates {
+		fmt.Fprintf(&sb, "- **%s**", t.Name)
+		if t.Description != "" {
+			sb.WriteString(" - " + t.Description)
+		}
+		if t.Model != "" {
+			fmt.Fprintf(&sb, " [%s]", t.Model)
+		}
+		if vars := prompttemplates.Variables(t.Context + "\n" + t.Message); len(vars) > 0 {
+			fmt.Fprintf(&sb, " (`%s`)", strings.Join(vars, "`, `"))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nUse `/template <name> key=value...` to start a new conversation from one.")
+	return sb.String()
+}
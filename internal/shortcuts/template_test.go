@@ -0,0 +1,78 @@
+package shortcuts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	config "github.com/inference-gateway/cli/config"
+	prompttemplates "github.com/inference-gateway/cli/internal/services/prompttemplates"
+)
+
+func newTestTemplateShortcut() *TemplateShortcut {
+	cfg := &config.Config{}
+	cfg.Prompts.ConversationTemplates = []config.ConversationTemplate{{
+		Name:        "bugfix",
+		Description: "Fix a reported bug",
+		Context:     "Reproduce {{issue}} with a failing test before changing code.",
+		Message:     "Fix {{issue}}",
+		Files:       []string{"CONTRIBUTING.md"},
+		Model:       "openai/gpt-4o",
+	}}
+	return NewTemplateShortcut(cfg)
+}
+
+func TestTemplateShortcut_StartsFromTemplate(t *testing.T) {
+	s := newTestTemplateShortcut()
+
+	result, err := s.Execute(context.Background(), []string{"bugfix", "issue=#42"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success || result.SideEffect != SideEffectStartFromTemplate {
+		t.Fatalf("expected a successful start-from-template result, got %+v", result)
+	}
+
+	seed, ok := result.Data.(prompttemplates.ConversationSeed)
+	if !ok {
+		t.Fatalf("Data = %T, want prompttemplates.ConversationSeed", result.Data)
+	}
+	if seed.Model != "openai/gpt-4o" || seed.Message != "Fix #42\n\n@CONTRIBUTING.md" {
+		t.Errorf("seed = %+v", seed)
+	}
+	if !strings.Contains(seed.Context, "Reproduce #42") {
+		t.Errorf("Context = %q", seed.Context)
+	}
+}
+
+func TestTemplateShortcut_ListsTemplates(t *testing.T) {
+	s := newTestTemplateShortcut()
+
+	result, err := s.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"**bugfix**", "Fix a reported bug", "[openai/gpt-4o]", "`issue`"} {
+		if !strings.Contains(result.Output, want) {
+			t.Errorf("Output missing %q:\n%s", want, result.Output)
+		}
+	}
+
+	if subs := s.GetSubcommands(); len(subs) != 1 || subs[0].Name != "bugfix" {
+		t.Errorf("GetSubcommands() = %+v", subs)
+	}
+}
+
+func TestTemplateShortcut_Errors(t *testing.T) {
+	s := newTestTemplateShortcut()
+
+	for _, args := range [][]string{{"missing"}, {"bugfix", "issue"}} {
+		result, err := s.Execute(context.Background(), args)
+		if err != nil {
+			t.Fatalf("Execute(%v) error = %v", args, err)
+		}
+		if result.Success {
+			t.Errorf("Execute(%v) should fail, got %+v", args, result)
+		}
+	}
+}
@@ -1,13 +1,15 @@
 // Package telemetry records and exports the CLI's OpenTelemetry metrics and
 // traces.
 //
-// Metrics: tool outcomes, token usage, and sessions, recorded into OTel SDK
+// Metrics: tool outcomes, token usage, gateway request and storage latency,
+// and sessions, recorded into OTel SDK
 // instruments named per the GenAI semantic conventions and infer-action's
 // exporter, so they line up with the gateway's OTLP ingest and existing
 // dashboards.
 //
-// Traces: one root span per session, child spans for each LLM turn and each
-// tool call. No prompt/response content is recorded.
+// Traces: one root span per session, child spans for each LLM turn, each
+// tool call, and each conversation storage call. No prompt/response content
+// is recorded.
 //
 // Both signals share the same resource and OTLP endpoint/headers config.
 // Local file export is always attempted; OTLP/HTTP export is opt-in via an
@@ -112,6 +114,8 @@ type Recorder struct {
 	runs         metric.Int64Counter     // infer.agent.runs
 	runDuration  metric.Float64Histogram // infer.agent.run.duration
 	costCounter  metric.Float64Counter   // infer.client.cost
+	opDuration   metric.Float64Histogram // gen_ai.client.operation.duration
+	dbDuration   metric.Float64Histogram // db.client.operation.duration
 
 	// Tracer provider (traces)
 	tracerProvider *sdktrace.TracerProvider
@@ -349,8 +353,16 @@ func (r *Recorder) initInstruments(meter metric.Meter) error {
 		metric.WithDescription("Agent session duration"), metric.WithUnit("s")); err != nil {
 		return err
 	}
-	r.costCounter, err = meter.Float64Counter("infer.client.cost",
-		metric.WithDescription("Estimated request cost in USD"), metric.WithUnit("USD"))
+	if r.costCounter, err = meter.Float64Counter("infer.client.cost",
+		metric.WithDescription("Estimated request cost in USD"), metric.WithUnit("USD")); err != nil {
+		return err
+	}
+	if r.opDuration, err = meter.Float64Histogram("gen_ai.client.operation.duration",
+		metric.WithDescription("Gateway chat request duration"), metric.WithUnit("s")); err != nil {
+		return err
+	}
+	r.dbDuration, err = meter.Float64Histogram("db.client.operation.duration",
+		metric.WithDescription("Conversation storage call duration"), metric.WithUnit("s"))
 	return err
 }
 
@@ -405,6 +417,39 @@ func (r *Recorder) RecordTool(tool, outcome, errType string, dur time.Duration)
 	r.toolDuration.Record(ctx, dur.Seconds(), metric.WithAttributes(r.withConv(durAttrs)...))
 }
 
+// RecordRequest records one gateway chat request (gen_ai.client.operation.duration).
+// errType is empty on success.
+func (r *Recorder) RecordRequest(model, errType string, dur time.Duration) {
+	if r == nil {
+		return
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("gen_ai.request.model", model),
+		attribute.String("gen_ai.provider.name", providerFromModel(model)),
+		attribute.String("gen_ai.operation.name", "chat"),
+	}
+	if errType != "" {
+		attrs = append(attrs, attribute.String("error.type", errType))
+	}
+	r.opDuration.Record(context.Background(), dur.Seconds(), metric.WithAttributes(r.withConv(attrs)...))
+}
+
+// RecordStorage records one conversation storage call (db.client.operation.duration).
+// errType is empty on success.
+func (r *Recorder) RecordStorage(system, operation, errType string, dur time.Duration) {
+	if r == nil {
+		return
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system.name", system),
+		attribute.String("db.operation.name", operation),
+	}
+	if errType != "" {
+		attrs = append(attrs, attribute.String("error.type", errType))
+	}
+	r.dbDuration.Record(context.Background(), dur.Seconds(), metric.WithAttributes(r.withConv(attrs)...))
+}
+
 // RecordSession records one completed agent session (infer.agent.runs +
 // infer.agent.run.duration). outcome is one of RunSuccess/RunFailed/RunStoppedEarly.
 func (r *Recorder) RecordSession(mode, outcome string, dur time.Duration) {
@@ -519,13 +564,14 @@ func (r *Recorder) SpanContext(ctx context.Context) context.Context {
 }
 
 // StartLLMTurnSpan creates a span for one LLM request with GenAI semconv
-// attributes and CLIENT kind (a remote call to the gateway). Safe on nil
+// attributes and CLIENT kind (a remote call to the gateway). Ending the span
+// also records the request's gen_ai.client.operation.duration. Safe on nil
 // (returns ctx unchanged and a no-op span).
 func (r *Recorder) StartLLMTurnSpan(ctx context.Context, model string) (context.Context, trace.Span) {
 	if r == nil {
 		return ctx, noop.Span{}
 	}
-	return r.Tracer().Start(ctx, "chat "+model,
+	ctx, span := r.Tracer().Start(ctx, "chat "+model,
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(
 			attribute.String("gen_ai.request.model", model),
@@ -534,6 +580,28 @@ func (r *Recorder) StartLLMTurnSpan(ctx context.Context, model string) (context.
 			attribute.String("gen_ai.conversation.id", r.sessionID),
 		),
 	)
+	return ctx, &llmTurnSpan{Span: span, rec: r, model: model, start: time.Now()}
+}
+
+// llmTurnSpan records the request duration metric when the turn span ends,
+// classified as failed when SetSpanError marked the span
+type llmTurnSpan struct {
+	trace.Span
+	rec   *Recorder
+	model string
+	start time.Time
+	once  sync.Once
+}
+
+func (s *llmTurnSpan) End(opts ...trace.SpanEndOption) {
+	s.once.Do(func() {
+		var errType string
+		if ro, ok := s.Span.(sdktrace.ReadOnlySpan); ok && ro.Status().Code == codes.Error {
+			errType = "_OTHER"
+		}
+		s.rec.RecordRequest(s.model, errType, time.Since(s.start))
+	})
+	s.Span.End(opts...)
 }
 
 // SetSpanUsage stamps token usage (gen_ai.usage.*) onto the span in ctx.
@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	attribute "go.opentelemetry.io/otel/attribute"
+	codes "go.opentelemetry.io/otel/codes"
+	trace "go.opentelemetry.io/otel/trace"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+// ErrTypeStorage is the error.type of a failed storage call
+const ErrTypeStorage = "storage_error"
+
+// conversationStorage decorates a storage.ConversationStorage, recording one
+// metric and one span per data call. Close and Health pass through unchanged.
+type conversationStorage struct {
+	storage.ConversationStorage
+	rec    *Recorder
+	system string
+}
+
+// NewConversationStorage wraps inner so storage calls are recorded. system is
+// the backend (db.system.name), e.g. "sqlite". Like NewToolService, the
+// container only applies this when rec is non-nil.
+func NewConversationStorage(inner storage.ConversationStorage, rec *Recorder, system string) storage.ConversationStorage {
+	return &conversationStorage{ConversationStorage: inner, rec: rec, system: system}
+}
+
+// observe runs one storage call under a CLIENT span and records its duration.
+// Calls made outside any span (autosave, title generation) parent to the
+// session root.
+func (s *conversationStorage) observe(ctx context.Context, operation string, call func(context.Context) error) {
+	start := time.Now()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = s.rec.SpanContext(ctx)
+	}
+	ctx, span := s.rec.Tracer().Start(ctx, operation+" "+s.system,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", s.system),
+			attribute.String("db.operation.name", operation),
+		),
+	)
+	defer span.End()
+
+	var errType string
+	if err := call(ctx); err != nil {
+		errType = ErrTypeStorage
+		span.SetAttributes(attribute.String("error.type", errType))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	s.rec.RecordStorage(s.system, operation, errType, time.Since(start))
+}
+
+func (s *conversationStorage) SaveConversation(ctx context.Context, conversationID string, entries []domain.ConversationEntry, metadata storage.ConversationMetadata) error {
+	var err error
+	s.observe(ctx, "save_conversation", func(ctx context.Context) error {
+		err = s.ConversationStorage.SaveConversation(ctx, conversationID, entries, metadata)
+		return err
+	})
+	return err
+}
+
+func (s *conversationStorage) LoadConversation(ctx context.Context, conversationID string) ([]domain.ConversationEntry, storage.ConversationMetadata, error) {
+	var (
+		entries  []domain.ConversationEntry
+		metadata storage.ConversationMetadata
+		err      error
+	)
+	s.observe(ctx, "load_conversation", func(ctx context.Context) error {
+		entries, metadata, err = s.ConversationStorage.LoadConversation(ctx, conversationID)
+		return err
+	})
+	return entries, metadata, err
+}
+
+func (s *conversationStorage) ListConversations(ctx context.Context, limit, offset int) ([]storage.ConversationSummary, error) {
+	var (
+		summaries []storage.ConversationSummary
+		err       error
+	)
+	s.observe(ctx, "list_conversations", func(ctx context.Context) error {
+		summaries, err = s.ConversationStorage.ListConversations(ctx, limit, offset)
+		return err
+	})
+	return summaries, err
+}
+
+func (s *conversationStorage) DeleteConversation(ctx context.Context, conversationID string) error {
+	var err error
+	s.observe(ctx, "delete_conversation", func(ctx context.Context) error {
+		err = s.ConversationStorage.DeleteConversation(ctx, conversationID)
+		return err
+	})
+	return err
+}
+
+func (s *conversationStorage) UpdateConversationMetadata(ctx context.Context, conversationID string, metadata storage.ConversationMetadata) error {
+	var err error
+	s.observe(ctx, "update_conversation_metadata", func(ctx context.Context) error {
+		err = s.ConversationStorage.UpdateConversationMetadata(ctx, conversationID, metadata)
+		return err
+	})
+	return err
+}
+
+func (s *conversationStorage) ListConversationsNeedingTitles(ctx context.Context, limit int) ([]storage.ConversationSummary, error) {
+	var (
+		summaries []storage.ConversationSummary
+		err       error
+	)
+	s.observe(ctx, "list_conversations_needing_titles", func(ctx context.Context) error {
+		summaries, err = s.ConversationStorage.ListConversationsNeedingTitles(ctx, limit)
+		return err
+	})
+	return summaries, err
+}
@@ -0,0 +1,67 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	storage "github.com/inference-gateway/cli/internal/infra/storage"
+)
+
+// TestConversationStorageSpansAndMetrics: storage calls get CLIENT spans
+// under the active span (or the session root outside one), failures are
+// marked, and both storage and gateway request durations reach the metric file.
+func TestConversationStorageSpansAndMetrics(t *testing.T) {
+	dir := t.TempDir()
+	rec := New(Options{Enabled: true, Dir: dir, SessionID: "sess-s"})
+	if rec == nil {
+		t.Fatal("expected a recorder when enabled")
+	}
+	store := NewConversationStorage(storage.NewMemoryStorage(), rec, "memory")
+
+	endSession := rec.StartSession("standard")
+	turnCtx, turnSpan := rec.StartLLMTurnSpan(rec.SpanContext(context.Background()), "openai/gpt-4o")
+	if err := store.SaveConversation(turnCtx, "conv-1", nil, storage.ConversationMetadata{ID: "conv-1"}); err != nil {
+		t.Fatalf("SaveConversation: %v", err)
+	}
+	SetSpanError(turnCtx, errors.New("gateway unavailable"))
+	turnSpan.End()
+	if _, _, err := store.LoadConversation(context.Background(), "missing"); err == nil {
+		t.Fatal("LoadConversation of a missing id must still return the inner error")
+	}
+	endSession(RunFailed)
+
+	rec.Shutdown(context.Background())
+
+	spans := readSpans(t, filepath.Join(dir, "sess-s-traces.jsonl"))
+	session, turn := spans["session"], spans["chat openai/gpt-4o"]
+
+	save := spans["save_conversation memory"]
+	if save.Parent.SpanID != turn.SpanContext.SpanID {
+		t.Fatalf("save parent=%s, want turn %s", save.Parent.SpanID, turn.SpanContext.SpanID)
+	}
+	if save.SpanKind != 3 || save.attr("db.system.name") != "memory" {
+		t.Fatalf("save span kind=%d system=%v, want CLIENT memory", save.SpanKind, save.attr("db.system.name"))
+	}
+
+	load := spans["load_conversation memory"]
+	if load.Parent.SpanID != session.SpanContext.SpanID {
+		t.Fatalf("load parent=%s, want session %s", load.Parent.SpanID, session.SpanContext.SpanID)
+	}
+	if got := load.attr("error.type"); got != ErrTypeStorage {
+		t.Fatalf("load error.type=%v, want %s", got, ErrTypeStorage)
+	}
+
+	metrics, err := os.ReadFile(filepath.Join(dir, "sess-s.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"db.client.operation.duration", "gen_ai.client.operation.duration", "_OTHER"} {
+		if !strings.Contains(string(metrics), name) {
+			t.Errorf("metric file missing %q", name)
+		}
+	}
+}
@@ -39,6 +39,10 @@ type ShortcutOption struct {
 	Shortcut    string
 	Description string
 	Usage       string
+
+	// matches holds the byte offsets of the query characters matched in the
+	// suggestion's name, for highlighting in the dropdown.
+	matches []int
 }
 
 // ShortcutRegistry interface for dependency injection
@@ -70,6 +74,8 @@ type AutocompleteImpl struct {
 	splicePrefix         string
 	spliceSuffix         string
 	lastCompletionCursor int
+	lastUsed             map[string]int
+	useClock             int
 }
 
 // NewAutocomplete creates a new autocomplete component
@@ -664,30 +670,11 @@ func (a *AutocompleteImpl) filterIssueSuggestions() {
 	}
 }
 
-// filterSuggestions filters commands based on current query
+// filterSuggestions fuzzy-matches the current query against the suggestion
+// names (so "/cmt" finds "/commit" and "!!wsrch" finds WebSearch) and ranks
+// the hits by match quality and how recently each was picked.
 func (a *AutocompleteImpl) filterSuggestions() {
-	a.filtered = []ShortcutOption{}
-
-	if a.query == "" {
-		a.filtered = a.suggestions
-		return
-	}
-
-	for _, cmd := range a.suggestions {
-		var commandName string
-		if name, found := strings.CutPrefix(cmd.Shortcut, "!!"); found {
-			commandName = name
-			if idx := strings.Index(commandName, "("); idx != -1 {
-				commandName = commandName[:idx]
-			}
-		} else {
-			commandName = strings.TrimPrefix(cmd.Shortcut, "/")
-		}
-
-		if strings.HasPrefix(strings.ToLower(commandName), strings.ToLower(a.query)) {
-			a.filtered = append(a.filtered, cmd)
-		}
-	}
+	a.filtered = a.rankSuggestions(a.query)
 }
 
 // HandleKey processes key input for autocomplete navigation
@@ -761,6 +748,7 @@ func (a *AutocompleteImpl) handleSelection() (bool, string) {
 	selected := a.filtered[a.selected].Shortcut
 	usage := a.filtered[a.selected].Usage
 	a.lastCompletionCursor = 0
+	a.recordUse(selected)
 
 	if a.completionMode == "issues" || a.completionMode == "skills-midtext" {
 		result, caret := a.spliceMidText(selected, a.splicePrefix, a.spliceSuffix)
@@ -955,7 +943,12 @@ func (a *AutocompleteImpl) renderItems(b *strings.Builder, start, end, maxShortc
 		displayText := a.getShortcutDisplayText(cmd)
 		displayText = strings.TrimPrefix(displayText, "!!")
 		displayText = formatting.TruncateText(displayText, maxShortcutWidth)
-		paddedShortcut := displayText + strings.Repeat(" ", maxShortcutWidth-len(displayText))
+		padding := strings.Repeat(" ", maxShortcutWidth-len(displayText))
+		baseColor := ""
+		if i == a.selected {
+			baseColor = a.theme.GetAccentColor()
+		}
+		paddedShortcut := a.highlightMatches(displayText, cmd, baseColor) + padding
 
 		description := formatting.TruncateText(cmd.Description, descWidth)
 		paddedDescription := description + strings.Repeat(" ", descWidth-len(description))
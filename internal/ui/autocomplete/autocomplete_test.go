@@ -724,3 +724,47 @@ func TestAutocomplete_TabCompletesWithoutSubmitting(t *testing.T) {
 			"a fully completed no-arg shortcut must not re-show the dropdown")
 	})
 }
+
+func TestAutocomplete_FuzzyMatchAndRecency(t *testing.T) {
+	var all []shortcuts.Shortcut
+	for _, name := range []string{"help", "clear", "compact", "exit"} {
+		s := &shortcutsmocks.FakeShortcut{}
+		s.GetNameReturns(name)
+		s.GetDescriptionReturns("Run " + name)
+		all = append(all, s)
+	}
+	mockRegistry := &uimocks.FakeShortcutRegistry{}
+	mockRegistry.GetAllReturns(all)
+
+	theme := &uimocks.FakeTheme{}
+	theme.GetDimColorReturns("#808080")
+	theme.GetAccentColorReturns("#FF00FF")
+
+	t.Run("query matches non-adjacent characters", func(t *testing.T) {
+		ac := autocomplete.NewAutocomplete(theme, mockRegistry)
+		ac.Update("/cpt", 4)
+		assert.True(t, ac.IsVisible())
+		assert.Equal(t, "/compact", ac.GetSelectedShortcut())
+	})
+
+	t.Run("matched characters are highlighted", func(t *testing.T) {
+		ac := autocomplete.NewAutocomplete(theme, mockRegistry)
+		ac.Update("/hp", 3)
+		out := ac.Render()
+		assert.Contains(t, out, "\033[1m#FF00FFh")
+		assert.Contains(t, out, "\033[1m#FF00FFp")
+	})
+
+	t.Run("recently used suggestions rank first", func(t *testing.T) {
+		ac := autocomplete.NewAutocomplete(theme, mockRegistry)
+		ac.Update("/", 1)
+		assert.Equal(t, "/help", ac.GetSelectedShortcut())
+
+		ac.Update("/ex", 3)
+		_, completion := ac.HandleKey(tea.KeyPressMsg{Code: tea.KeyTab})
+		assert.Equal(t, "/exit ", completion)
+
+		ac.Update("/", 1)
+		assert.Equal(t, "/exit", ac.GetSelectedShortcut())
+	})
+}
@@ -0,0 +1,129 @@
+package autocomplete
+
+import (
+	"sort"
+	"strings"
+
+	fuzzy "github.com/sahilm/fuzzy"
+
+	colors "github.com/inference-gateway/cli/internal/ui/styles/colors"
+)
+
+// maxRecencyBonus is the score boost given to the most recently selected
+// suggestion. Each later selection of something else lowers it by one, so a
+// handful of recent picks float up without drowning out a much better match.
+const maxRecencyBonus = 20
+
+// suggestionNames adapts the suggestion list to fuzzy.Source, matching on
+// the bare command name rather than the rendered shortcut.
+type suggestionNames []ShortcutOption
+
+func (s suggestionNames) String(i int) string { return matchName(s[i]) }
+func (s suggestionNames) Len() int            { return len(s) }
+
+// matchName returns the part of a suggestion the query is matched against:
+// "help" for "/help", "Read" for "!!Read(file_path=\"\")", and the model or
+// subcommand name as-is.
+func matchName(cmd ShortcutOption) string {
+	if name, found := strings.CutPrefix(cmd.Shortcut, "!!"); found {
+		if idx := strings.Index(name, "("); idx != -1 {
+			name = name[:idx]
+		}
+		return name
+	}
+	return strings.TrimPrefix(cmd.Shortcut, "/")
+}
+
+// rankSuggestions fuzzy-matches query against the suggestions and orders the
+// hits by match score plus a bonus for recent use. With an empty query every
+// suggestion is kept and only recency reorders them.
+func (a *AutocompleteImpl) rankSuggestions(query string) []ShortcutOption {
+	type ranked struct {
+		option ShortcutOption
+		score  int
+	}
+
+	var hits []ranked
+	if query == "" {
+		for _, cmd := range a.suggestions {
+			cmd.matches = nil
+			hits = append(hits, ranked{option: cmd, score: a.recencyBonus(cmd.Shortcut)})
+		}
+	} else {
+		for _, m := range fuzzy.FindFrom(query, suggestionNames(a.suggestions)) {
+			cmd := a.suggestions[m.Index]
+			cmd.matches = m.MatchedIndexes
+			hits = append(hits, ranked{option: cmd, score: m.Score + a.recencyBonus(cmd.Shortcut)})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	result := make([]ShortcutOption, len(hits))
+	for i, h := range hits {
+		result[i] = h.option
+	}
+	return result
+}
+
+// recordUse remembers that a suggestion was just picked so it ranks higher
+// the next time the dropdown opens.
+func (a *AutocompleteImpl) recordUse(shortcut string) {
+	if a.lastUsed == nil {
+		a.lastUsed = make(map[string]int)
+	}
+	a.useClock++
+	a.lastUsed[shortcut] = a.useClock
+}
+
+func (a *AutocompleteImpl) recencyBonus(shortcut string) int {
+	used, ok := a.lastUsed[shortcut]
+	if !ok {
+		return 0
+	}
+	return max(maxRecencyBonus-(a.useClock-used), 1)
+}
+
+// highlightMatches wraps the matched characters of displayText in the accent
+// color, restoring baseColor after each run. Match positions are relative to
+// the suggestion's match name, which is located inside the display text
+// ("/model <name>" shows the "model" shortcut, "/git commit" the "commit"
+// subcommand).
+func (a *AutocompleteImpl) highlightMatches(displayText string, cmd ShortcutOption, baseColor string) string {
+	if len(cmd.matches) == 0 {
+		return displayText
+	}
+
+	name := matchName(cmd)
+	offset := strings.Index(displayText, name)
+	if strings.HasSuffix(displayText, name) {
+		offset = len(displayText) - len(name)
+	}
+	if offset < 0 {
+		return displayText
+	}
+
+	marked := make(map[int]bool, len(cmd.matches))
+	for _, idx := range cmd.matches {
+		marked[offset+idx] = true
+	}
+
+	highlight := colors.Bold + a.theme.GetAccentColor()
+	var b strings.Builder
+	inRun := false
+	for i, r := range displayText {
+		switch {
+		case marked[i] && !inRun:
+			b.WriteString(highlight)
+			inRun = true
+		case !marked[i] && inRun:
+			b.WriteString(colors.Reset + baseColor)
+			inRun = false
+		}
+		b.WriteRune(r)
+	}
+	if inRun {
+		b.WriteString(colors.Reset + baseColor)
+	}
+	return b.String()
+}
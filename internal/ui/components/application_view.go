@@ -3,6 +3,8 @@ package components
 import (
 	"strings"
 
+	ansi "github.com/charmbracelet/x/ansi"
+
 	domain "github.com/inference-gateway/cli/internal/domain"
 	formatting "github.com/inference-gateway/cli/internal/formatting"
 	ui "github.com/inference-gateway/cli/internal/ui"
@@ -42,23 +44,28 @@ func (r *ApplicationViewRenderer) RenderChatInterface(
 	helpBar ui.HelpBarComponent,
 	queueBoxView *QueueBoxView,
 	todoBoxView *TodoBoxView,
+	pinnedBoxView *PinnedBoxView,
 	approvalBoxView *ApprovalBoxView,
 	questionFormView *QuestionFormView,
 	snippetAttachments *SnippetAttachmentsView,
+	sidePanel *SidePanelView,
 ) string {
 	width, height := data.Width, data.Height
 
-	heights := r.calculateComponentHeights(data, height, conversationView, helpBar, queueBoxView, todoBoxView, approvalBoxView, questionFormView, snippetAttachments)
+	heights := r.calculateComponentHeights(data, height, conversationView, helpBar, queueBoxView, todoBoxView, pinnedBoxView, approvalBoxView, questionFormView, snippetAttachments)
 
 	r.setComponentDimensions(width, conversationView, inputView, autocomplete, inputStatusBar, statusView,
-		modeIndicator, queueBoxView, todoBoxView, approvalBoxView, questionFormView, snippetAttachments, heights)
+		modeIndicator, queueBoxView, todoBoxView, pinnedBoxView, approvalBoxView, questionFormView, snippetAttachments, sidePanel, heights)
 
 	header := r.renderHeader(data, width)
-	conversationArea := conversationView.Render()
+	if cv, ok := conversationView.(*ConversationView); ok {
+		cv.SetScreenTop(strings.Count(header, "\n") + 2)
+	}
+	conversationArea := r.joinSidePanel(conversationView.Render(), sidePanel, width)
 	inputArea := inputView.Render()
 
 	components := r.assembleComponents(data, header, conversationArea, inputArea, conversationView, statusView, modeIndicator,
-		inputView, inputStatusBar, autocomplete, helpBar, queueBoxView, todoBoxView, approvalBoxView, questionFormView, snippetAttachments, width, heights.statusHeight)
+		inputView, inputStatusBar, autocomplete, helpBar, queueBoxView, todoBoxView, pinnedBoxView, approvalBoxView, questionFormView, snippetAttachments, width, heights.statusHeight)
 
 	return strings.Join(components, "\n")
 }
@@ -69,6 +76,7 @@ type componentHeights struct {
 	helpBarHeight        int
 	queueBoxHeight       int
 	todoBoxHeight        int
+	pinnedBoxHeight      int
 	approvalBoxHeight    int
 	questionBoxHeight    int
 	attachmentsHeight    int
@@ -86,6 +94,7 @@ func (r *ApplicationViewRenderer) calculateComponentHeights(
 	helpBar ui.HelpBarComponent,
 	queueBoxView *QueueBoxView,
 	todoBoxView *TodoBoxView,
+	pinnedBoxView *PinnedBoxView,
 	approvalBoxView *ApprovalBoxView,
 	questionFormView *QuestionFormView,
 	snippetAttachments *SnippetAttachmentsView,
@@ -110,10 +119,14 @@ func (r *ApplicationViewRenderer) calculateComponentHeights(
 		heights.queueBoxHeight = totalItems + 4
 	}
 
-	if todoBoxView != nil && todoBoxView.HasTodos() {
+	if todoBoxView != nil && todoBoxView.IsVisible() {
 		heights.todoBoxHeight = todoBoxView.GetHeight()
 	}
 
+	if pinnedBoxView != nil {
+		heights.pinnedBoxHeight = pinnedBoxView.GetHeight()
+	}
+
 	if snippetAttachments != nil {
 		heights.attachmentsHeight = snippetAttachments.GetHeight()
 	}
@@ -139,7 +152,7 @@ func (r *ApplicationViewRenderer) calculateComponentHeights(
 	}
 
 	adjustedHeight := totalHeight - heights.headerHeight - heights.helpBarHeight -
-		heights.queueBoxHeight - heights.todoBoxHeight - heights.approvalBoxHeight -
+		heights.queueBoxHeight - heights.todoBoxHeight - heights.pinnedBoxHeight - heights.approvalBoxHeight -
 		heights.questionBoxHeight - heights.attachmentsHeight - heights.backgroundTasksLines
 	heights.conversationHeight = ui.CalculateConversationHeight(adjustedHeight)
 	heights.inputHeight = ui.CalculateInputHeight(adjustedHeight)
@@ -163,12 +176,18 @@ func (r *ApplicationViewRenderer) setComponentDimensions(
 	modeIndicator *ModeIndicator,
 	queueBoxView *QueueBoxView,
 	todoBoxView *TodoBoxView,
+	pinnedBoxView *PinnedBoxView,
 	approvalBoxView *ApprovalBoxView,
 	questionFormView *QuestionFormView,
 	snippetAttachments *SnippetAttachmentsView,
+	sidePanel *SidePanelView,
 	heights componentHeights,
 ) {
 	conversationWidth := formatting.GetResponsiveWidth(width)
+	if panelWidth := sidePanelWidth(sidePanel, width); panelWidth > 0 {
+		conversationWidth = formatting.GetResponsiveWidth(width - panelWidth)
+		sidePanel.SetSize(panelWidth, heights.conversationHeight)
+	}
 
 	conversationView.SetWidth(conversationWidth)
 	conversationView.SetHeight(heights.conversationHeight)
@@ -194,6 +213,10 @@ func (r *ApplicationViewRenderer) setComponentDimensions(
 		todoBoxView.SetWidth(width)
 	}
 
+	if pinnedBoxView != nil {
+		pinnedBoxView.SetWidth(width)
+	}
+
 	if snippetAttachments != nil {
 		snippetAttachments.SetWidth(width)
 	}
@@ -207,6 +230,48 @@ func (r *ApplicationViewRenderer) setComponentDimensions(
 	}
 }
 
+// sidePanelWidth returns the columns the side panel takes, 0 when it is absent
+// or hidden
+func sidePanelWidth(sidePanel *SidePanelView, width int) int {
+	if sidePanel == nil {
+		return 0
+	}
+	return sidePanel.PanelWidth(width)
+}
+
+// joinSidePanel places the side panel to the right of the conversation, each
+// conversation line padded or clipped to the space the panel leaves.
+func (r *ApplicationViewRenderer) joinSidePanel(conversationArea string, sidePanel *SidePanelView, width int) string {
+	panelWidth := sidePanelWidth(sidePanel, width)
+	if panelWidth == 0 {
+		return conversationArea
+	}
+	panel := sidePanel.Render()
+	if panel == "" {
+		return conversationArea
+	}
+
+	leftWidth := width - panelWidth
+	left := strings.Split(conversationArea, "\n")
+	right := strings.Split(panel, "\n")
+
+	rows := make([]string, max(len(left), len(right)))
+	for i := range rows {
+		var line string
+		if i < len(left) {
+			line = ansi.Truncate(left[i], leftWidth, "")
+		}
+		if pad := leftWidth - ansi.StringWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		if i < len(right) {
+			line += right[i]
+		}
+		rows[i] = line
+	}
+	return strings.Join(rows, "\n")
+}
+
 // renderHeader renders the header section
 func (r *ApplicationViewRenderer) renderHeader(_ ChatInterfaceData, width int) string {
 	headerText := ""
@@ -227,6 +292,7 @@ func (r *ApplicationViewRenderer) assembleComponents(
 	helpBar ui.HelpBarComponent,
 	queueBoxView *QueueBoxView,
 	todoBoxView *TodoBoxView,
+	pinnedBoxView *PinnedBoxView,
 	approvalBoxView *ApprovalBoxView,
 	questionFormView *QuestionFormView,
 	snippetAttachments *SnippetAttachmentsView,
@@ -236,6 +302,7 @@ func (r *ApplicationViewRenderer) assembleComponents(
 
 	components = r.appendQueueBox(components, data, queueBoxView)
 	components = r.appendTodoBox(components, todoBoxView)
+	components = r.appendPinnedBox(components, pinnedBoxView)
 	components = r.appendBackgroundTaskBar(components, conversationView, width)
 	components = r.appendModeIndicator(components, modeIndicator)
 	components = r.appendStatusView(components, statusView, statusHeight)
@@ -269,7 +336,7 @@ func (r *ApplicationViewRenderer) appendTodoBox(
 	components []string,
 	todoBoxView *TodoBoxView,
 ) []string {
-	if todoBoxView != nil && todoBoxView.HasTodos() {
+	if todoBoxView != nil && todoBoxView.IsVisible() {
 		if todoBoxContent := todoBoxView.Render(); todoBoxContent != "" {
 			components = append(components, todoBoxContent)
 		}
@@ -277,6 +344,19 @@ func (r *ApplicationViewRenderer) appendTodoBox(
 	return components
 }
 
+// appendPinnedBox appends the pinned messages panel when any message is pinned
+func (r *ApplicationViewRenderer) appendPinnedBox(
+	components []string,
+	pinnedBoxView *PinnedBoxView,
+) []string {
+	if pinnedBoxView != nil {
+		if content := pinnedBoxView.Render(); content != "" {
+			components = append(components, content)
+		}
+	}
+	return components
+}
+
 // appendSnippetAttachments appends the snippet attachments tree (file + line
 // ranges) directly below the input when any snippet is pending.
 func (r *ApplicationViewRenderer) appendSnippetAttachments(
@@ -431,15 +511,5 @@ func (r *ApplicationViewRenderer) RenderFileSelection(
 
 // filterFiles filters files based on search query
 func (r *ApplicationViewRenderer) filterFiles(allFiles []string, searchQuery string) []string {
-	if searchQuery == "" {
-		return allFiles
-	}
-
-	var files []string
-	for _, file := range allFiles {
-		if strings.Contains(strings.ToLower(file), strings.ToLower(searchQuery)) {
-			files = append(files, file)
-		}
-	}
-	return files
+	return fuzzyFilterFiles(allFiles, searchQuery)
 }
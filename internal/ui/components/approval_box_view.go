@@ -63,6 +63,10 @@ type ApprovalBoxView struct {
 	// window. Both reset for each new approval.
 	expanded     bool
 	scrollOffset int
+
+	// review is the per-hunk review of an Edit/MultiEdit call, set while the
+	// user is accepting/rejecting individual hunks instead of the whole call.
+	review *hunkReview
 }
 
 // ToggleExpanded flips between the capped diff preview and the scrollable full-diff
@@ -164,14 +168,21 @@ func (av *ApprovalBoxView) Begin() tea.Cmd {
 	av.choice = domain.ApprovalApprove
 	av.expanded = false
 	av.scrollOffset = 0
+	av.review = nil
+
+	options := []huh.Option[domain.ApprovalAction]{
+		huh.NewOption("Approve", domain.ApprovalApprove),
+		huh.NewOption("Reject", domain.ApprovalReject),
+		huh.NewOption("Auto-Approve", domain.ApprovalAutoAccept),
+	}
+	if _, hunks := pendingHunks(state); hunks != nil {
+		options = append(options, huh.NewOption(fmt.Sprintf("Review Hunks (%d)", len(hunks)), approvalReviewHunks))
+	}
+
 	av.form = huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[domain.ApprovalAction]().
-				Options(
-					huh.NewOption("Approve", domain.ApprovalApprove),
-					huh.NewOption("Reject", domain.ApprovalReject),
-					huh.NewOption("Auto-Approve", domain.ApprovalAutoAccept),
-				).
+				Options(options...).
 				Inline(true).
 				Value(&av.choice),
 		),
@@ -189,15 +200,24 @@ func (av *ApprovalBoxView) Forward(msg tea.Msg) tea.Cmd {
 	if state == nil || state != av.active || av.form == nil {
 		av.active = nil
 		av.form = nil
+		av.review = nil
 		return nil
 	}
 
+	if av.review != nil {
+		return av.forwardHunkReview(state, msg)
+	}
+
 	model, cmd := av.form.Update(msg)
 	if f, ok := model.(*huh.Form); ok {
 		av.form = f
 	}
 
 	if av.form.State == huh.StateCompleted {
+		if av.choice == approvalReviewHunks {
+			av.startHunkReview(state)
+			return nil
+		}
 		action := av.choice
 		toolCall := *state.PendingToolCall
 		av.active = nil
@@ -216,6 +236,13 @@ func (av *ApprovalBoxView) Forward(msg tea.Msg) tea.Cmd {
 func (av *ApprovalBoxView) renderApprovalBox(state *domain.ApprovalUIState) string {
 	accentColor := av.styleProvider.GetThemeColor("accent")
 
+	if av.review != nil {
+		title := av.styleProvider.RenderWithColorAndBold(
+			fmt.Sprintf("Review hunks: %s", av.toolCallSummary(state.PendingToolCall)), accentColor)
+		content := strings.Join([]string{title, av.renderHunkReview()}, "\n")
+		return av.styleProvider.RenderBorderedBox(content, accentColor, 0, 1)
+	}
+
 	title := av.styleProvider.RenderWithColorAndBold("Approval required", accentColor)
 	body := av.renderBody(state.PendingToolCall)
 
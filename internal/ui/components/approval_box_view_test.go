@@ -405,3 +405,68 @@ func TestApprovalBox_EditDiffShowsFileContext(t *testing.T) {
 		}
 	}
 }
+
+// drainApprovalCmds runs cmd and feeds the resulting messages back through
+// Forward until a ToolApprovalResponseEvent appears or the chain ends.
+func drainApprovalCmds(av *ApprovalBoxView, cmd tea.Cmd) *domain.ToolApprovalResponseEvent {
+	for cmd != nil {
+		msg := cmd()
+		if ev, ok := msg.(domain.ToolApprovalResponseEvent); ok {
+			return &ev
+		}
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				if ev := drainApprovalCmds(av, c); ev != nil {
+					return ev
+				}
+			}
+			return nil
+		}
+		cmd = av.Forward(msg)
+	}
+	return nil
+}
+
+// TestApprovalBox_HunkReview walks the per-hunk flow: pick "Review Hunks",
+// reject the second hunk, and submit. The emitted approval carries a review
+// whose arguments contain only the first hunk.
+func TestApprovalBox_HunkReview(t *testing.T) {
+	args := `{"file_path":"/x/y.go","old_string":"a\nb\nc\nd\ne\n","new_string":"a\nB\nc\nd\nE\n"}`
+	sm := approvalStateManager(approvalStateWith("Edit", args))
+
+	av := NewApprovalBoxView(createMockStyleProvider(), sm, argsAwareToolFormatter{})
+	av.SetWidth(80)
+	_ = av.Begin()
+
+	for range 3 {
+		_ = av.Forward(tea.KeyPressMsg{Code: tea.KeyRight})
+	}
+	if ev := drainApprovalCmds(av, av.Forward(tea.KeyPressMsg{Code: tea.KeyEnter})); ev != nil {
+		t.Fatalf("choosing Review Hunks must not emit a response, got %+v", ev)
+	}
+	if !av.IsReviewingHunks() {
+		t.Fatal("expected the box to be in hunk review")
+	}
+	if out := av.Render(); !strings.Contains(out, "line 2: -1 +1") || !strings.Contains(out, "line 5: -1 +1") {
+		t.Errorf("expected both hunk summaries in the review, got:\n%s", out)
+	}
+
+	_ = av.Forward(tea.KeyPressMsg{Code: tea.KeyDown})
+	_ = av.Forward(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	_ = drainApprovalCmds(av, av.Forward(tea.KeyPressMsg{Code: tea.KeyEnter}))
+
+	ev := drainApprovalCmds(av, av.Forward(tea.KeyPressMsg{Code: tea.KeyEnter}))
+	if ev == nil || ev.HunkReview == nil {
+		t.Fatal("expected an approval carrying a hunk review")
+	}
+	if ev.Action != domain.ApprovalApprove {
+		t.Errorf("expected Approve, got %v", ev.Action)
+	}
+	review := ev.HunkReview
+	if review.Total != 2 || len(review.Accepted) != 1 || len(review.Rejected) != 1 || review.Rejected[0].Hunk != 2 {
+		t.Errorf("unexpected review %+v", review)
+	}
+	if !strings.Contains(review.Arguments, `"new_string":"a\nB\nc\nd\ne\n"`) {
+		t.Errorf("expected only the first hunk applied, got %s", review.Arguments)
+	}
+}
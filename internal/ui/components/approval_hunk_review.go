@@ -0,0 +1,235 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	huh "charm.land/huh/v2"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	logger "github.com/inference-gateway/cli/internal/logger"
+)
+
+// approvalReviewHunks is the action-select value for "Review Hunks". It never
+// leaves the approval box: choosing it switches the box into hunk review, and
+// the review is submitted as an ApprovalApprove carrying a HunkReview.
+const approvalReviewHunks domain.ApprovalAction = -1
+
+// maxHunkPreviewLines bounds the removed/added lines shown for the hunk under
+// the cursor so a large hunk doesn't push the list off-screen.
+const maxHunkPreviewLines = 12
+
+// hunkReview is the in-progress per-hunk review of an Edit/MultiEdit approval.
+// reasonForm is non-nil once the user has submitted their choices and is being
+// asked why each rejected hunk was declined.
+type hunkReview struct {
+	toolName   string
+	args       map[string]any
+	hunks      []EditHunk
+	accepted   []bool
+	reasons    []string
+	cursor     int
+	err        string
+	reasonForm *huh.Form
+}
+
+// pendingHunks returns the reviewable hunks of the pending call, or nil when
+// the call is not an Edit/MultiEdit or has a single hunk (nothing to split).
+func pendingHunks(state *domain.ApprovalUIState) (map[string]any, []EditHunk) {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(state.PendingToolCall.Function.Arguments), &args); err != nil {
+		return nil, nil
+	}
+	hunks := SplitEditHunks(state.PendingToolCall.Function.Name, args)
+	if len(hunks) < 2 {
+		return nil, nil
+	}
+	return args, hunks
+}
+
+// IsReviewingHunks reports whether the box is in per-hunk review, so the caller
+// routes every key here rather than just the action-select keys.
+func (av *ApprovalBoxView) IsReviewingHunks() bool {
+	return av.IsActive() && av.review != nil
+}
+
+// startHunkReview switches from the action select to per-hunk review with
+// every hunk initially accepted.
+func (av *ApprovalBoxView) startHunkReview(state *domain.ApprovalUIState) {
+	args, hunks := pendingHunks(state)
+	if hunks == nil {
+		return
+	}
+	av.review = &hunkReview{
+		toolName: state.PendingToolCall.Function.Name,
+		args:     args,
+		hunks:    hunks,
+		accepted: make([]bool, len(hunks)),
+		reasons:  make([]string, len(hunks)),
+	}
+	for i := range av.review.accepted {
+		av.review.accepted[i] = true
+	}
+}
+
+// forwardHunkReview handles input while reviewing hunks: up/down move, space
+// toggles the hunk, enter submits, and esc returns to the action select.
+func (av *ApprovalBoxView) forwardHunkReview(state *domain.ApprovalUIState, msg tea.Msg) tea.Cmd {
+	r := av.review
+	if r.reasonForm != nil {
+		return av.forwardReasonForm(state, msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if r.cursor > 0 {
+			r.cursor--
+		}
+	case "down", "j":
+		if r.cursor < len(r.hunks)-1 {
+			r.cursor++
+		}
+	case "space", " ", "x":
+		r.accepted[r.cursor] = !r.accepted[r.cursor]
+	case "esc":
+		av.review = nil
+		return av.Begin()
+	case "enter":
+		return av.submitHunkChoices(state)
+	}
+	return nil
+}
+
+// submitHunkChoices asks for rejection reasons when any hunk was rejected, and
+// otherwise emits the review straight away.
+func (av *ApprovalBoxView) submitHunkChoices(state *domain.ApprovalUIState) tea.Cmd {
+	r := av.review
+
+	var fields []huh.Field
+	for i, ok := range r.accepted {
+		if ok {
+			continue
+		}
+		fields = append(fields, huh.NewInput().
+			Title(fmt.Sprintf("Why reject hunk %d (%s)? Optional", i+1, r.hunks[i].Summary)).
+			Value(&r.reasons[i]))
+	}
+	if len(fields) == 0 {
+		return av.emitHunkReview(state)
+	}
+
+	r.reasonForm = huh.NewForm(huh.NewGroup(fields...)).
+		WithShowHelp(false).
+		WithWidth(av.summaryBudget()).
+		WithTheme(huhTheme(av.styleProvider))
+	return r.reasonForm.Init()
+}
+
+func (av *ApprovalBoxView) forwardReasonForm(state *domain.ApprovalUIState, msg tea.Msg) tea.Cmd {
+	r := av.review
+	model, cmd := r.reasonForm.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		r.reasonForm = f
+	}
+
+	switch r.reasonForm.State {
+	case huh.StateCompleted:
+		return av.emitHunkReview(state)
+	case huh.StateAborted:
+		r.reasonForm = nil
+		return nil
+	}
+	return cmd
+}
+
+// emitHunkReview rewrites the tool arguments down to the accepted hunks and
+// emits the approval with the review attached. All hunks rejected yields an
+// empty Arguments, which the agent records as a rejection.
+func (av *ApprovalBoxView) emitHunkReview(state *domain.ApprovalUIState) tea.Cmd {
+	r := av.review
+	arguments, err := ApplyEditHunks(r.toolName, r.args, r.hunks, r.accepted)
+	if err != nil {
+		logger.Warn("failed to apply accepted hunks", "tool", r.toolName, "error", err)
+		r.err = err.Error()
+		r.reasonForm = nil
+		return nil
+	}
+
+	review := domain.HunkReview{Total: len(r.hunks), Arguments: arguments}
+	for i, ok := range r.accepted {
+		if ok {
+			review.Accepted = append(review.Accepted, i+1)
+			continue
+		}
+		review.Rejected = append(review.Rejected, domain.RejectedHunk{
+			Hunk:    i + 1,
+			Summary: r.hunks[i].Summary,
+			Reason:  strings.TrimSpace(r.reasons[i]),
+		})
+	}
+
+	toolCall := *state.PendingToolCall
+	av.active = nil
+	av.form = nil
+	av.review = nil
+	return func() tea.Msg {
+		return domain.ToolApprovalResponseEvent{
+			Action:     domain.ApprovalApprove,
+			ToolCall:   toolCall,
+			HunkReview: &review,
+		}
+	}
+}
+
+// renderHunkReview renders the hunk checklist with the lines of the hunk under
+// the cursor, or the rejection-reason form once choices are submitted.
+func (av *ApprovalBoxView) renderHunkReview() string {
+	r := av.review
+	if r.reasonForm != nil {
+		return r.reasonForm.View()
+	}
+
+	lines := make([]string, 0, len(r.hunks)+maxHunkPreviewLines+2)
+	for i, h := range r.hunks {
+		mark := "[✓]"
+		if !r.accepted[i] {
+			mark = "[✗]"
+		}
+		lines = append(lines, av.styleProvider.RenderListItem(fmt.Sprintf("%s %d. %s", mark, i+1, h.Summary), i == r.cursor))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, av.renderHunkLines(r.hunks[r.cursor])...)
+
+	if r.err != "" {
+		lines = append(lines, av.styleProvider.RenderErrorText(r.err))
+	}
+	lines = append(lines, av.styleProvider.RenderDimText(
+		"↑/↓ select · space accept/reject · enter apply accepted · esc back"))
+	return strings.Join(lines, "\n")
+}
+
+func (av *ApprovalBoxView) renderHunkLines(h EditHunk) []string {
+	width := av.diffWidth() - 2
+	var lines []string
+	for _, l := range h.Removed {
+		lines = append(lines, av.styleProvider.RenderDiffRemoval(formatting.TruncateText(l, width)))
+	}
+	for _, l := range h.Added {
+		lines = append(lines, av.styleProvider.RenderDiffAddition(formatting.TruncateText(l, width)))
+	}
+	if len(lines) > maxHunkPreviewLines {
+		hidden := len(lines) - maxHunkPreviewLines
+		lines = append(lines[:maxHunkPreviewLines],
+			av.styleProvider.RenderDimText(fmt.Sprintf("… %d more lines", hidden)))
+	}
+	return lines
+}
@@ -0,0 +1,134 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+)
+
+// LatestCodeBlocks returns the fenced code blocks of the most recent visible
+// assistant message that has text content.
+func LatestCodeBlocks(conversation []domain.ConversationEntry) []formatting.CodeBlock {
+	for i := len(conversation) - 1; i >= 0; i-- {
+		entry := conversation[i]
+		if entry.Hidden || entry.Message.Role != sdk.Assistant {
+			continue
+		}
+		content, _ := entry.Message.Content.AsMessageContent0()
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		return formatting.ExtractCodeBlocks(content)
+	}
+	return nil
+}
+
+// EnterCodeBlockPicker replaces the conversation with a numbered list of code
+// blocks to copy and selects the last one.
+func (cv *ConversationView) EnterCodeBlockPicker(blocks []formatting.CodeBlock) {
+	if len(blocks) == 0 {
+		return
+	}
+	cv.EndSearch()
+	cv.codeBlockReturnOffset = cv.Viewport.YOffset()
+	cv.navigationMode = NavigationModeCodeBlocks
+	cv.codeBlocks = blocks
+	cv.codeBlockSelectedIndex = len(blocks) - 1
+	cv.updateCodeBlockView()
+}
+
+// IsInCodeBlockPicker returns true while the code block picker is shown
+func (cv *ConversationView) IsInCodeBlockPicker() bool {
+	return cv.navigationMode == NavigationModeCodeBlocks
+}
+
+// NavigateCodeBlockPicker moves the selection by delta rows, clamped to the list
+func (cv *ConversationView) NavigateCodeBlockPicker(delta int) {
+	if len(cv.codeBlocks) == 0 {
+		return
+	}
+	cv.codeBlockSelectedIndex = max(min(cv.codeBlockSelectedIndex+delta, len(cv.codeBlocks)-1), 0)
+	cv.updateCodeBlockView()
+}
+
+// SelectCodeBlock closes the picker and returns the block with the given
+// 1-based number, or the highlighted block when number is 0.
+func (cv *ConversationView) SelectCodeBlock(number int) (formatting.CodeBlock, bool) {
+	index := cv.codeBlockSelectedIndex
+	if number > 0 {
+		index = number - 1
+	}
+	if index < 0 || index >= len(cv.codeBlocks) {
+		return formatting.CodeBlock{}, false
+	}
+	block := cv.codeBlocks[index]
+	cv.ExitCodeBlockPicker()
+	return block, true
+}
+
+// ExitCodeBlockPicker closes the picker and returns to where the user was reading
+func (cv *ConversationView) ExitCodeBlockPicker() {
+	cv.navigationMode = NavigationModeNormal
+	cv.codeBlocks = nil
+	cv.codeBlockSelectedIndex = 0
+	cv.updateViewportContentFull()
+	if cv.userScrolledUp {
+		cv.Viewport.SetYOffset(cv.codeBlockReturnOffset)
+	}
+}
+
+func (cv *ConversationView) updateCodeBlockView() {
+	cv.Viewport.SetContent(cv.renderCodeBlockPicker())
+	cv.Viewport.GotoTop()
+}
+
+// renderCodeBlockPicker lists each block by number with its language, size,
+// and first line, in the same layout as the conversation outline.
+func (cv *ConversationView) renderCodeBlockPicker() string {
+	var b strings.Builder
+
+	b.WriteString(cv.styleProvider.RenderWithColor("Copy Code Block", cv.styleProvider.GetThemeColor("accent")))
+	b.WriteString("\n")
+	b.WriteString(cv.styleProvider.RenderDimText(
+		fmt.Sprintf("%d blocks · 1-9 or enter copy · ↑/↓ select · esc close", len(cv.codeBlocks))))
+	b.WriteString("\n\n")
+
+	maxVisible := max(cv.height-5, 5)
+	start, end := calculatePaginationBounds(cv.codeBlockSelectedIndex, len(cv.codeBlocks), maxVisible)
+
+	if start > 0 {
+		b.WriteString(cv.styleProvider.RenderDimText(fmt.Sprintf("  ... %d earlier blocks", start)))
+		b.WriteString("\n")
+	}
+
+	for i := start; i < end; i++ {
+		block := cv.codeBlocks[i]
+
+		language := block.Language
+		if language == "" {
+			language = "text"
+		}
+		lines := strings.Count(block.Code, "\n") + 1
+		prefix := fmt.Sprintf("%d. [%s, %d lines] ", i+1, language, lines)
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(block.Code), "\n")
+		label := formatting.TruncateText(strings.TrimSpace(firstLine), max(cv.width-len(prefix)-4, 20))
+
+		if i == cv.codeBlockSelectedIndex {
+			b.WriteString(cv.styleProvider.RenderWithColor("▶ "+prefix+label, cv.styleProvider.GetThemeColor("accent")))
+		} else {
+			b.WriteString(cv.styleProvider.RenderDimText("  " + prefix + label))
+		}
+		b.WriteString("\n")
+	}
+
+	if end < len(cv.codeBlocks) {
+		b.WriteString(cv.styleProvider.RenderDimText(fmt.Sprintf("  ... %d later blocks", len(cv.codeBlocks)-end)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package components
+
+import (
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+)
+
+func TestLatestCodeBlocks(t *testing.T) {
+	conversation := []domain.ConversationEntry{
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("```go\nold()\n```")}},
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("```\nfrom the user\n```")}},
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("```sh\nls\n```\n```sh\npwd\n```")}},
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("")}},
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("```\nhidden\n```")}, Hidden: true},
+	}
+
+	blocks := LatestCodeBlocks(conversation)
+	if len(blocks) != 2 || blocks[0].Code != "ls" || blocks[1].Code != "pwd" {
+		t.Fatalf("got %+v, want the two blocks of the latest visible assistant reply", blocks)
+	}
+}
+
+func TestConversationView_CodeBlockPicker(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetHeight(10)
+
+	blocks := []formatting.CodeBlock{{Language: "go", Code: "a()"}, {Code: "b"}, {Language: "sh", Code: "c\nd"}}
+	cv.EnterCodeBlockPicker(blocks)
+	if !cv.IsInCodeBlockPicker() {
+		t.Fatal("expected the code block picker to be open")
+	}
+
+	if _, ok := cv.SelectCodeBlock(4); ok || !cv.IsInCodeBlockPicker() {
+		t.Fatal("an out-of-range number should be ignored and keep the picker open")
+	}
+
+	cv.NavigateCodeBlockPicker(-1)
+	block, ok := cv.SelectCodeBlock(0)
+	if !ok || block.Code != "b" {
+		t.Fatalf("enter copied %+v, want the highlighted block", block)
+	}
+	if cv.IsInCodeBlockPicker() {
+		t.Fatal("expected the picker to close after selecting")
+	}
+
+	cv.EnterCodeBlockPicker(blocks)
+	if block, ok := cv.SelectCodeBlock(1); !ok || block.Code != "a()" {
+		t.Fatalf("number 1 copied %+v, want the first block", block)
+	}
+}
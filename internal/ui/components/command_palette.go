@@ -0,0 +1,258 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	key "charm.land/bubbles/v2/key"
+	textinput "charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	fuzzy "github.com/sahilm/fuzzy"
+
+	styles "github.com/inference-gateway/cli/internal/ui/styles"
+)
+
+// paletteChromeLines is the vertical space around the command list: title,
+// search input, blank separators and the footer hint.
+const paletteChromeLines = 6
+
+// PaletteCommand is a single runnable row in the command palette.
+type PaletteCommand struct {
+	// Category groups the row ("command", "display", "view", "toggle", ...).
+	Category string
+	Title    string
+	// Hint is shown dimmed on the right: the key that triggers the command
+	// directly, the slash command, or the current value of a toggle.
+	Hint string
+	// Run is invoked after the palette has closed and the chat view is active
+	// again, so keybinding handlers see the context they were written for.
+	Run func() tea.Cmd
+}
+
+// paletteMatch is a filtered row: the index into the command list and the
+// byte offsets of the query characters matched in its title.
+type paletteMatch struct {
+	index     int
+	positions []int
+}
+
+// paletteSource adapts the commands to fuzzy.Source, matching on the title,
+// category and hint. Titles come first so match offsets below len(Title) can
+// be highlighted directly.
+type paletteSource []PaletteCommand
+
+func (s paletteSource) String(i int) string {
+	return s[i].Title + " " + s[i].Category + " " + s[i].Hint
+}
+func (s paletteSource) Len() int { return len(s) }
+
+// CommandPaletteImpl is a searchable list of every action the chat can run -
+// shortcuts, keybinding actions, view transitions and config toggles - so
+// nothing needs to be memorized. Typing fuzzy-filters the list, enter picks
+// the highlighted row and esc closes the palette without running anything.
+type CommandPaletteImpl struct {
+	commands      []PaletteCommand
+	filtered      []paletteMatch
+	cursor        int
+	search        textinput.Model
+	width         int
+	height        int
+	styleProvider *styles.Provider
+	selected      *PaletteCommand
+	cancelled     bool
+}
+
+// NewCommandPalette creates an empty command palette. Commands are supplied
+// by SetCommands each time the palette opens.
+func NewCommandPalette(styleProvider *styles.Provider) *CommandPaletteImpl {
+	search := textinput.New()
+	search.Prompt = "> "
+	search.Placeholder = "Type to search commands"
+
+	return &CommandPaletteImpl{
+		search:        search,
+		width:         80,
+		height:        24,
+		styleProvider: styleProvider,
+	}
+}
+
+// SetCommands loads the rows to choose from and resets the query, cursor and
+// outcome so the palette can be reused.
+func (p *CommandPaletteImpl) SetCommands(commands []PaletteCommand) tea.Cmd {
+	p.commands = commands
+	p.selected = nil
+	p.cancelled = false
+	p.cursor = 0
+	p.search.SetValue("")
+	p.applyFilter()
+	return p.search.Focus()
+}
+
+func (p *CommandPaletteImpl) Init() tea.Cmd { return nil }
+
+func (p *CommandPaletteImpl) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.SetWidth(msg.Width)
+		p.SetHeight(msg.Height)
+		return p, nil
+	case tea.KeyPressMsg:
+		return p, p.handleKey(msg)
+	}
+
+	var cmd tea.Cmd
+	p.search, cmd = p.search.Update(msg)
+	return p, cmd
+}
+
+func (p *CommandPaletteImpl) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, commandPaletteKeys.cancel):
+		p.cancelled = true
+		return nil
+	case key.Matches(msg, commandPaletteKeys.navUp):
+		p.moveCursor(-1)
+		return nil
+	case key.Matches(msg, commandPaletteKeys.navDown):
+		p.moveCursor(1)
+		return nil
+	case key.Matches(msg, commandPaletteKeys.enter):
+		if p.cursor < len(p.filtered) {
+			cmd := p.commands[p.filtered[p.cursor].index]
+			p.selected = &cmd
+		}
+		return nil
+	}
+
+	before := p.search.Value()
+	var cmd tea.Cmd
+	p.search, cmd = p.search.Update(msg)
+	if p.search.Value() != before {
+		p.cursor = 0
+		p.applyFilter()
+	}
+	return cmd
+}
+
+// moveCursor moves the highlight by delta rows, wrapping around the list.
+func (p *CommandPaletteImpl) moveCursor(delta int) {
+	if len(p.filtered) == 0 {
+		return
+	}
+	p.cursor = (p.cursor + delta + len(p.filtered)) % len(p.filtered)
+}
+
+// applyFilter narrows the commands to those fuzzy-matching the query, best
+// match first. An empty query keeps the original order.
+func (p *CommandPaletteImpl) applyFilter() {
+	p.filtered = p.filtered[:0]
+	query := strings.TrimSpace(p.search.Value())
+	if query == "" {
+		for i := range p.commands {
+			p.filtered = append(p.filtered, paletteMatch{index: i})
+		}
+		return
+	}
+	for _, m := range fuzzy.FindFrom(query, paletteSource(p.commands)) {
+		p.filtered = append(p.filtered, paletteMatch{index: m.Index, positions: m.MatchedIndexes})
+	}
+}
+
+// Selected returns the command the user picked, if any.
+func (p *CommandPaletteImpl) Selected() (PaletteCommand, bool) {
+	if p.selected == nil {
+		return PaletteCommand{}, false
+	}
+	return *p.selected, true
+}
+
+// IsCancelled reports whether the user closed the palette without a pick.
+func (p *CommandPaletteImpl) IsCancelled() bool { return p.cancelled }
+
+// SetWidth sets the palette width.
+func (p *CommandPaletteImpl) SetWidth(width int) { p.width = width }
+
+// SetHeight sets the palette height.
+func (p *CommandPaletteImpl) SetHeight(height int) { p.height = height }
+
+func (p *CommandPaletteImpl) View() tea.View {
+	return tea.NewView(p.viewContent())
+}
+
+func (p *CommandPaletteImpl) viewContent() string {
+	var b strings.Builder
+
+	accent := p.styleProvider.GetThemeColor("accent")
+	b.WriteString(p.styleProvider.RenderWithColorAndBold("Command Palette", accent))
+	b.WriteString("\n")
+	b.WriteString(p.search.View())
+	b.WriteString("\n\n")
+
+	if len(p.filtered) == 0 {
+		b.WriteString(p.styleProvider.RenderDimText(fmt.Sprintf("  No commands match %q", p.search.Value())))
+	} else {
+		p.writeRows(&b)
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(p.styleProvider.RenderDimText(fmt.Sprintf("%d commands • ↑/↓ navigate • enter run • esc close", len(p.filtered))))
+	return b.String()
+}
+
+// writeRows renders the window of rows around the cursor.
+func (p *CommandPaletteImpl) writeRows(b *strings.Builder) {
+	visible := max(p.height-paletteChromeLines, 3)
+	start := 0
+	if p.cursor >= visible {
+		start = p.cursor - visible + 1
+	}
+	end := min(start+visible, len(p.filtered))
+
+	accent := p.styleProvider.GetThemeColor("accent")
+	for i := start; i < end; i++ {
+		m := p.filtered[i]
+		cmd := p.commands[m.index]
+		selected := i == p.cursor
+
+		marker := "  "
+		if selected {
+			marker = p.styleProvider.RenderWithColor("▌ ", accent)
+		}
+		left := marker + p.renderTitle(cmd.Title, m.positions, selected)
+
+		right := cmd.Category
+		if cmd.Hint != "" {
+			right += " · " + cmd.Hint
+		}
+		b.WriteString(p.styleProvider.PlaceHorizontal(p.width, left, p.styleProvider.RenderDimText(right+" ")))
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+}
+
+// renderTitle draws a title with its matched characters in bold accent; the
+// highlighted row is drawn entirely in the accent color.
+func (p *CommandPaletteImpl) renderTitle(title string, positions []int, selected bool) string {
+	accent := p.styleProvider.GetThemeColor("accent")
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		if pos < len(title) {
+			matched[pos] = true
+		}
+	}
+
+	var b strings.Builder
+	for i, r := range title {
+		switch {
+		case matched[i]:
+			b.WriteString(p.styleProvider.RenderWithColorAndBold(string(r), accent))
+		case selected:
+			b.WriteString(p.styleProvider.RenderWithColor(string(r), accent))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
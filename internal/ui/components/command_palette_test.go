@@ -0,0 +1,80 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
+	uimocks "github.com/inference-gateway/cli/tests/mocks/ui"
+
+	styles "github.com/inference-gateway/cli/internal/ui/styles"
+)
+
+func newCommandPaletteForTest(titles ...string) *CommandPaletteImpl {
+	fakeTheme := &uimocks.FakeTheme{}
+	fakeTheme.GetAccentColorReturns("#ff9e64")
+	fakeTheme.GetDimColorReturns("#888888")
+	themeService := &domainmocks.FakeThemeService{}
+	themeService.GetCurrentThemeReturns(fakeTheme)
+
+	commands := make([]PaletteCommand, len(titles))
+	for i, title := range titles {
+		commands[i] = PaletteCommand{Category: "command", Title: title}
+	}
+
+	p := NewCommandPalette(styles.NewProvider(themeService))
+	p.SetCommands(commands)
+	return p
+}
+
+func typeInto(p *CommandPaletteImpl, text string) {
+	for _, r := range text {
+		p.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+func TestCommandPalette_FuzzyFilterAndSelect(t *testing.T) {
+	p := newCommandPaletteForTest("Select a theme", "Expand/collapse thinking blocks", "Search the conversation")
+
+	if len(p.filtered) != 3 {
+		t.Fatalf("an empty query should list every command, got %d", len(p.filtered))
+	}
+
+	typeInto(p, "thnk")
+	if len(p.filtered) != 1 || p.commands[p.filtered[0].index].Title != "Expand/collapse thinking blocks" {
+		t.Fatalf("expected only the thinking toggle to match, got %+v", p.filtered)
+	}
+	if !strings.Contains(p.View().Content, "1 commands") {
+		t.Error("the footer should count the matches")
+	}
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	selected, ok := p.Selected()
+	if !ok || selected.Title != "Expand/collapse thinking blocks" {
+		t.Errorf("enter should pick the highlighted command, got %+v", selected)
+	}
+}
+
+func TestCommandPalette_NavigationAndCancel(t *testing.T) {
+	p := newCommandPaletteForTest("First", "Second")
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if p.cursor != 1 {
+		t.Errorf("up from the first row should wrap to the last, cursor = %d", p.cursor)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if !p.IsCancelled() {
+		t.Error("esc should close the palette")
+	}
+	if _, ok := p.Selected(); ok {
+		t.Error("a cancelled palette must not report a selection")
+	}
+
+	p.SetCommands(p.commands)
+	if p.IsCancelled() || p.cursor != 0 {
+		t.Error("SetCommands should reset the palette for reuse")
+	}
+}
@@ -85,6 +85,8 @@ var conversationSelectorKeys = struct {
 	enter     key.Binding
 	search    key.Binding
 	delete    key.Binding
+	tag       key.Binding
+	archive   key.Binding
 	backspace key.Binding
 	confirm   key.Binding
 	deny      key.Binding
@@ -93,6 +95,8 @@ var conversationSelectorKeys = struct {
 	enter:     key.NewBinding(key.WithKeys("enter")),
 	search:    key.NewBinding(key.WithKeys("/")),
 	delete:    key.NewBinding(key.WithKeys("d", "delete")),
+	tag:       key.NewBinding(key.WithKeys("t")),
+	archive:   key.NewBinding(key.WithKeys("a")),
 	backspace: key.NewBinding(key.WithKeys("backspace")),
 	confirm:   key.NewBinding(key.WithKeys("y", "Y")),
 	deny:      key.NewBinding(key.WithKeys("n", "N", "esc")),
@@ -106,6 +110,13 @@ var helpViewKeys = struct {
 	pgDown  key.Binding
 	top     key.Binding
 	bottom  key.Binding
+
+	search          key.Binding
+	acceptSearch    key.Binding
+	clearSearch     key.Binding
+	searchBackspace key.Binding
+	searchScroll    key.Binding
+	searchQuit      key.Binding
 }{
 	dismiss: key.NewBinding(key.WithKeys("esc", "q", "ctrl+c")),
 	navUp:   key.NewBinding(key.WithKeys("up", "k")),
@@ -114,6 +125,13 @@ var helpViewKeys = struct {
 	pgDown:  key.NewBinding(key.WithKeys("pgdown", "f")),
 	top:     key.NewBinding(key.WithKeys("home", "g")),
 	bottom:  key.NewBinding(key.WithKeys("end", "G")),
+
+	search:          key.NewBinding(key.WithKeys("/")),
+	acceptSearch:    key.NewBinding(key.WithKeys("enter")),
+	clearSearch:     key.NewBinding(key.WithKeys("esc")),
+	searchBackspace: key.NewBinding(key.WithKeys("backspace")),
+	searchScroll:    key.NewBinding(key.WithKeys("up", "down", "pgup", "pgdown")),
+	searchQuit:      key.NewBinding(key.WithKeys("ctrl+c")),
 }
 
 // listViewKeys is shared by a2a_agents, tools, and theme selection views.
@@ -194,3 +212,15 @@ var inputViewKeys = struct {
 		key.NewBinding(key.WithKeys("end")),
 	},
 }
+
+var commandPaletteKeys = struct {
+	cancel  key.Binding
+	enter   key.Binding
+	navUp   key.Binding
+	navDown key.Binding
+}{
+	cancel:  key.NewBinding(key.WithKeys("esc", "ctrl+c")),
+	enter:   key.NewBinding(key.WithKeys("enter")),
+	navUp:   key.NewBinding(key.WithKeys("up", "ctrl+p")),
+	navDown: key.NewBinding(key.WithKeys("down", "ctrl+n")),
+}
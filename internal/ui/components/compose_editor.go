@@ -0,0 +1,84 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// ComposeInEditor suspends the TUI and opens text in the user's editor
+// ($VISUAL/$EDITOR/vim) in a temporary markdown file, like git commit does.
+// When the editor exits the saved file replaces the input text.
+func ComposeInEditor(text string) tea.Cmd {
+	return openInEditor(text, "infer-message-*.md", readComposedMessage)
+}
+
+// EditPlanInEditor opens a plan awaiting approval in the user's editor. When
+// the editor exits the saved file replaces the pending plan.
+func EditPlanInEditor(plan string) tea.Cmd {
+	return openInEditor(plan, "infer-plan-*.md", readEditedPlan)
+}
+
+// openInEditor writes text to a temp file matching pattern, suspends the TUI
+// while the editor runs and hands the file to done once it exits
+func openInEditor(text, pattern string, done func(path string, runErr error) tea.Msg) tea.Cmd {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return composeError(err)
+	}
+	path := f.Name()
+	_, err = f.WriteString(text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return composeError(err)
+	}
+
+	editor := resolveEditor()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return done(path, err)
+	})
+}
+
+// readComposedMessage loads the edited file into the input and removes it.
+// The trailing newline editors add on save is dropped.
+func readComposedMessage(path string, runErr error) tea.Msg {
+	defer func() { _ = os.Remove(path) }()
+
+	if runErr != nil {
+		return domain.ShowErrorEvent{Error: fmt.Sprintf("Editor exited with an error: %v", runErr)}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to read the edited message: %v", err)}
+	}
+	return domain.SetInputEvent{Text: strings.TrimRight(string(data), "\r\n")}
+}
+
+// readEditedPlan loads the edited plan and removes the file
+func readEditedPlan(path string, runErr error) tea.Msg {
+	defer func() { _ = os.Remove(path) }()
+
+	if runErr != nil {
+		return domain.ShowErrorEvent{Error: fmt.Sprintf("Editor exited with an error: %v", runErr)}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to read the edited plan: %v", err)}
+	}
+	return domain.PlanEditedEvent{PlanContent: string(data)}
+}
+
+func composeError(err error) tea.Cmd {
+	return func() tea.Msg {
+		return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to open the editor: %v", err)}
+	}
+}
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestReadComposedMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message.md")
+	if err := os.WriteFile(path, []byte("Fix the parser:\n\n```go\nfunc main() {}\n```\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := readComposedMessage(path, nil).(domain.SetInputEvent)
+	if !ok {
+		t.Fatalf("expected SetInputEvent, got %T", msg)
+	}
+	if want := "Fix the parser:\n\n```go\nfunc main() {}\n```"; msg.Text != want {
+		t.Errorf("text = %q, want %q", msg.Text, want)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("the temporary file should be removed")
+	}
+
+	if _, ok := readComposedMessage(path, errors.New("exit status 1")).(domain.ShowErrorEvent); !ok {
+		t.Error("an editor failure should surface as an error")
+	}
+}
+
+func TestReadEditedPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(path, []byte("# Add auth\n\n1. Add middleware\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := readEditedPlan(path, nil).(domain.PlanEditedEvent)
+	if !ok {
+		t.Fatalf("expected PlanEditedEvent, got %T", msg)
+	}
+	if want := "# Add auth\n\n1. Add middleware\n"; msg.PlanContent != want {
+		t.Errorf("plan = %q, want %q", msg.PlanContent, want)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("the temporary file should be removed")
+	}
+}
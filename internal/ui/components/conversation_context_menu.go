@@ -0,0 +1,207 @@
+package components
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	ansi "github.com/charmbracelet/x/ansi"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+)
+
+// contextMenu is the menu opened by right-clicking a conversation entry. row
+// and col place its top-left corner in the lines Render returns.
+type contextMenu struct {
+	entryIndex int
+	actions    []domain.ConversationEntryAction
+	labels     []string
+	selected   int
+	row, col   int
+	width      int
+}
+
+// ConversationEntryText is the plain text of an entry, as the context menu
+// copies and quotes it.
+func ConversationEntryText(entry domain.ConversationEntry) string {
+	text, err := entry.Message.Content.AsMessageContent0()
+	if err != nil {
+		text = formatting.ExtractTextFromContent(entry.Message.Content, entry.Images)
+	}
+	return text
+}
+
+// contextMenuActions lists what the menu offers for an entry. Only entries
+// with text can be copied or quoted, and only tool results expanded or re-run.
+func contextMenuActions(entry domain.ConversationEntry) []domain.ConversationEntryAction {
+	hasText := entryHasText(entry)
+
+	var actions []domain.ConversationEntryAction
+	if hasText {
+		actions = append(actions, domain.EntryActionCopy)
+	}
+	if entry.Message.Role == sdk.Tool {
+		actions = append(actions, domain.EntryActionToggleExpand)
+	}
+	actions = append(actions, domain.EntryActionPin)
+	if hasText && (entry.Message.Role == sdk.User || entry.Message.Role == sdk.Assistant) {
+		actions = append(actions, domain.EntryActionQuoteReply)
+	}
+	if entry.ToolExecution != nil && entry.ToolExecution.ToolName != "" {
+		actions = append(actions, domain.EntryActionRerunTool)
+	}
+	return actions
+}
+
+// entryHasText reports whether the message itself carries text. An assistant
+// message with only tool calls has none, however its entry is rendered.
+func entryHasText(entry domain.ConversationEntry) bool {
+	if text, err := entry.Message.Content.AsMessageContent0(); err == nil {
+		return strings.TrimSpace(text) != ""
+	}
+	parts, err := entry.Message.Content.AsMessageContent1()
+	if err != nil {
+		return false
+	}
+	for _, part := range parts {
+		if textPart, err := part.AsTextContentPart(); err == nil && strings.TrimSpace(textPart.Text) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (cv *ConversationView) contextMenuLabel(action domain.ConversationEntryAction, index int) string {
+	switch action {
+	case domain.EntryActionCopy:
+		return "Copy"
+	case domain.EntryActionToggleExpand:
+		if cv.IsToolResultExpanded(index) {
+			return "Collapse tool result"
+		}
+		return "Expand tool result"
+	case domain.EntryActionPin:
+		if cv.conversation[index].Pinned {
+			return "Unpin"
+		}
+		return "Pin"
+	case domain.EntryActionQuoteReply:
+		return "Quote reply"
+	case domain.EntryActionRerunTool:
+		return "Re-run tool"
+	}
+	return ""
+}
+
+// IsContextMenuOpen reports whether an entry's context menu is showing
+func (cv *ConversationView) IsContextMenuOpen() bool {
+	return cv.contextMenu != nil
+}
+
+// CloseContextMenu dismisses the context menu without picking an action
+func (cv *ConversationView) CloseContextMenu() {
+	cv.contextMenu = nil
+}
+
+// MoveContextMenu moves the menu selection by delta, stopping at the ends
+func (cv *ConversationView) MoveContextMenu(delta int) {
+	if m := cv.contextMenu; m != nil {
+		m.selected = max(0, min(m.selected+delta, len(m.actions)-1))
+	}
+}
+
+// ConfirmContextMenu closes the menu and returns a command reporting the
+// selected action, or nil if the entry is gone.
+func (cv *ConversationView) ConfirmContextMenu() tea.Cmd {
+	m := cv.contextMenu
+	cv.contextMenu = nil
+	if m == nil || m.entryIndex >= len(cv.conversation) {
+		return nil
+	}
+
+	event := domain.ConversationEntryActionEvent{Action: m.actions[m.selected], EntryIndex: m.entryIndex}
+	return func() tea.Msg { return event }
+}
+
+// openContextMenu opens the menu for the entry at index with its corner at
+// the given row and column, moved up and left if it would not fit.
+func (cv *ConversationView) openContextMenu(index, row, col int) {
+	m := &contextMenu{entryIndex: index, actions: contextMenuActions(cv.conversation[index])}
+
+	labelWidth := 0
+	for _, action := range m.actions {
+		label := cv.contextMenuLabel(action, index)
+		m.labels = append(m.labels, label)
+		labelWidth = max(labelWidth, ansi.StringWidth(label))
+	}
+	for i, label := range m.labels {
+		m.labels[i] = label + strings.Repeat(" ", labelWidth-ansi.StringWidth(label))
+	}
+
+	// Border and padding on each side, plus the list item marker.
+	m.width = labelWidth + 6
+	height := len(m.actions) + 2
+	m.row = max(0, min(row, cv.Viewport.Height()-height))
+	m.col = max(0, min(col, cv.width+2-m.width))
+	cv.contextMenu = m
+}
+
+// entryAtLine returns the index of the entry drawn on a line of the
+// rendered conversation, or -1 past the last entry.
+func (cv *ConversationView) entryAtLine(line int) int {
+	for index, span := range cv.entryLineSpans() {
+		if line >= span[0] && line < span[0]+span[1] {
+			return index
+		}
+	}
+	return -1
+}
+
+// handleContextMenuClick opens the menu on the entry under a right-click.
+// While it is open, a left-click on an item picks it and a click anywhere
+// outside the menu closes it. Reports whether the click was used.
+func (cv *ConversationView) handleContextMenuClick(click tea.MouseClickMsg) (tea.Cmd, bool) {
+	if m := cv.contextMenu; m != nil {
+		item := click.Y - cv.screenTop - m.row - 1
+		if click.X < m.col || click.X >= m.col+m.width || item < -1 || item > len(m.actions) {
+			cv.CloseContextMenu()
+			return nil, true
+		}
+		if click.Button == tea.MouseLeft && item >= 0 && item < len(m.actions) {
+			m.selected = item
+			return cv.ConfirmContextMenu(), true
+		}
+		return nil, true
+	}
+
+	if click.Button != tea.MouseRight || cv.navigationMode != NavigationModeNormal {
+		return nil, false
+	}
+	row := click.Y - cv.screenTop
+	if row < 0 || row >= cv.Viewport.Height() {
+		return nil, false
+	}
+	index := cv.entryAtLine(cv.Viewport.YOffset() + row)
+	if index < 0 {
+		return nil, false
+	}
+	cv.openContextMenu(index, row, click.X)
+	return nil, true
+}
+
+// overlayContextMenu draws the open menu over the lines Render produced
+func (cv *ConversationView) overlayContextMenu(lines []string) []string {
+	m := cv.contextMenu
+	if m == nil || cv.styleProvider == nil {
+		return lines
+	}
+
+	items := make([]string, len(m.labels))
+	for i, label := range m.labels {
+		items[i] = cv.styleProvider.RenderListItem(label, i == m.selected)
+	}
+	box := cv.styleProvider.RenderBorderedBox(strings.Join(items, "\n"), cv.styleProvider.GetThemeColor("accent"), 0, 1)
+
+	return overlayBlock(lines, box, m.row, m.col)
+}
@@ -0,0 +1,93 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	ansi "github.com/charmbracelet/x/ansi"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestContextMenuActions(t *testing.T) {
+	user := domain.ConversationEntry{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("hi")}}
+	tool := domain.ConversationEntry{
+		Message:       sdk.Message{Role: sdk.Tool, Content: sdk.NewMessageContent("ok")},
+		ToolExecution: &domain.ToolExecutionResult{ToolName: "Read", Success: true},
+	}
+	toolCalls := toolCallEntry("Read", `{}`)
+
+	cases := []struct {
+		name  string
+		entry domain.ConversationEntry
+		want  []domain.ConversationEntryAction
+	}{
+		{"user message", user, []domain.ConversationEntryAction{domain.EntryActionCopy, domain.EntryActionPin, domain.EntryActionQuoteReply}},
+		{"tool result", tool, []domain.ConversationEntryAction{domain.EntryActionCopy, domain.EntryActionToggleExpand, domain.EntryActionPin, domain.EntryActionRerunTool}},
+		{"tool calls without text", toolCalls, []domain.ConversationEntryAction{domain.EntryActionPin}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := contextMenuActions(tc.entry)
+			if len(got) != len(tc.want) {
+				t.Fatalf("contextMenuActions() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("contextMenuActions() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestConversationView_ContextMenu(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetWidth(60)
+	cv.SetHeight(10)
+	cv.SetScreenTop(2)
+	cv.SetConversation([]domain.ConversationEntry{
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("hello there")}, Time: time.Now()},
+	})
+
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: 5, Y: 2})
+	if cv.IsContextMenuOpen() {
+		t.Fatal("a left-click should not open the menu")
+	}
+
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseRight, X: 5, Y: 2})
+	if !cv.IsContextMenuOpen() {
+		t.Fatal("a right-click on an entry should open its menu")
+	}
+	rendered := ansi.Strip(cv.Render())
+	for _, label := range []string{"Copy", "Pin", "Quote reply"} {
+		if !strings.Contains(rendered, label) {
+			t.Errorf("menu should offer %q:\n%s", label, rendered)
+		}
+	}
+
+	cv.MoveContextMenu(5)
+	event, ok := cv.ConfirmContextMenu()().(domain.ConversationEntryActionEvent)
+	if !ok || event.Action != domain.EntryActionQuoteReply || event.EntryIndex != 0 {
+		t.Errorf("confirm = %#v, want quote reply on entry 0", event)
+	}
+	if cv.IsContextMenuOpen() {
+		t.Error("confirming should close the menu")
+	}
+
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseRight, X: 5, Y: 2})
+	m := cv.contextMenu
+	_, cmd := cv.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: m.col + 2, Y: cv.screenTop + m.row + 1})
+	if event, _ := cmd().(domain.ConversationEntryActionEvent); event.Action != domain.EntryActionCopy {
+		t.Errorf("clicking the first item should pick Copy, got %#v", event)
+	}
+
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseRight, X: 5, Y: 2})
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: 59, Y: 11})
+	if cv.IsContextMenuOpen() {
+		t.Error("a click outside the menu should close it")
+	}
+}
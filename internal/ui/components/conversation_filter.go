@@ -0,0 +1,127 @@
+package components
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// conversationFilter is a parsed conversation selector query. Plain words
+// must all appear in the title, summary, model or tags; qualifiers narrow
+// the list further:
+//
+//	tag:<name>     has the tag (repeatable)
+//	model:<text>   model name contains text
+//	since:<when>   updated on or after when
+//	before:<when>  updated before when
+//	is:archived    archived conversations, which are otherwise hidden
+//
+// where <when> is a date (2006-01-02) or an age such as 12h, 7d or 2w.
+type conversationFilter struct {
+	terms    []string
+	tags     []string
+	model    string
+	since    time.Time
+	before   time.Time
+	archived bool
+}
+
+func parseConversationFilter(query string, now time.Time) conversationFilter {
+	var f conversationFilter
+	for _, field := range strings.Fields(strings.ToLower(query)) {
+		name, value, found := strings.Cut(field, ":")
+		if !found || value == "" {
+			f.terms = append(f.terms, field)
+			continue
+		}
+
+		switch name {
+		case "tag":
+			f.tags = append(f.tags, value)
+		case "model":
+			f.model = value
+		case "is":
+			if value != "archived" {
+				f.terms = append(f.terms, field)
+				continue
+			}
+			f.archived = true
+		case "since", "before":
+			when, ok := parseFilterTime(value, now)
+			if !ok {
+				f.terms = append(f.terms, field)
+				continue
+			}
+			if name == "since" {
+				f.since = when
+			} else {
+				f.before = when
+			}
+		default:
+			f.terms = append(f.terms, field)
+		}
+	}
+	return f
+}
+
+// parseFilterTime reads a date or an age relative to now.
+func parseFilterTime(value string, now time.Time) (time.Time, bool) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, now.Location()); err == nil {
+		return t, true
+	}
+
+	units := map[byte]time.Duration{'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+	return now.Add(-time.Duration(n) * unit), true
+}
+
+func (f conversationFilter) matches(conv domain.ConversationSummary) bool {
+	if conv.Archived != f.archived {
+		return false
+	}
+	if !f.since.IsZero() && conv.UpdatedAt.Before(f.since) {
+		return false
+	}
+	if !f.before.IsZero() && !conv.UpdatedAt.Before(f.before) {
+		return false
+	}
+	if f.model != "" && !strings.Contains(strings.ToLower(conv.Model), f.model) {
+		return false
+	}
+	for _, tag := range f.tags {
+		if !slices.ContainsFunc(conv.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+
+	haystack := strings.ToLower(strings.Join([]string{conv.Title, conv.Summary, conv.Model, strings.Join(conv.Tags, " ")}, "\n"))
+	for _, term := range f.terms {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTags splits a comma-separated tag list, lowercasing each tag, joining
+// inner whitespace with dashes and dropping empties and duplicates.
+func parseTags(input string) []string {
+	var tags []string
+	for _, part := range strings.Split(input, ",") {
+		tag := strings.Join(strings.Fields(strings.ToLower(part)), "-")
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
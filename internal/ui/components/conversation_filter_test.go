@@ -0,0 +1,58 @@
+package components
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestConversationFilter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	convs := []domain.ConversationSummary{
+		{ID: "a", Title: "Fix the flaky build", Model: "openai/gpt-4o", Tags: []string{"ci"}, UpdatedAt: now.Add(-2 * time.Hour)},
+		{ID: "b", Title: "Refactor the parser", Summary: "split the build step", Model: "anthropic/claude", UpdatedAt: now.Add(-10 * 24 * time.Hour)},
+		{ID: "c", Title: "Release notes", Tags: []string{"ci", "release"}, UpdatedAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "d", Title: "Old build logs", Archived: true, UpdatedAt: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "build", want: []string{"a", "b"}},
+		{query: "flaky BUILD", want: []string{"a"}},
+		{query: "tag:ci", want: []string{"a", "c"}},
+		{query: "tag:ci tag:release", want: []string{"c"}},
+		{query: "model:claude", want: []string{"b"}},
+		{query: "since:7d", want: []string{"a"}},
+		{query: "before:2026-09-02", want: []string{"c"}},
+		{query: "since:soon", want: nil},
+		{query: "is:archived", want: []string{"d"}},
+		{query: "is:archived build", want: []string{"d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filter := parseConversationFilter(tt.query, now)
+			var got []string
+			for _, conv := range convs {
+				if filter.matches(conv) {
+					got = append(got, conv.ID)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("query %q matched %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	got := parseTags(" Bug Fix, ci,, CI ,release ")
+	want := []string{"bug-fix", "ci", "release"}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseTags() = %v, want %v", got, want)
+	}
+}
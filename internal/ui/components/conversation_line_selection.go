@@ -0,0 +1,179 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	ansi "github.com/charmbracelet/x/ansi"
+
+	styles "github.com/inference-gateway/cli/internal/ui/styles"
+)
+
+// lineSelection is the state of visual selection mode: a cursor over the
+// rendered conversation lines and, once marked, the other end of the range.
+// Like search it works on the rendered content, so what gets copied is what
+// is on screen, minus styling.
+type lineSelection struct {
+	cursor int
+	anchor int
+	marked bool
+}
+
+// StartLineSelection enters visual selection mode with the cursor on the last
+// line on screen. Reports false when there is nothing to select.
+func (cv *ConversationView) StartLineSelection() bool {
+	if cv.navigationMode != NavigationModeNormal || len(cv.conversation) == 0 {
+		return false
+	}
+	cv.EndSearch()
+	cv.renderWholeConversation()
+
+	lines := cv.selectionLineCount()
+	bottom := cv.Viewport.YOffset() + cv.Viewport.Height() - 1
+	cv.selection = &lineSelection{cursor: max(min(bottom, lines-1), 0)}
+	cv.userScrolledUp = true
+	cv.refreshSelection()
+	return true
+}
+
+// IsInLineSelection reports whether visual selection mode is active.
+func (cv *ConversationView) IsInLineSelection() bool {
+	return cv.selection != nil
+}
+
+// EndLineSelection leaves visual selection mode, keeping the scroll position.
+func (cv *ConversationView) EndLineSelection() {
+	if cv.selection == nil {
+		return
+	}
+	cv.selection = nil
+	cv.Viewport.SetLineDecorator(nil)
+	if cv.Viewport.AtBottom() {
+		cv.userScrolledUp = false
+	}
+}
+
+// MoveLineSelection moves the cursor by delta lines, clamped to the content,
+// scrolling to keep it on screen.
+func (cv *ConversationView) MoveLineSelection(delta int) {
+	s := cv.selection
+	if s == nil {
+		return
+	}
+	s.cursor = max(min(s.cursor+delta, cv.selectionLineCount()-1), 0)
+	cv.refreshSelection()
+}
+
+// ToggleSelectionMark starts a range at the cursor, or drops the range and
+// goes back to selecting the cursor line alone.
+func (cv *ConversationView) ToggleSelectionMark() {
+	s := cv.selection
+	if s == nil {
+		return
+	}
+	s.marked = !s.marked
+	s.anchor = s.cursor
+	cv.refreshSelection()
+}
+
+// SelectedText returns the selected lines without styling. Trailing spaces
+// are trimmed and the common indentation removed, so the rendering's gutter
+// does not end up in the clipboard.
+func (cv *ConversationView) SelectedText() string {
+	s := cv.selection
+	if s == nil {
+		return ""
+	}
+	start, end := s.bounds()
+	if end >= cv.rendered.Len() {
+		return ""
+	}
+
+	selected := make([]string, 0, end-start+1)
+	indent := -1
+	for _, line := range cv.rendered.Lines(start, end+1) {
+		line = strings.TrimRight(ansi.Strip(line), " ")
+		selected = append(selected, line)
+		if trimmed := strings.TrimLeft(line, " "); trimmed != "" {
+			if n := len(line) - len(trimmed); indent < 0 || n < indent {
+				indent = n
+			}
+		}
+	}
+	for i, line := range selected {
+		if len(line) >= indent && indent > 0 {
+			selected[i] = line[indent:]
+		}
+	}
+	return strings.Join(selected, "\n")
+}
+
+// SelectionHint is the input-area hint shown in visual selection mode.
+func (cv *ConversationView) SelectionHint() string {
+	if cv.selection == nil {
+		return ""
+	}
+	start, end := cv.selection.bounds()
+	lines := "1 line"
+	if n := end - start + 1; n > 1 {
+		lines = fmt.Sprintf("%d lines", n)
+	}
+	return "Visual: " + lines + "  ·  ↑/↓ move · v mark · y/enter copy · esc cancel"
+}
+
+func (s *lineSelection) bounds() (int, int) {
+	if !s.marked {
+		return s.cursor, s.cursor
+	}
+	return min(s.anchor, s.cursor), max(s.anchor, s.cursor)
+}
+
+func (cv *ConversationView) selectionLineCount() int {
+	return max(cv.rendered.Len(), 1)
+}
+
+// refreshSelection re-applies the highlight after the cursor moved or the
+// content was rebuilt, and scrolls the cursor into view.
+func (cv *ConversationView) refreshSelection() {
+	s := cv.selection
+	s.cursor = max(min(s.cursor, cv.selectionLineCount()-1), 0)
+	s.anchor = max(min(s.anchor, cv.selectionLineCount()-1), 0)
+
+	offset := cv.Viewport.YOffset()
+	cv.Viewport.SetLines(cv.rendered)
+	cv.Viewport.SetLineDecorator(cv.highlightSelection)
+	height := cv.Viewport.Height()
+	switch {
+	case s.cursor < offset:
+		offset = s.cursor
+	case height > 0 && s.cursor >= offset+height:
+		offset = s.cursor - height + 1
+	}
+	cv.Viewport.SetYOffset(offset)
+}
+
+// highlightSelection is the viewport's line decorator in selection mode: the
+// selected lines in reverse video and the cursor line picked out in the
+// accent colour.
+func (cv *ConversationView) highlightSelection(index int, line string) string {
+	s := cv.selection
+	if cv.styleProvider == nil || s == nil {
+		return line
+	}
+
+	start, end := s.bounds()
+	if index < start || index > end {
+		return line
+	}
+	plain := ansi.Strip(line)
+	if plain == "" {
+		plain = " "
+	}
+	if index == s.cursor {
+		return cv.styleProvider.RenderStyledText(plain, styles.StyleOptions{
+			Background: cv.styleProvider.GetThemeColor("accent"),
+			Bold:       true,
+		})
+	}
+	return cv.styleProvider.RenderCursor(plain)
+}
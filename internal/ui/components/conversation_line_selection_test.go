@@ -0,0 +1,73 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestConversationView_LineSelection(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetHeight(5)
+
+	if cv.StartLineSelection() {
+		t.Fatal("an empty conversation has nothing to select")
+	}
+
+	var conversation []domain.ConversationEntry
+	for i := range 20 {
+		conversation = append(conversation, domain.ConversationEntry{
+			Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(fmt.Sprintf("message %d", i))},
+			Time:    time.Now(),
+		})
+	}
+	cv.SetConversation(conversation)
+
+	if !cv.StartLineSelection() || !cv.IsInLineSelection() {
+		t.Fatal("expected selection mode")
+	}
+	lines := strings.Split(cv.rendered.String(), "\n")
+	if cv.selection.cursor != cv.Viewport.YOffset()+cv.Viewport.Height()-1 {
+		t.Errorf("cursor should start on the last line on screen, got %d", cv.selection.cursor)
+	}
+
+	// Walk the cursor onto the last message and select it with the line above.
+	last := -1
+	for i, line := range lines {
+		if strings.Contains(line, "message 19") {
+			last = i
+		}
+	}
+	if last < 1 {
+		t.Fatalf("message 19 not found in rendered content:\n%s", cv.rendered.String())
+	}
+	cv.MoveLineSelection(last - cv.selection.cursor)
+	if got := cv.SelectedText(); !strings.Contains(got, "message 19") || strings.Contains(got, "\x1b[") {
+		t.Errorf("expected the plain cursor line, got %q", got)
+	}
+
+	cv.ToggleSelectionMark()
+	cv.MoveLineSelection(-1)
+	got := cv.SelectedText()
+	if strings.Count(got, "\n") != 1 || !strings.Contains(got, "message 19") {
+		t.Errorf("expected two lines ending with the last message, got %q", got)
+	}
+	if !strings.Contains(cv.SelectionHint(), "2 lines") {
+		t.Errorf("hint should count the selected lines, got %q", cv.SelectionHint())
+	}
+
+	cv.MoveLineSelection(-1000)
+	if cv.selection.cursor != 0 || cv.Viewport.YOffset() != 0 {
+		t.Errorf("cursor should clamp to the top and scroll there, cursor %d offset %d", cv.selection.cursor, cv.Viewport.YOffset())
+	}
+
+	cv.EndLineSelection()
+	if cv.IsInLineSelection() || cv.SelectedText() != "" {
+		t.Error("expected selection mode to end")
+	}
+}
@@ -0,0 +1,147 @@
+package components
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	ansi "github.com/charmbracelet/x/ansi"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+// minimapMark is what a conversation entry contributes to the minimap. When
+// several entries share a row the highest mark wins.
+type minimapMark int
+
+const (
+	minimapNone minimapMark = iota
+	minimapTool
+	minimapUser
+	minimapError
+)
+
+// minimapMarkOf classifies an entry for the minimap: user messages, tool
+// calls, and failed tool calls. Assistant text is left unmarked.
+func minimapMarkOf(entry domain.ConversationEntry) minimapMark {
+	switch entry.Message.Role {
+	case sdk.User:
+		return minimapUser
+	case sdk.Tool:
+		if entry.ToolExecution != nil && !entry.ToolExecution.Success && !entry.ToolExecution.Rejected {
+			return minimapError
+		}
+		return minimapTool
+	case sdk.Assistant:
+		if entry.Message.ToolCalls != nil && len(*entry.Message.ToolCalls) > 0 {
+			return minimapTool
+		}
+	}
+	return minimapNone
+}
+
+// ToggleMinimap shows or hides the activity strip to the right of the
+// conversation, reporting whether it is now shown.
+func (cv *ConversationView) ToggleMinimap() bool {
+	cv.minimapEnabled = !cv.minimapEnabled
+	return cv.minimapEnabled
+}
+
+// SetScreenTop records the screen row the conversation's first line is drawn
+// on, so mouse clicks on the minimap can be mapped to its rows.
+func (cv *ConversationView) SetScreenTop(row int) {
+	cv.screenTop = row
+}
+
+// minimapVisible reports whether the strip is drawn: it only accompanies the
+// conversation itself, not the welcome screen or the pickers.
+func (cv *ConversationView) minimapVisible() bool {
+	return cv.minimapEnabled && cv.navigationMode == NavigationModeNormal &&
+		len(cv.conversation) > 0 && cv.Viewport.Height() > 0
+}
+
+// minimapColumn is the strip's column in the lines Render returns: past the
+// two-column left padding, the conversation and a one-column gap.
+func (cv *ConversationView) minimapColumn() int {
+	return cv.width + 3
+}
+
+// minimapRows scales the whole conversation down to one mark per viewport
+// row. Each entry marks the row its first line falls on.
+func (cv *ConversationView) minimapRows() []minimapMark {
+	height := cv.Viewport.Height()
+	rows := make([]minimapMark, height)
+	total := cv.rendered.Len()
+	if total == 0 {
+		return rows
+	}
+
+	for i, mark := range cv.minimapMarks {
+		if mark == minimapNone || i >= len(cv.rendered.starts) {
+			continue
+		}
+		row := min(cv.rendered.starts[i]*height/total, height-1)
+		rows[row] = max(rows[row], mark)
+	}
+	return rows
+}
+
+// overlayMinimap appends the strip to the lines Render produced. Rows that
+// cover the part of the conversation on screen form the thumb, the rest the
+// track, and marks are drawn over both.
+func (cv *ConversationView) overlayMinimap(lines []string) []string {
+	if !cv.minimapVisible() || cv.styleProvider == nil {
+		return lines
+	}
+
+	rows := cv.minimapRows()
+	total := max(cv.rendered.Len(), 1)
+	height := len(rows)
+	thumbStart := cv.Viewport.YOffset() * height / total
+	thumbEnd := (cv.Viewport.YOffset() + height - 1) * height / total
+
+	width := cv.minimapColumn()
+	for i := range lines {
+		if i >= height {
+			break
+		}
+		line := ansi.Truncate(lines[i], width, "")
+		if pad := width - ansi.StringWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		lines[i] = line + cv.renderMinimapCell(rows[i], i >= thumbStart && i <= thumbEnd)
+	}
+	return lines
+}
+
+func (cv *ConversationView) renderMinimapCell(mark minimapMark, thumb bool) string {
+	switch mark {
+	case minimapError:
+		return cv.styleProvider.RenderWithColor("✗", cv.styleProvider.GetThemeColor("error"))
+	case minimapUser:
+		return cv.styleProvider.RenderWithColor("●", cv.getUserColor())
+	case minimapTool:
+		return cv.styleProvider.RenderWithColor("•", cv.styleProvider.GetThemeColor("accent"))
+	}
+	if thumb {
+		return cv.styleProvider.RenderWithColor("┃", cv.styleProvider.GetThemeColor("accent"))
+	}
+	return cv.styleProvider.RenderDimText("│")
+}
+
+// handleMinimapClick scrolls to the part of the conversation a clicked
+// minimap row stands for, centring it on screen.
+func (cv *ConversationView) handleMinimapClick(click tea.MouseClickMsg) {
+	if !cv.minimapVisible() || click.Button != tea.MouseLeft || click.X != cv.minimapColumn() {
+		return
+	}
+	height := cv.Viewport.Height()
+	row := click.Y - cv.screenTop
+	if row < 0 || row >= height {
+		return
+	}
+
+	line := row * cv.rendered.Len() / height
+	cv.Viewport.SetYOffset(line - height/2)
+	cv.userScrolledUp = !cv.Viewport.AtBottom()
+}
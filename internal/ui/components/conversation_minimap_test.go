@@ -0,0 +1,94 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	ansi "github.com/charmbracelet/x/ansi"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestMinimapMarkOf(t *testing.T) {
+	cases := []struct {
+		name  string
+		entry domain.ConversationEntry
+		want  minimapMark
+	}{
+		{"user", domain.ConversationEntry{Message: sdk.Message{Role: sdk.User}}, minimapUser},
+		{"assistant text", domain.ConversationEntry{Message: sdk.Message{Role: sdk.Assistant}}, minimapNone},
+		{"tool call", toolCallEntry("Read", `{}`), minimapTool},
+		{"tool result", domain.ConversationEntry{
+			Message:       sdk.Message{Role: sdk.Tool},
+			ToolExecution: &domain.ToolExecutionResult{ToolName: "Bash", Success: true},
+		}, minimapTool},
+		{"failed tool", domain.ConversationEntry{
+			Message:       sdk.Message{Role: sdk.Tool},
+			ToolExecution: &domain.ToolExecutionResult{ToolName: "Bash", Error: "exit 1"},
+		}, minimapError},
+		{"rejected tool", domain.ConversationEntry{
+			Message:       sdk.Message{Role: sdk.Tool},
+			ToolExecution: &domain.ToolExecutionResult{ToolName: "Bash", Rejected: true},
+		}, minimapTool},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := minimapMarkOf(tc.entry); got != tc.want {
+				t.Errorf("minimapMarkOf() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConversationView_Minimap(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetWidth(60)
+	cv.SetHeight(5)
+	cv.SetScreenTop(2)
+
+	var conversation []domain.ConversationEntry
+	for i := range 20 {
+		conversation = append(conversation, domain.ConversationEntry{
+			Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(fmt.Sprintf("message %d", i))},
+			Time:    time.Now(),
+		})
+	}
+	cv.SetConversation(conversation)
+
+	if strings.Contains(ansi.Strip(cv.Render()), "●") {
+		t.Fatal("the minimap is off by default")
+	}
+
+	if !cv.ToggleMinimap() {
+		t.Fatal("ToggleMinimap should report the minimap as shown")
+	}
+	lines := strings.Split(cv.Render(), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("rendered %d lines, want 5", len(lines))
+	}
+	for i, line := range lines {
+		cell := ansi.Cut(line, cv.minimapColumn(), cv.minimapColumn()+1)
+		if ansi.Strip(cell) != "●" {
+			t.Errorf("row %d: minimap cell = %q, want a user message mark", i, ansi.Strip(cell))
+		}
+	}
+
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: cv.minimapColumn(), Y: 2})
+	if cv.Viewport.YOffset() != 0 || !cv.userScrolledUp {
+		t.Errorf("clicking the top row should scroll to the top, offset %d", cv.Viewport.YOffset())
+	}
+
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: cv.minimapColumn() - 1, Y: 6})
+	if cv.Viewport.YOffset() != 0 {
+		t.Error("clicks beside the minimap must not scroll")
+	}
+
+	cv.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: cv.minimapColumn(), Y: 6})
+	if got, total := cv.Viewport.YOffset(), cv.Viewport.TotalLineCount(); got < total/2 {
+		t.Errorf("clicking the bottom row should scroll to the end of the conversation, offset %d of %d", got, total)
+	}
+}
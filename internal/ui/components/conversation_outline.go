@@ -0,0 +1,172 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+)
+
+// outlineItem is one row of the conversation outline: a user message, an
+// assistant turn, or a tool call, pointing back at its conversation index.
+type outlineItem struct {
+	index int
+	kind  string
+	label string
+	time  time.Time
+}
+
+// buildOutline lists the visible entries worth jumping to, in order.
+func buildOutline(conversation []domain.ConversationEntry) []outlineItem {
+	var items []outlineItem
+	for i, entry := range conversation {
+		if entry.Hidden {
+			continue
+		}
+		item := outlineItem{index: i, time: entry.Time}
+		content, _ := entry.Message.Content.AsMessageContent0()
+
+		switch entry.Message.Role {
+		case sdk.User:
+			item.kind, item.label = "User", formatting.CollapsePastedText(content)
+		case sdk.Assistant:
+			item.kind, item.label = "Assistant", content
+			if strings.TrimSpace(content) == "" && entry.Message.ToolCalls != nil {
+				item.label = fmt.Sprintf("(%d tool calls)", len(*entry.Message.ToolCalls))
+			}
+		case sdk.Tool:
+			item.kind = "Tool"
+			switch {
+			case entry.ToolExecution != nil:
+				item.label = entry.ToolExecution.ToolName
+				if !entry.ToolExecution.Success {
+					item.label += " (failed)"
+				}
+			case entry.PendingToolCall != nil:
+				item.label = entry.PendingToolCall.Function.Name + " (pending)"
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// EnterOutlineMode replaces the conversation with an outline of its entries
+// and selects the latest one. It reports false when there is nothing to list.
+func (cv *ConversationView) EnterOutlineMode() bool {
+	items := buildOutline(cv.conversation)
+	if len(items) == 0 {
+		return false
+	}
+	cv.EndSearch()
+	cv.outlineReturnOffset = cv.Viewport.YOffset()
+	cv.navigationMode = NavigationModeOutline
+	cv.outlineItems = items
+	cv.outlineSelectedIndex = len(items) - 1
+	cv.updateOutlineView()
+	return true
+}
+
+// IsInOutlineMode returns true while the conversation outline is shown
+func (cv *ConversationView) IsInOutlineMode() bool {
+	return cv.navigationMode == NavigationModeOutline
+}
+
+// NavigateOutline moves the outline selection by delta rows, clamped to the list
+func (cv *ConversationView) NavigateOutline(delta int) {
+	if len(cv.outlineItems) == 0 {
+		return
+	}
+	cv.outlineSelectedIndex = max(min(cv.outlineSelectedIndex+delta, len(cv.outlineItems)-1), 0)
+	cv.updateOutlineView()
+}
+
+// ExitOutlineMode closes the outline and returns to where the user was reading
+func (cv *ConversationView) ExitOutlineMode() {
+	cv.leaveOutline()
+	if cv.userScrolledUp {
+		cv.Viewport.SetYOffset(cv.outlineReturnOffset)
+	}
+}
+
+// JumpToOutlineSelection closes the outline and scrolls the selected entry to
+// the top of the viewport.
+func (cv *ConversationView) JumpToOutlineSelection() {
+	if len(cv.outlineItems) == 0 {
+		cv.ExitOutlineMode()
+		return
+	}
+	index := cv.outlineItems[cv.outlineSelectedIndex].index
+	cv.leaveOutline()
+
+	if span, ok := cv.entryLineSpans()[index]; ok {
+		cv.userScrolledUp = true
+		cv.Viewport.SetYOffset(span[0])
+	}
+}
+
+func (cv *ConversationView) leaveOutline() {
+	cv.navigationMode = NavigationModeNormal
+	cv.outlineItems = nil
+	cv.outlineSelectedIndex = 0
+	cv.updateViewportContentFull()
+}
+
+func (cv *ConversationView) updateOutlineView() {
+	cv.Viewport.SetContent(cv.renderOutline())
+	cv.Viewport.GotoTop()
+}
+
+// renderOutline renders the outline list in the same layout as the message
+// history selector: a title, a count, and a window around the selection.
+func (cv *ConversationView) renderOutline() string {
+	var b strings.Builder
+
+	b.WriteString(cv.styleProvider.RenderWithColor("Conversation Outline", cv.styleProvider.GetThemeColor("accent")))
+	b.WriteString("\n")
+	b.WriteString(cv.styleProvider.RenderDimText(
+		fmt.Sprintf("%d entries · ↑/↓ select · enter jump · esc close", len(cv.outlineItems))))
+	b.WriteString("\n\n")
+
+	maxVisible := max(cv.height-5, 5)
+	start, end := calculatePaginationBounds(cv.outlineSelectedIndex, len(cv.outlineItems), maxVisible)
+
+	if start > 0 {
+		b.WriteString(cv.styleProvider.RenderDimText(fmt.Sprintf("  ... %d earlier entries", start)))
+		b.WriteString("\n")
+	}
+
+	for i := start; i < end; i++ {
+		item := cv.outlineItems[i]
+
+		indent := ""
+		if item.kind == "Tool" {
+			indent = "  "
+		}
+		prefix := fmt.Sprintf("[%s] %s[%s] ", item.time.Format("15:04:05"), indent, item.kind)
+		label := strings.Join(strings.Fields(item.label), " ")
+		label = formatting.TruncateText(label, max(cv.width-len(prefix)-4, 20))
+
+		if i == cv.outlineSelectedIndex {
+			b.WriteString(cv.styleProvider.RenderWithColor("▶ "+prefix+label, cv.styleProvider.GetThemeColor("accent")))
+		} else {
+			b.WriteString(cv.styleProvider.RenderDimText("  " + prefix + label))
+		}
+		b.WriteString("\n")
+	}
+
+	if end < len(cv.outlineItems) {
+		b.WriteString(cv.styleProvider.RenderDimText(fmt.Sprintf("  ... %d later entries", len(cv.outlineItems)-end)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package components
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestBuildOutline(t *testing.T) {
+	toolCalls := []sdk.ChatCompletionMessageToolCall{{}, {}}
+	conversation := []domain.ConversationEntry{
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("fix the bug")}},
+		{Message: sdk.Message{Role: sdk.System, Content: sdk.NewMessageContent("system")}},
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent(""), ToolCalls: &toolCalls}},
+		{
+			Message:       sdk.Message{Role: sdk.Tool, Content: sdk.NewMessageContent("ok")},
+			ToolExecution: &domain.ToolExecutionResult{ToolName: "Read", Success: true},
+		},
+		{
+			Message:       sdk.Message{Role: sdk.Tool, Content: sdk.NewMessageContent("boom")},
+			ToolExecution: &domain.ToolExecutionResult{ToolName: "Bash", Success: false},
+		},
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("hidden")}, Hidden: true},
+	}
+
+	items := buildOutline(conversation)
+
+	want := []struct {
+		index int
+		kind  string
+		label string
+	}{
+		{0, "User", "fix the bug"},
+		{2, "Assistant", "(2 tool calls)"},
+		{3, "Tool", "Read"},
+		{4, "Tool", "Bash (failed)"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i, w := range want {
+		if items[i].index != w.index || items[i].kind != w.kind || items[i].label != w.label {
+			t.Errorf("item %d = %+v, want %+v", i, items[i], w)
+		}
+	}
+}
+
+func TestConversationView_OutlineJump(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetHeight(5)
+
+	if cv.EnterOutlineMode() {
+		t.Fatal("an empty conversation has nothing to outline")
+	}
+
+	var conversation []domain.ConversationEntry
+	for i := range 30 {
+		conversation = append(conversation, domain.ConversationEntry{
+			Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(fmt.Sprintf("message %d", i))},
+			Time:    time.Now(),
+		})
+	}
+	cv.SetConversation(conversation)
+
+	if !cv.EnterOutlineMode() || !cv.IsInOutlineMode() {
+		t.Fatal("expected outline mode")
+	}
+	if cv.outlineSelectedIndex != 29 {
+		t.Errorf("selected = %d, want the latest entry", cv.outlineSelectedIndex)
+	}
+
+	cv.NavigateOutline(-100)
+	if cv.outlineSelectedIndex != 0 {
+		t.Errorf("selected = %d, want clamp to 0", cv.outlineSelectedIndex)
+	}
+	cv.NavigateOutline(10)
+
+	cv.JumpToOutlineSelection()
+	if cv.IsInOutlineMode() {
+		t.Fatal("expected outline mode to close after jumping")
+	}
+	if want := cv.entryLineSpans()[10][0]; cv.Viewport.YOffset() != want {
+		t.Errorf("offset = %d, want %d (start of entry 10)", cv.Viewport.YOffset(), want)
+	}
+}
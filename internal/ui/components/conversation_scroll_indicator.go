@@ -0,0 +1,66 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	ansi "github.com/charmbracelet/x/ansi"
+)
+
+// scrollIndicator describes the viewport position while the user is scrolled
+// back, e.g. "57% · 312/540 lines". It is empty when following the tail or
+// when the content fits on screen.
+func (cv *ConversationView) scrollIndicator() string {
+	total := cv.Viewport.TotalLineCount()
+	visible := cv.Viewport.VisibleLineCount()
+	if cv.Viewport.AtBottom() || total <= visible {
+		return ""
+	}
+
+	lastVisible := min(cv.Viewport.YOffset()+visible, total)
+	percent := cv.Viewport.ScrollPercent() * 100
+	return fmt.Sprintf("%.0f%% · %d/%d lines", percent, lastVisible, total)
+}
+
+// markNewContentBelow flags content that arrived while scrolled back, so the
+// indicator can point the user at it.
+func (cv *ConversationView) markNewContentBelow() {
+	if !cv.Viewport.AtBottom() {
+		cv.newContentBelow = true
+	}
+}
+
+// overlayScrollIndicator right-aligns the scroll position, and a "↓ new
+// messages" pill when content arrived since the user scrolled up, over the
+// last viewport line. Returning to the bottom clears the pill.
+func (cv *ConversationView) overlayScrollIndicator(lines []string) []string {
+	indicator := cv.scrollIndicator()
+	if indicator == "" {
+		cv.newContentBelow = false
+		return lines
+	}
+	if len(lines) == 0 || cv.styleProvider == nil {
+		return lines
+	}
+
+	indicator = cv.styleProvider.RenderDimText(indicator)
+	if cv.newContentBelow {
+		indicator = cv.styleProvider.RenderSelectedIndicator("↓ new messages") + " " + indicator
+	}
+
+	// Lines arrive with Render's two-column left padding.
+	width := cv.width + 2
+	indicatorWidth := ansi.StringWidth(indicator)
+	if indicatorWidth >= width {
+		return lines
+	}
+
+	last := len(lines) - 1
+	room := width - indicatorWidth - 1
+	line := ansi.Truncate(lines[last], room, "")
+	if pad := room - ansi.StringWidth(line); pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	lines[last] = line + " " + indicator
+	return lines
+}
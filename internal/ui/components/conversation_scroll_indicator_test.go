@@ -0,0 +1,56 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestConversationView_ScrollIndicator(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetHeight(5)
+
+	message := func(i int) domain.ConversationEntry {
+		return domain.ConversationEntry{
+			Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(fmt.Sprintf("message %d", i))},
+			Time:    time.Now(),
+		}
+	}
+	var conversation []domain.ConversationEntry
+	for i := range 20 {
+		conversation = append(conversation, message(i))
+	}
+	cv.SetConversation(conversation)
+
+	if got := cv.Render(); strings.Contains(got, "lines") {
+		t.Errorf("no indicator expected while following the tail, got:\n%s", got)
+	}
+
+	cv.handleScrollRequest(domain.ScrollRequestEvent{Direction: domain.ScrollToTop})
+	total := cv.Viewport.TotalLineCount()
+	want := fmt.Sprintf("0%% · 5/%d lines", total)
+	if got := cv.Render(); !strings.Contains(got, want) {
+		t.Errorf("expected %q at the top, got:\n%s", want, got)
+	}
+	if strings.Contains(cv.Render(), "new messages") {
+		t.Error("no new messages pill expected before content arrives")
+	}
+
+	cv.SetConversation(append(conversation, message(20)))
+	if got := cv.Render(); !strings.Contains(got, "↓ new messages") {
+		t.Errorf("expected the new messages pill, got:\n%s", got)
+	}
+
+	cv.handleScrollRequest(domain.ScrollRequestEvent{Direction: domain.ScrollToBottom})
+	if got := cv.Render(); strings.Contains(got, "lines") || strings.Contains(got, "new messages") {
+		t.Errorf("indicator should clear at the bottom, got:\n%s", got)
+	}
+	if cv.newContentBelow {
+		t.Error("returning to the bottom should clear the new messages flag")
+	}
+}
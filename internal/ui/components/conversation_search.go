@@ -0,0 +1,233 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	ansi "github.com/charmbracelet/x/ansi"
+
+	styles "github.com/inference-gateway/cli/internal/ui/styles"
+)
+
+// conversationSearch is the state of an in-conversation search. Matches are
+// found in the ANSI-stripped rendered lines, so what gets highlighted is
+// exactly what is on screen (wrapped, markdown-rendered, collapsed or not).
+type conversationSearch struct {
+	query   string
+	matches []searchMatch
+	current int
+}
+
+// searchMatch locates one hit in the rendered content. start and end are
+// terminal cell columns, the coordinate space ansi.Cut works in.
+type searchMatch struct {
+	line  int
+	start int
+	end   int
+	text  string
+}
+
+// StartSearch enters search mode. Re-entering keeps the previous query so the
+// user can pick up where they left off.
+func (cv *ConversationView) StartSearch() {
+	if cv.search == nil {
+		cv.renderWholeConversation()
+		cv.search = &conversationSearch{}
+	}
+	cv.refreshSearch(true)
+}
+
+// IsSearching reports whether the conversation search is active.
+func (cv *ConversationView) IsSearching() bool {
+	return cv.search != nil
+}
+
+// EndSearch leaves search mode and restores the unhighlighted content at the
+// current scroll position.
+func (cv *ConversationView) EndSearch() {
+	if cv.search == nil {
+		return
+	}
+	cv.search = nil
+	cv.Viewport.SetLineDecorator(nil)
+}
+
+// SearchQuery returns the current search query.
+func (cv *ConversationView) SearchQuery() string {
+	if cv.search == nil {
+		return ""
+	}
+	return cv.search.query
+}
+
+// SetSearchQuery replaces the query, re-runs the search, and jumps to the
+// match nearest above the bottom of the screen.
+func (cv *ConversationView) SetSearchQuery(query string) {
+	if cv.search == nil {
+		return
+	}
+	cv.search.query = query
+	cv.refreshSearch(true)
+}
+
+// NextSearchMatch moves to the next match further down, wrapping to the top.
+func (cv *ConversationView) NextSearchMatch() {
+	cv.stepSearch(1)
+}
+
+// PrevSearchMatch moves to the previous match further up, wrapping to the bottom.
+func (cv *ConversationView) PrevSearchMatch() {
+	cv.stepSearch(-1)
+}
+
+// SearchStatus returns the match counter, e.g. "3/12", or "no matches".
+func (cv *ConversationView) SearchStatus() string {
+	if cv.search == nil || cv.search.query == "" {
+		return ""
+	}
+	if len(cv.search.matches) == 0 {
+		return "no matches"
+	}
+	return fmt.Sprintf("%d/%d", cv.search.current+1, len(cv.search.matches))
+}
+
+// SearchHint is the input-area hint shown while searching: the query, the
+// match counter, and the search keys.
+func (cv *ConversationView) SearchHint() string {
+	hint := "Search: " + cv.SearchQuery()
+	if status := cv.SearchStatus(); status != "" {
+		hint += "  [" + status + "]"
+	}
+	return hint + "  ·  enter/↓ next · ↑ prev · esc close"
+}
+
+func (cv *ConversationView) stepSearch(delta int) {
+	s := cv.search
+	if s == nil || len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current + delta + len(s.matches)) % len(s.matches)
+	cv.scrollToSearchMatch()
+}
+
+// refreshSearch recomputes the matches against the latest rendered content.
+// With reselect it picks the match nearest above the bottom of the screen and
+// scrolls to it; otherwise (a rebuild while searching) it keeps the current
+// index and the scroll behaviour of a normal rebuild.
+func (cv *ConversationView) refreshSearch(reselect bool) {
+	s := cv.search
+	s.matches = findSearchMatches(cv.rendered, s.query)
+
+	if reselect {
+		bottom := cv.Viewport.YOffset() + cv.Viewport.Height()
+		s.current = len(s.matches) - 1
+		for i, m := range s.matches {
+			if m.line >= bottom {
+				s.current = max(i-1, 0)
+				break
+			}
+		}
+	}
+	s.current = max(min(s.current, len(s.matches)-1), 0)
+
+	offset := cv.Viewport.YOffset()
+	cv.Viewport.SetLines(cv.rendered)
+	cv.Viewport.SetLineDecorator(cv.highlightSearch)
+	switch {
+	case reselect:
+		cv.scrollToSearchMatch()
+	case cv.userScrolledUp:
+		cv.Viewport.SetYOffset(offset)
+	default:
+		cv.Viewport.GotoBottom()
+	}
+}
+
+// scrollToSearchMatch centres the current match in the viewport. It counts as
+// a user scroll so streaming output doesn't yank the view back to the bottom.
+func (cv *ConversationView) scrollToSearchMatch() {
+	s := cv.search
+	if len(s.matches) == 0 {
+		return
+	}
+	cv.userScrolledUp = true
+	cv.Viewport.SetYOffset(max(s.matches[s.current].line-cv.Viewport.Height()/2, 0))
+}
+
+// highlightSearch is the viewport's line decorator while searching: every
+// match highlighted and the current match picked out in the accent colour.
+// Text around a match keeps its original styling.
+func (cv *ConversationView) highlightSearch(index int, line string) string {
+	s := cv.search
+	if s == nil {
+		return line
+	}
+
+	first := sort.Search(len(s.matches), func(i int) bool { return s.matches[i].line >= index })
+	last := first
+	for last < len(s.matches) && s.matches[last].line == index {
+		last++
+	}
+	for i := last - 1; i >= first; i-- {
+		m := s.matches[i]
+		line = ansi.Cut(line, 0, m.start) +
+			cv.renderSearchMatch(m.text, i == s.current) +
+			ansi.Cut(line, m.end, ansi.StringWidth(line))
+	}
+	return line
+}
+
+func (cv *ConversationView) renderSearchMatch(text string, current bool) string {
+	if cv.styleProvider == nil {
+		return text
+	}
+	if current {
+		return cv.styleProvider.RenderStyledText(text, styles.StyleOptions{
+			Background: cv.styleProvider.GetThemeColor("accent"),
+			Bold:       true,
+		})
+	}
+	return cv.styleProvider.RenderCursor(text)
+}
+
+// findSearchMatches returns the case-insensitive, non-overlapping matches of
+// query in the ANSI-stripped lines, in reading order.
+func findSearchMatches(lines *lineIndex, query string) []searchMatch {
+	needle := []rune(strings.Map(unicode.ToLower, query))
+	if len(needle) == 0 {
+		return nil
+	}
+
+	var matches []searchMatch
+	for lineIdx, line := range lines.Lines(0, lines.Len()) {
+		plain := []rune(ansi.Strip(line))
+		for i := 0; i+len(needle) <= len(plain); {
+			if !runesEqualFold(plain[i:i+len(needle)], needle) {
+				i++
+				continue
+			}
+			start := ansi.StringWidth(string(plain[:i]))
+			text := string(plain[i : i+len(needle)])
+			matches = append(matches, searchMatch{
+				line:  lineIdx,
+				start: start,
+				end:   start + ansi.StringWidth(text),
+				text:  text,
+			})
+			i += len(needle)
+		}
+	}
+	return matches
+}
+
+// runesEqualFold compares haystack against an already lower-cased needle.
+func runesEqualFold(haystack, needle []rune) bool {
+	for i, r := range haystack {
+		if unicode.ToLower(r) != needle[i] {
+			return false
+		}
+	}
+	return true
+}
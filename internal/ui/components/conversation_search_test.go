@@ -0,0 +1,90 @@
+package components
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestFindSearchMatches(t *testing.T) {
+	content := "\x1b[1mFoo\x1b[0m bar foo\nnothing here\n日本 foo"
+
+	matches := findSearchMatches(splitLineIndex(content), "FOO")
+	want := []searchMatch{
+		{line: 0, start: 0, end: 3, text: "Foo"},
+		{line: 0, start: 8, end: 11, text: "foo"},
+		{line: 2, start: 5, end: 8, text: "foo"},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("got %d matches, want %d: %+v", len(matches), len(want), matches)
+	}
+	for i := range want {
+		if matches[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, matches[i], want[i])
+		}
+	}
+
+	if got := findSearchMatches(splitLineIndex(content), ""); got != nil {
+		t.Errorf("empty query should match nothing, got %+v", got)
+	}
+	if got := findSearchMatches(splitLineIndex("aaaa"), "aa"); len(got) != 2 {
+		t.Errorf("matches must not overlap, got %+v", got)
+	}
+}
+
+func TestConversationView_Search(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetHeight(5)
+
+	var conversation []domain.ConversationEntry
+	for i := range 20 {
+		conversation = append(conversation, domain.ConversationEntry{
+			Message: sdk.Message{
+				Role:    sdk.User,
+				Content: sdk.NewMessageContent(fmt.Sprintf("message %d needle", i)),
+			},
+			Time: time.Now(),
+		})
+	}
+	cv.SetConversation(conversation)
+
+	cv.StartSearch()
+	if !cv.IsSearching() {
+		t.Fatal("expected search mode")
+	}
+	if got := cv.SearchStatus(); got != "" {
+		t.Errorf("status with empty query = %q, want empty", got)
+	}
+
+	cv.SetSearchQuery("NEEDLE")
+	if got := cv.SearchStatus(); got != "20/20" {
+		t.Errorf("status = %q, want the last match selected when scrolled to the bottom", got)
+	}
+
+	cv.NextSearchMatch()
+	if got := cv.SearchStatus(); got != "1/20" {
+		t.Errorf("status after next = %q, want wrap to 1/20", got)
+	}
+	if cv.Viewport.YOffset() != 0 || !cv.userScrolledUp {
+		t.Errorf("expected a jump to the top match, offset=%d", cv.Viewport.YOffset())
+	}
+
+	cv.PrevSearchMatch()
+	if got := cv.SearchStatus(); got != "20/20" {
+		t.Errorf("status after prev = %q, want wrap to 20/20", got)
+	}
+
+	cv.SetSearchQuery("missing")
+	if got := cv.SearchStatus(); got != "no matches" {
+		t.Errorf("status = %q, want no matches", got)
+	}
+
+	cv.EndSearch()
+	if cv.IsSearching() || cv.SearchStatus() != "" {
+		t.Error("expected search to be closed")
+	}
+}
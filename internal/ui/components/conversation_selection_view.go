@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	key "charm.land/bubbles/v2/key"
 	spinner "charm.land/bubbles/v2/spinner"
@@ -20,6 +21,10 @@ import (
 	styles "github.com/inference-gateway/cli/internal/ui/styles"
 )
 
+// conversationListLimit caps how many saved conversations the selector
+// loads; search and filters then narrow them down.
+const conversationListLimit = 1000
+
 // ConversationSelectorImpl implements conversation selection UI
 type ConversationSelectorImpl struct {
 	conversations         []domain.ConversationSummary
@@ -36,6 +41,9 @@ type ConversationSelectorImpl struct {
 	loadError             error
 	confirmDelete         bool
 	deleteError           error
+	tagMode               bool
+	tagInput              string
+	updateError           error
 	dataLoaded            bool
 	spinner               spinner.Model
 	table                 table.Model
@@ -61,6 +69,7 @@ func NewConversationSelector(repo shortcuts.PersistentConversationRepository, st
 		table.WithColumns([]table.Column{
 			{Title: "ID", Width: 38},
 			{Title: "Summary", Width: 25},
+			{Title: "Tags", Width: 16},
 			{Title: "Messages", Width: 10},
 			{Title: "Requests", Width: 8},
 			{Title: "Input Tokens", Width: 12},
@@ -96,9 +105,16 @@ func (c *ConversationSelectorImpl) tableHeight() int {
 // syncTable refreshes the table rows from the filtered conversations, keeping
 // the cursor in range.
 func (c *ConversationSelectorImpl) syncTable() {
+	forks := make(map[string]int)
+	for _, conv := range c.conversations {
+		if conv.ParentID != "" {
+			forks[conv.ParentID]++
+		}
+	}
+
 	rows := make([]table.Row, 0, len(c.filteredConversations))
 	for _, conv := range c.filteredConversations {
-		rows = append(rows, conversationRow(conv))
+		rows = append(rows, conversationRow(conv, forks[conv.ID]))
 	}
 	c.table.SetRows(rows)
 	if c.table.Cursor() >= len(rows) {
@@ -107,8 +123,9 @@ func (c *ConversationSelectorImpl) syncTable() {
 }
 
 // conversationRow renders one conversation as table cells, keeping the
-// cost-tier precision of the previous hand-built table.
-func conversationRow(conv domain.ConversationSummary) table.Row {
+// cost-tier precision of the previous hand-built table. forks is the number
+// of listed conversations forked from this one.
+func conversationRow(conv domain.ConversationSummary, forks int) table.Row {
 	costStr := "-"
 	switch cost := conv.CostStats.TotalCost; {
 	case cost > 0 && cost < 0.01:
@@ -121,7 +138,8 @@ func conversationRow(conv domain.ConversationSummary) table.Row {
 
 	return table.Row{
 		conv.ID,
-		formatting.TruncateText(conv.Title, 25),
+		conversationLabel(conv, forks),
+		formatting.TruncateText(strings.Join(conv.Tags, ", "), 16),
 		fmt.Sprintf("%d", conv.MessageCount),
 		fmt.Sprintf("%d", conv.TokenStats.RequestCount),
 		fmt.Sprintf("%d", conv.TokenStats.TotalInputTokens),
@@ -130,6 +148,20 @@ func conversationRow(conv domain.ConversationSummary) table.Row {
 	}
 }
 
+// conversationLabel is the Summary cell: the title, marked with ↳ when the
+// conversation is a fork and with ⑂N when N forks were made from it.
+func conversationLabel(conv domain.ConversationSummary, forks int) string {
+	prefix, suffix := "", ""
+	if conv.ParentID != "" {
+		prefix = "↳ "
+	}
+	if forks > 0 {
+		suffix = fmt.Sprintf(" ⑂%d", forks)
+	}
+	width := 25 - lipgloss.Width(prefix) - lipgloss.Width(suffix)
+	return prefix + formatting.TruncateText(conv.Title, width) + suffix
+}
+
 func (c *ConversationSelectorImpl) Init() tea.Cmd {
 	return tea.Batch(c.loadConversationsCmd(), c.spinner.Tick)
 }
@@ -141,7 +173,7 @@ func (c *ConversationSelectorImpl) loadConversationsCmd() tea.Cmd {
 
 		time.Sleep(constants.TestSleepDelay / 10)
 
-		conversations, err := c.repo.ListSavedConversations(ctx, 50, 0)
+		conversations, err := c.repo.ListSavedConversations(ctx, conversationListLimit, 0)
 
 		interfaceConversations := make([]any, len(conversations))
 		for i, conv := range conversations {
@@ -192,8 +224,7 @@ func (c *ConversationSelectorImpl) handleConversationsLoaded(msg domain.Conversa
 		}
 
 		c.conversations = conversations
-		c.filteredConversations = make([]domain.ConversationSummary, len(conversations))
-		copy(c.filteredConversations, conversations)
+		c.filterConversations()
 		c.syncTable()
 		c.table.GotoTop()
 	} else {
@@ -215,6 +246,9 @@ func (c *ConversationSelectorImpl) handleKeyInput(msg tea.KeyPressMsg) (tea.Mode
 	if c.confirmDelete {
 		return c.handleDeleteConfirmation(msg)
 	}
+	if c.tagMode {
+		return c.handleTagInput(msg)
+	}
 
 	switch {
 	case key.Matches(msg, conversationSelectorKeys.cancel):
@@ -224,11 +258,16 @@ func (c *ConversationSelectorImpl) handleKeyInput(msg tea.KeyPressMsg) (tea.Mode
 		return c.handleCancel()
 	case key.Matches(msg, conversationSelectorKeys.enter):
 		return c.handleSelection()
-	case key.Matches(msg, conversationSelectorKeys.delete):
-		if !c.searchMode && len(c.filteredConversations) > 0 {
+	case key.Matches(msg, conversationSelectorKeys.delete) && !c.searchMode:
+		if len(c.filteredConversations) > 0 {
 			return c.handleDeleteRequest()
 		}
 		return c, nil
+	case key.Matches(msg, conversationSelectorKeys.tag) && !c.searchMode:
+		return c.handleTagRequest()
+	case key.Matches(msg, conversationSelectorKeys.archive) && !c.searchMode:
+		c.toggleArchived()
+		return c, nil
 	case key.Matches(msg, conversationSelectorKeys.search):
 		if !c.searchMode {
 			return c.handleSearchToggle()
@@ -280,8 +319,8 @@ func (c *ConversationSelectorImpl) handleBackspace() (tea.Model, tea.Cmd) {
 }
 
 func (c *ConversationSelectorImpl) handleCharacterInput(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	if c.searchMode && len(msg.String()) == 1 && msg.String()[0] >= 32 {
-		c.searchQuery += msg.String()
+	if c.searchMode && msg.Text != "" {
+		c.searchQuery += msg.Text
 		c.updateSearch()
 	}
 	return c, nil
@@ -314,10 +353,19 @@ func (c *ConversationSelectorImpl) viewContent() string {
 		return c.writeDeleteConfirmation(&b)
 	}
 
+	if c.tagMode {
+		return c.writeTagEditor(&b)
+	}
+
 	if c.deleteError != nil {
 		c.writeDeleteError(&b)
 	}
 
+	if c.updateError != nil {
+		errorMsg := fmt.Sprintf("Error updating conversation: %v", c.updateError)
+		fmt.Fprintf(&b, "%s\n\n", c.styleProvider.RenderWithColor(errorMsg, c.styleProvider.GetThemeColor("error")))
+	}
+
 	c.writeSearchInfo(&b)
 
 	if len(c.filteredConversations) == 0 {
@@ -330,21 +378,111 @@ func (c *ConversationSelectorImpl) viewContent() string {
 	return b.String()
 }
 
-// filterConversations filters the conversations based on the search query
+// filterConversations filters the conversations by the search query, which
+// may mix free text with tag:, model:, since:, before: and is:archived
+// qualifiers. Archived conversations only show up for is:archived.
 func (c *ConversationSelectorImpl) filterConversations() {
-	if c.searchQuery == "" {
-		c.filteredConversations = make([]domain.ConversationSummary, len(c.conversations))
-		copy(c.filteredConversations, c.conversations)
+	c.filteredConversations = make([]domain.ConversationSummary, 0, len(c.conversations))
+	filter := parseConversationFilter(c.searchQuery, time.Now())
+
+	for _, conv := range c.conversations {
+		if filter.matches(conv) {
+			c.filteredConversations = append(c.filteredConversations, conv)
+		}
+	}
+}
+
+// toggleArchived archives the highlighted conversation, or restores it when
+// browsing archived ones. Either way it leaves the current list.
+func (c *ConversationSelectorImpl) toggleArchived() {
+	cursor := c.table.Cursor()
+	if cursor >= len(c.filteredConversations) {
 		return
 	}
+	conv := c.filteredConversations[cursor]
+	archived := !conv.Archived
 
-	c.filteredConversations = c.filteredConversations[:0]
-	query := strings.ToLower(c.searchQuery)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	for _, conv := range c.conversations {
-		if strings.Contains(strings.ToLower(conv.Title), query) ||
-			strings.Contains(strings.ToLower(conv.Summary), query) {
-			c.filteredConversations = append(c.filteredConversations, conv)
+	if err := c.repo.SetSavedConversationArchived(ctx, conv.ID, archived); err != nil {
+		c.updateError = err
+		logger.Error("failed to archive conversation", "error", err, "id", conv.ID)
+		return
+	}
+	c.updateError = nil
+
+	for i := range c.conversations {
+		if c.conversations[i].ID == conv.ID {
+			c.conversations[i].Archived = archived
+		}
+	}
+	c.filterConversations()
+	c.syncTable()
+}
+
+// handleTagRequest opens the tag editor for the highlighted conversation,
+// prefilled with its current tags.
+func (c *ConversationSelectorImpl) handleTagRequest() (tea.Model, tea.Cmd) {
+	cursor := c.table.Cursor()
+	if cursor >= len(c.filteredConversations) {
+		return c, nil
+	}
+
+	c.tagMode = true
+	c.updateError = nil
+	c.tagInput = strings.Join(c.filteredConversations[cursor].Tags, ", ")
+	return c, nil
+}
+
+func (c *ConversationSelectorImpl) handleTagInput(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, conversationSelectorKeys.cancel):
+		c.tagMode = false
+	case key.Matches(msg, conversationSelectorKeys.enter):
+		c.saveTags()
+	case key.Matches(msg, conversationSelectorKeys.backspace):
+		if c.tagInput != "" {
+			_, size := utf8.DecodeLastRuneInString(c.tagInput)
+			c.tagInput = c.tagInput[:len(c.tagInput)-size]
+		}
+	default:
+		c.tagInput += msg.Text
+	}
+	return c, nil
+}
+
+// saveTags persists the edited tags and updates the listed conversation,
+// keeping the cursor on it when it still matches the search.
+func (c *ConversationSelectorImpl) saveTags() {
+	c.tagMode = false
+	cursor := c.table.Cursor()
+	if cursor >= len(c.filteredConversations) {
+		return
+	}
+	id := c.filteredConversations[cursor].ID
+	tags := parseTags(c.tagInput)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.repo.SetSavedConversationTags(ctx, id, tags); err != nil {
+		c.updateError = err
+		logger.Error("failed to save conversation tags", "error", err, "id", id)
+		return
+	}
+	c.updateError = nil
+
+	for i := range c.conversations {
+		if c.conversations[i].ID == id {
+			c.conversations[i].Tags = tags
+		}
+	}
+	c.filterConversations()
+	c.syncTable()
+	for i, conv := range c.filteredConversations {
+		if conv.ID == id {
+			c.table.SetCursor(i)
 		}
 	}
 }
@@ -442,6 +580,9 @@ func (c *ConversationSelectorImpl) Reset() {
 	c.cancelled = false
 	c.searchQuery = ""
 	c.searchMode = false
+	c.tagMode = false
+	c.tagInput = ""
+	c.updateError = nil
 	c.loading = true
 	c.loadError = nil
 	c.conversations = make([]domain.ConversationSummary, 0)
@@ -482,7 +623,19 @@ func (c *ConversationSelectorImpl) writeSearchInfo(b *strings.Builder) {
 			c.styleProvider.RenderWithColor("Search: "+c.searchQuery, c.styleProvider.GetThemeColor("status")),
 			c.styleProvider.RenderWithColor("│", c.styleProvider.GetThemeColor("accent")))
 	} else {
-		helpText := fmt.Sprintf("Press / to search • %d conversations available", len(c.conversations))
+		archived := 0
+		for _, conv := range c.conversations {
+			if conv.Archived {
+				archived++
+			}
+		}
+		helpText := fmt.Sprintf("Press / to search • %d conversations available", len(c.conversations)-archived)
+		if archived > 0 {
+			helpText += fmt.Sprintf(" • %d archived (is:archived)", archived)
+		}
+		if c.searchQuery != "" {
+			helpText = fmt.Sprintf("Filter: %s • %d of %d conversations", c.searchQuery, len(c.filteredConversations), len(c.conversations))
+		}
 		fmt.Fprintf(b, "%s\n\n", c.styleProvider.RenderDimText(helpText))
 	}
 }
@@ -511,10 +664,14 @@ func (c *ConversationSelectorImpl) writeFooter(b *strings.Builder) {
 	b.WriteString("\n")
 
 	if c.searchMode {
-		helpText := "Type to search, ↑↓ to navigate, Enter to select, Esc to clear search"
+		helpText := "Type to search (tag:name, model:name, since:7d, before:2006-01-02, is:archived), ↑↓ to navigate, Enter to select, Esc to clear search"
 		fmt.Fprintf(b, "%s", c.styleProvider.RenderDimText(helpText))
 	} else {
-		helpText := "Use ↑↓ arrows to navigate, Enter to select, d to delete, / to search, Esc/Ctrl+C to cancel"
+		archiveHint := "a to archive"
+		if parseConversationFilter(c.searchQuery, time.Now()).archived {
+			archiveHint = "a to restore"
+		}
+		helpText := "Use ↑↓ arrows to navigate, Enter to select, t to tag, " + archiveHint + ", d to delete, / to search, Esc/Ctrl+C to cancel"
 		fmt.Fprintf(b, "%s", c.styleProvider.RenderDimText(helpText))
 	}
 }
@@ -546,6 +703,30 @@ func (c *ConversationSelectorImpl) writeDeleteConfirmation(b *strings.Builder) s
 	return b.String()
 }
 
+// writeTagEditor writes the tag input below the list
+func (c *ConversationSelectorImpl) writeTagEditor(b *strings.Builder) string {
+	if c.table.Cursor() >= len(c.filteredConversations) {
+		return b.String()
+	}
+
+	conv := c.filteredConversations[c.table.Cursor()]
+
+	c.writeSearchInfo(b)
+	c.writeConversationList(b)
+
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", c.width))
+	b.WriteString("\n\n")
+
+	fmt.Fprintf(b, "%s\n", c.styleProvider.RenderDimText("Tags for: "+conv.Title))
+	fmt.Fprintf(b, "%s%s\n\n",
+		c.styleProvider.RenderWithColor("Tags: "+c.tagInput, c.styleProvider.GetThemeColor("status")),
+		c.styleProvider.RenderWithColor("│", c.styleProvider.GetThemeColor("accent")))
+	fmt.Fprintf(b, "%s", c.styleProvider.RenderDimText("Comma-separated, Enter to save, Esc to cancel"))
+
+	return b.String()
+}
+
 // writeDeleteError writes the delete error message
 func (c *ConversationSelectorImpl) writeDeleteError(b *strings.Builder) {
 	errorColor := c.styleProvider.GetThemeColor("error")
@@ -1,8 +1,11 @@
 package components
 
 import (
+	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
+
 	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
 	uimocks "github.com/inference-gateway/cli/tests/mocks/ui"
 
@@ -105,3 +108,107 @@ func TestConversationSelectorImpl_ResetAllowsReuse(t *testing.T) {
 		t.Error("Expected selector to be selected after second use")
 	}
 }
+
+func TestConversationLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		conv  domain.ConversationSummary
+		forks int
+		want  string
+	}{
+		{name: "plain", conv: domain.ConversationSummary{Title: "Fix the build"}, want: "Fix the build"},
+		{name: "fork", conv: domain.ConversationSummary{Title: "Fix the build", ParentID: "p"}, want: "↳ Fix the build"},
+		{name: "parent", conv: domain.ConversationSummary{Title: "Fix the build"}, forks: 2, want: "Fix the build ⑂2"},
+		{
+			name:  "long title keeps the markers",
+			conv:  domain.ConversationSummary{Title: "A very long conversation title indeed", ParentID: "p"},
+			forks: 1,
+			want:  "↳ A very long conve... ⑂1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conversationLabel(tt.conv, tt.forks); got != tt.want {
+				t.Errorf("conversationLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConversationSelectorImpl_TagConversation(t *testing.T) {
+	mockRepo := &shortcutsmocks.FakePersistentConversationRepository{}
+	fakeTheme := &uimocks.FakeTheme{}
+	fakeThemeService := &domainmocks.FakeThemeService{}
+	fakeThemeService.GetCurrentThemeReturns(fakeTheme)
+
+	selector := NewConversationSelector(mockRepo, styles.NewProvider(fakeThemeService))
+	selector.handleConversationsLoaded(domain.ConversationsLoadedEvent{Conversations: []any{
+		domain.ConversationSummary{ID: "one", Title: "First", Tags: []string{"draft"}},
+		domain.ConversationSummary{ID: "two", Title: "Second"},
+	}})
+
+	selector.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	if !selector.tagMode || selector.tagInput != "draft" {
+		t.Fatalf("t should open the tag editor with the current tags, got %q", selector.tagInput)
+	}
+	for _, r := range ", Work Item" {
+		selector.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	selector.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if mockRepo.SetSavedConversationTagsCallCount() != 1 {
+		t.Fatal("enter should save the tags")
+	}
+	_, id, tags := mockRepo.SetSavedConversationTagsArgsForCall(0)
+	if id != "one" || strings.Join(tags, ",") != "draft,work-item" {
+		t.Errorf("saved tags %v for %q", tags, id)
+	}
+	if selector.done || selector.tagMode {
+		t.Error("saving tags should return to the list without selecting")
+	}
+
+	selector.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "tag:work-item" {
+		selector.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	if len(selector.filteredConversations) != 1 || selector.filteredConversations[0].ID != "one" {
+		t.Errorf("the new tag should be searchable, got %+v", selector.filteredConversations)
+	}
+}
+
+func TestConversationSelectorImpl_ArchiveConversation(t *testing.T) {
+	mockRepo := &shortcutsmocks.FakePersistentConversationRepository{}
+	fakeThemeService := &domainmocks.FakeThemeService{}
+	fakeThemeService.GetCurrentThemeReturns(&uimocks.FakeTheme{})
+
+	selector := NewConversationSelector(mockRepo, styles.NewProvider(fakeThemeService))
+	selector.handleConversationsLoaded(domain.ConversationsLoadedEvent{Conversations: []any{
+		domain.ConversationSummary{ID: "one", Title: "First"},
+		domain.ConversationSummary{ID: "two", Title: "Second"},
+		domain.ConversationSummary{ID: "old", Title: "Old", Archived: true},
+	}})
+
+	if len(selector.filteredConversations) != 2 {
+		t.Fatalf("archived conversations should be hidden by default, got %d rows", len(selector.filteredConversations))
+	}
+
+	selector.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if mockRepo.SetSavedConversationArchivedCallCount() != 1 {
+		t.Fatal("a should archive the highlighted conversation")
+	}
+	if _, id, archived := mockRepo.SetSavedConversationArchivedArgsForCall(0); id != "one" || !archived {
+		t.Errorf("archived %q = %v, want one = true", id, archived)
+	}
+	if len(selector.filteredConversations) != 1 || selector.filteredConversations[0].ID != "two" {
+		t.Errorf("the archived conversation should leave the list, got %+v", selector.filteredConversations)
+	}
+
+	selector.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "is:archived" {
+		selector.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	if len(selector.filteredConversations) != 2 {
+		t.Errorf("is:archived should list both archived conversations, got %+v", selector.filteredConversations)
+	}
+}
@@ -9,11 +9,11 @@ import (
 	"path/filepath"
 	"regexp"
 	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	spinner "charm.land/bubbles/v2/spinner"
-	viewport "charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 
@@ -36,6 +36,10 @@ const (
 	NavigationModeNormal NavigationMode = iota
 	// NavigationModeMessageHistory is the mode for navigating message history
 	NavigationModeMessageHistory
+	// NavigationModeOutline is the mode for jumping to an entry via the outline
+	NavigationModeOutline
+	// NavigationModeCodeBlocks is the mode for picking a code block to copy
+	NavigationModeCodeBlocks
 )
 
 // backgroundTaskRemovalDelay is how long a terminal-state background-task
@@ -87,13 +91,14 @@ type subagentRemovalTickMsg struct {
 // only, so it holds no locks. Off-loop producers must go through Program.Send.
 type ConversationView struct {
 	conversation           []domain.ConversationEntry
-	Viewport               viewport.Model
+	Viewport               ConversationViewport
 	width                  int
 	height                 int
 	expandedToolResults    map[int]bool
 	expandedThinkingBlocks map[int]bool
 	allToolsExpanded       bool
 	allThinkingExpanded    bool
+	thinkingHidden         bool
 	defaultExpandedTools   map[string]bool
 	toolFormatter          domain.ToolFormatter
 	lineFormatter          *formatting.ConversationLineFormatter
@@ -106,13 +111,33 @@ type ConversationView struct {
 	rawFormat              bool
 	userScrolledUp         bool
 	stateManager           domain.PlanApprovalUIManager
-	renderedContent        string
+	rendered               *lineIndex
+
+	// newContentBelow is set when messages arrive while scrolled back and
+	// cleared on returning to the bottom.
+	newContentBelow bool
+
+	// Minimap: minimapMarks classifies each rendered entry, in the order of
+	// the line index segments; screenTop maps mouse clicks to its rows.
+	minimapEnabled bool
+	minimapMarks   []minimapMark
+	screenTop      int
 
 	// renderCache memoizes per-entry rendered output keyed by conversation
 	// index; an entry re-renders only when its fingerprint changes. Cleared
-	// on theme refresh, which restyles without touching entry state.
+	// on theme refresh, which restyles without touching entry state. Only
+	// entries near the rendered window stay in it (renderCacheRadius).
 	renderCache map[int]renderCacheEntry
 
+	// layout places each shown entry in the rendered content. Entries away
+	// from the screen are not rendered: they stand in as blank lines of
+	// their measured height (entryHeights, kept after their lines leave the
+	// cache) or an estimate, and layoutPartial is set while any do.
+	layout        []entrySpan
+	entryHeights  map[int]entryHeight
+	layoutPartial bool
+	blankRows     []string
+
 	// Streaming state
 	streamingBuffer          strings.Builder
 	streamingReasoningBuffer strings.Builder
@@ -121,6 +146,12 @@ type ConversationView struct {
 	streamingDirty           bool
 	streamingRenderArmed     bool
 
+	// streamingPrefix is the rendered lines of the conversation entries,
+	// kept by the last full rebuild so render ticks only redo the tail. streamingStable holds the streamed markdown blocks that are
+	// complete and will not change as more text arrives.
+	streamingPrefix [][]string
+	streamingStable streamingStableRender
+
 	keyHintFormatter *hints.Formatter
 
 	// Message history navigation
@@ -128,6 +159,30 @@ type ConversationView struct {
 	messageSnapshots     []domain.MessageSnapshot
 	historySelectedIndex int
 
+	// Conversation outline
+	outlineItems         []outlineItem
+	outlineSelectedIndex int
+	outlineReturnOffset  int
+
+	// Code block picker
+	codeBlocks             []formatting.CodeBlock
+	codeBlockSelectedIndex int
+	codeBlockReturnOffset  int
+
+	// inlineImages draws image attachments with terminal graphics, nil when
+	// images are shown as text placeholders.
+	inlineImages *inlineImages
+
+	// contextMenu is the menu opened by right-clicking an entry, nil when
+	// closed.
+	contextMenu *contextMenu
+
+	// search is the active in-conversation search, nil when not searching.
+	search *conversationSearch
+
+	// selection is the active visual selection, nil outside selection mode.
+	selection *lineSelection
+
 	// Inline background-task indicators for A2A_SubmitTask delegations.
 	// Keyed by remote task ID. Entries are inserted on
 	// A2ATaskSubmittedEvent, updated on status/complete/fail events, and
@@ -146,10 +201,8 @@ type ConversationView struct {
 }
 
 func NewConversationView(styleProvider *styles.Provider) *ConversationView {
-	vp := viewport.New(viewport.WithWidth(80), viewport.WithHeight(20))
-	vp.SetContent("")
+	vp := NewConversationViewport(80, 20)
 	vp.MouseWheelEnabled = true
-	vp.MouseWheelDelta = 3
 
 	var mdRenderer *markdown.Renderer
 	if themeService := styleProvider.GetThemeService(); themeService != nil {
@@ -176,6 +229,7 @@ func NewConversationView(styleProvider *styles.Provider) *ConversationView {
 		subagentTasks:          make(map[string]*subagentDisplay),
 		backgroundSpinner:      bgSpin,
 		renderCache:            make(map[int]renderCacheEntry),
+		entryHeights:           make(map[int]entryHeight),
 	}
 }
 
@@ -227,12 +281,15 @@ func (cv *ConversationView) SetAgentModelResolver(resolver func(url string) stri
 func (cv *ConversationView) SetConversation(conversation []domain.ConversationEntry) {
 	wasAtBottom := cv.Viewport.AtBottom()
 	if len(conversation) < len(cv.conversation) {
-		cv.renderCache = make(map[int]renderCacheEntry)
+		cv.resetRenderCache()
+	}
+	if len(conversation) > len(cv.conversation) {
+		cv.markNewContentBelow()
 	}
 	cv.conversation = conversation
 	cv.updatePlainTextLines()
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContentFull()
 		if wasAtBottom {
 			cv.Viewport.GotoBottom()
@@ -262,7 +319,7 @@ func (cv *ConversationView) ToggleToolResultExpansion(index int) {
 	if index >= 0 && index < len(cv.conversation) {
 		cv.rebuildPreservingScroll(func() {
 			cv.expandedToolResults[index] = !cv.IsToolResultExpanded(index)
-		}, index)
+		})
 	}
 }
 
@@ -281,63 +338,30 @@ func (cv *ConversationView) ToggleAllToolResultsExpansion() {
 		for _, i := range changed {
 			cv.expandedToolResults[i] = expand
 		}
-	}, changed...)
+	})
 }
 
 // rebuildPreservingScroll applies mutate (the expand/collapse state change) and
 // re-renders the viewport while keeping the content the user is looking at anchored
 // in place. Expanding an entry that sits above the viewport top would otherwise shift
-// everything down and make the view jump; we offset by the height delta of the changed
-// entries above the current scroll position. The old layout is measured before mutate
-// runs, so the delta is real. When the user is following the tail we stay pinned to the
-// bottom.
-func (cv *ConversationView) rebuildPreservingScroll(mutate func(), changed ...int) {
-	if cv.navigationMode == NavigationModeMessageHistory {
-		mutate()
-		return
-	}
-	if !cv.userScrolledUp {
-		mutate()
-		cv.updateViewportContentFull()
-		return
-	}
-
-	oldOffset := cv.Viewport.YOffset()
-	oldSpans := cv.entryLineSpans()
-
+// everything down and make the view jump; the rebuild keeps the first entry on screen
+// at its row (see scrollAnchor). When the user is following the tail we stay pinned
+// to the bottom.
+func (cv *ConversationView) rebuildPreservingScroll(mutate func()) {
 	mutate()
-	cv.updateViewportContentFull()
-
-	newSpans := cv.entryLineSpans()
-	delta := 0
-	for _, idx := range changed {
-		old, ok := oldSpans[idx]
-		if !ok || old[0] >= oldOffset {
-			continue
-		}
-		if nw, ok := newSpans[idx]; ok {
-			delta += nw[1] - old[1]
-		}
-	}
-	if delta != 0 {
-		cv.Viewport.SetYOffset(oldOffset + delta)
+	if cv.navigationMode == NavigationModeNormal {
+		cv.updateViewportContentFull()
 	}
 }
 
 // entryLineSpans returns, per visible conversation entry, its {startLine, height}
-// in the rendered viewport content - the same coordinate space YOffset uses. Heights
-// come from the same cached per-entry render updateViewportContentFull emits, so the
-// two stay in lockstep.
+// in the rendered viewport content - the same coordinate space YOffset uses. They
+// come from the layout of the last rebuild, so entries not rendered yet report
+// their estimated height.
 func (cv *ConversationView) entryLineSpans() map[int][2]int {
-	spans := make(map[int][2]int, len(cv.conversation))
-	line := 0
-	for i, entry := range cv.conversation {
-		if entry.Hidden {
-			continue
-		}
-		h := cv.styleProvider.GetHeight(cv.renderEntryCached(entry, i))
-		spans[i] = [2]int{line, h}
-		line += h
+	spans := make(map[int][2]int, len(cv.layout))
+	for _, span := range cv.layout {
+		spans[span.index] = [2]int{span.start, span.height}
 	}
 	return spans
 }
@@ -402,7 +426,23 @@ func (cv *ConversationView) ToggleAllThinkingExpansion() {
 		for _, i := range changed {
 			cv.expandedThinkingBlocks[i] = cv.allThinkingExpanded
 		}
-	}, changed...)
+	})
+}
+
+// ToggleThinkingVisibility hides or shows every thinking block, reporting
+// whether they are now shown.
+func (cv *ConversationView) ToggleThinkingVisibility() bool {
+	cv.rebuildPreservingScroll(func() {
+		cv.thinkingHidden = !cv.thinkingHidden
+	})
+	return !cv.thinkingHidden
+}
+
+// SetThinkingHidden sets whether thinking blocks are left out of the
+// conversation, from chat.hide_thinking
+func (cv *ConversationView) SetThinkingHidden(hidden bool) {
+	cv.thinkingHidden = hidden
+	cv.resetRenderCache()
 }
 
 func (cv *ConversationView) IsThinkingExpanded(index int) bool {
@@ -415,7 +455,7 @@ func (cv *ConversationView) IsThinkingExpanded(index int) bool {
 // ToggleRawFormat toggles between raw and rendered markdown display
 func (cv *ConversationView) ToggleRawFormat() {
 	cv.rawFormat = !cv.rawFormat
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContentFull()
 	}
 }
@@ -425,13 +465,24 @@ func (cv *ConversationView) IsRawFormat() bool {
 	return cv.rawFormat
 }
 
+// SetSyntaxHighlighting toggles colorized code blocks in rendered markdown
+func (cv *ConversationView) SetSyntaxHighlighting(enabled bool) {
+	if cv.markdownRenderer == nil {
+		return
+	}
+	cv.markdownRenderer.SetSyntaxHighlighting(enabled)
+	cv.resetRenderCache()
+	cv.streamingStable = streamingStableRender{}
+}
+
 // RefreshTheme rebuilds the markdown renderer with current theme colors
 func (cv *ConversationView) RefreshTheme() {
 	if cv.markdownRenderer != nil {
 		cv.markdownRenderer.RefreshTheme()
 	}
-	cv.renderCache = make(map[int]renderCacheEntry)
-	if cv.navigationMode != NavigationModeMessageHistory {
+	cv.resetRenderCache()
+	cv.streamingStable = streamingStableRender{}
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContentFull()
 	}
 }
@@ -440,7 +491,8 @@ func (cv *ConversationView) RefreshTheme() {
 // This returns the actual rendered content that was displayed in the viewport,
 // preserving the same text wrapping and formatting
 func (cv *ConversationView) GetPlainTextLines() []string {
-	lines := strings.Split(cv.renderedContent, "\n")
+	cv.renderWholeConversation()
+	lines := cv.rendered.Lines(0, cv.rendered.Len())
 
 	for i, line := range lines {
 		lines[i] = strings.TrimRight(line, " ")
@@ -476,7 +528,7 @@ func (cv *ConversationView) SetHeight(height int) {
 }
 
 func (cv *ConversationView) Render() string {
-	if cv.navigationMode == NavigationModeMessageHistory {
+	if cv.navigationMode != NavigationModeNormal {
 		viewportContent := cv.Viewport.View()
 
 		lines := strings.Split(viewportContent, "\n")
@@ -490,6 +542,8 @@ func (cv *ConversationView) Render() string {
 
 	if len(cv.conversation) == 0 {
 		cv.Viewport.SetContent(cv.renderWelcome())
+	} else {
+		cv.renderVisibleEntries()
 	}
 	viewportContent := cv.Viewport.View()
 
@@ -499,7 +553,7 @@ func (cv *ConversationView) Render() string {
 	for i, line := range lines {
 		lines[i] = leftPadding + strings.TrimRight(line, " ")
 	}
-	return strings.Join(lines, "\n")
+	return strings.Join(cv.overlayContextMenu(cv.overlayMinimap(cv.overlayScrollIndicator(lines))), "\n")
 }
 
 func (cv *ConversationView) updateViewportContent() {
@@ -540,6 +594,87 @@ func (cv *ConversationView) flushStreamingBuffer() {
 	cv.isStreaming = false
 	cv.streamingModel = ""
 	cv.streamingDirty = false
+	cv.streamingStable = streamingStableRender{}
+}
+
+// streamingStableRender is the rendered output of the leading, complete
+// markdown blocks of the streaming message: content[:end] at width.
+type streamingStableRender struct {
+	end      int
+	rendered string
+	width    int
+}
+
+// renderStreamingMarkdown renders the streaming message body. Blocks that a
+// later chunk can no longer change are rendered once and reused; only the
+// trailing, still growing block is rendered on every tick.
+func (cv *ConversationView) renderStreamingMarkdown(content string, wrapWidth int) string {
+	if cv.markdownRenderer == nil || cv.rawFormat {
+		return formatting.FormatResponsiveMessage(content, wrapWidth)
+	}
+
+	st := &cv.streamingStable
+	if st.width != wrapWidth || st.end > len(content) {
+		*st = streamingStableRender{width: wrapWidth}
+	}
+
+	if end := stableMarkdownEnd(content); end > st.end {
+		block := strings.TrimRight(cv.applyMarkdownIfEnabled(content[st.end:end], wrapWidth), "\n")
+		if st.rendered != "" && block != "" {
+			st.rendered += "\n\n"
+		}
+		st.rendered += block
+		st.end = end
+	}
+
+	tail := ""
+	if strings.TrimSpace(content[st.end:]) != "" {
+		tail = cv.applyMarkdownIfEnabled(content[st.end:], wrapWidth)
+	}
+	switch {
+	case st.rendered == "":
+		return tail
+	case tail == "":
+		return st.rendered
+	default:
+		return st.rendered + "\n\n" + tail
+	}
+}
+
+// stableMarkdownEnd returns the offset of the last block boundary in content:
+// the start of an unindented line following a blank line, outside any code
+// fence. Text before it forms complete blocks that appending cannot alter.
+func stableMarkdownEnd(content string) int {
+	end, offset := 0, 0
+	fence := ""
+	afterBlank := false
+	for line := range strings.SplitAfterSeq(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasSuffix(line, "\n") {
+			// The unfinished last line still starts a new block once its first
+			// character rules out an indented continuation.
+			if fence == "" && afterBlank && trimmed != "" && line[0] != ' ' && line[0] != '\t' {
+				end = offset
+			}
+			break
+		}
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"), strings.HasPrefix(trimmed, "~~~"):
+			if afterBlank && line[0] != ' ' && line[0] != '\t' {
+				end = offset
+			}
+			fence = trimmed[:3]
+		case trimmed != "" && afterBlank && line[0] != ' ' && line[0] != '\t':
+			end = offset
+		}
+		afterBlank = fence == "" && trimmed == ""
+		offset += len(line)
+	}
+	return end
 }
 
 // renderStreamingContent renders the currently streaming assistant message
@@ -556,7 +691,7 @@ func (cv *ConversationView) renderStreamingContent() string {
 		result.WriteString(thinkingBlock)
 	}
 
-	streamingContent = cv.applyMarkdownIfEnabled(streamingContent, max(cv.width-2, 40))
+	streamingContent = cv.renderStreamingMarkdown(streamingContent, max(cv.width-2, 40))
 
 	assistantColor := cv.styleProvider.GetThemeColor("assistant")
 	var roleStyled string
@@ -573,20 +708,231 @@ func (cv *ConversationView) renderStreamingContent() string {
 	return result.String()
 }
 
-// updateViewportContentFull performs a full rebuild of the viewport content
+// renderWindowMargin is how many lines above and below the screen a rebuild
+// renders, so ordinary scrolling finds the entries it reaches already drawn.
+const renderWindowMargin = 100
+
+// renderCacheRadius is how many entries either side of the rendered window
+// keep their rendering cached after a rebuild.
+const renderCacheRadius = 50
+
+// entrySpan places one shown entry in the rendered content. lines is nil
+// while the entry is not rendered and stands in at its known or estimated
+// height.
+type entrySpan struct {
+	index       int
+	fingerprint uint64
+	start       int
+	height      int
+	lines       []string
+}
+
+// entryHeight is a measured entry height, valid while the entry's
+// fingerprint is unchanged.
+type entryHeight struct {
+	fingerprint uint64
+	lines       int
+}
+
+// updateViewportContentFull performs a full rebuild of the viewport content.
+// Only the entries on or near the screen are rendered; the others keep their
+// cached lines or stand in at their measured or estimated height until they
+// scroll into view (renderVisibleEntries). Search and line selection read the
+// whole rendering, so while either is open every entry is rendered.
 func (cv *ConversationView) updateViewportContentFull() {
-	var b strings.Builder
+	whole := cv.search != nil || cv.selection != nil
+	cv.layoutEntries(whole, cv.userScrolledUp && cv.navigationMode == NavigationModeNormal)
+}
+
+// renderWholeConversation renders every entry still standing in, for the
+// features that read the whole rendering.
+func (cv *ConversationView) renderWholeConversation() {
+	if cv.layoutPartial {
+		cv.layoutEntries(true, cv.userScrolledUp && cv.navigationMode == NavigationModeNormal)
+	}
+}
+
+// renderVisibleEntries rebuilds when the screen has scrolled onto entries that
+// are not rendered yet. Render calls it before drawing, so a stand-in is never
+// shown.
+func (cv *ConversationView) renderVisibleEntries() {
+	if !cv.layoutPartial {
+		return
+	}
+	top := cv.Viewport.YOffset()
+	bottom := top + cv.Viewport.Height()
+	first := sort.Search(len(cv.layout), func(i int) bool {
+		return cv.layout[i].start+cv.layout[i].height > top
+	})
+	for _, span := range cv.layout[first:] {
+		if span.start >= bottom {
+			return
+		}
+		if span.lines == nil {
+			cv.layoutEntries(false, true)
+			return
+		}
+	}
+}
+
+// layoutEntries lays out the shown entries, renders those within
+// renderWindowMargin of the screen (all of them when whole) and shows the
+// result. With keepScroll the screen stays on the same content, otherwise it
+// follows the bottom. Rendering replaces estimated heights with real ones,
+// which can move the window, so it repeats until the window is rendered.
+func (cv *ConversationView) layoutEntries(whole, keepScroll bool) {
+	anchor, anchorRow, rowsToEnd := cv.scrollAnchor()
+
+	tail := cv.renderStreamingTail()
+	tailLines := strings.Count(tail, "\n") + 1
 
-	displayIndex := 0
+	spans := make([]entrySpan, 0, len(cv.conversation))
+	marks := make([]minimapMark, 0, len(cv.conversation))
 	for i, entry := range cv.conversation {
 		if entry.Hidden {
 			continue
 		}
-		b.WriteString(cv.renderEntryCached(entry, i))
-		b.WriteString("\n")
-		displayIndex++
+		spans = append(spans, cv.placeEntry(entry, i))
+		marks = append(marks, minimapMarkOf(entry))
 	}
 
+	top, first, last := 0, -1, -1
+	for rendered := true; rendered; {
+		total := stackEntrySpans(spans) + tailLines
+		anchored := slices.IndexFunc(spans, func(s entrySpan) bool { return s.index == anchor })
+		switch {
+		case !keepScroll:
+			top = max(total-cv.Viewport.Height(), 0)
+		case anchored >= 0:
+			top = max(spans[anchored].start-anchorRow, 0)
+		default:
+			top = max(total-rowsToEnd, 0)
+		}
+
+		from, to := top-renderWindowMargin, top+cv.Viewport.Height()+renderWindowMargin
+		rendered, first, last = false, -1, -1
+		for k := range spans {
+			span := &spans[k]
+			if !whole && (span.start >= to || span.start+span.height <= from) {
+				continue
+			}
+			if first < 0 {
+				first = span.index
+			}
+			last = span.index
+			if span.lines != nil {
+				continue
+			}
+			span.lines = cv.renderEntryLines(cv.conversation[span.index], span.index)
+			span.height = len(span.lines)
+			cv.entryHeights[span.index] = entryHeight{fingerprint: span.fingerprint, lines: span.height}
+			rendered = true
+		}
+	}
+
+	segments := make([][]string, 0, len(spans))
+	cv.layoutPartial = false
+	for _, span := range spans {
+		if span.lines == nil {
+			cv.layoutPartial = true
+			segments = append(segments, cv.blankLines(span.height))
+			continue
+		}
+		segments = append(segments, span.lines)
+	}
+	if !whole && first >= 0 {
+		for index := range cv.renderCache {
+			if index < first-renderCacheRadius || index > last+renderCacheRadius {
+				delete(cv.renderCache, index)
+			}
+		}
+	}
+
+	cv.layout = spans
+	cv.minimapMarks = marks
+	cv.streamingPrefix = segments
+	cv.applyRenderedTail(tail)
+	if keepScroll && cv.selection == nil {
+		cv.Viewport.SetYOffset(top)
+	}
+}
+
+// scrollAnchor picks what a rebuild keeps in place while the user is scrolled
+// back: the first entry starting on or below the top of the screen, at its
+// row, or failing that (the top is in the last entry or the tail) the
+// distance from the top to the end of the content.
+func (cv *ConversationView) scrollAnchor() (index, row, rowsToEnd int) {
+	offset := cv.Viewport.YOffset()
+	for _, span := range cv.layout {
+		if span.start >= offset {
+			return span.index, span.start - offset, 0
+		}
+	}
+	return -1, 0, cv.rendered.Len() - offset
+}
+
+// placeEntry lays out an entry without rendering it: with its cached lines
+// when the cache is current, else at its measured or estimated height.
+func (cv *ConversationView) placeEntry(entry domain.ConversationEntry, index int) entrySpan {
+	span := entrySpan{index: index, fingerprint: cv.entryFingerprint(entry, index)}
+	if cached, ok := cv.renderCache[index]; ok && cached.fingerprint == span.fingerprint {
+		span.lines = cv.renderEntryLines(entry, index)
+		span.height = len(span.lines)
+		return span
+	}
+	if measured, ok := cv.entryHeights[index]; ok && measured.fingerprint == span.fingerprint {
+		span.height = measured.lines
+		return span
+	}
+	span.height = cv.estimateEntryHeight(entry, index)
+	return span
+}
+
+// stackEntrySpans sets each span's first line and returns their total height.
+func stackEntrySpans(spans []entrySpan) int {
+	line := 0
+	for i := range spans {
+		spans[i].start = line
+		line += spans[i].height
+	}
+	return line
+}
+
+// estimateEntryHeight guesses the height of an entry that has not been
+// rendered: its text wrapped to the width plus the role line and the blank
+// line after it. A collapsed tool result shows only a summary.
+func (cv *ConversationView) estimateEntryHeight(entry domain.ConversationEntry, index int) int {
+	if entry.Message.Role == "tool" && !cv.IsToolResultExpanded(index) {
+		return 3
+	}
+	width := max(cv.width-4, 20)
+	height := 2
+	content, _ := entry.Message.Content.AsMessageContent0()
+	for line := range strings.SplitSeq(content, "\n") {
+		height += max((len(line)+width-1)/width, 1)
+	}
+	return height
+}
+
+// blankLines returns n empty lines standing in for an entry that is not
+// rendered. They share one backing array, so a stand-in costs no copy.
+func (cv *ConversationView) blankLines(n int) []string {
+	if len(cv.blankRows) < n {
+		cv.blankRows = make([]string, 2*n)
+	}
+	return cv.blankRows[:n:n]
+}
+
+// updateViewportStreamingTail re-renders only what follows the conversation
+// entries - tool previews and the streaming message - reusing the entries
+// rendered by the last full rebuild.
+func (cv *ConversationView) updateViewportStreamingTail() {
+	cv.applyRenderedTail(cv.renderStreamingTail())
+}
+
+func (cv *ConversationView) renderStreamingTail() string {
+	var b strings.Builder
+
 	if cv.toolCallRenderer != nil {
 		toolPreviews := cv.toolCallRenderer.RenderPreviews()
 		if toolPreviews != "" {
@@ -597,13 +943,28 @@ func (cv *ConversationView) updateViewportContentFull() {
 
 	shouldRenderStreaming := cv.isStreaming && (cv.streamingBuffer.Len() > 0 || cv.streamingReasoningBuffer.Len() > 0)
 	if shouldRenderStreaming {
-		streamingText := cv.renderStreamingContent()
-		b.WriteString(streamingText)
+		b.WriteString(cv.renderStreamingContent())
 	}
 
-	cv.renderedContent = b.String()
+	return b.String()
+}
+
+// applyRenderedTail indexes the entries' lines followed by the tail and
+// shows them.
+func (cv *ConversationView) applyRenderedTail(tail string) {
+	prefix := cv.streamingPrefix[:len(cv.streamingPrefix):len(cv.streamingPrefix)]
+	cv.rendered = newLineIndex(append(prefix, strings.Split(tail, "\n")))
+
+	if cv.search != nil {
+		cv.refreshSearch(false)
+		return
+	}
+	if cv.selection != nil {
+		cv.refreshSelection()
+		return
+	}
 
-	cv.Viewport.SetContent(cv.renderedContent)
+	cv.Viewport.SetLines(cv.rendered)
 	if !cv.userScrolledUp {
 		cv.Viewport.GotoBottom()
 	}
@@ -667,10 +1028,17 @@ func (cv *ConversationView) renderCompactWelcome() string {
 	return cv.styleProvider.RenderBorderedBox(content, cv.styleProvider.GetThemeColor("accent"), 1, 1)
 }
 
+// resetRenderCache drops every memoized rendering and measured height.
+func (cv *ConversationView) resetRenderCache() {
+	cv.renderCache = make(map[int]renderCacheEntry)
+	cv.entryHeights = make(map[int]entryHeight)
+}
+
 // renderCacheEntry is one memoized entry rendering.
 type renderCacheEntry struct {
 	fingerprint uint64
 	rendered    string
+	lines       []string
 }
 
 // renderEntryCached returns the memoized rendering for the entry at index,
@@ -691,6 +1059,21 @@ func (cv *ConversationView) renderEntryCached(entry domain.ConversationEntry, in
 	return rendered
 }
 
+// renderEntryLines is renderEntryCached split into lines, with the split
+// memoized alongside the rendering.
+func (cv *ConversationView) renderEntryLines(entry domain.ConversationEntry, index int) []string {
+	rendered := cv.renderEntryCached(entry, index)
+	cached, ok := cv.renderCache[index]
+	if !ok || cached.rendered != rendered {
+		return strings.Split(rendered, "\n")
+	}
+	if cached.lines == nil {
+		cached.lines = strings.Split(rendered, "\n")
+		cv.renderCache[index] = cached
+	}
+	return cached.lines
+}
+
 // entryFingerprint hashes every input that affects an entry's rendered output.
 // Message text is identified by the entry's creation time rather than hashed:
 // entries are append-only, so post-creation changes only touch the mutable
@@ -727,6 +1110,7 @@ func (cv *ConversationView) entryFingerprint(entry domain.ConversationEntry, ind
 	writeInt(int64(entry.ToolApprovalStatus))
 	writeInt(int64(entry.PlanApprovalStatus))
 	writeBool(entry.PendingToolCall != nil)
+	writeBool(entry.ChangeSummary != nil)
 	if entry.Message.ToolCalls != nil {
 		writeInt(int64(len(*entry.Message.ToolCalls)))
 	}
@@ -737,16 +1121,22 @@ func (cv *ConversationView) entryFingerprint(entry domain.ConversationEntry, ind
 		writeInt(int64(te.Duration))
 		writeInt(int64(len(te.Error)))
 		writeInt(int64(len(te.Diff)))
+		writeInt(int64(len(te.Images)))
 	}
 
 	writeInt(int64(cv.width))
 	writeBool(cv.rawFormat)
 	writeBool(cv.IsToolResultExpanded(index))
 	writeBool(cv.IsThinkingExpanded(index))
+	writeBool(cv.thinkingHidden)
 	return h.Sum64()
 }
 
 func (cv *ConversationView) renderEntryWithIndex(entry domain.ConversationEntry, index int) string {
+	return cv.renderEntryBody(entry, index) + cv.renderInlineImages(entry)
+}
+
+func (cv *ConversationView) renderEntryBody(entry domain.ConversationEntry, index int) string {
 	if handled, result := cv.tryRenderSpecialEntry(entry, index); handled {
 		return result
 	}
@@ -768,6 +1158,9 @@ func (cv *ConversationView) tryRenderSpecialEntry(entry domain.ConversationEntry
 			return true, result
 		}
 	case "assistant":
+		if entry.ChangeSummary != nil {
+			return true, cv.renderChangeSummaryEntry(*entry.ChangeSummary)
+		}
 		if entry.IsPlan {
 			return true, cv.renderPlanEntry(entry, index)
 		}
@@ -853,6 +1246,9 @@ func (cv *ConversationView) renderStandardEntry(entry domain.ConversationEntry,
 	if err != nil {
 		contentStr = formatting.ExtractTextFromContent(entry.Message.Content, entry.Images)
 	}
+	if entry.Message.Role == sdk.User {
+		contentStr = formatting.CollapsePastedText(contentStr)
+	}
 
 	rolePrefixLength := len(role) + 2
 	var modelLabelText string
@@ -1122,7 +1518,7 @@ func (cv *ConversationView) parseToolCallFromLine(line string) *ToolCallInfo {
 
 // renderThinkingBlock renders a thinking/reasoning block for assistant messages
 func (cv *ConversationView) renderThinkingBlock(thinking string, _ int, expanded bool) string {
-	if thinking == "" {
+	if thinking == "" || cv.thinkingHidden {
 		return ""
 	}
 
@@ -1198,7 +1594,16 @@ func (cv *ConversationView) buildVersionLine() string {
 	prefix := cv.styleProvider.RenderWithColor("• Version: ", dimColor)
 	versionStyled := cv.styleProvider.RenderWithColor(version, accentColor)
 
-	return prefix + versionStyled
+	return prefix + versionStyled + cv.buildUpdateNotice()
+}
+
+// buildUpdateNotice renders the "new version available" suffix of the
+// version line, or "" when no newer release is known
+func (cv *ConversationView) buildUpdateNotice() string {
+	if cv.versionInfo == nil || cv.versionInfo.Latest == "" {
+		return ""
+	}
+	return cv.styleProvider.RenderWithColor("  ↑ "+cv.versionInfo.Latest+" available - run infer update", cv.styleProvider.GetThemeColor("success"))
 }
 
 // buildVersionShort constructs the short version for compact layout
@@ -1210,7 +1615,7 @@ func (cv *ConversationView) buildVersionShort() string {
 	dimColor := cv.styleProvider.GetThemeColor("dim")
 	version := cv.versionInfo.Version
 
-	return cv.styleProvider.RenderWithColor(version, dimColor)
+	return cv.styleProvider.RenderWithColor(version, dimColor) + cv.buildUpdateNotice()
 }
 
 // getConfigType determines if the config is project-level or userspace
@@ -1300,14 +1705,21 @@ func (cv *ConversationView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
-// handleMouseEvents processes mouse wheel events.
-// Bubble Tea v2 split MouseMsg into concrete types - wheel-up events arrive
-// as MouseWheelMsg with Button == MouseWheelUp.
+// handleMouseEvents processes mouse wheel events, clicks on the minimap and
+// the entry context menu. Bubble Tea v2 split MouseMsg into concrete types -
+// wheel-up events arrive as MouseWheelMsg with Button == MouseWheelUp.
 func (cv *ConversationView) handleMouseEvents(msg tea.Msg) tea.Cmd {
-	if wheel, ok := msg.(tea.MouseWheelMsg); ok {
-		if wheel.Button == tea.MouseWheelUp {
+	switch msg := msg.(type) {
+	case tea.MouseWheelMsg:
+		cv.CloseContextMenu()
+		if msg.Button == tea.MouseWheelUp {
 			cv.userScrolledUp = true
 		}
+	case tea.MouseClickMsg:
+		if cmd, handled := cv.handleContextMenuClick(msg); handled {
+			return cmd
+		}
+		cv.handleMinimapClick(msg)
 	}
 	return nil
 }
@@ -1317,7 +1729,7 @@ func (cv *ConversationView) handleWindowSizeEvents(msg tea.Msg) tea.Cmd {
 	if windowMsg, ok := msg.(tea.WindowSizeMsg); ok {
 		cv.SetWidth(formatting.GetResponsiveWidth(windowMsg.Width))
 		cv.height = windowMsg.Height
-		if cv.navigationMode != NavigationModeMessageHistory {
+		if cv.navigationMode == NavigationModeNormal {
 			cv.updateViewportContentFull()
 		} else {
 			cv.updateMessageHistoryView()
@@ -1329,7 +1741,7 @@ func (cv *ConversationView) handleWindowSizeEvents(msg tea.Msg) tea.Cmd {
 // handlePlanApprovalSelectionChanged refreshes the conversation viewport so
 // the highlighted plan-approval button reflects the new selection index.
 func (cv *ConversationView) handlePlanApprovalSelectionChanged(_ domain.PlanApprovalSelectionChangedEvent, cmd tea.Cmd) (tea.Model, tea.Cmd) {
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cv, cmd
@@ -1337,7 +1749,7 @@ func (cv *ConversationView) handlePlanApprovalSelectionChanged(_ domain.PlanAppr
 
 // handleUpdateHistoryEvent processes history update events
 func (cv *ConversationView) handleUpdateHistoryEvent(msg domain.UpdateHistoryEvent, cmd tea.Cmd) (tea.Model, tea.Cmd) {
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.flushStreamingBuffer()
 		cv.SetConversation(msg.History)
 	}
@@ -1354,7 +1766,7 @@ func (cv *ConversationView) handleToolCallEvents(msg tea.Msg, cmd tea.Cmd) (tea.
 
 // handleBashCommandCompletedEvent processes bash command completion events
 func (cv *ConversationView) handleBashCommandCompletedEvent(msg domain.BashCommandCompletedEvent, cmd tea.Cmd) (tea.Model, tea.Cmd) {
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.SetConversation(msg.History)
 		if cv.toolCallRenderer != nil {
 			cv.toolCallRenderer.ClearPreviews()
@@ -1367,7 +1779,7 @@ func (cv *ConversationView) handleBashCommandCompletedEvent(msg domain.BashComma
 // stream begins - after a mid-stream reconnect the retried response restarts
 // from the top, so the partial output of the broken attempt must not remain.
 func (cv *ConversationView) handleChatStartEvent(cmd tea.Cmd) (tea.Model, tea.Cmd) {
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.flushStreamingBuffer()
 		cv.updateViewportContentFull()
 	}
@@ -1376,7 +1788,7 @@ func (cv *ConversationView) handleChatStartEvent(cmd tea.Cmd) (tea.Model, tea.Cm
 
 // handleStreamingContentEvent processes streaming content events
 func (cv *ConversationView) handleStreamingContentEvent(msg domain.StreamingContentEvent, cmd tea.Cmd) (tea.Model, tea.Cmd) {
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.appendStreamingContent(msg.Content, msg.ReasoningContent, msg.Model)
 		if !cv.streamingRenderArmed {
 			cv.streamingRenderArmed = true
@@ -1386,12 +1798,15 @@ func (cv *ConversationView) handleStreamingContentEvent(msg domain.StreamingCont
 	return cv, cmd
 }
 
-// handleStreamingRenderTick performs the coalesced viewport rebuild: at most one
-// rebuild per tick while streaming, re-arming until streaming ends (issue #888).
+// handleStreamingRenderTick performs the coalesced viewport update: at most one
+// per tick while streaming, re-arming until streaming ends (issue #888). Only
+// the streaming tail is re-rendered; the finished message is rendered in full
+// once it lands in the conversation.
 func (cv *ConversationView) handleStreamingRenderTick(cmd tea.Cmd) (tea.Model, tea.Cmd) {
 	if cv.streamingDirty {
 		cv.streamingDirty = false
-		cv.updateViewportContentFull()
+		cv.updateViewportStreamingTail()
+		cv.markNewContentBelow()
 	}
 	if cv.isStreaming {
 		return cv, tea.Batch(cmd, streamingRenderTick())
@@ -1423,14 +1838,14 @@ func (cv *ConversationView) handleSpinnerTick(msg spinner.TickMsg, cmd tea.Cmd)
 	if cv.toolCallRenderer != nil {
 		updatedRenderer, rendererCmd := cv.toolCallRenderer.Update(msg)
 		cv.toolCallRenderer = updatedRenderer
-		if cv.navigationMode != NavigationModeMessageHistory &&
+		if cv.navigationMode == NavigationModeNormal &&
 			(cv.toolCallRenderer.HasActivePreviews() || cv.hasActiveBackgroundTasks()) {
 			cv.updateViewportContent()
 		}
 		if rendererCmd != nil {
 			cmd = tea.Batch(cmd, rendererCmd)
 		}
-	} else if cv.navigationMode != NavigationModeMessageHistory && cv.hasActiveBackgroundTasks() {
+	} else if cv.navigationMode == NavigationModeNormal && cv.hasActiveBackgroundTasks() {
 		cv.updateViewportContent()
 	}
 	return cv, cmd
@@ -1472,7 +1887,7 @@ func (cv *ConversationView) handleA2ATaskSubmitted(msg domain.A2ATaskSubmittedEv
 
 	startSpinner := !cv.hasOtherActiveBackgroundTasks(msg.TaskID) && !cv.hasActiveSubagents()
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 
@@ -1515,7 +1930,7 @@ func (cv *ConversationView) handleA2ATaskStatusUpdate(msg domain.A2ATaskStatusUp
 		}
 	}
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cv, cmd
@@ -1545,7 +1960,7 @@ func (cv *ConversationView) handleA2ATaskCompleted(msg domain.A2ATaskCompletedEv
 		display.AgentName = extractA2AAgentName(msg.Result.Data)
 	}
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cv, tea.Batch(cmd, scheduleBackgroundTaskRemoval(msg.TaskID))
@@ -1574,7 +1989,7 @@ func (cv *ConversationView) handleA2ATaskFailed(msg domain.A2ATaskFailedEvent, c
 		display.AgentName = extractA2AAgentName(msg.Result.Data)
 	}
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cv, tea.Batch(cmd, scheduleBackgroundTaskRemoval(msg.TaskID))
@@ -1588,7 +2003,7 @@ func (cv *ConversationView) handleRemoveBackgroundTask(msg BackgroundTaskRemoval
 	}
 	delete(cv.backgroundTasks, msg.TaskID)
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cv, cmd
@@ -1612,7 +2027,7 @@ func (cv *ConversationView) handleSubagentSubmitted(msg domain.SubagentSubmitted
 	d.IsTerminal = false
 
 	startSpinner := !cv.hasActiveA2A() && !cv.hasActiveSubagentsExcept(msg.SubagentID)
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	if startSpinner {
@@ -1638,7 +2053,7 @@ func (cv *ConversationView) handleSubagentTerminal(id, label, status string, cmd
 	d.IsTerminal = true
 	d.CompletedAt = time.Now()
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cv, tea.Batch(cmd, scheduleSubagentRemoval(id))
@@ -1650,7 +2065,7 @@ func (cv *ConversationView) handleRemoveSubagent(msg subagentRemovalTickMsg, cmd
 		return cv, cmd
 	}
 	delete(cv.subagentTasks, msg.ID)
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cv, cmd
@@ -2162,7 +2577,7 @@ func computeElapsedString(display *BackgroundTaskDisplay) string {
 // handleDefaultEvents processes all other events
 func (cv *ConversationView) handleDefaultEvents(msg tea.Msg, cmd tea.Cmd) (tea.Model, tea.Cmd) {
 	if _, isKeyMsg := msg.(tea.KeyPressMsg); !isKeyMsg {
-		cv.Viewport, cmd = cv.Viewport.Update(msg)
+		cmd = cv.Viewport.Update(msg)
 		if cv.Viewport.AtBottom() {
 			cv.userScrolledUp = false
 		}
@@ -2455,6 +2870,81 @@ func (cv *ConversationView) renderPendingToolEntry(entry domain.ConversationEntr
 	return result.String() + "\n"
 }
 
+// changeSummaryMaxFiles caps the files listed in a change summary entry
+const changeSummaryMaxFiles = 10
+
+// renderChangeSummaryEntry renders what an agent turn changed as a dim block:
+// a headline with the line totals, a git-status style line per file and the
+// commands the turn ran.
+func (cv *ConversationView) renderChangeSummaryEntry(summary domain.ChangeSummary) string {
+	dim := cv.styleProvider.GetThemeColor("dim")
+	accent := cv.styleProvider.GetThemeColor("accent")
+
+	added, removed := summary.Totals()
+	headline := fmt.Sprintf("%d %s changed", len(summary.Files), pluralize(len(summary.Files), "file", "files"))
+	if n := len(summary.Commands); n > 0 {
+		headline += fmt.Sprintf(" · %d %s run", n, pluralize(n, "command", "commands"))
+	}
+
+	var result strings.Builder
+	result.WriteString(cv.styleProvider.RenderWithColor("Changes:", accent))
+	result.WriteString(" ")
+	result.WriteString(cv.styleProvider.RenderWithColor(headline, dim))
+	result.WriteString(" ")
+	result.WriteString(cv.renderLineCounts(domain.FileChange{Added: added, Removed: removed}))
+	result.WriteString("\n")
+
+	pathWidth := max(cv.width-20, 20)
+	for i, f := range summary.Files {
+		if i == changeSummaryMaxFiles {
+			more := fmt.Sprintf("  … and %d more", len(summary.Files)-i)
+			result.WriteString(cv.styleProvider.RenderWithColor(more, dim) + "\n")
+			break
+		}
+		markerColor := dim
+		switch {
+		case f.Deleted:
+			markerColor = cv.styleProvider.GetThemeColor("error")
+		case f.Created:
+			markerColor = cv.styleProvider.GetThemeColor("success")
+		}
+		result.WriteString("  ")
+		result.WriteString(cv.styleProvider.RenderWithColor(f.Marker(), markerColor))
+		result.WriteString(" ")
+		result.WriteString(formatting.TruncateText(f.Path, pathWidth))
+		if counts := cv.renderLineCounts(f); counts != "" {
+			result.WriteString(" ")
+			result.WriteString(counts)
+		}
+		result.WriteString("\n")
+	}
+	for _, command := range summary.Commands {
+		line := "  $ " + formatting.TruncateText(strings.Join(strings.Fields(command), " "), max(cv.width-6, 20))
+		result.WriteString(cv.styleProvider.RenderWithColor(line, dim) + "\n")
+	}
+	return result.String()
+}
+
+// renderLineCounts renders a change's "+added -removed" in diff colors,
+// leaving out zero counts
+func (cv *ConversationView) renderLineCounts(f domain.FileChange) string {
+	var parts []string
+	if f.Added > 0 {
+		parts = append(parts, cv.styleProvider.RenderWithColor(fmt.Sprintf("+%d", f.Added), cv.styleProvider.GetThemeColor("diffAdd")))
+	}
+	if f.Removed > 0 {
+		parts = append(parts, cv.styleProvider.RenderWithColor(fmt.Sprintf("-%d", f.Removed), cv.styleProvider.GetThemeColor("diffRemove")))
+	}
+	return strings.Join(parts, " ")
+}
+
+func pluralize(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
 // renderApprovalHeader renders a themed one-line header for an approved/rejected tool
 // call, mirroring the completed result status line: "<icon> Name(args) · <status>".
 func (cv *ConversationView) renderApprovalHeader(toolName string, args map[string]any, status domain.ToolApprovalStatus) string {
@@ -2520,7 +3010,7 @@ func (cv *ConversationView) handleToolCallRendererEvents(msg tea.Msg, cmd tea.Cm
 		}
 	}
 
-	if cv.navigationMode != NavigationModeMessageHistory {
+	if cv.navigationMode == NavigationModeNormal {
 		cv.updateViewportContent()
 	}
 	return cmd
@@ -2665,7 +3155,7 @@ func (cv *ConversationView) renderMessageHistorySelector() string {
 
 	maxVisible := max(cv.height-10, 5)
 
-	start, end := cv.calculatePaginationBounds(maxVisible)
+	start, end := calculatePaginationBounds(cv.historySelectedIndex, len(cv.messageSnapshots), maxVisible)
 
 	b.WriteString("\n")
 
@@ -2678,6 +3168,9 @@ func (cv *ConversationView) renderMessageHistorySelector() string {
 		if msg.Role == sdk.Assistant {
 			roleIndicator = "Assistant"
 		}
+		if msg.Pinned {
+			roleIndicator += " 📌"
+		}
 
 		prefixWidth := 25
 		availableWidth := max(cv.width-prefixWidth, 20)
@@ -2718,14 +3211,14 @@ func (cv *ConversationView) renderMessageHistorySelector() string {
 	return b.String()
 }
 
-// calculatePaginationBounds calculates the start and end indices for pagination
-func (cv *ConversationView) calculatePaginationBounds(maxVisible int) (int, int) {
-	totalMessages := len(cv.messageSnapshots)
+// calculatePaginationBounds calculates the start and end indices of a list
+// window of maxVisible rows that keeps the selected row centred
+func calculatePaginationBounds(selected, totalMessages, maxVisible int) (int, int) {
 	if totalMessages <= maxVisible {
 		return 0, totalMessages
 	}
 
-	start := max(cv.historySelectedIndex-maxVisible/2, 0)
+	start := max(selected-maxVisible/2, 0)
 	end := start + maxVisible
 	if end > totalMessages {
 		end = totalMessages
@@ -10,6 +10,7 @@ import (
 	uimocks "github.com/inference-gateway/cli/tests/mocks/ui"
 
 	lipgloss "charm.land/lipgloss/v2"
+	ansi "github.com/charmbracelet/x/ansi"
 
 	sdk "github.com/inference-gateway/sdk"
 
@@ -384,6 +385,44 @@ func TestConversationView_Render(t *testing.T) {
 	}
 }
 
+func TestConversationView_ToggleThinkingVisibility(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetWidth(80)
+	cv.SetHeight(20)
+	cv.SetConversation([]domain.ConversationEntry{
+		{
+			Message: sdk.Message{
+				Role:    sdk.Assistant,
+				Content: sdk.NewMessageContent("The answer is 42"),
+			},
+			ReasoningContent: "weighing the options carefully",
+			Time:             time.Now(),
+		},
+	})
+
+	if !strings.Contains(cv.Render(), "weighing the options") {
+		t.Fatal("thinking should be shown by default")
+	}
+
+	if cv.ToggleThinkingVisibility() {
+		t.Fatal("ToggleThinkingVisibility should report thinking as hidden")
+	}
+	output := cv.Render()
+	if strings.Contains(output, "weighing the options") {
+		t.Error("hidden thinking block was rendered")
+	}
+	if !strings.Contains(output, "The answer is 42") {
+		t.Error("hiding thinking must keep the answer")
+	}
+
+	if !cv.ToggleThinkingVisibility() {
+		t.Fatal("ToggleThinkingVisibility should report thinking as shown")
+	}
+	if !strings.Contains(cv.Render(), "weighing the options") {
+		t.Error("thinking should be shown again")
+	}
+}
+
 func TestBackgroundTaskDisplay_A2AEventHandlers(t *testing.T) {
 	boolPtr := func(b bool) *bool { return &b }
 
@@ -878,8 +917,8 @@ func TestBackgroundTaskDisplay_BarNotInsideConversationViewport(t *testing.T) {
 	}
 	cv.SetConversation([]domain.ConversationEntry{entry})
 
-	if strings.Contains(cv.renderedContent, "Agent(weather-agent=working") {
-		t.Errorf("did not expect background-task line inside the scrollable viewport, got:\n%s", cv.renderedContent)
+	if strings.Contains(cv.rendered.String(), "Agent(weather-agent=working") {
+		t.Errorf("did not expect background-task line inside the scrollable viewport, got:\n%s", cv.rendered.String())
 	}
 }
 
@@ -1234,7 +1273,7 @@ func TestConversationView_StreamingRenderCoalesced(t *testing.T) {
 		t.Fatal("subsequent streamed deltas must not arm a second render tick")
 	}
 
-	if strings.Contains(cv.renderedContent, marker) {
+	if strings.Contains(cv.rendered.String(), marker) {
 		t.Fatal("streamed content must not be rendered synchronously on every delta")
 	}
 	if !cv.streamingDirty {
@@ -1242,7 +1281,7 @@ func TestConversationView_StreamingRenderCoalesced(t *testing.T) {
 	}
 
 	_, tickCmd := cv.handleStreamingRenderTick(nil)
-	if !strings.Contains(cv.renderedContent, marker) {
+	if !strings.Contains(cv.rendered.String(), marker) {
 		t.Fatal("render tick should rebuild the viewport with the streamed content")
 	}
 	if cv.streamingDirty {
@@ -1261,6 +1300,61 @@ func TestConversationView_StreamingRenderCoalesced(t *testing.T) {
 	}
 }
 
+func TestStableMarkdownEnd(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"single growing paragraph", "Hello wor", 0},
+		{"paragraph then partial one", "First.\n\nSecond", len("First.\n\n")},
+		{"unterminated last line is not a boundary", "First.\n\nSec", len("First.\n\n")},
+		{"indented continuation", "- item\n\n  more of the item\n", 0},
+		{"open fence with blank lines", "Intro\n\n```go\nfunc a() {}\n\nfunc b() {}\n", len("Intro\n\n")},
+		{"closed fence", "```\ncode\n\n```\n\nAfter\n", len("```\ncode\n\n```\n\n")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stableMarkdownEnd(tc.content); got != tc.want {
+				t.Errorf("stableMarkdownEnd(%q) = %d, want %d", tc.content, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestConversationView_StreamingRendersTail checks that render ticks keep the
+// finished blocks of a streaming message and re-render only what follows.
+func TestConversationView_StreamingRendersTail(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetWidth(100)
+	cv.SetHeight(30)
+
+	cv.appendStreamingContent("FIRST_BLOCK done.\n\nSECOND_BLOCK", "", "test-model")
+	cv.handleStreamingRenderTick(nil)
+
+	stable := cv.streamingStable
+	if stable.end != len("FIRST_BLOCK done.\n\n") || !strings.Contains(stable.rendered, "FIRST_BLOCK") {
+		t.Fatalf("first block should be rendered once and kept, got %+v", stable)
+	}
+
+	cv.appendStreamingContent(" grows", "", "test-model")
+	cv.handleStreamingRenderTick(nil)
+
+	if cv.streamingStable.rendered != stable.rendered {
+		t.Error("finished blocks must not be re-rendered as the tail grows")
+	}
+	for _, want := range []string{"FIRST_BLOCK", "SECOND_BLOCK grows"} {
+		if !strings.Contains(cv.rendered.String(), want) {
+			t.Errorf("rendered content missing %q:\n%s", want, cv.rendered.String())
+		}
+	}
+
+	cv.flushStreamingBuffer()
+	if cv.streamingStable.end != 0 {
+		t.Error("flushing the stream should drop the kept blocks")
+	}
+}
+
 func approvalEntry(status domain.ToolApprovalStatus) domain.ConversationEntry {
 	return domain.ConversationEntry{
 		PendingToolCall: &sdk.ChatCompletionMessageToolCall{
@@ -1350,7 +1444,7 @@ func TestConversationView_RenderCache(t *testing.T) {
 		fresh := NewConversationView(createMockStyleProvider())
 		fresh.SetConversation(renderCacheConversation())
 
-		if cached.renderedContent != fresh.renderedContent {
+		if cached.rendered.String() != fresh.rendered.String() {
 			t.Error("cached rendering diverged from fresh rendering")
 		}
 	})
@@ -1422,6 +1516,45 @@ func TestConversationView_RenderCache(t *testing.T) {
 	})
 }
 
+func TestConversationView_RendersOnlyNearTheScreen(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetWidth(80)
+	cv.SetHeight(10)
+	conversation := make([]domain.ConversationEntry, 0, 1000)
+	for i := range 1000 {
+		conversation = append(conversation, domain.ConversationEntry{
+			Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent(fmt.Sprintf("message %d", i))},
+			Time:    time.Unix(int64(i), 0),
+		})
+	}
+	cv.SetConversation(conversation)
+
+	limit := 2*renderCacheRadius + cv.Viewport.Height() + 2*renderWindowMargin
+	if !cv.layoutPartial || len(cv.renderCache) > limit {
+		t.Fatalf("rendered %d of %d entries, want only those near the screen", len(cv.renderCache), len(conversation))
+	}
+	if view := cv.Render(); !strings.Contains(view, "message 999") {
+		t.Errorf("expected the latest entry on screen:\n%s", view)
+	}
+
+	cv.handleScrollRequest(domain.ScrollRequestEvent{Direction: domain.ScrollToTop})
+	if view := cv.Render(); !strings.Contains(view, "message 0") {
+		t.Errorf("expected the first entry on screen after scrolling up:\n%s", view)
+	}
+	if len(cv.renderCache) > limit {
+		t.Errorf("render cache holds %d entries, want at most %d", len(cv.renderCache), limit)
+	}
+	if cv.Viewport.YOffset() != 0 {
+		t.Errorf("offset = %d, want to stay at the top", cv.Viewport.YOffset())
+	}
+
+	cv.StartSearch()
+	cv.SetSearchQuery("message 500")
+	if len(cv.search.matches) != 1 || cv.layoutPartial {
+		t.Errorf("search should see every entry, got %d matches", len(cv.search.matches))
+	}
+}
+
 // heightFormatter renders a tool result as `collapsed` lines when collapsed and
 // `expanded` lines when expanded, giving scroll-anchoring math a real height delta.
 type heightFormatter struct{ collapsed, expanded int }
@@ -1490,3 +1623,31 @@ func TestRebuildPreservingScroll_IgnoresBelowViewportEntry(t *testing.T) {
 		t.Errorf("toggling a below-viewport entry must not move the offset: got %d, want %d", got, before)
 	}
 }
+
+func TestConversationView_RendersChangeSummaryEntry(t *testing.T) {
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetWidth(100)
+
+	summary := domain.ChangeSummary{
+		Files: []domain.FileChange{
+			{Path: "main.go", Added: 3, Removed: 1},
+			{Path: "new.go", Added: 5, Created: true},
+		},
+		Commands: []string{"go test ./..."},
+	}
+	entry := domain.ConversationEntry{
+		Message: sdk.Message{
+			Role:    sdk.Assistant,
+			Content: sdk.NewMessageContent(summary.String()),
+		},
+		Time:          time.Now(),
+		ChangeSummary: &summary,
+	}
+
+	rendered := ansi.Strip(cv.renderEntryBody(entry, 0))
+	for _, want := range []string{"Changes: 2 files changed · 1 command run +8 -1", "M main.go +3 -1", "A new.go +5", "$ go test ./..."} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("rendered summary missing %q:\n%s", want, rendered)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package components
+
+import (
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	ansi "github.com/charmbracelet/x/ansi"
+)
+
+// lineIndex is rendered content kept as segments of lines - one per
+// conversation entry, plus the tail below them - along with the first line of
+// each segment. Lines are looked up by position without ever joining the
+// segments into one string, so a rebuild costs a slice of references rather
+// than a copy of the whole conversation.
+type lineIndex struct {
+	segments [][]string
+	starts   []int
+	total    int
+}
+
+// newLineIndex indexes segments. Consecutive segments are consecutive lines:
+// the result reads like the segments joined with newlines.
+func newLineIndex(segments [][]string) *lineIndex {
+	li := &lineIndex{segments: segments, starts: make([]int, len(segments))}
+	for i, segment := range segments {
+		li.starts[i] = li.total
+		li.total += len(segment)
+	}
+	return li
+}
+
+// splitLineIndex indexes content as a single segment.
+func splitLineIndex(content string) *lineIndex {
+	return newLineIndex([][]string{strings.Split(content, "\n")})
+}
+
+// Len returns the number of lines
+func (li *lineIndex) Len() int {
+	if li == nil {
+		return 0
+	}
+	return li.total
+}
+
+// Line returns line i, or "" when out of range
+func (li *lineIndex) Line(i int) string {
+	if i < 0 || i >= li.Len() {
+		return ""
+	}
+	seg := sort.SearchInts(li.starts, i+1) - 1
+	return li.segments[seg][i-li.starts[seg]]
+}
+
+// Lines returns lines [from, to), clamped to the content
+func (li *lineIndex) Lines(from, to int) []string {
+	from, to = max(from, 0), min(to, li.Len())
+	if from >= to {
+		return nil
+	}
+
+	lines := make([]string, 0, to-from)
+	seg := sort.SearchInts(li.starts, from+1) - 1
+	for i := from - li.starts[seg]; len(lines) < to-from; seg, i = seg+1, 0 {
+		segment := li.segments[seg]
+		lines = append(lines, segment[i:min(len(segment), i+to-from-len(lines))]...)
+	}
+	return lines
+}
+
+// String joins every line. Only on-demand consumers (tests, copying) use it.
+func (li *lineIndex) String() string {
+	return strings.Join(li.Lines(0, li.Len()), "\n")
+}
+
+// ConversationViewport is the scrollable window over the conversation. Unlike
+// a viewport holding one big string, it keeps the content as a lineIndex and
+// View materializes only the visible lines, so memory and redraw time stay
+// flat however long the session grows. Its scrolling API mirrors the bubbles
+// viewport it replaces.
+type ConversationViewport struct {
+	width   int
+	height  int
+	yOffset int
+	content *lineIndex
+
+	// decorate restyles a visible line just before it is drawn, which is
+	// how search and line selection highlight without copying the content.
+	decorate func(index int, line string) string
+
+	MouseWheelEnabled bool
+	MouseWheelDelta   int
+}
+
+// NewConversationViewport creates an empty viewport of the given size
+func NewConversationViewport(width, height int) ConversationViewport {
+	return ConversationViewport{
+		width:           width,
+		height:          height,
+		content:         splitLineIndex(""),
+		MouseWheelDelta: 3,
+	}
+}
+
+// SetContent replaces the content with a plain string, dropping any line
+// decoration. The message history, outline and code block screens use it.
+func (v *ConversationViewport) SetContent(content string) {
+	v.decorate = nil
+	v.SetLines(splitLineIndex(content))
+}
+
+// SetLines replaces the content with an indexed rendering, keeping the line
+// decoration.
+func (v *ConversationViewport) SetLines(content *lineIndex) {
+	v.content = content
+	v.SetYOffset(v.yOffset)
+}
+
+// SetLineDecorator sets the function restyling visible lines, nil for none
+func (v *ConversationViewport) SetLineDecorator(decorate func(index int, line string) string) {
+	v.decorate = decorate
+}
+
+func (v *ConversationViewport) Width() int  { return v.width }
+func (v *ConversationViewport) Height() int { return v.height }
+
+func (v *ConversationViewport) SetWidth(width int) {
+	v.width = width
+}
+
+func (v *ConversationViewport) SetHeight(height int) {
+	v.height = height
+	v.SetYOffset(v.yOffset)
+}
+
+// TotalLineCount returns the number of content lines
+func (v *ConversationViewport) TotalLineCount() int {
+	return v.content.Len()
+}
+
+// VisibleLineCount returns the number of content lines on screen
+func (v *ConversationViewport) VisibleLineCount() int {
+	return max(min(v.height, v.content.Len()-v.yOffset), 0)
+}
+
+func (v *ConversationViewport) YOffset() int {
+	return v.yOffset
+}
+
+// SetYOffset scrolls so the given line is at the top, clamped to the content
+func (v *ConversationViewport) SetYOffset(offset int) {
+	v.yOffset = max(min(offset, v.maxYOffset()), 0)
+}
+
+func (v *ConversationViewport) maxYOffset() int {
+	return max(v.content.Len()-v.height, 0)
+}
+
+func (v *ConversationViewport) AtTop() bool {
+	return v.yOffset <= 0
+}
+
+func (v *ConversationViewport) AtBottom() bool {
+	return v.yOffset >= v.maxYOffset()
+}
+
+func (v *ConversationViewport) GotoTop() {
+	v.yOffset = 0
+}
+
+func (v *ConversationViewport) GotoBottom() {
+	v.yOffset = v.maxYOffset()
+}
+
+func (v *ConversationViewport) ScrollUp(n int) {
+	v.SetYOffset(v.yOffset - n)
+}
+
+func (v *ConversationViewport) ScrollDown(n int) {
+	v.SetYOffset(v.yOffset + n)
+}
+
+// ScrollPercent returns how far down the content is scrolled, from 0 to 1
+func (v *ConversationViewport) ScrollPercent() float64 {
+	total := v.content.Len()
+	if v.height >= total {
+		return 1
+	}
+	return max(min(float64(v.yOffset)/float64(total-v.height), 1), 0)
+}
+
+// Update scrolls on mouse wheel events
+func (v *ConversationViewport) Update(msg tea.Msg) tea.Cmd {
+	wheel, ok := msg.(tea.MouseWheelMsg)
+	if !ok || !v.MouseWheelEnabled {
+		return nil
+	}
+	switch wheel.Button {
+	case tea.MouseWheelUp:
+		v.ScrollUp(v.MouseWheelDelta)
+	case tea.MouseWheelDown:
+		v.ScrollDown(v.MouseWheelDelta)
+	}
+	return nil
+}
+
+// View renders the visible lines, clipped to the width and padded to the
+// height.
+func (v *ConversationViewport) View() string {
+	if v.height <= 0 {
+		return ""
+	}
+
+	lines := v.content.Lines(v.yOffset, v.yOffset+v.height)
+	for i, line := range lines {
+		if v.decorate != nil {
+			line = v.decorate(v.yOffset+i, line)
+		}
+		if v.width > 0 {
+			line = ansi.Truncate(line, v.width, "")
+		}
+		lines[i] = line
+	}
+	for len(lines) < v.height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
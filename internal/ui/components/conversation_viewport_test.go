@@ -0,0 +1,78 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestLineIndex(t *testing.T) {
+	li := newLineIndex([][]string{{"a0", "a1"}, {"b0"}, {"c0", "c1", "c2"}})
+
+	if li.Len() != 6 {
+		t.Fatalf("Len() = %d, want 6", li.Len())
+	}
+	for i, want := range []string{"a0", "a1", "b0", "c0", "c1", "c2"} {
+		if got := li.Line(i); got != want {
+			t.Errorf("Line(%d) = %q, want %q", i, got, want)
+		}
+	}
+	if got := strings.Join(li.Lines(1, 5), ","); got != "a1,b0,c0,c1" {
+		t.Errorf("Lines(1, 5) = %q, want a window across segments", got)
+	}
+	if got := li.Lines(4, 100); len(got) != 2 {
+		t.Errorf("Lines past the end should clamp, got %q", got)
+	}
+	if got := li.String(); got != "a0\na1\nb0\nc0\nc1\nc2" {
+		t.Errorf("String() = %q", got)
+	}
+
+	var empty *lineIndex
+	if empty.Len() != 0 || empty.Lines(0, 1) != nil {
+		t.Error("a nil index should be empty")
+	}
+}
+
+func TestConversationViewport_ViewRendersOnlyTheWindow(t *testing.T) {
+	segments := make([][]string, 0, 1000)
+	for i := range 1000 {
+		segments = append(segments, []string{fmt.Sprintf("entry %d", i), ""})
+	}
+
+	vp := NewConversationViewport(40, 5)
+	vp.MouseWheelEnabled = true
+	vp.SetLines(newLineIndex(segments))
+
+	decorated := 0
+	vp.SetLineDecorator(func(index int, line string) string {
+		decorated++
+		return fmt.Sprintf("%d:%s", index, line)
+	})
+
+	vp.GotoBottom()
+	if !vp.AtBottom() || vp.YOffset() != 1995 {
+		t.Fatalf("GotoBottom left offset %d, want 1995", vp.YOffset())
+	}
+	view := vp.View()
+	if decorated != 5 {
+		t.Errorf("decorated %d lines, want only the 5 on screen", decorated)
+	}
+	if !strings.HasPrefix(view, "1995:") || !strings.Contains(view, "entry 999") {
+		t.Errorf("unexpected view of the last lines:\n%s", view)
+	}
+
+	vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if vp.YOffset() != 1992 || vp.AtBottom() {
+		t.Errorf("wheel up should scroll 3 lines, offset %d", vp.YOffset())
+	}
+
+	vp.SetContent("short")
+	if vp.YOffset() != 0 || vp.TotalLineCount() != 1 {
+		t.Errorf("shorter content should clamp the offset, got %d", vp.YOffset())
+	}
+	if got := vp.View(); strings.Count(got, "\n") != 4 || strings.Contains(got, ":") {
+		t.Errorf("view should pad to the height without the dropped decorator:\n%q", got)
+	}
+}
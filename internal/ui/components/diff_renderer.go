@@ -310,10 +310,11 @@ func (d *DiffRenderer) diffStyle() diffview.Style {
 }
 
 // chromaStyle returns a chroma highlighting style derived from the active
-// theme's brightness. Returns nil when no theme is available (no highlighting).
+// theme's brightness. Returns nil when no theme is available or the theme is
+// monochrome (no highlighting).
 func (d *DiffRenderer) chromaStyle() *chroma.Style {
 	theme := d.themeOrNil()
-	if theme == nil {
+	if theme == nil || d.styleProvider.IsMonochrome() {
 		return nil
 	}
 	if isLightTheme(theme) {
@@ -0,0 +1,152 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	udiff "github.com/aymanbagabas/go-udiff"
+)
+
+// EditHunk is one independently reviewable change inside a pending Edit or
+// MultiEdit call. For Edit it is a run of adjacent changed lines between
+// old_string and new_string; for MultiEdit it is one entry of the edits array.
+type EditHunk struct {
+	Summary string
+	Removed []string
+	Added   []string
+
+	edits     []udiff.Edit
+	editIndex int
+}
+
+// SplitEditHunks breaks an Edit/MultiEdit call into hunks for per-hunk review.
+// It returns nil for any other tool or for arguments it can't interpret.
+func SplitEditHunks(toolName string, args map[string]any) []EditHunk {
+	switch toolName {
+	case "Edit":
+		oldString, _ := args["old_string"].(string)
+		newString, _ := args["new_string"].(string)
+		return splitStringHunks(oldString, newString)
+	case "MultiEdit":
+		return splitMultiEditHunks(args)
+	}
+	return nil
+}
+
+// splitStringHunks groups the line-level edit script between two strings into
+// hunks: edits that touch (one ends where the next starts) belong together.
+func splitStringHunks(before, after string) []EditHunk {
+	var hunks []EditHunk
+	for _, e := range udiff.Lines(before, after) {
+		if n := len(hunks); n > 0 {
+			last := &hunks[n-1]
+			if last.edits[len(last.edits)-1].End == e.Start {
+				last.edits = append(last.edits, e)
+				continue
+			}
+		}
+		hunks = append(hunks, EditHunk{edits: []udiff.Edit{e}})
+	}
+
+	for i := range hunks {
+		h := &hunks[i]
+		first, last := h.edits[0], h.edits[len(h.edits)-1]
+		h.Removed = splitHunkLines(before[first.Start:last.End])
+		for _, e := range h.edits {
+			h.Added = append(h.Added, splitHunkLines(e.New)...)
+		}
+		line := strings.Count(before[:first.Start], "\n") + 1
+		h.Summary = fmt.Sprintf("line %d: -%d +%d", line, len(h.Removed), len(h.Added))
+	}
+	return hunks
+}
+
+func splitMultiEditHunks(args map[string]any) []EditHunk {
+	edits, ok := args["edits"].([]any)
+	if !ok {
+		return nil
+	}
+
+	hunks := make([]EditHunk, 0, len(edits))
+	for i, raw := range edits {
+		edit, ok := raw.(map[string]any)
+		if !ok {
+			return nil
+		}
+		oldString, _ := edit["old_string"].(string)
+		newString, _ := edit["new_string"].(string)
+		removed, added := splitHunkLines(oldString), splitHunkLines(newString)
+		hunks = append(hunks, EditHunk{
+			Summary:   fmt.Sprintf("edit %d: -%d +%d", i+1, len(removed), len(added)),
+			Removed:   removed,
+			Added:     added,
+			editIndex: i,
+		})
+	}
+	return hunks
+}
+
+func splitHunkLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// ApplyEditHunks rewrites the tool arguments so they carry only the accepted
+// hunks. It returns "" when no hunk is accepted. For Edit the new_string is
+// rebuilt from old_string with just the accepted line changes; for MultiEdit
+// the rejected entries are dropped from the edits array.
+func ApplyEditHunks(toolName string, args map[string]any, hunks []EditHunk, accepted []bool) (string, error) {
+	if len(hunks) != len(accepted) {
+		return "", fmt.Errorf("hunk count mismatch: %d hunks, %d decisions", len(hunks), len(accepted))
+	}
+
+	anyAccepted := false
+	for _, ok := range accepted {
+		anyAccepted = anyAccepted || ok
+	}
+	if !anyAccepted {
+		return "", nil
+	}
+
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		out[k] = v
+	}
+
+	switch toolName {
+	case "Edit":
+		oldString, _ := args["old_string"].(string)
+		var kept []udiff.Edit
+		for i, h := range hunks {
+			if accepted[i] {
+				kept = append(kept, h.edits...)
+			}
+		}
+		newString, err := udiff.Apply(oldString, kept)
+		if err != nil {
+			return "", fmt.Errorf("failed to apply accepted hunks: %w", err)
+		}
+		out["new_string"] = newString
+	case "MultiEdit":
+		edits, _ := args["edits"].([]any)
+		var kept []any
+		for i, h := range hunks {
+			if accepted[i] && h.editIndex < len(edits) {
+				kept = append(kept, edits[h.editIndex])
+			}
+		}
+		out["edits"] = kept
+	default:
+		return "", fmt.Errorf("per-hunk review is not supported for %s", toolName)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode arguments: %w", err)
+	}
+	return string(data), nil
+}
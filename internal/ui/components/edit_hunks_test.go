@@ -0,0 +1,114 @@
+package components
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSplitEditHunks_Edit(t *testing.T) {
+	args := map[string]any{
+		"file_path":  "main.go",
+		"old_string": "a\nb\nc\nd\ne\n",
+		"new_string": "a\nB\nc\nd\nE\nF\n",
+	}
+
+	hunks := SplitEditHunks("Edit", args)
+	if len(hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d: %+v", len(hunks), hunks)
+	}
+	if hunks[0].Summary != "line 2: -1 +1" {
+		t.Errorf("unexpected first summary %q", hunks[0].Summary)
+	}
+	if hunks[1].Summary != "line 5: -1 +2" {
+		t.Errorf("unexpected second summary %q", hunks[1].Summary)
+	}
+}
+
+func TestApplyEditHunks_Edit(t *testing.T) {
+	args := map[string]any{
+		"file_path":  "main.go",
+		"old_string": "a\nb\nc\nd\ne\n",
+		"new_string": "a\nB\nc\nd\nE\nF\n",
+	}
+	hunks := SplitEditHunks("Edit", args)
+
+	tests := []struct {
+		name     string
+		accepted []bool
+		want     string
+	}{
+		{name: "first only", accepted: []bool{true, false}, want: "a\nB\nc\nd\ne\n"},
+		{name: "second only", accepted: []bool{false, true}, want: "a\nb\nc\nd\nE\nF\n"},
+		{name: "both", accepted: []bool{true, true}, want: "a\nB\nc\nd\nE\nF\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := ApplyEditHunks("Edit", args, hunks, tt.accepted)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("invalid JSON %q: %v", out, err)
+			}
+			if got["new_string"] != tt.want {
+				t.Errorf("new_string = %q, want %q", got["new_string"], tt.want)
+			}
+			if got["old_string"] != args["old_string"] || got["file_path"] != "main.go" {
+				t.Errorf("other arguments must pass through unchanged, got %v", got)
+			}
+		})
+	}
+}
+
+func TestApplyEditHunks_NoneAccepted(t *testing.T) {
+	args := map[string]any{"old_string": "a\nb\n", "new_string": "A\nb\nC\n"}
+	hunks := SplitEditHunks("Edit", args)
+
+	out, err := ApplyEditHunks("Edit", args, hunks, make([]bool, len(hunks)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "" {
+		t.Errorf("expected empty arguments when nothing is accepted, got %q", out)
+	}
+}
+
+func TestApplyEditHunks_MultiEdit(t *testing.T) {
+	args := map[string]any{
+		"file_path": "main.go",
+		"edits": []any{
+			map[string]any{"old_string": "foo", "new_string": "bar"},
+			map[string]any{"old_string": "baz", "new_string": "qux\nquux"},
+		},
+	}
+
+	hunks := SplitEditHunks("MultiEdit", args)
+	if len(hunks) != 2 {
+		t.Fatalf("expected one hunk per edit, got %d", len(hunks))
+	}
+	if hunks[1].Summary != "edit 2: -1 +2" {
+		t.Errorf("unexpected summary %q", hunks[1].Summary)
+	}
+
+	out, err := ApplyEditHunks("MultiEdit", args, hunks, []bool{false, true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		Edits []map[string]any `json:"edits"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(got.Edits) != 1 || got.Edits[0]["old_string"] != "baz" {
+		t.Errorf("expected only the second edit to remain, got %v", got.Edits)
+	}
+}
+
+func TestSplitEditHunks_OtherTool(t *testing.T) {
+	if hunks := SplitEditHunks("Write", map[string]any{"content": "x"}); hunks != nil {
+		t.Errorf("expected no hunks for Write, got %v", hunks)
+	}
+}
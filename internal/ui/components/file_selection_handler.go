@@ -2,7 +2,6 @@ package components
 
 import (
 	"fmt"
-	"strings"
 
 	key "charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
@@ -107,17 +106,7 @@ func (h *FileSelectionHandler) findAtSymbolIndex(input string, cursor int) int {
 
 // filterFiles filters files based on search query
 func (h *FileSelectionHandler) filterFiles(allFiles []string, searchQuery string) []string {
-	if searchQuery == "" {
-		return allFiles
-	}
-
-	var files []string
-	for _, file := range allFiles {
-		if strings.Contains(strings.ToLower(file), strings.ToLower(searchQuery)) {
-			files = append(files, file)
-		}
-	}
-	return files
+	return fuzzyFilterFiles(allFiles, searchQuery)
 }
 
 // RenderFileSelection renders the file selection view
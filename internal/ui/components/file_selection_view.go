@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	fuzzy "github.com/sahilm/fuzzy"
+
 	styles "github.com/inference-gateway/cli/internal/ui/styles"
 )
 
@@ -46,16 +48,21 @@ func (f *FileSelectionView) RenderView(allFiles []string, searchQuery string, se
 }
 
 func (f *FileSelectionView) filterFiles(allFiles []string, searchQuery string) []string {
+	return fuzzyFilterFiles(allFiles, searchQuery)
+}
+
+// fuzzyFilterFiles keeps the files whose path fuzzy-matches searchQuery
+// ("cfgkb" finds config/keybindings.go), best match first. The view, the
+// renderer and the key handler all filter through here so the highlighted
+// index always points at the same file.
+func fuzzyFilterFiles(allFiles []string, searchQuery string) []string {
 	if searchQuery == "" {
 		return allFiles
 	}
 
 	var filtered []string
-	query := strings.ToLower(searchQuery)
-	for _, file := range allFiles {
-		if strings.Contains(strings.ToLower(file), query) {
-			filtered = append(filtered, file)
-		}
+	for _, m := range fuzzy.Find(searchQuery, allFiles) {
+		filtered = append(filtered, m.Str)
 	}
 	return filtered
 }
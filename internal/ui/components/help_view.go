@@ -26,10 +26,11 @@ type HelpCommand struct {
 }
 
 // HelpViewImpl is a full-screen, scrollable overlay documenting every available
-// slash command and keybinding in two lipgloss tables. Both tables are sized to
-// the terminal width - long descriptions wrap rather than truncate - and the
-// whole view lives inside a viewport, so every row stays reachable even on a
-// narrow or short terminal. It is read-only: esc/q returns to the chat.
+// slash command and keybinding in lipgloss tables, the keybindings grouped by
+// their category. Tables are sized to the terminal width - long descriptions
+// wrap rather than truncate - and the whole view lives inside a viewport, so
+// every row stays reachable even on a narrow or short terminal. Typing / starts
+// a search that narrows every table; esc/q returns to the chat.
 type HelpViewImpl struct {
 	width         int
 	height        int
@@ -39,6 +40,11 @@ type HelpViewImpl struct {
 	commands      []HelpCommand
 	keybindings   []ui.KeyShortcut
 	cancelled     bool
+
+	// query filters every table down to rows mentioning it; searching is
+	// true while the user is still typing it.
+	query     string
+	searching bool
 }
 
 // NewHelpView creates a new help overlay component.
@@ -66,9 +72,14 @@ func (h *HelpViewImpl) SetContent(commands []HelpCommand, keybindings []ui.KeySh
 	h.viewport.GotoTop()
 }
 
-// Reset clears the cancelled flag and scroll position for reuse.
+// Reset clears the cancelled flag, search and scroll position for reuse.
 func (h *HelpViewImpl) Reset() {
 	h.cancelled = false
+	h.searching = false
+	if h.query != "" {
+		h.query = ""
+		h.rebuild()
+	}
 	h.viewport.GotoTop()
 }
 
@@ -110,7 +121,15 @@ func (h *HelpViewImpl) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (h *HelpViewImpl) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if h.searching {
+		return h.handleSearchKey(msg)
+	}
+
 	switch {
+	case key.Matches(msg, helpViewKeys.search):
+		h.searching = true
+	case key.Matches(msg, helpViewKeys.clearSearch) && h.query != "":
+		h.setQuery("")
 	case key.Matches(msg, helpViewKeys.dismiss):
 		h.cancelled = true
 	case key.Matches(msg, helpViewKeys.navUp):
@@ -133,6 +152,53 @@ func (h *HelpViewImpl) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return h, nil
 }
 
+// handleSearchKey edits the search query. Enter or an arrow key keeps the
+// filter and goes back to scrolling; esc drops it.
+func (h *HelpViewImpl) handleSearchKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, helpViewKeys.acceptSearch):
+		h.searching = false
+	case key.Matches(msg, helpViewKeys.clearSearch):
+		h.searching = false
+		h.setQuery("")
+	case key.Matches(msg, helpViewKeys.searchBackspace):
+		if runes := []rune(h.query); len(runes) > 0 {
+			h.setQuery(string(runes[:len(runes)-1]))
+		}
+	case key.Matches(msg, helpViewKeys.searchScroll):
+		h.searching = false
+		return h.handleKey(msg)
+	case key.Matches(msg, helpViewKeys.searchQuit):
+		h.cancelled = true
+	default:
+		if msg.Text != "" {
+			h.setQuery(h.query + msg.Text)
+		}
+	}
+	return h, nil
+}
+
+func (h *HelpViewImpl) setQuery(query string) {
+	h.query = query
+	h.rebuild()
+	h.viewport.GotoTop()
+}
+
+// matchesQuery reports whether any of fields contains the search query,
+// ignoring case. Everything matches an empty query.
+func (h *HelpViewImpl) matchesQuery(fields ...string) bool {
+	if h.query == "" {
+		return true
+	}
+	query := strings.ToLower(h.query)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), query) {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *HelpViewImpl) View() tea.View {
 	return tea.NewView(h.viewContent())
 }
@@ -143,8 +209,18 @@ func (h *HelpViewImpl) viewContent() string {
 	var b strings.Builder
 	b.WriteString(h.viewport.View())
 	b.WriteString("\n")
-	b.WriteString(h.styleProvider.RenderWithColor(
-		"↑/↓ scroll · g/G top/bottom · esc to close", dim))
+	switch {
+	case h.searching:
+		b.WriteString(h.styleProvider.RenderWithColor("Search: ", dim))
+		b.WriteString(h.query + h.styleProvider.RenderCursor(" "))
+		b.WriteString(h.styleProvider.RenderWithColor("  ·  enter keep · esc clear", dim))
+	case h.query != "":
+		b.WriteString(h.styleProvider.RenderWithColor(
+			"Filtered by \""+h.query+"\" · / edit · esc clear · q close", dim))
+	default:
+		b.WriteString(h.styleProvider.RenderWithColor(
+			"↑/↓ scroll · g/G top/bottom · / search · esc to close", dim))
+	}
 	return b.String()
 }
 
@@ -169,7 +245,7 @@ func (h *HelpViewImpl) rebuild() {
 	var b strings.Builder
 	b.WriteString(titleStyle.Render("Help"))
 	b.WriteString("\n")
-	b.WriteString(subtitleStyle.Render("Slash commands and keyboard shortcuts"))
+	b.WriteString(subtitleStyle.Render("Slash commands, input modes and keyboard shortcuts"))
 	b.WriteString("\n\n")
 
 	b.WriteString(sectionStyle.Render("Commands"))
@@ -179,31 +255,83 @@ func (h *HelpViewImpl) rebuild() {
 
 	b.WriteString(sectionStyle.Render("Keybindings"))
 	b.WriteString("\n")
-	b.WriteString(h.renderKeybindingsTable(width, accent, dim, border))
+	groups := h.keybindingGroups()
+	if len(groups) == 0 {
+		b.WriteString(renderHelpTable(width, accent, dim, border, "Key", "Action",
+			[][2]string{{"-", h.emptyText("keybindings")}}))
+	}
+	for i, g := range groups {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(subtitleStyle.Render(helpCategoryTitle(g.category)))
+		b.WriteString("\n")
+		b.WriteString(renderHelpTable(width, accent, dim, border, "Key", "Action", g.rows))
+	}
 
 	h.viewport.SetContent(b.String())
 }
 
-func (h *HelpViewImpl) renderCommandsTable(width int, accent, dim, border color.Color) string {
-	rows := make([][2]string, 0, len(h.commands))
-	for _, c := range h.commands {
-		rows = append(rows, [2]string{"/" + c.Name, c.Description})
+// helpKeybindingGroup is the rows of one keybinding category, in the order
+// the caller supplied them.
+type helpKeybindingGroup struct {
+	category string
+	rows     [][2]string
+}
+
+// keybindingGroups buckets the keybindings matching the search by category,
+// keeping categories in order of first appearance.
+func (h *HelpViewImpl) keybindingGroups() []helpKeybindingGroup {
+	var groups []helpKeybindingGroup
+	index := make(map[string]int)
+	for _, k := range h.keybindings {
+		category := k.Category
+		if category == "" {
+			category = "general"
+		}
+		if !h.matchesQuery(k.Key, k.Description, category) {
+			continue
+		}
+		i, ok := index[category]
+		if !ok {
+			i = len(groups)
+			index[category] = i
+			groups = append(groups, helpKeybindingGroup{category: category})
+		}
+		groups[i].rows = append(groups[i].rows, [2]string{k.Key, k.Description})
 	}
-	if len(rows) == 0 {
-		rows = append(rows, [2]string{"-", "No commands available"})
+	return groups
+}
+
+// helpCategoryTitle turns a category such as "plan_approval" into a heading
+// such as "Plan approval".
+func helpCategoryTitle(category string) string {
+	title := strings.ReplaceAll(category, "_", " ")
+	if title == "" {
+		return title
 	}
-	return renderHelpTable(width, accent, dim, border, "Command", "Description", rows)
+	return strings.ToUpper(title[:1]) + title[1:]
 }
 
-func (h *HelpViewImpl) renderKeybindingsTable(width int, accent, dim, border color.Color) string {
-	rows := make([][2]string, 0, len(h.keybindings))
-	for _, k := range h.keybindings {
-		rows = append(rows, [2]string{k.Key, k.Description})
+// emptyText is the placeholder row for a table with nothing to show.
+func (h *HelpViewImpl) emptyText(what string) string {
+	if h.query != "" {
+		return "No " + what + " match \"" + h.query + "\""
+	}
+	return "No " + what + " available"
+}
+
+func (h *HelpViewImpl) renderCommandsTable(width int, accent, dim, border color.Color) string {
+	rows := make([][2]string, 0, len(h.commands))
+	for _, c := range h.commands {
+		if h.matchesQuery("/"+c.Name, c.Description) {
+			rows = append(rows, [2]string{"/" + c.Name, c.Description})
+		}
 	}
 	if len(rows) == 0 {
-		rows = append(rows, [2]string{"-", "No keybindings available"})
+		rows = append(rows, [2]string{"-", h.emptyText("commands")})
 	}
-	return renderHelpTable(width, accent, dim, border, "Key", "Action", rows)
+	return renderHelpTable(width, accent, dim, border, "Command", "Description", rows)
 }
 
 // renderHelpTable builds a themed two-column table that fits exactly into the
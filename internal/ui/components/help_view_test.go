@@ -137,3 +137,94 @@ func TestHelpView_ScrollMovesViewport(t *testing.T) {
 		t.Errorf("expected home to scroll back to top, got offset %d", got)
 	}
 }
+
+func TestHelpView_GroupsKeybindingsByCategory(t *testing.T) {
+	h := newTestHelpView()
+	h.SetContent(nil, []ui.KeyShortcut{
+		{Key: "!", Description: "for bash mode", Category: "input_modes"},
+		{Key: "ctrl+c", Description: "exit application", Category: "global"},
+		{Key: "pgup", Description: "page up", Category: "plan_approval"},
+		{Key: "esc", Description: "cancel current operation", Category: "global"},
+	})
+	h.SetWidth(100)
+	h.SetHeight(100)
+
+	out := h.View().Content
+	modes := strings.Index(out, "Input modes")
+	global := strings.Index(out, "Global")
+	plan := strings.Index(out, "Plan approval")
+	if modes < 0 || global < 0 || plan < 0 {
+		t.Fatalf("expected a heading per category\n---\n%s", out)
+	}
+	if modes >= global || global >= plan {
+		t.Errorf("expected categories in order of first appearance\n---\n%s", out)
+	}
+	if strings.Count(out, "Global") != 1 {
+		t.Errorf("expected one global group\n---\n%s", out)
+	}
+}
+
+func TestHelpView_SearchFiltersRows(t *testing.T) {
+	h := newTestHelpView()
+	h.SetContent(sampleHelpContent())
+	h.SetWidth(100)
+	h.SetHeight(100)
+
+	_, _ = h.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "THEME" {
+		_, _ = h.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+
+	out := h.View().Content
+	if !strings.Contains(out, "/theme") {
+		t.Errorf("expected /theme to match the search\n---\n%s", out)
+	}
+	if strings.Contains(out, "/exit") || strings.Contains(out, "ctrl+c") {
+		t.Errorf("expected non-matching rows to be hidden\n---\n%s", out)
+	}
+	if !strings.Contains(out, `No keybindings match "THEME"`) {
+		t.Errorf("expected no-match placeholder for keybindings\n---\n%s", out)
+	}
+
+	_, _ = h.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	if h.IsCancelled() {
+		t.Fatal("expected q to be typed into the search, not close the help")
+	}
+
+	_, _ = h.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if h.IsCancelled() {
+		t.Fatal("expected esc to clear the search before closing the help")
+	}
+	if out := h.View().Content; !strings.Contains(out, "/exit") {
+		t.Errorf("expected clearing the search to restore every row\n---\n%s", out)
+	}
+
+	_, _ = h.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if !h.IsCancelled() {
+		t.Error("expected esc to close the help once the search is cleared")
+	}
+}
+
+func TestHelpView_SearchKeptAfterEnter(t *testing.T) {
+	h := newTestHelpView()
+	h.SetContent(sampleHelpContent())
+	h.SetWidth(100)
+	h.SetHeight(100)
+
+	_, _ = h.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	_, _ = h.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	_, _ = h.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	_, _ = h.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if h.searching {
+		t.Fatal("expected enter to leave search input")
+	}
+	if out := h.View().Content; strings.Contains(out, "/help") || !strings.Contains(out, "/exit") {
+		t.Errorf("expected filter to stay applied after enter\n---\n%s", out)
+	}
+
+	h.Reset()
+	if out := h.View().Content; !strings.Contains(out, "/help") {
+		t.Errorf("expected Reset to clear the filter\n---\n%s", out)
+	}
+}
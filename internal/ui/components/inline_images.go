@@ -0,0 +1,122 @@
+package components
+
+import (
+	"encoding/base64"
+	"hash/fnv"
+	"strings"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	logger "github.com/inference-gateway/cli/internal/logger"
+	graphics "github.com/inference-gateway/cli/internal/ui/graphics"
+)
+
+// inlineImageMaxCols caps the width of an inline image in terminal cells
+const inlineImageMaxCols = 60
+
+// inlineImageMaxRows caps the height of an inline image in terminal rows
+const inlineImageMaxRows = 16
+
+// inlineImageKey identifies a prepared image: the same picture rendered at a
+// different size is a separate upload.
+type inlineImageKey struct {
+	hash    uint64
+	maxCols int
+}
+
+// inlineImages prepares image attachments for inline display and queues the
+// one-time upload of each image to the terminal.
+type inlineImages struct {
+	protocol graphics.Protocol
+	images   map[inlineImageKey]*graphics.Image
+	failed   map[inlineImageKey]bool
+	nextID   uint32
+	pending  []string
+}
+
+func newInlineImages(protocol graphics.Protocol) *inlineImages {
+	return &inlineImages{
+		protocol: protocol,
+		images:   make(map[inlineImageKey]*graphics.Image),
+		failed:   make(map[inlineImageKey]bool),
+	}
+}
+
+// placeholder returns the cells that display the attachment, or "" when it
+// cannot be shown inline and the text placeholder should stand in for it.
+func (ii *inlineImages) placeholder(attachment domain.ImageAttachment, maxCols int) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(attachment.Data))
+	key := inlineImageKey{hash: h.Sum64(), maxCols: maxCols}
+
+	if img, ok := ii.images[key]; ok {
+		return img.Placeholder()
+	}
+	if ii.failed[key] {
+		return ""
+	}
+
+	data, err := base64.StdEncoding.DecodeString(attachment.Data)
+	if err == nil {
+		ii.nextID++
+		var img *graphics.Image
+		if img, err = graphics.Prepare(data, ii.nextID, maxCols, inlineImageMaxRows); err == nil {
+			ii.images[key] = img
+			ii.pending = append(ii.pending, img.Transmit())
+			return img.Placeholder()
+		}
+	}
+
+	logger.Debug("showing image attachment as text", "name", attachment.DisplayName, "error", err)
+	ii.failed[key] = true
+	return ""
+}
+
+// SetInlineImages enables inline image rendering with the given terminal
+// graphics protocol; graphics.ProtocolNone keeps the text placeholders.
+func (cv *ConversationView) SetInlineImages(protocol graphics.Protocol) {
+	cv.inlineImages = nil
+	if protocol != graphics.ProtocolNone {
+		cv.inlineImages = newInlineImages(protocol)
+	}
+	cv.resetRenderCache()
+}
+
+// TakeImageTransmissions returns the escape sequences uploading images that
+// were rendered since the last call. The caller writes them to the terminal
+// raw, outside the view.
+func (cv *ConversationView) TakeImageTransmissions() string {
+	if cv.inlineImages == nil || len(cv.inlineImages.pending) == 0 {
+		return ""
+	}
+	seq := strings.Join(cv.inlineImages.pending, "")
+	cv.inlineImages.pending = nil
+	return seq
+}
+
+// renderInlineImages draws the entry's image attachments and tool screenshots
+// below it when inline images are enabled.
+func (cv *ConversationView) renderInlineImages(entry domain.ConversationEntry) string {
+	if cv.inlineImages == nil || entry.Hidden {
+		return ""
+	}
+
+	images := entry.Images
+	if entry.ToolExecution != nil {
+		images = append(images[:len(images):len(images)], entry.ToolExecution.Images...)
+	}
+
+	var b strings.Builder
+	maxCols := max(min(cv.width-4, inlineImageMaxCols), 1)
+	for _, attachment := range images {
+		placeholder := cv.inlineImages.placeholder(attachment, maxCols)
+		if placeholder == "" {
+			continue
+		}
+		for line := range strings.SplitSeq(placeholder, "\n") {
+			b.WriteString("  ")
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package components
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	graphics "github.com/inference-gateway/cli/internal/ui/graphics"
+)
+
+func testImageAttachment(t *testing.T) domain.ImageAttachment {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 20))); err != nil {
+		t.Fatal(err)
+	}
+	return domain.ImageAttachment{
+		Data:        base64.StdEncoding.EncodeToString(buf.Bytes()),
+		MimeType:    "image/png",
+		DisplayName: "screenshot.png",
+	}
+}
+
+func TestConversationView_InlineImages(t *testing.T) {
+	entry := domain.ConversationEntry{
+		Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("what is this?")},
+		Images:  []domain.ImageAttachment{testImageAttachment(t), {Data: "not base64!", DisplayName: "broken"}},
+	}
+
+	cv := NewConversationView(createMockStyleProvider())
+	cv.SetWidth(80)
+	if out := cv.renderEntryWithIndex(entry, 0); strings.Contains(out, "\U0010EEEE") {
+		t.Fatal("images must not render inline unless a graphics protocol is enabled")
+	}
+
+	cv.SetInlineImages(graphics.ProtocolKitty)
+	out := cv.renderEntryWithIndex(entry, 0)
+	if got := strings.Count(out, "\U0010EEEE"); got == 0 {
+		t.Fatal("expected placeholder cells for the image")
+	}
+
+	transmit := cv.TakeImageTransmissions()
+	if strings.Count(transmit, "\x1b_Ga=T") != 1 {
+		t.Fatalf("expected exactly one upload (the broken attachment falls back to text), got %q", transmit)
+	}
+	if cv.TakeImageTransmissions() != "" {
+		t.Fatal("transmissions should be handed out once")
+	}
+
+	cv.renderEntryWithIndex(entry, 0)
+	if cv.TakeImageTransmissions() != "" {
+		t.Fatal("an image already uploaded must not be sent again")
+	}
+
+	hidden := entry
+	hidden.Hidden = true
+	if out := cv.renderInlineImages(hidden); out != "" {
+		t.Fatal("hidden entries should not show images")
+	}
+}
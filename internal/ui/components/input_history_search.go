@@ -0,0 +1,124 @@
+package components
+
+import "fmt"
+
+// inputHistorySearch is the state of a reverse search over the input history,
+// like readline's ctrl+r. The current match is previewed in the input; the
+// text the input held before the search comes back on cancel.
+type inputHistorySearch struct {
+	query    string
+	matches  []string
+	current  int
+	original string
+}
+
+// StartHistorySearch opens the reverse history search. While it is open the
+// chat view routes keys to the search instead of the input.
+func (iv *InputView) StartHistorySearch() {
+	if iv.historySearch != nil {
+		return
+	}
+	iv.historySearch = &inputHistorySearch{original: iv.ta.Value()}
+}
+
+// IsSearchingHistory reports whether the reverse history search is open.
+func (iv *InputView) IsSearchingHistory() bool {
+	return iv.historySearch != nil
+}
+
+// HistorySearchQuery returns the current search query.
+func (iv *InputView) HistorySearchQuery() string {
+	if iv.historySearch == nil {
+		return ""
+	}
+	return iv.historySearch.query
+}
+
+// SetHistorySearchQuery replaces the query and previews its most recent match.
+func (iv *InputView) SetHistorySearchQuery(query string) {
+	s := iv.historySearch
+	if s == nil {
+		return
+	}
+	s.query = query
+	s.matches = iv.historyManager.SearchHistory(query)
+	s.current = 0
+	iv.previewHistoryMatch()
+}
+
+// HistorySearchOlder moves to the next older match, staying on the oldest.
+func (iv *InputView) HistorySearchOlder() {
+	iv.stepHistorySearch(1)
+}
+
+// HistorySearchNewer moves back to the next newer match, staying on the newest.
+func (iv *InputView) HistorySearchNewer() {
+	iv.stepHistorySearch(-1)
+}
+
+func (iv *InputView) stepHistorySearch(delta int) {
+	s := iv.historySearch
+	if s == nil || len(s.matches) == 0 {
+		return
+	}
+	s.current = max(min(s.current+delta, len(s.matches)-1), 0)
+	iv.previewHistoryMatch()
+}
+
+// AcceptHistorySearch closes the search leaving the match in the input for
+// editing, or the original text when nothing matched.
+func (iv *InputView) AcceptHistorySearch() {
+	s := iv.historySearch
+	if s == nil {
+		return
+	}
+	iv.historySearch = nil
+	if len(s.matches) == 0 {
+		iv.setHistorySearchText(s.original)
+	}
+	iv.historyManager.ResetNavigation()
+}
+
+// CancelHistorySearch closes the search and restores the text the input held
+// before it opened.
+func (iv *InputView) CancelHistorySearch() {
+	s := iv.historySearch
+	if s == nil {
+		return
+	}
+	iv.historySearch = nil
+	iv.setHistorySearchText(s.original)
+}
+
+// HistorySearchHint is the input-area hint shown while searching: the query,
+// the match counter, and the search keys.
+func (iv *InputView) HistorySearchHint() string {
+	s := iv.historySearch
+	if s == nil {
+		return ""
+	}
+
+	hint := "reverse-i-search: " + s.query
+	switch {
+	case s.query == "":
+	case len(s.matches) == 0:
+		hint += "  [no matches]"
+	default:
+		hint += fmt.Sprintf("  [%d/%d]", s.current+1, len(s.matches))
+	}
+	return hint + "  ·  ctrl+r/↑ older · ↓ newer · enter accept · esc cancel"
+}
+
+func (iv *InputView) previewHistoryMatch() {
+	s := iv.historySearch
+	if len(s.matches) == 0 {
+		iv.setHistorySearchText(s.original)
+		return
+	}
+	iv.setHistorySearchText(s.matches[s.current])
+}
+
+func (iv *InputView) setHistorySearchText(text string) {
+	iv.SetText(text)
+	iv.SetCursor(len(text))
+}
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInputView_HistorySearch(t *testing.T) {
+	iv := createInputViewWithTheme(createMockModelService())
+	for _, command := range []string{"run the tests", "explain the parser", "run the linter"} {
+		if err := iv.AddToHistory(command); err != nil {
+			t.Fatal(err)
+		}
+	}
+	iv.SetText("draft")
+
+	iv.StartHistorySearch()
+	if !iv.IsSearchingHistory() {
+		t.Fatal("expected history search mode")
+	}
+
+	iv.SetHistorySearchQuery("run")
+	if got := iv.GetInput(); got != "run the linter" {
+		t.Errorf("input previews %q, want the newest match", got)
+	}
+	if hint := iv.HistorySearchHint(); !strings.Contains(hint, "reverse-i-search: run") || !strings.Contains(hint, "[1/2]") {
+		t.Errorf("unexpected hint %q", hint)
+	}
+
+	iv.HistorySearchOlder()
+	iv.HistorySearchOlder()
+	if got := iv.GetInput(); got != "run the tests" {
+		t.Errorf("input previews %q, want the older match kept at the oldest", got)
+	}
+	iv.HistorySearchNewer()
+	if got := iv.GetInput(); got != "run the linter" {
+		t.Errorf("input previews %q after newer, want the newest match", got)
+	}
+
+	iv.SetHistorySearchQuery("nothing like it")
+	if got := iv.GetInput(); got != "draft" || !strings.Contains(iv.HistorySearchHint(), "no matches") {
+		t.Errorf("without a match the input should show the draft, got %q", got)
+	}
+
+	iv.CancelHistorySearch()
+	if iv.IsSearchingHistory() || iv.GetInput() != "draft" {
+		t.Errorf("cancel should restore the draft, got %q", iv.GetInput())
+	}
+
+	iv.StartHistorySearch()
+	iv.SetHistorySearchQuery("PARSER")
+	iv.AcceptHistorySearch()
+	if iv.IsSearchingHistory() || iv.GetInput() != "explain the parser" {
+		t.Errorf("accept should keep the match for editing, got %q", iv.GetInput())
+	}
+}
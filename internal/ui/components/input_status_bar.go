@@ -13,6 +13,7 @@ import (
 	models "github.com/inference-gateway/cli/internal/models"
 	ui "github.com/inference-gateway/cli/internal/ui"
 	styles "github.com/inference-gateway/cli/internal/ui/styles"
+	colors "github.com/inference-gateway/cli/internal/ui/styles/colors"
 )
 
 // InputStatusBar displays input status information like model, theme, agents
@@ -23,6 +24,7 @@ type InputStatusBar struct {
 	stateManager           statusBarState
 	config                 *config.Config
 	conversationRepo       domain.ConversationRepository
+	budgetTracker          domain.BudgetTracker
 	toolService            domain.ToolService
 	tokenEstimator         domain.TokenEstimator
 	backgroundShellService domain.BackgroundShellService
@@ -31,6 +33,9 @@ type InputStatusBar struct {
 	mcpStatus              *domain.MCPServerStatus
 	styleProvider          *styles.Provider
 	currentInputText       string
+	// segmentOutputs holds the latest output of each configured script
+	// segment, keyed by segment name.
+	segmentOutputs map[string]string
 
 	// Keyboard focus state: when focused, selected indexes the actionable
 	// indicators (those that open a view) in build order.
@@ -86,6 +91,11 @@ func (isb *InputStatusBar) SetConversationRepo(repo domain.ConversationRepositor
 	isb.conversationRepo = repo
 }
 
+// SetBudgetTracker sets the tracker the cost indicator is measured against
+func (isb *InputStatusBar) SetBudgetTracker(tracker domain.BudgetTracker) {
+	isb.budgetTracker = tracker
+}
+
 // SetToolService sets the tool service
 func (isb *InputStatusBar) SetToolService(toolService domain.ToolService) {
 	isb.toolService = toolService
@@ -117,6 +127,15 @@ func (isb *InputStatusBar) UpdateMCPStatus(status *domain.MCPServerStatus) {
 	isb.mcpStatus = status
 }
 
+// SetSegmentOutput records the latest output of a script segment; an empty
+// output hides the segment.
+func (isb *InputStatusBar) SetSegmentOutput(name, output string) {
+	if isb.segmentOutputs == nil {
+		isb.segmentOutputs = make(map[string]string)
+	}
+	isb.segmentOutputs[name] = output
+}
+
 // SetInputText sets the current input text for mode detection
 func (isb *InputStatusBar) SetInputText(text string) {
 	isb.currentInputText = text
@@ -331,74 +350,136 @@ func (isb *InputStatusBar) getAllIndicatorParts() []indicatorPart {
 	return isb.buildIndicatorParts(currentModel)
 }
 
-// buildIndicatorParts builds individual indicator parts without joining them.
-// The git branch is not included here - it is rendered in the input box top
-// border by InputView, not in the status bar.
+// defaultIndicatorOrder is the built-in indicators' position when
+// chat.status_bar.order does not mention them. background_jobs covers both
+// the background_shells and a2a_tasks toggles.
+var defaultIndicatorOrder = []string{
+	"model", "theme", "max_output", "a2a_agents", "tools", "background_jobs",
+	"mcp", "context_usage", "session_tokens", "cost",
+}
+
+// buildIndicatorParts builds individual indicator parts without joining them,
+// in the configured order. The git branch is not included here - it is
+// rendered in the input box top border by InputView, not in the status bar.
 func (isb *InputStatusBar) buildIndicatorParts(currentModel string) []indicatorPart {
 	parts := []indicatorPart{}
-
-	if isb.shouldShowIndicator("model") {
-		parts = append(parts, indicatorPart{text: currentModel, action: ui.StatusIndicatorActionModelSelection})
+	for _, name := range isb.indicatorOrder() {
+		parts = append(parts, isb.buildIndicator(name, currentModel)...)
 	}
+	return parts
+}
 
-	if isb.shouldShowIndicator("theme") {
-		if themePart := isb.buildThemeIndicator(); themePart != "" {
-			parts = append(parts, indicatorPart{text: themePart, action: ui.StatusIndicatorActionThemeSelection})
-		}
+// indicatorOrder returns the configured order followed by the remaining
+// built-in indicators and then the remaining script segments.
+func (isb *InputStatusBar) indicatorOrder() []string {
+	var configured []string
+	var segments []config.StatusBarSegment
+	if isb.config != nil {
+		configured = isb.config.Chat.StatusBar.Order
+		segments = isb.config.Chat.StatusBar.Segments
 	}
 
-	if isb.shouldShowIndicator("max_output") {
-		if maxOutputPart := isb.buildMaxOutputIndicator(); maxOutputPart != "" {
-			parts = append(parts, indicatorPart{text: maxOutputPart})
+	order := make([]string, 0, len(defaultIndicatorOrder)+len(segments))
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name == "background_shells" || name == "a2a_tasks" {
+			name = "background_jobs"
 		}
-	}
-
-	if isb.shouldShowIndicator("a2a_agents") {
-		if agentsPart := isb.buildA2AAgentsIndicator(); agentsPart != "" {
-			parts = append(parts, indicatorPart{text: agentsPart, action: ui.StatusIndicatorActionA2AAgents, color: isb.a2aIndicatorColor()})
+		if !seen[name] {
+			seen[name] = true
+			order = append(order, name)
 		}
 	}
 
-	if isb.shouldShowIndicator("tools") {
-		if toolInfo := isb.getToolInfo(); toolInfo != "" {
-			parts = append(parts, indicatorPart{text: toolInfo, action: ui.StatusIndicatorActionToolsList})
-		}
+	for _, name := range configured {
+		add(name)
 	}
-
-	if isb.shouldShowIndicator("background_shells") || isb.shouldShowIndicator("a2a_tasks") {
-		if jobsInfo := isb.getBackgroundJobsInfo(); jobsInfo != "" {
-			parts = append(parts, indicatorPart{text: jobsInfo, action: ui.StatusIndicatorActionTaskManagement})
-		}
+	for _, name := range defaultIndicatorOrder {
+		add(name)
 	}
-
-	if isb.shouldShowIndicator("mcp") {
-		if mcpPart := isb.buildMCPIndicator(); mcpPart != "" {
-			parts = append(parts, indicatorPart{text: mcpPart})
-		}
+	for _, segment := range segments {
+		add(segment.Name)
 	}
+	return order
+}
 
-	if isb.shouldShowIndicator("context_usage") {
-		if contextIndicator := isb.getContextUsageIndicator(currentModel); contextIndicator != "" {
-			parts = append(parts, indicatorPart{text: contextIndicator})
+// buildIndicator builds the parts of one named indicator; names that are not
+// built in are looked up among the script segments.
+func (isb *InputStatusBar) buildIndicator(name, currentModel string) []indicatorPart {
+	var parts []indicatorPart
+	add := func(part indicatorPart) {
+		if part.text != "" {
+			parts = append(parts, part)
 		}
 	}
 
-	if isb.shouldShowIndicator("session_tokens") {
-		if sessionTokensPart := isb.buildSessionTokensIndicator(); sessionTokensPart != "" {
-			parts = append(parts, indicatorPart{text: sessionTokensPart})
+	switch name {
+	case "model":
+		if isb.shouldShowIndicator("model") {
+			add(indicatorPart{text: currentModel, action: ui.StatusIndicatorActionModelSelection})
 		}
-		if cachedTokensPart := isb.buildCachedTokensIndicator(); cachedTokensPart != "" {
-			parts = append(parts, indicatorPart{text: cachedTokensPart})
+	case "theme":
+		if isb.shouldShowIndicator("theme") {
+			add(indicatorPart{text: isb.buildThemeIndicator(), action: ui.StatusIndicatorActionThemeSelection})
 		}
+	case "max_output":
+		if isb.shouldShowIndicator("max_output") {
+			add(indicatorPart{text: isb.buildMaxOutputIndicator()})
+		}
+	case "a2a_agents":
+		if isb.shouldShowIndicator("a2a_agents") {
+			if agentsPart := isb.buildA2AAgentsIndicator(); agentsPart != "" {
+				add(indicatorPart{text: agentsPart + isb.a2aIndicatorMarker(), action: ui.StatusIndicatorActionA2AAgents, color: isb.a2aIndicatorColor()})
+			}
+		}
+	case "tools":
+		if isb.shouldShowIndicator("tools") {
+			add(indicatorPart{text: isb.getToolInfo(), action: ui.StatusIndicatorActionToolsList})
+		}
+	case "background_jobs":
+		if isb.shouldShowIndicator("background_shells") || isb.shouldShowIndicator("a2a_tasks") {
+			add(indicatorPart{text: isb.getBackgroundJobsInfo(), action: ui.StatusIndicatorActionTaskManagement})
+		}
+	case "mcp":
+		if isb.shouldShowIndicator("mcp") {
+			add(indicatorPart{text: isb.buildMCPIndicator()})
+		}
+	case "context_usage":
+		if isb.shouldShowIndicator("context_usage") {
+			add(indicatorPart{text: isb.getContextUsageIndicator(currentModel)})
+		}
+	case "session_tokens":
+		if isb.shouldShowIndicator("session_tokens") {
+			add(indicatorPart{text: isb.buildSessionTokensIndicator()})
+			add(indicatorPart{text: isb.buildCachedTokensIndicator()})
+		}
+	case "cost":
+		if isb.shouldShowIndicator("cost") {
+			add(indicatorPart{text: isb.buildCostIndicator(), color: isb.costIndicatorColor()})
+		}
+	default:
+		add(isb.buildSegmentIndicator(name))
 	}
+	return parts
+}
 
-	if isb.shouldShowIndicator("cost") {
-		if costPart := isb.buildCostIndicator(); costPart != "" {
-			parts = append(parts, indicatorPart{text: costPart})
+// buildSegmentIndicator builds the part for a script segment from its latest
+// output, colored with the segment's theme color when one is configured.
+func (isb *InputStatusBar) buildSegmentIndicator(name string) indicatorPart {
+	if isb.config == nil {
+		return indicatorPart{}
+	}
+	for _, segment := range isb.config.Chat.StatusBar.Segments {
+		if segment.Name != name {
+			continue
+		}
+		part := indicatorPart{text: isb.segmentOutputs[name]}
+		if segment.Color != "" && isb.styleProvider != nil {
+			part.color = isb.styleProvider.GetThemeColor(segment.Color)
 		}
+		return part
 	}
-
-	return parts
+	return indicatorPart{}
 }
 
 // selectedIndicatorPadding is the extra width the selected part's pill adds:
@@ -613,22 +694,54 @@ func (isb *InputStatusBar) buildA2AAgentsIndicator() string {
 // a2aIndicatorColor color-codes the A2A segment: green once all agents are
 // ready, red when any agent failed, empty (dim) while starting up.
 func (isb *InputStatusBar) a2aIndicatorColor() string {
-	if isb.stateManager == nil || isb.styleProvider == nil {
+	if isb.styleProvider == nil {
 		return ""
 	}
+	switch isb.a2aReadiness() {
+	case domain.AgentStateReady:
+		return isb.styleProvider.GetThemeColor("success")
+	case domain.AgentStateFailed:
+		return isb.styleProvider.GetThemeColor("error")
+	default:
+		return ""
+	}
+}
+
+// a2aIndicatorMarker stands in for the A2A segment's color under the
+// monochrome theme: ✓ once all agents are ready, ✗ when any agent failed.
+func (isb *InputStatusBar) a2aIndicatorMarker() string {
+	if isb.styleProvider == nil || !isb.styleProvider.IsMonochrome() {
+		return ""
+	}
+	switch isb.a2aReadiness() {
+	case domain.AgentStateReady:
+		return " ✓"
+	case domain.AgentStateFailed:
+		return " ✗"
+	default:
+		return ""
+	}
+}
+
+// a2aReadiness summarizes the agents as ready (all ready), failed (any
+// failed) or unknown while they are still starting up.
+func (isb *InputStatusBar) a2aReadiness() domain.AgentState {
+	if isb.stateManager == nil {
+		return domain.AgentStateUnknown
+	}
 	readiness := isb.stateManager.GetAgentReadiness()
 	if readiness == nil || readiness.TotalAgents == 0 {
-		return ""
+		return domain.AgentStateUnknown
 	}
 	if readiness.ReadyAgents >= readiness.TotalAgents {
-		return isb.styleProvider.GetThemeColor("success")
+		return domain.AgentStateReady
 	}
 	for _, agent := range readiness.Agents {
 		if agent.State == domain.AgentStateFailed {
-			return isb.styleProvider.GetThemeColor("error")
+			return domain.AgentStateFailed
 		}
 	}
-	return ""
+	return domain.AgentStateUnknown
 }
 
 // buildMCPIndicator builds the MCP server status indicator text
@@ -752,13 +865,59 @@ func (isb *InputStatusBar) buildCostIndicator() string {
 	}
 
 	// Format: $0.0234
-	if costStats.TotalCost < 0.01 {
-		return fmt.Sprintf("$%.4f", costStats.TotalCost)
-	} else if costStats.TotalCost < 1.0 {
-		return fmt.Sprintf("$%.3f", costStats.TotalCost)
-	} else {
-		return fmt.Sprintf("$%.2f", costStats.TotalCost)
+	cost := formatCostAmount(costStats.TotalCost)
+
+	// Format: $0.0234 (cache -$0.0120), what prompt cache hits saved
+	if costStats.TotalCacheSavings > 0 {
+		cost += fmt.Sprintf(" (cache -%s)", formatCostAmount(costStats.TotalCacheSavings))
+	}
+
+	// Format: $4.123 (82% of $5.00) or $4.123 (82% of $10.00/day)
+	if budget, limit, percent := isb.budgetStatus().Usage(); budget != "" {
+		suffix := ""
+		if budget == "daily" {
+			suffix = "/day"
+		}
+		cost += fmt.Sprintf(" (%.0f%% of $%.2f%s)", percent, limit, suffix)
+	}
+	return cost
+}
+
+// formatCostAmount formats a dollar amount with more precision the smaller
+// it is: $0.0234, $0.123, $4.12
+func formatCostAmount(amount float64) string {
+	switch {
+	case amount < 0.01:
+		return fmt.Sprintf("$%.4f", amount)
+	case amount < 1.0:
+		return fmt.Sprintf("$%.3f", amount)
+	default:
+		return fmt.Sprintf("$%.2f", amount)
+	}
+}
+
+// costIndicatorColor turns the cost indicator yellow past the budget warning
+// threshold and red once a budget is used up. The percentage in the text
+// carries the same information under the monochrome theme.
+func (isb *InputStatusBar) costIndicatorColor() string {
+	if isb.styleProvider == nil || isb.styleProvider.IsMonochrome() {
+		return ""
+	}
+	switch isb.budgetStatus().Level() {
+	case domain.BudgetExceeded:
+		return isb.styleProvider.GetThemeColor("error")
+	case domain.BudgetWarning:
+		return colors.WarningColor.Lipgloss
+	default:
+		return ""
+	}
+}
+
+func (isb *InputStatusBar) budgetStatus() domain.BudgetStatus {
+	if isb.budgetTracker == nil {
+		return domain.BudgetStatus{}
 	}
+	return isb.budgetTracker.BudgetStatus()
 }
 
 // getToolInfo returns tool count and token information
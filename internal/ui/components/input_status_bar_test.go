@@ -1129,3 +1129,70 @@ func TestInputStatusBar_A2AIndicatorCountsDown(t *testing.T) {
 		t.Fatalf("after recovery: got %q, want %q", got, "A2A: 1/1")
 	}
 }
+
+func TestInputStatusBar_OrderAndScriptSegments(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Chat.StatusBar.Order = []string{"k8s", "theme"}
+	cfg.Chat.StatusBar.Segments = []config.StatusBarSegment{
+		{Name: "vpn", Command: "vpn-status"},
+		{Name: "k8s", Command: "kubectl config current-context"},
+	}
+
+	themeService := &domainmocks.FakeThemeService{}
+	themeService.GetCurrentThemeNameReturns("tokyo-night")
+	statusBar := &InputStatusBar{config: cfg, themeService: themeService}
+
+	texts := func() []string {
+		var texts []string
+		for _, part := range statusBar.buildIndicatorParts("test-model") {
+			texts = append(texts, part.text)
+		}
+		return texts
+	}
+
+	if got := strings.Join(texts(), ","); got != "tokyo-night,test-model" {
+		t.Errorf("segments without output should be hidden, got %q", got)
+	}
+
+	statusBar.SetSegmentOutput("k8s", "prod-eu")
+	statusBar.SetSegmentOutput("vpn", "VPN up")
+	if got := strings.Join(texts(), ","); got != "prod-eu,tokyo-night,test-model,VPN up" {
+		t.Errorf("expected configured order then defaults then segments, got %q", got)
+	}
+
+	statusBar.SetSegmentOutput("k8s", "")
+	if got := strings.Join(texts(), ","); got != "tokyo-night,test-model,VPN up" {
+		t.Errorf("empty output should hide the segment again, got %q", got)
+	}
+}
+
+func TestInputStatusBar_MonochromeA2AMarker(t *testing.T) {
+	themeService := domain.NewThemeProvider()
+	provider := styles.NewProvider(themeService)
+
+	st := domain.NewApplicationState()
+	st.InitializeAgentReadiness(2)
+	st.UpdateAgentStatus("agent-a", domain.AgentStateReady, "", "", "")
+	statusBar := &InputStatusBar{stateManager: st, styleProvider: provider}
+
+	if got := statusBar.a2aIndicatorMarker(); got != "" {
+		t.Errorf("colored themes need no marker, got %q", got)
+	}
+
+	if err := themeService.SetTheme(domain.MonochromeThemeName); err != nil {
+		t.Fatal(err)
+	}
+	if got := statusBar.a2aIndicatorMarker(); got != "" {
+		t.Errorf("starting agents have no marker, got %q", got)
+	}
+
+	st.UpdateAgentStatus("agent-b", domain.AgentStateFailed, "", "", "")
+	if got := statusBar.a2aIndicatorMarker(); got != " ✗" {
+		t.Errorf("failed agent marker = %q, want %q", got, " ✗")
+	}
+
+	st.UpdateAgentStatus("agent-b", domain.AgentStateReady, "", "", "")
+	if got := statusBar.a2aIndicatorMarker(); got != " ✓" {
+		t.Errorf("ready marker = %q, want %q", got, " ✓")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -51,10 +52,12 @@ type InputView struct {
 	themeService         domain.ThemeService
 	styleProvider        *styles.Provider
 	imageAttachments     []domain.ImageAttachment
+	pastedTexts          map[string]string
 	messageQueue         domain.MessageQueue
 	historySuggestion    string
 	historySuggestions   []string
 	historySelectedIndex int
+	historySearch        *inputHistorySearch
 	focused              bool
 	usageHint            string
 	customHint           string
@@ -63,6 +66,7 @@ type InputView struct {
 	gitBranchCacheTTL    time.Duration
 	gitPRCache           string
 	resolveGitBranch     func() (string, error)
+	vim                  *vimEditor
 }
 
 // gitCurrentBranch returns the current git branch by shelling out to git. It is
@@ -172,6 +176,10 @@ func (iv *InputView) SetStateManager(stateManager inputViewState) {
 func (iv *InputView) SetConfig(cfg *config.Config) {
 	iv.config = cfg
 	iv.applyKeybindings(cfg.Chat.Keybindings)
+	iv.vim = nil
+	if cfg.Chat.Keybindings.Vim {
+		iv.vim = newVimEditor()
+	}
 	if cfg.Chat.InputMaxLines > 0 {
 		iv.ta.MaxHeight = cfg.Chat.InputMaxLines
 		iv.height = cfg.Chat.InputMaxLines + 2
@@ -264,7 +272,11 @@ func (iv *InputView) GetInput() string {
 func (iv *InputView) ClearInput() {
 	iv.ta.Reset()
 	iv.imageAttachments = []domain.ImageAttachment{}
+	iv.pastedTexts = nil
 	iv.historyManager.ResetNavigation()
+	if iv.vim != nil {
+		iv.vim = newVimEditor()
+	}
 }
 
 func (iv *InputView) SetPlaceholder(text string) {
@@ -382,7 +394,7 @@ func (iv *InputView) Render() string {
 
 	displayText := iv.renderDisplayText()
 
-	inputContent := fmt.Sprintf("> %s", displayText)
+	inputContent := fmt.Sprintf("%s %s", iv.promptSymbol(), displayText)
 
 	focused := isBashMode || isToolsMode
 	borderedInput := iv.styleProvider.RenderInputField(inputContent, iv.width-4, focused, iv.buildGitBranchLabel())
@@ -907,6 +919,75 @@ func (iv *InputView) HandleKey(k tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return iv, nil
 }
 
+// IsVimNormalMode reports whether Vim keybindings are enabled and the input is
+// in normal mode.
+func (iv *InputView) IsVimNormalMode() bool {
+	return iv.vim != nil && iv.vim.mode == vimModeNormal
+}
+
+// EnterVimNormalMode leaves insert mode. It returns false when Vim keybindings
+// are off or the input is already in normal mode, so the caller can give the
+// key its usual meaning.
+func (iv *InputView) EnterVimNormalMode() bool {
+	if iv.vim == nil || iv.vim.mode == vimModeNormal || iv.disabled {
+		return false
+	}
+	text := []rune(iv.ta.Value())
+	cursor := iv.vim.enterNormal(text, iv.runeCursor())
+	iv.SetCursor(len(string(text[:cursor])))
+	return true
+}
+
+// HandleVimKey applies a key in Vim normal mode. handled is false when the
+// input is not in normal mode or the key is not a Vim key (enter, arrows, ctrl
+// chords), in which case the key is processed as usual.
+func (iv *InputView) HandleVimKey(k tea.KeyPressMsg) (tea.Cmd, bool) {
+	if !iv.IsVimNormalMode() || iv.disabled {
+		return nil, false
+	}
+	if k.String() == "esc" && iv.vim.pending() {
+		iv.vim.reset()
+		return nil, true
+	}
+
+	before := iv.ta.Value()
+	text, cursor, consumed := iv.vim.handleKey(keys.PrintableText(k), []rune(before), iv.runeCursor())
+	if !consumed {
+		return nil, false
+	}
+
+	after := string(text)
+	if after != before {
+		iv.SetText(after)
+	}
+	iv.SetCursor(len(string(text[:cursor])))
+	if after == before {
+		return nil, true
+	}
+	pos := iv.GetCursor()
+	return func() tea.Msg {
+		return domain.AutocompleteUpdateEvent{Text: after, CursorPos: pos}
+	}, true
+}
+
+// runeCursor is GetCursor as a rune index, which is what the Vim editor uses.
+func (iv *InputView) runeCursor() int {
+	return utf8.RuneCountInString(iv.ta.Value()[:iv.GetCursor()])
+}
+
+// promptSymbol is the input prompt: "❮" in Vim normal mode, "❯" in Vim insert
+// mode, and the plain ">" when Vim keybindings are off.
+func (iv *InputView) promptSymbol() string {
+	switch {
+	case iv.vim == nil:
+		return ">"
+	case iv.vim.mode == vimModeNormal:
+		return "❮"
+	default:
+		return "❯"
+	}
+}
+
 // isNavigationKey reports whether the key is a cursor-movement key that should not
 // reset history navigation.
 func isNavigationKey(msg tea.KeyPressMsg) bool {
@@ -952,6 +1033,64 @@ func (iv *InputView) AddImageAttachment(image domain.ImageAttachment) {
 	iv.SetCursor(cursor + len(imageToken))
 }
 
+// AddPastedText stores a large paste as an attachment and inserts its
+// placeholder label at the cursor, keeping the input to a single line per
+// paste. Repeated pastes of the same size get a numbered label.
+func (iv *InputView) AddPastedText(text string) string {
+	lines := formatting.CountLines(text)
+	label := formatting.PastedTextLabel(lines)
+	for n := 2; iv.pastedTexts[label] != ""; n++ {
+		label = fmt.Sprintf("[pasted %d lines (%d)]", lines, n)
+	}
+	if iv.pastedTexts == nil {
+		iv.pastedTexts = make(map[string]string)
+	}
+	iv.pastedTexts[label] = text
+
+	cursor := iv.GetCursor()
+	value := iv.ta.Value()
+	iv.SetText(value[:cursor] + label + value[cursor:])
+	iv.SetCursor(cursor + len(label))
+	return label
+}
+
+// ExpandPastedText replaces the placeholder labels still present in input
+// with their pasted text, wrapped so the conversation view can collapse it
+// again. Labels the user deleted drop their attachment.
+func (iv *InputView) ExpandPastedText(input string) string {
+	for label, text := range iv.pastedTexts {
+		input = strings.Replace(input, label, formatting.WrapPastedText(text), 1)
+	}
+	return input
+}
+
+// Draft is the input as it was when sent: the raw text with its image
+// tokens and paste labels, plus the attachments those refer to.
+type Draft struct {
+	Text        string
+	Images      []domain.ImageAttachment
+	PastedTexts map[string]string
+}
+
+// Draft captures the current input so it can later be put back with
+// RestoreDraft.
+func (iv *InputView) Draft() Draft {
+	return Draft{
+		Text:        iv.ta.Value(),
+		Images:      slices.Clone(iv.imageAttachments),
+		PastedTexts: maps.Clone(iv.pastedTexts),
+	}
+}
+
+// RestoreDraft replaces the input with a captured draft, cursor at the end.
+func (iv *InputView) RestoreDraft(draft Draft) {
+	iv.ClearInput()
+	iv.imageAttachments = append(iv.imageAttachments, draft.Images...)
+	iv.pastedTexts = maps.Clone(draft.PastedTexts)
+	iv.SetText(draft.Text)
+	iv.SetCursor(len(draft.Text))
+}
+
 // GetImageAttachments returns the list of pending image attachments
 func (iv *InputView) GetImageAttachments() []domain.ImageAttachment {
 	return iv.imageAttachments
@@ -225,6 +225,33 @@ func TestInputView_AddImageAttachmentTokenHasNoIssueRef(t *testing.T) {
 	}
 }
 
+func TestInputView_PastedTextAttachments(t *testing.T) {
+	iv := NewInputView(createMockModelService())
+	trace := strings.Repeat("at frame\n", 30)
+
+	iv.SetText("see ")
+	iv.SetCursor(4)
+	first := iv.AddPastedText(trace)
+	second := iv.AddPastedText(trace)
+
+	if first != "[pasted 30 lines]" || second != "[pasted 30 lines (2)]" {
+		t.Fatalf("labels = %q, %q", first, second)
+	}
+	if got := iv.GetInput(); got != "see [pasted 30 lines][pasted 30 lines (2)]" {
+		t.Fatalf("input = %q", got)
+	}
+
+	expanded := iv.ExpandPastedText("see [pasted 30 lines (2)]")
+	if strings.Contains(expanded, "[pasted") || strings.Count(expanded, "at frame") != 30 {
+		t.Errorf("expected only the remaining label to expand, got %q", expanded)
+	}
+
+	iv.ClearInput()
+	if got := iv.ExpandPastedText(first); got != first {
+		t.Errorf("attachments should be dropped with the input, got %q", got)
+	}
+}
+
 func TestInputView_Render(t *testing.T) {
 	mockModelService := createMockModelService()
 	iv := createInputViewWithTheme(mockModelService)
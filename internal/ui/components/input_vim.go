@@ -0,0 +1,571 @@
+package components
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// vimMode is the editing mode of the chat input when Vim keybindings are on.
+type vimMode int
+
+const (
+	vimModeInsert vimMode = iota
+	vimModeNormal
+)
+
+// maxVimUndo bounds the undo history kept for normal-mode `u`.
+const maxVimUndo = 100
+
+type vimSnapshot struct {
+	text   []rune
+	cursor int
+}
+
+// vimEditor implements the normal-mode half of the input's optional Vim
+// keybindings (chat.keybindings.vim). Insert mode is the textarea itself; in
+// normal mode InputView hands each key here together with the buffer and a
+// rune cursor, and copies the result back into the textarea.
+//
+// Supported: counts, h l j k 0 ^ $ w b e gg G motions, the d c y operators
+// with those motions (and dd cc yy), i a I A o O s S x X D C r ~ p P u.
+type vimEditor struct {
+	mode vimMode
+
+	// Pending command state: counts before and after an operator, the
+	// operator itself (d, c, y) and a prefix waiting for its second key
+	// (g for gg, r for the replacement character).
+	count    string
+	opCount  string
+	operator string
+	prefix   string
+
+	register []rune
+	linewise bool
+
+	undo []vimSnapshot
+}
+
+func newVimEditor() *vimEditor {
+	return &vimEditor{mode: vimModeInsert}
+}
+
+// reset drops any half-typed command.
+func (v *vimEditor) reset() {
+	v.count, v.opCount, v.operator, v.prefix = "", "", "", ""
+}
+
+// pending reports whether a command is partially typed, so esc can cancel it
+// instead of falling through to the global cancel action.
+func (v *vimEditor) pending() bool {
+	return v.count != "" || v.opCount != "" || v.operator != "" || v.prefix != ""
+}
+
+func (v *vimEditor) pushUndo(text []rune, cursor int) {
+	v.undo = append(v.undo, vimSnapshot{text: append([]rune(nil), text...), cursor: cursor})
+	if len(v.undo) > maxVimUndo {
+		v.undo = v.undo[len(v.undo)-maxVimUndo:]
+	}
+}
+
+// enterNormal switches to normal mode; like Vim, the cursor steps back onto
+// the last inserted character.
+func (v *vimEditor) enterNormal(text []rune, cursor int) int {
+	v.mode = vimModeNormal
+	v.reset()
+	if cursor > lineStartAt(text, cursor) {
+		cursor--
+	}
+	return clampNormalCursor(text, cursor)
+}
+
+// enterInsert switches to insert mode, recording the buffer so the whole
+// insert session undoes as one step.
+func (v *vimEditor) enterInsert(text []rune, cursor int) {
+	v.pushUndo(text, cursor)
+	v.mode = vimModeInsert
+	v.reset()
+}
+
+// handleKey applies one normal-mode key, given as its printable text. consumed
+// is false for anything that isn't a single character (enter, arrows, ctrl
+// chords), which Vim mode leaves to the rest of the UI; every printable key is
+// consumed so stray letters never land in the buffer.
+func (v *vimEditor) handleKey(key string, text []rune, cursor int) (out []rune, newCursor int, consumed bool) {
+	if len([]rune(key)) != 1 {
+		return text, cursor, false
+	}
+
+	if v.prefix == "r" {
+		n := v.takeCount()
+		v.reset()
+		return v.replaceChars(text, cursor, []rune(key)[0], n), cursor + n - 1, true
+	}
+
+	if isVimCountKey(key, v.operator == "", v.count, v.opCount) {
+		if v.operator == "" {
+			v.count += key
+		} else {
+			v.opCount += key
+		}
+		return text, cursor, true
+	}
+
+	if v.prefix == "g" {
+		v.prefix = ""
+		if key != "g" {
+			v.reset()
+			return text, cursor, true
+		}
+		key = "gg"
+	} else if key == "g" {
+		v.prefix = "g"
+		return text, cursor, true
+	}
+
+	if v.operator != "" {
+		out, newCursor = v.applyOperator(key, text, cursor)
+		return out, clampCursor(v, out, newCursor), true
+	}
+
+	out, newCursor = v.applyCommand(key, text, cursor)
+	return out, clampCursor(v, out, newCursor), true
+}
+
+// clampCursor keeps the normal-mode cursor on a character; insert mode may
+// sit after the last one.
+func clampCursor(v *vimEditor, text []rune, cursor int) int {
+	if v.mode == vimModeNormal {
+		return clampNormalCursor(text, cursor)
+	}
+	return max(0, min(cursor, len(text)))
+}
+
+func isVimCountKey(key string, beforeOperator bool, count, opCount string) bool {
+	if key[0] < '0' || key[0] > '9' {
+		return false
+	}
+	if key == "0" {
+		if beforeOperator {
+			return count != ""
+		}
+		return opCount != ""
+	}
+	return true
+}
+
+// takeCount returns the effective repeat count (count before the operator
+// times count after it) and clears both.
+func (v *vimEditor) takeCount() int {
+	n := 1
+	if c, err := strconv.Atoi(v.count); err == nil && c > 0 {
+		n = c
+	}
+	if c, err := strconv.Atoi(v.opCount); err == nil && c > 0 {
+		n *= c
+	}
+	v.count, v.opCount = "", ""
+	return n
+}
+
+// applyCommand runs a key that is not part of an operator: a motion, an
+// operator start, or a standalone command.
+func (v *vimEditor) applyCommand(key string, text []rune, cursor int) ([]rune, int) {
+	switch key {
+	case "d", "c", "y":
+		v.operator = key
+		return text, cursor
+	case "r":
+		v.prefix = "r"
+		return text, cursor
+	}
+
+	n := v.takeCount()
+	if target, _, _, ok := vimMotion(key, text, cursor, n, false); ok {
+		return text, target
+	}
+
+	start, end := lineStartAt(text, cursor), lineEndAt(text, cursor)
+	switch key {
+	case "i":
+		v.enterInsert(text, cursor)
+		return text, cursor
+	case "a":
+		v.enterInsert(text, cursor)
+		return text, min(cursor+1, end)
+	case "I":
+		v.enterInsert(text, cursor)
+		return text, firstNonBlankAt(text, cursor)
+	case "A":
+		v.enterInsert(text, cursor)
+		return text, end
+	case "o":
+		v.enterInsert(text, cursor)
+		return insertRunes(text, end, []rune("\n")), end + 1
+	case "O":
+		v.enterInsert(text, cursor)
+		return insertRunes(text, start, []rune("\n")), start
+	case "x":
+		if cursor >= end {
+			return text, cursor
+		}
+		return v.deleteRange(text, cursor, min(cursor+n, end), false), cursor
+	case "X":
+		from := max(start, cursor-n)
+		return v.deleteRange(text, from, cursor, false), from
+	case "s":
+		v.pushUndo(text, cursor)
+		out := v.cut(text, cursor, min(cursor+n, end), false)
+		v.mode = vimModeInsert
+		return out, cursor
+	case "D":
+		return v.deleteRange(text, cursor, end, false), cursor
+	case "C", "S":
+		from := cursor
+		if key == "S" {
+			from = start
+		}
+		v.pushUndo(text, cursor)
+		out := v.cut(text, from, end, false)
+		v.mode = vimModeInsert
+		return out, from
+	case "~":
+		v.pushUndo(text, cursor)
+		out := append([]rune(nil), text...)
+		stop := min(cursor+n, end)
+		for i := cursor; i < stop; i++ {
+			out[i] = toggleCase(out[i])
+		}
+		return out, stop
+	case "p", "P":
+		return v.paste(text, cursor, key == "P", n)
+	case "u":
+		for range n {
+			if len(v.undo) == 0 {
+				break
+			}
+			snap := v.undo[len(v.undo)-1]
+			v.undo = v.undo[:len(v.undo)-1]
+			text, cursor = snap.text, snap.cursor
+		}
+		return text, cursor
+	}
+	return text, cursor
+}
+
+// applyOperator completes a pending d/c/y with a motion or its doubled form
+// (dd, cc, yy) and resets the pending state.
+func (v *vimEditor) applyOperator(key string, text []rune, cursor int) ([]rune, int) {
+	op := v.operator
+	n := v.takeCount()
+	v.reset()
+
+	var from, to int
+	linewise := false
+	switch {
+	case key == op:
+		linewise = true
+		from, to = cursor, lineStartAfter(text, cursor, n-1)
+	case op == "c" && key == "w":
+		// cw changes to the end of the word, like ce.
+		key = "e"
+		fallthrough
+	default:
+		target, inclusive, lw, ok := vimMotion(key, text, cursor, n, true)
+		if !ok {
+			return text, cursor
+		}
+		linewise = lw
+		from, to = min(cursor, target), max(cursor, target)
+		if inclusive && to < len(text) {
+			to++
+		}
+		if key == "w" && !linewise {
+			to = min(to, max(lineEndAt(text, cursor), from))
+		}
+	}
+
+	if linewise {
+		from, to = lineStartAt(text, from), lineEndAt(text, to)
+	}
+
+	switch op {
+	case "y":
+		v.yank(text, from, to, linewise)
+		return text, from
+	case "d":
+		out := v.deleteRange(text, from, to, linewise)
+		if linewise {
+			return out, firstNonBlankAt(out, min(from, len(out)))
+		}
+		return out, from
+	default: // "c"
+		// A linewise change (cc, cj) keeps the emptied line to type into, so
+		// the separator stays; the register still pastes linewise.
+		v.pushUndo(text, cursor)
+		out := v.cut(text, from, to, false)
+		v.linewise = linewise
+		v.mode = vimModeInsert
+		return out, from
+	}
+}
+
+// deleteRange removes [from, to) into the register after recording undo.
+// Linewise deletes also take one line separator so no blank line is left.
+func (v *vimEditor) deleteRange(text []rune, from, to int, linewise bool) []rune {
+	if from >= to && !linewise {
+		return text
+	}
+	v.pushUndo(text, from)
+	return v.cut(text, from, to, linewise)
+}
+
+func (v *vimEditor) cut(text []rune, from, to int, linewise bool) []rune {
+	v.yank(text, from, to, linewise)
+	if linewise {
+		switch {
+		case to < len(text):
+			to++
+		case from > 0:
+			from--
+		}
+	}
+	out := append([]rune(nil), text[:from]...)
+	return append(out, text[to:]...)
+}
+
+func (v *vimEditor) yank(text []rune, from, to int, linewise bool) {
+	v.register = append([]rune(nil), text[from:to]...)
+	v.linewise = linewise
+}
+
+func (v *vimEditor) paste(text []rune, cursor int, before bool, n int) ([]rune, int) {
+	if len(v.register) == 0 {
+		return text, cursor
+	}
+	v.pushUndo(text, cursor)
+
+	var chunk []rune
+	for range n {
+		if v.linewise && len(chunk) > 0 {
+			chunk = append(chunk, '\n')
+		}
+		chunk = append(chunk, v.register...)
+	}
+
+	if v.linewise {
+		if before {
+			at := lineStartAt(text, cursor)
+			return insertRunes(text, at, append(chunk, '\n')), at
+		}
+		at := lineEndAt(text, cursor)
+		return insertRunes(text, at, append([]rune{'\n'}, chunk...)), at + 1
+	}
+
+	at := cursor
+	if !before && cursor < lineEndAt(text, cursor) {
+		at++
+	}
+	return insertRunes(text, at, chunk), at + len(chunk) - 1
+}
+
+func (v *vimEditor) replaceChars(text []rune, cursor int, r rune, n int) []rune {
+	if cursor+n > lineEndAt(text, cursor) {
+		return text
+	}
+	v.pushUndo(text, cursor)
+	out := append([]rune(nil), text...)
+	for i := cursor; i < cursor+n; i++ {
+		out[i] = r
+	}
+	return out
+}
+
+// vimMotion resolves a motion key to a target cursor. inclusive marks motions
+// whose target character belongs to an operator's range (e); linewise marks
+// motions that make operators act on whole lines (j, k, G, gg). forOperator
+// lets l and $ reach one past the last character of the line.
+func vimMotion(key string, text []rune, cursor, n int, forOperator bool) (target int, inclusive, linewise, ok bool) {
+	start, end := lineStartAt(text, cursor), lineEndAt(text, cursor)
+	lastChar := end
+	if !forOperator && end > start {
+		lastChar = end - 1
+	}
+
+	switch key {
+	case "h":
+		return max(start, cursor-n), false, false, true
+	case "l":
+		return min(lastChar, cursor+n), false, false, true
+	case "0":
+		return start, false, false, true
+	case "^":
+		return firstNonBlankAt(text, cursor), false, false, true
+	case "$":
+		return lastChar, false, false, true
+	case "w":
+		for range n {
+			cursor = nextWordStart(text, cursor)
+		}
+		return cursor, false, false, true
+	case "b":
+		for range n {
+			cursor = prevWordStart(text, cursor)
+		}
+		return cursor, false, false, true
+	case "e":
+		for range n {
+			cursor = wordEnd(text, cursor)
+		}
+		return cursor, true, false, true
+	case "j":
+		return moveLines(text, cursor, n), false, true, true
+	case "k":
+		return moveLines(text, cursor, -n), false, true, true
+	case "G":
+		return lineStartAt(text, len(text)), false, true, true
+	case "gg":
+		return 0, false, true, true
+	}
+	return cursor, false, false, false
+}
+
+// Word classes for w/b/e: blanks, keyword characters, and other punctuation.
+func vimCharClass(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return 0
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func nextWordStart(text []rune, i int) int {
+	if i >= len(text) {
+		return len(text)
+	}
+	class := vimCharClass(text[i])
+	for i < len(text) && class != 0 && vimCharClass(text[i]) == class {
+		i++
+	}
+	for i < len(text) && vimCharClass(text[i]) == 0 {
+		i++
+	}
+	return i
+}
+
+func prevWordStart(text []rune, i int) int {
+	if i > len(text) {
+		i = len(text)
+	}
+	i--
+	for i > 0 && vimCharClass(text[i]) == 0 {
+		i--
+	}
+	if i <= 0 {
+		return 0
+	}
+	class := vimCharClass(text[i])
+	for i > 0 && vimCharClass(text[i-1]) == class {
+		i--
+	}
+	return i
+}
+
+func wordEnd(text []rune, i int) int {
+	i++
+	for i < len(text) && vimCharClass(text[i]) == 0 {
+		i++
+	}
+	if i >= len(text) {
+		return max(0, len(text)-1)
+	}
+	class := vimCharClass(text[i])
+	for i+1 < len(text) && vimCharClass(text[i+1]) == class {
+		i++
+	}
+	return i
+}
+
+func lineStartAt(text []rune, i int) int {
+	i = min(i, len(text))
+	for i > 0 && text[i-1] != '\n' {
+		i--
+	}
+	return i
+}
+
+func lineEndAt(text []rune, i int) int {
+	for i < len(text) && text[i] != '\n' {
+		i++
+	}
+	return i
+}
+
+// lineStartAfter returns a position on the line n lines below the cursor's,
+// stopping at the last line.
+func lineStartAfter(text []rune, i, n int) int {
+	for range n {
+		end := lineEndAt(text, i)
+		if end >= len(text) {
+			break
+		}
+		i = end + 1
+	}
+	return i
+}
+
+func firstNonBlankAt(text []rune, i int) int {
+	j, end := lineStartAt(text, i), lineEndAt(text, i)
+	for j < end && (text[j] == ' ' || text[j] == '\t') {
+		j++
+	}
+	return j
+}
+
+// moveLines moves the cursor n lines down (negative: up), keeping the column
+// where the target line is long enough.
+func moveLines(text []rune, cursor, n int) int {
+	col := cursor - lineStartAt(text, cursor)
+	line := lineStartAt(text, cursor)
+	for ; n > 0; n-- {
+		end := lineEndAt(text, line)
+		if end >= len(text) {
+			break
+		}
+		line = end + 1
+	}
+	for ; n < 0; n++ {
+		if line == 0 {
+			break
+		}
+		line = lineStartAt(text, line-1)
+	}
+	return min(line+col, lineEndAt(text, line))
+}
+
+// clampNormalCursor keeps the cursor on a character of its line, as Vim's
+// normal mode never rests past the last one.
+func clampNormalCursor(text []rune, cursor int) int {
+	cursor = max(0, min(cursor, len(text)))
+	start, end := lineStartAt(text, cursor), lineEndAt(text, cursor)
+	if cursor == end && end > start {
+		return end - 1
+	}
+	return cursor
+}
+
+func insertRunes(text []rune, at int, chunk []rune) []rune {
+	out := make([]rune, 0, len(text)+len(chunk))
+	out = append(out, text[:at]...)
+	out = append(out, chunk...)
+	return append(out, text[at:]...)
+}
+
+func toggleCase(r rune) rune {
+	if unicode.IsUpper(r) {
+		return unicode.ToLower(r)
+	}
+	return unicode.ToUpper(r)
+}
@@ -0,0 +1,115 @@
+package components
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// runVimKeys feeds keys to a normal-mode vimEditor, one character each.
+func runVimKeys(v *vimEditor, text string, cursor int, keys string) (string, int) {
+	buf := []rune(text)
+	for _, k := range keys {
+		buf, cursor, _ = v.handleKey(string(k), buf, cursor)
+	}
+	return string(buf), cursor
+}
+
+func TestVimEditor_NormalMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		cursor     int
+		keys       string
+		wantText   string
+		wantCursor int
+		wantInsert bool
+	}{
+		{name: "w moves to next word", text: "hello world", keys: "w", wantText: "hello world", wantCursor: 6},
+		{name: "$ stops on the last character", text: "hello", keys: "$", wantText: "hello", wantCursor: 4},
+		{name: "G goes to the last line", text: "a\nb\nc", keys: "G", wantText: "a\nb\nc", wantCursor: 4},
+		{name: "dw", text: "hello world", keys: "dw", wantText: "world", wantCursor: 0},
+		{name: "count before operator", text: "foo bar baz", keys: "2dw", wantText: "baz", wantCursor: 0},
+		{name: "dw on last word stays on the line", text: "hello world\nnext", cursor: 6, keys: "dw", wantText: "hello \nnext", wantCursor: 5},
+		{name: "de", text: "abc def", keys: "de", wantText: " def", wantCursor: 0},
+		{name: "cw changes to word end", text: "hello world", keys: "cw", wantText: " world", wantCursor: 0, wantInsert: true},
+		{name: "x", text: "hello world", cursor: 6, keys: "x", wantText: "hello orld", wantCursor: 6},
+		{name: "3x", text: "abc", keys: "3x", wantText: "", wantCursor: 0},
+		{name: "D", text: "abc", cursor: 1, keys: "D", wantText: "a", wantCursor: 0},
+		{name: "dd", text: "one\ntwo\nthree", cursor: 4, keys: "dd", wantText: "one\nthree", wantCursor: 4},
+		{name: "dd on last line", text: "one\ntwo", cursor: 4, keys: "dd", wantText: "one", wantCursor: 0},
+		{name: "yyp", text: "one\ntwo", keys: "yyp", wantText: "one\none\ntwo", wantCursor: 4},
+		{name: "r", text: "hello", keys: "rj", wantText: "jello", wantCursor: 0},
+		{name: "~", text: "Hello", keys: "~~", wantText: "hEllo", wantCursor: 2},
+		{name: "A appends", text: "hello", keys: "A", wantText: "hello", wantCursor: 5, wantInsert: true},
+		{name: "o opens a line", text: "one\ntwo", keys: "o", wantText: "one\n\ntwo", wantCursor: 4, wantInsert: true},
+		{name: "undo", text: "abc", keys: "xu", wantText: "abc", wantCursor: 0},
+		{name: "unknown keys are swallowed", text: "abc", keys: "zQ", wantText: "abc", wantCursor: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newVimEditor()
+			v.mode = vimModeNormal
+
+			gotText, gotCursor := runVimKeys(v, tt.text, tt.cursor, tt.keys)
+			if gotText != tt.wantText {
+				t.Errorf("text = %q, want %q", gotText, tt.wantText)
+			}
+			if gotCursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", gotCursor, tt.wantCursor)
+			}
+			if gotInsert := v.mode == vimModeInsert; gotInsert != tt.wantInsert {
+				t.Errorf("insert mode = %v after %q, want %v", gotInsert, tt.keys, tt.wantInsert)
+			}
+		})
+	}
+}
+
+func TestVimEditor_EnterNormalStepsBack(t *testing.T) {
+	v := newVimEditor()
+	if got := v.enterNormal([]rune("abc"), 3); got != 2 {
+		t.Errorf("cursor = %d, want 2", got)
+	}
+	if got := v.enterNormal([]rune("abc\n"), 4); got != 4 {
+		t.Errorf("cursor on empty line = %d, want 4", got)
+	}
+}
+
+func TestInputView_VimMode(t *testing.T) {
+	iv := createInputViewWithTheme(createMockModelService())
+	iv.vim = newVimEditor()
+	iv.SetText("hello world")
+	iv.SetCursor(len("hello world"))
+
+	if _, handled := iv.HandleVimKey(tea.KeyPressMsg{Code: 'b', Text: "b"}); handled {
+		t.Fatal("insert mode must leave keys to the textarea")
+	}
+	if !iv.EnterVimNormalMode() {
+		t.Fatal("expected esc to enter normal mode")
+	}
+	if iv.EnterVimNormalMode() {
+		t.Error("entering normal mode twice should report false")
+	}
+	if iv.promptSymbol() != "❮" {
+		t.Errorf("prompt = %q, want normal-mode symbol", iv.promptSymbol())
+	}
+
+	for _, k := range "bdw" {
+		if _, handled := iv.HandleVimKey(tea.KeyPressMsg{Code: k, Text: string(k)}); !handled {
+			t.Fatalf("expected %q to be handled in normal mode", k)
+		}
+	}
+	if got := iv.GetInput(); got != "hello " {
+		t.Errorf("input = %q, want %q", got, "hello ")
+	}
+
+	if _, handled := iv.HandleVimKey(tea.KeyPressMsg{Code: tea.KeyEnter}); handled {
+		t.Error("enter must fall through so the message can be sent")
+	}
+
+	iv.ClearInput()
+	if iv.IsVimNormalMode() {
+		t.Error("a cleared input should start in insert mode")
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
 	models "github.com/inference-gateway/cli/internal/models"
 	styles "github.com/inference-gateway/cli/internal/ui/styles"
 )
@@ -281,7 +282,7 @@ func (m *ModelSelectorImpl) formatModelSuffix(model string) string {
 
 	window, ok := models.LookupContextWindow(model)
 	if ok {
-		parts = append(parts, formatContextWindow(window))
+		parts = append(parts, formatting.FormatContextWindow(window))
 	} else {
 		parts = append(parts, "?")
 	}
@@ -293,24 +294,6 @@ func (m *ModelSelectorImpl) formatModelSuffix(model string) string {
 	return fmt.Sprintf("(%s)", strings.Join(parts, ", "))
 }
 
-// formatContextWindow renders a token count as "1M" / "128K" / raw, picking
-// the most readable form. Boundaries are exact multiples to avoid awkward
-// numbers like "1.0M" when a matcher returns 1_000_000.
-func formatContextWindow(tokens int) string {
-	switch {
-	case tokens >= 1_000_000 && tokens%1_000_000 == 0:
-		return fmt.Sprintf("%dM", tokens/1_000_000)
-	case tokens >= 1_000_000:
-		return fmt.Sprintf("%.1fM", float64(tokens)/1_000_000)
-	case tokens >= 1024 && tokens%1024 == 0:
-		return fmt.Sprintf("%dK", tokens/1024)
-	case tokens >= 1000:
-		return fmt.Sprintf("%dK", tokens/1000)
-	default:
-		return fmt.Sprintf("%d", tokens)
-	}
-}
-
 // tabModels returns the models visible under the current pricing tab.
 func (m *ModelSelectorImpl) tabModels() []string {
 	switch m.currentView {
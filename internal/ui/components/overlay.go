@@ -0,0 +1,32 @@
+package components
+
+import (
+	"strings"
+
+	ansi "github.com/charmbracelet/x/ansi"
+
+	colors "github.com/inference-gateway/cli/internal/ui/styles/colors"
+)
+
+// overlayBlock draws block over lines with its top-left corner at row and
+// col, keeping what lies left and right of it. Lines shorter than col are
+// padded, and rows of the block past the last line are dropped.
+func overlayBlock(lines []string, block string, row, col int) []string {
+	for i, blockLine := range strings.Split(block, "\n") {
+		r := row + i
+		if r < 0 {
+			continue
+		}
+		if r >= len(lines) {
+			break
+		}
+		line := lines[r]
+		left := ansi.Cut(line, 0, col)
+		if pad := col - ansi.StringWidth(left); pad > 0 {
+			left += strings.Repeat(" ", pad)
+		}
+		right := ansi.Cut(line, col+ansi.StringWidth(blockLine), ansi.StringWidth(line))
+		lines[r] = left + colors.Reset + blockLine + right
+	}
+	return lines
+}
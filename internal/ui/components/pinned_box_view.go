@@ -0,0 +1,113 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
+	styles "github.com/inference-gateway/cli/internal/ui/styles"
+)
+
+// pinnedItem is one pinned message as listed in the panel
+type pinnedItem struct {
+	role    sdk.MessageRole
+	content string
+}
+
+// PinnedBoxView displays the pinned messages of the conversation in a
+// collapsible panel above the input. It starts collapsed to a single line.
+type PinnedBoxView struct {
+	width         int
+	styleProvider *styles.Provider
+	items         []pinnedItem
+	expanded      bool
+}
+
+// NewPinnedBoxView creates a new pinned messages panel
+func NewPinnedBoxView(styleProvider *styles.Provider) *PinnedBoxView {
+	return &PinnedBoxView{
+		width:         80,
+		styleProvider: styleProvider,
+	}
+}
+
+// SetWidth sets the component width
+func (pv *PinnedBoxView) SetWidth(width int) {
+	pv.width = width
+}
+
+// SetEntries picks the pinned messages out of the conversation
+func (pv *PinnedBoxView) SetEntries(entries []domain.ConversationEntry) {
+	pv.items = pv.items[:0]
+	for _, entry := range entries {
+		if !entry.Pinned {
+			continue
+		}
+		content, _ := entry.Message.Content.AsMessageContent0()
+		pv.items = append(pv.items, pinnedItem{role: entry.Message.Role, content: content})
+	}
+}
+
+// Toggle toggles the expanded state
+func (pv *PinnedBoxView) Toggle() {
+	pv.expanded = !pv.expanded
+}
+
+// IsExpanded returns whether the panel is expanded
+func (pv *PinnedBoxView) IsExpanded() bool {
+	return pv.expanded
+}
+
+// HasPinned returns whether any message is pinned
+func (pv *PinnedBoxView) HasPinned() bool {
+	return len(pv.items) > 0
+}
+
+// GetHeight returns the height of the rendered component
+func (pv *PinnedBoxView) GetHeight() int {
+	if !pv.HasPinned() {
+		return 0
+	}
+	if !pv.expanded {
+		return 1
+	}
+	// header + one line per message + border
+	return len(pv.items) + 3
+}
+
+// Render renders the pinned messages panel
+func (pv *PinnedBoxView) Render() string {
+	if !pv.HasPinned() {
+		return ""
+	}
+
+	accentColor := pv.styleProvider.GetThemeColor("accent")
+	dimColor := pv.styleProvider.GetThemeColor("dim")
+
+	summary := fmt.Sprintf("📌 %d pinned", len(pv.items))
+	if !pv.expanded {
+		return fmt.Sprintf(" %s %s",
+			pv.styleProvider.RenderWithColor(summary, accentColor),
+			pv.styleProvider.RenderWithColor("(alt+p to expand)", dimColor))
+	}
+
+	lines := []string{fmt.Sprintf("%s %s",
+		pv.styleProvider.RenderWithColorAndBold(summary, accentColor),
+		pv.styleProvider.RenderWithColor("(alt+p to collapse)", dimColor))}
+
+	for _, item := range pv.items {
+		role := "User"
+		if item.role == sdk.Assistant {
+			role = "Assistant"
+		}
+		prefix := fmt.Sprintf("[%s] ", role)
+		text := strings.Join(strings.Fields(item.content), " ")
+		text = formatting.TruncateText(text, max(pv.width-len(prefix)-6, 20))
+		lines = append(lines, " "+pv.styleProvider.RenderWithColor(prefix, dimColor)+text)
+	}
+
+	return pv.styleProvider.RenderBorderedBox(strings.Join(lines, "\n"), dimColor, 0, 1)
+}
@@ -0,0 +1,51 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	ansi "github.com/charmbracelet/x/ansi"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func TestPinnedBoxView(t *testing.T) {
+	pv := NewPinnedBoxView(createMockStyleProvider())
+
+	if pv.HasPinned() || pv.GetHeight() != 0 || pv.Render() != "" {
+		t.Fatal("an empty panel must take no space")
+	}
+
+	pv.SetEntries([]domain.ConversationEntry{
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("not pinned")}},
+		{Message: sdk.Message{Role: sdk.User, Content: sdk.NewMessageContent("always use tabs")}, Pinned: true},
+		{Message: sdk.Message{Role: sdk.Assistant, Content: sdk.NewMessageContent("the API is v2")}, Pinned: true},
+	})
+
+	if got := pv.GetHeight(); got != 1 {
+		t.Errorf("collapsed height = %d, want 1", got)
+	}
+	if out := ansi.Strip(pv.Render()); !strings.Contains(out, "2 pinned") || strings.Contains(out, "always use tabs") {
+		t.Errorf("collapsed panel should only show the count, got %q", out)
+	}
+
+	pv.Toggle()
+	if got := pv.GetHeight(); got != 5 {
+		t.Errorf("expanded height = %d, want 5", got)
+	}
+	out := ansi.Strip(pv.Render())
+	for _, want := range []string{"[User] always use tabs", "[Assistant] the API is v2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expanded panel missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "not pinned") {
+		t.Error("unpinned messages must not be listed")
+	}
+
+	pv.SetEntries(nil)
+	if pv.HasPinned() {
+		t.Error("expected no pinned messages after the history is cleared")
+	}
+}
@@ -17,6 +17,8 @@ type QueueBoxView struct {
 	width         int
 	styleProvider *styles.Provider
 	toolFormatter domain.ToolFormatter
+	focused       bool // true while the user is managing the queue
+	cursor        int  // selected message while focused
 }
 
 func NewQueueBoxView(styleProvider *styles.Provider) *QueueBoxView {
@@ -39,6 +41,38 @@ func (qv *QueueBoxView) SetWidth(width int) {
 func (qv *QueueBoxView) SetHeight(height int) {
 }
 
+// Focus gives the queue keyboard focus so its messages can be reordered,
+// edited and deleted. It reports false when the queue of size messages is
+// empty.
+func (qv *QueueBoxView) Focus(size int) bool {
+	if size == 0 {
+		return false
+	}
+	qv.focused = true
+	qv.cursor = max(min(qv.cursor, size-1), 0)
+	return true
+}
+
+// Blur returns keyboard focus to the input
+func (qv *QueueBoxView) Blur() {
+	qv.focused = false
+}
+
+// IsFocused returns whether the queue holds keyboard focus
+func (qv *QueueBoxView) IsFocused() bool {
+	return qv.focused
+}
+
+// Cursor returns the index of the selected message
+func (qv *QueueBoxView) Cursor() int {
+	return qv.cursor
+}
+
+// SetCursor selects the message at index, clamped to a queue of size messages
+func (qv *QueueBoxView) SetCursor(index, size int) {
+	qv.cursor = max(min(index, size-1), 0)
+}
+
 func (qv *QueueBoxView) Render(queuedMessages []domain.QueuedMessage) string {
 	if len(queuedMessages) == 0 {
 		return ""
@@ -49,13 +83,26 @@ func (qv *QueueBoxView) Render(queuedMessages []domain.QueuedMessage) string {
 
 func (qv *QueueBoxView) renderQueuedMessages(queuedMessages []domain.QueuedMessage) string {
 	var messageLines []string
-	for _, queuedMsg := range queuedMessages {
+	if qv.focused {
+		hint := fmt.Sprintf(" Queue (%d) · ↑/↓ select · shift+↑/↓ reorder · e edit · p priority · d delete · esc done", len(queuedMessages))
+		messageLines = append(messageLines, qv.styleProvider.RenderWithColor(hint, qv.styleProvider.GetThemeColor("dim")))
+	}
+	for i, queuedMsg := range queuedMessages {
+		if qv.focused && i == qv.cursor {
+			messageLines = append(messageLines, qv.formatSelectedMessage(queuedMsg))
+			continue
+		}
 		messageLines = append(messageLines, qv.formatQueuedMessage(queuedMsg))
 	}
 
 	return strings.Join(messageLines, "\n")
 }
 
+func (qv *QueueBoxView) formatSelectedMessage(queuedMsg domain.QueuedMessage) string {
+	accentColor := qv.styleProvider.GetThemeColor("accent")
+	return qv.styleProvider.RenderWithColor(" › "+qv.formatMessagePreview(queuedMsg), accentColor)
+}
+
 func (qv *QueueBoxView) formatQueuedMessage(queuedMsg domain.QueuedMessage) string {
 	dimColor := qv.styleProvider.GetThemeColor("dim")
 	preview := qv.formatMessagePreview(queuedMsg)
@@ -65,7 +112,17 @@ func (qv *QueueBoxView) formatQueuedMessage(queuedMsg domain.QueuedMessage) stri
 	return qv.styleProvider.RenderWithColor(formattedLine, dimColor)
 }
 
+// formatMessagePreview returns the message's preview, tagged with its
+// priority unless it is normal
 func (qv *QueueBoxView) formatMessagePreview(queuedMsg domain.QueuedMessage) string {
+	preview := qv.formatContentPreview(queuedMsg)
+	if label := queuedMsg.Priority.Label(); label != "" {
+		return fmt.Sprintf("[%s] %s", label, preview)
+	}
+	return preview
+}
+
+func (qv *QueueBoxView) formatContentPreview(queuedMsg domain.QueuedMessage) string {
 	msg := queuedMsg.Message
 
 	if msg.ToolCalls != nil && len(*msg.ToolCalls) > 0 {
@@ -76,6 +133,7 @@ func (qv *QueueBoxView) formatMessagePreview(queuedMsg domain.QueuedMessage) str
 	if err != nil {
 		contentStr = formatting.ExtractTextFromContent(msg.Content, nil)
 	}
+	contentStr = formatting.CollapsePastedText(contentStr)
 
 	if strings.HasPrefix(contentStr, "[A2A Task Completed:") || strings.HasPrefix(contentStr, "[A2A Task Failed:") {
 		lines := strings.Split(contentStr, "\n")
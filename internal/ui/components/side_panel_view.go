@@ -0,0 +1,288 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ansi "github.com/charmbracelet/x/ansi"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+	styles "github.com/inference-gateway/cli/internal/ui/styles"
+)
+
+const (
+	// defaultSidePanelPercent is the share of the terminal width the side
+	// panel takes when first opened.
+	defaultSidePanelPercent = 40
+
+	minSidePanelPercent  = 25
+	maxSidePanelPercent  = 70
+	sidePanelResizeStep  = 5
+	minSidePanelTermCols = 100
+
+	// maxSidePanelFileBytes bounds how much of a file the preview reads.
+	maxSidePanelFileBytes = 256 * 1024
+)
+
+// SidePanelContent is what the side panel shows
+type SidePanelContent int
+
+const (
+	SidePanelHidden SidePanelContent = iota
+	SidePanelTodos
+	SidePanelDiff
+	SidePanelFile
+)
+
+// String returns the panel content's display name
+func (c SidePanelContent) String() string {
+	switch c {
+	case SidePanelTodos:
+		return "todos"
+	case SidePanelDiff:
+		return "latest diff"
+	case SidePanelFile:
+		return "file preview"
+	default:
+		return "hidden"
+	}
+}
+
+// sidePanelToolCall is the latest file tool call, kept as its decoded
+// arguments so the diff can be re-rendered when the panel is resized.
+type sidePanelToolCall struct {
+	name string
+	args map[string]any
+}
+
+// SidePanelView is the optional right-hand pane of the chat layout. It shows
+// the todo list, the diff of the agent's latest file change, or a preview of
+// the file the agent last touched, while the conversation continues on the
+// left. The ApplicationViewRenderer gives it the conversation's height and
+// PanelWidth columns.
+type SidePanelView struct {
+	width         int
+	height        int
+	styleProvider *styles.Provider
+	content       SidePanelContent
+	percent       int
+	todos         []domain.TodoItem
+	lastEdit      *sidePanelToolCall
+	lastFile      string
+
+	// rendered caches the diff or file body, which is costly to highlight,
+	// until the content, size or conversation changes.
+	rendered    string
+	renderedKey string
+}
+
+// NewSidePanelView creates a hidden side panel
+func NewSidePanelView(styleProvider *styles.Provider) *SidePanelView {
+	return &SidePanelView{
+		styleProvider: styleProvider,
+		percent:       defaultSidePanelPercent,
+	}
+}
+
+// Cycle switches to the next content - todos, latest diff, file preview -
+// and then hides the panel, returning the new content.
+func (sp *SidePanelView) Cycle() SidePanelContent {
+	sp.content = (sp.content + 1) % (SidePanelFile + 1)
+	return sp.content
+}
+
+// Resize grows (delta > 0) or shrinks the panel by whole steps, returning
+// the new share of the terminal width in percent.
+func (sp *SidePanelView) Resize(delta int) int {
+	sp.percent = max(min(sp.percent+delta*sidePanelResizeStep, maxSidePanelPercent), minSidePanelPercent)
+	return sp.percent
+}
+
+// Content returns what the panel currently shows
+func (sp *SidePanelView) Content() SidePanelContent {
+	return sp.content
+}
+
+// PanelWidth returns the columns the panel takes out of a terminal of the
+// given width, or 0 when it is hidden or the terminal is too narrow to split.
+func (sp *SidePanelView) PanelWidth(terminalWidth int) int {
+	if sp.content == SidePanelHidden || terminalWidth < minSidePanelTermCols {
+		return 0
+	}
+	return terminalWidth * sp.percent / 100
+}
+
+// SetSize sets the panel's outer dimensions
+func (sp *SidePanelView) SetSize(width, height int) {
+	sp.width = width
+	sp.height = height
+}
+
+// SetTodos updates the todo list shown in the todos view
+func (sp *SidePanelView) SetTodos(todos []domain.TodoItem) {
+	sp.todos = todos
+}
+
+// SetEntries picks the latest file change and the latest file touched out of
+// the conversation's tool calls.
+func (sp *SidePanelView) SetEntries(entries []domain.ConversationEntry) {
+	sp.lastEdit = nil
+	sp.lastFile = ""
+	sp.renderedKey = ""
+
+	for i := len(entries) - 1; i >= 0 && (sp.lastEdit == nil || sp.lastFile == ""); i-- {
+		calls := entries[i].Message.ToolCalls
+		if calls == nil {
+			continue
+		}
+		for j := len(*calls) - 1; j >= 0; j-- {
+			call := (*calls)[j]
+			name := call.Function.Name
+			if name != "Read" && name != "Edit" && name != "MultiEdit" && name != "Write" {
+				continue
+			}
+
+			var args map[string]any
+			if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+				continue
+			}
+			path, _ := args["file_path"].(string)
+			if path == "" {
+				continue
+			}
+
+			if sp.lastFile == "" {
+				sp.lastFile = path
+			}
+			if sp.lastEdit == nil && name != "Read" {
+				sp.lastEdit = &sidePanelToolCall{name: name, args: args}
+			}
+		}
+	}
+}
+
+// Render draws the panel as a titled box exactly SetSize's height tall
+func (sp *SidePanelView) Render() string {
+	if sp.content == SidePanelHidden || sp.width <= 0 || sp.height < 3 {
+		return ""
+	}
+
+	// Stay clear of the border and padding whether or not the box width
+	// includes them, so long lines are clipped here rather than wrapped.
+	innerWidth := max(sp.width-6, 1)
+	innerHeight := sp.height - 2
+
+	title, body := sp.renderBody(innerWidth, innerHeight)
+
+	lines := strings.Split(body, "\n")
+	if len(lines) > innerHeight {
+		lines = lines[:innerHeight]
+	}
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, innerWidth, "…")
+	}
+	for len(lines) < innerHeight {
+		lines = append(lines, "")
+	}
+
+	return sp.styleProvider.RenderTitledCard(
+		strings.Join(lines, "\n"),
+		title,
+		sp.styleProvider.GetThemeColor("border"),
+		sp.styleProvider.GetThemeColor("accent"),
+		sp.width-2,
+	)
+}
+
+func (sp *SidePanelView) renderBody(width, height int) (string, string) {
+	switch sp.content {
+	case SidePanelTodos:
+		return "Todos", sp.renderTodos()
+	case SidePanelDiff:
+		if sp.lastEdit == nil {
+			return "Latest diff", sp.styleProvider.RenderDimText("No file changes yet")
+		}
+		return "Latest diff", sp.cachedBody(width, height, func() string {
+			return sp.renderEdit(NewToolDiffRenderer(sp.styleProvider).SetWidth(width).SetMaxLines(height))
+		})
+	case SidePanelFile:
+		if sp.lastFile == "" {
+			return "File", sp.styleProvider.RenderDimText("No file touched yet")
+		}
+		return filepath.Base(sp.lastFile), sp.cachedBody(width, height, func() string {
+			return sp.renderFile(height)
+		})
+	}
+	return "", ""
+}
+
+func (sp *SidePanelView) cachedBody(width, height int, render func() string) string {
+	key := fmt.Sprintf("%d:%dx%d", sp.content, width, height)
+	if key != sp.renderedKey {
+		sp.rendered = render()
+		sp.renderedKey = key
+	}
+	return sp.rendered
+}
+
+func (sp *SidePanelView) renderTodos() string {
+	if len(sp.todos) == 0 {
+		return sp.styleProvider.RenderDimText("No todos")
+	}
+
+	completed := 0
+	lines := make([]string, 0, len(sp.todos)+2)
+	for _, todo := range sp.todos {
+		if todo.Status == "completed" {
+			completed++
+		}
+	}
+	lines = append(lines, sp.styleProvider.RenderDimText(fmt.Sprintf("%d/%d tasks done", completed, len(sp.todos))), "")
+
+	formatter := &TodoBoxView{styleProvider: sp.styleProvider}
+	for _, todo := range sp.todos {
+		lines = append(lines, formatter.formatTodoItem(todo))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (sp *SidePanelView) renderEdit(renderer *DiffRenderer) string {
+	args := sp.lastEdit.args
+	switch sp.lastEdit.name {
+	case "Edit":
+		return renderer.RenderEditToolArguments(args)
+	case "MultiEdit":
+		return renderer.RenderMultiEditToolArguments(args)
+	default:
+		path, _ := args["file_path"].(string)
+		content, _ := args["content"].(string)
+		header := sp.styleProvider.RenderWithColorAndBold(path, sp.styleProvider.GetThemeColor("accent"))
+		return header + "\n\n" + renderer.renderContentPreview(path, content)
+	}
+}
+
+func (sp *SidePanelView) renderFile(height int) string {
+	header := sp.styleProvider.RenderDimText(sp.lastFile)
+
+	f, err := os.Open(sp.lastFile)
+	if err != nil {
+		return header + "\n\n" + sp.styleProvider.RenderDimText("Cannot read file: "+err.Error())
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSidePanelFileBytes))
+	if err != nil {
+		return header + "\n\n" + sp.styleProvider.RenderDimText("Cannot read file: "+err.Error())
+	}
+	content := string(data)
+	if strings.ContainsRune(content, 0) {
+		return header + "\n\n" + sp.styleProvider.RenderDimText("Binary file")
+	}
+
+	renderer := NewToolDiffRenderer(sp.styleProvider).SetMaxLines(max(height-3, 1))
+	return header + "\n\n" + renderer.renderContentPreview(sp.lastFile, content)
+}
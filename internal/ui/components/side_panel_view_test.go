@@ -0,0 +1,108 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ansi "github.com/charmbracelet/x/ansi"
+	sdk "github.com/inference-gateway/sdk"
+
+	domain "github.com/inference-gateway/cli/internal/domain"
+)
+
+func toolCallEntry(name, args string) domain.ConversationEntry {
+	return domain.ConversationEntry{Message: sdk.Message{
+		Role: sdk.Assistant,
+		ToolCalls: &[]sdk.ChatCompletionMessageToolCall{
+			{ID: "call_" + name, Function: sdk.ChatCompletionMessageToolCallFunction{Name: name, Arguments: args}},
+		},
+	}}
+}
+
+func TestSidePanelView_CycleAndResize(t *testing.T) {
+	sp := NewSidePanelView(createMockStyleProvider())
+
+	if sp.PanelWidth(200) != 0 || sp.Render() != "" {
+		t.Fatal("a hidden panel must take no space")
+	}
+
+	for _, want := range []SidePanelContent{SidePanelTodos, SidePanelDiff, SidePanelFile, SidePanelHidden} {
+		if got := sp.Cycle(); got != want {
+			t.Fatalf("Cycle() = %s, want %s", got, want)
+		}
+	}
+
+	sp.Cycle()
+	if got := sp.PanelWidth(200); got != 80 {
+		t.Errorf("PanelWidth(200) = %d, want 80", got)
+	}
+	if got := sp.PanelWidth(80); got != 0 {
+		t.Errorf("PanelWidth(80) = %d, want 0 on a narrow terminal", got)
+	}
+
+	for range 20 {
+		sp.Resize(1)
+	}
+	if got := sp.Resize(1); got != maxSidePanelPercent {
+		t.Errorf("Resize clamps to %d, got %d", maxSidePanelPercent, got)
+	}
+	for range 20 {
+		sp.Resize(-1)
+	}
+	if got := sp.Resize(-1); got != minSidePanelPercent {
+		t.Errorf("Resize clamps to %d, got %d", minSidePanelPercent, got)
+	}
+}
+
+func TestSidePanelView_Render(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sp := NewSidePanelView(createMockStyleProvider())
+	sp.SetSize(50, 12)
+
+	sp.Cycle()
+	sp.SetTodos([]domain.TodoItem{
+		{ID: "1", Content: "write the parser", Status: "completed"},
+		{ID: "2", Content: "add tests", Status: "in_progress"},
+	})
+	out := sp.Render()
+	if got := strings.Count(out, "\n") + 1; got != 12 {
+		t.Errorf("rendered %d lines, want 12", got)
+	}
+	if plain := ansi.Strip(out); !strings.Contains(plain, "1/2 tasks done") || !strings.Contains(plain, "add tests") {
+		t.Errorf("todos view missing items:\n%s", plain)
+	}
+
+	sp.Cycle()
+	if plain := ansi.Strip(sp.Render()); !strings.Contains(plain, "No file changes yet") {
+		t.Errorf("diff view without edits should say so:\n%s", plain)
+	}
+
+	sp.SetEntries([]domain.ConversationEntry{
+		toolCallEntry("Edit", `{"file_path":"`+path+`","old_string":"func main() {}","new_string":"func main() { run() }"}`),
+		toolCallEntry("Read", `{"file_path":"`+path+`"}`),
+	})
+	if plain := ansi.Strip(sp.Render()); !strings.Contains(plain, "run()") {
+		t.Errorf("diff view should show the latest edit:\n%s", plain)
+	}
+
+	sp.Cycle()
+	out = sp.Render()
+	if got := strings.Count(out, "\n") + 1; got != 12 {
+		t.Errorf("rendered %d lines, want 12", got)
+	}
+	if plain := ansi.Strip(out); !strings.Contains(plain, "main.go") || !strings.Contains(plain, "package main") {
+		t.Errorf("file view should preview the latest file:\n%s", plain)
+	}
+	for line := range strings.SplitSeq(out, "\n") {
+		if w := ansi.StringWidth(line); w > 50 {
+			t.Errorf("line is %d columns wide, want at most 50: %q", w, ansi.Strip(line))
+		}
+	}
+}
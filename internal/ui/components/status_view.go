@@ -8,6 +8,7 @@ import (
 	spinner "charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 
+	config "github.com/inference-gateway/cli/config"
 	domain "github.com/inference-gateway/cli/internal/domain"
 	formatting "github.com/inference-gateway/cli/internal/formatting"
 	hints "github.com/inference-gateway/cli/internal/ui/hints"
@@ -33,6 +34,7 @@ type StatusView struct {
 	toolName         string
 	stateManager     statusViewState
 	pausedAt         time.Time
+	flashing         bool
 }
 
 // statusViewState is the narrow slice of StateManager the status view reads:
@@ -200,7 +202,29 @@ func (sv *StatusView) SetKeyHintFormatter(formatter *hints.Formatter) {
 	sv.keyHintFormatter = formatter
 }
 
+// retryHint is appended to an error offering to retry the failed turn with
+// model, "" without a model or a bound key.
+func (sv *StatusView) retryHint(model string) string {
+	if model == "" || sv.keyHintFormatter == nil {
+		return ""
+	}
+	hint := sv.keyHintFormatter.GetKeyHint(config.ActionID(config.NamespaceChat, "retry_with_alternate"), "retry with "+model)
+	if hint == "" {
+		return ""
+	}
+	return "\n" + hint
+}
+
+// SetFlash highlights the status line in reverse video to draw the eye, as
+// when an approval prompt has gone unanswered.
+func (sv *StatusView) SetFlash(on bool) {
+	sv.flashing = on
+}
+
 func (sv *StatusView) Render() string {
+	if sv.flashing {
+		return " " + sv.renderFlash()
+	}
 	if sv.message == "" && sv.baseMessage == "" && sv.debugInfo == "" {
 		return ""
 	}
@@ -241,6 +265,19 @@ func (sv *StatusView) Render() string {
 	return " " + styledStatusLine
 }
 
+// renderFlash draws the current status message, or a reminder when there is
+// none, highlighted in the theme's accent color.
+func (sv *StatusView) renderFlash() string {
+	message := sv.baseMessage
+	if message == "" {
+		message = sv.message
+	}
+	if message == "" {
+		message = "Waiting for your response"
+	}
+	return sv.styleProvider.RenderSelectedIndicator(message)
+}
+
 // formatStatusWithType enhances the status message with type-specific formatting and progress
 func (sv *StatusView) formatStatusWithType(message string) string {
 	if sv.progress != nil && sv.progress.Total > 0 {
@@ -340,7 +377,8 @@ func awaitingUserDecision(sm approvalOverlayReader) bool {
 }
 
 // reconnectingMessage returns the reconnect notice when the HTTP client is
-// retrying or the stream has stalled past the configured threshold, empty
+// retrying, counting down to the retry of a rate-limited request, or the
+// stream has stalled past the configured threshold, empty
 // otherwise. Derived from state on each render, so it appears and clears with
 // the regular spinner tick.
 func (sv *StatusView) reconnectingMessage() string {
@@ -354,6 +392,12 @@ func (sv *StatusView) reconnectingMessage() string {
 	if status.Attempt == 0 {
 		return "Reconnecting..."
 	}
+	if !status.RetryAt.IsZero() {
+		if remaining := time.Until(status.RetryAt).Round(time.Second); remaining > 0 {
+			return fmt.Sprintf("Rate limited, retrying in %s (%d/%d)", remaining, status.Attempt, status.MaxAttempts)
+		}
+		return fmt.Sprintf("Rate limited, retrying (%d/%d)", status.Attempt, status.MaxAttempts)
+	}
 	return fmt.Sprintf("Reconnecting (%d/%d)", status.Attempt, status.MaxAttempts)
 }
 
@@ -409,7 +453,7 @@ func (sv *StatusView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		sv.UpdateSpinnerMessage(msg.Message, msg.StatusType)
 
 	case domain.ShowErrorEvent:
-		sv.ShowError(msg.Error)
+		sv.ShowError(msg.Error + sv.retryHint(msg.RetryModel))
 
 	case domain.ClearErrorEvent:
 		sv.ClearStatus()
@@ -197,6 +197,25 @@ func TestStatusView_Render(t *testing.T) {
 	}
 }
 
+func TestStatusView_Render_Flash(t *testing.T) {
+	sv := NewStatusView(createMockStyleProviderForStatus())
+
+	sv.SetFlash(true)
+	if output := sv.Render(); !strings.Contains(output, "Waiting for your response") {
+		t.Errorf("Expected a reminder while flashing an empty status line, got %q", output)
+	}
+
+	sv.ShowSpinner("Executing tools")
+	if output := sv.Render(); !strings.Contains(output, "Executing tools") || strings.Contains(output, "(") {
+		t.Errorf("Expected the bare status message while flashing, got %q", output)
+	}
+
+	sv.SetFlash(false)
+	if output := sv.Render(); !strings.Contains(output, "Executing tools (") {
+		t.Errorf("Expected the regular spinner line after the flash, got %q", output)
+	}
+}
+
 func TestStatusView_StateTransitions(t *testing.T) {
 	sv := NewStatusView(createMockStyleProviderForStatus())
 
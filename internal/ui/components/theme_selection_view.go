@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	key "charm.land/bubbles/v2/key"
 	list "charm.land/bubbles/v2/list"
@@ -62,9 +63,23 @@ func (d themeDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 	_, _ = fmt.Fprint(w, line)
 }
 
+// themeLookup is implemented by theme services that can resolve a theme by
+// name without activating it, which the selector uses for its live preview.
+type themeLookup interface {
+	GetTheme(name string) (domain.Theme, error)
+}
+
+// themePreviewHeight is the number of lines the preview panel takes below the list
+const themePreviewHeight = 9
+
+// themePanelWidth is the width of the selector when it is drawn as a panel
+// over the chat; the screen must be at least twice as wide for that.
+const themePanelWidth = 40
+
 // ThemeSelectorImpl implements theme selection UI on top of bubbles/v2/list,
 // which provides cursor movement, fuzzy filtering (press /), pagination and
-// help for free.
+// help for free. The highlighted theme is applied as the cursor moves so the
+// chat behind the selector shows it live; cancelling restores originalTheme.
 type ThemeSelectorImpl struct {
 	list          list.Model
 	themes        []string
@@ -73,6 +88,8 @@ type ThemeSelectorImpl struct {
 	done          bool
 	cancelled     bool
 	selectedTheme string
+	originalTheme string
+	backdrop      string
 	themeService  domain.ThemeService
 	styleProvider *styles.Provider
 }
@@ -100,6 +117,7 @@ func NewThemeSelector(themeService domain.ThemeService, styleProvider *styles.Pr
 		themes:        themes,
 		width:         80,
 		height:        24,
+		originalTheme: themeService.GetCurrentThemeName(),
 		themeService:  themeService,
 		styleProvider: styleProvider,
 	}
@@ -134,7 +152,7 @@ func (m *ThemeSelectorImpl) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.list.SetSize(msg.Width, msg.Height)
+		m.resizeList()
 		return m, nil
 	case tea.KeyPressMsg:
 		if handled, cmd := m.handleKey(msg); handled {
@@ -144,9 +162,20 @@ func (m *ThemeSelectorImpl) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
+	m.previewHighlighted()
 	return m, cmd
 }
 
+// previewHighlighted applies the highlighted theme, so everything drawn with
+// the theme service - the chat behind the selector included - shows it.
+func (m *ThemeSelectorImpl) previewHighlighted() {
+	item, ok := m.list.SelectedItem().(themeItem)
+	if !ok || item.name == m.themeService.GetCurrentThemeName() {
+		return
+	}
+	_ = m.themeService.SetTheme(item.name)
+}
+
 // handleKey intercepts selection/cancel keys when the list is not actively
 // filtering; otherwise it lets the list own typing, enter (apply filter) and
 // esc (clear filter).
@@ -174,6 +203,9 @@ func (m *ThemeSelectorImpl) handleKey(msg tea.KeyPressMsg) (handled bool, cmd te
 func (m *ThemeSelectorImpl) cancel() {
 	m.cancelled = true
 	m.done = true
+	if m.themeService.GetCurrentThemeName() != m.originalTheme {
+		_ = m.themeService.SetTheme(m.originalTheme)
+	}
 }
 
 func (m *ThemeSelectorImpl) selectTheme() tea.Cmd {
@@ -192,7 +224,106 @@ func (m *ThemeSelectorImpl) selectTheme() tea.Cmd {
 }
 
 func (m *ThemeSelectorImpl) View() tea.View {
-	return tea.NewView(m.list.View())
+	if m.showAsPanel() {
+		return tea.NewView(m.renderPanel())
+	}
+	preview := m.renderPreview()
+	if preview == "" {
+		return tea.NewView(m.list.View())
+	}
+	return tea.NewView(m.list.View() + "\n" + preview)
+}
+
+// SetBackdrop sets the screen drawn behind the selector: the chat, rendered
+// in the highlighted theme. When there is room the selector becomes a panel
+// over it instead of taking the whole screen.
+func (m *ThemeSelectorImpl) SetBackdrop(backdrop string) {
+	m.backdrop = backdrop
+	m.resizeList()
+}
+
+// showAsPanel reports whether the selector is drawn over the backdrop
+func (m *ThemeSelectorImpl) showAsPanel() bool {
+	return m.backdrop != "" && m.width >= themePanelWidth*2 && m.height >= themePreviewHeight*2
+}
+
+// showPreview reports whether there is room and a way to preview themes
+func (m *ThemeSelectorImpl) showPreview() bool {
+	_, ok := m.themeService.(themeLookup)
+	return ok && !m.showAsPanel() && m.height >= themePreviewHeight*2
+}
+
+// panelListHeight is the height of the list inside the panel: enough for
+// every theme plus the list's title, status and help, within the screen.
+func (m *ThemeSelectorImpl) panelListHeight() int {
+	return min(len(m.themes)+8, m.height-4)
+}
+
+// resizeList gives the list the space left over by the preview panel, or
+// the inside of the panel drawn over the chat
+func (m *ThemeSelectorImpl) resizeList() {
+	if m.showAsPanel() {
+		m.list.SetSize(themePanelWidth-4, m.panelListHeight())
+		return
+	}
+
+	height := m.height
+	if m.showPreview() {
+		height -= themePreviewHeight
+	}
+	m.list.SetSize(m.width, height)
+}
+
+// renderPanel draws the list in a bordered panel in the top-right corner of
+// the backdrop, leaving most of the chat visible in the highlighted theme.
+func (m *ThemeSelectorImpl) renderPanel() string {
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.styleProvider.GetThemeColor("accent"))).
+		Padding(0, 1).
+		Width(themePanelWidth - 2).
+		Render(m.list.View())
+
+	lines := strings.Split(m.backdrop, "\n")
+	return strings.Join(overlayBlock(lines, panel, 1, m.width-themePanelWidth-1), "\n")
+}
+
+// renderPreview draws sample conversation lines in the colors of the
+// highlighted theme, so a theme can be judged before it is applied.
+func (m *ThemeSelectorImpl) renderPreview() string {
+	if !m.showPreview() {
+		return ""
+	}
+	item, ok := m.list.SelectedItem().(themeItem)
+	if !ok {
+		return ""
+	}
+	theme, err := m.themeService.(themeLookup).GetTheme(item.name)
+	if err != nil {
+		return ""
+	}
+
+	paint := func(text, color string) string {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(text)
+	}
+	lines := []string{
+		paint("Preview: "+item.name, theme.GetAccentColor()),
+		paint("> How do I list the files here?", theme.GetUserColor()),
+		paint("⏺ Run `ls -la` to see every file, including hidden ones.", theme.GetAssistantColor()),
+		paint("✓ Bash(ls -la) completed", theme.GetSuccessColor()) + "  " +
+			paint("✗ Read(missing.go) failed", theme.GetErrorColor()),
+		paint("+ added line", theme.GetDiffAddColor()) + "  " +
+			paint("- removed line", theme.GetDiffRemoveColor()),
+		paint("● Working...", theme.GetStatusColor()) + "  " +
+			paint("press esc to cancel", theme.GetDimColor()),
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(theme.GetBorderColor())).
+		Padding(0, 1).
+		Width(max(m.width-2, 20)).
+		Render(strings.Join(lines, "\n"))
 }
 
 // IsSelected returns true if a theme was selected.
@@ -212,13 +343,13 @@ func (m *ThemeSelectorImpl) GetSelected() string {
 // SetWidth sets the width of the theme selector.
 func (m *ThemeSelectorImpl) SetWidth(width int) {
 	m.width = width
-	m.list.SetSize(width, m.height)
+	m.resizeList()
 }
 
 // SetHeight sets the height of the theme selector.
 func (m *ThemeSelectorImpl) SetHeight(height int) {
 	m.height = height
-	m.list.SetSize(m.width, height)
+	m.resizeList()
 }
 
 // Reset returns the selector to its initial state, rebuilding the items so the
@@ -227,6 +358,7 @@ func (m *ThemeSelectorImpl) Reset() {
 	m.done = false
 	m.cancelled = false
 	m.selectedTheme = ""
+	m.originalTheme = m.themeService.GetCurrentThemeName()
 	m.list.ResetFilter()
 	m.list.SetItems(themeItems(m.themes, m.themeService.GetCurrentThemeName()))
 	m.selectCurrentTheme()
@@ -1,12 +1,14 @@
 package components
 
 import (
+	"strings"
 	"testing"
 
 	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
 	uimocks "github.com/inference-gateway/cli/tests/mocks/ui"
 
 	tea "charm.land/bubbletea/v2"
+	ansi "github.com/charmbracelet/x/ansi"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
 	styles "github.com/inference-gateway/cli/internal/ui/styles"
@@ -83,3 +85,70 @@ func TestThemeSelector_ResetRebuildsForNewCurrentTheme(t *testing.T) {
 		t.Fatalf("expected cursor on new current theme index 2 after Reset, got %d", got)
 	}
 }
+
+func TestThemeSelector_PreviewFollowsHighlightedTheme(t *testing.T) {
+	tp := domain.NewThemeProvider()
+	sel := NewThemeSelector(tp, styles.NewProvider(tp))
+	sel.SetWidth(80)
+	sel.SetHeight(40)
+
+	if !strings.Contains(ansi.Strip(sel.View().Content), "Preview: tokyo-night") {
+		t.Fatal("expected the preview to show the current theme")
+	}
+
+	above := sel.themes[sel.list.Index()-1]
+	model, _ := sel.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	sel = model.(*ThemeSelectorImpl)
+	if !strings.Contains(ansi.Strip(sel.View().Content), "Preview: "+above) {
+		t.Fatalf("expected the preview to follow the highlighted theme %q", above)
+	}
+	if tp.GetCurrentThemeName() != above {
+		t.Fatalf("expected the highlighted theme %q to be applied live, got %q", above, tp.GetCurrentThemeName())
+	}
+
+	sel.SetHeight(themePreviewHeight)
+	if strings.Contains(sel.View().Content, "Preview:") {
+		t.Fatal("expected no preview when the selector is too short")
+	}
+}
+
+func TestThemeSelector_CancelRevertsLivePreview(t *testing.T) {
+	tp := domain.NewThemeProvider()
+	sel := NewThemeSelector(tp, styles.NewProvider(tp))
+
+	// tokyo-night sorts last, so move up to highlight another theme
+	sel.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if tp.GetCurrentThemeName() == "tokyo-night" {
+		t.Fatal("expected moving the cursor to apply the highlighted theme")
+	}
+
+	sel.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if !sel.IsCancelled() || tp.GetCurrentThemeName() != "tokyo-night" {
+		t.Fatalf("cancelling should restore the original theme, got %q", tp.GetCurrentThemeName())
+	}
+}
+
+func TestThemeSelector_DrawnAsPanelOverBackdrop(t *testing.T) {
+	tp := domain.NewThemeProvider()
+	sel := NewThemeSelector(tp, styles.NewProvider(tp))
+	sel.SetWidth(100)
+	sel.SetHeight(30)
+
+	backdrop := make([]string, 30)
+	for i := range backdrop {
+		backdrop[i] = strings.Repeat("chat ", 20)
+	}
+	sel.SetBackdrop(strings.Join(backdrop, "\n"))
+
+	view := ansi.Strip(sel.View().Content)
+	lines := strings.Split(view, "\n")
+	if len(lines) != 30 {
+		t.Fatalf("expected the panel drawn over the 30 backdrop lines, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[5], "chat chat") || !strings.Contains(view, "Select a Theme") {
+		t.Fatalf("expected the chat to stay visible beside the panel:\n%s", view)
+	}
+	if strings.Contains(view, "Preview:") {
+		t.Fatal("the chat itself is the preview when drawn as a panel")
+	}
+}
@@ -2,11 +2,13 @@ package components
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	progress "charm.land/bubbles/v2/progress"
 	tea "charm.land/bubbletea/v2"
+	ansi "github.com/charmbracelet/x/ansi"
 
 	domain "github.com/inference-gateway/cli/internal/domain"
 	styles "github.com/inference-gateway/cli/internal/ui/styles"
@@ -16,15 +18,20 @@ import (
 // AutoCollapseDelay is the duration to wait before auto-collapsing after an update
 const AutoCollapseDelay = 3 * time.Second
 
-// TodoBoxView displays a collapsible todo list component
+// TodoBoxView displays a collapsible todo list component, with the approved
+// plan's checklist beside it while a plan is being executed
 type TodoBoxView struct {
 	width         int
 	height        int
 	styleProvider *styles.Provider
 	todos         []domain.TodoItem
+	planSteps     []domain.PlanStep
 	expanded      bool
 	autoExpanded  bool      // true if expanded due to auto-expand (not user action)
 	lastUpdate    time.Time // time of last todo update
+	focused       bool      // true while the user is editing the list by hand
+	cursor        int       // selected item while focused
+	edited        bool      // true once the user changed the list, until TakeEdits
 }
 
 // NewTodoBoxView creates a new todo box view
@@ -53,6 +60,11 @@ func (tv *TodoBoxView) SetHeight(height int) {
 func (tv *TodoBoxView) SetTodos(todos []domain.TodoItem) {
 	tv.todos = todos
 	tv.lastUpdate = time.Now()
+	tv.edited = false
+	tv.cursor = max(min(tv.cursor, len(todos)-1), 0)
+	if len(todos) == 0 {
+		tv.focused = false
+	}
 
 	// Auto-expand when todos are updated
 	if len(todos) > 0 && !tv.expanded {
@@ -61,6 +73,18 @@ func (tv *TodoBoxView) SetTodos(todos []domain.TodoItem) {
 	}
 }
 
+// SetPlanSteps updates the plan checklist and triggers auto-expand like
+// SetTodos. An empty list hides it.
+func (tv *TodoBoxView) SetPlanSteps(steps []domain.PlanStep) {
+	tv.planSteps = steps
+	tv.lastUpdate = time.Now()
+
+	if len(steps) > 0 && !tv.expanded {
+		tv.expanded = true
+		tv.autoExpanded = true
+	}
+}
+
 // GetTodos returns the current todos
 func (tv *TodoBoxView) GetTodos() []domain.TodoItem {
 	return tv.todos
@@ -85,7 +109,7 @@ func (tv *TodoBoxView) IsExpanded() bool {
 
 // ShouldAutoCollapse returns true if the component should auto-collapse
 func (tv *TodoBoxView) ShouldAutoCollapse() bool {
-	if !tv.autoExpanded || !tv.expanded {
+	if !tv.autoExpanded || !tv.expanded || tv.focused {
 		return false
 	}
 	return time.Since(tv.lastUpdate) >= AutoCollapseDelay
@@ -101,26 +125,128 @@ func (tv *TodoBoxView) AutoCollapse() bool {
 	return false
 }
 
+// Focus gives the list keyboard focus for editing, expanding it so every item
+// is visible. It reports false when there is nothing to edit.
+func (tv *TodoBoxView) Focus() bool {
+	if !tv.HasTodos() {
+		return false
+	}
+	tv.focused = true
+	tv.expanded = true
+	tv.autoExpanded = false
+	tv.cursor = max(min(tv.cursor, len(tv.todos)-1), 0)
+	return true
+}
+
+// Blur returns keyboard focus to the input
+func (tv *TodoBoxView) Blur() {
+	tv.focused = false
+}
+
+// IsFocused returns whether the list holds keyboard focus
+func (tv *TodoBoxView) IsFocused() bool {
+	return tv.focused
+}
+
+// MoveCursor moves the selection by delta, staying within the list
+func (tv *TodoBoxView) MoveCursor(delta int) {
+	tv.cursor = max(min(tv.cursor+delta, len(tv.todos)-1), 0)
+}
+
+// ToggleSelectedDone checks the selected item off, or reopens it when it is
+// already completed.
+func (tv *TodoBoxView) ToggleSelectedDone() {
+	if !tv.hasSelection() {
+		return
+	}
+	todos := tv.editableTodos()
+	if todos[tv.cursor].Status == "completed" {
+		todos[tv.cursor].Status = "pending"
+	} else {
+		todos[tv.cursor].Status = "completed"
+	}
+}
+
+// MoveSelected moves the selected item up (negative delta) or down the list,
+// keeping it selected.
+func (tv *TodoBoxView) MoveSelected(delta int) {
+	target := tv.cursor + delta
+	if !tv.hasSelection() || target < 0 || target >= len(tv.todos) {
+		return
+	}
+	todos := tv.editableTodos()
+	todos[tv.cursor], todos[target] = todos[target], todos[tv.cursor]
+	tv.cursor = target
+}
+
+// DeleteSelected removes the selected item, giving up focus once the list
+// is empty.
+func (tv *TodoBoxView) DeleteSelected() {
+	if !tv.hasSelection() {
+		return
+	}
+	tv.todos = slices.Delete(tv.editableTodos(), tv.cursor, tv.cursor+1)
+	tv.cursor = max(min(tv.cursor, len(tv.todos)-1), 0)
+	if len(tv.todos) == 0 {
+		tv.focused = false
+	}
+}
+
+// TakeEdits returns the list and true if the user changed it since the last
+// call or the last SetTodos, clearing the edited flag.
+func (tv *TodoBoxView) TakeEdits() ([]domain.TodoItem, bool) {
+	if !tv.edited {
+		return nil, false
+	}
+	tv.edited = false
+	return tv.todos, true
+}
+
+func (tv *TodoBoxView) hasSelection() bool {
+	return tv.cursor >= 0 && tv.cursor < len(tv.todos)
+}
+
+// editableTodos marks the list edited and returns it, copied on the first
+// edit since the slice is shared with the state manager.
+func (tv *TodoBoxView) editableTodos() []domain.TodoItem {
+	if !tv.edited {
+		tv.todos = slices.Clone(tv.todos)
+		tv.edited = true
+	}
+	tv.lastUpdate = time.Now()
+	return tv.todos
+}
+
 // HasTodos returns whether there are any todos
 func (tv *TodoBoxView) HasTodos() bool {
 	return len(tv.todos) > 0
 }
 
+// HasPlanSteps returns whether a plan checklist is being shown
+func (tv *TodoBoxView) HasPlanSteps() bool {
+	return len(tv.planSteps) > 0
+}
+
+// IsVisible returns whether there is anything to render
+func (tv *TodoBoxView) IsVisible() bool {
+	return tv.HasTodos() || tv.HasPlanSteps()
+}
+
 // GetHeight returns the height of the rendered component
 func (tv *TodoBoxView) GetHeight() int {
-	if !tv.HasTodos() {
+	if !tv.IsVisible() {
 		return 0
 	}
 	if !tv.expanded {
 		return 1 // collapsed: single line
 	}
-	// expanded: header + todos + padding
-	return len(tv.todos) + 3
+	// expanded: header + the longer of the two lists + padding
+	return max(len(tv.todos), len(tv.planSteps)) + 3
 }
 
 // Render renders the todo box
 func (tv *TodoBoxView) Render() string {
-	if !tv.HasTodos() {
+	if !tv.IsVisible() {
 		return ""
 	}
 
@@ -132,46 +258,73 @@ func (tv *TodoBoxView) Render() string {
 
 // renderCollapsed renders the collapsed view with progress indicator
 func (tv *TodoBoxView) renderCollapsed() string {
-	completed, total := tv.countTasks()
-	progressBar := tv.formatMiniProgressBar(completed, total)
-
 	accentColor := tv.styleProvider.GetThemeColor("accent")
 	dimColor := tv.styleProvider.GetThemeColor("dim")
 
-	inProgressTask := tv.getInProgressTask()
-
-	var indicator string
-	if inProgressTask != "" {
-		maxLen := tv.width - 50
-		taskPreview := inProgressTask
-		if maxLen > 10 && len(inProgressTask) > maxLen {
-			taskPreview = inProgressTask[:maxLen-3] + "..."
-		}
-		indicator = fmt.Sprintf("%s %s %d/%d tasks",
-			taskPreview,
-			progressBar,
-			completed,
-			total,
-		)
-	} else {
-		indicator = fmt.Sprintf("%s %d/%d tasks",
-			progressBar,
+	var indicators []string
+	if tv.HasTodos() {
+		indicators = append(indicators, tv.collapsedTodoIndicator())
+	}
+	if tv.HasPlanSteps() {
+		completed, total := tv.countPlanSteps()
+		indicators = append(indicators, fmt.Sprintf("Plan %s %d/%d steps",
+			tv.formatMiniProgressBar(completed, total),
 			completed,
 			total,
-		)
+		))
 	}
 
 	hint := "(ctrl+t to expand)"
 
-	indicatorStyled := tv.styleProvider.RenderWithColor(indicator, accentColor)
+	indicatorStyled := tv.styleProvider.RenderWithColor(strings.Join(indicators, " · "), accentColor)
 	hintStyled := tv.styleProvider.RenderWithColor(hint, dimColor)
 
 	leftPadding := " "
 	return fmt.Sprintf("%s%s %s", leftPadding, indicatorStyled, hintStyled)
 }
 
-// renderExpanded renders the full expanded view
+// collapsedTodoIndicator is the todo part of the collapsed line
+func (tv *TodoBoxView) collapsedTodoIndicator() string {
+	completed, total := tv.countTasks()
+	progressBar := tv.formatMiniProgressBar(completed, total)
+
+	inProgressTask := tv.getInProgressTask()
+	if inProgressTask == "" {
+		return fmt.Sprintf("%s %d/%d tasks",
+			progressBar,
+			completed,
+			total,
+		)
+	}
+
+	maxLen := tv.width - 50
+	taskPreview := inProgressTask
+	if maxLen > 10 && len(inProgressTask) > maxLen {
+		taskPreview = inProgressTask[:maxLen-3] + "..."
+	}
+	return fmt.Sprintf("%s %s %d/%d tasks",
+		taskPreview,
+		progressBar,
+		completed,
+		total,
+	)
+}
+
+// renderExpanded renders the full expanded view, with the todo list and the
+// plan checklist side by side when both are present
 func (tv *TodoBoxView) renderExpanded() string {
+	var boxes []string
+	if tv.HasTodos() {
+		boxes = append(boxes, tv.renderTodoBox())
+	}
+	if tv.HasPlanSteps() {
+		boxes = append(boxes, tv.renderPlanBox())
+	}
+	return joinColumns(boxes, " ")
+}
+
+// renderTodoBox renders the bordered todo list
+func (tv *TodoBoxView) renderTodoBox() string {
 	completed, total := tv.countTasks()
 	progressBar := tv.formatProgressBar(completed, total)
 	percentage := 0
@@ -203,12 +356,19 @@ func (tv *TodoBoxView) renderExpanded() string {
 		)
 	}
 	headerStyled := tv.styleProvider.RenderWithColorAndBold(header, accentColor)
-	hint := "(ctrl+t to collapse)"
+	hint := "(ctrl+t to collapse · alt+x to edit)"
+	if tv.focused {
+		hint = "(↑/↓ move · space done · shift+↑/↓ reorder · d delete · esc done)"
+	}
 	hintStyled := tv.styleProvider.RenderWithColor(hint, dimColor)
 	lines = append(lines, fmt.Sprintf("%s %s", headerStyled, hintStyled))
 
-	for _, todo := range tv.todos {
+	for i, todo := range tv.todos {
 		line := tv.formatTodoItem(todo)
+		if tv.focused && i == tv.cursor {
+			lines = append(lines, tv.styleProvider.RenderWithColor("›", accentColor)+line)
+			continue
+		}
 		lines = append(lines, " "+line)
 	}
 
@@ -217,6 +377,76 @@ func (tv *TodoBoxView) renderExpanded() string {
 	return tv.styleProvider.RenderBorderedBox(content, dimColor, 0, 1)
 }
 
+// renderPlanBox renders the bordered plan checklist. Beside the todo list it
+// gets half the width, so long steps are truncated.
+func (tv *TodoBoxView) renderPlanBox() string {
+	completed, total := tv.countPlanSteps()
+	percentage := int(float64(completed) / float64(total) * 100)
+
+	accentColor := tv.styleProvider.GetThemeColor("accent")
+
+	header := fmt.Sprintf("Plan %s %d%% (%d/%d steps)",
+		tv.formatProgressBar(completed, total),
+		percentage,
+		completed,
+		total,
+	)
+	lines := []string{tv.styleProvider.RenderWithColorAndBold(header, accentColor)}
+
+	maxWidth := tv.width - 8
+	if tv.HasTodos() {
+		maxWidth = tv.width/2 - 8
+	}
+	for _, step := range tv.planSteps {
+		content := fmt.Sprintf("%d. %s", step.Number, step.Content)
+		if maxWidth > 10 {
+			content = ansi.Truncate(content, maxWidth, "...")
+		}
+		lines = append(lines, " "+tv.formatTodoItem(domain.TodoItem{Content: content, Status: step.Status}))
+	}
+
+	return tv.styleProvider.RenderBorderedBox(strings.Join(lines, "\n"), tv.styleProvider.GetThemeColor("dim"), 0, 1)
+}
+
+// joinColumns places rendered blocks side by side, padding each to its widest
+// line
+func joinColumns(blocks []string, gap string) string {
+	if len(blocks) == 1 {
+		return blocks[0]
+	}
+
+	columns := make([][]string, len(blocks))
+	widths := make([]int, len(blocks))
+	rows := 0
+	for i, block := range blocks {
+		columns[i] = strings.Split(block, "\n")
+		rows = max(rows, len(columns[i]))
+		for _, line := range columns[i] {
+			widths[i] = max(widths[i], ansi.StringWidth(line))
+		}
+	}
+
+	lines := make([]string, rows)
+	for row := range lines {
+		var b strings.Builder
+		for i, column := range columns {
+			if i > 0 {
+				b.WriteString(gap)
+			}
+			var line string
+			if row < len(column) {
+				line = column[row]
+			}
+			b.WriteString(line)
+			if i < len(columns)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-ansi.StringWidth(line)))
+			}
+		}
+		lines[row] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
 // formatTodoItem formats a single todo item
 func (tv *TodoBoxView) formatTodoItem(todo domain.TodoItem) string {
 	var checkbox, content string
@@ -287,6 +517,17 @@ func (tv *TodoBoxView) countTasks() (completed, total int) {
 	return
 }
 
+// countPlanSteps returns completed and total plan step counts
+func (tv *TodoBoxView) countPlanSteps() (completed, total int) {
+	total = len(tv.planSteps)
+	for _, step := range tv.planSteps {
+		if step.Status == "completed" {
+			completed++
+		}
+	}
+	return
+}
+
 // getInProgressTask returns the content of the in-progress task, if any
 func (tv *TodoBoxView) getInProgressTask() string {
 	for _, todo := range tv.todos {
@@ -40,10 +40,11 @@ func (r *ToolCallRenderer) SetToolFormatter(f domain.ToolFormatter) {
 }
 
 // summaryLine builds the shared tool summary. args are the raw JSON string carried
-// by the live tool state; icon and trailing are already styled by the caller.
+// by the live tool state, possibly still streaming; icon and trailing are already
+// styled by the caller.
 func (r *ToolCallRenderer) summaryLine(icon, name, argsJSON, trailing string) string {
 	if r.toolFormatter != nil {
-		return r.toolFormatter.RenderToolSummary(icon, name, parseToolArgs(argsJSON), trailing, r.width)
+		return r.toolFormatter.RenderToolSummary(icon, name, parsePartialToolArgs(argsJSON), trailing, r.width)
 	}
 	line := name
 	if icon != "" {
@@ -69,8 +70,7 @@ func (r *ToolCallRenderer) liveCard(name, content string) string {
 }
 
 // parseToolArgs decodes a tool-call arguments JSON object into a map. It returns nil
-// for empty or not-yet-complete (streaming) JSON, so the summary shows "Name()" until
-// the arguments finish accumulating rather than a truncated fragment.
+// for empty or not-yet-complete (streaming) JSON rather than a truncated fragment.
 func parseToolArgs(argsJSON string) map[string]any {
 	argsJSON = strings.TrimSpace(argsJSON)
 	if argsJSON == "" || argsJSON == "{}" {
@@ -83,6 +83,83 @@ func parseToolArgs(argsJSON string) map[string]any {
 	return m
 }
 
+// parsePartialToolArgs decodes the arguments of a tool call that may still be
+// streaming. Incomplete JSON is closed off (open string, arrays and objects) so
+// the fields generated so far - a file path, the start of a command - can be
+// shown before the call finishes; a trailing key without a value is dropped.
+func parsePartialToolArgs(argsJSON string) map[string]any {
+	if m := parseToolArgs(argsJSON); m != nil {
+		return m
+	}
+	s := strings.TrimSpace(argsJSON)
+	if !strings.HasPrefix(s, "{") {
+		return nil
+	}
+	for end := len(s); end > 0; {
+		if m := closePartialJSON(s[:end]); len(m) > 0 {
+			return m
+		}
+		cut := strings.LastIndexAny(s[:end], ",{[")
+		switch {
+		case cut <= 0:
+			return nil
+		case s[cut] == ',':
+			end = cut
+		case cut+1 < end:
+			end = cut + 1
+		default:
+			end = cut
+		}
+	}
+	return nil
+}
+
+// closePartialJSON appends whatever closing quote and brackets prefix needs to
+// become a complete JSON object and decodes it, returning nil if it still isn't.
+func closePartialJSON(prefix string) map[string]any {
+	var closers []byte
+	inString, escaped := false, false
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			closers = append(closers, '}')
+		case c == '[':
+			closers = append(closers, ']')
+		case (c == '}' || c == ']') && len(closers) > 0:
+			closers = closers[:len(closers)-1]
+		}
+	}
+
+	if escaped {
+		return nil
+	}
+	body := prefix
+	if inString {
+		body += `"`
+	}
+	body = strings.TrimSuffix(strings.TrimRight(body, " \t\r\n"), ",")
+	if strings.HasSuffix(body, ":") {
+		return nil
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		body += string(closers[i])
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal([]byte(body), &m); err != nil {
+		return nil
+	}
+	return m
+}
+
 // KeyHintFormatter provides formatted key hints for actions
 type KeyHintFormatter interface {
 	GetKeyHint(actionID, defaultLabel string) string
@@ -99,6 +176,7 @@ type ToolRenderState struct {
 	LastUpdate       time.Time
 	OutputBuffer     []string
 	TotalOutputLines int
+	Progress         *domain.ToolProgress
 	IsComplete       bool
 	IsExpanded       bool
 }
@@ -180,7 +258,21 @@ func (r *ToolCallRenderer) handleToolCallPreview(msg domain.ToolCallPreviewEvent
 	return r, nil
 }
 
+// handleToolCallUpdate refreshes a tool call's arguments while the model is still
+// generating them. The first update for a call creates its live preview, so a
+// bad Write or Bash is visible (and can be cancelled) before it finishes streaming.
 func (r *ToolCallRenderer) handleToolCallUpdate(msg domain.ToolCallUpdateEvent) (*ToolCallRenderer, tea.Cmd) {
+	if _, exists := r.tools[msg.ToolCallID]; !exists {
+		return r.handleToolCallPreview(domain.ToolCallPreviewEvent{
+			RequestID:  msg.RequestID,
+			Timestamp:  msg.Timestamp,
+			ToolCallID: msg.ToolCallID,
+			ToolName:   msg.ToolName,
+			Arguments:  msg.Arguments,
+			Status:     msg.Status,
+			IsComplete: msg.Status == domain.ToolCallStreamStatusComplete,
+		})
+	}
 	if state, exists := r.tools[msg.ToolCallID]; exists {
 		if time.Since(r.lastUpdate) < constants.ToolCallUpdateThrottle {
 			return r, nil
@@ -209,6 +301,7 @@ func (r *ToolCallRenderer) handleToolExecutionProgress(msg domain.ToolExecutionP
 			Arguments:  msg.Arguments,
 			StartTime:  now,
 			LastUpdate: now,
+			Progress:   msg.Progress,
 		}
 		if len(r.tools) == 1 {
 			return r, r.spinner.Tick
@@ -218,6 +311,9 @@ func (r *ToolCallRenderer) handleToolExecutionProgress(msg domain.ToolExecutionP
 
 	state.Status = msg.Status
 	state.LastUpdate = now
+	if msg.Progress != nil {
+		state.Progress = msg.Progress
+	}
 	if msg.Arguments != "" && state.Arguments == "" {
 		state.Arguments = msg.Arguments
 	}
@@ -335,7 +431,12 @@ func (r *ToolCallRenderer) renderTool(tool *ToolRenderState) string {
 		statusText = "queued"
 		iconColor = "dim"
 		statusColor = "dim"
-	case "running", "starting", "saving", "executing", "streaming":
+	case "streaming":
+		statusIcon = r.spinner.View()
+		statusText = "generating"
+		iconColor = "accent"
+		statusColor = "dim"
+	case "running", "starting", "saving", "executing":
 		statusIcon = r.spinner.View()
 		switch {
 		case !r.pausedAt.IsZero():
@@ -380,6 +481,9 @@ func (r *ToolCallRenderer) renderTool(tool *ToolRenderState) string {
 	styledStatus := r.styleProvider.RenderWithColor(statusText, r.styleProvider.GetThemeColor(statusColor))
 
 	header := r.summaryLine(styledIcon, tool.ToolName, tool.Arguments, styledStatus)
+	if progress := r.renderProgressLine(tool); progress != "" {
+		header += "\n" + progress
+	}
 
 	switch {
 	case r.shouldRenderBashOutput(tool):
@@ -451,6 +555,31 @@ func (r *ToolCallRenderer) formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm%.1fs", minutes, remainingSeconds)
 }
 
+// renderProgressLine renders the latest progress a running tool reported: a
+// bar with the percentage when the total is known, then the step description.
+func (r *ToolCallRenderer) renderProgressLine(tool *ToolRenderState) string {
+	if tool.Progress == nil || tool.IsComplete {
+		return ""
+	}
+
+	const barWidth = 20
+	var parts []string
+	if percent := tool.Progress.Percent; percent >= 0 {
+		percent = min(percent, 100)
+		filled := int(percent * barWidth / 100)
+		bar := r.styleProvider.RenderWithColor(strings.Repeat("█", filled), r.styleProvider.GetThemeColor("accent")) +
+			r.styleProvider.RenderDimText(strings.Repeat("░", barWidth-filled))
+		parts = append(parts, fmt.Sprintf("%s %3.0f%%", bar, percent))
+	}
+	if tool.Progress.Step != "" {
+		parts = append(parts, r.styleProvider.RenderDimText(tool.Progress.Step))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "    " + strings.Join(parts, " · ")
+}
+
 // shouldRenderBashOutput determines if Bash tool output should be rendered
 func (r *ToolCallRenderer) shouldRenderBashOutput(tool *ToolRenderState) bool {
 	if tool.ToolName != "Bash" {
@@ -1,10 +1,13 @@
 package components
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	ansi "github.com/charmbracelet/x/ansi"
+
 	domain "github.com/inference-gateway/cli/internal/domain"
 )
 
@@ -86,3 +89,101 @@ func TestToolCallRenderer_PausesTimerDuringApproval(t *testing.T) {
 		t.Errorf("expected running ticker after question answered, got %q", resumed)
 	}
 }
+
+// TestToolCallRenderer_ProgressLine verifies a running tool's reported
+// progress is drawn under its summary line, and kept when later status-only
+// events arrive without a report.
+func TestToolCallRenderer_ProgressLine(t *testing.T) {
+	const toolCallID = "tc-1"
+
+	r := NewToolCallRenderer(createMockStyleProvider())
+	r.handleToolExecutionProgress(domain.ToolExecutionProgressEvent{
+		ToolCallID: toolCallID,
+		ToolName:   "WebFetch",
+		Status:     "running",
+		Progress:   &domain.ToolProgress{Percent: 50, Step: "1.0 MB of 2.0 MB"},
+	})
+	r.handleToolExecutionProgress(domain.ToolExecutionProgressEvent{
+		ToolCallID: toolCallID,
+		ToolName:   "WebFetch",
+		Status:     "running",
+		Message:    "Processing...",
+	})
+
+	line := ansi.Strip(r.renderProgressLine(r.tools[toolCallID]))
+	if !strings.Contains(line, "██████████░░░░░░░░░░") || !strings.Contains(line, "50%") ||
+		!strings.Contains(line, "1.0 MB of 2.0 MB") {
+		t.Errorf("unexpected progress line %q", line)
+	}
+
+	r.tools[toolCallID].Progress = &domain.ToolProgress{Percent: -1, Step: "12 tests run"}
+	if line := ansi.Strip(r.renderProgressLine(r.tools[toolCallID])); strings.Contains(line, "%") ||
+		!strings.Contains(line, "12 tests run") {
+		t.Errorf("an unknown total should show only the step, got %q", line)
+	}
+
+	r.handleToolExecutionProgress(domain.ToolExecutionProgressEvent{
+		ToolCallID: toolCallID,
+		ToolName:   "WebFetch",
+		Status:     "completed",
+	})
+	if line := r.renderProgressLine(r.tools[toolCallID]); line != "" {
+		t.Errorf("a completed tool should drop its progress line, got %q", line)
+	}
+}
+
+func TestParsePartialToolArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want map[string]any
+	}{
+		{"complete", `{"file_path": "/a.go"}`, map[string]any{"file_path": "/a.go"}},
+		{"open string", `{"command": "rm -rf bu`, map[string]any{"command": "rm -rf bu"}},
+		{"key without value", `{"file_path": "/a.go", "content":`, map[string]any{"file_path": "/a.go"}},
+		{"partial key", `{"file_path": "/a.go", "cont`, map[string]any{"file_path": "/a.go"}},
+		{"nested", `{"edits": [{"old_string": "x`, map[string]any{"edits": []any{map[string]any{"old_string": "x"}}}},
+		{"dangling escape", `{"path": "a", "content": "line\`, map[string]any{"path": "a"}},
+		{"nothing yet", `{"file`, nil},
+		{"empty", ``, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parsePartialToolArgs(tt.args)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parsePartialToolArgs(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+			for k, v := range tt.want {
+				if !reflect.DeepEqual(got[k], v) {
+					t.Errorf("parsePartialToolArgs(%q)[%q] = %v, want %v", tt.args, k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+// TestToolCallRenderer_UpdateCreatesStreamingPreview verifies that the first
+// streaming update for an unseen tool call starts a live preview carrying the
+// partial arguments.
+func TestToolCallRenderer_UpdateCreatesStreamingPreview(t *testing.T) {
+	r := NewToolCallRenderer(nil)
+
+	r.handleToolCallUpdate(domain.ToolCallUpdateEvent{
+		ToolCallID: "call-1",
+		ToolName:   "Bash",
+		Arguments:  `{"command": "rm -r`,
+		Status:     domain.ToolCallStreamStatusStreaming,
+	})
+
+	state, ok := r.tools["call-1"]
+	if !ok {
+		t.Fatal("expected a preview for the streaming tool call")
+	}
+	if state.Status != string(domain.ToolCallStreamStatusStreaming) || state.Arguments != `{"command": "rm -r` {
+		t.Errorf("unexpected state %+v", state)
+	}
+	if len(r.toolsOrder) != 1 {
+		t.Errorf("expected one ordered preview, got %v", r.toolsOrder)
+	}
+}
@@ -0,0 +1,163 @@
+// Package graphics renders images inline in the terminal using the kitty
+// graphics protocol. Images are placed with Unicode placeholders: the image
+// data is transmitted once out of band, and the picture is drawn wherever the
+// placeholder cells appear. Because the placeholders are ordinary text cells
+// they scroll, wrap, and diff like the rest of the Bubble Tea view, which the
+// cursor-positioned iTerm2 and sixel protocols cannot do.
+package graphics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"strings"
+)
+
+// Protocol identifies a terminal graphics protocol
+type Protocol string
+
+const (
+	// ProtocolNone means images are shown as text placeholders
+	ProtocolNone Protocol = ""
+	// ProtocolKitty is the kitty graphics protocol with Unicode placeholders
+	ProtocolKitty Protocol = "kitty"
+)
+
+// DetectProtocol picks the graphics protocol supported by the terminal from
+// its environment. Multiplexers are treated as unsupported because they do not
+// forward the graphics escape sequences. Terminals that only speak iTerm2 or
+// sixel graphics, such as iTerm2, WezTerm and foot, get ProtocolNone as well:
+// those protocols are not implemented.
+func DetectProtocol(getenv func(string) string) Protocol {
+	if getenv("TMUX") != "" || strings.HasPrefix(getenv("TERM"), "screen") {
+		return ProtocolNone
+	}
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", getenv("TERM") == "xterm-kitty":
+		return ProtocolKitty
+	case getenv("TERM_PROGRAM") == "ghostty", getenv("TERM") == "xterm-ghostty":
+		return ProtocolKitty
+	}
+	return ProtocolNone
+}
+
+// placeholder is the kitty Unicode placeholder character
+const placeholder = "\U0010EEEE"
+
+// rowDiacritics encode the placeholder row; the column is inferred from the
+// previous cell, so only the first cell of each row carries diacritics.
+var rowDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F, 0x0346, 0x034A,
+	0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357, 0x035B, 0x0363, 0x0364, 0x0365,
+}
+
+// MaxRows is the tallest an inline image can be, in terminal rows
+var MaxRows = len(rowDiacritics)
+
+// transmitChunkSize is the largest base64 payload per escape sequence
+const transmitChunkSize = 4096
+
+// Image is an image prepared for inline display
+type Image struct {
+	ID   uint32
+	Cols int
+	Rows int
+
+	transmit string
+}
+
+// Prepare decodes an image and sizes it to fit within maxCols x maxRows
+// terminal cells, assuming cells twice as tall as they are wide. Images that
+// are not PNG are re-encoded, since the protocol only takes PNG directly.
+func Prepare(data []byte, id uint32, maxCols, maxRows int) (*Image, error) {
+	if id == 0 || id > 0xFFFFFF {
+		return nil, fmt.Errorf("image id %d out of range", id)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, fmt.Errorf("image has no pixels")
+	}
+
+	if format != "png" {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		data = buf.Bytes()
+	}
+
+	cols, rows := fitCells(cfg.Width, cfg.Height, maxCols, min(maxRows, MaxRows))
+	return &Image{
+		ID:       id,
+		Cols:     cols,
+		Rows:     rows,
+		transmit: transmitSequence(data, id, cols, rows),
+	}, nil
+}
+
+// fitCells scales a width x height pixel image into at most maxCols x maxRows
+// cells, keeping the aspect ratio of a 1:2 cell.
+func fitCells(width, height, maxCols, maxRows int) (int, int) {
+	maxCols, maxRows = max(maxCols, 1), max(maxRows, 1)
+	cols := maxCols
+	rows := (height*cols + width) / (2 * width)
+	if rows > maxRows {
+		rows = maxRows
+		cols = (2*width*rows + height/2) / height
+	}
+	return min(max(cols, 1), maxCols), max(rows, 1)
+}
+
+// transmitSequence builds the escape sequences that upload the PNG and create
+// a virtual placement of cols x rows cells for the placeholders to show.
+func transmitSequence(data []byte, id uint32, cols, rows int) string {
+	payload := base64.StdEncoding.EncodeToString(data)
+
+	var b strings.Builder
+	for first := true; first || payload != ""; first = false {
+		chunk := payload[:min(transmitChunkSize, len(payload))]
+		payload = payload[len(chunk):]
+
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&b, "\x1b_Ga=T,U=1,f=100,q=2,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", id, cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return b.String()
+}
+
+// Transmit returns the escape sequences that upload the image to the
+// terminal. They must be written to the terminal once, outside the view.
+func (img *Image) Transmit() string {
+	return img.transmit
+}
+
+// Placeholder returns the rows of placeholder cells that display the image.
+// The image id is carried in the 24-bit foreground color.
+func (img *Image) Placeholder() string {
+	color := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", img.ID>>16&0xFF, img.ID>>8&0xFF, img.ID&0xFF)
+	rest := strings.Repeat(placeholder, img.Cols-1)
+
+	lines := make([]string, img.Rows)
+	for row := range img.Rows {
+		lines[row] = color + placeholder + string(rowDiacritics[row]) + string(rowDiacritics[0]) + rest + "\x1b[39m"
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,124 @@
+package graphics
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestDetectProtocol(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Protocol
+	}{
+		{"plain terminal", map[string]string{"TERM": "xterm-256color"}, ProtocolNone},
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, ProtocolKitty},
+		{"kitty window id", map[string]string{"KITTY_WINDOW_ID": "1"}, ProtocolKitty},
+		{"ghostty", map[string]string{"TERM_PROGRAM": "ghostty"}, ProtocolKitty},
+		{"iterm2 is not supported", map[string]string{"TERM_PROGRAM": "iTerm.app"}, ProtocolNone},
+		{"wezterm is not supported", map[string]string{"TERM_PROGRAM": "WezTerm"}, ProtocolNone},
+		{"sixel only foot is not supported", map[string]string{"TERM": "foot"}, ProtocolNone},
+		{"kitty inside tmux", map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux"}, ProtocolNone},
+		{"screen", map[string]string{"TERM": "screen-256color", "KITTY_WINDOW_ID": "1"}, ProtocolNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := DetectProtocol(getenv); got != tt.want {
+				t.Errorf("DetectProtocol() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitCells(t *testing.T) {
+	tests := []struct {
+		name               string
+		width, height      int
+		maxCols, maxRows   int
+		wantCols, wantRows int
+	}{
+		{"square limited by rows", 100, 100, 40, 10, 20, 10},
+		{"square fits", 100, 100, 20, 20, 20, 10},
+		{"wide banner", 1000, 100, 40, 20, 40, 2},
+		{"tall strip", 10, 1000, 40, 20, 1, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols, rows := fitCells(tt.width, tt.height, tt.maxCols, tt.maxRows)
+			if cols != tt.wantCols || rows != tt.wantRows {
+				t.Errorf("fitCells() = %dx%d, want %dx%d", cols, rows, tt.wantCols, tt.wantRows)
+			}
+		})
+	}
+}
+
+func encodeTestImage(t *testing.T, jpg bool) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for x := range 64 {
+		img.Set(x, x%32, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	var err error
+	if jpg {
+		err = jpeg.Encode(&buf, img, nil)
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestPrepare(t *testing.T) {
+	img, err := Prepare(encodeTestImage(t, false), 0x010203, 16, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Cols != 16 || img.Rows != 4 {
+		t.Fatalf("size = %dx%d, want 16x4", img.Cols, img.Rows)
+	}
+	if !strings.HasPrefix(img.Transmit(), "\x1b_Ga=T,U=1,f=100,q=2,i=66051,c=16,r=4,m=0;") {
+		t.Errorf("unexpected transmit sequence prefix: %q", img.Transmit()[:60])
+	}
+
+	lines := strings.Split(img.Placeholder(), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d placeholder rows, want 4", len(lines))
+	}
+	if !strings.HasPrefix(lines[1], "\x1b[38;2;1;2;3m"+placeholder+string(rowDiacritics[1])+string(rowDiacritics[0])) {
+		t.Errorf("row 1 should carry the image id color and row/column diacritics: %q", lines[1])
+	}
+	if got := strings.Count(lines[0], placeholder); got != 16 {
+		t.Errorf("row has %d placeholder cells, want 16", got)
+	}
+
+	if _, err := Prepare(encodeTestImage(t, true), 7, 16, 10); err != nil {
+		t.Errorf("JPEG images should be re-encoded, got %v", err)
+	}
+	if _, err := Prepare([]byte("not an image"), 1, 16, 10); err == nil {
+		t.Error("expected an error for undecodable data")
+	}
+	if _, err := Prepare(encodeTestImage(t, false), 0, 16, 10); err == nil {
+		t.Error("expected an error for image id 0")
+	}
+}
+
+func TestTransmitSequenceChunks(t *testing.T) {
+	data := bytes.Repeat([]byte{0xAB}, transmitChunkSize)
+	seq := transmitSequence(data, 5, 2, 1)
+
+	if got := strings.Count(seq, "\x1b_G"); got != 2 {
+		t.Fatalf("got %d chunks, want 2", got)
+	}
+	if !strings.Contains(seq, "m=1;") || !strings.Contains(seq, "\x1b_Gm=0;") {
+		t.Errorf("expected a continued first chunk and a final chunk: %q", seq[:40])
+	}
+}
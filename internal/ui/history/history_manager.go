@@ -169,6 +169,27 @@ func (hm *HistoryManager) IsNavigating() bool {
 	return hm.historyIndex != -1
 }
 
+// SearchHistory returns the commands containing query, ignoring case, newest
+// first. A command entered several times is listed once.
+func (hm *HistoryManager) SearchHistory(query string) []string {
+	query = strings.ToLower(query)
+	if query == "" {
+		return nil
+	}
+
+	var matches []string
+	seen := make(map[string]bool)
+	for i := len(hm.allHistory) - 1; i >= 0; i-- {
+		command := hm.allHistory[i]
+		if seen[command] || !strings.Contains(strings.ToLower(command), query) {
+			continue
+		}
+		seen[command] = true
+		matches = append(matches, command)
+	}
+	return matches
+}
+
 // GetShellHistoryFile returns the shell history file path
 func (hm *HistoryManager) GetShellHistoryFile() string {
 	return hm.shellHistory.GetHistoryFile()
@@ -155,3 +155,27 @@ func TestNewHistoryManager_Integration(t *testing.T) {
 		t.Error("NavigateUp should return a history command, not current input")
 	}
 }
+
+func TestHistoryManager_SearchHistory(t *testing.T) {
+	hm := history.NewMemoryOnlyHistoryManager(10)
+	for _, command := range []string{"run the tests", "fix the build", "Run the linter", "run the tests", "deploy"} {
+		if err := hm.AddToHistory(command); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := hm.SearchHistory("RUN")
+	want := []string{"run the tests", "Run the linter"}
+	if len(got) != len(want) {
+		t.Fatalf("SearchHistory(RUN) = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("match %d = %q, want %q (newest first, repeats once)", i, got[i], want[i])
+		}
+	}
+
+	if got := hm.SearchHistory(""); got != nil {
+		t.Errorf("an empty query should match nothing, got %q", got)
+	}
+}
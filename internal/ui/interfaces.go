@@ -12,6 +12,8 @@ import (
 type KeyShortcut struct {
 	Key         string
 	Description string
+	// Category groups the shortcut in the help overlay, e.g. "navigation".
+	Category string
 }
 
 // ScrollDirection represents different scroll directions
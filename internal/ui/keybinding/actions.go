@@ -1,6 +1,7 @@
 package keybinding
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,7 +11,9 @@ import (
 	tea "charm.land/bubbletea/v2"
 	config "github.com/inference-gateway/cli/config"
 	clipboard "github.com/inference-gateway/cli/internal/clipboard"
+	clipboardtext "github.com/inference-gateway/cli/internal/clipboard/text"
 	domain "github.com/inference-gateway/cli/internal/domain"
+	formatting "github.com/inference-gateway/cli/internal/formatting"
 	logger "github.com/inference-gateway/cli/internal/logger"
 	services "github.com/inference-gateway/cli/internal/services"
 	ui "github.com/inference-gateway/cli/internal/ui"
@@ -68,14 +71,27 @@ func defaultActions() []*KeyAction {
 		{ID: config.ActionID(config.NamespaceTools, "background_shell"), Handler: handleBackgroundShell, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceDisplay, "toggle_raw_format"), Handler: handleToggleRawFormat, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceDisplay, "toggle_todo_box"), Handler: handleToggleTodoBox, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "toggle_pinned_box"), Handler: handleTogglePinnedBox, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "toggle_side_panel"), Handler: handleToggleSidePanel, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "grow_side_panel"), Handler: handleGrowSidePanel, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "shrink_side_panel"), Handler: handleShrinkSidePanel, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "toggle_minimap"), Handler: handleToggleMinimap, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceDisplay, "toggle_thinking"), Handler: handleToggleThinkingExpansion, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "hide_thinking"), Handler: handleToggleThinkingVisibility, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "search_conversation"), Handler: handleSearchConversation, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceDisplay, "conversation_outline"), Handler: handleConversationOutline, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceSelection, "toggle_mouse_mode"), Handler: handleToggleMouseMode, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceSelection, "visual_mode"), Handler: handleVisualMode, Context: chatView(noApprovalPending)},
 		{ID: config.ActionID(config.NamespaceChat, "tab_key_handler"), Handler: handleTabKey, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceChat, "enter_key_handler"), Handler: handleEnterKey, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceChat, "undo_send"), Handler: handleUndoSend, Context: chatView(inputIsEmpty)},
+		{ID: config.ActionID(config.NamespaceChat, "retry_with_alternate"), Handler: handleRetryWithAlternate, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceHelp, "toggle_help"), Handler: handleToggleHelp, Context: chatView(inputIsEmpty)},
+		{ID: config.ActionID(config.NamespaceHelp, "command_palette"), Handler: handleCommandPalette, Context: chatView(noApprovalPending)},
 
 		{ID: config.ActionID(config.NamespaceClipboard, "paste_text"), Handler: handlePaste, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceClipboard, "copy_text"), Handler: handleCopy, Context: chatView()},
+		{ID: config.ActionID(config.NamespaceClipboard, "copy_code_block"), Handler: handleCopyCodeBlock, Context: chatView()},
 
 		{ID: config.ActionID(config.NamespaceTextEditing, "insert_newline_alt"), Handler: handleInsertNewline, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceTextEditing, "insert_newline_ctrl"), Handler: handleInsertNewline, Context: chatView()},
@@ -91,6 +107,8 @@ func defaultActions() []*KeyAction {
 		{ID: config.ActionID(config.NamespaceTextEditing, "move_to_end"), Handler: handleMoveToEnd, Context: chatView()},
 		{ID: config.ActionID(config.NamespaceTextEditing, "history_up"), Handler: handleHistoryUp, Context: chatView(noApprovalPending)},
 		{ID: config.ActionID(config.NamespaceTextEditing, "history_down"), Handler: handleHistoryDown, Context: chatView(noApprovalPending)},
+		{ID: config.ActionID(config.NamespaceTextEditing, "history_search"), Handler: handleHistorySearch, Context: chatView(noApprovalPending)},
+		{ID: config.ActionID(config.NamespaceTextEditing, "open_in_editor"), Handler: handleOpenInEditor, Context: chatView(noApprovalPending)},
 
 		{ID: config.ActionID(config.NamespaceNavigation, "go_back_in_time"), Handler: handleGoBackInTime, Context: chatView(chatIdleOrCompleted)},
 		{ID: config.ActionID(config.NamespaceNavigation, "scroll_to_top"), Handler: handleScrollToTop, Context: chatView()},
@@ -105,6 +123,7 @@ func defaultActions() []*KeyAction {
 		{ID: config.ActionID(config.NamespacePlanApproval, "plan_approval_accept"), Handler: handlePlanApprovalAccept, Context: planApprovalView},
 		{ID: config.ActionID(config.NamespacePlanApproval, "plan_approval_reject"), Handler: handlePlanApprovalReject, Context: planApprovalView},
 		{ID: config.ActionID(config.NamespacePlanApproval, "plan_approval_accept_standard"), Handler: handlePlanApprovalAcceptStandard, Context: planApprovalView},
+		{ID: config.ActionID(config.NamespacePlanApproval, "plan_approval_edit"), Handler: handlePlanApprovalEdit, Context: planApprovalView},
 	}
 }
 
@@ -156,6 +175,13 @@ func handleCancel(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 		}
 	}
 
+	// With Vim keybindings, esc while idle leaves insert mode rather than
+	// interrupting; a running turn is still cancelled.
+	if iv, ok := app.GetInputView().(*components.InputView); ok &&
+		stateManager.GetChatSession() == nil && iv.EnterVimNormalMode() {
+		return nil
+	}
+
 	if chatSession := stateManager.GetChatSession(); chatSession != nil {
 		agentService := app.GetAgentService()
 		if agentService != nil {
@@ -234,6 +260,26 @@ func handleToggleThinkingExpansion(app KeyHandlerContext, keyMsg tea.KeyPressMsg
 	return nil
 }
 
+// handleToggleThinkingVisibility hides or shows the model's thinking blocks
+// altogether, where toggle_thinking only expands or collapses them
+func handleToggleThinkingVisibility(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	cv, ok := app.GetConversationView().(*components.ConversationView)
+	if !ok {
+		return nil
+	}
+	message := "Thinking hidden"
+	if cv.ToggleThinkingVisibility() {
+		message = "Thinking shown"
+	}
+	return func() tea.Msg {
+		return domain.SetStatusEvent{
+			Message:    message,
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+}
+
 func handleBackgroundShell(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	return func() tea.Msg {
 		return domain.BackgroundShellRequestEvent{}
@@ -250,6 +296,58 @@ func handleToggleRawFormat(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cm
 	}
 }
 
+// handleSearchConversation opens the in-conversation search. While it is open
+// the chat view routes keys to the search instead of the input.
+func handleSearchConversation(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	cv, ok := app.GetConversationView().(*components.ConversationView)
+	if !ok {
+		return nil
+	}
+	cv.StartSearch()
+	if iv, ok := app.GetInputView().(*components.InputView); ok {
+		iv.SetCustomHint(cv.SearchHint())
+	}
+	return nil
+}
+
+// handleVisualMode opens visual selection mode: a line cursor over the
+// rendered conversation for copying a region without the UI chrome that
+// terminal mouse selection picks up.
+func handleVisualMode(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	cv, ok := app.GetConversationView().(*components.ConversationView)
+	if !ok {
+		return nil
+	}
+	if !cv.StartLineSelection() {
+		return flashStatus(app, "Nothing to select yet")
+	}
+	if iv, ok := app.GetInputView().(*components.InputView); ok {
+		iv.SetCustomHint(cv.SelectionHint())
+	}
+	return nil
+}
+
+// handleConversationOutline opens the outline of user messages, assistant
+// turns, and tool calls; selecting a row scrolls the conversation to it.
+func handleConversationOutline(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	cv, ok := app.GetConversationView().(*components.ConversationView)
+	if !ok {
+		return nil
+	}
+	if !cv.EnterOutlineMode() {
+		return func() tea.Msg {
+			return domain.SetStatusEvent{
+				Message: "Nothing to outline yet",
+				Spinner: false,
+			}
+		}
+	}
+	if iv, ok := app.GetInputView().(*components.InputView); ok {
+		iv.SetCustomHint("Input paused - use ↑/↓ to navigate, enter to jump, esc to cancel")
+	}
+	return nil
+}
+
 func handleEnterKey(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	stateManager := app.GetStateManager()
 
@@ -469,16 +567,7 @@ func handlePaste(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 		}
 	}
 
-	currentText := inputView.GetInput()
-	cursor := inputView.GetCursor()
-
-	newText := currentText[:cursor] + cleanText + currentText[cursor:]
-	newCursor := cursor + len(cleanText)
-
-	inputView.SetText(newText)
-	inputView.SetCursor(newCursor)
-
-	return flashStatus(app, "Text pasted from clipboard")
+	return insertPastedText(app, inputView, cleanText)
 }
 
 func handleCopy(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
@@ -496,6 +585,65 @@ func handleCopy(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	return flashStatus(app, "Copied to clipboard")
 }
 
+// handleCopyCodeBlock copies a fenced code block from the latest assistant
+// message. A single block is copied straight away; several open a numbered
+// picker in the conversation view.
+func handleCopyCodeBlock(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	repo := app.GetConversationRepository()
+	if repo == nil {
+		return nil
+	}
+
+	blocks := components.LatestCodeBlocks(repo.GetMessages())
+	switch len(blocks) {
+	case 0:
+		return flashStatus(app, "No code blocks in the latest response")
+	case 1:
+		return CopyCodeBlock(blocks[0])
+	}
+
+	cv, ok := app.GetConversationView().(*components.ConversationView)
+	if !ok {
+		return CopyCodeBlock(blocks[len(blocks)-1])
+	}
+	cv.EnterCodeBlockPicker(blocks)
+	if iv, ok := app.GetInputView().(*components.InputView); ok {
+		iv.SetCustomHint(fmt.Sprintf("Input paused - press 1-%d or enter to copy a block, esc to cancel", min(len(blocks), 9)))
+	}
+	return nil
+}
+
+// CopyCodeBlock copies the code of a block to the system clipboard. It goes
+// through the native clipboard utilities so it works without CGO, and keeps
+// the block's indentation exactly as the model wrote it.
+func CopyCodeBlock(block formatting.CodeBlock) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboardtext.NewWriter().Copy(context.Background(), block.Code); err != nil {
+			return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to copy code block: %v", err)}
+		}
+		lines := strings.Count(block.Code, "\n") + 1
+		return domain.SetStatusEvent{
+			Message: fmt.Sprintf("Copied code block to clipboard (%d lines)", lines),
+			Spinner: false,
+		}
+	}
+}
+
+// CopySelectedText copies the lines picked in visual selection mode to the
+// system clipboard.
+func CopySelectedText(text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboardtext.NewWriter().Copy(context.Background(), text); err != nil {
+			return domain.ShowErrorEvent{Error: fmt.Sprintf("Failed to copy selection: %v", err)}
+		}
+		lines := strings.Count(text, "\n") + 1
+		return domain.SetStatusEvent{
+			Message: fmt.Sprintf("Copied %d lines to clipboard", lines),
+			Spinner: false,
+		}
+	}
+}
+
 func handleGoBackInTime(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	return func() tea.Msg {
 		return domain.NavigateBackInTimeEvent{
@@ -640,6 +788,18 @@ func handleHistoryUp(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	return nil
 }
 
+// handleHistorySearch opens the reverse search over the input history. While
+// it is open the chat view routes keys to the search instead of the input.
+func handleHistorySearch(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	iv, ok := app.GetInputView().(*components.InputView)
+	if !ok {
+		return nil
+	}
+	iv.StartHistorySearch()
+	iv.SetCustomHint(iv.HistorySearchHint())
+	return nil
+}
+
 func handleHistoryDown(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	inputView := app.GetInputView()
 	autocomplete := app.GetAutocomplete()
@@ -688,18 +848,93 @@ func handleInsertNewline(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd
 	return handleInputChangedAfterTextarea(app, false)
 }
 
+// handleOpenInEditor hands the input buffer to $EDITOR for composing longer
+// messages; the saved text comes back into the input when the editor exits.
+func handleOpenInEditor(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	inputView := app.GetInputView()
+	if inputView == nil {
+		return nil
+	}
+	return components.ComposeInEditor(inputView.GetInput())
+}
+
 func handleToggleHelp(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	return func() tea.Msg {
 		return domain.ToggleHelpBarEvent{}
 	}
 }
 
+func handleCommandPalette(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return domain.TriggerCommandPaletteEvent{}
+	}
+}
+
+func handleUndoSend(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return domain.UndoSendEvent{}
+	}
+}
+
+func handleRetryWithAlternate(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return domain.RetryTurnEvent{}
+	}
+}
+
 func handleToggleTodoBox(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	return func() tea.Msg {
 		return domain.ToggleTodoBoxEvent{}
 	}
 }
 
+func handleTogglePinnedBox(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return domain.TogglePinnedBoxEvent{}
+	}
+}
+
+func handleToggleSidePanel(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return domain.ToggleSidePanelEvent{}
+	}
+}
+
+// handleToggleMinimap shows or hides the activity strip beside the
+// conversation. Clicking it to jump needs mouse mode.
+func handleToggleMinimap(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	cv, ok := app.GetConversationView().(*components.ConversationView)
+	if !ok {
+		return nil
+	}
+	message := "Minimap hidden"
+	if cv.ToggleMinimap() {
+		message = "Minimap shown"
+		if !app.GetMouseEnabled() {
+			message += " - enable mouse mode to click it"
+		}
+	}
+	return func() tea.Msg {
+		return domain.SetStatusEvent{
+			Message:    message,
+			Spinner:    false,
+			StatusType: domain.StatusDefault,
+		}
+	}
+}
+
+func handleGrowSidePanel(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return domain.ResizeSidePanelEvent{Delta: 1}
+	}
+}
+
+func handleShrinkSidePanel(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return domain.ResizeSidePanelEvent{Delta: -1}
+	}
+}
+
 func handleCycleAgentMode(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
 	stateManager := app.GetStateManager()
 	statusView := app.GetStatusView()
@@ -1107,13 +1342,25 @@ func HandlePasteEvent(app KeyHandlerContext, pastedText string) tea.Cmd {
 		return nil
 	}
 
-	cursor := inputView.GetCursor()
-	text := inputView.GetInput()
-	newText := text[:cursor] + cleanText + text[cursor:]
-	newCursor := cursor + len(cleanText)
+	return insertPastedText(app, inputView, cleanText)
+}
+
+// insertPastedText inserts pasted text at the cursor. A paste longer than
+// chat.paste_collapse_lines becomes a text attachment shown as a one-line
+// label instead, so a huge stack trace cannot take over the input.
+func insertPastedText(app KeyHandlerContext, inputView ui.InputComponent, text string) tea.Cmd {
+	if iv, ok := inputView.(*components.InputView); ok {
+		if cfg := app.GetConfig(); cfg != nil && cfg.Chat.PasteCollapseLines > 0 &&
+			formatting.CountLines(text) > cfg.Chat.PasteCollapseLines {
+			label := iv.AddPastedText(text)
+			return flashStatus(app, "Pasted text attached as "+label)
+		}
+	}
 
-	inputView.SetText(newText)
-	inputView.SetCursor(newCursor)
+	cursor := inputView.GetCursor()
+	current := inputView.GetInput()
+	inputView.SetText(current[:cursor] + text + current[cursor:])
+	inputView.SetCursor(cursor + len(text))
 
 	return flashStatus(app, "Text pasted from clipboard")
 }
@@ -1191,3 +1438,13 @@ func handlePlanApprovalAcceptStandard(app KeyHandlerContext, keyMsg tea.KeyPress
 		}
 	}
 }
+
+// handlePlanApprovalEdit opens the pending plan in the user's editor; the
+// saved version replaces the plan before it is approved
+func handlePlanApprovalEdit(app KeyHandlerContext, keyMsg tea.KeyPressMsg) tea.Cmd {
+	planApprovalState := app.GetStateManager().GetPlanApprovalUIState()
+	if planApprovalState == nil {
+		return nil
+	}
+	return components.EditPlanInEditor(planApprovalState.PlanContent)
+}
@@ -1,6 +1,8 @@
 package keybinding
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,10 +33,12 @@ type flashTestCtx struct {
 	KeyHandlerContext
 	status ui.StatusComponent
 	input  ui.InputComponent
+	cfg    *config.Config
 }
 
 func (c *flashTestCtx) GetStatusView() ui.StatusComponent { return c.status }
 func (c *flashTestCtx) GetInputView() ui.InputComponent   { return c.input }
+func (c *flashTestCtx) GetConfig() *config.Config         { return c.cfg }
 
 func newFlashCtx(spinnerActive bool, input string) *flashTestCtx {
 	status := &uimocks.FakeStatusComponent{}
@@ -131,6 +135,29 @@ func TestHandlePasteEventFlashesAndInserts(t *testing.T) {
 	}
 }
 
+// TestHandlePasteEventCollapsesLargePastes verifies a paste longer than
+// chat.paste_collapse_lines is attached behind a one-line label.
+func TestHandlePasteEventCollapsesLargePastes(t *testing.T) {
+	input := components.NewInputView(&domainmocks.FakeModelService{})
+	cfg := config.DefaultConfig()
+	ctx := &flashTestCtx{status: &uimocks.FakeStatusComponent{}, input: input, cfg: cfg}
+
+	trace := strings.TrimSuffix(strings.Repeat("line\n", cfg.Chat.PasteCollapseLines+1), "\n")
+	if cmd := HandlePasteEvent(ctx, trace); cmd == nil {
+		t.Fatal("expected a command for a non-empty paste")
+	}
+	if got, want := input.GetInput(), fmt.Sprintf("[pasted %d lines]", cfg.Chat.PasteCollapseLines+1); got != want {
+		t.Fatalf("input = %q, want %q", got, want)
+	}
+
+	input.ClearInput()
+	short := strings.TrimSuffix(strings.Repeat("line\n", cfg.Chat.PasteCollapseLines), "\n")
+	HandlePasteEvent(ctx, short)
+	if got := input.GetInput(); got != short {
+		t.Errorf("pastes at the limit should be inserted as is, got %q", got)
+	}
+}
+
 // TestHandlePasteEventEmptyIsNoop verifies an empty paste neither inserts text
 // nor flashes a confirmation.
 func TestHandlePasteEventEmptyIsNoop(t *testing.T) {
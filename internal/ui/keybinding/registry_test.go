@@ -123,11 +123,17 @@ func TestKeyResolution(t *testing.T) {
 			wantID:    "tools_toggle_tool_expansion",
 		},
 		{
-			name:      "ctrl+r resolves to raw format toggle",
+			name:      "alt+r resolves to raw format toggle",
 			inputText: "test message",
-			key:       "ctrl+r",
+			key:       "alt+r",
 			wantID:    "display_toggle_raw_format",
 		},
+		{
+			name:      "ctrl+r resolves to reverse history search",
+			inputText: "test message",
+			key:       "ctrl+r",
+			wantID:    "text_editing_history_search",
+		},
 		{
 			name:      "ctrl+z resolves to no action",
 			inputText: "test message",
@@ -212,7 +218,8 @@ func TestHelpShortcutGeneration(t *testing.T) {
 	}{
 		{key: "ctrl+c", description: "exit application"},
 		{key: "ctrl+o", description: "expand/collapse tool results"},
-		{key: "ctrl+r", description: "toggle raw/rendered markdown"},
+		{key: "alt+r", description: "toggle raw/rendered markdown"},
+		{key: "ctrl+r", description: "reverse search the input history"},
 	}
 
 	for _, want := range expected {
@@ -48,7 +48,7 @@ var AllKnownKeys = []string{
 	"alt+h", "alt+i", "alt+j", "alt+k", "alt+l", "alt+m", "alt+n",
 	"alt+o", "alt+p", "alt+q", "alt+r", "alt+s", "alt+t", "alt+u",
 	"alt+v", "alt+w", "alt+x", "alt+y", "alt+z",
-	"alt+enter", "alt+backspace", "alt+delete",
+	"alt+enter", "alt+backspace", "alt+delete", "alt+=", "alt+-",
 
 	// Super (Cmd on macOS) combinations
 	"super+v", "super+c",
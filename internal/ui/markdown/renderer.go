@@ -11,16 +11,18 @@ import (
 
 // Renderer handles markdown to styled terminal output conversion
 type Renderer struct {
-	themeService domain.ThemeService
-	width        int
-	renderer     *glamour.TermRenderer
+	themeService       domain.ThemeService
+	width              int
+	syntaxHighlighting bool
+	renderer           *glamour.TermRenderer
 }
 
 // NewRenderer creates a new markdown renderer with theme integration
 func NewRenderer(themeService domain.ThemeService, width int) *Renderer {
 	r := &Renderer{
-		themeService: themeService,
-		width:        width,
+		themeService:       themeService,
+		width:              width,
+		syntaxHighlighting: true,
 	}
 	r.updateRenderer()
 	return r
@@ -34,6 +36,16 @@ func (r *Renderer) SetWidth(width int) {
 	}
 }
 
+// SetSyntaxHighlighting enables or disables chroma highlighting of fenced code
+// blocks. With it off, code is drawn in a single theme color, which reads
+// better on terminals with few colors.
+func (r *Renderer) SetSyntaxHighlighting(enabled bool) {
+	if enabled != r.syntaxHighlighting {
+		r.syntaxHighlighting = enabled
+		r.updateRenderer()
+	}
+}
+
 // RefreshTheme rebuilds the renderer with current theme colors
 // Call this when the theme changes
 func (r *Renderer) RefreshTheme() {
@@ -142,13 +154,7 @@ func (r *Renderer) buildBaseStyleConfig(dimColor, statusColor string) ansi.Style
 				BackgroundColor: stringPtr("#1a1a2e"),
 			},
 		},
-		CodeBlock: ansi.StyleCodeBlock{
-			StyleBlock: ansi.StyleBlock{
-				StylePrimitive: ansi.StylePrimitive{},
-				Margin:         uintPtr(1),
-			},
-			Chroma: r.buildChromaConfig(),
-		},
+		CodeBlock: r.buildCodeBlockStyle(statusColor),
 		Table: ansi.StyleTable{
 			StyleBlock: ansi.StyleBlock{
 				StylePrimitive: ansi.StylePrimitive{},
@@ -167,6 +173,23 @@ func (r *Renderer) buildBaseStyleConfig(dimColor, statusColor string) ansi.Style
 	}
 }
 
+// buildCodeBlockStyle styles fenced code blocks: chroma-highlighted with theme
+// colors, or a single color when syntax highlighting is disabled
+func (r *Renderer) buildCodeBlockStyle(statusColor string) ansi.StyleCodeBlock {
+	style := ansi.StyleCodeBlock{
+		StyleBlock: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{},
+			Margin:         uintPtr(1),
+		},
+	}
+	if !r.syntaxHighlighting {
+		style.Color = stringPtr(statusColor)
+		return style
+	}
+	style.Chroma = r.buildChromaConfig()
+	return style
+}
+
 // buildChromaConfig creates the syntax highlighting configuration for code blocks
 // Uses the injected theme service for consistent colors across themes
 func (r *Renderer) buildChromaConfig() *ansi.Chroma {
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	domainmocks "github.com/inference-gateway/cli/tests/mocks/domain"
+	uimocks "github.com/inference-gateway/cli/tests/mocks/ui"
 )
 
 func TestContainsMarkdown(t *testing.T) {
@@ -207,3 +210,24 @@ func TestPlainTextNotDetected(t *testing.T) {
 		})
 	}
 }
+
+func TestSyntaxHighlightingToggle(t *testing.T) {
+	fakeTheme := &uimocks.FakeTheme{}
+	fakeTheme.GetStatusColorReturns("#00ff00")
+	fakeTheme.GetAccentColorReturns("#00ffff")
+	themeService := &domainmocks.FakeThemeService{}
+	themeService.GetCurrentThemeReturns(fakeTheme)
+
+	r := NewRenderer(themeService, 80)
+	assert.NotNil(t, r.buildStyleConfig().CodeBlock.Chroma, "highlighting is on by default")
+
+	r.SetSyntaxHighlighting(false)
+	codeBlock := r.buildStyleConfig().CodeBlock
+	assert.Nil(t, codeBlock.Chroma)
+	if assert.NotNil(t, codeBlock.Color) {
+		assert.Equal(t, "#00ff00", *codeBlock.Color)
+	}
+
+	rendered := r.Render("```go\nfunc main() {\n\treturn\n}\n```")
+	assert.Contains(t, rendered, "func main()")
+}
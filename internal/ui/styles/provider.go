@@ -184,6 +184,12 @@ func (p *Provider) GetCurrentTheme() domain.Theme {
 	return p.themeService.GetCurrentTheme()
 }
 
+// IsMonochrome reports whether the monochrome theme is active, so components
+// can add a marker where color alone would carry meaning.
+func (p *Provider) IsMonochrome() bool {
+	return p.themeService.GetCurrentThemeName() == domain.MonochromeThemeName
+}
+
 // Modal styles
 
 // RenderModal renders a modal with rounded border
@@ -55,6 +55,12 @@ type FakeAgentService struct {
 		result1 *domain.ChatSyncResponse
 		result2 error
 	}
+	RunLifecycleHooksStub        func(context.Context, domain.HookPayload)
+	runLifecycleHooksMutex       sync.RWMutex
+	runLifecycleHooksArgsForCall []struct {
+		arg1 context.Context
+		arg2 domain.HookPayload
+	}
 	RunWithStreamStub        func(context.Context, *domain.AgentRequest) (<-chan domain.ChatEvent, error)
 	runWithStreamMutex       sync.RWMutex
 	runWithStreamArgsForCall []struct {
@@ -313,6 +319,39 @@ func (fake *FakeAgentService) RunReturnsOnCall(i int, result1 *domain.ChatSyncRe
 	}{result1, result2}
 }
 
+func (fake *FakeAgentService) RunLifecycleHooks(arg1 context.Context, arg2 domain.HookPayload) {
+	fake.runLifecycleHooksMutex.Lock()
+	fake.runLifecycleHooksArgsForCall = append(fake.runLifecycleHooksArgsForCall, struct {
+		arg1 context.Context
+		arg2 domain.HookPayload
+	}{arg1, arg2})
+	stub := fake.RunLifecycleHooksStub
+	fake.recordInvocation("RunLifecycleHooks", []interface{}{arg1, arg2})
+	fake.runLifecycleHooksMutex.Unlock()
+	if stub != nil {
+		fake.RunLifecycleHooksStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeAgentService) RunLifecycleHooksCallCount() int {
+	fake.runLifecycleHooksMutex.RLock()
+	defer fake.runLifecycleHooksMutex.RUnlock()
+	return len(fake.runLifecycleHooksArgsForCall)
+}
+
+func (fake *FakeAgentService) RunLifecycleHooksCalls(stub func(context.Context, domain.HookPayload)) {
+	fake.runLifecycleHooksMutex.Lock()
+	defer fake.runLifecycleHooksMutex.Unlock()
+	fake.RunLifecycleHooksStub = stub
+}
+
+func (fake *FakeAgentService) RunLifecycleHooksArgsForCall(i int) (context.Context, domain.HookPayload) {
+	fake.runLifecycleHooksMutex.RLock()
+	defer fake.runLifecycleHooksMutex.RUnlock()
+	argsForCall := fake.runLifecycleHooksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeAgentService) RunWithStream(arg1 context.Context, arg2 *domain.AgentRequest) (<-chan domain.ChatEvent, error) {
 	fake.runWithStreamMutex.Lock()
 	ret, specificReturn := fake.runWithStreamReturnsOnCall[len(fake.runWithStreamArgsForCall)]
@@ -57,6 +57,17 @@ type FakeApprovalCoordinator struct {
 		result1 tea.Cmd
 		result2 bool
 	}
+	HandlePlanEditedStub        func(domain.PlanEditedEvent) tea.Cmd
+	handlePlanEditedMutex       sync.RWMutex
+	handlePlanEditedArgsForCall []struct {
+		arg1 domain.PlanEditedEvent
+	}
+	handlePlanEditedReturns struct {
+		result1 tea.Cmd
+	}
+	handlePlanEditedReturnsOnCall map[int]struct {
+		result1 tea.Cmd
+	}
 	HandleUserQuestionRequestedStub        func(domain.UserQuestionRequestedEvent) tea.Cmd
 	handleUserQuestionRequestedMutex       sync.RWMutex
 	handleUserQuestionRequestedArgsForCall []struct {
@@ -322,6 +333,67 @@ func (fake *FakeApprovalCoordinator) HandlePlanApprovalResponseReturnsOnCall(i i
 	}{result1, result2}
 }
 
+func (fake *FakeApprovalCoordinator) HandlePlanEdited(arg1 domain.PlanEditedEvent) tea.Cmd {
+	fake.handlePlanEditedMutex.Lock()
+	ret, specificReturn := fake.handlePlanEditedReturnsOnCall[len(fake.handlePlanEditedArgsForCall)]
+	fake.handlePlanEditedArgsForCall = append(fake.handlePlanEditedArgsForCall, struct {
+		arg1 domain.PlanEditedEvent
+	}{arg1})
+	stub := fake.HandlePlanEditedStub
+	fakeReturns := fake.handlePlanEditedReturns
+	fake.recordInvocation("HandlePlanEdited", []interface{}{arg1})
+	fake.handlePlanEditedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApprovalCoordinator) HandlePlanEditedCallCount() int {
+	fake.handlePlanEditedMutex.RLock()
+	defer fake.handlePlanEditedMutex.RUnlock()
+	return len(fake.handlePlanEditedArgsForCall)
+}
+
+func (fake *FakeApprovalCoordinator) HandlePlanEditedCalls(stub func(domain.PlanEditedEvent) tea.Cmd) {
+	fake.handlePlanEditedMutex.Lock()
+	defer fake.handlePlanEditedMutex.Unlock()
+	fake.HandlePlanEditedStub = stub
+}
+
+func (fake *FakeApprovalCoordinator) HandlePlanEditedArgsForCall(i int) domain.PlanEditedEvent {
+	fake.handlePlanEditedMutex.RLock()
+	defer fake.handlePlanEditedMutex.RUnlock()
+	argsForCall := fake.handlePlanEditedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApprovalCoordinator) HandlePlanEditedReturns(result1 tea.Cmd) {
+	fake.handlePlanEditedMutex.Lock()
+	defer fake.handlePlanEditedMutex.Unlock()
+	fake.HandlePlanEditedStub = nil
+	fake.handlePlanEditedReturns = struct {
+		result1 tea.Cmd
+	}{result1}
+}
+
+func (fake *FakeApprovalCoordinator) HandlePlanEditedReturnsOnCall(i int, result1 tea.Cmd) {
+	fake.handlePlanEditedMutex.Lock()
+	defer fake.handlePlanEditedMutex.Unlock()
+	fake.HandlePlanEditedStub = nil
+	if fake.handlePlanEditedReturnsOnCall == nil {
+		fake.handlePlanEditedReturnsOnCall = make(map[int]struct {
+			result1 tea.Cmd
+		})
+	}
+	fake.handlePlanEditedReturnsOnCall[i] = struct {
+		result1 tea.Cmd
+	}{result1}
+}
+
 func (fake *FakeApprovalCoordinator) HandleUserQuestionRequested(arg1 domain.UserQuestionRequestedEvent) tea.Cmd {
 	fake.handleUserQuestionRequestedMutex.Lock()
 	ret, specificReturn := fake.handleUserQuestionRequestedReturnsOnCall[len(fake.handleUserQuestionRequestedArgsForCall)]
@@ -64,6 +64,11 @@ type FakeChatCompletionRunner struct {
 	handleOptimizationStatusReturnsOnCall map[int]struct {
 		result1 tea.Cmd
 	}
+	SetNextRequestToolsStub        func([]string)
+	setNextRequestToolsMutex       sync.RWMutex
+	setNextRequestToolsArgsForCall []struct {
+		arg1 []string
+	}
 	SetPendingRestorationStub        func(string)
 	setPendingRestorationMutex       sync.RWMutex
 	setPendingRestorationArgsForCall []struct {
@@ -80,6 +85,16 @@ type FakeChatCompletionRunner struct {
 	startReturnsOnCall map[int]struct {
 		result1 tea.Cmd
 	}
+	TakeRetryModelStub        func() string
+	takeRetryModelMutex       sync.RWMutex
+	takeRetryModelArgsForCall []struct {
+	}
+	takeRetryModelReturns struct {
+		result1 string
+	}
+	takeRetryModelReturnsOnCall map[int]struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -389,6 +404,43 @@ func (fake *FakeChatCompletionRunner) HandleOptimizationStatusReturnsOnCall(i in
 	}{result1}
 }
 
+func (fake *FakeChatCompletionRunner) SetNextRequestTools(arg1 []string) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.setNextRequestToolsMutex.Lock()
+	fake.setNextRequestToolsArgsForCall = append(fake.setNextRequestToolsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.SetNextRequestToolsStub
+	fake.recordInvocation("SetNextRequestTools", []interface{}{arg1Copy})
+	fake.setNextRequestToolsMutex.Unlock()
+	if stub != nil {
+		fake.SetNextRequestToolsStub(arg1)
+	}
+}
+
+func (fake *FakeChatCompletionRunner) SetNextRequestToolsCallCount() int {
+	fake.setNextRequestToolsMutex.RLock()
+	defer fake.setNextRequestToolsMutex.RUnlock()
+	return len(fake.setNextRequestToolsArgsForCall)
+}
+
+func (fake *FakeChatCompletionRunner) SetNextRequestToolsCalls(stub func([]string)) {
+	fake.setNextRequestToolsMutex.Lock()
+	defer fake.setNextRequestToolsMutex.Unlock()
+	fake.SetNextRequestToolsStub = stub
+}
+
+func (fake *FakeChatCompletionRunner) SetNextRequestToolsArgsForCall(i int) []string {
+	fake.setNextRequestToolsMutex.RLock()
+	defer fake.setNextRequestToolsMutex.RUnlock()
+	argsForCall := fake.setNextRequestToolsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeChatCompletionRunner) SetPendingRestoration(arg1 string) {
 	fake.setPendingRestorationMutex.Lock()
 	fake.setPendingRestorationArgsForCall = append(fake.setPendingRestorationArgsForCall, struct {
@@ -482,6 +534,59 @@ func (fake *FakeChatCompletionRunner) StartReturnsOnCall(i int, result1 tea.Cmd)
 	}{result1}
 }
 
+func (fake *FakeChatCompletionRunner) TakeRetryModel() string {
+	fake.takeRetryModelMutex.Lock()
+	ret, specificReturn := fake.takeRetryModelReturnsOnCall[len(fake.takeRetryModelArgsForCall)]
+	fake.takeRetryModelArgsForCall = append(fake.takeRetryModelArgsForCall, struct {
+	}{})
+	stub := fake.TakeRetryModelStub
+	fakeReturns := fake.takeRetryModelReturns
+	fake.recordInvocation("TakeRetryModel", []interface{}{})
+	fake.takeRetryModelMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeChatCompletionRunner) TakeRetryModelCallCount() int {
+	fake.takeRetryModelMutex.RLock()
+	defer fake.takeRetryModelMutex.RUnlock()
+	return len(fake.takeRetryModelArgsForCall)
+}
+
+func (fake *FakeChatCompletionRunner) TakeRetryModelCalls(stub func() string) {
+	fake.takeRetryModelMutex.Lock()
+	defer fake.takeRetryModelMutex.Unlock()
+	fake.TakeRetryModelStub = stub
+}
+
+func (fake *FakeChatCompletionRunner) TakeRetryModelReturns(result1 string) {
+	fake.takeRetryModelMutex.Lock()
+	defer fake.takeRetryModelMutex.Unlock()
+	fake.TakeRetryModelStub = nil
+	fake.takeRetryModelReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeChatCompletionRunner) TakeRetryModelReturnsOnCall(i int, result1 string) {
+	fake.takeRetryModelMutex.Lock()
+	defer fake.takeRetryModelMutex.Unlock()
+	fake.TakeRetryModelStub = nil
+	if fake.takeRetryModelReturnsOnCall == nil {
+		fake.takeRetryModelReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.takeRetryModelReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeChatCompletionRunner) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -28,6 +28,13 @@ type FakeMessageQueue struct {
 		arg1 domain.Message
 		arg2 string
 	}
+	EnqueueWithPriorityStub        func(domain.Message, string, domain.QueuePriority)
+	enqueueWithPriorityMutex       sync.RWMutex
+	enqueueWithPriorityArgsForCall []struct {
+		arg1 domain.Message
+		arg2 string
+		arg3 domain.QueuePriority
+	}
 	GetAllStub        func() []domain.QueuedMessage
 	getAllMutex       sync.RWMutex
 	getAllArgsForCall []struct {
@@ -38,6 +45,16 @@ type FakeMessageQueue struct {
 	getAllReturnsOnCall map[int]struct {
 		result1 []domain.QueuedMessage
 	}
+	HasInterruptStub        func() bool
+	hasInterruptMutex       sync.RWMutex
+	hasInterruptArgsForCall []struct {
+	}
+	hasInterruptReturns struct {
+		result1 bool
+	}
+	hasInterruptReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsEmptyStub        func() bool
 	isEmptyMutex       sync.RWMutex
 	isEmptyArgsForCall []struct {
@@ -48,6 +65,18 @@ type FakeMessageQueue struct {
 	isEmptyReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	MoveStub        func(int, int) bool
+	moveMutex       sync.RWMutex
+	moveArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	moveReturns struct {
+		result1 bool
+	}
+	moveReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	PeekStub        func() *domain.QueuedMessage
 	peekMutex       sync.RWMutex
 	peekArgsForCall []struct {
@@ -58,6 +87,41 @@ type FakeMessageQueue struct {
 	peekReturnsOnCall map[int]struct {
 		result1 *domain.QueuedMessage
 	}
+	RemoveStub        func(int) bool
+	removeMutex       sync.RWMutex
+	removeArgsForCall []struct {
+		arg1 int
+	}
+	removeReturns struct {
+		result1 bool
+	}
+	removeReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	ReplaceStub        func(int, domain.Message) bool
+	replaceMutex       sync.RWMutex
+	replaceArgsForCall []struct {
+		arg1 int
+		arg2 domain.Message
+	}
+	replaceReturns struct {
+		result1 bool
+	}
+	replaceReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	SetPriorityStub        func(int, domain.QueuePriority) bool
+	setPriorityMutex       sync.RWMutex
+	setPriorityArgsForCall []struct {
+		arg1 int
+		arg2 domain.QueuePriority
+	}
+	setPriorityReturns struct {
+		result1 bool
+	}
+	setPriorityReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	SizeStub        func() int
 	sizeMutex       sync.RWMutex
 	sizeArgsForCall []struct {
@@ -182,6 +246,40 @@ func (fake *FakeMessageQueue) EnqueueArgsForCall(i int) (domain.Message, string)
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeMessageQueue) EnqueueWithPriority(arg1 domain.Message, arg2 string, arg3 domain.QueuePriority) {
+	fake.enqueueWithPriorityMutex.Lock()
+	fake.enqueueWithPriorityArgsForCall = append(fake.enqueueWithPriorityArgsForCall, struct {
+		arg1 domain.Message
+		arg2 string
+		arg3 domain.QueuePriority
+	}{arg1, arg2, arg3})
+	stub := fake.EnqueueWithPriorityStub
+	fake.recordInvocation("EnqueueWithPriority", []interface{}{arg1, arg2, arg3})
+	fake.enqueueWithPriorityMutex.Unlock()
+	if stub != nil {
+		fake.EnqueueWithPriorityStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeMessageQueue) EnqueueWithPriorityCallCount() int {
+	fake.enqueueWithPriorityMutex.RLock()
+	defer fake.enqueueWithPriorityMutex.RUnlock()
+	return len(fake.enqueueWithPriorityArgsForCall)
+}
+
+func (fake *FakeMessageQueue) EnqueueWithPriorityCalls(stub func(domain.Message, string, domain.QueuePriority)) {
+	fake.enqueueWithPriorityMutex.Lock()
+	defer fake.enqueueWithPriorityMutex.Unlock()
+	fake.EnqueueWithPriorityStub = stub
+}
+
+func (fake *FakeMessageQueue) EnqueueWithPriorityArgsForCall(i int) (domain.Message, string, domain.QueuePriority) {
+	fake.enqueueWithPriorityMutex.RLock()
+	defer fake.enqueueWithPriorityMutex.RUnlock()
+	argsForCall := fake.enqueueWithPriorityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeMessageQueue) GetAll() []domain.QueuedMessage {
 	fake.getAllMutex.Lock()
 	ret, specificReturn := fake.getAllReturnsOnCall[len(fake.getAllArgsForCall)]
@@ -235,6 +333,59 @@ func (fake *FakeMessageQueue) GetAllReturnsOnCall(i int, result1 []domain.Queued
 	}{result1}
 }
 
+func (fake *FakeMessageQueue) HasInterrupt() bool {
+	fake.hasInterruptMutex.Lock()
+	ret, specificReturn := fake.hasInterruptReturnsOnCall[len(fake.hasInterruptArgsForCall)]
+	fake.hasInterruptArgsForCall = append(fake.hasInterruptArgsForCall, struct {
+	}{})
+	stub := fake.HasInterruptStub
+	fakeReturns := fake.hasInterruptReturns
+	fake.recordInvocation("HasInterrupt", []interface{}{})
+	fake.hasInterruptMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMessageQueue) HasInterruptCallCount() int {
+	fake.hasInterruptMutex.RLock()
+	defer fake.hasInterruptMutex.RUnlock()
+	return len(fake.hasInterruptArgsForCall)
+}
+
+func (fake *FakeMessageQueue) HasInterruptCalls(stub func() bool) {
+	fake.hasInterruptMutex.Lock()
+	defer fake.hasInterruptMutex.Unlock()
+	fake.HasInterruptStub = stub
+}
+
+func (fake *FakeMessageQueue) HasInterruptReturns(result1 bool) {
+	fake.hasInterruptMutex.Lock()
+	defer fake.hasInterruptMutex.Unlock()
+	fake.HasInterruptStub = nil
+	fake.hasInterruptReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeMessageQueue) HasInterruptReturnsOnCall(i int, result1 bool) {
+	fake.hasInterruptMutex.Lock()
+	defer fake.hasInterruptMutex.Unlock()
+	fake.HasInterruptStub = nil
+	if fake.hasInterruptReturnsOnCall == nil {
+		fake.hasInterruptReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.hasInterruptReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeMessageQueue) IsEmpty() bool {
 	fake.isEmptyMutex.Lock()
 	ret, specificReturn := fake.isEmptyReturnsOnCall[len(fake.isEmptyArgsForCall)]
@@ -288,6 +439,68 @@ func (fake *FakeMessageQueue) IsEmptyReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeMessageQueue) Move(arg1 int, arg2 int) bool {
+	fake.moveMutex.Lock()
+	ret, specificReturn := fake.moveReturnsOnCall[len(fake.moveArgsForCall)]
+	fake.moveArgsForCall = append(fake.moveArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.MoveStub
+	fakeReturns := fake.moveReturns
+	fake.recordInvocation("Move", []interface{}{arg1, arg2})
+	fake.moveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMessageQueue) MoveCallCount() int {
+	fake.moveMutex.RLock()
+	defer fake.moveMutex.RUnlock()
+	return len(fake.moveArgsForCall)
+}
+
+func (fake *FakeMessageQueue) MoveCalls(stub func(int, int) bool) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = stub
+}
+
+func (fake *FakeMessageQueue) MoveArgsForCall(i int) (int, int) {
+	fake.moveMutex.RLock()
+	defer fake.moveMutex.RUnlock()
+	argsForCall := fake.moveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMessageQueue) MoveReturns(result1 bool) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = nil
+	fake.moveReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeMessageQueue) MoveReturnsOnCall(i int, result1 bool) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = nil
+	if fake.moveReturnsOnCall == nil {
+		fake.moveReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.moveReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeMessageQueue) Peek() *domain.QueuedMessage {
 	fake.peekMutex.Lock()
 	ret, specificReturn := fake.peekReturnsOnCall[len(fake.peekArgsForCall)]
@@ -341,6 +554,191 @@ func (fake *FakeMessageQueue) PeekReturnsOnCall(i int, result1 *domain.QueuedMes
 	}{result1}
 }
 
+func (fake *FakeMessageQueue) Remove(arg1 int) bool {
+	fake.removeMutex.Lock()
+	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
+	fake.removeArgsForCall = append(fake.removeArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RemoveStub
+	fakeReturns := fake.removeReturns
+	fake.recordInvocation("Remove", []interface{}{arg1})
+	fake.removeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMessageQueue) RemoveCallCount() int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return len(fake.removeArgsForCall)
+}
+
+func (fake *FakeMessageQueue) RemoveCalls(stub func(int) bool) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = stub
+}
+
+func (fake *FakeMessageQueue) RemoveArgsForCall(i int) int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	argsForCall := fake.removeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMessageQueue) RemoveReturns(result1 bool) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = nil
+	fake.removeReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeMessageQueue) RemoveReturnsOnCall(i int, result1 bool) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = nil
+	if fake.removeReturnsOnCall == nil {
+		fake.removeReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.removeReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeMessageQueue) Replace(arg1 int, arg2 domain.Message) bool {
+	fake.replaceMutex.Lock()
+	ret, specificReturn := fake.replaceReturnsOnCall[len(fake.replaceArgsForCall)]
+	fake.replaceArgsForCall = append(fake.replaceArgsForCall, struct {
+		arg1 int
+		arg2 domain.Message
+	}{arg1, arg2})
+	stub := fake.ReplaceStub
+	fakeReturns := fake.replaceReturns
+	fake.recordInvocation("Replace", []interface{}{arg1, arg2})
+	fake.replaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMessageQueue) ReplaceCallCount() int {
+	fake.replaceMutex.RLock()
+	defer fake.replaceMutex.RUnlock()
+	return len(fake.replaceArgsForCall)
+}
+
+func (fake *FakeMessageQueue) ReplaceCalls(stub func(int, domain.Message) bool) {
+	fake.replaceMutex.Lock()
+	defer fake.replaceMutex.Unlock()
+	fake.ReplaceStub = stub
+}
+
+func (fake *FakeMessageQueue) ReplaceArgsForCall(i int) (int, domain.Message) {
+	fake.replaceMutex.RLock()
+	defer fake.replaceMutex.RUnlock()
+	argsForCall := fake.replaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMessageQueue) ReplaceReturns(result1 bool) {
+	fake.replaceMutex.Lock()
+	defer fake.replaceMutex.Unlock()
+	fake.ReplaceStub = nil
+	fake.replaceReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeMessageQueue) ReplaceReturnsOnCall(i int, result1 bool) {
+	fake.replaceMutex.Lock()
+	defer fake.replaceMutex.Unlock()
+	fake.ReplaceStub = nil
+	if fake.replaceReturnsOnCall == nil {
+		fake.replaceReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.replaceReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeMessageQueue) SetPriority(arg1 int, arg2 domain.QueuePriority) bool {
+	fake.setPriorityMutex.Lock()
+	ret, specificReturn := fake.setPriorityReturnsOnCall[len(fake.setPriorityArgsForCall)]
+	fake.setPriorityArgsForCall = append(fake.setPriorityArgsForCall, struct {
+		arg1 int
+		arg2 domain.QueuePriority
+	}{arg1, arg2})
+	stub := fake.SetPriorityStub
+	fakeReturns := fake.setPriorityReturns
+	fake.recordInvocation("SetPriority", []interface{}{arg1, arg2})
+	fake.setPriorityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMessageQueue) SetPriorityCallCount() int {
+	fake.setPriorityMutex.RLock()
+	defer fake.setPriorityMutex.RUnlock()
+	return len(fake.setPriorityArgsForCall)
+}
+
+func (fake *FakeMessageQueue) SetPriorityCalls(stub func(int, domain.QueuePriority) bool) {
+	fake.setPriorityMutex.Lock()
+	defer fake.setPriorityMutex.Unlock()
+	fake.SetPriorityStub = stub
+}
+
+func (fake *FakeMessageQueue) SetPriorityArgsForCall(i int) (int, domain.QueuePriority) {
+	fake.setPriorityMutex.RLock()
+	defer fake.setPriorityMutex.RUnlock()
+	argsForCall := fake.setPriorityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMessageQueue) SetPriorityReturns(result1 bool) {
+	fake.setPriorityMutex.Lock()
+	defer fake.setPriorityMutex.Unlock()
+	fake.SetPriorityStub = nil
+	fake.setPriorityReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeMessageQueue) SetPriorityReturnsOnCall(i int, result1 bool) {
+	fake.setPriorityMutex.Lock()
+	defer fake.setPriorityMutex.Unlock()
+	fake.SetPriorityStub = nil
+	if fake.setPriorityReturnsOnCall == nil {
+		fake.setPriorityReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.setPriorityReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeMessageQueue) Size() int {
 	fake.sizeMutex.Lock()
 	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
@@ -85,6 +85,32 @@ type FakePersistentConversationRepository struct {
 	setConversationTitleArgsForCall []struct {
 		arg1 string
 	}
+	SetSavedConversationArchivedStub        func(context.Context, string, bool) error
+	setSavedConversationArchivedMutex       sync.RWMutex
+	setSavedConversationArchivedArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}
+	setSavedConversationArchivedReturns struct {
+		result1 error
+	}
+	setSavedConversationArchivedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetSavedConversationTagsStub        func(context.Context, string, []string) error
+	setSavedConversationTagsMutex       sync.RWMutex
+	setSavedConversationTagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}
+	setSavedConversationTagsReturns struct {
+		result1 error
+	}
+	setSavedConversationTagsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartNewConversationStub        func(string) error
 	startNewConversationMutex       sync.RWMutex
 	startNewConversationArgsForCall []struct {
@@ -489,6 +515,137 @@ func (fake *FakePersistentConversationRepository) SetConversationTitleArgsForCal
 	return argsForCall.arg1
 }
 
+func (fake *FakePersistentConversationRepository) SetSavedConversationArchived(arg1 context.Context, arg2 string, arg3 bool) error {
+	fake.setSavedConversationArchivedMutex.Lock()
+	ret, specificReturn := fake.setSavedConversationArchivedReturnsOnCall[len(fake.setSavedConversationArchivedArgsForCall)]
+	fake.setSavedConversationArchivedArgsForCall = append(fake.setSavedConversationArchivedArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.SetSavedConversationArchivedStub
+	fakeReturns := fake.setSavedConversationArchivedReturns
+	fake.recordInvocation("SetSavedConversationArchived", []interface{}{arg1, arg2, arg3})
+	fake.setSavedConversationArchivedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationArchivedCallCount() int {
+	fake.setSavedConversationArchivedMutex.RLock()
+	defer fake.setSavedConversationArchivedMutex.RUnlock()
+	return len(fake.setSavedConversationArchivedArgsForCall)
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationArchivedCalls(stub func(context.Context, string, bool) error) {
+	fake.setSavedConversationArchivedMutex.Lock()
+	defer fake.setSavedConversationArchivedMutex.Unlock()
+	fake.SetSavedConversationArchivedStub = stub
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationArchivedArgsForCall(i int) (context.Context, string, bool) {
+	fake.setSavedConversationArchivedMutex.RLock()
+	defer fake.setSavedConversationArchivedMutex.RUnlock()
+	argsForCall := fake.setSavedConversationArchivedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationArchivedReturns(result1 error) {
+	fake.setSavedConversationArchivedMutex.Lock()
+	defer fake.setSavedConversationArchivedMutex.Unlock()
+	fake.SetSavedConversationArchivedStub = nil
+	fake.setSavedConversationArchivedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationArchivedReturnsOnCall(i int, result1 error) {
+	fake.setSavedConversationArchivedMutex.Lock()
+	defer fake.setSavedConversationArchivedMutex.Unlock()
+	fake.SetSavedConversationArchivedStub = nil
+	if fake.setSavedConversationArchivedReturnsOnCall == nil {
+		fake.setSavedConversationArchivedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSavedConversationArchivedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationTags(arg1 context.Context, arg2 string, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.setSavedConversationTagsMutex.Lock()
+	ret, specificReturn := fake.setSavedConversationTagsReturnsOnCall[len(fake.setSavedConversationTagsArgsForCall)]
+	fake.setSavedConversationTagsArgsForCall = append(fake.setSavedConversationTagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.SetSavedConversationTagsStub
+	fakeReturns := fake.setSavedConversationTagsReturns
+	fake.recordInvocation("SetSavedConversationTags", []interface{}{arg1, arg2, arg3Copy})
+	fake.setSavedConversationTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationTagsCallCount() int {
+	fake.setSavedConversationTagsMutex.RLock()
+	defer fake.setSavedConversationTagsMutex.RUnlock()
+	return len(fake.setSavedConversationTagsArgsForCall)
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationTagsCalls(stub func(context.Context, string, []string) error) {
+	fake.setSavedConversationTagsMutex.Lock()
+	defer fake.setSavedConversationTagsMutex.Unlock()
+	fake.SetSavedConversationTagsStub = stub
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationTagsArgsForCall(i int) (context.Context, string, []string) {
+	fake.setSavedConversationTagsMutex.RLock()
+	defer fake.setSavedConversationTagsMutex.RUnlock()
+	argsForCall := fake.setSavedConversationTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationTagsReturns(result1 error) {
+	fake.setSavedConversationTagsMutex.Lock()
+	defer fake.setSavedConversationTagsMutex.Unlock()
+	fake.SetSavedConversationTagsStub = nil
+	fake.setSavedConversationTagsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistentConversationRepository) SetSavedConversationTagsReturnsOnCall(i int, result1 error) {
+	fake.setSavedConversationTagsMutex.Lock()
+	defer fake.setSavedConversationTagsMutex.Unlock()
+	fake.SetSavedConversationTagsStub = nil
+	if fake.setSavedConversationTagsReturnsOnCall == nil {
+		fake.setSavedConversationTagsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSavedConversationTagsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePersistentConversationRepository) StartNewConversation(arg1 string) error {
 	fake.startNewConversationMutex.Lock()
 	ret, specificReturn := fake.startNewConversationReturnsOnCall[len(fake.startNewConversationArgsForCall)]